@@ -0,0 +1,309 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4"
+)
+
+// batchCmd represents the batch command
+var batchCmd = &cobra.Command{
+	Use:   "batch <manifest>",
+	Short: "Run the analyses on many repositories listed in a file.",
+	Long: `Read the manifest ("-" is stdin) with one repository per line: the path or the URL
+followed by the optional options which override the command line for that repository, e.g.
+
+  # comments and empty lines are ignored
+  https://github.com/src-d/go-git granularity=15 sampling=15
+  https://github.com/src-d/hercules couples=true
+  /home/user/projects/local
+
+The options are named as the flags in "hercules run --help"; the analyses are toggled with
+"<flag>=true|false". The analyses which are enabled with the flags of this command run on
+every repository. --workers repositories are analysed at the same time and each result is
+written to the path which --output-template generates. The template is executed with
+.Host (empty for the local paths), .Path (without ".git"), .Name (the last element of
+the path), .Line (the line number in the manifest) and .Ext ("yaml", "pb" or "json"). The failures are logged and do not stop
+the other repositories; the summary is printed at the end and the exit code is 1 if any
+repository failed.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := cmd.Flags()
+		format := outputFormat(flags)
+		workers, _ := flags.GetInt("workers")
+		templateText, _ := flags.GetString("output-template")
+		summaryPath, _ := flags.GetString("summary")
+		resume, _ := flags.GetBool("resume")
+		analyses := enabledAnalyses(flags)
+		if workers < 1 {
+			fmt.Fprintf(os.Stderr, "--workers must be positive: %d\n", workers)
+			os.Exit(1)
+		}
+		outputTemplate, err := template.New("output").Parse(templateText)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid --output-template: %v\n", err)
+			os.Exit(1)
+		}
+		entries, err := loadBatchManifest(args[0], analyses, format, outputTemplate)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		clones := newCloneManager(flags)
+		queue := make(chan *batchEntry)
+		wg := sync.WaitGroup{}
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for entry := range queue {
+					entry.run(clones, format)
+					entry.report()
+				}
+			}()
+		}
+		for _, entry := range entries {
+			if _, err := os.Stat(entry.Output); resume && err == nil {
+				entry.Skipped = true
+				entry.report()
+				continue
+			}
+			queue <- entry
+		}
+		close(queue)
+		wg.Wait()
+		failed := 0
+		for _, entry := range entries {
+			if entry.Error != "" {
+				failed++
+			}
+		}
+		fmt.Fprintf(os.Stderr, "%d repositories succeeded, %d failed.\n",
+			len(entries)-failed, failed)
+		if summaryPath != "" {
+			data, _ := json.MarshalIndent(entries, "", "  ")
+			if err = ioutil.WriteFile(summaryPath, data, 0644); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+		}
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// batchEntry is a repository in the manifest of "hercules batch" and its outcome.
+type batchEntry struct {
+	// Repository is the path or the URL.
+	Repository string `json:"repository"`
+	// Line is the line number in the manifest.
+	Line int `json:"line"`
+	// Output is the path to the result.
+	Output string `json:"output"`
+	// Skipped is true if the result existed and --resume was set.
+	Skipped bool `json:"skipped,omitempty"`
+	// Error is the reason of the failure; empty if the analysis succeeded.
+	Error string `json:"error,omitempty"`
+	// Seconds is the elapsed time.
+	Seconds float64 `json:"seconds"`
+
+	analyses []string
+	facts    map[string]interface{}
+}
+
+// batchOutputName is passed to --output-template.
+type batchOutputName struct {
+	Host string
+	Path string
+	Name string
+	Line int
+	Ext  string
+}
+
+// loadBatchManifest parses the manifest and validates the options before anything runs.
+func loadBatchManifest(manifestPath string, analyses []string, format string,
+	outputTemplate *template.Template) ([]*batchEntry, error) {
+	file := os.Stdin
+	var err error
+	if manifestPath != "-" {
+		if file, err = os.Open(manifestPath); err != nil {
+			return nil, err
+		}
+		defer file.Close()
+	}
+	options := map[string]hercules.ConfigurationOption{}
+	for _, item := range configurableItems() {
+		for _, opt := range item.ListConfigurationOptions() {
+			options[opt.Flag] = opt
+		}
+	}
+	leaves := map[string]string{}
+	for _, leaf := range hercules.Registry.GetLeaves() {
+		leaves[leaf.Flag()] = leaf.Name()
+	}
+	entries := []*batchEntry{}
+	outputs := map[string]int{}
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		entry := &batchEntry{Repository: fields[0], Line: line, facts: map[string]interface{}{}}
+		// each job writes to its own facts
+		for key, val := range cmdlineFacts {
+			entry.facts[key] = val
+		}
+		enabled := map[string]bool{}
+		for _, name := range analyses {
+			enabled[name] = true
+		}
+		for _, field := range fields[1:] {
+			parts := strings.SplitN(field, "=", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("%s:%d: expected <option>=<value>, got %s",
+					manifestPath, line, field)
+			}
+			if name, exists := leaves[parts[0]]; exists {
+				value, err := strconv.ParseBool(parts[1])
+				if err != nil {
+					return nil, fmt.Errorf("%s:%d: %s: %v", manifestPath, line, parts[0], err)
+				}
+				enabled[name] = value
+				continue
+			}
+			opt, exists := options[parts[0]]
+			if !exists {
+				return nil, fmt.Errorf("%s:%d: unknown option %s", manifestPath, line, parts[0])
+			}
+			if entry.facts[opt.Name], err = parseOption(opt, parts[1]); err != nil {
+				return nil, fmt.Errorf("%s:%d: %v", manifestPath, line, err)
+			}
+		}
+		for name, value := range enabled {
+			if value {
+				entry.analyses = append(entry.analyses, name)
+			}
+		}
+		if len(entry.analyses) == 0 {
+			return nil, fmt.Errorf("%s:%d: no analyses are enabled", manifestPath, line)
+		}
+		name := newBatchOutputName(entry.Repository, line, format)
+		buffer := &bytes.Buffer{}
+		if err = outputTemplate.Execute(buffer, name); err != nil {
+			return nil, fmt.Errorf("%s:%d: --output-template: %v", manifestPath, line, err)
+		}
+		entry.Output = buffer.String()
+		if previous, exists := outputs[entry.Output]; exists {
+			return nil, fmt.Errorf("%s:%d: the output %s is the same as on line %d",
+				manifestPath, line, entry.Output, previous)
+		}
+		outputs[entry.Output] = line
+		entries = append(entries, entry)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func newBatchOutputName(repository string, line int, format string) batchOutputName {
+	name := batchOutputName{Line: line, Ext: format}
+	repoPath := repository
+	if parsed, err := url.Parse(repository); err == nil && strings.Contains(repository, "://") {
+		name.Host = parsed.Host
+		repoPath = parsed.Path
+	}
+	repoPath = strings.TrimSuffix(path.Clean("/"+filepath.ToSlash(repoPath)), ".git")
+	name.Path = strings.TrimPrefix(repoPath, "/")
+	name.Name = path.Base(repoPath)
+	return name
+}
+
+// run analyses the repository and writes the result. The result is written only if
+// the analysis succeeds, so that --resume does not skip the failed repositories.
+func (entry *batchEntry) run(clones *cloneManager, format string) {
+	start := time.Now()
+	defer func() {
+		entry.Seconds = time.Since(start).Seconds()
+	}()
+	var repository *git.Repository
+	var err error
+	if strings.Contains(entry.Repository, "://") {
+		repository, err = clones.clone(entry.Repository)
+	} else {
+		repository, err = git.PlainOpen(strings.TrimSuffix(entry.Repository, "/"))
+	}
+	if err != nil {
+		entry.Error = err.Error()
+		return
+	}
+	job := analysisJob{
+		Repository: repository,
+		URI:        entry.Repository,
+		Analyses:   entry.analyses,
+		Facts:      entry.facts,
+		Format:     format,
+	}
+	result := &bytes.Buffer{}
+	if entry.Error = job.fails(result); entry.Error != "" {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(entry.Output), 0755); err != nil {
+		entry.Error = err.Error()
+		return
+	}
+	output, err := createOutput(entry.Output)
+	if err == nil {
+		_, err = output.Write(result.Bytes())
+		if closeErr := output.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		entry.Error = err.Error()
+		os.Remove(entry.Output)
+	}
+}
+
+// report prints the outcome to stderr.
+func (entry *batchEntry) report() {
+	switch {
+	case entry.Skipped:
+		fmt.Fprintf(os.Stderr, "SKIP %s: %s exists\n", entry.Repository, entry.Output)
+	case entry.Error != "":
+		fmt.Fprintf(os.Stderr, "FAIL %s: %s\n", entry.Repository, entry.Error)
+	default:
+		fmt.Fprintf(os.Stderr, "OK   %s -> %s (%.1fs)\n",
+			entry.Repository, entry.Output, entry.Seconds)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(batchCmd)
+	batchFlags := batchCmd.Flags()
+	batchFlags.Int("workers", 4, "The number of the repositories which are analysed at once.")
+	batchFlags.String("output-template", "{{if .Host}}{{.Host}}/{{end}}{{.Path}}.{{.Ext}}",
+		"The Go template of the result paths. The files are compressed if the names end "+
+			"with .gz or .zst.")
+	batchFlags.String("summary", "", "Write the JSON summary of the successes and the "+
+		"failures to this file.")
+	batchFlags.Bool("resume", false, "Skip the repositories whose results already exist.")
+	addCloneFlags(batchFlags)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport/client"
+	githttp "gopkg.in/src-d/go-git.v4/plumbing/transport/http"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+)
+
+// cloneManager limits the clones and the fetches of the remote repositories in the server
+// and the batch modes, so that analysing thousands of repositories from the same hosting
+// does not trip its abuse detection.
+type cloneManager struct {
+	// slots limit the number of the simultaneous clones.
+	slots chan struct{}
+	// Retries is the number of the additional attempts after a failure.
+	Retries int
+	// Backoff is the delay before the first retry. It doubles with each next attempt.
+	Backoff time.Duration
+}
+
+// addCloneFlags defines the options of newCloneManager().
+func addCloneFlags(flags *pflag.FlagSet) {
+	flags.Int("clone-workers", 4, "The maximum number of the simultaneous clones.")
+	flags.Int("clone-bandwidth", 0,
+		"The total download speed limit in KiB/s of the clones over HTTP(S). 0 means no limit.")
+	flags.Int("clone-retries", 3, "The number of the attempts to repeat a failed clone.")
+	flags.Duration("clone-backoff", 5*time.Second,
+		"The delay before the first repeated clone, it doubles with each next attempt.")
+}
+
+// newCloneManager creates the cloneManager from the flags which addCloneFlags() defined.
+// The bandwidth limit replaces the HTTP(S) transport of go-git globally.
+func newCloneManager(flags *pflag.FlagSet) *cloneManager {
+	workers, _ := flags.GetInt("clone-workers")
+	if workers < 1 {
+		log.Fatalf("--clone-workers must be positive: %d", workers)
+	}
+	manager := &cloneManager{slots: make(chan struct{}, workers)}
+	manager.Retries, _ = flags.GetInt("clone-retries")
+	manager.Backoff, _ = flags.GetDuration("clone-backoff")
+	if bandwidth, _ := flags.GetInt("clone-bandwidth"); bandwidth > 0 {
+		installThrottledTransport(newBandwidthLimiter(int64(bandwidth) * 1024))
+	}
+	return manager
+}
+
+// run executes the operation on the remote repository, waiting for a free slot and repeating
+// it with the exponential backoff on the errors which are not permanent.
+func (manager *cloneManager) run(uri string, operation func() error) error {
+	manager.slots <- struct{}{}
+	defer func() { <-manager.slots }()
+	delay := manager.Backoff
+	for attempt := 0; ; attempt++ {
+		err := operation()
+		if err == nil || attempt >= manager.Retries || isPermanentCloneError(err) {
+			return err
+		}
+		// the jitter prevents the synchronized retries of the simultaneous failures
+		jittered := delay/2 + time.Duration(rand.Int63n(int64(delay)+1))
+		log.Printf("Failed to clone %s, retrying in %v: %v", uri, jittered, err)
+		time.Sleep(jittered)
+		delay *= 2
+	}
+}
+
+// clone fetches the whole remote repository to memory.
+func (manager *cloneManager) clone(uri string) (*git.Repository, error) {
+	var repository *git.Repository
+	err := manager.run(uri, func() error {
+		var err error
+		repository, err = git.Clone(memory.NewStorage(), nil, &git.CloneOptions{URL: uri})
+		return err
+	})
+	return repository, err
+}
+
+// isPermanentCloneError checks whether repeating the clone is useless.
+func isPermanentCloneError(err error) bool {
+	switch err {
+	case transport.ErrRepositoryNotFound, transport.ErrEmptyRemoteRepository,
+		transport.ErrAuthenticationRequired, transport.ErrInvalidAuthMethod:
+		return true
+	}
+	return false
+}
+
+// bandwidthLimiter is the token bucket which is shared by all the connections.
+type bandwidthLimiter struct {
+	lock sync.Mutex
+	// rate is the number of bytes per second.
+	rate int64
+	// available is the number of bytes which can be read without waiting; it is negative
+	// if the readers are in debt.
+	available int64
+	last      time.Time
+}
+
+func newBandwidthLimiter(rate int64) *bandwidthLimiter {
+	return &bandwidthLimiter{rate: rate, available: rate, last: time.Now()}
+}
+
+// wait blocks until reading size bytes does not exceed the rate.
+func (limiter *bandwidthLimiter) wait(size int) {
+	limiter.lock.Lock()
+	now := time.Now()
+	limiter.available += int64(now.Sub(limiter.last).Seconds() * float64(limiter.rate))
+	if limiter.available > limiter.rate {
+		// at most one second of burst
+		limiter.available = limiter.rate
+	}
+	limiter.last = now
+	limiter.available -= int64(size)
+	debt := -limiter.available
+	limiter.lock.Unlock()
+	if debt > 0 {
+		time.Sleep(time.Duration(float64(debt) / float64(limiter.rate) * float64(time.Second)))
+	}
+}
+
+// throttledConn limits the reading speed of the connection.
+type throttledConn struct {
+	net.Conn
+	limiter *bandwidthLimiter
+}
+
+func (conn throttledConn) Read(buffer []byte) (int, error) {
+	if len(buffer) > 32*1024 {
+		// keep the pauses short
+		buffer = buffer[:32*1024]
+	}
+	n, err := conn.Conn.Read(buffer)
+	conn.limiter.wait(n)
+	return n, err
+}
+
+// installThrottledTransport makes go-git download over HTTP(S) through the limiter.
+func installThrottledTransport(limiter *bandwidthLimiter) {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	httpClient := &http.Client{Transport: &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+			conn, err := dialer.DialContext(ctx, network, address)
+			if err != nil {
+				return nil, err
+			}
+			return throttledConn{Conn: conn, limiter: limiter}, nil
+		},
+		TLSHandshakeTimeout: 10 * time.Second,
+	}}
+	client.InstallProtocol("http", githttp.NewClient(httpClient))
+	client.InstallProtocol("https", githttp.NewClient(httpClient))
+}
@@ -1,16 +1,12 @@
 package main
 
 import (
-	"bufio"
-	"bytes"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"os"
 	"sort"
 	"strings"
 
-	"github.com/gogo/protobuf/proto"
 	"github.com/spf13/cobra"
 	"gopkg.in/src-d/hercules.v4"
 	"gopkg.in/src-d/hercules.v4/internal/pb"
@@ -20,7 +16,7 @@ import (
 var combineCmd = &cobra.Command{
 	Use:   "combine",
 	Short: "Merge several binary analysis results together.",
-	Long:  ``,
+	Long:  `The input files may be compressed with gzip or zstd, the compression is detected automatically.`,
 	Args:  cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, files []string) {
 		if len(files) == 1 {
@@ -29,7 +25,11 @@ var combineCmd = &cobra.Command{
 				panic(err)
 			}
 			defer file.Close()
-			io.Copy(os.Stdout, bufio.NewReader(file))
+			reader, err := decompressReader(file)
+			if err != nil {
+				panic(err)
+			}
+			io.Copy(os.Stdout, reader)
 			return
 		}
 		repos := []string{}
@@ -48,43 +48,38 @@ var combineCmd = &cobra.Command{
 		if mergedMetadata == nil {
 			return
 		}
-		mergedMessage := pb.AnalysisResults{
-			Header: &pb.Metadata{
-				Version:    2,
-				Hash:       hercules.BinaryGitHash,
-				Repository: strings.Join(repos, " & "),
-			},
-			Contents: map[string][]byte{},
-		}
-		mergedMetadata.FillMetadata(mergedMessage.Header)
-		for key, val := range mergedResults {
-			buffer := bytes.Buffer{}
-			hercules.Registry.Summon(key)[0].(hercules.LeafPipelineItem).Serialize(
-				val, true, &buffer)
-			mergedMessage.Contents[key] = buffer.Bytes()
-		}
-		serialized, err := proto.Marshal(&mergedMessage)
+		header := &pb.Metadata{
+			Version:    2,
+			Hash:       hercules.BinaryGitHash,
+			Repository: strings.Join(repos, " & "),
+		}
+		mergedMetadata.FillMetadata(header)
+		container, err := pb.NewContainerWriter(os.Stdout, header)
 		if err != nil {
 			panic(err)
 		}
-		os.Stdout.Write(serialized)
+		keys := make([]string, 0, len(mergedResults))
+		for key := range mergedResults {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			item := hercules.Registry.Summon(key)[0].(hercules.LeafPipelineItem)
+			if err := writeResultChunk(container, item, mergedResults[key]); err != nil {
+				panic(err)
+			}
+		}
 	},
 }
 
 func loadMessage(fileName string, repos *[]string) (
 	map[string]interface{}, *hercules.CommonAnalysisResult, []string) {
 	errs := []string{}
-	buffer, err := ioutil.ReadFile(fileName)
+	message, err := readResults(fileName)
 	if err != nil {
 		errs = append(errs, "Cannot read "+fileName+": "+err.Error())
 		return nil, nil, errs
 	}
-	message := pb.AnalysisResults{}
-	err = proto.Unmarshal(buffer, &message)
-	if err != nil {
-		errs = append(errs, "Cannot parse "+fileName+": "+err.Error())
-		return nil, nil, errs
-	}
 	*repos = append(*repos, message.Header.Repository)
 	results := map[string]interface{}{}
 	for key, val := range message.Contents {
@@ -105,6 +100,30 @@ func loadMessage(fileName string, repos *[]string) (
 		}
 		results[key] = msg
 	}
+	extensions := map[string]hercules.ExtensionPipelineItem{}
+	for _, leaf := range hercules.Registry.GetLeaves() {
+		if epi, ok := leaf.(hercules.ExtensionPipelineItem); ok {
+			extensions[epi.ExtensionName()] = epi
+		}
+	}
+	for key, val := range message.Extensions {
+		epi, exists := extensions[key]
+		if !exists {
+			errs = append(errs, fileName+": extension not found: "+key)
+			continue
+		}
+		mpi, ok := epi.(hercules.MergeablePipelineItem)
+		if !ok {
+			errs = append(errs, fileName+": "+key+": MergeablePipelineItem is not implemented")
+			continue
+		}
+		msg, err := mpi.Deserialize(val.Value)
+		if err != nil {
+			errs = append(errs, fileName+": deserialization failed: "+key+": "+err.Error())
+			continue
+		}
+		results[epi.Name()] = msg
+	}
 	return results, hercules.MetadataToCommonAnalysisResult(message.Header), errs
 }
 
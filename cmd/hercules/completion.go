@@ -0,0 +1,328 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/src-d/hercules.v4"
+)
+
+// completionCmd represents the completion command
+var completionCmd = &cobra.Command{
+	Use:   "completion <bash|zsh|fish>",
+	Short: "Generate the shell completion script.",
+	Long: `Write the completion script for the specified shell to stdout. The script includes
+the flags of all the registered analyses and suggests their values. The flags of the plugins
+are included if they are loaded with --plugin. Installation:
+
+    bash: source <(hercules completion bash)
+    zsh:  hercules completion zsh > "${fpath[1]}/_hercules"
+    fish: hercules completion fish > ~/.config/fish/completions/hercules.fish`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"bash", "zsh", "fish"},
+	Run: func(cmd *cobra.Command, args []string) {
+		commands := collectCompletions(rootCmd)
+		var err error
+		switch args[0] {
+		case "bash":
+			err = writeBashCompletion(os.Stdout, commands)
+		case "zsh":
+			err = writeZshCompletion(os.Stdout, commands)
+		case "fish":
+			err = writeFishCompletion(os.Stdout, commands)
+		default:
+			fmt.Fprintf(os.Stderr, "Unsupported shell: %s\n", args[0])
+			os.Exit(1)
+		}
+		if err != nil {
+			panic(err)
+		}
+	},
+}
+
+// completionFlag describes how to complete a command line flag and its value.
+type completionFlag struct {
+	Name        string
+	Shorthand   string
+	Description string
+	// TakesValue is false for the boolean flags.
+	TakesValue bool
+	// Files indicates that the value is a path.
+	Files bool
+	// Values are the suggested values.
+	Values []string
+}
+
+// completionCommand describes how to complete a (sub)command. The root command has an empty Name.
+type completionCommand struct {
+	Name        string
+	Description string
+	Flags       []completionFlag
+	// Args are the valid positional arguments. Paths are completed if it is empty.
+	Args []string
+}
+
+// quotedWordRegexp extracts the enumerated values from the option descriptions, e.g.
+// `Which commit date to use: "author" or "committer".`
+var quotedWordRegexp = regexp.MustCompile(`"([\w-]+)"`)
+
+// simpleValueRegexp matches the default values which do not need escaping in the scripts.
+var simpleValueRegexp = regexp.MustCompile(`^[\w.,/+-]+$`)
+
+// optionHint derives the completion of the flag's value from the ConfigurationOption.
+func optionHint(flag *completionFlag, opt hercules.ConfigurationOption) {
+	if opt.Type == hercules.BoolConfigurationOption {
+		flag.TakesValue = false
+		return
+	}
+	flag.TakesValue = true
+	if opt.Type == hercules.StringConfigurationOption &&
+		(strings.HasPrefix(opt.Description, "Path") || strings.Contains(opt.Description, "directory")) {
+		flag.Files = true
+		return
+	}
+	values := map[string]bool{}
+	for _, match := range quotedWordRegexp.FindAllStringSubmatch(opt.Description, -1) {
+		values[match[1]] = true
+	}
+	if defaultValue := strings.Trim(opt.FormatDefault(), "\""); simpleValueRegexp.MatchString(defaultValue) {
+		values[defaultValue] = true
+	}
+	for value := range values {
+		flag.Values = append(flag.Values, value)
+	}
+	sort.Strings(flag.Values)
+}
+
+// collectCompletions lists the subcommands and their flags with the value hints.
+func collectCompletions(root *cobra.Command) []completionCommand {
+	options := map[string]hercules.ConfigurationOption{}
+	items := hercules.Registry.GetPlumbingItems()
+	for _, leaf := range hercules.Registry.GetLeaves() {
+		items = append(items, leaf)
+	}
+	for _, item := range items {
+		for _, opt := range item.ListConfigurationOptions() {
+			options[opt.Flag] = opt
+		}
+	}
+	features := []string{}
+	for feature := range hercules.Registry.GetFeaturedItems() {
+		features = append(features, feature)
+	}
+	sort.Strings(features)
+
+	describe := func(cmd *cobra.Command, name string) completionCommand {
+		result := completionCommand{Name: name, Description: cmd.Short, Args: cmd.ValidArgs}
+		seen := map[string]bool{}
+		visit := func(flag *pflag.Flag) {
+			if flag.Hidden || seen[flag.Name] {
+				return
+			}
+			seen[flag.Name] = true
+			cflag := completionFlag{
+				Name:        flag.Name,
+				Shorthand:   flag.Shorthand,
+				Description: strings.SplitN(flag.Usage, "\n", 2)[0],
+				TakesValue:  flag.Value.Type() != "bool",
+			}
+			if opt, exists := options[flag.Name]; exists {
+				optionHint(&cflag, opt)
+			} else if _, exists := flag.Annotations[cobra.BashCompFilenameExt]; exists {
+				cflag.Files = true
+			} else if flag.Name == "feature" {
+				cflag.Values = features
+			}
+			result.Flags = append(result.Flags, cflag)
+		}
+		cmd.Flags().VisitAll(visit)
+		cmd.InheritedFlags().VisitAll(visit)
+		return result
+	}
+	commands := []completionCommand{describe(root, "")}
+	for _, cmd := range root.Commands() {
+		if cmd.IsAvailableCommand() {
+			commands = append(commands, describe(cmd, cmd.Name()))
+		}
+	}
+	return commands
+}
+
+func writeBashCompletion(writer io.Writer, commands []completionCommand) error {
+	names := []string{}
+	for _, cmd := range commands[1:] {
+		names = append(names, cmd.Name)
+	}
+	fmt.Fprintln(writer, "# bash completion for hercules")
+	fmt.Fprintln(writer, "_hercules()\n{")
+	fmt.Fprintln(writer, `    local cur="${COMP_WORDS[COMP_CWORD]}" prev="${COMP_WORDS[COMP_CWORD-1]}"`)
+	fmt.Fprintln(writer, `    if [[ "$prev" == "=" ]]; then
+        prev="${COMP_WORDS[COMP_CWORD-2]}"
+    elif [[ "$cur" == "=" ]]; then
+        cur=""
+    fi`)
+	fmt.Fprintf(writer, "    local commands=\"%s\" command=\"\" flags=\"\" word\n", strings.Join(names, " "))
+	fmt.Fprintln(writer, `    for word in "${COMP_WORDS[@]:1:COMP_CWORD-1}"; do
+        if [[ " $commands " == *" $word "* ]]; then
+            command="$word"
+            break
+        fi
+    done`)
+	fmt.Fprintln(writer, `    case "$command" in`)
+	for _, cmd := range commands {
+		fmt.Fprintf(writer, "        \"%s\")\n", cmd.Name)
+		fmt.Fprintln(writer, `            case "$prev" in`)
+		flags := []string{}
+		for _, flag := range cmd.Flags {
+			patterns := "--" + flag.Name
+			flags = append(flags, "--"+flag.Name)
+			if flag.Shorthand != "" {
+				patterns += "|-" + flag.Shorthand
+				flags = append(flags, "-"+flag.Shorthand)
+			}
+			if !flag.TakesValue {
+				continue
+			}
+			var action string
+			if flag.Files {
+				action = `COMPREPLY=($(compgen -f -- "$cur"))`
+			} else {
+				action = fmt.Sprintf(`COMPREPLY=($(compgen -W "%s" -- "$cur"))`,
+					strings.Join(flag.Values, " "))
+			}
+			fmt.Fprintf(writer, "                %s) %s; return ;;\n", patterns, action)
+		}
+		fmt.Fprintln(writer, "            esac")
+		fmt.Fprintf(writer, "            flags=\"%s\"\n", strings.Join(flags, " "))
+		if len(cmd.Args) > 0 {
+			fmt.Fprintf(writer, "            [[ \"$cur\" != -* ]] && COMPREPLY=($(compgen -W \"%s\" -- \"$cur\")) && return\n",
+				strings.Join(cmd.Args, " "))
+		}
+		fmt.Fprintln(writer, "            ;;")
+	}
+	fmt.Fprintln(writer, "    esac")
+	fmt.Fprintln(writer, `    if [[ "$cur" == -* ]]; then
+        COMPREPLY=($(compgen -W "$flags" -- "$cur"))
+    elif [[ -z "$command" ]]; then
+        COMPREPLY=($(compgen -W "$commands" -- "$cur") $(compgen -f -- "$cur"))
+    else
+        COMPREPLY=($(compgen -f -- "$cur"))
+    fi
+}`)
+	_, err := fmt.Fprintln(writer, "complete -o default -F _hercules hercules")
+	return err
+}
+
+// zshEscape escapes the description for _arguments and _describe specs in single quotes.
+func zshEscape(text string) string {
+	return strings.NewReplacer(
+		"'", `'\''`, "[", `\[`, "]", `\]`, ":", `\:`).Replace(text)
+}
+
+func zshFlagSpecs(cmd completionCommand) []string {
+	specs := []string{}
+	for _, flag := range cmd.Flags {
+		value := ""
+		if flag.TakesValue {
+			switch {
+			case flag.Files:
+				value = ":file:_files"
+			case len(flag.Values) > 0:
+				value = fmt.Sprintf(":value:(%s)", strings.Join(flag.Values, " "))
+			default:
+				value = ":value: "
+			}
+		}
+		description := zshEscape(flag.Description)
+		name := "--" + flag.Name
+		if flag.TakesValue {
+			name += "="
+		}
+		specs = append(specs, fmt.Sprintf("'%s[%s]%s'", name, description, value))
+		if flag.Shorthand != "" {
+			specs = append(specs, fmt.Sprintf("'-%s[%s]%s'", flag.Shorthand, description, value))
+		}
+	}
+	return specs
+}
+
+func writeZshCompletion(writer io.Writer, commands []completionCommand) error {
+	const separator = " \\\n    "
+	fmt.Fprintln(writer, "#compdef hercules\n\n_hercules() {")
+	fmt.Fprintln(writer, `  local curcontext="$curcontext" state line
+  local -a commands
+  commands=(`)
+	for _, cmd := range commands[1:] {
+		fmt.Fprintf(writer, "    '%s:%s'\n", cmd.Name, zshEscape(cmd.Description))
+	}
+	fmt.Fprintln(writer, "  )")
+	fmt.Fprintf(writer, "  _arguments -C%s%s%s'1: :->command'%s'*:: :->args'\n",
+		separator, strings.Join(zshFlagSpecs(commands[0]), separator), separator, separator)
+	fmt.Fprintln(writer, `  case $state in
+    command)
+      _describe -t commands 'hercules command' commands
+      _files
+      ;;
+    args)
+      case $line[1] in`)
+	for _, cmd := range commands[1:] {
+		args := "'*:file:_files'"
+		if len(cmd.Args) > 0 {
+			args = fmt.Sprintf("'1:argument:(%s)'", strings.Join(cmd.Args, " "))
+		}
+		specs := append(zshFlagSpecs(cmd), args)
+		fmt.Fprintf(writer, "        %s)\n          _arguments%s%s\n          ;;\n",
+			cmd.Name, " \\\n            ", strings.Join(specs, " \\\n            "))
+	}
+	fmt.Fprint(writer, "      esac\n      ;;\n  esac\n}\n\n")
+	_, err := fmt.Fprintln(writer, `_hercules "$@"`)
+	return err
+}
+
+// fishEscape escapes the text for fish single quotes.
+func fishEscape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(text)
+}
+
+func writeFishCompletion(writer io.Writer, commands []completionCommand) error {
+	fmt.Fprintln(writer, "# fish completion for hercules")
+	for _, cmd := range commands {
+		condition := "__fish_use_subcommand"
+		if cmd.Name != "" {
+			condition = "__fish_seen_subcommand_from " + cmd.Name
+			fmt.Fprintf(writer, "complete -c hercules -n __fish_use_subcommand -f -a %s -d '%s'\n",
+				cmd.Name, fishEscape(cmd.Description))
+			if len(cmd.Args) > 0 {
+				fmt.Fprintf(writer, "complete -c hercules -n '%s' -f -a '%s'\n",
+					condition, strings.Join(cmd.Args, " "))
+			}
+		}
+		for _, flag := range cmd.Flags {
+			line := fmt.Sprintf("complete -c hercules -n '%s' -l %s", condition, flag.Name)
+			if flag.Shorthand != "" {
+				line += " -s " + flag.Shorthand
+			}
+			if flag.TakesValue {
+				line += " -r"
+				if flag.Files {
+					line += " -F"
+				} else if len(flag.Values) > 0 {
+					line += fmt.Sprintf(" -f -a '%s'", strings.Join(flag.Values, " "))
+				}
+			}
+			fmt.Fprintf(writer, "%s -d '%s'\n", line, fishEscape(flag.Description))
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+	completionCmd.SetUsageFunc(completionCmd.UsageFunc())
+}
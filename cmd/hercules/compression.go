@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// nopWriteCloser turns an io.Writer into an io.WriteCloser which does nothing on Close().
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// chainedWriteCloser closes the compressor and then the underlying file.
+type chainedWriteCloser struct {
+	io.WriteCloser
+	file io.Closer
+}
+
+func (writer chainedWriteCloser) Close() error {
+	err := writer.WriteCloser.Close()
+	if fileErr := writer.file.Close(); err == nil {
+		err = fileErr
+	}
+	return err
+}
+
+// createOutput opens the file to write the results to. The data is compressed if the file name
+// ends with ".gz" or ".zst"; the latter requires the build with the "zstd" tag.
+// Empty name or "-" means stdout.
+func createOutput(fileName string) (io.WriteCloser, error) {
+	if fileName == "" || fileName == "-" {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+	file, err := os.Create(fileName)
+	if err != nil {
+		return nil, err
+	}
+	var compressor io.WriteCloser
+	switch {
+	case strings.HasSuffix(fileName, ".gz"):
+		compressor = gzip.NewWriter(file)
+	case strings.HasSuffix(fileName, ".zst"):
+		compressor, err = newZstdWriter(file)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+	default:
+		return file, nil
+	}
+	return chainedWriteCloser{WriteCloser: compressor, file: file}, nil
+}
+
+// decompressReader detects gzip and zstd streams by the magic bytes and decompresses them.
+// Other data is passed through unchanged. zstd fails unless built with the "zstd" tag.
+func decompressReader(reader io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(reader)
+	header, _ := buffered.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		return gzip.NewReader(buffered)
+	case bytes.HasPrefix(header, zstdMagic):
+		return newZstdReader(buffered)
+	}
+	return buffered, nil
+}
+
+// openResultsFile opens the file with the analysis results for streaming, decompressing it
+// if needed. The uncompressed file is returned as is, so that the container reader can seek
+// over the chunks which it skips.
+func openResultsFile(fileName string) (io.ReadCloser, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, len(zstdMagic))
+	size, _ := io.ReadFull(file, header)
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if !bytes.HasPrefix(header[:size], gzipMagic) && !bytes.HasPrefix(header[:size], zstdMagic) {
+		return file, nil
+	}
+	reader, err := decompressReader(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return chainedReadCloser{Reader: reader, file: file}, nil
+}
+
+// chainedReadCloser reads the decompressed stream and closes the underlying file.
+type chainedReadCloser struct {
+	io.Reader
+	file io.Closer
+}
+
+func (reader chainedReadCloser) Close() error {
+	return reader.file.Close()
+}
+
+// readResults reads the analysis results from the file. Only the specified analyses are loaded
+// from the chunked container, all if none are specified.
+func readResults(fileName string, analyses ...string) (*pb.AnalysisResults, error) {
+	file, err := openResultsFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return pb.ReadAnalysisResults(file, analyses...)
+}
@@ -0,0 +1,19 @@
+// +build !zstd
+
+package main
+
+import (
+	"errors"
+	"io"
+)
+
+// errZstdDisabled is returned for zstd streams when hercules is built without the "zstd" tag.
+var errZstdDisabled = errors.New("zstd is not supported by this build, rebuild with -tags zstd")
+
+func newZstdWriter(io.Writer) (io.WriteCloser, error) {
+	return nil, errZstdDisabled
+}
+
+func newZstdReader(io.Reader) (io.Reader, error) {
+	return nil, errZstdDisabled
+}
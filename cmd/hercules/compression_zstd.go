@@ -0,0 +1,21 @@
+// +build zstd
+
+package main
+
+import (
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func newZstdWriter(writer io.Writer) (io.WriteCloser, error) {
+	return zstd.NewWriter(writer)
+}
+
+func newZstdReader(reader io.Reader) (io.Reader, error) {
+	decoder, err := zstd.NewReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	return decoder.IOReadCloser(), nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	goyaml "gopkg.in/yaml.v3"
+)
+
+// convertCmd represents the convert command
+var convertCmd = &cobra.Command{
+	Use:   "convert <analysis results.pb>",
+	Short: "Convert the binary analysis results to YAML or JSON.",
+	Long: `Reads the results in Protocol Buffers format, which may be compressed with gzip or zstd, decodes
+the header, every known analysis and the extensions of the loaded plugins to the typed messages and
+writes them as YAML or JSON documents.
+The field names are the same as in pb.proto. This way the binary format may be used for storage
+while the results are still easy to inspect without Python.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("to")
+		outputFile, _ := cmd.Flags().GetString("output")
+		if format != "yaml" && format != "json" {
+			fmt.Fprintf(os.Stderr, "Unknown output format: %s\n", format)
+			os.Exit(1)
+		}
+		message, err := readResults(args[0])
+		if err != nil {
+			panic(err)
+		}
+		document, errs := convertResults(message)
+		printErrors(map[string][]string{args[0]: errs})
+		output, err := createOutput(outputFile)
+		if err != nil {
+			panic(err)
+		}
+		if format == "json" {
+			err = writeJSON(document, output)
+		} else {
+			err = writeYAML(document, output)
+		}
+		if err != nil {
+			panic(err)
+		}
+		if err = output.Close(); err != nil {
+			panic(err)
+		}
+	},
+}
+
+// convertResults encodes the header and the results of the known analyses to JSON objects.
+// The header is stored under the "header" key. The generated messages carry the JSON tags
+// with the original field names, so the numbers stay numbers unlike in jsonpb.
+func convertResults(message *pb.AnalysisResults) (map[string]json.RawMessage, []string) {
+	document := map[string]json.RawMessage{}
+	errs := []string{}
+	if message.Header != nil {
+		header, err := json.Marshal(message.Header)
+		if err != nil {
+			errs = append(errs, "header: "+err.Error())
+		} else {
+			document["header"] = header
+		}
+	}
+	for name, data := range message.Contents {
+		result, err := pb.DecodeResult(name, data)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		encoded, err := json.Marshal(result)
+		if err != nil {
+			errs = append(errs, name+": "+err.Error())
+			continue
+		}
+		document[name] = encoded
+	}
+	for _, name := range message.ExtensionNames() {
+		result, err := pb.DecodeExtension(name, message.Extensions[name])
+		if err == pb.ErrUnknownExtension {
+			err = fmt.Errorf("unknown extension: %s", name)
+		}
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		encoded, err := json.Marshal(result)
+		if err != nil {
+			errs = append(errs, name+": "+err.Error())
+			continue
+		}
+		document[name] = encoded
+	}
+	return document, errs
+}
+
+func writeJSON(document map[string]json.RawMessage, writer io.Writer) error {
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(append(data, '\n'))
+	return err
+}
+
+// writeYAML parses the JSON document as YAML, which preserves the order of the keys, and
+// writes it in the block style.
+func writeYAML(document map[string]json.RawMessage, writer io.Writer) error {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	node := goyaml.Node{}
+	if err = goyaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	resetYAMLStyle(&node)
+	encoder := goyaml.NewEncoder(writer)
+	encoder.SetIndent(2)
+	if err = encoder.Encode(&node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// resetYAMLStyle removes the JSON flow style and the quotes which are not required.
+func resetYAMLStyle(node *goyaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(convertCmd)
+	convertCmd.SetUsageFunc(convertCmd.UsageFunc())
+	convertCmd.Flags().String("to", "yaml", "Output format: \"yaml\" or \"json\".")
+	convertCmd.Flags().StringP("output", "o", "", "Write the converted results to the file "+
+		"instead of stdout. The file is compressed with gzip or zstd if the name ends with "+
+		".gz or .zst respectively; zstd requires the build with the \"zstd\" tag.")
+	convertCmd.MarkFlagFilename("output")
+}
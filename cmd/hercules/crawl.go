@@ -0,0 +1,269 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// crawlCmd represents the crawl command
+var crawlCmd = &cobra.Command{
+	Use:   "crawl <github|gitlab> <organization>",
+	Short: "List the repositories of a GitHub organization or a GitLab group.",
+	Long: `Enumerate the repositories of the organization (GitHub) or the group with the subgroups
+(GitLab) through the API and print their clone URLs in the manifest format of
+"hercules batch", so that the whole organization is analysed with
+
+  hercules crawl github src-d --language Go | hercules batch - --burndown
+
+The token is taken from --token, $GITHUB_TOKEN or $GITLAB_TOKEN respectively; it is not
+required for the public repositories but raises the API rate limit. The archived
+repositories and the forks are skipped unless --archived and --forks are set.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := cmd.Flags()
+		crawler := repositoryCrawler{Client: http.DefaultClient}
+		crawler.Token, _ = flags.GetString("token")
+		crawler.APIURL, _ = flags.GetString("api-url")
+		crawler.Languages, _ = flags.GetStringSlice("language")
+		crawler.Archived, _ = flags.GetBool("archived")
+		crawler.Forks, _ = flags.GetBool("forks")
+		crawler.MaxSize, _ = flags.GetInt64("max-size")
+		var repos []crawledRepository
+		var err error
+		switch args[0] {
+		case "github":
+			if crawler.Token == "" {
+				crawler.Token = os.Getenv("GITHUB_TOKEN")
+			}
+			if crawler.APIURL == "" {
+				crawler.APIURL = "https://api.github.com"
+			}
+			repos, err = crawler.crawlGitHub(args[1])
+		case "gitlab":
+			if crawler.Token == "" {
+				crawler.Token = os.Getenv("GITLAB_TOKEN")
+			}
+			if crawler.APIURL == "" {
+				crawler.APIURL = "https://gitlab.com"
+			}
+			repos, err = crawler.crawlGitLab(args[1])
+		default:
+			err = fmt.Errorf("unknown hosting %s, choose either github or gitlab", args[0])
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		selected := 0
+		for _, repo := range repos {
+			if crawler.accepts(repo) {
+				fmt.Println(repo.CloneURL)
+				selected++
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Selected %d repositories out of %d.\n", selected, len(repos))
+	},
+}
+
+// crawledRepository is the description of a repository which the hosting API returns.
+type crawledRepository struct {
+	CloneURL string
+	// Languages are empty if the hosting did not report them.
+	Languages []string
+	Archived  bool
+	Fork      bool
+	// Size is in KiB; -1 if the hosting did not report it.
+	Size int64
+}
+
+// repositoryCrawler enumerates the repositories through the hosting API.
+type repositoryCrawler struct {
+	Client *http.Client
+	// APIURL is the root of the API, e.g. https://api.github.com or https://gitlab.com.
+	APIURL string
+	Token  string
+	// Languages select the repositories by the main language (GitHub) or by any of the
+	// languages (GitLab), case-insensitive. Empty selects all.
+	Languages []string
+	// Archived and Forks include the archived repositories and the forks.
+	Archived bool
+	Forks    bool
+	// MaxSize is the size limit in KiB; 0 means no limit.
+	MaxSize int64
+}
+
+// accepts checks the repository against the filters.
+func (crawler repositoryCrawler) accepts(repo crawledRepository) bool {
+	if (repo.Archived && !crawler.Archived) || (repo.Fork && !crawler.Forks) {
+		return false
+	}
+	if crawler.MaxSize > 0 && repo.Size > crawler.MaxSize {
+		return false
+	}
+	if len(crawler.Languages) == 0 {
+		return true
+	}
+	for _, wanted := range crawler.Languages {
+		for _, language := range repo.Languages {
+			if strings.EqualFold(wanted, language) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (crawler repositoryCrawler) crawlGitHub(organization string) ([]crawledRepository, error) {
+	type githubRepository struct {
+		CloneURL string `json:"clone_url"`
+		Language string `json:"language"`
+		Archived bool   `json:"archived"`
+		Fork     bool   `json:"fork"`
+		Size     int64  `json:"size"`
+	}
+	header := http.Header{}
+	if crawler.Token != "" {
+		header.Set("Authorization", "token "+crawler.Token)
+	}
+	var repos []crawledRepository
+	next := fmt.Sprintf("%s/orgs/%s/repos?per_page=100&type=all",
+		crawler.APIURL, url.PathEscape(organization))
+	for page := 0; next != ""; page++ {
+		var batch []githubRepository
+		var err error
+		next, err = crawler.get(next, header, &batch)
+		if err == errCrawlNotFound && page == 0 {
+			// a user instead of an organization
+			next, err = crawler.get(fmt.Sprintf("%s/users/%s/repos?per_page=100&type=owner",
+				crawler.APIURL, url.PathEscape(organization)), header, &batch)
+		}
+		if err == errCrawlNotFound {
+			return nil, fmt.Errorf("%s is neither an organization nor a user", organization)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range batch {
+			crawled := crawledRepository{
+				CloneURL: repo.CloneURL, Archived: repo.Archived, Fork: repo.Fork, Size: repo.Size}
+			if repo.Language != "" {
+				crawled.Languages = []string{repo.Language}
+			}
+			repos = append(repos, crawled)
+		}
+	}
+	return repos, nil
+}
+
+func (crawler repositoryCrawler) crawlGitLab(group string) ([]crawledRepository, error) {
+	type gitlabProject struct {
+		ID         int  `json:"id"`
+		Archived   bool `json:"archived"`
+		ForkedFrom *struct {
+			ID int `json:"id"`
+		} `json:"forked_from_project"`
+		HTTPURLToRepo string `json:"http_url_to_repo"`
+		Statistics    *struct {
+			RepositorySize int64 `json:"repository_size"`
+		} `json:"statistics"`
+	}
+	header := http.Header{}
+	if crawler.Token != "" {
+		header.Set("PRIVATE-TOKEN", crawler.Token)
+	}
+	var repos []crawledRepository
+	next := fmt.Sprintf("%s/api/v4/groups/%s/projects?per_page=100&include_subgroups=true&"+
+		"statistics=true", crawler.APIURL, url.PathEscape(group))
+	for next != "" {
+		var batch []gitlabProject
+		var err error
+		next, err = crawler.get(next, header, &batch)
+		if err == errCrawlNotFound {
+			return nil, fmt.Errorf("group %s does not exist", group)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range batch {
+			crawled := crawledRepository{CloneURL: project.HTTPURLToRepo,
+				Archived: project.Archived, Fork: project.ForkedFrom != nil, Size: -1}
+			if project.Statistics != nil {
+				crawled.Size = project.Statistics.RepositorySize / 1024
+			}
+			if len(crawler.Languages) > 0 {
+				// the languages are not listed, so they are requested only when needed
+				languages := map[string]float64{}
+				_, err = crawler.get(fmt.Sprintf("%s/api/v4/projects/%d/languages",
+					crawler.APIURL, project.ID), header, &languages)
+				if err != nil {
+					return nil, err
+				}
+				for language := range languages {
+					crawled.Languages = append(crawled.Languages, language)
+				}
+			}
+			repos = append(repos, crawled)
+		}
+	}
+	return repos, nil
+}
+
+// errCrawlNotFound is returned by repositoryCrawler.get() on 404.
+var errCrawlNotFound = errors.New("not found")
+
+// linkNextRegexp extracts the URL of the next page from the Link header.
+var linkNextRegexp = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// get requests the API and decodes the JSON response. It returns the URL of the next page
+// or "" if this page is the last.
+func (crawler repositoryCrawler) get(
+	location string, header http.Header, result interface{}) (string, error) {
+	request, err := http.NewRequest(http.MethodGet, location, nil)
+	if err != nil {
+		return "", err
+	}
+	for key, values := range header {
+		request.Header[key] = values
+	}
+	response, err := crawler.Client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return "", errCrawlNotFound
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GET %s: %s", location, response.Status)
+	}
+	if err = json.NewDecoder(response.Body).Decode(result); err != nil {
+		return "", fmt.Errorf("GET %s: %v", location, err)
+	}
+	if match := linkNextRegexp.FindStringSubmatch(response.Header.Get("Link")); match != nil {
+		return match[1], nil
+	}
+	return "", nil
+}
+
+func init() {
+	rootCmd.AddCommand(crawlCmd)
+	crawlCmd.SetUsageFunc(crawlCmd.UsageFunc())
+	crawlFlags := crawlCmd.Flags()
+	crawlFlags.String("token", "", "The API token; defaults to $GITHUB_TOKEN or $GITLAB_TOKEN.")
+	crawlFlags.String("api-url", "", "The root of the API for GitHub Enterprise or self-hosted "+
+		"GitLab, e.g. https://github.example.com/api/v3 or https://gitlab.example.com.")
+	crawlFlags.StringSlice("language", []string{}, "Select the repositories in these languages. "+
+		"GitHub reports only the main language of each repository.")
+	crawlFlags.Bool("archived", false, "Include the archived repositories.")
+	crawlFlags.Bool("forks", false, "Include the forks.")
+	crawlFlags.Int64("max-size", 0, "Skip the repositories which are bigger than this number "+
+		"of KiB. 0 means no limit.")
+}
@@ -8,20 +8,32 @@ import (
 	"text/template"
 )
 
+// templates map the embedded files to the names and the descriptions of the constants.
+var templates = []struct {
+	File, Const, Description string
+}{
+	{"plugin.template", "PluginTemplateSource",
+		"the source code template of a Hercules plugin"},
+	{"plugin_test.template", "PluginTestTemplateSource",
+		"the source code template of the tests of a Hercules plugin"},
+}
+
 func main() {
-	contents, err := ioutil.ReadFile("plugin.template")
-	if err != nil {
-		panic(err)
-	}
-	template.Must(template.New("plugin").Parse(string(contents)))
 	file, err := os.Create("plugin_template_source.go")
 	if err != nil {
 		panic(err)
 	}
 	defer file.Close()
-	file.WriteString("package main\n\n" +
-		"// PluginTemplateSource is the source code template of a Hercules plugin.\n" +
-		"const PluginTemplateSource = `")
-	file.Write(contents)
-	file.WriteString("`\n")
+	file.WriteString("package main\n")
+	for _, embedded := range templates {
+		contents, err := ioutil.ReadFile(embedded.File)
+		if err != nil {
+			panic(err)
+		}
+		template.Must(template.New(embedded.Const).Parse(string(contents)))
+		file.WriteString("\n// " + embedded.Const + " is " + embedded.Description + ".\n" +
+			"const " + embedded.Const + " = `")
+		file.Write(contents)
+		file.WriteString("`\n")
+	}
 }
@@ -3,16 +3,20 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"go/format"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"text/template"
 
 	"github.com/fatih/camelcase"
 	"github.com/spf13/cobra"
+	goyaml "gopkg.in/yaml.v3"
 )
 
 //go:generate go run embed.go
@@ -29,56 +33,82 @@ var ShlibExts = map[string]string{
 var generatePluginCmd = &cobra.Command{
 	Use:   "generate-plugin",
 	Short: "Write the plugin source skeleton.",
-	Long:  ``,
+	Long: `Write the source code of the analysis plugin, the Protocol Buffers message of its
+result, the tests and the Makefile. The plugin is described either with the flags or,
+completely, with the YAML file which is passed with --spec:
+
+  name: CommentDensity
+  description: measures the share of the comments in the changed lines.
+  requires: [file_diff, changes]
+  options:
+    - name: MinLines
+      type: int
+      default: 10
+      description: Ignore the files which are shorter than this number of lines.
+  result:
+    - name: ratios
+      type: float
+      repeated: true
+
+The options have the types bool, int, float, string or strings; the result fields have the
+Protocol Buffers scalar types. The explicit flags override the spec.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		flags := cmd.Flags()
-		name, _ := flags.GetString("name")
+		specPath, _ := flags.GetString("spec")
 		outputDir, _ := flags.GetString("output")
-		varname, _ := flags.GetString("varname")
-		flag, _ := flags.GetString("flag")
 		disableMakefile, _ := flags.GetBool("no-makefile")
-		pkg, _ := flags.GetString("package")
-
-		splitted := camelcase.Split(name)
-		err := os.MkdirAll(outputDir, os.ModePerm)
+		spec := &pluginSpec{}
+		if specPath != "" {
+			var err error
+			if spec, err = loadPluginSpec(specPath); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+		}
+		for _, override := range []struct {
+			Flag  string
+			Value *string
+		}{{"name", &spec.Name}, {"varname", &spec.Varname}, {"flag", &spec.Flag},
+			{"package", &spec.Package}, {"namespace", &spec.Namespace}} {
+			if flags.Changed(override.Flag) || *override.Value == "" {
+				*override.Value, _ = flags.GetString(override.Flag)
+			}
+		}
+		if spec.Name == "" {
+			fmt.Fprintln(os.Stderr, "Either --name or --spec must be specified.")
+			os.Exit(1)
+		}
+		dict, err := spec.templateData()
 		if err != nil {
-			panic(err)
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
-		outputPath := path.Join(outputDir, strings.ToLower(strings.Join(splitted, "_"))+".go")
-		gen := template.Must(template.New("plugin").Parse(PluginTemplateSource))
-		outFile, err := os.Create(outputPath)
+
+		splitted := camelcase.Split(spec.Name)
+		err = os.MkdirAll(outputDir, os.ModePerm)
 		if err != nil {
 			panic(err)
 		}
-		defer outFile.Close()
-		if varname == "" {
-			varname = strings.ToLower(splitted[0])
-		}
-		if flag == "" {
-			flag = strings.ToLower(strings.Join(splitted, "-"))
-		}
+		outputPath := path.Join(outputDir, strings.ToLower(strings.Join(splitted, "_"))+".go")
 		outputBase := path.Base(outputPath)
 		shlib := outputBase[:len(outputBase)-2] + ShlibExts[runtime.GOOS]
 		protoBuf := outputPath[:len(outputPath)-3] + ".proto"
 		pbGo := outputPath[:len(outputPath)-3] + ".pb.go"
-		dict := map[string]string{
-			"name": name, "varname": varname, "flag": flag, "package": pkg,
+		testPath := outputPath[:len(outputPath)-3] + "_test.go"
+		for key, val := range map[string]string{
 			"output": outputPath, "shlib": shlib, "proto": protoBuf, "protogo": pbGo,
-			"outdir": outputDir}
-		err = gen.Execute(outFile, dict)
-		if err != nil {
-			panic(err)
+			"test": testPath, "outdir": outputDir} {
+			dict[key] = val
 		}
+		writeGoTemplate(outputPath, PluginTemplateSource, dict)
+		writeGoTemplate(testPath, PluginTestTemplateSource, dict)
 		// write pb file
-		ioutil.WriteFile(protoBuf, []byte(fmt.Sprintf(`syntax = "proto3";
-	option go_package = "%s";
-	
-	message %sResultMessage {
-	  // add fields here
-	  // reference: https://developers.google.com/protocol-buffers/docs/proto3
-	  // example: pb/pb.proto https://github.com/src-d/hercules/blob/master/pb/pb.proto
-	}
-	`, pkg, name)), 0666)
+		gen := template.Must(template.New("proto").Parse(pluginProtoTemplate))
+		buffer := new(bytes.Buffer)
+		if err = gen.Execute(buffer, dict); err != nil {
+			panic(err)
+		}
+		ioutil.WriteFile(protoBuf, buffer.Bytes(), 0666)
 		// generate the pb Go file
 		protoc, err := exec.LookPath("protoc")
 		cmdargs := [...]string{
@@ -108,26 +138,331 @@ var generatePluginCmd = &cobra.Command{
 
 {{.protogo}}: {{.proto}}
 ` + "\t" + `PATH=$$PATH:$$GOPATH/bin protoc --gogo_out=. --proto_path=. {{.proto}}
+
+test: {{.output}} {{.test}} {{.protogo}}
+` + "\t" + `go test {{.output}} {{.test}} {{.protogo}}
+
+.PHONY: all test
 `))
 			buffer := new(bytes.Buffer)
 			mkrelative := func(name string) {
-				dict[name] = path.Base(dict[name])
+				dict[name] = path.Base(dict[name].(string))
 			}
 			mkrelative("output")
 			mkrelative("protogo")
 			mkrelative("proto")
+			mkrelative("test")
 			gen.Execute(buffer, dict)
 			ioutil.WriteFile(makefile, buffer.Bytes(), 0666)
 		}
 	},
 }
 
+// pluginProtoTemplate is the template of the Protocol Buffers message of the plugin's result.
+const pluginProtoTemplate = `syntax = "proto3";
+option go_package = "{{.package}}";
+
+message {{.name}}ResultMessage {
+{{- range .fields}}
+  {{if .Repeated}}repeated {{end}}{{.ProtoType}} {{.ProtoName}} = {{.Number}};
+{{- else}}
+  // add fields here
+  // reference: https://developers.google.com/protocol-buffers/docs/proto3
+  // example: pb/pb.proto https://github.com/src-d/hercules/blob/master/pb/pb.proto
+{{- end}}
+}
+`
+
+// writeGoTemplate executes the template and writes the formatted Go source code.
+func writeGoTemplate(outputPath string, source string, dict map[string]interface{}) {
+	gen := template.Must(template.New(path.Base(outputPath)).Parse(source))
+	buffer := new(bytes.Buffer)
+	if err := gen.Execute(buffer, dict); err != nil {
+		panic(err)
+	}
+	code, err := format.Source(buffer.Bytes())
+	if err != nil {
+		// write it as is so that the error can be found
+		fmt.Fprintf(os.Stderr, "%s: %v\n", outputPath, err)
+		code = buffer.Bytes()
+	}
+	if err = ioutil.WriteFile(outputPath, code, 0666); err != nil {
+		panic(err)
+	}
+}
+
+// pluginSpec is the YAML description of the plugin which "generate-plugin --spec" reads.
+type pluginSpec struct {
+	// Name is the name of the analysis type, CamelCase.
+	Name string `yaml:"name"`
+	// Flag activates the analysis; inferred from Name if empty.
+	Flag string `yaml:"flag"`
+	// Varname is the name of the method receivers; inferred from Name if empty.
+	Varname string `yaml:"varname"`
+	// Package is "main" if empty.
+	Package string `yaml:"package"`
+	// Namespace makes the binary result an extension of the combined output, e.g.
+	// "github.com/user/plugin".
+	Namespace string `yaml:"namespace"`
+	// Description continues the doc comment of the analysis type after its name.
+	Description string `yaml:"description"`
+	// Requires are the names of the dependencies, e.g. "file_diff".
+	Requires []string `yaml:"requires"`
+	// Provides are the names of the dependencies which the analysis produces.
+	Provides []string           `yaml:"provides"`
+	Options  []pluginOptionSpec `yaml:"options"`
+	Result   []pluginFieldSpec  `yaml:"result"`
+}
+
+// pluginOptionSpec describes a ConfigurationOption of the plugin.
+type pluginOptionSpec struct {
+	// Name is the name of the field in the analysis type, CamelCase.
+	Name string `yaml:"name"`
+	// Flag is inferred from the plugin's flag and Name if empty.
+	Flag string `yaml:"flag"`
+	// Type is one of bool, int, float, string and strings.
+	Type        string      `yaml:"type"`
+	Description string      `yaml:"description"`
+	Default     interface{} `yaml:"default"`
+}
+
+// pluginFieldSpec describes a field of the result message.
+type pluginFieldSpec struct {
+	// Name is the name of the field in the message, snake_case.
+	Name string `yaml:"name"`
+	// Type is the Protocol Buffers scalar type.
+	Type     string `yaml:"type"`
+	Repeated bool   `yaml:"repeated"`
+}
+
+// pluginOption is the template data of pluginOptionSpec.
+type pluginOption struct {
+	Field, Const, Flag, Description, Comment, GoType, OptionType, Default string
+}
+
+// pluginProvided is the template data of a dependency which the plugin provides.
+type pluginProvided struct {
+	Const, Value string
+}
+
+// pluginField is the template data of pluginFieldSpec.
+type pluginField struct {
+	ProtoName, ProtoType, GoName, GoType string
+	Number                               int
+	Repeated                             bool
+}
+
+// TextValue returns the expression which prints the value in YAML.
+func (field pluginField) TextValue(expr string) string {
+	if field.ProtoType == "string" {
+		return "yaml.SafeString(" + expr + ")"
+	}
+	return expr
+}
+
+var (
+	pluginCamelCaseRegexp = regexp.MustCompile("^[A-Z][A-Za-z0-9]*$")
+	pluginSnakeCaseRegexp = regexp.MustCompile("^[a-z][a-z0-9_]*$")
+
+	// pluginDependencies map the names of the dependencies to the exported constants.
+	pluginDependencies = map[string]string{
+		"author":        "hercules.DependencyAuthor",
+		"blob_cache":    "hercules.DependencyBlobCache",
+		"day":           "hercules.DependencyDay",
+		"file_diff":     "hercules.DependencyFileDiff",
+		"changes":       "hercules.DependencyTreeChanges",
+		"changed_uasts": "hercules.DependencyUastChanges",
+		"uasts":         "hercules.DependencyUasts",
+	}
+
+	// pluginScalarTypes map the Protocol Buffers scalar types to the Go types.
+	pluginScalarTypes = map[string]string{
+		"double": "float64", "float": "float32", "int32": "int32", "int64": "int64",
+		"uint32": "uint32", "uint64": "uint64", "sint32": "int32", "sint64": "int64",
+		"fixed32": "uint32", "fixed64": "uint64", "sfixed32": "int32", "sfixed64": "int64",
+		"bool": "bool", "string": "string",
+	}
+
+	// pluginOptionTypes map the option types to the Go types and the ConfigurationOptionType-s.
+	pluginOptionTypes = map[string][2]string{
+		"bool":    {"bool", "hercules.BoolConfigurationOption"},
+		"int":     {"int", "hercules.IntConfigurationOption"},
+		"float":   {"float32", "hercules.FloatConfigurationOption"},
+		"string":  {"string", "hercules.StringConfigurationOption"},
+		"strings": {"[]string", "hercules.StringsConfigurationOption"},
+	}
+)
+
+// loadPluginSpec reads the YAML description of the plugin.
+func loadPluginSpec(specPath string) (*pluginSpec, error) {
+	data, err := ioutil.ReadFile(specPath)
+	if err != nil {
+		return nil, err
+	}
+	spec := &pluginSpec{}
+	decoder := goyaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(spec); err != nil {
+		return nil, fmt.Errorf("%s: %v", specPath, err)
+	}
+	return spec, nil
+}
+
+// templateData validates the spec and converts it to the data of the templates.
+func (spec *pluginSpec) templateData() (map[string]interface{}, error) {
+	if !pluginCamelCaseRegexp.MatchString(spec.Name) {
+		return nil, fmt.Errorf("the name must be CamelCase: %s", spec.Name)
+	}
+	splitted := camelcase.Split(spec.Name)
+	if spec.Varname == "" {
+		spec.Varname = strings.ToLower(splitted[0])
+	}
+	if spec.Flag == "" {
+		spec.Flag = strings.ToLower(strings.Join(splitted, "-"))
+	}
+	if spec.Package == "" {
+		spec.Package = "main"
+	}
+	spec.Namespace = strings.Trim(spec.Namespace, "/")
+	requires := []string{}
+	runnable := true
+	for _, dep := range spec.Requires {
+		if expr, exists := pluginDependencies[dep]; exists {
+			requires = append(requires, expr)
+		} else {
+			requires = append(requires, strconv.Quote(dep))
+		}
+		// RunLeaf() cannot resolve the unknown dependencies and Babelfish is not available
+		runnable = runnable && pluginDependencies[dep] != "" && !strings.Contains(dep, "uast")
+	}
+	provides := []pluginProvided{}
+	for _, dep := range spec.Provides {
+		if !pluginSnakeCaseRegexp.MatchString(dep) {
+			return nil, fmt.Errorf("the provided dependency must be snake_case: %s", dep)
+		}
+		provides = append(provides, pluginProvided{
+			Const: "Dependency" + snakeToCamel(dep), Value: dep})
+	}
+	options := []pluginOption{}
+	for _, opt := range spec.Options {
+		if !pluginCamelCaseRegexp.MatchString(opt.Name) {
+			return nil, fmt.Errorf("the option name must be CamelCase: %s", opt.Name)
+		}
+		types, exists := pluginOptionTypes[opt.Type]
+		if !exists {
+			return nil, fmt.Errorf("option %s: unsupported type %s", opt.Name, opt.Type)
+		}
+		defaultValue, err := pluginOptionDefault(opt.Type, opt.Default)
+		if err != nil {
+			return nil, fmt.Errorf("option %s: %v", opt.Name, err)
+		}
+		flag := opt.Flag
+		if flag == "" {
+			flag = spec.Flag + "-" + strings.ToLower(strings.Join(camelcase.Split(opt.Name), "-"))
+		}
+		constName := "Config" + spec.Name + opt.Name
+		options = append(options, pluginOption{
+			Field: opt.Name, Const: constName, Flag: flag, Description: opt.Description,
+			Comment: "is set with " + constName + ".", GoType: types[0], OptionType: types[1],
+			Default: defaultValue})
+	}
+	fields := []pluginField{}
+	hasStrings := false
+	for i, field := range spec.Result {
+		if !pluginSnakeCaseRegexp.MatchString(field.Name) {
+			return nil, fmt.Errorf("the result field name must be snake_case: %s", field.Name)
+		}
+		goType, exists := pluginScalarTypes[field.Type]
+		if !exists {
+			return nil, fmt.Errorf("result field %s: unsupported type %s", field.Name, field.Type)
+		}
+		if field.Repeated {
+			goType = "[]" + goType
+		}
+		hasStrings = hasStrings || field.Type == "string"
+		fields = append(fields, pluginField{
+			ProtoName: field.Name, ProtoType: field.Type, GoName: snakeToCamel(field.Name),
+			GoType: goType, Number: i + 1, Repeated: field.Repeated})
+	}
+	return map[string]interface{}{
+		"name": spec.Name, "varname": spec.Varname, "flag": spec.Flag, "package": spec.Package,
+		"namespace": spec.Namespace, "description": spec.Description, "requires": requires, "provides": provides,
+		"options": options, "fields": fields, "yaml": hasStrings, "runnable": runnable,
+	}, nil
+}
+
+// pluginOptionDefault returns the Go literal of the option's default value.
+func pluginOptionDefault(optType string, value interface{}) (string, error) {
+	mismatch := fmt.Errorf("the default value %v is not %s", value, optType)
+	switch optType {
+	case "bool":
+		if value == nil {
+			return "false", nil
+		}
+		if val, ok := value.(bool); ok {
+			return strconv.FormatBool(val), nil
+		}
+	case "int":
+		if value == nil {
+			return "0", nil
+		}
+		if val, ok := value.(int); ok {
+			return strconv.Itoa(val), nil
+		}
+	case "float":
+		switch val := value.(type) {
+		case nil:
+			return "float32(0)", nil
+		case int:
+			return fmt.Sprintf("float32(%d)", val), nil
+		case float64:
+			return fmt.Sprintf("float32(%s)", strconv.FormatFloat(val, 'g', -1, 32)), nil
+		}
+	case "string":
+		if value == nil {
+			return `""`, nil
+		}
+		if val, ok := value.(string); ok {
+			return strconv.Quote(val), nil
+		}
+	case "strings":
+		if value == nil {
+			return "[]string{}", nil
+		}
+		if items, ok := value.([]interface{}); ok {
+			quoted := make([]string, len(items))
+			for i, item := range items {
+				str, ok := item.(string)
+				if !ok {
+					return "", mismatch
+				}
+				quoted[i] = strconv.Quote(str)
+			}
+			return "[]string{" + strings.Join(quoted, ", ") + "}", nil
+		}
+	}
+	return "", mismatch
+}
+
+// snakeToCamel converts the snake_case name to CamelCase the same way as protoc-gen-gogo.
+func snakeToCamel(name string) string {
+	parts := strings.Split(name, "_")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
 func init() {
 	rootCmd.AddCommand(generatePluginCmd)
 	generatePluginCmd.SetUsageFunc(generatePluginCmd.UsageFunc())
 	gpFlags := generatePluginCmd.Flags()
-	gpFlags.StringP("name", "n", "", "Name of the plugin, CamelCase. Required.")
-	generatePluginCmd.MarkFlagRequired("name")
+	gpFlags.StringP("name", "n", "", "Name of the plugin, CamelCase. Required unless --spec "+
+		"is specified.")
+	gpFlags.String("spec", "", "YAML description of the plugin: the name, the dependencies, "+
+		"the options and the result fields.")
 	gpFlags.StringP("output", "o", ".", "Output directory for the generated plugin files.")
 	gpFlags.String("varname", "", "Name of the plugin instance variable, If not "+
 		"specified, inferred from -n.")
@@ -135,4 +470,6 @@ func init() {
 		"specified, inferred from -varname.")
 	gpFlags.Bool("no-makefile", false, "Do not generate the Makefile.")
 	gpFlags.String("package", "main", "Name of the package.")
+	gpFlags.String("namespace", "", "Write the binary result as the extension of the combined "+
+		"output with this prefix, e.g. github.com/user/plugin.")
 }
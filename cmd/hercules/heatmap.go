@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/spf13/cobra"
+	"gopkg.in/src-d/hercules.v4/internal/calendar"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+)
+
+// heatmapCmd represents the heatmap command
+var heatmapCmd = &cobra.Command{
+	Use:   "heatmap <analysis results.pb>",
+	Short: "Draw the calendar heatmap of the project activity as SVG.",
+	Long: `Reads the commit features results in Protocol Buffers format and writes the GitHub-style
+calendar heatmap of the number of commits or the churn (added + removed lines) per day.
+Run hercules with --commit-features --pb to produce the input.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		metric, _ := cmd.Flags().GetString("metric")
+		if metric != "commits" && metric != "churn" {
+			fmt.Fprintf(os.Stderr, "Unknown metric: %s\n", metric)
+			os.Exit(1)
+		}
+		message, err := readResults(args[0], "CommitFeatures")
+		if err != nil {
+			panic(err)
+		}
+		contents, exists := message.Contents["CommitFeatures"]
+		if !exists {
+			fmt.Fprintln(os.Stderr, "Nothing to do: re-run hercules with --commit-features.")
+			os.Exit(1)
+		}
+		features := pb.CommitFeaturesResults{}
+		if err = proto.Unmarshal(contents, &features); err != nil {
+			panic(err)
+		}
+		// the day indexes are counted from the first commit's day, see DaysSinceStart
+		day0 := calendar.Day(time.Unix(message.Header.BeginUnixTime, 0))
+		activity := map[time.Time]int64{}
+		for _, commit := range features.Commits {
+			day := day0.AddDate(0, 0, int(commit.Day))
+			if metric == "commits" {
+				activity[day]++
+			} else {
+				activity[day] += int64(commit.Added + commit.Removed)
+			}
+		}
+		file, err := os.Create(output)
+		if err != nil {
+			panic(err)
+		}
+		defer file.Close()
+		err = calendar.Render(file, activity, message.Header.Repository, metric)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Fprintln(os.Stderr, "Wrote", output)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(heatmapCmd)
+	heatmapCmd.SetUsageFunc(heatmapCmd.UsageFunc())
+	heatmapCmd.Flags().StringP("output", "o", "heatmap.svg", "Path to the output SVG file.")
+	heatmapCmd.MarkFlagFilename("output", "svg")
+	heatmapCmd.Flags().String("metric", "commits", "Daily value to draw: \"commits\" or \"churn\".")
+}
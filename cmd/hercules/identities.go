@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+// identitiesCmd represents the identities command
+var identitiesCmd = &cobra.Command{
+	Use:   "identities [repository] [cache]",
+	Short: "Merge the developer identities and write the people dictionary.",
+	Long: `Detect the developer identities in the repository the same way as the analyses do, propose
+the pairs which probably belong to the same person (similar names, the same email user, a name
+spelled in an email) and ask to accept or reject each. The decisions can be also supplied with
+--decisions, one per line: "+ <name or email> | <name or email>" merges, "- ... | ..." keeps
+apart. The result is the people dictionary for "hercules run --people-dict". The repository
+is not needed if --people-dict is specified.`,
+	Args: cobra.RangeArgs(0, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := cmd.Flags()
+		commitsFile, _ := flags.GetString("commits")
+		peopleDictPath, _ := flags.GetString("people-dict")
+		decisionsFile, _ := flags.GetString("decisions")
+		batch, _ := flags.GetBool("batch")
+		outputFile, _ := flags.GetString("output")
+		cachePath := ""
+		if len(args) == 2 {
+			cachePath = args[1]
+		}
+
+		var identities []string
+		var peopleDict map[string]int
+		if peopleDictPath != "" {
+			var err error
+			identities, peopleDict, err = readPeopleDict(peopleDictPath)
+			if err != nil {
+				panic(err)
+			}
+		} else {
+			if len(args) == 0 {
+				fmt.Fprintln(os.Stderr, "Either the repository or --people-dict must be specified.")
+				os.Exit(1)
+			}
+			repository := loadRepository(args[0], cachePath, true)
+			var commits []*object.Commit
+			if commitsFile == "" {
+				commits = hercules.NewPipeline(repository).Commits()
+			} else {
+				var err error
+				commits, err = hercules.LoadCommitsFromFile(commitsFile, repository)
+				if err != nil {
+					panic(err)
+				}
+			}
+			detector := identity.Detector{}
+			detector.GeneratePeopleDict(commits)
+			identities, peopleDict = detector.ReversedPeopleDict, detector.PeopleDict
+		}
+		fmt.Fprintf(os.Stderr, "Detected %d identities\n", len(identities))
+
+		decisions := map[[2]int]bool{}
+		if decisionsFile != "" {
+			var err error
+			decisions, err = loadIdentityDecisions(decisionsFile, peopleDict)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+		}
+		var merges [][2]int
+		for pair, accepted := range decisions {
+			if accepted {
+				merges = append(merges, pair)
+			}
+		}
+		stdin := bufio.NewReader(os.Stdin)
+		for _, candidate := range identity.FindMergeCandidates(identities) {
+			pair := [2]int{candidate.First, candidate.Second}
+			if _, decided := decisions[pair]; decided || batch {
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "\n  %s\n  %s\n%s. Merge? [y/n/q] ",
+				identities[candidate.First], identities[candidate.Second], candidate.Reason)
+			answer, err := stdin.ReadString('\n')
+			answer = strings.ToLower(strings.TrimSpace(answer))
+			if err != nil || answer == "q" {
+				fmt.Fprintln(os.Stderr)
+				break
+			}
+			if answer == "y" || answer == "yes" {
+				merges = append(merges, pair)
+			}
+		}
+
+		output, err := createOutput(outputFile)
+		if err != nil {
+			panic(err)
+		}
+		lines := identity.MergeIdentities(identities, merges)
+		err = writeIdentities(output, lines)
+		if closeErr := output.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			panic(err)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %d identities\n", len(lines))
+	},
+}
+
+// readPeopleDict reads the existing people dictionary. Unlike identity.Detector.LoadPeopleDict(),
+// it keeps all the keys of each identity and their case.
+func readPeopleDict(path string) ([]string, map[string]int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+	var identities []string
+	peopleDict := map[string]int{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		for _, key := range strings.Split(line, "|") {
+			peopleDict[strings.ToLower(key)] = len(identities)
+		}
+		identities = append(identities, line)
+	}
+	return identities, peopleDict, scanner.Err()
+}
+
+// loadIdentityDecisions reads the file with the merge decisions. Each line is either
+// "+ <key> | <key>" to merge the identities or "- <key> | <key>" to keep them apart;
+// the keys are the names or the emails. Empty lines and lines starting with "#" are ignored.
+func loadIdentityDecisions(path string, peopleDict map[string]int) (map[[2]int]bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	decisions := map[[2]int]bool{}
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		keys := strings.Split(line[1:], "|")
+		if (line[0] != '+' && line[0] != '-') || len(keys) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected \"+ key | key\" or \"- key | key\"", path, lineNumber)
+		}
+		var pair [2]int
+		for i, key := range keys {
+			key = strings.ToLower(strings.TrimSpace(key))
+			id, exists := peopleDict[key]
+			if !exists {
+				return nil, fmt.Errorf("%s:%d: unknown identity %q", path, lineNumber, key)
+			}
+			pair[i] = id
+		}
+		if pair[0] > pair[1] {
+			pair[0], pair[1] = pair[1], pair[0]
+		}
+		if pair[0] != pair[1] {
+			decisions[pair] = line[0] == '+'
+		}
+	}
+	return decisions, scanner.Err()
+}
+
+// writeIdentities writes the people dictionary in the format of identity.Detector.LoadPeopleDict().
+func writeIdentities(writer io.Writer, lines []string) error {
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(writer, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(identitiesCmd)
+	identitiesCmd.SetUsageFunc(identitiesCmd.UsageFunc())
+	identitiesCmd.Flags().String("commits", "", "Path to the text file with the "+
+		"commit history to follow instead of the default rev-list --first-parent.")
+	identitiesCmd.MarkFlagFilename("commits")
+	identitiesCmd.Flags().String("people-dict", "", "Refine the existing people dictionary "+
+		"instead of detecting the identities in the repository.")
+	identitiesCmd.MarkFlagFilename("people-dict")
+	identitiesCmd.Flags().String("decisions", "", "Path to the file with the merge decisions.")
+	identitiesCmd.MarkFlagFilename("decisions")
+	identitiesCmd.Flags().Bool("batch", false, "Do not ask, reject the undecided candidates.")
+	identitiesCmd.Flags().StringP("output", "o", "", "Path to the people dictionary to write; "+
+		"stdout if empty.")
+	identitiesCmd.MarkFlagFilename("output")
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/src-d/hercules.v4"
+	"gopkg.in/src-d/hercules.v4/internal/impact"
+	"gopkg.in/src-d/hercules.v4/leaves"
+)
+
+// impactCmd represents the impact command
+var impactCmd = &cobra.Command{
+	Use:   "impact <repository> <from> <to> [cache]",
+	Short: "Map the changes between two releases to the affected areas and the people to ask.",
+	Long: `Compare the trees of two revisions, usually the tags of the consecutive releases, and group
+the changed files by directory up to --ownership-depth path components. For each affected area,
+list the developers who own the most lines there at <to> and the other areas which usually
+change together with it. The line ownership and the coupling are calculated by running
+the ownership concentration and the couples analyses on the first-parent history up to <to>,
+so the identity flags such as --people-dict apply. The report is written in YAML.`,
+	Args: cobra.RangeArgs(3, 4),
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := cmd.Flags()
+		maxOwners, _ := flags.GetInt("owners")
+		maxCoupled, _ := flags.GetInt("coupled")
+		quiet, _ := flags.GetBool("quiet")
+		cachePath := ""
+		if len(args) == 4 {
+			cachePath = args[3]
+		}
+		repository := loadRepository(args[0], cachePath, quiet)
+		commits, err := resolveCommitRange(repository, args[2])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		released, err := resolveCommitRange(repository, args[1]+".."+args[2])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		from := commits[len(commits)-len(released)-1]
+		changes, err := impact.Changes(from, commits[len(commits)-1])
+		if err != nil {
+			panic(err)
+		}
+		ownershipLeaf := &leaves.OwnershipConcentrationAnalysis{}
+		couplesLeaf := &leaves.CouplesAnalysis{}
+		job := analysisJob{
+			Repository:   repository,
+			URI:          args[0],
+			Analyses:     []string{ownershipLeaf.Name(), couplesLeaf.Name()},
+			Facts:        cmdlineFacts,
+			Commits:      commits,
+			ShowProgress: !quiet,
+		}
+		_, deployed, results := job.execute()
+		if results == nil {
+			return
+		}
+		depth := leaves.DefaultOwnershipConcentrationDepth
+		if val, exists := cmdlineFacts[leaves.ConfigOwnershipConcentrationDepth].(int); exists {
+			depth = val
+		}
+		mapper := impact.Mapper{Depth: depth, MaxOwners: maxOwners, MaxCoupled: maxCoupled}
+		var ownership impact.Ownership
+		var coupling impact.Coupling
+		for _, item := range deployed {
+			switch result := results[item].(type) {
+			case leaves.OwnershipConcentrationResult:
+				ownership.Directories = result.Directories
+			case leaves.CouplesResult:
+				coupling.Files = result.Files
+				coupling.Matrix = result.FilesMatrix
+			}
+		}
+		if people, ok := cmdlineFacts[hercules.FactIdentityDetectorReversedPeopleDict].([]string); ok {
+			ownership.People = people
+		}
+		report := impact.Report{
+			From:    args[1],
+			To:      args[2],
+			Commits: len(released),
+			Areas:   mapper.Map(changes, ownership, coupling),
+		}
+		report.Serialize(os.Stdout)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(impactCmd)
+	impactCmd.Flags().Int("owners", 3, "Maximum number of the owners to list for each area; "+
+		"negative lists all.")
+	impactCmd.Flags().Int("coupled", 5, "Maximum number of the coupled areas to list for each "+
+		"area; negative lists all.")
+}
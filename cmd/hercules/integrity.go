@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ed25519"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// signatureExtension is appended to the result file name to get the detached signature file name.
+const signatureExtension = ".sig"
+
+// signCmd represents the sign command
+var signCmd = &cobra.Command{
+	Use:   "sign",
+	Short: "Write detached Ed25519 signatures of the analysis results.",
+	Long: `Sign the result files with the private key from --key. Each signature is written next to
+the signed file with the .sig extension. --generate-key creates a new key pair instead:
+the private key is written to the specified path and the public key to the same path with
+the .pub extension.`,
+	Run: func(cmd *cobra.Command, files []string) {
+		keyFile, _ := cmd.Flags().GetString("key")
+		newKeyFile, _ := cmd.Flags().GetString("generate-key")
+		if newKeyFile != "" {
+			if err := generateKeys(newKeyFile); err != nil {
+				panic(err)
+			}
+			return
+		}
+		if keyFile == "" || len(files) == 0 {
+			cmd.Usage()
+			os.Exit(1)
+		}
+		key, err := readKey(keyFile, ed25519.PrivateKeySize)
+		if err != nil {
+			panic(err)
+		}
+		for _, fileName := range files {
+			data, err := ioutil.ReadFile(fileName)
+			if err != nil {
+				panic(err)
+			}
+			signature := ed25519.Sign(ed25519.PrivateKey(key), data)
+			err = writeKey(fileName+signatureExtension, signature, 0644)
+			if err != nil {
+				panic(err)
+			}
+		}
+	},
+}
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check the integrity of the analysis results.",
+	Long: `Recompute the checksums of the analyses in YAML or Protocol Buffers result files and
+compare them with the embedded values. If --public-key is set, additionally check the detached
+signatures written by "hercules sign". The exit code is 1 if any of the files is corrupted.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, files []string) {
+		publicKeyFile, _ := cmd.Flags().GetString("public-key")
+		var publicKey []byte
+		if publicKeyFile != "" {
+			var err error
+			publicKey, err = readKey(publicKeyFile, ed25519.PublicKeySize)
+			if err != nil {
+				panic(err)
+			}
+		}
+		allErrors := map[string][]string{}
+		failed := false
+		for _, fileName := range files {
+			errs := verifyFile(fileName, publicKey)
+			allErrors[fileName] = errs
+			failed = failed || len(errs) > 0
+		}
+		printErrors(allErrors)
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+func verifyFile(fileName string, publicKey []byte) []string {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return []string{"Cannot read " + fileName + ": " + err.Error()}
+	}
+	var errs []string
+	if publicKey != nil {
+		signature, err := readKey(fileName+signatureExtension, ed25519.SignatureSize)
+		if err != nil {
+			errs = append(errs, err.Error())
+		} else if !ed25519.Verify(ed25519.PublicKey(publicKey), data, signature) {
+			errs = append(errs, "invalid signature")
+		}
+	}
+	reader, err := decompressReader(bytes.NewReader(data))
+	if err == nil {
+		data, err = ioutil.ReadAll(reader)
+	}
+	if err != nil {
+		return append(errs, "Cannot decompress "+fileName+": "+err.Error())
+	}
+	for _, err := range verifyChecksums(data) {
+		errs = append(errs, err.Error())
+	}
+	return errs
+}
+
+// verifyChecksums checks the embedded checksums of YAML and chunked Protocol Buffers results.
+func verifyChecksums(data []byte) []error {
+	if bytes.HasPrefix(data, []byte(yaml.HeaderKey+":")) {
+		return yaml.VerifyChecksums(data)
+	}
+	if !pb.IsContainer(data) {
+		return []error{errors.New("the file does not contain checksums")}
+	}
+	reader, err := pb.NewContainerReader(bytes.NewReader(data))
+	if err != nil {
+		return []error{err}
+	}
+	var errs []error
+	for {
+		name, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return append(errs, err)
+		}
+		if _, err = reader.Data(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", name, err))
+		}
+	}
+	return errs
+}
+
+func generateKeys(fileName string) error {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	if err = writeKey(fileName, private, 0600); err != nil {
+		return err
+	}
+	return writeKey(fileName+".pub", public, 0644)
+}
+
+// writeKey writes the base64 encoded key or signature to the file.
+func writeKey(fileName string, key []byte, mode os.FileMode) error {
+	return ioutil.WriteFile(fileName, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), mode)
+}
+
+// readKey reads the base64 encoded key or signature of the expected size from the file.
+func readKey(fileName string, size int) ([]byte, error) {
+	text, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(text)))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", fileName, err)
+	}
+	if len(key) != size {
+		return nil, fmt.Errorf("%s: invalid size %d, expected %d", fileName, len(key), size)
+	}
+	return key, nil
+}
+
+func init() {
+	rootCmd.AddCommand(signCmd)
+	signCmd.SetUsageFunc(signCmd.UsageFunc())
+	signCmd.Flags().StringP("key", "k", "", "Path to the private key.")
+	signCmd.MarkFlagFilename("key")
+	signCmd.Flags().String("generate-key", "", "Generate a new key pair and write it to the specified path.")
+	rootCmd.AddCommand(verifyCmd)
+	verifyCmd.SetUsageFunc(verifyCmd.UsageFunc())
+	verifyCmd.Flags().String("public-key", "", "Path to the public key to check the signatures.")
+	verifyCmd.MarkFlagFilename("public-key")
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/src-d/hercules.v4"
+)
+
+// lsCmd represents the ls command
+var lsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List the available analyses and their options.",
+	Long: `Print the analysis targets which can be passed to "hercules run" grouped with their
+configuration options. The plugins are included if they are loaded with --plugin.`,
+	Args: cobra.MaximumNArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		showPlumbing, _ := cmd.Flags().GetBool("plumbing")
+		for _, leaf := range hercules.Registry.GetLeaves() {
+			fmt.Printf("%-44s %s\n", "--"+leaf.Flag(), leaf.Name())
+			if featured, ok := leaf.(hercules.FeaturedPipelineItem); ok && len(featured.Features()) > 0 {
+				fmt.Printf("    requires --feature=%s\n", strings.Join(featured.Features(), ","))
+			}
+			if featured, ok := leaf.(hercules.OptionallyFeaturedPipelineItem); ok {
+				fmt.Printf("    optional --feature=%s\n", strings.Join(featured.OptionalFeatures(), ","))
+			}
+			printOptions(os.Stdout, leaf.ListConfigurationOptions())
+		}
+		if !showPlumbing {
+			return
+		}
+		for _, item := range hercules.Registry.GetPlumbingItems() {
+			options := item.ListConfigurationOptions()
+			if len(options) == 0 {
+				continue
+			}
+			fmt.Printf("[%s]\n", item.Name())
+			printOptions(os.Stdout, options)
+		}
+	},
+}
+
+// printOptions writes one line per configuration option: the flag, the value type,
+// the description and the default value.
+func printOptions(writer io.Writer, options []hercules.ConfigurationOption) {
+	for _, opt := range options {
+		flag := "--" + opt.Flag
+		if opt.Type.String() != "" {
+			flag += " " + opt.Type.String()
+		}
+		fmt.Fprintf(writer, "    %-40s %s", flag, opt.Description)
+		if opt.Default != nil {
+			fmt.Fprintf(writer, " The default value is %s.", opt.FormatDefault())
+		}
+		fmt.Fprintln(writer)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(lsCmd)
+	lsCmd.SetUsageFunc(lsCmd.UsageFunc())
+	lsCmd.Flags().Bool("plumbing", false, "Also list the options of the plumbing items.")
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// plotCmd represents the plot command
+var plotCmd = &cobra.Command{
+	Use:   "plot [labours.py arguments]",
+	Short: "Plot the analysis results with labours.py.",
+	Long: `Run labours.py with the given arguments. The script is searched in $HERCULES_LABOURS,
+next to the hercules executable and in $PATH. The Python interpreter is $PYTHON, python3
+by default. Example:
+
+    hercules run --burndown --pb https://github.com/src-d/go-git | hercules plot -m burndown-project -f pb`,
+	DisableFlagParsing: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		script, err := findLabours()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		python := os.Getenv("PYTHON")
+		if python == "" {
+			python = "python3"
+		}
+		labours := exec.Command(python, append([]string{script}, args...)...)
+		labours.Stdin = os.Stdin
+		labours.Stdout = os.Stdout
+		labours.Stderr = os.Stderr
+		if err = labours.Run(); err != nil {
+			if exitErr, ok := err.(*exec.ExitError); ok && !exitErr.Success() {
+				os.Exit(1)
+			}
+			panic(err)
+		}
+	},
+}
+
+// findLabours returns the path to labours.py.
+func findLabours() (string, error) {
+	if path := os.Getenv("HERCULES_LABOURS"); path != "" {
+		return path, nil
+	}
+	if executable, err := os.Executable(); err == nil {
+		path := filepath.Join(filepath.Dir(executable), "labours.py")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	path, err := exec.LookPath("labours.py")
+	if err != nil {
+		return "", fmt.Errorf("labours.py was not found, please set HERCULES_LABOURS")
+	}
+	return path, nil
+}
+
+func init() {
+	rootCmd.AddCommand(plotCmd)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// analysisPreset is a curated set of analyses with their options which --preset enables.
+type analysisPreset struct {
+	// Description is printed in --help.
+	Description string
+	// Flags map the command line flags to their values. The analyses are enabled by
+	// their boolean flags, e.g. "burndown": "true".
+	Flags map[string]string
+}
+
+// analysisPresets are the presets which are available in --preset.
+var analysisPresets = map[string]analysisPreset{
+	"health": {
+		Description: "the code age, the activity of the developers and the size of the repository",
+		Flags: map[string]string{
+			"burndown":        "true",
+			"devs":            "true",
+			"repository-size": "true",
+			"file-lifecycle":  "true",
+			"commit-messages": "true",
+		},
+	},
+	"ownership": {
+		Description: "who wrote the code which is alive and who works on which files",
+		Flags: map[string]string{
+			"burndown":                "true",
+			"burndown-people":         "true",
+			"burndown-top-files":      "1000",
+			"couples":                 "true",
+			"couples-top-files":       "1000",
+			"churn-origin":            "true",
+			"company-attribution":     "true",
+			"ownership-concentration": "true",
+		},
+	},
+	"research": {
+		Description: "the fine-grained datasets for the further processing",
+		Flags: map[string]string{
+			"burndown":             "true",
+			"burndown-files":       "true",
+			"burndown-people":      "true",
+			"couples":              "true",
+			"couples-people-files": "true",
+			"devs":                 "true",
+			"commit-features":      "true",
+			"file-history":         "true",
+		},
+	},
+}
+
+// presetNames returns the sorted names of analysisPresets.
+func presetNames() []string {
+	names := make([]string, 0, len(analysisPresets))
+	for name := range analysisPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// presetsHelp lists the presets for the description of --preset.
+func presetsHelp() string {
+	items := make([]string, 0, len(analysisPresets))
+	for _, name := range presetNames() {
+		items = append(items, fmt.Sprintf("%s - %s", name, analysisPresets[name].Description))
+	}
+	return strings.Join(items, "; ")
+}
+
+// applyPreset sets the flags of the named preset. The flags which were explicitly passed
+// in the command line keep their values, so that the preset's options can be overridden.
+func applyPreset(flags *pflag.FlagSet, name string) error {
+	preset, exists := analysisPresets[name]
+	if !exists {
+		return fmt.Errorf("unknown preset %q, choose one of %s",
+			name, strings.Join(presetNames(), ", "))
+	}
+	for flag, value := range preset.Flags {
+		if flags.Changed(flag) {
+			continue
+		}
+		if err := flags.Set(flag, value); err != nil {
+			return fmt.Errorf("preset %s: --%s: %v", name, flag, err)
+		}
+	}
+	return nil
+}
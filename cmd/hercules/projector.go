@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/spf13/cobra"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	"gopkg.in/src-d/hercules.v4/internal/projector"
+)
+
+// projectorCmd represents the projector command
+var projectorCmd = &cobra.Command{
+	Use:   "projector <analysis results.pb>",
+	Short: "Train the coupling embeddings and write them for Tensorflow Projector.",
+	Long: `Reads the couples and shotness results in Protocol Buffers format, trains the embeddings
+from the co-occurrence matrices and writes them to TSV files which can be loaded into
+http://projector.tensorflow.org. This is an alternative to "labours.py -m couples" which
+does not require Tensorflow.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		dimensions, _ := cmd.Flags().GetInt("dimensions")
+		iterations, _ := cmd.Flags().GetInt("iterations")
+		message, err := readResults(args[0], "Couples", "Shotness")
+		if err != nil {
+			panic(err)
+		}
+		written := 0
+		embed := func(name string, index []string, matrix []map[int]int64) {
+			if len(index) == 0 {
+				return
+			}
+			embeddings := projector.Embed(matrix, dimensions, iterations)[:len(index)]
+			commits := make([]int64, len(index))
+			for i := range index {
+				commits[i] = matrix[i][i]
+			}
+			files, err := projector.Write(output, name, index, commits, embeddings)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+				return
+			}
+			for _, file := range files {
+				fmt.Fprintln(os.Stderr, "Wrote", file)
+			}
+			written++
+		}
+		if contents, exists := message.Contents["Couples"]; exists {
+			couples := pb.CouplesAnalysisResults{}
+			err = proto.Unmarshal(contents, &couples)
+			if err != nil {
+				panic(err)
+			}
+			if couples.FileCouples != nil {
+				embed("files", couples.FileCouples.Index,
+					pb.CompressedSparseRowMatrixToMap(couples.FileCouples.Matrix))
+			}
+			if couples.PeopleCouples != nil {
+				embed("people", couples.PeopleCouples.Index,
+					pb.CompressedSparseRowMatrixToMap(couples.PeopleCouples.Matrix))
+			}
+		}
+		if contents, exists := message.Contents["Shotness"]; exists {
+			shotness := pb.ShotnessAnalysisResults{}
+			err = proto.Unmarshal(contents, &shotness)
+			if err != nil {
+				panic(err)
+			}
+			index := make([]string, len(shotness.Records))
+			matrix := make([]map[int]int64, len(shotness.Records))
+			for i, record := range shotness.Records {
+				index[i] = record.File + ":" + record.Name
+				matrix[i] = map[int]int64{}
+				for key, val := range record.Counters {
+					matrix[i][int(key)] = int64(val)
+				}
+			}
+			embed("shotness", index, matrix)
+		}
+		if written == 0 {
+			fmt.Fprintln(os.Stderr, "Nothing to do: re-run hercules with --couples and/or --shotness.")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(projectorCmd)
+	projectorCmd.SetUsageFunc(projectorCmd.UsageFunc())
+	projectorCmd.Flags().StringP("output", "o", "couples",
+		"Prefix of the output files.")
+	projectorCmd.Flags().Int("dimensions", projector.DefaultDimensions,
+		"Size of the trained embeddings.")
+	projectorCmd.Flags().Int("iterations", projector.DefaultIterations,
+		"Number of the subspace iterations. Larger values improve the accuracy.")
+}
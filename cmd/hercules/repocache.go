@@ -0,0 +1,251 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/src-d/go-billy.v4/osfs"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/storage/filesystem"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+)
+
+// repositoryCache keeps the clones of the remote repositories on disk for "hercules serve --cache".
+// The forks and the mirrors of the same project share a single object storage - the family -
+// which is named after the root commit of the first cloned history. A URL joins the family
+// if it was seen before or if any of its advertised references already exists in the family's
+// storage; then only the missing objects are fetched instead of cloning from scratch.
+type repositoryCache struct {
+	// root is the directory with the families and the index.
+	root string
+	// lock guards index and families.
+	lock sync.Mutex
+	// index maps the URLs to the families.
+	index map[string]string
+	// families serialize the updates of the object storages.
+	families map[string]*sync.Mutex
+}
+
+// repositoryCacheIndex is the name of the file in repositoryCache.root which stores the index.
+const repositoryCacheIndex = "index.json"
+
+// newRepositoryCache opens the cache in the directory, creating it if needed.
+func newRepositoryCache(root string) (*repositoryCache, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, err
+	}
+	cache := &repositoryCache{
+		root: root, index: map[string]string{}, families: map[string]*sync.Mutex{}}
+	data, err := ioutil.ReadFile(filepath.Join(root, repositoryCacheIndex))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err = json.Unmarshal(data, &cache.index); err != nil {
+			return nil, fmt.Errorf("%s: %v", repositoryCacheIndex, err)
+		}
+	}
+	return cache, nil
+}
+
+// load returns the up to date repository together with the hash of the remote HEAD and
+// the family, which is the fingerprint of the history.
+func (cache *repositoryCache) load(uri string) (*git.Repository, plumbing.Hash, string, error) {
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: "origin", URLs: []string{uri}})
+	refs, err := remote.List(&git.ListOptions{})
+	if err != nil {
+		return nil, plumbing.ZeroHash, "", err
+	}
+	head := advertisedHead(refs)
+	if head == plumbing.ZeroHash {
+		return nil, plumbing.ZeroHash, "", fmt.Errorf("%s: HEAD is not advertised", uri)
+	}
+	family := cache.findFamily(uri, refs)
+	if family == "" {
+		if family, err = cache.clone(uri); err != nil {
+			return nil, plumbing.ZeroHash, "", err
+		}
+	} else {
+		log.Printf("%s belongs to %s", uri, family)
+		if err = cache.fetch(family, uri); err != nil {
+			return nil, plumbing.ZeroHash, "", err
+		}
+	}
+	repository, err := cache.open(family)
+	if err != nil {
+		return nil, plumbing.ZeroHash, "", err
+	}
+	if _, err = repository.CommitObject(head); err != nil {
+		return nil, plumbing.ZeroHash, "", fmt.Errorf("%s: HEAD %s: %v", uri, head, err)
+	}
+	return repository, head, family, nil
+}
+
+// advertisedHead returns the hash of HEAD in the list of the remote references.
+func advertisedHead(refs []*plumbing.Reference) plumbing.Hash {
+	hashes := map[plumbing.ReferenceName]plumbing.Hash{}
+	var target plumbing.ReferenceName
+	for _, ref := range refs {
+		if ref.Type() == plumbing.HashReference {
+			hashes[ref.Name()] = ref.Hash()
+		} else if ref.Name() == plumbing.HEAD {
+			target = ref.Target()
+		}
+	}
+	if hash, exists := hashes[plumbing.HEAD]; exists {
+		return hash
+	}
+	return hashes[target]
+}
+
+// findFamily returns the family of the URL or "" if the URL's history is not cached.
+func (cache *repositoryCache) findFamily(uri string, refs []*plumbing.Reference) string {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	if family, exists := cache.index[uri]; exists {
+		return family
+	}
+	dirs, err := filepath.Glob(filepath.Join(cache.root, "*"))
+	if err != nil {
+		return ""
+	}
+	for _, dir := range dirs {
+		family := filepath.Base(dir)
+		if len(family) != 40 {
+			// the index and the clones in progress
+			continue
+		}
+		repository, err := cache.open(family)
+		if err != nil {
+			continue
+		}
+		for _, ref := range refs {
+			if ref.Type() != plumbing.HashReference {
+				continue
+			}
+			if _, err = repository.CommitObject(ref.Hash()); err == nil {
+				cache.remember(uri, family)
+				return family
+			}
+		}
+	}
+	return ""
+}
+
+// clone fetches the whole repository and adds it to the family with the same root commit,
+// or creates the new family.
+func (cache *repositoryCache) clone(uri string) (string, error) {
+	tmp, err := ioutil.TempDir(cache.root, ".clone-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+	backend, err := filesystem.NewStorage(osfs.New(tmp))
+	if err != nil {
+		return "", err
+	}
+	repository, err := git.Clone(backend, nil, &git.CloneOptions{URL: uri})
+	if err != nil {
+		return "", err
+	}
+	head, err := repository.Head()
+	if err != nil {
+		return "", err
+	}
+	commit, err := repository.CommitObject(head.Hash())
+	if err != nil {
+		return "", err
+	}
+	for commit.NumParents() > 0 {
+		if commit, err = repository.CommitObject(commit.ParentHashes[0]); err != nil {
+			return "", err
+		}
+	}
+	family := commit.Hash.String()
+	familyLock := cache.familyLock(family)
+	familyLock.Lock()
+	_, err = os.Stat(filepath.Join(cache.root, family))
+	if os.IsNotExist(err) {
+		err = os.Rename(tmp, filepath.Join(cache.root, family))
+	}
+	familyLock.Unlock()
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(tmp); err == nil {
+		// a fork whose references diverged from the cached ones
+		log.Printf("%s belongs to %s", uri, family)
+		if err = cache.fetch(family, uri); err != nil {
+			return "", err
+		}
+	}
+	cache.lock.Lock()
+	cache.remember(uri, family)
+	cache.lock.Unlock()
+	return family, nil
+}
+
+// fetch downloads the missing objects of the URL to the family's storage.
+func (cache *repositoryCache) fetch(family string, uri string) error {
+	familyLock := cache.familyLock(family)
+	familyLock.Lock()
+	defer familyLock.Unlock()
+	repository, err := cache.open(family)
+	if err != nil {
+		return err
+	}
+	// every URL has its own namespace of the references
+	name := plumbing.ComputeHash(plumbing.BlobObject, []byte(uri)).String()
+	remote := git.NewRemote(repository.Storer, &config.RemoteConfig{
+		Name: name, URLs: []string{uri}, Fetch: []config.RefSpec{
+			config.RefSpec(fmt.Sprintf("+refs/heads/*:refs/remotes/%s/*", name))}})
+	err = remote.Fetch(&git.FetchOptions{})
+	if err == git.NoErrAlreadyUpToDate {
+		err = nil
+	}
+	return err
+}
+
+func (cache *repositoryCache) open(family string) (*git.Repository, error) {
+	dir := filepath.Join(cache.root, family)
+	backend, err := filesystem.NewStorage(osfs.New(dir))
+	if err != nil {
+		return nil, err
+	}
+	return git.Open(backend, nil)
+}
+
+func (cache *repositoryCache) familyLock(family string) *sync.Mutex {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	familyLock := cache.families[family]
+	if familyLock == nil {
+		familyLock = &sync.Mutex{}
+		cache.families[family] = familyLock
+	}
+	return familyLock
+}
+
+// remember adds the URL to the index and saves it. cache.lock must be held.
+func (cache *repositoryCache) remember(uri string, family string) {
+	cache.index[uri] = family
+	path := filepath.Join(cache.root, repositoryCacheIndex)
+	data, err := json.MarshalIndent(cache.index, "", "  ")
+	if err == nil {
+		err = ioutil.WriteFile(path+".tmp", data, 0644)
+	}
+	if err == nil {
+		err = os.Rename(path+".tmp", path)
+	}
+	if err != nil {
+		log.Printf("Failed to save the repository cache index: %v", err)
+	}
+}
@@ -0,0 +1,410 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+	"gopkg.in/src-d/go-git.v4/storage"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+)
+
+// reproduceCmd represents the reproduce command
+var reproduceCmd = &cobra.Command{
+	Use:   "reproduce <repository> <range> <output>",
+	Short: "Extract the anonymized repository which reproduces a failure.",
+	Long: `Rewrite the first-parent commits in the range ("<from>..<to>" or "<to>") to a synthetic
+repository: the file and directory names are hashed except for the extensions, the files are
+truncated to --max-lines lines, the authors are renamed and the commit messages are replaced.
+Then run the analyses which are enabled with the same flags as in "hercules run" and drop
+the commits from both ends of the range while the failure persists. The resulting bare
+repository is written to <output> and can be attached to a bug report.`,
+	Args: cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := cmd.Flags()
+		format := outputFormat(flags)
+		maxLines, _ := flags.GetInt("max-lines")
+		scramble, _ := flags.GetBool("scramble")
+		keepMessages, _ := flags.GetBool("keep-messages")
+		analyses := enabledAnalyses(flags)
+		if len(analyses) == 0 {
+			fmt.Fprintln(os.Stderr, "No analyses are enabled, nothing can fail.")
+			os.Exit(1)
+		}
+		repository := loadRepository(args[0], "", true)
+		commits, err := resolveCommitRange(repository, args[1])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if _, err = os.Stat(args[2]); err == nil {
+			fmt.Fprintf(os.Stderr, "%s already exists.\n", args[2])
+			os.Exit(1)
+		}
+		rewrite := func(backend storage.Storer, commits []*object.Commit) error {
+			writer := newReproducerWriter(repository, backend)
+			writer.MaxLines = maxLines
+			writer.Scramble = scramble
+			writer.KeepMessages = keepMessages
+			head, err := writer.writeHistory(commits)
+			if err != nil {
+				return err
+			}
+			return backend.SetReference(plumbing.NewHashReference(plumbing.Master, head))
+		}
+		fails := func(commits []*object.Commit) string {
+			backend := memory.NewStorage()
+			synthetic, err := git.Init(backend, nil)
+			if err != nil {
+				panic(err)
+			}
+			if err = rewrite(backend, commits); err != nil {
+				panic(err)
+			}
+			job := analysisJob{
+				Repository: synthetic,
+				URI:        "reproducer",
+				Analyses:   analyses,
+				Facts:      cmdlineFacts,
+				Format:     format,
+			}
+			return job.fails(ioutil.Discard)
+		}
+		fmt.Fprintf(os.Stderr, "Trying %d commits...\n", len(commits))
+		failure := fails(commits)
+		if failure == "" {
+			fmt.Fprintln(os.Stderr, "The failure was not reproduced on the rewritten commits; "+
+				"try to increase --max-lines or to disable --scramble. Writing all the commits.")
+		} else {
+			fmt.Fprintf(os.Stderr, "Reproduced: %s\nMinimizing...\n", failure)
+			commits = minimizeCommits(commits, func(commits []*object.Commit) bool {
+				return fails(commits) != ""
+			})
+			fmt.Fprintf(os.Stderr, "The failure is reproduced with %d commits.\n", len(commits))
+		}
+		synthetic, err := git.PlainInit(args[2], true)
+		if err != nil {
+			panic(err)
+		}
+		if err = rewrite(synthetic.Storer, commits); err != nil {
+			panic(err)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %s\n", args[2])
+	},
+}
+
+// fails runs the job and returns the text of the panic or the error; it is empty if the job
+// succeeds.
+func (job analysisJob) fails(writer io.Writer) (failure string) {
+	defer func() {
+		if r := recover(); r != nil {
+			failure = fmt.Sprint(r)
+		}
+	}()
+	job.run(writer)
+	return ""
+}
+
+// resolveCommitRange returns the first-parent commits from the oldest to the newest.
+// The range is either "<from>..<to>", where <from> is excluded, or "<to>" which includes all
+// the first-parent ancestors.
+func resolveCommitRange(repository *git.Repository, commitRange string) ([]*object.Commit, error) {
+	from, to := "", commitRange
+	if parts := strings.SplitN(commitRange, "..", 2); len(parts) == 2 {
+		from, to = parts[0], parts[1]
+	}
+	resolve := func(rev string) (*object.Commit, error) {
+		hash, err := repository.ResolveRevision(plumbing.Revision(rev))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", rev, err)
+		}
+		return repository.CommitObject(*hash)
+	}
+	commit, err := resolve(to)
+	if err != nil {
+		return nil, err
+	}
+	stop := plumbing.ZeroHash
+	if from != "" {
+		fromCommit, err := resolve(from)
+		if err != nil {
+			return nil, err
+		}
+		stop = fromCommit.Hash
+	}
+	var commits []*object.Commit
+	for commit.Hash != stop {
+		commits = append(commits, commit)
+		if commit.NumParents() == 0 {
+			if stop != plumbing.ZeroHash {
+				return nil, fmt.Errorf("%s is not a first-parent ancestor of %s", from, to)
+			}
+			break
+		}
+		if commit, err = repository.CommitObject(commit.ParentHashes[0]); err != nil {
+			return nil, err
+		}
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("%s is empty", commitRange)
+	}
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+	return commits, nil
+}
+
+// minimizeCommits drops the commits from the end and then from the beginning of the sequence
+// while fails() holds. It is a binary search which assumes that the failure persists
+// in all the longer sequences.
+func minimizeCommits(commits []*object.Commit, fails func([]*object.Commit) bool) []*object.Commit {
+	// the shortest failing prefix
+	lo, hi := 1, len(commits)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if fails(commits[:mid]) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	commits = commits[:hi]
+	// the shortest failing suffix of that prefix
+	lo, hi = 0, len(commits)-1
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if fails(commits[mid:]) {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return commits[lo:]
+}
+
+// reproducerWriter rewrites the commits of the source repository to the anonymized ones
+// in the destination storage.
+type reproducerWriter struct {
+	// MaxLines is the maximum number of lines in each file; 0 means no limit.
+	MaxLines int
+	// Scramble replaces each line with its hash, so that the diffs stay the same.
+	Scramble bool
+	// KeepMessages disables replacing the commit messages.
+	KeepMessages bool
+
+	source  *git.Repository
+	storage storer.EncodedObjectStorer
+	// blobs and trees map the source hashes to the rewritten ones.
+	blobs map[plumbing.Hash]plumbing.Hash
+	trees map[plumbing.Hash]plumbing.Hash
+	// authors map the lower case emails to the anonymous indices.
+	authors map[string]int
+}
+
+func newReproducerWriter(
+	source *git.Repository, storage storer.EncodedObjectStorer) *reproducerWriter {
+	return &reproducerWriter{
+		source:  source,
+		storage: storage,
+		blobs:   map[plumbing.Hash]plumbing.Hash{},
+		trees:   map[plumbing.Hash]plumbing.Hash{},
+		authors: map[string]int{},
+	}
+}
+
+// writeHistory writes the linear history of the rewritten commits and returns the hash
+// of the last one.
+func (writer *reproducerWriter) writeHistory(commits []*object.Commit) (plumbing.Hash, error) {
+	parent := plumbing.ZeroHash
+	for i, commit := range commits {
+		tree, err := writer.writeTree(commit.TreeHash)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		rewritten := &object.Commit{
+			Author:    writer.anonymizeSignature(commit.Author),
+			Committer: writer.anonymizeSignature(commit.Committer),
+			Message:   fmt.Sprintf("Commit %d\n", i+1),
+			TreeHash:  tree,
+		}
+		if writer.KeepMessages {
+			rewritten.Message = commit.Message
+		}
+		if parent != plumbing.ZeroHash {
+			rewritten.ParentHashes = []plumbing.Hash{parent}
+		}
+		if parent, err = writer.writeObject(rewritten); err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+	return parent, nil
+}
+
+func (writer *reproducerWriter) anonymizeSignature(signature object.Signature) object.Signature {
+	email := strings.ToLower(signature.Email)
+	index, exists := writer.authors[email]
+	if !exists {
+		index = len(writer.authors) + 1
+		writer.authors[email] = index
+	}
+	return object.Signature{
+		Name:  fmt.Sprintf("Developer %d", index),
+		Email: fmt.Sprintf("developer%d@example.com", index),
+		When:  signature.When,
+	}
+}
+
+func (writer *reproducerWriter) writeTree(hash plumbing.Hash) (plumbing.Hash, error) {
+	if rewritten, exists := writer.trees[hash]; exists {
+		return rewritten, nil
+	}
+	tree, err := writer.source.TreeObject(hash)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	rewritten := &object.Tree{}
+	for _, entry := range tree.Entries {
+		newEntry := object.TreeEntry{Name: anonymizeFileName(entry.Name), Mode: entry.Mode}
+		switch entry.Mode {
+		case filemode.Dir:
+			newEntry.Name = anonymizeDirectoryName(entry.Name)
+			newEntry.Hash, err = writer.writeTree(entry.Hash)
+		case filemode.Submodule:
+			newEntry.Hash = entry.Hash
+		default:
+			newEntry.Hash, err = writer.writeBlob(entry.Hash)
+		}
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		rewritten.Entries = append(rewritten.Entries, newEntry)
+	}
+	// Git compares the directory names as if they ended with a slash
+	sortKey := func(entry object.TreeEntry) string {
+		if entry.Mode == filemode.Dir {
+			return entry.Name + "/"
+		}
+		return entry.Name
+	}
+	sort.Slice(rewritten.Entries, func(i, j int) bool {
+		return sortKey(rewritten.Entries[i]) < sortKey(rewritten.Entries[j])
+	})
+	result, err := writer.writeObject(rewritten)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	writer.trees[hash] = result
+	return result, nil
+}
+
+func (writer *reproducerWriter) writeBlob(hash plumbing.Hash) (plumbing.Hash, error) {
+	if rewritten, exists := writer.blobs[hash]; exists {
+		return rewritten, nil
+	}
+	blob, err := writer.source.BlobObject(hash)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	reader, err := blob.Reader()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	defer reader.Close()
+	buffer := &bytes.Buffer{}
+	scanner := bufio.NewReader(reader)
+	for lines := 0; writer.MaxLines <= 0 || lines < writer.MaxLines; lines++ {
+		line, err := scanner.ReadString('\n')
+		if line != "" {
+			if writer.Scramble {
+				line = scrambleLine(line)
+			}
+			buffer.WriteString(line)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+	obj := writer.storage.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+	obj.SetSize(int64(buffer.Len()))
+	objWriter, err := obj.Writer()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if _, err = objWriter.Write(buffer.Bytes()); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if err = objWriter.Close(); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	result, err := writer.storage.SetEncodedObject(obj)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	writer.blobs[hash] = result
+	return result, nil
+}
+
+// encodableObject is either *object.Tree or *object.Commit.
+type encodableObject interface {
+	Encode(plumbing.EncodedObject) error
+}
+
+// writeObject encodes the tree or the commit to the storage.
+func (writer *reproducerWriter) writeObject(encodable encodableObject) (plumbing.Hash, error) {
+	obj := writer.storage.NewEncodedObject()
+	if err := encodable.Encode(obj); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return writer.storage.SetEncodedObject(obj)
+}
+
+// anonymizeFileName hashes the file name but keeps the extension, so that the languages
+// are still detected.
+func anonymizeFileName(name string) string {
+	return anonymizeDirectoryName(name) + path.Ext(name)
+}
+
+func anonymizeDirectoryName(name string) string {
+	hash := sha1.Sum([]byte(name))
+	return hex.EncodeToString(hash[:])[:12]
+}
+
+// scrambleLine replaces the contents of the line with the hash but keeps the indentation
+// and the line ending. The equal lines remain equal.
+func scrambleLine(line string) string {
+	body := strings.TrimRight(line, "\r\n")
+	ending := line[len(body):]
+	text := strings.TrimLeft(body, " \t")
+	if text == "" {
+		return line
+	}
+	hash := sha1.Sum([]byte(text))
+	return body[:len(body)-len(text)] + hex.EncodeToString(hash[:])[:16] + ending
+}
+
+func init() {
+	rootCmd.AddCommand(reproduceCmd)
+	reproduceCmd.Flags().Int("max-lines", 100, "Truncate the files to this number of lines; "+
+		"0 keeps the whole files.")
+	reproduceCmd.Flags().Bool("scramble", false, "Replace each line with its hash; the diffs "+
+		"stay the same but the UAST-based analyses no longer work.")
+	reproduceCmd.Flags().Bool("keep-messages", false, "Do not replace the commit messages.")
+}
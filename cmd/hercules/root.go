@@ -2,15 +2,14 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
-	"net/http"
 	_ "net/http/pprof"
 	"os"
 	"plugin"
-	"runtime/pprof"
 	"strings"
 	_ "unsafe" // for go:linkname
 
@@ -18,15 +17,14 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"golang.org/x/crypto/ssh/terminal"
-	progress "gopkg.in/cheggaaa/pb.v1"
 	"gopkg.in/src-d/go-billy.v4/osfs"
 	"gopkg.in/src-d/go-git.v4"
-	"gopkg.in/src-d/go-git.v4/plumbing/object"
 	"gopkg.in/src-d/go-git.v4/storage"
 	"gopkg.in/src-d/go-git.v4/storage/filesystem"
 	"gopkg.in/src-d/go-git.v4/storage/memory"
 	"gopkg.in/src-d/hercules.v4"
 	"gopkg.in/src-d/hercules.v4/internal/pb"
+	"gopkg.in/src-d/hercules.v4/yaml"
 )
 
 // oneLineWriter splits the output data by lines and outputs one on top of another using '\r'.
@@ -115,6 +113,8 @@ func loadPlugins() {
 		"Can be specified multiple times."
 	fs.Var(&pluginFlags, pluginFlagName, pluginDesc)
 	pflag.Var(&pluginFlags, pluginFlagName, pluginDesc)
+	// rootCmd.MarkFlagFilename() does not see the global flags yet
+	pflag.CommandLine.SetAnnotation(pluginFlagName, cobra.BashCompFilenameExt, []string{"so"})
 	fs.Parse(os.Args[1:])
 	for path := range pluginFlags {
 		_, err := plugin.Open(path)
@@ -128,153 +128,213 @@ func loadPlugins() {
 var rootCmd = &cobra.Command{
 	Use:   "hercules",
 	Short: "Analyse a Git repository.",
-	Long: `Hercules is a flexible and fast Git repository analysis engine. The base command executes
+	Long: `Hercules is a flexible and fast Git repository analysis engine. "hercules run" executes
 the commit processing pipeline which is automatically generated from the dependencies of one
-or several analysis targets. The list of the available targets is printed in --help. External
-targets can be added using the --plugin system.`,
+or several analysis targets. "hercules ls" prints the available targets and their options.
+External targets can be added using the --plugin system. The other commands post-process
+the results. Running the analyses without "run" is deprecated.`,
 	Args: cobra.RangeArgs(1, 2),
 	Run: func(cmd *cobra.Command, args []string) {
-		flags := cmd.Flags()
-		commitsFile, _ := flags.GetString("commits")
-		protobuf, _ := flags.GetBool("pb")
-		profile, _ := flags.GetBool("profile")
-		disableStatus, _ := flags.GetBool("quiet")
-
-		if profile {
-			go http.ListenAndServe("localhost:6060", nil)
-			prof, _ := os.Create("hercules.pprof")
-			pprof.StartCPUProfile(prof)
-			defer pprof.StopCPUProfile()
-		}
-		uri := args[0]
-		cachePath := ""
-		if len(args) == 2 {
-			cachePath = args[1]
-		}
-		repository := loadRepository(uri, cachePath, disableStatus)
+		fmt.Fprintln(os.Stderr, "Warning: \"hercules <repository>\" is deprecated, "+
+			"use \"hercules run <repository>\"")
+		runCmd.Run(cmd, args)
+	},
+}
 
-		// core logic
-		pipeline := hercules.NewPipeline(repository)
-		pipeline.SetFeaturesFromFlags()
-		var bar *progress.ProgressBar
-		if !disableStatus {
-			pipeline.OnProgress = func(commit, length int) {
-				if bar == nil {
-					bar = progress.New(length)
-					bar.Callback = func(msg string) {
-						os.Stderr.WriteString("\r" + msg)
-					}
-					bar.NotPrint = true
-					bar.ShowPercent = false
-					bar.ShowSpeed = false
-					bar.SetMaxWidth(80)
-					bar.Start()
-				}
-				if commit == length {
-					bar.Finish()
-					fmt.Fprint(os.Stderr, "\r"+strings.Repeat(" ", 80)+"\rfinalizing...")
-				} else {
-					bar.Set(commit)
-				}
-			}
-		}
+// The output formats of the analysis results.
+const (
+	formatYAML     = "yaml"
+	formatProtobuf = "pb"
+	formatJSON     = "json"
+)
 
-		var commits []*object.Commit
-		if commitsFile == "" {
-			// list of commits belonging to the default branch, from oldest to newest
-			// rev-list --first-parent
-			commits = pipeline.Commits()
-		} else {
-			var err error
-			commits, err = hercules.LoadCommitsFromFile(commitsFile, repository)
-			if err != nil {
-				panic(err)
-			}
-		}
-		cmdlineFacts["commits"] = commits
-		deployed := []hercules.LeafPipelineItem{}
-		for name, valPtr := range cmdlineDeployed {
-			if *valPtr {
-				item := pipeline.DeployItem(hercules.Registry.Summon(name)[0])
-				deployed = append(deployed, item.(hercules.LeafPipelineItem))
-			}
-		}
-		pipeline.Initialize(cmdlineFacts)
-		if dryRun, _ := cmdlineFacts[hercules.ConfigPipelineDryRun].(bool); dryRun {
-			return
-		}
-		results, err := pipeline.Run(commits)
-		if err != nil {
-			panic(err)
-		}
-		if !disableStatus {
-			fmt.Fprint(os.Stderr, "\r"+strings.Repeat(" ", 80)+"\r")
-			// if not a terminal, the user will not see the output, so show the status
-			if !terminal.IsTerminal(int(os.Stdout.Fd())) {
-				fmt.Fprint(os.Stderr, "writing...\r")
-			}
-		}
-		if !protobuf {
-			printResults(uri, deployed, results)
-		} else {
-			protobufResults(uri, deployed, results)
-		}
-	},
+// outputFormat returns the output format which is selected with --pb or --json.
+// It exits if both are set.
+func outputFormat(flags *pflag.FlagSet) string {
+	protobuf, _ := flags.GetBool("pb")
+	jsonFormat, _ := flags.GetBool("json")
+	switch {
+	case protobuf && jsonFormat:
+		fmt.Fprintln(os.Stderr, "--pb and --json are mutually exclusive")
+		os.Exit(1)
+	case protobuf:
+		return formatProtobuf
+	case jsonFormat:
+		return formatJSON
+	}
+	return formatYAML
+}
+
+// writeResults writes the results in the specified output format.
+func writeResults(
+	format string, uri string, deployed []hercules.LeafPipelineItem,
+	results map[hercules.LeafPipelineItem]interface{}, writer io.Writer) {
+	switch format {
+	case formatProtobuf:
+		protobufResults(uri, deployed, results, writer)
+	case formatJSON:
+		jsonResults(uri, deployed, results, writer)
+	default:
+		printResults(uri, deployed, results, writer)
+	}
 }
 
 func printResults(
 	uri string, deployed []hercules.LeafPipelineItem,
-	results map[hercules.LeafPipelineItem]interface{}) {
+	results map[hercules.LeafPipelineItem]interface{}, writer io.Writer) {
 	commonResult := results[nil].(*hercules.CommonAnalysisResult)
 
-	fmt.Println("hercules:")
-	fmt.Println("  version: 3")
-	fmt.Println("  hash:", hercules.BinaryGitHash)
-	fmt.Println("  repository:", uri)
-	fmt.Println("  begin_unix_time:", commonResult.BeginTime)
-	fmt.Println("  end_unix_time:", commonResult.EndTime)
-	fmt.Println("  commits:", commonResult.CommitsNumber)
-	fmt.Println("  run_time:", commonResult.RunTime.Nanoseconds()/1e6)
+	fmt.Fprintln(writer, "hercules:")
+	fmt.Fprintln(writer, "  version:", yaml.SchemaVersion)
+	fmt.Fprintln(writer, "  hash:", yaml.SafeString(hercules.BinaryGitHash))
+	fmt.Fprintln(writer, "  repository:", yaml.SafeString(uri))
+	fmt.Fprintln(writer, "  begin_unix_time:", commonResult.BeginTime)
+	fmt.Fprintln(writer, "  end_unix_time:", commonResult.EndTime)
+	fmt.Fprintln(writer, "  commits:", commonResult.CommitsNumber)
+	fmt.Fprintln(writer, "  run_time:", commonResult.RunTime.Nanoseconds()/1e6)
+	if commonResult.Partial {
+		fmt.Fprintln(writer, "  partial: true")
+	}
+	if len(commonResult.Failures) > 0 {
+		fmt.Fprintln(writer, "  failures:")
+		for _, failure := range commonResult.Failures {
+			fmt.Fprintln(writer, "    - commit:", yaml.SafeString(failure.Commit.String()))
+			fmt.Fprintln(writer, "      index:", failure.Index)
+			fmt.Fprintln(writer, "      item:", yaml.SafeString(failure.Item))
+			fmt.Fprintln(writer, "      error:", yaml.SafeString(failure.Error))
+		}
+	}
+	if len(commonResult.Skipped) > 0 {
+		fmt.Fprintln(writer, "  skipped:")
+		for _, skipped := range commonResult.Skipped {
+			fmt.Fprintln(writer, "    - item:", yaml.SafeString(skipped.Item))
+			fmt.Fprintln(writer, "      reason:", yaml.SafeString(skipped.Reason))
+		}
+	}
+	if len(commonResult.Markers) > 0 {
+		fmt.Fprintln(writer, "  markers:")
+		for _, marker := range commonResult.Markers {
+			fmt.Fprintln(writer, "    - label:", yaml.SafeString(marker.Label))
+			fmt.Fprintln(writer, "      unix_time:", marker.Time)
+			if !marker.Commit.IsZero() {
+				fmt.Fprintln(writer, "      commit:", yaml.SafeString(marker.Commit.String()))
+			}
+		}
+	}
 
-	for _, item := range deployed {
-		result := results[item]
-		fmt.Printf("%s:\n", item.Name())
-		if err := item.Serialize(result, false, os.Stdout); err != nil {
+	// the sections are buffered to write their checksums in the header
+	sections := make([][]byte, len(deployed))
+	for i, item := range deployed {
+		buffer := &bytes.Buffer{}
+		if err := item.Serialize(results[item], false, buffer); err != nil {
 			panic(err)
 		}
+		sections[i] = buffer.Bytes()
+	}
+	fmt.Fprintf(writer, "  %s:\n", yaml.ChecksumsKey)
+	for i, item := range deployed {
+		fmt.Fprintf(writer, "    %s: %s\n", item.Name(), yaml.Checksum(sections[i]))
+	}
+	for i, item := range deployed {
+		fmt.Fprintf(writer, "%s:\n", item.Name())
+		writer.Write(sections[i])
 	}
 }
 
 func protobufResults(
 	uri string, deployed []hercules.LeafPipelineItem,
-	results map[hercules.LeafPipelineItem]interface{}) {
+	results map[hercules.LeafPipelineItem]interface{}, writer io.Writer) {
 
-	header := pb.Metadata{
-		Version:    2,
-		Hash:       hercules.BinaryGitHash,
-		Repository: uri,
+	header := resultsMetadata(uri, results)
+	container, err := pb.NewContainerWriter(writer, header)
+	if err != nil {
+		panic(err)
 	}
-	results[nil].(*hercules.CommonAnalysisResult).FillMetadata(&header)
-
-	message := pb.AnalysisResults{
-		Header:   &header,
-		Contents: map[string][]byte{},
+	for _, item := range deployed {
+		if err := writeResultChunk(container, item, results[item]); err != nil {
+			panic(err)
+		}
 	}
+}
 
+// jsonResults writes the results as a single JSON object, the same as "hercules convert" writes:
+// "header" is the metadata and the rest of the keys are the names of the analyses.
+func jsonResults(
+	uri string, deployed []hercules.LeafPipelineItem,
+	results map[hercules.LeafPipelineItem]interface{}, writer io.Writer) {
+
+	header, err := json.Marshal(resultsMetadata(uri, results))
+	if err != nil {
+		panic(err)
+	}
+	document := map[string]json.RawMessage{"header": header}
 	for _, item := range deployed {
-		result := results[item]
 		buffer := &bytes.Buffer{}
-		if err := item.Serialize(result, true, buffer); err != nil {
+		if err := item.SerializeJSON(results[item], buffer); err != nil {
 			panic(err)
 		}
-		message.Contents[item.Name()] = buffer.Bytes()
+		name := item.Name()
+		if _, ok := hercules.Unwrap(item).(hercules.ExtensionPipelineItem); ok {
+			name = item.(hercules.ExtensionPipelineItem).ExtensionName()
+		}
+		document[name] = buffer.Bytes()
+	}
+	if err := writeJSON(document, writer); err != nil {
+		panic(err)
+	}
+}
+
+// resultsMetadata creates the header of the binary and JSON results.
+func resultsMetadata(
+	uri string, results map[hercules.LeafPipelineItem]interface{}) *pb.Metadata {
+	header := &pb.Metadata{
+		Version:    2,
+		Hash:       hercules.BinaryGitHash,
+		Repository: uri,
 	}
+	results[nil].(*hercules.CommonAnalysisResult).FillMetadata(header)
+	return header
+}
 
-	serialized, err := proto.Marshal(&message)
+// resultsDayRange is the number of days in each chunk of the time series results
+// in the binary output.
+const resultsDayRange = 365
+
+// writeResultChunk serializes the result of the item and appends it to the container.
+// The results of the DayRangePipelineItem-s are split into the chunks of resultsDayRange days.
+// The results of the ExtensionPipelineItem-s are wrapped in the namespaced extensions.
+func writeResultChunk(
+	container *pb.ContainerWriter, item hercules.LeafPipelineItem, result interface{}) error {
+	if _, ok := hercules.Unwrap(item).(hercules.DayRangePipelineItem); ok {
+		parts, err := item.(hercules.DayRangePipelineItem).SerializeDayRanges(result, resultsDayRange)
+		if err != nil {
+			return err
+		}
+		for _, part := range parts {
+			name := pb.DayRangeChunkName(item.Name(), part.From, part.To)
+			if err = container.WriteChunk(name, part.Data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	buffer := &bytes.Buffer{}
+	if err := item.Serialize(result, true, buffer); err != nil {
+		return err
+	}
+	data := buffer.Bytes()
+	if _, ok := hercules.Unwrap(item).(hercules.ExtensionPipelineItem); !ok {
+		return container.WriteChunk(item.Name(), data)
+	}
+	epi := item.(hercules.ExtensionPipelineItem)
+	extension, err := pb.PackExtension(epi.ExtensionName(), data)
 	if err != nil {
-		panic(err)
+		return err
+	}
+	if data, err = proto.Marshal(extension); err != nil {
+		return err
 	}
-	os.Stdout.Write(serialized)
+	return container.WriteChunk(epi.ExtensionName(), data)
 }
 
 // animate the private function defined in Cobra
@@ -376,14 +436,54 @@ func init() {
 	rootFlags.String("commits", "", "Path to the text file with the "+
 		"commit history to follow instead of the default rev-list "+
 		"--first-parent. The format is the list of hashes, each hash on a "+
-		"separate line. The first hash is the root.")
+		"separate line. The first hash is the root. \"-\" reads stdin.")
 	rootCmd.MarkFlagFilename("commits")
+	rootFlags.String("exclude-commits", "", "Path to the text file with the hashes of the "+
+		"commits to skip, in the same format as --commits. Their changes are attributed to "+
+		"the next analysed commit.")
+	rootCmd.MarkFlagFilename("exclude-commits")
+	rootFlags.String("history-cache", "", "Path to the file which caches the first-parent "+
+		"history between the runs on the same repository. It is invalidated when the packs "+
+		"change.")
+	rootCmd.MarkFlagFilename("history-cache")
+	rootFlags.String("state", "", "Path to the file with the saved state of the analysis. If it "+
+		"exists, only the commits after the last analysed one are consumed and the results "+
+		"cover the whole history. The state is updated after each run.")
+	rootCmd.MarkFlagFilename("state")
+	rootFlags.String("filter", "", "Analyse only the commits which match the expression, e.g. "+
+		"'author.email =~ \"@corp.com\" && files < 500 && !message.contains(\"vendor\")'.")
 	rootFlags.Bool("pb", false, "The output format will be Protocol Buffers instead of YAML.")
+	rootFlags.Bool("json", false, "The output format will be JSON instead of YAML. The objects "+
+		"have the same fields as the Protocol Buffers messages.")
+	rootFlags.StringP("output", "o", "", "Write the results to the file instead of stdout. "+
+		"The file is compressed with gzip or zstd if the name ends with .gz or .zst respectively; "+
+		"zstd requires the build with the \"zstd\" tag.")
+	rootCmd.MarkFlagFilename("output")
+	rootFlags.String("scope", "", "Path to the YAML file which limits the analyses to the "+
+		"specified path globs, e.g. \"Burndown: [src/**]\".")
+	rootCmd.MarkFlagFilename("scope", "yaml", "yml")
 	rootFlags.Bool("quiet", !terminal.IsTerminal(int(os.Stdin.Fd())),
 		"Do not print status updates to stderr.")
+	rootFlags.String("progress", "bar", "The format of the status updates in stderr: \"bar\" "+
+		"or \"json\" - one JSON object per processed commit.")
+	rootFlags.String("preset", "", "Enable the curated set of analyses with their options; "+
+		"the explicit flags take precedence. Available presets: "+presetsHelp()+".")
 	rootFlags.Bool("profile", false, "Collect the profile to hercules.pprof.")
 	cmdlineFacts, cmdlineDeployed = hercules.Registry.AddFlags(rootFlags)
 	rootCmd.SetUsageFunc(formatUsage)
+	// "run" shares the flags with the deprecated root command
+	runCmd.Flags().AddFlagSet(rootFlags)
+	runCmd.SetUsageFunc(formatUsage)
+	// "reproduce" runs the same analyses
+	reproduceCmd.Flags().AddFlagSet(rootFlags)
+	reproduceCmd.SetUsageFunc(formatUsage)
+	// "batch" runs the same analyses on every repository
+	batchCmd.Flags().AddFlagSet(rootFlags)
+	batchCmd.SetUsageFunc(formatUsage)
+	// "impact" runs the ownership and the couples analyses with the same options
+	impactCmd.Flags().AddFlagSet(rootFlags)
+	impactCmd.SetUsageFunc(formatUsage)
+	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(versionCmd)
 	versionCmd.SetUsageFunc(versionCmd.UsageFunc())
 }
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime/pprof"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/crypto/ssh/terminal"
+	progress "gopkg.in/cheggaaa/pb.v1"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4"
+	"gopkg.in/src-d/hercules.v4/leaves"
+)
+
+// runCmd represents the run command
+var runCmd = &cobra.Command{
+	Use:   "run <repository> [cache]",
+	Short: "Run the analyses on a Git repository.",
+	Long: `Execute the commit processing pipeline which is automatically generated from the dependencies
+of one or several analysis targets. The repository is either a local path or a URL to clone;
+the optional cache is the directory to clone to. The list of the available targets and their
+options is printed in --help and by "hercules ls".`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := cmd.Flags()
+		commitsFile, _ := flags.GetString("commits")
+		historyCache, _ := flags.GetString("history-cache")
+		excludeFile, _ := flags.GetString("exclude-commits")
+		stateFile, _ := flags.GetString("state")
+		format := outputFormat(flags)
+		profile, _ := flags.GetBool("profile")
+		disableStatus, _ := flags.GetBool("quiet")
+		outputFile, _ := flags.GetString("output")
+		scopeFile, _ := flags.GetString("scope")
+		filterExpression, _ := flags.GetString("filter")
+		progressFormat, _ := flags.GetString("progress")
+		analyses := enabledAnalyses(flags)
+		if progressFormat != "bar" && progressFormat != "json" {
+			fmt.Fprintf(os.Stderr, "Unknown progress format: %s\n", progressFormat)
+			os.Exit(1)
+		}
+
+		if profile {
+			go http.ListenAndServe("localhost:6060", nil)
+			prof, _ := os.Create("hercules.pprof")
+			pprof.StartCPUProfile(prof)
+			defer pprof.StopCPUProfile()
+		}
+		uri := args[0]
+		cachePath := ""
+		if len(args) == 2 {
+			cachePath = args[1]
+		}
+		var scopes map[string][]string
+		if scopeFile != "" {
+			var err error
+			scopes, err = loadScopes(scopeFile)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+		}
+		var filter *hercules.CommitFilter
+		if filterExpression != "" {
+			var err error
+			filter, err = hercules.ParseCommitFilter(filterExpression)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --filter: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		repository := loadRepository(uri, cachePath, disableStatus)
+		// open the output before the analysis to fail fast
+		output, err := createOutput(outputFile)
+		if err != nil {
+			panic(err)
+		}
+		defer func() {
+			if err := output.Close(); err != nil {
+				panic(err)
+			}
+		}()
+		job := analysisJob{
+			Repository:   repository,
+			URI:          uri,
+			Analyses:     analyses,
+			Facts:        cmdlineFacts,
+			Scopes:       scopes,
+			CommitsFile:  commitsFile,
+			HistoryCache: historyCache,
+			ExcludeFile:  excludeFile,
+			StateFile:    stateFile,
+			Filter:       filter,
+			Format:       format,
+			ShowProgress: !disableStatus && progressFormat == "bar",
+			ProgressJSON: progressFormat == "json",
+			ShowWriting:  outputFile != "" || !terminal.IsTerminal(int(os.Stdout.Fd())),
+		}
+		job.run(output)
+	},
+}
+
+// enabledAnalyses applies --preset and returns the names of the analyses which are enabled
+// in the command line. It exits if the preset is unknown.
+func enabledAnalyses(flags *pflag.FlagSet) []string {
+	if preset, _ := flags.GetString("preset"); preset != "" {
+		if err := applyPreset(flags, preset); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	analyses := []string{}
+	for name, valPtr := range cmdlineDeployed {
+		if *valPtr {
+			analyses = append(analyses, name)
+		}
+	}
+	return analyses
+}
+
+// analysisJob is a single execution of the analysis pipeline.
+type analysisJob struct {
+	// Repository is the analysed Git repository.
+	Repository *git.Repository
+	// URI is the repository's path or URL which is written to the results.
+	URI string
+	// Analyses are the names of the leaves to deploy.
+	Analyses []string
+	// Facts configure the pipeline items.
+	Facts map[string]interface{}
+	// Scopes map the names of the analyses to the path globs which limit them.
+	Scopes map[string][]string
+	// Features are enabled in the pipeline. If nil, they are taken from --feature.
+	Features []string
+	// CommitsFile is the optional path to the list of commits to analyse.
+	CommitsFile string
+	// Commits replace the first-parent history of HEAD if CommitsFile is empty.
+	Commits []*object.Commit
+	// HistoryCache is the optional path to the file which caches the first-parent history.
+	HistoryCache string
+	// ExcludeFile is the optional path to the list of commits to skip.
+	ExcludeFile string
+	// StateFile is the optional path to the saved pipeline state to resume from and to update.
+	StateFile string
+	// Filter is the optional expression which selects the commits to analyse.
+	Filter *hercules.CommitFilter
+	// Format is the output format: formatYAML (the default if empty), formatProtobuf
+	// or formatJSON.
+	Format string
+	// ShowProgress enables the progress bar in stderr.
+	ShowProgress bool
+	// ProgressJSON enables writing a JSON object per processed commit to stderr.
+	ProgressJSON bool
+	// ShowWriting enables the final status message in stderr.
+	ShowWriting bool
+}
+
+// jsonProgress is written by --progress=json. The durations are in seconds.
+type jsonProgress struct {
+	Hash    string             `json:"hash"`
+	Index   int                `json:"index"`
+	Total   int                `json:"total"`
+	Elapsed float64            `json:"elapsed"`
+	Items   map[string]float64 `json:"items"`
+}
+
+// run executes the pipeline and writes the results. It panics on errors.
+func (job analysisJob) run(writer io.Writer) {
+	pipeline, deployed, results := job.execute()
+	if results == nil {
+		return
+	}
+	writeResults(job.Format, job.URI, deployed, results, writer)
+	if job.StateFile != "" {
+		savePipelineState(pipeline, job.StateFile)
+	}
+}
+
+// execute runs the pipeline and returns it together with the executed leaves and their results.
+// The results are nil in the dry run mode. It panics on errors.
+func (job analysisJob) execute() (
+	*hercules.Pipeline, []hercules.LeafPipelineItem, map[hercules.LeafPipelineItem]interface{}) {
+	pipeline := hercules.NewPipeline(job.Repository)
+	if job.Features == nil {
+		pipeline.SetFeaturesFromFlags()
+	} else {
+		for _, feature := range job.Features {
+			pipeline.SetFeature(feature)
+		}
+	}
+	var bar *progress.ProgressBar
+	if job.ShowProgress {
+		pipeline.OnProgress = func(commit, length int) {
+			if bar == nil {
+				bar = progress.New(length)
+				bar.Callback = func(msg string) {
+					os.Stderr.WriteString("\r" + msg)
+				}
+				bar.NotPrint = true
+				bar.ShowPercent = false
+				bar.ShowSpeed = false
+				bar.SetMaxWidth(80)
+				bar.Start()
+			}
+			if commit == length {
+				bar.Finish()
+				fmt.Fprint(os.Stderr, "\r"+strings.Repeat(" ", 80)+"\rfinalizing...")
+			} else {
+				bar.Set(commit)
+			}
+		}
+	}
+
+	if job.ProgressJSON {
+		encoder := json.NewEncoder(os.Stderr)
+		pipeline.OnCommit = func(commit hercules.CommitProgress) {
+			timings := map[string]float64{}
+			for name, timing := range commit.Timings {
+				timings[name] = timing.Seconds()
+			}
+			encoder.Encode(jsonProgress{
+				Hash: commit.Hash.String(), Index: commit.Index, Total: commit.Total,
+				Elapsed: commit.Elapsed.Seconds(), Items: timings,
+			})
+		}
+	}
+
+	var commits []*object.Commit
+	if job.CommitsFile == "" && job.Commits != nil {
+		commits = job.Commits
+	} else if branches, _ := job.Facts[hercules.ConfigPipelineBranches].(bool); job.CommitsFile == "" && branches {
+		// all the commits reachable from HEAD, rev-list --topo-order --reverse
+		commits = pipeline.AllCommits()
+	} else if job.CommitsFile == "" && job.HistoryCache != "" {
+		var err error
+		commits, err = hercules.LoadHistory(job.HistoryCache, job.Repository)
+		if err != nil {
+			panic(err)
+		}
+	} else if job.CommitsFile == "" {
+		// list of commits belonging to the default branch, from oldest to newest
+		// rev-list --first-parent
+		commits = pipeline.Commits()
+	} else {
+		var err error
+		commits, err = hercules.LoadCommitsFromFile(job.CommitsFile, job.Repository)
+		if err != nil {
+			panic(err)
+		}
+	}
+	if job.ExcludeFile != "" {
+		var err error
+		commits, err = hercules.ExcludeCommitsFromFile(commits, job.ExcludeFile)
+		if err != nil {
+			panic(err)
+		}
+		if len(commits) == 0 {
+			panic("all the commits are excluded by " + job.ExcludeFile)
+		}
+	}
+	if job.Filter != nil {
+		var err error
+		commits, err = hercules.FilterCommits(commits, job.Filter)
+		if err != nil {
+			panic(err)
+		}
+		if len(commits) == 0 {
+			panic("no commits match the filter " + job.Filter.String())
+		}
+	}
+	job.Facts["commits"] = commits
+	// deploy in the stable order so that the output is deterministic
+	analyses := append([]string{}, job.Analyses...)
+	sort.Strings(analyses)
+	deployed := []hercules.LeafPipelineItem{}
+	for _, name := range analyses {
+		item := hercules.Registry.Summon(name)[0].(hercules.LeafPipelineItem)
+		if globs, exists := job.Scopes[name]; exists {
+			scoped, err := leaves.NewScopedLeaf(item, globs)
+			if err != nil {
+				panic(err)
+			}
+			item = scoped
+		}
+		deployed = append(deployed, pipeline.DeployItem(item).(hercules.LeafPipelineItem))
+	}
+	if job.StateFile != "" {
+		// fail before the analysis if the state cannot be saved
+		job.Facts[hercules.ConfigPipelineSaveState] = true
+	}
+	pipeline.Initialize(job.Facts)
+	if dryRun, _ := job.Facts[hercules.ConfigPipelineDryRun].(bool); dryRun {
+		return pipeline, deployed, nil
+	}
+	if job.StateFile != "" {
+		loadPipelineState(pipeline, job.StateFile)
+	}
+	results, err := pipeline.Run(commits)
+	if err != nil {
+		panic(err)
+	}
+	// the items which depend on the disabled features were removed from the pipeline
+	executed := make([]hercules.LeafPipelineItem, 0, len(deployed))
+	for _, item := range deployed {
+		if _, exists := results[item]; exists {
+			executed = append(executed, item)
+		}
+	}
+	if job.ShowProgress {
+		fmt.Fprint(os.Stderr, "\r"+strings.Repeat(" ", 80)+"\r")
+		// if not a terminal, the user will not see the output, so show the status
+		if job.ShowWriting {
+			fmt.Fprint(os.Stderr, "writing...\r")
+		}
+	}
+	return pipeline, executed, results
+}
+
+// loadPipelineState restores the pipeline state from the file if it exists. It panics on errors.
+func loadPipelineState(pipeline *hercules.Pipeline, path string) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+	if err = pipeline.Load(bufio.NewReader(file)); err != nil {
+		panic(fmt.Sprintf("failed to load the state from %s: %v", path, err))
+	}
+}
+
+// savePipelineState writes the pipeline state to the file. The file is replaced atomically,
+// so that an interrupted run does not corrupt the previous state. It panics on errors.
+func savePipelineState(pipeline *hercules.Pipeline, path string) {
+	file, err := os.Create(path + ".tmp")
+	if err != nil {
+		panic(err)
+	}
+	writer := bufio.NewWriter(file)
+	err = pipeline.Dump(writer)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path + ".tmp")
+		panic(fmt.Sprintf("failed to save the state to %s: %v", path, err))
+	}
+	if err = os.Rename(path+".tmp", path); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// clientHeader is the HTTP header which names the client in "hercules serve". The requests
+// without it are attributed to the client's IP address.
+const clientHeader = "X-Hercules-Client"
+
+// clientQuota is the scheduling policy of a client in "hercules serve --config".
+type clientQuota struct {
+	// Priority orders the queued runs: the higher, the sooner the run starts.
+	Priority int `yaml:"priority"`
+	// MaxRunning is the maximum number of the simultaneous runs; 0 means no limit.
+	MaxRunning int `yaml:"max-running"`
+	// MaxQueued is the maximum number of the waiting runs; 0 means no limit.
+	MaxQueued int `yaml:"max-queued"`
+}
+
+// validate checks that the limits are not negative.
+func (quota clientQuota) validate() error {
+	if quota.MaxRunning < 0 {
+		return fmt.Errorf("max-running must not be negative: %d", quota.MaxRunning)
+	}
+	if quota.MaxQueued < 0 {
+		return fmt.Errorf("max-queued must not be negative: %d", quota.MaxQueued)
+	}
+	return nil
+}
+
+// clientName identifies the client which sent the request.
+func clientName(r *http.Request) string {
+	if name := r.Header.Get(clientHeader); name != "" {
+		return name
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+var (
+	// errQueueFull is returned by jobScheduler.acquire() when the client exceeds MaxQueued.
+	errQueueFull = errors.New("too many queued runs")
+	// errCanceled is returned by jobScheduler.acquire() when the request is gone.
+	errCanceled = errors.New("the request was canceled while queued")
+)
+
+// jobScheduler admits the analysis runs to the limited number of workers. The waiting runs
+// start in the order of their priorities and then in the order of arrival, skipping those
+// whose clients already have MaxRunning runs. Thus a client with a huge repository can be
+// limited to a part of the workers while the rest serve the small interactive requests.
+type jobScheduler struct {
+	lock sync.Mutex
+	// free is the number of the idle workers.
+	free int
+	// running and queued count the runs of each client.
+	running map[string]int
+	queued  map[string]int
+	// waiting are the queued runs in the order of arrival.
+	waiting []*pendingRun
+}
+
+// pendingRun is a queued run in jobScheduler.
+type pendingRun struct {
+	client string
+	quota  clientQuota
+	// start is closed when the run is admitted.
+	start chan struct{}
+}
+
+func newJobScheduler(workers int) *jobScheduler {
+	return &jobScheduler{
+		free:    workers,
+		running: map[string]int{},
+		queued:  map[string]int{},
+	}
+}
+
+// acquire blocks until the client's run may start and returns the function which must be
+// called after the run finishes. It fails if the client has MaxQueued waiting runs or if
+// done is closed before the run starts.
+func (scheduler *jobScheduler) acquire(
+	client string, quota clientQuota, done <-chan struct{}) (func(), error) {
+	scheduler.lock.Lock()
+	if quota.MaxQueued > 0 && scheduler.queued[client] >= quota.MaxQueued {
+		scheduler.lock.Unlock()
+		return nil, errQueueFull
+	}
+	run := &pendingRun{client: client, quota: quota, start: make(chan struct{})}
+	scheduler.waiting = append(scheduler.waiting, run)
+	scheduler.queued[client]++
+	scheduler.dispatch()
+	scheduler.lock.Unlock()
+	release := func() {
+		scheduler.lock.Lock()
+		defer scheduler.lock.Unlock()
+		scheduler.running[client]--
+		if scheduler.running[client] == 0 {
+			delete(scheduler.running, client)
+		}
+		scheduler.free++
+		scheduler.dispatch()
+	}
+	select {
+	case <-run.start:
+		return release, nil
+	case <-done:
+	}
+	scheduler.lock.Lock()
+	select {
+	case <-run.start:
+		// admitted at the same time as canceled
+		scheduler.lock.Unlock()
+		release()
+		return nil, errCanceled
+	default:
+	}
+	defer scheduler.lock.Unlock()
+	for i, other := range scheduler.waiting {
+		if other == run {
+			scheduler.waiting = append(scheduler.waiting[:i], scheduler.waiting[i+1:]...)
+			break
+		}
+	}
+	scheduler.dequeued(client)
+	return nil, errCanceled
+}
+
+// dispatch admits the waiting runs while there are idle workers. The lock must be held.
+func (scheduler *jobScheduler) dispatch() {
+	for scheduler.free > 0 {
+		best := -1
+		for i, run := range scheduler.waiting {
+			if run.quota.MaxRunning > 0 && scheduler.running[run.client] >= run.quota.MaxRunning {
+				continue
+			}
+			if best < 0 || run.quota.Priority > scheduler.waiting[best].quota.Priority {
+				best = i
+			}
+		}
+		if best < 0 {
+			return
+		}
+		run := scheduler.waiting[best]
+		scheduler.waiting = append(scheduler.waiting[:best], scheduler.waiting[best+1:]...)
+		scheduler.dequeued(run.client)
+		scheduler.running[run.client]++
+		scheduler.free--
+		close(run.start)
+	}
+}
+
+// dequeued decrements the number of the client's waiting runs. The lock must be held.
+func (scheduler *jobScheduler) dequeued(client string) {
+	scheduler.queued[client]--
+	if scheduler.queued[client] == 0 {
+		delete(scheduler.queued, client)
+	}
+}
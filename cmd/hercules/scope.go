@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/src-d/hercules.v4"
+	goyaml "gopkg.in/yaml.v3"
+)
+
+// loadScopes reads the YAML mapping from the analysis names to the path globs, for example
+//
+//	Burndown: ["src/**", "lib/**"]
+//	Couples: "**"
+func loadScopes(path string) (map[string][]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]interface{}
+	if err = goyaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	known := map[string]bool{}
+	for _, leaf := range hercules.Registry.GetLeaves() {
+		known[leaf.Name()] = true
+	}
+	scopes := map[string][]string{}
+	for name, value := range raw {
+		if !known[name] {
+			return nil, fmt.Errorf("%s: unknown analysis %s", path, name)
+		}
+		switch value := value.(type) {
+		case string:
+			scopes[name] = []string{value}
+		case []interface{}:
+			for _, glob := range value {
+				str, ok := glob.(string)
+				if !ok {
+					return nil, fmt.Errorf("%s: %s: the globs must be strings", path, name)
+				}
+				scopes[name] = append(scopes[name], str)
+			}
+		default:
+			return nil, fmt.Errorf("%s: %s: expected a glob or a list of globs", path, name)
+		}
+	}
+	return scopes, nil
+}
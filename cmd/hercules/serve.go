@@ -0,0 +1,612 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/hercules.v4"
+	"gopkg.in/src-d/hercules.v4/internal/store"
+	goyaml "gopkg.in/yaml.v3"
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run the analyses over HTTP.",
+	Long: `Start the HTTP server which executes the analyses on request.
+
+GET /analyses returns the JSON list of the available analyses and their options.
+POST /run?repository=<path or URL>&analysis=<flag>[&analysis=<flag>...] runs the pipeline and
+returns the results. The other query parameters are "format" (yaml, pb or json), "feature" (can be
+repeated), "commits" and the analysis options named as the flags in "hercules run --help",
+e.g. "granularity=30". --workers runs execute at the same time and the rest wait in the queue.
+
+--config points to the YAML file which limits the available analyses and sets the default
+values of the options, e.g. the identities:
+
+  analyses: [burndown, devs]
+  options:
+    granularity: 15
+    people-dict: /etc/hercules/identities.txt
+  clients:
+    ci: {priority: -1, max-running: 1, max-queued: 10}
+    "*": {priority: 0, max-queued: 3}
+
+"clients" set the priorities and the quotas of the clients which are named in the
+X-Hercules-Client header or else identified by their IP addresses; "*" applies to the
+clients which are not listed. The queued runs start in the order of their priorities, and
+a client never has more than max-running runs at once, so a huge repository does not block
+the workers for everybody else. The requests beyond max-queued fail with 429.
+
+--store saves the results of the successful runs to the file system ("file:///var/lib/hercules"),
+S3 ("s3://key:secret@s3.amazonaws.com/bucket/prefix") or PostgreSQL ("postgres://user@host/db").
+The results belong to the clients which are identified the same way as for the quotas; /run
+returns the ID of the result in the X-Hercules-Result header.
+GET /results[?repository=<path or URL>] returns the JSON list of the client's results.
+GET /results/<id> returns the result and DELETE /results/<id> removes it.
+--retention-age and --retention-count limit the stored results of each repository of each client.
+
+--cache keeps the clones of the remote repositories on disk. The forks and the mirrors share
+the object storage: the URL which was not requested before joins the cached history if any of
+its advertised references is already there, so only the missing objects are fetched. /run
+returns the root commit of the cached history in the X-Hercules-Fingerprint header.
+
+The --clone-* options limit the number and the speed of the simultaneous clones and retry
+the failed ones with the exponential backoff.
+
+The file is read again on SIGHUP and on POST /admin/reload. The requests which are already
+accepted keep the configuration which was active when they arrived; if the new file is
+invalid, the previous configuration stays.`,
+	Args: cobra.MaximumNArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		addr, _ := cmd.Flags().GetString("addr")
+		configPath, _ := cmd.Flags().GetString("config")
+		workers, _ := cmd.Flags().GetInt("workers")
+		if workers < 1 {
+			log.Fatalf("--workers must be positive: %d", workers)
+		}
+		server := &analysisServer{
+			configPath: configPath, config: &serverConfig{}, scheduler: newJobScheduler(workers),
+			clones: newCloneManager(cmd.Flags())}
+		if cachePath, _ := cmd.Flags().GetString("cache"); cachePath != "" {
+			var err error
+			if server.repositories, err = newRepositoryCache(cachePath); err != nil {
+				log.Fatalf("Failed to open the repository cache: %v", err)
+			}
+		}
+		if location, _ := cmd.Flags().GetString("store"); location != "" {
+			var err error
+			if server.results, err = store.Open(location); err != nil {
+				log.Fatalf("Failed to open the result store: %v", err)
+			}
+			server.retention.MaxAge, _ = cmd.Flags().GetDuration("retention-age")
+			server.retention.MaxCount, _ = cmd.Flags().GetInt("retention-count")
+			if server.retention.MaxAge > 0 {
+				go server.expireResults()
+			}
+		}
+		if configPath != "" {
+			if err := server.reload(); err != nil {
+				log.Fatal(err)
+			}
+			hangups := make(chan os.Signal, 1)
+			signal.Notify(hangups, syscall.SIGHUP)
+			go func() {
+				for range hangups {
+					if err := server.reload(); err != nil {
+						log.Printf("Failed to reload the configuration: %v", err)
+					}
+				}
+			}()
+		}
+		// not the default mux which has net/http/pprof handlers
+		mux := http.NewServeMux()
+		mux.HandleFunc("/analyses", server.serveAnalyses)
+		mux.HandleFunc("/run", server.serveRun)
+		mux.HandleFunc("/admin/reload", server.serveReload)
+		mux.HandleFunc("/results", server.serveResults)
+		mux.HandleFunc("/results/", server.serveResult)
+		log.Printf("Listening on %s", addr)
+		log.Fatal(http.ListenAndServe(addr, mux))
+	},
+}
+
+// analysisServer executes the pipeline in response to HTTP requests.
+type analysisServer struct {
+	// scheduler limits the number of the simultaneous runs and orders the queued ones.
+	scheduler *jobScheduler
+	// configPath is the path to the configuration file; empty if there is none.
+	configPath string
+	// config is replaced as a whole on reload, so the requests take it once.
+	config     *serverConfig
+	configLock sync.RWMutex
+	// results persist the outputs of the runs; nil if --store is not set.
+	results   store.Store
+	retention store.RetentionPolicy
+	// repositories keep the remote repositories on disk; nil if --cache is not set.
+	repositories *repositoryCache
+	// clones limit the downloads of the remote repositories.
+	clones *cloneManager
+}
+
+// serverConfig is the contents of the file passed in "hercules serve --config".
+type serverConfig struct {
+	// Analyses are the flags of the allowed analyses; all are allowed if it is empty.
+	Analyses []string `yaml:"analyses"`
+	// Options map the flags of the configuration options to their default values
+	// which the query parameters override.
+	Options map[string]string `yaml:"options"`
+	// Clients map the client names to their priorities and quotas. "*" matches the
+	// clients which are not listed.
+	Clients map[string]clientQuota `yaml:"clients"`
+}
+
+// quota returns the scheduling policy of the client.
+func (config *serverConfig) quota(client string) clientQuota {
+	if quota, exists := config.Clients[client]; exists {
+		return quota
+	}
+	return config.Clients["*"]
+}
+
+// allows checks whether the analysis with the given flag may run.
+func (config *serverConfig) allows(flag string) bool {
+	if len(config.Analyses) == 0 {
+		return true
+	}
+	for _, allowed := range config.Analyses {
+		if allowed == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// loadServerConfig reads and validates the server configuration.
+func loadServerConfig(path string) (*serverConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &serverConfig{}
+	if err = goyaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	leaves := map[string]bool{}
+	for _, leaf := range hercules.Registry.GetLeaves() {
+		leaves[leaf.Flag()] = true
+	}
+	for _, flag := range config.Analyses {
+		if !leaves[flag] {
+			return nil, fmt.Errorf("%s: unknown analysis %s", path, flag)
+		}
+	}
+	options := url.Values{}
+	for flag, value := range config.Options {
+		options.Set(flag, value)
+	}
+	if _, err = parseFacts(options); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	known := map[string]bool{}
+	for _, item := range configurableItems() {
+		for _, opt := range item.ListConfigurationOptions() {
+			known[opt.Flag] = true
+		}
+	}
+	for flag := range config.Options {
+		if !known[flag] {
+			return nil, fmt.Errorf("%s: unknown option %s", path, flag)
+		}
+	}
+	for client, quota := range config.Clients {
+		if err = quota.validate(); err != nil {
+			return nil, fmt.Errorf("%s: client %s: %v", path, client, err)
+		}
+	}
+	return config, nil
+}
+
+// reload reads the configuration file and replaces the active configuration if it is valid.
+func (server *analysisServer) reload() error {
+	config, err := loadServerConfig(server.configPath)
+	if err != nil {
+		return err
+	}
+	server.configLock.Lock()
+	server.config = config
+	server.configLock.Unlock()
+	log.Printf("Loaded the configuration from %s", server.configPath)
+	return nil
+}
+
+// currentConfig returns the active configuration.
+func (server *analysisServer) currentConfig() *serverConfig {
+	server.configLock.RLock()
+	defer server.configLock.RUnlock()
+	return server.config
+}
+
+func (server *analysisServer) serveReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if server.configPath == "" {
+		http.Error(w, "the server was started without --config", http.StatusConflict)
+		return
+	}
+	if err := server.reload(); err != nil {
+		log.Printf("Failed to reload the configuration: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type optionDescription struct {
+	Name        string      `json:"name"`
+	Flag        string      `json:"flag"`
+	Type        string      `json:"type"`
+	Description string      `json:"description"`
+	Default     interface{} `json:"default"`
+}
+
+type analysisDescription struct {
+	Name     string              `json:"name"`
+	Flag     string              `json:"flag"`
+	Features []string            `json:"features,omitempty"`
+	Options  []optionDescription `json:"options"`
+}
+
+func (server *analysisServer) serveAnalyses(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	config := server.currentConfig()
+	analyses := []analysisDescription{}
+	for _, leaf := range hercules.Registry.GetLeaves() {
+		if !config.allows(leaf.Flag()) {
+			continue
+		}
+		description := analysisDescription{
+			Name: leaf.Name(), Flag: leaf.Flag(), Options: []optionDescription{}}
+		if featured, ok := leaf.(hercules.FeaturedPipelineItem); ok {
+			description.Features = featured.Features()
+		}
+		for _, opt := range leaf.ListConfigurationOptions() {
+			typeName := opt.Type.String()
+			if opt.Type == hercules.BoolConfigurationOption {
+				typeName = "bool"
+			} else if opt.Type == hercules.StringsConfigurationOption {
+				typeName = "strings"
+			}
+			description.Options = append(description.Options, optionDescription{
+				Name: opt.Name, Flag: opt.Flag, Type: typeName, Description: opt.Description,
+				Default: opt.Default,
+			})
+		}
+		analyses = append(analyses, description)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(analyses)
+}
+
+func (server *analysisServer) serveRun(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	config := server.currentConfig()
+	query := r.URL.Query()
+	uri := query.Get("repository")
+	if uri == "" {
+		http.Error(w, "repository is required", http.StatusBadRequest)
+		return
+	}
+	format := query.Get("format")
+	if format == "" {
+		format = formatYAML
+	}
+	if format != formatYAML && format != formatProtobuf && format != formatJSON {
+		http.Error(w, "format must be yaml, pb or json", http.StatusBadRequest)
+		return
+	}
+	leaves := map[string]string{}
+	for _, leaf := range hercules.Registry.GetLeaves() {
+		leaves[leaf.Flag()] = leaf.Name()
+	}
+	analyses := []string{}
+	for _, flag := range query["analysis"] {
+		name, exists := leaves[flag]
+		if !exists {
+			http.Error(w, "unknown analysis: "+flag, http.StatusBadRequest)
+			return
+		}
+		if !config.allows(flag) {
+			http.Error(w, "analysis is disabled: "+flag, http.StatusForbidden)
+			return
+		}
+		analyses = append(analyses, name)
+	}
+	if len(analyses) == 0 {
+		http.Error(w, "at least one analysis is required", http.StatusBadRequest)
+		return
+	}
+	for flag, value := range config.Options {
+		if _, exists := query[flag]; !exists {
+			query.Set(flag, value)
+		}
+	}
+	facts, err := parseFacts(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if dayZero := query.Get("day-zero"); dayZero != "" {
+		facts[hercules.ConfigPipelineDayZero] = dayZero
+	}
+	var filter *hercules.CommitFilter
+	if expression := query.Get("filter"); expression != "" {
+		filter, err = hercules.ParseCommitFilter(expression)
+		if err != nil {
+			http.Error(w, "invalid filter: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	features := query["feature"]
+	if features == nil {
+		features = []string{}
+	}
+
+	client := clientName(r)
+	release, err := server.scheduler.acquire(client, config.quota(client), r.Context().Done())
+	if err == errQueueFull {
+		http.Error(w, fmt.Sprintf("%s: %s", client, err), http.StatusTooManyRequests)
+		return
+	} else if err != nil {
+		log.Printf("%s: %v", client, err)
+		return
+	}
+	defer release()
+	output := &bytes.Buffer{}
+	var fingerprint string
+	err = func() (err error) {
+		// the pipeline panics on errors
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%v", r)
+			}
+		}()
+		job := analysisJob{
+			URI:         uri,
+			Analyses:    analyses,
+			Facts:       facts,
+			Features:    features,
+			CommitsFile: query.Get("commits"),
+			Filter:      filter,
+			Format:      format,
+		}
+		if server.repositories != nil && strings.Contains(uri, "://") {
+			var head plumbing.Hash
+			err = server.clones.run(uri, func() (err error) {
+				job.Repository, head, fingerprint, err = server.repositories.load(uri)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			if job.Commits, err = resolveCommitRange(job.Repository, head.String()); err != nil {
+				return err
+			}
+		} else if strings.Contains(uri, "://") {
+			if job.Repository, err = server.clones.clone(uri); err != nil {
+				return err
+			}
+		} else {
+			job.Repository = loadRepository(uri, "", true)
+		}
+		job.run(output)
+		return nil
+	}()
+	if err != nil {
+		log.Printf("Failed to analyse %s: %v", uri, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if fingerprint != "" {
+		w.Header().Set(fingerprintHeader, fingerprint)
+	}
+	if server.results != nil {
+		record := &store.Record{
+			Tenant: client, Repository: uri, Analyses: query["analysis"], Format: format}
+		if err = server.results.Put(record, output.Bytes()); err != nil {
+			log.Printf("Failed to store the result of %s: %v", uri, err)
+		} else {
+			w.Header().Set(resultHeader, record.ID)
+		}
+	}
+	writeResult(w, format, output.Bytes())
+	if server.results != nil {
+		_, err = store.Prune(server.results, store.Query{Tenant: client, Repository: uri},
+			server.retention, time.Now())
+		if err != nil {
+			log.Printf("Failed to delete the old results of %s: %v", uri, err)
+		}
+	}
+}
+
+const (
+	// resultHeader is the HTTP header with the ID of the stored result.
+	resultHeader = "X-Hercules-Result"
+	// fingerprintHeader is the HTTP header with the root commit of the cached history.
+	fingerprintHeader = "X-Hercules-Fingerprint"
+)
+
+func writeResult(w http.ResponseWriter, format string, data []byte) {
+	switch format {
+	case formatProtobuf:
+		w.Header().Set("Content-Type", "application/octet-stream")
+	case formatJSON:
+		w.Header().Set("Content-Type", "application/json")
+	default:
+		w.Header().Set("Content-Type", "application/x-yaml")
+	}
+	w.Write(data)
+}
+
+func (server *analysisServer) serveResults(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if server.results == nil {
+		http.Error(w, "the server was started without --store", http.StatusNotFound)
+		return
+	}
+	records, err := server.results.List(store.Query{
+		Tenant: clientName(r), Repository: r.URL.Query().Get("repository")})
+	if err != nil {
+		log.Printf("Failed to list the results: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(records)
+}
+
+func (server *analysisServer) serveResult(w http.ResponseWriter, r *http.Request) {
+	if server.results == nil {
+		http.Error(w, "the server was started without --store", http.StatusNotFound)
+		return
+	}
+	client := clientName(r)
+	id := strings.TrimPrefix(r.URL.Path, "/results/")
+	var err error
+	switch r.Method {
+	case http.MethodGet:
+		var record *store.Record
+		var data []byte
+		if record, data, err = server.results.Get(client, id); err == nil {
+			writeResult(w, record.Format, data)
+			return
+		}
+	case http.MethodDelete:
+		if err = server.results.Delete(client, id); err == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err == store.ErrNotFound {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	log.Printf("Failed to access the result %s: %v", id, err)
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// expireResults periodically deletes the results which are older than --retention-age,
+// including those of the repositories which are not analysed anymore.
+func (server *analysisServer) expireResults() {
+	for now := range time.Tick(time.Hour) {
+		deleted, err := store.Prune(server.results, store.Query{}, server.retention, now)
+		if err != nil {
+			log.Printf("Failed to delete the old results: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Deleted %d old results", deleted)
+		}
+	}
+}
+
+// parseFacts converts the query parameters named after the configuration options' flags
+// to the pipeline facts. The missing options are set to their defaults.
+func parseFacts(query url.Values) (map[string]interface{}, error) {
+	facts := map[string]interface{}{}
+	for _, item := range configurableItems() {
+		for _, opt := range item.ListConfigurationOptions() {
+			values, exists := query[opt.Flag]
+			if !exists {
+				facts[opt.Name] = opt.Default
+				continue
+			}
+			var err error
+			if facts[opt.Name], err = parseOption(opt, values[len(values)-1]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return facts, nil
+}
+
+// parseOption converts the string value of the configuration option to its type.
+func parseOption(opt hercules.ConfigurationOption, value string) (interface{}, error) {
+	var parsed interface{}
+	var err error
+	switch opt.Type {
+	case hercules.BoolConfigurationOption:
+		parsed, err = strconv.ParseBool(value)
+	case hercules.IntConfigurationOption:
+		parsed, err = strconv.Atoi(value)
+	case hercules.StringConfigurationOption:
+		parsed = value
+	case hercules.FloatConfigurationOption:
+		var float float64
+		float, err = strconv.ParseFloat(value, 32)
+		parsed = float32(float)
+	case hercules.StringsConfigurationOption:
+		parsed = strings.Split(value, ",")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid value of %s: %v", opt.Flag, err)
+	}
+	return parsed, nil
+}
+
+// configurableItems returns all the registered items which have the configuration options.
+func configurableItems() []hercules.PipelineItem {
+	items := hercules.Registry.GetPlumbingItems()
+	for _, leaf := range hercules.Registry.GetLeaves() {
+		items = append(items, leaf)
+	}
+	return items
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.SetUsageFunc(serveCmd.UsageFunc())
+	serveCmd.Flags().String("addr", "localhost:8080", "The address to listen on.")
+	serveCmd.Flags().String("config", "", "Path to the YAML file with the allowed analyses and "+
+		"the default option values. It is reloaded on SIGHUP and POST /admin/reload.")
+	serveCmd.MarkFlagFilename("config", "yaml", "yml")
+	serveCmd.Flags().String("cache", "", "The directory with the clones of the remote "+
+		"repositories. The forks and the mirrors share the objects.")
+	serveCmd.MarkFlagDirname("cache")
+	serveCmd.Flags().String("store", "", "The URL of the storage of the results: "+
+		"file:///path, s3://[key:secret@]endpoint/bucket[/prefix] or postgres://... "+
+		"(the latter requires the build with the \"postgres\" tag)")
+	serveCmd.Flags().Duration("retention-age", 0,
+		"Delete the stored results which are older than this, e.g. 720h. 0 keeps them forever.")
+	serveCmd.Flags().Int("retention-count", 0,
+		"The number of the newest stored results of each repository of each client to keep. "+
+			"0 keeps all.")
+	addCloneFlags(serveCmd.Flags())
+	serveCmd.Flags().Int("workers", 1, "The number of the analyses which run at the same time.")
+}
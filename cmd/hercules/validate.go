@@ -0,0 +1,65 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/src-d/hercules.v4"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check YAML analysis results against the output schema.",
+	Long: `Check that the YAML files produced by hercules are well-formed, have the supported schema
+version and contain only the known analyses. "-" reads from stdin. The exit code is 1 if any
+of the files is invalid.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, files []string) {
+		allErrors := map[string][]string{}
+		failed := false
+		for _, fileName := range files {
+			errs := validateFile(fileName)
+			allErrors[fileName] = errs
+			failed = failed || len(errs) > 0
+		}
+		printErrors(allErrors)
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+func validateFile(fileName string) []string {
+	var reader io.Reader
+	if fileName == "-" {
+		reader = os.Stdin
+	} else {
+		file, err := os.Open(fileName)
+		if err != nil {
+			return []string{"Cannot read " + fileName + ": " + err.Error()}
+		}
+		defer file.Close()
+		reader = file
+	}
+	isKnown := func(name string) bool {
+		for _, item := range hercules.Registry.Summon(name) {
+			if _, isLeaf := item.(hercules.LeafPipelineItem); isLeaf {
+				return true
+			}
+		}
+		return false
+	}
+	var errs []string
+	for _, err := range yaml.Validate(reader, isKnown) {
+		errs = append(errs, err.Error())
+	}
+	return errs
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+	validateCmd.SetUsageFunc(validateCmd.UsageFunc())
+}
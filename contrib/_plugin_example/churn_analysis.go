@@ -189,6 +189,10 @@ func (churn *ChurnAnalysis) Serialize(result interface{}, binary bool, writer io
 	return nil
 }
 
+func (churn *ChurnAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return hercules.SerializeJSON(churn, result, &ChurnAnalysisResultMessage{}, writer)
+}
+
 func (churn *ChurnAnalysis) serializeText(result *ChurnAnalysisResult, writer io.Writer) {
 	fmt.Fprintln(writer, "  global:")
 	printEdits(result.Global, writer, 4)
@@ -1,9 +1,15 @@
 package hercules
 
 import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
 	git "gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
 	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
 	"gopkg.in/src-d/hercules.v4/internal/plumbing"
 	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
 	"gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
@@ -35,20 +41,96 @@ type PipelineItem = core.PipelineItem
 // FeaturedPipelineItem enables switching the automatic insertion of pipeline items on or off.
 type FeaturedPipelineItem = core.FeaturedPipelineItem
 
+// OptionallyFeaturedPipelineItem is a FeaturedPipelineItem which uses some features only if they are enabled.
+type OptionallyFeaturedPipelineItem = core.OptionallyFeaturedPipelineItem
+
 // LeafPipelineItem corresponds to the top level pipeline items which produce the end results.
 type LeafPipelineItem = core.LeafPipelineItem
 
 // MergeablePipelineItem specifies the methods to combine several analysis results together.
 type MergeablePipelineItem = core.MergeablePipelineItem
 
+// ExtensionPipelineItem writes its binary result as the namespaced extension of the combined output.
+type ExtensionPipelineItem = core.ExtensionPipelineItem
+
+// DayRangePipelineItem writes its binary time series result in parts, one per day range.
+type DayRangePipelineItem = core.DayRangePipelineItem
+
+// DayRange is the part of the binary time series result which covers the days [From, To).
+type DayRange = core.DayRange
+
+// ShrinkablePipelineItem is able to release some memory at the cost of the speed or the precision.
+// Pipeline.Run() calls Shrink() when the resident memory exceeds ConfigPipelineMemoryLimit.
+type ShrinkablePipelineItem = core.ShrinkablePipelineItem
+
+// ForkablePipelineItem keeps the state which depends on the line of development, so that
+// Pipeline.Run() clones it at the branch points and combines the clones at the merge commits.
+// See ConfigPipelineBranches.
+type ForkablePipelineItem = core.ForkablePipelineItem
+
+// WrappingPipelineItem delegates to another item and implements the optional interfaces
+// on its behalf. Their support must be checked with Unwrap().
+type WrappingPipelineItem = core.WrappingPipelineItem
+
+// Unwrap returns the innermost item which the WrappingPipelineItem-s delegate to.
+func Unwrap(item PipelineItem) PipelineItem {
+	return core.Unwrap(item)
+}
+
+// LinearPipelineItem keeps the state which depends on the line of development but is not able
+// to fork it, so it is rejected in the ConfigPipelineBranches mode.
+type LinearPipelineItem = core.LinearPipelineItem
+
+// PersistentPipelineItem is able to save and restore its internal state, so that the analysis
+// continues from the last consumed commit. See Pipeline.Dump() and Pipeline.Load().
+type PersistentPipelineItem = core.PersistentPipelineItem
+
 // CommonAnalysisResult holds the information which is always extracted at Pipeline.Run().
 type CommonAnalysisResult = core.CommonAnalysisResult
 
+// CommitFailure is the error of a pipeline item on a commit which was skipped.
+type CommitFailure = core.CommitFailure
+
+// SkippedItem is a pipeline item which was not executed.
+type SkippedItem = core.SkippedItem
+
+// Marker is a labeled point in time which annotates the results, e.g. a migration, a team change
+// or an incident. The markers are written to the metadata and drawn by the plots.
+type Marker = core.Marker
+
 // MetadataToCommonAnalysisResult copies the data from a Protobuf message.
 func MetadataToCommonAnalysisResult(meta *core.Metadata) *CommonAnalysisResult {
 	return core.MetadataToCommonAnalysisResult(meta)
 }
 
+// ReadResultExtensions parses the binary analysis results and decodes the extensions which
+// the registered ExtensionPipelineItem-s wrote. The names of the other extensions, e.g. whose
+// plugins are not loaded, are returned as unknown.
+func ReadResultExtensions(data []byte) (
+	known map[string]proto.Message, unknown []string, err error) {
+	message, err := pb.ReadAnalysisResults(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	known = map[string]proto.Message{}
+	unknown = []string{}
+	for _, name := range message.ExtensionNames() {
+		decoded, err := pb.DecodeExtension(name, message.Extensions[name])
+		if err == pb.ErrUnknownExtension {
+			unknown = append(unknown, name)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		known[name] = decoded
+	}
+	return known, unknown, nil
+}
+
+// CommitProgress describes the processed commit in Pipeline.OnCommit.
+type CommitProgress = core.CommitProgress
+
 // Pipeline is the core Hercules entity which carries several PipelineItems and executes them.
 // See the extended example of how a Pipeline works in doc.go
 type Pipeline = core.Pipeline
@@ -65,6 +147,51 @@ const (
 	// ConfigPipelineCommits is the name of the Pipeline configuration option (Pipeline.Initialize())
 	// which allows to specify the custom commit sequence. By default, Pipeline.Commits() is used.
 	ConfigPipelineCommits = core.ConfigPipelineCommits
+	// ConfigPipelineSkipErrors is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which makes Run() log the errors and panics in Consume(), skip the failed commit for the
+	// failed item and the items which depend on it and continue. The failures are reported in
+	// CommonAnalysisResult.
+	ConfigPipelineSkipErrors = core.ConfigPipelineSkipErrors
+	// ConfigPipelineCommitTimeout is the name of the Pipeline configuration option
+	// (Pipeline.Initialize()) which sets the time budget of a single commit (time.Duration).
+	// If the commit exceeds it, the rest of the items which are enabled by features (e.g. UAST)
+	// and the items which depend on them are skipped and the skips are reported in
+	// CommonAnalysisResult.Failures.
+	ConfigPipelineCommitTimeout = core.ConfigPipelineCommitTimeout
+	// ConfigPipelineDeadline is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the time budget of Run() (time.Duration). If it is exceeded, the rest of the
+	// commits are not processed and the results are marked as CommonAnalysisResult.Partial.
+	ConfigPipelineDeadline = core.ConfigPipelineDeadline
+	// ConfigPipelineDayZero is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which anchors the day indexes and CommonAnalysisResult.BeginTime to the specified date
+	// ("2006-01-02" or RFC3339) or tag instead of the first commit. This way the results from
+	// several repositories share the same time axis.
+	ConfigPipelineDayZero = core.ConfigPipelineDayZero
+	// FactPipelineDayZero is the resolved ConfigPipelineDayZero (time.Time) which
+	// Pipeline.Initialize() passes to the items. It is absent if the option is not set.
+	FactPipelineDayZero = core.FactPipelineDayZero
+	// ConfigPipelineMemoryLimit is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the resident memory in megabytes (int) above which Run() calls Shrink() of every
+	// ShrinkablePipelineItem, e.g. to evict the caches. Zero disables the watchdog.
+	ConfigPipelineMemoryLimit = core.ConfigPipelineMemoryLimit
+	// ConfigPipelineMarkers is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the path to the file with the labeled events, e.g. migrations or incidents,
+	// which are written to CommonAnalysisResult.Markers. See LoadMarkers() for the format.
+	ConfigPipelineMarkers = core.ConfigPipelineMarkers
+	// ConfigPipelineWorkers is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the number of goroutines (int) which run the independent items concurrently on
+	// each commit. The values less than 2 keep the sequential execution.
+	ConfigPipelineWorkers = core.ConfigPipelineWorkers
+	// ConfigPipelineBranches is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which enables the branch-aware analysis (bool): the commits are all the commits reachable
+	// from HEAD (Pipeline.AllCommits()) and every ForkablePipelineItem follows each branch
+	// separately, from the branch point to the merge commit. The LinearPipelineItem-s are
+	// not supported.
+	ConfigPipelineBranches = core.ConfigPipelineBranches
+	// ConfigPipelineSaveState is the name of the Pipeline configuration option
+	// (Pipeline.Initialize()) which declares that the state is going to be saved with Dump()
+	// after Run() (bool). Initialize() fails unless every item is a PersistentPipelineItem.
+	ConfigPipelineSaveState = core.ConfigPipelineSaveState
 )
 
 // NewPipeline initializes a new instance of Pipeline struct.
@@ -73,11 +200,53 @@ func NewPipeline(repository *git.Repository) *Pipeline {
 }
 
 // LoadCommitsFromFile reads the file by the specified FS path and generates the sequence of commits
-// by interpreting each line as a Git commit hash.
+// by interpreting each line as a Git commit hash. The path "-" means stdin. Only the first word
+// of each line is the hash, so that the output of `git log --format="%H %s"` is accepted.
+// Empty lines and the lines which start with "#" are ignored.
 func LoadCommitsFromFile(path string, repository *git.Repository) ([]*object.Commit, error) {
 	return core.LoadCommitsFromFile(path, repository)
 }
 
+// ExcludeCommitsFromFile returns the commits except those listed in the file by the specified
+// FS path, preserving the order. The file format is the same as in LoadCommitsFromFile().
+func ExcludeCommitsFromFile(commits []*object.Commit, path string) ([]*object.Commit, error) {
+	return core.ExcludeCommitsFromFile(commits, path)
+}
+
+// LoadHistory returns the same commits as Pipeline.Commits() and caches their hashes in
+// the sidecar file by the specified path, so that the repeated runs on the same repository
+// do not trace the history again. The cache is keyed by the checksums of the packs.
+func LoadHistory(path string, repository *git.Repository) ([]*object.Commit, error) {
+	return core.LoadHistory(path, repository)
+}
+
+// CommitFilter is a compiled boolean expression which selects the commits to analyse.
+type CommitFilter = core.CommitFilter
+
+// ResolveDayZero converts the value of ConfigPipelineDayZero to the time. The anchor is either
+// a date in "2006-01-02" (UTC) or RFC3339 format or the name of a tag; in the latter case,
+// the author date of the tagged commit is returned.
+func ResolveDayZero(anchor string, repository *git.Repository) (time.Time, error) {
+	return core.ResolveDayZero(anchor, repository)
+}
+
+// LoadMarkers reads the markers from the text file. Each line is "<anchor> <label>", where
+// the anchor is a date ("2006-01-02" or RFC3339), a tag or a commit hash. Empty lines and
+// the lines which start with "#" are ignored. The markers are sorted by time.
+func LoadMarkers(path string, repository *git.Repository) ([]Marker, error) {
+	return core.LoadMarkers(path, repository)
+}
+
+// ParseCommitFilter compiles the commit filter expression. See CommitFilter for the syntax.
+func ParseCommitFilter(expression string) (*CommitFilter, error) {
+	return core.ParseCommitFilter(expression)
+}
+
+// FilterCommits returns the commits which match the filter, preserving the order.
+func FilterCommits(commits []*object.Commit, filter *CommitFilter) ([]*object.Commit, error) {
+	return core.FilterCommits(commits, filter)
+}
+
 // PipelineItemRegistry contains all the known PipelineItem-s.
 type PipelineItemRegistry = core.PipelineItemRegistry
 
@@ -119,6 +288,18 @@ const (
 // FileDiffData is the type of the dependency provided by plumbing.FileDiff.
 type FileDiffData = plumbing.FileDiffData
 
+// UASTChange is the type of the items in the dependency provided by uast.Changes
+// (DependencyUastChanges): the UASTs of the file before and after the commit.
+type UASTChange = uast.Change
+
+// SerializeJSON writes the result of the LeafPipelineItem in JSON through the Protocol Buffers
+// message which the item's Serialize() writes. This is the usual implementation of
+// LeafPipelineItem.SerializeJSON().
+func SerializeJSON(
+	item LeafPipelineItem, result interface{}, message proto.Message, writer io.Writer) error {
+	return core.SerializeJSON(item, result, message, writer)
+}
+
 // CountLines returns the number of lines in a *object.Blob.
 func CountLines(file *object.Blob) (int, error) {
 	return plumbing.CountLines(file)
@@ -0,0 +1,114 @@
+package herculestest
+
+import (
+	"io"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4"
+)
+
+// CommitDeps runs the plumbing items over the first-parent history of the repository and
+// returns the dependencies of each commit as they are passed to Consume(): "commit",
+// hercules.DependencyAuthor, hercules.DependencyBlobCache, hercules.DependencyDay,
+// hercules.DependencyFileDiff, hercules.DependencyTreeChanges and the names in extra, e.g.
+// the dependencies provided by the other registered items. facts configure the items
+// and may be nil.
+func CommitDeps(repository *git.Repository, facts map[string]interface{},
+	extra ...string) ([]map[string]interface{}, error) {
+	recorder := &depsRecorder{requires: append([]string{
+		hercules.DependencyAuthor, hercules.DependencyBlobCache, hercules.DependencyDay,
+		hercules.DependencyFileDiff, hercules.DependencyTreeChanges}, extra...)}
+	if _, err := RunLeaf(repository, recorder, facts); err != nil {
+		return nil, err
+	}
+	return recorder.deps, nil
+}
+
+// RunLeaf runs the pipeline with the item and its dependencies over the first-parent history
+// of the repository and returns the result of the item. facts configure the items and may be nil.
+func RunLeaf(repository *git.Repository, item hercules.LeafPipelineItem,
+	facts map[string]interface{}) (interface{}, error) {
+	if facts == nil {
+		facts = map[string]interface{}{}
+	}
+	pipeline := hercules.NewPipeline(repository)
+	pipeline.DeployItem(item)
+	pipeline.Initialize(facts)
+	commits, _ := facts[hercules.ConfigPipelineCommits].([]*object.Commit)
+	results, err := pipeline.Run(commits)
+	if err != nil {
+		return nil, err
+	}
+	return results[item], nil
+}
+
+// depsRecorder is the leaf which stores the dependencies of each commit.
+type depsRecorder struct {
+	requires []string
+	deps     []map[string]interface{}
+}
+
+func (recorder *depsRecorder) Name() string {
+	return "DepsRecorder"
+}
+
+func (recorder *depsRecorder) Provides() []string {
+	return []string{}
+}
+
+func (recorder *depsRecorder) Requires() []string {
+	return recorder.requires
+}
+
+func (recorder *depsRecorder) ListConfigurationOptions() []hercules.ConfigurationOption {
+	return []hercules.ConfigurationOption{}
+}
+
+func (recorder *depsRecorder) Configure(facts map[string]interface{}) {
+}
+
+func (recorder *depsRecorder) Initialize(repository *git.Repository) {
+	recorder.deps = nil
+}
+
+func (recorder *depsRecorder) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	copied := make(map[string]interface{}, len(deps))
+	for key, val := range deps {
+		copied[key] = val
+	}
+	recorder.deps = append(recorder.deps, copied)
+	return nil, nil
+}
+
+func (recorder *depsRecorder) Flag() string {
+	return "record-deps"
+}
+
+func (recorder *depsRecorder) Finalize() interface{} {
+	return recorder.deps
+}
+
+func (recorder *depsRecorder) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	return nil
+}
+
+func (recorder *depsRecorder) SerializeJSON(result interface{}, writer io.Writer) error {
+	return nil
+}
+
+// FakeChange creates an artificial modification of the file between two arbitrary blob hashes.
+// Either hash may be empty, then the change is an insertion or a deletion.
+func FakeChange(name string, hashFrom string, hashTo string) *object.Change {
+	change := &object.Change{}
+	if hashFrom != "" {
+		change.From = object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: plumbing.NewHash(hashFrom)}}
+	}
+	if hashTo != "" {
+		change.To = object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: plumbing.NewHash(hashTo)}}
+	}
+	return change
+}
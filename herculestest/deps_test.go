@@ -0,0 +1,57 @@
+package herculestest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4"
+	"gopkg.in/src-d/hercules.v4/leaves"
+)
+
+func TestCommitDeps(t *testing.T) {
+	repository, err := NewRepository(fixtureRepositoryCommits()...)
+	assert.Nil(t, err)
+	deps, err := CommitDeps(repository, nil)
+	assert.Nil(t, err)
+	assert.Len(t, deps, 3)
+	for i, commitDeps := range deps {
+		assert.Equal(t, commitDeps[hercules.DependencyDay], []int{0, 1, 31}[i])
+		assert.Equal(t, commitDeps[hercules.DependencyAuthor], []int{0, 1, 1}[i])
+		assert.NotNil(t, commitDeps["commit"].(*object.Commit))
+	}
+	changes := deps[1][hercules.DependencyTreeChanges].(object.Changes)
+	assert.Len(t, changes, 2)
+	fileDiffs := deps[1][hercules.DependencyFileDiff].(map[string]hercules.FileDiffData)
+	assert.Len(t, fileDiffs, 1)
+	assert.Equal(t, fileDiffs["pkg/util/util.go"].OldLinesOfCode, 1)
+	assert.Equal(t, fileDiffs["pkg/util/util.go"].NewLinesOfCode, 3)
+	changes = deps[2][hercules.DependencyTreeChanges].(object.Changes)
+	assert.Len(t, changes, 1)
+	action, err := changes[0].Action()
+	assert.Nil(t, err)
+	assert.Equal(t, action, merkletrie.Delete)
+}
+
+func TestRunLeaf(t *testing.T) {
+	repository, err := NewRepository(fixtureRepositoryCommits()...)
+	assert.Nil(t, err)
+	result, err := RunLeaf(repository, &leaves.DevsAnalysis{}, nil)
+	assert.Nil(t, err)
+	days := result.(leaves.DevsResult).Days
+	assert.Len(t, days, 3)
+	assert.Equal(t, days[1][1].Commits, 1)
+	assert.Equal(t, days[1][1].Added, 3)
+}
+
+func TestFakeChange(t *testing.T) {
+	hash := "db99e1890f581ad69e1527fe8302978c661eb473"
+	for i, change := range []*object.Change{
+		FakeChange("a.go", hash, hash), FakeChange("a.go", "", hash), FakeChange("a.go", hash, "")} {
+		action, err := change.Action()
+		assert.Nil(t, err)
+		assert.Equal(t, action, []merkletrie.Action{
+			merkletrie.Modify, merkletrie.Insert, merkletrie.Delete}[i])
+	}
+}
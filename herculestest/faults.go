@@ -0,0 +1,41 @@
+package herculestest
+
+import (
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4"
+)
+
+// FaultyItem wraps a PipelineItem and makes its Consume() fail on the chosen commits, so that
+// the error handling of the dependent items and of the pipeline, e.g.
+// hercules.ConfigPipelineSkipErrors, can be tested. FaultyItem has the same name as the wrapped
+// item, so deploying it before the dependents replaces the item which the registry would
+// create otherwise. The plumbing items are created with hercules.Registry.Summon().
+type FaultyItem struct {
+	hercules.PipelineItem
+	// Errors map the zero-based commit indexes to the errors which Consume() returns.
+	Errors map[int]error
+	// Panics map the zero-based commit indexes to the values which Consume() panics with.
+	Panics map[int]interface{}
+
+	index int
+}
+
+// Initialize resets the commit counter and initializes the wrapped item.
+func (item *FaultyItem) Initialize(repository *git.Repository) {
+	item.index = 0
+	item.PipelineItem.Initialize(repository)
+}
+
+// Consume injects the fault of the current commit if there is one and calls the wrapped item
+// otherwise.
+func (item *FaultyItem) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	index := item.index
+	item.index++
+	if val, exists := item.Panics[index]; exists {
+		panic(val)
+	}
+	if err, exists := item.Errors[index]; exists {
+		return nil, err
+	}
+	return item.PipelineItem.Consume(deps)
+}
@@ -0,0 +1,45 @@
+package herculestest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v4"
+	"gopkg.in/src-d/hercules.v4/leaves"
+)
+
+func TestFaultyItem(t *testing.T) {
+	repository, err := NewRepository(fixtureRepositoryCommits()...)
+	assert.Nil(t, err)
+	run := func(skipErrors bool) (*hercules.CommonAnalysisResult, interface{}, error) {
+		pipeline := hercules.NewPipeline(repository)
+		pipeline.DeployItem(&FaultyItem{
+			PipelineItem: hercules.Registry.Summon("FileDiff")[0],
+			Errors:       map[int]error{1: errors.New("injected")},
+			Panics:       map[int]interface{}{2: "boom"},
+		})
+		devs := &leaves.DevsAnalysis{}
+		pipeline.DeployItem(devs)
+		pipeline.Initialize(map[string]interface{}{hercules.ConfigPipelineSkipErrors: skipErrors})
+		results, err := pipeline.Run(pipeline.Commits())
+		if err != nil {
+			return nil, nil, err
+		}
+		return results[nil].(*hercules.CommonAnalysisResult), results[devs], nil
+	}
+	_, _, err = run(false)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "injected")
+	common, result, err := run(true)
+	assert.Nil(t, err)
+	// the dependent Devs skips the same commits
+	assert.Len(t, common.Failures, 4)
+	assert.Equal(t, common.Failures[0].Index, 1)
+	assert.Equal(t, common.Failures[0].Item, "FileDiff")
+	assert.Equal(t, common.Failures[0].Error, "injected")
+	assert.Equal(t, common.Failures[1].Item, "Devs")
+	assert.Equal(t, common.Failures[2].Index, 2)
+	assert.Equal(t, common.Failures[2].Error, "panic: boom")
+	assert.Len(t, result.(leaves.DevsResult).Days, 1)
+}
@@ -0,0 +1,38 @@
+package herculestest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"gopkg.in/src-d/hercules.v4"
+)
+
+// UpdateGolden makes AssertGolden() overwrite the golden files instead of comparing with them.
+// It is initialized from the HERCULES_UPDATE_GOLDEN environment variable.
+var UpdateGolden = os.Getenv("HERCULES_UPDATE_GOLDEN") != ""
+
+// AssertGolden serializes the result of the item to YAML and compares it with the contents
+// of the golden file at path. The test fails if they differ or the file cannot be read.
+func AssertGolden(t testing.TB, item hercules.LeafPipelineItem, result interface{}, path string) {
+	t.Helper()
+	buffer := &bytes.Buffer{}
+	if err := item.Serialize(result, false, buffer); err != nil {
+		t.Fatalf("%s: failed to serialize the result: %v", item.Name(), err)
+	}
+	if UpdateGolden {
+		if err := ioutil.WriteFile(path, buffer.Bytes(), 0644); err != nil {
+			t.Fatalf("failed to update %s: %v", path, err)
+		}
+		return
+	}
+	golden, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v; set HERCULES_UPDATE_GOLDEN=1 to create it", err)
+	}
+	if !bytes.Equal(golden, buffer.Bytes()) {
+		t.Errorf("%s: the result differs from %s; set HERCULES_UPDATE_GOLDEN=1 to update it.\n"+
+			"expected:\n%s\nactual:\n%s", item.Name(), path, golden, buffer.Bytes())
+	}
+}
@@ -0,0 +1,31 @@
+package herculestest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v4/leaves"
+)
+
+func TestAssertGolden(t *testing.T) {
+	repository, err := NewRepository(fixtureRepositoryCommits()...)
+	assert.Nil(t, err)
+	devs := &leaves.DevsAnalysis{}
+	result, err := RunLeaf(repository, devs, nil)
+	assert.Nil(t, err)
+	dir, err := ioutil.TempDir("", "hercules-golden-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "devs.yaml")
+	UpdateGolden = true
+	AssertGolden(t, devs, result, path)
+	UpdateGolden = false
+	golden, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(golden), "  days:\n    0:\n"))
+	AssertGolden(t, devs, result, path)
+}
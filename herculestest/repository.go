@@ -0,0 +1,166 @@
+// Package herculestest provides the fixtures for testing the third-party pipeline items:
+// in-memory repositories, the dependencies which the plumbing items produce, fake UASTs,
+// fault injection and golden results.
+package herculestest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+)
+
+// Commit describes a commit of the repository which NewRepository() creates.
+type Commit struct {
+	// Files map the paths to the contents of the added or modified files.
+	Files map[string]string
+	// Deleted are the paths of the removed files.
+	Deleted []string
+	// Author is the name of the author, "author" if empty.
+	Author string
+	// Email is the email of the author, "<Author>@example.com" if empty.
+	Email string
+	// When is the author and the committer time. If it is zero, the commit is one day
+	// after the previous one, starting with DefaultTime.
+	When time.Time
+	// Message is the commit message, "Commit <number>" if empty.
+	Message string
+}
+
+// DefaultTime is the time of the first commit if Commit.When is zero.
+var DefaultTime = time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// NewRepository creates the in-memory repository with the linear history of the commits
+// on the master branch.
+func NewRepository(commits ...Commit) (*git.Repository, error) {
+	backend := memory.NewStorage()
+	repository, err := git.Init(backend, nil)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]plumbing.Hash{}
+	parent := plumbing.ZeroHash
+	when := DefaultTime
+	for i, commit := range commits {
+		for name, contents := range commit.Files {
+			if files[name], err = writeBlob(backend, contents); err != nil {
+				return nil, err
+			}
+		}
+		for _, name := range commit.Deleted {
+			if _, exists := files[name]; !exists {
+				return nil, fmt.Errorf("commit %d: cannot delete %s: no such file", i+1, name)
+			}
+			delete(files, name)
+		}
+		tree, err := writeTree(backend, files)
+		if err != nil {
+			return nil, err
+		}
+		if !commit.When.IsZero() {
+			when = commit.When
+		} else if i > 0 {
+			when = when.AddDate(0, 0, 1)
+		}
+		author := object.Signature{Name: commit.Author, Email: commit.Email, When: when}
+		if author.Name == "" {
+			author.Name = "author"
+		}
+		if author.Email == "" {
+			author.Email = strings.Replace(strings.ToLower(author.Name), " ", ".", -1) + "@example.com"
+		}
+		gitCommit := &object.Commit{
+			Author: author, Committer: author, Message: commit.Message, TreeHash: tree}
+		if gitCommit.Message == "" {
+			gitCommit.Message = fmt.Sprintf("Commit %d", i+1)
+		}
+		if parent != plumbing.ZeroHash {
+			gitCommit.ParentHashes = []plumbing.Hash{parent}
+		}
+		if parent, err = writeObject(backend, gitCommit); err != nil {
+			return nil, err
+		}
+	}
+	if parent != plumbing.ZeroHash {
+		err = backend.SetReference(plumbing.NewHashReference(plumbing.Master, parent))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return repository, nil
+}
+
+func writeBlob(backend storage.Storer, contents string) (plumbing.Hash, error) {
+	obj := backend.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+	obj.SetSize(int64(len(contents)))
+	writer, err := obj.Writer()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if _, err = writer.Write([]byte(contents)); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if err = writer.Close(); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return backend.SetEncodedObject(obj)
+}
+
+// writeTree writes the nested trees of the files which are specified by their paths.
+func writeTree(backend storage.Storer, files map[string]plumbing.Hash) (plumbing.Hash, error) {
+	tree := &object.Tree{}
+	dirs := map[string]map[string]plumbing.Hash{}
+	for name, hash := range files {
+		if slash := strings.IndexByte(name, '/'); slash >= 0 {
+			dir := dirs[name[:slash]]
+			if dir == nil {
+				dir = map[string]plumbing.Hash{}
+				dirs[name[:slash]] = dir
+			}
+			dir[name[slash+1:]] = hash
+			continue
+		}
+		tree.Entries = append(tree.Entries, object.TreeEntry{
+			Name: name, Mode: filemode.Regular, Hash: hash})
+	}
+	for name, dir := range dirs {
+		hash, err := writeTree(backend, dir)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		tree.Entries = append(tree.Entries, object.TreeEntry{
+			Name: name, Mode: filemode.Dir, Hash: hash})
+	}
+	// Git compares the directory names as if they ended with a slash
+	sortKey := func(entry object.TreeEntry) string {
+		if entry.Mode == filemode.Dir {
+			return entry.Name + "/"
+		}
+		return entry.Name
+	}
+	sort.Slice(tree.Entries, func(i, j int) bool {
+		return sortKey(tree.Entries[i]) < sortKey(tree.Entries[j])
+	})
+	return writeObject(backend, tree)
+}
+
+// encodableObject is either *object.Tree or *object.Commit.
+type encodableObject interface {
+	Encode(plumbing.EncodedObject) error
+}
+
+func writeObject(backend storage.Storer, encodable encodableObject) (plumbing.Hash, error) {
+	obj := backend.NewEncodedObject()
+	if err := encodable.Encode(obj); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return backend.SetEncodedObject(obj)
+}
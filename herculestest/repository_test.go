@@ -0,0 +1,54 @@
+package herculestest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4"
+)
+
+func fixtureRepositoryCommits() []Commit {
+	return []Commit{
+		{Files: map[string]string{"main.go": "package main\n", "pkg/util/util.go": "package util\n"},
+			Author: "Vadim Markovtsev"},
+		{Files: map[string]string{"pkg/util/util.go": "package util\n\nfunc Util() {}\n",
+			"pkg/README.md": "# pkg\n"}, Message: "Add Util"},
+		{Deleted: []string{"main.go"}, When: time.Date(2018, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+func TestNewRepository(t *testing.T) {
+	repository, err := NewRepository(fixtureRepositoryCommits()...)
+	assert.Nil(t, err)
+	commits := hercules.NewPipeline(repository).Commits()
+	assert.Len(t, commits, 3)
+	assert.Equal(t, commits[0].Author.Name, "Vadim Markovtsev")
+	assert.Equal(t, commits[0].Author.Email, "vadim.markovtsev@example.com")
+	assert.Equal(t, commits[0].Author.When.Unix(), DefaultTime.Unix())
+	assert.Equal(t, commits[0].Message, "Commit 1")
+	assert.Equal(t, commits[1].Author.Name, "author")
+	assert.Equal(t, commits[1].Author.When.Unix(), DefaultTime.AddDate(0, 0, 1).Unix())
+	assert.Equal(t, commits[1].Message, "Add Util")
+	assert.Equal(t, commits[2].Author.When.Unix(), time.Date(2018, 2, 1, 0, 0, 0, 0, time.UTC).Unix())
+	file, err := commits[1].File("pkg/util/util.go")
+	assert.Nil(t, err)
+	contents, err := file.Contents()
+	assert.Nil(t, err)
+	assert.Equal(t, contents, "package util\n\nfunc Util() {}\n")
+	tree, err := commits[2].Tree()
+	assert.Nil(t, err)
+	var names []string
+	tree.Files().ForEach(func(file *object.File) error {
+		names = append(names, file.Name)
+		return nil
+	})
+	assert.Equal(t, names, []string{"pkg/README.md", "pkg/util/util.go"})
+	_, err = NewRepository(Commit{Deleted: []string{"main.go"}})
+	assert.NotNil(t, err)
+	repository, err = NewRepository()
+	assert.Nil(t, err)
+	_, err = repository.Head()
+	assert.NotNil(t, err)
+}
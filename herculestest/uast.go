@@ -0,0 +1,47 @@
+package herculestest
+
+import (
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4"
+)
+
+// UASTNode creates a UAST node without the positions.
+func UASTNode(internalType string, token string, roles []uast.Role,
+	children ...*uast.Node) *uast.Node {
+	return &uast.Node{InternalType: internalType, Token: token, Roles: roles, Children: children}
+}
+
+// UASTFile creates the root node of a file.
+func UASTFile(children ...*uast.Node) *uast.Node {
+	return UASTNode("File", "", []uast.Role{uast.File}, children...)
+}
+
+// UASTFunction creates the function declaration which spans the lines from start to end,
+// inclusive, and has the name identifier as the first child. It matches the default XPath
+// queries of the Shotness analysis.
+func UASTFunction(name string, start uint32, end uint32, children ...*uast.Node) *uast.Node {
+	identifier := UASTNode("Identifier", name,
+		[]uast.Role{uast.Function, uast.Identifier, uast.Name})
+	identifier.StartPosition = &uast.Position{Line: start}
+	identifier.EndPosition = &uast.Position{Line: start}
+	node := UASTNode("FunctionDecl", "", []uast.Role{uast.Function, uast.Declaration},
+		append([]*uast.Node{identifier}, children...)...)
+	node.StartPosition = &uast.Position{Line: start}
+	node.EndPosition = &uast.Position{Line: end}
+	return node
+}
+
+// UASTChange creates the item of hercules.DependencyUastChanges. If before is nil, the file
+// was added; if after is nil, the file was deleted. The blob hashes are zero.
+func UASTChange(name string, before *uast.Node, after *uast.Node) hercules.UASTChange {
+	change := &object.Change{}
+	entry := object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Mode: 0100644}}
+	if before != nil {
+		change.From = entry
+	}
+	if after != nil {
+		change.To = entry
+	}
+	return hercules.UASTChange{Before: before, After: after, Change: change}
+}
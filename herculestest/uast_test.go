@@ -0,0 +1,36 @@
+package herculestest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+)
+
+func TestUASTFunction(t *testing.T) {
+	function := UASTFunction("main", 3, 5, UASTNode("Return", "", []uast.Role{uast.Return}))
+	assert.Equal(t, function.Roles, []uast.Role{uast.Function, uast.Declaration})
+	assert.Equal(t, function.StartPosition.Line, uint32(3))
+	assert.Equal(t, function.EndPosition.Line, uint32(5))
+	assert.Len(t, function.Children, 2)
+	assert.Equal(t, function.Children[0].Token, "main")
+	assert.Equal(t, function.Children[1].InternalType, "Return")
+	file := UASTFile(function)
+	assert.Equal(t, file.Roles, []uast.Role{uast.File})
+	assert.Equal(t, file.Children[0], function)
+}
+
+func TestUASTChange(t *testing.T) {
+	file := UASTFile()
+	for i, change := range []struct{ before, after *uast.Node }{
+		{file, file}, {nil, file}, {file, nil}} {
+		uastChange := UASTChange("a.go", change.before, change.after)
+		assert.Equal(t, uastChange.Before, change.before)
+		assert.Equal(t, uastChange.After, change.after)
+		action, err := uastChange.Change.Action()
+		assert.Nil(t, err)
+		assert.Equal(t, action, []merkletrie.Action{
+			merkletrie.Modify, merkletrie.Insert, merkletrie.Delete}[i])
+	}
+}
@@ -1,6 +1,7 @@
 package burndown
 
 import (
+	"encoding/binary"
 	"fmt"
 
 	"gopkg.in/src-d/hercules.v4/internal"
@@ -23,10 +24,18 @@ type Status struct {
 // Update() mutates File by introducing tree structural changes and updating the
 // length mapping.
 //
+// Values() returns the values of the individual lines.
+//
 // Dump() writes the tree to a string and Validate() checks the tree integrity.
+//
+// Hibernate() compresses the tree to save memory; it is restored on the next access.
+//
+// Bytes() encodes the tree in the same format and NewFileFromBytes() restores the File.
 type File struct {
 	tree     *rbtree.RBTree
 	statuses []Status
+	// hibernated is the varint-encoded tree while it is hibernated, see Hibernate().
+	hibernated []byte
 }
 
 // NewStatus initializes a new instance of Status struct. It is needed to set the only two
@@ -86,9 +95,19 @@ func NewFileFromTree(keys []int, vals []int, statuses ...Status) *File {
 	return file
 }
 
+// NewFileFromBytes is an alternative constructor for File which restores the tree written by
+// Bytes(). The statuses are not updated because they are supposed to be restored together
+// with the tree.
+func NewFileFromBytes(data []byte, statuses ...Status) *File {
+	file := &File{statuses: statuses, hibernated: data}
+	file.wake()
+	return file
+}
+
 // Len returns the File's size - that is, the maximum key in the tree of line
 // intervals.
 func (file *File) Len() int {
+	file.wake()
 	return file.tree.Max().Item().Key
 }
 
@@ -120,6 +139,7 @@ func (file *File) Update(time int, pos int, insLength int, delLength int) {
 	if insLength|delLength == 0 {
 		return
 	}
+	file.wake()
 	tree := file.tree
 	if tree.Len() < 2 && tree.Min().Item().Key != 0 {
 		panic("invalid tree state")
@@ -218,6 +238,25 @@ func (file *File) Update(time int, pos int, insLength int, delLength int) {
 	}
 }
 
+// Values returns the values of the lines in the range [pos, pos + length).
+func (file *File) Values(pos int, length int) []int {
+	file.wake()
+	if pos < 0 || length < 0 || pos+length > file.tree.Max().Item().Key {
+		panic(fmt.Sprintf("line range [%d, %d) is out of bounds [0, %d)",
+			pos, pos+length, file.tree.Max().Item().Key))
+	}
+	values := make([]int, 0, length)
+	iter := file.tree.FindLE(pos)
+	for len(values) < length {
+		end := internal.Min(iter.Next().Item().Key, pos+length)
+		for line := internal.Max(iter.Item().Key, pos); line < end; line++ {
+			values = append(values, iter.Item().Value)
+		}
+		iter = iter.Next()
+	}
+	return values
+}
+
 // Status returns the bound status object by the specified index.
 func (file *File) Status(index int) interface{} {
 	if index < 0 || index >= len(file.statuses) {
@@ -227,9 +266,33 @@ func (file *File) Status(index int) interface{} {
 	return file.statuses[index].data
 }
 
+// SetStatus replaces the bound status object by the specified index. The new status is updated
+// with the current line intervals as if all the lines were inserted at once.
+func (file *File) SetStatus(index int, status Status) {
+	if index < 0 || index >= len(file.statuses) {
+		panic(fmt.Sprintf("status index %d is out of bounds [0, %d)",
+			index, len(file.statuses)))
+	}
+	file.wake()
+	for iter := file.tree.Min(); !iter.Limit(); iter = iter.Next() {
+		item := iter.Item()
+		if item.Value == TreeEnd {
+			continue
+		}
+		status.update(status.data, item.Value, item.Value, iter.Next().Item().Key-item.Key)
+	}
+	file.statuses[index] = status
+}
+
+// NumStatuses returns the number of the bound status objects.
+func (file *File) NumStatuses() int {
+	return len(file.statuses)
+}
+
 // Dump formats the underlying line interval tree into a string.
 // Useful for error messages, panic()-s and debugging.
 func (file *File) Dump() string {
+	file.wake()
 	buffer := ""
 	for iter := file.tree.Min(); !iter.Limit(); iter = iter.Next() {
 		node := iter.Item()
@@ -248,6 +311,7 @@ func (file *File) Dump() string {
 //
 // 3. Node keys must monotonically increase and never duplicate.
 func (file *File) Validate() {
+	file.wake()
 	if file.tree.Min().Item().Key != 0 {
 		panic("the tree must start with key 0")
 	}
@@ -263,3 +327,58 @@ func (file *File) Validate() {
 		prevKey = node.Key
 	}
 }
+
+// Hibernate compresses the line interval tree to a byte buffer which is several times smaller.
+// The tree is restored automatically on the next call to Len(), Update(), Values(), Dump() or
+// Validate().
+func (file *File) Hibernate() {
+	if file.tree == nil {
+		return
+	}
+	file.hibernated = file.encodeTree()
+	file.tree = nil
+}
+
+// Bytes returns the line interval tree encoded in the same compact format as in Hibernate().
+func (file *File) Bytes() []byte {
+	if file.tree == nil {
+		return append([]byte{}, file.hibernated...)
+	}
+	return file.encodeTree()
+}
+
+// encodeTree writes the keys and the values of the tree as varints.
+func (file *File) encodeTree() []byte {
+	buffer := make([]byte, 0, file.tree.Len()*4)
+	scratch := make([]byte, binary.MaxVarintLen64)
+	previousKey := 0
+	for iter := file.tree.Min(); !iter.Limit(); iter = iter.Next() {
+		item := iter.Item()
+		// the keys increase, so the deltas are small
+		size := binary.PutUvarint(scratch, uint64(item.Key-previousKey))
+		buffer = append(buffer, scratch[:size]...)
+		size = binary.PutVarint(scratch, int64(item.Value))
+		buffer = append(buffer, scratch[:size]...)
+		previousKey = item.Key
+	}
+	return buffer
+}
+
+// wake restores the tree after Hibernate().
+func (file *File) wake() {
+	if file.tree != nil {
+		return
+	}
+	tree := new(rbtree.RBTree)
+	key := 0
+	for buffer := file.hibernated; len(buffer) > 0; {
+		delta, size := binary.Uvarint(buffer)
+		buffer = buffer[size:]
+		value, size := binary.Varint(buffer)
+		buffer = buffer[size:]
+		key += int(delta)
+		tree.Insert(rbtree.Item{Key: key, Value: int(value)})
+	}
+	file.tree = tree
+	file.hibernated = nil
+}
@@ -397,6 +397,22 @@ func TestFileStatus(t *testing.T) {
 	assert.NotNil(t, f.Status(0))
 }
 
+func TestFileSetStatus(t *testing.T) {
+	file, status := fixtureFile()
+	file.Update(1, 20, 30, 0)
+	file.Update(2, 10, 0, 15)
+	file.Hibernate()
+	replaced := map[int]int64{}
+	file.SetStatus(0, NewStatus(replaced, updateStatusFile))
+	assert.Equal(t, replaced, map[int]int64{0: 90, 1: 25})
+	assert.Equal(t, status[0], int64(90))
+	assert.Equal(t, status[1], int64(25))
+	file.Update(3, 0, 5, 0)
+	assert.Equal(t, replaced[3], int64(5))
+	assert.Equal(t, status[3], int64(0))
+	assert.Panics(t, func() { file.SetStatus(1, NewStatus(replaced, updateStatusFile)) })
+}
+
 func TestFileValidate(t *testing.T) {
 	keys := [...]int{0}
 	vals := [...]int{-1}
@@ -418,3 +434,60 @@ func TestFileValidate(t *testing.T) {
 	file.tree.FindGE(2).Item().Key = 1
 	assert.Panics(t, func() { file.Validate() })
 }
+
+func TestFileHibernate(t *testing.T) {
+	file, status := fixtureFile()
+	file.Update(1, 20, 30, 0)
+	file.Update(2, 10, 0, 15)
+	file.Update(3, 50, 5, 10)
+	dump := file.Dump()
+	file.Hibernate()
+	assert.Nil(t, file.tree)
+	assert.NotEmpty(t, file.hibernated)
+	file.Hibernate()
+	assert.Equal(t, file.Dump(), dump)
+	assert.NotNil(t, file.tree)
+	assert.Nil(t, file.hibernated)
+	file.Hibernate()
+	file.Update(4, 0, 10, 0)
+	file.Validate()
+	assert.Equal(t, file.Len(), 120)
+	assert.Equal(t, status[4], int64(10))
+	empty := NewFile(0, 0)
+	empty.Hibernate()
+	assert.Equal(t, empty.Len(), 0)
+}
+
+func TestFileBytes(t *testing.T) {
+	file, status := fixtureFile()
+	file.Update(1, 20, 30, 0)
+	file.Update(2, 10, 0, 15)
+	dump := file.Dump()
+	data := file.Bytes()
+	file.Hibernate()
+	assert.Equal(t, file.Bytes(), data)
+	restored := NewFileFromBytes(data, NewStatus(status, updateStatusFile))
+	assert.Equal(t, restored.Dump(), dump)
+	assert.Equal(t, restored.NumStatuses(), 1)
+	assert.Equal(t, status[1], int64(25))
+	restored.Update(3, 0, 10, 0)
+	restored.Validate()
+	assert.Equal(t, restored.Len(), 125)
+	assert.Equal(t, status[3], int64(10))
+	assert.Equal(t, file.Dump(), dump)
+}
+
+func TestFileValues(t *testing.T) {
+	file, _ := fixtureFile()
+	// 0 - 100 with value 0
+	file.Update(1, 20, 5, 0)
+	file.Update(2, 22, 2, 0)
+	assert.Equal(t, file.Values(18, 11), []int{0, 0, 1, 1, 2, 2, 1, 1, 1, 0, 0})
+	assert.Equal(t, file.Values(106, 1), []int{0})
+	assert.Len(t, file.Values(0, 0), 0)
+	assert.Len(t, file.Values(0, 107), 107)
+	file.Hibernate()
+	assert.Equal(t, file.Values(23, 2), []int{2, 1})
+	assert.Panics(t, func() { file.Values(100, 8) })
+	assert.Panics(t, func() { file.Values(-1, 2) })
+}
@@ -0,0 +1,123 @@
+// Package calendar renders GitHub-style calendar heatmaps of the daily activity as SVG.
+// Each year is a separate row of weeks; each week is a column of seven days starting
+// from Sunday. The cells are colored in five levels according to the quartiles of
+// the non-zero values.
+package calendar
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"time"
+)
+
+const (
+	// CellSize is the side of a day square in pixels.
+	CellSize = 11
+	// cellGap is the distance between the day squares.
+	cellGap = 2
+	// leftMargin is the space for the weekday labels.
+	leftMargin = 30
+	// topMargin is the space for the title.
+	topMargin = 20
+	// yearHeaderHeight is the space for the year and the month labels above each year.
+	yearHeaderHeight = 30
+	// weeksInYear is the maximum number of week columns in a year.
+	weeksInYear = 54
+)
+
+// Colors are the fill colors of the activity levels, from no activity to the highest.
+var Colors = [...]string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"}
+
+// Day truncates the time to the beginning of the UTC day, which is the key format
+// in the activity maps.
+func Day(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// Levels calculates the thresholds which separate the activity levels: the quartiles of
+// the non-zero values. A value v belongs to level i if thresholds[i-1] < v <= thresholds[i].
+func Levels(activity map[time.Time]int64) [len(Colors) - 1]int64 {
+	var values []int64
+	for _, value := range activity {
+		if value > 0 {
+			values = append(values, value)
+		}
+	}
+	var thresholds [len(Colors) - 1]int64
+	if len(values) == 0 {
+		return thresholds
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	for i := range thresholds {
+		thresholds[i] = values[(len(values)-1)*(i+1)/len(thresholds)]
+	}
+	return thresholds
+}
+
+func level(value int64, thresholds [len(Colors) - 1]int64) int {
+	if value <= 0 {
+		return 0
+	}
+	for i, threshold := range thresholds {
+		if value <= threshold {
+			return i + 1
+		}
+	}
+	return len(thresholds)
+}
+
+// Render writes the SVG calendar heatmap of the daily activity. The keys of `activity`
+// must be the results of Day(). `unit` names the values in the tooltips, e.g. "commits".
+func Render(writer io.Writer, activity map[time.Time]int64, title string, unit string) error {
+	if len(activity) == 0 {
+		return fmt.Errorf("there is no activity to render")
+	}
+	var first, last time.Time
+	for day := range activity {
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+		if day.After(last) {
+			last = day
+		}
+	}
+	thresholds := Levels(activity)
+	years := last.Year() - first.Year() + 1
+	step := CellSize + cellGap
+	width := leftMargin + weeksInYear*step
+	yearHeight := yearHeaderHeight + 7*step
+	height := topMargin + years*yearHeight
+	fmt.Fprintf(writer, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" `+
+		`font-family="sans-serif" font-size="9">`+"\n", width, height)
+	fmt.Fprintf(writer, `  <text x="0" y="12" font-size="12">%s</text>`+"\n", html.EscapeString(title))
+	for year := first.Year(); year <= last.Year(); year++ {
+		top := topMargin + (year-first.Year())*yearHeight
+		fmt.Fprintf(writer, `  <g transform="translate(0,%d)">`+"\n", top)
+		fmt.Fprintf(writer, `    <text x="0" y="10" font-size="11">%d</text>`+"\n", year)
+		for i, weekday := range [...]string{"Mon", "Wed", "Fri"} {
+			fmt.Fprintf(writer, `    <text x="0" y="%d">%s</text>`+"\n",
+				yearHeaderHeight+(2*i+1)*step+CellSize-2, weekday)
+		}
+		january1 := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		offset := int(january1.Weekday())
+		for month := time.January; month <= time.December; month++ {
+			day := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+			week := (day.YearDay() - 1 + offset) / 7
+			fmt.Fprintf(writer, `    <text x="%d" y="%d">%s</text>`+"\n",
+				leftMargin+week*step, yearHeaderHeight-4, month.String()[:3])
+		}
+		for day := january1; day.Year() == year; day = day.AddDate(0, 0, 1) {
+			week := (day.YearDay() - 1 + offset) / 7
+			value := activity[day]
+			fmt.Fprintf(writer, `    <rect x="%d" y="%d" width="%d" height="%d" fill="%s">`+
+				`<title>%s: %d %s</title></rect>`+"\n",
+				leftMargin+week*step, yearHeaderHeight+int(day.Weekday())*step, CellSize, CellSize,
+				Colors[level(value, thresholds)], day.Format("2006-01-02"), value, html.EscapeString(unit))
+		}
+		fmt.Fprintln(writer, "  </g>")
+	}
+	_, err := fmt.Fprintln(writer, "</svg>")
+	return err
+}
@@ -0,0 +1,58 @@
+package calendar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDay(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*3600)
+	assert.Equal(t, Day(time.Date(2018, 3, 1, 1, 30, 0, 0, moscow)), date(2018, 2, 28))
+	assert.Equal(t, Day(time.Date(2018, 3, 1, 23, 59, 0, 0, time.UTC)), date(2018, 3, 1))
+}
+
+func TestLevels(t *testing.T) {
+	activity := map[time.Time]int64{}
+	assert.Equal(t, Levels(activity), [4]int64{})
+	for i := 1; i <= 8; i++ {
+		activity[date(2018, 1, i)] = int64(i)
+	}
+	activity[date(2018, 2, 1)] = 0
+	thresholds := Levels(activity)
+	assert.Equal(t, thresholds, [4]int64{2, 4, 6, 8})
+	assert.Equal(t, level(0, thresholds), 0)
+	assert.Equal(t, level(1, thresholds), 1)
+	assert.Equal(t, level(3, thresholds), 2)
+	assert.Equal(t, level(8, thresholds), 4)
+	assert.Equal(t, level(100, thresholds), 4)
+}
+
+func TestRender(t *testing.T) {
+	activity := map[time.Time]int64{
+		date(2017, 12, 31): 1,
+		date(2018, 1, 1):   10,
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, Render(buffer, activity, "src-d/<hercules>", "commits"))
+	svg := buffer.String()
+	assert.True(t, strings.HasPrefix(svg, "<svg "))
+	assert.True(t, strings.HasSuffix(svg, "</svg>\n"))
+	assert.Contains(t, svg, "src-d/&lt;hercules&gt;")
+	assert.Contains(t, svg, ">2017</text>")
+	assert.Contains(t, svg, ">2018</text>")
+	assert.Equal(t, strings.Count(svg, "<rect "), 365*2)
+	// 2017-12-31 is Sunday in the 53rd week (index 52), 2018-01-01 is Monday in the first week
+	assert.Contains(t, svg, `<rect x="706" y="30" width="11" height="11" fill="#9be9a8">`+
+		`<title>2017-12-31: 1 commits</title></rect>`)
+	assert.Contains(t, svg, `<rect x="30" y="43" width="11" height="11" fill="#216e39">`+
+		`<title>2018-01-01: 10 commits</title></rect>`)
+	assert.NotNil(t, Render(buffer, map[time.Time]int64{}, "", ""))
+}
@@ -0,0 +1,239 @@
+package core
+
+import (
+	"container/heap"
+	"fmt"
+
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// AllCommits returns all the commits which are reachable from HEAD, including the merged
+// branches, in the topological order: each commit goes after its parents. The commits which
+// do not depend on each other are ordered by the commit time, so that the branches interleave
+// the same way as they were developed. The parents which are missing in a shallow clone
+// are ignored.
+func (pipeline *Pipeline) AllCommits() []*object.Commit {
+	repository := pipeline.repository
+	head, err := repository.Head()
+	if err != nil {
+		panic(err)
+	}
+	commit, err := repository.CommitObject(head.Hash())
+	if err != nil {
+		panic(err)
+	}
+	visited := map[plumbing.Hash]bool{commit.Hash: true}
+	children := map[plumbing.Hash][]*object.Commit{}
+	// pending is the number of the parents of each commit which have not been emitted yet
+	pending := map[plumbing.Hash]int{}
+	ready := &commitsByTime{}
+	for stack := []*object.Commit{commit}; len(stack) > 0; {
+		commit = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, hash := range commit.ParentHashes {
+			if !visited[hash] {
+				parent, err := repository.CommitObject(hash)
+				if err == plumbing.ErrObjectNotFound {
+					continue
+				}
+				if err != nil {
+					panic(err)
+				}
+				visited[hash] = true
+				stack = append(stack, parent)
+			}
+			children[hash] = append(children[hash], commit)
+			pending[commit.Hash]++
+		}
+		if pending[commit.Hash] == 0 {
+			*ready = append(*ready, commit)
+		}
+	}
+	heap.Init(ready)
+	result := make([]*object.Commit, 0, len(visited))
+	for ready.Len() > 0 {
+		commit = heap.Pop(ready).(*object.Commit)
+		result = append(result, commit)
+		for _, child := range children[commit.Hash] {
+			pending[child.Hash]--
+			if pending[child.Hash] == 0 {
+				heap.Push(ready, child)
+			}
+		}
+	}
+	return result
+}
+
+// commitsByTime is the min-heap of the commits by the commit time.
+type commitsByTime []*object.Commit
+
+func (commits commitsByTime) Len() int {
+	return len(commits)
+}
+
+func (commits commitsByTime) Less(i, j int) bool {
+	ti, tj := commits[i].Committer.When, commits[j].Committer.When
+	if !ti.Equal(tj) {
+		return ti.Before(tj)
+	}
+	return commits[i].Hash.String() < commits[j].Hash.String()
+}
+
+func (commits commitsByTime) Swap(i, j int) {
+	commits[i], commits[j] = commits[j], commits[i]
+}
+
+func (commits *commitsByTime) Push(x interface{}) {
+	*commits = append(*commits, x.(*object.Commit))
+}
+
+func (commits *commitsByTime) Pop() interface{} {
+	n := len(*commits)
+	commit := (*commits)[n-1]
+	*commits = (*commits)[:n-1]
+	return commit
+}
+
+// branchTracker assigns the copies of the ForkablePipelineItem-s to the commits in
+// the ConfigPipelineBranches mode. Each commit continues the state of its first analysed
+// parent and merges the states of the rest. The original items follow the first-parent
+// chain of the last commit, so that Finalize() and Dump() see the whole history.
+type branchTracker struct {
+	// items are the original items of the pipeline.
+	items []PipelineItem
+	// forkable are the indexes of the ForkablePipelineItem-s in items.
+	forkable []int
+	// parents are the positions of the analysed parents of each commit in the sequence.
+	parents [][]int
+	// children is the number of the commits which still need the state after each commit.
+	children []int
+	// mainline marks the commits which are consumed by the original items.
+	mainline []bool
+	// heads are the states after the commits which are still needed by their children.
+	heads map[int][]PipelineItem
+	// roots are the pristine copies of the items for the commits without the analysed parents
+	// outside of the mainline, e.g. the unrelated histories which were merged later.
+	roots [][]PipelineItem
+}
+
+// newBranchTracker indexes the parents of the commits. It fails if a commit goes before
+// one of its parents.
+func newBranchTracker(items []PipelineItem, commits []*object.Commit) (*branchTracker, error) {
+	tracker := &branchTracker{
+		items:    items,
+		parents:  make([][]int, len(commits)),
+		children: make([]int, len(commits)),
+		mainline: make([]bool, len(commits)),
+		heads:    map[int][]PipelineItem{},
+	}
+	for i, item := range items {
+		if _, ok := Unwrap(item).(ForkablePipelineItem); ok {
+			tracker.forkable = append(tracker.forkable, i)
+		}
+	}
+	positions := make(map[plumbing.Hash]int, len(commits))
+	for i, commit := range commits {
+		positions[commit.Hash] = i
+	}
+	roots := 0
+	for i, commit := range commits {
+		for _, hash := range commit.ParentHashes {
+			parent, exists := positions[hash]
+			if !exists {
+				continue
+			}
+			if parent > i {
+				return nil, fmt.Errorf("commit %s goes before its parent %s, the commits must be "+
+					"ordered topologically", commit.Hash.String(), hash.String())
+			}
+			tracker.parents[i] = append(tracker.parents[i], parent)
+			tracker.children[parent]++
+		}
+		if len(tracker.parents[i]) == 0 {
+			roots++
+		}
+	}
+	for i := len(commits) - 1; i >= 0; {
+		tracker.mainline[i] = true
+		if len(tracker.parents[i]) == 0 {
+			break
+		}
+		i = tracker.parents[i][0]
+	}
+	if roots > 1 {
+		tracker.roots = tracker.fork(items, roots-1)
+	}
+	return tracker, nil
+}
+
+// fork copies the state n times. The items which are not forkable are shared.
+func (tracker *branchTracker) fork(state []PipelineItem, n int) [][]PipelineItem {
+	copies := make([][]PipelineItem, n)
+	for i := range copies {
+		copies[i] = make([]PipelineItem, len(state))
+		copy(copies[i], state)
+	}
+	for _, index := range tracker.forkable {
+		for i, clone := range state[index].(ForkablePipelineItem).Fork(n) {
+			copies[i][index] = clone
+		}
+	}
+	return copies
+}
+
+// checkout returns the items which must consume the commit at the specified position.
+// The states of the merged branches are passed to Merge().
+func (tracker *branchTracker) checkout(position int) []PipelineItem {
+	parents := tracker.parents[position]
+	if len(parents) == 0 {
+		if tracker.mainline[position] {
+			return tracker.items
+		}
+		state := tracker.roots[0]
+		tracker.roots = tracker.roots[1:]
+		return state
+	}
+	state := tracker.take(parents[0], position)
+	if len(parents) == 1 {
+		return state
+	}
+	merged := make([][]PipelineItem, len(parents)-1)
+	for i, parent := range parents[1:] {
+		merged[i] = tracker.take(parent, position)
+	}
+	for _, index := range tracker.forkable {
+		branches := make([]PipelineItem, len(merged))
+		for i, branch := range merged {
+			branches[i] = branch[index]
+		}
+		state[index].(ForkablePipelineItem).Merge(branches)
+	}
+	return state
+}
+
+// take returns the state after the parent for the child. The last child receives the state
+// itself and the rest receive the copies, except that the original items always continue
+// the mainline.
+func (tracker *branchTracker) take(parent, child int) []PipelineItem {
+	state := tracker.heads[parent]
+	tracker.children[parent]--
+	if tracker.children[parent] == 0 {
+		delete(tracker.heads, parent)
+		return state
+	}
+	copies := tracker.fork(state, 1)
+	if tracker.mainline[child] && tracker.parents[child][0] == parent {
+		tracker.heads[parent] = copies[0]
+		return state
+	}
+	return copies[0]
+}
+
+// commit records the state after the commit at the specified position if the children
+// need it.
+func (tracker *branchTracker) commit(position int, state []PipelineItem) {
+	if tracker.children[position] > 0 {
+		tracker.heads[position] = state
+	}
+}
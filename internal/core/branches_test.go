@@ -0,0 +1,248 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+// forkableTestPipelineItem collects the commits of its line of development.
+type forkableTestPipelineItem struct {
+	Consumed []plumbing.Hash
+	// forks is the total number of the copies made by Fork().
+	forks *int
+}
+
+func (item *forkableTestPipelineItem) Name() string {
+	return "Forkable"
+}
+
+func (item *forkableTestPipelineItem) Provides() []string {
+	return []string{}
+}
+
+func (item *forkableTestPipelineItem) Requires() []string {
+	return []string{}
+}
+
+func (item *forkableTestPipelineItem) ListConfigurationOptions() []ConfigurationOption {
+	return nil
+}
+
+func (item *forkableTestPipelineItem) Configure(facts map[string]interface{}) {
+}
+
+func (item *forkableTestPipelineItem) Initialize(repository *git.Repository) {
+	item.forks = new(int)
+}
+
+func (item *forkableTestPipelineItem) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	item.Consumed = append(item.Consumed, deps["commit"].(*object.Commit).Hash)
+	return nil, nil
+}
+
+func (item *forkableTestPipelineItem) Fork(n int) []PipelineItem {
+	*item.forks += n
+	clones := make([]PipelineItem, n)
+	for i := range clones {
+		clones[i] = &forkableTestPipelineItem{
+			Consumed: append([]plumbing.Hash{}, item.Consumed...), forks: item.forks}
+	}
+	return clones
+}
+
+func (item *forkableTestPipelineItem) Merge(branches []PipelineItem) {
+	for _, branch := range branches {
+		for _, hash := range branch.(*forkableTestPipelineItem).Consumed {
+			exists := false
+			for _, other := range item.Consumed {
+				if other == hash {
+					exists = true
+					break
+				}
+			}
+			if !exists {
+				item.Consumed = append(item.Consumed, hash)
+			}
+		}
+	}
+}
+
+// fixtureBranchCommits creates the history where 2 and 3 branch from 1 and 5 merges 4 into 2:
+//
+//	1 - 2 ------- 5 - 6
+//	 \           /
+//	  3 ------- 4
+func fixtureBranchCommits() []*object.Commit {
+	commits := fixtureStateCommits(6)
+	parents := [][]int{nil, {0}, {0}, {2}, {1, 3}, {4}}
+	for i, commit := range commits {
+		for _, parent := range parents[i] {
+			commit.ParentHashes = append(commit.ParentHashes, commits[parent].Hash)
+		}
+		commit.Committer = commit.Author
+	}
+	return commits
+}
+
+func fixtureBranchPipeline(facts map[string]interface{}) (
+	*Pipeline, *forkableTestPipelineItem, *persistentTestPipelineItem) {
+	pipeline := NewPipeline(test.Repository)
+	forkable := &forkableTestPipelineItem{}
+	shared := &persistentTestPipelineItem{}
+	pipeline.AddItem(forkable)
+	pipeline.AddItem(shared)
+	facts[ConfigPipelineCommits] = []*object.Commit{}
+	facts[ConfigPipelineBranches] = true
+	pipeline.Initialize(facts)
+	return pipeline, forkable, shared
+}
+
+func hashes(commits ...*object.Commit) []plumbing.Hash {
+	result := make([]plumbing.Hash, len(commits))
+	for i, commit := range commits {
+		result[i] = commit.Hash
+	}
+	return result
+}
+
+func TestPipelineBranches(t *testing.T) {
+	c := fixtureBranchCommits()
+	for _, order := range [][]int{{0, 1, 2, 3, 4, 5}, {0, 2, 1, 3, 4, 5}, {0, 2, 3, 1, 4, 5}} {
+		for _, workers := range []int{1, 4} {
+			pipeline, forkable, shared := fixtureBranchPipeline(
+				map[string]interface{}{ConfigPipelineWorkers: workers})
+			assert.True(t, pipeline.branches)
+			commits := make([]*object.Commit, len(order))
+			for i, index := range order {
+				commits[i] = c[index]
+			}
+			result, err := pipeline.Run(commits)
+			assert.Nil(t, err)
+			assert.Equal(t, result[nil].(*CommonAnalysisResult).CommitsNumber, 6)
+			// the branch is merged before the merge commit is consumed
+			assert.Equal(t, forkable.Consumed, hashes(c[0], c[1], c[2], c[3], c[4], c[5]), order)
+			assert.Equal(t, *forkable.forks, 1)
+			assert.Equal(t, shared.Consumed, hashes(commits...))
+			assert.Equal(t, shared.Indexes, []int{0, 1, 2, 3, 4, 5})
+			assert.Equal(t, pipeline.items[0], forkable)
+		}
+	}
+}
+
+func TestPipelineBranchesUnrelatedHistories(t *testing.T) {
+	// 2 is another root which is merged by 4
+	commits := fixtureStateCommits(4)
+	commits[2].ParentHashes = []plumbing.Hash{commits[0].Hash}
+	commits[3].ParentHashes = []plumbing.Hash{commits[2].Hash, commits[1].Hash}
+	pipeline, forkable, _ := fixtureBranchPipeline(map[string]interface{}{})
+	_, err := pipeline.Run(commits)
+	assert.Nil(t, err)
+	assert.Equal(t, forkable.Consumed, hashes(commits[0], commits[2], commits[1], commits[3]))
+	assert.Equal(t, *forkable.forks, 1)
+}
+
+func TestPipelineBranchesDanglingHead(t *testing.T) {
+	// 3 branches from 1 and is never merged
+	commits := fixtureStateCommits(4)
+	commits[1].ParentHashes = []plumbing.Hash{commits[0].Hash}
+	commits[2].ParentHashes = []plumbing.Hash{commits[0].Hash}
+	commits[3].ParentHashes = []plumbing.Hash{commits[1].Hash}
+	pipeline, forkable, shared := fixtureBranchPipeline(map[string]interface{}{})
+	_, err := pipeline.Run(commits)
+	assert.Nil(t, err)
+	assert.Equal(t, forkable.Consumed, hashes(commits[0], commits[1], commits[3]))
+	assert.Len(t, shared.Consumed, 4)
+}
+
+func TestPipelineBranchesOrder(t *testing.T) {
+	c := fixtureBranchCommits()
+	pipeline, forkable, _ := fixtureBranchPipeline(map[string]interface{}{})
+	result, err := pipeline.Run([]*object.Commit{c[0], c[3], c[2]})
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "commit "+c[3].Hash.String()+" goes before its parent "+
+		c[2].Hash.String()+", the commits must be ordered topologically")
+	assert.Len(t, forkable.Consumed, 0)
+	assert.Equal(t, pipeline.items[0], forkable)
+}
+
+// linearTestPipelineItem must consume a single line of development.
+type linearTestPipelineItem struct {
+	persistentTestPipelineItem
+	linear bool
+}
+
+func (item *linearTestPipelineItem) Name() string {
+	return "Linear"
+}
+
+func (item *linearTestPipelineItem) RequiresLinearHistory() bool {
+	return item.linear
+}
+
+func TestPipelineBranchesLinear(t *testing.T) {
+	for _, linear := range []bool{false, true} {
+		for _, branches := range []bool{false, true} {
+			pipeline := NewPipeline(test.Repository)
+			pipeline.AddItem(&forkableTestPipelineItem{})
+			pipeline.AddItem(&linearTestPipelineItem{linear: linear})
+			initialize := func() {
+				pipeline.Initialize(map[string]interface{}{
+					ConfigPipelineCommits: []*object.Commit{}, ConfigPipelineBranches: branches})
+			}
+			if linear && branches {
+				assert.PanicsWithError(t,
+					"Linear cannot follow the branches, disable Pipeline.Branches", initialize)
+			} else {
+				assert.NotPanics(t, initialize)
+			}
+		}
+	}
+}
+
+func TestPipelineAllCommits(t *testing.T) {
+	storage := memory.NewStorage()
+	c := fixtureBranchCommits()
+	// 3 is committed before 2
+	c[1].Committer.When, c[2].Committer.When = c[2].Committer.When, c[1].Committer.When
+	// the parents are stored first, so their real hashes are known
+	for i, commit := range c {
+		obj := storage.NewEncodedObject()
+		assert.Nil(t, commit.Encode(obj))
+		hash, err := storage.SetEncodedObject(obj)
+		assert.Nil(t, err)
+		for _, other := range c[i+1:] {
+			for j, parent := range other.ParentHashes {
+				if parent == commit.Hash {
+					other.ParentHashes[j] = hash
+				}
+			}
+		}
+		commit.Hash = hash
+	}
+	assert.Nil(t, storage.SetReference(plumbing.NewHashReference(plumbing.HEAD, c[5].Hash)))
+	repository, err := git.Open(storage, nil)
+	assert.Nil(t, err)
+	pipeline := NewPipeline(repository)
+	commits := pipeline.AllCommits()
+	assert.Equal(t, hashes(commits...), hashes(c[0], c[2], c[1], c[3], c[4], c[5]))
+	assert.Len(t, pipeline.Commits(), 4)
+	facts := map[string]interface{}{ConfigPipelineBranches: true}
+	pipeline.Initialize(facts)
+	assert.Len(t, facts[ConfigPipelineCommits], 6)
+}
+
+func TestBranchTrackerMainline(t *testing.T) {
+	c := fixtureBranchCommits()
+	tracker, err := newBranchTracker(nil, c)
+	assert.Nil(t, err)
+	assert.Equal(t, tracker.mainline, []bool{true, true, false, false, true, true})
+	assert.Equal(t, tracker.parents, [][]int{nil, {0}, {0}, {2}, {1, 3}, {4}})
+	assert.Equal(t, tracker.children, []int{2, 1, 1, 1, 1, 0})
+	assert.Len(t, tracker.roots, 0)
+}
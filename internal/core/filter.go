@@ -0,0 +1,579 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// CommitFilter is a compiled boolean expression which is evaluated on each commit to decide
+// whether it should be analysed. The grammar is:
+//
+//	expression = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | "(" expression ")" | comparison
+//	comparison = operand [ ("==" | "!=" | "<" | "<=" | ">" | ">=" | "=~" | "!~") operand ]
+//	operand    = field { "." method "(" [ string ] ")" } | string | number
+//
+// The fields are author.name, author.email, author.date, committer.name, committer.email,
+// committer.date, message, hash, parents (the number of parents), merge (more than one parent)
+// and files (the number of files changed against the first parent). The string methods are
+// contains(), startsWith(), endsWith() and lower(). "=~" and "!~" match regular expressions.
+// The dates are compared with the strings in RFC3339 or "2006-01-02" formats.
+// For example,
+//
+//	author.email =~ "@corp.com" && files < 500 && !message.contains("vendor")
+type CommitFilter struct {
+	source string
+	root   filterNode
+}
+
+// ParseCommitFilter compiles the commit filter expression. See CommitFilter for the syntax.
+func ParseCommitFilter(expression string) (*CommitFilter, error) {
+	tokens, err := tokenizeFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+	parser := &filterParser{tokens: tokens}
+	root, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos < len(parser.tokens) {
+		return nil, fmt.Errorf("unexpected %q at position %d",
+			parser.tokens[parser.pos].text, parser.tokens[parser.pos].pos)
+	}
+	return &CommitFilter{source: expression, root: root}, nil
+}
+
+// String returns the source expression.
+func (filter *CommitFilter) String() string {
+	return filter.source
+}
+
+// Match evaluates the filter on the commit.
+func (filter *CommitFilter) Match(commit *object.Commit) (bool, error) {
+	val, err := filter.root.eval(&filterContext{commit: commit, files: -1})
+	if err != nil {
+		return false, fmt.Errorf("%s: %v", commit.Hash.String(), err)
+	}
+	result, ok := val.(bool)
+	if !ok {
+		return false, fmt.Errorf("the filter must evaluate to a boolean, got %v", val)
+	}
+	return result, nil
+}
+
+// FilterCommits returns the commits which match the filter, preserving the order.
+func FilterCommits(commits []*object.Commit, filter *CommitFilter) ([]*object.Commit, error) {
+	result := make([]*object.Commit, 0, len(commits))
+	for _, commit := range commits {
+		matches, err := filter.Match(commit)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			result = append(result, commit)
+		}
+	}
+	return result, nil
+}
+
+// filterContext is the commit on which the filter is evaluated and the lazily calculated
+// properties of it.
+type filterContext struct {
+	commit *object.Commit
+	// files is the number of changed files, -1 means not calculated yet.
+	files int
+}
+
+func (ctx *filterContext) changedFiles() (int, error) {
+	if ctx.files >= 0 {
+		return ctx.files, nil
+	}
+	tree, err := ctx.commit.Tree()
+	if err != nil {
+		return 0, err
+	}
+	var parentTree *object.Tree
+	if ctx.commit.NumParents() > 0 {
+		parent, err := ctx.commit.Parent(0)
+		if err != nil {
+			return 0, err
+		}
+		parentTree, err = parent.Tree()
+		if err != nil {
+			return 0, err
+		}
+	}
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return 0, err
+	}
+	ctx.files = len(changes)
+	return ctx.files, nil
+}
+
+type filterNode interface {
+	// eval returns string, int, bool or time.Time.
+	eval(ctx *filterContext) (interface{}, error)
+}
+
+type filterLiteral struct {
+	value interface{}
+}
+
+func (node filterLiteral) eval(*filterContext) (interface{}, error) {
+	return node.value, nil
+}
+
+type filterField struct {
+	name string
+}
+
+var filterFields = map[string]func(ctx *filterContext) (interface{}, error){
+	"author.name":     func(ctx *filterContext) (interface{}, error) { return ctx.commit.Author.Name, nil },
+	"author.email":    func(ctx *filterContext) (interface{}, error) { return ctx.commit.Author.Email, nil },
+	"author.date":     func(ctx *filterContext) (interface{}, error) { return ctx.commit.Author.When, nil },
+	"committer.name":  func(ctx *filterContext) (interface{}, error) { return ctx.commit.Committer.Name, nil },
+	"committer.email": func(ctx *filterContext) (interface{}, error) { return ctx.commit.Committer.Email, nil },
+	"committer.date":  func(ctx *filterContext) (interface{}, error) { return ctx.commit.Committer.When, nil },
+	"message":         func(ctx *filterContext) (interface{}, error) { return ctx.commit.Message, nil },
+	"hash":            func(ctx *filterContext) (interface{}, error) { return ctx.commit.Hash.String(), nil },
+	"parents":         func(ctx *filterContext) (interface{}, error) { return ctx.commit.NumParents(), nil },
+	"merge":           func(ctx *filterContext) (interface{}, error) { return ctx.commit.NumParents() > 1, nil },
+	"files":           func(ctx *filterContext) (interface{}, error) { return ctx.changedFiles() },
+}
+
+func (node filterField) eval(ctx *filterContext) (interface{}, error) {
+	return filterFields[node.name](ctx)
+}
+
+type filterMethod struct {
+	object filterNode
+	name   string
+	arg    string
+}
+
+var filterMethods = map[string]bool{
+	"contains": true, "startsWith": true, "endsWith": true, "lower": false,
+}
+
+func (node filterMethod) eval(ctx *filterContext) (interface{}, error) {
+	val, err := node.object.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	str, ok := val.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s() is called on a non-string value %v", node.name, val)
+	}
+	switch node.name {
+	case "contains":
+		return strings.Contains(str, node.arg), nil
+	case "startsWith":
+		return strings.HasPrefix(str, node.arg), nil
+	case "endsWith":
+		return strings.HasSuffix(str, node.arg), nil
+	}
+	return strings.ToLower(str), nil
+}
+
+type filterNot struct {
+	operand filterNode
+}
+
+func (node filterNot) eval(ctx *filterContext) (interface{}, error) {
+	val, err := node.operand.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	boolean, ok := val.(bool)
+	if !ok {
+		return nil, fmt.Errorf("\"!\" is applied to a non-boolean value %v", val)
+	}
+	return !boolean, nil
+}
+
+type filterLogical struct {
+	and         bool
+	left, right filterNode
+}
+
+func (node filterLogical) eval(ctx *filterContext) (interface{}, error) {
+	left, err := evalBool(node.left, ctx)
+	if err != nil {
+		return nil, err
+	}
+	// short circuit
+	if left != node.and {
+		return left, nil
+	}
+	return evalBool(node.right, ctx)
+}
+
+func evalBool(node filterNode, ctx *filterContext) (bool, error) {
+	val, err := node.eval(ctx)
+	if err != nil {
+		return false, err
+	}
+	boolean, ok := val.(bool)
+	if !ok {
+		return false, fmt.Errorf("a logical operator is applied to a non-boolean value %v", val)
+	}
+	return boolean, nil
+}
+
+type filterComparison struct {
+	operator    string
+	left, right filterNode
+	// regexp is the compiled right operand of "=~" and "!~".
+	regexp *regexp.Regexp
+}
+
+func (node filterComparison) eval(ctx *filterContext) (interface{}, error) {
+	left, err := node.left.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if node.regexp != nil {
+		str, ok := left.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s is applied to a non-string value %v", node.operator, left)
+		}
+		return node.regexp.MatchString(str) == (node.operator == "=~"), nil
+	}
+	right, err := node.right.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var cmp int
+	switch typed := left.(type) {
+	case string:
+		str, ok := right.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare %q with %v", typed, right)
+		}
+		cmp = strings.Compare(typed, str)
+	case int:
+		number, ok := right.(int)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare %d with %v", typed, right)
+		}
+		cmp = typed - number
+	case bool:
+		boolean, ok := right.(bool)
+		if !ok || (node.operator != "==" && node.operator != "!=") {
+			return nil, fmt.Errorf("cannot compare %v with %v using %s", typed, right, node.operator)
+		}
+		if typed != boolean {
+			cmp = 1
+		}
+	case time.Time:
+		str, ok := right.(string)
+		if !ok {
+			return nil, fmt.Errorf("dates must be compared with strings, got %v", right)
+		}
+		date, err := parseFilterDate(str)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case typed.Before(date):
+			cmp = -1
+		case typed.After(date):
+			cmp = 1
+		}
+	}
+	switch node.operator {
+	case "==":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	}
+	return cmp >= 0, nil
+}
+
+func parseFilterDate(str string) (time.Time, error) {
+	if date, err := time.Parse(time.RFC3339, str); err == nil {
+		return date, nil
+	}
+	date, err := time.Parse("2006-01-02", str)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", str)
+	}
+	return date, nil
+}
+
+type filterTokenType int
+
+const (
+	filterTokenIdent filterTokenType = iota
+	filterTokenString
+	filterTokenNumber
+	filterTokenOperator
+)
+
+type filterToken struct {
+	kind filterTokenType
+	text string
+	pos  int
+}
+
+func tokenizeFilter(expression string) ([]filterToken, error) {
+	tokens := []filterToken{}
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		char := runes[i]
+		switch {
+		case unicode.IsSpace(char):
+			i++
+		case unicode.IsLetter(char) || char == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) ||
+				runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, filterToken{filterTokenIdent, string(runes[start:i]), start})
+		case unicode.IsDigit(char):
+			start := i
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, filterToken{filterTokenNumber, string(runes[start:i]), start})
+		case char == '"':
+			start := i
+			for i++; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			i++
+			str, err := strconv.Unquote(string(runes[start:i]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at position %d: %v", start, err)
+			}
+			tokens = append(tokens, filterToken{filterTokenString, str, start})
+		default:
+			operator := ""
+			if i+1 < len(runes) {
+				switch two := string(runes[i : i+2]); two {
+				case "&&", "||", "==", "!=", "<=", ">=", "=~", "!~":
+					operator = two
+				}
+			}
+			if operator == "" {
+				switch char {
+				case '!', '<', '>', '(', ')', '.':
+					operator = string(char)
+				default:
+					return nil, fmt.Errorf("unexpected %q at position %d", char, i)
+				}
+			}
+			tokens = append(tokens, filterToken{filterTokenOperator, operator, i})
+			i += len(operator)
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+// accept consumes the next token if it is the specified operator.
+func (parser *filterParser) accept(operator string) bool {
+	if parser.pos < len(parser.tokens) && parser.tokens[parser.pos].kind == filterTokenOperator &&
+		parser.tokens[parser.pos].text == operator {
+		parser.pos++
+		return true
+	}
+	return false
+}
+
+func (parser *filterParser) next() (filterToken, error) {
+	if parser.pos >= len(parser.tokens) {
+		return filterToken{}, errors.New("unexpected end of the expression")
+	}
+	token := parser.tokens[parser.pos]
+	parser.pos++
+	return token, nil
+}
+
+func (parser *filterParser) parseOr() (filterNode, error) {
+	left, err := parser.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for parser.accept("||") {
+		right, err := parser.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = filterLogical{and: false, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (parser *filterParser) parseAnd() (filterNode, error) {
+	left, err := parser.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for parser.accept("&&") {
+		right, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = filterLogical{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (parser *filterParser) parseUnary() (filterNode, error) {
+	if parser.accept("!") {
+		operand, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return filterNot{operand}, nil
+	}
+	if parser.accept("(") {
+		node, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !parser.accept(")") {
+			return nil, errors.New("missing \")\"")
+		}
+		return node, nil
+	}
+	return parser.parseComparison()
+}
+
+func (parser *filterParser) parseComparison() (filterNode, error) {
+	left, err := parser.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for _, operator := range [...]string{"==", "!=", "<=", ">=", "<", ">", "=~", "!~"} {
+		if !parser.accept(operator) {
+			continue
+		}
+		right, err := parser.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		node := filterComparison{operator: operator, left: left, right: right}
+		if operator == "=~" || operator == "!~" {
+			literal, ok := right.(filterLiteral)
+			str, isString := literal.value.(string)
+			if !ok || !isString {
+				return nil, fmt.Errorf("%s requires a string literal", operator)
+			}
+			node.regexp, err = regexp.Compile(str)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return node, nil
+	}
+	return left, nil
+}
+
+func (parser *filterParser) parseOperand() (filterNode, error) {
+	token, err := parser.next()
+	if err != nil {
+		return nil, err
+	}
+	switch token.kind {
+	case filterTokenString:
+		return filterLiteral{token.text}, nil
+	case filterTokenNumber:
+		number, err := strconv.Atoi(token.text)
+		if err != nil {
+			return nil, err
+		}
+		return filterLiteral{number}, nil
+	case filterTokenIdent:
+		switch token.text {
+		case "true":
+			return filterLiteral{true}, nil
+		case "false":
+			return filterLiteral{false}, nil
+		}
+		if _, exists := filterFields[token.text]; exists {
+			return filterField{token.text}, nil
+		}
+		dot := strings.LastIndex(token.text, ".")
+		if dot < 0 {
+			return nil, fmt.Errorf("unknown field %q at position %d", token.text, token.pos)
+		}
+		field, method := token.text[:dot], token.text[dot+1:]
+		hasArg, exists := filterMethods[method]
+		if _, fieldExists := filterFields[field]; !fieldExists || !exists {
+			return nil, fmt.Errorf("unknown field %q at position %d", token.text, token.pos)
+		}
+		node, err := parser.parseCall(filterField{field}, method, hasArg, token.pos)
+		if err != nil {
+			return nil, err
+		}
+		// chained calls, e.g. message.lower().contains("x")
+		for parser.accept(".") {
+			token, err = parser.next()
+			if err != nil {
+				return nil, err
+			}
+			hasArg, exists := filterMethods[token.text]
+			if token.kind != filterTokenIdent || !exists {
+				return nil, fmt.Errorf("unknown method %q at position %d", token.text, token.pos)
+			}
+			node, err = parser.parseCall(node, token.text, hasArg, token.pos)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return node, nil
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", token.text, token.pos)
+}
+
+// parseCall parses the arguments of the string method.
+func (parser *filterParser) parseCall(
+	object filterNode, method string, hasArg bool, pos int) (filterNode, error) {
+	if !parser.accept("(") {
+		return nil, fmt.Errorf("%s must be called at position %d", method, pos)
+	}
+	node := filterMethod{object: object, name: method}
+	if hasArg {
+		arg, err := parser.next()
+		if err != nil {
+			return nil, err
+		}
+		if arg.kind != filterTokenString {
+			return nil, fmt.Errorf("%s() requires a string argument at position %d",
+				method, arg.pos)
+		}
+		node.arg = arg.text
+	}
+	if !parser.accept(")") {
+		return nil, fmt.Errorf("missing \")\" after %s(", method)
+	}
+	return node, nil
+}
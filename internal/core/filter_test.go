@@ -0,0 +1,127 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+func fixtureFilterCommit() *object.Commit {
+	return &object.Commit{
+		Hash: plumbing.NewHash("af9ddc0db70f09f3f27b4b98e415592a7485171c"),
+		Author: object.Signature{Name: "Vadim Markovtsev", Email: "vadim@corp.com",
+			When: time.Date(2018, 3, 10, 12, 0, 0, 0, time.UTC)},
+		Committer: object.Signature{Name: "GitHub", Email: "noreply@github.com",
+			When: time.Date(2018, 3, 11, 12, 0, 0, 0, time.UTC)},
+		Message:      "Update the vendored dependencies\n",
+		ParentHashes: []plumbing.Hash{plumbing.ZeroHash, plumbing.ZeroHash},
+	}
+}
+
+func TestCommitFilterMatch(t *testing.T) {
+	commit := fixtureFilterCommit()
+	for expression, expected := range map[string]bool{
+		`author.email =~ "@corp\\.com$"`:                            true,
+		`author.email !~ "@corp"`:                                   false,
+		`author.name == "Vadim Markovtsev" && committer.name != ""`: true,
+		`!message.contains("vendor")`:                               false,
+		`message.lower().startsWith("update")`:                      true,
+		`message.startsWith("Update") || hash == "0"`:               true,
+		`hash.endsWith("171c")`:                                     true,
+		`parents >= 2 && merge`:                                     true,
+		`merge == false`:                                            false,
+		`(parents < 2 || parents > 5) && true`:                      false,
+		`author.date >= "2018-03-10" && author.date < "2018-03-11"`: true,
+		`committer.date > "2018-03-11T12:00:00Z"`:                   false,
+		`committer.date <= "2018-03-11T12:00:00Z"`:                  true,
+		`false || !(author.email == "x")`:                           true,
+	} {
+		filter, err := ParseCommitFilter(expression)
+		if !assert.Nil(t, err, expression) {
+			continue
+		}
+		assert.Equal(t, filter.String(), expression)
+		matches, err := filter.Match(commit)
+		assert.Nil(t, err, expression)
+		assert.Equal(t, matches, expected, expression)
+	}
+}
+
+func TestCommitFilterShortCircuit(t *testing.T) {
+	// "files" would require the repository
+	filter, err := ParseCommitFilter(`!merge && files < 500`)
+	assert.Nil(t, err)
+	matches, err := filter.Match(fixtureFilterCommit())
+	assert.Nil(t, err)
+	assert.False(t, matches)
+	ctx := &filterContext{commit: fixtureFilterCommit(), files: 10}
+	files, err := ctx.changedFiles()
+	assert.Nil(t, err)
+	assert.Equal(t, files, 10)
+}
+
+func TestCommitFilterParseErrors(t *testing.T) {
+	for _, expression := range []string{
+		``,
+		`author.email ==`,
+		`author.mail == "x"`,
+		`message.contains`,
+		`message.contains(1)`,
+		`message.contains("x"`,
+		`message.split("x")`,
+		`message.lower().split("x")`,
+		`message.lower().`,
+		`message.lower().lower`,
+		`(merge`,
+		`merge merge`,
+		`message =~ "("`,
+		`message =~ hash`,
+		`"unterminated`,
+		`message == "\q"`,
+		`message # 1`,
+		`x`,
+	} {
+		_, err := ParseCommitFilter(expression)
+		assert.NotNil(t, err, expression)
+	}
+}
+
+func TestCommitFilterEvalErrors(t *testing.T) {
+	commit := fixtureFilterCommit()
+	for _, expression := range []string{
+		`message`,
+		`message && merge`,
+		`!message`,
+		`parents == "2"`,
+		`message == 2`,
+		`merge < true`,
+		`author.date < 5`,
+		`author.date < "yesterday"`,
+		`parents =~ "2"`,
+	} {
+		filter, err := ParseCommitFilter(expression)
+		if !assert.Nil(t, err, expression) {
+			continue
+		}
+		_, err = filter.Match(commit)
+		assert.NotNil(t, err, expression)
+	}
+}
+
+func TestFilterCommits(t *testing.T) {
+	first := fixtureFilterCommit()
+	second := fixtureFilterCommit()
+	second.ParentHashes = nil
+	filter, err := ParseCommitFilter(`!merge`)
+	assert.Nil(t, err)
+	commits, err := FilterCommits([]*object.Commit{first, second}, filter)
+	assert.Nil(t, err)
+	assert.Equal(t, commits, []*object.Commit{second})
+	filter, err = ParseCommitFilter(`message`)
+	assert.Nil(t, err)
+	_, err = FilterCommits([]*object.Commit{first}, filter)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,138 @@
+package core
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+)
+
+// historyCache is the sidecar file which LoadHistory() reads and writes.
+type historyCache struct {
+	// Key is the checksum of the packs in the object storage, see historyKey().
+	Key string `json:"key"`
+	// Head is the hash of the commit which the history starts from.
+	Head string `json:"head"`
+	// Commits are the hashes of the first-parent history from the root to Head.
+	Commits []string `json:"commits"`
+}
+
+// LoadHistory returns the same commits as Pipeline.Commits() and caches their hashes in
+// the sidecar file by the specified path, so that the repeated runs on the same repository
+// do not trace the history again. The cache is keyed by the checksums of the packs: if the
+// packs are unchanged and HEAD moved forward, only the new commits are traced. The repositories
+// without packs, e.g. in memory, are not cached.
+func LoadHistory(path string, repository *git.Repository) ([]*object.Commit, error) {
+	key, err := historyKey(repository)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		log.Printf("Warning: the history of the repository cannot be cached: no packs\n")
+	}
+	return loadHistory(path, key, repository)
+}
+
+// historyKey combines the checksums of the packs in the repository's object storage.
+// It returns an empty string if the storage does not support packs or has none.
+func historyKey(repository *git.Repository) (string, error) {
+	packed, ok := repository.Storer.(storer.PackedObjectStorer)
+	if !ok {
+		return "", nil
+	}
+	packs, err := packed.ObjectPacks()
+	if err != nil {
+		return "", err
+	}
+	if len(packs) == 0 {
+		return "", nil
+	}
+	sort.Slice(packs, func(i, j int) bool {
+		return packs[i].String() < packs[j].String()
+	})
+	hasher := sha1.New()
+	for _, pack := range packs {
+		hasher.Write(pack[:])
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// loadHistory traces the first-parent history of HEAD, reusing the cache if its key matches
+// and writing the cache back. An empty key disables the cache.
+func loadHistory(path string, key string, repository *git.Repository) ([]*object.Commit, error) {
+	head, err := repository.Head()
+	if err != nil {
+		return nil, err
+	}
+	cache := historyCache{}
+	if key != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if err == nil {
+			if err = json.Unmarshal(data, &cache); err != nil {
+				log.Printf("Warning: ignored the broken history cache %s: %v\n", path, err)
+				cache = historyCache{}
+			}
+		}
+	}
+	var cached []string
+	if key != "" && cache.Key == key {
+		// the commits are immutable, so the cached history remains valid if the traced one
+		// reaches the cached head
+		cached = cache.Commits
+	} else {
+		cache.Head = ""
+	}
+	// trace the new commits backwards
+	var traced []*object.Commit
+	for hash := head.Hash(); hash.String() != cache.Head; {
+		commit, err := repository.CommitObject(hash)
+		if err != nil {
+			return nil, err
+		}
+		traced = append(traced, commit)
+		if commit.NumParents() == 0 {
+			// the cached head is not a first-parent ancestor, e.g. after a force push
+			cached = nil
+			break
+		}
+		hash = commit.ParentHashes[0]
+	}
+	commits := make([]*object.Commit, 0, len(cached)+len(traced))
+	for _, hash := range cached {
+		commit, err := repository.CommitObject(plumbing.NewHash(hash))
+		if err != nil {
+			return nil, fmt.Errorf("history cache %s: %v", path, err)
+		}
+		commits = append(commits, commit)
+	}
+	for i := len(traced) - 1; i >= 0; i-- {
+		commits = append(commits, traced[i])
+	}
+	if key == "" || len(traced) == 0 {
+		return commits, nil
+	}
+	cache = historyCache{Key: key, Head: head.Hash().String(), Commits: make([]string, len(commits))}
+	for i, commit := range commits {
+		cache.Commits[i] = commit.Hash.String()
+	}
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return nil, err
+	}
+	if err = ioutil.WriteFile(path, data, 0644); err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
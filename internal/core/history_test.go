@@ -0,0 +1,125 @@
+package core
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-billy.v4/memfs"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+)
+
+func fixtureHistoryCommit(repository *git.Repository, day int) plumbing.Hash {
+	worktree, err := repository.Worktree()
+	if err != nil {
+		panic(err)
+	}
+	file, err := worktree.Filesystem.Create("README")
+	if err != nil {
+		panic(err)
+	}
+	file.Write([]byte{byte('a' + day)})
+	file.Close()
+	worktree.Add("README")
+	hash, err := worktree.Commit("Commit", &git.CommitOptions{Author: &object.Signature{
+		Name: "Vadim", Email: "vadim@sourced.tech",
+		When: time.Date(2018, 1, 1+day, 12, 0, 0, 0, time.UTC)}})
+	if err != nil {
+		panic(err)
+	}
+	return hash
+}
+
+func readHistoryCache(t *testing.T, path string) historyCache {
+	data, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	cache := historyCache{}
+	assert.Nil(t, json.Unmarshal(data, &cache))
+	return cache
+}
+
+func historyHashes(commits []*object.Commit) []plumbing.Hash {
+	hashes := make([]plumbing.Hash, len(commits))
+	for i, commit := range commits {
+		hashes[i] = commit.Hash
+	}
+	return hashes
+}
+
+func TestLoadHistory(t *testing.T) {
+	repository, err := git.Init(memory.NewStorage(), memfs.New())
+	assert.Nil(t, err)
+	first := fixtureHistoryCommit(repository, 0)
+	second := fixtureHistoryCommit(repository, 1)
+	key, err := historyKey(repository)
+	assert.Nil(t, err)
+	assert.Equal(t, key, "")
+	dir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "history.json")
+
+	// no cache
+	commits, err := LoadHistory(path, repository)
+	assert.Nil(t, err)
+	assert.Equal(t, historyHashes(commits), []plumbing.Hash{first, second})
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+
+	commits, err = loadHistory(path, "packs", repository)
+	assert.Nil(t, err)
+	assert.Equal(t, historyHashes(commits), []plumbing.Hash{first, second})
+	assert.Equal(t, readHistoryCache(t, path), historyCache{
+		Key: "packs", Head: second.String(), Commits: []string{first.String(), second.String()}})
+
+	// the cache is trusted
+	data, _ := json.Marshal(historyCache{
+		Key: "packs", Head: second.String(), Commits: []string{second.String()}})
+	assert.Nil(t, ioutil.WriteFile(path, data, 0644))
+	commits, err = loadHistory(path, "packs", repository)
+	assert.Nil(t, err)
+	assert.Equal(t, historyHashes(commits), []plumbing.Hash{second})
+
+	// only the new commits are traced
+	third := fixtureHistoryCommit(repository, 2)
+	commits, err = loadHistory(path, "packs", repository)
+	assert.Nil(t, err)
+	assert.Equal(t, historyHashes(commits), []plumbing.Hash{second, third})
+	assert.Equal(t, readHistoryCache(t, path).Head, third.String())
+
+	// the packs changed
+	commits, err = loadHistory(path, "repacked", repository)
+	assert.Nil(t, err)
+	assert.Equal(t, historyHashes(commits), []plumbing.Hash{first, second, third})
+	assert.Equal(t, readHistoryCache(t, path).Key, "repacked")
+
+	// the cached head is not an ancestor
+	data, _ = json.Marshal(historyCache{
+		Key: "packs", Head: "ffffffffffffffffffffffffffffffffffffffff", Commits: []string{}})
+	assert.Nil(t, ioutil.WriteFile(path, data, 0644))
+	commits, err = loadHistory(path, "packs", repository)
+	assert.Nil(t, err)
+	assert.Equal(t, historyHashes(commits), []plumbing.Hash{first, second, third})
+
+	// the cache is broken
+	assert.Nil(t, ioutil.WriteFile(path, []byte("{"), 0644))
+	commits, err = loadHistory(path, "packs", repository)
+	assert.Nil(t, err)
+	assert.Len(t, commits, 3)
+	assert.Len(t, readHistoryCache(t, path).Commits, 3)
+
+	// the cached commit is missing
+	data, _ = json.Marshal(historyCache{
+		Key: "packs", Head: third.String(),
+		Commits: []string{"ffffffffffffffffffffffffffffffffffffffff", third.String()}})
+	assert.Nil(t, ioutil.WriteFile(path, data, 0644))
+	_, err = loadHistory(path, "packs", repository)
+	assert.NotNil(t, err)
+}
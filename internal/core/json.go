@@ -0,0 +1,25 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// SerializeJSON writes the result of the LeafPipelineItem in JSON. The result is serialized
+// to Protocol Buffers and decoded to `message`, the empty message which item.Serialize() writes,
+// so the JSON object has the same fields as the message in pb.proto and is the same as
+// "hercules convert" writes. This is the usual implementation of LeafPipelineItem.SerializeJSON().
+func SerializeJSON(
+	item LeafPipelineItem, result interface{}, message proto.Message, writer io.Writer) error {
+	buffer := &bytes.Buffer{}
+	if err := item.Serialize(result, true, buffer); err != nil {
+		return err
+	}
+	if err := proto.Unmarshal(buffer.Bytes(), message); err != nil {
+		return err
+	}
+	return json.NewEncoder(writer).Encode(message)
+}
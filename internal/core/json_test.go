@@ -0,0 +1,43 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+)
+
+// jsonTestPipelineItem writes pb.Metadata in Serialize().
+type jsonTestPipelineItem struct {
+	testPipelineItem
+	err error
+}
+
+func (item *jsonTestPipelineItem) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	if item.err != nil {
+		return item.err
+	}
+	serialized, err := proto.Marshal(result.(*pb.Metadata))
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func TestSerializeJSON(t *testing.T) {
+	item := &jsonTestPipelineItem{}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, SerializeJSON(item, &pb.Metadata{
+		Version: 2, Repository: "test", BeginUnixTime: 1500000000,
+		Failures: []*pb.CommitFailure{{Item: "Burndown", Index: 1}}},
+		&pb.Metadata{}, buffer))
+	assert.Equal(t, buffer.String(), `{"version":2,"repository":"test","begin_unix_time":1500000000,`+
+		`"failures":[{"index":1,"item":"Burndown"}]}`+"\n")
+	item.err = errors.New("test")
+	assert.EqualError(t, SerializeJSON(item, &pb.Metadata{}, &pb.Metadata{}, buffer), "test")
+}
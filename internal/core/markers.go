@@ -0,0 +1,106 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+)
+
+// Marker is a labeled point in time which annotates the results, e.g. a migration, a team change
+// or an incident. The markers are written to the metadata and drawn by the plots.
+type Marker struct {
+	// Label is the description of the event.
+	Label string
+	// Time is the UNIX timestamp of the event.
+	Time int64
+	// Commit is the hash of the marked commit; it is zero if the marker is a date.
+	Commit plumbing.Hash
+}
+
+// LoadMarkers reads the markers from the text file. Each line is "<anchor> <label>", where
+// the anchor is a date ("2006-01-02" or RFC3339), a tag or a commit hash, see ResolveMarker().
+// Empty lines and the lines which start with "#" are ignored. The markers are sorted by time.
+func LoadMarkers(path string, repository *git.Repository) ([]Marker, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	markers, err := readMarkers(file, repository)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return markers, nil
+}
+
+func readMarkers(reader io.Reader, repository *git.Repository) ([]Marker, error) {
+	markers := []Marker{}
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.SplitN(text, " ", 2)
+		if len(fields) < 2 || strings.TrimSpace(fields[1]) == "" {
+			return nil, fmt.Errorf("line %d: the label is missing", line)
+		}
+		when, hash, err := ResolveMarker(fields[0], repository)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		markers = append(markers, Marker{
+			Label: strings.TrimSpace(fields[1]), Time: when.Unix(), Commit: hash})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(markers, func(i, j int) bool {
+		return markers[i].Time < markers[j].Time
+	})
+	return markers, nil
+}
+
+// ResolveMarker converts the anchor of a marker to the time and the commit hash. The anchor is
+// either a date in "2006-01-02" (UTC) or RFC3339 format, the name of a tag or a commit hash;
+// in the latter two cases, the author date of the commit is returned.
+func ResolveMarker(anchor string, repository *git.Repository) (time.Time, plumbing.Hash, error) {
+	if date, err := time.Parse("2006-01-02", anchor); err == nil {
+		return date, plumbing.ZeroHash, nil
+	}
+	if date, err := time.Parse(time.RFC3339, anchor); err == nil {
+		return date, plumbing.ZeroHash, nil
+	}
+	if repository == nil {
+		return time.Time{}, plumbing.ZeroHash, fmt.Errorf(
+			"marker %q is neither a date nor a tag nor a commit", anchor)
+	}
+	if hash := plumbing.NewHash(anchor); hash.String() == strings.ToLower(anchor) {
+		commit, err := repository.CommitObject(hash)
+		if err != nil {
+			return time.Time{}, plumbing.ZeroHash, fmt.Errorf("marker %q: %v", anchor, err)
+		}
+		return commit.Author.When, commit.Hash, nil
+	}
+	ref, err := repository.Tag(anchor)
+	if err != nil {
+		return time.Time{}, plumbing.ZeroHash, fmt.Errorf(
+			"marker %q is neither a date nor a tag nor a commit: %v", anchor, err)
+	}
+	hash := ref.Hash()
+	if tag, err := repository.TagObject(hash); err == nil {
+		hash = tag.Target
+	}
+	commit, err := repository.CommitObject(hash)
+	if err != nil {
+		return time.Time{}, plumbing.ZeroHash, err
+	}
+	return commit.Author.When, commit.Hash, nil
+}
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func TestResolveMarker(t *testing.T) {
+	date, hash, err := ResolveMarker("2018-01-01", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, date, time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, hash.IsZero())
+	date, _, err = ResolveMarker("2018-01-01T12:00:00+03:00", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, date.Unix(), time.Date(2018, 1, 1, 9, 0, 0, 0, time.UTC).Unix())
+	_, _, err = ResolveMarker("v1.0.0", nil)
+	assert.NotNil(t, err)
+	repository := fixtureDayZeroRepository()
+	head, err := repository.Head()
+	assert.Nil(t, err)
+	for _, anchor := range []string{"v1.0.0", "v2.0.0", head.Hash().String()} {
+		date, hash, err = ResolveMarker(anchor, repository)
+		assert.Nil(t, err, anchor)
+		assert.Equal(t, date.Unix(), int64(1514808000))
+		assert.Equal(t, hash, head.Hash())
+	}
+	_, _, err = ResolveMarker("v3.0.0", repository)
+	assert.NotNil(t, err)
+	_, _, err = ResolveMarker("ffffffffffffffffffffffffffffffffffffffff", repository)
+	assert.NotNil(t, err)
+}
+
+func TestReadMarkers(t *testing.T) {
+	repository := fixtureDayZeroRepository()
+	head, err := repository.Head()
+	assert.Nil(t, err)
+	markers, err := readMarkers(strings.NewReader(`# events
+2018-03-01 Team change
+
+v1.0.0   First release
+2017-12-01T00:00:00Z Migration to Go
+`), repository)
+	assert.Nil(t, err)
+	assert.Equal(t, markers, []Marker{
+		{Label: "Migration to Go", Time: 1512086400},
+		{Label: "First release", Time: 1514808000, Commit: head.Hash()},
+		{Label: "Team change", Time: 1519862400},
+	})
+	_, err = readMarkers(strings.NewReader("2018-03-01\n"), repository)
+	assert.NotNil(t, err)
+	_, err = readMarkers(strings.NewReader("2018-03-01 \n"), repository)
+	assert.NotNil(t, err)
+	_, err = readMarkers(strings.NewReader("\nyesterday Incident\n"), repository)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+func TestLoadMarkers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-markers-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "markers.txt")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("2018-03-01 Team change\n"), 0644))
+	markers, err := LoadMarkers(path, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, markers, []Marker{{Label: "Team change", Time: 1519862400}})
+	_, err = LoadMarkers(filepath.Join(dir, "missing.txt"), nil)
+	assert.NotNil(t, err)
+	assert.Nil(t, ioutil.WriteFile(path, []byte("v1.0 Release\n"), 0644))
+	_, err = LoadMarkers(path, nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "markers.txt")
+}
+
+func TestPipelineMarkers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-markers-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "markers.txt")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("2018-03-01 Team change\n"), 0644))
+	pipeline := NewPipeline(test.Repository)
+	pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommits: []*object.Commit{}, ConfigPipelineMarkers: path})
+	result, err := pipeline.Run([]*object.Commit{{Author: object.Signature{
+		When: time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)}}})
+	assert.Nil(t, err)
+	assert.Equal(t, result[nil].(*CommonAnalysisResult).Markers, []Marker{
+		{Label: "Team change", Time: 1519862400}})
+	assert.Panics(t, func() {
+		NewPipeline(nil).Initialize(map[string]interface{}{
+			ConfigPipelineCommits: []*object.Commit{},
+			ConfigPipelineMarkers: filepath.Join(dir, "missing.txt")})
+	})
+}
+
+func TestCommonAnalysisResultMarkers(t *testing.T) {
+	hash := plumbing.NewHash("af9ddc0db70f09f3f27b4b98e415592a7485171c")
+	c1 := &CommonAnalysisResult{BeginTime: 1, EndTime: 2, Markers: []Marker{
+		{Label: "one", Time: 100}, {Label: "two", Time: 300, Commit: hash}}}
+	c2 := &CommonAnalysisResult{BeginTime: 1, EndTime: 2, Markers: []Marker{
+		{Label: "three", Time: 200}, {Label: "two", Time: 300, Commit: hash}}}
+	c1.Merge(c2)
+	assert.Equal(t, c1.Markers, []Marker{
+		{Label: "one", Time: 100}, {Label: "three", Time: 200},
+		{Label: "two", Time: 300, Commit: hash}})
+	meta := c1.FillMetadata(&Metadata{})
+	assert.Len(t, meta.Markers, 3)
+	assert.Equal(t, meta.Markers[0].Commit, "")
+	assert.Equal(t, meta.Markers[2].Commit, hash.String())
+	assert.Equal(t, MetadataToCommonAnalysisResult(meta).Markers, c1.Markers)
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"bufio"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -9,10 +10,12 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"runtime/debug"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
@@ -112,6 +115,18 @@ type FeaturedPipelineItem interface {
 	Features() []string
 }
 
+// OptionallyFeaturedPipelineItem is a FeaturedPipelineItem which runs without some features
+// but uses them if they are enabled. Pipeline.DeployItem() passes the enabled ones to
+// EnableFeatures() before it resolves the dependencies, so that Requires() and Features()
+// grow only when the features are on.
+type OptionallyFeaturedPipelineItem interface {
+	FeaturedPipelineItem
+	// OptionalFeatures returns the list of names of the features which the item can use.
+	OptionalFeatures() []string
+	// EnableFeatures activates the enabled subset of OptionalFeatures().
+	EnableFeatures(features []string)
+}
+
 // LeafPipelineItem corresponds to the top level pipeline items which produce the end results.
 type LeafPipelineItem interface {
 	PipelineItem
@@ -121,6 +136,8 @@ type LeafPipelineItem interface {
 	Finalize() interface{}
 	// Serialize encodes the object returned by Finalize() to YAML or Protocol Buffers.
 	Serialize(result interface{}, binary bool, writer io.Writer) error
+	// SerializeJSON encodes the object returned by Finalize() to JSON, see SerializeJSON().
+	SerializeJSON(result interface{}, writer io.Writer) error
 }
 
 // MergeablePipelineItem specifies the methods to combine several analysis results together.
@@ -132,6 +149,105 @@ type MergeablePipelineItem interface {
 	MergeResults(r1, r2 interface{}, c1, c2 *CommonAnalysisResult) interface{}
 }
 
+// ExtensionPipelineItem is the LeafPipelineItem, typically of a plugin, whose binary result is
+// written to AnalysisResults.Extensions under the namespaced name instead of the contents.
+// This way the third-party results are stored in the same file as the rest and the readers are
+// able to enumerate and decode them. Registry.Register() registers the message.
+type ExtensionPipelineItem interface {
+	LeafPipelineItem
+	// ExtensionName returns the unique name of the result which is prefixed with the namespace,
+	// e.g. "github.com/user/churn/Churn".
+	ExtensionName() string
+	// ExtensionMessage creates the empty Protocol Buffers message which Serialize() writes.
+	ExtensionMessage() proto.Message
+}
+
+// DayRangePipelineItem is a LeafPipelineItem whose binary result is a time series which can be
+// written in parts, one per day range, so that the readers load only the periods they need.
+type DayRangePipelineItem interface {
+	LeafPipelineItem
+	// SerializeDayRanges splits the binary result into the parts which cover `days` days each.
+	// Concatenating the parts in order gives the message which Serialize() writes, because
+	// Protocol Buffers merge the repeated fields, the maps and the nested messages.
+	SerializeDayRanges(result interface{}, days int) ([]DayRange, error)
+}
+
+// DayRange is the part of the binary time series result which covers the days from From
+// inclusive to To exclusive. See DayRangePipelineItem.
+type DayRange struct {
+	From int
+	To   int
+	Data []byte
+}
+
+// ShrinkablePipelineItem is able to release some memory at the cost of the speed or the precision.
+// Pipeline.Run() calls Shrink() when the resident memory exceeds ConfigPipelineMemoryLimit.
+type ShrinkablePipelineItem interface {
+	PipelineItem
+	// Shrink releases the memory which is not strictly required to continue the analysis.
+	Shrink()
+}
+
+// ForkablePipelineItem keeps the state which depends on the line of development, so that
+// Pipeline.Run() clones it at the branch points and combines the clones at the merge commits
+// in the ConfigPipelineBranches mode. The rest of the items are shared by all the branches
+// and consume each commit once in the topological order.
+type ForkablePipelineItem interface {
+	PipelineItem
+	// Fork returns n independent copies of the item which continue the analysis of
+	// the different branches from the current state.
+	Fork(n int) []PipelineItem
+	// Merge combines the states of the merged branches, which are the copies returned by Fork(),
+	// with the state of the item. They are listed in the order of the merge commit's parents
+	// after the first. Merge is called right before Consume() of the merge commit.
+	Merge(branches []PipelineItem)
+}
+
+// WrappingPipelineItem delegates to another item, e.g. to filter its input. It implements
+// the optional interfaces, such as ForkablePipelineItem or PersistentPipelineItem, on behalf
+// of the wrapped item, so the support of each of them must be checked on Unwrap()-ed item.
+type WrappingPipelineItem interface {
+	PipelineItem
+	// Unwrap returns the wrapped item.
+	Unwrap() PipelineItem
+}
+
+// Unwrap returns the innermost item which the WrappingPipelineItem-s delegate to, or the item
+// itself if it does not wrap anything. The optional interfaces must be checked on the result.
+func Unwrap(item PipelineItem) PipelineItem {
+	for {
+		wrapper, ok := item.(WrappingPipelineItem)
+		if !ok {
+			return item
+		}
+		item = wrapper.Unwrap()
+	}
+}
+
+// LinearPipelineItem keeps the state which depends on the line of development, like
+// ForkablePipelineItem, but is not able to fork it, e.g. it follows the lines of the files
+// through the diffs. The interleaved branches would break such a state, so Pipeline.Initialize()
+// rejects the item in the ConfigPipelineBranches mode.
+type LinearPipelineItem interface {
+	PipelineItem
+	// RequiresLinearHistory returns true if the item must consume a single line of development.
+	RequiresLinearHistory() bool
+}
+
+// CommitProgress describes the processed commit in Pipeline.OnCommit.
+type CommitProgress struct {
+	// Hash of the commit.
+	Hash plumbing.Hash
+	// Index of the commit in the analysed sequence.
+	Index int
+	// Total is the number of commits in the analysed sequence.
+	Total int
+	// Elapsed is the time since the beginning of Pipeline.Run().
+	Elapsed time.Duration
+	// Timings map the names of the pipeline items to the durations of their Consume().
+	Timings map[string]time.Duration
+}
+
 // CommonAnalysisResult holds the information which is always extracted at Pipeline.Run().
 type CommonAnalysisResult struct {
 	// Time of the first commit in the analysed sequence.
@@ -142,6 +258,37 @@ type CommonAnalysisResult struct {
 	CommitsNumber int
 	// The duration of Pipeline.Run().
 	RunTime time.Duration
+	// Failures are the commits which the pipeline items failed to consume.
+	// They are recorded only if ConfigPipelineSkipErrors or ConfigPipelineCommitTimeout is set.
+	Failures []CommitFailure
+	// Partial indicates that ConfigPipelineDeadline has interrupted the analysis and
+	// only the first CommitsNumber commits were processed.
+	Partial bool
+	// Skipped are the deployed items which were removed from the pipeline because their
+	// dependencies are provided only by the items with disabled features.
+	Skipped []SkippedItem
+	// Markers are the labeled events which annotate the time axis, see ConfigPipelineMarkers.
+	Markers []Marker
+}
+
+// SkippedItem is a pipeline item which was not executed.
+type SkippedItem struct {
+	// Item is the name of the skipped pipeline item.
+	Item string
+	// Reason is the description of why the item was skipped.
+	Reason string
+}
+
+// CommitFailure is the error of a pipeline item on a commit which was skipped.
+type CommitFailure struct {
+	// Commit is the hash of the skipped commit.
+	Commit plumbing.Hash
+	// Index of the commit in the analysed sequence.
+	Index int
+	// Item is the name of the failed pipeline item.
+	Item string
+	// Error is the description of the failure.
+	Error string
 }
 
 // BeginTimeAsTime converts the UNIX timestamp of the beginning to Go time.
@@ -156,7 +303,7 @@ func (car *CommonAnalysisResult) EndTimeAsTime() time.Time {
 
 // Merge combines the CommonAnalysisResult with an other one.
 // We choose the earlier BeginTime, the later EndTime, sum the number of commits and the
-// elapsed run times.
+// elapsed run times. The markers are united.
 func (car *CommonAnalysisResult) Merge(other *CommonAnalysisResult) {
 	if car.EndTime == 0 || other.BeginTime == 0 {
 		panic("Merging with an uninitialized CommonAnalysisResult")
@@ -169,6 +316,35 @@ func (car *CommonAnalysisResult) Merge(other *CommonAnalysisResult) {
 	}
 	car.CommitsNumber += other.CommitsNumber
 	car.RunTime += other.RunTime
+	car.Failures = append(car.Failures, other.Failures...)
+	car.Partial = car.Partial || other.Partial
+	for _, skipped := range other.Skipped {
+		exists := false
+		for _, mine := range car.Skipped {
+			if mine.Item == skipped.Item {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			car.Skipped = append(car.Skipped, skipped)
+		}
+	}
+	for _, marker := range other.Markers {
+		exists := false
+		for _, mine := range car.Markers {
+			if mine == marker {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			car.Markers = append(car.Markers, marker)
+		}
+	}
+	sort.SliceStable(car.Markers, func(i, j int) bool {
+		return car.Markers[i].Time < car.Markers[j].Time
+	})
 }
 
 // FillMetadata copies the data to a Protobuf message.
@@ -177,6 +353,27 @@ func (car *CommonAnalysisResult) FillMetadata(meta *pb.Metadata) *pb.Metadata {
 	meta.EndUnixTime = car.EndTime
 	meta.Commits = int32(car.CommitsNumber)
 	meta.RunTime = car.RunTime.Nanoseconds() / 1e6
+	meta.Partial = car.Partial
+	meta.Failures = make([]*pb.CommitFailure, len(car.Failures))
+	for i, failure := range car.Failures {
+		meta.Failures[i] = &pb.CommitFailure{
+			Commit: failure.Commit.String(),
+			Index:  int32(failure.Index),
+			Item:   failure.Item,
+			Error:  failure.Error,
+		}
+	}
+	meta.Skipped = make([]*pb.SkippedItem, len(car.Skipped))
+	for i, skipped := range car.Skipped {
+		meta.Skipped[i] = &pb.SkippedItem{Item: skipped.Item, Reason: skipped.Reason}
+	}
+	meta.Markers = make([]*pb.Marker, len(car.Markers))
+	for i, marker := range car.Markers {
+		meta.Markers[i] = &pb.Marker{Label: marker.Label, UnixTime: marker.Time}
+		if !marker.Commit.IsZero() {
+			meta.Markers[i].Commit = marker.Commit.String()
+		}
+	}
 	return meta
 }
 
@@ -185,12 +382,29 @@ type Metadata = pb.Metadata
 
 // MetadataToCommonAnalysisResult copies the data from a Protobuf message.
 func MetadataToCommonAnalysisResult(meta *Metadata) *CommonAnalysisResult {
-	return &CommonAnalysisResult{
+	car := &CommonAnalysisResult{
 		BeginTime:     meta.BeginUnixTime,
 		EndTime:       meta.EndUnixTime,
 		CommitsNumber: int(meta.Commits),
 		RunTime:       time.Duration(meta.RunTime * 1e6),
+		Partial:       meta.Partial,
+	}
+	for _, failure := range meta.Failures {
+		car.Failures = append(car.Failures, CommitFailure{
+			Commit: plumbing.NewHash(failure.Commit),
+			Index:  int(failure.Index),
+			Item:   failure.Item,
+			Error:  failure.Error,
+		})
+	}
+	for _, skipped := range meta.Skipped {
+		car.Skipped = append(car.Skipped, SkippedItem{Item: skipped.Item, Reason: skipped.Reason})
+	}
+	for _, marker := range meta.Markers {
+		car.Markers = append(car.Markers, Marker{
+			Label: marker.Label, Time: marker.UnixTime, Commit: plumbing.NewHash(marker.Commit)})
 	}
+	return car
 }
 
 // Pipeline is the core Hercules entity which carries several PipelineItems and executes them.
@@ -201,6 +415,10 @@ type Pipeline struct {
 	// second is the total number of commits.
 	OnProgress func(int, int)
 
+	// OnCommit is the callback which is invoked in Run() after each commit is processed.
+	// The per-item timings are measured only if it is set.
+	OnCommit func(CommitProgress)
+
 	// Repository points to the analysed Git repository struct from go-git.
 	repository *git.Repository
 
@@ -213,6 +431,45 @@ type Pipeline struct {
 
 	// Feature flags which enable the corresponding items.
 	features map[string]bool
+
+	// disabledFeatures maps the dependencies which were not deployed to the features
+	// which disabled their providers, see DeployItem().
+	disabledFeatures map[string][]string
+
+	// skipped are the items which resolve() removed because of the disabled features.
+	skipped []SkippedItem
+
+	// skipErrors makes Run() skip the commits which fail instead of aborting.
+	skipErrors bool
+
+	// commitTimeout is the time budget of a single commit, see ConfigPipelineCommitTimeout.
+	commitTimeout time.Duration
+
+	// deadline is the time budget of Run(), see ConfigPipelineDeadline.
+	deadline time.Duration
+
+	// dayZero is the resolved ConfigPipelineDayZero, zero if not set.
+	dayZero time.Time
+
+	// markers are loaded from ConfigPipelineMarkers.
+	markers []Marker
+
+	// memoryLimit is the resident memory in megabytes which triggers the shrinking of the items,
+	// see ConfigPipelineMemoryLimit.
+	memoryLimit int
+
+	// workers is the number of goroutines which run the items on each commit,
+	// see ConfigPipelineWorkers.
+	workers int
+
+	// branches enables the branch-aware analysis, see ConfigPipelineBranches.
+	branches bool
+
+	// checkpoint is the position in the commit sequence, see Dump() and Load().
+	checkpoint pipelineCheckpoint
+
+	// resumed indicates that Load() has restored the state and Run() must skip the consumed commits.
+	resumed bool
 }
 
 const (
@@ -227,6 +484,52 @@ const (
 	// ConfigPipelineCommits is the name of the Pipeline configuration option (Pipeline.Initialize())
 	// which allows to specify the custom commit sequence. By default, Pipeline.Commits() is used.
 	ConfigPipelineCommits = "commits"
+	// ConfigPipelineSkipErrors is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which makes Run() log the errors and panics in Consume(), skip the failed commit for the
+	// failed item and the items which depend on it and continue. The failures are reported in
+	// CommonAnalysisResult.
+	ConfigPipelineSkipErrors = "Pipeline.SkipErrors"
+	// ConfigPipelineCommitTimeout is the name of the Pipeline configuration option
+	// (Pipeline.Initialize()) which sets the time budget of a single commit (time.Duration).
+	// If the commit exceeds it, the rest of the items which are enabled by features (e.g. UAST)
+	// and the items which depend on them are skipped and the skips are reported in
+	// CommonAnalysisResult.Failures.
+	ConfigPipelineCommitTimeout = "Pipeline.CommitTimeout"
+	// ConfigPipelineDeadline is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the time budget of Run() (time.Duration). If it is exceeded, the rest of the
+	// commits are not processed and the results are marked as CommonAnalysisResult.Partial.
+	ConfigPipelineDeadline = "Pipeline.Deadline"
+	// ConfigPipelineDayZero is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which anchors the day indexes and CommonAnalysisResult.BeginTime to the specified date
+	// ("2006-01-02" or RFC3339) or tag instead of the first commit. This way the results from
+	// several repositories share the same time axis.
+	ConfigPipelineDayZero = "Pipeline.DayZero"
+	// FactPipelineDayZero is the resolved ConfigPipelineDayZero (time.Time) which
+	// Pipeline.Initialize() passes to the items. It is absent if the option is not set.
+	FactPipelineDayZero = "Pipeline.DayZeroTime"
+	// ConfigPipelineMemoryLimit is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the resident memory in megabytes (int) above which Run() calls Shrink() of every
+	// ShrinkablePipelineItem, e.g. to evict the caches. Zero disables the watchdog.
+	ConfigPipelineMemoryLimit = "Pipeline.MemoryLimit"
+	// ConfigPipelineMarkers is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the path to the file with the labeled events, e.g. migrations or incidents,
+	// which are written to CommonAnalysisResult.Markers. See LoadMarkers() for the format.
+	ConfigPipelineMarkers = "Pipeline.Markers"
+	// ConfigPipelineWorkers is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the number of goroutines (int) which run the independent items concurrently on
+	// each commit. The values less than 2 keep the sequential execution.
+	ConfigPipelineWorkers = "Pipeline.Workers"
+	// ConfigPipelineBranches is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which enables the branch-aware analysis (bool): the commits are all the commits reachable
+	// from HEAD (Pipeline.AllCommits()) and every ForkablePipelineItem follows each branch
+	// separately, from the branch point to the merge commit. The LinearPipelineItem-s are
+	// not supported.
+	ConfigPipelineBranches = "Pipeline.Branches"
+	// ConfigPipelineSaveState is the name of the Pipeline configuration option
+	// (Pipeline.Initialize()) which declares that the state is going to be saved with Dump()
+	// after Run() (bool). Initialize() fails unless every item is a PersistentPipelineItem,
+	// so that the analysis does not run in vain.
+	ConfigPipelineSaveState = "Pipeline.SaveState"
 )
 
 // NewPipeline initializes a new instance of Pipeline struct.
@@ -236,6 +539,8 @@ func NewPipeline(repository *git.Repository) *Pipeline {
 		items:      []PipelineItem{},
 		facts:      map[string]interface{}{},
 		features:   map[string]bool{},
+
+		disabledFeatures: map[string][]string{},
 	}
 }
 
@@ -282,6 +587,15 @@ func (pipeline *Pipeline) SetFeaturesFromFlags(registry ...*PipelineItemRegistry
 // DeployItem inserts a PipelineItem into the pipeline. It also recursively creates all of it's
 // dependencies (PipelineItem.Requires()). Returns the same item as specified in the arguments.
 func (pipeline *Pipeline) DeployItem(item PipelineItem) PipelineItem {
+	if opi, ok := item.(OptionallyFeaturedPipelineItem); ok {
+		enabled := []string{}
+		for _, f := range opi.OptionalFeatures() {
+			if pipeline.features[f] {
+				enabled = append(enabled, f)
+			}
+		}
+		opi.EnableFeatures(enabled)
+	}
 	fpi, ok := item.(FeaturedPipelineItem)
 	if ok {
 		for _, f := range fpi.Features() {
@@ -308,6 +622,7 @@ func (pipeline *Pipeline) DeployItem(item PipelineItem) PipelineItem {
 						for _, feature := range fpi.Features() {
 							if !pipeline.features[feature] {
 								disabled = true
+								pipeline.disableDependency(dep, feature)
 								break
 							}
 						}
@@ -325,6 +640,17 @@ func (pipeline *Pipeline) DeployItem(item PipelineItem) PipelineItem {
 	return item
 }
 
+// disableDependency records that the provider of `dep` was not deployed because `feature`
+// is disabled.
+func (pipeline *Pipeline) disableDependency(dep string, feature string) {
+	for _, existing := range pipeline.disabledFeatures[dep] {
+		if existing == feature {
+			return
+		}
+	}
+	pipeline.disabledFeatures[dep] = append(pipeline.disabledFeatures[dep], feature)
+}
+
 // AddItem inserts a PipelineItem into the pipeline. It does not check any dependencies.
 // See also: DeployItem().
 func (pipeline *Pipeline) AddItem(item PipelineItem) PipelineItem {
@@ -389,7 +715,53 @@ func (items sortablePipelineItems) Swap(i, j int) {
 	items[i], items[j] = items[j], items[i]
 }
 
+// pruneDisabled removes the items which depend on the entities which were not deployed
+// because of the disabled features, directly or through the other removed items.
+// The removed items are recorded in pipeline.skipped.
+func (pipeline *Pipeline) pruneDisabled() {
+	reasons := map[string]string{}
+	for dep, features := range pipeline.disabledFeatures {
+		reasons[dep] = fmt.Sprintf("%s requires the disabled feature %s",
+			dep, strings.Join(features, ", "))
+	}
+	for pruned := true; pruned; {
+		pruned = false
+		provided := map[string]bool{}
+		for _, item := range pipeline.items {
+			for _, key := range item.Provides() {
+				provided[key] = true
+			}
+		}
+		items := make([]PipelineItem, 0, len(pipeline.items))
+		for _, item := range pipeline.items {
+			reason := ""
+			for _, key := range item.Requires() {
+				if provided[key] {
+					continue
+				}
+				if reasons[key] != "" {
+					reason = reasons[key]
+					break
+				}
+			}
+			if reason == "" {
+				items = append(items, item)
+				continue
+			}
+			pruned = true
+			pipeline.skipped = append(pipeline.skipped, SkippedItem{Item: item.Name(), Reason: reason})
+			log.Printf("Warning: skipped %s: %s\n", item.Name(), reason)
+			for _, key := range item.Provides() {
+				reasons[key] = fmt.Sprintf("%s is provided by the skipped %s: %s",
+					key, item.Name(), reason)
+			}
+		}
+		pipeline.items = items
+	}
+}
+
 func (pipeline *Pipeline) resolve(dumpPath string) {
+	pipeline.pruneDisabled()
 	graph := toposort.NewGraph()
 	sort.Sort(sortablePipelineItems(pipeline.items))
 	name2item := map[string]PipelineItem{}
@@ -521,11 +893,50 @@ func (pipeline *Pipeline) Initialize(facts map[string]interface{}) {
 	if facts == nil {
 		facts = map[string]interface{}{}
 	}
+	pipeline.branches, _ = facts[ConfigPipelineBranches].(bool)
 	if _, exists := facts[ConfigPipelineCommits]; !exists {
-		facts[ConfigPipelineCommits] = pipeline.Commits()
+		if pipeline.branches {
+			facts[ConfigPipelineCommits] = pipeline.AllCommits()
+		} else {
+			facts[ConfigPipelineCommits] = pipeline.Commits()
+		}
 	}
 	dumpPath, _ := facts[ConfigPipelineDumpPath].(string)
+	pipeline.skipErrors, _ = facts[ConfigPipelineSkipErrors].(bool)
+	pipeline.commitTimeout, _ = facts[ConfigPipelineCommitTimeout].(time.Duration)
+	pipeline.deadline, _ = facts[ConfigPipelineDeadline].(time.Duration)
+	pipeline.memoryLimit, _ = facts[ConfigPipelineMemoryLimit].(int)
+	pipeline.workers, _ = facts[ConfigPipelineWorkers].(int)
+	pipeline.checkpoint = pipelineCheckpoint{}
+	pipeline.resumed = false
+	pipeline.dayZero = time.Time{}
+	if anchor, _ := facts[ConfigPipelineDayZero].(string); anchor != "" {
+		dayZero, err := ResolveDayZero(anchor, pipeline.repository)
+		if err != nil {
+			panic(err)
+		}
+		pipeline.dayZero = dayZero
+		facts[FactPipelineDayZero] = dayZero
+	}
+	pipeline.markers = nil
+	if path, _ := facts[ConfigPipelineMarkers].(string); path != "" {
+		markers, err := LoadMarkers(path, pipeline.repository)
+		if err != nil {
+			panic(err)
+		}
+		pipeline.markers = markers
+	}
 	pipeline.resolve(dumpPath)
+	if pipeline.branches {
+		if err := pipeline.checkBranches(); err != nil {
+			panic(err)
+		}
+	}
+	if saveState, _ := facts[ConfigPipelineSaveState].(bool); saveState {
+		if err := pipeline.checkPersistent(); err != nil {
+			panic(err)
+		}
+	}
 	if dryRun, _ := facts[ConfigPipelineDryRun].(bool); dryRun {
 		return
 	}
@@ -537,58 +948,326 @@ func (pipeline *Pipeline) Initialize(facts map[string]interface{}) {
 	}
 }
 
+// checkBranches fails if any of the items must consume a single line of development,
+// see LinearPipelineItem.
+func (pipeline *Pipeline) checkBranches() error {
+	var linear []string
+	for _, item := range pipeline.items {
+		if _, forkable := Unwrap(item).(ForkablePipelineItem); forkable {
+			continue
+		}
+		if lpi, ok := Unwrap(item).(LinearPipelineItem); ok && lpi.RequiresLinearHistory() {
+			linear = append(linear, item.Name())
+		}
+	}
+	if len(linear) > 0 {
+		return fmt.Errorf("%s cannot follow the branches, disable %s",
+			strings.Join(linear, ", "), ConfigPipelineBranches)
+	}
+	return nil
+}
+
 // Run method executes the pipeline.
 //
 // commits is a slice with the sequential commit history. It shall start from
 // the root (ascending order).
 //
+// If the state was restored with Load(), the commits up to and including the last consumed one
+// are skipped and CommonAnalysisResult describes the whole analysed sequence.
+//
+// If ConfigPipelineBranches is set, the commits must be in the topological order instead, e.g.
+// as returned by AllCommits(), and the ForkablePipelineItem-s are forked and merged following
+// the parents of the commits.
+//
 // Returns the mapping from each LeafPipelineItem to the corresponding analysis result.
 // There is always a "nil" record with CommonAnalysisResult.
 func (pipeline *Pipeline) Run(commits []*object.Commit) (map[LeafPipelineItem]interface{}, error) {
 	startRunTime := time.Now()
+	previous := pipelineCheckpoint{}
+	if pipeline.resumed {
+		var err error
+		if commits, err = pipeline.skipConsumed(commits); err != nil {
+			return nil, err
+		}
+		previous = pipeline.checkpoint
+	}
 	onProgress := pipeline.OnProgress
 	if onProgress == nil {
 		onProgress = func(int, int) {}
 	}
+	var failures []CommitFailure
+	processed := len(commits)
+	var watchdog *memoryWatchdog
+	if pipeline.memoryLimit > 0 {
+		watchdog = startMemoryWatchdog(uint64(pipeline.memoryLimit) << 20)
+		defer watchdog.Stop()
+	}
+	var scheduler *commitScheduler
+	if pipeline.workers > 1 {
+		scheduler = newCommitScheduler(pipeline.items, pipeline.workers, pipeline.consume)
+		defer scheduler.Stop()
+	}
+	var branches *branchTracker
+	if pipeline.branches {
+		var err error
+		if branches, err = newBranchTracker(pipeline.items, commits); err != nil {
+			return nil, err
+		}
+		defer func() {
+			pipeline.items = branches.items
+		}()
+	}
 
 	for index, commit := range commits {
+		if pipeline.deadline > 0 && time.Since(startRunTime) > pipeline.deadline {
+			log.Printf("The deadline is reached after %d commits out of %d, finalizing\n",
+				index, len(commits))
+			processed = index
+			break
+		}
+		if watchdog != nil && watchdog.Exceeded() {
+			pipeline.shrink()
+		}
 		onProgress(index, len(commits))
-		state := map[string]interface{}{"commit": commit, "index": index}
-		for _, item := range pipeline.items {
-			update, err := item.Consume(state)
-			if err != nil {
-				log.Printf("%s failed on commit #%d %s\n",
-					item.Name(), index, commit.Hash.String())
-				return nil, err
-			}
-			for _, key := range item.Provides() {
-				val, ok := update[key]
-				if !ok {
-					panic(fmt.Sprintf("%s: Consume() did not return %s", item.Name(), key))
-				}
-				state[key] = val
+		// the index is continuous across the resumed runs
+		position := previous.CommitsNumber + index
+		state := map[string]interface{}{"commit": commit, "index": position}
+		if branches != nil {
+			pipeline.items = branches.checkout(index)
+			if scheduler != nil {
+				scheduler.items = pipeline.items
 			}
 		}
+		var timings map[string]time.Duration
+		if pipeline.OnCommit != nil {
+			timings = map[string]time.Duration{}
+		}
+		var commitFailures []CommitFailure
+		var err error
+		if scheduler != nil {
+			commitFailures, err = scheduler.consumeCommit(pipeline, state, timings)
+		} else {
+			commitFailures, err = pipeline.consumeCommit(state, timings)
+		}
+		if err != nil {
+			return nil, err
+		}
+		failures = append(failures, commitFailures...)
+		if branches != nil {
+			branches.commit(index, pipeline.items)
+		}
+		if pipeline.OnCommit != nil {
+			pipeline.OnCommit(CommitProgress{
+				Hash: commit.Hash, Index: index, Total: len(commits),
+				Elapsed: time.Since(startRunTime), Timings: timings,
+			})
+		}
 	}
 	onProgress(len(commits), len(commits))
+	if branches != nil {
+		pipeline.items = branches.items
+	}
+	if processed == 0 && !pipeline.resumed {
+		return nil, errors.New("the deadline is reached before the first commit was analysed")
+	}
+	pipeline.checkpoint = previous
+	if processed > 0 {
+		if pipeline.checkpoint.CommitsNumber == 0 {
+			pipeline.checkpoint.BeginTime = commits[0].Author.When.Unix()
+		}
+		pipeline.checkpoint.LastCommit = commits[processed-1].Hash
+		pipeline.checkpoint.EndTime = commits[processed-1].Author.When.Unix()
+		pipeline.checkpoint.CommitsNumber += processed
+	}
 	result := map[LeafPipelineItem]interface{}{}
 	for _, item := range pipeline.items {
 		if casted, ok := item.(LeafPipelineItem); ok {
 			result[casted] = casted.Finalize()
 		}
 	}
+	beginTime := pipeline.checkpoint.BeginTime
+	if !pipeline.dayZero.IsZero() {
+		beginTime = pipeline.dayZero.Unix()
+	}
 	result[nil] = &CommonAnalysisResult{
-		BeginTime:     commits[0].Author.When.Unix(),
-		EndTime:       commits[len(commits)-1].Author.When.Unix(),
-		CommitsNumber: len(commits),
+		BeginTime:     beginTime,
+		EndTime:       pipeline.checkpoint.EndTime,
+		CommitsNumber: pipeline.checkpoint.CommitsNumber,
 		RunTime:       time.Since(startRunTime),
+		Failures:      failures,
+		Partial:       processed < len(commits),
+		Skipped:       pipeline.skipped,
+		Markers:       pipeline.markers,
 	}
 	return result, nil
 }
 
+// shrink calls Shrink() of every ShrinkablePipelineItem and returns the freed memory to the OS.
+func (pipeline *Pipeline) shrink() {
+	names := []string{}
+	for _, item := range pipeline.items {
+		if _, ok := Unwrap(item).(ShrinkablePipelineItem); ok {
+			item.(ShrinkablePipelineItem).Shrink()
+			names = append(names, item.Name())
+		}
+	}
+	log.Printf("Warning: the resident memory exceeded %d MB, shrinking [%s]\n",
+		pipeline.memoryLimit, strings.Join(names, ", "))
+	debug.FreeOSMemory()
+}
+
+// consumeCommit runs the items on the commit in the state one by one in the topological order.
+// It returns the skipped items and the first error if the errors are not skipped.
+func (pipeline *Pipeline) consumeCommit(
+	state map[string]interface{}, timings map[string]time.Duration) ([]CommitFailure, error) {
+	startCommitTime := time.Now()
+	var failures []CommitFailure
+	for _, item := range pipeline.items {
+		if reason := pipeline.skipReason(item, state, startCommitTime); reason != "" {
+			failures = append(failures, newCommitFailure(state, item, reason))
+			continue
+		}
+		startConsumeTime := time.Now()
+		update, err := pipeline.consume(item, state)
+		if timings != nil {
+			timings[item.Name()] = time.Since(startConsumeTime)
+		}
+		if err != nil {
+			if fatal := pipeline.handleError(item, state, err); fatal != nil {
+				return nil, fatal
+			}
+			failures = append(failures, newCommitFailure(state, item, err.Error()))
+			continue
+		}
+		applyUpdate(item, update, state)
+	}
+	return failures, nil
+}
+
+// skipReason returns why the item must not consume the commit in the state: either an entity
+// which it requires has not been produced or the commit timeout is exceeded and the item is
+// featured. It returns an empty string if the item must run.
+func (pipeline *Pipeline) skipReason(
+	item PipelineItem, state map[string]interface{}, startCommitTime time.Time) string {
+	if pipeline.skipErrors || pipeline.commitTimeout > 0 {
+		if missing := missingRequirement(item, state); missing != "" {
+			return "skipped because " + missing + " is not available"
+		}
+	}
+	if pipeline.commitTimeout > 0 && time.Since(startCommitTime) > pipeline.commitTimeout {
+		if featured, ok := item.(FeaturedPipelineItem); ok && len(featured.Features()) > 0 {
+			return "skipped because the commit timeout is exceeded"
+		}
+	}
+	return ""
+}
+
+// handleError logs the error returned by item.Consume(). It returns nil if the error is skipped
+// and the error itself otherwise.
+func (pipeline *Pipeline) handleError(
+	item PipelineItem, state map[string]interface{}, err error) error {
+	commit := state["commit"].(*object.Commit)
+	log.Printf("%s failed on commit #%d %s\n", item.Name(), state["index"].(int), commit.Hash.String())
+	if !pipeline.skipErrors {
+		return err
+	}
+	log.Printf("%v, skipping\n", err)
+	return nil
+}
+
+// newCommitFailure records that the item did not consume the commit in the state.
+func newCommitFailure(state map[string]interface{}, item PipelineItem, reason string) CommitFailure {
+	return CommitFailure{
+		Commit: state["commit"].(*object.Commit).Hash, Index: state["index"].(int),
+		Item: item.Name(), Error: reason,
+	}
+}
+
+// applyUpdate inserts the entities which the item has produced into the state.
+func applyUpdate(item PipelineItem, update map[string]interface{}, state map[string]interface{}) {
+	for _, key := range item.Provides() {
+		val, ok := update[key]
+		if !ok {
+			panic(fmt.Sprintf("%s: Consume() did not return %s", item.Name(), key))
+		}
+		state[key] = val
+	}
+}
+
+// consume calls item.Consume() and converts the panics to errors if the errors are skipped.
+func (pipeline *Pipeline) consume(item PipelineItem, state map[string]interface{}) (
+	update map[string]interface{}, err error) {
+	if pipeline.skipErrors {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+	}
+	return item.Consume(state)
+}
+
+// missingRequirement returns the first entity required by the item which is absent in the state
+// because the item which provides it has failed. It returns an empty string if all are present.
+func missingRequirement(item PipelineItem, state map[string]interface{}) string {
+	for _, key := range item.Requires() {
+		if _, exists := state[key]; !exists {
+			return key
+		}
+	}
+	return ""
+}
+
 // LoadCommitsFromFile reads the file by the specified FS path and generates the sequence of commits
-// by interpreting each line as a Git commit hash.
+// by interpreting each line as a Git commit hash. The path "-" means stdin. Only the first word
+// of each line is the hash, so that the output of `git log --format="%H %s"` is accepted.
+// Empty lines and the lines which start with "#" are ignored.
 func LoadCommitsFromFile(path string, repository *git.Repository) ([]*object.Commit, error) {
+	hashes, err := readCommitHashes(path)
+	if err != nil {
+		return nil, err
+	}
+	commits := make([]*object.Commit, 0, len(hashes))
+	for _, hash := range hashes {
+		commit, err := repository.CommitObject(hash)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", hash.String(), err)
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// ExcludeCommitsFromFile returns the commits except those listed in the file by the specified
+// FS path, preserving the order. The file format is the same as in LoadCommitsFromFile().
+// The changes of the excluded commits are attributed to the next commit in the sequence.
+func ExcludeCommitsFromFile(commits []*object.Commit, path string) ([]*object.Commit, error) {
+	hashes, err := readCommitHashes(path)
+	if err != nil {
+		return nil, err
+	}
+	excluded := map[plumbing.Hash]bool{}
+	for _, hash := range hashes {
+		excluded[hash] = false
+	}
+	result := make([]*object.Commit, 0, len(commits))
+	for _, commit := range commits {
+		if _, exists := excluded[commit.Hash]; exists {
+			excluded[commit.Hash] = true
+			continue
+		}
+		result = append(result, commit)
+	}
+	for _, hash := range hashes {
+		if !excluded[hash] {
+			log.Printf("Warning: excluded commit %s is not in the analysed sequence\n", hash.String())
+		}
+	}
+	return result, nil
+}
+
+// readCommitHashes parses the file with the commit hashes, see LoadCommitsFromFile().
+func readCommitHashes(path string) ([]plumbing.Hash, error) {
 	var file io.ReadCloser
 	if path != "-" {
 		var err error
@@ -601,17 +1280,51 @@ func LoadCommitsFromFile(path string, repository *git.Repository) ([]*object.Com
 		file = os.Stdin
 	}
 	scanner := bufio.NewScanner(file)
-	commits := []*object.Commit{}
-	for scanner.Scan() {
-		hash := plumbing.NewHash(scanner.Text())
-		if len(hash) != 20 {
-			return nil, errors.New("invalid commit hash " + scanner.Text())
+	hashes := []plumbing.Hash{}
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
 		}
-		commit, err := repository.CommitObject(hash)
+		if _, err := hex.DecodeString(fields[0]); err != nil || len(fields[0]) != 40 {
+			return nil, fmt.Errorf("%s:%d: invalid commit hash %s", path, line, fields[0])
+		}
+		hashes = append(hashes, plumbing.NewHash(fields[0]))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// ResolveDayZero converts the value of ConfigPipelineDayZero to the time. The anchor is either
+// a date in "2006-01-02" (UTC) or RFC3339 format or the name of a tag; in the latter case,
+// the author date of the tagged commit is returned.
+func ResolveDayZero(anchor string, repository *git.Repository) (time.Time, error) {
+	if date, err := time.Parse("2006-01-02", anchor); err == nil {
+		return date, nil
+	}
+	if date, err := time.Parse(time.RFC3339, anchor); err == nil {
+		return date, nil
+	}
+	if repository == nil {
+		return time.Time{}, fmt.Errorf("day zero %q is neither a date nor a tag", anchor)
+	}
+	ref, err := repository.Tag(anchor)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("day zero %q is neither a date nor a tag: %v", anchor, err)
+	}
+	var commit *object.Commit
+	if tag, err := repository.TagObject(ref.Hash()); err == nil {
+		commit, err = tag.Commit()
 		if err != nil {
-			return nil, err
+			return time.Time{}, err
+		}
+	} else {
+		commit, err = repository.CommitObject(ref.Hash())
+		if err != nil {
+			return time.Time{}, err
 		}
-		commits = append(commits, commit)
 	}
-	return commits, nil
+	return commit.Author.When, nil
 }
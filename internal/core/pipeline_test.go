@@ -6,11 +6,14 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-billy.v4/memfs"
 	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
 	"gopkg.in/src-d/hercules.v4/internal/pb"
 	"gopkg.in/src-d/hercules.v4/internal/test"
 )
@@ -89,6 +92,10 @@ func (item *testPipelineItem) Serialize(result interface{}, binary bool, writer
 	return nil
 }
 
+func (item *testPipelineItem) SerializeJSON(result interface{}, writer io.Writer) error {
+	return nil
+}
+
 type dependingTestPipelineItem struct {
 	DependencySatisfied  bool
 	TestNilConsumeReturn bool
@@ -146,6 +153,10 @@ func (item *dependingTestPipelineItem) Serialize(result interface{}, binary bool
 	return nil
 }
 
+func (item *dependingTestPipelineItem) SerializeJSON(result interface{}, writer io.Writer) error {
+	return nil
+}
+
 func TestPipelineFacts(t *testing.T) {
 	pipeline := NewPipeline(test.Repository)
 	pipeline.SetFact("fact", "value")
@@ -223,6 +234,113 @@ func TestPipelineOnProgress(t *testing.T) {
 	assert.True(t, progressOk2)
 }
 
+func TestPipelineOnCommit(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	item := &testPipelineItem{}
+	pipeline.AddItem(item)
+	var progress []CommitProgress
+	pipeline.OnCommit = func(commit CommitProgress) {
+		progress = append(progress, commit)
+	}
+	pipeline.Initialize(map[string]interface{}{})
+	commits := make([]*object.Commit, 2)
+	commits[0], _ = test.Repository.CommitObject(plumbing.NewHash(
+		"af9ddc0db70f09f3f27b4b98e415592a7485171c"))
+	commits[1], _ = test.Repository.CommitObject(plumbing.NewHash(
+		"cce947b98a050c6d356bc6ba95030254914027b1"))
+	_, err := pipeline.Run(commits)
+	assert.Nil(t, err)
+	assert.Len(t, progress, 2)
+	for i, commit := range progress {
+		assert.Equal(t, commit.Hash, commits[i].Hash)
+		assert.Equal(t, commit.Index, i)
+		assert.Equal(t, commit.Total, 2)
+		assert.Len(t, commit.Timings, 1)
+		_, exists := commit.Timings[item.Name()]
+		assert.True(t, exists)
+	}
+	assert.True(t, progress[1].Elapsed >= progress[0].Elapsed)
+}
+
+// sleepingTestPipelineItem is slow and is not featured.
+type sleepingTestPipelineItem struct {
+	testPipelineItem
+}
+
+func (item *sleepingTestPipelineItem) Name() string {
+	return "Sleep"
+}
+
+func (item *sleepingTestPipelineItem) Provides() []string {
+	arr := [...]string{"sleep"}
+	return arr[:]
+}
+
+func (item *sleepingTestPipelineItem) Features() []string {
+	return nil
+}
+
+func (item *sleepingTestPipelineItem) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	time.Sleep(10 * time.Millisecond)
+	return map[string]interface{}{"sleep": item}, nil
+}
+
+// afterSleepTestPipelineItem is featured and runs after sleepingTestPipelineItem.
+type afterSleepTestPipelineItem struct {
+	testPipelineItem
+}
+
+func (item *afterSleepTestPipelineItem) Name() string {
+	return "AfterSleep"
+}
+
+func (item *afterSleepTestPipelineItem) Requires() []string {
+	arr := [...]string{"sleep"}
+	return arr[:]
+}
+
+func TestPipelineCommitTimeout(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	item1 := &sleepingTestPipelineItem{}
+	item2 := &afterSleepTestPipelineItem{}
+	item3 := &dependingTestPipelineItem{}
+	pipeline.AddItem(item1)
+	pipeline.AddItem(item2)
+	pipeline.AddItem(item3)
+	pipeline.Initialize(map[string]interface{}{ConfigPipelineCommitTimeout: time.Millisecond})
+	commits := make([]*object.Commit, 1)
+	commits[0], _ = test.Repository.CommitObject(plumbing.NewHash(
+		"af9ddc0db70f09f3f27b4b98e415592a7485171c"))
+	result, err := pipeline.Run(commits)
+	assert.Nil(t, err)
+	assert.False(t, item2.DepsConsumed)
+	assert.False(t, item3.DependencySatisfied)
+	failures := result[nil].(*CommonAnalysisResult).Failures
+	assert.Len(t, failures, 2)
+	assert.Equal(t, failures[0].Item, item2.Name())
+	assert.Equal(t, failures[0].Error, "skipped because the commit timeout is exceeded")
+	assert.Equal(t, failures[1].Item, item3.Name())
+	assert.False(t, result[nil].(*CommonAnalysisResult).Partial)
+}
+
+func TestPipelineDeadline(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	item := &sleepingTestPipelineItem{}
+	pipeline.AddItem(item)
+	pipeline.Initialize(map[string]interface{}{ConfigPipelineDeadline: 5 * time.Millisecond})
+	commits := make([]*object.Commit, 2)
+	commits[0], _ = test.Repository.CommitObject(plumbing.NewHash(
+		"af9ddc0db70f09f3f27b4b98e415592a7485171c"))
+	commits[1], _ = test.Repository.CommitObject(plumbing.NewHash(
+		"cce947b98a050c6d356bc6ba95030254914027b1"))
+	result, err := pipeline.Run(commits)
+	assert.Nil(t, err)
+	common := result[nil].(*CommonAnalysisResult)
+	assert.True(t, common.Partial)
+	assert.Equal(t, common.CommitsNumber, 1)
+	assert.Equal(t, common.EndTime, commits[0].Author.When.Unix())
+}
+
 func TestPipelineCommits(t *testing.T) {
 	pipeline := NewPipeline(test.Repository)
 	commits := pipeline.Commits()
@@ -266,6 +384,48 @@ func TestLoadCommitsFromFile(t *testing.T) {
 	commits, err = LoadCommitsFromFile(tmp.Name(), test.Repository)
 	assert.Nil(t, commits)
 	assert.NotNil(t, err)
+	tmp, err = ioutil.TempFile("", "hercules-test-")
+	assert.Nil(t, err)
+	tmp.WriteString("# curated\n\ncce947b98a050c6d356bc6ba95030254914027b1 Initial\n" +
+		"  6db8065cdb9bb0758f36a7e75fc72ab95f9e8145\t# second\n")
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+	commits, err = LoadCommitsFromFile(tmp.Name(), test.Repository)
+	assert.Nil(t, err)
+	assert.Len(t, commits, 2)
+	assert.Equal(t, commits[1].Hash, plumbing.NewHash(
+		"6db8065cdb9bb0758f36a7e75fc72ab95f9e8145"))
+}
+
+func TestExcludeCommitsFromFile(t *testing.T) {
+	hashes := []string{
+		"cce947b98a050c6d356bc6ba95030254914027b1",
+		"6db8065cdb9bb0758f36a7e75fc72ab95f9e8145",
+		"fc9ceecb6dabcb2aab60e8619d972e8d8208a7df",
+	}
+	commits := make([]*object.Commit, len(hashes))
+	for i, hash := range hashes {
+		commits[i] = &object.Commit{Hash: plumbing.NewHash(hash)}
+	}
+	tmp, err := ioutil.TempFile("", "hercules-test-")
+	assert.Nil(t, err)
+	tmp.WriteString("6db8065cdb9bb0758f36a7e75fc72ab95f9e8145 huge import\n" +
+		"ffffffffffffffffffffffffffffffffffffffff\n")
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+	result, err := ExcludeCommitsFromFile(commits, tmp.Name())
+	assert.Nil(t, err)
+	assert.Equal(t, result, []*object.Commit{commits[0], commits[2]})
+	assert.Len(t, commits, 3)
+	_, err = ExcludeCommitsFromFile(commits, "/WAT?xxx!")
+	assert.NotNil(t, err)
+	tmp, err = ioutil.TempFile("", "hercules-test-")
+	assert.Nil(t, err)
+	tmp.WriteString("6db8065\n")
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+	_, err = ExcludeCommitsFromFile(commits, tmp.Name())
+	assert.NotNil(t, err)
 }
 
 func TestPipelineDeps(t *testing.T) {
@@ -308,6 +468,30 @@ func TestPipelineError(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestPipelineSkipErrors(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	item1 := &dependingTestPipelineItem{}
+	item2 := &testPipelineItem{}
+	item2.TestError = true
+	pipeline.AddItem(item1)
+	pipeline.AddItem(item2)
+	pipeline.Initialize(map[string]interface{}{ConfigPipelineSkipErrors: true})
+	commits := make([]*object.Commit, 1)
+	commits[0], _ = test.Repository.CommitObject(plumbing.NewHash(
+		"af9ddc0db70f09f3f27b4b98e415592a7485171c"))
+	result, err := pipeline.Run(commits)
+	assert.Nil(t, err)
+	assert.False(t, item1.DependencySatisfied)
+	failures := result[nil].(*CommonAnalysisResult).Failures
+	assert.Len(t, failures, 2)
+	assert.Equal(t, failures[0].Commit, commits[0].Hash)
+	assert.Equal(t, failures[0].Index, 0)
+	assert.Equal(t, failures[0].Item, item2.Name())
+	assert.Equal(t, failures[0].Error, "error")
+	assert.Equal(t, failures[1].Item, item1.Name())
+	assert.Equal(t, failures[1].Error, "skipped because test is not available")
+}
+
 func TestCommonAnalysisResultMerge(t *testing.T) {
 	c1 := CommonAnalysisResult{
 		BeginTime: 1513620635, EndTime: 1513720635, CommitsNumber: 1, RunTime: 100}
@@ -320,17 +504,162 @@ func TestCommonAnalysisResultMerge(t *testing.T) {
 	assert.Equal(t, c1.EndTime, int64(1513730635))
 	assert.Equal(t, c1.CommitsNumber, 3)
 	assert.Equal(t, c1.RunTime.Nanoseconds(), int64(300))
+	c1.Skipped = []SkippedItem{{Item: "Test2", Reason: "reason"}}
+	c2.Skipped = []SkippedItem{{Item: "Test2", Reason: "reason"}, {Item: "Test3", Reason: "other"}}
+	c1.Merge(&c2)
+	assert.Equal(t, c1.Skipped, []SkippedItem{
+		{Item: "Test2", Reason: "reason"}, {Item: "Test3", Reason: "other"}})
+}
+
+func TestPipelinePruneDisabled(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	item := &dependingTestPipelineItem{}
+	pipeline.AddItem(item)
+	pipeline.disableDependency("test", "power")
+	pipeline.disableDependency("test", "power")
+	assert.Equal(t, pipeline.disabledFeatures, map[string][]string{"test": {"power"}})
+	pipeline.Initialize(map[string]interface{}{ConfigPipelineCommits: []*object.Commit{}})
+	assert.Equal(t, pipeline.Len(), 0)
+	assert.Equal(t, pipeline.skipped, []SkippedItem{{
+		Item: "Test2", Reason: "test requires the disabled feature power"}})
+	result, err := pipeline.Run([]*object.Commit{{Author: object.Signature{When: time.Now()}}})
+	assert.Nil(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, result[nil].(*CommonAnalysisResult).Skipped, pipeline.skipped)
+	// the provider is deployed in spite of the disabled feature
+	pipeline = NewPipeline(test.Repository)
+	pipeline.AddItem(item)
+	pipeline.AddItem(&testPipelineItem{})
+	pipeline.disableDependency("test", "power")
+	pipeline.Initialize(map[string]interface{}{ConfigPipelineCommits: []*object.Commit{}})
+	assert.Equal(t, pipeline.Len(), 2)
+	assert.Len(t, pipeline.skipped, 0)
+}
+
+func TestPipelineDayZero(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	facts := map[string]interface{}{
+		ConfigPipelineCommits: []*object.Commit{}, ConfigPipelineDayZero: "2018-01-01"}
+	pipeline.Initialize(facts)
+	dayZero := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, facts[FactPipelineDayZero], dayZero)
+	result, err := pipeline.Run([]*object.Commit{{Author: object.Signature{
+		When: time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)}}})
+	assert.Nil(t, err)
+	assert.Equal(t, result[nil].(*CommonAnalysisResult).BeginTime, dayZero.Unix())
+	assert.Panics(t, func() {
+		NewPipeline(nil).Initialize(map[string]interface{}{
+			ConfigPipelineCommits: []*object.Commit{}, ConfigPipelineDayZero: "v1.0"})
+	})
+}
+
+type shrinkableTestPipelineItem struct {
+	testPipelineItem
+	Shrunk int
+}
+
+func (item *shrinkableTestPipelineItem) Shrink() {
+	item.Shrunk++
+}
+
+func TestPipelineMemoryLimit(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	item := &shrinkableTestPipelineItem{}
+	pipeline.AddItem(item)
+	pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommits: []*object.Commit{}, ConfigPipelineMemoryLimit: 1})
+	assert.Equal(t, pipeline.memoryLimit, 1)
+	pipeline.shrink()
+	assert.Equal(t, item.Shrunk, 1)
+	// any process takes more than 1 MB
+	watchdog := startMemoryWatchdog(1 << 20)
+	assert.True(t, watchdog.Exceeded())
+	assert.False(t, watchdog.Exceeded())
+	watchdog.Stop()
+	watchdog = startMemoryWatchdog(1 << 60)
+	assert.False(t, watchdog.Exceeded())
+	watchdog.Stop()
+	assert.True(t, residentMemory() > 1<<20)
+}
+
+func TestResolveDayZero(t *testing.T) {
+	date, err := ResolveDayZero("2018-01-01", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, date, time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	date, err = ResolveDayZero("2018-01-01T12:00:00+03:00", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, date.Unix(), time.Date(2018, 1, 1, 9, 0, 0, 0, time.UTC).Unix())
+	_, err = ResolveDayZero("v1.0", nil)
+	assert.NotNil(t, err)
+	repository := fixtureDayZeroRepository()
+	_, err = ResolveDayZero("v3.0.0", repository)
+	assert.NotNil(t, err)
+	date, err = ResolveDayZero("v1.0.0", repository)
+	assert.Nil(t, err)
+	assert.Equal(t, date.Unix(), int64(1514808000))
+	date, err = ResolveDayZero("v2.0.0", repository)
+	assert.Nil(t, err)
+	assert.Equal(t, date.Unix(), int64(1514808000))
+}
+
+// fixtureDayZeroRepository creates a repository with a single commit which is tagged
+// with the lightweight v1.0.0 and the annotated v2.0.0.
+func fixtureDayZeroRepository() *git.Repository {
+	repository, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		panic(err)
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		panic(err)
+	}
+	file, err := worktree.Filesystem.Create("README")
+	if err != nil {
+		panic(err)
+	}
+	file.Write([]byte("hercules"))
+	file.Close()
+	worktree.Add("README")
+	signature := &object.Signature{
+		Name: "Vadim", Email: "vadim@sourced.tech",
+		When: time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC)}
+	hash, err := worktree.Commit("Initial", &git.CommitOptions{Author: signature})
+	if err != nil {
+		panic(err)
+	}
+	err = repository.Storer.SetReference(
+		plumbing.NewHashReference(plumbing.ReferenceName("refs/tags/v1.0.0"), hash))
+	if err != nil {
+		panic(err)
+	}
+	_, err = repository.CreateTag("v2.0.0", hash, &git.CreateTagOptions{
+		Tagger: signature, Message: "v2.0.0"})
+	if err != nil {
+		panic(err)
+	}
+	return repository
 }
 
 func TestCommonAnalysisResultMetadata(t *testing.T) {
 	c1 := &CommonAnalysisResult{
-		BeginTime: 1513620635, EndTime: 1513720635, CommitsNumber: 1, RunTime: 100 * 1e6}
+		BeginTime: 1513620635, EndTime: 1513720635, CommitsNumber: 1, RunTime: 100 * 1e6,
+		Failures: []CommitFailure{{
+			Commit: plumbing.NewHash("af9ddc0db70f09f3f27b4b98e415592a7485171c"),
+			Index:  5, Item: "Test", Error: "error"}},
+		Skipped: []SkippedItem{{Item: "Test2", Reason: "reason"}}}
 	meta := &pb.Metadata{}
 	c1 = MetadataToCommonAnalysisResult(c1.FillMetadata(meta))
 	assert.Equal(t, c1.BeginTimeAsTime().Unix(), int64(1513620635))
 	assert.Equal(t, c1.EndTimeAsTime().Unix(), int64(1513720635))
 	assert.Equal(t, c1.CommitsNumber, 1)
 	assert.Equal(t, c1.RunTime.Nanoseconds(), int64(100*1e6))
+	assert.Len(t, c1.Failures, 1)
+	assert.Equal(t, c1.Failures[0].Commit, plumbing.NewHash(
+		"af9ddc0db70f09f3f27b4b98e415592a7485171c"))
+	assert.Equal(t, c1.Failures[0].Index, 5)
+	assert.Equal(t, c1.Failures[0].Item, "Test")
+	assert.Equal(t, c1.Failures[0].Error, "error")
+	assert.Equal(t, c1.Skipped, []SkippedItem{{Item: "Test2", Reason: "reason"}})
 }
 
 func TestConfigurationOptionTypeString(t *testing.T) {
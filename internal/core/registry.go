@@ -5,9 +5,11 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 	"unsafe"
 
 	"github.com/spf13/pflag"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
 )
 
 // PipelineItemRegistry contains all the known PipelineItem-s.
@@ -25,6 +27,9 @@ func (registry *PipelineItemRegistry) Register(example PipelineItem) {
 	if fpi, ok := example.(LeafPipelineItem); ok {
 		registry.flags[fpi.Flag()] = t
 	}
+	if epi, ok := example.(ExtensionPipelineItem); ok {
+		pb.RegisterExtension(epi.ExtensionName(), epi.ExtensionMessage)
+	}
 	for _, dep := range example.Provides() {
 		ts := registry.provided[dep]
 		if ts == nil {
@@ -208,6 +213,50 @@ func (registry *PipelineItemRegistry) AddFlags(flagSet *pflag.FlagSet) (
 		*ptr2 = flagSet.Bool("dry-run", false, "Do not run any analyses - only resolve the DAG. "+
 			"Useful for -dump-dag.")
 		flags[ConfigPipelineDryRun] = iface
+		iface = interface{}(true)
+		ptr3 := (**bool)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr3 = flagSet.Bool("skip-errors", false, "Log the errors on single commits, skip "+
+			"those commits for the failed analyses and report them in the results instead of "+
+			"aborting.")
+		flags[ConfigPipelineSkipErrors] = iface
+		iface = interface{}(time.Duration(0))
+		ptr4 := (**time.Duration)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr4 = flagSet.Duration("commit-timeout", 0, "Skip the UAST and the other featured "+
+			"analyses of the commit if it takes longer, e.g. \"30s\". Zero means no limit.")
+		flags[ConfigPipelineCommitTimeout] = iface
+		iface = interface{}(time.Duration(0))
+		ptr5 := (**time.Duration)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr5 = flagSet.Duration("deadline", 0, "Stop processing the commits after this time, "+
+			"e.g. \"2h\", and write the partial results. Zero means no limit.")
+		flags[ConfigPipelineDeadline] = iface
+		iface = interface{}("")
+		ptr6 := (**string)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr6 = flagSet.String("day-zero", "", "Count the days since this date (\"2006-01-02\") "+
+			"or tag instead of the first commit, so that the results of several repositories "+
+			"share the same time axis.")
+		flags[ConfigPipelineDayZero] = iface
+		iface = interface{}(0)
+		ptr7 := (**int)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr7 = flagSet.Int("memory-limit", 0, "Evict the caches and compress the internal "+
+			"structures once the resident memory exceeds this number of megabytes instead of "+
+			"running out of memory. Zero disables the watchdog.")
+		flags[ConfigPipelineMemoryLimit] = iface
+		iface = interface{}("")
+		ptr8 := (**string)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr8 = flagSet.String("markers", "", "Path to the file with the labeled events which "+
+			"annotate the results and the plots, one \"<date|tag|commit> <label>\" per line.")
+		flags[ConfigPipelineMarkers] = iface
+		iface = interface{}(0)
+		ptr9 := (**int)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr9 = flagSet.Int("workers", 1, "Number of goroutines which run the independent "+
+			"analyses concurrently on each commit. 1 means the sequential execution.")
+		flags[ConfigPipelineWorkers] = iface
+		iface = interface{}(true)
+		ptr10 := (**bool)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr10 = flagSet.Bool("branches", false, "Analyse all the commits reachable from HEAD "+
+			"instead of the first-parent history and follow each branch separately from the "+
+			"branch point to the merge commit.")
+		flags[ConfigPipelineBranches] = iface
 	}
 	features := []string{}
 	for f := range registry.featureFlags.Choices {
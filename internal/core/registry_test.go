@@ -3,10 +3,13 @@ package core
 import (
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
 	"gopkg.in/src-d/hercules.v4/internal/test"
 )
 
@@ -115,17 +118,33 @@ func TestRegistryAddFlags(t *testing.T) {
 		Run:   func(cmd *cobra.Command, args []string) {},
 	}
 	facts, deployed := reg.AddFlags(testCmd.Flags())
-	assert.Len(t, facts, 4)
+	assert.Len(t, facts, 12)
 	assert.IsType(t, 0, facts[(&testPipelineItem{}).ListConfigurationOptions()[0].Name])
 	assert.IsType(t, true, facts[(&dummyPipelineItem{}).ListConfigurationOptions()[0].Name])
 	assert.Contains(t, facts, ConfigPipelineDryRun)
 	assert.Contains(t, facts, ConfigPipelineDumpPath)
+	assert.Contains(t, facts, ConfigPipelineSkipErrors)
+	assert.IsType(t, time.Duration(0), facts[ConfigPipelineCommitTimeout])
+	assert.IsType(t, time.Duration(0), facts[ConfigPipelineDeadline])
+	assert.IsType(t, "", facts[ConfigPipelineDayZero])
+	assert.IsType(t, 0, facts[ConfigPipelineMemoryLimit])
+	assert.IsType(t, "", facts[ConfigPipelineMarkers])
+	assert.IsType(t, 0, facts[ConfigPipelineWorkers])
+	assert.IsType(t, true, facts[ConfigPipelineBranches])
 	assert.Len(t, deployed, 1)
 	assert.Contains(t, deployed, (&testPipelineItem{}).Name())
 	assert.NotNil(t, testCmd.Flags().Lookup((&testPipelineItem{}).Flag()))
 	assert.NotNil(t, testCmd.Flags().Lookup("feature"))
 	assert.NotNil(t, testCmd.Flags().Lookup("dump-dag"))
 	assert.NotNil(t, testCmd.Flags().Lookup("dry-run"))
+	assert.NotNil(t, testCmd.Flags().Lookup("skip-errors"))
+	assert.NotNil(t, testCmd.Flags().Lookup("commit-timeout"))
+	assert.NotNil(t, testCmd.Flags().Lookup("deadline"))
+	assert.NotNil(t, testCmd.Flags().Lookup("day-zero"))
+	assert.NotNil(t, testCmd.Flags().Lookup("memory-limit"))
+	assert.NotNil(t, testCmd.Flags().Lookup("markers"))
+	assert.NotNil(t, testCmd.Flags().Lookup("workers"))
+	assert.NotNil(t, testCmd.Flags().Lookup("branches"))
 	assert.NotNil(t, testCmd.Flags().Lookup(
 		(&testPipelineItem{}).ListConfigurationOptions()[0].Flag))
 	assert.NotNil(t, testCmd.Flags().Lookup(
@@ -191,3 +210,21 @@ func TestRegistryFeaturedItems(t *testing.T) {
 	assert.Equal(t, featured["power"][0].Name(), (&testPipelineItem{}).Name())
 	assert.Equal(t, featured["power"][1].Name(), (&dummyPipelineItem{}).Name())
 }
+
+type extensionTestPipelineItem struct {
+	testPipelineItem
+}
+
+func (item *extensionTestPipelineItem) ExtensionName() string {
+	return "example.com/test/Extension"
+}
+
+func (item *extensionTestPipelineItem) ExtensionMessage() proto.Message {
+	return &pb.Marker{}
+}
+
+func TestRegistryExtensions(t *testing.T) {
+	reg := getRegistry()
+	reg.Register(&extensionTestPipelineItem{})
+	assert.IsType(t, &pb.Marker{}, pb.NewExtensionMessage("example.com/test/Extension"))
+}
@@ -0,0 +1,193 @@
+package core
+
+import (
+	"time"
+)
+
+// commitScheduler runs the items which do not depend on each other concurrently on the same
+// commit, see ConfigPipelineWorkers. The items form a DAG by their Provides() and Requires():
+// an item starts as soon as all the items which provide its requirements have finished.
+// The commits are still processed one after another, so every item consumes them in order.
+//
+// Only the goroutine which calls consumeCommit() reads and writes the commit state; each
+// Consume() receives its own copy of the state. The entities in the state are shared, so
+// Consume() must not change the entities which it receives.
+type commitScheduler struct {
+	items []PipelineItem
+	// dependents are the indexes of the items which require the entities provided by each item.
+	dependents [][]int
+	// dependencies is the number of the items which provide the requirements of each item.
+	dependencies []int
+
+	tasks   chan schedulerTask
+	results chan schedulerResult
+}
+
+// schedulerTask is the item to run on the copy of the commit state.
+type schedulerTask struct {
+	index int
+	deps  map[string]interface{}
+}
+
+// schedulerResult is the outcome of schedulerTask.
+type schedulerResult struct {
+	index   int
+	update  map[string]interface{}
+	err     error
+	elapsed time.Duration
+	// panicked is the recovered panic value which is raised again in consumeCommit().
+	panicked interface{}
+}
+
+// newCommitScheduler builds the DAG of the items which are sorted topologically and starts
+// the workers which call `consume`.
+func newCommitScheduler(items []PipelineItem, workers int,
+	consume func(PipelineItem, map[string]interface{}) (map[string]interface{}, error),
+) *commitScheduler {
+	scheduler := &commitScheduler{
+		items:        items,
+		dependents:   make([][]int, len(items)),
+		dependencies: make([]int, len(items)),
+		tasks:        make(chan schedulerTask, len(items)),
+		results:      make(chan schedulerResult, len(items)),
+	}
+	providers := map[string]int{}
+	for i, item := range items {
+		linked := map[int]bool{}
+		for _, key := range item.Requires() {
+			provider, exists := providers[key]
+			if !exists || linked[provider] {
+				continue
+			}
+			linked[provider] = true
+			scheduler.dependents[provider] = append(scheduler.dependents[provider], i)
+			scheduler.dependencies[i]++
+		}
+		for _, key := range item.Provides() {
+			providers[key] = i
+		}
+	}
+	for i := 0; i < workers; i++ {
+		go scheduler.work(consume)
+	}
+	return scheduler
+}
+
+// work runs the tasks until Stop() is called.
+func (scheduler *commitScheduler) work(
+	consume func(PipelineItem, map[string]interface{}) (map[string]interface{}, error)) {
+	for task := range scheduler.tasks {
+		scheduler.results <- scheduler.run(task, consume)
+	}
+}
+
+// run calls Consume() of the task's item and recovers from the panic.
+func (scheduler *commitScheduler) run(task schedulerTask,
+	consume func(PipelineItem, map[string]interface{}) (map[string]interface{}, error),
+) (result schedulerResult) {
+	result.index = task.index
+	start := time.Now()
+	defer func() {
+		result.elapsed = time.Since(start)
+		if r := recover(); r != nil {
+			result.panicked = r
+		}
+	}()
+	result.update, result.err = consume(scheduler.items[task.index], task.deps)
+	return
+}
+
+// Stop terminates the workers.
+func (scheduler *commitScheduler) Stop() {
+	close(scheduler.tasks)
+}
+
+// consumeCommit runs the items on the commit in the state, the same as
+// Pipeline.consumeCommit() but concurrently. The failures are reported in the topological order.
+func (scheduler *commitScheduler) consumeCommit(pipeline *Pipeline,
+	state map[string]interface{}, timings map[string]time.Duration) ([]CommitFailure, error) {
+	startCommitTime := time.Now()
+	pending := make([]int, len(scheduler.items))
+	copy(pending, scheduler.dependencies)
+	var ready []int
+	for i, count := range pending {
+		if count == 0 {
+			ready = append(ready, i)
+		}
+	}
+	finish := func(index int) {
+		for _, dependent := range scheduler.dependents[index] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+	failures := make([]*CommitFailure, len(scheduler.items))
+	fail := func(index int, reason string) {
+		failure := newCommitFailure(state, scheduler.items[index], reason)
+		failures[index] = &failure
+	}
+	var fatal error
+	var panicked interface{}
+	running := 0
+	for {
+		for fatal == nil && panicked == nil && len(ready) > 0 {
+			index := ready[0]
+			ready = ready[1:]
+			item := scheduler.items[index]
+			if reason := pipeline.skipReason(item, state, startCommitTime); reason != "" {
+				fail(index, reason)
+				finish(index)
+				continue
+			}
+			deps := make(map[string]interface{}, len(state))
+			for key, val := range state {
+				deps[key] = val
+			}
+			scheduler.tasks <- schedulerTask{index: index, deps: deps}
+			running++
+		}
+		if running == 0 {
+			break
+		}
+		result := <-scheduler.results
+		running--
+		item := scheduler.items[result.index]
+		if timings != nil {
+			timings[item.Name()] = result.elapsed
+		}
+		if result.panicked != nil {
+			// wait for the rest of the running items and panic in the caller's goroutine
+			if panicked == nil {
+				panicked = result.panicked
+			}
+			continue
+		}
+		if result.err != nil {
+			if err := pipeline.handleError(item, state, result.err); err != nil {
+				if fatal == nil {
+					fatal = err
+				}
+				continue
+			}
+			fail(result.index, result.err.Error())
+		} else {
+			applyUpdate(item, result.update, state)
+		}
+		finish(result.index)
+	}
+	if panicked != nil {
+		panic(panicked)
+	}
+	if fatal != nil {
+		return nil, fatal
+	}
+	var ordered []CommitFailure
+	for _, failure := range failures {
+		if failure != nil {
+			ordered = append(ordered, *failure)
+		}
+	}
+	return ordered, nil
+}
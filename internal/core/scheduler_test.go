@@ -0,0 +1,205 @@
+package core
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+// schedulerTestPipelineItem sleeps in Consume() and records the commits and the dependencies.
+type schedulerTestPipelineItem struct {
+	name     string
+	provides []string
+	requires []string
+	err      error
+	panics   bool
+	// running is the number of the items which are currently inside Consume().
+	running *int32
+	// maxRunning is the maximum observed value of running.
+	maxRunning *int32
+
+	Consumed []plumbing.Hash
+	Deps     []map[string]interface{}
+}
+
+func (item *schedulerTestPipelineItem) Name() string {
+	return item.name
+}
+
+func (item *schedulerTestPipelineItem) Provides() []string {
+	return item.provides
+}
+
+func (item *schedulerTestPipelineItem) Requires() []string {
+	return item.requires
+}
+
+func (item *schedulerTestPipelineItem) ListConfigurationOptions() []ConfigurationOption {
+	return nil
+}
+
+func (item *schedulerTestPipelineItem) Configure(facts map[string]interface{}) {
+}
+
+func (item *schedulerTestPipelineItem) Initialize(repository *git.Repository) {
+}
+
+func (item *schedulerTestPipelineItem) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	running := atomic.AddInt32(item.running, 1)
+	defer atomic.AddInt32(item.running, -1)
+	for {
+		max := atomic.LoadInt32(item.maxRunning)
+		if running <= max || atomic.CompareAndSwapInt32(item.maxRunning, max, running) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	if item.panics {
+		panic("test")
+	}
+	if item.err != nil {
+		return nil, item.err
+	}
+	item.Consumed = append(item.Consumed, deps["commit"].(*object.Commit).Hash)
+	item.Deps = append(item.Deps, deps)
+	update := map[string]interface{}{}
+	for _, key := range item.provides {
+		update[key] = item.name
+	}
+	return update, nil
+}
+
+// fixtureSchedulerItems creates the diamond DAG "a" -> "b", "c" -> "d" and
+// the independent item "e".
+func fixtureSchedulerItems() []*schedulerTestPipelineItem {
+	running, maxRunning := new(int32), new(int32)
+	newItem := func(name string, provides []string, requires ...string) *schedulerTestPipelineItem {
+		return &schedulerTestPipelineItem{
+			name: name, provides: provides, requires: requires,
+			running: running, maxRunning: maxRunning,
+		}
+	}
+	return []*schedulerTestPipelineItem{
+		newItem("a", []string{"a"}),
+		newItem("b", []string{"b"}, "a"),
+		newItem("c", []string{"c"}, "a"),
+		newItem("d", []string{}, "b", "c"),
+		newItem("e", []string{}),
+	}
+}
+
+func fixtureSchedulerPipeline(
+	items []*schedulerTestPipelineItem, facts map[string]interface{}) *Pipeline {
+	pipeline := NewPipeline(test.Repository)
+	for _, item := range items {
+		pipeline.AddItem(item)
+	}
+	facts[ConfigPipelineCommits] = []*object.Commit{}
+	pipeline.Initialize(facts)
+	return pipeline
+}
+
+func TestCommitSchedulerDAG(t *testing.T) {
+	items := fixtureSchedulerItems()
+	pipelineItems := make([]PipelineItem, len(items))
+	for i, item := range items {
+		pipelineItems[i] = item
+	}
+	scheduler := newCommitScheduler(pipelineItems, 1, nil)
+	defer scheduler.Stop()
+	assert.Equal(t, scheduler.dependencies, []int{0, 1, 1, 2, 0})
+	assert.Equal(t, scheduler.dependents, [][]int{{1, 2}, {3}, {3}, nil, nil})
+}
+
+func TestPipelineWorkers(t *testing.T) {
+	commits := fixtureStateCommits(3)
+	items := fixtureSchedulerItems()
+	pipeline := fixtureSchedulerPipeline(items, map[string]interface{}{ConfigPipelineWorkers: 4})
+	assert.Equal(t, pipeline.workers, 4)
+	var timings []map[string]time.Duration
+	pipeline.OnCommit = func(progress CommitProgress) {
+		timings = append(timings, progress.Timings)
+	}
+	result, err := pipeline.Run(commits)
+	assert.Nil(t, err)
+	assert.Equal(t, result[nil].(*CommonAnalysisResult).CommitsNumber, 3)
+	for _, item := range items {
+		assert.Equal(t, item.Consumed, []plumbing.Hash{
+			commits[0].Hash, commits[1].Hash, commits[2].Hash}, item.name)
+	}
+	assert.True(t, atomic.LoadInt32(items[0].maxRunning) > 1)
+	deps := items[3].Deps[2]
+	assert.Equal(t, deps["b"], "b")
+	assert.Equal(t, deps["c"], "c")
+	assert.Equal(t, deps["index"], 2)
+	assert.Equal(t, deps["commit"], commits[2])
+	// every item receives its own copy
+	deps["b"] = "x"
+	assert.Equal(t, items[3].Deps[1]["b"], "b")
+	assert.Len(t, timings, 3)
+	assert.Len(t, timings[0], 5)
+	assert.True(t, timings[0]["d"] >= 5*time.Millisecond)
+}
+
+func TestPipelineWorkersSequential(t *testing.T) {
+	items := fixtureSchedulerItems()
+	pipeline := fixtureSchedulerPipeline(items, map[string]interface{}{ConfigPipelineWorkers: 1})
+	_, err := pipeline.Run(fixtureStateCommits(2))
+	assert.Nil(t, err)
+	assert.Len(t, items[3].Consumed, 2)
+	assert.Equal(t, atomic.LoadInt32(items[0].maxRunning), int32(1))
+}
+
+func TestPipelineWorkersError(t *testing.T) {
+	items := fixtureSchedulerItems()
+	items[2].err = errors.New("error")
+	pipeline := fixtureSchedulerPipeline(items, map[string]interface{}{ConfigPipelineWorkers: 4})
+	result, err := pipeline.Run(fixtureStateCommits(2))
+	assert.Nil(t, result)
+	assert.NotNil(t, err)
+	assert.Len(t, items[3].Consumed, 0)
+
+	items = fixtureSchedulerItems()
+	items[2].err = errors.New("error")
+	pipeline = fixtureSchedulerPipeline(items, map[string]interface{}{
+		ConfigPipelineWorkers: 4, ConfigPipelineSkipErrors: true})
+	commits := fixtureStateCommits(2)
+	result, err = pipeline.Run(commits)
+	assert.Nil(t, err)
+	assert.Len(t, items[1].Consumed, 2)
+	assert.Len(t, items[3].Consumed, 0)
+	assert.Len(t, items[4].Consumed, 2)
+	failures := result[nil].(*CommonAnalysisResult).Failures
+	assert.Len(t, failures, 4)
+	assert.Equal(t, failures[0], CommitFailure{
+		Commit: commits[0].Hash, Index: 0, Item: "c", Error: "error"})
+	assert.Equal(t, failures[1], CommitFailure{
+		Commit: commits[0].Hash, Index: 0, Item: "d", Error: "skipped because c is not available"})
+	assert.Equal(t, failures[3].Index, 1)
+}
+
+func TestPipelineWorkersPanic(t *testing.T) {
+	items := fixtureSchedulerItems()
+	items[1].panics = true
+	pipeline := fixtureSchedulerPipeline(items, map[string]interface{}{ConfigPipelineWorkers: 4})
+	assert.PanicsWithValue(t, "test", func() {
+		pipeline.Run(fixtureStateCommits(1))
+	})
+	items = fixtureSchedulerItems()
+	items[1].panics = true
+	pipeline = fixtureSchedulerPipeline(items, map[string]interface{}{
+		ConfigPipelineWorkers: 4, ConfigPipelineSkipErrors: true})
+	result, err := pipeline.Run(fixtureStateCommits(1))
+	assert.Nil(t, err)
+	failures := result[nil].(*CommonAnalysisResult).Failures
+	assert.Len(t, failures, 2)
+	assert.Equal(t, failures[0].Item, "b")
+	assert.Equal(t, failures[0].Error, "panic: test")
+}
@@ -0,0 +1,132 @@
+package core
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// PersistentPipelineItem is able to save and restore its internal state, so that the analysis
+// continues from the last consumed commit in a later process instead of starting over.
+// See Pipeline.Dump() and Pipeline.Load().
+type PersistentPipelineItem interface {
+	PipelineItem
+	// DumpState writes the state which is required to consume the following commits.
+	// It is called after Pipeline.Run(), so Finalize() must leave the state intact.
+	DumpState(writer io.Writer) error
+	// LoadState restores the state written by DumpState(). It is called after Initialize().
+	LoadState(reader io.Reader) error
+}
+
+// pipelineStateVersion is incremented each time the format of pipelineState changes.
+const pipelineStateVersion = 1
+
+// pipelineCheckpoint describes the commits which the pipeline has consumed so far.
+type pipelineCheckpoint struct {
+	// LastCommit is the hash of the last consumed commit.
+	LastCommit plumbing.Hash
+	// CommitsNumber is the number of consumed commits.
+	CommitsNumber int
+	// BeginTime is the UNIX time of the first consumed commit.
+	BeginTime int64
+	// EndTime is the UNIX time of the last consumed commit.
+	EndTime int64
+}
+
+// pipelineState is the format of Pipeline.Dump().
+type pipelineState struct {
+	Version    int
+	Checkpoint pipelineCheckpoint
+	// Items map the names of the pipeline items to their states.
+	Items map[string][]byte
+}
+
+// Dump writes the state of every item in the pipeline together with the position in the commit
+// sequence, so that Load() in a later process makes Run() consume only the new commits and
+// return the results as if the whole history was analysed at once. It must be called after Run()
+// and fails if any of the items is not a PersistentPipelineItem; ConfigPipelineSaveState makes
+// Initialize() check it beforehand.
+func (pipeline *Pipeline) Dump(state io.Writer) error {
+	if pipeline.checkpoint.CommitsNumber == 0 {
+		return errors.New("no commits have been analysed yet")
+	}
+	dump := pipelineState{
+		Version:    pipelineStateVersion,
+		Checkpoint: pipeline.checkpoint,
+		Items:      map[string][]byte{},
+	}
+	for _, item := range pipeline.items {
+		if _, ok := Unwrap(item).(PersistentPipelineItem); !ok {
+			return fmt.Errorf("%s does not support saving the state", item.Name())
+		}
+		persistent := item.(PersistentPipelineItem)
+		buffer := &bytes.Buffer{}
+		if err := persistent.DumpState(buffer); err != nil {
+			return fmt.Errorf("%s: %v", item.Name(), err)
+		}
+		dump.Items[item.Name()] = buffer.Bytes()
+	}
+	return gob.NewEncoder(state).Encode(&dump)
+}
+
+// Load restores the state written by Dump(). It must be called after Initialize() and the
+// pipeline must consist of the same items with the same configuration. The next Run() skips
+// the commits up to and including the last consumed one.
+func (pipeline *Pipeline) Load(state io.Reader) error {
+	dump := pipelineState{}
+	if err := gob.NewDecoder(state).Decode(&dump); err != nil {
+		return err
+	}
+	if dump.Version != pipelineStateVersion {
+		return fmt.Errorf("unsupported state version %d, expected %d",
+			dump.Version, pipelineStateVersion)
+	}
+	for _, item := range pipeline.items {
+		if _, ok := Unwrap(item).(PersistentPipelineItem); !ok {
+			return fmt.Errorf("%s does not support loading the state", item.Name())
+		}
+		persistent := item.(PersistentPipelineItem)
+		itemState, exists := dump.Items[item.Name()]
+		if !exists {
+			return fmt.Errorf("%s was not in the pipeline when the state was saved", item.Name())
+		}
+		if err := persistent.LoadState(bytes.NewReader(itemState)); err != nil {
+			return fmt.Errorf("%s: %v", item.Name(), err)
+		}
+	}
+	pipeline.checkpoint = dump.Checkpoint
+	pipeline.resumed = true
+	return nil
+}
+
+// checkPersistent fails if any of the items is not a PersistentPipelineItem, that is,
+// Dump() is going to fail.
+func (pipeline *Pipeline) checkPersistent() error {
+	var names []string
+	for _, item := range pipeline.items {
+		if _, ok := Unwrap(item).(PersistentPipelineItem); !ok {
+			names = append(names, item.Name())
+		}
+	}
+	if len(names) > 0 {
+		return fmt.Errorf("%s does not support saving the state", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// skipConsumed removes the commits which were consumed before the state was loaded.
+func (pipeline *Pipeline) skipConsumed(commits []*object.Commit) ([]*object.Commit, error) {
+	for i, commit := range commits {
+		if commit.Hash == pipeline.checkpoint.LastCommit {
+			return commits[i+1:], nil
+		}
+	}
+	return nil, fmt.Errorf("the last analysed commit %s is not in the sequence",
+		pipeline.checkpoint.LastCommit.String())
+}
@@ -0,0 +1,197 @@
+package core
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+type persistentTestPipelineItem struct {
+	testPipelineItem
+	Consumed []plumbing.Hash
+	Indexes  []int
+}
+
+func (item *persistentTestPipelineItem) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	item.Consumed = append(item.Consumed, deps["commit"].(*object.Commit).Hash)
+	item.Indexes = append(item.Indexes, deps["index"].(int))
+	return map[string]interface{}{"test": item}, nil
+}
+
+func (item *persistentTestPipelineItem) DumpState(writer io.Writer) error {
+	return gob.NewEncoder(writer).Encode(item.Consumed)
+}
+
+func (item *persistentTestPipelineItem) LoadState(reader io.Reader) error {
+	return gob.NewDecoder(reader).Decode(&item.Consumed)
+}
+
+func fixtureStateCommits(number int) []*object.Commit {
+	commits := make([]*object.Commit, number)
+	for i := range commits {
+		commits[i] = &object.Commit{
+			Hash: plumbing.NewHash(fmt.Sprintf("%040d", i+1)),
+			Author: object.Signature{
+				When: time.Date(2018, 1, i+1, 0, 0, 0, 0, time.UTC)},
+		}
+	}
+	return commits
+}
+
+func fixtureStatePipeline() (*Pipeline, *persistentTestPipelineItem) {
+	pipeline := NewPipeline(test.Repository)
+	item := &persistentTestPipelineItem{}
+	pipeline.AddItem(item)
+	pipeline.Initialize(map[string]interface{}{ConfigPipelineCommits: []*object.Commit{}})
+	return pipeline, item
+}
+
+func TestPipelineDumpLoad(t *testing.T) {
+	commits := fixtureStateCommits(5)
+	pipeline, _ := fixtureStatePipeline()
+	assert.NotNil(t, pipeline.Dump(&bytes.Buffer{}))
+	_, err := pipeline.Run(commits[:3])
+	assert.Nil(t, err)
+	state := &bytes.Buffer{}
+	assert.Nil(t, pipeline.Dump(state))
+
+	pipeline, item := fixtureStatePipeline()
+	assert.Nil(t, pipeline.Load(bytes.NewReader(state.Bytes())))
+	assert.Len(t, item.Consumed, 3)
+	result, err := pipeline.Run(commits)
+	assert.Nil(t, err)
+	assert.Len(t, item.Consumed, 5)
+	assert.Equal(t, item.Consumed[3], commits[3].Hash)
+	assert.Equal(t, item.Indexes, []int{3, 4})
+	common := result[nil].(*CommonAnalysisResult)
+	assert.Equal(t, common.BeginTime, commits[0].Author.When.Unix())
+	assert.Equal(t, common.EndTime, commits[4].Author.When.Unix())
+	assert.Equal(t, common.CommitsNumber, 5)
+	assert.False(t, common.Partial)
+	// the state is updated
+	state.Reset()
+	assert.Nil(t, pipeline.Dump(state))
+	pipeline, item = fixtureStatePipeline()
+	assert.Nil(t, pipeline.Load(bytes.NewReader(state.Bytes())))
+	assert.Len(t, item.Consumed, 5)
+	// no new commits
+	result, err = pipeline.Run(commits)
+	assert.Nil(t, err)
+	assert.Len(t, item.Consumed, 5)
+	assert.Equal(t, result[nil].(*CommonAnalysisResult).CommitsNumber, 5)
+	// Initialize() forgets the loaded state
+	pipeline.Initialize(map[string]interface{}{ConfigPipelineCommits: []*object.Commit{}})
+	result, err = pipeline.Run(commits[:2])
+	assert.Nil(t, err)
+	assert.Equal(t, result[nil].(*CommonAnalysisResult).CommitsNumber, 2)
+}
+
+func TestPipelineLoadMissingCommit(t *testing.T) {
+	commits := fixtureStateCommits(5)
+	pipeline, _ := fixtureStatePipeline()
+	pipeline.Run(commits[:3])
+	state := &bytes.Buffer{}
+	assert.Nil(t, pipeline.Dump(state))
+	pipeline, item := fixtureStatePipeline()
+	assert.Nil(t, pipeline.Load(state))
+	result, err := pipeline.Run(commits[3:])
+	assert.Nil(t, result)
+	assert.NotNil(t, err)
+	assert.Len(t, item.Consumed, 3)
+}
+
+func TestPipelineDumpLoadErrors(t *testing.T) {
+	commits := fixtureStateCommits(2)
+	pipeline := NewPipeline(test.Repository)
+	pipeline.AddItem(&testPipelineItem{})
+	pipeline.Initialize(map[string]interface{}{ConfigPipelineCommits: []*object.Commit{}})
+	pipeline.Run(commits)
+	assert.NotNil(t, pipeline.Dump(&bytes.Buffer{}))
+
+	persistent, _ := fixtureStatePipeline()
+	persistent.Run(commits)
+	state := &bytes.Buffer{}
+	assert.Nil(t, persistent.Dump(state))
+	assert.NotNil(t, pipeline.Load(bytes.NewReader(state.Bytes())))
+	persistent, _ = fixtureStatePipeline()
+	persistent.AddItem(&dependingPersistentTestPipelineItem{})
+	assert.NotNil(t, persistent.Load(bytes.NewReader(state.Bytes())))
+	assert.NotNil(t, persistent.Load(bytes.NewReader(state.Bytes()[:10])))
+	persistent, _ = fixtureStatePipeline()
+	state.Reset()
+	gob.NewEncoder(state).Encode(&pipelineState{Version: pipelineStateVersion + 1})
+	assert.NotNil(t, persistent.Load(state))
+}
+
+type dependingPersistentTestPipelineItem struct {
+	dependingTestPipelineItem
+}
+
+func (item *dependingPersistentTestPipelineItem) DumpState(writer io.Writer) error {
+	return nil
+}
+
+func (item *dependingPersistentTestPipelineItem) LoadState(reader io.Reader) error {
+	return nil
+}
+
+// wrappingTestPipelineItem claims to be persistent on behalf of the wrapped item.
+type wrappingTestPipelineItem struct {
+	PipelineItem
+}
+
+func (item *wrappingTestPipelineItem) Unwrap() PipelineItem {
+	return item.PipelineItem
+}
+
+func (item *wrappingTestPipelineItem) DumpState(writer io.Writer) error {
+	return item.PipelineItem.(PersistentPipelineItem).DumpState(writer)
+}
+
+func (item *wrappingTestPipelineItem) LoadState(reader io.Reader) error {
+	return item.PipelineItem.(PersistentPipelineItem).LoadState(reader)
+}
+
+func TestPipelineDumpLoadWrapped(t *testing.T) {
+	commits := fixtureStateCommits(2)
+	wrapped := &persistentTestPipelineItem{}
+	wrapper := &wrappingTestPipelineItem{&wrappingTestPipelineItem{wrapped}}
+	assert.Equal(t, Unwrap(wrapper), wrapped)
+	pipeline := NewPipeline(test.Repository)
+	pipeline.AddItem(wrapper)
+	pipeline.Initialize(map[string]interface{}{ConfigPipelineCommits: []*object.Commit{}})
+	pipeline.Run(commits)
+	state := &bytes.Buffer{}
+	assert.Nil(t, pipeline.Dump(state))
+	assert.Nil(t, pipeline.Load(state))
+	assert.Len(t, wrapped.Consumed, 2)
+
+	pipeline = NewPipeline(test.Repository)
+	pipeline.AddItem(&wrappingTestPipelineItem{&testPipelineItem{}})
+	pipeline.Initialize(map[string]interface{}{ConfigPipelineCommits: []*object.Commit{}})
+	pipeline.Run(commits)
+	assert.EqualError(t, pipeline.Dump(&bytes.Buffer{}), "Test does not support saving the state")
+}
+
+func TestPipelineSaveState(t *testing.T) {
+	facts := map[string]interface{}{
+		ConfigPipelineCommits: []*object.Commit{}, ConfigPipelineSaveState: true}
+	pipeline := NewPipeline(test.Repository)
+	pipeline.AddItem(&persistentTestPipelineItem{})
+	assert.NotPanics(t, func() { pipeline.Initialize(facts) })
+	pipeline = NewPipeline(test.Repository)
+	pipeline.AddItem(&testPipelineItem{})
+	assert.PanicsWithError(t, "Test does not support saving the state", func() {
+		pipeline.Initialize(facts)
+	})
+	delete(facts, ConfigPipelineSaveState)
+	assert.NotPanics(t, func() { pipeline.Initialize(facts) })
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// memoryWatchdogPeriod is the interval between the resident memory measurements.
+var memoryWatchdogPeriod = 10 * time.Second
+
+// memoryWatchdog measures the resident memory of the process in the background and raises
+// the flag when it exceeds the limit. Pipeline.Run() checks the flag between the commits
+// and shrinks the items, because they are not safe for concurrent access.
+type memoryWatchdog struct {
+	// limit is the maximum resident memory in bytes.
+	limit uint64
+	// exceeded is 1 if the limit was exceeded since the last call to Exceeded().
+	exceeded int32
+	stop     chan struct{}
+}
+
+// startMemoryWatchdog measures the resident memory once and launches the background checks.
+func startMemoryWatchdog(limit uint64) *memoryWatchdog {
+	watchdog := &memoryWatchdog{limit: limit, stop: make(chan struct{})}
+	watchdog.check()
+	go func() {
+		ticker := time.NewTicker(memoryWatchdogPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				watchdog.check()
+			case <-watchdog.stop:
+				return
+			}
+		}
+	}()
+	return watchdog
+}
+
+func (watchdog *memoryWatchdog) check() {
+	if residentMemory() > watchdog.limit {
+		atomic.StoreInt32(&watchdog.exceeded, 1)
+	}
+}
+
+// Exceeded returns true if the limit was exceeded since the previous call.
+func (watchdog *memoryWatchdog) Exceeded() bool {
+	return atomic.SwapInt32(&watchdog.exceeded, 0) == 1
+}
+
+// Stop terminates the background checks.
+func (watchdog *memoryWatchdog) Stop() {
+	close(watchdog.stop)
+}
+
+// residentMemory returns the resident set size of the process. It falls back to the memory
+// obtained by the Go runtime from the OS if /proc is not available.
+func residentMemory() uint64 {
+	statm, err := ioutil.ReadFile("/proc/self/statm")
+	if err == nil {
+		fields := strings.Fields(string(statm))
+		if len(fields) > 1 {
+			pages, err := strconv.ParseUint(fields[1], 10, 64)
+			if err == nil {
+				return pages * uint64(os.Getpagesize())
+			}
+		}
+	}
+	stats := runtime.MemStats{}
+	runtime.ReadMemStats(&stats)
+	return stats.Sys
+}
@@ -0,0 +1,276 @@
+// Package impact maps the changes between two releases onto the directories of the repository
+// and finds the people to ask about each affected area: the developers who own most of the lines
+// there. The areas which usually change together with the affected ones are listed as well,
+// so that the release notes mention the indirect impact.
+package impact
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/src-d/go-git.v4/plumbing/format/diff"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// RootDirectory is the name of the repository root in the report.
+const RootDirectory = "/"
+
+// Mapper builds the Report.
+type Mapper struct {
+	// Depth is the maximum number of the path components of the areas. 0 maps all the changes
+	// to the repository root and negative values keep the full directory paths.
+	// It must be the same as the depth of the ownership.
+	Depth int
+	// MaxOwners is the maximum number of the owners to list for each area.
+	MaxOwners int
+	// MaxCoupled is the maximum number of the coupled areas to list for each area.
+	MaxCoupled int
+}
+
+// Ownership is the current line ownership in the repository.
+type Ownership struct {
+	// Directories maps the directories to the developer indexes to the numbers of owned lines.
+	Directories map[string]map[int]int
+	// People are the names of the developers. The indexes outside are unidentified.
+	People []string
+}
+
+// Coupling is the files co-occurrence matrix, see CouplesResult.
+type Coupling struct {
+	// Files are the names of the rows and the columns of Matrix.
+	Files []string
+	// Matrix is the number of commits which changed each pair of files.
+	Matrix []map[int]int64
+}
+
+// Report is the list of the areas affected by a release.
+type Report struct {
+	// From and To are the names of the compared revisions.
+	From, To string
+	// Commits is the number of the first-parent commits in the release.
+	Commits int
+	// Areas are sorted by the number of changed lines in the descending order.
+	Areas []Area
+}
+
+// Area is a directory with the changed files.
+type Area struct {
+	// Directory is the path of the area, RootDirectory for the files in the root.
+	Directory string
+	// Files is the number of the changed files.
+	Files int
+	// Lines is the number of the added and removed lines.
+	Lines int
+	// Owners are the developers who own the most lines in the area, the biggest first.
+	Owners []Owner
+	// Coupled are the other areas which change together with this one, the strongest first.
+	Coupled []CoupledArea
+}
+
+// Owner is a developer who owns lines in an area.
+type Owner struct {
+	// Name is the developer's identity.
+	Name string
+	// Lines is the number of owned lines.
+	Lines int
+	// Share is the fraction of the lines in the area.
+	Share float64
+}
+
+// CoupledArea is an area which changes together with another area.
+type CoupledArea struct {
+	// Directory is the path of the coupled area.
+	Directory string
+	// Changed indicates whether the coupled area has been changed in the release, too.
+	Changed bool
+	// Strength is the number of the commits which changed the files in both areas.
+	Strength int64
+}
+
+// Area returns the directory which the file belongs to in the report.
+func (mapper Mapper) Area(file string) string {
+	dir := path.Dir(file)
+	if dir == "." || mapper.Depth == 0 {
+		return RootDirectory
+	}
+	parts := strings.Split(dir, "/")
+	if mapper.Depth > 0 && len(parts) > mapper.Depth {
+		parts = parts[:mapper.Depth]
+	}
+	return strings.Join(parts, "/")
+}
+
+// Map groups the changed files (the mapping from the names to the numbers of the changed lines)
+// by area and finds the owners and the coupled areas of each.
+func (mapper Mapper) Map(changes map[string]int, ownership Ownership, coupling Coupling) []Area {
+	areas := map[string]*Area{}
+	for file, lines := range changes {
+		dir := mapper.Area(file)
+		area := areas[dir]
+		if area == nil {
+			area = &Area{Directory: dir}
+			areas[dir] = area
+		}
+		area.Files++
+		area.Lines += lines
+	}
+	coupled := mapper.coupledAreas(changes, coupling)
+	result := make([]Area, 0, len(areas))
+	for dir, area := range areas {
+		area.Owners = mapper.owners(ownership.Directories[dir], ownership.People)
+		for other, strength := range coupled[dir] {
+			_, changed := areas[other]
+			area.Coupled = append(area.Coupled, CoupledArea{
+				Directory: other, Changed: changed, Strength: strength})
+		}
+		sort.Slice(area.Coupled, func(i, j int) bool {
+			if area.Coupled[i].Strength != area.Coupled[j].Strength {
+				return area.Coupled[i].Strength > area.Coupled[j].Strength
+			}
+			return area.Coupled[i].Directory < area.Coupled[j].Directory
+		})
+		if mapper.MaxCoupled >= 0 && len(area.Coupled) > mapper.MaxCoupled {
+			area.Coupled = area.Coupled[:mapper.MaxCoupled]
+		}
+		result = append(result, *area)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Lines != result[j].Lines {
+			return result[i].Lines > result[j].Lines
+		}
+		return result[i].Directory < result[j].Directory
+	})
+	return result
+}
+
+// owners returns the developers who own the most lines, the biggest first.
+func (mapper Mapper) owners(lines map[int]int, people []string) []Owner {
+	total := 0
+	for _, count := range lines {
+		total += count
+	}
+	owners := make([]Owner, 0, len(lines))
+	for person, count := range lines {
+		if count <= 0 {
+			continue
+		}
+		name := identity.AuthorMissingName
+		if person >= 0 && person < len(people) {
+			name = people[person]
+		}
+		owners = append(owners, Owner{
+			Name: name, Lines: count, Share: float64(count) / float64(total)})
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		if owners[i].Lines != owners[j].Lines {
+			return owners[i].Lines > owners[j].Lines
+		}
+		return owners[i].Name < owners[j].Name
+	})
+	if mapper.MaxOwners >= 0 && len(owners) > mapper.MaxOwners {
+		owners = owners[:mapper.MaxOwners]
+	}
+	return owners
+}
+
+// coupledAreas sums the co-occurrences of the changed files with the files in the other areas.
+// It returns the mapping from the area to the coupled area to the strength.
+func (mapper Mapper) coupledAreas(changes map[string]int, coupling Coupling) map[string]map[string]int64 {
+	result := map[string]map[string]int64{}
+	for i, file := range coupling.Files {
+		if _, changed := changes[file]; !changed || i >= len(coupling.Matrix) {
+			continue
+		}
+		dir := mapper.Area(file)
+		for j, count := range coupling.Matrix[i] {
+			if j == i || j >= len(coupling.Files) {
+				continue
+			}
+			other := mapper.Area(coupling.Files[j])
+			if other == dir {
+				continue
+			}
+			if result[dir] == nil {
+				result[dir] = map[string]int64{}
+			}
+			result[dir][other] += count
+		}
+	}
+	return result
+}
+
+// Changes returns the mapping from the names of the files which differ between the commits
+// to the numbers of the added and removed lines. A binary file counts as one line.
+func Changes(from, to *object.Commit) (map[string]int, error) {
+	fromTree, err := from.Tree()
+	if err != nil {
+		return nil, err
+	}
+	toTree, err := to.Tree()
+	if err != nil {
+		return nil, err
+	}
+	treeDiff, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := treeDiff.Patch()
+	if err != nil {
+		return nil, err
+	}
+	changes := map[string]int{}
+	for _, filePatch := range patch.FilePatches() {
+		fromFile, toFile := filePatch.Files()
+		name := ""
+		if toFile != nil {
+			name = toFile.Path()
+		} else {
+			name = fromFile.Path()
+		}
+		if filePatch.IsBinary() {
+			changes[name] = 1
+			continue
+		}
+		lines := 0
+		for _, chunk := range filePatch.Chunks() {
+			if chunk.Type() == diff.Equal {
+				continue
+			}
+			content := chunk.Content()
+			lines += strings.Count(content, "\n")
+			if content != "" && !strings.HasSuffix(content, "\n") {
+				lines++
+			}
+		}
+		changes[name] = lines
+	}
+	return changes, nil
+}
+
+// Serialize writes the report in YAML.
+func (report Report) Serialize(writer io.Writer) {
+	fmt.Fprintf(writer, "from: %s\n", yaml.SafeString(report.From))
+	fmt.Fprintf(writer, "to: %s\n", yaml.SafeString(report.To))
+	fmt.Fprintf(writer, "commits: %d\n", report.Commits)
+	fmt.Fprintln(writer, "areas:")
+	for _, area := range report.Areas {
+		fmt.Fprintf(writer, "  - directory: %s\n", yaml.SafeString(area.Directory))
+		fmt.Fprintf(writer, "    files: %d\n", area.Files)
+		fmt.Fprintf(writer, "    lines: %d\n", area.Lines)
+		fmt.Fprintln(writer, "    ask:  # owned lines, share")
+		for _, owner := range area.Owners {
+			fmt.Fprintf(writer, "      %s: [%d, %.4f]\n",
+				yaml.SafeString(owner.Name), owner.Lines, owner.Share)
+		}
+		fmt.Fprintln(writer, "    coupled:  # commits, changed in the release")
+		for _, coupled := range area.Coupled {
+			fmt.Fprintf(writer, "      %s: [%d, %t]\n",
+				yaml.SafeString(coupled.Directory), coupled.Strength, coupled.Changed)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package impact
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-billy.v4/memfs"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+)
+
+func fixtureOwnership() Ownership {
+	return Ownership{
+		Directories: map[string]map[int]int{
+			"/":       {0: 60, 1: 30, 2: 10},
+			"src":     {0: 40, 1: 20},
+			"src/api": {1: 20},
+			"docs":    {2: 10},
+		},
+		People: []string{"alice", "bob"},
+	}
+}
+
+func fixtureCoupling() Coupling {
+	return Coupling{
+		Files: []string{"src/api/a.go", "src/b.go", "docs/c.md", "README.md"},
+		Matrix: []map[int]int64{
+			{0: 5, 1: 3, 2: 2},
+			{0: 3, 1: 4, 3: 1},
+			{0: 2, 2: 2},
+			{1: 1, 3: 1},
+		},
+	}
+}
+
+func TestMapperArea(t *testing.T) {
+	mapper := Mapper{Depth: 2}
+	assert.Equal(t, mapper.Area("a.go"), RootDirectory)
+	assert.Equal(t, mapper.Area("src/a.go"), "src")
+	assert.Equal(t, mapper.Area("src/api/a.go"), "src/api")
+	assert.Equal(t, mapper.Area("src/api/v1/a.go"), "src/api")
+	mapper.Depth = 0
+	assert.Equal(t, mapper.Area("src/a.go"), RootDirectory)
+	mapper.Depth = -1
+	assert.Equal(t, mapper.Area("src/api/v1/a.go"), "src/api/v1")
+}
+
+func TestMapperMap(t *testing.T) {
+	mapper := Mapper{Depth: 1, MaxOwners: -1, MaxCoupled: -1}
+	areas := mapper.Map(map[string]int{"src/api/a.go": 10, "src/b.go": 5, "README.md": 20},
+		fixtureOwnership(), fixtureCoupling())
+	assert.Equal(t, areas, []Area{{
+		Directory: RootDirectory,
+		Files:     1,
+		Lines:     20,
+		Owners: []Owner{
+			{Name: "alice", Lines: 60, Share: 0.6},
+			{Name: "bob", Lines: 30, Share: 0.3},
+			{Name: "<unmatched>", Lines: 10, Share: 0.1},
+		},
+		Coupled: []CoupledArea{{Directory: "src", Changed: true, Strength: 1}},
+	}, {
+		Directory: "src",
+		Files:     2,
+		Lines:     15,
+		Owners: []Owner{
+			{Name: "alice", Lines: 40, Share: 2.0 / 3},
+			{Name: "bob", Lines: 20, Share: 1.0 / 3},
+		},
+		Coupled: []CoupledArea{
+			{Directory: "docs", Changed: false, Strength: 2},
+			{Directory: RootDirectory, Changed: true, Strength: 1},
+		},
+	}})
+	mapper = Mapper{Depth: 2, MaxOwners: 1, MaxCoupled: 0}
+	areas = mapper.Map(map[string]int{"src/api/a.go": 10, "docs/c.md": 1},
+		fixtureOwnership(), fixtureCoupling())
+	assert.Len(t, areas, 2)
+	assert.Equal(t, areas[0].Directory, "src/api")
+	assert.Equal(t, areas[0].Owners, []Owner{{Name: "bob", Lines: 20, Share: 1}})
+	assert.Len(t, areas[0].Coupled, 0)
+	assert.Equal(t, areas[1].Directory, "docs")
+	// the unknown directories do not have owners
+	areas = mapper.Map(map[string]int{"vendor/x.go": 1}, Ownership{}, Coupling{})
+	assert.Equal(t, areas, []Area{{Directory: "vendor", Files: 1, Lines: 1, Owners: []Owner{}}})
+}
+
+func TestReportSerialize(t *testing.T) {
+	report := Report{From: "v1.0", To: "v1.1", Commits: 7, Areas: []Area{{
+		Directory: "src",
+		Files:     2,
+		Lines:     15,
+		Owners:    []Owner{{Name: "alice", Lines: 40, Share: 2.0 / 3}},
+		Coupled:   []CoupledArea{{Directory: "docs", Strength: 2}},
+	}, {
+		Directory: RootDirectory,
+		Files:     1,
+		Lines:     1,
+	}}}
+	buffer := &bytes.Buffer{}
+	report.Serialize(buffer)
+	assert.Equal(t, buffer.String(), `from: "v1.0"
+to: "v1.1"
+commits: 7
+areas:
+  - directory: "src"
+    files: 2
+    lines: 15
+    ask:  # owned lines, share
+      "alice": [40, 0.6667]
+    coupled:  # commits, changed in the release
+      "docs": [2, false]
+  - directory: "/"
+    files: 1
+    lines: 1
+    ask:  # owned lines, share
+    coupled:  # commits, changed in the release
+`)
+}
+
+func TestChanges(t *testing.T) {
+	repository, err := git.Init(memory.NewStorage(), memfs.New())
+	assert.Nil(t, err)
+	worktree, err := repository.Worktree()
+	assert.Nil(t, err)
+	write := func(name, contents string) {
+		file, err := worktree.Filesystem.Create(name)
+		assert.Nil(t, err)
+		file.Write([]byte(contents))
+		file.Close()
+		worktree.Add(name)
+	}
+	commit := func() *object.Commit {
+		hash, err := worktree.Commit("Release", &git.CommitOptions{Author: &object.Signature{
+			Name: "Vadim", Email: "vadim@sourced.tech", When: time.Now()}})
+		assert.Nil(t, err)
+		commit, err := repository.CommitObject(hash)
+		assert.Nil(t, err)
+		return commit
+	}
+	write("a.go", "1\n2\n3\n")
+	write("src/b.go", "1\n")
+	write("c.bin", "\x00\x01")
+	from := commit()
+	write("a.go", "1\nx\n3\n4")
+	worktree.Remove("src/b.go")
+	write("c.bin", "\x00\x02")
+	write("d.go", "1\n2\n")
+	changes, err := Changes(from, commit())
+	assert.Nil(t, err)
+	assert.Equal(t, changes, map[string]int{"a.go": 3, "src/b.go": 1, "c.bin": 1, "d.go": 2})
+}
@@ -0,0 +1,303 @@
+package pb
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// ContainerMagic starts every chunked results container.
+var ContainerMagic = []byte("HERCULES")
+
+// ContainerVersion is the version of the chunked container layout. Version 1 does not have
+// the chunk checksums, version 2 does not have the day range chunks.
+const ContainerVersion = 3
+
+// ErrChecksumMismatch is returned by ContainerReader.Data() if the chunk is corrupted.
+var ErrChecksumMismatch = errors.New("chunk checksum mismatch")
+
+// ContainerWriter writes the analysis results as the chunked container - the sequence of
+// length-prefixed chunks. It starts with ContainerMagic and the uvarint ContainerVersion,
+// then goes the chunk with the serialized Metadata and then one chunk per analysis. Each chunk
+// is the uvarint length of the name, the name, the uvarint length of the data, the data and
+// the SHA-256 hash of the data. The chunks of the extensions are named after them and contain
+// the serialized Extension messages. The time series analyses may be split into several chunks,
+// one per day range, see DayRangeChunkName(). Readers skip the analyses and the periods they do
+// not need by the length prefixes without loading them into memory and verify the integrity
+// of those they load.
+type ContainerWriter struct {
+	writer io.Writer
+}
+
+// NewContainerWriter writes the container preamble and the header to `writer`.
+func NewContainerWriter(writer io.Writer, header *Metadata) (*ContainerWriter, error) {
+	if _, err := writer.Write(ContainerMagic); err != nil {
+		return nil, err
+	}
+	cw := &ContainerWriter{writer: writer}
+	if err := cw.writeUvarint(ContainerVersion); err != nil {
+		return nil, err
+	}
+	serialized, err := proto.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	if err = cw.WriteChunk("", serialized); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+// WriteChunk appends the serialized analysis result named `name` to the container.
+func (cw *ContainerWriter) WriteChunk(name string, data []byte) error {
+	if err := cw.writeUvarint(uint64(len(name))); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(cw.writer, name); err != nil {
+		return err
+	}
+	if err := cw.writeUvarint(uint64(len(data))); err != nil {
+		return err
+	}
+	if _, err := cw.writer.Write(data); err != nil {
+		return err
+	}
+	checksum := sha256.Sum256(data)
+	_, err := cw.writer.Write(checksum[:])
+	return err
+}
+
+func (cw *ContainerWriter) writeUvarint(value uint64) error {
+	buffer := make([]byte, binary.MaxVarintLen64)
+	_, err := cw.writer.Write(buffer[:binary.PutUvarint(buffer, value)])
+	return err
+}
+
+// DayRangeChunkName returns the name of the chunk with the part of the analysis result
+// which covers the days from `from` inclusive to `to` exclusive. Concatenating such chunks
+// of the same analysis in order gives the whole result.
+func DayRangeChunkName(analysis string, from, to int) string {
+	return fmt.Sprintf("%s@%d-%d", analysis, from, to)
+}
+
+// ParseChunkName splits the chunk name into the analysis name and the day range.
+// `ranged` is false if the chunk contains the whole result of the analysis.
+func ParseChunkName(name string) (analysis string, from, to int, ranged bool) {
+	at := strings.LastIndexByte(name, '@')
+	if at < 0 || IsExtensionName(name) {
+		return name, 0, 0, false
+	}
+	bounds := strings.SplitN(name[at+1:], "-", 2)
+	if len(bounds) != 2 {
+		return name, 0, 0, false
+	}
+	var err error
+	if from, err = strconv.Atoi(bounds[0]); err != nil {
+		return name, 0, 0, false
+	}
+	if to, err = strconv.Atoi(bounds[1]); err != nil {
+		return name, 0, 0, false
+	}
+	return name[:at], from, to, true
+}
+
+// ContainerReader reads the chunked container sequentially. If the underlying reader is
+// an io.Seeker, e.g. *os.File, the skipped chunks are not read at all.
+type ContainerReader struct {
+	// Header is the metadata of the analysis results.
+	Header *Metadata
+
+	reader  *bufio.Reader
+	source  io.Reader
+	seeker  io.Seeker
+	size    int64
+	version uint64
+	// remaining is the number of unread bytes in the current chunk.
+	remaining uint64
+}
+
+// NewContainerReader validates the container preamble and reads the header.
+func NewContainerReader(reader io.Reader) (*ContainerReader, error) {
+	magic := make([]byte, len(ContainerMagic))
+	if _, err := io.ReadFull(reader, magic); err != nil || !bytes.Equal(magic, ContainerMagic) {
+		return nil, errors.New("not a chunked results container")
+	}
+	return openContainer(reader)
+}
+
+// openContainer reads the rest of the container preamble and the header after the magic.
+func openContainer(reader io.Reader) (*ContainerReader, error) {
+	cr := &ContainerReader{reader: bufio.NewReader(reader), source: reader}
+	if seeker, ok := reader.(io.Seeker); ok {
+		if offset, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			if cr.size, err = seeker.Seek(0, io.SeekEnd); err != nil {
+				return nil, err
+			}
+			if _, err = seeker.Seek(offset, io.SeekStart); err != nil {
+				return nil, err
+			}
+			cr.seeker = seeker
+		}
+	}
+	var err error
+	cr.version, err = binary.ReadUvarint(cr.reader)
+	if err != nil {
+		return nil, err
+	}
+	if cr.version < 1 || cr.version > ContainerVersion {
+		return nil, fmt.Errorf("unsupported container version %d", cr.version)
+	}
+	if name, err := cr.Next(); err != nil || name != "" {
+		return nil, errors.New("the container header is missing")
+	}
+	data, err := cr.Data()
+	if err != nil {
+		return nil, err
+	}
+	cr.Header = &Metadata{}
+	if err = proto.Unmarshal(data, cr.Header); err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+// Next skips the rest of the current chunk without decoding it and returns the name
+// of the next one. io.EOF is returned after the last chunk.
+func (cr *ContainerReader) Next() (string, error) {
+	if err := cr.skip(int64(cr.remaining)); err != nil {
+		return "", err
+	}
+	cr.remaining = 0
+	size, err := binary.ReadUvarint(cr.reader)
+	if err == io.EOF {
+		return "", io.EOF
+	}
+	if err != nil {
+		return "", err
+	}
+	name := make([]byte, size)
+	if _, err = io.ReadFull(cr.reader, name); err != nil {
+		return "", io.ErrUnexpectedEOF
+	}
+	if cr.remaining, err = binary.ReadUvarint(cr.reader); err != nil {
+		return "", io.ErrUnexpectedEOF
+	}
+	if cr.version > 1 {
+		cr.remaining += sha256.Size
+	}
+	return string(name), nil
+}
+
+// skip moves forward by `size` bytes. It seeks if possible and reads otherwise.
+func (cr *ContainerReader) skip(size int64) error {
+	buffered := int64(cr.reader.Buffered())
+	if cr.seeker == nil || size <= buffered {
+		if _, err := cr.reader.Discard(int(size)); err != nil {
+			return io.ErrUnexpectedEOF
+		}
+		return nil
+	}
+	cr.reader.Discard(int(buffered))
+	offset, err := cr.seeker.Seek(size-buffered, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if offset > cr.size {
+		return io.ErrUnexpectedEOF
+	}
+	cr.reader.Reset(cr.source)
+	return nil
+}
+
+// Data reads the contents of the current chunk and verifies its checksum.
+func (cr *ContainerReader) Data() ([]byte, error) {
+	data := make([]byte, cr.remaining)
+	_, err := io.ReadFull(cr.reader, data)
+	cr.remaining = 0
+	if err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if cr.version > 1 {
+		checksum := data[len(data)-sha256.Size:]
+		data = data[:len(data)-sha256.Size]
+		if actual := sha256.Sum256(data); !bytes.Equal(checksum, actual[:]) {
+			return nil, ErrChecksumMismatch
+		}
+	}
+	return data, nil
+}
+
+// IsContainer checks whether the data starts with ContainerMagic.
+func IsContainer(data []byte) bool {
+	return bytes.HasPrefix(data, ContainerMagic)
+}
+
+// ReadAnalysisResults parses either the chunked container or the legacy monolithic
+// AnalysisResults message from the stream. If `analyses` are specified, the container chunks
+// of the other analyses and extensions are skipped without loading; the legacy message is always
+// read whole. The day range chunks of the same analysis are joined.
+func ReadAnalysisResults(reader io.Reader, analyses ...string) (*AnalysisResults, error) {
+	magic := make([]byte, len(ContainerMagic))
+	size, err := io.ReadFull(reader, magic)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	message := &AnalysisResults{}
+	if !IsContainer(magic[:size]) {
+		data, err := ioutil.ReadAll(io.MultiReader(bytes.NewReader(magic[:size]), reader))
+		if err != nil {
+			return nil, err
+		}
+		if err = proto.Unmarshal(data, message); err != nil {
+			return nil, err
+		}
+		return message, nil
+	}
+	container, err := openContainer(reader)
+	if err != nil {
+		return nil, err
+	}
+	wanted := map[string]bool{}
+	for _, name := range analyses {
+		wanted[name] = true
+	}
+	message.Header = container.Header
+	message.Contents = map[string][]byte{}
+	message.Extensions = map[string]*Extension{}
+	for {
+		name, err := container.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		analysis, _, _, _ := ParseChunkName(name)
+		if len(wanted) > 0 && !wanted[analysis] {
+			continue
+		}
+		data, err := container.Data()
+		if err != nil {
+			return nil, err
+		}
+		if !IsExtensionName(name) {
+			message.Contents[analysis] = append(message.Contents[analysis], data...)
+			continue
+		}
+		extension := &Extension{}
+		if err = proto.Unmarshal(data, extension); err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		message.Extensions[name] = extension
+	}
+	return message, nil
+}
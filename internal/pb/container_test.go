@@ -0,0 +1,203 @@
+package pb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func fixtureContainer() []byte {
+	buffer := &bytes.Buffer{}
+	writer, err := NewContainerWriter(buffer, &Metadata{Version: 2, Repository: "test", Commits: 10})
+	if err != nil {
+		panic(err)
+	}
+	writer.WriteChunk("Burndown", bytes.Repeat([]byte{1}, 300))
+	writer.WriteChunk("Couples", []byte{2, 3})
+	writer.WriteChunk("Empty", []byte{})
+	return buffer.Bytes()
+}
+
+func TestContainerReadWrite(t *testing.T) {
+	data := fixtureContainer()
+	assert.True(t, IsContainer(data))
+	reader, err := NewContainerReader(bytes.NewReader(data))
+	assert.Nil(t, err)
+	assert.Equal(t, reader.Header.Repository, "test")
+	assert.Equal(t, reader.Header.Commits, int32(10))
+	// skip Burndown without reading it
+	name, err := reader.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, name, "Burndown")
+	name, err = reader.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, name, "Couples")
+	chunk, err := reader.Data()
+	assert.Nil(t, err)
+	assert.Equal(t, chunk, []byte{2, 3})
+	name, err = reader.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, name, "Empty")
+	name, err = reader.Next()
+	assert.Equal(t, err, io.EOF)
+}
+
+func TestContainerErrors(t *testing.T) {
+	_, err := NewContainerReader(bytes.NewReader([]byte("garbage")))
+	assert.NotNil(t, err)
+	data := fixtureContainer()
+	data[len(ContainerMagic)] = 100
+	_, err = NewContainerReader(bytes.NewReader(data))
+	assert.NotNil(t, err)
+	data = fixtureContainer()
+	// cut the data size of the last chunk
+	reader, err := NewContainerReader(bytes.NewReader(data[:len(data)-1-sha256.Size]))
+	assert.Nil(t, err)
+	reader.Next()
+	_, err = reader.Next()
+	assert.Nil(t, err)
+	_, err = reader.Next()
+	assert.Equal(t, err, io.ErrUnexpectedEOF)
+	// cut the data of the first chunk
+	reader, err = NewContainerReader(bytes.NewReader(data[:len(data)-100]))
+	assert.Nil(t, err)
+	reader.Next()
+	_, err = reader.Next()
+	assert.Equal(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestContainerChecksum(t *testing.T) {
+	data := fixtureContainer()
+	// corrupt the Couples chunk
+	data[len(data)-2*sha256.Size-len("Empty")-4] ^= 0xff
+	reader, err := NewContainerReader(bytes.NewReader(data))
+	assert.Nil(t, err)
+	reader.Next()
+	chunk, err := reader.Data()
+	assert.Nil(t, err)
+	assert.Len(t, chunk, 300)
+	name, err := reader.Next()
+	assert.Equal(t, name, "Couples")
+	_, err = reader.Data()
+	assert.Equal(t, err, ErrChecksumMismatch)
+	// the next chunk is still readable
+	name, err = reader.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, name, "Empty")
+	_, err = reader.Data()
+	assert.Nil(t, err)
+	_, err = ReadAnalysisResults(bytes.NewReader(data))
+	assert.Equal(t, err, ErrChecksumMismatch)
+}
+
+func TestContainerVersion1(t *testing.T) {
+	data := []byte("HERCULES\x01\x00\x02\x1a\x00\x03abc\x01\x07")
+	message, err := ReadAnalysisResults(bytes.NewReader(data))
+	assert.Nil(t, err)
+	assert.Equal(t, message.Contents["abc"], []byte{7})
+}
+
+func TestReadAnalysisResults(t *testing.T) {
+	message, err := ReadAnalysisResults(bytes.NewReader(fixtureContainer()))
+	assert.Nil(t, err)
+	assert.Equal(t, message.Header.Repository, "test")
+	assert.Len(t, message.Contents, 3)
+	assert.Len(t, message.Contents["Burndown"], 300)
+	assert.Equal(t, message.Contents["Couples"], []byte{2, 3})
+	legacy, _ := proto.Marshal(message)
+	assert.False(t, IsContainer(legacy))
+	message, err = ReadAnalysisResults(bytes.NewReader(legacy))
+	assert.Nil(t, err)
+	assert.Equal(t, message.Header.Repository, "test")
+	assert.Len(t, message.Contents["Burndown"], 300)
+}
+
+func TestReadAnalysisResultsExtensions(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	writer, err := NewContainerWriter(buffer, &Metadata{Repository: "test"})
+	assert.Nil(t, err)
+	assert.Nil(t, writer.WriteChunk("Couples", []byte{2, 3}))
+	data, _ := proto.Marshal(&Extension{TypeUrl: ExtensionTypeURLPrefix + "Marker", Value: []byte{1}})
+	assert.Nil(t, writer.WriteChunk("example.com/plugin/Churn", data))
+	message, err := ReadAnalysisResults(buffer)
+	assert.Nil(t, err)
+	assert.Len(t, message.Contents, 1)
+	assert.Equal(t, message.ExtensionNames(), []string{"example.com/plugin/Churn"})
+	assert.Equal(t, message.Extensions["example.com/plugin/Churn"].Value, []byte{1})
+}
+
+// countingReader counts the bytes which were read from the underlying bytes.Reader.
+type countingReader struct {
+	*bytes.Reader
+	read int
+}
+
+func (reader *countingReader) Read(p []byte) (int, error) {
+	n, err := reader.Reader.Read(p)
+	reader.read += n
+	return n, err
+}
+
+func TestContainerSeek(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	writer, err := NewContainerWriter(buffer, &Metadata{Repository: "test"})
+	assert.Nil(t, err)
+	assert.Nil(t, writer.WriteChunk("Burndown", bytes.Repeat([]byte{1}, 100000)))
+	assert.Nil(t, writer.WriteChunk("Couples", []byte{2, 3}))
+	source := &countingReader{Reader: bytes.NewReader(buffer.Bytes())}
+	message, err := ReadAnalysisResults(source, "Couples")
+	assert.Nil(t, err)
+	assert.Len(t, message.Contents, 1)
+	assert.Equal(t, message.Contents["Couples"], []byte{2, 3})
+	assert.True(t, source.read < 10000, source.read)
+	// not seekable
+	message, err = ReadAnalysisResults(bytes.NewBuffer(buffer.Bytes()), "Couples")
+	assert.Nil(t, err)
+	assert.Equal(t, message.Contents["Couples"], []byte{2, 3})
+	// truncated in the middle of the skipped chunk
+	data := buffer.Bytes()[:buffer.Len()-1000]
+	_, err = ReadAnalysisResults(bytes.NewReader(data), "Couples")
+	assert.Equal(t, err, io.ErrUnexpectedEOF)
+	_, err = ReadAnalysisResults(bytes.NewBuffer(data), "Couples")
+	assert.Equal(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestChunkNames(t *testing.T) {
+	assert.Equal(t, DayRangeChunkName("Burndown", 365, 730), "Burndown@365-730")
+	name, from, to, ranged := ParseChunkName("Burndown@365-730")
+	assert.Equal(t, name, "Burndown")
+	assert.Equal(t, from, 365)
+	assert.Equal(t, to, 730)
+	assert.True(t, ranged)
+	for _, plain := range []string{"Burndown", "Burndown@", "Burndown@1", "Burndown@a-2",
+		"Burndown@1-b", "example.com/a@b/Churn@1-2"} {
+		name, _, _, ranged = ParseChunkName(plain)
+		assert.Equal(t, name, plain)
+		assert.False(t, ranged)
+	}
+}
+
+func TestReadAnalysisResultsDayRanges(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	writer, err := NewContainerWriter(buffer, &Metadata{Repository: "test"})
+	assert.Nil(t, err)
+	part1, _ := proto.Marshal(&DevsAnalysisResults{
+		Days: map[int32]*DayDevs{1: {}}, DevIndex: []string{"one"}})
+	part2, _ := proto.Marshal(&DevsAnalysisResults{Days: map[int32]*DayDevs{400: {}}})
+	assert.Nil(t, writer.WriteChunk(DayRangeChunkName("Devs", 0, 365), part1))
+	assert.Nil(t, writer.WriteChunk("Couples", []byte{2, 3}))
+	assert.Nil(t, writer.WriteChunk(DayRangeChunkName("Devs", 365, 730), part2))
+	message, err := ReadAnalysisResults(bytes.NewReader(buffer.Bytes()), "Devs")
+	assert.Nil(t, err)
+	assert.Len(t, message.Contents, 1)
+	devs := DevsAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(message.Contents["Devs"], &devs))
+	assert.Len(t, devs.Days, 2)
+	assert.Contains(t, devs.Days, int32(1))
+	assert.Contains(t, devs.Days, int32(400))
+	assert.Equal(t, devs.DevIndex, []string{"one"})
+}
@@ -0,0 +1,103 @@
+package pb
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// ExtensionTypeURLPrefix starts Extension.TypeUrl, the same as in google.protobuf.Any.
+const ExtensionTypeURLPrefix = "type.googleapis.com/"
+
+// ErrUnknownExtension is returned by DecodeExtension() if the extension was not registered.
+var ErrUnknownExtension = errors.New("unknown extension")
+
+// extensionMessages maps the namespaced names of the extensions to the constructors of their
+// messages.
+var extensionMessages = map[string]func() proto.Message{}
+
+// IsExtensionName checks whether the result name is namespaced, e.g. "github.com/user/churn/Churn".
+// The names of the built-in analyses never contain slashes.
+func IsExtensionName(name string) bool {
+	slash := strings.LastIndex(name, "/")
+	return slash > 0 && slash < len(name)-1
+}
+
+// RegisterExtension adds the result message of a third-party analysis, so that PackExtension()
+// and DecodeExtension() work with it. The name must be namespaced. The later registration
+// of the same name replaces the former.
+func RegisterExtension(name string, factory func() proto.Message) {
+	if !IsExtensionName(name) {
+		panic(fmt.Sprintf("the extension name must be <namespace>/<name>: %s", name))
+	}
+	extensionMessages[name] = factory
+}
+
+// ExtensionNames returns the sorted names of the registered extensions.
+func ExtensionNames() []string {
+	names := make([]string, 0, len(extensionMessages))
+	for name := range extensionMessages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewExtensionMessage creates the empty message of the registered extension. It returns nil
+// if the extension is unknown.
+func NewExtensionMessage(name string) proto.Message {
+	factory, exists := extensionMessages[name]
+	if !exists {
+		return nil
+	}
+	return factory()
+}
+
+// extensionTypeURL returns the type URL of the message, falling back to the extension name
+// if the message type is not registered in proto.
+func extensionTypeURL(name string, message proto.Message) string {
+	if typeName := proto.MessageName(message); typeName != "" {
+		return ExtensionTypeURLPrefix + typeName
+	}
+	return ExtensionTypeURLPrefix + name
+}
+
+// PackExtension wraps the serialized result of the registered extension.
+func PackExtension(name string, data []byte) (*Extension, error) {
+	message := NewExtensionMessage(name)
+	if message == nil {
+		return nil, fmt.Errorf("%s: %v", name, ErrUnknownExtension)
+	}
+	return &Extension{TypeUrl: extensionTypeURL(name, message), Value: data}, nil
+}
+
+// DecodeExtension parses AnalysisResults.Extensions[name] to the typed message. It returns
+// ErrUnknownExtension if the extension is not registered.
+func DecodeExtension(name string, extension *Extension) (proto.Message, error) {
+	message := NewExtensionMessage(name)
+	if message == nil {
+		return nil, ErrUnknownExtension
+	}
+	if expected := extensionTypeURL(name, message); extension.TypeUrl != expected {
+		return nil, fmt.Errorf("%s: the type is %s instead of %s",
+			name, extension.TypeUrl, expected)
+	}
+	if err := proto.Unmarshal(extension.Value, message); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return message, nil
+}
+
+// ExtensionNames returns the sorted names of the extensions in the results, both known and
+// unknown.
+func (m *AnalysisResults) ExtensionNames() []string {
+	names := make([]string, 0, len(m.Extensions))
+	for name := range m.Extensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
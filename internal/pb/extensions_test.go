@@ -0,0 +1,41 @@
+package pb
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsExtensionName(t *testing.T) {
+	assert.True(t, IsExtensionName("example.com/plugin/Churn"))
+	assert.True(t, IsExtensionName("acme/Churn"))
+	assert.False(t, IsExtensionName("Burndown"))
+	assert.False(t, IsExtensionName("/Churn"))
+	assert.False(t, IsExtensionName("acme/"))
+	assert.Panics(t, func() {
+		RegisterExtension("Churn", func() proto.Message { return &Marker{} })
+	})
+}
+
+func TestExtensions(t *testing.T) {
+	RegisterExtension("example.com/test/Marker", func() proto.Message { return &Marker{} })
+	defer delete(extensionMessages, "example.com/test/Marker")
+	assert.Contains(t, ExtensionNames(), "example.com/test/Marker")
+	assert.IsType(t, &Marker{}, NewExtensionMessage("example.com/test/Marker"))
+	assert.Nil(t, NewExtensionMessage("example.com/test/Unknown"))
+	data, _ := proto.Marshal(&Marker{Label: "release"})
+	extension, err := PackExtension("example.com/test/Marker", data)
+	assert.Nil(t, err)
+	assert.Equal(t, extension.TypeUrl, "type.googleapis.com/Marker")
+	message, err := DecodeExtension("example.com/test/Marker", extension)
+	assert.Nil(t, err)
+	assert.Equal(t, message.(*Marker).Label, "release")
+	_, err = PackExtension("example.com/test/Unknown", data)
+	assert.NotNil(t, err)
+	_, err = DecodeExtension("example.com/test/Unknown", extension)
+	assert.Equal(t, err, ErrUnknownExtension)
+	extension.TypeUrl = ExtensionTypeURLPrefix + "SkippedItem"
+	_, err = DecodeExtension("example.com/test/Marker", extension)
+	assert.NotNil(t, err)
+}
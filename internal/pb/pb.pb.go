@@ -9,9 +9,13 @@ It is generated from these files:
 
 It has these top-level messages:
 	Metadata
+	Marker
+	SkippedItem
+	CommitFailure
 	BurndownSparseMatrixRow
 	BurndownSparseMatrix
 	BurndownAnalysisResults
+	BurndownDirectory
 	CompressedSparseRowMatrix
 	Couples
 	TouchedFiles
@@ -24,6 +28,103 @@ It has these top-level messages:
 	FileHistoryResultMessage
 	Sentiment
 	CommentSentimentResults
+	CommitFeatures
+	CommitFeaturesResults
+	RolesHistogram
+	LanguageRolesHistograms
+	RolesHistogramResults
+	HalsteadMetrics
+	DirectoryHalstead
+	HalsteadResults
+	IndentationStats
+	IndentationHistory
+	IndentationComplexityResults
+	StyleStats
+	LanguageStyleStats
+	StyleDriftResults
+	GofmtCompliance
+	GofmtComplianceResults
+	StringLiteralsRelease
+	StringLiteralsResults
+	SQLStats
+	SQLTableOrigin
+	SQLResults
+	ErrorHandlingStats
+	ErrorHandlingResults
+	TestCoChange
+	DirectoryTestCoChanges
+	TestCouplingResults
+	TimeSkewStats
+	TimeSkewResults
+	CherryPick
+	BackportStats
+	CherryPicksResults
+	LineStats
+	DevDay
+	DayDevs
+	DevsAnalysisResults
+	TypoFix
+	TypoFixesResults
+	CommentRatioStats
+	LanguageCommentRatios
+	CommentRatioResults
+	CommitMessageStats
+	CommitMessagesResults
+	ChurnOriginStats
+	DeveloperChurnOrigin
+	ChurnOriginResults
+	FileLifecycleCounts
+	DirectoryLifecycles
+	FileLifecycleResults
+	ReleasePressureStats
+	ReleasePressureResults
+	CompanyAttributionStats
+	CompanyQuarter
+	CompanyAttributionResults
+	BinaryChurnStats
+	BinaryChurnDirectories
+	BinaryAssets
+	BinaryChurnResults
+	RepositorySizeStats
+	RepositorySizeCommit
+	RepositorySizeResults
+	RefactoringStats
+	RefactoringResults
+	CoverageChurnStats
+	CoverageChurnResults
+	DefectsStats
+	DefectsResults
+	OwnershipConcentrationStats
+	DirectoryOwnershipConcentration
+	OwnershipConcentrationResults
+	EffortStats
+	ComponentEfforts
+	EffortEstimationResults
+	CommentReadabilityStats
+	DirectoryCommentReadability
+	CommentReadabilityResults
+	StaleComment
+	StaleCommentsResults
+	ToxicityStats
+	ToxicityResults
+	FunctionSizeStats
+	LanguageFunctionSizes
+	LongestFunction
+	FunctionSizeResults
+	NestingDepthStats
+	NestingDepthHistory
+	NestingDepthResults
+	CommitEntropy
+	CommitEntropyStats
+	CommitEntropyResults
+	TicketlessStats
+	TicketlessCommitsResults
+	ChangeSet
+	ChangeSetStats
+	ChangeSetsResults
+	CaseCollision
+	CaseCollisionsResults
+	Extension
 	AnalysisResults
 */
 package pb
@@ -58,6 +159,14 @@ type Metadata struct {
 	Commits int32 `protobuf:"varint,6,opt,name=commits,proto3" json:"commits,omitempty"`
 	// duration of the analysis in milliseconds
 	RunTime int64 `protobuf:"varint,7,opt,name=run_time,json=runTime,proto3" json:"run_time,omitempty"`
+	// commits which were skipped because of the errors
+	Failures []*CommitFailure `protobuf:"bytes,8,rep,name=failures" json:"failures,omitempty"`
+	// the analysis was interrupted by the deadline and the results are incomplete
+	Partial bool `protobuf:"varint,9,opt,name=partial,proto3" json:"partial,omitempty"`
+	// requested items which were not executed because of the disabled features
+	Skipped []*SkippedItem `protobuf:"bytes,10,rep,name=skipped" json:"skipped,omitempty"`
+	// labeled events which annotate the time axis, sorted by time
+	Markers []*Marker `protobuf:"bytes,11,rep,name=markers" json:"markers,omitempty"`
 }
 
 func (m *Metadata) Reset()                    { *m = Metadata{} }
@@ -114,6 +223,139 @@ func (m *Metadata) GetRunTime() int64 {
 	return 0
 }
 
+func (m *Metadata) GetFailures() []*CommitFailure {
+	if m != nil {
+		return m.Failures
+	}
+	return nil
+}
+
+func (m *Metadata) GetPartial() bool {
+	if m != nil {
+		return m.Partial
+	}
+	return false
+}
+
+func (m *Metadata) GetSkipped() []*SkippedItem {
+	if m != nil {
+		return m.Skipped
+	}
+	return nil
+}
+
+func (m *Metadata) GetMarkers() []*Marker {
+	if m != nil {
+		return m.Markers
+	}
+	return nil
+}
+
+type Marker struct {
+	// description of the event, e.g. "migrated to Go modules"
+	Label string `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	// UNIX timestamp of the event
+	UnixTime int64 `protobuf:"varint,2,opt,name=unix_time,json=unixTime,proto3" json:"unix_time,omitempty"`
+	// hash of the marked commit, empty if the marker is a date
+	Commit string `protobuf:"bytes,3,opt,name=commit,proto3" json:"commit,omitempty"`
+}
+
+func (m *Marker) Reset()                    { *m = Marker{} }
+func (m *Marker) String() string            { return proto.CompactTextString(m) }
+func (*Marker) ProtoMessage()               {}
+func (*Marker) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{1} }
+
+func (m *Marker) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *Marker) GetUnixTime() int64 {
+	if m != nil {
+		return m.UnixTime
+	}
+	return 0
+}
+
+func (m *Marker) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+type SkippedItem struct {
+	// name of the skipped pipeline item
+	Item string `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	// why the item was skipped
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (m *SkippedItem) Reset()                    { *m = SkippedItem{} }
+func (m *SkippedItem) String() string            { return proto.CompactTextString(m) }
+func (*SkippedItem) ProtoMessage()               {}
+func (*SkippedItem) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{2} }
+
+func (m *SkippedItem) GetItem() string {
+	if m != nil {
+		return m.Item
+	}
+	return ""
+}
+
+func (m *SkippedItem) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type CommitFailure struct {
+	// hash of the skipped commit
+	Commit string `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	// index of the commit in the analysed sequence
+	Index int32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	// name of the failed pipeline item
+	Item string `protobuf:"bytes,3,opt,name=item,proto3" json:"item,omitempty"`
+	// error message
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *CommitFailure) Reset()                    { *m = CommitFailure{} }
+func (m *CommitFailure) String() string            { return proto.CompactTextString(m) }
+func (*CommitFailure) ProtoMessage()               {}
+func (*CommitFailure) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{3} }
+
+func (m *CommitFailure) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *CommitFailure) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *CommitFailure) GetItem() string {
+	if m != nil {
+		return m.Item
+	}
+	return ""
+}
+
+func (m *CommitFailure) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 type BurndownSparseMatrixRow struct {
 	// the first `len(column)` elements are stored,
 	// the rest `number_of_columns - len(column)` values are zeros
@@ -123,7 +365,7 @@ type BurndownSparseMatrixRow struct {
 func (m *BurndownSparseMatrixRow) Reset()                    { *m = BurndownSparseMatrixRow{} }
 func (m *BurndownSparseMatrixRow) String() string            { return proto.CompactTextString(m) }
 func (*BurndownSparseMatrixRow) ProtoMessage()               {}
-func (*BurndownSparseMatrixRow) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{1} }
+func (*BurndownSparseMatrixRow) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{4} }
 
 func (m *BurndownSparseMatrixRow) GetColumns() []uint32 {
 	if m != nil {
@@ -143,7 +385,7 @@ type BurndownSparseMatrix struct {
 func (m *BurndownSparseMatrix) Reset()                    { *m = BurndownSparseMatrix{} }
 func (m *BurndownSparseMatrix) String() string            { return proto.CompactTextString(m) }
 func (*BurndownSparseMatrix) ProtoMessage()               {}
-func (*BurndownSparseMatrix) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{2} }
+func (*BurndownSparseMatrix) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{5} }
 
 func (m *BurndownSparseMatrix) GetName() string {
 	if m != nil {
@@ -186,12 +428,21 @@ type BurndownAnalysisResults struct {
 	People []*BurndownSparseMatrix `protobuf:"bytes,5,rep,name=people" json:"people,omitempty"`
 	// rows and cols order correspond to `burndown_developer`
 	PeopleInteraction *CompressedSparseRowMatrix `protobuf:"bytes,6,opt,name=people_interaction,json=peopleInteraction" json:"people_interaction,omitempty"`
+	// this is included if `-burndown-groups` was specified
+	Groups []*BurndownSparseMatrix `protobuf:"bytes,7,rep,name=groups" json:"groups,omitempty"`
+	// this is included if `-burndown-directories` was specified;
+	// the parents always go before their children
+	Directories []*BurndownDirectory `protobuf:"bytes,8,rep,name=directories" json:"directories,omitempty"`
+	// the policy for the lines of the first commit: "keep" (empty), "spread" or "exclude"
+	InitialCommit string `protobuf:"bytes,9,opt,name=initial_commit,json=initialCommit,proto3" json:"initial_commit,omitempty"`
+	// the number of synthetic days before begin_unix_time in the matrices ("spread")
+	BackfillDays int32 `protobuf:"varint,10,opt,name=backfill_days,json=backfillDays,proto3" json:"backfill_days,omitempty"`
 }
 
 func (m *BurndownAnalysisResults) Reset()                    { *m = BurndownAnalysisResults{} }
 func (m *BurndownAnalysisResults) String() string            { return proto.CompactTextString(m) }
 func (*BurndownAnalysisResults) ProtoMessage()               {}
-func (*BurndownAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{3} }
+func (*BurndownAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{6} }
 
 func (m *BurndownAnalysisResults) GetGranularity() int32 {
 	if m != nil {
@@ -235,6 +486,60 @@ func (m *BurndownAnalysisResults) GetPeopleInteraction() *CompressedSparseRowMat
 	return nil
 }
 
+func (m *BurndownAnalysisResults) GetGroups() []*BurndownSparseMatrix {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+func (m *BurndownAnalysisResults) GetDirectories() []*BurndownDirectory {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+func (m *BurndownAnalysisResults) GetInitialCommit() string {
+	if m != nil {
+		return m.InitialCommit
+	}
+	return ""
+}
+
+func (m *BurndownAnalysisResults) GetBackfillDays() int32 {
+	if m != nil {
+		return m.BackfillDays
+	}
+	return 0
+}
+
+type BurndownDirectory struct {
+	// the name of the matrix is the directory path, "/" for the repository root
+	Matrix *BurndownSparseMatrix `protobuf:"bytes,1,opt,name=matrix" json:"matrix,omitempty"`
+	// the index of the parent directory in `directories`, -1 for the root
+	Parent int32 `protobuf:"varint,2,opt,name=parent,proto3" json:"parent,omitempty"`
+}
+
+func (m *BurndownDirectory) Reset()                    { *m = BurndownDirectory{} }
+func (m *BurndownDirectory) String() string            { return proto.CompactTextString(m) }
+func (*BurndownDirectory) ProtoMessage()               {}
+func (*BurndownDirectory) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{7} }
+
+func (m *BurndownDirectory) GetMatrix() *BurndownSparseMatrix {
+	if m != nil {
+		return m.Matrix
+	}
+	return nil
+}
+
+func (m *BurndownDirectory) GetParent() int32 {
+	if m != nil {
+		return m.Parent
+	}
+	return 0
+}
+
 type CompressedSparseRowMatrix struct {
 	NumberOfRows    int32 `protobuf:"varint,1,opt,name=number_of_rows,json=numberOfRows,proto3" json:"number_of_rows,omitempty"`
 	NumberOfColumns int32 `protobuf:"varint,2,opt,name=number_of_columns,json=numberOfColumns,proto3" json:"number_of_columns,omitempty"`
@@ -247,7 +552,7 @@ type CompressedSparseRowMatrix struct {
 func (m *CompressedSparseRowMatrix) Reset()                    { *m = CompressedSparseRowMatrix{} }
 func (m *CompressedSparseRowMatrix) String() string            { return proto.CompactTextString(m) }
 func (*CompressedSparseRowMatrix) ProtoMessage()               {}
-func (*CompressedSparseRowMatrix) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{4} }
+func (*CompressedSparseRowMatrix) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{8} }
 
 func (m *CompressedSparseRowMatrix) GetNumberOfRows() int32 {
 	if m != nil {
@@ -294,7 +599,7 @@ type Couples struct {
 func (m *Couples) Reset()                    { *m = Couples{} }
 func (m *Couples) String() string            { return proto.CompactTextString(m) }
 func (*Couples) ProtoMessage()               {}
-func (*Couples) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{5} }
+func (*Couples) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{9} }
 
 func (m *Couples) GetIndex() []string {
 	if m != nil {
@@ -317,7 +622,7 @@ type TouchedFiles struct {
 func (m *TouchedFiles) Reset()                    { *m = TouchedFiles{} }
 func (m *TouchedFiles) String() string            { return proto.CompactTextString(m) }
 func (*TouchedFiles) ProtoMessage()               {}
-func (*TouchedFiles) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{6} }
+func (*TouchedFiles) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{10} }
 
 func (m *TouchedFiles) GetFiles() []int32 {
 	if m != nil {
@@ -331,12 +636,18 @@ type CouplesAnalysisResults struct {
 	PeopleCouples *Couples `protobuf:"bytes,7,opt,name=people_couples,json=peopleCouples" json:"people_couples,omitempty"`
 	// order corresponds to `people_couples::index`
 	PeopleFiles []*TouchedFiles `protobuf:"bytes,8,rep,name=people_files,json=peopleFiles" json:"people_files,omitempty"`
+	// rows correspond to `people_couples::index` plus the unidentified authors,
+	// columns correspond to `file_couples::index`; included if `-couples-people-files` was specified
+	PeopleFilesMatrix *CompressedSparseRowMatrix `protobuf:"bytes,9,opt,name=people_files_matrix,json=peopleFilesMatrix" json:"people_files_matrix,omitempty"`
+	// cluster of each file in `file_couples::index`, the biggest is 0;
+	// included if `-couples-clusters` was specified
+	FileClusters []int32 `protobuf:"varint,10,rep,packed,name=file_clusters,json=fileClusters" json:"file_clusters,omitempty"`
 }
 
 func (m *CouplesAnalysisResults) Reset()                    { *m = CouplesAnalysisResults{} }
 func (m *CouplesAnalysisResults) String() string            { return proto.CompactTextString(m) }
 func (*CouplesAnalysisResults) ProtoMessage()               {}
-func (*CouplesAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{7} }
+func (*CouplesAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{11} }
 
 func (m *CouplesAnalysisResults) GetFileCouples() *Couples {
 	if m != nil {
@@ -359,18 +670,41 @@ func (m *CouplesAnalysisResults) GetPeopleFiles() []*TouchedFiles {
 	return nil
 }
 
+func (m *CouplesAnalysisResults) GetPeopleFilesMatrix() *CompressedSparseRowMatrix {
+	if m != nil {
+		return m.PeopleFilesMatrix
+	}
+	return nil
+}
+
+func (m *CouplesAnalysisResults) GetFileClusters() []int32 {
+	if m != nil {
+		return m.FileClusters
+	}
+	return nil
+}
+
 type UASTChange struct {
-	FileName   string `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// empty if the file was deleted
+	FileName string `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// the following four paths are empty if the corresponding side does not exist
 	SrcBefore  string `protobuf:"bytes,2,opt,name=src_before,json=srcBefore,proto3" json:"src_before,omitempty"`
 	SrcAfter   string `protobuf:"bytes,3,opt,name=src_after,json=srcAfter,proto3" json:"src_after,omitempty"`
 	UastBefore string `protobuf:"bytes,4,opt,name=uast_before,json=uastBefore,proto3" json:"uast_before,omitempty"`
 	UastAfter  string `protobuf:"bytes,5,opt,name=uast_after,json=uastAfter,proto3" json:"uast_after,omitempty"`
+	// the hash of the commit
+	Commit string `protobuf:"bytes,6,opt,name=commit,proto3" json:"commit,omitempty"`
+	// empty if the file was added
+	FileNameBefore string `protobuf:"bytes,7,opt,name=file_name_before,json=fileNameBefore,proto3" json:"file_name_before,omitempty"`
+	// blob hashes, empty if the corresponding side does not exist
+	HashBefore string `protobuf:"bytes,8,opt,name=hash_before,json=hashBefore,proto3" json:"hash_before,omitempty"`
+	HashAfter  string `protobuf:"bytes,9,opt,name=hash_after,json=hashAfter,proto3" json:"hash_after,omitempty"`
 }
 
 func (m *UASTChange) Reset()                    { *m = UASTChange{} }
 func (m *UASTChange) String() string            { return proto.CompactTextString(m) }
 func (*UASTChange) ProtoMessage()               {}
-func (*UASTChange) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{8} }
+func (*UASTChange) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{12} }
 
 func (m *UASTChange) GetFileName() string {
 	if m != nil {
@@ -407,6 +741,34 @@ func (m *UASTChange) GetUastAfter() string {
 	return ""
 }
 
+func (m *UASTChange) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *UASTChange) GetFileNameBefore() string {
+	if m != nil {
+		return m.FileNameBefore
+	}
+	return ""
+}
+
+func (m *UASTChange) GetHashBefore() string {
+	if m != nil {
+		return m.HashBefore
+	}
+	return ""
+}
+
+func (m *UASTChange) GetHashAfter() string {
+	if m != nil {
+		return m.HashAfter
+	}
+	return ""
+}
+
 type UASTChangesSaverResults struct {
 	Changes []*UASTChange `protobuf:"bytes,1,rep,name=changes" json:"changes,omitempty"`
 }
@@ -414,7 +776,7 @@ type UASTChangesSaverResults struct {
 func (m *UASTChangesSaverResults) Reset()                    { *m = UASTChangesSaverResults{} }
 func (m *UASTChangesSaverResults) String() string            { return proto.CompactTextString(m) }
 func (*UASTChangesSaverResults) ProtoMessage()               {}
-func (*UASTChangesSaverResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{9} }
+func (*UASTChangesSaverResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{13} }
 
 func (m *UASTChangesSaverResults) GetChanges() []*UASTChange {
 	if m != nil {
@@ -429,12 +791,17 @@ type ShotnessRecord struct {
 	Name         string          `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
 	File         string          `protobuf:"bytes,4,opt,name=file,proto3" json:"file,omitempty"`
 	Counters     map[int32]int32 `protobuf:"bytes,5,rep,name=counters" json:"counters,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// 1-based lines of the node in the latest revision of the file
+	StartLine int32 `protobuf:"varint,6,opt,name=start_line,json=startLine,proto3" json:"start_line,omitempty"`
+	EndLine   int32 `protobuf:"varint,7,opt,name=end_line,json=endLine,proto3" json:"end_line,omitempty"`
+	// dot-separated names of the types which contain the node, e.g. the class of a method
+	EnclosingType string `protobuf:"bytes,8,opt,name=enclosing_type,json=enclosingType,proto3" json:"enclosing_type,omitempty"`
 }
 
 func (m *ShotnessRecord) Reset()                    { *m = ShotnessRecord{} }
 func (m *ShotnessRecord) String() string            { return proto.CompactTextString(m) }
 func (*ShotnessRecord) ProtoMessage()               {}
-func (*ShotnessRecord) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{10} }
+func (*ShotnessRecord) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{14} }
 
 func (m *ShotnessRecord) GetInternalRole() string {
 	if m != nil {
@@ -471,6 +838,27 @@ func (m *ShotnessRecord) GetCounters() map[int32]int32 {
 	return nil
 }
 
+func (m *ShotnessRecord) GetStartLine() int32 {
+	if m != nil {
+		return m.StartLine
+	}
+	return 0
+}
+
+func (m *ShotnessRecord) GetEndLine() int32 {
+	if m != nil {
+		return m.EndLine
+	}
+	return 0
+}
+
+func (m *ShotnessRecord) GetEnclosingType() string {
+	if m != nil {
+		return m.EnclosingType
+	}
+	return ""
+}
+
 type ShotnessAnalysisResults struct {
 	Records []*ShotnessRecord `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
 }
@@ -478,7 +866,7 @@ type ShotnessAnalysisResults struct {
 func (m *ShotnessAnalysisResults) Reset()                    { *m = ShotnessAnalysisResults{} }
 func (m *ShotnessAnalysisResults) String() string            { return proto.CompactTextString(m) }
 func (*ShotnessAnalysisResults) ProtoMessage()               {}
-func (*ShotnessAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{11} }
+func (*ShotnessAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{15} }
 
 func (m *ShotnessAnalysisResults) GetRecords() []*ShotnessRecord {
 	if m != nil {
@@ -494,7 +882,7 @@ type FileHistory struct {
 func (m *FileHistory) Reset()                    { *m = FileHistory{} }
 func (m *FileHistory) String() string            { return proto.CompactTextString(m) }
 func (*FileHistory) ProtoMessage()               {}
-func (*FileHistory) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{12} }
+func (*FileHistory) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{16} }
 
 func (m *FileHistory) GetCommits() []string {
 	if m != nil {
@@ -510,7 +898,7 @@ type FileHistoryResultMessage struct {
 func (m *FileHistoryResultMessage) Reset()                    { *m = FileHistoryResultMessage{} }
 func (m *FileHistoryResultMessage) String() string            { return proto.CompactTextString(m) }
 func (*FileHistoryResultMessage) ProtoMessage()               {}
-func (*FileHistoryResultMessage) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{13} }
+func (*FileHistoryResultMessage) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{17} }
 
 func (m *FileHistoryResultMessage) GetFiles() map[string]*FileHistory {
 	if m != nil {
@@ -523,12 +911,14 @@ type Sentiment struct {
 	Value    float32  `protobuf:"fixed32,1,opt,name=value,proto3" json:"value,omitempty"`
 	Comments []string `protobuf:"bytes,2,rep,name=comments" json:"comments,omitempty"`
 	Commits  []string `protobuf:"bytes,3,rep,name=commits" json:"commits,omitempty"`
+	// shares of the emotion categories among the comments, empty if not classified
+	Emotions map[string]float32 `protobuf:"bytes,4,rep,name=emotions" json:"emotions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed32,2,opt,name=value,proto3"`
 }
 
 func (m *Sentiment) Reset()                    { *m = Sentiment{} }
 func (m *Sentiment) String() string            { return proto.CompactTextString(m) }
 func (*Sentiment) ProtoMessage()               {}
-func (*Sentiment) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{14} }
+func (*Sentiment) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{18} }
 
 func (m *Sentiment) GetValue() float32 {
 	if m != nil {
@@ -551,6 +941,13 @@ func (m *Sentiment) GetCommits() []string {
 	return nil
 }
 
+func (m *Sentiment) GetEmotions() map[string]float32 {
+	if m != nil {
+		return m.Emotions
+	}
+	return nil
+}
+
 type CommentSentimentResults struct {
 	SentimentByDay map[int32]*Sentiment `protobuf:"bytes,1,rep,name=sentiment_by_day,json=sentimentByDay" json:"sentiment_by_day,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
 }
@@ -558,7 +955,7 @@ type CommentSentimentResults struct {
 func (m *CommentSentimentResults) Reset()                    { *m = CommentSentimentResults{} }
 func (m *CommentSentimentResults) String() string            { return proto.CompactTextString(m) }
 func (*CommentSentimentResults) ProtoMessage()               {}
-func (*CommentSentimentResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{15} }
+func (*CommentSentimentResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{19} }
 
 func (m *CommentSentimentResults) GetSentimentByDay() map[int32]*Sentiment {
 	if m != nil {
@@ -567,36 +964,3195 @@ func (m *CommentSentimentResults) GetSentimentByDay() map[int32]*Sentiment {
 	return nil
 }
 
-type AnalysisResults struct {
-	Header *Metadata `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
-	// the mapped values are dynamic messages which require the second parsing pass.
-	Contents map[string][]byte `protobuf:"bytes,2,rep,name=contents" json:"contents,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+type CommitFeatures struct {
+	Commit string `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	// number of days since the beginning of the history
+	Day int32 `protobuf:"varint,2,opt,name=day,proto3" json:"day,omitempty"`
+	// hour of the day in the author's time zone
+	Hour    int32 `protobuf:"varint,3,opt,name=hour,proto3" json:"hour,omitempty"`
+	Files   int32 `protobuf:"varint,4,opt,name=files,proto3" json:"files,omitempty"`
+	Added   int32 `protobuf:"varint,5,opt,name=added,proto3" json:"added,omitempty"`
+	Removed int32 `protobuf:"varint,6,opt,name=removed,proto3" json:"removed,omitempty"`
+	// Shannon entropy of the changed files' directories in bits
+	DirEntropy float32 `protobuf:"fixed32,7,opt,name=dir_entropy,json=dirEntropy,proto3" json:"dir_entropy,omitempty"`
+	// number of commits made by the same author before
+	AuthorExperience int32 `protobuf:"varint,8,opt,name=author_experience,json=authorExperience,proto3" json:"author_experience,omitempty"`
+	MessageLength    int32 `protobuf:"varint,9,opt,name=message_length,json=messageLength,proto3" json:"message_length,omitempty"`
+	MessageLines     int32 `protobuf:"varint,10,opt,name=message_lines,json=messageLines,proto3" json:"message_lines,omitempty"`
+	// UAST nodes added and removed by the commit, 0 without --feature=uast
+	UastNodesAdded   int32 `protobuf:"varint,11,opt,name=uast_nodes_added,json=uastNodesAdded,proto3" json:"uast_nodes_added,omitempty"`
+	UastNodesRemoved int32 `protobuf:"varint,12,opt,name=uast_nodes_removed,json=uastNodesRemoved,proto3" json:"uast_nodes_removed,omitempty"`
 }
 
-func (m *AnalysisResults) Reset()                    { *m = AnalysisResults{} }
-func (m *AnalysisResults) String() string            { return proto.CompactTextString(m) }
-func (*AnalysisResults) ProtoMessage()               {}
-func (*AnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{16} }
+func (m *CommitFeatures) Reset()                    { *m = CommitFeatures{} }
+func (m *CommitFeatures) String() string            { return proto.CompactTextString(m) }
+func (*CommitFeatures) ProtoMessage()               {}
+func (*CommitFeatures) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{20} }
 
-func (m *AnalysisResults) GetHeader() *Metadata {
+func (m *CommitFeatures) GetCommit() string {
 	if m != nil {
-		return m.Header
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *CommitFeatures) GetDay() int32 {
+	if m != nil {
+		return m.Day
+	}
+	return 0
+}
+
+func (m *CommitFeatures) GetHour() int32 {
+	if m != nil {
+		return m.Hour
+	}
+	return 0
+}
+
+func (m *CommitFeatures) GetFiles() int32 {
+	if m != nil {
+		return m.Files
+	}
+	return 0
+}
+
+func (m *CommitFeatures) GetAdded() int32 {
+	if m != nil {
+		return m.Added
+	}
+	return 0
+}
+
+func (m *CommitFeatures) GetRemoved() int32 {
+	if m != nil {
+		return m.Removed
+	}
+	return 0
+}
+
+func (m *CommitFeatures) GetDirEntropy() float32 {
+	if m != nil {
+		return m.DirEntropy
+	}
+	return 0
+}
+
+func (m *CommitFeatures) GetAuthorExperience() int32 {
+	if m != nil {
+		return m.AuthorExperience
+	}
+	return 0
+}
+
+func (m *CommitFeatures) GetMessageLength() int32 {
+	if m != nil {
+		return m.MessageLength
+	}
+	return 0
+}
+
+func (m *CommitFeatures) GetMessageLines() int32 {
+	if m != nil {
+		return m.MessageLines
+	}
+	return 0
+}
+
+func (m *CommitFeatures) GetUastNodesAdded() int32 {
+	if m != nil {
+		return m.UastNodesAdded
+	}
+	return 0
+}
+
+func (m *CommitFeatures) GetUastNodesRemoved() int32 {
+	if m != nil {
+		return m.UastNodesRemoved
+	}
+	return 0
+}
+
+type CommitFeaturesResults struct {
+	// chronological order
+	Commits []*CommitFeatures `protobuf:"bytes,1,rep,name=commits" json:"commits,omitempty"`
+}
+
+func (m *CommitFeaturesResults) Reset()                    { *m = CommitFeaturesResults{} }
+func (m *CommitFeaturesResults) String() string            { return proto.CompactTextString(m) }
+func (*CommitFeaturesResults) ProtoMessage()               {}
+func (*CommitFeaturesResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{21} }
+
+func (m *CommitFeaturesResults) GetCommits() []*CommitFeatures {
+	if m != nil {
+		return m.Commits
 	}
 	return nil
 }
 
-func (m *AnalysisResults) GetContents() map[string][]byte {
+type RolesHistogram struct {
+	// UAST role -> number of nodes
+	Roles map[int32]int64 `protobuf:"bytes,1,rep,name=roles" json:"roles,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (m *RolesHistogram) Reset()                    { *m = RolesHistogram{} }
+func (m *RolesHistogram) String() string            { return proto.CompactTextString(m) }
+func (*RolesHistogram) ProtoMessage()               {}
+func (*RolesHistogram) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{22} }
+
+func (m *RolesHistogram) GetRoles() map[int32]int64 {
 	if m != nil {
-		return m.Contents
+		return m.Roles
+	}
+	return nil
+}
+
+type LanguageRolesHistograms struct {
+	Languages map[string]*RolesHistogram `protobuf:"bytes,1,rep,name=languages" json:"languages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *LanguageRolesHistograms) Reset()                    { *m = LanguageRolesHistograms{} }
+func (m *LanguageRolesHistograms) String() string            { return proto.CompactTextString(m) }
+func (*LanguageRolesHistograms) ProtoMessage()               {}
+func (*LanguageRolesHistograms) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{23} }
+
+func (m *LanguageRolesHistograms) GetLanguages() map[string]*RolesHistogram {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+type RolesHistogramResults struct {
+	// day index -> histograms
+	Days map[int32]*LanguageRolesHistograms `protobuf:"bytes,1,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *RolesHistogramResults) Reset()                    { *m = RolesHistogramResults{} }
+func (m *RolesHistogramResults) String() string            { return proto.CompactTextString(m) }
+func (*RolesHistogramResults) ProtoMessage()               {}
+func (*RolesHistogramResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{24} }
+
+func (m *RolesHistogramResults) GetDays() map[int32]*LanguageRolesHistograms {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+type HalsteadMetrics struct {
+	// the sum of the files' volumes
+	Volume float32 `protobuf:"fixed32,1,opt,name=volume,proto3" json:"volume,omitempty"`
+	// the average of the files' difficulties
+	Difficulty float32 `protobuf:"fixed32,2,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Files      int32   `protobuf:"varint,3,opt,name=files,proto3" json:"files,omitempty"`
+}
+
+func (m *HalsteadMetrics) Reset()                    { *m = HalsteadMetrics{} }
+func (m *HalsteadMetrics) String() string            { return proto.CompactTextString(m) }
+func (*HalsteadMetrics) ProtoMessage()               {}
+func (*HalsteadMetrics) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{25} }
+
+func (m *HalsteadMetrics) GetVolume() float32 {
+	if m != nil {
+		return m.Volume
+	}
+	return 0
+}
+
+func (m *HalsteadMetrics) GetDifficulty() float32 {
+	if m != nil {
+		return m.Difficulty
+	}
+	return 0
+}
+
+func (m *HalsteadMetrics) GetFiles() int32 {
+	if m != nil {
+		return m.Files
+	}
+	return 0
+}
+
+type DirectoryHalstead struct {
+	Directories map[string]*HalsteadMetrics `protobuf:"bytes,1,rep,name=directories" json:"directories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *DirectoryHalstead) Reset()                    { *m = DirectoryHalstead{} }
+func (m *DirectoryHalstead) String() string            { return proto.CompactTextString(m) }
+func (*DirectoryHalstead) ProtoMessage()               {}
+func (*DirectoryHalstead) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{26} }
+
+func (m *DirectoryHalstead) GetDirectories() map[string]*HalsteadMetrics {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+type HalsteadResults struct {
+	// day index -> the directories which changed on that day
+	Days map[int32]*DirectoryHalstead `protobuf:"bytes,1,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *HalsteadResults) Reset()                    { *m = HalsteadResults{} }
+func (m *HalsteadResults) String() string            { return proto.CompactTextString(m) }
+func (*HalsteadResults) ProtoMessage()               {}
+func (*HalsteadResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{27} }
+
+func (m *HalsteadResults) GetDays() map[int32]*DirectoryHalstead {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+type IndentationStats struct {
+	Day int32 `protobuf:"varint,1,opt,name=day,proto3" json:"day,omitempty"`
+	// number of non-blank lines
+	Lines int32 `protobuf:"varint,2,opt,name=lines,proto3" json:"lines,omitempty"`
+	// sum of the indentation levels
+	Total int32 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	// maximum indentation level
+	Max int32 `protobuf:"varint,4,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (m *IndentationStats) Reset()                    { *m = IndentationStats{} }
+func (m *IndentationStats) String() string            { return proto.CompactTextString(m) }
+func (*IndentationStats) ProtoMessage()               {}
+func (*IndentationStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{28} }
+
+func (m *IndentationStats) GetDay() int32 {
+	if m != nil {
+		return m.Day
+	}
+	return 0
+}
+
+func (m *IndentationStats) GetLines() int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *IndentationStats) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *IndentationStats) GetMax() int32 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+type IndentationHistory struct {
+	Stats []*IndentationStats `protobuf:"bytes,1,rep,name=stats" json:"stats,omitempty"`
+}
+
+func (m *IndentationHistory) Reset()                    { *m = IndentationHistory{} }
+func (m *IndentationHistory) String() string            { return proto.CompactTextString(m) }
+func (*IndentationHistory) ProtoMessage()               {}
+func (*IndentationHistory) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{29} }
+
+func (m *IndentationHistory) GetStats() []*IndentationStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type IndentationComplexityResults struct {
+	Files map[string]*IndentationHistory `protobuf:"bytes,1,rep,name=files" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *IndentationComplexityResults) Reset()                    { *m = IndentationComplexityResults{} }
+func (m *IndentationComplexityResults) String() string            { return proto.CompactTextString(m) }
+func (*IndentationComplexityResults) ProtoMessage()               {}
+func (*IndentationComplexityResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{30} }
+
+func (m *IndentationComplexityResults) GetFiles() map[string]*IndentationHistory {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+type StyleStats struct {
+	Lines              int64 `protobuf:"varint,1,opt,name=lines,proto3" json:"lines,omitempty"`
+	TabIndented        int64 `protobuf:"varint,2,opt,name=tab_indented,json=tabIndented,proto3" json:"tab_indented,omitempty"`
+	SpaceIndented      int64 `protobuf:"varint,3,opt,name=space_indented,json=spaceIndented,proto3" json:"space_indented,omitempty"`
+	TrailingWhitespace int64 `protobuf:"varint,4,opt,name=trailing_whitespace,json=trailingWhitespace,proto3" json:"trailing_whitespace,omitempty"`
+	// line length histogram, see StyleDriftResults.line_length_bucket
+	LineLengths []int64 `protobuf:"varint,5,rep,packed,name=line_lengths,json=lineLengths" json:"line_lengths,omitempty"`
+}
+
+func (m *StyleStats) Reset()                    { *m = StyleStats{} }
+func (m *StyleStats) String() string            { return proto.CompactTextString(m) }
+func (*StyleStats) ProtoMessage()               {}
+func (*StyleStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{31} }
+
+func (m *StyleStats) GetLines() int64 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *StyleStats) GetTabIndented() int64 {
+	if m != nil {
+		return m.TabIndented
+	}
+	return 0
+}
+
+func (m *StyleStats) GetSpaceIndented() int64 {
+	if m != nil {
+		return m.SpaceIndented
+	}
+	return 0
+}
+
+func (m *StyleStats) GetTrailingWhitespace() int64 {
+	if m != nil {
+		return m.TrailingWhitespace
+	}
+	return 0
+}
+
+func (m *StyleStats) GetLineLengths() []int64 {
+	if m != nil {
+		return m.LineLengths
+	}
+	return nil
+}
+
+type LanguageStyleStats struct {
+	Languages map[string]*StyleStats `protobuf:"bytes,1,rep,name=languages" json:"languages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *LanguageStyleStats) Reset()                    { *m = LanguageStyleStats{} }
+func (m *LanguageStyleStats) String() string            { return proto.CompactTextString(m) }
+func (*LanguageStyleStats) ProtoMessage()               {}
+func (*LanguageStyleStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{32} }
+
+func (m *LanguageStyleStats) GetLanguages() map[string]*StyleStats {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+type StyleDriftResults struct {
+	// the width of each line length histogram bucket
+	LineLengthBucket int32 `protobuf:"varint,1,opt,name=line_length_bucket,json=lineLengthBucket,proto3" json:"line_length_bucket,omitempty"`
+	// day -> language -> style statistics
+	Days map[int32]*LanguageStyleStats `protobuf:"bytes,2,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *StyleDriftResults) Reset()                    { *m = StyleDriftResults{} }
+func (m *StyleDriftResults) String() string            { return proto.CompactTextString(m) }
+func (*StyleDriftResults) ProtoMessage()               {}
+func (*StyleDriftResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{33} }
+
+func (m *StyleDriftResults) GetLineLengthBucket() int32 {
+	if m != nil {
+		return m.LineLengthBucket
+	}
+	return 0
+}
+
+func (m *StyleDriftResults) GetDays() map[int32]*LanguageStyleStats {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+type GofmtCompliance struct {
+	// number of checked Go files
+	Checked int32 `protobuf:"varint,1,opt,name=checked,proto3" json:"checked,omitempty"`
+	// number of gofmt-clean Go files
+	Clean int32 `protobuf:"varint,2,opt,name=clean,proto3" json:"clean,omitempty"`
+}
+
+func (m *GofmtCompliance) Reset()                    { *m = GofmtCompliance{} }
+func (m *GofmtCompliance) String() string            { return proto.CompactTextString(m) }
+func (*GofmtCompliance) ProtoMessage()               {}
+func (*GofmtCompliance) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{34} }
+
+func (m *GofmtCompliance) GetChecked() int32 {
+	if m != nil {
+		return m.Checked
+	}
+	return 0
+}
+
+func (m *GofmtCompliance) GetClean() int32 {
+	if m != nil {
+		return m.Clean
+	}
+	return 0
+}
+
+type GofmtComplianceResults struct {
+	// day -> compliance of the files changed on that day
+	Days map[int32]*GofmtCompliance `protobuf:"bytes,1,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// developer index -> compliance, the last element is the unmatched authors
+	People []*GofmtCompliance `protobuf:"bytes,2,rep,name=people" json:"people,omitempty"`
+	// developer names
+	PeopleSequence []string `protobuf:"bytes,3,rep,name=people_sequence,json=peopleSequence" json:"people_sequence,omitempty"`
+}
+
+func (m *GofmtComplianceResults) Reset()                    { *m = GofmtComplianceResults{} }
+func (m *GofmtComplianceResults) String() string            { return proto.CompactTextString(m) }
+func (*GofmtComplianceResults) ProtoMessage()               {}
+func (*GofmtComplianceResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{35} }
+
+func (m *GofmtComplianceResults) GetDays() map[int32]*GofmtCompliance {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *GofmtComplianceResults) GetPeople() []*GofmtCompliance {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *GofmtComplianceResults) GetPeopleSequence() []string {
+	if m != nil {
+		return m.PeopleSequence
+	}
+	return nil
+}
+
+type StringLiteralsRelease struct {
+	// tag name or "HEAD"
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Day  int32  `protobuf:"varint,2,opt,name=day,proto3" json:"day,omitempty"`
+	// number of unique literals in the release
+	Total   int32    `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Added   []string `protobuf:"bytes,4,rep,name=added" json:"added,omitempty"`
+	Removed []string `protobuf:"bytes,5,rep,name=removed" json:"removed,omitempty"`
+}
+
+func (m *StringLiteralsRelease) Reset()                    { *m = StringLiteralsRelease{} }
+func (m *StringLiteralsRelease) String() string            { return proto.CompactTextString(m) }
+func (*StringLiteralsRelease) ProtoMessage()               {}
+func (*StringLiteralsRelease) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{36} }
+
+func (m *StringLiteralsRelease) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *StringLiteralsRelease) GetDay() int32 {
+	if m != nil {
+		return m.Day
+	}
+	return 0
+}
+
+func (m *StringLiteralsRelease) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *StringLiteralsRelease) GetAdded() []string {
+	if m != nil {
+		return m.Added
+	}
+	return nil
+}
+
+func (m *StringLiteralsRelease) GetRemoved() []string {
+	if m != nil {
+		return m.Removed
+	}
+	return nil
+}
+
+type StringLiteralsResults struct {
+	Releases []*StringLiteralsRelease `protobuf:"bytes,1,rep,name=releases" json:"releases,omitempty"`
+}
+
+func (m *StringLiteralsResults) Reset()                    { *m = StringLiteralsResults{} }
+func (m *StringLiteralsResults) String() string            { return proto.CompactTextString(m) }
+func (*StringLiteralsResults) ProtoMessage()               {}
+func (*StringLiteralsResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{37} }
+
+func (m *StringLiteralsResults) GetReleases() []*StringLiteralsRelease {
+	if m != nil {
+		return m.Releases
+	}
+	return nil
+}
+
+type SQLStats struct {
+	Statements int32 `protobuf:"varint,1,opt,name=statements,proto3" json:"statements,omitempty"`
+	Joins      int32 `protobuf:"varint,2,opt,name=joins,proto3" json:"joins,omitempty"`
+	// table -> number of statements which reference it
+	Tables map[string]int32 `protobuf:"bytes,3,rep,name=tables" json:"tables,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (m *SQLStats) Reset()                    { *m = SQLStats{} }
+func (m *SQLStats) String() string            { return proto.CompactTextString(m) }
+func (*SQLStats) ProtoMessage()               {}
+func (*SQLStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{38} }
+
+func (m *SQLStats) GetStatements() int32 {
+	if m != nil {
+		return m.Statements
+	}
+	return 0
+}
+
+func (m *SQLStats) GetJoins() int32 {
+	if m != nil {
+		return m.Joins
+	}
+	return 0
+}
+
+func (m *SQLStats) GetTables() map[string]int32 {
+	if m != nil {
+		return m.Tables
+	}
+	return nil
+}
+
+type SQLTableOrigin struct {
+	Day  int32  `protobuf:"varint,1,opt,name=day,proto3" json:"day,omitempty"`
+	File string `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+}
+
+func (m *SQLTableOrigin) Reset()                    { *m = SQLTableOrigin{} }
+func (m *SQLTableOrigin) String() string            { return proto.CompactTextString(m) }
+func (*SQLTableOrigin) ProtoMessage()               {}
+func (*SQLTableOrigin) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{39} }
+
+func (m *SQLTableOrigin) GetDay() int32 {
+	if m != nil {
+		return m.Day
+	}
+	return 0
+}
+
+func (m *SQLTableOrigin) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+type SQLResults struct {
+	// day -> repository-wide SQL statistics
+	Days map[int32]*SQLStats `protobuf:"bytes,1,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// table -> where it was referenced for the first time
+	Origins map[string]*SQLTableOrigin `protobuf:"bytes,2,rep,name=origins" json:"origins,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *SQLResults) Reset()                    { *m = SQLResults{} }
+func (m *SQLResults) String() string            { return proto.CompactTextString(m) }
+func (*SQLResults) ProtoMessage()               {}
+func (*SQLResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{40} }
+
+func (m *SQLResults) GetDays() map[int32]*SQLStats {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *SQLResults) GetOrigins() map[string]*SQLTableOrigin {
+	if m != nil {
+		return m.Origins
+	}
+	return nil
+}
+
+type ErrorHandlingStats struct {
+	// number of panic() calls
+	Panics int32 `protobuf:"varint,1,opt,name=panics,proto3" json:"panics,omitempty"`
+	// number of log.Fatal*() calls
+	Fatals int32 `protobuf:"varint,2,opt,name=fatals,proto3" json:"fatals,omitempty"`
+	// number of return statements which return errors
+	Returns int32 `protobuf:"varint,3,opt,name=returns,proto3" json:"returns,omitempty"`
+	// number of call results assigned to "_"
+	Ignored int32 `protobuf:"varint,4,opt,name=ignored,proto3" json:"ignored,omitempty"`
+}
+
+func (m *ErrorHandlingStats) Reset()                    { *m = ErrorHandlingStats{} }
+func (m *ErrorHandlingStats) String() string            { return proto.CompactTextString(m) }
+func (*ErrorHandlingStats) ProtoMessage()               {}
+func (*ErrorHandlingStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{41} }
+
+func (m *ErrorHandlingStats) GetPanics() int32 {
+	if m != nil {
+		return m.Panics
+	}
+	return 0
+}
+
+func (m *ErrorHandlingStats) GetFatals() int32 {
+	if m != nil {
+		return m.Fatals
+	}
+	return 0
+}
+
+func (m *ErrorHandlingStats) GetReturns() int32 {
+	if m != nil {
+		return m.Returns
+	}
+	return 0
+}
+
+func (m *ErrorHandlingStats) GetIgnored() int32 {
+	if m != nil {
+		return m.Ignored
+	}
+	return 0
+}
+
+type ErrorHandlingResults struct {
+	// day -> repository-wide error handling statistics
+	Days map[int32]*ErrorHandlingStats `protobuf:"bytes,1,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *ErrorHandlingResults) Reset()                    { *m = ErrorHandlingResults{} }
+func (m *ErrorHandlingResults) String() string            { return proto.CompactTextString(m) }
+func (*ErrorHandlingResults) ProtoMessage()               {}
+func (*ErrorHandlingResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{42} }
+
+func (m *ErrorHandlingResults) GetDays() map[int32]*ErrorHandlingStats {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+type TestCoChange struct {
+	// number of changed production files which have tests
+	Changed int32 `protobuf:"varint,1,opt,name=changed,proto3" json:"changed,omitempty"`
+	// number of those changes which updated the tests in the same commit
+	WithTests int32 `protobuf:"varint,2,opt,name=with_tests,json=withTests,proto3" json:"with_tests,omitempty"`
+}
+
+func (m *TestCoChange) Reset()                    { *m = TestCoChange{} }
+func (m *TestCoChange) String() string            { return proto.CompactTextString(m) }
+func (*TestCoChange) ProtoMessage()               {}
+func (*TestCoChange) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{43} }
+
+func (m *TestCoChange) GetChanged() int32 {
+	if m != nil {
+		return m.Changed
+	}
+	return 0
+}
+
+func (m *TestCoChange) GetWithTests() int32 {
+	if m != nil {
+		return m.WithTests
+	}
+	return 0
+}
+
+type DirectoryTestCoChanges struct {
+	Directories map[string]*TestCoChange `protobuf:"bytes,1,rep,name=directories" json:"directories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *DirectoryTestCoChanges) Reset()                    { *m = DirectoryTestCoChanges{} }
+func (m *DirectoryTestCoChanges) String() string            { return proto.CompactTextString(m) }
+func (*DirectoryTestCoChanges) ProtoMessage()               {}
+func (*DirectoryTestCoChanges) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{44} }
+
+func (m *DirectoryTestCoChanges) GetDirectories() map[string]*TestCoChange {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+type TestCouplingResults struct {
+	// day -> directory -> co-change statistics
+	Days map[int32]*DirectoryTestCoChanges `protobuf:"bytes,1,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *TestCouplingResults) Reset()                    { *m = TestCouplingResults{} }
+func (m *TestCouplingResults) String() string            { return proto.CompactTextString(m) }
+func (*TestCouplingResults) ProtoMessage()               {}
+func (*TestCouplingResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{45} }
+
+func (m *TestCouplingResults) GetDays() map[int32]*DirectoryTestCoChanges {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+type TimeSkewStats struct {
+	Commits int32 `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
+	// number of commits with the skew greater than the threshold
+	Skewed int32 `protobuf:"varint,2,opt,name=skewed,proto3" json:"skewed,omitempty"`
+	// sum of the skews in seconds
+	Total int64 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	// maximum skew in seconds
+	Max int64 `protobuf:"varint,4,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (m *TimeSkewStats) Reset()                    { *m = TimeSkewStats{} }
+func (m *TimeSkewStats) String() string            { return proto.CompactTextString(m) }
+func (*TimeSkewStats) ProtoMessage()               {}
+func (*TimeSkewStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{46} }
+
+func (m *TimeSkewStats) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *TimeSkewStats) GetSkewed() int32 {
+	if m != nil {
+		return m.Skewed
+	}
+	return 0
+}
+
+func (m *TimeSkewStats) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *TimeSkewStats) GetMax() int64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+type TimeSkewResults struct {
+	// in seconds
+	Threshold int64                    `protobuf:"varint,1,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Days      map[int32]*TimeSkewStats `protobuf:"bytes,2,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// developer index -> skew statistics, the last element is the unmatched authors
+	People []*TimeSkewStats `protobuf:"bytes,3,rep,name=people" json:"people,omitempty"`
+	// developer names
+	PeopleSequence []string `protobuf:"bytes,4,rep,name=people_sequence,json=peopleSequence" json:"people_sequence,omitempty"`
+}
+
+func (m *TimeSkewResults) Reset()                    { *m = TimeSkewResults{} }
+func (m *TimeSkewResults) String() string            { return proto.CompactTextString(m) }
+func (*TimeSkewResults) ProtoMessage()               {}
+func (*TimeSkewResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{47} }
+
+func (m *TimeSkewResults) GetThreshold() int64 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *TimeSkewResults) GetDays() map[int32]*TimeSkewStats {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *TimeSkewResults) GetPeople() []*TimeSkewStats {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *TimeSkewResults) GetPeopleSequence() []string {
+	if m != nil {
+		return m.PeopleSequence
+	}
+	return nil
+}
+
+type CherryPick struct {
+	Original       string `protobuf:"bytes,1,opt,name=original,proto3" json:"original,omitempty"`
+	OriginalBranch string `protobuf:"bytes,2,opt,name=original_branch,json=originalBranch,proto3" json:"original_branch,omitempty"`
+	// Unix timestamp
+	OriginalTime int64  `protobuf:"varint,3,opt,name=original_time,json=originalTime,proto3" json:"original_time,omitempty"`
+	Copy         string `protobuf:"bytes,4,opt,name=copy,proto3" json:"copy,omitempty"`
+	CopyBranch   string `protobuf:"bytes,5,opt,name=copy_branch,json=copyBranch,proto3" json:"copy_branch,omitempty"`
+	// Unix timestamp
+	CopyTime int64 `protobuf:"varint,6,opt,name=copy_time,json=copyTime,proto3" json:"copy_time,omitempty"`
+}
+
+func (m *CherryPick) Reset()                    { *m = CherryPick{} }
+func (m *CherryPick) String() string            { return proto.CompactTextString(m) }
+func (*CherryPick) ProtoMessage()               {}
+func (*CherryPick) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{48} }
+
+func (m *CherryPick) GetOriginal() string {
+	if m != nil {
+		return m.Original
+	}
+	return ""
+}
+
+func (m *CherryPick) GetOriginalBranch() string {
+	if m != nil {
+		return m.OriginalBranch
+	}
+	return ""
+}
+
+func (m *CherryPick) GetOriginalTime() int64 {
+	if m != nil {
+		return m.OriginalTime
+	}
+	return 0
+}
+
+func (m *CherryPick) GetCopy() string {
+	if m != nil {
+		return m.Copy
+	}
+	return ""
+}
+
+func (m *CherryPick) GetCopyBranch() string {
+	if m != nil {
+		return m.CopyBranch
+	}
+	return ""
+}
+
+func (m *CherryPick) GetCopyTime() int64 {
+	if m != nil {
+		return m.CopyTime
+	}
+	return 0
+}
+
+type BackportStats struct {
+	// hashes of the main branch commits
+	Fixes []string `protobuf:"bytes,1,rep,name=fixes" json:"fixes,omitempty"`
+	// in seconds
+	MedianLatency int64 `protobuf:"varint,2,opt,name=median_latency,json=medianLatency,proto3" json:"median_latency,omitempty"`
+}
+
+func (m *BackportStats) Reset()                    { *m = BackportStats{} }
+func (m *BackportStats) String() string            { return proto.CompactTextString(m) }
+func (*BackportStats) ProtoMessage()               {}
+func (*BackportStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{49} }
+
+func (m *BackportStats) GetFixes() []string {
+	if m != nil {
+		return m.Fixes
+	}
+	return nil
+}
+
+func (m *BackportStats) GetMedianLatency() int64 {
+	if m != nil {
+		return m.MedianLatency
+	}
+	return 0
+}
+
+type CherryPicksResults struct {
+	Commits    int32         `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
+	Duplicated int32         `protobuf:"varint,2,opt,name=duplicated,proto3" json:"duplicated,omitempty"`
+	Pairs      []*CherryPick `protobuf:"bytes,3,rep,name=pairs" json:"pairs,omitempty"`
+	// release branch name -> backported fixes
+	Backports map[string]*BackportStats `protobuf:"bytes,4,rep,name=backports" json:"backports,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *CherryPicksResults) Reset()                    { *m = CherryPicksResults{} }
+func (m *CherryPicksResults) String() string            { return proto.CompactTextString(m) }
+func (*CherryPicksResults) ProtoMessage()               {}
+func (*CherryPicksResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{50} }
+
+func (m *CherryPicksResults) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *CherryPicksResults) GetDuplicated() int32 {
+	if m != nil {
+		return m.Duplicated
+	}
+	return 0
+}
+
+func (m *CherryPicksResults) GetPairs() []*CherryPick {
+	if m != nil {
+		return m.Pairs
+	}
+	return nil
+}
+
+func (m *CherryPicksResults) GetBackports() map[string]*BackportStats {
+	if m != nil {
+		return m.Backports
+	}
+	return nil
+}
+
+type LineStats struct {
+	Added   int32 `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	Removed int32 `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"`
+	Changed int32 `protobuf:"varint,3,opt,name=changed,proto3" json:"changed,omitempty"`
+}
+
+func (m *LineStats) Reset()                    { *m = LineStats{} }
+func (m *LineStats) String() string            { return proto.CompactTextString(m) }
+func (*LineStats) ProtoMessage()               {}
+func (*LineStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{51} }
+
+func (m *LineStats) GetAdded() int32 {
+	if m != nil {
+		return m.Added
+	}
+	return 0
+}
+
+func (m *LineStats) GetRemoved() int32 {
+	if m != nil {
+		return m.Removed
+	}
+	return 0
+}
+
+func (m *LineStats) GetChanged() int32 {
+	if m != nil {
+		return m.Changed
+	}
+	return 0
+}
+
+type DevDay struct {
+	Commits int32      `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
+	Stats   *LineStats `protobuf:"bytes,2,opt,name=stats" json:"stats,omitempty"`
+	// number of changed files summed over the commits
+	Files int32 `protobuf:"varint,3,opt,name=files,proto3" json:"files,omitempty"`
+	// language -> line stats
+	Languages map[string]*LineStats `protobuf:"bytes,4,rep,name=languages" json:"languages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *DevDay) Reset()                    { *m = DevDay{} }
+func (m *DevDay) String() string            { return proto.CompactTextString(m) }
+func (*DevDay) ProtoMessage()               {}
+func (*DevDay) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{52} }
+
+func (m *DevDay) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *DevDay) GetStats() *LineStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+func (m *DevDay) GetFiles() int32 {
+	if m != nil {
+		return m.Files
+	}
+	return 0
+}
+
+func (m *DevDay) GetLanguages() map[string]*LineStats {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+type DayDevs struct {
+	// developer index -> stats, the index len(dev_index) is the unmatched authors
+	Devs map[int32]*DevDay `protobuf:"bytes,1,rep,name=devs" json:"devs,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *DayDevs) Reset()                    { *m = DayDevs{} }
+func (m *DayDevs) String() string            { return proto.CompactTextString(m) }
+func (*DayDevs) ProtoMessage()               {}
+func (*DayDevs) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{53} }
+
+func (m *DayDevs) GetDevs() map[int32]*DevDay {
+	if m != nil {
+		return m.Devs
+	}
+	return nil
+}
+
+type DevsAnalysisResults struct {
+	Days map[int32]*DayDevs `protobuf:"bytes,1,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// developer names
+	DevIndex []string `protobuf:"bytes,2,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *DevsAnalysisResults) Reset()                    { *m = DevsAnalysisResults{} }
+func (m *DevsAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*DevsAnalysisResults) ProtoMessage()               {}
+func (*DevsAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{54} }
+
+func (m *DevsAnalysisResults) GetDays() map[int32]*DayDevs {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *DevsAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type TypoFix struct {
+	Wrong   string `protobuf:"bytes,1,opt,name=wrong,proto3" json:"wrong,omitempty"`
+	Correct string `protobuf:"bytes,2,opt,name=correct,proto3" json:"correct,omitempty"`
+	Commit  string `protobuf:"bytes,3,opt,name=commit,proto3" json:"commit,omitempty"`
+	File    string `protobuf:"bytes,4,opt,name=file,proto3" json:"file,omitempty"`
+	// zero-based line number in the new version of the file
+	Line   int32  `protobuf:"varint,5,opt,name=line,proto3" json:"line,omitempty"`
+	Before string `protobuf:"bytes,6,opt,name=before,proto3" json:"before,omitempty"`
+	After  string `protobuf:"bytes,7,opt,name=after,proto3" json:"after,omitempty"`
+}
+
+func (m *TypoFix) Reset()                    { *m = TypoFix{} }
+func (m *TypoFix) String() string            { return proto.CompactTextString(m) }
+func (*TypoFix) ProtoMessage()               {}
+func (*TypoFix) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{55} }
+
+func (m *TypoFix) GetWrong() string {
+	if m != nil {
+		return m.Wrong
+	}
+	return ""
+}
+
+func (m *TypoFix) GetCorrect() string {
+	if m != nil {
+		return m.Correct
+	}
+	return ""
+}
+
+func (m *TypoFix) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *TypoFix) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *TypoFix) GetLine() int32 {
+	if m != nil {
+		return m.Line
+	}
+	return 0
+}
+
+func (m *TypoFix) GetBefore() string {
+	if m != nil {
+		return m.Before
+	}
+	return ""
+}
+
+func (m *TypoFix) GetAfter() string {
+	if m != nil {
+		return m.After
+	}
+	return ""
+}
+
+type TypoFixesResults struct {
+	Fixes []*TypoFix `protobuf:"bytes,1,rep,name=fixes" json:"fixes,omitempty"`
+}
+
+func (m *TypoFixesResults) Reset()                    { *m = TypoFixesResults{} }
+func (m *TypoFixesResults) String() string            { return proto.CompactTextString(m) }
+func (*TypoFixesResults) ProtoMessage()               {}
+func (*TypoFixesResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{56} }
+
+func (m *TypoFixesResults) GetFixes() []*TypoFix {
+	if m != nil {
+		return m.Fixes
+	}
+	return nil
+}
+
+type CommentRatioStats struct {
+	// number of lines with comments
+	Comments int32 `protobuf:"varint,1,opt,name=comments,proto3" json:"comments,omitempty"`
+	// number of lines with code
+	Code int32 `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (m *CommentRatioStats) Reset()                    { *m = CommentRatioStats{} }
+func (m *CommentRatioStats) String() string            { return proto.CompactTextString(m) }
+func (*CommentRatioStats) ProtoMessage()               {}
+func (*CommentRatioStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{57} }
+
+func (m *CommentRatioStats) GetComments() int32 {
+	if m != nil {
+		return m.Comments
+	}
+	return 0
+}
+
+func (m *CommentRatioStats) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+type LanguageCommentRatios struct {
+	Languages map[string]*CommentRatioStats `protobuf:"bytes,1,rep,name=languages" json:"languages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *LanguageCommentRatios) Reset()                    { *m = LanguageCommentRatios{} }
+func (m *LanguageCommentRatios) String() string            { return proto.CompactTextString(m) }
+func (*LanguageCommentRatios) ProtoMessage()               {}
+func (*LanguageCommentRatios) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{58} }
+
+func (m *LanguageCommentRatios) GetLanguages() map[string]*CommentRatioStats {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+type CommentRatioResults struct {
+	// day -> language -> line counts
+	Days map[int32]*LanguageCommentRatios `protobuf:"bytes,1,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *CommentRatioResults) Reset()                    { *m = CommentRatioResults{} }
+func (m *CommentRatioResults) String() string            { return proto.CompactTextString(m) }
+func (*CommentRatioResults) ProtoMessage()               {}
+func (*CommentRatioResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{59} }
+
+func (m *CommentRatioResults) GetDays() map[int32]*LanguageCommentRatios {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+type CommitMessageStats struct {
+	Commits int32 `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
+	// average score from 0 to 1
+	Score float32 `protobuf:"fixed32,2,opt,name=score,proto3" json:"score,omitempty"`
+	// number of commits with a subject of the right length
+	GoodSubjects int32 `protobuf:"varint,3,opt,name=good_subjects,json=goodSubjects,proto3" json:"good_subjects,omitempty"`
+	// number of commits with a body
+	Bodies int32 `protobuf:"varint,4,opt,name=bodies,proto3" json:"bodies,omitempty"`
+	// number of commits with the subject in the imperative mood
+	Imperative int32 `protobuf:"varint,5,opt,name=imperative,proto3" json:"imperative,omitempty"`
+	// number of commits which reference an issue
+	IssueRefs int32 `protobuf:"varint,6,opt,name=issue_refs,json=issueRefs,proto3" json:"issue_refs,omitempty"`
+}
+
+func (m *CommitMessageStats) Reset()                    { *m = CommitMessageStats{} }
+func (m *CommitMessageStats) String() string            { return proto.CompactTextString(m) }
+func (*CommitMessageStats) ProtoMessage()               {}
+func (*CommitMessageStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{60} }
+
+func (m *CommitMessageStats) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *CommitMessageStats) GetScore() float32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *CommitMessageStats) GetGoodSubjects() int32 {
+	if m != nil {
+		return m.GoodSubjects
+	}
+	return 0
+}
+
+func (m *CommitMessageStats) GetBodies() int32 {
+	if m != nil {
+		return m.Bodies
+	}
+	return 0
+}
+
+func (m *CommitMessageStats) GetImperative() int32 {
+	if m != nil {
+		return m.Imperative
+	}
+	return 0
+}
+
+func (m *CommitMessageStats) GetIssueRefs() int32 {
+	if m != nil {
+		return m.IssueRefs
+	}
+	return 0
+}
+
+type CommitMessagesResults struct {
+	// quarter ("2018Q3") -> stats
+	Quarters map[string]*CommitMessageStats `protobuf:"bytes,1,rep,name=quarters" json:"quarters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// developer index -> stats, the last element is the unmatched authors
+	People []*CommitMessageStats `protobuf:"bytes,2,rep,name=people" json:"people,omitempty"`
+	// developer names
+	PeopleSequence []string `protobuf:"bytes,3,rep,name=people_sequence,json=peopleSequence" json:"people_sequence,omitempty"`
+}
+
+func (m *CommitMessagesResults) Reset()                    { *m = CommitMessagesResults{} }
+func (m *CommitMessagesResults) String() string            { return proto.CompactTextString(m) }
+func (*CommitMessagesResults) ProtoMessage()               {}
+func (*CommitMessagesResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{61} }
+
+func (m *CommitMessagesResults) GetQuarters() map[string]*CommitMessageStats {
+	if m != nil {
+		return m.Quarters
+	}
+	return nil
+}
+
+func (m *CommitMessagesResults) GetPeople() []*CommitMessageStats {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *CommitMessagesResults) GetPeopleSequence() []string {
+	if m != nil {
+		return m.PeopleSequence
+	}
+	return nil
+}
+
+type ChurnOriginStats struct {
+	// number of deleted lines which were written by the same developer
+	Self int32 `protobuf:"varint,1,opt,name=self,proto3" json:"self,omitempty"`
+	// number of deleted lines which were written by other developers
+	Foreign int32 `protobuf:"varint,2,opt,name=foreign,proto3" json:"foreign,omitempty"`
+}
+
+func (m *ChurnOriginStats) Reset()                    { *m = ChurnOriginStats{} }
+func (m *ChurnOriginStats) String() string            { return proto.CompactTextString(m) }
+func (*ChurnOriginStats) ProtoMessage()               {}
+func (*ChurnOriginStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{62} }
+
+func (m *ChurnOriginStats) GetSelf() int32 {
+	if m != nil {
+		return m.Self
+	}
+	return 0
+}
+
+func (m *ChurnOriginStats) GetForeign() int32 {
+	if m != nil {
+		return m.Foreign
+	}
+	return 0
+}
+
+type DeveloperChurnOrigin struct {
+	// month ("2018-03") -> stats
+	Months map[string]*ChurnOriginStats `protobuf:"bytes,1,rep,name=months" json:"months,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *DeveloperChurnOrigin) Reset()                    { *m = DeveloperChurnOrigin{} }
+func (m *DeveloperChurnOrigin) String() string            { return proto.CompactTextString(m) }
+func (*DeveloperChurnOrigin) ProtoMessage()               {}
+func (*DeveloperChurnOrigin) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{63} }
+
+func (m *DeveloperChurnOrigin) GetMonths() map[string]*ChurnOriginStats {
+	if m != nil {
+		return m.Months
+	}
+	return nil
+}
+
+type ChurnOriginResults struct {
+	// developer index -> stats, the last element is the unmatched authors
+	People []*DeveloperChurnOrigin `protobuf:"bytes,1,rep,name=people" json:"people,omitempty"`
+	// developer names
+	PeopleSequence []string `protobuf:"bytes,2,rep,name=people_sequence,json=peopleSequence" json:"people_sequence,omitempty"`
+}
+
+func (m *ChurnOriginResults) Reset()                    { *m = ChurnOriginResults{} }
+func (m *ChurnOriginResults) String() string            { return proto.CompactTextString(m) }
+func (*ChurnOriginResults) ProtoMessage()               {}
+func (*ChurnOriginResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{64} }
+
+func (m *ChurnOriginResults) GetPeople() []*DeveloperChurnOrigin {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *ChurnOriginResults) GetPeopleSequence() []string {
+	if m != nil {
+		return m.PeopleSequence
+	}
+	return nil
+}
+
+type FileLifecycleCounts struct {
+	Active     int32 `protobuf:"varint,1,opt,name=active,proto3" json:"active,omitempty"`
+	Maintained int32 `protobuf:"varint,2,opt,name=maintained,proto3" json:"maintained,omitempty"`
+	Frozen     int32 `protobuf:"varint,3,opt,name=frozen,proto3" json:"frozen,omitempty"`
+	Abandoned  int32 `protobuf:"varint,4,opt,name=abandoned,proto3" json:"abandoned,omitempty"`
+}
+
+func (m *FileLifecycleCounts) Reset()                    { *m = FileLifecycleCounts{} }
+func (m *FileLifecycleCounts) String() string            { return proto.CompactTextString(m) }
+func (*FileLifecycleCounts) ProtoMessage()               {}
+func (*FileLifecycleCounts) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{65} }
+
+func (m *FileLifecycleCounts) GetActive() int32 {
+	if m != nil {
+		return m.Active
+	}
+	return 0
+}
+
+func (m *FileLifecycleCounts) GetMaintained() int32 {
+	if m != nil {
+		return m.Maintained
+	}
+	return 0
+}
+
+func (m *FileLifecycleCounts) GetFrozen() int32 {
+	if m != nil {
+		return m.Frozen
+	}
+	return 0
+}
+
+func (m *FileLifecycleCounts) GetAbandoned() int32 {
+	if m != nil {
+		return m.Abandoned
+	}
+	return 0
+}
+
+type DirectoryLifecycles struct {
+	// directory -> number of files in each state, "/" is the repository root
+	Directories map[string]*FileLifecycleCounts `protobuf:"bytes,1,rep,name=directories" json:"directories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *DirectoryLifecycles) Reset()                    { *m = DirectoryLifecycles{} }
+func (m *DirectoryLifecycles) String() string            { return proto.CompactTextString(m) }
+func (*DirectoryLifecycles) ProtoMessage()               {}
+func (*DirectoryLifecycles) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{66} }
+
+func (m *DirectoryLifecycles) GetDirectories() map[string]*FileLifecycleCounts {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+type FileLifecycleResults struct {
+	// file name -> "active", "maintained", "frozen" or "abandoned"
+	Files map[string]string `protobuf:"bytes,1,rep,name=files" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// day index -> stats
+	Days map[int32]*DirectoryLifecycles `protobuf:"bytes,2,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *FileLifecycleResults) Reset()                    { *m = FileLifecycleResults{} }
+func (m *FileLifecycleResults) String() string            { return proto.CompactTextString(m) }
+func (*FileLifecycleResults) ProtoMessage()               {}
+func (*FileLifecycleResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{67} }
+
+func (m *FileLifecycleResults) GetFiles() map[string]string {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *FileLifecycleResults) GetDays() map[int32]*DirectoryLifecycles {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+type ReleasePressureStats struct {
+	Commits int32      `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
+	Lines   *LineStats `protobuf:"bytes,2,opt,name=lines" json:"lines,omitempty"`
+	// number of changed files summed over the commits
+	Files int32 `protobuf:"varint,3,opt,name=files,proto3" json:"files,omitempty"`
+	// number of commits which revert other commits
+	Reverts int32 `protobuf:"varint,4,opt,name=reverts,proto3" json:"reverts,omitempty"`
+}
+
+func (m *ReleasePressureStats) Reset()                    { *m = ReleasePressureStats{} }
+func (m *ReleasePressureStats) String() string            { return proto.CompactTextString(m) }
+func (*ReleasePressureStats) ProtoMessage()               {}
+func (*ReleasePressureStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{68} }
+
+func (m *ReleasePressureStats) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *ReleasePressureStats) GetLines() *LineStats {
+	if m != nil {
+		return m.Lines
+	}
+	return nil
+}
+
+func (m *ReleasePressureStats) GetFiles() int32 {
+	if m != nil {
+		return m.Files
+	}
+	return 0
+}
+
+func (m *ReleasePressureStats) GetReverts() int32 {
+	if m != nil {
+		return m.Reverts
+	}
+	return 0
+}
+
+type ReleasePressureResults struct {
+	// number of releases (tags)
+	Releases int32 `protobuf:"varint,1,opt,name=releases,proto3" json:"releases,omitempty"`
+	// days before the release -> stats; the first element is the release day
+	Days []*ReleasePressureStats `protobuf:"bytes,2,rep,name=days" json:"days,omitempty"`
+	// stats of the commits outside of the release windows
+	Baseline *ReleasePressureStats `protobuf:"bytes,3,opt,name=baseline" json:"baseline,omitempty"`
+}
+
+func (m *ReleasePressureResults) Reset()                    { *m = ReleasePressureResults{} }
+func (m *ReleasePressureResults) String() string            { return proto.CompactTextString(m) }
+func (*ReleasePressureResults) ProtoMessage()               {}
+func (*ReleasePressureResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{69} }
+
+func (m *ReleasePressureResults) GetReleases() int32 {
+	if m != nil {
+		return m.Releases
+	}
+	return 0
+}
+
+func (m *ReleasePressureResults) GetDays() []*ReleasePressureStats {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *ReleasePressureResults) GetBaseline() *ReleasePressureStats {
+	if m != nil {
+		return m.Baseline
+	}
+	return nil
+}
+
+type CompanyAttributionStats struct {
+	// number of commits
+	Commits int32 `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
+	// number of lines owned at the end of the quarter
+	Lines int32 `protobuf:"varint,2,opt,name=lines,proto3" json:"lines,omitempty"`
+}
+
+func (m *CompanyAttributionStats) Reset()                    { *m = CompanyAttributionStats{} }
+func (m *CompanyAttributionStats) String() string            { return proto.CompactTextString(m) }
+func (*CompanyAttributionStats) ProtoMessage()               {}
+func (*CompanyAttributionStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{70} }
+
+func (m *CompanyAttributionStats) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *CompanyAttributionStats) GetLines() int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+type CompanyQuarter struct {
+	// company -> stats
+	Companies map[string]*CompanyAttributionStats `protobuf:"bytes,1,rep,name=companies" json:"companies,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *CompanyQuarter) Reset()                    { *m = CompanyQuarter{} }
+func (m *CompanyQuarter) String() string            { return proto.CompactTextString(m) }
+func (*CompanyQuarter) ProtoMessage()               {}
+func (*CompanyQuarter) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{71} }
+
+func (m *CompanyQuarter) GetCompanies() map[string]*CompanyAttributionStats {
+	if m != nil {
+		return m.Companies
+	}
+	return nil
+}
+
+type CompanyAttributionResults struct {
+	// quarter ("2018Q1") -> stats
+	Quarters map[string]*CompanyQuarter `protobuf:"bytes,1,rep,name=quarters" json:"quarters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *CompanyAttributionResults) Reset()                    { *m = CompanyAttributionResults{} }
+func (m *CompanyAttributionResults) String() string            { return proto.CompactTextString(m) }
+func (*CompanyAttributionResults) ProtoMessage()               {}
+func (*CompanyAttributionResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{72} }
+
+func (m *CompanyAttributionResults) GetQuarters() map[string]*CompanyQuarter {
+	if m != nil {
+		return m.Quarters
+	}
+	return nil
+}
+
+type BinaryChurnStats struct {
+	// number of added, modified and deleted binary files
+	Changes int32 `protobuf:"varint,1,opt,name=changes,proto3" json:"changes,omitempty"`
+	// total size of the added binary blobs
+	AddedBytes int64 `protobuf:"varint,2,opt,name=added_bytes,json=addedBytes,proto3" json:"added_bytes,omitempty"`
+	// total size of the removed binary blobs
+	RemovedBytes int64 `protobuf:"varint,3,opt,name=removed_bytes,json=removedBytes,proto3" json:"removed_bytes,omitempty"`
+}
+
+func (m *BinaryChurnStats) Reset()                    { *m = BinaryChurnStats{} }
+func (m *BinaryChurnStats) String() string            { return proto.CompactTextString(m) }
+func (*BinaryChurnStats) ProtoMessage()               {}
+func (*BinaryChurnStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{73} }
+
+func (m *BinaryChurnStats) GetChanges() int32 {
+	if m != nil {
+		return m.Changes
+	}
+	return 0
+}
+
+func (m *BinaryChurnStats) GetAddedBytes() int64 {
+	if m != nil {
+		return m.AddedBytes
+	}
+	return 0
+}
+
+func (m *BinaryChurnStats) GetRemovedBytes() int64 {
+	if m != nil {
+		return m.RemovedBytes
+	}
+	return 0
+}
+
+type BinaryChurnDirectories struct {
+	// directory -> stats, the root is "/"
+	Directories map[string]*BinaryChurnStats `protobuf:"bytes,1,rep,name=directories" json:"directories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *BinaryChurnDirectories) Reset()                    { *m = BinaryChurnDirectories{} }
+func (m *BinaryChurnDirectories) String() string            { return proto.CompactTextString(m) }
+func (*BinaryChurnDirectories) ProtoMessage()               {}
+func (*BinaryChurnDirectories) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{74} }
+
+func (m *BinaryChurnDirectories) GetDirectories() map[string]*BinaryChurnStats {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+type BinaryAssets struct {
+	Files int32 `protobuf:"varint,1,opt,name=files,proto3" json:"files,omitempty"`
+	Bytes int64 `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+}
+
+func (m *BinaryAssets) Reset()                    { *m = BinaryAssets{} }
+func (m *BinaryAssets) String() string            { return proto.CompactTextString(m) }
+func (*BinaryAssets) ProtoMessage()               {}
+func (*BinaryAssets) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{75} }
+
+func (m *BinaryAssets) GetFiles() int32 {
+	if m != nil {
+		return m.Files
+	}
+	return 0
+}
+
+func (m *BinaryAssets) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+type BinaryChurnResults struct {
+	// month ("2018-03") -> stats
+	Months map[string]*BinaryChurnDirectories `protobuf:"bytes,1,rep,name=months" json:"months,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// directory -> binary files in the last analysed revision
+	Current map[string]*BinaryAssets `protobuf:"bytes,2,rep,name=current" json:"current,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *BinaryChurnResults) Reset()                    { *m = BinaryChurnResults{} }
+func (m *BinaryChurnResults) String() string            { return proto.CompactTextString(m) }
+func (*BinaryChurnResults) ProtoMessage()               {}
+func (*BinaryChurnResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{76} }
+
+func (m *BinaryChurnResults) GetMonths() map[string]*BinaryChurnDirectories {
+	if m != nil {
+		return m.Months
+	}
+	return nil
+}
+
+func (m *BinaryChurnResults) GetCurrent() map[string]*BinaryAssets {
+	if m != nil {
+		return m.Current
+	}
+	return nil
+}
+
+type RepositorySizeStats struct {
+	// number of files in the tree
+	Files int32 `protobuf:"varint,1,opt,name=files,proto3" json:"files,omitempty"`
+	// total size of the files in the tree
+	Bytes int64 `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	// total number of lines in the text files in the tree
+	Lines int64 `protobuf:"varint,3,opt,name=lines,proto3" json:"lines,omitempty"`
+	// total size of all the unique blobs so far, the uncompressed packfile estimate
+	History int64 `protobuf:"varint,4,opt,name=history,proto3" json:"history,omitempty"`
+}
+
+func (m *RepositorySizeStats) Reset()                    { *m = RepositorySizeStats{} }
+func (m *RepositorySizeStats) String() string            { return proto.CompactTextString(m) }
+func (*RepositorySizeStats) ProtoMessage()               {}
+func (*RepositorySizeStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{77} }
+
+func (m *RepositorySizeStats) GetFiles() int32 {
+	if m != nil {
+		return m.Files
+	}
+	return 0
+}
+
+func (m *RepositorySizeStats) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *RepositorySizeStats) GetLines() int64 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *RepositorySizeStats) GetHistory() int64 {
+	if m != nil {
+		return m.History
+	}
+	return 0
+}
+
+type RepositorySizeCommit struct {
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// total size of the blobs introduced by the commit
+	Growth int64 `protobuf:"varint,2,opt,name=growth,proto3" json:"growth,omitempty"`
+}
+
+func (m *RepositorySizeCommit) Reset()                    { *m = RepositorySizeCommit{} }
+func (m *RepositorySizeCommit) String() string            { return proto.CompactTextString(m) }
+func (*RepositorySizeCommit) ProtoMessage()               {}
+func (*RepositorySizeCommit) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{78} }
+
+func (m *RepositorySizeCommit) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *RepositorySizeCommit) GetGrowth() int64 {
+	if m != nil {
+		return m.Growth
+	}
+	return 0
+}
+
+type RepositorySizeResults struct {
+	// day index -> stats at the end of the day
+	Days map[int32]*RepositorySizeStats `protobuf:"bytes,1,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// tag name -> stats at the tagged commit
+	Tags map[string]*RepositorySizeStats `protobuf:"bytes,2,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// the commits which introduced the biggest blobs, in descending order
+	TopCommits []*RepositorySizeCommit `protobuf:"bytes,3,rep,name=top_commits,json=topCommits" json:"top_commits,omitempty"`
+}
+
+func (m *RepositorySizeResults) Reset()                    { *m = RepositorySizeResults{} }
+func (m *RepositorySizeResults) String() string            { return proto.CompactTextString(m) }
+func (*RepositorySizeResults) ProtoMessage()               {}
+func (*RepositorySizeResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{79} }
+
+func (m *RepositorySizeResults) GetDays() map[int32]*RepositorySizeStats {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *RepositorySizeResults) GetTags() map[string]*RepositorySizeStats {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *RepositorySizeResults) GetTopCommits() []*RepositorySizeCommit {
+	if m != nil {
+		return m.TopCommits
+	}
+	return nil
+}
+
+type RefactoringStats struct {
+	ExtractedMethods int32 `protobuf:"varint,1,opt,name=extracted_methods,json=extractedMethods,proto3" json:"extracted_methods,omitempty"`
+	RenamedMethods   int32 `protobuf:"varint,2,opt,name=renamed_methods,json=renamedMethods,proto3" json:"renamed_methods,omitempty"`
+	RenamedClasses   int32 `protobuf:"varint,3,opt,name=renamed_classes,json=renamedClasses,proto3" json:"renamed_classes,omitempty"`
+	MovedClasses     int32 `protobuf:"varint,4,opt,name=moved_classes,json=movedClasses,proto3" json:"moved_classes,omitempty"`
+	// number of changed UAST nodes which belong to the refactorings
+	RefactoringNodes int32 `protobuf:"varint,5,opt,name=refactoring_nodes,json=refactoringNodes,proto3" json:"refactoring_nodes,omitempty"`
+	// total number of changed UAST nodes
+	ChangedNodes int32 `protobuf:"varint,6,opt,name=changed_nodes,json=changedNodes,proto3" json:"changed_nodes,omitempty"`
+	Commits      int32 `protobuf:"varint,7,opt,name=commits,proto3" json:"commits,omitempty"`
+	// number of commits with at least one refactoring
+	RefactoringCommits int32 `protobuf:"varint,8,opt,name=refactoring_commits,json=refactoringCommits,proto3" json:"refactoring_commits,omitempty"`
+}
+
+func (m *RefactoringStats) Reset()                    { *m = RefactoringStats{} }
+func (m *RefactoringStats) String() string            { return proto.CompactTextString(m) }
+func (*RefactoringStats) ProtoMessage()               {}
+func (*RefactoringStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{80} }
+
+func (m *RefactoringStats) GetExtractedMethods() int32 {
+	if m != nil {
+		return m.ExtractedMethods
+	}
+	return 0
+}
+
+func (m *RefactoringStats) GetRenamedMethods() int32 {
+	if m != nil {
+		return m.RenamedMethods
+	}
+	return 0
+}
+
+func (m *RefactoringStats) GetRenamedClasses() int32 {
+	if m != nil {
+		return m.RenamedClasses
+	}
+	return 0
+}
+
+func (m *RefactoringStats) GetMovedClasses() int32 {
+	if m != nil {
+		return m.MovedClasses
+	}
+	return 0
+}
+
+func (m *RefactoringStats) GetRefactoringNodes() int32 {
+	if m != nil {
+		return m.RefactoringNodes
+	}
+	return 0
+}
+
+func (m *RefactoringStats) GetChangedNodes() int32 {
+	if m != nil {
+		return m.ChangedNodes
+	}
+	return 0
+}
+
+func (m *RefactoringStats) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *RefactoringStats) GetRefactoringCommits() int32 {
+	if m != nil {
+		return m.RefactoringCommits
+	}
+	return 0
+}
+
+type RefactoringResults struct {
+	// day index -> stats
+	Days map[int32]*RefactoringStats `protobuf:"bytes,1,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// developer index -> stats, the last element is the unmatched authors
+	People []*RefactoringStats `protobuf:"bytes,2,rep,name=people" json:"people,omitempty"`
+	// developer names
+	PeopleSequence []string `protobuf:"bytes,3,rep,name=people_sequence,json=peopleSequence" json:"people_sequence,omitempty"`
+}
+
+func (m *RefactoringResults) Reset()                    { *m = RefactoringResults{} }
+func (m *RefactoringResults) String() string            { return proto.CompactTextString(m) }
+func (*RefactoringResults) ProtoMessage()               {}
+func (*RefactoringResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{81} }
+
+func (m *RefactoringResults) GetDays() map[int32]*RefactoringStats {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *RefactoringResults) GetPeople() []*RefactoringStats {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *RefactoringResults) GetPeopleSequence() []string {
+	if m != nil {
+		return m.PeopleSequence
+	}
+	return nil
+}
+
+type CoverageChurnStats struct {
+	// number of deleted or rewritten lines which the tests executed
+	Covered int32 `protobuf:"varint,1,opt,name=covered,proto3" json:"covered,omitempty"`
+	// number of deleted or rewritten lines which the tests did not execute
+	Uncovered int32 `protobuf:"varint,2,opt,name=uncovered,proto3" json:"uncovered,omitempty"`
+	// number of deleted or rewritten lines which the coverage report did not mention
+	Unmeasured int32 `protobuf:"varint,3,opt,name=unmeasured,proto3" json:"unmeasured,omitempty"`
+}
+
+func (m *CoverageChurnStats) Reset()                    { *m = CoverageChurnStats{} }
+func (m *CoverageChurnStats) String() string            { return proto.CompactTextString(m) }
+func (*CoverageChurnStats) ProtoMessage()               {}
+func (*CoverageChurnStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{82} }
+
+func (m *CoverageChurnStats) GetCovered() int32 {
+	if m != nil {
+		return m.Covered
+	}
+	return 0
+}
+
+func (m *CoverageChurnStats) GetUncovered() int32 {
+	if m != nil {
+		return m.Uncovered
+	}
+	return 0
+}
+
+func (m *CoverageChurnStats) GetUnmeasured() int32 {
+	if m != nil {
+		return m.Unmeasured
+	}
+	return 0
+}
+
+type CoverageChurnResults struct {
+	// day index -> stats
+	Days map[int32]*CoverageChurnStats `protobuf:"bytes,1,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// developer index -> stats, the last element is the unmatched authors
+	People []*CoverageChurnStats `protobuf:"bytes,2,rep,name=people" json:"people,omitempty"`
+	// developer names
+	PeopleSequence []string `protobuf:"bytes,3,rep,name=people_sequence,json=peopleSequence" json:"people_sequence,omitempty"`
+}
+
+func (m *CoverageChurnResults) Reset()                    { *m = CoverageChurnResults{} }
+func (m *CoverageChurnResults) String() string            { return proto.CompactTextString(m) }
+func (*CoverageChurnResults) ProtoMessage()               {}
+func (*CoverageChurnResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{83} }
+
+func (m *CoverageChurnResults) GetDays() map[int32]*CoverageChurnStats {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *CoverageChurnResults) GetPeople() []*CoverageChurnStats {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *CoverageChurnResults) GetPeopleSequence() []string {
+	if m != nil {
+		return m.PeopleSequence
+	}
+	return nil
+}
+
+type DefectsStats struct {
+	// number of fixed issues
+	Issues int32 `protobuf:"varint,1,opt,name=issues,proto3" json:"issues,omitempty"`
+	// number of commits which fixed the issues
+	FixCommits int32 `protobuf:"varint,2,opt,name=fix_commits,json=fixCommits,proto3" json:"fix_commits,omitempty"`
+	// number of lines in the component
+	Lines int32 `protobuf:"varint,3,opt,name=lines,proto3" json:"lines,omitempty"`
+	// mean lifetime of the fixed issues in days
+	MeanTimeToFix float32 `protobuf:"fixed32,4,opt,name=mean_time_to_fix,json=meanTimeToFix,proto3" json:"mean_time_to_fix,omitempty"`
+	// issue severity -> number of fixed issues
+	Severities map[string]int32 `protobuf:"bytes,5,rep,name=severities" json:"severities,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (m *DefectsStats) Reset()                    { *m = DefectsStats{} }
+func (m *DefectsStats) String() string            { return proto.CompactTextString(m) }
+func (*DefectsStats) ProtoMessage()               {}
+func (*DefectsStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{84} }
+
+func (m *DefectsStats) GetIssues() int32 {
+	if m != nil {
+		return m.Issues
+	}
+	return 0
+}
+
+func (m *DefectsStats) GetFixCommits() int32 {
+	if m != nil {
+		return m.FixCommits
+	}
+	return 0
+}
+
+func (m *DefectsStats) GetLines() int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *DefectsStats) GetMeanTimeToFix() float32 {
+	if m != nil {
+		return m.MeanTimeToFix
+	}
+	return 0
+}
+
+func (m *DefectsStats) GetSeverities() map[string]int32 {
+	if m != nil {
+		return m.Severities
+	}
+	return nil
+}
+
+type DefectsResults struct {
+	// component name -> stats
+	Components map[string]*DefectsStats `protobuf:"bytes,1,rep,name=components" json:"components,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// number of issues in the issue tracker export
+	Issues int32 `protobuf:"varint,2,opt,name=issues,proto3" json:"issues,omitempty"`
+	// number of issues which are referenced by commits
+	LinkedIssues int32 `protobuf:"varint,3,opt,name=linked_issues,json=linkedIssues,proto3" json:"linked_issues,omitempty"`
+}
+
+func (m *DefectsResults) Reset()                    { *m = DefectsResults{} }
+func (m *DefectsResults) String() string            { return proto.CompactTextString(m) }
+func (*DefectsResults) ProtoMessage()               {}
+func (*DefectsResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{85} }
+
+func (m *DefectsResults) GetComponents() map[string]*DefectsStats {
+	if m != nil {
+		return m.Components
+	}
+	return nil
+}
+
+func (m *DefectsResults) GetIssues() int32 {
+	if m != nil {
+		return m.Issues
+	}
+	return 0
+}
+
+func (m *DefectsResults) GetLinkedIssues() int32 {
+	if m != nil {
+		return m.LinkedIssues
+	}
+	return 0
+}
+
+type OwnershipConcentrationStats struct {
+	// number of lines in the directory
+	Lines int32 `protobuf:"varint,1,opt,name=lines,proto3" json:"lines,omitempty"`
+	// number of developers who own at least one line
+	Owners int32 `protobuf:"varint,2,opt,name=owners,proto3" json:"owners,omitempty"`
+	// Gini coefficient of the owned lines among the developers who committed so far
+	Gini float32 `protobuf:"fixed32,3,opt,name=gini,proto3" json:"gini,omitempty"`
+	// Shannon entropy of the line ownership in bits
+	Entropy float32 `protobuf:"fixed32,4,opt,name=entropy,proto3" json:"entropy,omitempty"`
+}
+
+func (m *OwnershipConcentrationStats) Reset()                    { *m = OwnershipConcentrationStats{} }
+func (m *OwnershipConcentrationStats) String() string            { return proto.CompactTextString(m) }
+func (*OwnershipConcentrationStats) ProtoMessage()               {}
+func (*OwnershipConcentrationStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{86} }
+
+func (m *OwnershipConcentrationStats) GetLines() int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *OwnershipConcentrationStats) GetOwners() int32 {
+	if m != nil {
+		return m.Owners
+	}
+	return 0
+}
+
+func (m *OwnershipConcentrationStats) GetGini() float32 {
+	if m != nil {
+		return m.Gini
+	}
+	return 0
+}
+
+func (m *OwnershipConcentrationStats) GetEntropy() float32 {
+	if m != nil {
+		return m.Entropy
+	}
+	return 0
+}
+
+type DirectoryOwnershipConcentration struct {
+	// directory -> stats, the root is "/"
+	Directories map[string]*OwnershipConcentrationStats `protobuf:"bytes,1,rep,name=directories" json:"directories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *DirectoryOwnershipConcentration) Reset()         { *m = DirectoryOwnershipConcentration{} }
+func (m *DirectoryOwnershipConcentration) String() string { return proto.CompactTextString(m) }
+func (*DirectoryOwnershipConcentration) ProtoMessage()    {}
+func (*DirectoryOwnershipConcentration) Descriptor() ([]byte, []int) {
+	return fileDescriptorPb, []int{87}
+}
+
+func (m *DirectoryOwnershipConcentration) GetDirectories() map[string]*OwnershipConcentrationStats {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+type OwnershipConcentrationResults struct {
+	// quarter ("2018Q1") -> stats at the end of the quarter
+	Quarters map[string]*DirectoryOwnershipConcentration `protobuf:"bytes,1,rep,name=quarters" json:"quarters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *OwnershipConcentrationResults) Reset()         { *m = OwnershipConcentrationResults{} }
+func (m *OwnershipConcentrationResults) String() string { return proto.CompactTextString(m) }
+func (*OwnershipConcentrationResults) ProtoMessage()    {}
+func (*OwnershipConcentrationResults) Descriptor() ([]byte, []int) {
+	return fileDescriptorPb, []int{88}
+}
+
+func (m *OwnershipConcentrationResults) GetQuarters() map[string]*DirectoryOwnershipConcentration {
+	if m != nil {
+		return m.Quarters
+	}
+	return nil
+}
+
+type EffortStats struct {
+	Added   int32 `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	Removed int32 `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"`
+	// number of added lines which are alive at the end of the analysed history
+	Surviving int32 `protobuf:"varint,3,opt,name=surviving,proto3" json:"surviving,omitempty"`
+	// estimated effort to write the added lines in person-months
+	Effort float32 `protobuf:"fixed32,4,opt,name=effort,proto3" json:"effort,omitempty"`
+	// estimated effort to write the surviving lines in person-months
+	SurvivingEffort float32 `protobuf:"fixed32,5,opt,name=surviving_effort,json=survivingEffort,proto3" json:"surviving_effort,omitempty"`
+}
+
+func (m *EffortStats) Reset()                    { *m = EffortStats{} }
+func (m *EffortStats) String() string            { return proto.CompactTextString(m) }
+func (*EffortStats) ProtoMessage()               {}
+func (*EffortStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{89} }
+
+func (m *EffortStats) GetAdded() int32 {
+	if m != nil {
+		return m.Added
+	}
+	return 0
+}
+
+func (m *EffortStats) GetRemoved() int32 {
+	if m != nil {
+		return m.Removed
+	}
+	return 0
+}
+
+func (m *EffortStats) GetSurviving() int32 {
+	if m != nil {
+		return m.Surviving
+	}
+	return 0
+}
+
+func (m *EffortStats) GetEffort() float32 {
+	if m != nil {
+		return m.Effort
+	}
+	return 0
+}
+
+func (m *EffortStats) GetSurvivingEffort() float32 {
+	if m != nil {
+		return m.SurvivingEffort
+	}
+	return 0
+}
+
+type ComponentEfforts struct {
+	// component -> stats, the root is "/"
+	Components map[string]*EffortStats `protobuf:"bytes,1,rep,name=components" json:"components,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *ComponentEfforts) Reset()                    { *m = ComponentEfforts{} }
+func (m *ComponentEfforts) String() string            { return proto.CompactTextString(m) }
+func (*ComponentEfforts) ProtoMessage()               {}
+func (*ComponentEfforts) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{90} }
+
+func (m *ComponentEfforts) GetComponents() map[string]*EffortStats {
+	if m != nil {
+		return m.Components
+	}
+	return nil
+}
+
+type EffortEstimationResults struct {
+	// the COCOMO model: effort = coefficient * KLOC ^ exponent
+	Coefficient float32 `protobuf:"fixed32,1,opt,name=coefficient,proto3" json:"coefficient,omitempty"`
+	Exponent    float32 `protobuf:"fixed32,2,opt,name=exponent,proto3" json:"exponent,omitempty"`
+	// "month", "quarter" or "year"
+	Period string `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	// period ("2018Q1", "2018-03" or "2018") -> stats
+	Periods map[string]*ComponentEfforts `protobuf:"bytes,4,rep,name=periods" json:"periods,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// component -> stats over the whole analysed history
+	Components map[string]*EffortStats `protobuf:"bytes,5,rep,name=components" json:"components,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// stats of the whole repository over the whole analysed history
+	Total *EffortStats `protobuf:"bytes,6,opt,name=total" json:"total,omitempty"`
+}
+
+func (m *EffortEstimationResults) Reset()                    { *m = EffortEstimationResults{} }
+func (m *EffortEstimationResults) String() string            { return proto.CompactTextString(m) }
+func (*EffortEstimationResults) ProtoMessage()               {}
+func (*EffortEstimationResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{91} }
+
+func (m *EffortEstimationResults) GetCoefficient() float32 {
+	if m != nil {
+		return m.Coefficient
+	}
+	return 0
+}
+
+func (m *EffortEstimationResults) GetExponent() float32 {
+	if m != nil {
+		return m.Exponent
+	}
+	return 0
+}
+
+func (m *EffortEstimationResults) GetPeriod() string {
+	if m != nil {
+		return m.Period
+	}
+	return ""
+}
+
+func (m *EffortEstimationResults) GetPeriods() map[string]*ComponentEfforts {
+	if m != nil {
+		return m.Periods
+	}
+	return nil
+}
+
+func (m *EffortEstimationResults) GetComponents() map[string]*EffortStats {
+	if m != nil {
+		return m.Components
+	}
+	return nil
+}
+
+func (m *EffortEstimationResults) GetTotal() *EffortStats {
+	if m != nil {
+		return m.Total
+	}
+	return nil
+}
+
+type CommentReadabilityStats struct {
+	// number of comments with words
+	Comments  int32 `protobuf:"varint,1,opt,name=comments,proto3" json:"comments,omitempty"`
+	Sentences int32 `protobuf:"varint,2,opt,name=sentences,proto3" json:"sentences,omitempty"`
+	Words     int32 `protobuf:"varint,3,opt,name=words,proto3" json:"words,omitempty"`
+	// estimated number of syllables in the words
+	Syllables int32 `protobuf:"varint,4,opt,name=syllables,proto3" json:"syllables,omitempty"`
+}
+
+func (m *CommentReadabilityStats) Reset()                    { *m = CommentReadabilityStats{} }
+func (m *CommentReadabilityStats) String() string            { return proto.CompactTextString(m) }
+func (*CommentReadabilityStats) ProtoMessage()               {}
+func (*CommentReadabilityStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{92} }
+
+func (m *CommentReadabilityStats) GetComments() int32 {
+	if m != nil {
+		return m.Comments
+	}
+	return 0
+}
+
+func (m *CommentReadabilityStats) GetSentences() int32 {
+	if m != nil {
+		return m.Sentences
+	}
+	return 0
+}
+
+func (m *CommentReadabilityStats) GetWords() int32 {
+	if m != nil {
+		return m.Words
+	}
+	return 0
+}
+
+func (m *CommentReadabilityStats) GetSyllables() int32 {
+	if m != nil {
+		return m.Syllables
+	}
+	return 0
+}
+
+type DirectoryCommentReadability struct {
+	Directories map[string]*CommentReadabilityStats `protobuf:"bytes,1,rep,name=directories" json:"directories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *DirectoryCommentReadability) Reset()                    { *m = DirectoryCommentReadability{} }
+func (m *DirectoryCommentReadability) String() string            { return proto.CompactTextString(m) }
+func (*DirectoryCommentReadability) ProtoMessage()               {}
+func (*DirectoryCommentReadability) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{93} }
+
+func (m *DirectoryCommentReadability) GetDirectories() map[string]*CommentReadabilityStats {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+type CommentReadabilityResults struct {
+	// day -> leading directories -> stats of the new or changed comments
+	Days map[int32]*DirectoryCommentReadability `protobuf:"bytes,1,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *CommentReadabilityResults) Reset()                    { *m = CommentReadabilityResults{} }
+func (m *CommentReadabilityResults) String() string            { return proto.CompactTextString(m) }
+func (*CommentReadabilityResults) ProtoMessage()               {}
+func (*CommentReadabilityResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{94} }
+
+func (m *CommentReadabilityResults) GetDays() map[int32]*DirectoryCommentReadability {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+type StaleComment struct {
+	File string `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	// 1-based first line of the comment block at HEAD
+	Line    int32  `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	Comment string `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	// day when the comment appeared in its current form
+	Since int32 `protobuf:"varint,4,opt,name=since,proto3" json:"since,omitempty"`
+	// number of commits which changed the documented code since then
+	Changes int32 `protobuf:"varint,5,opt,name=changes,proto3" json:"changes,omitempty"`
+	// day when the documented code changed the last time
+	LastChange int32 `protobuf:"varint,6,opt,name=last_change,json=lastChange,proto3" json:"last_change,omitempty"`
+}
+
+func (m *StaleComment) Reset()                    { *m = StaleComment{} }
+func (m *StaleComment) String() string            { return proto.CompactTextString(m) }
+func (*StaleComment) ProtoMessage()               {}
+func (*StaleComment) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{95} }
+
+func (m *StaleComment) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *StaleComment) GetLine() int32 {
+	if m != nil {
+		return m.Line
+	}
+	return 0
+}
+
+func (m *StaleComment) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+func (m *StaleComment) GetSince() int32 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *StaleComment) GetChanges() int32 {
+	if m != nil {
+		return m.Changes
+	}
+	return 0
+}
+
+func (m *StaleComment) GetLastChange() int32 {
+	if m != nil {
+		return m.LastChange
+	}
+	return 0
+}
+
+type StaleCommentsResults struct {
+	Comments []*StaleComment `protobuf:"bytes,1,rep,name=comments" json:"comments,omitempty"`
+}
+
+func (m *StaleCommentsResults) Reset()                    { *m = StaleCommentsResults{} }
+func (m *StaleCommentsResults) String() string            { return proto.CompactTextString(m) }
+func (*StaleCommentsResults) ProtoMessage()               {}
+func (*StaleCommentsResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{96} }
+
+func (m *StaleCommentsResults) GetComments() []*StaleComment {
+	if m != nil {
+		return m.Comments
+	}
+	return nil
+}
+
+type ToxicityStats struct {
+	// number of toxic phrases in the new or changed comments
+	Comments int32 `protobuf:"varint,1,opt,name=comments,proto3" json:"comments,omitempty"`
+	// number of toxic phrases in the commit messages
+	Messages int32 `protobuf:"varint,2,opt,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (m *ToxicityStats) Reset()                    { *m = ToxicityStats{} }
+func (m *ToxicityStats) String() string            { return proto.CompactTextString(m) }
+func (*ToxicityStats) ProtoMessage()               {}
+func (*ToxicityStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{97} }
+
+func (m *ToxicityStats) GetComments() int32 {
+	if m != nil {
+		return m.Comments
+	}
+	return 0
+}
+
+func (m *ToxicityStats) GetMessages() int32 {
+	if m != nil {
+		return m.Messages
+	}
+	return 0
+}
+
+type ToxicityResults struct {
+	// day -> phrase counts
+	Days map[int32]*ToxicityStats `protobuf:"bytes,1,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// leading directories -> number of toxic phrases in the comments
+	Directories map[string]int32 `protobuf:"bytes,2,rep,name=directories" json:"directories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// phrase -> number of occurrences
+	Phrases map[string]int32 `protobuf:"bytes,3,rep,name=phrases" json:"phrases,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (m *ToxicityResults) Reset()                    { *m = ToxicityResults{} }
+func (m *ToxicityResults) String() string            { return proto.CompactTextString(m) }
+func (*ToxicityResults) ProtoMessage()               {}
+func (*ToxicityResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{98} }
+
+func (m *ToxicityResults) GetDays() map[int32]*ToxicityStats {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *ToxicityResults) GetDirectories() map[string]int32 {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+func (m *ToxicityResults) GetPhrases() map[string]int32 {
+	if m != nil {
+		return m.Phrases
+	}
+	return nil
+}
+
+type FunctionSizeStats struct {
+	Functions int32 `protobuf:"varint,1,opt,name=functions,proto3" json:"functions,omitempty"`
+	// total number of lines in the functions
+	Lines  int32 `protobuf:"varint,2,opt,name=lines,proto3" json:"lines,omitempty"`
+	Median int32 `protobuf:"varint,3,opt,name=median,proto3" json:"median,omitempty"`
+	// 90th percentile of the function lengths
+	P90 int32 `protobuf:"varint,4,opt,name=p90,proto3" json:"p90,omitempty"`
+	Max int32 `protobuf:"varint,5,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (m *FunctionSizeStats) Reset()                    { *m = FunctionSizeStats{} }
+func (m *FunctionSizeStats) String() string            { return proto.CompactTextString(m) }
+func (*FunctionSizeStats) ProtoMessage()               {}
+func (*FunctionSizeStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{99} }
+
+func (m *FunctionSizeStats) GetFunctions() int32 {
+	if m != nil {
+		return m.Functions
+	}
+	return 0
+}
+
+func (m *FunctionSizeStats) GetLines() int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *FunctionSizeStats) GetMedian() int32 {
+	if m != nil {
+		return m.Median
+	}
+	return 0
+}
+
+func (m *FunctionSizeStats) GetP90() int32 {
+	if m != nil {
+		return m.P90
+	}
+	return 0
+}
+
+func (m *FunctionSizeStats) GetMax() int32 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+type LanguageFunctionSizes struct {
+	// language -> function length distribution
+	Languages map[string]*FunctionSizeStats `protobuf:"bytes,1,rep,name=languages" json:"languages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *LanguageFunctionSizes) Reset()                    { *m = LanguageFunctionSizes{} }
+func (m *LanguageFunctionSizes) String() string            { return proto.CompactTextString(m) }
+func (*LanguageFunctionSizes) ProtoMessage()               {}
+func (*LanguageFunctionSizes) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{100} }
+
+func (m *LanguageFunctionSizes) GetLanguages() map[string]*FunctionSizeStats {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+type LongestFunction struct {
+	File string `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// length of the function at HEAD
+	Lines int32 `protobuf:"varint,3,opt,name=lines,proto3" json:"lines,omitempty"`
+	// day when the function appeared
+	Since int32 `protobuf:"varint,4,opt,name=since,proto3" json:"since,omitempty"`
+	// length of the function when it appeared
+	InitialLines int32 `protobuf:"varint,5,opt,name=initial_lines,json=initialLines,proto3" json:"initial_lines,omitempty"`
+}
+
+func (m *LongestFunction) Reset()                    { *m = LongestFunction{} }
+func (m *LongestFunction) String() string            { return proto.CompactTextString(m) }
+func (*LongestFunction) ProtoMessage()               {}
+func (*LongestFunction) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{101} }
+
+func (m *LongestFunction) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *LongestFunction) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LongestFunction) GetLines() int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *LongestFunction) GetSince() int32 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *LongestFunction) GetInitialLines() int32 {
+	if m != nil {
+		return m.InitialLines
+	}
+	return 0
+}
+
+type FunctionSizeResults struct {
+	// day -> language -> function length distribution
+	Days map[int32]*LanguageFunctionSizes `protobuf:"bytes,1,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// longest functions at HEAD sorted by length in descending order
+	Longest []*LongestFunction `protobuf:"bytes,2,rep,name=longest" json:"longest,omitempty"`
+}
+
+func (m *FunctionSizeResults) Reset()                    { *m = FunctionSizeResults{} }
+func (m *FunctionSizeResults) String() string            { return proto.CompactTextString(m) }
+func (*FunctionSizeResults) ProtoMessage()               {}
+func (*FunctionSizeResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{102} }
+
+func (m *FunctionSizeResults) GetDays() map[int32]*LanguageFunctionSizes {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *FunctionSizeResults) GetLongest() []*LongestFunction {
+	if m != nil {
+		return m.Longest
+	}
+	return nil
+}
+
+type NestingDepthStats struct {
+	Day int32 `protobuf:"varint,1,opt,name=day,proto3" json:"day,omitempty"`
+	// number of blocks
+	Blocks int32 `protobuf:"varint,2,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	// sum of the nesting depths of the blocks
+	Total int32 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	// maximum nesting depth
+	Max int32 `protobuf:"varint,4,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (m *NestingDepthStats) Reset()                    { *m = NestingDepthStats{} }
+func (m *NestingDepthStats) String() string            { return proto.CompactTextString(m) }
+func (*NestingDepthStats) ProtoMessage()               {}
+func (*NestingDepthStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{103} }
+
+func (m *NestingDepthStats) GetDay() int32 {
+	if m != nil {
+		return m.Day
+	}
+	return 0
+}
+
+func (m *NestingDepthStats) GetBlocks() int32 {
+	if m != nil {
+		return m.Blocks
+	}
+	return 0
+}
+
+func (m *NestingDepthStats) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *NestingDepthStats) GetMax() int32 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+type NestingDepthHistory struct {
+	Stats []*NestingDepthStats `protobuf:"bytes,1,rep,name=stats" json:"stats,omitempty"`
+}
+
+func (m *NestingDepthHistory) Reset()                    { *m = NestingDepthHistory{} }
+func (m *NestingDepthHistory) String() string            { return proto.CompactTextString(m) }
+func (*NestingDepthHistory) ProtoMessage()               {}
+func (*NestingDepthHistory) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{104} }
+
+func (m *NestingDepthHistory) GetStats() []*NestingDepthStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type NestingDepthResults struct {
+	Files map[string]*NestingDepthHistory `protobuf:"bytes,1,rep,name=files" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// file -> number of the consecutive nesting increases
+	Increasing map[string]int32 `protobuf:"bytes,2,rep,name=increasing" json:"increasing,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (m *NestingDepthResults) Reset()                    { *m = NestingDepthResults{} }
+func (m *NestingDepthResults) String() string            { return proto.CompactTextString(m) }
+func (*NestingDepthResults) ProtoMessage()               {}
+func (*NestingDepthResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{105} }
+
+func (m *NestingDepthResults) GetFiles() map[string]*NestingDepthHistory {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *NestingDepthResults) GetIncreasing() map[string]int32 {
+	if m != nil {
+		return m.Increasing
+	}
+	return nil
+}
+
+type CommitEntropy struct {
+	Commit string `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	Day    int32  `protobuf:"varint,2,opt,name=day,proto3" json:"day,omitempty"`
+	Files  int32  `protobuf:"varint,3,opt,name=files,proto3" json:"files,omitempty"`
+	// number of the distinct parent directories of the changed files
+	Directories int32 `protobuf:"varint,4,opt,name=directories,proto3" json:"directories,omitempty"`
+	// number of added, removed and changed lines
+	Lines int32 `protobuf:"varint,5,opt,name=lines,proto3" json:"lines,omitempty"`
+	// Shannon entropy in bits of the changed lines over the directories
+	Entropy float32 `protobuf:"fixed32,6,opt,name=entropy,proto3" json:"entropy,omitempty"`
+	// entropy divided by log2(directories)
+	NormalizedEntropy float32 `protobuf:"fixed32,7,opt,name=normalized_entropy,json=normalizedEntropy,proto3" json:"normalized_entropy,omitempty"`
+}
+
+func (m *CommitEntropy) Reset()                    { *m = CommitEntropy{} }
+func (m *CommitEntropy) String() string            { return proto.CompactTextString(m) }
+func (*CommitEntropy) ProtoMessage()               {}
+func (*CommitEntropy) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{106} }
+
+func (m *CommitEntropy) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *CommitEntropy) GetDay() int32 {
+	if m != nil {
+		return m.Day
+	}
+	return 0
+}
+
+func (m *CommitEntropy) GetFiles() int32 {
+	if m != nil {
+		return m.Files
+	}
+	return 0
+}
+
+func (m *CommitEntropy) GetDirectories() int32 {
+	if m != nil {
+		return m.Directories
+	}
+	return 0
+}
+
+func (m *CommitEntropy) GetLines() int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *CommitEntropy) GetEntropy() float32 {
+	if m != nil {
+		return m.Entropy
+	}
+	return 0
+}
+
+func (m *CommitEntropy) GetNormalizedEntropy() float32 {
+	if m != nil {
+		return m.NormalizedEntropy
+	}
+	return 0
+}
+
+type CommitEntropyStats struct {
+	Commits     int32   `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
+	MeanEntropy float32 `protobuf:"fixed32,2,opt,name=mean_entropy,json=meanEntropy,proto3" json:"mean_entropy,omitempty"`
+	// number of commits with the entropy above the threshold
+	Scattered int32 `protobuf:"varint,3,opt,name=scattered,proto3" json:"scattered,omitempty"`
+}
+
+func (m *CommitEntropyStats) Reset()                    { *m = CommitEntropyStats{} }
+func (m *CommitEntropyStats) String() string            { return proto.CompactTextString(m) }
+func (*CommitEntropyStats) ProtoMessage()               {}
+func (*CommitEntropyStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{107} }
+
+func (m *CommitEntropyStats) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *CommitEntropyStats) GetMeanEntropy() float32 {
+	if m != nil {
+		return m.MeanEntropy
+	}
+	return 0
+}
+
+func (m *CommitEntropyStats) GetScattered() int32 {
+	if m != nil {
+		return m.Scattered
+	}
+	return 0
+}
+
+type CommitEntropyResults struct {
+	// commits in the chronological order
+	Commits []*CommitEntropy `protobuf:"bytes,1,rep,name=commits" json:"commits,omitempty"`
+	// day -> aggregated entropies
+	Days map[int32]*CommitEntropyStats `protobuf:"bytes,2,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *CommitEntropyResults) Reset()                    { *m = CommitEntropyResults{} }
+func (m *CommitEntropyResults) String() string            { return proto.CompactTextString(m) }
+func (*CommitEntropyResults) ProtoMessage()               {}
+func (*CommitEntropyResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{108} }
+
+func (m *CommitEntropyResults) GetCommits() []*CommitEntropy {
+	if m != nil {
+		return m.Commits
+	}
+	return nil
+}
+
+func (m *CommitEntropyResults) GetDays() map[int32]*CommitEntropyStats {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+type TicketlessStats struct {
+	Commits int32 `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
+	// number of commits without any issue reference
+	Ticketless int32 `protobuf:"varint,2,opt,name=ticketless,proto3" json:"ticketless,omitempty"`
+}
+
+func (m *TicketlessStats) Reset()                    { *m = TicketlessStats{} }
+func (m *TicketlessStats) String() string            { return proto.CompactTextString(m) }
+func (*TicketlessStats) ProtoMessage()               {}
+func (*TicketlessStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{109} }
+
+func (m *TicketlessStats) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *TicketlessStats) GetTicketless() int32 {
+	if m != nil {
+		return m.Ticketless
+	}
+	return 0
+}
+
+type TicketlessCommitsResults struct {
+	// month ("2018-03") -> stats
+	Months map[string]*TicketlessStats `protobuf:"bytes,1,rep,name=months" json:"months,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// developer index -> stats, the last element is the unmatched authors
+	People []*TicketlessStats `protobuf:"bytes,2,rep,name=people" json:"people,omitempty"`
+	// developer names
+	PeopleSequence []string `protobuf:"bytes,3,rep,name=people_sequence,json=peopleSequence" json:"people_sequence,omitempty"`
+}
+
+func (m *TicketlessCommitsResults) Reset()                    { *m = TicketlessCommitsResults{} }
+func (m *TicketlessCommitsResults) String() string            { return proto.CompactTextString(m) }
+func (*TicketlessCommitsResults) ProtoMessage()               {}
+func (*TicketlessCommitsResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{110} }
+
+func (m *TicketlessCommitsResults) GetMonths() map[string]*TicketlessStats {
+	if m != nil {
+		return m.Months
+	}
+	return nil
+}
+
+func (m *TicketlessCommitsResults) GetPeople() []*TicketlessStats {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *TicketlessCommitsResults) GetPeopleSequence() []string {
+	if m != nil {
+		return m.PeopleSequence
+	}
+	return nil
+}
+
+type ChangeSet struct {
+	// hash of the merge commit
+	Merge string `protobuf:"bytes,1,opt,name=merge,proto3" json:"merge,omitempty"`
+	// committer date of the merge commit, Unix time
+	Merged int64 `protobuf:"varint,2,opt,name=merged,proto3" json:"merged,omitempty"`
+	// number of the non-merge commits in the second-parent range
+	Commits int32 `protobuf:"varint,3,opt,name=commits,proto3" json:"commits,omitempty"`
+	// number of the files changed by the merge relative to the first parent
+	Files int32 `protobuf:"varint,4,opt,name=files,proto3" json:"files,omitempty"`
+	// number of added, removed and changed lines
+	Lines int32 `protobuf:"varint,5,opt,name=lines,proto3" json:"lines,omitempty"`
+	// seconds between the first and the last authored commit
+	Duration int64 `protobuf:"varint,6,opt,name=duration,proto3" json:"duration,omitempty"`
+	// number of the distinct authors
+	Authors int32 `protobuf:"varint,7,opt,name=authors,proto3" json:"authors,omitempty"`
+}
+
+func (m *ChangeSet) Reset()                    { *m = ChangeSet{} }
+func (m *ChangeSet) String() string            { return proto.CompactTextString(m) }
+func (*ChangeSet) ProtoMessage()               {}
+func (*ChangeSet) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{111} }
+
+func (m *ChangeSet) GetMerge() string {
+	if m != nil {
+		return m.Merge
+	}
+	return ""
+}
+
+func (m *ChangeSet) GetMerged() int64 {
+	if m != nil {
+		return m.Merged
+	}
+	return 0
+}
+
+func (m *ChangeSet) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *ChangeSet) GetFiles() int32 {
+	if m != nil {
+		return m.Files
+	}
+	return 0
+}
+
+func (m *ChangeSet) GetLines() int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *ChangeSet) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *ChangeSet) GetAuthors() int32 {
+	if m != nil {
+		return m.Authors
+	}
+	return 0
+}
+
+type ChangeSetStats struct {
+	ChangeSets  int32 `protobuf:"varint,1,opt,name=change_sets,json=changeSets,proto3" json:"change_sets,omitempty"`
+	MedianLines int32 `protobuf:"varint,2,opt,name=median_lines,json=medianLines,proto3" json:"median_lines,omitempty"`
+	P90Lines    int32 `protobuf:"varint,3,opt,name=p90_lines,json=p90Lines,proto3" json:"p90_lines,omitempty"`
+	// seconds
+	MedianDuration int64   `protobuf:"varint,4,opt,name=median_duration,json=medianDuration,proto3" json:"median_duration,omitempty"`
+	P90Duration    int64   `protobuf:"varint,5,opt,name=p90_duration,json=p90Duration,proto3" json:"p90_duration,omitempty"`
+	MeanAuthors    float32 `protobuf:"fixed32,6,opt,name=mean_authors,json=meanAuthors,proto3" json:"mean_authors,omitempty"`
+	// number of change sets with more than one author
+	MultiAuthor int32 `protobuf:"varint,7,opt,name=multi_author,json=multiAuthor,proto3" json:"multi_author,omitempty"`
+}
+
+func (m *ChangeSetStats) Reset()                    { *m = ChangeSetStats{} }
+func (m *ChangeSetStats) String() string            { return proto.CompactTextString(m) }
+func (*ChangeSetStats) ProtoMessage()               {}
+func (*ChangeSetStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{112} }
+
+func (m *ChangeSetStats) GetChangeSets() int32 {
+	if m != nil {
+		return m.ChangeSets
+	}
+	return 0
+}
+
+func (m *ChangeSetStats) GetMedianLines() int32 {
+	if m != nil {
+		return m.MedianLines
+	}
+	return 0
+}
+
+func (m *ChangeSetStats) GetP90Lines() int32 {
+	if m != nil {
+		return m.P90Lines
+	}
+	return 0
+}
+
+func (m *ChangeSetStats) GetMedianDuration() int64 {
+	if m != nil {
+		return m.MedianDuration
+	}
+	return 0
+}
+
+func (m *ChangeSetStats) GetP90Duration() int64 {
+	if m != nil {
+		return m.P90Duration
+	}
+	return 0
+}
+
+func (m *ChangeSetStats) GetMeanAuthors() float32 {
+	if m != nil {
+		return m.MeanAuthors
+	}
+	return 0
+}
+
+func (m *ChangeSetStats) GetMultiAuthor() int32 {
+	if m != nil {
+		return m.MultiAuthor
+	}
+	return 0
+}
+
+type ChangeSetsResults struct {
+	// change sets in the chronological order of the merges
+	ChangeSets []*ChangeSet `protobuf:"bytes,1,rep,name=change_sets,json=changeSets" json:"change_sets,omitempty"`
+	// month ("2018-03") -> stats
+	Months map[string]*ChangeSetStats `protobuf:"bytes,2,rep,name=months" json:"months,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *ChangeSetsResults) Reset()                    { *m = ChangeSetsResults{} }
+func (m *ChangeSetsResults) String() string            { return proto.CompactTextString(m) }
+func (*ChangeSetsResults) ProtoMessage()               {}
+func (*ChangeSetsResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{113} }
+
+func (m *ChangeSetsResults) GetChangeSets() []*ChangeSet {
+	if m != nil {
+		return m.ChangeSets
+	}
+	return nil
+}
+
+func (m *ChangeSetsResults) GetMonths() map[string]*ChangeSetStats {
+	if m != nil {
+		return m.Months
+	}
+	return nil
+}
+
+type CaseCollision struct {
+	// paths which differ only in case, the directories end with a slash
+	Paths []string `protobuf:"bytes,1,rep,name=paths" json:"paths,omitempty"`
+	// hash of the commit which made the paths collide
+	Introduced string `protobuf:"bytes,2,opt,name=introduced,proto3" json:"introduced,omitempty"`
+	// hash of the commit which resolved the collision, empty if it still exists
+	Resolved string `protobuf:"bytes,3,opt,name=resolved,proto3" json:"resolved,omitempty"`
+}
+
+func (m *CaseCollision) Reset()                    { *m = CaseCollision{} }
+func (m *CaseCollision) String() string            { return proto.CompactTextString(m) }
+func (*CaseCollision) ProtoMessage()               {}
+func (*CaseCollision) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{114} }
+
+func (m *CaseCollision) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+func (m *CaseCollision) GetIntroduced() string {
+	if m != nil {
+		return m.Introduced
+	}
+	return ""
+}
+
+func (m *CaseCollision) GetResolved() string {
+	if m != nil {
+		return m.Resolved
+	}
+	return ""
+}
+
+type CaseCollisionsResults struct {
+	// collisions in the order of appearance
+	Collisions []*CaseCollision `protobuf:"bytes,1,rep,name=collisions" json:"collisions,omitempty"`
+}
+
+func (m *CaseCollisionsResults) Reset()                    { *m = CaseCollisionsResults{} }
+func (m *CaseCollisionsResults) String() string            { return proto.CompactTextString(m) }
+func (*CaseCollisionsResults) ProtoMessage()               {}
+func (*CaseCollisionsResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{115} }
+
+func (m *CaseCollisionsResults) GetCollisions() []*CaseCollision {
+	if m != nil {
+		return m.Collisions
+	}
+	return nil
+}
+
+// Extension is the result of a third-party analysis. It is wire compatible with
+// google.protobuf.Any, so the generic Any decoders are able to read it.
+type Extension struct {
+	// "type.googleapis.com/" followed by the full name of the message type
+	TypeUrl string `protobuf:"bytes,1,opt,name=type_url,json=typeUrl,proto3" json:"type_url,omitempty"`
+	// serialized message
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *Extension) Reset()                    { *m = Extension{} }
+func (m *Extension) String() string            { return proto.CompactTextString(m) }
+func (*Extension) ProtoMessage()               {}
+func (*Extension) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{116} }
+
+func (m *Extension) GetTypeUrl() string {
+	if m != nil {
+		return m.TypeUrl
+	}
+	return ""
+}
+
+func (m *Extension) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type AnalysisResults struct {
+	Header *Metadata `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// the mapped values are dynamic messages which require the second parsing pass.
+	Contents map[string][]byte `protobuf:"bytes,2,rep,name=contents" json:"contents,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the results of the plugins keyed by the namespaced names, e.g. "github.com/user/churn/Churn".
+	Extensions map[string]*Extension `protobuf:"bytes,3,rep,name=extensions" json:"extensions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *AnalysisResults) Reset()                    { *m = AnalysisResults{} }
+func (m *AnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*AnalysisResults) ProtoMessage()               {}
+func (*AnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{117} }
+
+func (m *AnalysisResults) GetHeader() *Metadata {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *AnalysisResults) GetContents() map[string][]byte {
+	if m != nil {
+		return m.Contents
+	}
+	return nil
+}
+
+func (m *AnalysisResults) GetExtensions() map[string]*Extension {
+	if m != nil {
+		return m.Extensions
 	}
 	return nil
 }
 
 func init() {
 	proto.RegisterType((*Metadata)(nil), "Metadata")
+	proto.RegisterType((*Marker)(nil), "Marker")
+	proto.RegisterType((*SkippedItem)(nil), "SkippedItem")
+	proto.RegisterType((*CommitFailure)(nil), "CommitFailure")
 	proto.RegisterType((*BurndownSparseMatrixRow)(nil), "BurndownSparseMatrixRow")
 	proto.RegisterType((*BurndownSparseMatrix)(nil), "BurndownSparseMatrix")
 	proto.RegisterType((*BurndownAnalysisResults)(nil), "BurndownAnalysisResults")
+	proto.RegisterType((*BurndownDirectory)(nil), "BurndownDirectory")
 	proto.RegisterType((*CompressedSparseRowMatrix)(nil), "CompressedSparseRowMatrix")
 	proto.RegisterType((*Couples)(nil), "Couples")
 	proto.RegisterType((*TouchedFiles)(nil), "TouchedFiles")
@@ -609,77 +4165,472 @@ func init() {
 	proto.RegisterType((*FileHistoryResultMessage)(nil), "FileHistoryResultMessage")
 	proto.RegisterType((*Sentiment)(nil), "Sentiment")
 	proto.RegisterType((*CommentSentimentResults)(nil), "CommentSentimentResults")
+	proto.RegisterType((*CommitFeatures)(nil), "CommitFeatures")
+	proto.RegisterType((*CommitFeaturesResults)(nil), "CommitFeaturesResults")
+	proto.RegisterType((*RolesHistogram)(nil), "RolesHistogram")
+	proto.RegisterType((*LanguageRolesHistograms)(nil), "LanguageRolesHistograms")
+	proto.RegisterType((*RolesHistogramResults)(nil), "RolesHistogramResults")
+	proto.RegisterType((*HalsteadMetrics)(nil), "HalsteadMetrics")
+	proto.RegisterType((*DirectoryHalstead)(nil), "DirectoryHalstead")
+	proto.RegisterType((*HalsteadResults)(nil), "HalsteadResults")
+	proto.RegisterType((*IndentationStats)(nil), "IndentationStats")
+	proto.RegisterType((*IndentationHistory)(nil), "IndentationHistory")
+	proto.RegisterType((*IndentationComplexityResults)(nil), "IndentationComplexityResults")
+	proto.RegisterType((*StyleStats)(nil), "StyleStats")
+	proto.RegisterType((*LanguageStyleStats)(nil), "LanguageStyleStats")
+	proto.RegisterType((*StyleDriftResults)(nil), "StyleDriftResults")
+	proto.RegisterType((*GofmtCompliance)(nil), "GofmtCompliance")
+	proto.RegisterType((*GofmtComplianceResults)(nil), "GofmtComplianceResults")
+	proto.RegisterType((*StringLiteralsRelease)(nil), "StringLiteralsRelease")
+	proto.RegisterType((*StringLiteralsResults)(nil), "StringLiteralsResults")
+	proto.RegisterType((*SQLStats)(nil), "SQLStats")
+	proto.RegisterType((*SQLTableOrigin)(nil), "SQLTableOrigin")
+	proto.RegisterType((*SQLResults)(nil), "SQLResults")
+	proto.RegisterType((*ErrorHandlingStats)(nil), "ErrorHandlingStats")
+	proto.RegisterType((*ErrorHandlingResults)(nil), "ErrorHandlingResults")
+	proto.RegisterType((*TestCoChange)(nil), "TestCoChange")
+	proto.RegisterType((*DirectoryTestCoChanges)(nil), "DirectoryTestCoChanges")
+	proto.RegisterType((*TestCouplingResults)(nil), "TestCouplingResults")
+	proto.RegisterType((*TimeSkewStats)(nil), "TimeSkewStats")
+	proto.RegisterType((*TimeSkewResults)(nil), "TimeSkewResults")
+	proto.RegisterType((*CherryPick)(nil), "CherryPick")
+	proto.RegisterType((*BackportStats)(nil), "BackportStats")
+	proto.RegisterType((*CherryPicksResults)(nil), "CherryPicksResults")
+	proto.RegisterType((*LineStats)(nil), "LineStats")
+	proto.RegisterType((*DevDay)(nil), "DevDay")
+	proto.RegisterType((*DayDevs)(nil), "DayDevs")
+	proto.RegisterType((*DevsAnalysisResults)(nil), "DevsAnalysisResults")
+	proto.RegisterType((*TypoFix)(nil), "TypoFix")
+	proto.RegisterType((*TypoFixesResults)(nil), "TypoFixesResults")
+	proto.RegisterType((*CommentRatioStats)(nil), "CommentRatioStats")
+	proto.RegisterType((*LanguageCommentRatios)(nil), "LanguageCommentRatios")
+	proto.RegisterType((*CommentRatioResults)(nil), "CommentRatioResults")
+	proto.RegisterType((*CommitMessageStats)(nil), "CommitMessageStats")
+	proto.RegisterType((*CommitMessagesResults)(nil), "CommitMessagesResults")
+	proto.RegisterType((*ChurnOriginStats)(nil), "ChurnOriginStats")
+	proto.RegisterType((*DeveloperChurnOrigin)(nil), "DeveloperChurnOrigin")
+	proto.RegisterType((*ChurnOriginResults)(nil), "ChurnOriginResults")
+	proto.RegisterType((*FileLifecycleCounts)(nil), "FileLifecycleCounts")
+	proto.RegisterType((*DirectoryLifecycles)(nil), "DirectoryLifecycles")
+	proto.RegisterType((*FileLifecycleResults)(nil), "FileLifecycleResults")
+	proto.RegisterType((*ReleasePressureStats)(nil), "ReleasePressureStats")
+	proto.RegisterType((*ReleasePressureResults)(nil), "ReleasePressureResults")
+	proto.RegisterType((*CompanyAttributionStats)(nil), "CompanyAttributionStats")
+	proto.RegisterType((*CompanyQuarter)(nil), "CompanyQuarter")
+	proto.RegisterType((*CompanyAttributionResults)(nil), "CompanyAttributionResults")
+	proto.RegisterType((*BinaryChurnStats)(nil), "BinaryChurnStats")
+	proto.RegisterType((*BinaryChurnDirectories)(nil), "BinaryChurnDirectories")
+	proto.RegisterType((*BinaryAssets)(nil), "BinaryAssets")
+	proto.RegisterType((*BinaryChurnResults)(nil), "BinaryChurnResults")
+	proto.RegisterType((*RepositorySizeStats)(nil), "RepositorySizeStats")
+	proto.RegisterType((*RepositorySizeCommit)(nil), "RepositorySizeCommit")
+	proto.RegisterType((*RepositorySizeResults)(nil), "RepositorySizeResults")
+	proto.RegisterType((*RefactoringStats)(nil), "RefactoringStats")
+	proto.RegisterType((*RefactoringResults)(nil), "RefactoringResults")
+	proto.RegisterType((*CoverageChurnStats)(nil), "CoverageChurnStats")
+	proto.RegisterType((*CoverageChurnResults)(nil), "CoverageChurnResults")
+	proto.RegisterType((*DefectsStats)(nil), "DefectsStats")
+	proto.RegisterType((*DefectsResults)(nil), "DefectsResults")
+	proto.RegisterType((*OwnershipConcentrationStats)(nil), "OwnershipConcentrationStats")
+	proto.RegisterType((*DirectoryOwnershipConcentration)(nil), "DirectoryOwnershipConcentration")
+	proto.RegisterType((*OwnershipConcentrationResults)(nil), "OwnershipConcentrationResults")
+	proto.RegisterType((*EffortStats)(nil), "EffortStats")
+	proto.RegisterType((*ComponentEfforts)(nil), "ComponentEfforts")
+	proto.RegisterType((*EffortEstimationResults)(nil), "EffortEstimationResults")
+	proto.RegisterType((*CommentReadabilityStats)(nil), "CommentReadabilityStats")
+	proto.RegisterType((*DirectoryCommentReadability)(nil), "DirectoryCommentReadability")
+	proto.RegisterType((*CommentReadabilityResults)(nil), "CommentReadabilityResults")
+	proto.RegisterType((*StaleComment)(nil), "StaleComment")
+	proto.RegisterType((*StaleCommentsResults)(nil), "StaleCommentsResults")
+	proto.RegisterType((*ToxicityStats)(nil), "ToxicityStats")
+	proto.RegisterType((*ToxicityResults)(nil), "ToxicityResults")
+	proto.RegisterType((*FunctionSizeStats)(nil), "FunctionSizeStats")
+	proto.RegisterType((*LanguageFunctionSizes)(nil), "LanguageFunctionSizes")
+	proto.RegisterType((*LongestFunction)(nil), "LongestFunction")
+	proto.RegisterType((*FunctionSizeResults)(nil), "FunctionSizeResults")
+	proto.RegisterType((*NestingDepthStats)(nil), "NestingDepthStats")
+	proto.RegisterType((*NestingDepthHistory)(nil), "NestingDepthHistory")
+	proto.RegisterType((*NestingDepthResults)(nil), "NestingDepthResults")
+	proto.RegisterType((*CommitEntropy)(nil), "CommitEntropy")
+	proto.RegisterType((*CommitEntropyStats)(nil), "CommitEntropyStats")
+	proto.RegisterType((*CommitEntropyResults)(nil), "CommitEntropyResults")
+	proto.RegisterType((*TicketlessStats)(nil), "TicketlessStats")
+	proto.RegisterType((*TicketlessCommitsResults)(nil), "TicketlessCommitsResults")
+	proto.RegisterType((*ChangeSet)(nil), "ChangeSet")
+	proto.RegisterType((*ChangeSetStats)(nil), "ChangeSetStats")
+	proto.RegisterType((*ChangeSetsResults)(nil), "ChangeSetsResults")
+	proto.RegisterType((*CaseCollision)(nil), "CaseCollision")
+	proto.RegisterType((*CaseCollisionsResults)(nil), "CaseCollisionsResults")
+	proto.RegisterType((*Extension)(nil), "Extension")
 	proto.RegisterType((*AnalysisResults)(nil), "AnalysisResults")
 }
 
 func init() { proto.RegisterFile("pb.proto", fileDescriptorPb) }
 
 var fileDescriptorPb = []byte{
-	// 1053 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xdf, 0x6e, 0x1b, 0xc5,
-	0x17, 0xd6, 0x66, 0xfd, 0xf7, 0xac, 0x9d, 0xb4, 0xf3, 0xeb, 0xaf, 0xd9, 0x06, 0xb5, 0x98, 0x25,
-	0x80, 0xa1, 0x65, 0x8b, 0xdc, 0x1b, 0x08, 0x37, 0x24, 0x2e, 0x15, 0xbd, 0x08, 0x48, 0xe3, 0x14,
-	0x2e, 0xad, 0xf1, 0xee, 0x24, 0x5e, 0x58, 0xcf, 0xac, 0x66, 0x76, 0x93, 0xf8, 0x65, 0xb8, 0x43,
-	0x42, 0x48, 0x88, 0x0b, 0x5e, 0x80, 0xd7, 0xe0, 0x19, 0x78, 0x09, 0x34, 0xff, 0xec, 0xb5, 0xe5,
-	0x54, 0xdc, 0xcd, 0x39, 0xe7, 0xfb, 0xce, 0x9c, 0xf9, 0xce, 0x99, 0xd9, 0x85, 0x4e, 0x31, 0x8b,
-	0x0b, 0xc1, 0x4b, 0x1e, 0xfd, 0xed, 0x41, 0xe7, 0x9c, 0x96, 0x24, 0x25, 0x25, 0x41, 0x21, 0xb4,
-	0xaf, 0xa9, 0x90, 0x19, 0x67, 0xa1, 0x37, 0xf0, 0x86, 0x4d, 0xec, 0x4c, 0x84, 0xa0, 0x31, 0x27,
-	0x72, 0x1e, 0xee, 0x0d, 0xbc, 0x61, 0x17, 0xeb, 0x35, 0x7a, 0x02, 0x20, 0x68, 0xc1, 0x65, 0x56,
-	0x72, 0xb1, 0x0c, 0x7d, 0x1d, 0xa9, 0x79, 0xd0, 0x87, 0x70, 0x30, 0xa3, 0x57, 0x19, 0x9b, 0x56,
-	0x2c, 0xbb, 0x9d, 0x96, 0xd9, 0x82, 0x86, 0x8d, 0x81, 0x37, 0xf4, 0x71, 0x5f, 0xbb, 0xdf, 0xb0,
-	0xec, 0xf6, 0x22, 0x5b, 0x50, 0x14, 0x41, 0x9f, 0xb2, 0xb4, 0x86, 0x6a, 0x6a, 0x54, 0x40, 0x59,
-	0xba, 0xc2, 0x84, 0xd0, 0x4e, 0xf8, 0x62, 0x91, 0x95, 0x32, 0x6c, 0x99, 0xca, 0xac, 0x89, 0x1e,
-	0x41, 0x47, 0x54, 0xcc, 0x10, 0xdb, 0x9a, 0xd8, 0x16, 0x15, 0x53, 0xa4, 0xe8, 0x05, 0x1c, 0x9e,
-	0x55, 0x82, 0xa5, 0xfc, 0x86, 0x4d, 0x0a, 0x22, 0x24, 0x3d, 0x27, 0xa5, 0xc8, 0x6e, 0x31, 0xbf,
-	0x31, 0xf9, 0xf2, 0x6a, 0xc1, 0x64, 0xe8, 0x0d, 0xfc, 0x61, 0x1f, 0x3b, 0x33, 0xfa, 0xcd, 0x83,
-	0x07, 0xbb, 0x58, 0x4a, 0x02, 0x46, 0x16, 0x54, 0x2b, 0xd3, 0xc5, 0x7a, 0x8d, 0x8e, 0x61, 0x9f,
-	0x55, 0x8b, 0x19, 0x15, 0x53, 0x7e, 0x39, 0x15, 0xfc, 0x46, 0x6a, 0x81, 0x9a, 0xb8, 0x67, 0xbc,
-	0xdf, 0x5d, 0x62, 0x7e, 0x23, 0xd1, 0x27, 0x70, 0x7f, 0x8d, 0x72, 0xdb, 0xfa, 0x1a, 0x78, 0xe0,
-	0x80, 0x63, 0xe3, 0x46, 0xcf, 0xa0, 0xa1, 0xf3, 0x34, 0x06, 0xfe, 0x30, 0x18, 0x85, 0xf1, 0x1d,
-	0x07, 0xc0, 0x1a, 0x15, 0xfd, 0xb1, 0xb7, 0x3e, 0xe2, 0x29, 0x23, 0xf9, 0x52, 0x66, 0x12, 0x53,
-	0x59, 0xe5, 0xa5, 0x44, 0x03, 0x08, 0xae, 0x04, 0x61, 0x55, 0x4e, 0x44, 0x56, 0x2e, 0x6d, 0x43,
-	0xeb, 0x2e, 0x74, 0x04, 0x1d, 0x49, 0x16, 0x45, 0x9e, 0xb1, 0x2b, 0x5b, 0xf7, 0xca, 0x46, 0xcf,
-	0xa1, 0x5d, 0x08, 0xfe, 0x23, 0x4d, 0x4a, 0x5d, 0x69, 0x30, 0xfa, 0xff, 0xee, 0x52, 0x1c, 0x0a,
-	0x3d, 0x85, 0xe6, 0x65, 0x96, 0x53, 0x57, 0xf9, 0x1d, 0x70, 0x83, 0x41, 0x9f, 0x42, 0xab, 0xa0,
-	0xbc, 0xc8, 0x55, 0xaf, 0xdf, 0x82, 0xb6, 0x20, 0xf4, 0x1a, 0x90, 0x59, 0x4d, 0x33, 0x56, 0x52,
-	0x41, 0x92, 0x52, 0x8d, 0x68, 0x4b, 0xd7, 0x75, 0x14, 0x8f, 0xf9, 0xa2, 0x10, 0x54, 0x4a, 0x9a,
-	0x1a, 0x32, 0xe6, 0x37, 0x96, 0x7f, 0xdf, 0xb0, 0x5e, 0xaf, 0x49, 0xd1, 0x9f, 0x1e, 0x3c, 0xba,
-	0x93, 0xb0, 0xa3, 0x9f, 0xde, 0x7f, 0xed, 0xe7, 0xde, 0xee, 0x7e, 0x22, 0x68, 0xa8, 0xab, 0x15,
-	0xfa, 0x03, 0x7f, 0xe8, 0xe3, 0x86, 0xbb, 0x66, 0x19, 0x4b, 0xb3, 0xc4, 0x8a, 0xd5, 0xc4, 0xce,
-	0x44, 0x0f, 0xa1, 0x95, 0xb1, 0xb4, 0x28, 0x85, 0xd6, 0xc5, 0xc7, 0xd6, 0x8a, 0x26, 0xd0, 0x1e,
-	0xf3, 0xaa, 0x50, 0xd2, 0x3d, 0x80, 0x66, 0xc6, 0x52, 0x7a, 0xab, 0xe7, 0xb6, 0x8b, 0x8d, 0x81,
-	0x46, 0xd0, 0x5a, 0xe8, 0x23, 0xe8, 0x3a, 0xde, 0xae, 0x8a, 0x45, 0x46, 0xc7, 0xd0, 0xbb, 0xe0,
-	0x55, 0x32, 0xa7, 0xe9, 0xab, 0xcc, 0x66, 0x36, 0x1d, 0xf4, 0x74, 0x51, 0xc6, 0x88, 0x7e, 0xf5,
-	0xe0, 0xa1, 0xdd, 0x7b, 0x7b, 0xc2, 0x9e, 0x42, 0x4f, 0x61, 0xa6, 0x89, 0x09, 0xdb, 0x86, 0x74,
-	0x62, 0x0b, 0xc7, 0x81, 0x8a, 0xba, 0xba, 0x9f, 0xc3, 0xbe, 0xed, 0xa1, 0x83, 0xb7, 0xb7, 0xe0,
-	0x7d, 0x13, 0x77, 0x84, 0xcf, 0xa0, 0x67, 0x09, 0xa6, 0xaa, 0x8e, 0x9e, 0x94, 0x7e, 0x5c, 0xaf,
-	0x19, 0x07, 0x06, 0xa2, 0x8d, 0xe8, 0x17, 0x0f, 0xe0, 0xcd, 0xe9, 0xe4, 0x62, 0x3c, 0x27, 0xec,
-	0x8a, 0xa2, 0x77, 0xa0, 0xab, 0xcb, 0xab, 0xdd, 0xda, 0x8e, 0x72, 0x7c, 0xab, 0x6e, 0xee, 0x63,
-	0x00, 0x29, 0x92, 0xe9, 0x8c, 0x5e, 0x72, 0x41, 0xed, 0xb3, 0xd6, 0x95, 0x22, 0x39, 0xd3, 0x0e,
-	0xc5, 0x55, 0x61, 0x72, 0x59, 0x52, 0x61, 0x9f, 0xb6, 0x8e, 0x14, 0xc9, 0xa9, 0xb2, 0xd1, 0xbb,
-	0x10, 0x54, 0x44, 0x96, 0x8e, 0xdc, 0x30, 0x2f, 0x9f, 0x72, 0x59, 0xf6, 0x63, 0xd0, 0x96, 0xa5,
-	0x37, 0x4d, 0x72, 0xe5, 0xd1, 0xfc, 0xe8, 0x2b, 0x38, 0x5c, 0x97, 0x29, 0x27, 0xe4, 0x9a, 0x0a,
-	0x27, 0xe9, 0x07, 0xd0, 0x4e, 0x8c, 0x5b, 0x77, 0x21, 0x18, 0x05, 0xf1, 0x1a, 0x8a, 0x5d, 0x2c,
-	0xfa, 0xc7, 0x83, 0xfd, 0xc9, 0x9c, 0x97, 0x8c, 0x4a, 0x89, 0x69, 0xc2, 0x45, 0x8a, 0xde, 0x87,
-	0xbe, 0xbe, 0x1c, 0x8c, 0xe4, 0x53, 0xc1, 0x73, 0x77, 0xe2, 0x9e, 0x73, 0x62, 0x9e, 0x53, 0xd5,
-	0x62, 0x15, 0x53, 0xd3, 0xaa, 0x5b, 0xac, 0x8d, 0xd5, 0xcb, 0xe6, 0xd7, 0x5e, 0x36, 0x04, 0x0d,
-	0xa5, 0x95, 0x3d, 0x9c, 0x5e, 0xa3, 0x2f, 0xa0, 0x93, 0xf0, 0x4a, 0xe5, 0x93, 0xf6, 0xde, 0x3e,
-	0x8e, 0x37, 0xab, 0x50, 0xbd, 0xd4, 0xf1, 0xaf, 0x59, 0x29, 0x96, 0x78, 0x05, 0x3f, 0xfa, 0x12,
-	0xfa, 0x1b, 0x21, 0x74, 0x0f, 0xfc, 0x9f, 0xa8, 0x7b, 0x95, 0xd4, 0x52, 0xd5, 0x76, 0x4d, 0xf2,
-	0x8a, 0xda, 0x9b, 0x64, 0x8c, 0x93, 0xbd, 0xcf, 0xbd, 0xe8, 0x25, 0x1c, 0xba, 0x6d, 0xb6, 0x47,
-	0xf0, 0x63, 0x68, 0x0b, 0xbd, 0xb3, 0xd3, 0xeb, 0x60, 0xab, 0x22, 0xec, 0xe2, 0xd1, 0x47, 0x10,
-	0xa8, 0x31, 0xf9, 0x26, 0x93, 0xfa, 0xeb, 0x54, 0xfb, 0xa2, 0x98, 0x9b, 0xe4, 0xcc, 0xe8, 0x67,
-	0x0f, 0xc2, 0x1a, 0xd2, 0x6c, 0x75, 0x4e, 0xa5, 0x24, 0x57, 0x14, 0x9d, 0xd4, 0x2f, 0x49, 0x30,
-	0x3a, 0x8e, 0xef, 0x42, 0xea, 0x80, 0xd5, 0xc1, 0x50, 0x8e, 0x5e, 0x01, 0xac, 0x9d, 0x75, 0x05,
-	0xba, 0x46, 0x81, 0xa8, 0xae, 0x40, 0x30, 0xea, 0x6d, 0xe4, 0xae, 0xe9, 0xf1, 0x03, 0x74, 0x27,
-	0x94, 0xa9, 0x2f, 0x1e, 0x2b, 0xd7, 0xb2, 0xa9, 0x44, 0x7b, 0x16, 0xa6, 0x9e, 0x76, 0x75, 0x1c,
-	0xca, 0x4a, 0xd3, 0xeb, 0x2e, 0x5e, 0xd9, 0xf5, 0x93, 0xfb, 0x9b, 0x27, 0xff, 0xcb, 0x83, 0xc3,
-	0xb1, 0x81, 0xad, 0x36, 0x70, 0x4a, 0x7f, 0x0f, 0xf7, 0xa4, 0xf3, 0x4d, 0x67, 0xcb, 0x69, 0x4a,
-	0x96, 0x56, 0x83, 0x67, 0xf1, 0x1d, 0x9c, 0x78, 0xe5, 0x38, 0x5b, 0xbe, 0x24, 0x4b, 0xa3, 0xc5,
-	0xbe, 0xdc, 0x70, 0x1e, 0x9d, 0xc3, 0xff, 0x76, 0xc0, 0x76, 0xcc, 0xc7, 0x60, 0x53, 0x1d, 0x58,
-	0x67, 0xaf, 0x6b, 0xf3, 0xbb, 0x07, 0x07, 0xdb, 0x43, 0xf2, 0x1e, 0xb4, 0xe6, 0x94, 0xa4, 0x54,
-	0xe8, 0x74, 0xc1, 0xa8, 0x1b, 0xbb, 0x3f, 0x1e, 0x6c, 0x03, 0xe8, 0x44, 0xe9, 0xc5, 0xca, 0x95,
-	0x5e, 0xc1, 0xe8, 0x49, 0xbc, 0x95, 0x26, 0x1e, 0x5b, 0xc0, 0x6a, 0xb6, 0x8d, 0x69, 0x66, 0xbb,
-	0x16, 0xda, 0xd1, 0xd9, 0x8d, 0xd9, 0xee, 0xd5, 0xea, 0x9d, 0xb5, 0xf4, 0x6f, 0xd8, 0x8b, 0x7f,
-	0x03, 0x00, 0x00, 0xff, 0xff, 0x7e, 0x55, 0x2d, 0x51, 0x92, 0x09, 0x00, 0x00,
+	// 5823 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x7c, 0x4d, 0x8c, 0x24, 0x47,
+	0x56, 0xb0, 0xb2, 0xaa, 0xbb, 0xab, 0xea, 0x55, 0xf5, 0x5f, 0x76, 0x4f, 0x4f, 0xb9, 0xe6, 0xaf,
+	0x27, 0xed, 0xf1, 0xb4, 0x3d, 0xe3, 0xf4, 0x78, 0xec, 0xcf, 0xeb, 0x99, 0x6f, 0xbd, 0x9e, 0x99,
+	0xee, 0xf1, 0xcc, 0x78, 0xba, 0xed, 0x99, 0xec, 0xf1, 0x82, 0x10, 0xa8, 0x94, 0x5d, 0x15, 0x55,
+	0x1d, 0xdb, 0x59, 0x99, 0xe5, 0xcc, 0xac, 0xee, 0x2e, 0x8b, 0x0b, 0x78, 0x05, 0x12, 0x42, 0x1c,
+	0xb8, 0x2d, 0x48, 0x0b, 0xe6, 0xc0, 0x02, 0x5a, 0xe0, 0x00, 0x08, 0x69, 0x4f, 0x20, 0x10, 0x42,
+	0x88, 0x03, 0x12, 0x5c, 0x40, 0x1c, 0xb8, 0x21, 0x21, 0x21, 0xce, 0x48, 0x1c, 0xd0, 0x8b, 0xbf,
+	0x8c, 0xfc, 0xa9, 0xaa, 0x1e, 0x76, 0x4f, 0x5d, 0xef, 0xc5, 0x8b, 0x88, 0x17, 0x2f, 0x5e, 0xbc,
+	0x78, 0x3f, 0x91, 0x0d, 0xd5, 0xe1, 0x81, 0x3d, 0x0c, 0x83, 0x38, 0xb0, 0xfe, 0xa3, 0x04, 0xd5,
+	0x3d, 0x12, 0xbb, 0x5d, 0x37, 0x76, 0xcd, 0x26, 0x54, 0x8e, 0x49, 0x18, 0xd1, 0xc0, 0x6f, 0x1a,
+	0x9b, 0xc6, 0xd6, 0xbc, 0x23, 0x41, 0xd3, 0x84, 0xb9, 0x43, 0x37, 0x3a, 0x6c, 0x96, 0x36, 0x8d,
+	0xad, 0x9a, 0xc3, 0x7e, 0x9b, 0x97, 0x01, 0x42, 0x32, 0x0c, 0x22, 0x1a, 0x07, 0xe1, 0xb8, 0x59,
+	0x66, 0x2d, 0x1a, 0xc6, 0x7c, 0x1d, 0x96, 0x0f, 0x48, 0x9f, 0xfa, 0xed, 0x91, 0x4f, 0x4f, 0xdb,
+	0x31, 0x1d, 0x90, 0xe6, 0xdc, 0xa6, 0xb1, 0x55, 0x76, 0x16, 0x19, 0xfa, 0x73, 0x9f, 0x9e, 0xbe,
+	0xa0, 0x03, 0x62, 0x5a, 0xb0, 0x48, 0xfc, 0xae, 0x46, 0x35, 0xcf, 0xa8, 0xea, 0xc4, 0xef, 0x2a,
+	0x9a, 0x26, 0x54, 0x3a, 0xc1, 0x60, 0x40, 0xe3, 0xa8, 0xb9, 0xc0, 0x39, 0x13, 0xa0, 0xf9, 0x0a,
+	0x54, 0xc3, 0x91, 0xcf, 0x3b, 0x56, 0x58, 0xc7, 0x4a, 0x38, 0xf2, 0x59, 0xa7, 0x37, 0xa1, 0xda,
+	0x73, 0xa9, 0x37, 0x0a, 0x49, 0xd4, 0xac, 0x6e, 0x96, 0xb7, 0xea, 0xb7, 0x97, 0xec, 0x6d, 0xd6,
+	0xed, 0x63, 0x8e, 0x76, 0x54, 0x3b, 0x4e, 0x30, 0x74, 0xc3, 0x98, 0xba, 0x5e, 0xb3, 0xb6, 0x69,
+	0x6c, 0x55, 0x1d, 0x09, 0x9a, 0xaf, 0x43, 0x25, 0x3a, 0xa2, 0xc3, 0x21, 0xe9, 0x36, 0x81, 0x0d,
+	0xd2, 0xb0, 0xf7, 0x39, 0xfc, 0x24, 0x26, 0x03, 0x47, 0x36, 0x9a, 0x57, 0xa1, 0x32, 0x70, 0xc3,
+	0x23, 0x12, 0x46, 0xcd, 0x3a, 0xa3, 0xab, 0xd8, 0x7b, 0x0c, 0x76, 0x24, 0xde, 0xda, 0x87, 0x05,
+	0x8e, 0x32, 0xd7, 0x61, 0xde, 0x73, 0x0f, 0x88, 0xc7, 0xe4, 0x5c, 0x73, 0x38, 0x60, 0x5e, 0x80,
+	0x5a, 0x22, 0x85, 0x12, 0x5b, 0x4c, 0x75, 0x24, 0x45, 0xb0, 0x01, 0x0b, 0x7c, 0xcd, 0x42, 0xd4,
+	0x02, 0xb2, 0xee, 0x40, 0x5d, 0xe3, 0x07, 0x77, 0x8a, 0xc6, 0x64, 0x20, 0x06, 0x66, 0xbf, 0xb1,
+	0x6b, 0x48, 0xdc, 0x28, 0xf0, 0xc5, 0xfe, 0x09, 0xc8, 0xea, 0xc3, 0x62, 0x4a, 0x1e, 0xda, 0x1c,
+	0x86, 0x3e, 0x07, 0xb2, 0x4b, 0xfd, 0x2e, 0x39, 0x65, 0xfd, 0xe7, 0x1d, 0x0e, 0xa8, 0xa9, 0xca,
+	0xda, 0x54, 0xeb, 0x30, 0x4f, 0xc2, 0x30, 0x08, 0xd9, 0x56, 0xd7, 0x1c, 0x0e, 0x58, 0xef, 0xc2,
+	0xf9, 0x07, 0xa3, 0xd0, 0xef, 0x06, 0x27, 0xfe, 0xfe, 0xd0, 0x0d, 0x23, 0xb2, 0xe7, 0xc6, 0x21,
+	0x3d, 0x75, 0x82, 0x13, 0xbe, 0xb3, 0xde, 0x68, 0xe0, 0x47, 0x4d, 0x63, 0xb3, 0xbc, 0xb5, 0xe8,
+	0x48, 0xd0, 0xfa, 0x03, 0x03, 0xd6, 0x8b, 0x7a, 0xe1, 0xbc, 0xbe, 0x3b, 0x20, 0x72, 0x89, 0xf8,
+	0xdb, 0x7c, 0x0d, 0x96, 0xfc, 0xd1, 0xe0, 0x80, 0x84, 0xed, 0xa0, 0xd7, 0x0e, 0x83, 0x93, 0x48,
+	0xb0, 0xda, 0xe0, 0xd8, 0xcf, 0x7a, 0x4e, 0x70, 0x12, 0x99, 0x6f, 0xc2, 0x6a, 0x42, 0x25, 0xa7,
+	0x2d, 0x33, 0xc2, 0x65, 0x49, 0xb8, 0xcd, 0xd1, 0xe6, 0x4d, 0x98, 0x63, 0xe3, 0xcc, 0xb1, 0xcd,
+	0x6c, 0xda, 0x13, 0x16, 0xe0, 0x30, 0x2a, 0xeb, 0xdf, 0xca, 0xc9, 0x12, 0xef, 0xfb, 0xae, 0x37,
+	0x8e, 0x68, 0xe4, 0x90, 0x68, 0xe4, 0xc5, 0x91, 0xb9, 0x09, 0xf5, 0x7e, 0xe8, 0xfa, 0x23, 0xcf,
+	0x0d, 0x69, 0x3c, 0x16, 0x47, 0x4b, 0x47, 0x99, 0x2d, 0xa8, 0x46, 0xee, 0x60, 0xe8, 0x51, 0xbf,
+	0x2f, 0xf8, 0x56, 0xb0, 0xf9, 0x36, 0x54, 0x86, 0x61, 0xf0, 0x1d, 0xd2, 0xe1, 0x1b, 0x5f, 0xbf,
+	0x7d, 0xae, 0x98, 0x15, 0x49, 0x65, 0xde, 0x80, 0xf9, 0x1e, 0xf5, 0x88, 0xe4, 0x7c, 0x02, 0x39,
+	0xa7, 0x31, 0xdf, 0x82, 0x85, 0x21, 0x09, 0x86, 0x1e, 0x9e, 0xba, 0x29, 0xd4, 0x82, 0xc8, 0x7c,
+	0x02, 0x26, 0xff, 0xd5, 0xa6, 0x7e, 0x4c, 0x42, 0xb7, 0x13, 0xa3, 0xb1, 0x58, 0x60, 0x7c, 0xb5,
+	0xf0, 0x70, 0x0d, 0x43, 0x12, 0x45, 0xa4, 0xcb, 0x3b, 0x3b, 0xc1, 0x89, 0xe8, 0xbf, 0xca, 0x7b,
+	0x3d, 0x49, 0x3a, 0xe1, 0xcc, 0xfd, 0x30, 0x18, 0x0d, 0xa3, 0x66, 0x65, 0xea, 0xcc, 0x9c, 0xc8,
+	0x7c, 0x0f, 0xea, 0x5d, 0x1a, 0x92, 0x4e, 0x1c, 0x84, 0x54, 0x9d, 0x67, 0x53, 0xf5, 0xd9, 0x11,
+	0x6d, 0x63, 0x47, 0x27, 0x33, 0xaf, 0xc1, 0x12, 0xf5, 0x29, 0x9e, 0xe3, 0xb6, 0x50, 0xec, 0x1a,
+	0x53, 0x9a, 0x45, 0x81, 0xe5, 0xea, 0x6f, 0xbe, 0x0a, 0x8b, 0x07, 0x6e, 0xe7, 0xa8, 0x47, 0x3d,
+	0xaf, 0xdd, 0x75, 0xc7, 0x51, 0x13, 0xb8, 0xf2, 0x48, 0xe4, 0x8e, 0x3b, 0x8e, 0xac, 0x9f, 0x81,
+	0xd5, 0xdc, 0x6c, 0xb8, 0x8a, 0x01, 0x63, 0x94, 0x6d, 0xeb, 0xe4, 0x55, 0x70, 0x22, 0x3c, 0x60,
+	0x43, 0x37, 0x24, 0x7e, 0x2c, 0xb6, 0x59, 0x40, 0xd6, 0x9f, 0x18, 0xf0, 0xca, 0x44, 0xe9, 0x15,
+	0x28, 0xb7, 0x71, 0x56, 0xe5, 0x2e, 0x15, 0x2b, 0xb7, 0x09, 0x73, 0x68, 0xf1, 0x9b, 0xe5, 0xcd,
+	0xf2, 0x56, 0xd9, 0x99, 0x93, 0xd6, 0x9f, 0xfa, 0x5d, 0xda, 0x11, 0x9a, 0x33, 0xef, 0x48, 0x10,
+	0xb9, 0xa6, 0x7e, 0x77, 0x18, 0x87, 0x4c, 0x49, 0xca, 0x8e, 0x80, 0xac, 0x7d, 0xa8, 0x6c, 0x07,
+	0xa3, 0x21, 0xea, 0x91, 0xb2, 0x10, 0x78, 0x88, 0x6b, 0xd2, 0x42, 0xdc, 0x56, 0xd2, 0x29, 0xcd,
+	0x54, 0x11, 0x41, 0x69, 0xbd, 0x06, 0x8d, 0x17, 0xc1, 0xa8, 0x73, 0x48, 0xba, 0x1f, 0x53, 0x31,
+	0x32, 0x57, 0x67, 0x83, 0x31, 0xc5, 0x01, 0xeb, 0x7b, 0x25, 0xd8, 0x10, 0x73, 0x67, 0x8f, 0xdb,
+	0x0d, 0x68, 0x20, 0x4d, 0xbb, 0xc3, 0x9b, 0x85, 0x76, 0x56, 0x6d, 0x41, 0xee, 0xd4, 0xb1, 0x55,
+	0xf2, 0xfd, 0x36, 0x2c, 0x09, 0x85, 0x96, 0xe4, 0x95, 0x0c, 0xf9, 0x22, 0x6f, 0x97, 0x1d, 0x6e,
+	0x41, 0x43, 0x74, 0xe0, 0x5c, 0x71, 0x45, 0x5c, 0xb4, 0x75, 0x9e, 0x9d, 0x3a, 0x27, 0xe1, 0x0b,
+	0xf8, 0x04, 0xd6, 0xf4, 0x1e, 0x6d, 0x21, 0x91, 0xda, 0x59, 0x0f, 0x0d, 0x1b, 0x85, 0xa3, 0x50,
+	0x51, 0xf9, 0xda, 0xbc, 0x51, 0x14, 0xe3, 0x55, 0x03, 0x4c, 0x28, 0x6c, 0xc1, 0xdb, 0x02, 0x67,
+	0xfd, 0xa0, 0x04, 0xf0, 0xf9, 0xfd, 0xfd, 0x17, 0xdb, 0x87, 0xae, 0xdf, 0x27, 0x78, 0xab, 0xb0,
+	0x3e, 0x9a, 0xcd, 0xac, 0x22, 0xe2, 0x53, 0xb4, 0x9b, 0x97, 0x00, 0xa2, 0xb0, 0xd3, 0x3e, 0x20,
+	0xbd, 0x20, 0x24, 0xe2, 0x7a, 0xa8, 0x45, 0x61, 0xe7, 0x01, 0x43, 0x60, 0x5f, 0x6c, 0x76, 0x7b,
+	0x31, 0x09, 0x85, 0x9d, 0xaf, 0x46, 0x61, 0xe7, 0x3e, 0xc2, 0xe6, 0x15, 0xa8, 0x8f, 0xdc, 0x28,
+	0x96, 0x9d, 0xb9, 0xc5, 0x07, 0x44, 0x89, 0xde, 0x97, 0x80, 0x41, 0xa2, 0xfb, 0x3c, 0x1f, 0x1c,
+	0x31, 0xbc, 0x7f, 0x72, 0xdb, 0x2c, 0xa4, 0x6e, 0x9b, 0x2d, 0x58, 0x51, 0x0c, 0xcb, 0xc1, 0x2b,
+	0x8c, 0x62, 0x49, 0xf2, 0x2d, 0x26, 0xb8, 0x02, 0x75, 0x74, 0x45, 0x24, 0x51, 0x95, 0x73, 0x80,
+	0xa8, 0x84, 0x03, 0x46, 0xc0, 0x39, 0xe0, 0x67, 0xbf, 0x86, 0x18, 0xc6, 0x81, 0x75, 0x0f, 0xce,
+	0x27, 0x82, 0x8a, 0xf6, 0xdd, 0x63, 0x12, 0x4a, 0x2d, 0xba, 0x06, 0x95, 0x0e, 0x47, 0x33, 0xc5,
+	0xab, 0xdf, 0xae, 0xdb, 0x09, 0xa9, 0x23, 0xdb, 0xac, 0xbf, 0x2f, 0xc1, 0xd2, 0xfe, 0x61, 0x10,
+	0xfb, 0x24, 0x8a, 0x1c, 0xd2, 0x09, 0xc2, 0x2e, 0xee, 0x11, 0x33, 0x8e, 0xbe, 0xeb, 0xb5, 0xc3,
+	0xc0, 0x93, 0x32, 0x6f, 0x48, 0xa4, 0x13, 0x78, 0x04, 0xb5, 0x1a, 0xdb, 0xf0, 0x80, 0x32, 0xad,
+	0x66, 0x80, 0xba, 0xd9, 0xca, 0xda, 0xcd, 0x66, 0xc2, 0x1c, 0xae, 0x5a, 0x88, 0x97, 0xfd, 0x36,
+	0xef, 0x40, 0xb5, 0x13, 0x8c, 0x7c, 0xa6, 0x01, 0xdc, 0x6e, 0x5f, 0xb2, 0xd3, 0x5c, 0xd8, 0xdb,
+	0xa2, 0xfd, 0xa1, 0x1f, 0x87, 0x63, 0x47, 0x91, 0xb3, 0x0d, 0x8f, 0xdd, 0x30, 0x6e, 0x7b, 0xd4,
+	0x27, 0xc2, 0x99, 0xaa, 0x31, 0xcc, 0x2e, 0xf5, 0x09, 0xba, 0x53, 0xe8, 0x8c, 0xb1, 0xc6, 0x0a,
+	0x6b, 0xac, 0x10, 0xbf, 0xcb, 0x9a, 0xae, 0xc1, 0x12, 0xf1, 0x3b, 0x5e, 0x10, 0x51, 0xbf, 0xdf,
+	0x8e, 0xc7, 0x43, 0x29, 0xef, 0x45, 0x85, 0x7d, 0x31, 0x1e, 0x92, 0xd6, 0xff, 0x47, 0xa7, 0x42,
+	0x9b, 0xdb, 0x5c, 0x81, 0xf2, 0x11, 0x91, 0xd7, 0x1e, 0xfe, 0xc4, 0xc5, 0x1f, 0xbb, 0xde, 0x88,
+	0x48, 0x77, 0x82, 0x01, 0x77, 0x4b, 0x1f, 0x18, 0xd6, 0x0e, 0x9c, 0x97, 0xeb, 0xc8, 0x1e, 0xeb,
+	0x37, 0xa0, 0x12, 0xb2, 0xa5, 0xc9, 0x0d, 0x59, 0xce, 0x2c, 0xd9, 0x91, 0xed, 0xd6, 0x75, 0xa8,
+	0xe3, 0xa1, 0x79, 0x4c, 0x23, 0x66, 0xa3, 0x35, 0xe7, 0x91, 0x5b, 0x27, 0x09, 0x5a, 0xdf, 0x37,
+	0xa0, 0xa9, 0x51, 0xf2, 0xa9, 0xf6, 0x48, 0x14, 0xb9, 0x7d, 0x62, 0xde, 0xd5, 0x0d, 0x4f, 0xfd,
+	0xf6, 0x6b, 0xf6, 0x24, 0x4a, 0xd6, 0x20, 0x04, 0xcd, 0xbb, 0xb4, 0x3e, 0x06, 0x48, 0x90, 0xba,
+	0x04, 0x6a, 0x5c, 0x02, 0x96, 0x2e, 0x01, 0x74, 0x29, 0xf5, 0xb1, 0x35, 0x79, 0xfc, 0x9d, 0x01,
+	0xb5, 0x7d, 0xe2, 0xa3, 0x43, 0xe8, 0xc7, 0x89, 0xdc, 0x70, 0xa4, 0x92, 0xa0, 0x43, 0xe7, 0x01,
+	0xd7, 0x43, 0xfc, 0x98, 0x6b, 0x53, 0xcd, 0x51, 0xb0, 0xbe, 0xf4, 0x72, 0x6a, 0xe9, 0xe6, 0x7b,
+	0x50, 0x25, 0x83, 0x00, 0x6f, 0xe2, 0xc4, 0xc5, 0x51, 0x33, 0xd9, 0x0f, 0x45, 0x93, 0xd0, 0x1e,
+	0x49, 0x89, 0x9b, 0x9b, 0x6a, 0x2a, 0x58, 0x5a, 0x6a, 0x73, 0x4b, 0xfa, 0x62, 0xfe, 0xc2, 0x80,
+	0xf3, 0xdb, 0x9c, 0x33, 0x35, 0x93, 0xdc, 0xdd, 0x6f, 0xc3, 0x4a, 0x24, 0x71, 0xed, 0x83, 0x31,
+	0xde, 0xc2, 0x42, 0xee, 0x37, 0xed, 0x09, 0x7d, 0x12, 0x76, 0x1f, 0x8c, 0x77, 0xdc, 0x31, 0x67,
+	0x75, 0x29, 0x4a, 0x21, 0x5b, 0x7b, 0xb0, 0x56, 0x40, 0x56, 0xa0, 0x93, 0x9b, 0xe9, 0x1d, 0x81,
+	0x64, 0x74, 0x7d, 0x09, 0xbf, 0x54, 0x86, 0x25, 0xe1, 0x32, 0x13, 0x37, 0x66, 0x91, 0xc3, 0x24,
+	0x9f, 0x79, 0x05, 0xca, 0xb8, 0x08, 0xae, 0xe2, 0xf8, 0x93, 0x05, 0x51, 0xc1, 0x28, 0x14, 0x0e,
+	0x27, 0xfb, 0x9d, 0xdc, 0x6e, 0x73, 0xfc, 0x28, 0xf4, 0xe4, 0x9d, 0xe7, 0x76, 0xbb, 0xa4, 0xcb,
+	0x6c, 0xe6, 0xbc, 0xc3, 0x01, 0xdc, 0xcc, 0x90, 0x0c, 0x82, 0x63, 0xd2, 0x95, 0x41, 0x90, 0x00,
+	0xd1, 0x0e, 0x76, 0x69, 0xd8, 0x26, 0x7e, 0x1c, 0x06, 0xc3, 0x31, 0x3b, 0xb8, 0x25, 0x07, 0xba,
+	0x34, 0x7c, 0xc8, 0x31, 0xe6, 0x0d, 0x58, 0x75, 0x47, 0xf1, 0x61, 0x10, 0xb6, 0xc9, 0xe9, 0x90,
+	0x84, 0x94, 0xf8, 0x1d, 0x7e, 0x7c, 0xe7, 0x9d, 0x15, 0xde, 0xf0, 0x50, 0xe1, 0xf1, 0xa0, 0x0f,
+	0xb8, 0x66, 0xb7, 0x3d, 0xe2, 0xf7, 0xe3, 0x43, 0x66, 0x38, 0xe7, 0x9d, 0x45, 0x81, 0xdd, 0x65,
+	0x48, 0xb4, 0x73, 0x8a, 0x8c, 0xfa, 0x44, 0x39, 0x4d, 0x92, 0x0a, 0x71, 0x68, 0xcb, 0xd9, 0x15,
+	0xe0, 0x07, 0x5d, 0x12, 0xb5, 0xf9, 0xa2, 0xea, 0x8c, 0x6e, 0x09, 0xf1, 0x9f, 0x22, 0xfa, 0x3e,
+	0x5b, 0xdd, 0x4d, 0x30, 0x35, 0x4a, 0xb9, 0xd0, 0x06, 0xe7, 0x51, 0xd1, 0x3a, 0x1c, 0x6f, 0x3d,
+	0x80, 0x73, 0xe9, 0x7d, 0xd0, 0xcc, 0x84, 0x7e, 0xd8, 0xd1, 0x4c, 0x64, 0x08, 0xd5, 0xe9, 0xff,
+	0x79, 0x58, 0x42, 0x5b, 0x1c, 0xb1, 0x73, 0xd7, 0x0f, 0xdd, 0x81, 0x79, 0x4b, 0x5a, 0x65, 0xde,
+	0xb5, 0x65, 0xa7, 0xdb, 0x39, 0x28, 0x0e, 0x3a, 0x23, 0x6c, 0x7d, 0x00, 0x90, 0x20, 0x67, 0x99,
+	0xba, 0xb2, 0xae, 0x4a, 0x7f, 0x6c, 0xc0, 0xf9, 0x5d, 0xd7, 0xef, 0x8f, 0xdc, 0x3e, 0x49, 0x4f,
+	0x13, 0x99, 0x0f, 0xa1, 0xe6, 0x89, 0x26, 0xc9, 0xcb, 0x75, 0x7b, 0x02, 0xb1, 0xc2, 0x0b, 0xc6,
+	0x92, 0x9e, 0xad, 0x3d, 0x58, 0x4a, 0x37, 0x16, 0x1c, 0xd7, 0x6b, 0x69, 0xbd, 0x5f, 0xce, 0x2c,
+	0x59, 0xe7, 0xf8, 0xb7, 0x0d, 0x38, 0x97, 0x69, 0x15, 0x42, 0x7f, 0x0f, 0xdd, 0xc9, 0xb1, 0x64,
+	0x75, 0xd3, 0x2e, 0xa4, 0xb2, 0xd1, 0x8b, 0xe6, 0x3c, 0x32, 0xea, 0xd6, 0x73, 0xa8, 0x29, 0x54,
+	0x81, 0xe8, 0xec, 0x34, 0x67, 0xcd, 0x49, 0x02, 0xd0, 0x59, 0x6c, 0xc3, 0xf2, 0x63, 0xd7, 0x8b,
+	0x62, 0xe2, 0x76, 0xf7, 0x48, 0x1c, 0xd2, 0x0e, 0x3b, 0x9f, 0xc7, 0xe8, 0xf5, 0x4a, 0xab, 0x29,
+	0x20, 0x4c, 0x5f, 0x74, 0x69, 0xaf, 0x47, 0x3b, 0x23, 0x2f, 0x1e, 0x0b, 0x63, 0xa5, 0x61, 0x92,
+	0x93, 0x59, 0xd6, 0x4e, 0xa6, 0xf5, 0x43, 0x03, 0x56, 0x95, 0xf7, 0x2f, 0xa7, 0x32, 0x1f, 0xa6,
+	0x83, 0x13, 0x2e, 0x86, 0x57, 0xed, 0x1c, 0xa1, 0xc2, 0x50, 0xb9, 0x5b, 0x7a, 0xbf, 0xd6, 0x33,
+	0x58, 0xc9, 0x12, 0x14, 0xec, 0xd8, 0xeb, 0x69, 0xb9, 0xac, 0xd8, 0x99, 0x15, 0xeb, 0xf2, 0xf8,
+	0x35, 0x23, 0x11, 0x88, 0xdc, 0x2c, 0x3b, 0xb5, 0x59, 0x2d, 0x3b, 0xd3, 0x9e, 0xdb, 0xa6, 0xa7,
+	0xd3, 0xb7, 0x69, 0x2b, 0xcd, 0x8e, 0x99, 0x5f, 0xb5, 0xce, 0xd0, 0x01, 0xac, 0x3c, 0xf1, 0xbb,
+	0xc4, 0x8f, 0x5d, 0xbc, 0x44, 0xf6, 0x63, 0x37, 0x8e, 0xa4, 0xa5, 0x34, 0x12, 0x4b, 0x89, 0xe9,
+	0x11, 0x66, 0x52, 0x84, 0x83, 0xc0, 0x00, 0xc4, 0xc6, 0x41, 0xec, 0x7a, 0x72, 0x47, 0x18, 0x80,
+	0xbd, 0x07, 0xee, 0xa9, 0xb0, 0x9f, 0xf8, 0xd3, 0xfa, 0x10, 0x4c, 0x6d, 0x0e, 0xe9, 0x05, 0x5c,
+	0x87, 0xf9, 0x08, 0xa7, 0x13, 0xeb, 0x5e, 0xb5, 0xb3, 0x7c, 0x38, 0xbc, 0xdd, 0xfa, 0x43, 0x03,
+	0x2e, 0x6a, 0x6d, 0xe8, 0x9f, 0x7b, 0xe4, 0x94, 0xc6, 0x63, 0x29, 0xc0, 0x6f, 0xa5, 0x1d, 0x83,
+	0x2d, 0x7b, 0x1a, 0x75, 0x81, 0x73, 0xb0, 0x37, 0xc3, 0x39, 0x78, 0x23, 0x2d, 0xd1, 0x35, 0x3b,
+	0xbf, 0x9a, 0xcc, 0xb5, 0x0a, 0xfb, 0xf1, 0xd8, 0x23, 0x5c, 0x9a, 0x4a, 0x76, 0x06, 0xb7, 0x38,
+	0x0c, 0x30, 0xaf, 0x42, 0x23, 0x76, 0x0f, 0xda, 0x94, 0x8d, 0x44, 0xba, 0xc2, 0x1c, 0xd5, 0x63,
+	0xf7, 0xe0, 0x89, 0x40, 0xa1, 0xd9, 0x8f, 0x86, 0x6e, 0x87, 0x24, 0x44, 0x65, 0x9e, 0xae, 0x63,
+	0x58, 0x45, 0xf6, 0x36, 0xac, 0xc5, 0xa1, 0x4b, 0x31, 0x37, 0xd1, 0x3e, 0x39, 0xa4, 0x31, 0x61,
+	0xcd, 0x22, 0xb5, 0x67, 0xca, 0xa6, 0x9f, 0x52, 0x2d, 0x38, 0x35, 0xf2, 0x20, 0xee, 0x92, 0x48,
+	0xc4, 0x90, 0x75, 0xc4, 0xf1, 0x9b, 0x24, 0xb2, 0xbe, 0x36, 0xc0, 0x94, 0xa7, 0x5b, 0x5b, 0xca,
+	0xbd, 0xbc, 0x19, 0xb4, 0xec, 0x3c, 0xdd, 0x14, 0x0b, 0xf8, 0xe4, 0x0c, 0x16, 0xf0, 0x6a, 0x5a,
+	0xdc, 0x75, 0x3b, 0x19, 0x59, 0x17, 0xf3, 0x5f, 0x1a, 0xb0, 0xca, 0x5a, 0x76, 0x42, 0xda, 0x53,
+	0x7e, 0xcb, 0x4d, 0x30, 0xb5, 0xc5, 0xb5, 0x0f, 0x46, 0x9d, 0x23, 0x12, 0x0b, 0x55, 0x5e, 0x49,
+	0x96, 0xf8, 0x80, 0xe1, 0xcd, 0x5b, 0xe2, 0xe8, 0x95, 0xd8, 0x5a, 0x2e, 0xda, 0xb9, 0xf1, 0x72,
+	0x87, 0x6f, 0x77, 0xfa, 0xe1, 0xcb, 0xa9, 0x4a, 0x5e, 0x3a, 0xfa, 0x1a, 0xee, 0xc3, 0xf2, 0xa3,
+	0xa0, 0x37, 0x88, 0x99, 0x96, 0x52, 0x17, 0x2f, 0x7b, 0xf4, 0x10, 0x0f, 0x49, 0xe7, 0x88, 0x74,
+	0x65, 0xce, 0x57, 0x80, 0xa8, 0x48, 0x1d, 0x8f, 0xb8, 0xbe, 0x3c, 0x84, 0x0c, 0xb0, 0xfe, 0xd3,
+	0x80, 0x8d, 0xcc, 0x18, 0x52, 0x16, 0xff, 0x2f, 0x65, 0x58, 0xae, 0xda, 0xc5, 0x64, 0xd9, 0x25,
+	0x9a, 0x5b, 0x2a, 0x05, 0xc5, 0xc5, 0xb2, 0x92, 0xeb, 0x28, 0xda, 0xcd, 0xeb, 0xb0, 0xcc, 0x7f,
+	0xb5, 0x23, 0xf2, 0xc5, 0x88, 0xf9, 0x30, 0xdc, 0xab, 0x15, 0x31, 0xfc, 0xbe, 0xc0, 0xb6, 0x9e,
+	0x4c, 0x97, 0x5a, 0xce, 0x82, 0x66, 0x27, 0xd4, 0x44, 0xf6, 0x95, 0x01, 0xe7, 0xf6, 0xe3, 0x90,
+	0xfa, 0xfd, 0x5d, 0x1a, 0x93, 0xd0, 0xf5, 0x22, 0x87, 0x78, 0xc4, 0x8d, 0x48, 0x61, 0x1a, 0x32,
+	0xef, 0xf4, 0x15, 0x1b, 0x2d, 0xe5, 0xe0, 0xcd, 0xf1, 0x74, 0x49, 0xce, 0xc1, 0x9b, 0x67, 0x78,
+	0x09, 0x5a, 0x4f, 0xf3, 0x4c, 0x70, 0x99, 0xdf, 0x86, 0x6a, 0xc8, 0xf9, 0x91, 0x72, 0xdf, 0xb0,
+	0x0b, 0xd9, 0x75, 0x14, 0x1d, 0x26, 0x56, 0xab, 0xfb, 0xcf, 0x77, 0xf9, 0x19, 0xbb, 0xcc, 0xe2,
+	0xc1, 0x98, 0xf0, 0xf8, 0x81, 0x0b, 0x49, 0xc3, 0x20, 0xa7, 0xdf, 0x09, 0xa8, 0xca, 0x24, 0x71,
+	0x00, 0xd3, 0x5e, 0xb1, 0x7b, 0xc0, 0x6f, 0x47, 0x9e, 0xbc, 0x93, 0x03, 0xda, 0x2f, 0x18, 0x9e,
+	0x6f, 0xb0, 0x20, 0x6a, 0xdd, 0x81, 0xba, 0x86, 0x9e, 0x15, 0x34, 0xa4, 0x22, 0xc2, 0xf7, 0x61,
+	0x69, 0xff, 0xf9, 0x2e, 0xeb, 0xfd, 0x59, 0x48, 0xfb, 0xd4, 0x2f, 0xb8, 0x2e, 0x64, 0x88, 0x5c,
+	0x4a, 0x42, 0x64, 0xeb, 0x7f, 0xd0, 0x2a, 0x3e, 0xdf, 0x4d, 0xdc, 0x42, 0x5d, 0x37, 0xcf, 0xd9,
+	0x49, 0x53, 0x4e, 0x1f, 0x6f, 0x43, 0x25, 0x60, 0x33, 0xc9, 0x73, 0xda, 0xd4, 0xa9, 0x39, 0x13,
+	0xa2, 0x83, 0x24, 0x6c, 0x3d, 0x98, 0xae, 0x70, 0x57, 0xd2, 0x0a, 0x57, 0x53, 0xd2, 0xd2, 0x56,
+	0xda, 0x7a, 0x0a, 0x0d, 0x7d, 0xf0, 0xb3, 0xf8, 0x6a, 0x69, 0xc9, 0xe8, 0x62, 0x3b, 0x05, 0xf3,
+	0x21, 0xa6, 0xde, 0x1f, 0xbb, 0x7e, 0x17, 0xed, 0x31, 0xdf, 0x6c, 0x96, 0x7e, 0xf4, 0x69, 0x47,
+	0x6e, 0xb4, 0x80, 0x10, 0xdf, 0x73, 0x63, 0xd7, 0x93, 0xbb, 0x2c, 0x20, 0xae, 0x90, 0xf1, 0x28,
+	0x54, 0x59, 0x72, 0x09, 0x62, 0x0b, 0xed, 0xfb, 0x41, 0xc8, 0x54, 0x98, 0xb5, 0x08, 0xd0, 0xfa,
+	0x9e, 0x01, 0xeb, 0xa9, 0xa9, 0xe5, 0x16, 0xbc, 0x9b, 0xda, 0x82, 0x2b, 0x76, 0x11, 0xd1, 0x8f,
+	0x6d, 0xff, 0xf2, 0x8b, 0xd6, 0xa5, 0xf2, 0x08, 0x1a, 0x2f, 0x48, 0x14, 0x6f, 0x07, 0x22, 0x35,
+	0xd6, 0x94, 0x49, 0x1e, 0xcd, 0xf8, 0x31, 0x10, 0xd3, 0x24, 0x27, 0x34, 0x3e, 0x6c, 0xc7, 0x24,
+	0x8a, 0xa5, 0x54, 0x6a, 0x88, 0xc1, 0xfe, 0x11, 0xe6, 0x6b, 0x37, 0x94, 0x9f, 0xa3, 0x0f, 0x89,
+	0xe9, 0xbe, 0x02, 0x5f, 0x70, 0xcb, 0x2e, 0xa6, 0x9e, 0xe1, 0x10, 0xee, 0x9d, 0xc9, 0x21, 0x7c,
+	0x35, 0x2d, 0x84, 0x45, 0x5b, 0x9f, 0x42, 0x5f, 0xfe, 0x6f, 0x1a, 0xb0, 0xc6, 0xdb, 0x46, 0x43,
+	0x7d, 0x67, 0x6e, 0xa7, 0x76, 0xe6, 0xb2, 0x5d, 0x40, 0x93, 0xdb, 0x98, 0x67, 0xd3, 0x37, 0xe6,
+	0xad, 0x34, 0x4f, 0xe7, 0x27, 0xac, 0x5f, 0xe7, 0x8e, 0xc2, 0x22, 0x16, 0xba, 0xf6, 0x8f, 0xc8,
+	0x09, 0xd7, 0xd6, 0x54, 0xde, 0x26, 0x55, 0xf4, 0xdb, 0x80, 0x85, 0xe8, 0x88, 0x9c, 0x08, 0x3f,
+	0x66, 0xde, 0x11, 0x50, 0xda, 0xd8, 0x96, 0x0b, 0x3c, 0xc4, 0x32, 0xf7, 0x10, 0xff, 0xdb, 0x80,
+	0x65, 0x39, 0x97, 0x14, 0xc2, 0x45, 0xa8, 0xc5, 0x87, 0x21, 0x89, 0x0e, 0x03, 0xaf, 0x2b, 0x7c,
+	0xa7, 0x04, 0xa1, 0x9c, 0xe6, 0x92, 0x70, 0x9a, 0x33, 0xbd, 0x73, 0x46, 0xe4, 0x75, 0x75, 0xa9,
+	0x95, 0x45, 0xe5, 0x31, 0xb5, 0xb6, 0x69, 0x57, 0xda, 0x5c, 0xe1, 0x95, 0xf6, 0x68, 0xba, 0xbc,
+	0x5f, 0x4b, 0xcb, 0x3b, 0x3b, 0x9d, 0x26, 0xe6, 0xbf, 0x35, 0x00, 0xb6, 0x0f, 0x49, 0x18, 0x8e,
+	0x9f, 0xd1, 0xce, 0x11, 0x66, 0x8f, 0xb8, 0x11, 0x73, 0x65, 0x31, 0x52, 0xc1, 0xc8, 0x9c, 0xfc,
+	0xdd, 0x3e, 0x08, 0x5d, 0xbf, 0x23, 0x0b, 0xc0, 0x4b, 0x12, 0xfd, 0x80, 0x61, 0x31, 0x15, 0xa0,
+	0x08, 0x59, 0xf1, 0x92, 0xcb, 0xbf, 0x21, 0x91, 0xc8, 0x0c, 0x5a, 0xe9, 0x0e, 0x66, 0x27, 0x44,
+	0x22, 0x13, 0x7f, 0x63, 0xe2, 0x02, 0xff, 0xca, 0xd1, 0x79, 0x8a, 0x18, 0x10, 0x25, 0x46, 0xbe,
+	0x00, 0x35, 0x46, 0xc0, 0x46, 0x5d, 0xe0, 0x25, 0x51, 0x44, 0xe0, 0x88, 0xd6, 0x2e, 0x2c, 0x3e,
+	0x70, 0x3b, 0x47, 0xc3, 0x20, 0x8c, 0x95, 0xef, 0xdb, 0xa3, 0xa7, 0x44, 0xe6, 0xf9, 0x38, 0xc0,
+	0xf3, 0x19, 0x5d, 0xea, 0xfa, 0x6d, 0xcf, 0x8d, 0x89, 0xdf, 0x19, 0x0b, 0xef, 0x77, 0x91, 0x63,
+	0x77, 0x39, 0xd2, 0xfa, 0x85, 0x12, 0x98, 0x89, 0x60, 0xd4, 0x0d, 0x3b, 0x59, 0x0b, 0x31, 0x82,
+	0xc4, 0x43, 0xd2, 0x71, 0x63, 0xa5, 0x89, 0x1a, 0x06, 0x1d, 0xcb, 0xa1, 0x4b, 0x43, 0x79, 0x47,
+	0xd6, 0xed, 0x64, 0x74, 0x87, 0xb7, 0xa0, 0x87, 0x7b, 0x20, 0x56, 0x20, 0xd3, 0x70, 0x96, 0x9d,
+	0x67, 0xc2, 0x96, 0xcb, 0x94, 0x1e, 0xae, 0xea, 0xd4, 0xda, 0x85, 0xa5, 0x74, 0x63, 0x81, 0x81,
+	0xc8, 0x29, 0x47, 0x4a, 0x6a, 0xba, 0x72, 0x7c, 0x0e, 0x35, 0xcc, 0xdb, 0x28, 0x69, 0x72, 0x27,
+	0xc5, 0x98, 0x90, 0x85, 0x2a, 0xa5, 0xb3, 0x50, 0x9a, 0x35, 0x2d, 0xa7, 0xac, 0xa9, 0xf5, 0x2f,
+	0x06, 0x2c, 0xec, 0x90, 0xe3, 0x1d, 0x77, 0x3c, 0x45, 0x9c, 0x9b, 0x32, 0x40, 0x93, 0x19, 0x38,
+	0xc5, 0x89, 0x88, 0xcc, 0x8a, 0x43, 0x72, 0xf3, 0x3d, 0x3d, 0x4a, 0x98, 0x13, 0x3e, 0x10, 0x9f,
+	0x6d, 0x4a, 0x64, 0xf0, 0xf8, 0x0c, 0x91, 0x41, 0x2e, 0x27, 0xa8, 0x71, 0x94, 0xc8, 0x2c, 0x82,
+	0xca, 0x8e, 0x3b, 0xde, 0x21, 0xc7, 0x78, 0xea, 0xe7, 0xba, 0xe4, 0x58, 0x1a, 0x52, 0xd3, 0x16,
+	0x78, 0xe4, 0x46, 0x59, 0x07, 0x72, 0x1c, 0xb5, 0xee, 0x41, 0x4d, 0xa1, 0x0a, 0x0e, 0xf3, 0xa5,
+	0xf4, 0xbc, 0x15, 0xb1, 0x1a, 0x7d, 0xd2, 0xdf, 0x37, 0x60, 0x0d, 0x87, 0xc8, 0x66, 0xc9, 0xb3,
+	0xa6, 0xbc, 0x80, 0x26, 0x67, 0xab, 0x2e, 0x40, 0xad, 0x4b, 0x8e, 0xdb, 0xb2, 0xc2, 0xcf, 0x32,
+	0xc8, 0x5d, 0x72, 0x8c, 0x11, 0xdf, 0x69, 0xeb, 0xfe, 0x74, 0xbb, 0x73, 0x39, 0xcd, 0x6a, 0x55,
+	0x2e, 0x59, 0xe7, 0xf5, 0x07, 0x06, 0x54, 0x5e, 0x8c, 0x87, 0xc1, 0xc7, 0xf4, 0x14, 0xb7, 0xf0,
+	0x24, 0x0c, 0xfc, 0xbe, 0x7c, 0xf8, 0xc0, 0x00, 0xae, 0x14, 0x21, 0x5e, 0x10, 0xc2, 0xc0, 0x48,
+	0x70, 0xd2, 0xab, 0x87, 0xc2, 0xaa, 0x88, 0x09, 0x73, 0xac, 0x6e, 0xc1, 0x93, 0xa6, 0xec, 0x37,
+	0xf6, 0x17, 0xc5, 0x21, 0x51, 0x63, 0xe2, 0x10, 0xd3, 0x6d, 0x56, 0x13, 0xe2, 0x85, 0x25, 0x0e,
+	0x58, 0xb7, 0x61, 0x45, 0x30, 0x9a, 0x24, 0x14, 0x2f, 0xeb, 0x36, 0x05, 0x57, 0x28, 0x28, 0x84,
+	0x75, 0xb1, 0xb6, 0x61, 0x55, 0x24, 0xa8, 0x1d, 0x8c, 0xd0, 0xf9, 0xd1, 0xd1, 0x73, 0xf2, 0x5c,
+	0x5a, 0x0a, 0xe6, 0x76, 0xb0, 0x2b, 0x5d, 0x5d, 0xf6, 0xdb, 0xfa, 0x23, 0x03, 0xce, 0x49, 0x75,
+	0xd4, 0x47, 0x8b, 0xcc, 0xed, 0x7c, 0x0c, 0x7c, 0xcd, 0x2e, 0x24, 0x9d, 0xa2, 0xec, 0xcf, 0xce,
+	0xa0, 0xec, 0xb9, 0x3c, 0x4e, 0x6e, 0x55, 0xfa, 0x9e, 0xfe, 0x86, 0x01, 0x6b, 0x3a, 0xc1, 0x24,
+	0xfd, 0x2b, 0xa0, 0xc9, 0xb9, 0x12, 0x9f, 0x4d, 0x57, 0xb1, 0x9b, 0x69, 0xc6, 0x36, 0x8a, 0x57,
+	0x9f, 0xc9, 0x88, 0x98, 0x3c, 0xe9, 0x2b, 0x2a, 0x34, 0xb3, 0xfc, 0x89, 0x75, 0x98, 0x8f, 0x3a,
+	0xb2, 0x00, 0x5a, 0x72, 0x38, 0x80, 0xb7, 0x5a, 0x3f, 0x08, 0xba, 0xed, 0x68, 0x74, 0x80, 0x0f,
+	0x2b, 0xa4, 0xd9, 0x69, 0x20, 0x72, 0x5f, 0xe0, 0x98, 0x82, 0x05, 0x5d, 0xaa, 0x32, 0xf8, 0x02,
+	0xc2, 0xcb, 0x81, 0x0e, 0x86, 0x24, 0x74, 0x63, 0x7a, 0x2c, 0x55, 0x52, 0xc3, 0xa0, 0x83, 0x49,
+	0xa3, 0x68, 0x44, 0xda, 0x21, 0xe9, 0xc9, 0x47, 0x4d, 0x35, 0x86, 0x71, 0x48, 0x2f, 0xc2, 0xcb,
+	0xe8, 0x5c, 0x6a, 0x09, 0x4a, 0x1f, 0xef, 0x41, 0xf5, 0x8b, 0x91, 0x1b, 0xb2, 0xda, 0x9f, 0xac,
+	0x4c, 0x15, 0x52, 0xda, 0xcf, 0x05, 0x99, 0x28, 0xe2, 0xc8, 0x5e, 0xe6, 0x8d, 0x4c, 0xc0, 0xbd,
+	0x66, 0xe7, 0x85, 0xf5, 0xf2, 0x31, 0xf7, 0x33, 0x58, 0x4c, 0x4d, 0x78, 0x96, 0xc4, 0x56, 0xc1,
+	0xbc, 0xda, 0x36, 0xde, 0x83, 0x95, 0xed, 0xc3, 0x51, 0xe8, 0xf3, 0xe8, 0x86, 0xef, 0xa1, 0x09,
+	0x73, 0x11, 0xf1, 0x7a, 0x62, 0x03, 0xd9, 0x6f, 0xdc, 0x57, 0x3c, 0xd3, 0xb4, 0x2f, 0x53, 0x15,
+	0x12, 0xb4, 0x7e, 0xcb, 0x80, 0xf5, 0x1d, 0x72, 0x4c, 0xbc, 0x60, 0x48, 0x42, 0x6d, 0x2c, 0xf3,
+	0x0e, 0x2c, 0x0c, 0x02, 0x3f, 0x3e, 0x94, 0x22, 0xbc, 0x6a, 0x17, 0x91, 0xd9, 0x7b, 0x8c, 0x46,
+	0xc4, 0xb2, 0xbc, 0x43, 0x6b, 0x17, 0xea, 0x1a, 0xba, 0x60, 0x95, 0xd7, 0xd3, 0xab, 0x5c, 0xb5,
+	0xb3, 0x8b, 0xd0, 0xd7, 0xe8, 0x81, 0xa9, 0x35, 0xcb, 0x3d, 0x4e, 0x5e, 0xe5, 0xc8, 0x78, 0xb5,
+	0x88, 0xbd, 0x69, 0x7b, 0x54, 0x2a, 0xda, 0x23, 0x4c, 0x66, 0xac, 0x61, 0xea, 0x71, 0x97, 0xf6,
+	0x48, 0x67, 0xdc, 0x61, 0xaf, 0x1a, 0x7c, 0xae, 0xc4, 0xf8, 0x2a, 0xe7, 0x98, 0xc8, 0xb8, 0x90,
+	0x43, 0xa8, 0xc4, 0x03, 0x97, 0xfa, 0xb1, 0x4b, 0xfd, 0xc4, 0xc3, 0x49, 0x30, 0x2c, 0x6e, 0x0c,
+	0x83, 0x2f, 0x89, 0x2f, 0x8e, 0x86, 0x80, 0xd0, 0x97, 0x76, 0x0f, 0x5c, 0xbf, 0x1b, 0xf8, 0x2a,
+	0x3e, 0x4c, 0x10, 0xd6, 0x9f, 0xe2, 0xdd, 0x25, 0xc3, 0x01, 0xc5, 0x4a, 0x64, 0x3e, 0x2a, 0x8a,
+	0x9c, 0xae, 0xd9, 0x05, 0xa4, 0x33, 0xc2, 0xa6, 0x17, 0x67, 0x0a, 0x9b, 0xde, 0x4c, 0xef, 0xd3,
+	0xba, 0x5d, 0x20, 0x19, 0x7d, 0xab, 0x7e, 0xb5, 0x04, 0xeb, 0x29, 0x12, 0xb9, 0x5b, 0xef, 0xa7,
+	0xf3, 0xc1, 0x9b, 0x76, 0x11, 0x55, 0x3e, 0x0f, 0xac, 0x02, 0xe2, 0x92, 0x08, 0x88, 0x0b, 0xbb,
+	0x65, 0x8d, 0xe5, 0x07, 0x33, 0x92, 0xc7, 0xa9, 0x4c, 0x4a, 0x4d, 0xcf, 0x2f, 0xec, 0x4d, 0x37,
+	0xb3, 0x39, 0x71, 0x14, 0xc8, 0x5d, 0x17, 0xc7, 0x2f, 0x1a, 0xb0, 0x2e, 0x72, 0x4b, 0xcf, 0x42,
+	0x12, 0x45, 0xa3, 0x70, 0xa6, 0x99, 0xdd, 0xd4, 0xd3, 0xfa, 0x19, 0x7f, 0x4a, 0xa5, 0xf8, 0x0b,
+	0x3c, 0x3c, 0xe6, 0x72, 0x1e, 0x13, 0xee, 0x23, 0x0b, 0x97, 0x93, 0x81, 0xd6, 0xaf, 0x1b, 0xb0,
+	0x91, 0x61, 0x42, 0xee, 0x4a, 0x2b, 0x95, 0x19, 0x63, 0x57, 0xb0, 0x84, 0xcd, 0x37, 0x52, 0x92,
+	0x3f, 0x67, 0x17, 0xad, 0x43, 0x38, 0x47, 0xef, 0x40, 0xf5, 0xc0, 0x8d, 0x08, 0x73, 0x2c, 0xe4,
+	0xfb, 0xbb, 0x42, 0x72, 0x45, 0x66, 0x3d, 0x61, 0x65, 0xee, 0xa1, 0xeb, 0x8f, 0xef, 0xc7, 0x71,
+	0x48, 0x0f, 0x46, 0x49, 0xa9, 0x63, 0xea, 0x15, 0x94, 0x2f, 0x79, 0x58, 0xbf, 0x6b, 0xc0, 0x92,
+	0x18, 0x4b, 0x18, 0x57, 0xf3, 0x9b, 0x18, 0x11, 0x21, 0x86, 0x92, 0xd4, 0x35, 0xab, 0xd1, 0x08,
+	0x50, 0x1d, 0x8e, 0xa4, 0x43, 0xeb, 0xdb, 0xb0, 0x94, 0x6e, 0x2c, 0x50, 0xa1, 0x5c, 0xe1, 0x6d,
+	0xc2, 0x6a, 0x32, 0xd5, 0xcc, 0x57, 0xf2, 0x64, 0x72, 0x2f, 0x76, 0x72, 0x77, 0xd6, 0x96, 0x3d,
+	0x91, 0x7a, 0xd2, 0xbd, 0xd5, 0xda, 0x9d, 0x7d, 0xc3, 0xe4, 0x32, 0x64, 0x69, 0xc1, 0xe8, 0x1c,
+	0x87, 0xb0, 0xf2, 0x80, 0xfa, 0x6e, 0x38, 0x66, 0x16, 0x35, 0xd9, 0x1e, 0xf5, 0xe8, 0x47, 0x8b,
+	0x60, 0x22, 0x0c, 0x54, 0x59, 0xf8, 0xd3, 0x3e, 0x18, 0xc7, 0x62, 0x93, 0xca, 0x0e, 0x30, 0xd4,
+	0x03, 0xc4, 0xa0, 0xb3, 0x20, 0xe2, 0x20, 0x41, 0x22, 0x42, 0x60, 0x81, 0x64, 0x44, 0xd6, 0x9f,
+	0x1b, 0xb0, 0xa1, 0x4d, 0xaa, 0x19, 0xa9, 0x49, 0x69, 0xa3, 0x62, 0xea, 0x19, 0xf6, 0xef, 0xf9,
+	0x99, 0xec, 0x5f, 0xee, 0x9e, 0xca, 0x8a, 0x43, 0x97, 0xd6, 0x5d, 0x68, 0xf0, 0xe6, 0xfb, 0x51,
+	0x44, 0xe2, 0xd4, 0xab, 0xbc, 0xf4, 0xbb, 0x05, 0x5d, 0x3e, 0x1c, 0xb0, 0x7e, 0xaf, 0x04, 0xa6,
+	0x36, 0xb6, 0x54, 0x8a, 0x6f, 0x64, 0xee, 0xe0, 0x2b, 0x76, 0x9e, 0xa8, 0xe8, 0x06, 0x36, 0xef,
+	0x42, 0xa5, 0x33, 0x0a, 0xc5, 0x2b, 0x4a, 0x6e, 0x71, 0x0b, 0x7a, 0x6e, 0x73, 0x12, 0xde, 0x55,
+	0x76, 0x68, 0x39, 0xb3, 0x6e, 0xef, 0x5c, 0xe2, 0xaa, 0x78, 0x07, 0x74, 0xc3, 0xfa, 0x04, 0x1a,
+	0xfa, 0x64, 0x67, 0xc9, 0xd0, 0xe9, 0xb2, 0xd4, 0xc5, 0xfc, 0x05, 0xac, 0x39, 0xea, 0x05, 0xfd,
+	0x3e, 0xfd, 0x92, 0xec, 0xa7, 0x03, 0xdf, 0xd9, 0xd2, 0x4e, 0x0c, 0x49, 0x59, 0xaf, 0xff, 0x35,
+	0xa1, 0x72, 0xc8, 0x4b, 0x87, 0x22, 0x0f, 0x26, 0x41, 0xeb, 0x01, 0xac, 0xa7, 0xa7, 0xdc, 0x56,
+	0x11, 0x16, 0x7b, 0xf2, 0x6f, 0x68, 0x4f, 0xfe, 0x37, 0xd8, 0x9b, 0xdd, 0x93, 0xf8, 0x50, 0x4c,
+	0x29, 0x20, 0xeb, 0x9f, 0x4b, 0x70, 0x2e, 0x3d, 0xc8, 0xc4, 0x97, 0x01, 0x45, 0x54, 0xb9, 0x88,
+	0xf4, 0x3d, 0x98, 0x8b, 0xdd, 0x7e, 0xd4, 0x2c, 0x4d, 0xed, 0xf5, 0xc2, 0xed, 0xcb, 0x5e, 0x48,
+	0x6d, 0xbe, 0x0f, 0xf5, 0x38, 0x18, 0xb6, 0xf5, 0x07, 0x4f, 0xdc, 0x5a, 0xe7, 0x57, 0xe7, 0x40,
+	0x1c, 0x0c, 0xf9, 0xcf, 0xe8, 0xa5, 0x2f, 0xc6, 0x82, 0x1d, 0xca, 0xdc, 0xb3, 0x8a, 0xb3, 0xb3,
+	0xb8, 0x1d, 0xd3, 0x87, 0xb3, 0xfe, 0xb1, 0x04, 0x2b, 0x0e, 0xe9, 0xb9, 0x4c, 0xf1, 0x64, 0x22,
+	0xff, 0x06, 0xac, 0x92, 0xd3, 0x18, 0x9f, 0x52, 0x93, 0x6e, 0x7b, 0x40, 0xe2, 0xc3, 0xa0, 0x2b,
+	0x95, 0x63, 0x45, 0x35, 0xec, 0x71, 0x3c, 0xba, 0x87, 0x21, 0xc1, 0xf2, 0x54, 0x42, 0xca, 0x2f,
+	0x99, 0x25, 0x81, 0x2e, 0x20, 0xec, 0x78, 0x6e, 0x14, 0xa9, 0x7b, 0x58, 0x12, 0x6e, 0x73, 0x2c,
+	0x7b, 0xfa, 0x13, 0x1c, 0x6b, 0x64, 0x73, 0xe2, 0xe9, 0x4f, 0x70, 0x9c, 0x10, 0xdd, 0x80, 0xd5,
+	0x30, 0xe1, 0x9b, 0xbf, 0xeb, 0x11, 0x81, 0xd0, 0x8a, 0xd6, 0xc0, 0x9e, 0xf5, 0xe0, 0x88, 0x22,
+	0x59, 0x24, 0x08, 0x79, 0x44, 0xd4, 0x10, 0x48, 0x4e, 0xa4, 0xdd, 0x9e, 0x95, 0xf4, 0xed, 0xf9,
+	0x36, 0xac, 0xe9, 0x73, 0x49, 0x2a, 0xfe, 0xc2, 0xc9, 0xd4, 0x9a, 0xc4, 0x9e, 0x5b, 0xff, 0x6e,
+	0x80, 0xa9, 0x49, 0x55, 0xaa, 0xeb, 0x3b, 0x29, 0x75, 0xbd, 0x64, 0xe7, 0x49, 0x72, 0xba, 0xfa,
+	0x46, 0x26, 0x9a, 0x5a, 0xb5, 0xb3, 0xbb, 0xf5, 0xf2, 0xb1, 0xd4, 0x27, 0xd3, 0x35, 0x32, 0x67,
+	0xb9, 0x73, 0x33, 0x66, 0x22, 0x8c, 0xe0, 0x98, 0x84, 0x18, 0x30, 0xa7, 0x6f, 0x3a, 0xc4, 0x6a,
+	0x95, 0x0f, 0x0e, 0xa2, 0xef, 0x3e, 0xf2, 0x65, 0x9b, 0x28, 0x7c, 0x28, 0x04, 0x46, 0x04, 0x23,
+	0x7f, 0x40, 0x5c, 0xf4, 0x7b, 0x64, 0x9a, 0x4f, 0xc3, 0x58, 0xff, 0x65, 0xc0, 0x7a, 0x6a, 0xba,
+	0x49, 0xd5, 0x9f, 0x22, 0xa2, 0x9c, 0x6c, 0x8b, 0x22, 0xd5, 0xec, 0x52, 0x5e, 0x5e, 0xba, 0x2f,
+	0x5b, 0x53, 0x2a, 0x98, 0x53, 0x93, 0xef, 0x77, 0x4b, 0xd0, 0xd8, 0x21, 0x3d, 0xd2, 0x89, 0x23,
+	0x55, 0x64, 0x63, 0x71, 0xbc, 0x2a, 0xb2, 0x71, 0x08, 0x5d, 0x88, 0x1e, 0x3d, 0x55, 0xba, 0x29,
+	0xa2, 0xa9, 0x1e, 0x3d, 0xdd, 0xce, 0xba, 0x80, 0x65, 0xfd, 0xd5, 0xcb, 0x75, 0x58, 0x19, 0x10,
+	0x97, 0x7f, 0xe1, 0xd4, 0x8e, 0x83, 0x76, 0x8f, 0xf2, 0x52, 0x46, 0x09, 0xf3, 0xd7, 0x2e, 0xfb,
+	0xd2, 0xe9, 0x05, 0x4b, 0xad, 0x7d, 0x08, 0x10, 0xa1, 0x5b, 0x4c, 0x63, 0x4a, 0x92, 0x67, 0xc1,
+	0x3a, 0x6b, 0xf6, 0xbe, 0x6a, 0xe7, 0x52, 0xd6, 0x3a, 0xb4, 0x3e, 0x84, 0xe5, 0x4c, 0xf3, 0x4b,
+	0xd5, 0x69, 0xff, 0xd5, 0x80, 0x25, 0x31, 0x97, 0xdc, 0xf2, 0x8f, 0x00, 0xd0, 0xf1, 0x0c, 0x7c,
+	0x91, 0x06, 0xe3, 0x1b, 0x9f, 0x26, 0xb2, 0xb7, 0x15, 0x85, 0x60, 0x29, 0xe9, 0xa2, 0x49, 0xb2,
+	0x94, 0x92, 0xe4, 0xab, 0xb0, 0xe8, 0x51, 0xff, 0x88, 0x74, 0xdb, 0xa2, 0x59, 0x24, 0x66, 0x38,
+	0xf2, 0x09, 0xc3, 0xb5, 0x76, 0x61, 0x39, 0x33, 0xf6, 0x59, 0x2e, 0x66, 0x5d, 0x5c, 0xfa, 0xf2,
+	0xc6, 0x70, 0xe1, 0xb3, 0x13, 0x9f, 0x84, 0xd1, 0x21, 0x1d, 0x6e, 0x07, 0x7e, 0x87, 0xf8, 0x71,
+	0xa8, 0x3d, 0x61, 0x4a, 0x3d, 0xba, 0x51, 0x5b, 0xb7, 0x01, 0x0b, 0x01, 0xeb, 0x24, 0xf9, 0xe7,
+	0x10, 0x5e, 0xad, 0x7d, 0xea, 0x53, 0xc6, 0x76, 0xc9, 0x61, 0xbf, 0xf1, 0x40, 0xca, 0xe7, 0x9b,
+	0x7c, 0x77, 0x25, 0x68, 0xfd, 0x93, 0x01, 0x57, 0x54, 0x2c, 0x56, 0xcc, 0x84, 0xb9, 0x5f, 0xe4,
+	0x3d, 0xbe, 0x63, 0xcf, 0xe8, 0x36, 0xc3, 0x8d, 0xfc, 0xd9, 0x33, 0xb9, 0x91, 0xb7, 0xd3, 0x22,
+	0xbc, 0x68, 0x4f, 0x91, 0x53, 0xa6, 0x0e, 0x75, 0xa9, 0x98, 0x54, 0xea, 0xcf, 0xe3, 0x5c, 0xd4,
+	0x70, 0xd3, 0x9e, 0xda, 0x63, 0x62, 0xe4, 0xf0, 0x73, 0xb3, 0x23, 0x87, 0xf7, 0xd3, 0xcb, 0xd8,
+	0x9c, 0x25, 0x3b, 0x7d, 0x29, 0xdf, 0x37, 0xa0, 0xfe, 0xb0, 0xd7, 0xd3, 0xcb, 0x50, 0x2f, 0x55,
+	0x38, 0xb9, 0x08, 0xb5, 0x68, 0x14, 0x1e, 0xd3, 0x63, 0xfc, 0xfe, 0xab, 0x2c, 0x9e, 0xe4, 0x4b,
+	0x04, 0x6a, 0x11, 0x61, 0x83, 0x0b, 0xc5, 0x10, 0x90, 0xf9, 0x06, 0xac, 0x28, 0xa2, 0xb6, 0xa0,
+	0x98, 0x67, 0x14, 0xcb, 0x0a, 0xcf, 0xb9, 0xb2, 0x7e, 0xc7, 0x80, 0x15, 0x75, 0x18, 0x38, 0x2e,
+	0x32, 0xef, 0x17, 0x1c, 0xcf, 0xab, 0x76, 0x96, 0x6c, 0xda, 0x01, 0x6d, 0x3d, 0x3d, 0xcb, 0x19,
+	0xcb, 0xbd, 0x75, 0xd7, 0x44, 0xa5, 0x4b, 0xf1, 0x47, 0x65, 0x38, 0xcf, 0x9b, 0x1e, 0x46, 0x31,
+	0x1d, 0xa4, 0x54, 0x61, 0x13, 0xeb, 0x84, 0x04, 0xdf, 0x66, 0x52, 0x74, 0xfb, 0xf9, 0x4b, 0x4e,
+	0x1d, 0x85, 0xe1, 0x3e, 0x39, 0xe5, 0x9c, 0x88, 0x2c, 0xae, 0x82, 0xd9, 0xb3, 0x07, 0x12, 0xd2,
+	0xa0, 0x2b, 0x8b, 0x08, 0x1c, 0x32, 0x3f, 0x82, 0x0a, 0xff, 0x25, 0xeb, 0x46, 0xd7, 0xec, 0x09,
+	0x0c, 0xd8, 0xcf, 0x38, 0x9d, 0x88, 0x26, 0x44, 0x2f, 0xf3, 0x71, 0x4a, 0x84, 0xf3, 0x22, 0x66,
+	0x9b, 0x34, 0xc6, 0x34, 0x53, 0x67, 0xc9, 0xca, 0xf5, 0x42, 0x91, 0x90, 0x58, 0x53, 0x6b, 0x0f,
+	0x1a, 0x3a, 0x1b, 0x67, 0x4a, 0x3d, 0x66, 0x76, 0x33, 0xfd, 0xde, 0xe4, 0x27, 0xb8, 0x79, 0xbf,
+	0x9c, 0xbc, 0xed, 0x77, 0x88, 0xdb, 0x75, 0x0f, 0xa8, 0x47, 0xe3, 0xf1, 0xec, 0x62, 0x08, 0xaa,
+	0x3e, 0xf1, 0xb1, 0x00, 0xab, 0xac, 0x7c, 0x82, 0x60, 0xd5, 0x22, 0xf6, 0xc5, 0x87, 0xb8, 0x11,
+	0x19, 0xc0, 0xfa, 0x8c, 0x3d, 0x8f, 0xbf, 0x3f, 0x12, 0xd9, 0x45, 0x85, 0x40, 0xbb, 0x72, 0x41,
+	0x9d, 0xdd, 0x3c, 0x4b, 0xe6, 0x67, 0x45, 0xa6, 0xf2, 0x2d, 0x7b, 0x4a, 0x97, 0x19, 0x66, 0xf2,
+	0xa7, 0xcf, 0x64, 0x26, 0x8b, 0x92, 0x2a, 0x45, 0xd2, 0xd2, 0x85, 0xfa, 0x43, 0x9e, 0x54, 0xc9,
+	0x90, 0xc9, 0x33, 0xf1, 0x41, 0xca, 0xa3, 0x7a, 0xcd, 0x9e, 0x48, 0x99, 0xcb, 0x21, 0x7e, 0x3e,
+	0xdd, 0x01, 0xca, 0x59, 0xf4, 0x29, 0xb2, 0xd1, 0xd9, 0xfd, 0xda, 0x80, 0xc6, 0x7e, 0xec, 0x7a,
+	0xb2, 0x30, 0xa3, 0x8a, 0x74, 0x46, 0x41, 0x91, 0xae, 0xa4, 0x15, 0xe9, 0x84, 0x5f, 0x8f, 0x47,
+	0xb7, 0x2c, 0xcb, 0x7f, 0x03, 0xf9, 0xc5, 0x4b, 0x44, 0x7d, 0xf1, 0xbc, 0x74, 0xde, 0xe1, 0x80,
+	0x9e, 0xa6, 0x99, 0xcf, 0xa5, 0x69, 0x3c, 0xfc, 0x88, 0x80, 0xc3, 0x22, 0x88, 0x00, 0x44, 0xf1,
+	0x07, 0x27, 0xd6, 0x7d, 0x58, 0xd7, 0x59, 0xd4, 0x3e, 0x1b, 0xd0, 0x75, 0x94, 0x7f, 0xd2, 0xa7,
+	0x13, 0x26, 0x2a, 0x6b, 0x3d, 0x82, 0xc5, 0x17, 0xc1, 0x29, 0xed, 0x9c, 0x49, 0xbf, 0x5b, 0x50,
+	0x15, 0xdf, 0x43, 0x48, 0xf5, 0x56, 0xb0, 0xf5, 0xdd, 0x32, 0x2c, 0xcb, 0x91, 0x26, 0x3d, 0xce,
+	0xce, 0xb4, 0xe7, 0x3c, 0xe4, 0xed, 0xb4, 0x36, 0x97, 0x84, 0x15, 0xcf, 0x75, 0x9b, 0xa6, 0xc1,
+	0xe6, 0x37, 0xa0, 0x32, 0x3c, 0x0c, 0xdd, 0x48, 0x3d, 0xe7, 0xbb, 0x94, 0x1b, 0xe0, 0x19, 0x6f,
+	0x97, 0xf6, 0x8f, 0x43, 0x2f, 0xff, 0x28, 0x45, 0x97, 0x9b, 0x6e, 0x8b, 0xbe, 0x75, 0xa6, 0x33,
+	0x34, 0xd1, 0xfb, 0x6c, 0xdd, 0x85, 0x86, 0xce, 0xe1, 0x4b, 0x79, 0xae, 0x5f, 0x19, 0xb0, 0xfa,
+	0xf1, 0xc8, 0x67, 0x5f, 0x25, 0x27, 0x29, 0x97, 0x8b, 0x50, 0xeb, 0x09, 0xa4, 0xdc, 0xd5, 0x04,
+	0x31, 0xe1, 0x81, 0xfa, 0x06, 0x2c, 0xf0, 0x27, 0x25, 0xb2, 0x1c, 0xc2, 0x21, 0xe4, 0x66, 0x78,
+	0xe7, 0x96, 0x7c, 0xa2, 0x3e, 0xbc, 0x73, 0x4b, 0x3e, 0x49, 0x9a, 0x4f, 0x1e, 0xad, 0xeb, 0x15,
+	0x60, 0x9d, 0x9b, 0x19, 0x15, 0xe0, 0x14, 0xe9, 0x4f, 0xba, 0x02, 0x9c, 0x93, 0x8a, 0x2e, 0xb6,
+	0x5f, 0x31, 0x60, 0x79, 0x37, 0xc0, 0x53, 0x17, 0x4b, 0xba, 0x49, 0x07, 0x9e, 0x3d, 0x93, 0x2d,
+	0x69, 0xcf, 0x64, 0x8b, 0x23, 0x9d, 0xe2, 0xc3, 0xfe, 0x2a, 0xc8, 0x8f, 0xb5, 0xc5, 0x67, 0x46,
+	0x5c, 0x68, 0x0d, 0x81, 0x64, 0x9f, 0x19, 0x59, 0x7f, 0x83, 0x85, 0x2d, 0x8d, 0xdb, 0x49, 0xe5,
+	0xe8, 0x02, 0x9a, 0xdc, 0x91, 0x7a, 0x13, 0x2a, 0x1e, 0x5f, 0x97, 0x7a, 0x90, 0x9c, 0x59, 0xa7,
+	0x23, 0x09, 0xfe, 0xcf, 0xa5, 0xeb, 0xd4, 0xb6, 0xe9, 0x52, 0x25, 0xb0, 0xfa, 0x29, 0x89, 0x62,
+	0xea, 0xf7, 0x77, 0xc8, 0x30, 0x3e, 0x9c, 0xf4, 0x81, 0x04, 0x56, 0x9d, 0xbd, 0xa0, 0x73, 0xa4,
+	0x22, 0x0b, 0x0e, 0x9d, 0xf9, 0x13, 0x89, 0x8f, 0x60, 0x4d, 0x9f, 0x46, 0x7e, 0x23, 0xb1, 0x95,
+	0xfe, 0x46, 0xc2, 0xb4, 0x73, 0xbc, 0xc8, 0x8f, 0x24, 0xbe, 0x2e, 0xa5, 0x47, 0x48, 0xde, 0x80,
+	0xa7, 0x6a, 0x61, 0x57, 0xec, 0x02, 0xa2, 0x82, 0x52, 0xd8, 0x0e, 0x00, 0xf5, 0x3b, 0x21, 0x71,
+	0x23, 0xfe, 0x2f, 0x10, 0xf8, 0x8d, 0x56, 0xd4, 0xf7, 0x89, 0x22, 0xe3, 0x03, 0x68, 0xfd, 0x5a,
+	0x9f, 0xce, 0xa8, 0x8d, 0xe5, 0x52, 0x6f, 0x05, 0x32, 0xd0, 0xad, 0xca, 0x87, 0xb0, 0x9c, 0x99,
+	0xee, 0xa5, 0x0c, 0xcb, 0x3f, 0x18, 0xf2, 0xff, 0x6b, 0xc8, 0xcf, 0xf0, 0xce, 0xfe, 0xad, 0x60,
+	0x71, 0x21, 0x6c, 0x33, 0x6d, 0xed, 0xf9, 0x86, 0xea, 0xa8, 0xe4, 0x64, 0xcd, 0xeb, 0x27, 0x4b,
+	0x0b, 0x2e, 0x17, 0x52, 0xc1, 0xa5, 0xf9, 0x16, 0x98, 0x7e, 0x10, 0x0e, 0x5c, 0x8f, 0x7e, 0x49,
+	0xba, 0x99, 0x0f, 0x08, 0x57, 0x93, 0x16, 0xb1, 0x00, 0x2b, 0x90, 0x0f, 0x2b, 0x04, 0x62, 0x56,
+	0x55, 0xeb, 0x2a, 0x34, 0x58, 0xf2, 0x42, 0x0e, 0xcc, 0x3d, 0xf3, 0x3a, 0xe2, 0xa4, 0x4c, 0xd0,
+	0x9b, 0xeb, 0xb8, 0x71, 0x4c, 0x92, 0x84, 0x52, 0x82, 0xb0, 0xfe, 0x8a, 0xe5, 0x93, 0xb4, 0x19,
+	0xa5, 0xa2, 0x6d, 0x65, 0xbf, 0xf3, 0x5b, 0xb2, 0xd3, 0x74, 0x8a, 0x87, 0x6c, 0x99, 0xb5, 0x68,
+	0xb8, 0x1f, 0xfb, 0xdd, 0x71, 0x5e, 0x2a, 0xba, 0x26, 0x3c, 0xc5, 0xe7, 0xa6, 0xf8, 0x05, 0x88,
+	0x47, 0xa2, 0x68, 0x96, 0xcc, 0x2e, 0x03, 0xc4, 0x8a, 0x58, 0xa6, 0x89, 0x12, 0x0c, 0x3e, 0x5e,
+	0x6d, 0x26, 0xa3, 0xf1, 0x89, 0x95, 0x1f, 0xf3, 0x61, 0xa6, 0xa8, 0x72, 0xcd, 0x9e, 0x44, 0x5a,
+	0x58, 0x5a, 0xc9, 0x7f, 0x8b, 0x91, 0xe1, 0xfb, 0xe5, 0xb3, 0x6d, 0x4f, 0x67, 0x55, 0x5c, 0x72,
+	0x5f, 0x63, 0x64, 0xa7, 0xd4, 0x04, 0xf9, 0x67, 0x06, 0xd4, 0xb8, 0x27, 0xb7, 0x4f, 0x98, 0x77,
+	0x38, 0x20, 0x61, 0x5f, 0xde, 0x37, 0x1c, 0xe0, 0xb7, 0x70, 0xd8, 0x57, 0x1f, 0x39, 0x09, 0x28,
+	0xfd, 0x2d, 0x74, 0xb6, 0xf6, 0x5a, 0xfc, 0x11, 0x6e, 0xc1, 0x51, 0x6a, 0x41, 0xb5, 0x3b, 0xe2,
+	0x29, 0x00, 0xf9, 0x1e, 0x55, 0xc2, 0x38, 0x03, 0xff, 0x98, 0x56, 0xe5, 0xa7, 0x05, 0x68, 0x7d,
+	0x55, 0x82, 0x25, 0xc5, 0x37, 0x57, 0x00, 0x7c, 0xfa, 0xca, 0x30, 0xed, 0x88, 0x24, 0x5f, 0x5e,
+	0x74, 0x24, 0x91, 0x38, 0x3b, 0xfc, 0xd9, 0xaa, 0xe6, 0x6a, 0xd4, 0x39, 0x8e, 0x7f, 0x5d, 0x7b,
+	0x01, 0x6a, 0xc3, 0x3b, 0xb7, 0xda, 0xfa, 0x5d, 0x5a, 0x1d, 0xde, 0xb9, 0xb5, 0x2b, 0x12, 0x87,
+	0xcb, 0xa2, 0xbf, 0x62, 0x98, 0x97, 0x7e, 0xc4, 0x6b, 0xd8, 0x1d, 0xc9, 0xf6, 0x55, 0x68, 0xe0,
+	0x28, 0x8a, 0x4a, 0xfc, 0xff, 0xa5, 0xe1, 0x9d, 0x5b, 0x3a, 0x09, 0x3b, 0xc7, 0x72, 0x79, 0x0b,
+	0xc9, 0x39, 0xbe, 0xcf, 0x51, 0x8c, 0x64, 0xe4, 0xc5, 0x54, 0xd0, 0x08, 0x09, 0xd4, 0x19, 0x8e,
+	0xd3, 0x58, 0x7f, 0x6d, 0xc0, 0xaa, 0x92, 0x82, 0xf6, 0xff, 0x3a, 0x32, 0x82, 0x28, 0xb3, 0xb7,
+	0x01, 0x8a, 0x30, 0x25, 0x94, 0xf7, 0x95, 0x7e, 0x97, 0x64, 0xe9, 0x3b, 0x3b, 0x60, 0xe1, 0xab,
+	0x9d, 0x4f, 0x66, 0x69, 0x61, 0xbe, 0x72, 0x9c, 0xda, 0x2e, 0x5d, 0x09, 0x5d, 0x58, 0xdc, 0x76,
+	0x23, 0xb2, 0x1d, 0x78, 0x1e, 0x65, 0xff, 0x1d, 0x6b, 0x1d, 0x1f, 0xfa, 0xca, 0x33, 0x57, 0x73,
+	0x38, 0xc0, 0x5e, 0x80, 0xa1, 0x3d, 0xe8, 0x8e, 0x3a, 0x42, 0x17, 0x6b, 0x8e, 0x86, 0xe1, 0x0f,
+	0x14, 0xa2, 0xc0, 0x3b, 0x26, 0x32, 0x2f, 0xa1, 0x60, 0xeb, 0x11, 0x9c, 0x4b, 0x4d, 0x11, 0x25,
+	0xf1, 0x01, 0x74, 0x14, 0x32, 0xb1, 0x7c, 0x3a, 0xad, 0xa3, 0x51, 0x58, 0xdf, 0x84, 0xda, 0xc3,
+	0xd3, 0x98, 0xf8, 0x8c, 0xcf, 0x57, 0xa0, 0x1a, 0x8f, 0x87, 0xa4, 0x3d, 0x0a, 0xe5, 0x5b, 0xef,
+	0x0a, 0xc2, 0x9f, 0x87, 0x5e, 0xfa, 0x16, 0x6b, 0x88, 0xd5, 0x5a, 0x3f, 0x2a, 0xc1, 0x72, 0xf6,
+	0x85, 0xe9, 0x55, 0x58, 0x38, 0x24, 0x6e, 0x97, 0x84, 0xe2, 0x3f, 0xde, 0xd4, 0x6c, 0xf9, 0xff,
+	0xc3, 0x1c, 0xd1, 0x60, 0xde, 0xc5, 0x80, 0x08, 0x63, 0xf8, 0x38, 0xd9, 0xa6, 0xcc, 0x30, 0xf6,
+	0xb6, 0x20, 0x50, 0xff, 0x9f, 0x82, 0x83, 0xe6, 0x3d, 0x00, 0x22, 0x19, 0x96, 0xe1, 0xc8, 0x66,
+	0xae, 0xb7, 0x5a, 0x93, 0xe8, 0xaf, 0xf5, 0xe1, 0xff, 0x80, 0x42, 0x1b, 0x7c, 0xd6, 0x9d, 0xdd,
+	0x48, 0xd7, 0x72, 0x97, 0x33, 0x63, 0x9f, 0xe5, 0x5d, 0xb0, 0xea, 0xa2, 0x0d, 0x75, 0xb0, 0xc0,
+	0xfe, 0xc3, 0xda, 0xbb, 0xff, 0x3b, 0x00, 0xd5, 0xeb, 0xac, 0xea, 0x6d, 0x4d, 0x00, 0x00,
 }
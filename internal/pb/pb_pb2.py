@@ -19,7 +19,7 @@ DESCRIPTOR = _descriptor.FileDescriptor(
   name='pb.proto',
   package='',
   syntax='proto3',
-  serialized_pb=_b('\n\x08pb.proto\"\x90\x01\n\x08Metadata\x12\x0f\n\x07version\x18\x01 \x01(\x05\x12\x0c\n\x04hash\x18\x02 \x01(\t\x12\x12\n\nrepository\x18\x03 \x01(\t\x12\x17\n\x0f\x62\x65gin_unix_time\x18\x04 \x01(\x03\x12\x15\n\rend_unix_time\x18\x05 \x01(\x03\x12\x0f\n\x07\x63ommits\x18\x06 \x01(\x05\x12\x10\n\x08run_time\x18\x07 \x01(\x03\"*\n\x17\x42urndownSparseMatrixRow\x12\x0f\n\x07\x63olumns\x18\x01 \x03(\r\"\x7f\n\x14\x42urndownSparseMatrix\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x16\n\x0enumber_of_rows\x18\x02 \x01(\x05\x12\x19\n\x11number_of_columns\x18\x03 \x01(\x05\x12&\n\x04rows\x18\x04 \x03(\x0b\x32\x18.BurndownSparseMatrixRow\"\xed\x01\n\x17\x42urndownAnalysisResults\x12\x13\n\x0bgranularity\x18\x01 \x01(\x05\x12\x10\n\x08sampling\x18\x02 \x01(\x05\x12&\n\x07project\x18\x03 \x01(\x0b\x32\x15.BurndownSparseMatrix\x12$\n\x05\x66iles\x18\x04 \x03(\x0b\x32\x15.BurndownSparseMatrix\x12%\n\x06people\x18\x05 \x03(\x0b\x32\x15.BurndownSparseMatrix\x12\x36\n\x12people_interaction\x18\x06 \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\"}\n\x19\x43ompressedSparseRowMatrix\x12\x16\n\x0enumber_of_rows\x18\x01 \x01(\x05\x12\x19\n\x11number_of_columns\x18\x02 \x01(\x05\x12\x0c\n\x04\x64\x61ta\x18\x03 \x03(\x03\x12\x0f\n\x07indices\x18\x04 \x03(\x05\x12\x0e\n\x06indptr\x18\x05 \x03(\x03\"D\n\x07\x43ouples\x12\r\n\x05index\x18\x01 \x03(\t\x12*\n\x06matrix\x18\x02 \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\"\x1d\n\x0cTouchedFiles\x12\r\n\x05\x66iles\x18\x01 \x03(\x05\"\x7f\n\x16\x43ouplesAnalysisResults\x12\x1e\n\x0c\x66ile_couples\x18\x06 \x01(\x0b\x32\x08.Couples\x12 \n\x0epeople_couples\x18\x07 \x01(\x0b\x32\x08.Couples\x12#\n\x0cpeople_files\x18\x08 \x03(\x0b\x32\r.TouchedFiles\"o\n\nUASTChange\x12\x11\n\tfile_name\x18\x01 \x01(\t\x12\x12\n\nsrc_before\x18\x02 \x01(\t\x12\x11\n\tsrc_after\x18\x03 \x01(\t\x12\x13\n\x0buast_before\x18\x04 \x01(\t\x12\x12\n\nuast_after\x18\x05 \x01(\t\"7\n\x17UASTChangesSaverResults\x12\x1c\n\x07\x63hanges\x18\x01 \x03(\x0b\x32\x0b.UASTChange\"\xb4\x01\n\x0eShotnessRecord\x12\x15\n\rinternal_role\x18\x01 \x01(\t\x12\r\n\x05roles\x18\x02 \x03(\x05\x12\x0c\n\x04name\x18\x03 \x01(\t\x12\x0c\n\x04\x66ile\x18\x04 \x01(\t\x12/\n\x08\x63ounters\x18\x05 \x03(\x0b\x32\x1d.ShotnessRecord.CountersEntry\x1a/\n\rCountersEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\";\n\x17ShotnessAnalysisResults\x12 \n\x07records\x18\x01 \x03(\x0b\x32\x0f.ShotnessRecord\"\x1e\n\x0b\x46ileHistory\x12\x0f\n\x07\x63ommits\x18\x01 \x03(\t\"\x8b\x01\n\x18\x46ileHistoryResultMessage\x12\x33\n\x05\x66iles\x18\x01 \x03(\x0b\x32$.FileHistoryResultMessage.FilesEntry\x1a:\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1b\n\x05value\x18\x02 \x01(\x0b\x32\x0c.FileHistory:\x02\x38\x01\"=\n\tSentiment\x12\r\n\x05value\x18\x01 \x01(\x02\x12\x10\n\x08\x63omments\x18\x02 \x03(\t\x12\x0f\n\x07\x63ommits\x18\x03 \x03(\t\"\xa4\x01\n\x17\x43ommentSentimentResults\x12\x46\n\x10sentiment_by_day\x18\x01 \x03(\x0b\x32,.CommentSentimentResults.SentimentByDayEntry\x1a\x41\n\x13SentimentByDayEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.Sentiment:\x02\x38\x01\"\x8f\x01\n\x0f\x41nalysisResults\x12\x19\n\x06header\x18\x01 \x01(\x0b\x32\t.Metadata\x12\x30\n\x08\x63ontents\x18\x02 \x03(\x0b\x32\x1e.AnalysisResults.ContentsEntry\x1a/\n\rContentsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\x62\x06proto3')
+  serialized_pb=_b('\n\x08pb.proto\"\xfc\x01\n\x08Metadata\x12\x0f\n\x07version\x18\x01 \x01(\x05\x12\x0c\n\x04hash\x18\x02 \x01(\t\x12\x12\n\nrepository\x18\x03 \x01(\t\x12\x17\n\x0f\x62\x65gin_unix_time\x18\x04 \x01(\x03\x12\x15\n\rend_unix_time\x18\x05 \x01(\x03\x12\x0f\n\x07\x63ommits\x18\x06 \x01(\x05\x12\x10\n\x08run_time\x18\x07 \x01(\x03\x12 \n\x08\x66\x61ilures\x18\x08 \x03(\x0b\x32\x0e.CommitFailure\x12\x0f\n\x07partial\x18\t \x01(\x08\x12\x1d\n\x07skipped\x18\n \x03(\x0b\x32\x0c.SkippedItem\x12\x18\n\x07markers\x18\x0b \x03(\x0b\x32\x07.Marker\":\n\x06Marker\x12\r\n\x05label\x18\x01 \x01(\t\x12\x11\n\tunix_time\x18\x02 \x01(\x03\x12\x0e\n\x06\x63ommit\x18\x03 \x01(\t\"+\n\x0bSkippedItem\x12\x0c\n\x04item\x18\x01 \x01(\t\x12\x0e\n\x06reason\x18\x02 \x01(\t\"K\n\rCommitFailure\x12\x0e\n\x06\x63ommit\x18\x01 \x01(\t\x12\r\n\x05index\x18\x02 \x01(\x05\x12\x0c\n\x04item\x18\x03 \x01(\t\x12\r\n\x05\x65rror\x18\x04 \x01(\t\"*\n\x17\x42urndownSparseMatrixRow\x12\x0f\n\x07\x63olumns\x18\x01 \x03(\r\"\x7f\n\x14\x42urndownSparseMatrix\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x16\n\x0enumber_of_rows\x18\x02 \x01(\x05\x12\x19\n\x11number_of_columns\x18\x03 \x01(\x05\x12&\n\x04rows\x18\x04 \x03(\x0b\x32\x18.BurndownSparseMatrixRow\"\xec\x02\n\x17\x42urndownAnalysisResults\x12\x13\n\x0bgranularity\x18\x01 \x01(\x05\x12\x10\n\x08sampling\x18\x02 \x01(\x05\x12&\n\x07project\x18\x03 \x01(\x0b\x32\x15.BurndownSparseMatrix\x12$\n\x05\x66iles\x18\x04 \x03(\x0b\x32\x15.BurndownSparseMatrix\x12%\n\x06people\x18\x05 \x03(\x0b\x32\x15.BurndownSparseMatrix\x12\x36\n\x12people_interaction\x18\x06 \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\x12%\n\x06groups\x18\x07 \x03(\x0b\x32\x15.BurndownSparseMatrix\x12\'\n\x0b\x64irectories\x18\x08 \x03(\x0b\x32\x12.BurndownDirectory\x12\x16\n\x0einitial_commit\x18\t \x01(\t\x12\x15\n\rbackfill_days\x18\n \x01(\x05\"J\n\x11\x42urndownDirectory\x12%\n\x06matrix\x18\x01 \x01(\x0b\x32\x15.BurndownSparseMatrix\x12\x0e\n\x06parent\x18\x02 \x01(\x05\"}\n\x19\x43ompressedSparseRowMatrix\x12\x16\n\x0enumber_of_rows\x18\x01 \x01(\x05\x12\x19\n\x11number_of_columns\x18\x02 \x01(\x05\x12\x0c\n\x04\x64\x61ta\x18\x03 \x03(\x03\x12\x0f\n\x07indices\x18\x04 \x03(\x05\x12\x0e\n\x06indptr\x18\x05 \x03(\x03\"D\n\x07\x43ouples\x12\r\n\x05index\x18\x01 \x03(\t\x12*\n\x06matrix\x18\x02 \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\"\x1d\n\x0cTouchedFiles\x12\r\n\x05\x66iles\x18\x01 \x03(\x05\"\xcf\x01\n\x16\x43ouplesAnalysisResults\x12\x1e\n\x0c\x66ile_couples\x18\x06 \x01(\x0b\x32\x08.Couples\x12 \n\x0epeople_couples\x18\x07 \x01(\x0b\x32\x08.Couples\x12#\n\x0cpeople_files\x18\x08 \x03(\x0b\x32\r.TouchedFiles\x12\x37\n\x13people_files_matrix\x18\t \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\x12\x15\n\rfile_clusters\x18\n \x03(\x05\"\xc2\x01\n\nUASTChange\x12\x11\n\tfile_name\x18\x01 \x01(\t\x12\x12\n\nsrc_before\x18\x02 \x01(\t\x12\x11\n\tsrc_after\x18\x03 \x01(\t\x12\x13\n\x0buast_before\x18\x04 \x01(\t\x12\x12\n\nuast_after\x18\x05 \x01(\t\x12\x0e\n\x06\x63ommit\x18\x06 \x01(\t\x12\x18\n\x10\x66ile_name_before\x18\x07 \x01(\t\x12\x13\n\x0bhash_before\x18\x08 \x01(\t\x12\x12\n\nhash_after\x18\t \x01(\t\"7\n\x17UASTChangesSaverResults\x12\x1c\n\x07\x63hanges\x18\x01 \x03(\x0b\x32\x0b.UASTChange\"\xf2\x01\n\x0eShotnessRecord\x12\x15\n\rinternal_role\x18\x01 \x01(\t\x12\r\n\x05roles\x18\x02 \x03(\x05\x12\x0c\n\x04name\x18\x03 \x01(\t\x12\x0c\n\x04\x66ile\x18\x04 \x01(\t\x12/\n\x08\x63ounters\x18\x05 \x03(\x0b\x32\x1d.ShotnessRecord.CountersEntry\x12\x12\n\nstart_line\x18\x06 \x01(\x05\x12\x10\n\x08\x65nd_line\x18\x07 \x01(\x05\x12\x16\n\x0e\x65nclosing_type\x18\x08 \x01(\t\x1a/\n\rCountersEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\";\n\x17ShotnessAnalysisResults\x12 \n\x07records\x18\x01 \x03(\x0b\x32\x0f.ShotnessRecord\"\x1e\n\x0b\x46ileHistory\x12\x0f\n\x07\x63ommits\x18\x01 \x03(\t\"\x8b\x01\n\x18\x46ileHistoryResultMessage\x12\x33\n\x05\x66iles\x18\x01 \x03(\x0b\x32$.FileHistoryResultMessage.FilesEntry\x1a:\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1b\n\x05value\x18\x02 \x01(\x0b\x32\x0c.FileHistory:\x02\x38\x01\"\x9a\x01\n\tSentiment\x12\r\n\x05value\x18\x01 \x01(\x02\x12\x10\n\x08\x63omments\x18\x02 \x03(\t\x12\x0f\n\x07\x63ommits\x18\x03 \x03(\t\x12*\n\x08\x65motions\x18\x04 \x03(\x0b\x32\x18.Sentiment.EmotionsEntry\x1a/\n\rEmotionsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x02:\x02\x38\x01\"\xa4\x01\n\x17\x43ommentSentimentResults\x12\x46\n\x10sentiment_by_day\x18\x01 \x03(\x0b\x32,.CommentSentimentResults.SentimentByDayEntry\x1a\x41\n\x13SentimentByDayEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.Sentiment:\x02\x38\x01\"\xff\x01\n\x0e\x43ommitFeatures\x12\x0e\n\x06\x63ommit\x18\x01 \x01(\t\x12\x0b\n\x03\x64\x61y\x18\x02 \x01(\x05\x12\x0c\n\x04hour\x18\x03 \x01(\x05\x12\r\n\x05\x66iles\x18\x04 \x01(\x05\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x05 \x01(\x05\x12\x0f\n\x07removed\x18\x06 \x01(\x05\x12\x13\n\x0b\x64ir_entropy\x18\x07 \x01(\x02\x12\x19\n\x11\x61uthor_experience\x18\x08 \x01(\x05\x12\x16\n\x0emessage_length\x18\t \x01(\x05\x12\x15\n\rmessage_lines\x18\n \x01(\x05\x12\x18\n\x10uast_nodes_added\x18\x0b \x01(\x05\x12\x1a\n\x12uast_nodes_removed\x18\x0c \x01(\x05\"9\n\x15\x43ommitFeaturesResults\x12 \n\x07\x63ommits\x18\x01 \x03(\x0b\x32\x0f.CommitFeatures\"i\n\x0eRolesHistogram\x12)\n\x05roles\x18\x01 \x03(\x0b\x32\x1a.RolesHistogram.RolesEntry\x1a,\n\nRolesEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x03:\x02\x38\x01\"\x98\x01\n\x17LanguageRolesHistograms\x12:\n\tlanguages\x18\x01 \x03(\x0b\x32\'.LanguageRolesHistograms.LanguagesEntry\x1a\x41\n\x0eLanguagesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1e\n\x05value\x18\x02 \x01(\x0b\x32\x0f.RolesHistogram:\x02\x38\x01\"\x8e\x01\n\x15RolesHistogramResults\x12.\n\x04\x64\x61ys\x18\x01 \x03(\x0b\x32 .RolesHistogramResults.DaysEntry\x1a\x45\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\'\n\x05value\x18\x02 \x01(\x0b\x32\x18.LanguageRolesHistograms:\x02\x38\x01\"D\n\x0fHalsteadMetrics\x12\x0e\n\x06volume\x18\x01 \x01(\x02\x12\x12\n\ndifficulty\x18\x02 \x01(\x02\x12\r\n\x05\x66iles\x18\x03 \x01(\x05\"\x93\x01\n\x11\x44irectoryHalstead\x12\x38\n\x0b\x64irectories\x18\x01 \x03(\x0b\x32#.DirectoryHalstead.DirectoriesEntry\x1a\x44\n\x10\x44irectoriesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1f\n\x05value\x18\x02 \x01(\x0b\x32\x10.HalsteadMetrics:\x02\x38\x01\"|\n\x0fHalsteadResults\x12(\n\x04\x64\x61ys\x18\x01 \x03(\x0b\x32\x1a.HalsteadResults.DaysEntry\x1a?\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12!\n\x05value\x18\x02 \x01(\x0b\x32\x12.DirectoryHalstead:\x02\x38\x01\"J\n\x10IndentationStats\x12\x0b\n\x03\x64\x61y\x18\x01 \x01(\x05\x12\r\n\x05lines\x18\x02 \x01(\x05\x12\r\n\x05total\x18\x03 \x01(\x05\x12\x0b\n\x03max\x18\x04 \x01(\x05\"6\n\x12IndentationHistory\x12 \n\x05stats\x18\x01 \x03(\x0b\x32\x11.IndentationStats\"\x9a\x01\n\x1cIndentationComplexityResults\x12\x37\n\x05\x66iles\x18\x01 \x03(\x0b\x32(.IndentationComplexityResults.FilesEntry\x1a\x41\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.IndentationHistory:\x02\x38\x01\"|\n\nStyleStats\x12\r\n\x05lines\x18\x01 \x01(\x03\x12\x14\n\x0ctab_indented\x18\x02 \x01(\x03\x12\x16\n\x0espace_indented\x18\x03 \x01(\x03\x12\x1b\n\x13trailing_whitespace\x18\x04 \x01(\x03\x12\x14\n\x0cline_lengths\x18\x05 \x03(\x03\"\x8a\x01\n\x12LanguageStyleStats\x12\x35\n\tlanguages\x18\x01 \x03(\x0b\x32\".LanguageStyleStats.LanguagesEntry\x1a=\n\x0eLanguagesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1a\n\x05value\x18\x02 \x01(\x0b\x32\x0b.StyleStats:\x02\x38\x01\"\x9d\x01\n\x11StyleDriftResults\x12\x1a\n\x12line_length_bucket\x18\x01 \x01(\x05\x12*\n\x04\x64\x61ys\x18\x02 \x03(\x0b\x32\x1c.StyleDriftResults.DaysEntry\x1a@\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.LanguageStyleStats:\x02\x38\x01\"1\n\x0fGofmtCompliance\x12\x0f\n\x07\x63hecked\x18\x01 \x01(\x05\x12\r\n\x05\x63lean\x18\x02 \x01(\x05\"\xc3\x01\n\x16GofmtComplianceResults\x12/\n\x04\x64\x61ys\x18\x01 \x03(\x0b\x32!.GofmtComplianceResults.DaysEntry\x12 \n\x06people\x18\x02 \x03(\x0b\x32\x10.GofmtCompliance\x12\x17\n\x0fpeople_sequence\x18\x03 \x03(\t\x1a=\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x1f\n\x05value\x18\x02 \x01(\x0b\x32\x10.GofmtCompliance:\x02\x38\x01\"a\n\x15StringLiteralsRelease\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x0b\n\x03\x64\x61y\x18\x02 \x01(\x05\x12\r\n\x05total\x18\x03 \x01(\x05\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x04 \x03(\t\x12\x0f\n\x07removed\x18\x05 \x03(\t\"A\n\x15StringLiteralsResults\x12(\n\x08releases\x18\x01 \x03(\x0b\x32\x16.StringLiteralsRelease\"\x83\x01\n\x08SQLStats\x12\x12\n\nstatements\x18\x01 \x01(\x05\x12\r\n\x05joins\x18\x02 \x01(\x05\x12%\n\x06tables\x18\x03 \x03(\x0b\x32\x15.SQLStats.TablesEntry\x1a-\n\x0bTablesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"+\n\x0eSQLTableOrigin\x12\x0b\n\x03\x64\x61y\x18\x01 \x01(\x05\x12\x0c\n\x04\x66ile\x18\x02 \x01(\t\"\xd5\x01\n\nSQLResults\x12#\n\x04\x64\x61ys\x18\x01 \x03(\x0b\x32\x15.SQLResults.DaysEntry\x12)\n\x07origins\x18\x02 \x03(\x0b\x32\x18.SQLResults.OriginsEntry\x1a\x36\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x18\n\x05value\x18\x02 \x01(\x0b\x32\t.SQLStats:\x02\x38\x01\x1a?\n\x0cOriginsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1e\n\x05value\x18\x02 \x01(\x0b\x32\x0f.SQLTableOrigin:\x02\x38\x01\"V\n\x12\x45rrorHandlingStats\x12\x0e\n\x06panics\x18\x01 \x01(\x05\x12\x0e\n\x06\x66\x61tals\x18\x02 \x01(\x05\x12\x0f\n\x07returns\x18\x03 \x01(\x05\x12\x0f\n\x07ignored\x18\x04 \x01(\x05\"\x87\x01\n\x14\x45rrorHandlingResults\x12-\n\x04\x64\x61ys\x18\x01 \x03(\x0b\x32\x1f.ErrorHandlingResults.DaysEntry\x1a@\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.ErrorHandlingStats:\x02\x38\x01\"3\n\x0cTestCoChange\x12\x0f\n\x07\x63hanged\x18\x01 \x01(\x05\x12\x12\n\nwith_tests\x18\x02 \x01(\x05\"\x9a\x01\n\x16\x44irectoryTestCoChanges\x12=\n\x0b\x64irectories\x18\x01 \x03(\x0b\x32(.DirectoryTestCoChanges.DirectoriesEntry\x1a\x41\n\x10\x44irectoriesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1c\n\x05value\x18\x02 \x01(\x0b\x32\r.TestCoChange:\x02\x38\x01\"\x89\x01\n\x13TestCouplingResults\x12,\n\x04\x64\x61ys\x18\x01 \x03(\x0b\x32\x1e.TestCouplingResults.DaysEntry\x1a\x44\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12&\n\x05value\x18\x02 \x01(\x0b\x32\x17.DirectoryTestCoChanges:\x02\x38\x01\"L\n\rTimeSkewStats\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\x0e\n\x06skewed\x18\x02 \x01(\x05\x12\r\n\x05total\x18\x03 \x01(\x03\x12\x0b\n\x03max\x18\x04 \x01(\x03\"\xc4\x01\n\x0fTimeSkewResults\x12\x11\n\tthreshold\x18\x01 \x01(\x03\x12(\n\x04\x64\x61ys\x18\x02 \x03(\x0b\x32\x1a.TimeSkewResults.DaysEntry\x12\x1e\n\x06people\x18\x03 \x03(\x0b\x32\x0e.TimeSkewStats\x12\x17\n\x0fpeople_sequence\x18\x04 \x03(\t\x1a;\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x1d\n\x05value\x18\x02 \x01(\x0b\x32\x0e.TimeSkewStats:\x02\x38\x01\"\x84\x01\n\nCherryPick\x12\x10\n\x08original\x18\x01 \x01(\t\x12\x17\n\x0foriginal_branch\x18\x02 \x01(\t\x12\x15\n\roriginal_time\x18\x03 \x01(\x03\x12\x0c\n\x04\x63opy\x18\x04 \x01(\t\x12\x13\n\x0b\x63opy_branch\x18\x05 \x01(\t\x12\x11\n\tcopy_time\x18\x06 \x01(\x03\"6\n\rBackportStats\x12\r\n\x05\x66ixes\x18\x01 \x03(\t\x12\x16\n\x0emedian_latency\x18\x02 \x01(\x03\"\xce\x01\n\x12\x43herryPicksResults\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\x12\n\nduplicated\x18\x02 \x01(\x05\x12\x1a\n\x05pairs\x18\x03 \x03(\x0b\x32\x0b.CherryPick\x12\x35\n\tbackports\x18\x04 \x03(\x0b\x32\".CherryPicksResults.BackportsEntry\x1a@\n\x0e\x42\x61\x63kportsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1d\n\x05value\x18\x02 \x01(\x0b\x32\x0e.BackportStats:\x02\x38\x01\"<\n\tLineStats\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x01 \x01(\x05\x12\x0f\n\x07removed\x18\x02 \x01(\x05\x12\x0f\n\x07\x63hanged\x18\x03 \x01(\x05\"\xac\x01\n\x06\x44\x65vDay\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\x19\n\x05stats\x18\x02 \x01(\x0b\x32\n.LineStats\x12\r\n\x05\x66iles\x18\x03 \x01(\x05\x12)\n\tlanguages\x18\x04 \x03(\x0b\x32\x16.DevDay.LanguagesEntry\x1a<\n\x0eLanguagesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.LineStats:\x02\x38\x01\"a\n\x07\x44\x61yDevs\x12 \n\x04\x64\x65vs\x18\x01 \x03(\x0b\x32\x12.DayDevs.DevsEntry\x1a\x34\n\tDevsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x16\n\x05value\x18\x02 \x01(\x0b\x32\x07.DevDay:\x02\x38\x01\"\x8d\x01\n\x13\x44\x65vsAnalysisResults\x12,\n\x04\x64\x61ys\x18\x01 \x03(\x0b\x32\x1e.DevsAnalysisResults.DaysEntry\x12\x11\n\tdev_index\x18\x02 \x03(\t\x1a\x35\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x17\n\x05value\x18\x02 \x01(\x0b\x32\x08.DayDevs:\x02\x38\x01\"t\n\x07TypoFix\x12\r\n\x05wrong\x18\x01 \x01(\t\x12\x0f\n\x07\x63orrect\x18\x02 \x01(\t\x12\x0e\n\x06\x63ommit\x18\x03 \x01(\t\x12\x0c\n\x04\x66ile\x18\x04 \x01(\t\x12\x0c\n\x04line\x18\x05 \x01(\x05\x12\x0e\n\x06\x62\x65\x66ore\x18\x06 \x01(\t\x12\r\n\x05\x61\x66ter\x18\x07 \x01(\t\"+\n\x10TypoFixesResults\x12\x17\n\x05\x66ixes\x18\x01 \x03(\x0b\x32\x08.TypoFix\"3\n\x11\x43ommentRatioStats\x12\x10\n\x08\x63omments\x18\x01 \x01(\x05\x12\x0c\n\x04\x63ode\x18\x02 \x01(\x05\"\x97\x01\n\x15LanguageCommentRatios\x12\x38\n\tlanguages\x18\x01 \x03(\x0b\x32%.LanguageCommentRatios.LanguagesEntry\x1a\x44\n\x0eLanguagesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12!\n\x05value\x18\x02 \x01(\x0b\x32\x12.CommentRatioStats:\x02\x38\x01\"\x88\x01\n\x13\x43ommentRatioResults\x12,\n\x04\x64\x61ys\x18\x01 \x03(\x0b\x32\x1e.CommentRatioResults.DaysEntry\x1a\x43\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12%\n\x05value\x18\x02 \x01(\x0b\x32\x16.LanguageCommentRatios:\x02\x38\x01\"\x83\x01\n\x12\x43ommitMessageStats\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\r\n\x05score\x18\x02 \x01(\x02\x12\x15\n\rgood_subjects\x18\x03 \x01(\x05\x12\x0e\n\x06\x62odies\x18\x04 \x01(\x05\x12\x12\n\nimperative\x18\x05 \x01(\x05\x12\x12\n\nissue_refs\x18\x06 \x01(\x05\"\xd3\x01\n\x15\x43ommitMessagesResults\x12\x36\n\x08quarters\x18\x01 \x03(\x0b\x32$.CommitMessagesResults.QuartersEntry\x12#\n\x06people\x18\x02 \x03(\x0b\x32\x13.CommitMessageStats\x12\x17\n\x0fpeople_sequence\x18\x03 \x03(\t\x1a\x44\n\rQuartersEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.CommitMessageStats:\x02\x38\x01\"1\n\x10\x43hurnOriginStats\x12\x0c\n\x04self\x18\x01 \x01(\x05\x12\x0f\n\x07\x66oreign\x18\x02 \x01(\x05\"\x8b\x01\n\x14\x44\x65veloperChurnOrigin\x12\x31\n\x06months\x18\x01 \x03(\x0b\x32!.DeveloperChurnOrigin.MonthsEntry\x1a@\n\x0bMonthsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12 \n\x05value\x18\x02 \x01(\x0b\x32\x11.ChurnOriginStats:\x02\x38\x01\"T\n\x12\x43hurnOriginResults\x12%\n\x06people\x18\x01 \x03(\x0b\x32\x15.DeveloperChurnOrigin\x12\x17\n\x0fpeople_sequence\x18\x02 \x03(\t\"\\\n\x13\x46ileLifecycleCounts\x12\x0e\n\x06\x61\x63tive\x18\x01 \x01(\x05\x12\x12\n\nmaintained\x18\x02 \x01(\x05\x12\x0e\n\x06\x66rozen\x18\x03 \x01(\x05\x12\x11\n\tabandoned\x18\x04 \x01(\x05\"\x9b\x01\n\x13\x44irectoryLifecycles\x12:\n\x0b\x64irectories\x18\x01 \x03(\x0b\x32%.DirectoryLifecycles.DirectoriesEntry\x1aH\n\x10\x44irectoriesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12#\n\x05value\x18\x02 \x01(\x0b\x32\x14.FileLifecycleCounts:\x02\x38\x01\"\xe7\x01\n\x14\x46ileLifecycleResults\x12/\n\x05\x66iles\x18\x01 \x03(\x0b\x32 .FileLifecycleResults.FilesEntry\x12-\n\x04\x64\x61ys\x18\x02 \x03(\x0b\x32\x1f.FileLifecycleResults.DaysEntry\x1a,\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\x1a\x41\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12#\n\x05value\x18\x02 \x01(\x0b\x32\x14.DirectoryLifecycles:\x02\x38\x01\"b\n\x14ReleasePressureStats\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\x19\n\x05lines\x18\x02 \x01(\x0b\x32\n.LineStats\x12\r\n\x05\x66iles\x18\x03 \x01(\x05\x12\x0f\n\x07reverts\x18\x04 \x01(\x05\"x\n\x16ReleasePressureResults\x12\x10\n\x08releases\x18\x01 \x01(\x05\x12#\n\x04\x64\x61ys\x18\x02 \x03(\x0b\x32\x15.ReleasePressureStats\x12\'\n\x08\x62\x61seline\x18\x03 \x01(\x0b\x32\x15.ReleasePressureStats\"9\n\x17\x43ompanyAttributionStats\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\r\n\x05lines\x18\x02 \x01(\x05\"\x8f\x01\n\x0e\x43ompanyQuarter\x12\x31\n\tcompanies\x18\x01 \x03(\x0b\x32\x1e.CompanyQuarter.CompaniesEntry\x1aJ\n\x0e\x43ompaniesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\'\n\x05value\x18\x02 \x01(\x0b\x32\x18.CompanyAttributionStats:\x02\x38\x01\"\x99\x01\n\x19\x43ompanyAttributionResults\x12:\n\x08quarters\x18\x01 \x03(\x0b\x32(.CompanyAttributionResults.QuartersEntry\x1a@\n\rQuartersEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1e\n\x05value\x18\x02 \x01(\x0b\x32\x0f.CompanyQuarter:\x02\x38\x01\"O\n\x10\x42inaryChurnStats\x12\x0f\n\x07\x63hanges\x18\x01 \x01(\x05\x12\x13\n\x0b\x61\x64\x64\x65\x64_bytes\x18\x02 \x01(\x03\x12\x15\n\rremoved_bytes\x18\x03 \x01(\x03\"\x9e\x01\n\x16\x42inaryChurnDirectories\x12=\n\x0b\x64irectories\x18\x01 \x03(\x0b\x32(.BinaryChurnDirectories.DirectoriesEntry\x1a\x45\n\x10\x44irectoriesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12 \n\x05value\x18\x02 \x01(\x0b\x32\x11.BinaryChurnStats:\x02\x38\x01\",\n\x0c\x42inaryAssets\x12\r\n\x05\x66iles\x18\x01 \x01(\x05\x12\r\n\x05\x62ytes\x18\x02 \x01(\x03\"\xff\x01\n\x12\x42inaryChurnResults\x12/\n\x06months\x18\x01 \x03(\x0b\x32\x1f.BinaryChurnResults.MonthsEntry\x12\x31\n\x07\x63urrent\x18\x02 \x03(\x0b\x32 .BinaryChurnResults.CurrentEntry\x1a\x46\n\x0bMonthsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12&\n\x05value\x18\x02 \x01(\x0b\x32\x17.BinaryChurnDirectories:\x02\x38\x01\x1a=\n\x0c\x43urrentEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1c\n\x05value\x18\x02 \x01(\x0b\x32\r.BinaryAssets:\x02\x38\x01\"S\n\x13RepositorySizeStats\x12\r\n\x05\x66iles\x18\x01 \x01(\x05\x12\r\n\x05\x62ytes\x18\x02 \x01(\x03\x12\r\n\x05lines\x18\x03 \x01(\x03\x12\x0f\n\x07history\x18\x04 \x01(\x03\"4\n\x14RepositorySizeCommit\x12\x0c\n\x04hash\x18\x01 \x01(\t\x12\x0e\n\x06growth\x18\x02 \x01(\x03\"\xa9\x02\n\x15RepositorySizeResults\x12.\n\x04\x64\x61ys\x18\x01 \x03(\x0b\x32 .RepositorySizeResults.DaysEntry\x12.\n\x04tags\x18\x02 \x03(\x0b\x32 .RepositorySizeResults.TagsEntry\x12*\n\x0btop_commits\x18\x03 \x03(\x0b\x32\x15.RepositorySizeCommit\x1a\x41\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12#\n\x05value\x18\x02 \x01(\x0b\x32\x14.RepositorySizeStats:\x02\x38\x01\x1a\x41\n\tTagsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12#\n\x05value\x18\x02 \x01(\x0b\x32\x14.RepositorySizeStats:\x02\x38\x01\"\xd6\x01\n\x10RefactoringStats\x12\x19\n\x11\x65xtracted_methods\x18\x01 \x01(\x05\x12\x17\n\x0frenamed_methods\x18\x02 \x01(\x05\x12\x17\n\x0frenamed_classes\x18\x03 \x01(\x05\x12\x15\n\rmoved_classes\x18\x04 \x01(\x05\x12\x19\n\x11refactoring_nodes\x18\x05 \x01(\x05\x12\x15\n\rchanged_nodes\x18\x06 \x01(\x05\x12\x0f\n\x07\x63ommits\x18\x07 \x01(\x05\x12\x1b\n\x13refactoring_commits\x18\x08 \x01(\x05\"\xbd\x01\n\x12RefactoringResults\x12+\n\x04\x64\x61ys\x18\x01 \x03(\x0b\x32\x1d.RefactoringResults.DaysEntry\x12!\n\x06people\x18\x02 \x03(\x0b\x32\x11.RefactoringStats\x12\x17\n\x0fpeople_sequence\x18\x03 \x03(\t\x1a>\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12 \n\x05value\x18\x02 \x01(\x0b\x32\x11.RefactoringStats:\x02\x38\x01\"L\n\x12\x43overageChurnStats\x12\x0f\n\x07\x63overed\x18\x01 \x01(\x05\x12\x11\n\tuncovered\x18\x02 \x01(\x05\x12\x12\n\nunmeasured\x18\x03 \x01(\x05\"\xc5\x01\n\x14\x43overageChurnResults\x12-\n\x04\x64\x61ys\x18\x01 \x03(\x0b\x32\x1f.CoverageChurnResults.DaysEntry\x12#\n\x06people\x18\x02 \x03(\x0b\x32\x13.CoverageChurnStats\x12\x17\n\x0fpeople_sequence\x18\x03 \x03(\t\x1a@\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.CoverageChurnStats:\x02\x38\x01\"\xc2\x01\n\x0c\x44\x65\x66\x65\x63tsStats\x12\x0e\n\x06issues\x18\x01 \x01(\x05\x12\x13\n\x0b\x66ix_commits\x18\x02 \x01(\x05\x12\r\n\x05lines\x18\x03 \x01(\x05\x12\x18\n\x10mean_time_to_fix\x18\x04 \x01(\x02\x12\x31\n\nseverities\x18\x05 \x03(\x0b\x32\x1d.DefectsStats.SeveritiesEntry\x1a\x31\n\x0fSeveritiesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"\xae\x01\n\x0e\x44\x65\x66\x65\x63tsResults\x12\x33\n\ncomponents\x18\x01 \x03(\x0b\x32\x1f.DefectsResults.ComponentsEntry\x12\x0e\n\x06issues\x18\x02 \x01(\x05\x12\x15\n\rlinked_issues\x18\x03 \x01(\x05\x1a@\n\x0f\x43omponentsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1c\n\x05value\x18\x02 \x01(\x0b\x32\r.DefectsStats:\x02\x38\x01\"[\n\x1bOwnershipConcentrationStats\x12\r\n\x05lines\x18\x01 \x01(\x05\x12\x0e\n\x06owners\x18\x02 \x01(\x05\x12\x0c\n\x04gini\x18\x03 \x01(\x02\x12\x0f\n\x07\x65ntropy\x18\x04 \x01(\x02\"\xbb\x01\n\x1f\x44irectoryOwnershipConcentration\x12\x46\n\x0b\x64irectories\x18\x01 \x03(\x0b\x32\x31.DirectoryOwnershipConcentration.DirectoriesEntry\x1aP\n\x10\x44irectoriesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12+\n\x05value\x18\x02 \x01(\x0b\x32\x1c.OwnershipConcentrationStats:\x02\x38\x01\"\xb2\x01\n\x1dOwnershipConcentrationResults\x12>\n\x08quarters\x18\x01 \x03(\x0b\x32,.OwnershipConcentrationResults.QuartersEntry\x1aQ\n\rQuartersEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12/\n\x05value\x18\x02 \x01(\x0b\x32 .DirectoryOwnershipConcentration:\x02\x38\x01\"j\n\x0b\x45\x66\x66ortStats\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x01 \x01(\x05\x12\x0f\n\x07removed\x18\x02 \x01(\x05\x12\x11\n\tsurviving\x18\x03 \x01(\x05\x12\x0e\n\x06\x65\x66\x66ort\x18\x04 \x01(\x02\x12\x18\n\x10surviving_effort\x18\x05 \x01(\x02\"\x8a\x01\n\x10\x43omponentEfforts\x12\x35\n\ncomponents\x18\x01 \x03(\x0b\x32!.ComponentEfforts.ComponentsEntry\x1a?\n\x0f\x43omponentsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1b\n\x05value\x18\x02 \x01(\x0b\x32\x0c.EffortStats:\x02\x38\x01\"\xe7\x02\n\x17\x45\x66\x66ortEstimationResults\x12\x13\n\x0b\x63oefficient\x18\x01 \x01(\x02\x12\x10\n\x08\x65xponent\x18\x02 \x01(\x02\x12\x0e\n\x06period\x18\x03 \x01(\t\x12\x36\n\x07periods\x18\x04 \x03(\x0b\x32%.EffortEstimationResults.PeriodsEntry\x12<\n\ncomponents\x18\x05 \x03(\x0b\x32(.EffortEstimationResults.ComponentsEntry\x12\x1b\n\x05total\x18\x06 \x01(\x0b\x32\x0c.EffortStats\x1a\x41\n\x0cPeriodsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12 \n\x05value\x18\x02 \x01(\x0b\x32\x11.ComponentEfforts:\x02\x38\x01\x1a?\n\x0f\x43omponentsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1b\n\x05value\x18\x02 \x01(\x0b\x32\x0c.EffortStats:\x02\x38\x01\"`\n\x17\x43ommentReadabilityStats\x12\x10\n\x08\x63omments\x18\x01 \x01(\x05\x12\x11\n\tsentences\x18\x02 \x01(\x05\x12\r\n\x05words\x18\x03 \x01(\x05\x12\x11\n\tsyllables\x18\x04 \x01(\x05\"\xaf\x01\n\x1b\x44irectoryCommentReadability\x12\x42\n\x0b\x64irectories\x18\x01 \x03(\x0b\x32-.DirectoryCommentReadability.DirectoriesEntry\x1aL\n\x10\x44irectoriesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\'\n\x05value\x18\x02 \x01(\x0b\x32\x18.CommentReadabilityStats:\x02\x38\x01\"\x9a\x01\n\x19\x43ommentReadabilityResults\x12\x32\n\x04\x64\x61ys\x18\x01 \x03(\x0b\x32$.CommentReadabilityResults.DaysEntry\x1aI\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12+\n\x05value\x18\x02 \x01(\x0b\x32\x1c.DirectoryCommentReadability:\x02\x38\x01\"p\n\x0cStaleComment\x12\x0c\n\x04\x66ile\x18\x01 \x01(\t\x12\x0c\n\x04line\x18\x02 \x01(\x05\x12\x0f\n\x07\x63omment\x18\x03 \x01(\t\x12\r\n\x05since\x18\x04 \x01(\x05\x12\x0f\n\x07\x63hanges\x18\x05 \x01(\x05\x12\x13\n\x0blast_change\x18\x06 \x01(\x05\"7\n\x14StaleCommentsResults\x12\x1f\n\x08\x63omments\x18\x01 \x03(\x0b\x32\r.StaleComment\"3\n\rToxicityStats\x12\x10\n\x08\x63omments\x18\x01 \x01(\x05\x12\x10\n\x08messages\x18\x02 \x01(\x05\"\xc4\x02\n\x0fToxicityResults\x12(\n\x04\x64\x61ys\x18\x01 \x03(\x0b\x32\x1a.ToxicityResults.DaysEntry\x12\x36\n\x0b\x64irectories\x18\x02 \x03(\x0b\x32!.ToxicityResults.DirectoriesEntry\x12.\n\x07phrases\x18\x03 \x03(\x0b\x32\x1d.ToxicityResults.PhrasesEntry\x1a;\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x1d\n\x05value\x18\x02 \x01(\x0b\x32\x0e.ToxicityStats:\x02\x38\x01\x1a\x32\n\x10\x44irectoriesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\x1a.\n\x0cPhrasesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"_\n\x11\x46unctionSizeStats\x12\x11\n\tfunctions\x18\x01 \x01(\x05\x12\r\n\x05lines\x18\x02 \x01(\x05\x12\x0e\n\x06median\x18\x03 \x01(\x05\x12\x0b\n\x03p90\x18\x04 \x01(\x05\x12\x0b\n\x03max\x18\x05 \x01(\x05\"\x97\x01\n\x15LanguageFunctionSizes\x12\x38\n\tlanguages\x18\x01 \x03(\x0b\x32%.LanguageFunctionSizes.LanguagesEntry\x1a\x44\n\x0eLanguagesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12!\n\x05value\x18\x02 \x01(\x0b\x32\x12.FunctionSizeStats:\x02\x38\x01\"b\n\x0fLongestFunction\x12\x0c\n\x04\x66ile\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\r\n\x05lines\x18\x03 \x01(\x05\x12\r\n\x05since\x18\x04 \x01(\x05\x12\x15\n\rinitial_lines\x18\x05 \x01(\x05\"\xab\x01\n\x13\x46unctionSizeResults\x12,\n\x04\x64\x61ys\x18\x01 \x03(\x0b\x32\x1e.FunctionSizeResults.DaysEntry\x12!\n\x07longest\x18\x02 \x03(\x0b\x32\x10.LongestFunction\x1a\x43\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12%\n\x05value\x18\x02 \x01(\x0b\x32\x16.LanguageFunctionSizes:\x02\x38\x01\"L\n\x11NestingDepthStats\x12\x0b\n\x03\x64\x61y\x18\x01 \x01(\x05\x12\x0e\n\x06\x62locks\x18\x02 \x01(\x05\x12\r\n\x05total\x18\x03 \x01(\x05\x12\x0b\n\x03max\x18\x04 \x01(\x05\"8\n\x13NestingDepthHistory\x12!\n\x05stats\x18\x01 \x03(\x0b\x32\x12.NestingDepthStats\"\xf6\x01\n\x13NestingDepthResults\x12.\n\x05\x66iles\x18\x01 \x03(\x0b\x32\x1f.NestingDepthResults.FilesEntry\x12\x38\n\nincreasing\x18\x02 \x03(\x0b\x32$.NestingDepthResults.IncreasingEntry\x1a\x42\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12#\n\x05value\x18\x02 \x01(\x0b\x32\x14.NestingDepthHistory:\x02\x38\x01\x1a\x31\n\x0fIncreasingEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"\x8c\x01\n\rCommitEntropy\x12\x0e\n\x06\x63ommit\x18\x01 \x01(\t\x12\x0b\n\x03\x64\x61y\x18\x02 \x01(\x05\x12\r\n\x05\x66iles\x18\x03 \x01(\x05\x12\x13\n\x0b\x64irectories\x18\x04 \x01(\x05\x12\r\n\x05lines\x18\x05 \x01(\x05\x12\x0f\n\x07\x65ntropy\x18\x06 \x01(\x02\x12\x1a\n\x12normalized_entropy\x18\x07 \x01(\x02\"N\n\x12\x43ommitEntropyStats\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\x14\n\x0cmean_entropy\x18\x02 \x01(\x02\x12\x11\n\tscattered\x18\x03 \x01(\x05\"\xa8\x01\n\x14\x43ommitEntropyResults\x12\x1f\n\x07\x63ommits\x18\x01 \x03(\x0b\x32\x0e.CommitEntropy\x12-\n\x04\x64\x61ys\x18\x02 \x03(\x0b\x32\x1f.CommitEntropyResults.DaysEntry\x1a@\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.CommitEntropyStats:\x02\x38\x01\"6\n\x0fTicketlessStats\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\x12\n\nticketless\x18\x02 \x01(\x05\"\xcd\x01\n\x18TicketlessCommitsResults\x12\x35\n\x06months\x18\x01 \x03(\x0b\x32%.TicketlessCommitsResults.MonthsEntry\x12 \n\x06people\x18\x02 \x03(\x0b\x32\x10.TicketlessStats\x12\x17\n\x0fpeople_sequence\x18\x03 \x03(\t\x1a?\n\x0bMonthsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1f\n\x05value\x18\x02 \x01(\x0b\x32\x10.TicketlessStats:\x02\x38\x01\"|\n\tChangeSet\x12\r\n\x05merge\x18\x01 \x01(\t\x12\x0e\n\x06merged\x18\x02 \x01(\x03\x12\x0f\n\x07\x63ommits\x18\x03 \x01(\x05\x12\r\n\x05\x66iles\x18\x04 \x01(\x05\x12\r\n\x05lines\x18\x05 \x01(\x05\x12\x10\n\x08\x64uration\x18\x06 \x01(\x03\x12\x0f\n\x07\x61uthors\x18\x07 \x01(\x05\"\xa9\x01\n\x0e\x43hangeSetStats\x12\x13\n\x0b\x63hange_sets\x18\x01 \x01(\x05\x12\x14\n\x0cmedian_lines\x18\x02 \x01(\x05\x12\x11\n\tp90_lines\x18\x03 \x01(\x05\x12\x17\n\x0fmedian_duration\x18\x04 \x01(\x03\x12\x14\n\x0cp90_duration\x18\x05 \x01(\x03\x12\x14\n\x0cmean_authors\x18\x06 \x01(\x02\x12\x14\n\x0cmulti_author\x18\x07 \x01(\x05\"\xa4\x01\n\x11\x43hangeSetsResults\x12\x1f\n\x0b\x63hange_sets\x18\x01 \x03(\x0b\x32\n.ChangeSet\x12.\n\x06months\x18\x02 \x03(\x0b\x32\x1e.ChangeSetsResults.MonthsEntry\x1a>\n\x0bMonthsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1e\n\x05value\x18\x02 \x01(\x0b\x32\x0f.ChangeSetStats:\x02\x38\x01\"D\n\rCaseCollision\x12\r\n\x05paths\x18\x01 \x03(\t\x12\x12\n\nintroduced\x18\x02 \x01(\t\x12\x10\n\x08resolved\x18\x03 \x01(\t\";\n\x15\x43\x61seCollisionsResults\x12\"\n\ncollisions\x18\x01 \x03(\x0b\x32\x0e.CaseCollision\",\n\tExtension\x12\x10\n\x08type_url\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c\"\x84\x02\n\x0f\x41nalysisResults\x12\x19\n\x06header\x18\x01 \x01(\x0b\x32\t.Metadata\x12\x30\n\x08\x63ontents\x18\x02 \x03(\x0b\x32\x1e.AnalysisResults.ContentsEntry\x12\x34\n\nextensions\x18\x03 \x03(\x0b\x32 .AnalysisResults.ExtensionsEntry\x1a/\n\rContentsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\x1a=\n\x0f\x45xtensionsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.Extension:\x02\x38\x01\x62\x06proto3')
 )
 
 
@@ -81,6 +81,34 @@ _METADATA = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='failures', full_name='Metadata.failures', index=7,
+      number=8, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='partial', full_name='Metadata.partial', index=8,
+      number=9, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='skipped', full_name='Metadata.skipped', index=9,
+      number=10, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='markers', full_name='Metadata.markers', index=10,
+      number=11, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -94,7 +122,142 @@ _METADATA = _descriptor.Descriptor(
   oneofs=[
   ],
   serialized_start=13,
-  serialized_end=157,
+  serialized_end=265,
+)
+
+
+_MARKER = _descriptor.Descriptor(
+  name='Marker',
+  full_name='Marker',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='label', full_name='Marker.label', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='unix_time', full_name='Marker.unix_time', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commit', full_name='Marker.commit', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=267,
+  serialized_end=325,
+)
+
+
+_SKIPPEDITEM = _descriptor.Descriptor(
+  name='SkippedItem',
+  full_name='SkippedItem',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='item', full_name='SkippedItem.item', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='reason', full_name='SkippedItem.reason', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=327,
+  serialized_end=370,
+)
+
+
+_COMMITFAILURE = _descriptor.Descriptor(
+  name='CommitFailure',
+  full_name='CommitFailure',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commit', full_name='CommitFailure.commit', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='index', full_name='CommitFailure.index', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='item', full_name='CommitFailure.item', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='error', full_name='CommitFailure.error', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=372,
+  serialized_end=447,
 )
 
 
@@ -124,8 +287,8 @@ _BURNDOWNSPARSEMATRIXROW = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=159,
-  serialized_end=201,
+  serialized_start=449,
+  serialized_end=491,
 )
 
 
@@ -176,8 +339,8 @@ _BURNDOWNSPARSEMATRIX = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=203,
-  serialized_end=330,
+  serialized_start=493,
+  serialized_end=620,
 )
 
 
@@ -230,6 +393,72 @@ _BURNDOWNANALYSISRESULTS = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='groups', full_name='BurndownAnalysisResults.groups', index=6,
+      number=7, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='BurndownAnalysisResults.directories', index=7,
+      number=8, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='initial_commit', full_name='BurndownAnalysisResults.initial_commit', index=8,
+      number=9, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='backfill_days', full_name='BurndownAnalysisResults.backfill_days', index=9,
+      number=10, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=623,
+  serialized_end=987,
+)
+
+
+_BURNDOWNDIRECTORY = _descriptor.Descriptor(
+  name='BurndownDirectory',
+  full_name='BurndownDirectory',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='matrix', full_name='BurndownDirectory.matrix', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='parent', full_name='BurndownDirectory.parent', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -242,8 +471,8 @@ _BURNDOWNANALYSISRESULTS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=333,
-  serialized_end=570,
+  serialized_start=989,
+  serialized_end=1063,
 )
 
 
@@ -301,8 +530,8 @@ _COMPRESSEDSPARSEROWMATRIX = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=572,
-  serialized_end=697,
+  serialized_start=1065,
+  serialized_end=1190,
 )
 
 
@@ -339,8 +568,8 @@ _COUPLES = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=699,
-  serialized_end=767,
+  serialized_start=1192,
+  serialized_end=1260,
 )
 
 
@@ -370,8 +599,8 @@ _TOUCHEDFILES = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=769,
-  serialized_end=798,
+  serialized_start=1262,
+  serialized_end=1291,
 )
 
 
@@ -403,6 +632,20 @@ _COUPLESANALYSISRESULTS = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='people_files_matrix', full_name='CouplesAnalysisResults.people_files_matrix', index=3,
+      number=9, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='file_clusters', full_name='CouplesAnalysisResults.file_clusters', index=4,
+      number=10, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -415,8 +658,8 @@ _COUPLESANALYSISRESULTS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=800,
-  serialized_end=927,
+  serialized_start=1294,
+  serialized_end=1501,
 )
 
 
@@ -462,6 +705,34 @@ _UASTCHANGE = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='commit', full_name='UASTChange.commit', index=5,
+      number=6, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='file_name_before', full_name='UASTChange.file_name_before', index=6,
+      number=7, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='hash_before', full_name='UASTChange.hash_before', index=7,
+      number=8, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='hash_after', full_name='UASTChange.hash_after', index=8,
+      number=9, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -474,8 +745,8 @@ _UASTCHANGE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=929,
-  serialized_end=1040,
+  serialized_start=1504,
+  serialized_end=1698,
 )
 
 
@@ -505,8 +776,8 @@ _UASTCHANGESSAVERRESULTS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1042,
-  serialized_end=1097,
+  serialized_start=1700,
+  serialized_end=1755,
 )
 
 
@@ -543,8 +814,8 @@ _SHOTNESSRECORD_COUNTERSENTRY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1233,
-  serialized_end=1280,
+  serialized_start=1953,
+  serialized_end=2000,
 )
 
 _SHOTNESSRECORD = _descriptor.Descriptor(
@@ -589,6 +860,27 @@ _SHOTNESSRECORD = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='start_line', full_name='ShotnessRecord.start_line', index=5,
+      number=6, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='end_line', full_name='ShotnessRecord.end_line', index=6,
+      number=7, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='enclosing_type', full_name='ShotnessRecord.enclosing_type', index=7,
+      number=8, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -601,8 +893,8 @@ _SHOTNESSRECORD = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1100,
-  serialized_end=1280,
+  serialized_start=1758,
+  serialized_end=2000,
 )
 
 
@@ -632,8 +924,8 @@ _SHOTNESSANALYSISRESULTS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1282,
-  serialized_end=1341,
+  serialized_start=2002,
+  serialized_end=2061,
 )
 
 
@@ -663,8 +955,8 @@ _FILEHISTORY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1343,
-  serialized_end=1373,
+  serialized_start=2063,
+  serialized_end=2093,
 )
 
 
@@ -701,8 +993,8 @@ _FILEHISTORYRESULTMESSAGE_FILESENTRY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1457,
-  serialized_end=1515,
+  serialized_start=2177,
+  serialized_end=2235,
 )
 
 _FILEHISTORYRESULTMESSAGE = _descriptor.Descriptor(
@@ -731,36 +1023,29 @@ _FILEHISTORYRESULTMESSAGE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1376,
-  serialized_end=1515,
+  serialized_start=2096,
+  serialized_end=2235,
 )
 
 
-_SENTIMENT = _descriptor.Descriptor(
-  name='Sentiment',
-  full_name='Sentiment',
+_SENTIMENT_EMOTIONSENTRY = _descriptor.Descriptor(
+  name='EmotionsEntry',
+  full_name='Sentiment.EmotionsEntry',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='value', full_name='Sentiment.value', index=0,
-      number=1, type=2, cpp_type=6, label=1,
-      has_default_value=False, default_value=float(0),
-      message_type=None, enum_type=None, containing_type=None,
-      is_extension=False, extension_scope=None,
-      options=None),
-    _descriptor.FieldDescriptor(
-      name='comments', full_name='Sentiment.comments', index=1,
-      number=2, type=9, cpp_type=9, label=3,
-      has_default_value=False, default_value=[],
+      name='key', full_name='Sentiment.EmotionsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='commits', full_name='Sentiment.commits', index=2,
-      number=3, type=9, cpp_type=9, label=3,
-      has_default_value=False, default_value=[],
+      name='value', full_name='Sentiment.EmotionsEntry.value', index=1,
+      number=2, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
@@ -770,20 +1055,71 @@ _SENTIMENT = _descriptor.Descriptor(
   nested_types=[],
   enum_types=[
   ],
-  options=None,
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
   is_extendable=False,
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1517,
-  serialized_end=1578,
+  serialized_start=2345,
+  serialized_end=2392,
 )
 
-
-_COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY = _descriptor.Descriptor(
-  name='SentimentByDayEntry',
-  full_name='CommentSentimentResults.SentimentByDayEntry',
+_SENTIMENT = _descriptor.Descriptor(
+  name='Sentiment',
+  full_name='Sentiment',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='value', full_name='Sentiment.value', index=0,
+      number=1, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='comments', full_name='Sentiment.comments', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='Sentiment.commits', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='emotions', full_name='Sentiment.emotions', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_SENTIMENT_EMOTIONSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2238,
+  serialized_end=2392,
+)
+
+
+_COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY = _descriptor.Descriptor(
+  name='SentimentByDayEntry',
+  full_name='CommentSentimentResults.SentimentByDayEntry',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
@@ -814,8 +1150,8 @@ _COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1680,
-  serialized_end=1745,
+  serialized_start=2494,
+  serialized_end=2559,
 )
 
 _COMMENTSENTIMENTRESULTS = _descriptor.Descriptor(
@@ -844,29 +1180,99 @@ _COMMENTSENTIMENTRESULTS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1581,
-  serialized_end=1745,
+  serialized_start=2395,
+  serialized_end=2559,
 )
 
 
-_ANALYSISRESULTS_CONTENTSENTRY = _descriptor.Descriptor(
-  name='ContentsEntry',
-  full_name='AnalysisResults.ContentsEntry',
+_COMMITFEATURES = _descriptor.Descriptor(
+  name='CommitFeatures',
+  full_name='CommitFeatures',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='key', full_name='AnalysisResults.ContentsEntry.key', index=0,
+      name='commit', full_name='CommitFeatures.commit', index=0,
       number=1, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=_b("").decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='value', full_name='AnalysisResults.ContentsEntry.value', index=1,
-      number=2, type=12, cpp_type=9, label=1,
-      has_default_value=False, default_value=_b(""),
+      name='day', full_name='CommitFeatures.day', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='hour', full_name='CommitFeatures.hour', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='files', full_name='CommitFeatures.files', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='added', full_name='CommitFeatures.added', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='removed', full_name='CommitFeatures.removed', index=5,
+      number=6, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dir_entropy', full_name='CommitFeatures.dir_entropy', index=6,
+      number=7, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='author_experience', full_name='CommitFeatures.author_experience', index=7,
+      number=8, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='message_length', full_name='CommitFeatures.message_length', index=8,
+      number=9, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='message_lines', full_name='CommitFeatures.message_lines', index=9,
+      number=10, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='uast_nodes_added', full_name='CommitFeatures.uast_nodes_added', index=10,
+      number=11, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='uast_nodes_removed', full_name='CommitFeatures.uast_nodes_removed', index=11,
+      number=12, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
@@ -876,33 +1282,95 @@ _ANALYSISRESULTS_CONTENTSENTRY = _descriptor.Descriptor(
   nested_types=[],
   enum_types=[
   ],
-  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  options=None,
   is_extendable=False,
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1844,
-  serialized_end=1891,
+  serialized_start=2562,
+  serialized_end=2817,
 )
 
-_ANALYSISRESULTS = _descriptor.Descriptor(
-  name='AnalysisResults',
-  full_name='AnalysisResults',
+
+_COMMITFEATURESRESULTS = _descriptor.Descriptor(
+  name='CommitFeaturesResults',
+  full_name='CommitFeaturesResults',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='header', full_name='AnalysisResults.header', index=0,
-      number=1, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='commits', full_name='CommitFeaturesResults.commits', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2819,
+  serialized_end=2876,
+)
+
+
+_ROLESHISTOGRAM_ROLESENTRY = _descriptor.Descriptor(
+  name='RolesEntry',
+  full_name='RolesHistogram.RolesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='RolesHistogram.RolesEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='contents', full_name='AnalysisResults.contents', index=1,
-      number=2, type=11, cpp_type=10, label=3,
+      name='value', full_name='RolesHistogram.RolesEntry.value', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2939,
+  serialized_end=2983,
+)
+
+_ROLESHISTOGRAM = _descriptor.Descriptor(
+  name='RolesHistogram',
+  full_name='RolesHistogram',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='roles', full_name='RolesHistogram.roles', index=0,
+      number=1, type=11, cpp_type=10, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -910,7 +1378,7 @@ _ANALYSISRESULTS = _descriptor.Descriptor(
   ],
   extensions=[
   ],
-  nested_types=[_ANALYSISRESULTS_CONTENTSENTRY, ],
+  nested_types=[_ROLESHISTOGRAM_ROLESENTRY, ],
   enum_types=[
   ],
   options=None,
@@ -919,186 +1387,7822 @@ _ANALYSISRESULTS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1748,
-  serialized_end=1891,
+  serialized_start=2878,
+  serialized_end=2983,
 )
 
-_BURNDOWNSPARSEMATRIX.fields_by_name['rows'].message_type = _BURNDOWNSPARSEMATRIXROW
-_BURNDOWNANALYSISRESULTS.fields_by_name['project'].message_type = _BURNDOWNSPARSEMATRIX
-_BURNDOWNANALYSISRESULTS.fields_by_name['files'].message_type = _BURNDOWNSPARSEMATRIX
-_BURNDOWNANALYSISRESULTS.fields_by_name['people'].message_type = _BURNDOWNSPARSEMATRIX
-_BURNDOWNANALYSISRESULTS.fields_by_name['people_interaction'].message_type = _COMPRESSEDSPARSEROWMATRIX
-_COUPLES.fields_by_name['matrix'].message_type = _COMPRESSEDSPARSEROWMATRIX
-_COUPLESANALYSISRESULTS.fields_by_name['file_couples'].message_type = _COUPLES
-_COUPLESANALYSISRESULTS.fields_by_name['people_couples'].message_type = _COUPLES
-_COUPLESANALYSISRESULTS.fields_by_name['people_files'].message_type = _TOUCHEDFILES
-_UASTCHANGESSAVERRESULTS.fields_by_name['changes'].message_type = _UASTCHANGE
-_SHOTNESSRECORD_COUNTERSENTRY.containing_type = _SHOTNESSRECORD
-_SHOTNESSRECORD.fields_by_name['counters'].message_type = _SHOTNESSRECORD_COUNTERSENTRY
-_SHOTNESSANALYSISRESULTS.fields_by_name['records'].message_type = _SHOTNESSRECORD
-_FILEHISTORYRESULTMESSAGE_FILESENTRY.fields_by_name['value'].message_type = _FILEHISTORY
-_FILEHISTORYRESULTMESSAGE_FILESENTRY.containing_type = _FILEHISTORYRESULTMESSAGE
-_FILEHISTORYRESULTMESSAGE.fields_by_name['files'].message_type = _FILEHISTORYRESULTMESSAGE_FILESENTRY
-_COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY.fields_by_name['value'].message_type = _SENTIMENT
-_COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY.containing_type = _COMMENTSENTIMENTRESULTS
-_COMMENTSENTIMENTRESULTS.fields_by_name['sentiment_by_day'].message_type = _COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY
-_ANALYSISRESULTS_CONTENTSENTRY.containing_type = _ANALYSISRESULTS
-_ANALYSISRESULTS.fields_by_name['header'].message_type = _METADATA
-_ANALYSISRESULTS.fields_by_name['contents'].message_type = _ANALYSISRESULTS_CONTENTSENTRY
-DESCRIPTOR.message_types_by_name['Metadata'] = _METADATA
-DESCRIPTOR.message_types_by_name['BurndownSparseMatrixRow'] = _BURNDOWNSPARSEMATRIXROW
-DESCRIPTOR.message_types_by_name['BurndownSparseMatrix'] = _BURNDOWNSPARSEMATRIX
-DESCRIPTOR.message_types_by_name['BurndownAnalysisResults'] = _BURNDOWNANALYSISRESULTS
-DESCRIPTOR.message_types_by_name['CompressedSparseRowMatrix'] = _COMPRESSEDSPARSEROWMATRIX
-DESCRIPTOR.message_types_by_name['Couples'] = _COUPLES
-DESCRIPTOR.message_types_by_name['TouchedFiles'] = _TOUCHEDFILES
-DESCRIPTOR.message_types_by_name['CouplesAnalysisResults'] = _COUPLESANALYSISRESULTS
-DESCRIPTOR.message_types_by_name['UASTChange'] = _UASTCHANGE
-DESCRIPTOR.message_types_by_name['UASTChangesSaverResults'] = _UASTCHANGESSAVERRESULTS
-DESCRIPTOR.message_types_by_name['ShotnessRecord'] = _SHOTNESSRECORD
-DESCRIPTOR.message_types_by_name['ShotnessAnalysisResults'] = _SHOTNESSANALYSISRESULTS
-DESCRIPTOR.message_types_by_name['FileHistory'] = _FILEHISTORY
-DESCRIPTOR.message_types_by_name['FileHistoryResultMessage'] = _FILEHISTORYRESULTMESSAGE
-DESCRIPTOR.message_types_by_name['Sentiment'] = _SENTIMENT
-DESCRIPTOR.message_types_by_name['CommentSentimentResults'] = _COMMENTSENTIMENTRESULTS
-DESCRIPTOR.message_types_by_name['AnalysisResults'] = _ANALYSISRESULTS
-_sym_db.RegisterFileDescriptor(DESCRIPTOR)
-
-Metadata = _reflection.GeneratedProtocolMessageType('Metadata', (_message.Message,), dict(
-  DESCRIPTOR = _METADATA,
-  __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:Metadata)
-  ))
-_sym_db.RegisterMessage(Metadata)
 
-BurndownSparseMatrixRow = _reflection.GeneratedProtocolMessageType('BurndownSparseMatrixRow', (_message.Message,), dict(
-  DESCRIPTOR = _BURNDOWNSPARSEMATRIXROW,
-  __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:BurndownSparseMatrixRow)
-  ))
-_sym_db.RegisterMessage(BurndownSparseMatrixRow)
+_LANGUAGEROLESHISTOGRAMS_LANGUAGESENTRY = _descriptor.Descriptor(
+  name='LanguagesEntry',
+  full_name='LanguageRolesHistograms.LanguagesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='LanguageRolesHistograms.LanguagesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='LanguageRolesHistograms.LanguagesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3073,
+  serialized_end=3138,
+)
 
-BurndownSparseMatrix = _reflection.GeneratedProtocolMessageType('BurndownSparseMatrix', (_message.Message,), dict(
-  DESCRIPTOR = _BURNDOWNSPARSEMATRIX,
-  __module__ = 'pb_pb2'
+_LANGUAGEROLESHISTOGRAMS = _descriptor.Descriptor(
+  name='LanguageRolesHistograms',
+  full_name='LanguageRolesHistograms',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='languages', full_name='LanguageRolesHistograms.languages', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_LANGUAGEROLESHISTOGRAMS_LANGUAGESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2986,
+  serialized_end=3138,
+)
+
+
+_ROLESHISTOGRAMRESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='RolesHistogramResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='RolesHistogramResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='RolesHistogramResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3214,
+  serialized_end=3283,
+)
+
+_ROLESHISTOGRAMRESULTS = _descriptor.Descriptor(
+  name='RolesHistogramResults',
+  full_name='RolesHistogramResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='RolesHistogramResults.days', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_ROLESHISTOGRAMRESULTS_DAYSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3141,
+  serialized_end=3283,
+)
+
+
+_HALSTEADMETRICS = _descriptor.Descriptor(
+  name='HalsteadMetrics',
+  full_name='HalsteadMetrics',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='volume', full_name='HalsteadMetrics.volume', index=0,
+      number=1, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='difficulty', full_name='HalsteadMetrics.difficulty', index=1,
+      number=2, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='files', full_name='HalsteadMetrics.files', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3285,
+  serialized_end=3353,
+)
+
+
+_DIRECTORYHALSTEAD_DIRECTORIESENTRY = _descriptor.Descriptor(
+  name='DirectoriesEntry',
+  full_name='DirectoryHalstead.DirectoriesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DirectoryHalstead.DirectoriesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DirectoryHalstead.DirectoriesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3435,
+  serialized_end=3503,
+)
+
+_DIRECTORYHALSTEAD = _descriptor.Descriptor(
+  name='DirectoryHalstead',
+  full_name='DirectoryHalstead',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='DirectoryHalstead.directories', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DIRECTORYHALSTEAD_DIRECTORIESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3356,
+  serialized_end=3503,
+)
+
+
+_HALSTEADRESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='HalsteadResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='HalsteadResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='HalsteadResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3566,
+  serialized_end=3629,
+)
+
+_HALSTEADRESULTS = _descriptor.Descriptor(
+  name='HalsteadResults',
+  full_name='HalsteadResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='HalsteadResults.days', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_HALSTEADRESULTS_DAYSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3505,
+  serialized_end=3629,
+)
+
+
+_INDENTATIONSTATS = _descriptor.Descriptor(
+  name='IndentationStats',
+  full_name='IndentationStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='day', full_name='IndentationStats.day', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='IndentationStats.lines', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='total', full_name='IndentationStats.total', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='max', full_name='IndentationStats.max', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3631,
+  serialized_end=3705,
+)
+
+
+_INDENTATIONHISTORY = _descriptor.Descriptor(
+  name='IndentationHistory',
+  full_name='IndentationHistory',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='stats', full_name='IndentationHistory.stats', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3707,
+  serialized_end=3761,
+)
+
+
+_INDENTATIONCOMPLEXITYRESULTS_FILESENTRY = _descriptor.Descriptor(
+  name='FilesEntry',
+  full_name='IndentationComplexityResults.FilesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='IndentationComplexityResults.FilesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='IndentationComplexityResults.FilesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3853,
+  serialized_end=3918,
+)
+
+_INDENTATIONCOMPLEXITYRESULTS = _descriptor.Descriptor(
+  name='IndentationComplexityResults',
+  full_name='IndentationComplexityResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='files', full_name='IndentationComplexityResults.files', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_INDENTATIONCOMPLEXITYRESULTS_FILESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3764,
+  serialized_end=3918,
+)
+
+
+_STYLESTATS = _descriptor.Descriptor(
+  name='StyleStats',
+  full_name='StyleStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='StyleStats.lines', index=0,
+      number=1, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='tab_indented', full_name='StyleStats.tab_indented', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='space_indented', full_name='StyleStats.space_indented', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='trailing_whitespace', full_name='StyleStats.trailing_whitespace', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='line_lengths', full_name='StyleStats.line_lengths', index=4,
+      number=5, type=3, cpp_type=2, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3920,
+  serialized_end=4044,
+)
+
+
+_LANGUAGESTYLESTATS_LANGUAGESENTRY = _descriptor.Descriptor(
+  name='LanguagesEntry',
+  full_name='LanguageStyleStats.LanguagesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='LanguageStyleStats.LanguagesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='LanguageStyleStats.LanguagesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4124,
+  serialized_end=4185,
+)
+
+_LANGUAGESTYLESTATS = _descriptor.Descriptor(
+  name='LanguageStyleStats',
+  full_name='LanguageStyleStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='languages', full_name='LanguageStyleStats.languages', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_LANGUAGESTYLESTATS_LANGUAGESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4047,
+  serialized_end=4185,
+)
+
+
+_STYLEDRIFTRESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='StyleDriftResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='StyleDriftResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='StyleDriftResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4281,
+  serialized_end=4345,
+)
+
+_STYLEDRIFTRESULTS = _descriptor.Descriptor(
+  name='StyleDriftResults',
+  full_name='StyleDriftResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='line_length_bucket', full_name='StyleDriftResults.line_length_bucket', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='days', full_name='StyleDriftResults.days', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_STYLEDRIFTRESULTS_DAYSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4188,
+  serialized_end=4345,
+)
+
+
+_GOFMTCOMPLIANCE = _descriptor.Descriptor(
+  name='GofmtCompliance',
+  full_name='GofmtCompliance',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='checked', full_name='GofmtCompliance.checked', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='clean', full_name='GofmtCompliance.clean', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4347,
+  serialized_end=4396,
+)
+
+
+_GOFMTCOMPLIANCERESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='GofmtComplianceResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='GofmtComplianceResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='GofmtComplianceResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4533,
+  serialized_end=4594,
+)
+
+_GOFMTCOMPLIANCERESULTS = _descriptor.Descriptor(
+  name='GofmtComplianceResults',
+  full_name='GofmtComplianceResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='GofmtComplianceResults.days', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people', full_name='GofmtComplianceResults.people', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people_sequence', full_name='GofmtComplianceResults.people_sequence', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_GOFMTCOMPLIANCERESULTS_DAYSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4399,
+  serialized_end=4594,
+)
+
+
+_STRINGLITERALSRELEASE = _descriptor.Descriptor(
+  name='StringLiteralsRelease',
+  full_name='StringLiteralsRelease',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='name', full_name='StringLiteralsRelease.name', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='day', full_name='StringLiteralsRelease.day', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='total', full_name='StringLiteralsRelease.total', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='added', full_name='StringLiteralsRelease.added', index=3,
+      number=4, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='removed', full_name='StringLiteralsRelease.removed', index=4,
+      number=5, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4596,
+  serialized_end=4693,
+)
+
+
+_STRINGLITERALSRESULTS = _descriptor.Descriptor(
+  name='StringLiteralsResults',
+  full_name='StringLiteralsResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='releases', full_name='StringLiteralsResults.releases', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4695,
+  serialized_end=4760,
+)
+
+
+_SQLSTATS_TABLESENTRY = _descriptor.Descriptor(
+  name='TablesEntry',
+  full_name='SQLStats.TablesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='SQLStats.TablesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='SQLStats.TablesEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4849,
+  serialized_end=4894,
+)
+
+_SQLSTATS = _descriptor.Descriptor(
+  name='SQLStats',
+  full_name='SQLStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='statements', full_name='SQLStats.statements', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='joins', full_name='SQLStats.joins', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='tables', full_name='SQLStats.tables', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_SQLSTATS_TABLESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4763,
+  serialized_end=4894,
+)
+
+
+_SQLTABLEORIGIN = _descriptor.Descriptor(
+  name='SQLTableOrigin',
+  full_name='SQLTableOrigin',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='day', full_name='SQLTableOrigin.day', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='file', full_name='SQLTableOrigin.file', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4896,
+  serialized_end=4939,
+)
+
+
+_SQLRESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='SQLResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='SQLResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='SQLResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5036,
+  serialized_end=5090,
+)
+
+_SQLRESULTS_ORIGINSENTRY = _descriptor.Descriptor(
+  name='OriginsEntry',
+  full_name='SQLResults.OriginsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='SQLResults.OriginsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='SQLResults.OriginsEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5092,
+  serialized_end=5155,
+)
+
+_SQLRESULTS = _descriptor.Descriptor(
+  name='SQLResults',
+  full_name='SQLResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='SQLResults.days', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='origins', full_name='SQLResults.origins', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_SQLRESULTS_DAYSENTRY, _SQLRESULTS_ORIGINSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4942,
+  serialized_end=5155,
+)
+
+
+_ERRORHANDLINGSTATS = _descriptor.Descriptor(
+  name='ErrorHandlingStats',
+  full_name='ErrorHandlingStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='panics', full_name='ErrorHandlingStats.panics', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='fatals', full_name='ErrorHandlingStats.fatals', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='returns', full_name='ErrorHandlingStats.returns', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='ignored', full_name='ErrorHandlingStats.ignored', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5157,
+  serialized_end=5243,
+)
+
+
+_ERRORHANDLINGRESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='ErrorHandlingResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ErrorHandlingResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ErrorHandlingResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5317,
+  serialized_end=5381,
+)
+
+_ERRORHANDLINGRESULTS = _descriptor.Descriptor(
+  name='ErrorHandlingResults',
+  full_name='ErrorHandlingResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='ErrorHandlingResults.days', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_ERRORHANDLINGRESULTS_DAYSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5246,
+  serialized_end=5381,
+)
+
+
+_TESTCOCHANGE = _descriptor.Descriptor(
+  name='TestCoChange',
+  full_name='TestCoChange',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='changed', full_name='TestCoChange.changed', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='with_tests', full_name='TestCoChange.with_tests', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5383,
+  serialized_end=5434,
+)
+
+
+_DIRECTORYTESTCOCHANGES_DIRECTORIESENTRY = _descriptor.Descriptor(
+  name='DirectoriesEntry',
+  full_name='DirectoryTestCoChanges.DirectoriesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DirectoryTestCoChanges.DirectoriesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DirectoryTestCoChanges.DirectoriesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5526,
+  serialized_end=5591,
+)
+
+_DIRECTORYTESTCOCHANGES = _descriptor.Descriptor(
+  name='DirectoryTestCoChanges',
+  full_name='DirectoryTestCoChanges',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='DirectoryTestCoChanges.directories', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DIRECTORYTESTCOCHANGES_DIRECTORIESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5437,
+  serialized_end=5591,
+)
+
+
+_TESTCOUPLINGRESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='TestCouplingResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='TestCouplingResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='TestCouplingResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5663,
+  serialized_end=5731,
+)
+
+_TESTCOUPLINGRESULTS = _descriptor.Descriptor(
+  name='TestCouplingResults',
+  full_name='TestCouplingResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='TestCouplingResults.days', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_TESTCOUPLINGRESULTS_DAYSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5594,
+  serialized_end=5731,
+)
+
+
+_TIMESKEWSTATS = _descriptor.Descriptor(
+  name='TimeSkewStats',
+  full_name='TimeSkewStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='TimeSkewStats.commits', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='skewed', full_name='TimeSkewStats.skewed', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='total', full_name='TimeSkewStats.total', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='max', full_name='TimeSkewStats.max', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5733,
+  serialized_end=5809,
+)
+
+
+_TIMESKEWRESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='TimeSkewResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='TimeSkewResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='TimeSkewResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5949,
+  serialized_end=6008,
+)
+
+_TIMESKEWRESULTS = _descriptor.Descriptor(
+  name='TimeSkewResults',
+  full_name='TimeSkewResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='threshold', full_name='TimeSkewResults.threshold', index=0,
+      number=1, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='days', full_name='TimeSkewResults.days', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people', full_name='TimeSkewResults.people', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people_sequence', full_name='TimeSkewResults.people_sequence', index=3,
+      number=4, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_TIMESKEWRESULTS_DAYSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5812,
+  serialized_end=6008,
+)
+
+
+_CHERRYPICK = _descriptor.Descriptor(
+  name='CherryPick',
+  full_name='CherryPick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='original', full_name='CherryPick.original', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='original_branch', full_name='CherryPick.original_branch', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='original_time', full_name='CherryPick.original_time', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='copy', full_name='CherryPick.copy', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='copy_branch', full_name='CherryPick.copy_branch', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='copy_time', full_name='CherryPick.copy_time', index=5,
+      number=6, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6011,
+  serialized_end=6143,
+)
+
+
+_BACKPORTSTATS = _descriptor.Descriptor(
+  name='BackportStats',
+  full_name='BackportStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='fixes', full_name='BackportStats.fixes', index=0,
+      number=1, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='median_latency', full_name='BackportStats.median_latency', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6145,
+  serialized_end=6199,
+)
+
+
+_CHERRYPICKSRESULTS_BACKPORTSENTRY = _descriptor.Descriptor(
+  name='BackportsEntry',
+  full_name='CherryPicksResults.BackportsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='CherryPicksResults.BackportsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='CherryPicksResults.BackportsEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6344,
+  serialized_end=6408,
+)
+
+_CHERRYPICKSRESULTS = _descriptor.Descriptor(
+  name='CherryPicksResults',
+  full_name='CherryPicksResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='CherryPicksResults.commits', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='duplicated', full_name='CherryPicksResults.duplicated', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='pairs', full_name='CherryPicksResults.pairs', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='backports', full_name='CherryPicksResults.backports', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_CHERRYPICKSRESULTS_BACKPORTSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6202,
+  serialized_end=6408,
+)
+
+
+_LINESTATS = _descriptor.Descriptor(
+  name='LineStats',
+  full_name='LineStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='added', full_name='LineStats.added', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='removed', full_name='LineStats.removed', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='changed', full_name='LineStats.changed', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6410,
+  serialized_end=6470,
+)
+
+
+_DEVDAY_LANGUAGESENTRY = _descriptor.Descriptor(
+  name='LanguagesEntry',
+  full_name='DevDay.LanguagesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DevDay.LanguagesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DevDay.LanguagesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6585,
+  serialized_end=6645,
+)
+
+_DEVDAY = _descriptor.Descriptor(
+  name='DevDay',
+  full_name='DevDay',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='DevDay.commits', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='stats', full_name='DevDay.stats', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='files', full_name='DevDay.files', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='languages', full_name='DevDay.languages', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DEVDAY_LANGUAGESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6473,
+  serialized_end=6645,
+)
+
+
+_DAYDEVS_DEVSENTRY = _descriptor.Descriptor(
+  name='DevsEntry',
+  full_name='DayDevs.DevsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DayDevs.DevsEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DayDevs.DevsEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6692,
+  serialized_end=6744,
+)
+
+_DAYDEVS = _descriptor.Descriptor(
+  name='DayDevs',
+  full_name='DayDevs',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='devs', full_name='DayDevs.devs', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DAYDEVS_DEVSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6647,
+  serialized_end=6744,
+)
+
+
+_DEVSANALYSISRESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='DevsAnalysisResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DevsAnalysisResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DevsAnalysisResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6835,
+  serialized_end=6888,
+)
+
+_DEVSANALYSISRESULTS = _descriptor.Descriptor(
+  name='DevsAnalysisResults',
+  full_name='DevsAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='DevsAnalysisResults.days', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='DevsAnalysisResults.dev_index', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DEVSANALYSISRESULTS_DAYSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6747,
+  serialized_end=6888,
+)
+
+
+_TYPOFIX = _descriptor.Descriptor(
+  name='TypoFix',
+  full_name='TypoFix',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='wrong', full_name='TypoFix.wrong', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='correct', full_name='TypoFix.correct', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commit', full_name='TypoFix.commit', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='file', full_name='TypoFix.file', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='line', full_name='TypoFix.line', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='before', full_name='TypoFix.before', index=5,
+      number=6, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='after', full_name='TypoFix.after', index=6,
+      number=7, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6890,
+  serialized_end=7006,
+)
+
+
+_TYPOFIXESRESULTS = _descriptor.Descriptor(
+  name='TypoFixesResults',
+  full_name='TypoFixesResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='fixes', full_name='TypoFixesResults.fixes', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7008,
+  serialized_end=7051,
+)
+
+
+_COMMENTRATIOSTATS = _descriptor.Descriptor(
+  name='CommentRatioStats',
+  full_name='CommentRatioStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='comments', full_name='CommentRatioStats.comments', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='code', full_name='CommentRatioStats.code', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7053,
+  serialized_end=7104,
+)
+
+
+_LANGUAGECOMMENTRATIOS_LANGUAGESENTRY = _descriptor.Descriptor(
+  name='LanguagesEntry',
+  full_name='LanguageCommentRatios.LanguagesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='LanguageCommentRatios.LanguagesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='LanguageCommentRatios.LanguagesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7190,
+  serialized_end=7258,
+)
+
+_LANGUAGECOMMENTRATIOS = _descriptor.Descriptor(
+  name='LanguageCommentRatios',
+  full_name='LanguageCommentRatios',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='languages', full_name='LanguageCommentRatios.languages', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_LANGUAGECOMMENTRATIOS_LANGUAGESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7107,
+  serialized_end=7258,
+)
+
+
+_COMMENTRATIORESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='CommentRatioResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='CommentRatioResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='CommentRatioResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7330,
+  serialized_end=7397,
+)
+
+_COMMENTRATIORESULTS = _descriptor.Descriptor(
+  name='CommentRatioResults',
+  full_name='CommentRatioResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='CommentRatioResults.days', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_COMMENTRATIORESULTS_DAYSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7261,
+  serialized_end=7397,
+)
+
+
+_COMMITMESSAGESTATS = _descriptor.Descriptor(
+  name='CommitMessageStats',
+  full_name='CommitMessageStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='CommitMessageStats.commits', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='score', full_name='CommitMessageStats.score', index=1,
+      number=2, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='good_subjects', full_name='CommitMessageStats.good_subjects', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='bodies', full_name='CommitMessageStats.bodies', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='imperative', full_name='CommitMessageStats.imperative', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='issue_refs', full_name='CommitMessageStats.issue_refs', index=5,
+      number=6, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7400,
+  serialized_end=7531,
+)
+
+
+_COMMITMESSAGESRESULTS_QUARTERSENTRY = _descriptor.Descriptor(
+  name='QuartersEntry',
+  full_name='CommitMessagesResults.QuartersEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='CommitMessagesResults.QuartersEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='CommitMessagesResults.QuartersEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7677,
+  serialized_end=7745,
+)
+
+_COMMITMESSAGESRESULTS = _descriptor.Descriptor(
+  name='CommitMessagesResults',
+  full_name='CommitMessagesResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='quarters', full_name='CommitMessagesResults.quarters', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people', full_name='CommitMessagesResults.people', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people_sequence', full_name='CommitMessagesResults.people_sequence', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_COMMITMESSAGESRESULTS_QUARTERSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7534,
+  serialized_end=7745,
+)
+
+
+_CHURNORIGINSTATS = _descriptor.Descriptor(
+  name='ChurnOriginStats',
+  full_name='ChurnOriginStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='self', full_name='ChurnOriginStats.self', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='foreign', full_name='ChurnOriginStats.foreign', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7747,
+  serialized_end=7796,
+)
+
+
+_DEVELOPERCHURNORIGIN_MONTHSENTRY = _descriptor.Descriptor(
+  name='MonthsEntry',
+  full_name='DeveloperChurnOrigin.MonthsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DeveloperChurnOrigin.MonthsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DeveloperChurnOrigin.MonthsEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7874,
+  serialized_end=7938,
+)
+
+_DEVELOPERCHURNORIGIN = _descriptor.Descriptor(
+  name='DeveloperChurnOrigin',
+  full_name='DeveloperChurnOrigin',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='months', full_name='DeveloperChurnOrigin.months', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DEVELOPERCHURNORIGIN_MONTHSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7799,
+  serialized_end=7938,
+)
+
+
+_CHURNORIGINRESULTS = _descriptor.Descriptor(
+  name='ChurnOriginResults',
+  full_name='ChurnOriginResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='people', full_name='ChurnOriginResults.people', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people_sequence', full_name='ChurnOriginResults.people_sequence', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7940,
+  serialized_end=8024,
+)
+
+
+_FILELIFECYCLECOUNTS = _descriptor.Descriptor(
+  name='FileLifecycleCounts',
+  full_name='FileLifecycleCounts',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='active', full_name='FileLifecycleCounts.active', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='maintained', full_name='FileLifecycleCounts.maintained', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='frozen', full_name='FileLifecycleCounts.frozen', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='abandoned', full_name='FileLifecycleCounts.abandoned', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8026,
+  serialized_end=8118,
+)
+
+
+_DIRECTORYLIFECYCLES_DIRECTORIESENTRY = _descriptor.Descriptor(
+  name='DirectoriesEntry',
+  full_name='DirectoryLifecycles.DirectoriesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DirectoryLifecycles.DirectoriesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DirectoryLifecycles.DirectoriesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8204,
+  serialized_end=8276,
+)
+
+_DIRECTORYLIFECYCLES = _descriptor.Descriptor(
+  name='DirectoryLifecycles',
+  full_name='DirectoryLifecycles',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='DirectoryLifecycles.directories', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DIRECTORYLIFECYCLES_DIRECTORIESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8121,
+  serialized_end=8276,
+)
+
+
+_FILELIFECYCLERESULTS_FILESENTRY = _descriptor.Descriptor(
+  name='FilesEntry',
+  full_name='FileLifecycleResults.FilesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='FileLifecycleResults.FilesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='FileLifecycleResults.FilesEntry.value', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8399,
+  serialized_end=8443,
+)
+
+_FILELIFECYCLERESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='FileLifecycleResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='FileLifecycleResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='FileLifecycleResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8445,
+  serialized_end=8510,
+)
+
+_FILELIFECYCLERESULTS = _descriptor.Descriptor(
+  name='FileLifecycleResults',
+  full_name='FileLifecycleResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='files', full_name='FileLifecycleResults.files', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='days', full_name='FileLifecycleResults.days', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_FILELIFECYCLERESULTS_FILESENTRY, _FILELIFECYCLERESULTS_DAYSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8279,
+  serialized_end=8510,
+)
+
+
+_RELEASEPRESSURESTATS = _descriptor.Descriptor(
+  name='ReleasePressureStats',
+  full_name='ReleasePressureStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='ReleasePressureStats.commits', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='ReleasePressureStats.lines', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='files', full_name='ReleasePressureStats.files', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='reverts', full_name='ReleasePressureStats.reverts', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8512,
+  serialized_end=8610,
+)
+
+
+_RELEASEPRESSURERESULTS = _descriptor.Descriptor(
+  name='ReleasePressureResults',
+  full_name='ReleasePressureResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='releases', full_name='ReleasePressureResults.releases', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='days', full_name='ReleasePressureResults.days', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='baseline', full_name='ReleasePressureResults.baseline', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8612,
+  serialized_end=8732,
+)
+
+
+_COMPANYATTRIBUTIONSTATS = _descriptor.Descriptor(
+  name='CompanyAttributionStats',
+  full_name='CompanyAttributionStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='CompanyAttributionStats.commits', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='CompanyAttributionStats.lines', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8734,
+  serialized_end=8791,
+)
+
+
+_COMPANYQUARTER_COMPANIESENTRY = _descriptor.Descriptor(
+  name='CompaniesEntry',
+  full_name='CompanyQuarter.CompaniesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='CompanyQuarter.CompaniesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='CompanyQuarter.CompaniesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8863,
+  serialized_end=8937,
+)
+
+_COMPANYQUARTER = _descriptor.Descriptor(
+  name='CompanyQuarter',
+  full_name='CompanyQuarter',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='companies', full_name='CompanyQuarter.companies', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_COMPANYQUARTER_COMPANIESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8794,
+  serialized_end=8937,
+)
+
+
+_COMPANYATTRIBUTIONRESULTS_QUARTERSENTRY = _descriptor.Descriptor(
+  name='QuartersEntry',
+  full_name='CompanyAttributionResults.QuartersEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='CompanyAttributionResults.QuartersEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='CompanyAttributionResults.QuartersEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9029,
+  serialized_end=9093,
+)
+
+_COMPANYATTRIBUTIONRESULTS = _descriptor.Descriptor(
+  name='CompanyAttributionResults',
+  full_name='CompanyAttributionResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='quarters', full_name='CompanyAttributionResults.quarters', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_COMPANYATTRIBUTIONRESULTS_QUARTERSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8940,
+  serialized_end=9093,
+)
+
+
+_BINARYCHURNSTATS = _descriptor.Descriptor(
+  name='BinaryChurnStats',
+  full_name='BinaryChurnStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='changes', full_name='BinaryChurnStats.changes', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='added_bytes', full_name='BinaryChurnStats.added_bytes', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='removed_bytes', full_name='BinaryChurnStats.removed_bytes', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9095,
+  serialized_end=9174,
+)
+
+
+_BINARYCHURNDIRECTORIES_DIRECTORIESENTRY = _descriptor.Descriptor(
+  name='DirectoriesEntry',
+  full_name='BinaryChurnDirectories.DirectoriesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='BinaryChurnDirectories.DirectoriesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='BinaryChurnDirectories.DirectoriesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9266,
+  serialized_end=9335,
+)
+
+_BINARYCHURNDIRECTORIES = _descriptor.Descriptor(
+  name='BinaryChurnDirectories',
+  full_name='BinaryChurnDirectories',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='BinaryChurnDirectories.directories', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_BINARYCHURNDIRECTORIES_DIRECTORIESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9177,
+  serialized_end=9335,
+)
+
+
+_BINARYASSETS = _descriptor.Descriptor(
+  name='BinaryAssets',
+  full_name='BinaryAssets',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='files', full_name='BinaryAssets.files', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='bytes', full_name='BinaryAssets.bytes', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9337,
+  serialized_end=9381,
+)
+
+
+_BINARYCHURNRESULTS_MONTHSENTRY = _descriptor.Descriptor(
+  name='MonthsEntry',
+  full_name='BinaryChurnResults.MonthsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='BinaryChurnResults.MonthsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='BinaryChurnResults.MonthsEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9506,
+  serialized_end=9576,
+)
+
+_BINARYCHURNRESULTS_CURRENTENTRY = _descriptor.Descriptor(
+  name='CurrentEntry',
+  full_name='BinaryChurnResults.CurrentEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='BinaryChurnResults.CurrentEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='BinaryChurnResults.CurrentEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9578,
+  serialized_end=9639,
+)
+
+_BINARYCHURNRESULTS = _descriptor.Descriptor(
+  name='BinaryChurnResults',
+  full_name='BinaryChurnResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='months', full_name='BinaryChurnResults.months', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='current', full_name='BinaryChurnResults.current', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_BINARYCHURNRESULTS_MONTHSENTRY, _BINARYCHURNRESULTS_CURRENTENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9384,
+  serialized_end=9639,
+)
+
+
+_REPOSITORYSIZESTATS = _descriptor.Descriptor(
+  name='RepositorySizeStats',
+  full_name='RepositorySizeStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='files', full_name='RepositorySizeStats.files', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='bytes', full_name='RepositorySizeStats.bytes', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='RepositorySizeStats.lines', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='history', full_name='RepositorySizeStats.history', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9641,
+  serialized_end=9724,
+)
+
+
+_REPOSITORYSIZECOMMIT = _descriptor.Descriptor(
+  name='RepositorySizeCommit',
+  full_name='RepositorySizeCommit',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='hash', full_name='RepositorySizeCommit.hash', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='growth', full_name='RepositorySizeCommit.growth', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9726,
+  serialized_end=9778,
+)
+
+
+_REPOSITORYSIZERESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='RepositorySizeResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='RepositorySizeResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='RepositorySizeResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9946,
+  serialized_end=10011,
+)
+
+_REPOSITORYSIZERESULTS_TAGSENTRY = _descriptor.Descriptor(
+  name='TagsEntry',
+  full_name='RepositorySizeResults.TagsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='RepositorySizeResults.TagsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='RepositorySizeResults.TagsEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10013,
+  serialized_end=10078,
+)
+
+_REPOSITORYSIZERESULTS = _descriptor.Descriptor(
+  name='RepositorySizeResults',
+  full_name='RepositorySizeResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='RepositorySizeResults.days', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='tags', full_name='RepositorySizeResults.tags', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='top_commits', full_name='RepositorySizeResults.top_commits', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_REPOSITORYSIZERESULTS_DAYSENTRY, _REPOSITORYSIZERESULTS_TAGSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9781,
+  serialized_end=10078,
+)
+
+
+_REFACTORINGSTATS = _descriptor.Descriptor(
+  name='RefactoringStats',
+  full_name='RefactoringStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='extracted_methods', full_name='RefactoringStats.extracted_methods', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='renamed_methods', full_name='RefactoringStats.renamed_methods', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='renamed_classes', full_name='RefactoringStats.renamed_classes', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='moved_classes', full_name='RefactoringStats.moved_classes', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='refactoring_nodes', full_name='RefactoringStats.refactoring_nodes', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='changed_nodes', full_name='RefactoringStats.changed_nodes', index=5,
+      number=6, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='RefactoringStats.commits', index=6,
+      number=7, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='refactoring_commits', full_name='RefactoringStats.refactoring_commits', index=7,
+      number=8, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10081,
+  serialized_end=10295,
+)
+
+
+_REFACTORINGRESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='RefactoringResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='RefactoringResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='RefactoringResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10425,
+  serialized_end=10487,
+)
+
+_REFACTORINGRESULTS = _descriptor.Descriptor(
+  name='RefactoringResults',
+  full_name='RefactoringResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='RefactoringResults.days', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people', full_name='RefactoringResults.people', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people_sequence', full_name='RefactoringResults.people_sequence', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_REFACTORINGRESULTS_DAYSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10298,
+  serialized_end=10487,
+)
+
+
+_COVERAGECHURNSTATS = _descriptor.Descriptor(
+  name='CoverageChurnStats',
+  full_name='CoverageChurnStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='covered', full_name='CoverageChurnStats.covered', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='uncovered', full_name='CoverageChurnStats.uncovered', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='unmeasured', full_name='CoverageChurnStats.unmeasured', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10489,
+  serialized_end=10565,
+)
+
+
+_COVERAGECHURNRESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='CoverageChurnResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='CoverageChurnResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='CoverageChurnResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10701,
+  serialized_end=10765,
+)
+
+_COVERAGECHURNRESULTS = _descriptor.Descriptor(
+  name='CoverageChurnResults',
+  full_name='CoverageChurnResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='CoverageChurnResults.days', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people', full_name='CoverageChurnResults.people', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people_sequence', full_name='CoverageChurnResults.people_sequence', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_COVERAGECHURNRESULTS_DAYSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10568,
+  serialized_end=10765,
+)
+
+
+_DEFECTSSTATS_SEVERITIESENTRY = _descriptor.Descriptor(
+  name='SeveritiesEntry',
+  full_name='DefectsStats.SeveritiesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DefectsStats.SeveritiesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DefectsStats.SeveritiesEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10913,
+  serialized_end=10962,
+)
+
+_DEFECTSSTATS = _descriptor.Descriptor(
+  name='DefectsStats',
+  full_name='DefectsStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='issues', full_name='DefectsStats.issues', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='fix_commits', full_name='DefectsStats.fix_commits', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='DefectsStats.lines', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='mean_time_to_fix', full_name='DefectsStats.mean_time_to_fix', index=3,
+      number=4, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='severities', full_name='DefectsStats.severities', index=4,
+      number=5, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DEFECTSSTATS_SEVERITIESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10768,
+  serialized_end=10962,
+)
+
+
+_DEFECTSRESULTS_COMPONENTSENTRY = _descriptor.Descriptor(
+  name='ComponentsEntry',
+  full_name='DefectsResults.ComponentsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DefectsResults.ComponentsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DefectsResults.ComponentsEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11075,
+  serialized_end=11139,
+)
+
+_DEFECTSRESULTS = _descriptor.Descriptor(
+  name='DefectsResults',
+  full_name='DefectsResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='components', full_name='DefectsResults.components', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='issues', full_name='DefectsResults.issues', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='linked_issues', full_name='DefectsResults.linked_issues', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DEFECTSRESULTS_COMPONENTSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10965,
+  serialized_end=11139,
+)
+
+
+_OWNERSHIPCONCENTRATIONSTATS = _descriptor.Descriptor(
+  name='OwnershipConcentrationStats',
+  full_name='OwnershipConcentrationStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='OwnershipConcentrationStats.lines', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='owners', full_name='OwnershipConcentrationStats.owners', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='gini', full_name='OwnershipConcentrationStats.gini', index=2,
+      number=3, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='entropy', full_name='OwnershipConcentrationStats.entropy', index=3,
+      number=4, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11141,
+  serialized_end=11232,
+)
+
+
+_DIRECTORYOWNERSHIPCONCENTRATION_DIRECTORIESENTRY = _descriptor.Descriptor(
+  name='DirectoriesEntry',
+  full_name='DirectoryOwnershipConcentration.DirectoriesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DirectoryOwnershipConcentration.DirectoriesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DirectoryOwnershipConcentration.DirectoriesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11342,
+  serialized_end=11422,
+)
+
+_DIRECTORYOWNERSHIPCONCENTRATION = _descriptor.Descriptor(
+  name='DirectoryOwnershipConcentration',
+  full_name='DirectoryOwnershipConcentration',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='DirectoryOwnershipConcentration.directories', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DIRECTORYOWNERSHIPCONCENTRATION_DIRECTORIESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11235,
+  serialized_end=11422,
+)
+
+
+_OWNERSHIPCONCENTRATIONRESULTS_QUARTERSENTRY = _descriptor.Descriptor(
+  name='QuartersEntry',
+  full_name='OwnershipConcentrationResults.QuartersEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='OwnershipConcentrationResults.QuartersEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='OwnershipConcentrationResults.QuartersEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11522,
+  serialized_end=11603,
+)
+
+_OWNERSHIPCONCENTRATIONRESULTS = _descriptor.Descriptor(
+  name='OwnershipConcentrationResults',
+  full_name='OwnershipConcentrationResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='quarters', full_name='OwnershipConcentrationResults.quarters', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_OWNERSHIPCONCENTRATIONRESULTS_QUARTERSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11425,
+  serialized_end=11603,
+)
+
+
+_EFFORTSTATS = _descriptor.Descriptor(
+  name='EffortStats',
+  full_name='EffortStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='added', full_name='EffortStats.added', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='removed', full_name='EffortStats.removed', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='surviving', full_name='EffortStats.surviving', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='effort', full_name='EffortStats.effort', index=3,
+      number=4, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='surviving_effort', full_name='EffortStats.surviving_effort', index=4,
+      number=5, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11605,
+  serialized_end=11711,
+)
+
+
+_COMPONENTEFFORTS_COMPONENTSENTRY = _descriptor.Descriptor(
+  name='ComponentsEntry',
+  full_name='ComponentEfforts.ComponentsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ComponentEfforts.ComponentsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ComponentEfforts.ComponentsEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11789,
+  serialized_end=11852,
+)
+
+_COMPONENTEFFORTS = _descriptor.Descriptor(
+  name='ComponentEfforts',
+  full_name='ComponentEfforts',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='components', full_name='ComponentEfforts.components', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_COMPONENTEFFORTS_COMPONENTSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11714,
+  serialized_end=11852,
+)
+
+
+_EFFORTESTIMATIONRESULTS_PERIODSENTRY = _descriptor.Descriptor(
+  name='PeriodsEntry',
+  full_name='EffortEstimationResults.PeriodsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='EffortEstimationResults.PeriodsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='EffortEstimationResults.PeriodsEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12084,
+  serialized_end=12149,
+)
+
+_EFFORTESTIMATIONRESULTS_COMPONENTSENTRY = _descriptor.Descriptor(
+  name='ComponentsEntry',
+  full_name='EffortEstimationResults.ComponentsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='EffortEstimationResults.ComponentsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='EffortEstimationResults.ComponentsEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12151,
+  serialized_end=12214,
+)
+
+_EFFORTESTIMATIONRESULTS = _descriptor.Descriptor(
+  name='EffortEstimationResults',
+  full_name='EffortEstimationResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='coefficient', full_name='EffortEstimationResults.coefficient', index=0,
+      number=1, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='exponent', full_name='EffortEstimationResults.exponent', index=1,
+      number=2, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='period', full_name='EffortEstimationResults.period', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='periods', full_name='EffortEstimationResults.periods', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='components', full_name='EffortEstimationResults.components', index=4,
+      number=5, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='total', full_name='EffortEstimationResults.total', index=5,
+      number=6, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_EFFORTESTIMATIONRESULTS_PERIODSENTRY, _EFFORTESTIMATIONRESULTS_COMPONENTSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11855,
+  serialized_end=12214,
+)
+
+
+_COMMENTREADABILITYSTATS = _descriptor.Descriptor(
+  name='CommentReadabilityStats',
+  full_name='CommentReadabilityStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='comments', full_name='CommentReadabilityStats.comments', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sentences', full_name='CommentReadabilityStats.sentences', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='words', full_name='CommentReadabilityStats.words', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='syllables', full_name='CommentReadabilityStats.syllables', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12216,
+  serialized_end=12312,
+)
+
+
+_DIRECTORYCOMMENTREADABILITY_DIRECTORIESENTRY = _descriptor.Descriptor(
+  name='DirectoriesEntry',
+  full_name='DirectoryCommentReadability.DirectoriesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='DirectoryCommentReadability.DirectoriesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='DirectoryCommentReadability.DirectoriesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12414,
+  serialized_end=12490,
+)
+
+_DIRECTORYCOMMENTREADABILITY = _descriptor.Descriptor(
+  name='DirectoryCommentReadability',
+  full_name='DirectoryCommentReadability',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='DirectoryCommentReadability.directories', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_DIRECTORYCOMMENTREADABILITY_DIRECTORIESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12315,
+  serialized_end=12490,
+)
+
+
+_COMMENTREADABILITYRESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='CommentReadabilityResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='CommentReadabilityResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='CommentReadabilityResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12574,
+  serialized_end=12647,
+)
+
+_COMMENTREADABILITYRESULTS = _descriptor.Descriptor(
+  name='CommentReadabilityResults',
+  full_name='CommentReadabilityResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='CommentReadabilityResults.days', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_COMMENTREADABILITYRESULTS_DAYSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12493,
+  serialized_end=12647,
+)
+
+
+_STALECOMMENT = _descriptor.Descriptor(
+  name='StaleComment',
+  full_name='StaleComment',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='file', full_name='StaleComment.file', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='line', full_name='StaleComment.line', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='comment', full_name='StaleComment.comment', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='since', full_name='StaleComment.since', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='changes', full_name='StaleComment.changes', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='last_change', full_name='StaleComment.last_change', index=5,
+      number=6, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12649,
+  serialized_end=12761,
+)
+
+
+_STALECOMMENTSRESULTS = _descriptor.Descriptor(
+  name='StaleCommentsResults',
+  full_name='StaleCommentsResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='comments', full_name='StaleCommentsResults.comments', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12763,
+  serialized_end=12818,
+)
+
+
+_TOXICITYSTATS = _descriptor.Descriptor(
+  name='ToxicityStats',
+  full_name='ToxicityStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='comments', full_name='ToxicityStats.comments', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='messages', full_name='ToxicityStats.messages', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12820,
+  serialized_end=12871,
+)
+
+
+_TOXICITYRESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='ToxicityResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ToxicityResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ToxicityResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13039,
+  serialized_end=13098,
+)
+
+_TOXICITYRESULTS_DIRECTORIESENTRY = _descriptor.Descriptor(
+  name='DirectoriesEntry',
+  full_name='ToxicityResults.DirectoriesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ToxicityResults.DirectoriesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ToxicityResults.DirectoriesEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13100,
+  serialized_end=13150,
+)
+
+_TOXICITYRESULTS_PHRASESENTRY = _descriptor.Descriptor(
+  name='PhrasesEntry',
+  full_name='ToxicityResults.PhrasesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ToxicityResults.PhrasesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ToxicityResults.PhrasesEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13152,
+  serialized_end=13198,
+)
+
+_TOXICITYRESULTS = _descriptor.Descriptor(
+  name='ToxicityResults',
+  full_name='ToxicityResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='ToxicityResults.days', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='ToxicityResults.directories', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='phrases', full_name='ToxicityResults.phrases', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_TOXICITYRESULTS_DAYSENTRY, _TOXICITYRESULTS_DIRECTORIESENTRY, _TOXICITYRESULTS_PHRASESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12874,
+  serialized_end=13198,
+)
+
+
+_FUNCTIONSIZESTATS = _descriptor.Descriptor(
+  name='FunctionSizeStats',
+  full_name='FunctionSizeStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='functions', full_name='FunctionSizeStats.functions', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='FunctionSizeStats.lines', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='median', full_name='FunctionSizeStats.median', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='p90', full_name='FunctionSizeStats.p90', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='max', full_name='FunctionSizeStats.max', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13200,
+  serialized_end=13295,
+)
+
+
+_LANGUAGEFUNCTIONSIZES_LANGUAGESENTRY = _descriptor.Descriptor(
+  name='LanguagesEntry',
+  full_name='LanguageFunctionSizes.LanguagesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='LanguageFunctionSizes.LanguagesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='LanguageFunctionSizes.LanguagesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13381,
+  serialized_end=13449,
+)
+
+_LANGUAGEFUNCTIONSIZES = _descriptor.Descriptor(
+  name='LanguageFunctionSizes',
+  full_name='LanguageFunctionSizes',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='languages', full_name='LanguageFunctionSizes.languages', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_LANGUAGEFUNCTIONSIZES_LANGUAGESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13298,
+  serialized_end=13449,
+)
+
+
+_LONGESTFUNCTION = _descriptor.Descriptor(
+  name='LongestFunction',
+  full_name='LongestFunction',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='file', full_name='LongestFunction.file', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='name', full_name='LongestFunction.name', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='LongestFunction.lines', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='since', full_name='LongestFunction.since', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='initial_lines', full_name='LongestFunction.initial_lines', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13451,
+  serialized_end=13549,
+)
+
+
+_FUNCTIONSIZERESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='FunctionSizeResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='FunctionSizeResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='FunctionSizeResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13656,
+  serialized_end=13723,
+)
+
+_FUNCTIONSIZERESULTS = _descriptor.Descriptor(
+  name='FunctionSizeResults',
+  full_name='FunctionSizeResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='FunctionSizeResults.days', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='longest', full_name='FunctionSizeResults.longest', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_FUNCTIONSIZERESULTS_DAYSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13552,
+  serialized_end=13723,
+)
+
+
+_NESTINGDEPTHSTATS = _descriptor.Descriptor(
+  name='NestingDepthStats',
+  full_name='NestingDepthStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='day', full_name='NestingDepthStats.day', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='blocks', full_name='NestingDepthStats.blocks', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='total', full_name='NestingDepthStats.total', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='max', full_name='NestingDepthStats.max', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13725,
+  serialized_end=13801,
+)
+
+
+_NESTINGDEPTHHISTORY = _descriptor.Descriptor(
+  name='NestingDepthHistory',
+  full_name='NestingDepthHistory',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='stats', full_name='NestingDepthHistory.stats', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13803,
+  serialized_end=13859,
+)
+
+
+_NESTINGDEPTHRESULTS_FILESENTRY = _descriptor.Descriptor(
+  name='FilesEntry',
+  full_name='NestingDepthResults.FilesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='NestingDepthResults.FilesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='NestingDepthResults.FilesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13991,
+  serialized_end=14057,
+)
+
+_NESTINGDEPTHRESULTS_INCREASINGENTRY = _descriptor.Descriptor(
+  name='IncreasingEntry',
+  full_name='NestingDepthResults.IncreasingEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='NestingDepthResults.IncreasingEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='NestingDepthResults.IncreasingEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14059,
+  serialized_end=14108,
+)
+
+_NESTINGDEPTHRESULTS = _descriptor.Descriptor(
+  name='NestingDepthResults',
+  full_name='NestingDepthResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='files', full_name='NestingDepthResults.files', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='increasing', full_name='NestingDepthResults.increasing', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_NESTINGDEPTHRESULTS_FILESENTRY, _NESTINGDEPTHRESULTS_INCREASINGENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13862,
+  serialized_end=14108,
+)
+
+
+_COMMITENTROPY = _descriptor.Descriptor(
+  name='CommitEntropy',
+  full_name='CommitEntropy',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commit', full_name='CommitEntropy.commit', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='day', full_name='CommitEntropy.day', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='files', full_name='CommitEntropy.files', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='CommitEntropy.directories', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='CommitEntropy.lines', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='entropy', full_name='CommitEntropy.entropy', index=5,
+      number=6, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='normalized_entropy', full_name='CommitEntropy.normalized_entropy', index=6,
+      number=7, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14111,
+  serialized_end=14251,
+)
+
+
+_COMMITENTROPYSTATS = _descriptor.Descriptor(
+  name='CommitEntropyStats',
+  full_name='CommitEntropyStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='CommitEntropyStats.commits', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='mean_entropy', full_name='CommitEntropyStats.mean_entropy', index=1,
+      number=2, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='scattered', full_name='CommitEntropyStats.scattered', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14253,
+  serialized_end=14331,
+)
+
+
+_COMMITENTROPYRESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='CommitEntropyResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='CommitEntropyResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='CommitEntropyResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14438,
+  serialized_end=14502,
+)
+
+_COMMITENTROPYRESULTS = _descriptor.Descriptor(
+  name='CommitEntropyResults',
+  full_name='CommitEntropyResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='CommitEntropyResults.commits', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='days', full_name='CommitEntropyResults.days', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_COMMITENTROPYRESULTS_DAYSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14334,
+  serialized_end=14502,
+)
+
+
+_TICKETLESSSTATS = _descriptor.Descriptor(
+  name='TicketlessStats',
+  full_name='TicketlessStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='TicketlessStats.commits', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='ticketless', full_name='TicketlessStats.ticketless', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14504,
+  serialized_end=14558,
+)
+
+
+_TICKETLESSCOMMITSRESULTS_MONTHSENTRY = _descriptor.Descriptor(
+  name='MonthsEntry',
+  full_name='TicketlessCommitsResults.MonthsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='TicketlessCommitsResults.MonthsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='TicketlessCommitsResults.MonthsEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14703,
+  serialized_end=14766,
+)
+
+_TICKETLESSCOMMITSRESULTS = _descriptor.Descriptor(
+  name='TicketlessCommitsResults',
+  full_name='TicketlessCommitsResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='months', full_name='TicketlessCommitsResults.months', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people', full_name='TicketlessCommitsResults.people', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people_sequence', full_name='TicketlessCommitsResults.people_sequence', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_TICKETLESSCOMMITSRESULTS_MONTHSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14561,
+  serialized_end=14766,
+)
+
+
+_CHANGESET = _descriptor.Descriptor(
+  name='ChangeSet',
+  full_name='ChangeSet',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='merge', full_name='ChangeSet.merge', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='merged', full_name='ChangeSet.merged', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='ChangeSet.commits', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='files', full_name='ChangeSet.files', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='ChangeSet.lines', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='duration', full_name='ChangeSet.duration', index=5,
+      number=6, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='authors', full_name='ChangeSet.authors', index=6,
+      number=7, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14768,
+  serialized_end=14892,
+)
+
+
+_CHANGESETSTATS = _descriptor.Descriptor(
+  name='ChangeSetStats',
+  full_name='ChangeSetStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='change_sets', full_name='ChangeSetStats.change_sets', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='median_lines', full_name='ChangeSetStats.median_lines', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='p90_lines', full_name='ChangeSetStats.p90_lines', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='median_duration', full_name='ChangeSetStats.median_duration', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='p90_duration', full_name='ChangeSetStats.p90_duration', index=4,
+      number=5, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='mean_authors', full_name='ChangeSetStats.mean_authors', index=5,
+      number=6, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='multi_author', full_name='ChangeSetStats.multi_author', index=6,
+      number=7, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14895,
+  serialized_end=15064,
+)
+
+
+_CHANGESETSRESULTS_MONTHSENTRY = _descriptor.Descriptor(
+  name='MonthsEntry',
+  full_name='ChangeSetsResults.MonthsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ChangeSetsResults.MonthsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ChangeSetsResults.MonthsEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15169,
+  serialized_end=15231,
+)
+
+_CHANGESETSRESULTS = _descriptor.Descriptor(
+  name='ChangeSetsResults',
+  full_name='ChangeSetsResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='change_sets', full_name='ChangeSetsResults.change_sets', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='months', full_name='ChangeSetsResults.months', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_CHANGESETSRESULTS_MONTHSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15067,
+  serialized_end=15231,
+)
+
+
+_CASECOLLISION = _descriptor.Descriptor(
+  name='CaseCollision',
+  full_name='CaseCollision',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='paths', full_name='CaseCollision.paths', index=0,
+      number=1, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='introduced', full_name='CaseCollision.introduced', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='resolved', full_name='CaseCollision.resolved', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15233,
+  serialized_end=15301,
+)
+
+
+_CASECOLLISIONSRESULTS = _descriptor.Descriptor(
+  name='CaseCollisionsResults',
+  full_name='CaseCollisionsResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='collisions', full_name='CaseCollisionsResults.collisions', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15303,
+  serialized_end=15362,
+)
+
+
+_EXTENSION = _descriptor.Descriptor(
+  name='Extension',
+  full_name='Extension',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='type_url', full_name='Extension.type_url', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='Extension.value', index=1,
+      number=2, type=12, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b(""),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15364,
+  serialized_end=15408,
+)
+
+
+_ANALYSISRESULTS_CONTENTSENTRY = _descriptor.Descriptor(
+  name='ContentsEntry',
+  full_name='AnalysisResults.ContentsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='AnalysisResults.ContentsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='AnalysisResults.ContentsEntry.value', index=1,
+      number=2, type=12, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b(""),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15561,
+  serialized_end=15608,
+)
+
+_ANALYSISRESULTS_EXTENSIONSENTRY = _descriptor.Descriptor(
+  name='ExtensionsEntry',
+  full_name='AnalysisResults.ExtensionsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='AnalysisResults.ExtensionsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='AnalysisResults.ExtensionsEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15610,
+  serialized_end=15671,
+)
+
+_ANALYSISRESULTS = _descriptor.Descriptor(
+  name='AnalysisResults',
+  full_name='AnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='header', full_name='AnalysisResults.header', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='contents', full_name='AnalysisResults.contents', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='extensions', full_name='AnalysisResults.extensions', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_ANALYSISRESULTS_CONTENTSENTRY, _ANALYSISRESULTS_EXTENSIONSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15411,
+  serialized_end=15671,
+)
+
+_METADATA.fields_by_name['failures'].message_type = _COMMITFAILURE
+_METADATA.fields_by_name['skipped'].message_type = _SKIPPEDITEM
+_METADATA.fields_by_name['markers'].message_type = _MARKER
+_BURNDOWNSPARSEMATRIX.fields_by_name['rows'].message_type = _BURNDOWNSPARSEMATRIXROW
+_BURNDOWNANALYSISRESULTS.fields_by_name['project'].message_type = _BURNDOWNSPARSEMATRIX
+_BURNDOWNANALYSISRESULTS.fields_by_name['files'].message_type = _BURNDOWNSPARSEMATRIX
+_BURNDOWNANALYSISRESULTS.fields_by_name['people'].message_type = _BURNDOWNSPARSEMATRIX
+_BURNDOWNANALYSISRESULTS.fields_by_name['people_interaction'].message_type = _COMPRESSEDSPARSEROWMATRIX
+_BURNDOWNANALYSISRESULTS.fields_by_name['groups'].message_type = _BURNDOWNSPARSEMATRIX
+_BURNDOWNANALYSISRESULTS.fields_by_name['directories'].message_type = _BURNDOWNDIRECTORY
+_BURNDOWNDIRECTORY.fields_by_name['matrix'].message_type = _BURNDOWNSPARSEMATRIX
+_COUPLES.fields_by_name['matrix'].message_type = _COMPRESSEDSPARSEROWMATRIX
+_COUPLESANALYSISRESULTS.fields_by_name['file_couples'].message_type = _COUPLES
+_COUPLESANALYSISRESULTS.fields_by_name['people_couples'].message_type = _COUPLES
+_COUPLESANALYSISRESULTS.fields_by_name['people_files'].message_type = _TOUCHEDFILES
+_COUPLESANALYSISRESULTS.fields_by_name['people_files_matrix'].message_type = _COMPRESSEDSPARSEROWMATRIX
+_UASTCHANGESSAVERRESULTS.fields_by_name['changes'].message_type = _UASTCHANGE
+_SHOTNESSRECORD_COUNTERSENTRY.containing_type = _SHOTNESSRECORD
+_SHOTNESSRECORD.fields_by_name['counters'].message_type = _SHOTNESSRECORD_COUNTERSENTRY
+_SHOTNESSANALYSISRESULTS.fields_by_name['records'].message_type = _SHOTNESSRECORD
+_FILEHISTORYRESULTMESSAGE_FILESENTRY.fields_by_name['value'].message_type = _FILEHISTORY
+_FILEHISTORYRESULTMESSAGE_FILESENTRY.containing_type = _FILEHISTORYRESULTMESSAGE
+_FILEHISTORYRESULTMESSAGE.fields_by_name['files'].message_type = _FILEHISTORYRESULTMESSAGE_FILESENTRY
+_SENTIMENT_EMOTIONSENTRY.containing_type = _SENTIMENT
+_SENTIMENT.fields_by_name['emotions'].message_type = _SENTIMENT_EMOTIONSENTRY
+_COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY.fields_by_name['value'].message_type = _SENTIMENT
+_COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY.containing_type = _COMMENTSENTIMENTRESULTS
+_COMMENTSENTIMENTRESULTS.fields_by_name['sentiment_by_day'].message_type = _COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY
+_COMMITFEATURESRESULTS.fields_by_name['commits'].message_type = _COMMITFEATURES
+_ROLESHISTOGRAM_ROLESENTRY.containing_type = _ROLESHISTOGRAM
+_ROLESHISTOGRAM.fields_by_name['roles'].message_type = _ROLESHISTOGRAM_ROLESENTRY
+_LANGUAGEROLESHISTOGRAMS_LANGUAGESENTRY.fields_by_name['value'].message_type = _ROLESHISTOGRAM
+_LANGUAGEROLESHISTOGRAMS_LANGUAGESENTRY.containing_type = _LANGUAGEROLESHISTOGRAMS
+_LANGUAGEROLESHISTOGRAMS.fields_by_name['languages'].message_type = _LANGUAGEROLESHISTOGRAMS_LANGUAGESENTRY
+_ROLESHISTOGRAMRESULTS_DAYSENTRY.fields_by_name['value'].message_type = _LANGUAGEROLESHISTOGRAMS
+_ROLESHISTOGRAMRESULTS_DAYSENTRY.containing_type = _ROLESHISTOGRAMRESULTS
+_ROLESHISTOGRAMRESULTS.fields_by_name['days'].message_type = _ROLESHISTOGRAMRESULTS_DAYSENTRY
+_DIRECTORYHALSTEAD_DIRECTORIESENTRY.fields_by_name['value'].message_type = _HALSTEADMETRICS
+_DIRECTORYHALSTEAD_DIRECTORIESENTRY.containing_type = _DIRECTORYHALSTEAD
+_DIRECTORYHALSTEAD.fields_by_name['directories'].message_type = _DIRECTORYHALSTEAD_DIRECTORIESENTRY
+_HALSTEADRESULTS_DAYSENTRY.fields_by_name['value'].message_type = _DIRECTORYHALSTEAD
+_HALSTEADRESULTS_DAYSENTRY.containing_type = _HALSTEADRESULTS
+_HALSTEADRESULTS.fields_by_name['days'].message_type = _HALSTEADRESULTS_DAYSENTRY
+_INDENTATIONHISTORY.fields_by_name['stats'].message_type = _INDENTATIONSTATS
+_INDENTATIONCOMPLEXITYRESULTS_FILESENTRY.fields_by_name['value'].message_type = _INDENTATIONHISTORY
+_INDENTATIONCOMPLEXITYRESULTS_FILESENTRY.containing_type = _INDENTATIONCOMPLEXITYRESULTS
+_INDENTATIONCOMPLEXITYRESULTS.fields_by_name['files'].message_type = _INDENTATIONCOMPLEXITYRESULTS_FILESENTRY
+_LANGUAGESTYLESTATS_LANGUAGESENTRY.fields_by_name['value'].message_type = _STYLESTATS
+_LANGUAGESTYLESTATS_LANGUAGESENTRY.containing_type = _LANGUAGESTYLESTATS
+_LANGUAGESTYLESTATS.fields_by_name['languages'].message_type = _LANGUAGESTYLESTATS_LANGUAGESENTRY
+_STYLEDRIFTRESULTS_DAYSENTRY.fields_by_name['value'].message_type = _LANGUAGESTYLESTATS
+_STYLEDRIFTRESULTS_DAYSENTRY.containing_type = _STYLEDRIFTRESULTS
+_STYLEDRIFTRESULTS.fields_by_name['days'].message_type = _STYLEDRIFTRESULTS_DAYSENTRY
+_GOFMTCOMPLIANCERESULTS_DAYSENTRY.fields_by_name['value'].message_type = _GOFMTCOMPLIANCE
+_GOFMTCOMPLIANCERESULTS_DAYSENTRY.containing_type = _GOFMTCOMPLIANCERESULTS
+_GOFMTCOMPLIANCERESULTS.fields_by_name['days'].message_type = _GOFMTCOMPLIANCERESULTS_DAYSENTRY
+_GOFMTCOMPLIANCERESULTS.fields_by_name['people'].message_type = _GOFMTCOMPLIANCE
+_STRINGLITERALSRESULTS.fields_by_name['releases'].message_type = _STRINGLITERALSRELEASE
+_SQLSTATS_TABLESENTRY.containing_type = _SQLSTATS
+_SQLSTATS.fields_by_name['tables'].message_type = _SQLSTATS_TABLESENTRY
+_SQLRESULTS_DAYSENTRY.fields_by_name['value'].message_type = _SQLSTATS
+_SQLRESULTS_DAYSENTRY.containing_type = _SQLRESULTS
+_SQLRESULTS_ORIGINSENTRY.fields_by_name['value'].message_type = _SQLTABLEORIGIN
+_SQLRESULTS_ORIGINSENTRY.containing_type = _SQLRESULTS
+_SQLRESULTS.fields_by_name['days'].message_type = _SQLRESULTS_DAYSENTRY
+_SQLRESULTS.fields_by_name['origins'].message_type = _SQLRESULTS_ORIGINSENTRY
+_ERRORHANDLINGRESULTS_DAYSENTRY.fields_by_name['value'].message_type = _ERRORHANDLINGSTATS
+_ERRORHANDLINGRESULTS_DAYSENTRY.containing_type = _ERRORHANDLINGRESULTS
+_ERRORHANDLINGRESULTS.fields_by_name['days'].message_type = _ERRORHANDLINGRESULTS_DAYSENTRY
+_DIRECTORYTESTCOCHANGES_DIRECTORIESENTRY.fields_by_name['value'].message_type = _TESTCOCHANGE
+_DIRECTORYTESTCOCHANGES_DIRECTORIESENTRY.containing_type = _DIRECTORYTESTCOCHANGES
+_DIRECTORYTESTCOCHANGES.fields_by_name['directories'].message_type = _DIRECTORYTESTCOCHANGES_DIRECTORIESENTRY
+_TESTCOUPLINGRESULTS_DAYSENTRY.fields_by_name['value'].message_type = _DIRECTORYTESTCOCHANGES
+_TESTCOUPLINGRESULTS_DAYSENTRY.containing_type = _TESTCOUPLINGRESULTS
+_TESTCOUPLINGRESULTS.fields_by_name['days'].message_type = _TESTCOUPLINGRESULTS_DAYSENTRY
+_TIMESKEWRESULTS_DAYSENTRY.fields_by_name['value'].message_type = _TIMESKEWSTATS
+_TIMESKEWRESULTS_DAYSENTRY.containing_type = _TIMESKEWRESULTS
+_TIMESKEWRESULTS.fields_by_name['days'].message_type = _TIMESKEWRESULTS_DAYSENTRY
+_TIMESKEWRESULTS.fields_by_name['people'].message_type = _TIMESKEWSTATS
+_CHERRYPICKSRESULTS_BACKPORTSENTRY.fields_by_name['value'].message_type = _BACKPORTSTATS
+_CHERRYPICKSRESULTS_BACKPORTSENTRY.containing_type = _CHERRYPICKSRESULTS
+_CHERRYPICKSRESULTS.fields_by_name['pairs'].message_type = _CHERRYPICK
+_CHERRYPICKSRESULTS.fields_by_name['backports'].message_type = _CHERRYPICKSRESULTS_BACKPORTSENTRY
+_DEVDAY_LANGUAGESENTRY.fields_by_name['value'].message_type = _LINESTATS
+_DEVDAY_LANGUAGESENTRY.containing_type = _DEVDAY
+_DEVDAY.fields_by_name['stats'].message_type = _LINESTATS
+_DEVDAY.fields_by_name['languages'].message_type = _DEVDAY_LANGUAGESENTRY
+_DAYDEVS_DEVSENTRY.fields_by_name['value'].message_type = _DEVDAY
+_DAYDEVS_DEVSENTRY.containing_type = _DAYDEVS
+_DAYDEVS.fields_by_name['devs'].message_type = _DAYDEVS_DEVSENTRY
+_DEVSANALYSISRESULTS_DAYSENTRY.fields_by_name['value'].message_type = _DAYDEVS
+_DEVSANALYSISRESULTS_DAYSENTRY.containing_type = _DEVSANALYSISRESULTS
+_DEVSANALYSISRESULTS.fields_by_name['days'].message_type = _DEVSANALYSISRESULTS_DAYSENTRY
+_TYPOFIXESRESULTS.fields_by_name['fixes'].message_type = _TYPOFIX
+_LANGUAGECOMMENTRATIOS_LANGUAGESENTRY.fields_by_name['value'].message_type = _COMMENTRATIOSTATS
+_LANGUAGECOMMENTRATIOS_LANGUAGESENTRY.containing_type = _LANGUAGECOMMENTRATIOS
+_LANGUAGECOMMENTRATIOS.fields_by_name['languages'].message_type = _LANGUAGECOMMENTRATIOS_LANGUAGESENTRY
+_COMMENTRATIORESULTS_DAYSENTRY.fields_by_name['value'].message_type = _LANGUAGECOMMENTRATIOS
+_COMMENTRATIORESULTS_DAYSENTRY.containing_type = _COMMENTRATIORESULTS
+_COMMENTRATIORESULTS.fields_by_name['days'].message_type = _COMMENTRATIORESULTS_DAYSENTRY
+_COMMITMESSAGESRESULTS_QUARTERSENTRY.fields_by_name['value'].message_type = _COMMITMESSAGESTATS
+_COMMITMESSAGESRESULTS_QUARTERSENTRY.containing_type = _COMMITMESSAGESRESULTS
+_COMMITMESSAGESRESULTS.fields_by_name['quarters'].message_type = _COMMITMESSAGESRESULTS_QUARTERSENTRY
+_COMMITMESSAGESRESULTS.fields_by_name['people'].message_type = _COMMITMESSAGESTATS
+_DEVELOPERCHURNORIGIN_MONTHSENTRY.fields_by_name['value'].message_type = _CHURNORIGINSTATS
+_DEVELOPERCHURNORIGIN_MONTHSENTRY.containing_type = _DEVELOPERCHURNORIGIN
+_DEVELOPERCHURNORIGIN.fields_by_name['months'].message_type = _DEVELOPERCHURNORIGIN_MONTHSENTRY
+_CHURNORIGINRESULTS.fields_by_name['people'].message_type = _DEVELOPERCHURNORIGIN
+_DIRECTORYLIFECYCLES_DIRECTORIESENTRY.fields_by_name['value'].message_type = _FILELIFECYCLECOUNTS
+_DIRECTORYLIFECYCLES_DIRECTORIESENTRY.containing_type = _DIRECTORYLIFECYCLES
+_DIRECTORYLIFECYCLES.fields_by_name['directories'].message_type = _DIRECTORYLIFECYCLES_DIRECTORIESENTRY
+_FILELIFECYCLERESULTS_FILESENTRY.containing_type = _FILELIFECYCLERESULTS
+_FILELIFECYCLERESULTS_DAYSENTRY.fields_by_name['value'].message_type = _DIRECTORYLIFECYCLES
+_FILELIFECYCLERESULTS_DAYSENTRY.containing_type = _FILELIFECYCLERESULTS
+_FILELIFECYCLERESULTS.fields_by_name['files'].message_type = _FILELIFECYCLERESULTS_FILESENTRY
+_FILELIFECYCLERESULTS.fields_by_name['days'].message_type = _FILELIFECYCLERESULTS_DAYSENTRY
+_RELEASEPRESSURESTATS.fields_by_name['lines'].message_type = _LINESTATS
+_RELEASEPRESSURERESULTS.fields_by_name['days'].message_type = _RELEASEPRESSURESTATS
+_RELEASEPRESSURERESULTS.fields_by_name['baseline'].message_type = _RELEASEPRESSURESTATS
+_COMPANYQUARTER_COMPANIESENTRY.fields_by_name['value'].message_type = _COMPANYATTRIBUTIONSTATS
+_COMPANYQUARTER_COMPANIESENTRY.containing_type = _COMPANYQUARTER
+_COMPANYQUARTER.fields_by_name['companies'].message_type = _COMPANYQUARTER_COMPANIESENTRY
+_COMPANYATTRIBUTIONRESULTS_QUARTERSENTRY.fields_by_name['value'].message_type = _COMPANYQUARTER
+_COMPANYATTRIBUTIONRESULTS_QUARTERSENTRY.containing_type = _COMPANYATTRIBUTIONRESULTS
+_COMPANYATTRIBUTIONRESULTS.fields_by_name['quarters'].message_type = _COMPANYATTRIBUTIONRESULTS_QUARTERSENTRY
+_BINARYCHURNDIRECTORIES_DIRECTORIESENTRY.fields_by_name['value'].message_type = _BINARYCHURNSTATS
+_BINARYCHURNDIRECTORIES_DIRECTORIESENTRY.containing_type = _BINARYCHURNDIRECTORIES
+_BINARYCHURNDIRECTORIES.fields_by_name['directories'].message_type = _BINARYCHURNDIRECTORIES_DIRECTORIESENTRY
+_BINARYCHURNRESULTS_MONTHSENTRY.fields_by_name['value'].message_type = _BINARYCHURNDIRECTORIES
+_BINARYCHURNRESULTS_MONTHSENTRY.containing_type = _BINARYCHURNRESULTS
+_BINARYCHURNRESULTS_CURRENTENTRY.fields_by_name['value'].message_type = _BINARYASSETS
+_BINARYCHURNRESULTS_CURRENTENTRY.containing_type = _BINARYCHURNRESULTS
+_BINARYCHURNRESULTS.fields_by_name['months'].message_type = _BINARYCHURNRESULTS_MONTHSENTRY
+_BINARYCHURNRESULTS.fields_by_name['current'].message_type = _BINARYCHURNRESULTS_CURRENTENTRY
+_REPOSITORYSIZERESULTS_DAYSENTRY.fields_by_name['value'].message_type = _REPOSITORYSIZESTATS
+_REPOSITORYSIZERESULTS_DAYSENTRY.containing_type = _REPOSITORYSIZERESULTS
+_REPOSITORYSIZERESULTS_TAGSENTRY.fields_by_name['value'].message_type = _REPOSITORYSIZESTATS
+_REPOSITORYSIZERESULTS_TAGSENTRY.containing_type = _REPOSITORYSIZERESULTS
+_REPOSITORYSIZERESULTS.fields_by_name['days'].message_type = _REPOSITORYSIZERESULTS_DAYSENTRY
+_REPOSITORYSIZERESULTS.fields_by_name['tags'].message_type = _REPOSITORYSIZERESULTS_TAGSENTRY
+_REPOSITORYSIZERESULTS.fields_by_name['top_commits'].message_type = _REPOSITORYSIZECOMMIT
+_REFACTORINGRESULTS_DAYSENTRY.fields_by_name['value'].message_type = _REFACTORINGSTATS
+_REFACTORINGRESULTS_DAYSENTRY.containing_type = _REFACTORINGRESULTS
+_REFACTORINGRESULTS.fields_by_name['days'].message_type = _REFACTORINGRESULTS_DAYSENTRY
+_REFACTORINGRESULTS.fields_by_name['people'].message_type = _REFACTORINGSTATS
+_COVERAGECHURNRESULTS_DAYSENTRY.fields_by_name['value'].message_type = _COVERAGECHURNSTATS
+_COVERAGECHURNRESULTS_DAYSENTRY.containing_type = _COVERAGECHURNRESULTS
+_COVERAGECHURNRESULTS.fields_by_name['days'].message_type = _COVERAGECHURNRESULTS_DAYSENTRY
+_COVERAGECHURNRESULTS.fields_by_name['people'].message_type = _COVERAGECHURNSTATS
+_DEFECTSSTATS_SEVERITIESENTRY.containing_type = _DEFECTSSTATS
+_DEFECTSSTATS.fields_by_name['severities'].message_type = _DEFECTSSTATS_SEVERITIESENTRY
+_DEFECTSRESULTS_COMPONENTSENTRY.fields_by_name['value'].message_type = _DEFECTSSTATS
+_DEFECTSRESULTS_COMPONENTSENTRY.containing_type = _DEFECTSRESULTS
+_DEFECTSRESULTS.fields_by_name['components'].message_type = _DEFECTSRESULTS_COMPONENTSENTRY
+_DIRECTORYOWNERSHIPCONCENTRATION_DIRECTORIESENTRY.fields_by_name['value'].message_type = _OWNERSHIPCONCENTRATIONSTATS
+_DIRECTORYOWNERSHIPCONCENTRATION_DIRECTORIESENTRY.containing_type = _DIRECTORYOWNERSHIPCONCENTRATION
+_DIRECTORYOWNERSHIPCONCENTRATION.fields_by_name['directories'].message_type = _DIRECTORYOWNERSHIPCONCENTRATION_DIRECTORIESENTRY
+_OWNERSHIPCONCENTRATIONRESULTS_QUARTERSENTRY.fields_by_name['value'].message_type = _DIRECTORYOWNERSHIPCONCENTRATION
+_OWNERSHIPCONCENTRATIONRESULTS_QUARTERSENTRY.containing_type = _OWNERSHIPCONCENTRATIONRESULTS
+_OWNERSHIPCONCENTRATIONRESULTS.fields_by_name['quarters'].message_type = _OWNERSHIPCONCENTRATIONRESULTS_QUARTERSENTRY
+_COMPONENTEFFORTS_COMPONENTSENTRY.fields_by_name['value'].message_type = _EFFORTSTATS
+_COMPONENTEFFORTS_COMPONENTSENTRY.containing_type = _COMPONENTEFFORTS
+_COMPONENTEFFORTS.fields_by_name['components'].message_type = _COMPONENTEFFORTS_COMPONENTSENTRY
+_EFFORTESTIMATIONRESULTS_PERIODSENTRY.fields_by_name['value'].message_type = _COMPONENTEFFORTS
+_EFFORTESTIMATIONRESULTS_PERIODSENTRY.containing_type = _EFFORTESTIMATIONRESULTS
+_EFFORTESTIMATIONRESULTS_COMPONENTSENTRY.fields_by_name['value'].message_type = _EFFORTSTATS
+_EFFORTESTIMATIONRESULTS_COMPONENTSENTRY.containing_type = _EFFORTESTIMATIONRESULTS
+_EFFORTESTIMATIONRESULTS.fields_by_name['periods'].message_type = _EFFORTESTIMATIONRESULTS_PERIODSENTRY
+_EFFORTESTIMATIONRESULTS.fields_by_name['components'].message_type = _EFFORTESTIMATIONRESULTS_COMPONENTSENTRY
+_EFFORTESTIMATIONRESULTS.fields_by_name['total'].message_type = _EFFORTSTATS
+_DIRECTORYCOMMENTREADABILITY_DIRECTORIESENTRY.fields_by_name['value'].message_type = _COMMENTREADABILITYSTATS
+_DIRECTORYCOMMENTREADABILITY_DIRECTORIESENTRY.containing_type = _DIRECTORYCOMMENTREADABILITY
+_DIRECTORYCOMMENTREADABILITY.fields_by_name['directories'].message_type = _DIRECTORYCOMMENTREADABILITY_DIRECTORIESENTRY
+_COMMENTREADABILITYRESULTS_DAYSENTRY.fields_by_name['value'].message_type = _DIRECTORYCOMMENTREADABILITY
+_COMMENTREADABILITYRESULTS_DAYSENTRY.containing_type = _COMMENTREADABILITYRESULTS
+_COMMENTREADABILITYRESULTS.fields_by_name['days'].message_type = _COMMENTREADABILITYRESULTS_DAYSENTRY
+_STALECOMMENTSRESULTS.fields_by_name['comments'].message_type = _STALECOMMENT
+_TOXICITYRESULTS_DAYSENTRY.fields_by_name['value'].message_type = _TOXICITYSTATS
+_TOXICITYRESULTS_DAYSENTRY.containing_type = _TOXICITYRESULTS
+_TOXICITYRESULTS_DIRECTORIESENTRY.containing_type = _TOXICITYRESULTS
+_TOXICITYRESULTS_PHRASESENTRY.containing_type = _TOXICITYRESULTS
+_TOXICITYRESULTS.fields_by_name['days'].message_type = _TOXICITYRESULTS_DAYSENTRY
+_TOXICITYRESULTS.fields_by_name['directories'].message_type = _TOXICITYRESULTS_DIRECTORIESENTRY
+_TOXICITYRESULTS.fields_by_name['phrases'].message_type = _TOXICITYRESULTS_PHRASESENTRY
+_LANGUAGEFUNCTIONSIZES_LANGUAGESENTRY.fields_by_name['value'].message_type = _FUNCTIONSIZESTATS
+_LANGUAGEFUNCTIONSIZES_LANGUAGESENTRY.containing_type = _LANGUAGEFUNCTIONSIZES
+_LANGUAGEFUNCTIONSIZES.fields_by_name['languages'].message_type = _LANGUAGEFUNCTIONSIZES_LANGUAGESENTRY
+_FUNCTIONSIZERESULTS_DAYSENTRY.fields_by_name['value'].message_type = _LANGUAGEFUNCTIONSIZES
+_FUNCTIONSIZERESULTS_DAYSENTRY.containing_type = _FUNCTIONSIZERESULTS
+_FUNCTIONSIZERESULTS.fields_by_name['days'].message_type = _FUNCTIONSIZERESULTS_DAYSENTRY
+_FUNCTIONSIZERESULTS.fields_by_name['longest'].message_type = _LONGESTFUNCTION
+_NESTINGDEPTHHISTORY.fields_by_name['stats'].message_type = _NESTINGDEPTHSTATS
+_NESTINGDEPTHRESULTS_FILESENTRY.fields_by_name['value'].message_type = _NESTINGDEPTHHISTORY
+_NESTINGDEPTHRESULTS_FILESENTRY.containing_type = _NESTINGDEPTHRESULTS
+_NESTINGDEPTHRESULTS_INCREASINGENTRY.containing_type = _NESTINGDEPTHRESULTS
+_NESTINGDEPTHRESULTS.fields_by_name['files'].message_type = _NESTINGDEPTHRESULTS_FILESENTRY
+_NESTINGDEPTHRESULTS.fields_by_name['increasing'].message_type = _NESTINGDEPTHRESULTS_INCREASINGENTRY
+_COMMITENTROPYRESULTS_DAYSENTRY.fields_by_name['value'].message_type = _COMMITENTROPYSTATS
+_COMMITENTROPYRESULTS_DAYSENTRY.containing_type = _COMMITENTROPYRESULTS
+_COMMITENTROPYRESULTS.fields_by_name['commits'].message_type = _COMMITENTROPY
+_COMMITENTROPYRESULTS.fields_by_name['days'].message_type = _COMMITENTROPYRESULTS_DAYSENTRY
+_TICKETLESSCOMMITSRESULTS_MONTHSENTRY.fields_by_name['value'].message_type = _TICKETLESSSTATS
+_TICKETLESSCOMMITSRESULTS_MONTHSENTRY.containing_type = _TICKETLESSCOMMITSRESULTS
+_TICKETLESSCOMMITSRESULTS.fields_by_name['months'].message_type = _TICKETLESSCOMMITSRESULTS_MONTHSENTRY
+_TICKETLESSCOMMITSRESULTS.fields_by_name['people'].message_type = _TICKETLESSSTATS
+_CHANGESETSRESULTS_MONTHSENTRY.fields_by_name['value'].message_type = _CHANGESETSTATS
+_CHANGESETSRESULTS_MONTHSENTRY.containing_type = _CHANGESETSRESULTS
+_CHANGESETSRESULTS.fields_by_name['change_sets'].message_type = _CHANGESET
+_CHANGESETSRESULTS.fields_by_name['months'].message_type = _CHANGESETSRESULTS_MONTHSENTRY
+_CASECOLLISIONSRESULTS.fields_by_name['collisions'].message_type = _CASECOLLISION
+_ANALYSISRESULTS_CONTENTSENTRY.containing_type = _ANALYSISRESULTS
+_ANALYSISRESULTS_EXTENSIONSENTRY.fields_by_name['value'].message_type = _EXTENSION
+_ANALYSISRESULTS_EXTENSIONSENTRY.containing_type = _ANALYSISRESULTS
+_ANALYSISRESULTS.fields_by_name['header'].message_type = _METADATA
+_ANALYSISRESULTS.fields_by_name['contents'].message_type = _ANALYSISRESULTS_CONTENTSENTRY
+_ANALYSISRESULTS.fields_by_name['extensions'].message_type = _ANALYSISRESULTS_EXTENSIONSENTRY
+DESCRIPTOR.message_types_by_name['Metadata'] = _METADATA
+DESCRIPTOR.message_types_by_name['Marker'] = _MARKER
+DESCRIPTOR.message_types_by_name['SkippedItem'] = _SKIPPEDITEM
+DESCRIPTOR.message_types_by_name['CommitFailure'] = _COMMITFAILURE
+DESCRIPTOR.message_types_by_name['BurndownSparseMatrixRow'] = _BURNDOWNSPARSEMATRIXROW
+DESCRIPTOR.message_types_by_name['BurndownSparseMatrix'] = _BURNDOWNSPARSEMATRIX
+DESCRIPTOR.message_types_by_name['BurndownAnalysisResults'] = _BURNDOWNANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['BurndownDirectory'] = _BURNDOWNDIRECTORY
+DESCRIPTOR.message_types_by_name['CompressedSparseRowMatrix'] = _COMPRESSEDSPARSEROWMATRIX
+DESCRIPTOR.message_types_by_name['Couples'] = _COUPLES
+DESCRIPTOR.message_types_by_name['TouchedFiles'] = _TOUCHEDFILES
+DESCRIPTOR.message_types_by_name['CouplesAnalysisResults'] = _COUPLESANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['UASTChange'] = _UASTCHANGE
+DESCRIPTOR.message_types_by_name['UASTChangesSaverResults'] = _UASTCHANGESSAVERRESULTS
+DESCRIPTOR.message_types_by_name['ShotnessRecord'] = _SHOTNESSRECORD
+DESCRIPTOR.message_types_by_name['ShotnessAnalysisResults'] = _SHOTNESSANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['FileHistory'] = _FILEHISTORY
+DESCRIPTOR.message_types_by_name['FileHistoryResultMessage'] = _FILEHISTORYRESULTMESSAGE
+DESCRIPTOR.message_types_by_name['Sentiment'] = _SENTIMENT
+DESCRIPTOR.message_types_by_name['CommentSentimentResults'] = _COMMENTSENTIMENTRESULTS
+DESCRIPTOR.message_types_by_name['CommitFeatures'] = _COMMITFEATURES
+DESCRIPTOR.message_types_by_name['CommitFeaturesResults'] = _COMMITFEATURESRESULTS
+DESCRIPTOR.message_types_by_name['RolesHistogram'] = _ROLESHISTOGRAM
+DESCRIPTOR.message_types_by_name['LanguageRolesHistograms'] = _LANGUAGEROLESHISTOGRAMS
+DESCRIPTOR.message_types_by_name['RolesHistogramResults'] = _ROLESHISTOGRAMRESULTS
+DESCRIPTOR.message_types_by_name['HalsteadMetrics'] = _HALSTEADMETRICS
+DESCRIPTOR.message_types_by_name['DirectoryHalstead'] = _DIRECTORYHALSTEAD
+DESCRIPTOR.message_types_by_name['HalsteadResults'] = _HALSTEADRESULTS
+DESCRIPTOR.message_types_by_name['IndentationStats'] = _INDENTATIONSTATS
+DESCRIPTOR.message_types_by_name['IndentationHistory'] = _INDENTATIONHISTORY
+DESCRIPTOR.message_types_by_name['IndentationComplexityResults'] = _INDENTATIONCOMPLEXITYRESULTS
+DESCRIPTOR.message_types_by_name['StyleStats'] = _STYLESTATS
+DESCRIPTOR.message_types_by_name['LanguageStyleStats'] = _LANGUAGESTYLESTATS
+DESCRIPTOR.message_types_by_name['StyleDriftResults'] = _STYLEDRIFTRESULTS
+DESCRIPTOR.message_types_by_name['GofmtCompliance'] = _GOFMTCOMPLIANCE
+DESCRIPTOR.message_types_by_name['GofmtComplianceResults'] = _GOFMTCOMPLIANCERESULTS
+DESCRIPTOR.message_types_by_name['StringLiteralsRelease'] = _STRINGLITERALSRELEASE
+DESCRIPTOR.message_types_by_name['StringLiteralsResults'] = _STRINGLITERALSRESULTS
+DESCRIPTOR.message_types_by_name['SQLStats'] = _SQLSTATS
+DESCRIPTOR.message_types_by_name['SQLTableOrigin'] = _SQLTABLEORIGIN
+DESCRIPTOR.message_types_by_name['SQLResults'] = _SQLRESULTS
+DESCRIPTOR.message_types_by_name['ErrorHandlingStats'] = _ERRORHANDLINGSTATS
+DESCRIPTOR.message_types_by_name['ErrorHandlingResults'] = _ERRORHANDLINGRESULTS
+DESCRIPTOR.message_types_by_name['TestCoChange'] = _TESTCOCHANGE
+DESCRIPTOR.message_types_by_name['DirectoryTestCoChanges'] = _DIRECTORYTESTCOCHANGES
+DESCRIPTOR.message_types_by_name['TestCouplingResults'] = _TESTCOUPLINGRESULTS
+DESCRIPTOR.message_types_by_name['TimeSkewStats'] = _TIMESKEWSTATS
+DESCRIPTOR.message_types_by_name['TimeSkewResults'] = _TIMESKEWRESULTS
+DESCRIPTOR.message_types_by_name['CherryPick'] = _CHERRYPICK
+DESCRIPTOR.message_types_by_name['BackportStats'] = _BACKPORTSTATS
+DESCRIPTOR.message_types_by_name['CherryPicksResults'] = _CHERRYPICKSRESULTS
+DESCRIPTOR.message_types_by_name['LineStats'] = _LINESTATS
+DESCRIPTOR.message_types_by_name['DevDay'] = _DEVDAY
+DESCRIPTOR.message_types_by_name['DayDevs'] = _DAYDEVS
+DESCRIPTOR.message_types_by_name['DevsAnalysisResults'] = _DEVSANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['TypoFix'] = _TYPOFIX
+DESCRIPTOR.message_types_by_name['TypoFixesResults'] = _TYPOFIXESRESULTS
+DESCRIPTOR.message_types_by_name['CommentRatioStats'] = _COMMENTRATIOSTATS
+DESCRIPTOR.message_types_by_name['LanguageCommentRatios'] = _LANGUAGECOMMENTRATIOS
+DESCRIPTOR.message_types_by_name['CommentRatioResults'] = _COMMENTRATIORESULTS
+DESCRIPTOR.message_types_by_name['CommitMessageStats'] = _COMMITMESSAGESTATS
+DESCRIPTOR.message_types_by_name['CommitMessagesResults'] = _COMMITMESSAGESRESULTS
+DESCRIPTOR.message_types_by_name['ChurnOriginStats'] = _CHURNORIGINSTATS
+DESCRIPTOR.message_types_by_name['DeveloperChurnOrigin'] = _DEVELOPERCHURNORIGIN
+DESCRIPTOR.message_types_by_name['ChurnOriginResults'] = _CHURNORIGINRESULTS
+DESCRIPTOR.message_types_by_name['FileLifecycleCounts'] = _FILELIFECYCLECOUNTS
+DESCRIPTOR.message_types_by_name['DirectoryLifecycles'] = _DIRECTORYLIFECYCLES
+DESCRIPTOR.message_types_by_name['FileLifecycleResults'] = _FILELIFECYCLERESULTS
+DESCRIPTOR.message_types_by_name['ReleasePressureStats'] = _RELEASEPRESSURESTATS
+DESCRIPTOR.message_types_by_name['ReleasePressureResults'] = _RELEASEPRESSURERESULTS
+DESCRIPTOR.message_types_by_name['CompanyAttributionStats'] = _COMPANYATTRIBUTIONSTATS
+DESCRIPTOR.message_types_by_name['CompanyQuarter'] = _COMPANYQUARTER
+DESCRIPTOR.message_types_by_name['CompanyAttributionResults'] = _COMPANYATTRIBUTIONRESULTS
+DESCRIPTOR.message_types_by_name['BinaryChurnStats'] = _BINARYCHURNSTATS
+DESCRIPTOR.message_types_by_name['BinaryChurnDirectories'] = _BINARYCHURNDIRECTORIES
+DESCRIPTOR.message_types_by_name['BinaryAssets'] = _BINARYASSETS
+DESCRIPTOR.message_types_by_name['BinaryChurnResults'] = _BINARYCHURNRESULTS
+DESCRIPTOR.message_types_by_name['RepositorySizeStats'] = _REPOSITORYSIZESTATS
+DESCRIPTOR.message_types_by_name['RepositorySizeCommit'] = _REPOSITORYSIZECOMMIT
+DESCRIPTOR.message_types_by_name['RepositorySizeResults'] = _REPOSITORYSIZERESULTS
+DESCRIPTOR.message_types_by_name['RefactoringStats'] = _REFACTORINGSTATS
+DESCRIPTOR.message_types_by_name['RefactoringResults'] = _REFACTORINGRESULTS
+DESCRIPTOR.message_types_by_name['CoverageChurnStats'] = _COVERAGECHURNSTATS
+DESCRIPTOR.message_types_by_name['CoverageChurnResults'] = _COVERAGECHURNRESULTS
+DESCRIPTOR.message_types_by_name['DefectsStats'] = _DEFECTSSTATS
+DESCRIPTOR.message_types_by_name['DefectsResults'] = _DEFECTSRESULTS
+DESCRIPTOR.message_types_by_name['OwnershipConcentrationStats'] = _OWNERSHIPCONCENTRATIONSTATS
+DESCRIPTOR.message_types_by_name['DirectoryOwnershipConcentration'] = _DIRECTORYOWNERSHIPCONCENTRATION
+DESCRIPTOR.message_types_by_name['OwnershipConcentrationResults'] = _OWNERSHIPCONCENTRATIONRESULTS
+DESCRIPTOR.message_types_by_name['EffortStats'] = _EFFORTSTATS
+DESCRIPTOR.message_types_by_name['ComponentEfforts'] = _COMPONENTEFFORTS
+DESCRIPTOR.message_types_by_name['EffortEstimationResults'] = _EFFORTESTIMATIONRESULTS
+DESCRIPTOR.message_types_by_name['CommentReadabilityStats'] = _COMMENTREADABILITYSTATS
+DESCRIPTOR.message_types_by_name['DirectoryCommentReadability'] = _DIRECTORYCOMMENTREADABILITY
+DESCRIPTOR.message_types_by_name['CommentReadabilityResults'] = _COMMENTREADABILITYRESULTS
+DESCRIPTOR.message_types_by_name['StaleComment'] = _STALECOMMENT
+DESCRIPTOR.message_types_by_name['StaleCommentsResults'] = _STALECOMMENTSRESULTS
+DESCRIPTOR.message_types_by_name['ToxicityStats'] = _TOXICITYSTATS
+DESCRIPTOR.message_types_by_name['ToxicityResults'] = _TOXICITYRESULTS
+DESCRIPTOR.message_types_by_name['FunctionSizeStats'] = _FUNCTIONSIZESTATS
+DESCRIPTOR.message_types_by_name['LanguageFunctionSizes'] = _LANGUAGEFUNCTIONSIZES
+DESCRIPTOR.message_types_by_name['LongestFunction'] = _LONGESTFUNCTION
+DESCRIPTOR.message_types_by_name['FunctionSizeResults'] = _FUNCTIONSIZERESULTS
+DESCRIPTOR.message_types_by_name['NestingDepthStats'] = _NESTINGDEPTHSTATS
+DESCRIPTOR.message_types_by_name['NestingDepthHistory'] = _NESTINGDEPTHHISTORY
+DESCRIPTOR.message_types_by_name['NestingDepthResults'] = _NESTINGDEPTHRESULTS
+DESCRIPTOR.message_types_by_name['CommitEntropy'] = _COMMITENTROPY
+DESCRIPTOR.message_types_by_name['CommitEntropyStats'] = _COMMITENTROPYSTATS
+DESCRIPTOR.message_types_by_name['CommitEntropyResults'] = _COMMITENTROPYRESULTS
+DESCRIPTOR.message_types_by_name['TicketlessStats'] = _TICKETLESSSTATS
+DESCRIPTOR.message_types_by_name['TicketlessCommitsResults'] = _TICKETLESSCOMMITSRESULTS
+DESCRIPTOR.message_types_by_name['ChangeSet'] = _CHANGESET
+DESCRIPTOR.message_types_by_name['ChangeSetStats'] = _CHANGESETSTATS
+DESCRIPTOR.message_types_by_name['ChangeSetsResults'] = _CHANGESETSRESULTS
+DESCRIPTOR.message_types_by_name['CaseCollision'] = _CASECOLLISION
+DESCRIPTOR.message_types_by_name['CaseCollisionsResults'] = _CASECOLLISIONSRESULTS
+DESCRIPTOR.message_types_by_name['Extension'] = _EXTENSION
+DESCRIPTOR.message_types_by_name['AnalysisResults'] = _ANALYSISRESULTS
+_sym_db.RegisterFileDescriptor(DESCRIPTOR)
+
+Metadata = _reflection.GeneratedProtocolMessageType('Metadata', (_message.Message,), dict(
+  DESCRIPTOR = _METADATA,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:Metadata)
+  ))
+_sym_db.RegisterMessage(Metadata)
+
+Marker = _reflection.GeneratedProtocolMessageType('Marker', (_message.Message,), dict(
+  DESCRIPTOR = _MARKER,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:Marker)
+  ))
+_sym_db.RegisterMessage(Marker)
+
+SkippedItem = _reflection.GeneratedProtocolMessageType('SkippedItem', (_message.Message,), dict(
+  DESCRIPTOR = _SKIPPEDITEM,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:SkippedItem)
+  ))
+_sym_db.RegisterMessage(SkippedItem)
+
+CommitFailure = _reflection.GeneratedProtocolMessageType('CommitFailure', (_message.Message,), dict(
+  DESCRIPTOR = _COMMITFAILURE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommitFailure)
+  ))
+_sym_db.RegisterMessage(CommitFailure)
+
+BurndownSparseMatrixRow = _reflection.GeneratedProtocolMessageType('BurndownSparseMatrixRow', (_message.Message,), dict(
+  DESCRIPTOR = _BURNDOWNSPARSEMATRIXROW,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:BurndownSparseMatrixRow)
+  ))
+_sym_db.RegisterMessage(BurndownSparseMatrixRow)
+
+BurndownSparseMatrix = _reflection.GeneratedProtocolMessageType('BurndownSparseMatrix', (_message.Message,), dict(
+  DESCRIPTOR = _BURNDOWNSPARSEMATRIX,
+  __module__ = 'pb_pb2'
   # @@protoc_insertion_point(class_scope:BurndownSparseMatrix)
   ))
-_sym_db.RegisterMessage(BurndownSparseMatrix)
+_sym_db.RegisterMessage(BurndownSparseMatrix)
+
+BurndownAnalysisResults = _reflection.GeneratedProtocolMessageType('BurndownAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _BURNDOWNANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:BurndownAnalysisResults)
+  ))
+_sym_db.RegisterMessage(BurndownAnalysisResults)
+
+BurndownDirectory = _reflection.GeneratedProtocolMessageType('BurndownDirectory', (_message.Message,), dict(
+  DESCRIPTOR = _BURNDOWNDIRECTORY,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:BurndownDirectory)
+  ))
+_sym_db.RegisterMessage(BurndownDirectory)
+
+CompressedSparseRowMatrix = _reflection.GeneratedProtocolMessageType('CompressedSparseRowMatrix', (_message.Message,), dict(
+  DESCRIPTOR = _COMPRESSEDSPARSEROWMATRIX,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CompressedSparseRowMatrix)
+  ))
+_sym_db.RegisterMessage(CompressedSparseRowMatrix)
+
+Couples = _reflection.GeneratedProtocolMessageType('Couples', (_message.Message,), dict(
+  DESCRIPTOR = _COUPLES,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:Couples)
+  ))
+_sym_db.RegisterMessage(Couples)
+
+TouchedFiles = _reflection.GeneratedProtocolMessageType('TouchedFiles', (_message.Message,), dict(
+  DESCRIPTOR = _TOUCHEDFILES,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TouchedFiles)
+  ))
+_sym_db.RegisterMessage(TouchedFiles)
+
+CouplesAnalysisResults = _reflection.GeneratedProtocolMessageType('CouplesAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _COUPLESANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CouplesAnalysisResults)
+  ))
+_sym_db.RegisterMessage(CouplesAnalysisResults)
+
+UASTChange = _reflection.GeneratedProtocolMessageType('UASTChange', (_message.Message,), dict(
+  DESCRIPTOR = _UASTCHANGE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:UASTChange)
+  ))
+_sym_db.RegisterMessage(UASTChange)
+
+UASTChangesSaverResults = _reflection.GeneratedProtocolMessageType('UASTChangesSaverResults', (_message.Message,), dict(
+  DESCRIPTOR = _UASTCHANGESSAVERRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:UASTChangesSaverResults)
+  ))
+_sym_db.RegisterMessage(UASTChangesSaverResults)
+
+ShotnessRecord = _reflection.GeneratedProtocolMessageType('ShotnessRecord', (_message.Message,), dict(
+
+  CountersEntry = _reflection.GeneratedProtocolMessageType('CountersEntry', (_message.Message,), dict(
+    DESCRIPTOR = _SHOTNESSRECORD_COUNTERSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ShotnessRecord.CountersEntry)
+    ))
+  ,
+  DESCRIPTOR = _SHOTNESSRECORD,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ShotnessRecord)
+  ))
+_sym_db.RegisterMessage(ShotnessRecord)
+_sym_db.RegisterMessage(ShotnessRecord.CountersEntry)
+
+ShotnessAnalysisResults = _reflection.GeneratedProtocolMessageType('ShotnessAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _SHOTNESSANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ShotnessAnalysisResults)
+  ))
+_sym_db.RegisterMessage(ShotnessAnalysisResults)
+
+FileHistory = _reflection.GeneratedProtocolMessageType('FileHistory', (_message.Message,), dict(
+  DESCRIPTOR = _FILEHISTORY,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:FileHistory)
+  ))
+_sym_db.RegisterMessage(FileHistory)
+
+FileHistoryResultMessage = _reflection.GeneratedProtocolMessageType('FileHistoryResultMessage', (_message.Message,), dict(
+
+  FilesEntry = _reflection.GeneratedProtocolMessageType('FilesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _FILEHISTORYRESULTMESSAGE_FILESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:FileHistoryResultMessage.FilesEntry)
+    ))
+  ,
+  DESCRIPTOR = _FILEHISTORYRESULTMESSAGE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:FileHistoryResultMessage)
+  ))
+_sym_db.RegisterMessage(FileHistoryResultMessage)
+_sym_db.RegisterMessage(FileHistoryResultMessage.FilesEntry)
+
+Sentiment = _reflection.GeneratedProtocolMessageType('Sentiment', (_message.Message,), dict(
+
+  EmotionsEntry = _reflection.GeneratedProtocolMessageType('EmotionsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _SENTIMENT_EMOTIONSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:Sentiment.EmotionsEntry)
+    ))
+  ,
+  DESCRIPTOR = _SENTIMENT,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:Sentiment)
+  ))
+_sym_db.RegisterMessage(Sentiment)
+_sym_db.RegisterMessage(Sentiment.EmotionsEntry)
+
+CommentSentimentResults = _reflection.GeneratedProtocolMessageType('CommentSentimentResults', (_message.Message,), dict(
+
+  SentimentByDayEntry = _reflection.GeneratedProtocolMessageType('SentimentByDayEntry', (_message.Message,), dict(
+    DESCRIPTOR = _COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:CommentSentimentResults.SentimentByDayEntry)
+    ))
+  ,
+  DESCRIPTOR = _COMMENTSENTIMENTRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommentSentimentResults)
+  ))
+_sym_db.RegisterMessage(CommentSentimentResults)
+_sym_db.RegisterMessage(CommentSentimentResults.SentimentByDayEntry)
+
+CommitFeatures = _reflection.GeneratedProtocolMessageType('CommitFeatures', (_message.Message,), dict(
+  DESCRIPTOR = _COMMITFEATURES,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommitFeatures)
+  ))
+_sym_db.RegisterMessage(CommitFeatures)
+
+CommitFeaturesResults = _reflection.GeneratedProtocolMessageType('CommitFeaturesResults', (_message.Message,), dict(
+  DESCRIPTOR = _COMMITFEATURESRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommitFeaturesResults)
+  ))
+_sym_db.RegisterMessage(CommitFeaturesResults)
+
+RolesHistogram = _reflection.GeneratedProtocolMessageType('RolesHistogram', (_message.Message,), dict(
+
+  RolesEntry = _reflection.GeneratedProtocolMessageType('RolesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _ROLESHISTOGRAM_ROLESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:RolesHistogram.RolesEntry)
+    ))
+  ,
+  DESCRIPTOR = _ROLESHISTOGRAM,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RolesHistogram)
+  ))
+_sym_db.RegisterMessage(RolesHistogram)
+_sym_db.RegisterMessage(RolesHistogram.RolesEntry)
+
+LanguageRolesHistograms = _reflection.GeneratedProtocolMessageType('LanguageRolesHistograms', (_message.Message,), dict(
+
+  LanguagesEntry = _reflection.GeneratedProtocolMessageType('LanguagesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _LANGUAGEROLESHISTOGRAMS_LANGUAGESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:LanguageRolesHistograms.LanguagesEntry)
+    ))
+  ,
+  DESCRIPTOR = _LANGUAGEROLESHISTOGRAMS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:LanguageRolesHistograms)
+  ))
+_sym_db.RegisterMessage(LanguageRolesHistograms)
+_sym_db.RegisterMessage(LanguageRolesHistograms.LanguagesEntry)
+
+RolesHistogramResults = _reflection.GeneratedProtocolMessageType('RolesHistogramResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _ROLESHISTOGRAMRESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:RolesHistogramResults.DaysEntry)
+    ))
+  ,
+  DESCRIPTOR = _ROLESHISTOGRAMRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RolesHistogramResults)
+  ))
+_sym_db.RegisterMessage(RolesHistogramResults)
+_sym_db.RegisterMessage(RolesHistogramResults.DaysEntry)
+
+HalsteadMetrics = _reflection.GeneratedProtocolMessageType('HalsteadMetrics', (_message.Message,), dict(
+  DESCRIPTOR = _HALSTEADMETRICS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:HalsteadMetrics)
+  ))
+_sym_db.RegisterMessage(HalsteadMetrics)
+
+DirectoryHalstead = _reflection.GeneratedProtocolMessageType('DirectoryHalstead', (_message.Message,), dict(
+
+  DirectoriesEntry = _reflection.GeneratedProtocolMessageType('DirectoriesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _DIRECTORYHALSTEAD_DIRECTORIESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:DirectoryHalstead.DirectoriesEntry)
+    ))
+  ,
+  DESCRIPTOR = _DIRECTORYHALSTEAD,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DirectoryHalstead)
+  ))
+_sym_db.RegisterMessage(DirectoryHalstead)
+_sym_db.RegisterMessage(DirectoryHalstead.DirectoriesEntry)
+
+HalsteadResults = _reflection.GeneratedProtocolMessageType('HalsteadResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _HALSTEADRESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:HalsteadResults.DaysEntry)
+    ))
+  ,
+  DESCRIPTOR = _HALSTEADRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:HalsteadResults)
+  ))
+_sym_db.RegisterMessage(HalsteadResults)
+_sym_db.RegisterMessage(HalsteadResults.DaysEntry)
+
+IndentationStats = _reflection.GeneratedProtocolMessageType('IndentationStats', (_message.Message,), dict(
+  DESCRIPTOR = _INDENTATIONSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:IndentationStats)
+  ))
+_sym_db.RegisterMessage(IndentationStats)
+
+IndentationHistory = _reflection.GeneratedProtocolMessageType('IndentationHistory', (_message.Message,), dict(
+  DESCRIPTOR = _INDENTATIONHISTORY,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:IndentationHistory)
+  ))
+_sym_db.RegisterMessage(IndentationHistory)
+
+IndentationComplexityResults = _reflection.GeneratedProtocolMessageType('IndentationComplexityResults', (_message.Message,), dict(
+
+  FilesEntry = _reflection.GeneratedProtocolMessageType('FilesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _INDENTATIONCOMPLEXITYRESULTS_FILESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:IndentationComplexityResults.FilesEntry)
+    ))
+  ,
+  DESCRIPTOR = _INDENTATIONCOMPLEXITYRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:IndentationComplexityResults)
+  ))
+_sym_db.RegisterMessage(IndentationComplexityResults)
+_sym_db.RegisterMessage(IndentationComplexityResults.FilesEntry)
+
+StyleStats = _reflection.GeneratedProtocolMessageType('StyleStats', (_message.Message,), dict(
+  DESCRIPTOR = _STYLESTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:StyleStats)
+  ))
+_sym_db.RegisterMessage(StyleStats)
+
+LanguageStyleStats = _reflection.GeneratedProtocolMessageType('LanguageStyleStats', (_message.Message,), dict(
+
+  LanguagesEntry = _reflection.GeneratedProtocolMessageType('LanguagesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _LANGUAGESTYLESTATS_LANGUAGESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:LanguageStyleStats.LanguagesEntry)
+    ))
+  ,
+  DESCRIPTOR = _LANGUAGESTYLESTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:LanguageStyleStats)
+  ))
+_sym_db.RegisterMessage(LanguageStyleStats)
+_sym_db.RegisterMessage(LanguageStyleStats.LanguagesEntry)
+
+StyleDriftResults = _reflection.GeneratedProtocolMessageType('StyleDriftResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _STYLEDRIFTRESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:StyleDriftResults.DaysEntry)
+    ))
+  ,
+  DESCRIPTOR = _STYLEDRIFTRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:StyleDriftResults)
+  ))
+_sym_db.RegisterMessage(StyleDriftResults)
+_sym_db.RegisterMessage(StyleDriftResults.DaysEntry)
+
+GofmtCompliance = _reflection.GeneratedProtocolMessageType('GofmtCompliance', (_message.Message,), dict(
+  DESCRIPTOR = _GOFMTCOMPLIANCE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:GofmtCompliance)
+  ))
+_sym_db.RegisterMessage(GofmtCompliance)
+
+GofmtComplianceResults = _reflection.GeneratedProtocolMessageType('GofmtComplianceResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _GOFMTCOMPLIANCERESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:GofmtComplianceResults.DaysEntry)
+    ))
+  ,
+  DESCRIPTOR = _GOFMTCOMPLIANCERESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:GofmtComplianceResults)
+  ))
+_sym_db.RegisterMessage(GofmtComplianceResults)
+_sym_db.RegisterMessage(GofmtComplianceResults.DaysEntry)
+
+StringLiteralsRelease = _reflection.GeneratedProtocolMessageType('StringLiteralsRelease', (_message.Message,), dict(
+  DESCRIPTOR = _STRINGLITERALSRELEASE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:StringLiteralsRelease)
+  ))
+_sym_db.RegisterMessage(StringLiteralsRelease)
+
+StringLiteralsResults = _reflection.GeneratedProtocolMessageType('StringLiteralsResults', (_message.Message,), dict(
+  DESCRIPTOR = _STRINGLITERALSRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:StringLiteralsResults)
+  ))
+_sym_db.RegisterMessage(StringLiteralsResults)
+
+SQLStats = _reflection.GeneratedProtocolMessageType('SQLStats', (_message.Message,), dict(
+
+  TablesEntry = _reflection.GeneratedProtocolMessageType('TablesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _SQLSTATS_TABLESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:SQLStats.TablesEntry)
+    ))
+  ,
+  DESCRIPTOR = _SQLSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:SQLStats)
+  ))
+_sym_db.RegisterMessage(SQLStats)
+_sym_db.RegisterMessage(SQLStats.TablesEntry)
+
+SQLTableOrigin = _reflection.GeneratedProtocolMessageType('SQLTableOrigin', (_message.Message,), dict(
+  DESCRIPTOR = _SQLTABLEORIGIN,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:SQLTableOrigin)
+  ))
+_sym_db.RegisterMessage(SQLTableOrigin)
+
+SQLResults = _reflection.GeneratedProtocolMessageType('SQLResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _SQLRESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:SQLResults.DaysEntry)
+    ))
+  ,
+
+  OriginsEntry = _reflection.GeneratedProtocolMessageType('OriginsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _SQLRESULTS_ORIGINSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:SQLResults.OriginsEntry)
+    ))
+  ,
+  DESCRIPTOR = _SQLRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:SQLResults)
+  ))
+_sym_db.RegisterMessage(SQLResults)
+_sym_db.RegisterMessage(SQLResults.DaysEntry)
+_sym_db.RegisterMessage(SQLResults.OriginsEntry)
+
+ErrorHandlingStats = _reflection.GeneratedProtocolMessageType('ErrorHandlingStats', (_message.Message,), dict(
+  DESCRIPTOR = _ERRORHANDLINGSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ErrorHandlingStats)
+  ))
+_sym_db.RegisterMessage(ErrorHandlingStats)
+
+ErrorHandlingResults = _reflection.GeneratedProtocolMessageType('ErrorHandlingResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _ERRORHANDLINGRESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ErrorHandlingResults.DaysEntry)
+    ))
+  ,
+  DESCRIPTOR = _ERRORHANDLINGRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ErrorHandlingResults)
+  ))
+_sym_db.RegisterMessage(ErrorHandlingResults)
+_sym_db.RegisterMessage(ErrorHandlingResults.DaysEntry)
+
+TestCoChange = _reflection.GeneratedProtocolMessageType('TestCoChange', (_message.Message,), dict(
+  DESCRIPTOR = _TESTCOCHANGE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TestCoChange)
+  ))
+_sym_db.RegisterMessage(TestCoChange)
+
+DirectoryTestCoChanges = _reflection.GeneratedProtocolMessageType('DirectoryTestCoChanges', (_message.Message,), dict(
+
+  DirectoriesEntry = _reflection.GeneratedProtocolMessageType('DirectoriesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _DIRECTORYTESTCOCHANGES_DIRECTORIESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:DirectoryTestCoChanges.DirectoriesEntry)
+    ))
+  ,
+  DESCRIPTOR = _DIRECTORYTESTCOCHANGES,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DirectoryTestCoChanges)
+  ))
+_sym_db.RegisterMessage(DirectoryTestCoChanges)
+_sym_db.RegisterMessage(DirectoryTestCoChanges.DirectoriesEntry)
+
+TestCouplingResults = _reflection.GeneratedProtocolMessageType('TestCouplingResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _TESTCOUPLINGRESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:TestCouplingResults.DaysEntry)
+    ))
+  ,
+  DESCRIPTOR = _TESTCOUPLINGRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TestCouplingResults)
+  ))
+_sym_db.RegisterMessage(TestCouplingResults)
+_sym_db.RegisterMessage(TestCouplingResults.DaysEntry)
+
+TimeSkewStats = _reflection.GeneratedProtocolMessageType('TimeSkewStats', (_message.Message,), dict(
+  DESCRIPTOR = _TIMESKEWSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TimeSkewStats)
+  ))
+_sym_db.RegisterMessage(TimeSkewStats)
+
+TimeSkewResults = _reflection.GeneratedProtocolMessageType('TimeSkewResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _TIMESKEWRESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:TimeSkewResults.DaysEntry)
+    ))
+  ,
+  DESCRIPTOR = _TIMESKEWRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TimeSkewResults)
+  ))
+_sym_db.RegisterMessage(TimeSkewResults)
+_sym_db.RegisterMessage(TimeSkewResults.DaysEntry)
+
+CherryPick = _reflection.GeneratedProtocolMessageType('CherryPick', (_message.Message,), dict(
+  DESCRIPTOR = _CHERRYPICK,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CherryPick)
+  ))
+_sym_db.RegisterMessage(CherryPick)
+
+BackportStats = _reflection.GeneratedProtocolMessageType('BackportStats', (_message.Message,), dict(
+  DESCRIPTOR = _BACKPORTSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:BackportStats)
+  ))
+_sym_db.RegisterMessage(BackportStats)
+
+CherryPicksResults = _reflection.GeneratedProtocolMessageType('CherryPicksResults', (_message.Message,), dict(
+
+  BackportsEntry = _reflection.GeneratedProtocolMessageType('BackportsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _CHERRYPICKSRESULTS_BACKPORTSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:CherryPicksResults.BackportsEntry)
+    ))
+  ,
+  DESCRIPTOR = _CHERRYPICKSRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CherryPicksResults)
+  ))
+_sym_db.RegisterMessage(CherryPicksResults)
+_sym_db.RegisterMessage(CherryPicksResults.BackportsEntry)
+
+LineStats = _reflection.GeneratedProtocolMessageType('LineStats', (_message.Message,), dict(
+  DESCRIPTOR = _LINESTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:LineStats)
+  ))
+_sym_db.RegisterMessage(LineStats)
+
+DevDay = _reflection.GeneratedProtocolMessageType('DevDay', (_message.Message,), dict(
+
+  LanguagesEntry = _reflection.GeneratedProtocolMessageType('LanguagesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _DEVDAY_LANGUAGESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:DevDay.LanguagesEntry)
+    ))
+  ,
+  DESCRIPTOR = _DEVDAY,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DevDay)
+  ))
+_sym_db.RegisterMessage(DevDay)
+_sym_db.RegisterMessage(DevDay.LanguagesEntry)
+
+DayDevs = _reflection.GeneratedProtocolMessageType('DayDevs', (_message.Message,), dict(
+
+  DevsEntry = _reflection.GeneratedProtocolMessageType('DevsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _DAYDEVS_DEVSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:DayDevs.DevsEntry)
+    ))
+  ,
+  DESCRIPTOR = _DAYDEVS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DayDevs)
+  ))
+_sym_db.RegisterMessage(DayDevs)
+_sym_db.RegisterMessage(DayDevs.DevsEntry)
+
+DevsAnalysisResults = _reflection.GeneratedProtocolMessageType('DevsAnalysisResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _DEVSANALYSISRESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:DevsAnalysisResults.DaysEntry)
+    ))
+  ,
+  DESCRIPTOR = _DEVSANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DevsAnalysisResults)
+  ))
+_sym_db.RegisterMessage(DevsAnalysisResults)
+_sym_db.RegisterMessage(DevsAnalysisResults.DaysEntry)
+
+TypoFix = _reflection.GeneratedProtocolMessageType('TypoFix', (_message.Message,), dict(
+  DESCRIPTOR = _TYPOFIX,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TypoFix)
+  ))
+_sym_db.RegisterMessage(TypoFix)
+
+TypoFixesResults = _reflection.GeneratedProtocolMessageType('TypoFixesResults', (_message.Message,), dict(
+  DESCRIPTOR = _TYPOFIXESRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TypoFixesResults)
+  ))
+_sym_db.RegisterMessage(TypoFixesResults)
+
+CommentRatioStats = _reflection.GeneratedProtocolMessageType('CommentRatioStats', (_message.Message,), dict(
+  DESCRIPTOR = _COMMENTRATIOSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommentRatioStats)
+  ))
+_sym_db.RegisterMessage(CommentRatioStats)
+
+LanguageCommentRatios = _reflection.GeneratedProtocolMessageType('LanguageCommentRatios', (_message.Message,), dict(
+
+  LanguagesEntry = _reflection.GeneratedProtocolMessageType('LanguagesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _LANGUAGECOMMENTRATIOS_LANGUAGESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:LanguageCommentRatios.LanguagesEntry)
+    ))
+  ,
+  DESCRIPTOR = _LANGUAGECOMMENTRATIOS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:LanguageCommentRatios)
+  ))
+_sym_db.RegisterMessage(LanguageCommentRatios)
+_sym_db.RegisterMessage(LanguageCommentRatios.LanguagesEntry)
+
+CommentRatioResults = _reflection.GeneratedProtocolMessageType('CommentRatioResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _COMMENTRATIORESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:CommentRatioResults.DaysEntry)
+    ))
+  ,
+  DESCRIPTOR = _COMMENTRATIORESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommentRatioResults)
+  ))
+_sym_db.RegisterMessage(CommentRatioResults)
+_sym_db.RegisterMessage(CommentRatioResults.DaysEntry)
+
+CommitMessageStats = _reflection.GeneratedProtocolMessageType('CommitMessageStats', (_message.Message,), dict(
+  DESCRIPTOR = _COMMITMESSAGESTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommitMessageStats)
+  ))
+_sym_db.RegisterMessage(CommitMessageStats)
+
+CommitMessagesResults = _reflection.GeneratedProtocolMessageType('CommitMessagesResults', (_message.Message,), dict(
+
+  QuartersEntry = _reflection.GeneratedProtocolMessageType('QuartersEntry', (_message.Message,), dict(
+    DESCRIPTOR = _COMMITMESSAGESRESULTS_QUARTERSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:CommitMessagesResults.QuartersEntry)
+    ))
+  ,
+  DESCRIPTOR = _COMMITMESSAGESRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommitMessagesResults)
+  ))
+_sym_db.RegisterMessage(CommitMessagesResults)
+_sym_db.RegisterMessage(CommitMessagesResults.QuartersEntry)
+
+ChurnOriginStats = _reflection.GeneratedProtocolMessageType('ChurnOriginStats', (_message.Message,), dict(
+  DESCRIPTOR = _CHURNORIGINSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ChurnOriginStats)
+  ))
+_sym_db.RegisterMessage(ChurnOriginStats)
+
+DeveloperChurnOrigin = _reflection.GeneratedProtocolMessageType('DeveloperChurnOrigin', (_message.Message,), dict(
+
+  MonthsEntry = _reflection.GeneratedProtocolMessageType('MonthsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _DEVELOPERCHURNORIGIN_MONTHSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:DeveloperChurnOrigin.MonthsEntry)
+    ))
+  ,
+  DESCRIPTOR = _DEVELOPERCHURNORIGIN,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DeveloperChurnOrigin)
+  ))
+_sym_db.RegisterMessage(DeveloperChurnOrigin)
+_sym_db.RegisterMessage(DeveloperChurnOrigin.MonthsEntry)
+
+ChurnOriginResults = _reflection.GeneratedProtocolMessageType('ChurnOriginResults', (_message.Message,), dict(
+  DESCRIPTOR = _CHURNORIGINRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ChurnOriginResults)
+  ))
+_sym_db.RegisterMessage(ChurnOriginResults)
+
+FileLifecycleCounts = _reflection.GeneratedProtocolMessageType('FileLifecycleCounts', (_message.Message,), dict(
+  DESCRIPTOR = _FILELIFECYCLECOUNTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:FileLifecycleCounts)
+  ))
+_sym_db.RegisterMessage(FileLifecycleCounts)
+
+DirectoryLifecycles = _reflection.GeneratedProtocolMessageType('DirectoryLifecycles', (_message.Message,), dict(
+
+  DirectoriesEntry = _reflection.GeneratedProtocolMessageType('DirectoriesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _DIRECTORYLIFECYCLES_DIRECTORIESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:DirectoryLifecycles.DirectoriesEntry)
+    ))
+  ,
+  DESCRIPTOR = _DIRECTORYLIFECYCLES,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DirectoryLifecycles)
+  ))
+_sym_db.RegisterMessage(DirectoryLifecycles)
+_sym_db.RegisterMessage(DirectoryLifecycles.DirectoriesEntry)
+
+FileLifecycleResults = _reflection.GeneratedProtocolMessageType('FileLifecycleResults', (_message.Message,), dict(
+
+  FilesEntry = _reflection.GeneratedProtocolMessageType('FilesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _FILELIFECYCLERESULTS_FILESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:FileLifecycleResults.FilesEntry)
+    ))
+  ,
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _FILELIFECYCLERESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:FileLifecycleResults.DaysEntry)
+    ))
+  ,
+  DESCRIPTOR = _FILELIFECYCLERESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:FileLifecycleResults)
+  ))
+_sym_db.RegisterMessage(FileLifecycleResults)
+_sym_db.RegisterMessage(FileLifecycleResults.FilesEntry)
+_sym_db.RegisterMessage(FileLifecycleResults.DaysEntry)
+
+ReleasePressureStats = _reflection.GeneratedProtocolMessageType('ReleasePressureStats', (_message.Message,), dict(
+  DESCRIPTOR = _RELEASEPRESSURESTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ReleasePressureStats)
+  ))
+_sym_db.RegisterMessage(ReleasePressureStats)
+
+ReleasePressureResults = _reflection.GeneratedProtocolMessageType('ReleasePressureResults', (_message.Message,), dict(
+  DESCRIPTOR = _RELEASEPRESSURERESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ReleasePressureResults)
+  ))
+_sym_db.RegisterMessage(ReleasePressureResults)
+
+CompanyAttributionStats = _reflection.GeneratedProtocolMessageType('CompanyAttributionStats', (_message.Message,), dict(
+  DESCRIPTOR = _COMPANYATTRIBUTIONSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CompanyAttributionStats)
+  ))
+_sym_db.RegisterMessage(CompanyAttributionStats)
+
+CompanyQuarter = _reflection.GeneratedProtocolMessageType('CompanyQuarter', (_message.Message,), dict(
+
+  CompaniesEntry = _reflection.GeneratedProtocolMessageType('CompaniesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _COMPANYQUARTER_COMPANIESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:CompanyQuarter.CompaniesEntry)
+    ))
+  ,
+  DESCRIPTOR = _COMPANYQUARTER,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CompanyQuarter)
+  ))
+_sym_db.RegisterMessage(CompanyQuarter)
+_sym_db.RegisterMessage(CompanyQuarter.CompaniesEntry)
+
+CompanyAttributionResults = _reflection.GeneratedProtocolMessageType('CompanyAttributionResults', (_message.Message,), dict(
+
+  QuartersEntry = _reflection.GeneratedProtocolMessageType('QuartersEntry', (_message.Message,), dict(
+    DESCRIPTOR = _COMPANYATTRIBUTIONRESULTS_QUARTERSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:CompanyAttributionResults.QuartersEntry)
+    ))
+  ,
+  DESCRIPTOR = _COMPANYATTRIBUTIONRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CompanyAttributionResults)
+  ))
+_sym_db.RegisterMessage(CompanyAttributionResults)
+_sym_db.RegisterMessage(CompanyAttributionResults.QuartersEntry)
 
-BurndownAnalysisResults = _reflection.GeneratedProtocolMessageType('BurndownAnalysisResults', (_message.Message,), dict(
-  DESCRIPTOR = _BURNDOWNANALYSISRESULTS,
+BinaryChurnStats = _reflection.GeneratedProtocolMessageType('BinaryChurnStats', (_message.Message,), dict(
+  DESCRIPTOR = _BINARYCHURNSTATS,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:BurndownAnalysisResults)
+  # @@protoc_insertion_point(class_scope:BinaryChurnStats)
   ))
-_sym_db.RegisterMessage(BurndownAnalysisResults)
+_sym_db.RegisterMessage(BinaryChurnStats)
 
-CompressedSparseRowMatrix = _reflection.GeneratedProtocolMessageType('CompressedSparseRowMatrix', (_message.Message,), dict(
-  DESCRIPTOR = _COMPRESSEDSPARSEROWMATRIX,
+BinaryChurnDirectories = _reflection.GeneratedProtocolMessageType('BinaryChurnDirectories', (_message.Message,), dict(
+
+  DirectoriesEntry = _reflection.GeneratedProtocolMessageType('DirectoriesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _BINARYCHURNDIRECTORIES_DIRECTORIESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:BinaryChurnDirectories.DirectoriesEntry)
+    ))
+  ,
+  DESCRIPTOR = _BINARYCHURNDIRECTORIES,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:CompressedSparseRowMatrix)
+  # @@protoc_insertion_point(class_scope:BinaryChurnDirectories)
   ))
-_sym_db.RegisterMessage(CompressedSparseRowMatrix)
+_sym_db.RegisterMessage(BinaryChurnDirectories)
+_sym_db.RegisterMessage(BinaryChurnDirectories.DirectoriesEntry)
 
-Couples = _reflection.GeneratedProtocolMessageType('Couples', (_message.Message,), dict(
-  DESCRIPTOR = _COUPLES,
+BinaryAssets = _reflection.GeneratedProtocolMessageType('BinaryAssets', (_message.Message,), dict(
+  DESCRIPTOR = _BINARYASSETS,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:Couples)
+  # @@protoc_insertion_point(class_scope:BinaryAssets)
   ))
-_sym_db.RegisterMessage(Couples)
+_sym_db.RegisterMessage(BinaryAssets)
 
-TouchedFiles = _reflection.GeneratedProtocolMessageType('TouchedFiles', (_message.Message,), dict(
-  DESCRIPTOR = _TOUCHEDFILES,
+BinaryChurnResults = _reflection.GeneratedProtocolMessageType('BinaryChurnResults', (_message.Message,), dict(
+
+  MonthsEntry = _reflection.GeneratedProtocolMessageType('MonthsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _BINARYCHURNRESULTS_MONTHSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:BinaryChurnResults.MonthsEntry)
+    ))
+  ,
+
+  CurrentEntry = _reflection.GeneratedProtocolMessageType('CurrentEntry', (_message.Message,), dict(
+    DESCRIPTOR = _BINARYCHURNRESULTS_CURRENTENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:BinaryChurnResults.CurrentEntry)
+    ))
+  ,
+  DESCRIPTOR = _BINARYCHURNRESULTS,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:TouchedFiles)
+  # @@protoc_insertion_point(class_scope:BinaryChurnResults)
   ))
-_sym_db.RegisterMessage(TouchedFiles)
+_sym_db.RegisterMessage(BinaryChurnResults)
+_sym_db.RegisterMessage(BinaryChurnResults.MonthsEntry)
+_sym_db.RegisterMessage(BinaryChurnResults.CurrentEntry)
 
-CouplesAnalysisResults = _reflection.GeneratedProtocolMessageType('CouplesAnalysisResults', (_message.Message,), dict(
-  DESCRIPTOR = _COUPLESANALYSISRESULTS,
+RepositorySizeStats = _reflection.GeneratedProtocolMessageType('RepositorySizeStats', (_message.Message,), dict(
+  DESCRIPTOR = _REPOSITORYSIZESTATS,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:CouplesAnalysisResults)
+  # @@protoc_insertion_point(class_scope:RepositorySizeStats)
   ))
-_sym_db.RegisterMessage(CouplesAnalysisResults)
+_sym_db.RegisterMessage(RepositorySizeStats)
 
-UASTChange = _reflection.GeneratedProtocolMessageType('UASTChange', (_message.Message,), dict(
-  DESCRIPTOR = _UASTCHANGE,
+RepositorySizeCommit = _reflection.GeneratedProtocolMessageType('RepositorySizeCommit', (_message.Message,), dict(
+  DESCRIPTOR = _REPOSITORYSIZECOMMIT,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:UASTChange)
+  # @@protoc_insertion_point(class_scope:RepositorySizeCommit)
   ))
-_sym_db.RegisterMessage(UASTChange)
+_sym_db.RegisterMessage(RepositorySizeCommit)
 
-UASTChangesSaverResults = _reflection.GeneratedProtocolMessageType('UASTChangesSaverResults', (_message.Message,), dict(
-  DESCRIPTOR = _UASTCHANGESSAVERRESULTS,
+RepositorySizeResults = _reflection.GeneratedProtocolMessageType('RepositorySizeResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _REPOSITORYSIZERESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:RepositorySizeResults.DaysEntry)
+    ))
+  ,
+
+  TagsEntry = _reflection.GeneratedProtocolMessageType('TagsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _REPOSITORYSIZERESULTS_TAGSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:RepositorySizeResults.TagsEntry)
+    ))
+  ,
+  DESCRIPTOR = _REPOSITORYSIZERESULTS,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:UASTChangesSaverResults)
+  # @@protoc_insertion_point(class_scope:RepositorySizeResults)
   ))
-_sym_db.RegisterMessage(UASTChangesSaverResults)
+_sym_db.RegisterMessage(RepositorySizeResults)
+_sym_db.RegisterMessage(RepositorySizeResults.DaysEntry)
+_sym_db.RegisterMessage(RepositorySizeResults.TagsEntry)
 
-ShotnessRecord = _reflection.GeneratedProtocolMessageType('ShotnessRecord', (_message.Message,), dict(
+RefactoringStats = _reflection.GeneratedProtocolMessageType('RefactoringStats', (_message.Message,), dict(
+  DESCRIPTOR = _REFACTORINGSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RefactoringStats)
+  ))
+_sym_db.RegisterMessage(RefactoringStats)
 
-  CountersEntry = _reflection.GeneratedProtocolMessageType('CountersEntry', (_message.Message,), dict(
-    DESCRIPTOR = _SHOTNESSRECORD_COUNTERSENTRY,
+RefactoringResults = _reflection.GeneratedProtocolMessageType('RefactoringResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _REFACTORINGRESULTS_DAYSENTRY,
     __module__ = 'pb_pb2'
-    # @@protoc_insertion_point(class_scope:ShotnessRecord.CountersEntry)
+    # @@protoc_insertion_point(class_scope:RefactoringResults.DaysEntry)
     ))
   ,
-  DESCRIPTOR = _SHOTNESSRECORD,
+  DESCRIPTOR = _REFACTORINGRESULTS,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:ShotnessRecord)
+  # @@protoc_insertion_point(class_scope:RefactoringResults)
   ))
-_sym_db.RegisterMessage(ShotnessRecord)
-_sym_db.RegisterMessage(ShotnessRecord.CountersEntry)
+_sym_db.RegisterMessage(RefactoringResults)
+_sym_db.RegisterMessage(RefactoringResults.DaysEntry)
 
-ShotnessAnalysisResults = _reflection.GeneratedProtocolMessageType('ShotnessAnalysisResults', (_message.Message,), dict(
-  DESCRIPTOR = _SHOTNESSANALYSISRESULTS,
+CoverageChurnStats = _reflection.GeneratedProtocolMessageType('CoverageChurnStats', (_message.Message,), dict(
+  DESCRIPTOR = _COVERAGECHURNSTATS,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:ShotnessAnalysisResults)
+  # @@protoc_insertion_point(class_scope:CoverageChurnStats)
   ))
-_sym_db.RegisterMessage(ShotnessAnalysisResults)
+_sym_db.RegisterMessage(CoverageChurnStats)
 
-FileHistory = _reflection.GeneratedProtocolMessageType('FileHistory', (_message.Message,), dict(
-  DESCRIPTOR = _FILEHISTORY,
+CoverageChurnResults = _reflection.GeneratedProtocolMessageType('CoverageChurnResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _COVERAGECHURNRESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:CoverageChurnResults.DaysEntry)
+    ))
+  ,
+  DESCRIPTOR = _COVERAGECHURNRESULTS,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:FileHistory)
+  # @@protoc_insertion_point(class_scope:CoverageChurnResults)
   ))
-_sym_db.RegisterMessage(FileHistory)
+_sym_db.RegisterMessage(CoverageChurnResults)
+_sym_db.RegisterMessage(CoverageChurnResults.DaysEntry)
 
-FileHistoryResultMessage = _reflection.GeneratedProtocolMessageType('FileHistoryResultMessage', (_message.Message,), dict(
+DefectsStats = _reflection.GeneratedProtocolMessageType('DefectsStats', (_message.Message,), dict(
+
+  SeveritiesEntry = _reflection.GeneratedProtocolMessageType('SeveritiesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _DEFECTSSTATS_SEVERITIESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:DefectsStats.SeveritiesEntry)
+    ))
+  ,
+  DESCRIPTOR = _DEFECTSSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DefectsStats)
+  ))
+_sym_db.RegisterMessage(DefectsStats)
+_sym_db.RegisterMessage(DefectsStats.SeveritiesEntry)
+
+DefectsResults = _reflection.GeneratedProtocolMessageType('DefectsResults', (_message.Message,), dict(
+
+  ComponentsEntry = _reflection.GeneratedProtocolMessageType('ComponentsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _DEFECTSRESULTS_COMPONENTSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:DefectsResults.ComponentsEntry)
+    ))
+  ,
+  DESCRIPTOR = _DEFECTSRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DefectsResults)
+  ))
+_sym_db.RegisterMessage(DefectsResults)
+_sym_db.RegisterMessage(DefectsResults.ComponentsEntry)
+
+OwnershipConcentrationStats = _reflection.GeneratedProtocolMessageType('OwnershipConcentrationStats', (_message.Message,), dict(
+  DESCRIPTOR = _OWNERSHIPCONCENTRATIONSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:OwnershipConcentrationStats)
+  ))
+_sym_db.RegisterMessage(OwnershipConcentrationStats)
+
+DirectoryOwnershipConcentration = _reflection.GeneratedProtocolMessageType('DirectoryOwnershipConcentration', (_message.Message,), dict(
+
+  DirectoriesEntry = _reflection.GeneratedProtocolMessageType('DirectoriesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _DIRECTORYOWNERSHIPCONCENTRATION_DIRECTORIESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:DirectoryOwnershipConcentration.DirectoriesEntry)
+    ))
+  ,
+  DESCRIPTOR = _DIRECTORYOWNERSHIPCONCENTRATION,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DirectoryOwnershipConcentration)
+  ))
+_sym_db.RegisterMessage(DirectoryOwnershipConcentration)
+_sym_db.RegisterMessage(DirectoryOwnershipConcentration.DirectoriesEntry)
+
+OwnershipConcentrationResults = _reflection.GeneratedProtocolMessageType('OwnershipConcentrationResults', (_message.Message,), dict(
+
+  QuartersEntry = _reflection.GeneratedProtocolMessageType('QuartersEntry', (_message.Message,), dict(
+    DESCRIPTOR = _OWNERSHIPCONCENTRATIONRESULTS_QUARTERSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:OwnershipConcentrationResults.QuartersEntry)
+    ))
+  ,
+  DESCRIPTOR = _OWNERSHIPCONCENTRATIONRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:OwnershipConcentrationResults)
+  ))
+_sym_db.RegisterMessage(OwnershipConcentrationResults)
+_sym_db.RegisterMessage(OwnershipConcentrationResults.QuartersEntry)
+
+EffortStats = _reflection.GeneratedProtocolMessageType('EffortStats', (_message.Message,), dict(
+  DESCRIPTOR = _EFFORTSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:EffortStats)
+  ))
+_sym_db.RegisterMessage(EffortStats)
+
+ComponentEfforts = _reflection.GeneratedProtocolMessageType('ComponentEfforts', (_message.Message,), dict(
+
+  ComponentsEntry = _reflection.GeneratedProtocolMessageType('ComponentsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _COMPONENTEFFORTS_COMPONENTSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ComponentEfforts.ComponentsEntry)
+    ))
+  ,
+  DESCRIPTOR = _COMPONENTEFFORTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ComponentEfforts)
+  ))
+_sym_db.RegisterMessage(ComponentEfforts)
+_sym_db.RegisterMessage(ComponentEfforts.ComponentsEntry)
+
+EffortEstimationResults = _reflection.GeneratedProtocolMessageType('EffortEstimationResults', (_message.Message,), dict(
+
+  PeriodsEntry = _reflection.GeneratedProtocolMessageType('PeriodsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _EFFORTESTIMATIONRESULTS_PERIODSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:EffortEstimationResults.PeriodsEntry)
+    ))
+  ,
+
+  ComponentsEntry = _reflection.GeneratedProtocolMessageType('ComponentsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _EFFORTESTIMATIONRESULTS_COMPONENTSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:EffortEstimationResults.ComponentsEntry)
+    ))
+  ,
+  DESCRIPTOR = _EFFORTESTIMATIONRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:EffortEstimationResults)
+  ))
+_sym_db.RegisterMessage(EffortEstimationResults)
+_sym_db.RegisterMessage(EffortEstimationResults.PeriodsEntry)
+_sym_db.RegisterMessage(EffortEstimationResults.ComponentsEntry)
+
+CommentReadabilityStats = _reflection.GeneratedProtocolMessageType('CommentReadabilityStats', (_message.Message,), dict(
+  DESCRIPTOR = _COMMENTREADABILITYSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommentReadabilityStats)
+  ))
+_sym_db.RegisterMessage(CommentReadabilityStats)
+
+DirectoryCommentReadability = _reflection.GeneratedProtocolMessageType('DirectoryCommentReadability', (_message.Message,), dict(
+
+  DirectoriesEntry = _reflection.GeneratedProtocolMessageType('DirectoriesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _DIRECTORYCOMMENTREADABILITY_DIRECTORIESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:DirectoryCommentReadability.DirectoriesEntry)
+    ))
+  ,
+  DESCRIPTOR = _DIRECTORYCOMMENTREADABILITY,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DirectoryCommentReadability)
+  ))
+_sym_db.RegisterMessage(DirectoryCommentReadability)
+_sym_db.RegisterMessage(DirectoryCommentReadability.DirectoriesEntry)
+
+CommentReadabilityResults = _reflection.GeneratedProtocolMessageType('CommentReadabilityResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _COMMENTREADABILITYRESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:CommentReadabilityResults.DaysEntry)
+    ))
+  ,
+  DESCRIPTOR = _COMMENTREADABILITYRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommentReadabilityResults)
+  ))
+_sym_db.RegisterMessage(CommentReadabilityResults)
+_sym_db.RegisterMessage(CommentReadabilityResults.DaysEntry)
+
+StaleComment = _reflection.GeneratedProtocolMessageType('StaleComment', (_message.Message,), dict(
+  DESCRIPTOR = _STALECOMMENT,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:StaleComment)
+  ))
+_sym_db.RegisterMessage(StaleComment)
+
+StaleCommentsResults = _reflection.GeneratedProtocolMessageType('StaleCommentsResults', (_message.Message,), dict(
+  DESCRIPTOR = _STALECOMMENTSRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:StaleCommentsResults)
+  ))
+_sym_db.RegisterMessage(StaleCommentsResults)
+
+ToxicityStats = _reflection.GeneratedProtocolMessageType('ToxicityStats', (_message.Message,), dict(
+  DESCRIPTOR = _TOXICITYSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ToxicityStats)
+  ))
+_sym_db.RegisterMessage(ToxicityStats)
+
+ToxicityResults = _reflection.GeneratedProtocolMessageType('ToxicityResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _TOXICITYRESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ToxicityResults.DaysEntry)
+    ))
+  ,
+
+  DirectoriesEntry = _reflection.GeneratedProtocolMessageType('DirectoriesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _TOXICITYRESULTS_DIRECTORIESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ToxicityResults.DirectoriesEntry)
+    ))
+  ,
+
+  PhrasesEntry = _reflection.GeneratedProtocolMessageType('PhrasesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _TOXICITYRESULTS_PHRASESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ToxicityResults.PhrasesEntry)
+    ))
+  ,
+  DESCRIPTOR = _TOXICITYRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ToxicityResults)
+  ))
+_sym_db.RegisterMessage(ToxicityResults)
+_sym_db.RegisterMessage(ToxicityResults.DaysEntry)
+_sym_db.RegisterMessage(ToxicityResults.DirectoriesEntry)
+_sym_db.RegisterMessage(ToxicityResults.PhrasesEntry)
+
+FunctionSizeStats = _reflection.GeneratedProtocolMessageType('FunctionSizeStats', (_message.Message,), dict(
+  DESCRIPTOR = _FUNCTIONSIZESTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:FunctionSizeStats)
+  ))
+_sym_db.RegisterMessage(FunctionSizeStats)
+
+LanguageFunctionSizes = _reflection.GeneratedProtocolMessageType('LanguageFunctionSizes', (_message.Message,), dict(
+
+  LanguagesEntry = _reflection.GeneratedProtocolMessageType('LanguagesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _LANGUAGEFUNCTIONSIZES_LANGUAGESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:LanguageFunctionSizes.LanguagesEntry)
+    ))
+  ,
+  DESCRIPTOR = _LANGUAGEFUNCTIONSIZES,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:LanguageFunctionSizes)
+  ))
+_sym_db.RegisterMessage(LanguageFunctionSizes)
+_sym_db.RegisterMessage(LanguageFunctionSizes.LanguagesEntry)
+
+LongestFunction = _reflection.GeneratedProtocolMessageType('LongestFunction', (_message.Message,), dict(
+  DESCRIPTOR = _LONGESTFUNCTION,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:LongestFunction)
+  ))
+_sym_db.RegisterMessage(LongestFunction)
+
+FunctionSizeResults = _reflection.GeneratedProtocolMessageType('FunctionSizeResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _FUNCTIONSIZERESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:FunctionSizeResults.DaysEntry)
+    ))
+  ,
+  DESCRIPTOR = _FUNCTIONSIZERESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:FunctionSizeResults)
+  ))
+_sym_db.RegisterMessage(FunctionSizeResults)
+_sym_db.RegisterMessage(FunctionSizeResults.DaysEntry)
+
+NestingDepthStats = _reflection.GeneratedProtocolMessageType('NestingDepthStats', (_message.Message,), dict(
+  DESCRIPTOR = _NESTINGDEPTHSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:NestingDepthStats)
+  ))
+_sym_db.RegisterMessage(NestingDepthStats)
+
+NestingDepthHistory = _reflection.GeneratedProtocolMessageType('NestingDepthHistory', (_message.Message,), dict(
+  DESCRIPTOR = _NESTINGDEPTHHISTORY,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:NestingDepthHistory)
+  ))
+_sym_db.RegisterMessage(NestingDepthHistory)
+
+NestingDepthResults = _reflection.GeneratedProtocolMessageType('NestingDepthResults', (_message.Message,), dict(
 
   FilesEntry = _reflection.GeneratedProtocolMessageType('FilesEntry', (_message.Message,), dict(
-    DESCRIPTOR = _FILEHISTORYRESULTMESSAGE_FILESENTRY,
+    DESCRIPTOR = _NESTINGDEPTHRESULTS_FILESENTRY,
     __module__ = 'pb_pb2'
-    # @@protoc_insertion_point(class_scope:FileHistoryResultMessage.FilesEntry)
+    # @@protoc_insertion_point(class_scope:NestingDepthResults.FilesEntry)
     ))
   ,
-  DESCRIPTOR = _FILEHISTORYRESULTMESSAGE,
+
+  IncreasingEntry = _reflection.GeneratedProtocolMessageType('IncreasingEntry', (_message.Message,), dict(
+    DESCRIPTOR = _NESTINGDEPTHRESULTS_INCREASINGENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:NestingDepthResults.IncreasingEntry)
+    ))
+  ,
+  DESCRIPTOR = _NESTINGDEPTHRESULTS,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:FileHistoryResultMessage)
+  # @@protoc_insertion_point(class_scope:NestingDepthResults)
   ))
-_sym_db.RegisterMessage(FileHistoryResultMessage)
-_sym_db.RegisterMessage(FileHistoryResultMessage.FilesEntry)
+_sym_db.RegisterMessage(NestingDepthResults)
+_sym_db.RegisterMessage(NestingDepthResults.FilesEntry)
+_sym_db.RegisterMessage(NestingDepthResults.IncreasingEntry)
 
-Sentiment = _reflection.GeneratedProtocolMessageType('Sentiment', (_message.Message,), dict(
-  DESCRIPTOR = _SENTIMENT,
+CommitEntropy = _reflection.GeneratedProtocolMessageType('CommitEntropy', (_message.Message,), dict(
+  DESCRIPTOR = _COMMITENTROPY,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:Sentiment)
+  # @@protoc_insertion_point(class_scope:CommitEntropy)
   ))
-_sym_db.RegisterMessage(Sentiment)
+_sym_db.RegisterMessage(CommitEntropy)
 
-CommentSentimentResults = _reflection.GeneratedProtocolMessageType('CommentSentimentResults', (_message.Message,), dict(
+CommitEntropyStats = _reflection.GeneratedProtocolMessageType('CommitEntropyStats', (_message.Message,), dict(
+  DESCRIPTOR = _COMMITENTROPYSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommitEntropyStats)
+  ))
+_sym_db.RegisterMessage(CommitEntropyStats)
 
-  SentimentByDayEntry = _reflection.GeneratedProtocolMessageType('SentimentByDayEntry', (_message.Message,), dict(
-    DESCRIPTOR = _COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY,
+CommitEntropyResults = _reflection.GeneratedProtocolMessageType('CommitEntropyResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _COMMITENTROPYRESULTS_DAYSENTRY,
     __module__ = 'pb_pb2'
-    # @@protoc_insertion_point(class_scope:CommentSentimentResults.SentimentByDayEntry)
+    # @@protoc_insertion_point(class_scope:CommitEntropyResults.DaysEntry)
     ))
   ,
-  DESCRIPTOR = _COMMENTSENTIMENTRESULTS,
+  DESCRIPTOR = _COMMITENTROPYRESULTS,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:CommentSentimentResults)
+  # @@protoc_insertion_point(class_scope:CommitEntropyResults)
   ))
-_sym_db.RegisterMessage(CommentSentimentResults)
-_sym_db.RegisterMessage(CommentSentimentResults.SentimentByDayEntry)
+_sym_db.RegisterMessage(CommitEntropyResults)
+_sym_db.RegisterMessage(CommitEntropyResults.DaysEntry)
+
+TicketlessStats = _reflection.GeneratedProtocolMessageType('TicketlessStats', (_message.Message,), dict(
+  DESCRIPTOR = _TICKETLESSSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TicketlessStats)
+  ))
+_sym_db.RegisterMessage(TicketlessStats)
+
+TicketlessCommitsResults = _reflection.GeneratedProtocolMessageType('TicketlessCommitsResults', (_message.Message,), dict(
+
+  MonthsEntry = _reflection.GeneratedProtocolMessageType('MonthsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _TICKETLESSCOMMITSRESULTS_MONTHSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:TicketlessCommitsResults.MonthsEntry)
+    ))
+  ,
+  DESCRIPTOR = _TICKETLESSCOMMITSRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TicketlessCommitsResults)
+  ))
+_sym_db.RegisterMessage(TicketlessCommitsResults)
+_sym_db.RegisterMessage(TicketlessCommitsResults.MonthsEntry)
+
+ChangeSet = _reflection.GeneratedProtocolMessageType('ChangeSet', (_message.Message,), dict(
+  DESCRIPTOR = _CHANGESET,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ChangeSet)
+  ))
+_sym_db.RegisterMessage(ChangeSet)
+
+ChangeSetStats = _reflection.GeneratedProtocolMessageType('ChangeSetStats', (_message.Message,), dict(
+  DESCRIPTOR = _CHANGESETSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ChangeSetStats)
+  ))
+_sym_db.RegisterMessage(ChangeSetStats)
+
+ChangeSetsResults = _reflection.GeneratedProtocolMessageType('ChangeSetsResults', (_message.Message,), dict(
+
+  MonthsEntry = _reflection.GeneratedProtocolMessageType('MonthsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _CHANGESETSRESULTS_MONTHSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ChangeSetsResults.MonthsEntry)
+    ))
+  ,
+  DESCRIPTOR = _CHANGESETSRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ChangeSetsResults)
+  ))
+_sym_db.RegisterMessage(ChangeSetsResults)
+_sym_db.RegisterMessage(ChangeSetsResults.MonthsEntry)
+
+CaseCollision = _reflection.GeneratedProtocolMessageType('CaseCollision', (_message.Message,), dict(
+  DESCRIPTOR = _CASECOLLISION,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CaseCollision)
+  ))
+_sym_db.RegisterMessage(CaseCollision)
+
+CaseCollisionsResults = _reflection.GeneratedProtocolMessageType('CaseCollisionsResults', (_message.Message,), dict(
+  DESCRIPTOR = _CASECOLLISIONSRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CaseCollisionsResults)
+  ))
+_sym_db.RegisterMessage(CaseCollisionsResults)
+
+Extension = _reflection.GeneratedProtocolMessageType('Extension', (_message.Message,), dict(
+  DESCRIPTOR = _EXTENSION,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:Extension)
+  ))
+_sym_db.RegisterMessage(Extension)
 
 AnalysisResults = _reflection.GeneratedProtocolMessageType('AnalysisResults', (_message.Message,), dict(
 
@@ -1108,20 +9212,142 @@ AnalysisResults = _reflection.GeneratedProtocolMessageType('AnalysisResults', (_
     # @@protoc_insertion_point(class_scope:AnalysisResults.ContentsEntry)
     ))
   ,
+
+  ExtensionsEntry = _reflection.GeneratedProtocolMessageType('ExtensionsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _ANALYSISRESULTS_EXTENSIONSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:AnalysisResults.ExtensionsEntry)
+    ))
+  ,
   DESCRIPTOR = _ANALYSISRESULTS,
   __module__ = 'pb_pb2'
   # @@protoc_insertion_point(class_scope:AnalysisResults)
   ))
 _sym_db.RegisterMessage(AnalysisResults)
 _sym_db.RegisterMessage(AnalysisResults.ContentsEntry)
+_sym_db.RegisterMessage(AnalysisResults.ExtensionsEntry)
 
 
 _SHOTNESSRECORD_COUNTERSENTRY.has_options = True
 _SHOTNESSRECORD_COUNTERSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
 _FILEHISTORYRESULTMESSAGE_FILESENTRY.has_options = True
 _FILEHISTORYRESULTMESSAGE_FILESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_SENTIMENT_EMOTIONSENTRY.has_options = True
+_SENTIMENT_EMOTIONSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
 _COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY.has_options = True
 _COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_ROLESHISTOGRAM_ROLESENTRY.has_options = True
+_ROLESHISTOGRAM_ROLESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_LANGUAGEROLESHISTOGRAMS_LANGUAGESENTRY.has_options = True
+_LANGUAGEROLESHISTOGRAMS_LANGUAGESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_ROLESHISTOGRAMRESULTS_DAYSENTRY.has_options = True
+_ROLESHISTOGRAMRESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_DIRECTORYHALSTEAD_DIRECTORIESENTRY.has_options = True
+_DIRECTORYHALSTEAD_DIRECTORIESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_HALSTEADRESULTS_DAYSENTRY.has_options = True
+_HALSTEADRESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_INDENTATIONCOMPLEXITYRESULTS_FILESENTRY.has_options = True
+_INDENTATIONCOMPLEXITYRESULTS_FILESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_LANGUAGESTYLESTATS_LANGUAGESENTRY.has_options = True
+_LANGUAGESTYLESTATS_LANGUAGESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_STYLEDRIFTRESULTS_DAYSENTRY.has_options = True
+_STYLEDRIFTRESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_GOFMTCOMPLIANCERESULTS_DAYSENTRY.has_options = True
+_GOFMTCOMPLIANCERESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_SQLSTATS_TABLESENTRY.has_options = True
+_SQLSTATS_TABLESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_SQLRESULTS_DAYSENTRY.has_options = True
+_SQLRESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_SQLRESULTS_ORIGINSENTRY.has_options = True
+_SQLRESULTS_ORIGINSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_ERRORHANDLINGRESULTS_DAYSENTRY.has_options = True
+_ERRORHANDLINGRESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_DIRECTORYTESTCOCHANGES_DIRECTORIESENTRY.has_options = True
+_DIRECTORYTESTCOCHANGES_DIRECTORIESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_TESTCOUPLINGRESULTS_DAYSENTRY.has_options = True
+_TESTCOUPLINGRESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_TIMESKEWRESULTS_DAYSENTRY.has_options = True
+_TIMESKEWRESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_CHERRYPICKSRESULTS_BACKPORTSENTRY.has_options = True
+_CHERRYPICKSRESULTS_BACKPORTSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_DEVDAY_LANGUAGESENTRY.has_options = True
+_DEVDAY_LANGUAGESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_DAYDEVS_DEVSENTRY.has_options = True
+_DAYDEVS_DEVSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_DEVSANALYSISRESULTS_DAYSENTRY.has_options = True
+_DEVSANALYSISRESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_LANGUAGECOMMENTRATIOS_LANGUAGESENTRY.has_options = True
+_LANGUAGECOMMENTRATIOS_LANGUAGESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_COMMENTRATIORESULTS_DAYSENTRY.has_options = True
+_COMMENTRATIORESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_COMMITMESSAGESRESULTS_QUARTERSENTRY.has_options = True
+_COMMITMESSAGESRESULTS_QUARTERSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_DEVELOPERCHURNORIGIN_MONTHSENTRY.has_options = True
+_DEVELOPERCHURNORIGIN_MONTHSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_DIRECTORYLIFECYCLES_DIRECTORIESENTRY.has_options = True
+_DIRECTORYLIFECYCLES_DIRECTORIESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_FILELIFECYCLERESULTS_FILESENTRY.has_options = True
+_FILELIFECYCLERESULTS_FILESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_FILELIFECYCLERESULTS_DAYSENTRY.has_options = True
+_FILELIFECYCLERESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_COMPANYQUARTER_COMPANIESENTRY.has_options = True
+_COMPANYQUARTER_COMPANIESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_COMPANYATTRIBUTIONRESULTS_QUARTERSENTRY.has_options = True
+_COMPANYATTRIBUTIONRESULTS_QUARTERSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_BINARYCHURNDIRECTORIES_DIRECTORIESENTRY.has_options = True
+_BINARYCHURNDIRECTORIES_DIRECTORIESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_BINARYCHURNRESULTS_MONTHSENTRY.has_options = True
+_BINARYCHURNRESULTS_MONTHSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_BINARYCHURNRESULTS_CURRENTENTRY.has_options = True
+_BINARYCHURNRESULTS_CURRENTENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_REPOSITORYSIZERESULTS_DAYSENTRY.has_options = True
+_REPOSITORYSIZERESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_REPOSITORYSIZERESULTS_TAGSENTRY.has_options = True
+_REPOSITORYSIZERESULTS_TAGSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_REFACTORINGRESULTS_DAYSENTRY.has_options = True
+_REFACTORINGRESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_COVERAGECHURNRESULTS_DAYSENTRY.has_options = True
+_COVERAGECHURNRESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_DEFECTSSTATS_SEVERITIESENTRY.has_options = True
+_DEFECTSSTATS_SEVERITIESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_DEFECTSRESULTS_COMPONENTSENTRY.has_options = True
+_DEFECTSRESULTS_COMPONENTSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_DIRECTORYOWNERSHIPCONCENTRATION_DIRECTORIESENTRY.has_options = True
+_DIRECTORYOWNERSHIPCONCENTRATION_DIRECTORIESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_OWNERSHIPCONCENTRATIONRESULTS_QUARTERSENTRY.has_options = True
+_OWNERSHIPCONCENTRATIONRESULTS_QUARTERSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_COMPONENTEFFORTS_COMPONENTSENTRY.has_options = True
+_COMPONENTEFFORTS_COMPONENTSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_EFFORTESTIMATIONRESULTS_PERIODSENTRY.has_options = True
+_EFFORTESTIMATIONRESULTS_PERIODSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_EFFORTESTIMATIONRESULTS_COMPONENTSENTRY.has_options = True
+_EFFORTESTIMATIONRESULTS_COMPONENTSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_DIRECTORYCOMMENTREADABILITY_DIRECTORIESENTRY.has_options = True
+_DIRECTORYCOMMENTREADABILITY_DIRECTORIESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_COMMENTREADABILITYRESULTS_DAYSENTRY.has_options = True
+_COMMENTREADABILITYRESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_TOXICITYRESULTS_DAYSENTRY.has_options = True
+_TOXICITYRESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_TOXICITYRESULTS_DIRECTORIESENTRY.has_options = True
+_TOXICITYRESULTS_DIRECTORIESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_TOXICITYRESULTS_PHRASESENTRY.has_options = True
+_TOXICITYRESULTS_PHRASESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_LANGUAGEFUNCTIONSIZES_LANGUAGESENTRY.has_options = True
+_LANGUAGEFUNCTIONSIZES_LANGUAGESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_FUNCTIONSIZERESULTS_DAYSENTRY.has_options = True
+_FUNCTIONSIZERESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_NESTINGDEPTHRESULTS_FILESENTRY.has_options = True
+_NESTINGDEPTHRESULTS_FILESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_NESTINGDEPTHRESULTS_INCREASINGENTRY.has_options = True
+_NESTINGDEPTHRESULTS_INCREASINGENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_COMMITENTROPYRESULTS_DAYSENTRY.has_options = True
+_COMMITENTROPYRESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_TICKETLESSCOMMITSRESULTS_MONTHSENTRY.has_options = True
+_TICKETLESSCOMMITSRESULTS_MONTHSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_CHANGESETSRESULTS_MONTHSENTRY.has_options = True
+_CHANGESETSRESULTS_MONTHSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
 _ANALYSISRESULTS_CONTENTSENTRY.has_options = True
 _ANALYSISRESULTS_CONTENTSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_ANALYSISRESULTS_EXTENSIONSENTRY.has_options = True
+_ANALYSISRESULTS_EXTENSIONSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
 # @@protoc_insertion_point(module_scope)
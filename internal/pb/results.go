@@ -0,0 +1,65 @@
+package pb
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// resultMessages maps the names of the analyses to the constructors of their result messages.
+var resultMessages = map[string]func() proto.Message{
+	"BinaryChurn":           func() proto.Message { return &BinaryChurnResults{} },
+	"Burndown":              func() proto.Message { return &BurndownAnalysisResults{} },
+	"CherryPicks":           func() proto.Message { return &CherryPicksResults{} },
+	"ChurnOrigin":           func() proto.Message { return &ChurnOriginResults{} },
+	"CommentRatio":          func() proto.Message { return &CommentRatioResults{} },
+	"CommitFeatures":        func() proto.Message { return &CommitFeaturesResults{} },
+	"CommitMessages":        func() proto.Message { return &CommitMessagesResults{} },
+	"CompanyAttribution":    func() proto.Message { return &CompanyAttributionResults{} },
+	"Couples":               func() proto.Message { return &CouplesAnalysisResults{} },
+	"CoverageChurn":         func() proto.Message { return &CoverageChurnResults{} },
+	"Defects":               func() proto.Message { return &DefectsResults{} },
+	"Devs":                  func() proto.Message { return &DevsAnalysisResults{} },
+	"ErrorHandling":         func() proto.Message { return &ErrorHandlingResults{} },
+	"FileHistory":           func() proto.Message { return &FileHistoryResultMessage{} },
+	"FileLifecycle":         func() proto.Message { return &FileLifecycleResults{} },
+	"GofmtCompliance":       func() proto.Message { return &GofmtComplianceResults{} },
+	"Halstead":              func() proto.Message { return &HalsteadResults{} },
+	"IndentationComplexity": func() proto.Message { return &IndentationComplexityResults{} },
+	"ReleasePressure":       func() proto.Message { return &ReleasePressureResults{} },
+	"Refactoring":           func() proto.Message { return &RefactoringResults{} },
+	"RepositorySize":        func() proto.Message { return &RepositorySizeResults{} },
+	"RolesHistogram":        func() proto.Message { return &RolesHistogramResults{} },
+	"SQL":                   func() proto.Message { return &SQLResults{} },
+	"Sentiment":             func() proto.Message { return &CommentSentimentResults{} },
+	"Shotness":              func() proto.Message { return &ShotnessAnalysisResults{} },
+	"StringLiterals":        func() proto.Message { return &StringLiteralsResults{} },
+	"StyleDrift":            func() proto.Message { return &StyleDriftResults{} },
+	"TestCoupling":          func() proto.Message { return &TestCouplingResults{} },
+	"TimeSkew":              func() proto.Message { return &TimeSkewResults{} },
+	"TypoFixes":             func() proto.Message { return &TypoFixesResults{} },
+	"UASTChangesSaver":      func() proto.Message { return &UASTChangesSaverResults{} },
+}
+
+// NewResultMessage creates the empty result message of the analysis with the specified name.
+// It returns nil if the analysis is unknown.
+func NewResultMessage(name string) proto.Message {
+	factory, exists := resultMessages[name]
+	if !exists {
+		return nil
+	}
+	return factory()
+}
+
+// DecodeResult parses the serialized result of the analysis with the specified name, e.g.
+// AnalysisResults.Contents["Burndown"], to the typed message.
+func DecodeResult(name string, data []byte) (proto.Message, error) {
+	message := NewResultMessage(name)
+	if message == nil {
+		return nil, fmt.Errorf("unknown analysis: %s", name)
+	}
+	if err := proto.Unmarshal(data, message); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return message, nil
+}
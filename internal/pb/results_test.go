@@ -0,0 +1,27 @@
+package pb
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewResultMessage(t *testing.T) {
+	assert.IsType(t, &BurndownAnalysisResults{}, NewResultMessage("Burndown"))
+	assert.IsType(t, &CommentSentimentResults{}, NewResultMessage("Sentiment"))
+	assert.Nil(t, NewResultMessage("Unknown"))
+}
+
+func TestDecodeResult(t *testing.T) {
+	data, err := proto.Marshal(&RepositorySizeResults{
+		TopCommits: []*RepositorySizeCommit{{Hash: "abc", Growth: 10}}})
+	assert.Nil(t, err)
+	message, err := DecodeResult("RepositorySize", data)
+	assert.Nil(t, err)
+	assert.Equal(t, message.(*RepositorySizeResults).TopCommits[0].Growth, int64(10))
+	_, err = DecodeResult("Unknown", data)
+	assert.NotNil(t, err)
+	_, err = DecodeResult("Burndown", []byte{0xff, 0xff})
+	assert.NotNil(t, err)
+}
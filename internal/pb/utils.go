@@ -93,3 +93,16 @@ func MapToCompressedSparseRowMatrix(matrix []map[int]int64) *CompressedSparseRow
 	}
 	return &r
 }
+
+// CompressedSparseRowMatrixToMap converts a Protobuf CSR matrix to the DOK format.
+// It is the inverse of MapToCompressedSparseRowMatrix.
+func CompressedSparseRowMatrixToMap(matrix *CompressedSparseRowMatrix) []map[int]int64 {
+	r := make([]map[int]int64, matrix.NumberOfRows)
+	for i := range r {
+		r[i] = map[int]int64{}
+		for j := matrix.Indptr[i]; j < matrix.Indptr[i+1]; j++ {
+			r[i][int(matrix.Indices[j])] = matrix.Data[j]
+		}
+	}
+	return r
+}
@@ -1,6 +1,7 @@
 package plumbing
 
 import (
+	"io"
 	"log"
 
 	"gopkg.in/src-d/go-git.v4"
@@ -21,15 +22,24 @@ type BlobCache struct {
 	// the blob. If false, we look inside .gitmodules and if don't find, raise an error.
 	// If true, we do not look inside .gitmodules and always succeed.
 	IgnoreMissingSubmodules bool
+	// Encodings are the legacy encodings of the blobs which are not valid UTF-8, e.g.
+	// "shift_jis" or "latin1". Such blobs are transcoded to UTF-8 from the first encoding
+	// which fits, so that the diffs and the comments are not garbled and the files are not
+	// mistaken for binary. Empty disables the transcoding.
+	Encodings []string
 
 	repository *git.Repository
 	cache      map[plumbing.Hash]*object.Blob
+	encodings  []blobEncoding
 }
 
 const (
 	// ConfigBlobCacheIgnoreMissingSubmodules is the name of the configuration option for
 	// BlobCache.Configure() to not check if the referenced submodules exist.
 	ConfigBlobCacheIgnoreMissingSubmodules = "BlobCache.IgnoreMissingSubmodules"
+	// ConfigBlobCacheEncodings is the name of the configuration option for
+	// BlobCache.Configure() to set BlobCache.Encodings.
+	ConfigBlobCacheEncodings = "BlobCache.Encodings"
 	// DependencyBlobCache identifies the dependency provided by BlobCache.
 	DependencyBlobCache = "blob_cache"
 )
@@ -64,7 +74,13 @@ func (blobCache *BlobCache) ListConfigurationOptions() []core.ConfigurationOptio
 			"history is dirty and you want to get things done.",
 		Flag:    "ignore-missing-submodules",
 		Type:    core.BoolConfigurationOption,
-		Default: false}}
+		Default: false}, {
+		Name: ConfigBlobCacheEncodings,
+		Description: "Transcode the files which are not valid UTF-8 from the first of these " +
+			"encodings which fits, e.g. \"shift_jis,latin1\".",
+		Flag:    "encodings",
+		Type:    core.StringsConfigurationOption,
+		Default: []string{}}}
 	return options[:]
 }
 
@@ -73,6 +89,9 @@ func (blobCache *BlobCache) Configure(facts map[string]interface{}) {
 	if val, exists := facts[ConfigBlobCacheIgnoreMissingSubmodules].(bool); exists {
 		blobCache.IgnoreMissingSubmodules = val
 	}
+	if val, exists := facts[ConfigBlobCacheEncodings].([]string); exists {
+		blobCache.Encodings = val
+	}
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
@@ -80,6 +99,11 @@ func (blobCache *BlobCache) Configure(facts map[string]interface{}) {
 func (blobCache *BlobCache) Initialize(repository *git.Repository) {
 	blobCache.repository = repository
 	blobCache.cache = map[plumbing.Hash]*object.Blob{}
+	encodings, err := parseBlobEncodings(blobCache.Encodings)
+	if err != nil {
+		log.Printf("Warning: disabled the transcoding: %v\n", err)
+	}
+	blobCache.encodings = encodings
 }
 
 // Consume runs this PipelineItem on the next commit data.
@@ -147,6 +171,23 @@ func (blobCache *BlobCache) Consume(deps map[string]interface{}) (map[string]int
 	return map[string]interface{}{DependencyBlobCache: cache}, nil
 }
 
+// Shrink evicts the cached blobs of the previous commit. They are loaded from the repository
+// again when needed, so the results stay the same.
+func (blobCache *BlobCache) Shrink() {
+	blobCache.cache = map[plumbing.Hash]*object.Blob{}
+}
+
+// DumpState does nothing: the cached blobs are loaded from the repository again when needed.
+// See core.PersistentPipelineItem.
+func (blobCache *BlobCache) DumpState(writer io.Writer) error {
+	return nil
+}
+
+// LoadState does nothing, see DumpState().
+func (blobCache *BlobCache) LoadState(reader io.Reader) error {
+	return nil
+}
+
 // FileGetter defines a function which loads the Git file by the specified path.
 // The state can be arbitrary though here it always corresponds to the currently processed
 // commit.
@@ -187,7 +228,8 @@ func (blobCache *BlobCache) getBlob(entry *object.ChangeEntry, fileGetter FileGe
 		}
 		return nil, err
 	}
-	return blob, nil
+	blob, _, err = transcodeBlob(blob, blobCache.encodings)
+	return blob, err
 }
 
 func init() {
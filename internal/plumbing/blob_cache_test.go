@@ -23,8 +23,15 @@ func TestBlobCacheConfigureInitialize(t *testing.T) {
 	assert.False(t, cache.IgnoreMissingSubmodules)
 	facts := map[string]interface{}{}
 	facts[ConfigBlobCacheIgnoreMissingSubmodules] = true
+	facts[ConfigBlobCacheEncodings] = []string{"shift_jis", "latin1"}
 	cache.Configure(facts)
 	assert.True(t, cache.IgnoreMissingSubmodules)
+	assert.Equal(t, cache.Encodings, []string{"shift_jis", "latin1"})
+	cache.Initialize(test.Repository)
+	assert.Len(t, cache.encodings, 2)
+	cache.Encodings = []string{"wat"}
+	cache.Initialize(test.Repository)
+	assert.Len(t, cache.encodings, 0)
 	facts = map[string]interface{}{}
 	cache.Configure(facts)
 	assert.True(t, cache.IgnoreMissingSubmodules)
@@ -39,8 +46,9 @@ func TestBlobCacheMetadata(t *testing.T) {
 	changes := &TreeDiff{}
 	assert.Equal(t, cache.Requires()[0], changes.Provides()[0])
 	opts := cache.ListConfigurationOptions()
-	assert.Len(t, opts, 1)
+	assert.Len(t, opts, 2)
 	assert.Equal(t, opts[0].Name, ConfigBlobCacheIgnoreMissingSubmodules)
+	assert.Equal(t, opts[1].Name, ConfigBlobCacheEncodings)
 }
 
 func TestBlobCacheRegistration(t *testing.T) {
@@ -402,3 +410,12 @@ func TestBlobCacheGetBlobGitModulesErrors(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.NotEqual(t, err.Error(), plumbing.ErrObjectNotFound.Error())
 }
+
+func TestBlobCacheShrink(t *testing.T) {
+	cache := fixtureBlobCache()
+	blob, _ := internal.CreateDummyBlob(plumbing.NewHash(
+		"ffffffffffffffffffffffffffffffffffffffff"))
+	cache.cache[blob.Hash] = blob
+	cache.Shrink()
+	assert.Len(t, cache.cache, 0)
+}
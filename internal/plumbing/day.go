@@ -1,6 +1,9 @@
 package plumbing
 
 import (
+	"encoding/gob"
+	"io"
+	"log"
 	"time"
 
 	"gopkg.in/src-d/go-git.v4"
@@ -12,9 +15,30 @@ import (
 // DaysSinceStart provides the relative date information for every commit.
 // It is a PipelineItem.
 type DaysSinceStart struct {
-	day0        time.Time
-	previousDay int
-	commits     map[int][]plumbing.Hash
+	// Timestamp selects the commit date to bucket by: TimestampAuthor or TimestampCommitter.
+	Timestamp string
+	// ClampBogusTimestamps replaces the dates before 1971 or in the future with the other
+	// signature's date or, if it is also bogus, with the date of the previous commit.
+	ClampBogusTimestamps bool
+	// Timezone defines the day boundaries: "UTC", "Local" or an IANA name, e.g. "Europe/Berlin".
+	Timezone string
+	// DayZero is the beginning of the day indexes. If it is zero, the day of the first commit
+	// is used. The commits before DayZero belong to day 0.
+	DayZero time.Time
+
+	location     *time.Location
+	day0         time.Time
+	previousDay  int
+	previousTime time.Time
+	commits      map[int][]plumbing.Hash
+}
+
+// daysSinceStartState is the format of DaysSinceStart.DumpState().
+type daysSinceStartState struct {
+	Day0         time.Time
+	PreviousDay  int
+	PreviousTime time.Time
+	Commits      map[int][]plumbing.Hash
 }
 
 const (
@@ -24,8 +48,25 @@ const (
 
 	// FactCommitsByDay contains the mapping between day indices and the corresponding commits.
 	FactCommitsByDay = "DaysSinceStart.Commits"
+
+	// ConfigDaysSinceStartTimestamp is the name of the option to set DaysSinceStart.Timestamp.
+	ConfigDaysSinceStartTimestamp = "DaysSinceStart.Timestamp"
+	// ConfigDaysSinceStartClampBogusTimestamps is the name of the option to set
+	// DaysSinceStart.ClampBogusTimestamps.
+	ConfigDaysSinceStartClampBogusTimestamps = "DaysSinceStart.ClampBogusTimestamps"
+	// ConfigDaysSinceStartTimezone is the name of the option to set DaysSinceStart.Timezone.
+	ConfigDaysSinceStartTimezone = "DaysSinceStart.Timezone"
+	// DefaultDaysSinceStartTimezone is the default value of DaysSinceStart.Timezone.
+	DefaultDaysSinceStartTimezone = "UTC"
+	// TimestampAuthor is the value of DaysSinceStart.Timestamp to bucket by the author date.
+	TimestampAuthor = "author"
+	// TimestampCommitter is the value of DaysSinceStart.Timestamp to bucket by the committer date.
+	TimestampCommitter = "committer"
 )
 
+// minSaneTimestamp is the earliest commit date which is not considered bogus.
+var minSaneTimestamp = time.Date(1971, 1, 1, 0, 0, 0, 0, time.UTC)
+
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
 func (days *DaysSinceStart) Name() string {
 	return "DaysSinceStart"
@@ -48,11 +89,42 @@ func (days *DaysSinceStart) Requires() []string {
 
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
 func (days *DaysSinceStart) ListConfigurationOptions() []core.ConfigurationOption {
-	return []core.ConfigurationOption{}
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigDaysSinceStartTimestamp,
+		Description: "Which commit date to use to calculate the day indices: \"" +
+			TimestampAuthor + "\" or \"" + TimestampCommitter + "\".",
+		Flag:    "day-timestamp",
+		Type:    core.StringConfigurationOption,
+		Default: TimestampAuthor}, {
+		Name:        ConfigDaysSinceStartClampBogusTimestamps,
+		Description: "Replace the commit dates before 1971 or in the future with sane ones.",
+		Flag:        "day-clamp-bogus",
+		Type:        core.BoolConfigurationOption,
+		Default:     true}, {
+		Name: ConfigDaysSinceStartTimezone,
+		Description: "Time zone which defines the day boundaries: \"UTC\", \"Local\" or " +
+			"an IANA name, e.g. \"Europe/Berlin\".",
+		Flag:    "timezone",
+		Type:    core.StringConfigurationOption,
+		Default: DefaultDaysSinceStartTimezone},
+	}
+	return options[:]
 }
 
 // Configure sets the properties previously published by ListConfigurationOptions().
 func (days *DaysSinceStart) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigDaysSinceStartTimestamp].(string); exists {
+		days.Timestamp = val
+	}
+	if val, exists := facts[ConfigDaysSinceStartClampBogusTimestamps].(bool); exists {
+		days.ClampBogusTimestamps = val
+	}
+	if val, exists := facts[ConfigDaysSinceStartTimezone].(string); exists {
+		days.Timezone = val
+	}
+	if val, exists := facts[core.FactPipelineDayZero].(time.Time); exists {
+		days.DayZero = val
+	}
 	if days.commits == nil {
 		days.commits = map[int][]plumbing.Hash{}
 	}
@@ -62,8 +134,29 @@ func (days *DaysSinceStart) Configure(facts map[string]interface{}) {
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (days *DaysSinceStart) Initialize(repository *git.Repository) {
+	switch days.Timestamp {
+	case TimestampAuthor, TimestampCommitter:
+	case "":
+		days.Timestamp = TimestampAuthor
+	default:
+		log.Printf("Warning: unknown commit timestamp \"%s\", falling back to \"%s\"\n",
+			days.Timestamp, TimestampAuthor)
+		days.Timestamp = TimestampAuthor
+	}
+	if days.Timezone == "" {
+		days.Timezone = DefaultDaysSinceStartTimezone
+	}
+	location, err := time.LoadLocation(days.Timezone)
+	if err != nil {
+		log.Printf("Warning: unknown time zone \"%s\", falling back to \"%s\"\n",
+			days.Timezone, DefaultDaysSinceStartTimezone)
+		days.Timezone = DefaultDaysSinceStartTimezone
+		location = time.UTC
+	}
+	days.location = location
 	days.day0 = time.Time{}
 	days.previousDay = 0
+	days.previousTime = time.Time{}
 	if len(days.commits) > 0 {
 		keys := make([]int, len(days.commits))
 		for key := range days.commits {
@@ -83,13 +176,18 @@ func (days *DaysSinceStart) Initialize(repository *git.Repository) {
 func (days *DaysSinceStart) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
 	commit := deps["commit"].(*object.Commit)
 	index := deps["index"].(int)
+	when := days.commitTime(commit, index == 0)
+	days.previousTime = when
 	if index == 0 {
 		// first iteration - initialize the file objects from the tree
-		days.day0 = commit.Author.When
 		// our precision is 1 day
-		days.day0 = days.day0.Truncate(24 * time.Hour)
+		if days.DayZero.IsZero() {
+			days.day0 = days.midnight(when)
+		} else {
+			days.day0 = days.midnight(days.DayZero)
+		}
 	}
-	day := int(commit.Author.When.Sub(days.day0).Hours() / 24)
+	day := days.daysBetween(days.day0, days.midnight(when))
 	if day < days.previousDay {
 		// rebase works miracles, but we need the monotonous time
 		day = days.previousDay
@@ -103,6 +201,74 @@ func (days *DaysSinceStart) Consume(deps map[string]interface{}) (map[string]int
 	return map[string]interface{}{DependencyDay: day}, nil
 }
 
+// midnight returns the beginning of the day of `when` in the configured time zone.
+func (days *DaysSinceStart) midnight(when time.Time) time.Time {
+	year, month, day := when.In(days.location).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, days.location)
+}
+
+// daysBetween returns the number of calendar days between two midnights. The days
+// are not always 24 hours long because of the daylight saving time.
+func (days *DaysSinceStart) daysBetween(from, to time.Time) int {
+	y1, m1, d1 := from.Date()
+	y2, m2, d2 := to.Date()
+	utc1 := time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)
+	utc2 := time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC)
+	return int(utc2.Sub(utc1).Hours() / 24)
+}
+
+// commitTime returns the date of the commit according to the configured policy.
+func (days *DaysSinceStart) commitTime(commit *object.Commit, first bool) time.Time {
+	primary, secondary := commit.Author.When, commit.Committer.When
+	if days.Timestamp == TimestampCommitter {
+		primary, secondary = secondary, primary
+	}
+	if !days.ClampBogusTimestamps {
+		return primary
+	}
+	maxSane := time.Now().Add(24 * time.Hour)
+	isSane := func(when time.Time) bool {
+		return !when.Before(minSaneTimestamp) && !when.After(maxSane)
+	}
+	if isSane(primary) {
+		return primary
+	}
+	if isSane(secondary) {
+		return secondary
+	}
+	if !first {
+		return days.previousTime
+	}
+	return primary
+}
+
+// DumpState writes the beginning of the day indexes, the last day and the commits by day.
+// See core.PersistentPipelineItem.
+func (days *DaysSinceStart) DumpState(writer io.Writer) error {
+	return gob.NewEncoder(writer).Encode(&daysSinceStartState{
+		Day0:         days.day0,
+		PreviousDay:  days.previousDay,
+		PreviousTime: days.previousTime,
+		Commits:      days.commits,
+	})
+}
+
+// LoadState restores the state written by DumpState().
+func (days *DaysSinceStart) LoadState(reader io.Reader) error {
+	state := daysSinceStartState{}
+	if err := gob.NewDecoder(reader).Decode(&state); err != nil {
+		return err
+	}
+	days.day0 = state.Day0.In(days.location)
+	days.previousDay = state.PreviousDay
+	days.previousTime = state.PreviousTime
+	// the map is shared with the other items through FactCommitsByDay
+	for day, hashes := range state.Commits {
+		days.commits[day] = hashes
+	}
+	return nil
+}
+
 func init() {
 	core.Registry.Register(&DaysSinceStart{})
 }
@@ -1,10 +1,13 @@
 package plumbing
 
 import (
+	"bytes"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
 	"gopkg.in/src-d/hercules.v4/internal/core"
 	"gopkg.in/src-d/hercules.v4/internal/test"
 )
@@ -22,8 +25,28 @@ func TestDaysSinceStartMeta(t *testing.T) {
 	assert.Equal(t, len(dss.Provides()), 1)
 	assert.Equal(t, dss.Provides()[0], DependencyDay)
 	assert.Equal(t, len(dss.Requires()), 0)
-	assert.Len(t, dss.ListConfigurationOptions(), 0)
-	dss.Configure(map[string]interface{}{})
+	opts := dss.ListConfigurationOptions()
+	assert.Len(t, opts, 3)
+	assert.Equal(t, opts[0].Name, ConfigDaysSinceStartTimestamp)
+	assert.Equal(t, opts[1].Name, ConfigDaysSinceStartClampBogusTimestamps)
+	assert.Equal(t, opts[2].Name, ConfigDaysSinceStartTimezone)
+	assert.Equal(t, dss.Timestamp, TimestampAuthor)
+	assert.False(t, dss.ClampBogusTimestamps)
+	assert.Equal(t, dss.Timezone, DefaultDaysSinceStartTimezone)
+	dss.Configure(map[string]interface{}{
+		ConfigDaysSinceStartTimestamp:            TimestampCommitter,
+		ConfigDaysSinceStartClampBogusTimestamps: true,
+		ConfigDaysSinceStartTimezone:             "Europe/Berlin",
+	})
+	assert.Equal(t, dss.Timestamp, TimestampCommitter)
+	assert.True(t, dss.ClampBogusTimestamps)
+	assert.Equal(t, dss.Timezone, "Europe/Berlin")
+	dss.Timestamp = "whatever"
+	dss.Timezone = "Mars/Olympus_Mons"
+	dss.Initialize(test.Repository)
+	assert.Equal(t, dss.Timestamp, TimestampAuthor)
+	assert.Equal(t, dss.Timezone, DefaultDaysSinceStartTimezone)
+	assert.Equal(t, dss.location, time.UTC)
 }
 
 func TestDaysSinceStartRegistration(t *testing.T) {
@@ -106,3 +129,141 @@ func TestDaysCommits(t *testing.T) {
 	assert.Len(t, dss.commits, 0)
 	assert.Equal(t, dss.commits, commits)
 }
+
+func fixtureDaysSinceStartCommit(author, committer time.Time) *object.Commit {
+	return &object.Commit{
+		Author:    object.Signature{When: author},
+		Committer: object.Signature{When: committer},
+	}
+}
+
+func TestDaysSinceStartCommitter(t *testing.T) {
+	dss := fixtureDaysSinceStart()
+	dss.Timestamp = TimestampCommitter
+	dss.Initialize(test.Repository)
+	day0 := time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC)
+	deps := map[string]interface{}{}
+	deps["commit"] = fixtureDaysSinceStartCommit(day0, day0)
+	deps["index"] = 0
+	res, err := dss.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, res[DependencyDay].(int), 0)
+	// rebased a week later
+	deps["commit"] = fixtureDaysSinceStartCommit(day0.Add(24*time.Hour), day0.Add(7*24*time.Hour))
+	deps["index"] = 1
+	res, err = dss.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, res[DependencyDay].(int), 7)
+}
+
+func TestDaysSinceStartDumpLoad(t *testing.T) {
+	dss := fixtureDaysSinceStart()
+	day0 := time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC)
+	deps := map[string]interface{}{}
+	deps["commit"] = fixtureDaysSinceStartCommit(day0, day0)
+	deps["index"] = 0
+	dss.Consume(deps)
+	deps["commit"] = fixtureDaysSinceStartCommit(day0.Add(48*time.Hour), day0)
+	deps["index"] = 1
+	dss.Consume(deps)
+	state := &bytes.Buffer{}
+	assert.Nil(t, dss.DumpState(state))
+	facts := map[string]interface{}{}
+	restored := DaysSinceStart{}
+	restored.Configure(facts)
+	restored.Initialize(test.Repository)
+	assert.Nil(t, restored.LoadState(state))
+	assert.Equal(t, facts[FactCommitsByDay].(map[int][]plumbing.Hash), dss.commits)
+	// the day is counted from the restored beginning and is monotonous
+	deps["commit"] = fixtureDaysSinceStartCommit(day0.Add(24*time.Hour), day0)
+	deps["index"] = 2
+	res, err := restored.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, res[DependencyDay].(int), 2)
+	deps["commit"] = fixtureDaysSinceStartCommit(day0.Add(72*time.Hour), day0)
+	res, _ = restored.Consume(deps)
+	assert.Equal(t, res[DependencyDay].(int), 3)
+	assert.NotNil(t, restored.LoadState(bytes.NewBufferString("garbage")))
+}
+
+func TestDaysSinceStartClampBogusTimestamps(t *testing.T) {
+	dss := fixtureDaysSinceStart()
+	dss.ClampBogusTimestamps = true
+	day0 := time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC)
+	deps := map[string]interface{}{}
+	deps["commit"] = fixtureDaysSinceStartCommit(time.Unix(0, 0), day0)
+	deps["index"] = 0
+	res, err := dss.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, res[DependencyDay].(int), 0)
+	assert.Equal(t, dss.day0, day0.Truncate(24*time.Hour))
+	deps["commit"] = fixtureDaysSinceStartCommit(day0.Add(2*24*time.Hour), day0)
+	deps["index"] = 1
+	res, err = dss.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, res[DependencyDay].(int), 2)
+	future := time.Now().Add(365 * 24 * time.Hour)
+	deps["commit"] = fixtureDaysSinceStartCommit(future, future)
+	deps["index"] = 2
+	res, err = dss.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, res[DependencyDay].(int), 2)
+	dss.ClampBogusTimestamps = false
+	deps["index"] = 3
+	res, err = dss.Consume(deps)
+	assert.Nil(t, err)
+	assert.True(t, res[DependencyDay].(int) > 365)
+}
+
+func TestDaysSinceStartTimezone(t *testing.T) {
+	dss := fixtureDaysSinceStart()
+	day0 := time.Date(2018, 3, 10, 12, 0, 0, 0, time.UTC)
+	// 22:00 in New York, but already the next day in UTC
+	evening := time.Date(2018, 3, 11, 3, 0, 0, 0, time.UTC)
+	// after the daylight saving time switch
+	later := time.Date(2018, 3, 13, 3, 30, 0, 0, time.UTC)
+	consume := func() []int {
+		result := []int{}
+		for i, when := range []time.Time{day0, evening, later} {
+			deps := map[string]interface{}{}
+			deps["commit"] = fixtureDaysSinceStartCommit(when, when)
+			deps["index"] = i
+			res, err := dss.Consume(deps)
+			assert.Nil(t, err)
+			result = append(result, res[DependencyDay].(int))
+		}
+		return result
+	}
+	assert.Equal(t, consume(), []int{0, 1, 3})
+	dss.Timezone = "America/New_York"
+	dss.Initialize(test.Repository)
+	assert.Equal(t, consume(), []int{0, 0, 2})
+	assert.Equal(t, dss.day0.Hour(), 0)
+	assert.Equal(t, dss.day0.Location().String(), "America/New_York")
+}
+
+func TestDaysSinceStartDayZero(t *testing.T) {
+	dss := fixtureDaysSinceStart()
+	dss.Configure(map[string]interface{}{
+		core.FactPipelineDayZero: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)})
+	assert.Equal(t, dss.DayZero, time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	dss.Initialize(test.Repository)
+	deps := map[string]interface{}{}
+	for i, day := range []int{10, 12} {
+		when := time.Date(2018, 1, 1+day, 12, 0, 0, 0, time.UTC)
+		deps["commit"] = fixtureDaysSinceStartCommit(when, when)
+		deps["index"] = i
+		res, err := dss.Consume(deps)
+		assert.Nil(t, err)
+		assert.Equal(t, res[DependencyDay].(int), day)
+	}
+	// the commits before day zero belong to day 0
+	dss.DayZero = time.Date(2018, 2, 1, 0, 0, 0, 0, time.UTC)
+	dss.Initialize(test.Repository)
+	when := time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC)
+	deps["commit"] = fixtureDaysSinceStartCommit(when, when)
+	deps["index"] = 0
+	res, err := dss.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, res[DependencyDay].(int), 0)
+}
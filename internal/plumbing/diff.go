@@ -5,6 +5,9 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"log"
+	"strings"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/sergi/go-diff/diffmatchpatch"
@@ -19,6 +22,12 @@ import (
 // It is a PipelineItem.
 type FileDiff struct {
 	CleanupDisabled bool
+	// WhitespaceIgnored makes the lines which differ only in whitespace equal, like `git diff -w`.
+	WhitespaceIgnored bool
+	// Algorithm is the name of the diff algorithm: "myers", "patience" or "histogram".
+	Algorithm string
+
+	algorithm diffAlgorithm
 }
 
 const (
@@ -26,6 +35,16 @@ const (
 	// to suppress diffmatchpatch.DiffCleanupSemanticLossless() which is supposed to improve
 	// the human interpretability of diffs.
 	ConfigFileDiffDisableCleanup = "FileDiff.NoCleanup"
+	// ConfigFileDiffIgnoreWhitespace is the name of the configuration option (FileDiff.Configure())
+	// to treat the lines which differ only in whitespace as equal. This way the mass re-indentation
+	// commits do not reset the ages of the lines.
+	ConfigFileDiffIgnoreWhitespace = "FileDiff.IgnoreWhitespace"
+	// ConfigFileDiffAlgorithm is the name of the configuration option (FileDiff.Configure())
+	// to choose the diff algorithm. Myers often produces noisy diffs of the heavily refactored
+	// files, patience and histogram anchor them at the rare lines instead.
+	ConfigFileDiffAlgorithm = "FileDiff.Algorithm"
+	// DefaultFileDiffAlgorithm is the default value of FileDiff.Algorithm.
+	DefaultFileDiffAlgorithm = DiffAlgorithmMyers
 
 	// DependencyFileDiff is the name of the dependency provided by FileDiff.
 	DependencyFileDiff = "file_diff"
@@ -66,7 +85,18 @@ func (diff *FileDiff) ListConfigurationOptions() []core.ConfigurationOption {
 		Description: "Do not apply additional heuristics to improve diffs.",
 		Flag:        "no-diff-cleanup",
 		Type:        core.BoolConfigurationOption,
-		Default:     false},
+		Default:     false}, {
+		Name:        ConfigFileDiffIgnoreWhitespace,
+		Description: "Ignore the changes in whitespace when comparing lines, like `git diff -w`.",
+		Flag:        "ignore-whitespace",
+		Type:        core.BoolConfigurationOption,
+		Default:     false}, {
+		Name: ConfigFileDiffAlgorithm,
+		Description: "Line diff algorithm: \"" + DiffAlgorithmMyers + "\", \"" +
+			DiffAlgorithmPatience + "\" or \"" + DiffAlgorithmHistogram + "\".",
+		Flag:    "diff-algorithm",
+		Type:    core.StringConfigurationOption,
+		Default: DefaultFileDiffAlgorithm},
 	}
 	return options[:]
 }
@@ -76,11 +106,28 @@ func (diff *FileDiff) Configure(facts map[string]interface{}) {
 	if val, exists := facts[ConfigFileDiffDisableCleanup].(bool); exists {
 		diff.CleanupDisabled = val
 	}
+	if val, exists := facts[ConfigFileDiffIgnoreWhitespace].(bool); exists {
+		diff.WhitespaceIgnored = val
+	}
+	if val, exists := facts[ConfigFileDiffAlgorithm].(string); exists {
+		diff.Algorithm = val
+	}
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
-func (diff *FileDiff) Initialize(repository *git.Repository) {}
+func (diff *FileDiff) Initialize(repository *git.Repository) {
+	if diff.Algorithm == "" {
+		diff.Algorithm = DefaultFileDiffAlgorithm
+	}
+	diff.algorithm = diffAlgorithms[diff.Algorithm]
+	if diff.algorithm == nil {
+		log.Printf("Warning: unknown diff algorithm \"%s\", falling back to \"%s\"\n",
+			diff.Algorithm, DefaultFileDiffAlgorithm)
+		diff.Algorithm = DefaultFileDiffAlgorithm
+		diff.algorithm = diffAlgorithms[diff.Algorithm]
+	}
+}
 
 // Consume runs this PipelineItem on the next commit data.
 // `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
@@ -111,8 +158,13 @@ func (diff *FileDiff) Consume(deps map[string]interface{}) (map[string]interface
 				return nil, err
 			}
 			dmp := diffmatchpatch.New()
-			src, dst, _ := dmp.DiffLinesToRunes(strFrom, strTo)
-			diffs := dmp.DiffMainRunes(src, dst, false)
+			var src, dst []rune
+			if diff.WhitespaceIgnored {
+				src, dst = linesToRunes(strFrom, strTo, stripWhitespace)
+			} else {
+				src, dst, _ = dmp.DiffLinesToRunes(strFrom, strTo)
+			}
+			diffs := diff.algorithm(dmp, src, dst)
 			if !diff.CleanupDisabled {
 				diffs = dmp.DiffCleanupMerge(dmp.DiffCleanupSemanticLossless(diffs))
 			}
@@ -128,6 +180,53 @@ func (diff *FileDiff) Consume(deps map[string]interface{}) (map[string]interface
 	return map[string]interface{}{DependencyFileDiff: result}, nil
 }
 
+// DumpState does nothing because FileDiff does not carry anything between the commits.
+// See core.PersistentPipelineItem.
+func (diff *FileDiff) DumpState(writer io.Writer) error {
+	return nil
+}
+
+// LoadState does nothing, see DumpState().
+func (diff *FileDiff) LoadState(reader io.Reader) error {
+	return nil
+}
+
+// linesToRunes splits the texts into lines the same way as diffmatchpatch.DiffLinesToRunes()
+// and encodes each line as a rune. The lines which are equal after normalize() share the rune.
+func linesToRunes(text1, text2 string, normalize func(string) string) ([]rune, []rune) {
+	hashes := map[string]rune{}
+	encode := func(text string) []rune {
+		var runes []rune
+		for len(text) > 0 {
+			end := strings.IndexByte(text, '\n') + 1
+			if end == 0 {
+				end = len(text)
+			}
+			line := normalize(text[:end])
+			text = text[end:]
+			hash, exists := hashes[line]
+			if !exists {
+				// the same as in diffmatchpatch: the first line gets 1
+				hash = rune(len(hashes) + 1)
+				hashes[line] = hash
+			}
+			runes = append(runes, hash)
+		}
+		return runes
+	}
+	return encode(text1), encode(text2)
+}
+
+// stripWhitespace removes all the whitespace characters including the line break.
+func stripWhitespace(line string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, line)
+}
+
 // CountLines returns the number of lines in a *object.Blob.
 func CountLines(file *object.Blob) (int, error) {
 	if file == nil {
@@ -0,0 +1,244 @@
+package plumbing
+
+import (
+	"sort"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+const (
+	// DiffAlgorithmMyers is the default diff algorithm from diffmatchpatch.
+	DiffAlgorithmMyers = "myers"
+	// DiffAlgorithmPatience is the patience diff algorithm which anchors the diff
+	// at the lines which are unique in both files.
+	DiffAlgorithmPatience = "patience"
+	// DiffAlgorithmHistogram is the histogram diff algorithm which anchors the diff
+	// at the longest common regions of the least frequent lines, as in git.
+	DiffAlgorithmHistogram = "histogram"
+
+	// histogramMaxOccurrences is the number of occurrences of a line after which
+	// the histogram algorithm does not consider it as an anchor.
+	histogramMaxOccurrences = 64
+)
+
+// diffAlgorithm calculates the difference of two files represented as the sequences
+// of lines encoded as runes, see diffmatchpatch.DiffLinesToRunes().
+type diffAlgorithm func(dmp *diffmatchpatch.DiffMatchPatch, src, dst []rune) []diffmatchpatch.Diff
+
+// diffRegion is the common region of two files.
+type diffRegion struct {
+	Src    int
+	Dst    int
+	Length int
+}
+
+// diffMatcher finds the common regions which split the files into independent parts.
+// The regions must be sorted and must not overlap. Returns nil if there are no good candidates.
+type diffMatcher func(src, dst []rune) []diffRegion
+
+var diffAlgorithms = map[string]diffAlgorithm{
+	DiffAlgorithmMyers:     diffMyers,
+	DiffAlgorithmPatience:  diffPatience,
+	DiffAlgorithmHistogram: diffHistogram,
+}
+
+func diffMyers(dmp *diffmatchpatch.DiffMatchPatch, src, dst []rune) []diffmatchpatch.Diff {
+	return dmp.DiffMainRunes(src, dst, false)
+}
+
+func diffPatience(dmp *diffmatchpatch.DiffMatchPatch, src, dst []rune) []diffmatchpatch.Diff {
+	builder := &diffBuilder{}
+	diffAnchored(dmp, src, dst, matchUniqueLines, builder)
+	return builder.Finish()
+}
+
+func diffHistogram(dmp *diffmatchpatch.DiffMatchPatch, src, dst []rune) []diffmatchpatch.Diff {
+	builder := &diffBuilder{}
+	diffAnchored(dmp, src, dst, matchRareRegion, builder)
+	return builder.Finish()
+}
+
+// diffAnchored strips the common prefix and suffix, splits the rest at the regions found
+// by match and recursively diffs the gaps between them. Myers diffs the parts without
+// any regions.
+func diffAnchored(dmp *diffmatchpatch.DiffMatchPatch, src, dst []rune, match diffMatcher,
+	builder *diffBuilder) {
+	prefix := 0
+	for prefix < len(src) && prefix < len(dst) && src[prefix] == dst[prefix] {
+		prefix++
+	}
+	builder.Add(diffmatchpatch.DiffEqual, src[:prefix])
+	src, dst = src[prefix:], dst[prefix:]
+	suffix := 0
+	for suffix < len(src) && suffix < len(dst) &&
+		src[len(src)-suffix-1] == dst[len(dst)-suffix-1] {
+		suffix++
+	}
+	tail := src[len(src)-suffix:]
+	src, dst = src[:len(src)-suffix], dst[:len(dst)-suffix]
+	if len(src) == 0 || len(dst) == 0 {
+		builder.Add(diffmatchpatch.DiffDelete, src)
+		builder.Add(diffmatchpatch.DiffInsert, dst)
+	} else if regions := match(src, dst); len(regions) == 0 {
+		for _, edit := range dmp.DiffMainRunes(src, dst, false) {
+			builder.Add(edit.Type, []rune(edit.Text))
+		}
+	} else {
+		srcPos, dstPos := 0, 0
+		for _, region := range regions {
+			diffAnchored(dmp, src[srcPos:region.Src], dst[dstPos:region.Dst], match, builder)
+			builder.Add(diffmatchpatch.DiffEqual, src[region.Src:region.Src+region.Length])
+			srcPos, dstPos = region.Src+region.Length, region.Dst+region.Length
+		}
+		diffAnchored(dmp, src[srcPos:], dst[dstPos:], match, builder)
+	}
+	builder.Add(diffmatchpatch.DiffEqual, tail)
+}
+
+// matchUniqueLines returns the longest increasing sequence of the lines which appear
+// exactly once in both files. This is the core of the patience diff.
+func matchUniqueLines(src, dst []rune) []diffRegion {
+	type occurrence struct {
+		SrcCount, DstCount int
+		Src, Dst           int
+	}
+	lines := map[rune]*occurrence{}
+	for i, line := range src {
+		occ := lines[line]
+		if occ == nil {
+			occ = &occurrence{}
+			lines[line] = occ
+		}
+		occ.SrcCount++
+		occ.Src = i
+	}
+	for i, line := range dst {
+		if occ := lines[line]; occ != nil {
+			occ.DstCount++
+			occ.Dst = i
+		}
+	}
+	var unique []diffRegion
+	for _, occ := range lines {
+		if occ.SrcCount == 1 && occ.DstCount == 1 {
+			unique = append(unique, diffRegion{Src: occ.Src, Dst: occ.Dst, Length: 1})
+		}
+	}
+	if len(unique) == 0 {
+		return nil
+	}
+	sort.Slice(unique, func(i, j int) bool { return unique[i].Src < unique[j].Src })
+	// patience sorting: piles hold the index of the top element, back points to the previous pile
+	piles := []int{}
+	back := make([]int, len(unique))
+	for i, region := range unique {
+		pile := sort.Search(len(piles), func(j int) bool {
+			return unique[piles[j]].Dst > region.Dst
+		})
+		back[i] = -1
+		if pile > 0 {
+			back[i] = piles[pile-1]
+		}
+		if pile == len(piles) {
+			piles = append(piles, i)
+		} else {
+			piles[pile] = i
+		}
+	}
+	result := make([]diffRegion, len(piles))
+	for i, j := len(piles)-1, piles[len(piles)-1]; i >= 0; i, j = i-1, back[j] {
+		result[i] = unique[j]
+	}
+	return result
+}
+
+// matchRareRegion returns the longest common region which contains the least frequent
+// line of the source file. This is the core of the histogram diff.
+func matchRareRegion(src, dst []rune) []diffRegion {
+	positions := map[rune][]int{}
+	for i, line := range src {
+		positions[line] = append(positions[line], i)
+	}
+	best := diffRegion{}
+	bestCount := histogramMaxOccurrences + 1
+	for j := 0; j < len(dst); j++ {
+		candidates := positions[dst[j]]
+		if len(candidates) == 0 || len(candidates) > bestCount {
+			continue
+		}
+		for _, i := range candidates {
+			start := 0
+			for i-start > 0 && j-start > 0 && src[i-start-1] == dst[j-start-1] {
+				start++
+			}
+			length := start + 1
+			for i+length-start < len(src) && j+length-start < len(dst) &&
+				src[i+length-start] == dst[j+length-start] {
+				length++
+			}
+			count := len(candidates)
+			for k := i - start; k < i-start+length; k++ {
+				if n := len(positions[src[k]]); n < count {
+					count = n
+				}
+			}
+			if count < bestCount || (count == bestCount && length > best.Length) {
+				best = diffRegion{Src: i - start, Dst: j - start, Length: length}
+				bestCount = count
+			}
+		}
+	}
+	if best.Length == 0 {
+		return nil
+	}
+	return []diffRegion{best}
+}
+
+// diffBuilder accumulates the diff and keeps it canonical: the edits between two equal
+// parts are merged into a single deletion followed by a single insertion.
+type diffBuilder struct {
+	diffs   []diffmatchpatch.Diff
+	deleted []rune
+	added   []rune
+}
+
+// Add appends the edit of the specified type.
+func (builder *diffBuilder) Add(op diffmatchpatch.Operation, lines []rune) {
+	if len(lines) == 0 {
+		return
+	}
+	switch op {
+	case diffmatchpatch.DiffDelete:
+		builder.deleted = append(builder.deleted, lines...)
+	case diffmatchpatch.DiffInsert:
+		builder.added = append(builder.added, lines...)
+	case diffmatchpatch.DiffEqual:
+		builder.flush()
+		last := len(builder.diffs) - 1
+		if last >= 0 && builder.diffs[last].Type == diffmatchpatch.DiffEqual {
+			builder.diffs[last].Text += string(lines)
+		} else {
+			builder.diffs = append(builder.diffs, diffmatchpatch.Diff{
+				Type: diffmatchpatch.DiffEqual, Text: string(lines)})
+		}
+	}
+}
+
+// Finish returns the accumulated diff.
+func (builder *diffBuilder) Finish() []diffmatchpatch.Diff {
+	builder.flush()
+	return builder.diffs
+}
+
+func (builder *diffBuilder) flush() {
+	if len(builder.deleted) > 0 {
+		builder.diffs = append(builder.diffs, diffmatchpatch.Diff{
+			Type: diffmatchpatch.DiffDelete, Text: string(builder.deleted)})
+		builder.deleted = nil
+	}
+	if len(builder.added) > 0 {
+		builder.diffs = append(builder.diffs, diffmatchpatch.Diff{
+			Type: diffmatchpatch.DiffInsert, Text: string(builder.added)})
+		builder.added = nil
+	}
+}
@@ -0,0 +1,144 @@
+package plumbing
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+)
+
+// fixtureDiffAlgorithm diffs the texts line by line and returns the diff with the lines
+// restored together with the reconstructed source and destination texts.
+func fixtureDiffAlgorithm(t *testing.T, name string, before, after string) (
+	[]diffmatchpatch.Diff, string, string) {
+	dmp := diffmatchpatch.New()
+	src, dst, lines := dmp.DiffLinesToRunes(before, after)
+	diffs := diffAlgorithms[name](dmp, src, dst)
+	srcLength, dstLength := 0, 0
+	for i, edit := range diffs {
+		length := utf8.RuneCountInString(edit.Text)
+		assert.NotZero(t, length)
+		if edit.Type != diffmatchpatch.DiffInsert {
+			srcLength += length
+		}
+		if edit.Type != diffmatchpatch.DiffDelete {
+			dstLength += length
+		}
+		if i > 0 {
+			prev := diffs[i-1].Type
+			switch edit.Type {
+			case diffmatchpatch.DiffEqual:
+				assert.NotEqual(t, prev, diffmatchpatch.DiffEqual)
+			case diffmatchpatch.DiffDelete:
+				assert.Equal(t, prev, diffmatchpatch.DiffEqual)
+			case diffmatchpatch.DiffInsert:
+				assert.NotEqual(t, prev, diffmatchpatch.DiffInsert)
+			}
+		}
+	}
+	assert.Equal(t, srcLength, len(src))
+	assert.Equal(t, dstLength, len(dst))
+	diffs = dmp.DiffCharsToLines(diffs, lines)
+	return diffs, dmp.DiffText1(diffs), dmp.DiffText2(diffs)
+}
+
+const diffAlgorithmBefore = `void Chunk_copy(Chunk *src, size_t src_start, Chunk *dst, size_t dst_start, size_t n)
+{
+    if (!Chunk_bounds_check(src, src_start, n)) return;
+    if (!Chunk_bounds_check(dst, dst_start, n)) return;
+
+    memcpy(dst->data + dst_start, src->data + src_start, n);
+}
+
+int Chunk_bounds_check(Chunk *chunk, size_t start, size_t n)
+{
+    if (chunk == NULL) return 0;
+
+    return start <= chunk->length && n <= chunk->length - start;
+}
+`
+
+const diffAlgorithmAfter = `int Chunk_bounds_check(Chunk *chunk, size_t start, size_t n)
+{
+    if (chunk == NULL) return 0;
+
+    return start <= chunk->length && n <= chunk->length - start;
+}
+
+void Chunk_copy(Chunk *src, size_t src_start, Chunk *dst, size_t dst_start, size_t n)
+{
+    if (!Chunk_bounds_check(src, src_start, n)) return;
+    if (!Chunk_bounds_check(dst, dst_start, n)) return;
+
+    memcpy(dst->data + dst_start, src->data + src_start, n);
+}
+`
+
+func TestDiffAlgorithmsReconstruct(t *testing.T) {
+	for name := range diffAlgorithms {
+		for _, pair := range [][2]string{
+			{diffAlgorithmBefore, diffAlgorithmAfter},
+			{"", "a\nb\n"},
+			{"a\nb\n", ""},
+			{"a\nb\nc\n", "a\nb\nc\n"},
+			{"a\nb\na\nb\n", "b\na\nb\na\n"},
+			{"x\na\ny\nb\nz\n", "a\nx\nb\ny\nz\nw\n"},
+		} {
+			_, src, dst := fixtureDiffAlgorithm(t, name, pair[0], pair[1])
+			assert.Equal(t, src, pair[0], name)
+			assert.Equal(t, dst, pair[1], name)
+		}
+	}
+}
+
+func TestDiffAlgorithmsMovedFunction(t *testing.T) {
+	// the moved function must be reported as a whole and the other one must stay intact
+	for _, name := range []string{DiffAlgorithmPatience, DiffAlgorithmHistogram} {
+		diffs, _, _ := fixtureDiffAlgorithm(t, name, diffAlgorithmBefore, diffAlgorithmAfter)
+		var inserted, deleted []string
+		for _, edit := range diffs {
+			switch edit.Type {
+			case diffmatchpatch.DiffInsert:
+				inserted = append(inserted, edit.Text)
+			case diffmatchpatch.DiffDelete:
+				deleted = append(deleted, edit.Text)
+			}
+		}
+		assert.Len(t, inserted, 1, name)
+		assert.Len(t, deleted, 1, name)
+		for _, text := range []string{inserted[0], deleted[0]} {
+			assert.Contains(t, text, "int Chunk_bounds_check", name)
+			assert.NotContains(t, text, "Chunk_copy", name)
+		}
+		assert.Equal(t, strings.Count(inserted[0], "\n"), strings.Count(deleted[0], "\n"), name)
+	}
+}
+
+func TestMatchUniqueLines(t *testing.T) {
+	src := []rune{1, 2, 3, 4, 5, 2}
+	dst := []rune{3, 1, 4, 2, 5, 2, 6}
+	assert.Equal(t, matchUniqueLines(src, dst), []diffRegion{
+		{Src: 2, Dst: 0, Length: 1}, {Src: 3, Dst: 2, Length: 1}, {Src: 4, Dst: 4, Length: 1}})
+	assert.Nil(t, matchUniqueLines([]rune{1, 1}, []rune{1, 2}))
+}
+
+func TestMatchRareRegion(t *testing.T) {
+	src := []rune{1, 2, 3, 1, 4, 1}
+	dst := []rune{1, 1, 4, 1, 2, 3}
+	assert.Equal(t, matchRareRegion(src, dst), []diffRegion{{Src: 3, Dst: 1, Length: 3}})
+	assert.Nil(t, matchRareRegion([]rune{1}, []rune{2}))
+}
+
+func TestLinesToRunes(t *testing.T) {
+	src, dst := linesToRunes("a\n b\nc", "a \nb\n\nc\n", stripWhitespace)
+	assert.Equal(t, src, []rune{1, 2, 3})
+	assert.Equal(t, dst, []rune{1, 2, 4, 3})
+	dmp := diffmatchpatch.New()
+	before, after := "a\nb\n\na\nc", "c\na\nb\n"
+	src, dst = linesToRunes(before, after, func(line string) string { return line })
+	expectedSrc, expectedDst, _ := dmp.DiffLinesToRunes(before, after)
+	assert.Equal(t, src, expectedSrc)
+	assert.Equal(t, dst, expectedDst)
+}
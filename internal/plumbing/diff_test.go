@@ -23,12 +23,22 @@ func TestFileDiffMeta(t *testing.T) {
 	assert.Equal(t, len(fd.Requires()), 2)
 	assert.Equal(t, fd.Requires()[0], items.DependencyTreeChanges)
 	assert.Equal(t, fd.Requires()[1], items.DependencyBlobCache)
-	assert.Len(t, fd.ListConfigurationOptions(), 1)
+	assert.Len(t, fd.ListConfigurationOptions(), 3)
 	assert.Equal(t, fd.ListConfigurationOptions()[0].Name, items.ConfigFileDiffDisableCleanup)
+	assert.Equal(t, fd.ListConfigurationOptions()[1].Name, items.ConfigFileDiffIgnoreWhitespace)
+	assert.Equal(t, fd.ListConfigurationOptions()[2].Name, items.ConfigFileDiffAlgorithm)
+	assert.Equal(t, fd.Algorithm, items.DiffAlgorithmMyers)
 	facts := map[string]interface{}{}
 	facts[items.ConfigFileDiffDisableCleanup] = true
+	facts[items.ConfigFileDiffIgnoreWhitespace] = true
+	facts[items.ConfigFileDiffAlgorithm] = items.DiffAlgorithmHistogram
 	fd.Configure(facts)
 	assert.True(t, fd.CleanupDisabled)
+	assert.True(t, fd.WhitespaceIgnored)
+	assert.Equal(t, fd.Algorithm, items.DiffAlgorithmHistogram)
+	fd.Algorithm = "xxx"
+	fd.Initialize(test.Repository)
+	assert.Equal(t, fd.Algorithm, items.DiffAlgorithmMyers)
 }
 
 func TestFileDiffRegistration(t *testing.T) {
@@ -180,6 +190,56 @@ func TestFileDiffConsumeInvalidBlob(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestFileDiffConsumeIgnoreWhitespace(t *testing.T) {
+	newBlob := func(contents string) *object.Blob {
+		obj := &plumbing.MemoryObject{}
+		obj.SetType(plumbing.BlobObject)
+		obj.Write([]byte(contents))
+		blob, err := object.DecodeBlob(obj)
+		assert.Nil(t, err)
+		return blob
+	}
+	blobFrom := newBlob("func main() {\nif x {\nreturn\n}\n}\n")
+	blobTo := newBlob("func main() {\n\tif x {\n\t\treturn\n\t}\n\n\tprint()\n}")
+	deps := map[string]interface{}{}
+	deps[items.DependencyBlobCache] = map[plumbing.Hash]*object.Blob{
+		blobFrom.Hash: blobFrom, blobTo.Hash: blobTo}
+	deps[items.DependencyTreeChanges] = object.Changes{&object.Change{
+		From: object.ChangeEntry{Name: "main.go", TreeEntry: object.TreeEntry{
+			Name: "main.go", Mode: 0100644, Hash: blobFrom.Hash}},
+		To: object.ChangeEntry{Name: "main.go", TreeEntry: object.TreeEntry{
+			Name: "main.go", Mode: 0100644, Hash: blobTo.Hash}},
+	}}
+	countEdits := func(diffs []diffmatchpatch.Diff) (int, int) {
+		insertions, deletions := 0, 0
+		for _, edit := range diffs {
+			switch edit.Type {
+			case diffmatchpatch.DiffInsert:
+				insertions += utf8.RuneCountInString(edit.Text)
+			case diffmatchpatch.DiffDelete:
+				deletions += utf8.RuneCountInString(edit.Text)
+			}
+		}
+		return insertions, deletions
+	}
+	fd := fixtures.FileDiff()
+	res, err := fd.Consume(deps)
+	assert.Nil(t, err)
+	diff := res[items.DependencyFileDiff].(map[string]items.FileDiffData)["main.go"]
+	insertions, deletions := countEdits(diff.Diffs)
+	assert.Equal(t, insertions, 6)
+	assert.Equal(t, deletions, 4)
+	fd.WhitespaceIgnored = true
+	res, err = fd.Consume(deps)
+	assert.Nil(t, err)
+	diff = res[items.DependencyFileDiff].(map[string]items.FileDiffData)["main.go"]
+	assert.Equal(t, diff.OldLinesOfCode, 5)
+	assert.Equal(t, diff.NewLinesOfCode, 7)
+	insertions, deletions = countEdits(diff.Diffs)
+	assert.Equal(t, insertions, 2)
+	assert.Equal(t, deletions, 0)
+}
+
 func TestCountLines(t *testing.T) {
 	blob, _ := test.Repository.BlobObject(
 		plumbing.NewHash("291286b4ac41952cbd1389fda66420ec03c1a9fe"))
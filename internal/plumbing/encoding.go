@@ -0,0 +1,101 @@
+package plumbing
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// blobEncoding is the candidate legacy encoding of the blobs, see BlobCache.Encodings.
+type blobEncoding struct {
+	name     string
+	encoding encoding.Encoding
+}
+
+// parseBlobEncodings resolves the names of the encodings, e.g. "shift_jis" or "latin1".
+// The names and the aliases are the same as in the WHATWG Encoding Standard.
+func parseBlobEncodings(names []string) ([]blobEncoding, error) {
+	encodings := make([]blobEncoding, 0, len(names))
+	for _, name := range names {
+		enc, err := htmlindex.Get(name)
+		if err != nil {
+			return nil, errors.New("unknown encoding " + name)
+		}
+		encodings = append(encodings, blobEncoding{name: name, encoding: enc})
+	}
+	return encodings, nil
+}
+
+// transcodeBlob converts the blob to UTF-8 from the first of the encodings which decodes it
+// without errors. The blobs which are already valid UTF-8, the binary blobs (with zero bytes)
+// and the blobs which no encoding fits are returned as is with the empty encoding name.
+// The transcoded blob keeps the original hash and is held in memory.
+func transcodeBlob(blob *object.Blob, encodings []blobEncoding) (*object.Blob, string, error) {
+	if len(encodings) == 0 {
+		return blob, "", nil
+	}
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil, "", err
+	}
+	defer checkClose(reader)
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, "", err
+	}
+	if utf8.Valid(data) || bytes.IndexByte(data, 0) >= 0 {
+		return blob, "", nil
+	}
+	for _, candidate := range encodings {
+		decoded, err := candidate.encoding.NewDecoder().Bytes(data)
+		// the decoders replace the invalid sequences with U+FFFD
+		if err != nil || bytes.ContainsRune(decoded, utf8.RuneError) {
+			continue
+		}
+		transcoded, err := object.DecodeBlob(transcodedObject{hash: blob.Hash, data: decoded})
+		if err != nil {
+			return nil, "", err
+		}
+		return transcoded, candidate.name, nil
+	}
+	return blob, "", nil
+}
+
+// transcodedObject is the in-memory blob with the UTF-8 contents and the hash of the original.
+type transcodedObject struct {
+	hash plumbing.Hash
+	data []byte
+}
+
+func (obj transcodedObject) Hash() plumbing.Hash {
+	return obj.hash
+}
+
+func (obj transcodedObject) Type() plumbing.ObjectType {
+	return plumbing.BlobObject
+}
+
+func (obj transcodedObject) SetType(plumbing.ObjectType) {
+}
+
+func (obj transcodedObject) Size() int64 {
+	return int64(len(obj.data))
+}
+
+func (obj transcodedObject) SetSize(int64) {
+}
+
+func (obj transcodedObject) Reader() (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader(obj.data)), nil
+}
+
+func (obj transcodedObject) Writer() (io.WriteCloser, error) {
+	return nil, errors.New("transcoded blobs are read-only")
+}
@@ -0,0 +1,79 @@
+package plumbing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/encoding/japanese"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+func fixtureEncodingBlob(contents string) *object.Blob {
+	obj := &plumbing.MemoryObject{}
+	obj.SetType(plumbing.BlobObject)
+	obj.Write([]byte(contents))
+	blob, err := object.DecodeBlob(obj)
+	if err != nil {
+		panic(err)
+	}
+	return blob
+}
+
+func TestParseBlobEncodings(t *testing.T) {
+	encodings, err := parseBlobEncodings([]string{"shift_jis", "latin1", "EUC-KR"})
+	assert.Nil(t, err)
+	assert.Len(t, encodings, 3)
+	assert.Equal(t, encodings[1].name, "latin1")
+	encodings, err = parseBlobEncodings(nil)
+	assert.Nil(t, err)
+	assert.Len(t, encodings, 0)
+	_, err = parseBlobEncodings([]string{"shift_jis", "wat"})
+	assert.NotNil(t, err)
+}
+
+func TestTranscodeBlob(t *testing.T) {
+	encodings, err := parseBlobEncodings([]string{"shift_jis", "latin1"})
+	assert.Nil(t, err)
+	sjis, err := japanese.ShiftJIS.NewEncoder().String("// こんにちは\nfunc main() {}\n")
+	assert.Nil(t, err)
+	blob := fixtureEncodingBlob(sjis)
+	lines, err := CountLines(blob)
+	assert.Equal(t, lines, -1)
+	assert.NotNil(t, err)
+	transcoded, name, err := transcodeBlob(blob, encodings)
+	assert.Nil(t, err)
+	assert.Equal(t, name, "shift_jis")
+	assert.Equal(t, transcoded.Hash, blob.Hash)
+	contents, err := BlobToString(transcoded)
+	assert.Nil(t, err)
+	assert.Equal(t, contents, "// こんにちは\nfunc main() {}\n")
+	assert.Equal(t, transcoded.Size, int64(len(contents)))
+	lines, err = CountLines(transcoded)
+	assert.Nil(t, err)
+	assert.Equal(t, lines, 2)
+
+	// not Shift-JIS
+	blob = fixtureEncodingBlob("caf\xe9\n")
+	transcoded, name, err = transcodeBlob(blob, encodings)
+	assert.Nil(t, err)
+	assert.Equal(t, name, "latin1")
+	contents, _ = BlobToString(transcoded)
+	assert.Equal(t, contents, "café\n")
+
+	for _, contents := range []string{"café\n", "caf\xe9\x00\n"} {
+		blob = fixtureEncodingBlob(contents)
+		transcoded, name, err = transcodeBlob(blob, encodings)
+		assert.Nil(t, err)
+		assert.Equal(t, name, "")
+		assert.True(t, transcoded == blob)
+	}
+	blob = fixtureEncodingBlob("caf\xe9\n")
+	transcoded, name, err = transcodeBlob(blob, encodings[:1])
+	assert.Nil(t, err)
+	assert.Equal(t, name, "")
+	assert.True(t, transcoded == blob)
+	transcoded, name, err = transcodeBlob(blob, nil)
+	assert.Nil(t, err)
+	assert.True(t, transcoded == blob)
+}
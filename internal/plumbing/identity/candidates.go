@@ -0,0 +1,191 @@
+package identity
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// MergeCandidate is a pair of identities in Detector.ReversedPeopleDict which probably
+// belong to the same person.
+type MergeCandidate struct {
+	// First is the index of the first identity.
+	First int
+	// Second is the index of the second identity, always greater than First.
+	Second int
+	// Reason explains why the identities are similar.
+	Reason string
+}
+
+// genericEmailUsers are the email local parts which are shared by unrelated people
+// and must not be used to match the identities.
+var genericEmailUsers = map[string]bool{
+	"admin": true, "dev": true, "git": true, "info": true, "mail": true, "noreply": true,
+	"no-reply": true, "root": true, "support": true, "ubuntu": true, "user": true,
+}
+
+// reasonRanks order the reasons of MergeCandidate from the strongest to the weakest.
+var reasonRanks = map[string]int{"same name": 0, "same email user": 1, "name matches email": 2}
+
+// SplitIdentity divides the identity from Detector.ReversedPeopleDict into names and emails.
+func SplitIdentity(identity string) (names []string, emails []string) {
+	for _, part := range strings.Split(identity, "|") {
+		if part == "" {
+			continue
+		}
+		if strings.Contains(part, "@") {
+			emails = append(emails, part)
+		} else {
+			names = append(names, part)
+		}
+	}
+	return names, emails
+}
+
+// normalizeName turns "Smith, John  A." into "a john smith" so that the different spellings
+// of the same name are equal.
+func normalizeName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// compactName removes everything except letters and digits from the name,
+// e.g. "John Smith" becomes "johnsmith".
+func compactName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
+
+// emailUser returns the local part of the email without the "+suffix".
+func emailUser(email string) string {
+	user := strings.ToLower(email[:strings.LastIndex(email, "@")])
+	if plus := strings.Index(user, "+"); plus > 0 {
+		user = user[:plus]
+	}
+	return user
+}
+
+// FindMergeCandidates proposes the pairs of identities which are likely to be the same person:
+// the names which are equal up to the case, punctuation and the order of words, the emails with
+// the same user part and the names which are spelled in the other identity's email.
+// The identities are the items of Detector.ReversedPeopleDict.
+func FindMergeCandidates(identities []string) []MergeCandidate {
+	type key struct {
+		kind  string
+		value string
+	}
+	index := map[key][]int{}
+	add := func(k key, i int) {
+		if k.value == "" {
+			return
+		}
+		members := index[k]
+		if len(members) > 0 && members[len(members)-1] == i {
+			return
+		}
+		index[k] = append(members, i)
+	}
+	for i, identity := range identities {
+		names, emails := SplitIdentity(identity)
+		for _, name := range names {
+			add(key{"same name", normalizeName(name)}, i)
+			if compact := compactName(name); len(compact) >= 5 {
+				add(key{"name matches email", compact}, i)
+			}
+		}
+		for _, email := range emails {
+			user := emailUser(email)
+			if len(user) >= 3 && !genericEmailUsers[user] {
+				add(key{"same email user", user}, i)
+				add(key{"name matches email", compactName(user)}, i)
+			}
+		}
+	}
+	reasons := map[[2]int]string{}
+	for k, members := range index {
+		for a := 0; a < len(members); a++ {
+			for b := a + 1; b < len(members); b++ {
+				pair := [2]int{members[a], members[b]}
+				if pair[0] > pair[1] {
+					pair[0], pair[1] = pair[1], pair[0]
+				}
+				if existing, exists := reasons[pair]; !exists || reasonRanks[k.kind] < reasonRanks[existing] {
+					reasons[pair] = k.kind
+				}
+			}
+		}
+	}
+	candidates := make([]MergeCandidate, 0, len(reasons))
+	for pair, reason := range reasons {
+		candidates = append(candidates, MergeCandidate{First: pair[0], Second: pair[1], Reason: reason})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].First != candidates[j].First {
+			return candidates[i].First < candidates[j].First
+		}
+		return candidates[i].Second < candidates[j].Second
+	})
+	return candidates
+}
+
+// MergeIdentities joins the identities according to the accepted pairs of indices and returns
+// the lines of the people dictionary in the format of Detector.LoadPeopleDict(). Each line
+// starts with the first name of the earliest identity in the group.
+func MergeIdentities(identities []string, merges [][2]int) []string {
+	parents := make([]int, len(identities))
+	for i := range parents {
+		parents[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+	for _, pair := range merges {
+		a, b := find(pair[0]), find(pair[1])
+		if a > b {
+			a, b = b, a
+		}
+		parents[b] = a
+	}
+	groups := map[int][]int{}
+	for i := range identities {
+		root := find(i)
+		groups[root] = append(groups[root], i)
+	}
+	var lines []string
+	for i := range identities {
+		members, exists := groups[i]
+		if !exists {
+			continue
+		}
+		var names, emails []string
+		seen := map[string]bool{}
+		for _, member := range members {
+			memberNames, memberEmails := SplitIdentity(identities[member])
+			for _, name := range memberNames {
+				if !seen[name] {
+					seen[name] = true
+					names = append(names, name)
+				}
+			}
+			for _, email := range memberEmails {
+				if !seen[email] {
+					seen[email] = true
+					emails = append(emails, email)
+				}
+			}
+		}
+		lines = append(lines, strings.Join(append(names, emails...), "|"))
+	}
+	return lines
+}
@@ -0,0 +1,52 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitIdentity(t *testing.T) {
+	names, emails := SplitIdentity("john smith|smith john|john@example.com")
+	assert.Equal(t, names, []string{"john smith", "smith john"})
+	assert.Equal(t, emails, []string{"john@example.com"})
+	names, emails = SplitIdentity("")
+	assert.Nil(t, names)
+	assert.Nil(t, emails)
+}
+
+func TestFindMergeCandidates(t *testing.T) {
+	identities := []string{
+		"john smith|john@example.com",
+		"smith, john|jsmith@work.com",
+		"vadim|vadim@sourced.tech",
+		"vadim markovtsev|vadim+github@gmail.com",
+		"alice|root@box1",
+		"bob|root@box2",
+		"j|johnsmith@mail.org",
+	}
+	candidates := FindMergeCandidates(identities)
+	assert.Equal(t, candidates, []MergeCandidate{
+		{First: 0, Second: 1, Reason: "same name"},
+		{First: 0, Second: 6, Reason: "name matches email"},
+		{First: 2, Second: 3, Reason: "same email user"},
+	})
+	assert.Len(t, FindMergeCandidates(nil), 0)
+}
+
+func TestMergeIdentities(t *testing.T) {
+	identities := []string{
+		"john smith|john@example.com",
+		"vadim|vadim@sourced.tech",
+		"smith, john|john@example.com|jsmith@work.com",
+		"alice|alice@example.com",
+		"vadim markovtsev|vadim@gmail.com",
+	}
+	lines := MergeIdentities(identities, [][2]int{{2, 0}, {4, 1}})
+	assert.Equal(t, lines, []string{
+		"john smith|smith, john|john@example.com|jsmith@work.com",
+		"vadim|vadim markovtsev|vadim@sourced.tech|vadim@gmail.com",
+		"alice|alice@example.com",
+	})
+	assert.Equal(t, MergeIdentities(identities[:2], nil), identities[:2])
+}
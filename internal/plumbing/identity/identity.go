@@ -1,8 +1,11 @@
 package identity
 
 import (
-	"bufio"
-	"os"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"sort"
 	"strings"
 
@@ -19,6 +22,9 @@ type Detector struct {
 	PeopleDict map[string]int
 	// ReversedPeopleDict maps developer id -> description
 	ReversedPeopleDict []string
+	// PeopleAttributes maps developer id -> team, company, etc. It is nil unless
+	// the people dict format carries the attributes, see LoadPeopleDictFormat().
+	PeopleAttributes []Attributes
 }
 
 const (
@@ -36,9 +42,21 @@ const (
 	// Detector.Configure(). It corresponds to Detector.ReversedPeopleDict -
 	// the mapping from the author indices to the main signature.
 	FactIdentityDetectorReversedPeopleDict = "IdentityDetector.ReversedPeopleDict"
+	// FactIdentityDetectorPeopleAttributes is the name of the fact which is inserted in
+	// Detector.Configure(). It corresponds to Detector.PeopleAttributes - the mapping
+	// from the author indices to their teams and companies. It is nil if the people dict
+	// does not have them.
+	FactIdentityDetectorPeopleAttributes = "IdentityDetector.PeopleAttributes"
 	// ConfigIdentityDetectorPeopleDictPath is the name of the configuration option
 	// (Detector.Configure()) which allows to set the external PeopleDict mapping from a file.
 	ConfigIdentityDetectorPeopleDictPath = "IdentityDetector.PeopleDictPath"
+	// ConfigIdentityDetectorPeopleDictFormat is the name of the configuration option
+	// (Detector.Configure()) which sets the format of the file with the external PeopleDict.
+	ConfigIdentityDetectorPeopleDictFormat = "IdentityDetector.PeopleDictFormat"
+	// ConfigIdentityDetectorStorePath is the name of the configuration option
+	// (Detector.Configure()) which sets the path to the identity store shared between
+	// several repositories (see Store).
+	ConfigIdentityDetectorStorePath = "IdentityDetector.StorePath"
 	// FactIdentityDetectorPeopleCount is the name of the fact which is inserted in
 	// Detector.Configure(). It is equal to the overall number of unique authors
 	// (the length of ReversedPeopleDict).
@@ -75,7 +93,20 @@ func (id *Detector) ListConfigurationOptions() []core.ConfigurationOption {
 		Description: "Path to the developers' email associations.",
 		Flag:        "people-dict",
 		Type:        core.StringConfigurationOption,
-		Default:     ""},
+		Default:     ""}, {
+		Name: ConfigIdentityDetectorPeopleDictFormat,
+		Description: "Format of the developers' email associations: \"" + PeopleDictFormatText +
+			"\", \"" + PeopleDictFormatGitdm + "\", \"" + PeopleDictFormatJSON + "\" or \"" +
+			PeopleDictFormatCSV + "\". Guessed by the file name if empty.",
+		Flag:    "people-dict-format",
+		Type:    core.StringConfigurationOption,
+		Default: ""}, {
+		Name: ConfigIdentityDetectorStorePath,
+		Description: "Path to the JSON identity store which keeps the developer indices " +
+			"the same across repositories. It is created if it does not exist.",
+		Flag:    "people-store",
+		Type:    core.StringConfigurationOption,
+		Default: ""},
 	}
 	return options[:]
 }
@@ -88,23 +119,47 @@ func (id *Detector) Configure(facts map[string]interface{}) {
 	if val, exists := facts[FactIdentityDetectorReversedPeopleDict].([]string); exists {
 		id.ReversedPeopleDict = val
 	}
+	if val, exists := facts[FactIdentityDetectorPeopleAttributes].([]Attributes); exists {
+		id.PeopleAttributes = val
+	}
 	if id.PeopleDict == nil || id.ReversedPeopleDict == nil {
+		loaded := false
 		peopleDictPath, _ := facts[ConfigIdentityDetectorPeopleDictPath].(string)
 		if peopleDictPath != "" {
-			id.LoadPeopleDict(peopleDictPath)
-			facts[FactIdentityDetectorPeopleCount] = len(id.ReversedPeopleDict) - 1
-		} else {
+			peopleDictFormat, _ := facts[ConfigIdentityDetectorPeopleDictFormat].(string)
+			if err := id.LoadPeopleDictFormat(peopleDictPath, peopleDictFormat); err != nil {
+				log.Printf("Warning: failed to load the people dict %s, the identities will be "+
+					"generated from the commits: %v\n", peopleDictPath, err)
+			} else {
+				loaded = true
+				facts[FactIdentityDetectorPeopleCount] = len(id.ReversedPeopleDict) - 1
+			}
+		}
+		if !loaded {
 			if _, exists := facts[core.ConfigPipelineCommits]; !exists {
 				panic("IdentityDetector needs a list of commits to initialize.")
 			}
 			id.GeneratePeopleDict(facts[core.ConfigPipelineCommits].([]*object.Commit))
 			facts[FactIdentityDetectorPeopleCount] = len(id.ReversedPeopleDict)
 		}
+		if storePath, _ := facts[ConfigIdentityDetectorStorePath].(string); storePath != "" {
+			// the local identities are not compatible with the other repositories in the store
+			if err := id.ApplyStore(storePath); err != nil {
+				panic(fmt.Sprintf("IdentityDetector failed to apply the identity store %s: %v",
+					storePath, err))
+			}
+			count := len(id.ReversedPeopleDict)
+			if count > 0 && id.ReversedPeopleDict[count-1] == AuthorMissingName {
+				count--
+			}
+			facts[FactIdentityDetectorPeopleCount] = count
+		}
 	} else {
 		facts[FactIdentityDetectorPeopleCount] = len(id.ReversedPeopleDict)
 	}
 	facts[FactIdentityDetectorPeopleDict] = id.PeopleDict
 	facts[FactIdentityDetectorReversedPeopleDict] = id.ReversedPeopleDict
+	facts[FactIdentityDetectorPeopleAttributes] = id.PeopleAttributes
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
@@ -130,33 +185,39 @@ func (id *Detector) Consume(deps map[string]interface{}) (map[string]interface{}
 	return map[string]interface{}{DependencyAuthor: authorID}, nil
 }
 
-// LoadPeopleDict loads author signatures from a text file.
-// The format is one signature per line, and the signature consists of several
-// keys separated by "|". The first key is the main one and used to reference all the rest.
-func (id *Detector) LoadPeopleDict(path string) error {
-	file, err := os.Open(path)
-	if err != nil {
+// DumpState writes ReversedPeopleDict, see core.PersistentPipelineItem. The author indices
+// in the states of the other items are only valid with the same identities.
+func (id *Detector) DumpState(writer io.Writer) error {
+	return gob.NewEncoder(writer).Encode(id.ReversedPeopleDict)
+}
+
+// LoadState checks that the identities have not changed since DumpState(). The automatically
+// detected identities change with the new authors, so the people dict should be loaded from
+// a file or from the identity store in the incremental mode.
+func (id *Detector) LoadState(reader io.Reader) error {
+	var reversedPeopleDict []string
+	if err := gob.NewDecoder(reader).Decode(&reversedPeopleDict); err != nil {
 		return err
 	}
-	defer file.Close()
-	scanner := bufio.NewScanner(file)
-	dict := make(map[string]int)
-	reverseDict := []string{}
-	size := 0
-	for scanner.Scan() {
-		ids := strings.Split(scanner.Text(), "|")
-		for _, id := range ids {
-			dict[strings.ToLower(id)] = size
+	changed := errors.New("the identities have changed since the state was saved")
+	if len(reversedPeopleDict) != len(id.ReversedPeopleDict) {
+		return changed
+	}
+	for i, name := range reversedPeopleDict {
+		if id.ReversedPeopleDict[i] != name {
+			return changed
 		}
-		reverseDict = append(reverseDict, ids[0])
-		size++
 	}
-	reverseDict = append(reverseDict, AuthorMissingName)
-	id.PeopleDict = dict
-	id.ReversedPeopleDict = reverseDict
 	return nil
 }
 
+// LoadPeopleDict loads author signatures from a text file.
+// The format is one signature per line, and the signature consists of several
+// keys separated by "|". The first key is the main one and used to reference all the rest.
+func (id *Detector) LoadPeopleDict(path string) error {
+	return id.LoadPeopleDictFormat(path, PeopleDictFormatText)
+}
+
 // GeneratePeopleDict loads author signatures from the specified list of Git commits.
 func (id *Detector) GeneratePeopleDict(commits []*object.Commit) {
 	dict := map[string]int{}
@@ -251,6 +312,7 @@ func (id *Detector) GeneratePeopleDict(commits []*object.Commit) {
 	}
 	id.PeopleDict = dict
 	id.ReversedPeopleDict = reverseDict
+	id.PeopleAttributes = nil
 }
 
 // MergeReversedDicts joins two identity lists together, excluding duplicates, in-order.
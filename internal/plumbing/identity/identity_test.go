@@ -1,6 +1,7 @@
 package identity
 
 import (
+	"bytes"
 	"io"
 	"io/ioutil"
 	"os"
@@ -39,8 +40,10 @@ func TestIdentityDetectorMeta(t *testing.T) {
 	assert.Equal(t, len(id.Provides()), 1)
 	assert.Equal(t, id.Provides()[0], DependencyAuthor)
 	opts := id.ListConfigurationOptions()
-	assert.Len(t, opts, 1)
+	assert.Len(t, opts, 3)
 	assert.Equal(t, opts[0].Name, ConfigIdentityDetectorPeopleDictPath)
+	assert.Equal(t, opts[1].Name, ConfigIdentityDetectorPeopleDictFormat)
+	assert.Equal(t, opts[2].Name, ConfigIdentityDetectorStorePath)
 }
 
 func TestIdentityDetectorConfigure(t *testing.T) {
@@ -145,6 +148,20 @@ func TestIdentityDetectorConsume(t *testing.T) {
 	assert.Equal(t, res[DependencyAuthor].(int), AuthorMissing)
 }
 
+func TestIdentityDetectorDumpLoad(t *testing.T) {
+	id := fixtureIdentityDetector()
+	state := &bytes.Buffer{}
+	assert.Nil(t, id.DumpState(state))
+	data := state.Bytes()
+	assert.Nil(t, fixtureIdentityDetector().LoadState(bytes.NewReader(data)))
+	changed := fixtureIdentityDetector()
+	changed.ReversedPeopleDict = []string{"Vadim", "Máximo"}
+	assert.NotNil(t, changed.LoadState(bytes.NewReader(data)))
+	changed.ReversedPeopleDict = []string{"Máximo"}
+	assert.NotNil(t, changed.LoadState(bytes.NewReader(data)))
+	assert.NotNil(t, id.LoadState(bytes.NewBufferString("garbage")))
+}
+
 func TestIdentityDetectorLoadPeopleDict(t *testing.T) {
 	id := fixtureIdentityDetector()
 	err := id.LoadPeopleDict(path.Join("..", "..", "test_data", "identities"))
@@ -0,0 +1,233 @@
+package identity
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Attributes are the properties of a developer which some people dict formats carry
+// besides the signatures.
+type Attributes struct {
+	// Team is the name of the developer's team.
+	Team string `json:"team"`
+	// Company is the name of the developer's employer.
+	Company string `json:"company"`
+}
+
+const (
+	// PeopleDictFormatText is the native people dict format: one developer per line,
+	// the signatures are separated by "|" and the first one is the main.
+	PeopleDictFormatText = "text"
+	// PeopleDictFormatGitdm is the format of gitdm's "aliases" files: each line is
+	// an alias email followed by the canonical email, separated by whitespace.
+	// The lines which start with "#" are comments.
+	PeopleDictFormatGitdm = "gitdm"
+	// PeopleDictFormatJSON is a JSON list of objects with the "name", "emails", "aliases",
+	// "team" and "company" fields.
+	PeopleDictFormatJSON = "json"
+	// PeopleDictFormatCSV is a CSV table with the header. The recognized columns are "name",
+	// "email", "team" and "company"; the rows with the same name belong to the same developer.
+	PeopleDictFormatCSV = "csv"
+)
+
+// peopleDictEntry is a single developer in a people dict.
+type peopleDictEntry struct {
+	// Keys are the names and the emails; the first one is the main.
+	Keys []string
+	// Attributes are the developer's properties.
+	Attributes Attributes
+}
+
+// DetectPeopleDictFormat guesses the people dict format by the file name.
+func DetectPeopleDictFormat(path string) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	switch {
+	case ext == ".json":
+		return PeopleDictFormatJSON
+	case ext == ".csv":
+		return PeopleDictFormatCSV
+	case ext == ".aliases" || strings.ToLower(filepath.Base(path)) == "aliases":
+		return PeopleDictFormatGitdm
+	}
+	return PeopleDictFormatText
+}
+
+// LoadPeopleDictFormat loads author signatures from a file in the specified format,
+// which is guessed by the file name if it is empty (see DetectPeopleDictFormat()).
+// All the formats except PeopleDictFormatText fill PeopleAttributes.
+func (id *Detector) LoadPeopleDictFormat(path string, format string) error {
+	if format == "" {
+		format = DetectPeopleDictFormat(path)
+	}
+	var read func(io.Reader) ([]peopleDictEntry, error)
+	switch format {
+	case PeopleDictFormatText:
+		read = readTextPeopleDict
+	case PeopleDictFormatGitdm:
+		read = readGitdmPeopleDict
+	case PeopleDictFormatJSON:
+		read = readJSONPeopleDict
+	case PeopleDictFormatCSV:
+		read = readCSVPeopleDict
+	default:
+		return fmt.Errorf("unsupported people dict format: %s", format)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	entries, err := read(file)
+	if err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	id.setPeopleDict(entries, format != PeopleDictFormatText)
+	return nil
+}
+
+// setPeopleDict fills PeopleDict, ReversedPeopleDict and, if `attributes` is true,
+// PeopleAttributes from the parsed people dict.
+func (id *Detector) setPeopleDict(entries []peopleDictEntry, attributes bool) {
+	dict := map[string]int{}
+	reverseDict := make([]string, 0, len(entries)+1)
+	var peopleAttributes []Attributes
+	if attributes {
+		peopleAttributes = make([]Attributes, 0, len(entries)+1)
+	}
+	for i, entry := range entries {
+		for _, key := range entry.Keys {
+			dict[strings.ToLower(key)] = i
+		}
+		reverseDict = append(reverseDict, entry.Keys[0])
+		if attributes {
+			peopleAttributes = append(peopleAttributes, entry.Attributes)
+		}
+	}
+	reverseDict = append(reverseDict, AuthorMissingName)
+	if attributes {
+		peopleAttributes = append(peopleAttributes, Attributes{})
+	}
+	id.PeopleDict = dict
+	id.ReversedPeopleDict = reverseDict
+	id.PeopleAttributes = peopleAttributes
+}
+
+func readTextPeopleDict(reader io.Reader) ([]peopleDictEntry, error) {
+	entries := []peopleDictEntry{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		entries = append(entries, peopleDictEntry{Keys: strings.Split(scanner.Text(), "|")})
+	}
+	return entries, scanner.Err()
+}
+
+func readGitdmPeopleDict(reader io.Reader) ([]peopleDictEntry, error) {
+	entries := []peopleDictEntry{}
+	index := map[string]int{}
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected \"alias canonical\", got %q", line, text)
+		}
+		alias, canonical := fields[0], fields[1]
+		i, exists := index[strings.ToLower(canonical)]
+		if !exists {
+			i = len(entries)
+			index[strings.ToLower(canonical)] = i
+			entries = append(entries, peopleDictEntry{Keys: []string{canonical}})
+		}
+		entries[i].Keys = append(entries[i].Keys, alias)
+	}
+	return entries, scanner.Err()
+}
+
+func readJSONPeopleDict(reader io.Reader) ([]peopleDictEntry, error) {
+	var people []struct {
+		Name    string   `json:"name"`
+		Emails  []string `json:"emails"`
+		Aliases []string `json:"aliases"`
+		Attributes
+	}
+	if err := json.NewDecoder(reader).Decode(&people); err != nil {
+		return nil, err
+	}
+	entries := make([]peopleDictEntry, 0, len(people))
+	for i, person := range people {
+		keys := []string{}
+		if person.Name != "" {
+			keys = append(keys, person.Name)
+		}
+		keys = append(keys, person.Emails...)
+		keys = append(keys, person.Aliases...)
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("person #%d has neither a name nor emails", i)
+		}
+		entries = append(entries, peopleDictEntry{Keys: keys, Attributes: person.Attributes})
+	}
+	return entries, nil
+}
+
+func readCSVPeopleDict(reader io.Reader) ([]peopleDictEntry, error) {
+	records, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return []peopleDictEntry{}, nil
+	}
+	columns := map[string]int{}
+	for i, column := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	_, hasName := columns["name"]
+	_, hasEmail := columns["email"]
+	if !hasName && !hasEmail {
+		return nil, fmt.Errorf("the header must contain \"name\" or \"email\"")
+	}
+	cell := func(record []string, column string) string {
+		if i, exists := columns[column]; exists && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	entries := []peopleDictEntry{}
+	index := map[string]int{}
+	for _, record := range records[1:] {
+		name, email := cell(record, "name"), cell(record, "email")
+		key := name
+		if key == "" {
+			key = email
+		}
+		if key == "" {
+			continue
+		}
+		i, exists := index[strings.ToLower(key)]
+		if !exists {
+			i = len(entries)
+			index[strings.ToLower(key)] = i
+			entries = append(entries, peopleDictEntry{Keys: []string{key}})
+		}
+		entry := &entries[i]
+		if email != "" && email != key {
+			entry.Keys = append(entry.Keys, email)
+		}
+		if entry.Attributes.Team == "" {
+			entry.Attributes.Team = cell(record, "team")
+		}
+		if entry.Attributes.Company == "" {
+			entry.Attributes.Company = cell(record, "company")
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,124 @@
+package identity
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectPeopleDictFormat(t *testing.T) {
+	assert.Equal(t, DetectPeopleDictFormat("people.json"), PeopleDictFormatJSON)
+	assert.Equal(t, DetectPeopleDictFormat("/tmp/People.CSV"), PeopleDictFormatCSV)
+	assert.Equal(t, DetectPeopleDictFormat("gitdm/aliases"), PeopleDictFormatGitdm)
+	assert.Equal(t, DetectPeopleDictFormat("linux.aliases"), PeopleDictFormatGitdm)
+	assert.Equal(t, DetectPeopleDictFormat("identities"), PeopleDictFormatText)
+	assert.Equal(t, DetectPeopleDictFormat("people.txt"), PeopleDictFormatText)
+}
+
+func TestIdentityDetectorLoadPeopleDictJSON(t *testing.T) {
+	id := Detector{}
+	err := id.LoadPeopleDictFormat(path.Join("..", "..", "test_data", "identities.json"), "")
+	assert.Nil(t, err)
+	assert.Len(t, id.PeopleDict, 8)
+	assert.Equal(t, id.PeopleDict["another@one.com"], 1)
+	assert.Equal(t, id.PeopleDict["mcuadros"], 2)
+	assert.Equal(t, id.ReversedPeopleDict, []string{
+		"Linus Torvalds", "Vadim Markovtsev", "Máximo Cuadros", AuthorMissingName})
+	assert.Equal(t, id.PeopleAttributes, []Attributes{
+		{Company: "Linux Foundation"}, {Team: "ML", Company: "source{d}"},
+		{Team: "Engine", Company: "source{d}"}, {}})
+}
+
+func TestIdentityDetectorLoadPeopleDictCSV(t *testing.T) {
+	id := Detector{}
+	err := id.LoadPeopleDictFormat(path.Join("..", "..", "test_data", "identities.csv"), "")
+	assert.Nil(t, err)
+	assert.Len(t, id.PeopleDict, 7)
+	assert.Equal(t, id.PeopleDict["vadim markovtsev"], 1)
+	assert.Equal(t, id.PeopleDict["another@one.com"], 1)
+	assert.Equal(t, id.PeopleDict["maximo@sourced.tech"], 2)
+	assert.Equal(t, id.ReversedPeopleDict, []string{
+		"Linus Torvalds", "Vadim Markovtsev", "Máximo Cuadros", AuthorMissingName})
+	assert.Equal(t, id.PeopleAttributes, []Attributes{
+		{Company: "Linux Foundation"}, {Team: "ML", Company: "source{d}"},
+		{Team: "Engine", Company: "source{d}"}, {}})
+}
+
+func TestIdentityDetectorLoadPeopleDictGitdm(t *testing.T) {
+	id := Detector{}
+	err := id.LoadPeopleDictFormat(path.Join("..", "..", "test_data", "identities.aliases"), "")
+	assert.Nil(t, err)
+	assert.Equal(t, id.PeopleDict, map[string]int{
+		"torvalds@linux-foundation.org": 0, "torvalds@osdl.org": 0,
+		"vadim@sourced.tech": 1, "another@one.com": 1, "gmarkhor@gmail.com": 1})
+	assert.Equal(t, id.ReversedPeopleDict, []string{
+		"torvalds@linux-foundation.org", "vadim@sourced.tech", AuthorMissingName})
+	assert.Equal(t, id.PeopleAttributes, []Attributes{{}, {}, {}})
+}
+
+func TestIdentityDetectorLoadPeopleDictFormatErrors(t *testing.T) {
+	id := Detector{}
+	err := id.LoadPeopleDictFormat(path.Join("..", "..", "test_data", "identities"), "xml")
+	assert.NotNil(t, err)
+	dir, err := ioutil.TempDir("", "hercules-test-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	for name, contents := range map[string]string{
+		"broken.json":    `[{"name": 1}]`,
+		"empty.json":     `[{"team": "ML"}]`,
+		"broken.csv":     "team,company\nML,source{d}\n",
+		"broken.aliases": "one two three\n",
+	} {
+		tmpf := filepath.Join(dir, name)
+		assert.Nil(t, ioutil.WriteFile(tmpf, []byte(contents), 0666))
+		err = id.LoadPeopleDictFormat(tmpf, "")
+		assert.NotNil(t, err, name)
+		assert.True(t, strings.HasPrefix(err.Error(), tmpf), name)
+	}
+}
+
+func TestIdentityDetectorConfigurePeopleDictFormat(t *testing.T) {
+	id := Detector{}
+	facts := map[string]interface{}{
+		ConfigIdentityDetectorPeopleDictPath:   path.Join("..", "..", "test_data", "identities.csv"),
+		ConfigIdentityDetectorPeopleDictFormat: PeopleDictFormatCSV,
+	}
+	id.Configure(facts)
+	assert.Equal(t, facts[FactIdentityDetectorPeopleCount], 3)
+	assert.Equal(t, facts[FactIdentityDetectorPeopleAttributes], id.PeopleAttributes)
+	assert.Len(t, id.PeopleAttributes, 4)
+	id = Detector{}
+	facts = map[string]interface{}{
+		ConfigIdentityDetectorPeopleDictPath:   path.Join("..", "..", "test_data", "identities.csv"),
+		ConfigIdentityDetectorPeopleDictFormat: "xml",
+	}
+	assert.Panics(t, func() { id.Configure(facts) })
+	id = Detector{}
+	facts = map[string]interface{}{
+		ConfigIdentityDetectorPeopleDictPath: path.Join("..", "..", "test_data", "identities"),
+	}
+	id.Configure(facts)
+	assert.Nil(t, facts[FactIdentityDetectorPeopleAttributes])
+}
+
+func TestIdentityDetectorApplyStoreAttributes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-test-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	storePath := filepath.Join(dir, "people.json")
+	assert.Nil(t, ioutil.WriteFile(storePath,
+		[]byte(`{"identities": [["egor"], ["vadim markovtsev"]]}`), 0666))
+	id := Detector{}
+	assert.Nil(t, id.LoadPeopleDictFormat(path.Join("..", "..", "test_data", "identities.csv"), ""))
+	assert.Nil(t, id.ApplyStore(storePath))
+	assert.Equal(t, id.ReversedPeopleDict, []string{
+		"egor", "vadim markovtsev", "linus torvalds", "máximo cuadros", AuthorMissingName})
+	assert.Equal(t, id.PeopleAttributes, []Attributes{
+		{}, {Team: "ML", Company: "source{d}"}, {Company: "Linux Foundation"},
+		{Team: "Engine", Company: "source{d}"}, {}})
+}
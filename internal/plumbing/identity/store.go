@@ -0,0 +1,200 @@
+package identity
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Store is the persistent list of developer identities which is shared by several
+// repositories, so that the same person has the same index in all of them and the people
+// matrices can be combined. It is a JSON file with the lists of the lower case names and emails;
+// the position in the list is the person's index and the first key is the name which is
+// written to the results. JSON is the only supported backend.
+// ApplyStore() holds the advisory lock of the store (see LockStore()) while it reads, merges
+// and saves the identities, so the concurrent runs which share the store do not lose
+// each other's people.
+type Store struct {
+	// Identities are the keys of each person.
+	Identities [][]string `json:"identities"`
+
+	path  string
+	index map[string]int
+}
+
+const (
+	// storeLockPoll is the interval between the attempts to take the lock of the store.
+	storeLockPoll = 50 * time.Millisecond
+	// storeLockStale is the age of the lock file after which it is considered left
+	// by a crashed run and removed. The lock is normally held for milliseconds.
+	storeLockStale = 30 * time.Second
+)
+
+// LockStore takes the advisory lock of the identity store at `path` and returns the function
+// which releases it. The lock is the file `path`.lock which is created exclusively, so it works
+// on every platform and on the network filesystems. LockStore() waits while another process
+// holds the lock; a lock file older than storeLockStale is broken.
+func LockStore(path string) (func(), error) {
+	lockPath := path + ".lock"
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0666)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > storeLockStale {
+			os.Remove(lockPath)
+			continue
+		}
+		time.Sleep(storeLockPoll)
+	}
+}
+
+// OpenStore reads the identity store from the file. The store is empty if the file
+// does not exist yet.
+func OpenStore(path string) (*Store, error) {
+	store := &Store{path: path, index: map[string]int{}}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, store); err != nil {
+		return nil, err
+	}
+	for i, keys := range store.Identities {
+		for _, key := range keys {
+			if _, exists := store.index[key]; !exists {
+				store.index[key] = i
+			}
+		}
+	}
+	return store, nil
+}
+
+// Resolve returns the index of the person who owns any of the keys. The keys which
+// the store does not know are attached to that person. If none of the keys is known,
+// a new person is appended.
+func (store *Store) Resolve(keys []string) int {
+	person := -1
+	for _, key := range keys {
+		if id, exists := store.index[strings.ToLower(key)]; exists && (person < 0 || id < person) {
+			person = id
+		}
+	}
+	if person < 0 {
+		person = len(store.Identities)
+		store.Identities = append(store.Identities, nil)
+	}
+	for _, key := range keys {
+		key = strings.ToLower(key)
+		if _, exists := store.index[key]; !exists {
+			store.index[key] = person
+			store.Identities[person] = append(store.Identities[person], key)
+		}
+	}
+	return person
+}
+
+// ReversedPeopleDict returns the names of all the people in the store in the format
+// of Detector.ReversedPeopleDict.
+func (store *Store) ReversedPeopleDict() []string {
+	names := make([]string, len(store.Identities))
+	for i, keys := range store.Identities {
+		if len(keys) > 0 {
+			names[i] = keys[0]
+		}
+	}
+	return names
+}
+
+// Save writes the store back to the file it was opened from. The file is replaced atomically.
+func (store *Store) Save() error {
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(store.path), filepath.Base(store.path)+".")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), store.path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// ApplyStore renumbers PeopleDict, ReversedPeopleDict and PeopleAttributes according to the shared identity store
+// at `path` and saves the people which were not there yet. ReversedPeopleDict becomes the list
+// of all the people in the store; the trailing AuthorMissingName is preserved.
+// The store is locked until the merged identities are saved.
+func (id *Detector) ApplyStore(path string) error {
+	unlock, err := LockStore(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	store, err := OpenStore(path)
+	if err != nil {
+		return err
+	}
+	keys := map[int][]string{}
+	for key, person := range id.PeopleDict {
+		keys[person] = append(keys[person], key)
+	}
+	persons := make([]int, 0, len(keys))
+	for person := range keys {
+		persons = append(persons, person)
+	}
+	// resolve in the order of the local indices so that the new people are appended
+	// deterministically
+	sort.Ints(persons)
+	mapping := map[int]int{}
+	for _, person := range persons {
+		names, emails := SplitIdentity(strings.Join(keys[person], "|"))
+		sort.Strings(names)
+		sort.Strings(emails)
+		mapping[person] = store.Resolve(append(names, emails...))
+	}
+	peopleDict := make(map[string]int, len(id.PeopleDict))
+	for key, person := range id.PeopleDict {
+		peopleDict[key] = mapping[person]
+	}
+	reversedPeopleDict := store.ReversedPeopleDict()
+	if len(id.ReversedPeopleDict) > 0 &&
+		id.ReversedPeopleDict[len(id.ReversedPeopleDict)-1] == AuthorMissingName {
+		reversedPeopleDict = append(reversedPeopleDict, AuthorMissingName)
+	}
+	var peopleAttributes []Attributes
+	if id.PeopleAttributes != nil {
+		peopleAttributes = make([]Attributes, len(reversedPeopleDict))
+		for person, newPerson := range mapping {
+			if person < len(id.PeopleAttributes) {
+				peopleAttributes[newPerson] = id.PeopleAttributes[person]
+			}
+		}
+	}
+	if err = store.Save(); err != nil {
+		return err
+	}
+	id.PeopleDict = peopleDict
+	id.ReversedPeopleDict = reversedPeopleDict
+	id.PeopleAttributes = peopleAttributes
+	return nil
+}
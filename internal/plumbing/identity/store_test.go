@@ -0,0 +1,149 @@
+package identity
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func TestStoreResolveSave(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-test-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "people.json")
+	store, err := OpenStore(path)
+	assert.Nil(t, err)
+	assert.Len(t, store.Identities, 0)
+	assert.Equal(t, store.Resolve([]string{"Vadim", "vadim@sourced.tech"}), 0)
+	assert.Equal(t, store.Resolve([]string{"egor", "egor@sourced.tech"}), 1)
+	assert.Equal(t, store.Resolve([]string{"gmarkhor@gmail.com", "vadim"}), 0)
+	assert.Equal(t, store.Identities, [][]string{
+		{"vadim", "vadim@sourced.tech", "gmarkhor@gmail.com"}, {"egor", "egor@sourced.tech"}})
+	assert.Equal(t, store.ReversedPeopleDict(), []string{"vadim", "egor"})
+	assert.Nil(t, store.Save())
+	store, err = OpenStore(path)
+	assert.Nil(t, err)
+	assert.Equal(t, store.Resolve([]string{"gmarkhor@gmail.com"}), 0)
+	assert.Equal(t, store.Resolve([]string{"Maxim", "max@sourced.tech"}), 2)
+	assert.Nil(t, ioutil.WriteFile(path, []byte("{"), 0666))
+	_, err = OpenStore(path)
+	assert.NotNil(t, err)
+}
+
+func TestIdentityDetectorApplyStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-test-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "people.json")
+	assert.Nil(t, ioutil.WriteFile(path,
+		[]byte(`{"identities": [["egor", "egor@sourced.tech"], ["vadim", "vadim@sourced.tech"]]}`),
+		0666))
+	id := Detector{
+		PeopleDict: map[string]int{
+			"vadim": 0, "gmarkhor@gmail.com": 0, "maxim": 1, "max@sourced.tech": 1},
+		ReversedPeopleDict: []string{"vadim|gmarkhor@gmail.com", "maxim|max@sourced.tech"},
+	}
+	assert.Nil(t, id.ApplyStore(path))
+	assert.Equal(t, id.PeopleDict, map[string]int{
+		"vadim": 1, "gmarkhor@gmail.com": 1, "maxim": 2, "max@sourced.tech": 2})
+	assert.Equal(t, id.ReversedPeopleDict, []string{"egor", "vadim", "maxim"})
+	store, err := OpenStore(path)
+	assert.Nil(t, err)
+	assert.Equal(t, store.Identities, [][]string{
+		{"egor", "egor@sourced.tech"},
+		{"vadim", "vadim@sourced.tech", "gmarkhor@gmail.com"},
+		{"maxim", "max@sourced.tech"}})
+
+	tmpf := filepath.Join(dir, "dict.txt")
+	assert.Nil(t, ioutil.WriteFile(tmpf, []byte("Egor|egor@sourced.tech\n"), 0666))
+	id = Detector{}
+	facts := map[string]interface{}{
+		ConfigIdentityDetectorPeopleDictPath: tmpf,
+		ConfigIdentityDetectorStorePath:      path,
+	}
+	id.Configure(facts)
+	assert.Equal(t, facts[FactIdentityDetectorPeopleCount], 3)
+	assert.Equal(t, id.ReversedPeopleDict, []string{"egor", "vadim", "maxim", AuthorMissingName})
+	assert.Equal(t, id.PeopleDict, map[string]int{"egor": 0, "egor@sourced.tech": 0})
+}
+
+func TestStoreLockConcurrent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-test-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "people.json")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("dev%d", i)
+			id := Detector{
+				PeopleDict:         map[string]int{name: 0},
+				ReversedPeopleDict: []string{name},
+			}
+			assert.Nil(t, id.ApplyStore(path))
+		}(i)
+	}
+	wg.Wait()
+	store, err := OpenStore(path)
+	assert.Nil(t, err)
+	assert.Len(t, store.Identities, 8)
+	_, err = os.Stat(path + ".lock")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestStoreLockStale(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-test-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "people.json")
+	assert.Nil(t, ioutil.WriteFile(path+".lock", nil, 0666))
+	past := time.Now().Add(-time.Hour)
+	assert.Nil(t, os.Chtimes(path+".lock", past, past))
+	unlock, err := LockStore(path)
+	assert.Nil(t, err)
+	info, err := os.Stat(path + ".lock")
+	assert.Nil(t, err)
+	assert.True(t, info.ModTime().After(past))
+	unlock()
+	_, err = os.Stat(path + ".lock")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestIdentityDetectorConfigureFallback(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-test-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "people.json")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("{"), 0666))
+	repository := test.NewMemoryRepository()
+	commit := test.CommitFiles(repository, time.Now(), map[string]string{"a.txt": "a\n"})
+	id := Detector{}
+	facts := map[string]interface{}{
+		ConfigIdentityDetectorPeopleDictPath: filepath.Join(dir, "missing.txt"),
+		ConfigIdentityDetectorStorePath:      path,
+		core.ConfigPipelineCommits:           []*object.Commit{commit},
+	}
+	// the broken store is fatal, otherwise the identities would not match the other repositories
+	assert.Panics(t, func() { id.Configure(facts) })
+	data, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, string(data), "{")
+	// the missing people dict is not
+	delete(facts, ConfigIdentityDetectorStorePath)
+	id = Detector{}
+	assert.NotPanics(t, func() { id.Configure(facts) })
+	assert.Equal(t, id.PeopleDict, map[string]int{"vadim": 0, "vadim@sourced.tech": 0})
+	assert.Equal(t, id.ReversedPeopleDict, []string{"vadim|vadim@sourced.tech"})
+	assert.Equal(t, facts[FactIdentityDetectorPeopleCount], 1)
+}
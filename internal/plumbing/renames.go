@@ -1,8 +1,11 @@
 package plumbing
 
 import (
+	"io"
 	"log"
+	"path"
 	"sort"
+	"strings"
 	"unicode/utf8"
 
 	"github.com/sergi/go-diff/diffmatchpatch"
@@ -16,12 +19,20 @@ import (
 
 // RenameAnalysis improves TreeDiff's results by searching for changed blobs under different
 // paths which are likely to be the result of a rename with subsequent edits.
+// The paths which differ only in case are always paired first, so that the case-only renames
+// made on case-insensitive filesystems are followed regardless of the edits.
 // RenameAnalysis is a PipelineItem.
 type RenameAnalysis struct {
 	// SimilarityThreshold adjusts the heuristic to determine file renames.
 	// It has the same units as cgit's -X rename-threshold or -M. Better to
 	// set it to the default value of 90 (90%).
 	SimilarityThreshold int
+	// Fast skips comparing the contents of the changed blobs, which is the slowest part of
+	// the rename detection, and matches the deleted and added files by their names instead.
+	Fast bool
+	// DirectoryRenames infers the renamed directories from the detected renames and pairs
+	// the rest of the deleted and added files in them by their relative paths.
+	DirectoryRenames bool
 
 	repository *git.Repository
 }
@@ -34,6 +45,15 @@ const (
 	// ConfigRenameAnalysisSimilarityThreshold is the name of the configuration option
 	// (RenameAnalysis.Configure()) which sets the similarity threshold.
 	ConfigRenameAnalysisSimilarityThreshold = "RenameAnalysis.SimilarityThreshold"
+
+	// ConfigRenameAnalysisFast is the name of the configuration option
+	// (RenameAnalysis.Configure()) which replaces the content similarity heuristic
+	// with matching the file names.
+	ConfigRenameAnalysisFast = "RenameAnalysis.Fast"
+
+	// ConfigRenameAnalysisDirectoryRenames is the name of the configuration option
+	// (RenameAnalysis.Configure()) which enables the inference of the renamed directories.
+	ConfigRenameAnalysisDirectoryRenames = "RenameAnalysis.DirectoryRenames"
 )
 
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
@@ -64,7 +84,19 @@ func (ra *RenameAnalysis) ListConfigurationOptions() []core.ConfigurationOption
 		Description: "The threshold on the similarity index used to detect renames.",
 		Flag:        "M",
 		Type:        core.IntConfigurationOption,
-		Default:     RenameAnalysisDefaultThreshold},
+		Default:     RenameAnalysisDefaultThreshold}, {
+		Name: ConfigRenameAnalysisFast,
+		Description: "Do not compare the contents of the files to detect renames; match the " +
+			"deleted and added files with the same name in the closest directories instead.",
+		Flag:    "renames-fast",
+		Type:    core.BoolConfigurationOption,
+		Default: false}, {
+		Name: ConfigRenameAnalysisDirectoryRenames,
+		Description: "Infer the renamed directories from the detected renames and pair the rest " +
+			"of the deleted and added files in them by their relative paths.",
+		Flag:    "renames-directories",
+		Type:    core.BoolConfigurationOption,
+		Default: false},
 	}
 	return options[:]
 }
@@ -74,6 +106,12 @@ func (ra *RenameAnalysis) Configure(facts map[string]interface{}) {
 	if val, exists := facts[ConfigRenameAnalysisSimilarityThreshold].(int); exists {
 		ra.SimilarityThreshold = val
 	}
+	if val, exists := facts[ConfigRenameAnalysisFast].(bool); exists {
+		ra.Fast = val
+	}
+	if val, exists := facts[ConfigRenameAnalysisDirectoryRenames].(bool); exists {
+		ra.DirectoryRenames = val
+	}
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
@@ -98,6 +136,11 @@ func (ra *RenameAnalysis) Consume(deps map[string]interface{}) (map[string]inter
 
 	reducedChanges := make(object.Changes, 0, changes.Len())
 
+	// Stage 0 - pair the case-only renames, the identical blobs elsewhere must not steal them
+	var caseRenamed object.Changes
+	caseRenamed, changes = matchCaseRenames(changes)
+	reducedChanges = append(reducedChanges, caseRenamed...)
+
 	// Stage 1 - find renames by matching the hashes
 	// n log(n)
 	// We sort additions and deletions by hash and then do the single scan along
@@ -146,7 +189,167 @@ func (ra *RenameAnalysis) Consume(deps map[string]interface{}) (map[string]inter
 		stillDeleted = append(stillDeleted, deleted[d].change)
 	}
 
-	// Stage 2 - apply the similarity threshold
+	if ra.Fast {
+		// Stage 2 - match the names instead of the contents
+		reducedChanges = append(reducedChanges, matchRenamesByName(stillAdded, stillDeleted)...)
+	} else {
+		// Stage 2 - apply the similarity threshold
+		renamed, err := ra.matchRenamesByContents(stillAdded, stillDeleted, cache)
+		if err != nil {
+			return nil, err
+		}
+		reducedChanges = append(reducedChanges, renamed...)
+	}
+
+	if ra.DirectoryRenames {
+		// Stage 3 - pair the files left in the renamed directories
+		reducedChanges = matchRenamesByDirectory(reducedChanges)
+	}
+	return map[string]interface{}{DependencyTreeChanges: reducedChanges}, nil
+}
+
+// DumpState does nothing because RenameAnalysis does not carry anything between the commits.
+// See core.PersistentPipelineItem.
+func (ra *RenameAnalysis) DumpState(writer io.Writer) error {
+	return nil
+}
+
+// LoadState does nothing, see DumpState().
+func (ra *RenameAnalysis) LoadState(reader io.Reader) error {
+	return nil
+}
+
+func (ra *RenameAnalysis) sizesAreClose(size1 int64, size2 int64) bool {
+	return internal.Abs64(size1-size2)*100/internal.Max64(1, internal.Min64(size1, size2)) <=
+		int64(100-ra.SimilarityThreshold)
+}
+
+func (ra *RenameAnalysis) blobsAreClose(
+	blob1 *object.Blob, blob2 *object.Blob) (bool, error) {
+	strFrom, err := BlobToString(blob1)
+	if err != nil {
+		return false, err
+	}
+	strTo, err := BlobToString(blob2)
+	if err != nil {
+		return false, err
+	}
+	dmp := diffmatchpatch.New()
+	src, dst, _ := dmp.DiffLinesToRunes(strFrom, strTo)
+	diffs := dmp.DiffMainRunes(src, dst, false)
+	common := 0
+	for _, edit := range diffs {
+		if edit.Type == diffmatchpatch.DiffEqual {
+			common += utf8.RuneCountInString(edit.Text)
+		}
+	}
+	similarity := common * 100 / internal.Max(1, internal.Min(len(src), len(dst)))
+	return similarity >= ra.SimilarityThreshold, nil
+}
+
+// matchCaseRenames pairs the added and deleted files whose paths are equal ignoring case,
+// provided that the folded path is unique among both the additions and the deletions.
+// It returns the renames and the rest of the changes in the same order.
+func matchCaseRenames(changes object.Changes) (renamed, rest object.Changes) {
+	added := map[string][]int{}
+	deleted := map[string][]int{}
+	for i, change := range changes {
+		if change.From.Name == "" {
+			name := strings.ToLower(change.To.Name)
+			added[name] = append(added[name], i)
+		} else if change.To.Name == "" {
+			name := strings.ToLower(change.From.Name)
+			deleted[name] = append(deleted[name], i)
+		}
+	}
+	// the index of the matched addition for each deletion
+	matches := map[int]int{}
+	matched := map[int]bool{}
+	for name, deletions := range deleted {
+		additions := added[name]
+		if len(deletions) == 1 && len(additions) == 1 {
+			matches[deletions[0]] = additions[0]
+			matched[additions[0]] = true
+		}
+	}
+	if len(matches) == 0 {
+		return nil, changes
+	}
+	renamed = make(object.Changes, 0, len(matches))
+	rest = make(object.Changes, 0, len(changes)-2*len(matches))
+	for i, change := range changes {
+		if j, exists := matches[i]; exists {
+			renamed = append(renamed, &object.Change{From: change.From, To: changes[j].To})
+		} else if !matched[i] {
+			rest = append(rest, change)
+		}
+	}
+	return renamed, rest
+}
+
+// matchRenamesByName pairs the added and deleted files which have the same base name.
+// Among several candidates, the pair with the fewest directory hops between the paths wins;
+// the ties are broken by the paths. The rest of the changes are returned unchanged.
+func matchRenamesByName(added, deleted object.Changes) object.Changes {
+	type candidate struct {
+		added, deleted int
+		distance       int
+	}
+	deletedByName := map[string][]int{}
+	for i, change := range deleted {
+		name := path.Base(change.From.Name)
+		deletedByName[name] = append(deletedByName[name], i)
+	}
+	var candidates []candidate
+	for i, change := range added {
+		for _, j := range deletedByName[path.Base(change.To.Name)] {
+			candidates = append(candidates, candidate{
+				added: i, deleted: j,
+				distance: directoryDistance(
+					path.Dir(change.To.Name), path.Dir(deleted[j].From.Name)),
+			})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		ci, cj := candidates[i], candidates[j]
+		if ci.distance != cj.distance {
+			return ci.distance < cj.distance
+		}
+		if added[ci.added].To.Name != added[cj.added].To.Name {
+			return added[ci.added].To.Name < added[cj.added].To.Name
+		}
+		return deleted[ci.deleted].From.Name < deleted[cj.deleted].From.Name
+	})
+	addedMatched := make([]bool, len(added))
+	deletedMatched := make([]bool, len(deleted))
+	result := make(object.Changes, 0, len(added)+len(deleted))
+	for _, c := range candidates {
+		if addedMatched[c.added] || deletedMatched[c.deleted] {
+			continue
+		}
+		addedMatched[c.added] = true
+		deletedMatched[c.deleted] = true
+		result = append(result, &object.Change{From: deleted[c.deleted].From, To: added[c.added].To})
+	}
+	for i, change := range added {
+		if !addedMatched[i] {
+			result = append(result, change)
+		}
+	}
+	for i, change := range deleted {
+		if !deletedMatched[i] {
+			result = append(result, change)
+		}
+	}
+	return result
+}
+
+// matchRenamesByContents pairs the added and deleted files which have blobs of close sizes and
+// enough common lines, see SimilarityThreshold. The rest of the changes are returned unchanged.
+func (ra *RenameAnalysis) matchRenamesByContents(
+	stillAdded, stillDeleted object.Changes, cache map[plumbing.Hash]*object.Blob,
+) (object.Changes, error) {
+	reducedChanges := make(object.Changes, 0, stillAdded.Len()+stillDeleted.Len())
 	// n^2 but actually linear
 	// We sort the blobs by size and do the single linear scan.
 	addedBlobs := make(sortableBlobs, 0, stillAdded.Len())
@@ -163,8 +366,8 @@ func (ra *RenameAnalysis) Consume(deps map[string]interface{}) (map[string]inter
 	}
 	sort.Sort(addedBlobs)
 	sort.Sort(deletedBlobs)
-	dStart := 0
-	for a = 0; a < addedBlobs.Len(); a++ {
+	d, dStart := 0, 0
+	for a := 0; a < addedBlobs.Len(); a++ {
 		myBlob := cache[addedBlobs[a].change.To.TreeEntry.Hash]
 		mySize := addedBlobs[a].size
 		for d = dStart; d < deletedBlobs.Len() && !ra.sizesAreClose(mySize, deletedBlobs[d].size); d++ {
@@ -193,42 +396,121 @@ func (ra *RenameAnalysis) Consume(deps map[string]interface{}) (map[string]inter
 		}
 	}
 
-	// Stage 3 - we give up, everything left are independent additions and deletions
+	// we give up, everything left are independent additions and deletions
 	for _, blob := range addedBlobs {
 		reducedChanges = append(reducedChanges, blob.change)
 	}
 	for _, blob := range deletedBlobs {
 		reducedChanges = append(reducedChanges, blob.change)
 	}
-	return map[string]interface{}{DependencyTreeChanges: reducedChanges}, nil
+	return reducedChanges, nil
 }
 
-func (ra *RenameAnalysis) sizesAreClose(size1 int64, size2 int64) bool {
-	return internal.Abs64(size1-size2)*100/internal.Max64(1, internal.Min64(size1, size2)) <=
-		int64(100-ra.SimilarityThreshold)
+// matchRenamesByDirectory infers the renamed directories from the renamed files and pairs
+// the deleted files in those directories with the added files under the same relative paths
+// in the new directories, regardless of the contents. A directory is renamed if it no longer
+// exists and most of its renamed files moved to the same directory. The nested directories
+// follow the renamed parents. The rest of the changes are returned unchanged, in the same order.
+func matchRenamesByDirectory(changes object.Changes) object.Changes {
+	var tree *object.Tree
+	var deleted []int
+	added := map[string]int{}
+	votes := map[string]map[string]int{}
+	for i, change := range changes {
+		switch {
+		case change.From.Name == "":
+			added[change.To.Name] = i
+			if tree == nil {
+				tree = change.To.Tree
+			}
+		case change.To.Name == "":
+			deleted = append(deleted, i)
+		case change.From.Name != change.To.Name:
+			// "a/pkg/x.go" -> "b/pkg/x.go" votes for both "a/pkg" -> "b/pkg" and "a" -> "b"
+			dirFrom, dirTo := path.Dir(change.From.Name), path.Dir(change.To.Name)
+			for dirFrom != "." && dirFrom != dirTo {
+				if votes[dirFrom] == nil {
+					votes[dirFrom] = map[string]int{}
+				}
+				votes[dirFrom][dirTo]++
+				if dirTo == "." || path.Base(dirFrom) != path.Base(dirTo) {
+					break
+				}
+				dirFrom, dirTo = path.Dir(dirFrom), path.Dir(dirTo)
+			}
+		}
+	}
+	if len(deleted) == 0 || tree == nil {
+		return changes
+	}
+	renamedDirs := map[string]string{}
+	for dirFrom, targets := range votes {
+		total, best, bestVotes := 0, "", 0
+		for dirTo, count := range targets {
+			total += count
+			if count > bestVotes || (count == bestVotes && dirTo < best) {
+				best, bestVotes = dirTo, count
+			}
+		}
+		if bestVotes*2 <= total {
+			continue
+		}
+		if _, err := tree.Tree(dirFrom); err != object.ErrDirectoryNotFound {
+			continue
+		}
+		renamedDirs[dirFrom] = best
+	}
+	if len(renamedDirs) == 0 {
+		return changes
+	}
+	// the index of the matched addition for each deletion
+	matches := map[int]int{}
+	matched := map[int]bool{}
+	for _, i := range deleted {
+		name := changes[i].From.Name
+		for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+			target, exists := renamedDirs[dir]
+			if !exists {
+				continue
+			}
+			if j, exists := added[path.Join(target, name[len(dir)+1:])]; exists && !matched[j] {
+				matches[i] = j
+				matched[j] = true
+			}
+			break
+		}
+	}
+	if len(matches) == 0 {
+		return changes
+	}
+	result := make(object.Changes, 0, len(changes)-len(matches))
+	for i, change := range changes {
+		if matched[i] {
+			continue
+		}
+		if j, exists := matches[i]; exists {
+			change = &object.Change{From: change.From, To: changes[j].To}
+		}
+		result = append(result, change)
+	}
+	return result
 }
 
-func (ra *RenameAnalysis) blobsAreClose(
-	blob1 *object.Blob, blob2 *object.Blob) (bool, error) {
-	strFrom, err := BlobToString(blob1)
-	if err != nil {
-		return false, err
-	}
-	strTo, err := BlobToString(blob2)
-	if err != nil {
-		return false, err
+// directoryDistance returns the number of the directory levels to go up and down to get
+// from one directory to the other.
+func directoryDistance(dir1, dir2 string) int {
+	split := func(dir string) []string {
+		if dir == "." || dir == "" {
+			return nil
+		}
+		return strings.Split(dir, "/")
 	}
-	dmp := diffmatchpatch.New()
-	src, dst, _ := dmp.DiffLinesToRunes(strFrom, strTo)
-	diffs := dmp.DiffMainRunes(src, dst, false)
+	parts1, parts2 := split(dir1), split(dir2)
 	common := 0
-	for _, edit := range diffs {
-		if edit.Type == diffmatchpatch.DiffEqual {
-			common += utf8.RuneCountInString(edit.Text)
-		}
+	for common < len(parts1) && common < len(parts2) && parts1[common] == parts2[common] {
+		common++
 	}
-	similarity := common * 100 / internal.Max(1, internal.Min(len(src), len(dst)))
-	return similarity >= ra.SimilarityThreshold, nil
+	return len(parts1) + len(parts2) - 2*common
 }
 
 type sortableChange struct {
@@ -1,11 +1,16 @@
 package plumbing
 
 import (
+	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-billy.v4/memfs"
+	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
 	"gopkg.in/src-d/hercules.v4/internal/core"
 	"gopkg.in/src-d/hercules.v4/internal/test"
 )
@@ -25,16 +30,26 @@ func TestRenameAnalysisMeta(t *testing.T) {
 	assert.Equal(t, ra.Requires()[0], DependencyBlobCache)
 	assert.Equal(t, ra.Requires()[1], DependencyTreeChanges)
 	opts := ra.ListConfigurationOptions()
-	assert.Len(t, opts, 1)
+	assert.Len(t, opts, 3)
 	assert.Equal(t, opts[0].Name, ConfigRenameAnalysisSimilarityThreshold)
+	assert.Equal(t, opts[1].Name, ConfigRenameAnalysisFast)
+	assert.Equal(t, opts[2].Name, ConfigRenameAnalysisDirectoryRenames)
 	ra.SimilarityThreshold = 0
 	facts := map[string]interface{}{}
 	facts[ConfigRenameAnalysisSimilarityThreshold] = 70
+	facts[ConfigRenameAnalysisFast] = true
+	facts[ConfigRenameAnalysisDirectoryRenames] = true
 	ra.Configure(facts)
 	assert.Equal(t, ra.SimilarityThreshold, 70)
+	assert.True(t, ra.Fast)
+	assert.True(t, ra.DirectoryRenames)
 	delete(facts, ConfigRenameAnalysisSimilarityThreshold)
+	delete(facts, ConfigRenameAnalysisFast)
+	delete(facts, ConfigRenameAnalysisDirectoryRenames)
 	ra.Configure(facts)
 	assert.Equal(t, ra.SimilarityThreshold, 70)
+	assert.True(t, ra.Fast)
+	assert.True(t, ra.DirectoryRenames)
 }
 
 func TestRenameAnalysisRegistration(t *testing.T) {
@@ -133,6 +148,210 @@ func TestRenameAnalysisConsume(t *testing.T) {
 	assert.Equal(t, len(renamed), 3)
 }
 
+func TestRenameAnalysisConsumeFast(t *testing.T) {
+	ra := RenameAnalysis{Fast: true}
+	ra.Initialize(nil)
+	entry := func(name string, hash string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: path.Base(name), Mode: 0100644, Hash: plumbing.NewHash(hash)}}
+	}
+	changes := object.Changes{
+		{From: entry("core/util.go", "1111111111111111111111111111111111111111")},
+		{To: entry("internal/core/util.go", "2222222222222222222222222222222222222222")},
+		{From: entry("README.md", "3333333333333333333333333333333333333333")},
+		{To: entry("docs/README.md", "4444444444444444444444444444444444444444")},
+		{To: entry("docs/api/README.md", "5555555555555555555555555555555555555555")},
+		{From: entry("old.go", "6666666666666666666666666666666666666666")},
+		{To: entry("new.go", "7777777777777777777777777777777777777777")},
+		{From: entry("a.go", "8888888888888888888888888888888888888888"),
+			To: entry("a.go", "9999999999999999999999999999999999999999")},
+	}
+	// the blob cache is not used
+	result, err := ra.Consume(map[string]interface{}{
+		DependencyBlobCache: map[plumbing.Hash]*object.Blob{}, DependencyTreeChanges: changes})
+	assert.Nil(t, err)
+	reduced := result[DependencyTreeChanges].(object.Changes)
+	assert.Len(t, reduced, 6)
+	assert.Equal(t, reduced[0], changes[7])
+	assert.Equal(t, reduced[1].From.Name, "README.md")
+	assert.Equal(t, reduced[1].To.Name, "docs/README.md")
+	assert.Equal(t, reduced[2].From.Name, "core/util.go")
+	assert.Equal(t, reduced[2].To.Name, "internal/core/util.go")
+	assert.Equal(t, reduced[3], changes[4])
+	assert.Equal(t, reduced[4], changes[6])
+	assert.Equal(t, reduced[5], changes[5])
+}
+
+func TestMatchCaseRenames(t *testing.T) {
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: path.Base(name), Mode: 0100644}}
+	}
+	changes := object.Changes{
+		{From: entry("README")},
+		{To: entry("src/Main.go")},
+		{From: entry("a.go"), To: entry("a.go")},
+		{From: entry("Src/main.go")},
+		{To: entry("Readme")},
+		// ambiguous
+		{From: entry("x.go")},
+		{To: entry("X.go")},
+		{To: entry("x.GO")},
+	}
+	renamed, rest := matchCaseRenames(changes)
+	assert.Len(t, renamed, 2)
+	assert.Equal(t, renamed[0].From.Name, "README")
+	assert.Equal(t, renamed[0].To.Name, "Readme")
+	assert.Equal(t, renamed[1].From.Name, "Src/main.go")
+	assert.Equal(t, renamed[1].To.Name, "src/Main.go")
+	assert.Equal(t, rest, object.Changes{changes[2], changes[5], changes[6], changes[7]})
+	renamed, rest = matchCaseRenames(rest)
+	assert.Len(t, renamed, 0)
+	assert.Len(t, rest, 4)
+}
+
+func TestRenameAnalysisConsumeCaseRenames(t *testing.T) {
+	ra := RenameAnalysis{Fast: true}
+	ra.Initialize(nil)
+	entry := func(name string, hash string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: path.Base(name), Mode: 0100644, Hash: plumbing.NewHash(hash)}}
+	}
+	// the renamed file is edited and the identical blob is added elsewhere
+	changes := object.Changes{
+		{From: entry("Foo.go", "1111111111111111111111111111111111111111")},
+		{To: entry("foo.go", "2222222222222222222222222222222222222222")},
+		{To: entry("bar/foo.go", "1111111111111111111111111111111111111111")},
+	}
+	result, err := ra.Consume(map[string]interface{}{
+		DependencyBlobCache: map[plumbing.Hash]*object.Blob{}, DependencyTreeChanges: changes})
+	assert.Nil(t, err)
+	reduced := result[DependencyTreeChanges].(object.Changes)
+	assert.Len(t, reduced, 2)
+	assert.Equal(t, reduced[0].From.Name, "Foo.go")
+	assert.Equal(t, reduced[0].To.Name, "foo.go")
+	assert.Equal(t, reduced[1], changes[2])
+}
+
+// fixtureDirectoryRenames returns the changes of the commit which moves "lib" to "src",
+// "keep/e.go" to "other" and "m/1.go", "m/2.go" to "p" and "q"; the changes of "lib/c.go",
+// "lib/sub/d.go" and "m/3.go" were too big to be detected as renames.
+func fixtureDirectoryRenames(t *testing.T) object.Changes {
+	repository, err := git.Init(memory.NewStorage(), memfs.New())
+	assert.Nil(t, err)
+	worktree, err := repository.Worktree()
+	assert.Nil(t, err)
+	for _, name := range []string{
+		"src/a.go", "src/b.go", "src/c.go", "src/sub/d.go", "keep/f.go", "other/e.go",
+		"other/g.go", "p/1.go", "p/3.go", "q/2.go", "new.go"} {
+		file, err := worktree.Filesystem.Create(name)
+		assert.Nil(t, err)
+		file.Write([]byte(name))
+		file.Close()
+		worktree.Add(name)
+	}
+	hash, err := worktree.Commit("Reorganize", &git.CommitOptions{Author: &object.Signature{
+		Name: "Vadim", Email: "vadim@sourced.tech", When: time.Now()}})
+	assert.Nil(t, err)
+	commit, err := repository.CommitObject(hash)
+	assert.Nil(t, err)
+	tree, err := commit.Tree()
+	assert.Nil(t, err)
+	entry := func(name string, tree *object.Tree) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, Tree: tree, TreeEntry: object.TreeEntry{
+			Name: path.Base(name), Mode: 0100644}}
+	}
+	renamed := func(from, to string) *object.Change {
+		return &object.Change{From: entry(from, nil), To: entry(to, tree)}
+	}
+	return object.Changes{
+		renamed("lib/a.go", "src/a.go"),
+		{From: entry("lib/c.go", nil)},
+		{To: entry("src/sub/d.go", tree)},
+		renamed("lib/b.go", "src/b.go"),
+		{From: entry("lib/sub/d.go", nil)},
+		{From: entry("lib/x.go", nil)},
+		{To: entry("src/c.go", tree)},
+		{To: entry("new.go", tree)},
+		renamed("keep/e.go", "other/e.go"),
+		{From: entry("keep/g.go", nil)},
+		{To: entry("other/g.go", tree)},
+		renamed("m/1.go", "p/1.go"),
+		renamed("m/2.go", "q/2.go"),
+		{From: entry("m/3.go", nil)},
+		{To: entry("p/3.go", tree)},
+		{From: entry("m/4.go", nil), To: entry("m/4.go", tree)},
+	}
+}
+
+func TestMatchRenamesByDirectory(t *testing.T) {
+	changes := fixtureDirectoryRenames(t)
+	reduced := matchRenamesByDirectory(changes)
+	assert.Len(t, reduced, len(changes)-2)
+	assert.Equal(t, reduced[0], changes[0])
+	assert.Equal(t, reduced[1].From.Name, "lib/c.go")
+	assert.Equal(t, reduced[1].To.Name, "src/c.go")
+	assert.Equal(t, reduced[2], changes[3])
+	assert.Equal(t, reduced[3].From.Name, "lib/sub/d.go")
+	assert.Equal(t, reduced[3].To.Name, "src/sub/d.go")
+	// the rest is the same: "keep" still exists and "m" does not have the majority target
+	assert.Equal(t, reduced[4], changes[5])
+	assert.Equal(t, reduced[5:], changes[7:])
+	// nothing is renamed
+	assert.Equal(t, matchRenamesByDirectory(changes[5:8]), changes[5:8])
+}
+
+func TestRenameAnalysisConsumeDirectoryRenames(t *testing.T) {
+	ra := RenameAnalysis{SimilarityThreshold: 80, DirectoryRenames: true}
+	ra.Initialize(nil)
+	storage := memory.NewStorage()
+	cache := map[plumbing.Hash]*object.Blob{}
+	blob := func(contents string) plumbing.Hash {
+		obj := storage.NewEncodedObject()
+		obj.SetType(plumbing.BlobObject)
+		writer, _ := obj.Writer()
+		writer.Write([]byte(contents))
+		writer.Close()
+		hash, err := storage.SetEncodedObject(obj)
+		assert.Nil(t, err)
+		cache[hash], err = object.GetBlob(storage, hash)
+		assert.Nil(t, err)
+		return hash
+	}
+	all := fixtureDirectoryRenames(t)
+	changes := object.Changes{all[4], all[2], all[0], all[5]}
+	all[0].From.TreeEntry.Hash = blob("package lib\n")
+	all[0].To.TreeEntry.Hash = all[0].From.TreeEntry.Hash
+	all[2].To.TreeEntry.Hash = blob("a\nb\nc\nd\n")
+	all[4].From.TreeEntry.Hash = blob("1\n2\n3\n")
+	all[5].From.TreeEntry.Hash = blob("x\n")
+	ra.DirectoryRenames = false
+	result, err := ra.Consume(map[string]interface{}{
+		DependencyBlobCache: cache, DependencyTreeChanges: changes})
+	assert.Nil(t, err)
+	assert.Len(t, result[DependencyTreeChanges].(object.Changes), 4)
+	ra.DirectoryRenames = true
+	result, err = ra.Consume(map[string]interface{}{
+		DependencyBlobCache: cache, DependencyTreeChanges: changes})
+	assert.Nil(t, err)
+	reduced := result[DependencyTreeChanges].(object.Changes)
+	assert.Len(t, reduced, 3)
+	assert.Equal(t, reduced[0].From.Name, "lib/a.go")
+	assert.Equal(t, reduced[0].To.Name, "src/a.go")
+	assert.Equal(t, reduced[1].From.Name, "lib/x.go")
+	assert.Equal(t, reduced[1].To.Name, "")
+	assert.Equal(t, reduced[2].From.Name, "lib/sub/d.go")
+	assert.Equal(t, reduced[2].To.Name, "src/sub/d.go")
+}
+
+func TestDirectoryDistance(t *testing.T) {
+	assert.Equal(t, directoryDistance(".", "."), 0)
+	assert.Equal(t, directoryDistance("a/b", "a/b"), 0)
+	assert.Equal(t, directoryDistance(".", "a/b"), 2)
+	assert.Equal(t, directoryDistance("a/b/c", "a/d"), 3)
+	assert.Equal(t, directoryDistance("ab", "a"), 2)
+}
+
 func TestSortableChanges(t *testing.T) {
 	changes := sortableChanges{
 		sortableChange{
@@ -1,10 +1,14 @@
 package plumbing
 
 import (
+	"encoding/gob"
 	"io"
+	"log"
 	"strings"
 
 	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
 	"gopkg.in/src-d/hercules.v4/internal/core"
 )
@@ -13,9 +17,22 @@ import (
 // under the same path: "before" and "after". If "before" is nil, the change is an addition.
 // If "after" is nil, the change is a removal. Otherwise, it is a modification.
 // TreeDiff is a PipelineItem.
+// In the branch-aware mode, the merge commits produce only the changes which do not come
+// from the merged branches, see SplitMergeChanges().
+// The symbolic links, the changes of the executable bit and the switches between the entry types
+// are handled according to the policies, so that they do not look like the changes of the contents.
 type TreeDiff struct {
-	SkipDirs     []string
+	SkipDirs []string
+	// Symlinks is the policy for the symbolic links: TreeDiffSymlinksSkip or TreeDiffSymlinksContent.
+	Symlinks string
+	// ModeChanges is the policy for the changes of the executable bit: TreeDiffModeChangesIgnore
+	// or TreeDiffModeChangesKeep.
+	ModeChanges string
+
 	previousTree *object.Tree
+	// mergedTrees are the trees of the branches which the next commit merges, see Merge().
+	mergedTrees []*object.Tree
+	repository  *git.Repository
 }
 
 const (
@@ -27,6 +44,24 @@ const (
 	// ConfigTreeDiffBlacklistedDirs s the name of the configuration option
 	// (TreeDiff.Configure()) which allows to set blacklisted directories.
 	ConfigTreeDiffBlacklistedDirs = "TreeDiff.BlacklistedDirs"
+	// ConfigTreeDiffSymlinks is the name of the configuration option (TreeDiff.Configure())
+	// which sets TreeDiff.Symlinks.
+	ConfigTreeDiffSymlinks = "TreeDiff.Symlinks"
+	// ConfigTreeDiffModeChanges is the name of the configuration option (TreeDiff.Configure())
+	// which sets TreeDiff.ModeChanges.
+	ConfigTreeDiffModeChanges = "TreeDiff.ModeChanges"
+
+	// TreeDiffSymlinksSkip excludes the symbolic links. A file which is replaced with a link
+	// is deleted and a link which is replaced with a file is inserted.
+	TreeDiffSymlinksSkip = "skip"
+	// TreeDiffSymlinksContent analyses the symbolic links as the files which contain the target
+	// paths. A file which is replaced with a link is modified.
+	TreeDiffSymlinksContent = "content"
+	// TreeDiffModeChangesIgnore drops the changes which only set or clear the executable bit.
+	TreeDiffModeChangesIgnore = "ignore"
+	// TreeDiffModeChangesKeep reports the changes of the executable bit as the modifications
+	// which do not change the contents.
+	TreeDiffModeChangesKeep = "keep"
 )
 
 var defaultBlacklistedDirs = []string{"vendor/", "vendors/", "node_modules/"}
@@ -63,7 +98,19 @@ func (treediff *TreeDiff) ListConfigurationOptions() []core.ConfigurationOption
 		Description: "List of blacklisted directories. Separated by comma \",\".",
 		Flag:        "blacklisted-dirs",
 		Type:        core.StringsConfigurationOption,
-		Default:     defaultBlacklistedDirs},
+		Default:     defaultBlacklistedDirs}, {
+		Name: ConfigTreeDiffSymlinks,
+		Description: "What to do with the symbolic links: \"" + TreeDiffSymlinksSkip +
+			"\" them or analyse their targets as the \"" + TreeDiffSymlinksContent + "\" of the files.",
+		Flag:    "symlinks",
+		Type:    core.StringConfigurationOption,
+		Default: TreeDiffSymlinksSkip}, {
+		Name: ConfigTreeDiffModeChanges,
+		Description: "What to do with the changes of the executable bit without the changes " +
+			"of the contents: \"" + TreeDiffModeChangesIgnore + "\" or \"" + TreeDiffModeChangesKeep + "\" them.",
+		Flag:    "mode-changes",
+		Type:    core.StringConfigurationOption,
+		Default: TreeDiffModeChangesIgnore},
 	}
 	return options[:]
 }
@@ -73,12 +120,38 @@ func (treediff *TreeDiff) Configure(facts map[string]interface{}) {
 	if val, exist := facts[ConfigTreeDiffEnableBlacklist]; exist && val.(bool) {
 		treediff.SkipDirs = facts[ConfigTreeDiffBlacklistedDirs].([]string)
 	}
+	if val, exists := facts[ConfigTreeDiffSymlinks].(string); exists {
+		treediff.Symlinks = val
+	}
+	if val, exists := facts[ConfigTreeDiffModeChanges].(string); exists {
+		treediff.ModeChanges = val
+	}
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (treediff *TreeDiff) Initialize(repository *git.Repository) {
 	treediff.previousTree = nil
+	treediff.mergedTrees = nil
+	treediff.repository = repository
+	switch treediff.Symlinks {
+	case "":
+		treediff.Symlinks = TreeDiffSymlinksSkip
+	case TreeDiffSymlinksSkip, TreeDiffSymlinksContent:
+	default:
+		log.Printf("Warning: unknown symlinks policy %q, adjusted to %s\n",
+			treediff.Symlinks, TreeDiffSymlinksSkip)
+		treediff.Symlinks = TreeDiffSymlinksSkip
+	}
+	switch treediff.ModeChanges {
+	case "":
+		treediff.ModeChanges = TreeDiffModeChangesIgnore
+	case TreeDiffModeChangesIgnore, TreeDiffModeChangesKeep:
+	default:
+		log.Printf("Warning: unknown mode changes policy %q, adjusted to %s\n",
+			treediff.ModeChanges, TreeDiffModeChangesIgnore)
+		treediff.ModeChanges = TreeDiffModeChangesIgnore
+	}
 }
 
 // Consume runs this PipelineItem on the next commit data.
@@ -98,6 +171,10 @@ func (treediff *TreeDiff) Consume(deps map[string]interface{}) (map[string]inter
 		if err != nil {
 			return nil, err
 		}
+		if treediff.mergedTrees != nil {
+			diff, _ = SplitMergeChanges(diff, treediff.mergedTrees)
+			treediff.mergedTrees = nil
+		}
 	} else {
 		diff = []*object.Change{}
 		err = func() error {
@@ -122,6 +199,7 @@ func (treediff *TreeDiff) Consume(deps map[string]interface{}) (map[string]inter
 		}
 	}
 	treediff.previousTree = tree
+	diff = treediff.filterTypes(diff)
 
 	if len(treediff.SkipDirs) > 0 {
 		// filter without allocation
@@ -141,6 +219,145 @@ func (treediff *TreeDiff) Consume(deps map[string]interface{}) (map[string]inter
 	return map[string]interface{}{DependencyTreeChanges: diff}, nil
 }
 
+// filterTypes applies the policies to the symbolic links and the mode changes. The switches
+// between the files, the links and the submodules lose the side which is not a file, because
+// the diff between, e.g., the contents of a file and a commit hash is meaningless. The deletion
+// of a file and the insertion of a directory under the same path are reported by DiffTree as is.
+func (treediff *TreeDiff) filterTypes(changes object.Changes) object.Changes {
+	filtered := changes[:0]
+	for _, change := range changes {
+		from, to := change.From, change.To
+		fromKind, toKind := treediff.entryKind(from), treediff.entryKind(to)
+		if fromKind == filemode.Symlink {
+			from = object.ChangeEntry{}
+		}
+		if toKind == filemode.Symlink {
+			to = object.ChangeEntry{}
+		}
+		if from.Name != "" && to.Name != "" && fromKind != toKind {
+			if fromKind != filemode.Regular {
+				from = object.ChangeEntry{}
+			}
+			if toKind != filemode.Regular {
+				to = object.ChangeEntry{}
+			}
+		}
+		if from.Name == "" && to.Name == "" {
+			continue
+		}
+		if from.Name != "" && to.Name != "" && from.TreeEntry.Hash == to.TreeEntry.Hash &&
+			from.TreeEntry.Mode != to.TreeEntry.Mode &&
+			treediff.ModeChanges == TreeDiffModeChangesIgnore {
+			continue
+		}
+		if from != change.From || to != change.To {
+			change = &object.Change{From: from, To: to}
+		}
+		filtered = append(filtered, change)
+	}
+	return filtered
+}
+
+// entryKind returns filemode.Regular for all the files and for the symbolic links which are
+// analysed as files, filemode.Symlink for the skipped links and the mode of the rest as is.
+func (treediff *TreeDiff) entryKind(entry object.ChangeEntry) filemode.FileMode {
+	switch entry.TreeEntry.Mode {
+	case filemode.Regular, filemode.Deprecated, filemode.Executable:
+		return filemode.Regular
+	case filemode.Symlink:
+		if treediff.Symlinks == TreeDiffSymlinksContent {
+			return filemode.Regular
+		}
+	}
+	return entry.TreeEntry.Mode
+}
+
+// Fork clones the item for the branches, see core.ForkablePipelineItem.
+func (treediff *TreeDiff) Fork(n int) []core.PipelineItem {
+	clones := make([]core.PipelineItem, n)
+	for i := range clones {
+		clone := *treediff
+		clones[i] = &clone
+	}
+	return clones
+}
+
+// Merge remembers the trees of the merged branches, so that the next Consume() does not
+// repeat their changes. See core.ForkablePipelineItem.
+func (treediff *TreeDiff) Merge(branches []core.PipelineItem) {
+	treediff.mergedTrees = make([]*object.Tree, len(branches))
+	for i, branch := range branches {
+		treediff.mergedTrees[i] = branch.(*TreeDiff).previousTree
+	}
+}
+
+// SplitMergeChanges divides the changes of a merge commit relative to its first parent.
+// `own` are the changes which are different from all the other parents' trees: the conflict
+// resolutions and the edits in the merge commit itself. `adopted` maps the paths of the rest
+// of the changes to the index of the first tree in `merged` which has the same version.
+// A deleted file matches a tree without it. nil trees are skipped.
+func SplitMergeChanges(changes object.Changes, merged []*object.Tree) (
+	own object.Changes, adopted map[string]int) {
+	own = object.Changes{}
+	adopted = map[string]int{}
+	for _, change := range changes {
+		name := change.To.Name
+		if name == "" {
+			name = change.From.Name
+		}
+		source := -1
+		for i, tree := range merged {
+			if tree == nil {
+				continue
+			}
+			entry, err := tree.FindEntry(name)
+			if change.To.Name == "" {
+				if err != nil {
+					source = i
+					break
+				}
+			} else if err == nil && entry.Hash == change.To.TreeEntry.Hash &&
+				entry.Mode == change.To.TreeEntry.Mode {
+				source = i
+				break
+			}
+		}
+		if source >= 0 {
+			adopted[name] = source
+		} else {
+			own = append(own, change)
+		}
+	}
+	return own, adopted
+}
+
+// DumpState writes the hash of the previous commit's tree, see core.PersistentPipelineItem.
+func (treediff *TreeDiff) DumpState(writer io.Writer) error {
+	hash := plumbing.ZeroHash
+	if treediff.previousTree != nil {
+		hash = treediff.previousTree.Hash
+	}
+	return gob.NewEncoder(writer).Encode(hash)
+}
+
+// LoadState reads the tree hash written by DumpState() and loads the tree from the repository.
+func (treediff *TreeDiff) LoadState(reader io.Reader) error {
+	var hash plumbing.Hash
+	if err := gob.NewDecoder(reader).Decode(&hash); err != nil {
+		return err
+	}
+	treediff.previousTree = nil
+	if hash == plumbing.ZeroHash {
+		return nil
+	}
+	tree, err := treediff.repository.TreeObject(hash)
+	if err != nil {
+		return err
+	}
+	treediff.previousTree = tree
+	return nil
+}
+
 func init() {
 	core.Registry.Register(&TreeDiff{})
 }
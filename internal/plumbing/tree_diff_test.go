@@ -1,10 +1,14 @@
 package plumbing
 
 import (
+	"bytes"
+	"encoding/gob"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
 	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
 	"gopkg.in/src-d/hercules.v4/internal/core"
@@ -25,7 +29,27 @@ func TestTreeDiffMeta(t *testing.T) {
 	assert.Equal(t, len(td.Provides()), 1)
 	assert.Equal(t, td.Provides()[0], DependencyTreeChanges)
 	opts := td.ListConfigurationOptions()
-	assert.Len(t, opts, 2)
+	assert.Len(t, opts, 4)
+}
+
+func TestTreeDiffConfigure(t *testing.T) {
+	td := fixtureTreeDiff()
+	assert.Equal(t, td.Symlinks, TreeDiffSymlinksSkip)
+	assert.Equal(t, td.ModeChanges, TreeDiffModeChangesIgnore)
+	td.Configure(map[string]interface{}{
+		ConfigTreeDiffSymlinks:    TreeDiffSymlinksContent,
+		ConfigTreeDiffModeChanges: TreeDiffModeChangesKeep,
+	})
+	td.Initialize(test.Repository)
+	assert.Equal(t, td.Symlinks, TreeDiffSymlinksContent)
+	assert.Equal(t, td.ModeChanges, TreeDiffModeChangesKeep)
+	td.Configure(map[string]interface{}{
+		ConfigTreeDiffSymlinks:    "follow",
+		ConfigTreeDiffModeChanges: "xxx",
+	})
+	td.Initialize(test.Repository)
+	assert.Equal(t, td.Symlinks, TreeDiffSymlinksSkip)
+	assert.Equal(t, td.ModeChanges, TreeDiffModeChangesIgnore)
 }
 
 func TestTreeDiffRegistration(t *testing.T) {
@@ -100,6 +124,25 @@ func TestTreeDiffConsumeFirst(t *testing.T) {
 	}
 }
 
+func TestTreeDiffDumpLoad(t *testing.T) {
+	td := fixtureTreeDiff()
+	state := &bytes.Buffer{}
+	assert.Nil(t, td.DumpState(state))
+	restored := fixtureTreeDiff()
+	assert.Nil(t, restored.LoadState(state))
+	assert.Nil(t, restored.previousTree)
+	prevCommit, _ := test.Repository.CommitObject(plumbing.NewHash(
+		"fbe766ffdc3f87f6affddc051c6f8b419beea6a2"))
+	td.previousTree, _ = prevCommit.Tree()
+	state.Reset()
+	assert.Nil(t, td.DumpState(state))
+	assert.Nil(t, restored.LoadState(state))
+	assert.Equal(t, restored.previousTree.Hash, td.previousTree.Hash)
+	state.Reset()
+	gob.NewEncoder(state).Encode(plumbing.NewHash("ffffffffffffffffffffffffffffffffffffffff"))
+	assert.NotNil(t, restored.LoadState(state))
+}
+
 func TestTreeDiffBadCommit(t *testing.T) {
 	td := fixtureTreeDiff()
 	commit, _ := test.Repository.CommitObject(plumbing.NewHash(
@@ -141,3 +184,141 @@ func TestTreeDiffConsumeSkip(t *testing.T) {
 	changes = res[DependencyTreeChanges].(object.Changes)
 	assert.Equal(t, 31, len(changes))
 }
+
+// fixtureTreeDiffMerge returns the commits c1, c2 and m on the main line and b1 on the branch
+// from c1 which m merges. The branch changes "b", adds "c" and deletes "e"; the merge changes
+// "a" differently from both parents and adds "d".
+func fixtureTreeDiffMerge() []*object.Commit {
+	repository := test.NewMemoryRepository()
+	when := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	c1 := test.CommitFiles(repository, when, map[string]string{"a": "1", "b": "1", "e": "1"})
+	b1 := test.CommitFiles(repository, when.AddDate(0, 0, 1),
+		map[string]string{"a": "1", "b": "2", "c": "1"}, c1.Hash)
+	c2 := test.CommitFiles(repository, when.AddDate(0, 0, 2),
+		map[string]string{"a": "2", "b": "1", "e": "1"}, c1.Hash)
+	m := test.CommitFiles(repository, when.AddDate(0, 0, 3),
+		map[string]string{"a": "3", "b": "2", "c": "1", "d": "1"}, c2.Hash, b1.Hash)
+	return []*object.Commit{c1, b1, c2, m}
+}
+
+func TestTreeDiffForkMerge(t *testing.T) {
+	commits := fixtureTreeDiffMerge()
+	td := fixtureTreeDiff()
+	consume := func(td *TreeDiff, commit *object.Commit) object.Changes {
+		res, err := td.Consume(map[string]interface{}{"commit": commit})
+		assert.Nil(t, err)
+		return res[DependencyTreeChanges].(object.Changes)
+	}
+	assert.Len(t, consume(td, commits[0]), 3)
+	branch := td.Fork(1)[0].(*TreeDiff)
+	assert.Len(t, consume(branch, commits[1]), 3)
+	assert.Len(t, consume(td, commits[2]), 1)
+	td.Merge([]core.PipelineItem{branch})
+	changes := consume(td, commits[3])
+	assert.Len(t, changes, 2)
+	assert.Equal(t, changes[0].To.Name, "a")
+	assert.Equal(t, changes[0].From.Name, "a")
+	assert.Equal(t, changes[1].To.Name, "d")
+	assert.Nil(t, td.mergedTrees)
+}
+
+func TestSplitMergeChanges(t *testing.T) {
+	commits := fixtureTreeDiffMerge()
+	trees := make([]*object.Tree, len(commits))
+	for i, commit := range commits {
+		var err error
+		trees[i], err = commit.Tree()
+		assert.Nil(t, err)
+	}
+	changes, err := object.DiffTree(trees[2], trees[3])
+	assert.Nil(t, err)
+	assert.Len(t, changes, 5)
+	own, adopted := SplitMergeChanges(changes, []*object.Tree{nil, trees[1]})
+	assert.Len(t, own, 2)
+	assert.Equal(t, adopted, map[string]int{"b": 1, "c": 1, "e": 1})
+	own, adopted = SplitMergeChanges(changes, []*object.Tree{nil})
+	assert.Equal(t, own, changes)
+	assert.Len(t, adopted, 0)
+}
+
+func TestTreeDiffFilterTypes(t *testing.T) {
+	entry := func(name string, mode filemode.FileMode, hash string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: mode, Hash: plumbing.NewHash(hash)}}
+	}
+	file := entry("a", filemode.Regular, "1111111111111111111111111111111111111111")
+	executable := entry("a", filemode.Executable, "1111111111111111111111111111111111111111")
+	edited := entry("a", filemode.Executable, "2222222222222222222222222222222222222222")
+	link := entry("a", filemode.Symlink, "3333333333333333333333333333333333333333")
+	otherLink := entry("b", filemode.Symlink, "4444444444444444444444444444444444444444")
+	submodule := entry("a", filemode.Submodule, "5555555555555555555555555555555555555555")
+	changes := func() object.Changes {
+		return object.Changes{
+			{From: file, To: executable},
+			{From: file, To: edited},
+			{From: file, To: link},
+			{From: link, To: file},
+			{To: otherLink},
+			{From: submodule, To: file},
+			{From: file, To: submodule},
+		}
+	}
+	td := fixtureTreeDiff()
+	assert.Equal(t, td.filterTypes(changes()), object.Changes{
+		{From: file, To: edited},
+		{From: file},
+		{To: file},
+		{To: file},
+		{From: file},
+	})
+	td.Symlinks = TreeDiffSymlinksContent
+	td.ModeChanges = TreeDiffModeChangesKeep
+	assert.Equal(t, td.filterTypes(changes()), object.Changes{
+		{From: file, To: executable},
+		{From: file, To: edited},
+		{From: file, To: link},
+		{From: link, To: file},
+		{To: otherLink},
+		{To: file},
+		{From: file},
+	})
+	// the links are files now
+	assert.Equal(t, td.filterTypes(object.Changes{{From: link, To: submodule}}),
+		object.Changes{{From: link}})
+}
+
+func TestTreeDiffFileToDirectory(t *testing.T) {
+	repository := test.NewMemoryRepository()
+	when := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	c1 := test.CommitFiles(repository, when, map[string]string{"a": "1", "b": "1"})
+	c2 := test.CommitFiles(repository, when.AddDate(0, 0, 1),
+		map[string]string{"a/b": "1", "b": "1"}, c1.Hash)
+	c3 := test.CommitFiles(repository, when.AddDate(0, 0, 2),
+		map[string]string{"a": "2", "b": "1"}, c2.Hash)
+	td := fixtureTreeDiff()
+	for _, commit := range []*object.Commit{c1, c2, c3} {
+		res, err := td.Consume(map[string]interface{}{"commit": commit})
+		assert.Nil(t, err)
+		changes := res[DependencyTreeChanges].(object.Changes)
+		if commit == c1 {
+			assert.Len(t, changes, 2)
+			continue
+		}
+		// the file and the directory are deleted and inserted separately
+		assert.Len(t, changes, 2)
+		var actions []merkletrie.Action
+		names := map[string]bool{}
+		for _, change := range changes {
+			action, err := change.Action()
+			assert.Nil(t, err)
+			actions = append(actions, action)
+			names[change.From.Name+">"+change.To.Name] = true
+		}
+		assert.ElementsMatch(t, actions, []merkletrie.Action{merkletrie.Delete, merkletrie.Insert})
+		if commit == c2 {
+			assert.Equal(t, names, map[string]bool{"a>": true, ">a/b": true})
+		} else {
+			assert.Equal(t, names, map[string]bool{"a/b>": true, ">a": true})
+		}
+	}
+}
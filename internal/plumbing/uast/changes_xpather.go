@@ -28,8 +28,8 @@ func (xpather ChangesXPather) Extract(changes []Change) []*uast.Node {
 		if change.After == nil {
 			continue
 		}
-		oldNodes := xpather.filter(change.Before, change.Change.From.TreeEntry.Hash)
-		newNodes := xpather.filter(change.After, change.Change.To.TreeEntry.Hash)
+		oldNodes := xpather.Filter(change.Before, change.Change.From.TreeEntry.Hash)
+		newNodes := xpather.Filter(change.After, change.Change.To.TreeEntry.Hash)
 		oldHashes := xpather.hash(oldNodes)
 		newHashes := xpather.hash(newNodes)
 		// remove any untouched nodes
@@ -44,7 +44,9 @@ func (xpather ChangesXPather) Extract(changes []Change) []*uast.Node {
 	return result
 }
 
-func (xpather ChangesXPather) filter(root *uast.Node, origin plumbing.Hash) []*uast.Node {
+// Filter returns all the nodes in the UAST which match XPath. `origin` is the hash of the blob
+// which is only used to report the errors.
+func (xpather ChangesXPather) Filter(root *uast.Node, origin plumbing.Hash) []*uast.Node {
 	if root != nil {
 		nodes, err := tools.Filter(root, xpather.XPath)
 		if err != nil {
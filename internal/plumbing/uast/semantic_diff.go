@@ -0,0 +1,594 @@
+package uast
+
+import (
+	"hash/fnv"
+	"sort"
+
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+// SemanticDiff calculates the tree edit scripts between the UASTs before and after each change.
+// The algorithm follows GumTree (Falleri et al., "Fine-grained and accurate source code
+// differencing", 2014): the identical subtrees are matched top-down, then their ancestors
+// are matched bottom-up if they share enough descendants. Unlike the line diffs, the edit
+// scripts distinguish the renames of identifiers (updates) and the reorderings (moves) from
+// the real logic changes (insertions and deletions).
+// It is a PipelineItem.
+type SemanticDiff struct {
+	// MinHeight is the minimum height of the identical subtrees which are matched top-down.
+	MinHeight int
+	// MinDice is the minimum ratio of the common descendants to match two nodes bottom-up.
+	MinDice float32
+}
+
+// EditAction is the kind of the change of a UAST node.
+type EditAction int
+
+const (
+	// EditInsert means that the node appeared.
+	EditInsert EditAction = iota
+	// EditDelete means that the node disappeared.
+	EditDelete
+	// EditUpdate means that the node's token changed, e.g. an identifier was renamed.
+	EditUpdate
+	// EditMove means that the node changed its parent or its position among the siblings.
+	EditMove
+)
+
+// Edit is a single action in EditScript.
+type Edit struct {
+	Action EditAction
+	// Before is the node in the old UAST, nil for EditInsert.
+	Before *uast.Node
+	// After is the node in the new UAST, nil for EditDelete.
+	After *uast.Node
+}
+
+// EditScript is the type of the items in the list provided by SemanticDiff.
+type EditScript struct {
+	Change *object.Change
+	// Edits are the deletions and the updates and the moves in the post-order of the old UAST
+	// followed by the insertions in the post-order of the new UAST.
+	Edits []Edit
+	// Mapping maps the nodes of the old UAST to the matched nodes of the new UAST.
+	Mapping map[*uast.Node]*uast.Node
+}
+
+const (
+	// ConfigSemanticDiffMinHeight is the name of the option to set SemanticDiff.MinHeight.
+	ConfigSemanticDiffMinHeight = "SemanticDiff.MinHeight"
+	// DefaultSemanticDiffMinHeight is the default value of SemanticDiff.MinHeight.
+	DefaultSemanticDiffMinHeight = 2
+	// DefaultSemanticDiffMinDice is the default value of SemanticDiff.MinDice.
+	DefaultSemanticDiffMinDice = 0.5
+
+	// DependencyUastEditScripts is the name of the dependency provided by SemanticDiff.
+	DependencyUastEditScripts = "uast_edit_scripts"
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (diff *SemanticDiff) Name() string {
+	return "UASTSemanticDiff"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (diff *SemanticDiff) Provides() []string {
+	arr := [...]string{DependencyUastEditScripts}
+	return arr[:]
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (diff *SemanticDiff) Requires() []string {
+	arr := [...]string{DependencyUastChanges}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (diff *SemanticDiff) Features() []string {
+	arr := [...]string{FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (diff *SemanticDiff) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigSemanticDiffMinHeight,
+		Description: "Minimum height of the identical UAST subtrees which are matched as a whole.",
+		Flag:        "semantic-diff-min-height",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultSemanticDiffMinHeight},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (diff *SemanticDiff) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigSemanticDiffMinHeight].(int); exists {
+		diff.MinHeight = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (diff *SemanticDiff) Initialize(repository *git.Repository) {
+	if diff.MinHeight <= 0 {
+		diff.MinHeight = DefaultSemanticDiffMinHeight
+	}
+	if diff.MinDice <= 0 || diff.MinDice > 1 {
+		diff.MinDice = DefaultSemanticDiffMinDice
+	}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (diff *SemanticDiff) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	changes := deps[DependencyUastChanges].([]Change)
+	scripts := make([]EditScript, 0, len(changes))
+	for _, change := range changes {
+		if change.Before == nil || change.After == nil {
+			continue
+		}
+		script := diff.Diff(change.Before, change.After)
+		script.Change = change.Change
+		scripts = append(scripts, script)
+	}
+	return map[string]interface{}{DependencyUastEditScripts: scripts}, nil
+}
+
+// Diff calculates the edit script which transforms the UAST before into the UAST after.
+func (diff *SemanticDiff) Diff(before, after *uast.Node) EditScript {
+	src, dst := newSemanticTree(before), newSemanticTree(after)
+	mapping := newSemanticMapping()
+	diff.matchTopDown(src, dst, mapping)
+	diff.matchBottomUp(src, dst, mapping)
+	return generateEditScript(src, dst, mapping)
+}
+
+// semanticNode wraps uast.Node with the information which the matching requires.
+type semanticNode struct {
+	Node     *uast.Node
+	Parent   *semanticNode
+	Children []*semanticNode
+	// Height is 1 for the leaves.
+	Height int
+	// Size is the number of nodes in the subtree including this node.
+	Size int
+	// Index is the position in the post-order traversal. The descendants are the nodes
+	// with the indexes in [Index - Size + 1, Index).
+	Index int
+	// Hash is the same for the isomorphic subtrees.
+	Hash uint64
+}
+
+// semanticTree is the UAST prepared for the matching.
+type semanticTree struct {
+	Root *semanticNode
+	// Nodes are in the post-order.
+	Nodes []*semanticNode
+}
+
+func newSemanticTree(root *uast.Node) *semanticTree {
+	tree := &semanticTree{}
+	var build func(node *uast.Node, parent *semanticNode) *semanticNode
+	build = func(node *uast.Node, parent *semanticNode) *semanticNode {
+		wrapped := &semanticNode{Node: node, Parent: parent, Height: 1, Size: 1}
+		hasher := fnv.New64a()
+		hasher.Write([]byte(node.InternalType))
+		hasher.Write([]byte{0})
+		hasher.Write([]byte(node.Token))
+		for _, child := range node.Children {
+			if child == nil {
+				continue
+			}
+			wrappedChild := build(child, wrapped)
+			wrapped.Children = append(wrapped.Children, wrappedChild)
+			if wrappedChild.Height+1 > wrapped.Height {
+				wrapped.Height = wrappedChild.Height + 1
+			}
+			wrapped.Size += wrappedChild.Size
+			var buffer [8]byte
+			for i := range buffer {
+				buffer[i] = byte(wrappedChild.Hash >> (8 * uint(i)))
+			}
+			hasher.Write(buffer[:])
+		}
+		wrapped.Hash = hasher.Sum64()
+		wrapped.Index = len(tree.Nodes)
+		tree.Nodes = append(tree.Nodes, wrapped)
+		return wrapped
+	}
+	tree.Root = build(root, nil)
+	return tree
+}
+
+// isDescendant returns true if node belongs to the subtree of root, excluding root itself.
+func (node *semanticNode) isDescendant(root *semanticNode) bool {
+	return node.Index < root.Index && node.Index > root.Index-root.Size
+}
+
+// descendants returns the nodes in the subtree excluding the root in the post-order.
+func (tree *semanticTree) descendants(root *semanticNode) []*semanticNode {
+	return tree.Nodes[root.Index-root.Size+1 : root.Index]
+}
+
+// semanticMapping is the bidirectional mapping between the nodes of two trees.
+type semanticMapping struct {
+	forward  map[*semanticNode]*semanticNode
+	backward map[*semanticNode]*semanticNode
+}
+
+func newSemanticMapping() *semanticMapping {
+	return &semanticMapping{
+		forward:  map[*semanticNode]*semanticNode{},
+		backward: map[*semanticNode]*semanticNode{},
+	}
+}
+
+func (mapping *semanticMapping) add(src, dst *semanticNode) {
+	mapping.forward[src] = dst
+	mapping.backward[dst] = src
+}
+
+// addIsomorphic maps the nodes of two isomorphic subtrees.
+func (mapping *semanticMapping) addIsomorphic(src, dst *semanticNode) {
+	mapping.add(src, dst)
+	for i, child := range src.Children {
+		mapping.addIsomorphic(child, dst.Children[i])
+	}
+}
+
+// dice is the ratio of the mapped descendants of src and dst.
+func (mapping *semanticMapping) dice(tree *semanticTree, src, dst *semanticNode) float32 {
+	if src.Size+dst.Size <= 2 {
+		return 0
+	}
+	common := 0
+	for _, node := range tree.descendants(src) {
+		if mapped := mapping.forward[node]; mapped != nil && mapped.isDescendant(dst) {
+			common++
+		}
+	}
+	return 2 * float32(common) / float32(src.Size+dst.Size-2)
+}
+
+// matchTopDown maps the identical subtrees starting from the highest ones. If a subtree has
+// several identical candidates, the pair with the most similar parents wins.
+func (diff *SemanticDiff) matchTopDown(src, dst *semanticTree, mapping *semanticMapping) {
+	srcQueue := []*semanticNode{src.Root}
+	dstQueue := []*semanticNode{dst.Root}
+	maxHeight := func(queue []*semanticNode) int {
+		height := 0
+		for _, node := range queue {
+			if node.Height > height {
+				height = node.Height
+			}
+		}
+		return height
+	}
+	// pop removes the nodes of the specified height from the queue
+	pop := func(queue []*semanticNode, height int) ([]*semanticNode, []*semanticNode) {
+		var popped, rest []*semanticNode
+		for _, node := range queue {
+			if node.Height == height {
+				popped = append(popped, node)
+			} else {
+				rest = append(rest, node)
+			}
+		}
+		return popped, rest
+	}
+	open := func(queue []*semanticNode, nodes []*semanticNode) []*semanticNode {
+		for _, node := range nodes {
+			queue = append(queue, node.Children...)
+		}
+		return queue
+	}
+	type candidate struct {
+		Src, Dst *semanticNode
+	}
+	var ambiguous []candidate
+	for {
+		srcHeight, dstHeight := maxHeight(srcQueue), maxHeight(dstQueue)
+		height := srcHeight
+		if dstHeight < height {
+			height = dstHeight
+		}
+		if height < diff.MinHeight {
+			break
+		}
+		if srcHeight != dstHeight {
+			// open the taller subtrees until the heights are equal
+			if srcHeight > dstHeight {
+				var popped []*semanticNode
+				popped, srcQueue = pop(srcQueue, srcHeight)
+				srcQueue = open(srcQueue, popped)
+			} else {
+				var popped []*semanticNode
+				popped, dstQueue = pop(dstQueue, dstHeight)
+				dstQueue = open(dstQueue, popped)
+			}
+			continue
+		}
+		var srcNodes, dstNodes []*semanticNode
+		srcNodes, srcQueue = pop(srcQueue, height)
+		dstNodes, dstQueue = pop(dstQueue, height)
+		srcByHash := map[uint64][]*semanticNode{}
+		dstByHash := map[uint64][]*semanticNode{}
+		for _, node := range srcNodes {
+			srcByHash[node.Hash] = append(srcByHash[node.Hash], node)
+		}
+		for _, node := range dstNodes {
+			dstByHash[node.Hash] = append(dstByHash[node.Hash], node)
+		}
+		matched := map[*semanticNode]bool{}
+		for _, node := range srcNodes {
+			srcGroup, dstGroup := srcByHash[node.Hash], dstByHash[node.Hash]
+			if len(dstGroup) == 0 || srcGroup[0] != node {
+				continue
+			}
+			for _, s := range srcGroup {
+				matched[s] = true
+			}
+			for _, d := range dstGroup {
+				matched[d] = true
+			}
+			if len(srcGroup) == 1 && len(dstGroup) == 1 {
+				mapping.addIsomorphic(srcGroup[0], dstGroup[0])
+				continue
+			}
+			for _, s := range srcGroup {
+				for _, d := range dstGroup {
+					ambiguous = append(ambiguous, candidate{s, d})
+				}
+			}
+		}
+		var unmatched []*semanticNode
+		for _, node := range srcNodes {
+			if !matched[node] {
+				unmatched = append(unmatched, node)
+			}
+		}
+		srcQueue = open(srcQueue, unmatched)
+		unmatched = nil
+		for _, node := range dstNodes {
+			if !matched[node] {
+				unmatched = append(unmatched, node)
+			}
+		}
+		dstQueue = open(dstQueue, unmatched)
+	}
+	parentDice := func(pair candidate) float32 {
+		if pair.Src.Parent == nil || pair.Dst.Parent == nil {
+			return 0
+		}
+		return mapping.dice(src, pair.Src.Parent, pair.Dst.Parent)
+	}
+	dices := make([]float32, len(ambiguous))
+	for i, pair := range ambiguous {
+		dices[i] = parentDice(pair)
+	}
+	order := make([]int, len(ambiguous))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return dices[order[i]] > dices[order[j]]
+	})
+	for _, i := range order {
+		pair := ambiguous[i]
+		if mapping.forward[pair.Src] == nil && mapping.backward[pair.Dst] == nil {
+			mapping.addIsomorphic(pair.Src, pair.Dst)
+		}
+	}
+}
+
+// matchBottomUp maps the nodes of the same type which have enough mapped descendants in common,
+// then maps the remaining descendants of such nodes which look the same.
+func (diff *SemanticDiff) matchBottomUp(src, dst *semanticTree, mapping *semanticMapping) {
+	for _, node := range src.Nodes {
+		if mapping.forward[node] != nil {
+			continue
+		}
+		var best *semanticNode
+		if node == src.Root {
+			if dst.Root.Node.InternalType == node.Node.InternalType &&
+				mapping.backward[dst.Root] == nil {
+				best = dst.Root
+			}
+		} else if len(node.Children) > 0 {
+			var bestDice float32
+			seen := map[*semanticNode]bool{}
+			for _, descendant := range src.descendants(node) {
+				mapped := mapping.forward[descendant]
+				if mapped == nil {
+					continue
+				}
+				for parent := mapped.Parent; parent != nil && !seen[parent]; parent = parent.Parent {
+					seen[parent] = true
+					if mapping.backward[parent] != nil ||
+						parent.Node.InternalType != node.Node.InternalType {
+						continue
+					}
+					if dice := mapping.dice(src, node, parent); dice > bestDice {
+						best, bestDice = parent, dice
+					}
+				}
+			}
+			if bestDice < diff.MinDice {
+				best = nil
+			}
+		}
+		if best == nil {
+			continue
+		}
+		mapping.add(node, best)
+		recoverMapping(src, dst, node, best, mapping)
+	}
+}
+
+// recoverMapping maps the unmapped descendants of two mapped nodes in the order of appearance:
+// the isomorphic subtrees first, then the nodes with the same type and token and finally
+// the nodes of the same type under the mapped parents, which become updates.
+func recoverMapping(src, dst *semanticTree, srcRoot, dstRoot *semanticNode,
+	mapping *semanticMapping) {
+	var srcNodes, dstNodes []*semanticNode
+	for _, node := range src.descendants(srcRoot) {
+		if mapping.forward[node] == nil {
+			srcNodes = append(srcNodes, node)
+		}
+	}
+	for _, node := range dst.descendants(dstRoot) {
+		if mapping.backward[node] == nil {
+			dstNodes = append(dstNodes, node)
+		}
+	}
+	if len(srcNodes) == 0 || len(dstNodes) == 0 {
+		return
+	}
+	// match returns whether any nodes were mapped
+	match := func(equal func(a, b *semanticNode) bool, isomorphic bool) bool {
+		changed := false
+		for _, s := range srcNodes {
+			if mapping.forward[s] != nil {
+				continue
+			}
+			for _, d := range dstNodes {
+				if mapping.backward[d] != nil || !equal(s, d) {
+					continue
+				}
+				if isomorphic {
+					mapping.addIsomorphic(s, d)
+				} else {
+					mapping.add(s, d)
+				}
+				changed = true
+				break
+			}
+		}
+		return changed
+	}
+	match(func(a, b *semanticNode) bool {
+		return a.Hash == b.Hash && a.Height > 1
+	}, true)
+	sameParent := func(a, b *semanticNode) bool {
+		return a.Node.InternalType == b.Node.InternalType &&
+			a.Parent != nil && b.Parent != nil && mapping.forward[a.Parent] == b.Parent
+	}
+	sameToken := func(a, b *semanticNode) bool {
+		return sameParent(a, b) && a.Node.Token == b.Node.Token
+	}
+	// the children are visited before their parents, so repeat until nothing changes
+	for _, equal := range []func(a, b *semanticNode) bool{sameToken, sameParent} {
+		for changed := true; changed; {
+			changed = match(equal, false)
+		}
+	}
+}
+
+// generateEditScript classifies the nodes according to the mapping.
+func generateEditScript(src, dst *semanticTree, mapping *semanticMapping) EditScript {
+	script := EditScript{Mapping: map[*uast.Node]*uast.Node{}}
+	moved := map[*semanticNode]bool{}
+	for _, node := range src.Nodes {
+		mapped := mapping.forward[node]
+		if mapped == nil || node.Parent == nil {
+			continue
+		}
+		if mapped.Parent == nil || mapping.forward[node.Parent] != mapped.Parent {
+			moved[node] = true
+		}
+	}
+	// the mapped children which left the longest common subsequence were reordered
+	for _, node := range src.Nodes {
+		mapped := mapping.forward[node]
+		if mapped == nil || len(node.Children) < 2 {
+			continue
+		}
+		var srcChildren, dstChildren []*semanticNode
+		for _, child := range node.Children {
+			if other := mapping.forward[child]; other != nil && other.Parent == mapped {
+				srcChildren = append(srcChildren, child)
+			}
+		}
+		for _, child := range mapped.Children {
+			if other := mapping.backward[child]; other != nil && other.Parent == node {
+				dstChildren = append(dstChildren, child)
+			}
+		}
+		inOrder := semanticLCS(srcChildren, dstChildren, mapping)
+		for _, child := range srcChildren {
+			if !inOrder[child] {
+				moved[child] = true
+			}
+		}
+	}
+	for _, node := range src.Nodes {
+		mapped := mapping.forward[node]
+		if mapped == nil {
+			script.Edits = append(script.Edits, Edit{Action: EditDelete, Before: node.Node})
+			continue
+		}
+		script.Mapping[node.Node] = mapped.Node
+		if node.Node.Token != mapped.Node.Token {
+			script.Edits = append(script.Edits, Edit{
+				Action: EditUpdate, Before: node.Node, After: mapped.Node})
+		}
+		if moved[node] {
+			script.Edits = append(script.Edits, Edit{
+				Action: EditMove, Before: node.Node, After: mapped.Node})
+		}
+	}
+	for _, node := range dst.Nodes {
+		if mapping.backward[node] == nil {
+			script.Edits = append(script.Edits, Edit{Action: EditInsert, After: node.Node})
+		}
+	}
+	return script
+}
+
+// semanticLCS returns the source nodes which belong to the longest common subsequence
+// of the mapped sequences.
+func semanticLCS(srcNodes, dstNodes []*semanticNode, mapping *semanticMapping) map[*semanticNode]bool {
+	lengths := make([][]int, len(srcNodes)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(dstNodes)+1)
+	}
+	for i := len(srcNodes) - 1; i >= 0; i-- {
+		for j := len(dstNodes) - 1; j >= 0; j-- {
+			if mapping.forward[srcNodes[i]] == dstNodes[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+	result := map[*semanticNode]bool{}
+	for i, j := 0, 0; i < len(srcNodes) && j < len(dstNodes); {
+		if mapping.forward[srcNodes[i]] == dstNodes[j] {
+			result[srcNodes[i]] = true
+			i++
+			j++
+		} else if lengths[i+1][j] >= lengths[i][j+1] {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+func init() {
+	core.Registry.Register(&SemanticDiff{})
+}
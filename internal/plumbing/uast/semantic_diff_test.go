@@ -0,0 +1,158 @@
+package uast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+func fixtureSemanticDiff() *SemanticDiff {
+	diff := &SemanticDiff{}
+	diff.Initialize(nil)
+	return diff
+}
+
+func fixtureSemanticNode(internalType string, token string, children ...*uast.Node) *uast.Node {
+	return &uast.Node{InternalType: internalType, Token: token, Children: children}
+}
+
+// fixtureSemanticFunction builds a function with the specified name and statements.
+func fixtureSemanticFunction(name string, statements ...*uast.Node) *uast.Node {
+	return fixtureSemanticNode("FuncDecl", "",
+		fixtureSemanticNode("Ident", name),
+		fixtureSemanticNode("BlockStmt", "", statements...))
+}
+
+// fixtureSemanticCall builds the statement which calls the function with the argument.
+func fixtureSemanticCall(function string, argument string) *uast.Node {
+	return fixtureSemanticNode("ExprStmt", "",
+		fixtureSemanticNode("CallExpr", "",
+			fixtureSemanticNode("Ident", function),
+			fixtureSemanticNode("BasicLit", argument)))
+}
+
+func countSemanticEdits(script EditScript) map[EditAction]int {
+	counts := map[EditAction]int{}
+	for _, edit := range script.Edits {
+		counts[edit.Action]++
+	}
+	return counts
+}
+
+func TestSemanticDiffMeta(t *testing.T) {
+	diff := fixtureSemanticDiff()
+	assert.Equal(t, diff.Name(), "UASTSemanticDiff")
+	assert.Equal(t, diff.Provides(), []string{DependencyUastEditScripts})
+	assert.Equal(t, diff.Requires(), []string{DependencyUastChanges})
+	assert.Equal(t, diff.Features(), []string{FeatureUast})
+	opts := diff.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigSemanticDiffMinHeight)
+	assert.Equal(t, diff.MinHeight, DefaultSemanticDiffMinHeight)
+	assert.Equal(t, diff.MinDice, float32(DefaultSemanticDiffMinDice))
+	diff.Configure(map[string]interface{}{ConfigSemanticDiffMinHeight: 3})
+	assert.Equal(t, diff.MinHeight, 3)
+}
+
+func TestSemanticDiffRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&SemanticDiff{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "UASTSemanticDiff")
+	summoned = core.Registry.Summon((&SemanticDiff{}).Provides()[0])
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "UASTSemanticDiff")
+}
+
+func TestSemanticDiffIdentical(t *testing.T) {
+	before := fixtureSemanticFunction("main", fixtureSemanticCall("print", "1"))
+	after := fixtureSemanticFunction("main", fixtureSemanticCall("print", "1"))
+	script := fixtureSemanticDiff().Diff(before, after)
+	assert.Len(t, script.Edits, 0)
+	assert.Len(t, script.Mapping, 7)
+	assert.Equal(t, script.Mapping[before], after)
+}
+
+func TestSemanticDiffRename(t *testing.T) {
+	before := fixtureSemanticFunction("main",
+		fixtureSemanticCall("print", "1"), fixtureSemanticCall("exit", "0"))
+	after := fixtureSemanticFunction("run",
+		fixtureSemanticCall("print", "1"), fixtureSemanticCall("exit", "0"))
+	script := fixtureSemanticDiff().Diff(before, after)
+	assert.Len(t, script.Edits, 1)
+	edit := script.Edits[0]
+	assert.Equal(t, edit.Action, EditUpdate)
+	assert.Equal(t, edit.Before.Token, "main")
+	assert.Equal(t, edit.After.Token, "run")
+}
+
+func TestSemanticDiffReorder(t *testing.T) {
+	before := fixtureSemanticFunction("main",
+		fixtureSemanticCall("print", "1"), fixtureSemanticCall("log", "2"),
+		fixtureSemanticCall("exit", "0"))
+	after := fixtureSemanticFunction("main",
+		fixtureSemanticCall("log", "2"), fixtureSemanticCall("print", "1"),
+		fixtureSemanticCall("exit", "0"))
+	script := fixtureSemanticDiff().Diff(before, after)
+	assert.Equal(t, countSemanticEdits(script), map[EditAction]int{EditMove: 1})
+	assert.Equal(t, script.Edits[0].Before.InternalType, "ExprStmt")
+}
+
+func TestSemanticDiffMoveBetweenParents(t *testing.T) {
+	before := fixtureSemanticNode("File", "",
+		fixtureSemanticFunction("main",
+			fixtureSemanticCall("print", "1"), fixtureSemanticCall("exit", "0")),
+		fixtureSemanticFunction("helper", fixtureSemanticCall("log", "2")))
+	after := fixtureSemanticNode("File", "",
+		fixtureSemanticFunction("main", fixtureSemanticCall("exit", "0")),
+		fixtureSemanticFunction("helper",
+			fixtureSemanticCall("log", "2"), fixtureSemanticCall("print", "1")))
+	script := fixtureSemanticDiff().Diff(before, after)
+	assert.Equal(t, countSemanticEdits(script), map[EditAction]int{EditMove: 1})
+	edit := script.Edits[0]
+	assert.Equal(t, edit.Before.Children[0].Children[0].Token, "print")
+	assert.Equal(t, edit.After, after.Children[1].Children[1].Children[1])
+}
+
+func TestSemanticDiffInsertDelete(t *testing.T) {
+	before := fixtureSemanticFunction("main",
+		fixtureSemanticCall("print", "1"), fixtureSemanticCall("exit", "0"))
+	after := fixtureSemanticFunction("main",
+		fixtureSemanticCall("print", "1"), fixtureSemanticCall("flush", "stdout"),
+		fixtureSemanticNode("ReturnStmt", ""))
+	script := fixtureSemanticDiff().Diff(before, after)
+	counts := countSemanticEdits(script)
+	// exit(0) is replaced with flush(stdout): the statement and the call are kept
+	assert.Equal(t, counts[EditUpdate], 2)
+	assert.Equal(t, counts[EditInsert], 1)
+	assert.Equal(t, counts[EditDelete], 0)
+	assert.Equal(t, counts[EditMove], 0)
+	before = fixtureSemanticNode("File", "", fixtureSemanticFunction("main",
+		fixtureSemanticCall("print", "1")))
+	after = fixtureSemanticNode("File", "", fixtureSemanticNode("GenDecl", "",
+		fixtureSemanticNode("Ident", "x")))
+	script = fixtureSemanticDiff().Diff(before, after)
+	counts = countSemanticEdits(script)
+	assert.Equal(t, counts[EditDelete], 7)
+	assert.Equal(t, counts[EditInsert], 2)
+	assert.Len(t, script.Mapping, 1)
+}
+
+func TestSemanticDiffConsume(t *testing.T) {
+	change := &object.Change{To: object.ChangeEntry{Name: "main.go"}}
+	before := fixtureSemanticFunction("main", fixtureSemanticCall("print", "1"))
+	after := fixtureSemanticFunction("run", fixtureSemanticCall("print", "1"))
+	deps := map[string]interface{}{DependencyUastChanges: []Change{
+		{Before: before, After: after, Change: change},
+		{Before: nil, After: after, Change: &object.Change{}},
+		{Before: before, After: nil, Change: &object.Change{}},
+	}}
+	result, err := fixtureSemanticDiff().Consume(deps)
+	assert.Nil(t, err)
+	scripts := result[DependencyUastEditScripts].([]EditScript)
+	assert.Len(t, scripts, 1)
+	assert.Equal(t, scripts[0].Change, change)
+	assert.Len(t, scripts[0].Edits, 1)
+}
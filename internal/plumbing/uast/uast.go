@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	goioutil "io/ioutil"
+	"log"
 	"os"
 	"path"
 	"runtime"
@@ -348,6 +349,9 @@ const (
 // in a commit. It is a PipelineItem.
 type Changes struct {
 	cache map[plumbing.Hash]*uast.Node
+	// noCache is set by Shrink(), the UASTs of the previous versions of the files are
+	// not remembered anymore.
+	noCache bool
 }
 
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
@@ -389,6 +393,7 @@ func (uc *Changes) Configure(facts map[string]interface{}) {}
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (uc *Changes) Initialize(repository *git.Repository) {
 	uc.cache = map[plumbing.Hash]*uast.Node{}
+	uc.noCache = false
 }
 
 // Consume runs this PipelineItem on the next commit data.
@@ -410,7 +415,9 @@ func (uc *Changes) Consume(deps map[string]interface{}) (map[string]interface{},
 			hashTo := change.To.TreeEntry.Hash
 			uastTo := uasts[hashTo]
 			commit = append(commit, Change{Before: nil, After: uastTo, Change: change})
-			uc.cache[hashTo] = uastTo
+			if !uc.noCache {
+				uc.cache[hashTo] = uastTo
+			}
 		case merkletrie.Delete:
 			hashFrom := change.From.TreeEntry.Hash
 			commit = append(commit, Change{Before: uc.cache[hashFrom], After: nil, Change: change})
@@ -421,20 +428,43 @@ func (uc *Changes) Consume(deps map[string]interface{}) (map[string]interface{},
 			uastTo := uasts[hashTo]
 			commit = append(commit, Change{Before: uc.cache[hashFrom], After: uastTo, Change: change})
 			delete(uc.cache, hashFrom)
-			uc.cache[hashTo] = uastTo
+			if !uc.noCache {
+				uc.cache[hashTo] = uastTo
+			}
 		}
 	}
 	return map[string]interface{}{DependencyUastChanges: commit}, nil
 }
 
-// ChangesSaver dumps changed files and corresponding UASTs for every commit.
+// Shrink drops the cached UASTs and disables the caching for the rest of the analysis.
+// The modified files do not have the UASTs before the change afterwards, so the dependent
+// analyses treat them as new.
+func (uc *Changes) Shrink() {
+	if !uc.noCache {
+		log.Println("Warning: disabled caching the UASTs, the modified files are treated as new")
+	}
+	uc.cache = map[plumbing.Hash]*uast.Node{}
+	uc.noCache = true
+}
+
+// ChangesSaver dumps changed files and corresponding UASTs for every commit, so that
+// the extracted UASTs can be analysed later without running Babelfish again.
 // it is a LeafPipelineItem.
 type ChangesSaver struct {
 	// OutputPath points to the target directory with UASTs
 	OutputPath string
 
 	repository *git.Repository
-	result     [][]Change
+	result     ChangesSaverResult
+}
+
+// ChangesSaverResult is returned by ChangesSaver.Finalize() and carries the UAST changes
+// of every analysed commit.
+type ChangesSaverResult struct {
+	// Commits are the hashes of the analysed commits.
+	Commits []plumbing.Hash
+	// Changes are the UAST changes of each commit in Commits.
+	Changes [][]Change
 }
 
 const (
@@ -497,7 +527,7 @@ func (saver *ChangesSaver) Configure(facts map[string]interface{}) {
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (saver *ChangesSaver) Initialize(repository *git.Repository) {
 	saver.repository = repository
-	saver.result = [][]Change{}
+	saver.result = ChangesSaverResult{Commits: []plumbing.Hash{}, Changes: [][]Change{}}
 }
 
 // Consume runs this PipelineItem on the next commit data.
@@ -506,8 +536,10 @@ func (saver *ChangesSaver) Initialize(repository *git.Repository) {
 // This function returns the mapping with analysis results. The keys must be the same as
 // in Provides(). If there was an error, nil is returned.
 func (saver *ChangesSaver) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
 	changes := deps[DependencyUastChanges].([]Change)
-	saver.result = append(saver.result, changes)
+	saver.result.Commits = append(saver.result.Commits, commit.Hash)
+	saver.result.Changes = append(saver.result.Changes, changes)
 	return nil, nil
 }
 
@@ -518,9 +550,13 @@ func (saver *ChangesSaver) Finalize() interface{} {
 
 // Serialize converts the analysis result as returned by Finalize() to text or bytes.
 // The text format is YAML and the bytes format is Protocol Buffers.
+// The files are written to OutputPath.
 func (saver *ChangesSaver) Serialize(result interface{}, binary bool, writer io.Writer) error {
-	saverResult := result.([][]Change)
-	fileNames := saver.dumpFiles(saverResult)
+	saverResult := result.(ChangesSaverResult)
+	fileNames, err := saver.dumpFiles(&saverResult)
+	if err != nil {
+		return err
+	}
 	if binary {
 		return saver.serializeBinary(fileNames, writer)
 	}
@@ -528,42 +564,79 @@ func (saver *ChangesSaver) Serialize(result interface{}, binary bool, writer io.
 	return nil
 }
 
-func (saver *ChangesSaver) dumpFiles(result [][]Change) []*pb.UASTChange {
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (saver *ChangesSaver) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(saver, result, &pb.UASTChangesSaverResults{}, writer)
+}
+
+// dumpFiles writes the sources and the UASTs of every change. The side which does not exist,
+// e.g. the "before" of an added file, is omitted.
+func (saver *ChangesSaver) dumpFiles(result *ChangesSaverResult) ([]*pb.UASTChange, error) {
 	fileNames := []*pb.UASTChange{}
-	for i, changes := range result {
+	dump := func(i, j int, node *uast.Node, hash plumbing.Hash, side string) (string, string, error) {
+		bs, err := node.Marshal()
+		if err != nil {
+			return "", "", err
+		}
+		uastPath := path.Join(saver.OutputPath, fmt.Sprintf("%d_%d_%s_%s.pb", i, j, side, hash.String()))
+		if err = goioutil.WriteFile(uastPath, bs, 0666); err != nil {
+			return "", "", err
+		}
+		blob, err := saver.repository.BlobObject(hash)
+		if err != nil {
+			return "", "", err
+		}
+		s, err := (&object.File{Blob: *blob}).Contents()
+		if err != nil {
+			return "", "", err
+		}
+		srcPath := path.Join(saver.OutputPath, fmt.Sprintf("%d_%d_%s_%s.src", i, j, side, hash.String()))
+		if err = goioutil.WriteFile(srcPath, []byte(s), 0666); err != nil {
+			return "", "", err
+		}
+		return srcPath, uastPath, nil
+	}
+	for i, changes := range result.Changes {
 		for j, change := range changes {
-			if change.Before == nil || change.After == nil {
+			if change.Before == nil && change.After == nil {
 				continue
 			}
-			record := &pb.UASTChange{FileName: change.Change.To.Name}
-			bs, _ := change.Before.Marshal()
-			record.UastBefore = path.Join(saver.OutputPath, fmt.Sprintf(
-				"%d_%d_before_%s.pb", i, j, change.Change.From.TreeEntry.Hash.String()))
-			goioutil.WriteFile(record.UastBefore, bs, 0666)
-			blob, _ := saver.repository.BlobObject(change.Change.From.TreeEntry.Hash)
-			s, _ := (&object.File{Blob: *blob}).Contents()
-			record.SrcBefore = path.Join(saver.OutputPath, fmt.Sprintf(
-				"%d_%d_before_%s.src", i, j, change.Change.From.TreeEntry.Hash.String()))
-			goioutil.WriteFile(record.SrcBefore, []byte(s), 0666)
-			bs, _ = change.After.Marshal()
-			record.UastAfter = path.Join(saver.OutputPath, fmt.Sprintf(
-				"%d_%d_after_%s.pb", i, j, change.Change.To.TreeEntry.Hash.String()))
-			goioutil.WriteFile(record.UastAfter, bs, 0666)
-			blob, _ = saver.repository.BlobObject(change.Change.To.TreeEntry.Hash)
-			s, _ = (&object.File{Blob: *blob}).Contents()
-			record.SrcAfter = path.Join(saver.OutputPath, fmt.Sprintf(
-				"%d_%d_after_%s.src", i, j, change.Change.To.TreeEntry.Hash.String()))
-			goioutil.WriteFile(record.SrcAfter, []byte(s), 0666)
+			record := &pb.UASTChange{
+				FileName:       change.Change.To.Name,
+				FileNameBefore: change.Change.From.Name,
+			}
+			if i < len(result.Commits) {
+				record.Commit = result.Commits[i].String()
+			}
+			var err error
+			if change.Before != nil {
+				record.HashBefore = change.Change.From.TreeEntry.Hash.String()
+				record.SrcBefore, record.UastBefore, err = dump(
+					i, j, change.Before, change.Change.From.TreeEntry.Hash, "before")
+				if err != nil {
+					return nil, err
+				}
+			}
+			if change.After != nil {
+				record.HashAfter = change.Change.To.TreeEntry.Hash.String()
+				record.SrcAfter, record.UastAfter, err = dump(
+					i, j, change.After, change.Change.To.TreeEntry.Hash, "after")
+				if err != nil {
+					return nil, err
+				}
+			}
 			fileNames = append(fileNames, record)
 		}
 	}
-	return fileNames
+	return fileNames, nil
 }
 
 func (saver *ChangesSaver) serializeText(result []*pb.UASTChange, writer io.Writer) {
 	for _, sc := range result {
 		kv := [...]string{
-			"file: " + sc.FileName,
+			"commit: " + sc.Commit,
+			"file0: " + sc.FileNameBefore, "file: " + sc.FileName,
+			"hash0: " + sc.HashBefore, "hash1: " + sc.HashAfter,
 			"src0: " + sc.SrcBefore, "src1: " + sc.SrcAfter,
 			"uast0: " + sc.UastBefore, "uast1: " + sc.UastAfter,
 		}
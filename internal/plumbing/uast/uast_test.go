@@ -271,6 +271,33 @@ func TestUASTChangesConsume(t *testing.T) {
 	assert.Nil(t, result[2].After)
 }
 
+func TestUASTChangesShrink(t *testing.T) {
+	hashFrom := plumbing.NewHash("dc248ba2b22048cc730c571a748e8ffcf7085ab9")
+	hashTo := plumbing.NewHash("baa64828831d174f40140e4b3cfa77d1e917a2c1")
+	entry := func(hash plumbing.Hash) object.ChangeEntry {
+		return object.ChangeEntry{Name: "analyser.go", TreeEntry: object.TreeEntry{
+			Name: "analyser.go", Mode: 0100644, Hash: hash}}
+	}
+	deps := map[string]interface{}{}
+	deps[DependencyUasts] = map[plumbing.Hash]*uast.Node{hashTo: {InternalType: "dos"}}
+	deps[items.DependencyTreeChanges] = object.Changes{
+		{From: entry(hashFrom), To: entry(hashTo)}}
+	ch := fixtureUASTChanges()
+	ch.cache[hashFrom] = &uast.Node{InternalType: "uno"}
+	ch.Shrink()
+	assert.Len(t, ch.cache, 0)
+	assert.True(t, ch.noCache)
+	resultMap, err := ch.Consume(deps)
+	assert.Nil(t, err)
+	result := resultMap[DependencyUastChanges].([]Change)
+	assert.Len(t, result, 1)
+	assert.Nil(t, result[0].Before)
+	assert.Equal(t, result[0].After.InternalType, "dos")
+	assert.Len(t, ch.cache, 0)
+	ch.Initialize(test.Repository)
+	assert.False(t, ch.noCache)
+}
+
 func fixtureUASTChangesSaver() *ChangesSaver {
 	ch := ChangesSaver{}
 	ch.Initialize(test.Repository)
@@ -322,6 +349,9 @@ func TestUASTChangesSaverPayload(t *testing.T) {
 	deps := map[string]interface{}{}
 	changes := make([]Change, 1)
 	deps[DependencyUastChanges] = changes
+	commit, _ := test.Repository.CommitObject(plumbing.NewHash(
+		"2b1ed978194a94edeabbca6de7ff3b5771d4d665"))
+	deps["commit"] = commit
 	treeFrom, _ := test.Repository.TreeObject(plumbing.NewHash(
 		"a1eb2ea76eb7f9bfbde9b243861474421000eb96"))
 	treeTo, _ := test.Repository.TreeObject(plumbing.NewHash(
@@ -356,6 +386,10 @@ func TestUASTChangesSaverPayload(t *testing.T) {
 	proto.Unmarshal(buffer.Bytes(), pbResults)
 	assert.Len(t, pbResults.Changes, 1)
 	assert.Equal(t, pbResults.Changes[0].FileName, "analyser.go")
+	assert.Equal(t, pbResults.Changes[0].FileNameBefore, "analyser.go")
+	assert.Equal(t, pbResults.Changes[0].Commit, "2b1ed978194a94edeabbca6de7ff3b5771d4d665")
+	assert.Equal(t, pbResults.Changes[0].HashBefore, "dc248ba2b22048cc730c571a748e8ffcf7085ab9")
+	assert.Equal(t, pbResults.Changes[0].HashAfter, "334cde09da4afcb74f8d2b3e6fd6cce61228b485")
 	assert.Equal(t, pbResults.Changes[0].SrcAfter,
 		path.Join(tmpdir, "0_0_after_334cde09da4afcb74f8d2b3e6fd6cce61228b485.src"))
 	assert.Equal(t, pbResults.Changes[0].SrcBefore,
@@ -384,7 +418,47 @@ func TestUASTChangesSaverPayload(t *testing.T) {
 	checkFiles()
 	buffer.Truncate(0)
 	chs.Serialize(res, false, buffer)
-	assert.Equal(t, buffer.String(), fmt.Sprintf(`  - {file: analyser.go, src0: %s/0_0_before_dc248ba2b22048cc730c571a748e8ffcf7085ab9.src, src1: %s/0_0_after_334cde09da4afcb74f8d2b3e6fd6cce61228b485.src, uast0: %s/0_0_before_dc248ba2b22048cc730c571a748e8ffcf7085ab9.pb, uast1: %s/0_0_after_334cde09da4afcb74f8d2b3e6fd6cce61228b485.pb}
+	assert.Equal(t, buffer.String(), fmt.Sprintf(`  - {commit: 2b1ed978194a94edeabbca6de7ff3b5771d4d665, file0: analyser.go, file: analyser.go, hash0: dc248ba2b22048cc730c571a748e8ffcf7085ab9, hash1: 334cde09da4afcb74f8d2b3e6fd6cce61228b485, src0: %s/0_0_before_dc248ba2b22048cc730c571a748e8ffcf7085ab9.src, src1: %s/0_0_after_334cde09da4afcb74f8d2b3e6fd6cce61228b485.src, uast0: %s/0_0_before_dc248ba2b22048cc730c571a748e8ffcf7085ab9.pb, uast1: %s/0_0_after_334cde09da4afcb74f8d2b3e6fd6cce61228b485.pb}
 `, tmpdir, tmpdir, tmpdir, tmpdir))
 	checkFiles()
 }
+
+func TestUASTChangesSaverAddedFile(t *testing.T) {
+	chs := fixtureUASTChangesSaver()
+	deps := map[string]interface{}{}
+	commit, _ := test.Repository.CommitObject(plumbing.NewHash(
+		"2b1ed978194a94edeabbca6de7ff3b5771d4d665"))
+	deps["commit"] = commit
+	deps[DependencyUastChanges] = []Change{{Before: nil, After: &uast.Node{},
+		Change: &object.Change{To: object.ChangeEntry{
+			Name: "analyser.go",
+			TreeEntry: object.TreeEntry{
+				Name: "analyser.go",
+				Mode: 0100644,
+				Hash: plumbing.NewHash("334cde09da4afcb74f8d2b3e6fd6cce61228b485"),
+			},
+		}}}, {Before: nil, After: nil, Change: &object.Change{}}}
+	chs.Consume(deps)
+	tmpdir, err := ioutil.TempDir("", "hercules-test-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpdir)
+	chs.OutputPath = tmpdir
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, chs.Serialize(chs.Finalize(), true, buffer))
+	pbResults := &pb.UASTChangesSaverResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), pbResults))
+	assert.Len(t, pbResults.Changes, 1)
+	record := pbResults.Changes[0]
+	assert.Equal(t, record.FileName, "analyser.go")
+	assert.Equal(t, record.FileNameBefore, "")
+	assert.Equal(t, record.HashBefore, "")
+	assert.Equal(t, record.SrcBefore, "")
+	assert.Equal(t, record.UastBefore, "")
+	assert.Equal(t, record.UastAfter,
+		path.Join(tmpdir, "0_0_after_334cde09da4afcb74f8d2b3e6fd6cce61228b485.pb"))
+	files, err := ioutil.ReadDir(tmpdir)
+	assert.Nil(t, err)
+	assert.Len(t, files, 2)
+	chs.OutputPath = path.Join(tmpdir, "missing")
+	assert.NotNil(t, chs.Serialize(chs.Finalize(), true, buffer))
+}
@@ -0,0 +1,297 @@
+// Package projector trains dense embeddings from co-occurrence matrices and writes them
+// in the format which Tensorflow Projector (http://projector.tensorflow.org) understands.
+// It replaces the Swivel training step in labours.py for the cases when Tensorflow
+// is not available.
+package projector
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultDimensions is the default size of the trained embeddings.
+	DefaultDimensions = 50
+	// DefaultIterations is the default number of the subspace iterations.
+	DefaultIterations = 20
+	// outlierPercentile is the percentile of the co-occurrence values which clips the rest.
+	// labours.py does the same before training Swivel.
+	outlierPercentile = 99
+)
+
+// Embed trains the embeddings from the symmetric co-occurrence matrix in DOK format.
+// It calculates the positive pointwise mutual information (PPMI) and finds the top
+// `dimensions` eigenvectors with the randomized subspace iteration. Each row of the result
+// is the embedding of the corresponding row in `matrix`.
+func Embed(matrix []map[int]int64, dimensions int, iterations int) [][]float32 {
+	size := len(matrix)
+	if size == 0 {
+		return [][]float32{}
+	}
+	if dimensions > size {
+		dimensions = size
+	}
+	if dimensions <= 0 {
+		dimensions = 1
+	}
+	ppmi := positivePMI(matrix)
+	rng := rand.New(rand.NewSource(7))
+	basis := make([][]float64, size)
+	for i := range basis {
+		basis[i] = make([]float64, dimensions)
+		for j := range basis[i] {
+			basis[i][j] = rng.NormFloat64()
+		}
+	}
+	orthonormalize(basis)
+	for iter := 0; iter < iterations; iter++ {
+		basis = multiply(ppmi, basis)
+		orthonormalize(basis)
+	}
+	// project the matrix on the found subspace and solve the small eigenproblem there
+	projected := multiply(ppmi, basis)
+	small := make([][]float64, dimensions)
+	for i := range small {
+		small[i] = make([]float64, dimensions)
+		for j := range small[i] {
+			var sum float64
+			for k := 0; k < size; k++ {
+				sum += basis[k][i] * projected[k][j]
+			}
+			small[i][j] = sum
+		}
+	}
+	values, vectors := jacobiEigen(small)
+	order := make([]int, dimensions)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return math.Abs(values[order[i]]) > math.Abs(values[order[j]])
+	})
+	result := make([][]float32, size)
+	for k := range result {
+		row := make([]float32, dimensions)
+		for i, col := range order {
+			var sum float64
+			for j := 0; j < dimensions; j++ {
+				sum += basis[k][j] * vectors[j][col]
+			}
+			row[i] = float32(sum * math.Sqrt(math.Abs(values[col])))
+		}
+		result[k] = row
+	}
+	return result
+}
+
+// Write saves the embeddings to "<output>_<name>_data.tsv", the labels to
+// "<output>_<name>_meta.tsv" and the projector configuration to "<output>_<name>.json".
+// The naming scheme is the same as in labours.py. Returns the written file names.
+func Write(output, name string, index []string, commits []int64, embeddings [][]float32) (
+	[]string, error) {
+	if len(index) != len(embeddings) || len(commits) != len(embeddings) {
+		return nil, fmt.Errorf("size mismatch: %d labels, %d counters, %d embeddings",
+			len(index), len(commits), len(embeddings))
+	}
+	if len(embeddings) == 0 {
+		return nil, fmt.Errorf("%s: nothing to write", name)
+	}
+	metaPath := fmt.Sprintf("%s_%s_meta.tsv", output, name)
+	dataPath := fmt.Sprintf("%s_%s_data.tsv", output, name)
+	jsonPath := fmt.Sprintf("%s_%s.json", output, name)
+	err := writeFile(metaPath, func(file *os.File) {
+		fmt.Fprint(file, "name\tcommits\n")
+		for i, label := range index {
+			fmt.Fprintf(file, "%s\t%d\n", strings.Replace(label, "\t", " ", -1), commits[i])
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = writeFile(dataPath, func(file *os.File) {
+		for _, vec := range embeddings {
+			strs := make([]string, len(vec))
+			for i, val := range vec {
+				strs[i] = fmt.Sprint(val)
+			}
+			fmt.Fprintln(file, strings.Join(strs, "\t"))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = writeFile(jsonPath, func(file *os.File) {
+		fmt.Fprintf(file, `{
+  "embeddings": [
+    {
+      "tensorName": "%s %s coupling",
+      "tensorShape": [%d, %d],
+      "tensorPath": "http://0.0.0.0:8000/%s",
+      "metadataPath": "http://0.0.0.0:8000/%s"
+    }
+  ]
+}
+`, output, name, len(embeddings), len(embeddings[0]), dataPath, metaPath)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []string{metaPath, dataPath, jsonPath}, nil
+}
+
+func writeFile(path string, write func(file *os.File)) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	write(file)
+	return file.Close()
+}
+
+// positivePMI clips the outliers in the co-occurrence matrix and converts it to PPMI.
+func positivePMI(matrix []map[int]int64) []map[int]float64 {
+	values := []int64{}
+	for _, row := range matrix {
+		for _, val := range row {
+			values = append(values, val)
+		}
+	}
+	var threshold int64
+	if len(values) > 0 {
+		sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+		threshold = values[(len(values)-1)*outlierPercentile/100]
+	}
+	clip := func(val int64) float64 {
+		if val > threshold {
+			val = threshold
+		}
+		return float64(val)
+	}
+	sums := make([]float64, len(matrix))
+	var total float64
+	for i, row := range matrix {
+		for _, val := range row {
+			sums[i] += clip(val)
+		}
+		total += sums[i]
+	}
+	result := make([]map[int]float64, len(matrix))
+	for i, row := range matrix {
+		result[i] = map[int]float64{}
+		for j, val := range row {
+			if val <= 0 || j >= len(matrix) {
+				continue
+			}
+			pmi := math.Log(clip(val) * total / (sums[i] * sums[j]))
+			if pmi > 0 {
+				result[i][j] = pmi
+			}
+		}
+	}
+	return result
+}
+
+// multiply calculates the product of the sparse square matrix and the dense matrix.
+func multiply(sparse []map[int]float64, dense [][]float64) [][]float64 {
+	cols := len(dense[0])
+	result := make([][]float64, len(sparse))
+	for i, row := range sparse {
+		out := make([]float64, cols)
+		for j, val := range row {
+			for k, other := range dense[j] {
+				out[k] += val * other
+			}
+		}
+		result[i] = out
+	}
+	return result
+}
+
+// orthonormalize runs the modified Gram-Schmidt process on the columns of the matrix.
+// Degenerate columns are zeroed.
+func orthonormalize(matrix [][]float64) {
+	cols := len(matrix[0])
+	for j := 0; j < cols; j++ {
+		for prev := 0; prev < j; prev++ {
+			var dot float64
+			for _, row := range matrix {
+				dot += row[j] * row[prev]
+			}
+			for _, row := range matrix {
+				row[j] -= dot * row[prev]
+			}
+		}
+		var norm float64
+		for _, row := range matrix {
+			norm += row[j] * row[j]
+		}
+		norm = math.Sqrt(norm)
+		for _, row := range matrix {
+			if norm > 1e-12 {
+				row[j] /= norm
+			} else {
+				row[j] = 0
+			}
+		}
+	}
+}
+
+// jacobiEigen finds the eigenvalues and the eigenvectors (columns) of a small symmetric matrix
+// with the cyclic Jacobi method. The input is destroyed.
+func jacobiEigen(matrix [][]float64) ([]float64, [][]float64) {
+	size := len(matrix)
+	vectors := make([][]float64, size)
+	for i := range vectors {
+		vectors[i] = make([]float64, size)
+		vectors[i][i] = 1
+	}
+	for sweep := 0; sweep < 100; sweep++ {
+		var offDiagonal float64
+		for p := 0; p < size; p++ {
+			for q := p + 1; q < size; q++ {
+				offDiagonal += matrix[p][q] * matrix[p][q]
+			}
+		}
+		if offDiagonal < 1e-20 {
+			break
+		}
+		for p := 0; p < size; p++ {
+			for q := p + 1; q < size; q++ {
+				if math.Abs(matrix[p][q]) < 1e-30 {
+					continue
+				}
+				theta := (matrix[q][q] - matrix[p][p]) / (2 * matrix[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for k := 0; k < size; k++ {
+					mkp, mkq := matrix[k][p], matrix[k][q]
+					matrix[k][p] = c*mkp - s*mkq
+					matrix[k][q] = s*mkp + c*mkq
+				}
+				for k := 0; k < size; k++ {
+					mpk, mqk := matrix[p][k], matrix[q][k]
+					matrix[p][k] = c*mpk - s*mqk
+					matrix[q][k] = s*mpk + c*mqk
+				}
+				for k := 0; k < size; k++ {
+					vkp, vkq := vectors[k][p], vectors[k][q]
+					vectors[k][p] = c*vkp - s*vkq
+					vectors[k][q] = s*vkp + c*vkq
+				}
+			}
+		}
+	}
+	values := make([]float64, size)
+	for i := range values {
+		values[i] = matrix[i][i]
+	}
+	return values, vectors
+}
@@ -0,0 +1,95 @@
+package projector
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fixtureMatrix() []map[int]int64 {
+	// two cliques: {0, 1, 2} and {3, 4, 5} with a weak link between 2 and 3
+	matrix := make([]map[int]int64, 6)
+	for i := range matrix {
+		matrix[i] = map[int]int64{}
+	}
+	link := func(i, j int, val int64) {
+		matrix[i][j] = val
+		matrix[j][i] = val
+	}
+	for _, clique := range [][]int{{0, 1, 2}, {3, 4, 5}} {
+		for _, i := range clique {
+			matrix[i][i] = 10
+			for _, j := range clique {
+				if i != j {
+					link(i, j, 8)
+				}
+			}
+		}
+	}
+	link(2, 3, 1)
+	return matrix
+}
+
+func distance(v1, v2 []float32) float64 {
+	var sum float64
+	for i := range v1 {
+		d := float64(v1[i] - v2[i])
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
+
+func TestEmbed(t *testing.T) {
+	embeddings := Embed(fixtureMatrix(), 2, DefaultIterations)
+	assert.Len(t, embeddings, 6)
+	for _, vec := range embeddings {
+		assert.Len(t, vec, 2)
+	}
+	assert.True(t, distance(embeddings[0], embeddings[1]) < distance(embeddings[0], embeddings[4]))
+	assert.True(t, distance(embeddings[3], embeddings[5]) < distance(embeddings[5], embeddings[1]))
+}
+
+func TestEmbedDegenerate(t *testing.T) {
+	assert.Len(t, Embed(nil, 2, DefaultIterations), 0)
+	embeddings := Embed([]map[int]int64{{0: 1}}, 10, DefaultIterations)
+	assert.Len(t, embeddings, 1)
+	assert.Len(t, embeddings[0], 1)
+}
+
+func TestJacobiEigen(t *testing.T) {
+	values, vectors := jacobiEigen([][]float64{{2, 1}, {1, 2}})
+	if values[0] > values[1] {
+		values[0], values[1] = values[1], values[0]
+		vectors[0][0], vectors[0][1] = vectors[0][1], vectors[0][0]
+		vectors[1][0], vectors[1][1] = vectors[1][1], vectors[1][0]
+	}
+	assert.InDelta(t, values[0], 1, 1e-9)
+	assert.InDelta(t, values[1], 3, 1e-9)
+	assert.InDelta(t, math.Abs(vectors[0][1]), math.Sqrt(0.5), 1e-9)
+	assert.InDelta(t, vectors[0][1], vectors[1][1], 1e-9)
+}
+
+func TestWrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-projector-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	output := filepath.Join(dir, "couples")
+	files, err := Write(output, "files", []string{"a", "b\tc"}, []int64{1, 2},
+		[][]float32{{0.5, 1}, {-1, 0.25}})
+	assert.Nil(t, err)
+	assert.Equal(t, files, []string{
+		output + "_files_meta.tsv", output + "_files_data.tsv", output + "_files.json"})
+	meta, _ := ioutil.ReadFile(files[0])
+	assert.Equal(t, string(meta), "name\tcommits\na\t1\nb c\t2\n")
+	data, _ := ioutil.ReadFile(files[1])
+	assert.Equal(t, string(data), "0.5\t1\n-1\t0.25\n")
+	config, _ := ioutil.ReadFile(files[2])
+	assert.True(t, strings.Contains(string(config), "\"tensorShape\": [2, 2]"))
+	_, err = Write(output, "files", []string{"a"}, []int64{1, 2}, [][]float32{{0}})
+	assert.NotNil(t, err)
+}
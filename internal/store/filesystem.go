@@ -0,0 +1,174 @@
+package store
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileStore keeps the results in the local directory. Each tenant has a subdirectory with
+// <id>.data files and the corresponding <id>.json records.
+type FileStore struct {
+	// Root is the directory with the results.
+	Root string
+}
+
+// NewFileStore creates the FileStore in the directory, creating it if needed.
+func NewFileStore(root string) (*FileStore, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, err
+	}
+	return &FileStore{Root: root}, nil
+}
+
+// tenantDir returns the directory of the tenant. The names are hex-encoded so that
+// the tenants cannot escape Root.
+func (store *FileStore) tenantDir(tenant string) string {
+	return filepath.Join(store.Root, "t"+hex.EncodeToString([]byte(tenant)))
+}
+
+// Put saves the result. The record is written after the data, so List() never returns
+// partially written results.
+func (store *FileStore) Put(record *Record, data []byte) error {
+	record.Created = time.Now().UTC()
+	record.ID = newID(record.Created)
+	record.Size = int64(len(data))
+	dir := store.tenantDir(record.Tenant)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := writeFileAtomically(filepath.Join(dir, record.ID+".data"), data); err != nil {
+		return err
+	}
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(filepath.Join(dir, record.ID+".json"), encoded)
+}
+
+// Get loads the tenant's result.
+func (store *FileStore) Get(tenant, id string) (*Record, []byte, error) {
+	if !validID(id) {
+		return nil, nil, ErrNotFound
+	}
+	path := filepath.Join(store.tenantDir(tenant), id)
+	record, err := readRecord(path + ".json")
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := ioutil.ReadFile(path + ".data")
+	if os.IsNotExist(err) {
+		return nil, nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return record, data, nil
+}
+
+// List returns the matching records from the newest to the oldest.
+func (store *FileStore) List(query Query) ([]Record, error) {
+	var dirs []string
+	if query.Tenant != "" {
+		dirs = []string{store.tenantDir(query.Tenant)}
+	} else {
+		var err error
+		dirs, err = filepath.Glob(filepath.Join(store.Root, "t*"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	records := []Record{}
+	for _, dir := range dirs {
+		paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			record, err := readRecord(path)
+			if err == ErrNotFound {
+				// deleted concurrently
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if query.matches(record) {
+				records = append(records, *record)
+			}
+		}
+	}
+	sortRecords(records)
+	return records, nil
+}
+
+// Delete removes the tenant's result. The record goes first, so that List() does not
+// return the results without the data.
+func (store *FileStore) Delete(tenant, id string) error {
+	if !validID(id) {
+		return ErrNotFound
+	}
+	path := filepath.Join(store.tenantDir(tenant), id)
+	err := os.Remove(path + ".json")
+	if os.IsNotExist(err) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return os.Remove(path + ".data")
+}
+
+func readRecord(path string) (*Record, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	record := &Record{}
+	if err = json.Unmarshal(data, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// writeFileAtomically writes the file under a temporary name and then renames it.
+func writeFileAtomically(path string, data []byte) error {
+	file, err := ioutil.TempFile(filepath.Dir(path), ".tmp")
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+	}
+	return err
+}
+
+func init() {
+	Register("file", func(location *url.URL) (Store, error) {
+		path := location.Path
+		if location.Host != "" && location.Host != "localhost" {
+			// file://relative/path
+			path = location.Host + path
+		}
+		if path == "" {
+			path = location.Opaque
+		}
+		return NewFileStore(strings.TrimSuffix(path, "/"))
+	})
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fixtureFileStore(t *testing.T) (*FileStore, func()) {
+	root, err := ioutil.TempDir("", "hercules-store-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewFileStore(filepath.Join(root, "results"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store, func() { os.RemoveAll(root) }
+}
+
+func TestFileStorePutGet(t *testing.T) {
+	store, cleanup := fixtureFileStore(t)
+	defer cleanup()
+	record := &Record{Tenant: "ci", Repository: "https://github.com/src-d/hercules",
+		Analyses: []string{"burndown", "devs"}, Format: "yaml"}
+	assert.Nil(t, store.Put(record, []byte("hercules:\n")))
+	assert.True(t, validID(record.ID))
+	assert.Equal(t, record.Size, int64(10))
+	assert.False(t, record.Created.IsZero())
+	loaded, data, err := store.Get("ci", record.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, string(data), "hercules:\n")
+	assert.Equal(t, loaded.ID, record.ID)
+	assert.Equal(t, loaded.Repository, record.Repository)
+	assert.Equal(t, loaded.Analyses, record.Analyses)
+	assert.True(t, loaded.Created.Equal(record.Created))
+	// the tenants are isolated
+	_, _, err = store.Get("other", record.ID)
+	assert.Equal(t, err, ErrNotFound)
+	_, _, err = store.Get("ci", "../../etc/passwd")
+	assert.Equal(t, err, ErrNotFound)
+}
+
+func TestFileStoreListDelete(t *testing.T) {
+	store, cleanup := fixtureFileStore(t)
+	defer cleanup()
+	records := []*Record{
+		{Tenant: "a", Repository: "one"},
+		{Tenant: "a", Repository: "two"},
+		{Tenant: "b", Repository: "one"},
+		{Tenant: "a", Repository: "one"},
+	}
+	for _, record := range records {
+		assert.Nil(t, store.Put(record, []byte{1}))
+	}
+	listed, err := store.List(Query{})
+	assert.Nil(t, err)
+	assert.Len(t, listed, 4)
+	listed, err = store.List(Query{Tenant: "a", Repository: "one"})
+	assert.Nil(t, err)
+	assert.Len(t, listed, 2)
+	// from the newest to the oldest
+	assert.Equal(t, listed[0].ID, records[3].ID)
+	assert.Equal(t, listed[1].ID, records[0].ID)
+	listed, err = store.List(Query{Repository: "one"})
+	assert.Nil(t, err)
+	assert.Len(t, listed, 3)
+	listed, err = store.List(Query{Tenant: "c"})
+	assert.Nil(t, err)
+	assert.Len(t, listed, 0)
+	assert.Equal(t, store.Delete("b", records[0].ID), ErrNotFound)
+	assert.Nil(t, store.Delete("a", records[0].ID))
+	assert.Equal(t, store.Delete("a", records[0].ID), ErrNotFound)
+	_, _, err = store.Get("a", records[0].ID)
+	assert.Equal(t, err, ErrNotFound)
+	listed, err = store.List(Query{Tenant: "a"})
+	assert.Nil(t, err)
+	assert.Len(t, listed, 2)
+}
+
+func TestFileStoreOpen(t *testing.T) {
+	root, err := ioutil.TempDir("", "hercules-store-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	store, err := Open("file://" + root + "/results/")
+	assert.Nil(t, err)
+	assert.Equal(t, store.(*FileStore).Root, root+"/results")
+	_, err = os.Stat(root + "/results")
+	assert.Nil(t, err)
+}
@@ -0,0 +1,186 @@
+package store
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go"
+)
+
+// S3Store keeps the results in an S3-compatible object storage with the same layout
+// as FileStore: <prefix>/t<hex tenant>/<id>.data and <id>.json.
+type S3Store struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// NewS3Store creates the S3Store from the URL
+// "s3://[key:secret@]endpoint/bucket[/prefix][?insecure=true&region=...]".
+// The credentials default to $AWS_ACCESS_KEY_ID and $AWS_SECRET_ACCESS_KEY.
+func NewS3Store(location *url.URL) (*S3Store, error) {
+	parts := strings.SplitN(strings.Trim(location.Path, "/"), "/", 2)
+	if parts[0] == "" {
+		return nil, errors.New("the bucket is not specified in the S3 URL")
+	}
+	key, secret := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if location.User != nil {
+		key = location.User.Username()
+		secret, _ = location.User.Password()
+	}
+	options := location.Query()
+	secure := options.Get("insecure") != "true"
+	client, err := minio.NewWithRegion(location.Host, key, secret, secure, options.Get("region"))
+	if err != nil {
+		return nil, err
+	}
+	store := &S3Store{client: client, bucket: parts[0]}
+	if len(parts) > 1 {
+		store.prefix = parts[1]
+	}
+	exists, err := client.BucketExists(store.bucket)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("the bucket %s does not exist", store.bucket)
+	}
+	return store, nil
+}
+
+func (store *S3Store) tenantPrefix(tenant string) string {
+	return path.Join(store.prefix, "t"+hex.EncodeToString([]byte(tenant))) + "/"
+}
+
+// Put saves the result. The record is written after the data, so List() never returns
+// partially written results.
+func (store *S3Store) Put(record *Record, data []byte) error {
+	record.Created = time.Now().UTC()
+	record.ID = newID(record.Created)
+	record.Size = int64(len(data))
+	name := store.tenantPrefix(record.Tenant) + record.ID
+	_, err := store.client.PutObject(store.bucket, name+".data", bytes.NewReader(data),
+		int64(len(data)), minio.PutObjectOptions{ContentType: "application/octet-stream"})
+	if err != nil {
+		return err
+	}
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = store.client.PutObject(store.bucket, name+".json", bytes.NewReader(encoded),
+		int64(len(encoded)), minio.PutObjectOptions{ContentType: "application/json"})
+	return err
+}
+
+// Get loads the tenant's result.
+func (store *S3Store) Get(tenant, id string) (*Record, []byte, error) {
+	if !validID(id) {
+		return nil, nil, ErrNotFound
+	}
+	name := store.tenantPrefix(tenant) + id
+	encoded, err := store.read(name + ".json")
+	if err != nil {
+		return nil, nil, err
+	}
+	record := &Record{}
+	if err = json.Unmarshal(encoded, record); err != nil {
+		return nil, nil, err
+	}
+	data, err := store.read(name + ".data")
+	if err != nil {
+		return nil, nil, err
+	}
+	return record, data, nil
+}
+
+// List returns the matching records from the newest to the oldest.
+func (store *S3Store) List(query Query) ([]Record, error) {
+	prefix := store.prefix
+	if query.Tenant != "" {
+		prefix = store.tenantPrefix(query.Tenant)
+	} else if prefix != "" {
+		prefix += "/"
+	}
+	done := make(chan struct{})
+	defer close(done)
+	records := []Record{}
+	for object := range store.client.ListObjectsV2(store.bucket, prefix, true, done) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		if !strings.HasSuffix(object.Key, ".json") {
+			continue
+		}
+		encoded, err := store.read(object.Key)
+		if err == ErrNotFound {
+			// deleted concurrently
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		record := &Record{}
+		if err = json.Unmarshal(encoded, record); err != nil {
+			return nil, fmt.Errorf("%s: %v", object.Key, err)
+		}
+		if query.matches(record) {
+			records = append(records, *record)
+		}
+	}
+	sortRecords(records)
+	return records, nil
+}
+
+// Delete removes the tenant's result.
+func (store *S3Store) Delete(tenant, id string) error {
+	if !validID(id) {
+		return ErrNotFound
+	}
+	name := store.tenantPrefix(tenant) + id
+	// S3 does not report missing objects on removal
+	_, err := store.client.StatObject(store.bucket, name+".json", minio.StatObjectOptions{})
+	if err != nil {
+		return convertS3Error(err)
+	}
+	if err = store.client.RemoveObject(store.bucket, name+".json"); err != nil {
+		return err
+	}
+	return store.client.RemoveObject(store.bucket, name+".data")
+}
+
+func (store *S3Store) read(name string) ([]byte, error) {
+	object, err := store.client.GetObject(store.bucket, name, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, convertS3Error(err)
+	}
+	defer object.Close()
+	data, err := ioutil.ReadAll(object)
+	if err != nil {
+		return nil, convertS3Error(err)
+	}
+	return data, nil
+}
+
+// convertS3Error replaces the missing key errors with ErrNotFound.
+func convertS3Error(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrNotFound
+	}
+	return err
+}
+
+func init() {
+	Register("s3", func(location *url.URL) (Store, error) {
+		return NewS3Store(location)
+	})
+}
@@ -0,0 +1,163 @@
+// Package store persists the results of the analyses which "hercules serve" executes.
+// The results are grouped by the tenants - the clients of the server - and by the analysed
+// repositories. The backends are selected by the URL scheme: file, s3 and postgres.
+// The postgres backend is compiled only with the "postgres" build tag.
+package store
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Record describes a stored result.
+type Record struct {
+	// ID is assigned by Store.Put() and is unique within the tenant.
+	ID string `json:"id"`
+	// Tenant is the owner of the result.
+	Tenant string `json:"tenant"`
+	// Repository is the path or the URL of the analysed repository.
+	Repository string `json:"repository"`
+	// Analyses are the flags of the executed analyses.
+	Analyses []string `json:"analyses"`
+	// Format is either "yaml" or "pb".
+	Format string `json:"format"`
+	// Created is the time when the result was stored.
+	Created time.Time `json:"created"`
+	// Size is the length of the result in bytes.
+	Size int64 `json:"size"`
+}
+
+// Query selects the records in Store.List(). The empty fields match everything.
+type Query struct {
+	Tenant     string
+	Repository string
+}
+
+// matches checks whether the record satisfies the query.
+func (query Query) matches(record *Record) bool {
+	return (query.Tenant == "" || query.Tenant == record.Tenant) &&
+		(query.Repository == "" || query.Repository == record.Repository)
+}
+
+// Store is the persistent storage of the results. The implementations must be safe
+// for concurrent use.
+type Store interface {
+	// Put saves the result and sets record.ID, record.Created and record.Size.
+	Put(record *Record, data []byte) error
+	// Get loads the tenant's result by its ID. It returns ErrNotFound if there is no such result.
+	Get(tenant, id string) (*Record, []byte, error)
+	// List returns the records which match the query, from the newest to the oldest.
+	List(query Query) ([]Record, error)
+	// Delete removes the tenant's result. It returns ErrNotFound if there is no such result.
+	Delete(tenant, id string) error
+}
+
+// ErrNotFound is returned by Store.Get() and Store.Delete() when the result does not exist.
+var ErrNotFound = errors.New("the result does not exist")
+
+// Opener creates the Store from the URL.
+type Opener func(location *url.URL) (Store, error)
+
+var (
+	openers     = map[string]Opener{}
+	openersLock sync.Mutex
+)
+
+// Register adds the backend which Open() selects by the URL scheme.
+func Register(scheme string, opener Opener) {
+	openersLock.Lock()
+	defer openersLock.Unlock()
+	openers[scheme] = opener
+}
+
+// Open creates the Store which is described by the URL, e.g. "file:///var/lib/hercules",
+// "s3://key:secret@s3.amazonaws.com/bucket/prefix" or "postgres://user@localhost/hercules".
+func Open(location string) (Store, error) {
+	parsed, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	openersLock.Lock()
+	opener, exists := openers[parsed.Scheme]
+	openersLock.Unlock()
+	if !exists {
+		return nil, fmt.Errorf("unsupported result store: %s", location)
+	}
+	return opener(parsed)
+}
+
+// RetentionPolicy limits the stored results of each repository of each tenant.
+// The zero fields do not limit anything.
+type RetentionPolicy struct {
+	// MaxAge is the age after which the results are deleted.
+	MaxAge time.Duration
+	// MaxCount is the number of the newest results which are kept.
+	MaxCount int
+}
+
+// Prune deletes the records which match the query and violate the policy. It returns
+// the number of the deleted records.
+func Prune(store Store, query Query, policy RetentionPolicy, now time.Time) (int, error) {
+	if policy.MaxAge <= 0 && policy.MaxCount <= 0 {
+		return 0, nil
+	}
+	records, err := store.List(query)
+	if err != nil {
+		return 0, err
+	}
+	type key struct {
+		tenant     string
+		repository string
+	}
+	counts := map[key]int{}
+	deleted := 0
+	for _, record := range records {
+		group := key{record.Tenant, record.Repository}
+		counts[group]++
+		if (policy.MaxAge <= 0 || now.Sub(record.Created) <= policy.MaxAge) &&
+			(policy.MaxCount <= 0 || counts[group] <= policy.MaxCount) {
+			continue
+		}
+		err = store.Delete(record.Tenant, record.ID)
+		if err == ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// newID generates the record ID which sorts in the order of the creation time.
+func newID(created time.Time) string {
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	return created.UTC().Format("20060102T150405.000000") + "-" + hex.EncodeToString(suffix)
+}
+
+// validID checks that the string could be generated by newID(), so that it is safe to use
+// in the paths and the keys.
+func validID(id string) bool {
+	return idRegexp.MatchString(id)
+}
+
+var idRegexp = regexp.MustCompile(`^[0-9]{8}T[0-9]{6}\.[0-9]{6}-[0-9a-f]{8}$`)
+
+// sortRecords orders the records from the newest to the oldest.
+func sortRecords(records []Record) {
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].Created.Equal(records[j].Created) {
+			return records[i].Created.After(records[j].Created)
+		}
+		return records[i].ID > records[j].ID
+	})
+}
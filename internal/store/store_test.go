@@ -0,0 +1,56 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenUnsupported(t *testing.T) {
+	store, err := Open("ftp://example.com/results")
+	assert.Nil(t, store)
+	assert.NotNil(t, err)
+}
+
+func TestNewID(t *testing.T) {
+	created := time.Date(2018, 3, 4, 5, 6, 7, 8000, time.UTC)
+	id := newID(created)
+	assert.True(t, validID(id))
+	assert.Equal(t, id[:22], "20180304T050607.000008")
+	assert.NotEqual(t, newID(created), id)
+	assert.False(t, validID(""))
+	assert.False(t, validID("../20180304T050607.000008-01234567"))
+}
+
+func TestPrune(t *testing.T) {
+	store, cleanup := fixtureFileStore(t)
+	defer cleanup()
+	for _, record := range []*Record{
+		{Tenant: "a", Repository: "one"}, {Tenant: "a", Repository: "one"},
+		{Tenant: "a", Repository: "one"}, {Tenant: "a", Repository: "two"},
+		{Tenant: "b", Repository: "one"}, {Tenant: "b", Repository: "one"},
+	} {
+		assert.Nil(t, store.Put(record, []byte{1}))
+	}
+	deleted, err := Prune(store, Query{}, RetentionPolicy{}, time.Now())
+	assert.Nil(t, err)
+	assert.Equal(t, deleted, 0)
+	deleted, err = Prune(store, Query{Tenant: "a"}, RetentionPolicy{MaxCount: 1}, time.Now())
+	assert.Nil(t, err)
+	assert.Equal(t, deleted, 2)
+	listed, _ := store.List(Query{})
+	assert.Len(t, listed, 4)
+	deleted, err = Prune(store, Query{}, RetentionPolicy{MaxCount: 1}, time.Now())
+	assert.Nil(t, err)
+	assert.Equal(t, deleted, 1)
+	deleted, err = Prune(store, Query{Repository: "two"}, RetentionPolicy{MaxAge: time.Hour},
+		time.Now().Add(2*time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, deleted, 1)
+	listed, _ = store.List(Query{})
+	assert.Len(t, listed, 2)
+	for _, record := range listed {
+		assert.Equal(t, record.Repository, "one")
+	}
+}
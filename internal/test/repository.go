@@ -3,7 +3,9 @@ package test
 import (
 	"io"
 	"os"
+	"time"
 
+	"gopkg.in/src-d/go-billy.v4/memfs"
 	git "gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
@@ -25,6 +27,65 @@ func FakeChangeForName(name string, hashFrom string, hashTo string) *object.Chan
 	}
 }
 
+// NewMemoryRepository creates an empty repository in memory for CommitFiles().
+func NewMemoryRepository() *git.Repository {
+	repository, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		panic(err)
+	}
+	return repository
+}
+
+// CommitFiles creates the commit with the specified parents whose tree consists of `files`:
+// the mapping from the paths to the contents. The parents default to HEAD.
+func CommitFiles(repository *git.Repository, when time.Time, files map[string]string,
+	parents ...plumbing.Hash) *object.Commit {
+	worktree, err := repository.Worktree()
+	if err != nil {
+		panic(err)
+	}
+	index, err := repository.Storer.Index()
+	if err != nil {
+		panic(err)
+	}
+	for _, entry := range index.Entries {
+		if _, exists := files[entry.Name]; !exists {
+			if _, err = worktree.Remove(entry.Name); err != nil {
+				panic(err)
+			}
+		}
+	}
+	for name, contents := range files {
+		if info, err := worktree.Filesystem.Stat(name); err == nil && info.IsDir() {
+			// the directory was replaced with the file, its files have already been removed
+			if err = worktree.Filesystem.Remove(name); err != nil {
+				panic(err)
+			}
+		}
+		file, err := worktree.Filesystem.Create(name)
+		if err != nil {
+			panic(err)
+		}
+		file.Write([]byte(contents))
+		file.Close()
+		if _, err = worktree.Add(name); err != nil {
+			panic(err)
+		}
+	}
+	hash, err := worktree.Commit("Commit", &git.CommitOptions{
+		Author:  &object.Signature{Name: "Vadim", Email: "vadim@sourced.tech", When: when},
+		Parents: parents,
+	})
+	if err != nil {
+		panic(err)
+	}
+	commit, err := repository.CommitObject(hash)
+	if err != nil {
+		panic(err)
+	}
+	return commit
+}
+
 func init() {
 	cwd, err := os.Getwd()
 	if err == nil {
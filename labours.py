@@ -31,7 +31,18 @@ PB_MESSAGES = {
     "Burndown": "internal.pb.pb_pb2.BurndownAnalysisResults",
     "Couples": "internal.pb.pb_pb2.CouplesAnalysisResults",
     "Shotness": "internal.pb.pb_pb2.ShotnessAnalysisResults",
+    "CommitFeatures": "internal.pb.pb_pb2.CommitFeaturesResults",
 }
+# the result messages of the plugins keyed by the namespaced names, see register_extension()
+PB_EXTENSIONS = {}
+
+
+def register_extension(name, message_class):
+    """
+    Makes ProtobufReader decode the plugin's result which is stored under the namespaced name,
+    e.g. "github.com/user/churn/Churn". message_class is the generated Python message.
+    """
+    PB_EXTENSIONS[name] = message_class
 
 
 def parse_args():
@@ -53,8 +64,8 @@ def parse_args():
                         help="Occupy 100%% height for every measurement.")
     parser.add_argument("--couples-tmp-dir", help="Temporary directory to work with couples.")
     parser.add_argument("-m", "--mode",
-                        choices=["project", "file", "person", "churn_matrix", "ownership",
-                                 "couples", "shotness", "sentiment", "all"],
+                        choices=["project", "file", "group", "person", "churn_matrix", "ownership",
+                                 "couples", "shotness", "sentiment", "features", "all"],
                         help="What to plot.")
     parser.add_argument(
         "--resample", default="year",
@@ -70,6 +81,43 @@ def parse_args():
     return args
 
 
+COMPRESSION_EXTENSIONS = (".gz", ".zst")
+
+
+def open_bytes(file):
+    """
+    Opens the file or stdin if file is "-" for reading. gzip and zstd compression is detected
+    by the magic bytes and the data is transparently decompressed while it is read. Only
+    the uncompressed files are seekable.
+    """
+    fin = open(file, "rb") if file != "-" else sys.stdin.buffer
+    magic = fin.peek(4)[:4]
+    if magic[:2] == b"\x1f\x8b":
+        import gzip
+        return gzip.GzipFile(fileobj=fin)
+    if magic == b"\x28\xb5\x2f\xfd":
+        try:
+            import zstandard
+        except ImportError as e:
+            print("\n\n>>> You need to install zstandard to read zstd compressed files - "
+                  "run \"pip3 install zstandard\"\n", file=sys.stderr)
+            raise e from None
+        return zstandard.ZstdDecompressor().stream_reader(fin)
+    return fin
+
+
+def read_bytes(file):
+    """
+    Reads the whole file or stdin if file is "-", see open_bytes().
+    """
+    fin = open_bytes(file)
+    try:
+        return fin.read()
+    finally:
+        if file != "-":
+            fin.close()
+
+
 class Reader(object):
     def read(self, file):
         raise NotImplementedError
@@ -80,15 +128,24 @@ class Reader(object):
     def get_header(self):
         raise NotImplementedError
 
+    def get_markers(self):
+        raise NotImplementedError
+
     def get_burndown_parameters(self):
         raise NotImplementedError
 
+    def get_burndown_backfill_days(self):
+        raise NotImplementedError
+
     def get_project_burndown(self):
         raise NotImplementedError
 
     def get_files_burndown(self):
         raise NotImplementedError
 
+    def get_groups_burndown(self):
+        raise NotImplementedError
+
     def get_people_burndown(self):
         raise NotImplementedError
 
@@ -104,12 +161,18 @@ class Reader(object):
     def get_people_coocc(self):
         raise NotImplementedError
 
+    def get_people_files_coocc(self):
+        raise NotImplementedError
+
     def get_shotness_coocc(self):
         raise NotImplementedError
 
     def get_shotness(self):
         raise NotImplementedError
 
+    def get_commit_features(self):
+        raise NotImplementedError
+
 
 class YamlReader(Reader):
     def read(self, file):
@@ -120,12 +183,8 @@ class YamlReader(Reader):
             print("Warning: failed to import yaml.CLoader, falling back to slow yaml.Loader")
             loader = yaml.Loader
         try:
-            if file != "-":
-                with open(file) as fin:
-                    data = yaml.load(fin, Loader=loader)
-            else:
-                data = yaml.load(sys.stdin, Loader=loader)
-        except (UnicodeEncodeError, yaml.reader.ReaderError) as e:
+            data = yaml.load(read_bytes(file).decode("utf-8"), Loader=loader)
+        except (UnicodeDecodeError, UnicodeEncodeError, yaml.reader.ReaderError) as e:
             print("\nInvalid unicode in the input: %s\nPlease filter it through "
                   "fix_yaml_unicode.py" % e)
             sys.exit(1)
@@ -138,10 +197,16 @@ class YamlReader(Reader):
         header = self.data["hercules"]
         return header["begin_unix_time"], header["end_unix_time"]
 
+    def get_markers(self):
+        return [(m["label"], m["unix_time"]) for m in self.data["hercules"].get("markers", [])]
+
     def get_burndown_parameters(self):
         header = self.data["Burndown"]
         return header["sampling"], header["granularity"]
 
+    def get_burndown_backfill_days(self):
+        return self.data["Burndown"].get("backfill_days", 0)
+
     def get_project_burndown(self):
         return self.data["hercules"]["repository"], \
                self._parse_burndown_matrix(self.data["Burndown"]["project"]).T
@@ -150,6 +215,10 @@ class YamlReader(Reader):
         return [(p[0], self._parse_burndown_matrix(p[1]).T)
                 for p in self.data["Burndown"]["files"].items()]
 
+    def get_groups_burndown(self):
+        return [(p[0], self._parse_burndown_matrix(p[1]).T)
+                for p in self.data["Burndown"]["groups"].items()]
+
     def get_people_burndown(self):
         return [(p[0], self._parse_burndown_matrix(p[1]).T)
                 for p in self.data["Burndown"]["people"].items()]
@@ -171,6 +240,12 @@ class YamlReader(Reader):
         coocc = self.data["Couples"]["people_coocc"]
         return coocc["index"], self._parse_coocc_matrix(coocc["matrix"])
 
+    def get_people_files_coocc(self):
+        couples = self.data["Couples"]
+        files = couples["files_coocc"]["index"]
+        return couples["people_coocc"]["index"], files, self._parse_coocc_matrix(
+            couples["people_files_coocc"]["matrix"], (len(files),))
+
     def get_shotness_coocc(self):
         shotness = self.data["Shotness"]
         index = ["%s:%s" % (i["file"], i["name"]) for i in shotness]
@@ -204,14 +279,18 @@ class YamlReader(Reader):
         return munchify({int(key): {
             "Comments": vals[2].split("|"),
             "Commits": vals[1],
-            "Value": float(vals[0])
+            "Value": float(vals[0]),
+            "Emotions": vals[3] if len(vals) > 3 else {}
         } for key, vals in self.data["Sentiment"].items()})
 
+    def get_commit_features(self):
+        return self.data["CommitFeatures"]["csv"] + "\n"
+
     def _parse_burndown_matrix(self, matrix):
         return numpy.array([numpy.fromstring(line, dtype=int, sep=" ")
                             for line in matrix.split("\n")])
 
-    def _parse_coocc_matrix(self, matrix):
+    def _parse_coocc_matrix(self, matrix, columns=None):
         from scipy.sparse import csr_matrix
         data = []
         indices = []
@@ -221,7 +300,42 @@ class YamlReader(Reader):
                 data.append(v)
                 indices.append(k)
             indptr.append(indptr[-1] + len(row))
-        return csr_matrix((data, indices, indptr), shape=(len(matrix),) * 2)
+        return csr_matrix((data, indices, indptr), shape=(len(matrix),) + (columns or (len(matrix),)))
+
+
+CONTAINER_MAGIC = b"HERCULES"
+CONTAINER_VERSION = 3
+CONTAINER_CHECKSUM_SIZE = 32
+DAY_RANGE_CHUNK_NAME = re.compile(r"^([^/@]+)@\d+-\d+$")
+
+
+class LazyContents(dict):
+    """
+    Maps the analysis names to the decoded messages. The chunks of the container are read
+    and decoded on the first access, so that the analyses which are not used are never loaded.
+    The day range chunks of the same analysis are joined.
+    """
+
+    def __init__(self, load, chunks):
+        super().__init__()
+        self._load = load
+        self._chunks = chunks
+
+    def __contains__(self, key):
+        return key in self._chunks or super().__contains__(key)
+
+    def __missing__(self, key):
+        if key not in self._chunks:
+            raise KeyError(key)
+        try:
+            mod, name = PB_MESSAGES[key].rsplit(".", 1)
+        except KeyError:
+            sys.stderr.write("Warning: there is no registered PB decoder for %s\n" % key)
+            raise
+        cls = getattr(import_module(mod), name)
+        self[key] = msg = cls()
+        msg.ParseFromString(b"".join(self._load(chunk) for chunk in self._chunks.pop(key)))
+        return msg
 
 
 class ProtobufReader(Reader):
@@ -233,21 +347,119 @@ class ProtobufReader(Reader):
                   file=sys.stderr)
             raise e from None
         self.data = AnalysisResults()
-        if file != "-":
-            with open(file, "rb") as fin:
-                self.data.ParseFromString(fin.read())
-        else:
-            self.data.ParseFromString(sys.stdin.buffer.read())
-        self.contents = {}
-        for key, val in self.data.contents.items():
+        fin = open_bytes(file)
+        magic = fin.read(len(CONTAINER_MAGIC))
+        if magic == CONTAINER_MAGIC:
+            # the file stays open while the chunks are loaded on demand
+            self.contents = self.read_container(fin)
+            return
+        try:
+            self.data.ParseFromString(magic + fin.read())
+        finally:
+            if file != "-":
+                fin.close()
+        self.contents = LazyContents(lambda chunk: chunk, {
+            key: [val] for key, val in self.data.contents.items()})
+
+    def read_container(self, fin):
+        """
+        Parses the chunked container after the magic: the version and the sequence of
+        (name length, name, data length, data, SHA-256 of data) chunks. The first chunk is
+        the header. Version 1 does not have the checksums, version 2 does not have the day
+        range chunks. The analyses are skipped by the data length without reading them if
+        the stream is seekable and loaded on demand. Returns the LazyContents.
+        """
+        import hashlib
+
+        def read_uvarint():
+            value = shift = 0
+            while True:
+                byte = fin.read(1)
+                if not byte:
+                    raise EOFError
+                value |= (byte[0] & 0x7f) << shift
+                if byte[0] < 0x80:
+                    return value
+                shift += 7
+
+        def read_exactly(size):
+            data = fin.read(size)
+            if len(data) != size:
+                raise ValueError("The container is truncated")
+            return data
+
+        version = read_uvarint()
+        if not 1 <= version <= CONTAINER_VERSION:
+            raise ValueError("Unsupported container version %d" % version)
+        checksum_size = CONTAINER_CHECKSUM_SIZE if version > 1 else 0
+        seekable = isinstance(fin, io.BufferedReader) and fin.seekable()
+        if seekable:
+            fin.seek(0, io.SEEK_END)
+            file_size = fin.tell()
+
+        def verify(name, data, checksum):
+            if checksum and hashlib.sha256(data).digest() != checksum:
+                raise ValueError("Checksum mismatch in %s" % (name or "the header"))
+            return data
+
+        def load(chunk):
+            if isinstance(chunk, bytes):
+                return chunk
+            name, offset, size = chunk
+            fin.seek(offset)
+            data = read_exactly(size)
+            return verify(name, data, read_exactly(checksum_size))
+
+        if seekable:
+            fin.seek(len(CONTAINER_MAGIC))
+            read_uvarint()
+        chunks = {}
+        first = True
+        while True:
             try:
-                mod, name = PB_MESSAGES[key].rsplit(".", 1)
-            except KeyError:
-                sys.stderr.write("Warning: there is no registered PB decoder for %s\n" % key)
+                size = read_uvarint()
+            except EOFError:
+                break
+            name = read_exactly(size).decode("utf-8")
+            size = read_uvarint()
+            match = DAY_RANGE_CHUNK_NAME.match(name)
+            analysis = match.group(1) if match else name
+            if not first and "/" not in name and seekable:
+                offset = fin.tell()
+                if offset + size + checksum_size > file_size:
+                    raise ValueError("The container is truncated")
+                fin.seek(size + checksum_size, io.SEEK_CUR)
+                chunks.setdefault(analysis, []).append((name, offset, size))
                 continue
-            cls = getattr(import_module(mod), name)
-            self.contents[key] = msg = cls()
-            msg.ParseFromString(val)
+            data = verify(name, read_exactly(size), read_exactly(checksum_size))
+            if first:
+                self.data.header.ParseFromString(data)
+                first = False
+            elif "/" in name:
+                self.data.extensions[name].ParseFromString(data)
+            else:
+                chunks.setdefault(analysis, []).append(data)
+        return LazyContents(load, chunks)
+
+    def get_extension_names(self):
+        return sorted(self.data.extensions)
+
+    def get_extension(self, name):
+        """
+        Decodes the plugin's result with the class which was passed to register_extension().
+        Raises KeyError if the extension is absent or not registered.
+        """
+        if name not in self.data.extensions:
+            # the message map inserts the missing keys on access
+            raise KeyError(name)
+        extension = self.data.extensions[name]
+        msg = PB_EXTENSIONS[name]()
+        expected = "type.googleapis.com/" + msg.DESCRIPTOR.full_name
+        if extension.type_url != expected:
+            raise ValueError("%s: the type is %s instead of %s" % (
+                name, extension.type_url, expected))
+        msg.ParseFromString(extension.value)
+        return msg
 
     def get_name(self):
         return self.data.header.repository
@@ -256,16 +468,28 @@ class ProtobufReader(Reader):
         header = self.data.header
         return header.begin_unix_time, header.end_unix_time
 
+    def get_markers(self):
+        return [(m.label, m.unix_time) for m in self.data.header.markers]
+
     def get_burndown_parameters(self):
         burndown = self.contents["Burndown"]
         return burndown.sampling, burndown.granularity
 
+    def get_burndown_backfill_days(self):
+        return self.contents["Burndown"].backfill_days
+
     def get_project_burndown(self):
         return self._parse_burndown_matrix(self.contents["Burndown"].project)
 
     def get_files_burndown(self):
         return [self._parse_burndown_matrix(i) for i in self.contents["Burndown"].files]
 
+    def get_groups_burndown(self):
+        groups = self.contents["Burndown"].groups
+        if not groups:
+            raise KeyError("groups")
+        return [self._parse_burndown_matrix(i) for i in groups]
+
     def get_people_burndown(self):
         return [self._parse_burndown_matrix(i) for i in self.contents["Burndown"].people]
 
@@ -286,6 +510,13 @@ class ProtobufReader(Reader):
         node = self.contents["Couples"].people_couples
         return list(node.index), self._parse_sparse_matrix(node.matrix)
 
+    def get_people_files_coocc(self):
+        couples = self.contents["Couples"]
+        if not couples.HasField("people_files_matrix"):
+            raise KeyError("people_files_matrix")
+        return list(couples.people_couples.index), list(couples.file_couples.index), \
+            self._parse_sparse_matrix(couples.people_files_matrix)
+
     def get_shotness_coocc(self):
         shotness = self.get_shotness()
         index = ["%s:%s" % (i.file, i.name) for i in shotness]
@@ -316,6 +547,19 @@ class ProtobufReader(Reader):
             raise KeyError
         return byday
 
+    def get_commit_features(self):
+        commits = self.contents["CommitFeatures"].commits
+        columns = ("commit", "day", "hour", "files", "added", "removed", "dir_entropy",
+                   "author_experience", "message_length", "message_lines",
+                   "uast_nodes_added", "uast_nodes_removed")
+        lines = [",".join(columns)]
+        for c in commits:
+            lines.append("%s,%d,%d,%d,%d,%d,%.4f,%d,%d,%d,%d,%d" % (
+                c.commit, c.day, c.hour, c.files, c.added, c.removed, c.dir_entropy,
+                c.author_experience, c.message_length, c.message_lines,
+                c.uast_nodes_added, c.uast_nodes_removed))
+        return "\n".join(lines) + "\n"
+
     def _parse_burndown_matrix(self, matrix):
         dense = numpy.zeros((matrix.number_of_rows, matrix.number_of_columns), dtype=int)
         for y, row in enumerate(matrix.rows):
@@ -337,7 +581,11 @@ def read_input(args):
     sys.stdout.flush()
     if args.input != "-":
         if args.input_format == "auto":
-            args.input_format = args.input.rsplit(".", 1)[1]
+            name = args.input
+            for ext in COMPRESSION_EXTENSIONS:
+                if name.endswith(ext):
+                    name = name[:-len(ext)]
+            args.input_format = name.rsplit(".", 1)[1]
     elif args.input_format == "auto":
         args.input_format = "yaml"
     reader = READERS[args.input_format]()
@@ -616,6 +864,20 @@ def apply_plot_style(figure, axes, legend, style, text_size, axes_size):
             text.set_color(style)
 
 
+def draw_markers(pyplot, markers, style, text_size):
+    import matplotlib.dates
+
+    xmin, xmax = pyplot.xlim()
+    ytop = pyplot.ylim()[1]
+    for label, unix_time in markers:
+        x = matplotlib.dates.date2num(datetime.fromtimestamp(unix_time))
+        if x < xmin or x > xmax:
+            continue
+        pyplot.axvline(x, color=style, linestyle="--", linewidth=1, alpha=0.6)
+        pyplot.text(x, ytop, " " + label, rotation=90, va="top", ha="right",
+                    fontsize=text_size, color=style)
+
+
 def get_plot_path(base, name):
     root, ext = os.path.splitext(base)
     if not ext:
@@ -651,7 +913,7 @@ def default_json(x):
 
 
 def plot_burndown(args, target, name, matrix, date_range_sampling, labels, granularity,
-                  sampling, resample):
+                  sampling, resample, markers=()):
     if args.output and args.output.endswith(".json"):
         data = locals().copy()
         del data["args"]
@@ -684,6 +946,7 @@ def plot_burndown(args, target, name, matrix, date_range_sampling, labels, granu
     pyplot.xlabel("Time")
     apply_plot_style(pyplot.gcf(), pyplot.gca(), legend, args.style, args.text_size, args.size)
     pyplot.xlim(date_range_sampling[0], date_range_sampling[-1])
+    draw_markers(pyplot, markers, args.style, args.text_size)
     locator = pyplot.gca().xaxis.get_major_locator()
     # set the optimal xticks locator
     if "M" not in resample:
@@ -730,7 +993,7 @@ def plot_burndown(args, target, name, matrix, date_range_sampling, labels, granu
     deploy_plot(title, output, args.style)
 
 
-def plot_many_burndown(args, target, header, parts):
+def plot_many_burndown(args, target, header, parts, markers=()):
     if not args.output:
         print("Warning: output not set, showing %d plots." % len(parts))
     itercnt = progress.bar(parts, expected_size=len(parts)) \
@@ -739,7 +1002,8 @@ def plot_many_burndown(args, target, header, parts):
     for name, matrix in itercnt:
         backup = sys.stdout
         sys.stdout = stdout
-        plot_burndown(args, target, *load_burndown(header, name, matrix, args.resample))
+        plot_burndown(args, target, *load_burndown(header, name, matrix, args.resample),
+                      markers=markers)
         sys.stdout = backup
     sys.stdout.write(stdout.getvalue())
 
@@ -790,7 +1054,7 @@ def plot_churn_matrix(args, repo, people, matrix):
     deploy_plot(title, output, args.style)
 
 
-def plot_ownership(args, repo, names, people, date_range, last):
+def plot_ownership(args, repo, names, people, date_range, last, markers=()):
     if args.output and args.output.endswith(".json"):
         data = locals().copy()
         del data["args"]
@@ -819,6 +1083,7 @@ def plot_ownership(args, repo, names, people, date_range, last):
         legend_loc = 2
     legend = pyplot.legend(loc=legend_loc, fontsize=args.text_size)
     apply_plot_style(pyplot.gcf(), pyplot.gca(), legend, args.style, args.text_size, args.size)
+    draw_markers(pyplot, markers, args.style, args.text_size)
     if args.mode == "all":
         output = get_plot_path(args.output, "people")
     else:
@@ -1047,7 +1312,18 @@ def show_shotness_stats(data):
         print("%8d  %s:%s [%s]" % (count, r.file, r.name, r.internal_role))
 
 
-def show_sentiment_stats(args, name, resample, start, data):
+def write_commit_features(output, data):
+    if not output:
+        sys.stdout.write(data)
+        return
+    if not output.endswith(".csv"):
+        output += ".csv"
+    with open(output, "w") as fout:
+        fout.write(data)
+    print("Wrote", output)
+
+
+def show_sentiment_stats(args, name, resample, start, data, markers=()):
     import matplotlib
     if args.backend:
         matplotlib.use(args.backend)
@@ -1075,6 +1351,7 @@ def show_sentiment_stats(args, name, resample, start, data):
     pyplot.xlabel("Time")
     apply_plot_style(pyplot.gcf(), pyplot.gca(), legend, args.style, args.text_size, args.size)
     pyplot.xlim(xdates[0], xdates[-1])
+    draw_markers(pyplot, markers, args.style, args.text_size)
     locator = pyplot.gca().xaxis.get_major_locator()
     # set the optimal xticks locator
     if "M" not in resample:
@@ -1121,11 +1398,15 @@ def main():
     reader = read_input(args)
     header = reader.get_header()
     name = reader.get_name()
+    markers = reader.get_markers()
 
     burndown_warning = "Burndown stats were not collected. Re-run hercules with --burndown."
     burndown_files_warning = \
         "Burndown stats for files were not collected. Re-run hercules with " \
         "--burndown --burndown-files."
+    burndown_groups_warning = \
+        "Burndown stats for extension groups were not collected. Re-run hercules with " \
+        "--burndown --burndown-groups=\"name=.ext1,.ext2;...\"."
     burndown_people_warning = \
         "Burndown stats for people were not collected. Re-run hercules with " \
         "--burndown --burndown-people."
@@ -1133,36 +1414,58 @@ def main():
     shotness_warning = "Structural hotness stats were not collected. Re-run hercules with " \
                        "--shotness. Also check --languages - the output may be empty."
     sentiment_warning = "Sentiment stats were not collected. Re-run hercules with --sentiment."
+    features_warning = "Commit features were not collected. Re-run hercules with " \
+                       "--commit-features."
+
+    def burndown_header():
+        # the matrices begin before the first commit if the initial lines were spread
+        backfill = reader.get_burndown_backfill_days() * 24 * 3600
+        return (header[0] - backfill, header[1]) + reader.get_burndown_parameters()
 
     def project_burndown():
         try:
-            full_header = header + reader.get_burndown_parameters()
+            full_header = burndown_header()
         except KeyError:
             print("project: " + burndown_warning)
             return
         plot_burndown(args, "project",
                       *load_burndown(full_header, *reader.get_project_burndown(),
-                                     resample=args.resample))
+                                     resample=args.resample),
+                      markers=markers)
 
     def files_burndown():
         try:
-            full_header = header + reader.get_burndown_parameters()
+            full_header = burndown_header()
         except KeyError:
             print(burndown_warning)
             return
         try:
-            plot_many_burndown(args, "file", full_header, reader.get_files_burndown())
+            plot_many_burndown(args, "file", full_header, reader.get_files_burndown(),
+                               markers=markers)
         except KeyError:
             print("files: " + burndown_files_warning)
 
+    def groups_burndown():
+        try:
+            full_header = burndown_header()
+        except KeyError:
+            print(burndown_warning)
+            return
+        try:
+            plot_many_burndown(args, "group", full_header, reader.get_groups_burndown(),
+                               markers=markers)
+        except KeyError:
+            print("groups: " + burndown_groups_warning)
+
     def people_burndown():
         try:
-            full_header = header + reader.get_burndown_parameters()
+            full_header = burndown_header()
         except KeyError:
             print(burndown_warning)
             return
         try:
-            plot_many_burndown(args, "person", full_header, reader.get_people_burndown())
+            plot_many_burndown(args, "person", full_header, reader.get_people_burndown(),
+                               markers=markers)
         except KeyError:
             print("people: " + burndown_people_warning)
 
@@ -1175,13 +1478,14 @@ def main():
 
     def ownership_burndown():
         try:
-            full_header = header + reader.get_burndown_parameters()
+            full_header = burndown_header()
         except KeyError:
             print(burndown_warning)
             return
         try:
             plot_ownership(args, name, *load_ownership(
-                full_header, *reader.get_ownership_burndown(), max_people=args.max_people))
+                full_header, *reader.get_ownership_burndown(), max_people=args.max_people),
+                markers=markers)
         except KeyError:
             print("ownership: " + burndown_people_warning)
 
@@ -1216,12 +1520,23 @@ def main():
         except KeyError:
             print(sentiment_warning)
             return
-        show_sentiment_stats(args, reader.get_name(), args.resample, reader.get_header()[0], data)
+        show_sentiment_stats(args, reader.get_name(), args.resample, reader.get_header()[0], data,
+                             markers=markers)
+
+    def features():
+        try:
+            data = reader.get_commit_features()
+        except KeyError:
+            print(features_warning)
+            return
+        write_commit_features(args.output, data)
 
     if args.mode == "project":
         project_burndown()
     elif args.mode == "file":
         files_burndown()
+    elif args.mode == "group":
+        groups_burndown()
     elif args.mode == "person":
         people_burndown()
     elif args.mode == "churn_matrix":
@@ -1234,9 +1549,12 @@ def main():
         shotness()
     elif args.mode == "sentiment":
         sentiment()
+    elif args.mode == "features":
+        features()
     elif args.mode == "all":
         project_burndown()
         files_burndown()
+        groups_burndown()
         people_burndown()
         churn_matrix()
         ownership_burndown()
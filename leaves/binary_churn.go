@@ -0,0 +1,291 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// BinaryChurnAnalysis tracks the binary files - images, archives, models, etc. - which
+// the line-based analyses ignore. It counts the changes of the binary files and the sizes
+// of the added and the removed binary blobs in each month, grouped by directory, and reports
+// the binary files in the last analysed revision. Every added version of a binary file stays
+// in the repository forever, so the added bytes estimate how much the history grows.
+// It is a LeafPipelineItem.
+type BinaryChurnAnalysis struct {
+	// files maps the paths of the binary files to their sizes.
+	files map[string]int64
+	// months maps the month to the directory to the stats.
+	months map[string]map[string]*BinaryChurnStats
+}
+
+// BinaryChurnStats are the changes of the binary files in a directory in a month.
+type BinaryChurnStats struct {
+	// Changes is the number of added, modified and deleted binary files.
+	Changes int
+	// AddedBytes is the total size of the added binary blobs.
+	AddedBytes int64
+	// RemovedBytes is the total size of the removed binary blobs.
+	RemovedBytes int64
+}
+
+// BinaryAssets are the binary files in a directory.
+type BinaryAssets struct {
+	// Files is the number of binary files.
+	Files int
+	// Bytes is their total size.
+	Bytes int64
+}
+
+// BinaryChurnResult is returned by BinaryChurnAnalysis.Finalize() and carries the binary
+// file changes.
+type BinaryChurnResult struct {
+	// Months maps the month ("2018-03") to the directory to the stats. The root directory is "/".
+	Months map[string]map[string]BinaryChurnStats
+	// Current maps the directory to the binary files in the last analysed revision.
+	Current map[string]BinaryAssets
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (churn *BinaryChurnAnalysis) Name() string {
+	return "BinaryChurn"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (churn *BinaryChurnAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (churn *BinaryChurnAnalysis) Requires() []string {
+	arr := [...]string{items.DependencyTreeChanges, items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (churn *BinaryChurnAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (churn *BinaryChurnAnalysis) Flag() string {
+	return "binary-churn"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (churn *BinaryChurnAnalysis) Configure(facts map[string]interface{}) {
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (churn *BinaryChurnAnalysis) Initialize(repository *git.Repository) {
+	churn.files = map[string]int64{}
+	churn.months = map[string]map[string]*BinaryChurnStats{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (churn *BinaryChurnAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	month := commit.Author.When.UTC().Format("2006-01")
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		var size int64
+		isBinary := false
+		if action != merkletrie.Delete {
+			size, isBinary, err = binaryBlobSize(cache[change.To.TreeEntry.Hash])
+			if err != nil {
+				return nil, err
+			}
+		}
+		var oldSize int64
+		wasBinary := false
+		if action != merkletrie.Insert {
+			oldSize, wasBinary = churn.files[change.From.Name]
+		}
+		if !isBinary && !wasBinary {
+			continue
+		}
+		name := change.From.Name
+		if isBinary {
+			name = change.To.Name
+		}
+		stats := churn.getStats(month, name)
+		stats.Changes++
+		if wasBinary {
+			delete(churn.files, change.From.Name)
+		}
+		if isBinary {
+			churn.files[change.To.Name] = size
+		}
+		if wasBinary && isBinary && change.From.TreeEntry.Hash == change.To.TreeEntry.Hash {
+			// pure rename, no new blobs
+			continue
+		}
+		if wasBinary {
+			stats.RemovedBytes += oldSize
+		}
+		if isBinary {
+			stats.AddedBytes += size
+		}
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (churn *BinaryChurnAnalysis) Finalize() interface{} {
+	months := map[string]map[string]BinaryChurnStats{}
+	for month, dirs := range churn.months {
+		months[month] = map[string]BinaryChurnStats{}
+		for dir, stats := range dirs {
+			months[month][dir] = *stats
+		}
+	}
+	current := map[string]BinaryAssets{}
+	for name, size := range churn.files {
+		dir := path.Dir(name)
+		if dir == "." {
+			dir = burndownRootDirectory
+		}
+		assets := current[dir]
+		assets.Files++
+		assets.Bytes += size
+		current[dir] = assets
+	}
+	return BinaryChurnResult{Months: months, Current: current}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (churn *BinaryChurnAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	churnResult := result.(BinaryChurnResult)
+	if binary {
+		return churn.serializeBinary(&churnResult, writer)
+	}
+	churn.serializeText(&churnResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (churn *BinaryChurnAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(churn, result, &pb.BinaryChurnResults{}, writer)
+}
+
+func (churn *BinaryChurnAnalysis) serializeText(result *BinaryChurnResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  # changes, added bytes, removed bytes")
+	fmt.Fprintln(writer, "  months:")
+	months := make([]string, 0, len(result.Months))
+	for month := range result.Months {
+		months = append(months, month)
+	}
+	sort.Strings(months)
+	for _, month := range months {
+		fmt.Fprintf(writer, "    %s:\n", yaml.SafeString(month))
+		dirs := make([]string, 0, len(result.Months[month]))
+		for dir := range result.Months[month] {
+			dirs = append(dirs, dir)
+		}
+		sort.Strings(dirs)
+		for _, dir := range dirs {
+			stats := result.Months[month][dir]
+			fmt.Fprintf(writer, "      %s: [%d, %d, %d]\n", yaml.SafeString(dir),
+				stats.Changes, stats.AddedBytes, stats.RemovedBytes)
+		}
+	}
+	fmt.Fprintln(writer, "  # files, bytes")
+	fmt.Fprintln(writer, "  current:")
+	dirs := make([]string, 0, len(result.Current))
+	for dir := range result.Current {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		assets := result.Current[dir]
+		fmt.Fprintf(writer, "    %s: [%d, %d]\n", yaml.SafeString(dir), assets.Files, assets.Bytes)
+	}
+}
+
+func (churn *BinaryChurnAnalysis) serializeBinary(result *BinaryChurnResult, writer io.Writer) error {
+	message := pb.BinaryChurnResults{
+		Months:  map[string]*pb.BinaryChurnDirectories{},
+		Current: map[string]*pb.BinaryAssets{},
+	}
+	for month, dirs := range result.Months {
+		pbDirs := &pb.BinaryChurnDirectories{Directories: map[string]*pb.BinaryChurnStats{}}
+		for dir, stats := range dirs {
+			pbDirs.Directories[dir] = &pb.BinaryChurnStats{
+				Changes:      int32(stats.Changes),
+				AddedBytes:   stats.AddedBytes,
+				RemovedBytes: stats.RemovedBytes,
+			}
+		}
+		message.Months[month] = pbDirs
+	}
+	for dir, assets := range result.Current {
+		message.Current[dir] = &pb.BinaryAssets{Files: int32(assets.Files), Bytes: assets.Bytes}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+// getStats returns the stats of the directory of the file in the month.
+func (churn *BinaryChurnAnalysis) getStats(month string, name string) *BinaryChurnStats {
+	dirs := churn.months[month]
+	if dirs == nil {
+		dirs = map[string]*BinaryChurnStats{}
+		churn.months[month] = dirs
+	}
+	dir := path.Dir(name)
+	if dir == "." {
+		dir = burndownRootDirectory
+	}
+	stats := dirs[dir]
+	if stats == nil {
+		stats = &BinaryChurnStats{}
+		dirs[dir] = stats
+	}
+	return stats
+}
+
+// binaryBlobSize returns the size of the blob and whether it is binary.
+func binaryBlobSize(blob *object.Blob) (int64, bool, error) {
+	_, err := items.CountLines(blob)
+	if err != nil {
+		if err.Error() == "binary" {
+			return blob.Size, true, nil
+		}
+		return 0, false, err
+	}
+	return blob.Size, false, nil
+}
+
+func init() {
+	core.Registry.Register(&BinaryChurnAnalysis{})
+}
@@ -0,0 +1,143 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureBinaryChurn() *BinaryChurnAnalysis {
+	churn := BinaryChurnAnalysis{}
+	churn.Configure(map[string]interface{}{})
+	churn.Initialize(test.Repository)
+	return &churn
+}
+
+func fixtureBinaryChurnDeps(month time.Month, changes object.Changes,
+	blobs ...*object.Blob) map[string]interface{} {
+	deps := map[string]interface{}{}
+	deps["commit"] = &object.Commit{
+		Author: object.Signature{When: time.Date(2018, month, 10, 0, 0, 0, 0, time.UTC)}}
+	deps[items.DependencyTreeChanges] = changes
+	cache := map[plumbing.Hash]*object.Blob{}
+	for _, blob := range blobs {
+		cache[blob.Hash] = blob
+	}
+	deps[items.DependencyBlobCache] = cache
+	return deps
+}
+
+func TestBinaryChurnMeta(t *testing.T) {
+	churn := fixtureBinaryChurn()
+	assert.Equal(t, churn.Name(), "BinaryChurn")
+	assert.Len(t, churn.Provides(), 0)
+	assert.Equal(t, churn.Requires(), []string{
+		items.DependencyTreeChanges, items.DependencyBlobCache})
+	assert.Len(t, churn.ListConfigurationOptions(), 0)
+	assert.Equal(t, churn.Flag(), "binary-churn")
+}
+
+func TestBinaryChurnRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&BinaryChurnAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "BinaryChurn")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&BinaryChurnAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestBinaryChurnConsumeFinalize(t *testing.T) {
+	churn := fixtureBinaryChurn()
+	logo := fixtureChurnOriginBlob("\x89PNG\r\n\x1a\n\xff\xfe")
+	logo2 := fixtureChurnOriginBlob("\x89PNG\r\n\x1a\n\xff\xfe\xfd\xfc")
+	model := fixtureChurnOriginBlob("\xff\xff\xff")
+	text := fixtureChurnOriginBlob("text\n")
+	entry := func(name string, blob *object.Blob) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: blob.Hash}}
+	}
+	result, err := churn.Consume(fixtureBinaryChurnDeps(time.January, object.Changes{
+		{To: entry("img/logo.png", logo)},
+		{To: entry("model.bin", model)},
+		{To: entry("README", text)},
+	}, logo, model, text))
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	// modification, pure rename and binary -> text
+	_, err = churn.Consume(fixtureBinaryChurnDeps(time.February, object.Changes{
+		{From: entry("img/logo.png", logo), To: entry("img/logo.png", logo2)},
+		{From: entry("model.bin", model), To: entry("models/model.bin", model)},
+		{From: entry("README", text), To: entry("README", text)},
+	}, logo2, model, text))
+	assert.Nil(t, err)
+	_, err = churn.Consume(fixtureBinaryChurnDeps(time.February, object.Changes{
+		{From: entry("models/model.bin", model), To: entry("models/model.bin", text)},
+		{From: entry("README", text)},
+	}, text))
+	assert.Nil(t, err)
+	assert.Equal(t, churn.files, map[string]int64{"img/logo.png": 12})
+	_, err = churn.Consume(fixtureBinaryChurnDeps(time.March, object.Changes{
+		{To: entry("broken", logo)},
+	}))
+	assert.NotNil(t, err)
+	res := churn.Finalize().(BinaryChurnResult)
+	assert.Equal(t, res.Months, map[string]map[string]BinaryChurnStats{
+		"2018-01": {
+			"img": {Changes: 1, AddedBytes: 10},
+			"/":   {Changes: 1, AddedBytes: 3},
+		},
+		"2018-02": {
+			"img":    {Changes: 1, AddedBytes: 12, RemovedBytes: 10},
+			"models": {Changes: 2, RemovedBytes: 3},
+		},
+	})
+	assert.Equal(t, res.Current, map[string]BinaryAssets{"img": {Files: 1, Bytes: 12}})
+}
+
+func TestBinaryChurnSerialize(t *testing.T) {
+	churn := fixtureBinaryChurn()
+	res := BinaryChurnResult{
+		Months: map[string]map[string]BinaryChurnStats{
+			"2018-02": {"img": {Changes: 1, AddedBytes: 12, RemovedBytes: 10}},
+			"2018-01": {"img": {Changes: 1, AddedBytes: 10}, "/": {Changes: 1, AddedBytes: 3}},
+		},
+		Current: map[string]BinaryAssets{"img": {Files: 1, Bytes: 12}, "/": {Files: 2, Bytes: 7}},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, churn.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  # changes, added bytes, removed bytes
+  months:
+    "2018-01":
+      "/": [1, 3, 0]
+      "img": [1, 10, 0]
+    "2018-02":
+      "img": [1, 12, 10]
+  # files, bytes
+  current:
+    "/": [2, 7]
+    "img": [1, 12]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, churn.Serialize(res, true, buffer))
+	msg := pb.BinaryChurnResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Months, 2)
+	assert.Equal(t, *msg.Months["2018-02"].Directories["img"],
+		pb.BinaryChurnStats{Changes: 1, AddedBytes: 12, RemovedBytes: 10})
+	assert.Equal(t, *msg.Current["/"], pb.BinaryAssets{Files: 2, Bytes: 7})
+}
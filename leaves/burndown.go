@@ -1,11 +1,17 @@
 package leaves
 
 import (
+	"bytes"
+	"encoding/gob"
 	"errors"
 	"fmt"
 	"io"
 	"log"
+	"path"
+	"reflect"
+	"regexp"
 	"sort"
+	"strings"
 	"sync"
 	"unicode/utf8"
 
@@ -39,6 +45,19 @@ type BurndownAnalysis struct {
 	// It does not change the project level burndown results.
 	TrackFiles bool
 
+	// FileGlobs limits the per-file burndown to the files which match any of the path globs,
+	// e.g. "src/core/**", so that the critical modules are tracked without the memory cost
+	// of all the files. It implies TrackFiles. The glob syntax is the same as in ScopedLeaf.
+	FileGlobs []string
+
+	// TrackDirectories enables or disables the hierarchical per-directory burndown output.
+	// The directory matrices are the sums of the per-file matrices, so it implies TrackFiles.
+	TrackDirectories bool
+
+	// TopFiles is the number of the files with the most alive lines to write; the rest are
+	// summed into TopOtherName. 0 writes all the files. The directories are not affected.
+	TopFiles int
+
 	// The number of developers for which to collect the burndown stats. 0 disables it.
 	PeopleNumber int
 
@@ -47,6 +66,24 @@ type BurndownAnalysis struct {
 	// violations.
 	Debug bool
 
+	// ExtensionGroups maps the group names to the file extensions, e.g. "frontend" -> [".ts", ".tsx"].
+	// Each group has a separate burndown matrix. The group of a file is determined when
+	// the file is created.
+	ExtensionGroups map[string][]string
+
+	// DetectMoves preserves the ages of the blocks of lines which were moved within a file or
+	// between the files in the same commit instead of treating them as deleted and inserted.
+	DetectMoves bool
+
+	// InitialCommit is the policy for the lines of the first analysed commit, which is often
+	// a single giant import from another VCS: BurndownInitialCommitKeep,
+	// BurndownInitialCommitSpread or BurndownInitialCommitExclude.
+	InitialCommit string
+
+	// BackfillDays is the length of the synthetic pre-history window in days which
+	// BurndownInitialCommitSpread distributes the initial lines over.
+	BackfillDays int
+
 	// Repository points to the analysed Git repository struct from go-git.
 	repository *git.Repository
 	// globalStatus is the current daily alive number of lines; key is the number
@@ -56,6 +93,15 @@ type BurndownAnalysis struct {
 	globalHistory [][]int64
 	// fileHistories is the periodic snapshots of each file's status.
 	fileHistories map[string][][]int64
+	// filePatterns are the compiled FileGlobs.
+	filePatterns []*regexp.Regexp
+	// groupStatuses are the same as globalStatus for each extension group.
+	groupStatuses map[string]map[int]int64
+	// groupHistories is the periodic snapshots of each extension group's status.
+	groupHistories map[string][][]int64
+	// extensionGroups maps the file extensions to the group names, it is the inverse of
+	// ExtensionGroups.
+	extensionGroups map[string]string
 	// peopleHistories is the periodic snapshots of each person's status.
 	peopleHistories [][][]int64
 	// files is the mapping <file path> -> *File.
@@ -71,6 +117,18 @@ type BurndownAnalysis struct {
 	previousDay int
 	// references IdentityDetector.ReversedPeopleDict
 	reversedPeopleDict []string
+	// moves are the moved lines in the current commit, nil if there are none.
+	moves *burndownMoves
+	// moving suppresses the updates of the overwrites matrix while the moved lines are transferred.
+	moving bool
+	// initial indicates that the first commit is being consumed.
+	initial bool
+	// dayOffset shifts the days by the synthetic pre-history window.
+	dayOffset int
+	// commit is the last consumed commit, the merge commits compare its tree with the branches.
+	commit *object.Commit
+	// merged are the branches which the next commit merges, see Merge().
+	merged []*BurndownAnalysis
 }
 
 // BurndownResult carries the result of running BurndownAnalysis - it is returned by
@@ -83,6 +141,9 @@ type BurndownResult struct {
 	// The key is the path inside the Git repository. The value's dimensions are the same as
 	// in GlobalHistory.
 	FileHistories map[string][][]int64
+	// The key is the name of the extension group. The value's dimensions are the same as
+	// in GlobalHistory.
+	GroupHistories map[string][][]int64
 	// [number of people][number of samples][number of bands]
 	PeopleHistories [][][]int64
 	// [number of people][number of people + 2]
@@ -102,6 +163,13 @@ type BurndownResult struct {
 	// such as merging several results together.
 	sampling    int
 	granularity int
+	// trackDirectories indicates whether the serializers should write the per-directory matrices.
+	trackDirectories bool
+	// initialCommit is BurndownAnalysis.InitialCommit, empty means BurndownInitialCommitKeep.
+	initialCommit string
+	// backfillDays is the number of synthetic days before the first commit in the matrices,
+	// that is, the first band starts that many days before CommonAnalysisResult.BeginTime.
+	backfillDays int
 }
 
 const (
@@ -111,13 +179,42 @@ const (
 	ConfigBurndownSampling = "Burndown.Sampling"
 	// ConfigBurndownTrackFiles enables burndown collection for files.
 	ConfigBurndownTrackFiles = "Burndown.TrackFiles"
+	// ConfigBurndownFileGlobs is the name of the option to set BurndownAnalysis.FileGlobs.
+	ConfigBurndownFileGlobs = "Burndown.FileGlobs"
+	// ConfigBurndownTrackDirectories enables the hierarchical burndown output for directories.
+	ConfigBurndownTrackDirectories = "Burndown.TrackDirectories"
 	// ConfigBurndownTrackPeople enables burndown collection for authors.
 	ConfigBurndownTrackPeople = "Burndown.TrackPeople"
 	// ConfigBurndownDebug enables some extra debug assertions.
 	ConfigBurndownDebug = "Burndown.Debug"
+	// ConfigBurndownExtensionGroups is the name of the option to set
+	// BurndownAnalysis.ExtensionGroups. The format is "name=.ext1,.ext2;name2=.ext3".
+	ConfigBurndownExtensionGroups = "Burndown.ExtensionGroups"
+	// ConfigBurndownDetectMoves is the name of the option to set BurndownAnalysis.DetectMoves.
+	ConfigBurndownDetectMoves = "Burndown.DetectMoves"
+	// ConfigBurndownTopFiles is the name of the option to set BurndownAnalysis.TopFiles.
+	ConfigBurndownTopFiles = "Burndown.TopFiles"
+	// ConfigBurndownInitialCommit is the name of the option to set BurndownAnalysis.InitialCommit.
+	ConfigBurndownInitialCommit = "Burndown.InitialCommit"
+	// ConfigBurndownBackfillDays is the name of the option to set BurndownAnalysis.BackfillDays.
+	ConfigBurndownBackfillDays = "Burndown.BackfillDays"
+	// BurndownInitialCommitKeep attributes the lines of the initial commit to its day.
+	BurndownInitialCommitKeep = "keep"
+	// BurndownInitialCommitSpread distributes the lines of the initial commit evenly over
+	// the synthetic pre-history window of BurndownAnalysis.BackfillDays days.
+	BurndownInitialCommitSpread = "spread"
+	// BurndownInitialCommitExclude does not count the lines of the initial commit in any band.
+	BurndownInitialCommitExclude = "exclude"
+	// DefaultBurndownBackfillDays is the default value of BurndownAnalysis.BackfillDays.
+	DefaultBurndownBackfillDays = 365
 	// DefaultBurndownGranularity is the default number of days for BurndownAnalysis.Granularity
 	// and BurndownAnalysis.Sampling.
 	DefaultBurndownGranularity = 30
+	// burndownRootDirectory is the name of the repository root in the per-directory results.
+	burndownRootDirectory = "/"
+	// burndownExcludedDay is the day of the excluded initial lines; the statuses ignore it.
+	// It is the maximum day which packPersonWithDay() supports.
+	burndownExcludedDay = 0x3FFF
 	// authorSelf is the internal author index which is used in BurndownAnalysis.Finalize() to
 	// format the author overwrites matrix.
 	authorSelf = (1 << 18) - 2
@@ -163,6 +260,17 @@ func (analyser *BurndownAnalysis) ListConfigurationOptions() []core.Configuratio
 		Flag:        "burndown-files",
 		Type:        core.BoolConfigurationOption,
 		Default:     false}, {
+		Name: ConfigBurndownFileGlobs,
+		Description: "Record detailed statistics only for the files which match these path " +
+			"globs, e.g. \"src/core/**\"; implies --burndown-files.",
+		Flag:    "burndown-file-globs",
+		Type:    core.StringsConfigurationOption,
+		Default: []string{}}, {
+		Name:        ConfigBurndownTrackDirectories,
+		Description: "Record hierarchical statistics per each directory; implies --burndown-files.",
+		Flag:        "burndown-directories",
+		Type:        core.BoolConfigurationOption,
+		Default:     false}, {
 		Name:        ConfigBurndownTrackPeople,
 		Description: "Record detailed statistics per each developer.",
 		Flag:        "burndown-people",
@@ -172,7 +280,35 @@ func (analyser *BurndownAnalysis) ListConfigurationOptions() []core.Configuratio
 		Description: "Validate the trees on each step.",
 		Flag:        "burndown-debug",
 		Type:        core.BoolConfigurationOption,
-		Default:     false},
+		Default:     false}, {
+		Name: ConfigBurndownExtensionGroups,
+		Description: "Record separate statistics per each group of file extensions, " +
+			"e.g. \"frontend=.ts,.tsx;backend=.go\".",
+		Flag:    "burndown-groups",
+		Type:    core.StringConfigurationOption,
+		Default: ""}, {
+		Name:        ConfigBurndownDetectMoves,
+		Description: "Keep the ages of the lines which were moved within or between the files.",
+		Flag:        "burndown-detect-moves",
+		Type:        core.BoolConfigurationOption,
+		Default:     false}, {
+		Name: ConfigBurndownTopFiles,
+		Description: "Write only this number of the files with the most alive lines and sum " +
+			"the rest into \"" + TopOtherName + "\". 0 writes all the files.",
+		Flag:    "burndown-top-files",
+		Type:    core.IntConfigurationOption,
+		Default: 0}, {
+		Name: ConfigBurndownInitialCommit,
+		Description: "What to do with the lines of the first commit, e.g. an import from SVN: " +
+			"\"keep\", \"spread\" them over the synthetic pre-history or \"exclude\" them.",
+		Flag:    "burndown-initial-commit",
+		Type:    core.StringConfigurationOption,
+		Default: BurndownInitialCommitKeep}, {
+		Name:        ConfigBurndownBackfillDays,
+		Description: "The length of the synthetic pre-history in days for --burndown-initial-commit=spread.",
+		Flag:        "burndown-backfill-days",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultBurndownBackfillDays},
 	}
 	return options[:]
 }
@@ -188,6 +324,12 @@ func (analyser *BurndownAnalysis) Configure(facts map[string]interface{}) {
 	if val, exists := facts[ConfigBurndownTrackFiles].(bool); exists {
 		analyser.TrackFiles = val
 	}
+	if val, exists := facts[ConfigBurndownFileGlobs].([]string); exists {
+		analyser.FileGlobs = val
+	}
+	if val, exists := facts[ConfigBurndownTrackDirectories].(bool); exists {
+		analyser.TrackDirectories = val
+	}
 	if people, exists := facts[ConfigBurndownTrackPeople].(bool); people {
 		if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
 			analyser.PeopleNumber = val
@@ -199,6 +341,55 @@ func (analyser *BurndownAnalysis) Configure(facts map[string]interface{}) {
 	if val, exists := facts[ConfigBurndownDebug].(bool); exists {
 		analyser.Debug = val
 	}
+	if val, exists := facts[ConfigBurndownExtensionGroups].(string); exists {
+		groups, err := ParseExtensionGroups(val)
+		if err != nil {
+			log.Printf("Warning: ignored the extension groups: %v\n", err)
+		}
+		analyser.ExtensionGroups = groups
+	}
+	if val, exists := facts[ConfigBurndownDetectMoves].(bool); exists {
+		analyser.DetectMoves = val
+	}
+	if val, exists := facts[ConfigBurndownTopFiles].(int); exists {
+		analyser.TopFiles = val
+	}
+	if val, exists := facts[ConfigBurndownInitialCommit].(string); exists {
+		analyser.InitialCommit = val
+	}
+	if val, exists := facts[ConfigBurndownBackfillDays].(int); exists {
+		analyser.BackfillDays = val
+	}
+}
+
+// ParseExtensionGroups converts the value of ConfigBurndownExtensionGroups to
+// BurndownAnalysis.ExtensionGroups.
+func ParseExtensionGroups(value string) (map[string][]string, error) {
+	groups := map[string][]string{}
+	for _, group := range strings.Split(value, ";") {
+		if strings.TrimSpace(group) == "" {
+			continue
+		}
+		parts := strings.SplitN(group, "=", 2)
+		name := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || name == "" {
+			return nil, fmt.Errorf("invalid extension group %q, expected name=.ext1,.ext2", group)
+		}
+		for _, ext := range strings.Split(parts[1], ",") {
+			ext = strings.TrimSpace(ext)
+			if ext == "" {
+				continue
+			}
+			if !strings.HasPrefix(ext, ".") {
+				ext = "." + ext
+			}
+			groups[name] = append(groups[name], ext)
+		}
+		if len(groups[name]) == 0 {
+			return nil, fmt.Errorf("extension group %s is empty", name)
+		}
+	}
+	return groups, nil
 }
 
 // Flag for the command line switch which enables this analysis.
@@ -224,16 +415,63 @@ func (analyser *BurndownAnalysis) Initialize(repository *git.Repository) {
 			analyser.Granularity)
 		analyser.Sampling = analyser.Granularity
 	}
+	if analyser.TrackDirectories && !analyser.TrackFiles {
+		log.Println("Warning: enabled the per-file burndown which the per-directory burndown requires")
+		analyser.TrackFiles = true
+	}
+	analyser.filePatterns = nil
+	for _, glob := range analyser.FileGlobs {
+		pattern, err := compileGlob(glob)
+		if err != nil {
+			log.Printf("Warning: ignored the file glob %q: %v\n", glob, err)
+			continue
+		}
+		analyser.filePatterns = append(analyser.filePatterns, pattern)
+	}
+	if len(analyser.filePatterns) > 0 {
+		// only the matching files carry the local statuses, see localStatus()
+		analyser.TrackFiles = true
+	}
+	switch analyser.InitialCommit {
+	case "":
+		analyser.InitialCommit = BurndownInitialCommitKeep
+	case BurndownInitialCommitKeep, BurndownInitialCommitSpread, BurndownInitialCommitExclude:
+	default:
+		log.Printf("Warning: unknown initial commit policy %q, adjusted to %s\n",
+			analyser.InitialCommit, BurndownInitialCommitKeep)
+		analyser.InitialCommit = BurndownInitialCommitKeep
+	}
+	if analyser.InitialCommit == BurndownInitialCommitSpread && analyser.BackfillDays <= 0 {
+		log.Printf("Warning: adjusted the backfill window to %d days\n", DefaultBurndownBackfillDays)
+		analyser.BackfillDays = DefaultBurndownBackfillDays
+	}
+	analyser.dayOffset = 0
+	if analyser.InitialCommit == BurndownInitialCommitSpread {
+		analyser.dayOffset = analyser.BackfillDays
+	}
 	analyser.repository = repository
 	analyser.globalStatus = map[int]int64{}
 	analyser.globalHistory = [][]int64{}
 	analyser.fileHistories = map[string][][]int64{}
+	analyser.groupStatuses = map[string]map[int]int64{}
+	analyser.groupHistories = map[string][][]int64{}
+	analyser.extensionGroups = map[string]string{}
+	for name, extensions := range analyser.ExtensionGroups {
+		analyser.groupStatuses[name] = map[int]int64{}
+		for _, ext := range extensions {
+			analyser.extensionGroups[strings.ToLower(ext)] = name
+		}
+	}
 	analyser.peopleHistories = make([][][]int64, analyser.PeopleNumber)
 	analyser.files = map[string]*burndown.File{}
 	analyser.matrix = make([]map[int]int64, analyser.PeopleNumber)
 	analyser.people = make([]map[int]int64, analyser.PeopleNumber)
 	analyser.day = 0
 	analyser.previousDay = 0
+	analyser.moves = nil
+	analyser.initial = true
+	analyser.commit = nil
+	analyser.merged = nil
 }
 
 // Consume runs this PipelineItem on the next commit data.
@@ -247,16 +485,26 @@ func (analyser *BurndownAnalysis) Consume(deps map[string]interface{}) (map[stri
 		sampling = 1
 	}
 	author := deps[identity.DependencyAuthor].(int)
-	analyser.day = deps[items.DependencyDay].(int)
+	analyser.day = deps[items.DependencyDay].(int) + analyser.dayOffset
 	delta := (analyser.day / sampling) - (analyser.previousDay / sampling)
 	if delta > 0 {
 		analyser.previousDay = analyser.day
-		gs, fss, pss := analyser.groupStatus()
-		analyser.updateHistories(gs, fss, pss, delta)
+		gs, fss, grs, pss := analyser.groupStatus()
+		analyser.updateHistories(gs, fss, grs, pss, delta)
+	}
+	commit, _ := deps["commit"].(*object.Commit)
+	if analyser.merged != nil {
+		if err := analyser.mergeBranches(commit); err != nil {
+			return nil, err
+		}
 	}
 	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
 	treeDiffs := deps[items.DependencyTreeChanges].(object.Changes)
 	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	analyser.moves = nil
+	if analyser.DetectMoves {
+		analyser.moves = analyser.detectMoves(treeDiffs, cache, fileDiffs)
+	}
 	for _, change := range treeDiffs {
 		action, _ := change.Action()
 		var err error
@@ -272,13 +520,145 @@ func (analyser *BurndownAnalysis) Consume(deps map[string]interface{}) (map[stri
 			return nil, err
 		}
 	}
+	analyser.initial = false
+	analyser.commit = commit
 	return nil, nil
 }
 
+// Fork clones the files and the statuses for the branches, see core.ForkablePipelineItem.
+// The branches count the overwrites matrix from scratch and their histories are discarded
+// because only the line ownership and the matrix are merged.
+func (analyser *BurndownAnalysis) Fork(n int) []core.PipelineItem {
+	source := *analyser
+	source.globalHistory = nil
+	source.fileHistories = nil
+	source.groupHistories = nil
+	source.peopleHistories = nil
+	state := &bytes.Buffer{}
+	if err := source.DumpState(state); err != nil {
+		panic(err)
+	}
+	clones := make([]core.PipelineItem, n)
+	for i := range clones {
+		clone := *analyser
+		clone.groupStatuses = make(map[string]map[int]int64, len(analyser.groupStatuses))
+		for name := range analyser.groupStatuses {
+			clone.groupStatuses[name] = map[int]int64{}
+		}
+		if err := clone.LoadState(bytes.NewReader(state.Bytes())); err != nil {
+			panic(err)
+		}
+		clone.initial = analyser.initial
+		clone.globalHistory = [][]int64{}
+		clone.fileHistories = map[string][][]int64{}
+		clone.groupHistories = map[string][][]int64{}
+		clone.peopleHistories = make([][][]int64, analyser.PeopleNumber)
+		// the files are bound to the same slice
+		for j := range clone.matrix {
+			clone.matrix[j] = nil
+		}
+		clone.merged = nil
+		clones[i] = &clone
+	}
+	return clones
+}
+
+// Merge schedules the merge of the branches in the next Consume(),
+// see core.ForkablePipelineItem.
+func (analyser *BurndownAnalysis) Merge(branches []core.PipelineItem) {
+	for _, branch := range branches {
+		analyser.merged = append(analyser.merged, branch.(*BurndownAnalysis))
+	}
+}
+
+// mergeBranches takes the files which the merge commit inherits from the merged branches
+// together with the ages and the authors of their lines and adds the overwrites matrices
+// of the branches. The rest of the changes are consumed as usual, see items.SplitMergeChanges().
+func (analyser *BurndownAnalysis) mergeBranches(commit *object.Commit) error {
+	merged := analyser.merged
+	analyser.merged = nil
+	for _, branch := range merged {
+		for i, row := range branch.matrix {
+			for author, lines := range row {
+				if analyser.matrix[i] == nil {
+					analyser.matrix[i] = map[int]int64{}
+				}
+				analyser.matrix[i][author] += lines
+			}
+		}
+	}
+	if commit == nil || analyser.commit == nil {
+		return nil
+	}
+	previousTree, err := analyser.commit.Tree()
+	if err != nil {
+		return err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+	changes, err := object.DiffTree(previousTree, tree)
+	if err != nil {
+		return err
+	}
+	trees := make([]*object.Tree, len(merged))
+	for i, branch := range merged {
+		if branch.commit == nil {
+			continue
+		}
+		if trees[i], err = branch.commit.Tree(); err != nil {
+			return err
+		}
+	}
+	_, adopted := items.SplitMergeChanges(changes, trees)
+	for name, index := range adopted {
+		analyser.adoptFile(name, merged[index].files[name])
+	}
+	return nil
+}
+
+// adoptFile replaces the file with its version from a merged branch, nil deletes it. The lines
+// keep their days and authors and the overwrites matrix does not change because the branch
+// has already counted them.
+func (analyser *BurndownAnalysis) adoptFile(name string, branchFile *burndown.File) {
+	analyser.moving = true
+	defer func() {
+		analyser.moving = false
+	}()
+	if file, exists := analyser.files[name]; exists {
+		file.Update(0, 0, 0, file.Len())
+		delete(analyser.files, name)
+	}
+	if branchFile == nil {
+		return
+	}
+	var group map[int]int64
+	if groupName, exists := analyser.extensionGroups[strings.ToLower(path.Ext(name))]; exists {
+		group = analyser.groupStatuses[groupName]
+	}
+	file := analyser.newFile(
+		name, 0, analyser.day, 0, analyser.globalStatus, analyser.people, analyser.matrix, group)
+	length := branchFile.Len()
+	values := branchFile.Values(0, length)
+	for begin := 0; begin < length; {
+		end := begin + 1
+		for end < length && values[end] == values[begin] {
+			end++
+		}
+		file.Update(values[begin], begin, end-begin, 0)
+		begin = end
+	}
+	analyser.files[name] = file
+}
+
 // Finalize returns the result of the analysis. Further Consume() calls are not expected.
 func (analyser *BurndownAnalysis) Finalize() interface{} {
-	gs, fss, pss := analyser.groupStatus()
-	analyser.updateHistories(gs, fss, pss, 1)
+	// the final sample is not a part of the state which DumpState() writes
+	restore := analyser.snapshotHistories()
+	defer restore()
+	gs, fss, grs, pss := analyser.groupStatus()
+	analyser.updateHistories(gs, fss, grs, pss, 1)
 	for key, statuses := range analyser.fileHistories {
 		if len(statuses) == len(analyser.globalHistory) {
 			continue
@@ -305,11 +685,182 @@ func (analyser *BurndownAnalysis) Finalize() interface{} {
 	return BurndownResult{
 		GlobalHistory:      analyser.globalHistory,
 		FileHistories:      analyser.fileHistories,
+		GroupHistories:     analyser.groupHistories,
 		PeopleHistories:    analyser.peopleHistories,
 		PeopleMatrix:       peopleMatrix,
 		reversedPeopleDict: analyser.reversedPeopleDict,
 		sampling:           analyser.Sampling,
 		granularity:        analyser.Granularity,
+		trackDirectories:   analyser.TrackDirectories,
+		initialCommit:      analyser.InitialCommit,
+		backfillDays:       analyser.dayOffset,
+	}
+}
+
+// Shrink hibernates the line interval trees of all the files. The trees are restored on demand,
+// so the results stay the same.
+func (analyser *BurndownAnalysis) Shrink() {
+	for _, file := range analyser.files {
+		file.Hibernate()
+	}
+}
+
+// burndownState is the format of BurndownAnalysis.DumpState().
+type burndownState struct {
+	Granularity     int
+	Sampling        int
+	TrackFiles      bool
+	GlobalStatus    map[int]int64
+	GlobalHistory   [][]int64
+	FileHistories   map[string][][]int64
+	GroupStatuses   map[string]map[int]int64
+	GroupHistories  map[string][][]int64
+	PeopleHistories [][][]int64
+	Files           map[string]burndownFileState
+	Matrix          []map[int]int64
+	People          []map[int]int64
+	Day             int
+	PreviousDay     int
+}
+
+// burndownFileState is the state of a single file in burndownState.
+type burndownFileState struct {
+	// Tree is the line interval tree, see burndown.File.Bytes().
+	Tree []byte
+	// Status is the per-file status, it is nil unless TrackFiles is set.
+	Status map[int]int64
+	// Group is the name of the extension group of the file, empty if there is none.
+	Group string
+}
+
+// DumpState writes the line interval trees of the files, the statuses and the histories,
+// see core.PersistentPipelineItem.
+func (analyser *BurndownAnalysis) DumpState(writer io.Writer) error {
+	state := burndownState{
+		Granularity:     analyser.Granularity,
+		Sampling:        analyser.Sampling,
+		TrackFiles:      analyser.TrackFiles,
+		GlobalStatus:    analyser.globalStatus,
+		GlobalHistory:   analyser.globalHistory,
+		FileHistories:   analyser.fileHistories,
+		GroupStatuses:   analyser.groupStatuses,
+		GroupHistories:  analyser.groupHistories,
+		PeopleHistories: analyser.peopleHistories,
+		Files:           make(map[string]burndownFileState, len(analyser.files)),
+		Matrix:          analyser.matrix,
+		People:          analyser.people,
+		Day:             analyser.day,
+		PreviousDay:     analyser.previousDay,
+	}
+	for name, file := range analyser.files {
+		fileState := burndownFileState{Tree: file.Bytes(), Group: analyser.fileGroup(file)}
+		if analyser.TrackFiles {
+			fileState.Status = file.Status(1).(map[int]int64)
+		}
+		state.Files[name] = fileState
+	}
+	return gob.NewEncoder(writer).Encode(&state)
+}
+
+// LoadState restores the state written by DumpState(). The configuration must be the same.
+func (analyser *BurndownAnalysis) LoadState(reader io.Reader) error {
+	state := burndownState{}
+	if err := gob.NewDecoder(reader).Decode(&state); err != nil {
+		return err
+	}
+	if state.Granularity != analyser.Granularity || state.Sampling != analyser.Sampling ||
+		state.TrackFiles != analyser.TrackFiles {
+		return errors.New("the granularity, the sampling or the per-file tracking has changed")
+	}
+	if len(state.People) != analyser.PeopleNumber {
+		return fmt.Errorf("the number of developers has changed from %d to %d",
+			len(state.People), analyser.PeopleNumber)
+	}
+	if len(state.GroupStatuses) != len(analyser.groupStatuses) {
+		return errors.New("the extension groups have changed")
+	}
+	for name := range state.GroupStatuses {
+		if _, exists := analyser.groupStatuses[name]; !exists {
+			return fmt.Errorf("the extension group %s does not exist", name)
+		}
+	}
+	analyser.globalStatus = state.GlobalStatus
+	if analyser.globalStatus == nil {
+		// gob omits the empty maps
+		analyser.globalStatus = map[int]int64{}
+	}
+	analyser.globalHistory = state.GlobalHistory
+	analyser.fileHistories = state.FileHistories
+	for name, status := range state.GroupStatuses {
+		analyser.groupStatuses[name] = status
+	}
+	analyser.groupHistories = state.GroupHistories
+	analyser.peopleHistories = state.PeopleHistories
+	analyser.matrix = state.Matrix
+	analyser.people = state.People
+	analyser.files = make(map[string]*burndown.File, len(state.Files))
+	for name, fileState := range state.Files {
+		local := fileState.Status
+		if local == nil {
+			local = map[int]int64{}
+		}
+		if !analyser.isFileTracked(name) {
+			local = nil
+		}
+		var group map[int]int64
+		if fileState.Group != "" {
+			group = analyser.groupStatuses[fileState.Group]
+		}
+		analyser.files[name] = burndown.NewFileFromBytes(fileState.Tree, analyser.fileStatuses(
+			analyser.globalStatus, local, analyser.people, analyser.matrix, group)...)
+	}
+	analyser.day = state.Day
+	analyser.previousDay = state.PreviousDay
+	analyser.initial = false
+	return nil
+}
+
+// fileGroup returns the name of the extension group whose status is bound to the file.
+func (analyser *BurndownAnalysis) fileGroup(file *burndown.File) string {
+	index := len(analyser.fileStatuses(nil, nil, nil, nil, nil))
+	if file.NumStatuses() <= index {
+		return ""
+	}
+	// the maps are not comparable, but the same map has the same pointer
+	status := reflect.ValueOf(file.Status(index)).Pointer()
+	for name, groupStatus := range analyser.groupStatuses {
+		if reflect.ValueOf(groupStatus).Pointer() == status {
+			return name
+		}
+	}
+	return ""
+}
+
+// snapshotHistories returns the function which reverts the histories to their current state.
+func (analyser *BurndownAnalysis) snapshotHistories() func() {
+	// appending to the clipped slice never overwrites the samples which were appended after
+	// the snapshot
+	clip := func(history [][]int64) [][]int64 {
+		return history[:len(history):len(history)]
+	}
+	globalHistory := clip(analyser.globalHistory)
+	fileHistories := make(map[string][][]int64, len(analyser.fileHistories))
+	for key, history := range analyser.fileHistories {
+		fileHistories[key] = clip(history)
+	}
+	groupHistories := make(map[string][][]int64, len(analyser.groupHistories))
+	for key, history := range analyser.groupHistories {
+		groupHistories[key] = clip(history)
+	}
+	peopleHistories := make([][][]int64, len(analyser.peopleHistories))
+	for i, history := range analyser.peopleHistories {
+		peopleHistories[i] = clip(history)
+	}
+	return func() {
+		analyser.globalHistory = globalHistory
+		analyser.fileHistories = fileHistories
+		analyser.groupHistories = groupHistories
+		analyser.peopleHistories = peopleHistories
 	}
 }
 
@@ -324,6 +875,11 @@ func (analyser *BurndownAnalysis) Serialize(result interface{}, binary bool, wri
 	return nil
 }
 
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (analyser *BurndownAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(analyser, result, &pb.BurndownAnalysisResults{}, writer)
+}
+
 // Deserialize converts the specified protobuf bytes to BurndownResult.
 func (analyser *BurndownAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
 	msg := pb.BurndownAnalysisResults{}
@@ -347,6 +903,12 @@ func (analyser *BurndownAnalysis) Deserialize(pbmessage []byte) (interface{}, er
 	for _, mat := range msg.Files {
 		result.FileHistories[mat.Name] = convertCSR(mat)
 	}
+	if len(msg.Groups) > 0 {
+		result.GroupHistories = map[string][][]int64{}
+		for _, mat := range msg.Groups {
+			result.GroupHistories[mat.Name] = convertCSR(mat)
+		}
+	}
 	result.reversedPeopleDict = make([]string, len(msg.People))
 	result.PeopleHistories = make([][][]int64, len(msg.People))
 	for i, mat := range msg.People {
@@ -364,6 +926,9 @@ func (analyser *BurndownAnalysis) Deserialize(pbmessage []byte) (interface{}, er
 	}
 	result.sampling = int(msg.Sampling)
 	result.granularity = int(msg.Granularity)
+	result.trackDirectories = len(msg.Directories) > 0
+	result.initialCommit = msg.InitialCommit
+	result.backfillDays = int(msg.BackfillDays)
 	return result, nil
 }
 
@@ -383,6 +948,24 @@ func (analyser *BurndownAnalysis) MergeResults(
 	} else {
 		merged.granularity = bar2.granularity
 	}
+	merged.trackDirectories = bar1.trackDirectories || bar2.trackDirectories
+	merged.initialCommit = bar1.initialCommit
+	if bar1.initialCommit != bar2.initialCommit {
+		log.Printf("Warning: merged the burndowns with different initial commit policies: "+
+			"%q and %q\n", bar1.initialCommit, bar2.initialCommit)
+	}
+	if bar1.backfillDays > 0 || bar2.backfillDays > 0 {
+		// the matrices begin before the commits, so the time axes are shifted accordingly
+		unshifted := *c1
+		unshifted.Merge(c2)
+		shifted1, shifted2 := *c1, *c2
+		shifted1.BeginTime -= int64(bar1.backfillDays) * 3600 * 24
+		shifted2.BeginTime -= int64(bar2.backfillDays) * 3600 * 24
+		c1, c2 = &shifted1, &shifted2
+		shifted := shifted1
+		shifted.Merge(c2)
+		merged.backfillDays = int((unshifted.BeginTime - shifted.BeginTime) / (3600 * 24))
+	}
 	var people map[string][3]int
 	people, merged.reversedPeopleDict = identity.Detector{}.MergeReversedDicts(
 		bar1.reversedPeopleDict, bar2.reversedPeopleDict)
@@ -425,6 +1008,34 @@ func (analyser *BurndownAnalysis) MergeResults(
 			}
 		}
 	}
+	if len(bar1.GroupHistories) > 0 || len(bar2.GroupHistories) > 0 {
+		merged.GroupHistories = map[string][][]int64{}
+		historyMutex := sync.Mutex{}
+		for key, gh1 := range bar1.GroupHistories {
+			wg.Add(1)
+			go func(gh1, gh2 [][]int64, key string) {
+				defer wg.Done()
+				gh := mergeMatrices(
+					gh1, gh2, bar1.granularity, bar1.sampling, bar2.granularity, bar2.sampling, c1, c2)
+				historyMutex.Lock()
+				defer historyMutex.Unlock()
+				merged.GroupHistories[key] = gh
+			}(gh1, bar2.GroupHistories[key], key)
+		}
+		for key, gh2 := range bar2.GroupHistories {
+			if _, exists := bar1.GroupHistories[key]; !exists {
+				wg.Add(1)
+				go func(gh2 [][]int64, key string) {
+					defer wg.Done()
+					gh := mergeMatrices(
+						nil, gh2, bar1.granularity, bar1.sampling, bar2.granularity, bar2.sampling, c1, c2)
+					historyMutex.Lock()
+					defer historyMutex.Unlock()
+					merged.GroupHistories[key] = gh
+				}(gh2, key)
+			}
+		}
+	}
 	if len(merged.reversedPeopleDict) > 0 {
 		merged.PeopleHistories = make([][][]int64, len(merged.reversedPeopleDict))
 		for i, key := range merged.reversedPeopleDict {
@@ -716,12 +1327,31 @@ func addBurndownMatrix(matrix [][]int64, granularity, sampling int, daily [][]fl
 func (analyser *BurndownAnalysis) serializeText(result *BurndownResult, writer io.Writer) {
 	fmt.Fprintln(writer, "  granularity:", result.granularity)
 	fmt.Fprintln(writer, "  sampling:", result.sampling)
+	if result.initialCommit != "" && result.initialCommit != BurndownInitialCommitKeep {
+		fmt.Fprintln(writer, "  initial_commit:", result.initialCommit)
+		fmt.Fprintln(writer, "  backfill_days:", result.backfillDays)
+	}
 	yaml.PrintMatrix(writer, result.GlobalHistory, 2, "project", true)
 	if len(result.FileHistories) > 0 {
 		fmt.Fprintln(writer, "  files:")
-		keys := sortedKeys(result.FileHistories)
+		fileHistories := truncateFileHistories(result.FileHistories, analyser.TopFiles)
+		keys := sortedKeys(fileHistories)
+		for _, key := range keys {
+			yaml.PrintMatrix(writer, fileHistories[key], 4, key, true)
+		}
+	}
+	if result.trackDirectories && len(result.FileHistories) > 0 {
+		fmt.Fprintln(writer, "  directories:")
+		histories, names, _ := directoryHistories(result.FileHistories)
+		for _, name := range names {
+			yaml.PrintMatrix(writer, histories[name], 4, name, true)
+		}
+	}
+	if len(result.GroupHistories) > 0 {
+		fmt.Fprintln(writer, "  groups:")
+		keys := sortedKeys(result.GroupHistories)
 		for _, key := range keys {
-			yaml.PrintMatrix(writer, result.FileHistories[key], 4, key, true)
+			yaml.PrintMatrix(writer, result.GroupHistories[key], 4, key, true)
 		}
 	}
 
@@ -739,24 +1369,88 @@ func (analyser *BurndownAnalysis) serializeText(result *BurndownResult, writer i
 	}
 }
 
+// SerializeDayRanges splits the binary result by the rows of the project matrix, row i is
+// day i * sampling: the first part carries the rest of the fields and the project rows of
+// the first range, the others only the project rows of their ranges.
+func (analyser *BurndownAnalysis) SerializeDayRanges(result interface{}, days int) ([]core.DayRange, error) {
+	burndownResult := result.(BurndownResult)
+	message := analyser.binaryMessage(&burndownResult)
+	sampling := burndownResult.sampling
+	if sampling <= 0 {
+		sampling = 1
+	}
+	parts := []*pb.BurndownAnalysisResults{message}
+	if message.Project != nil {
+		rows := message.Project.Rows
+		message.Project.Rows = nil
+		for i, row := range rows {
+			index := i * sampling / days
+			for len(parts) <= index {
+				parts = append(parts, &pb.BurndownAnalysisResults{Project: &pb.BurndownSparseMatrix{}})
+			}
+			parts[index].Project.Rows = append(parts[index].Project.Rows, row)
+		}
+	}
+	ranges := make([]core.DayRange, 0, len(parts))
+	for i, part := range parts {
+		if i > 0 && len(part.Project.Rows) == 0 {
+			continue
+		}
+		serialized, err := proto.Marshal(part)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, core.DayRange{From: i * days, To: (i + 1) * days, Data: serialized})
+	}
+	return ranges, nil
+}
+
 func (analyser *BurndownAnalysis) serializeBinary(result *BurndownResult, writer io.Writer) error {
+	serialized, err := proto.Marshal(analyser.binaryMessage(result))
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func (analyser *BurndownAnalysis) binaryMessage(result *BurndownResult) *pb.BurndownAnalysisResults {
 	message := pb.BurndownAnalysisResults{
-		Granularity: int32(result.granularity),
-		Sampling:    int32(result.sampling),
+		Granularity:   int32(result.granularity),
+		Sampling:      int32(result.sampling),
+		InitialCommit: result.initialCommit,
+		BackfillDays:  int32(result.backfillDays),
 	}
 	if len(result.GlobalHistory) > 0 {
 		message.Project = pb.ToBurndownSparseMatrix(result.GlobalHistory, "project")
 	}
 	if len(result.FileHistories) > 0 {
-		message.Files = make([]*pb.BurndownSparseMatrix, len(result.FileHistories))
-		keys := sortedKeys(result.FileHistories)
+		fileHistories := truncateFileHistories(result.FileHistories, analyser.TopFiles)
+		message.Files = make([]*pb.BurndownSparseMatrix, len(fileHistories))
+		keys := sortedKeys(fileHistories)
 		i := 0
 		for _, key := range keys {
 			message.Files[i] = pb.ToBurndownSparseMatrix(
-				result.FileHistories[key], key)
+				fileHistories[key], key)
 			i++
 		}
 	}
+	if result.trackDirectories && len(result.FileHistories) > 0 {
+		histories, names, parents := directoryHistories(result.FileHistories)
+		message.Directories = make([]*pb.BurndownDirectory, len(names))
+		for i, name := range names {
+			message.Directories[i] = &pb.BurndownDirectory{
+				Matrix: pb.ToBurndownSparseMatrix(histories[name], name),
+				Parent: int32(parents[i]),
+			}
+		}
+	}
+	if len(result.GroupHistories) > 0 {
+		message.Groups = make([]*pb.BurndownSparseMatrix, len(result.GroupHistories))
+		for i, key := range sortedKeys(result.GroupHistories) {
+			message.Groups[i] = pb.ToBurndownSparseMatrix(result.GroupHistories[key], key)
+		}
+	}
 
 	if len(result.PeopleHistories) > 0 {
 		message.People = make(
@@ -768,12 +1462,7 @@ func (analyser *BurndownAnalysis) serializeBinary(result *BurndownResult, writer
 		}
 		message.PeopleInteraction = pb.DenseToCompressedSparseRowMatrix(result.PeopleMatrix)
 	}
-	serialized, err := proto.Marshal(&message)
-	if err != nil {
-		return err
-	}
-	writer.Write(serialized)
-	return nil
+	return &message
 }
 
 func sortedKeys(m map[string][][]int64) []string {
@@ -785,6 +1474,96 @@ func sortedKeys(m map[string][][]int64) []string {
 	return keys
 }
 
+// directoryHistories sums the per-file burndown matrices by directories, recursively up to
+// the repository root which is named burndownRootDirectory. Besides the matrices, it returns
+// the directory names ordered so that every parent goes before its children and the index
+// of the parent of each directory in that order, -1 for the root.
+func directoryHistories(fileHistories map[string][][]int64) (
+	map[string][][]int64, []string, []int) {
+	histories := map[string][][]int64{}
+	for _, file := range sortedKeys(fileHistories) {
+		history := fileHistories[file]
+		for dir := path.Dir(file); ; dir = path.Dir(dir) {
+			if dir == "." {
+				histories[burndownRootDirectory] = sumMatrices(histories[burndownRootDirectory], history)
+				break
+			}
+			histories[dir] = sumMatrices(histories[dir], history)
+		}
+	}
+	names := make([]string, 0, len(histories))
+	for name := range histories {
+		if name != burndownRootDirectory {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	names = append([]string{burndownRootDirectory}, names...)
+	index := map[string]int{}
+	parents := make([]int, len(names))
+	for i, name := range names {
+		index[name] = i
+		if name == burndownRootDirectory {
+			parents[i] = -1
+			continue
+		}
+		parent := path.Dir(name)
+		if parent == "." {
+			parent = burndownRootDirectory
+		}
+		parents[i] = index[parent]
+	}
+	return histories, names, parents
+}
+
+// truncateFileHistories keeps the k files with the most alive lines in the last sample and
+// sums the rest into TopOtherName. The histories are returned as is if k is 0.
+func truncateFileHistories(fileHistories map[string][][]int64, k int) map[string][][]int64 {
+	keys := sortedKeys(fileHistories)
+	weights := make([]int64, len(keys))
+	for i, key := range keys {
+		history := fileHistories[key]
+		if len(history) == 0 {
+			continue
+		}
+		for _, val := range history[len(history)-1] {
+			weights[i] += val
+		}
+	}
+	remap := topRemap(weights, k)
+	if remap == nil {
+		return fileHistories
+	}
+	truncated := map[string][][]int64{}
+	var other [][]int64
+	for i, key := range keys {
+		if remap[i] < k {
+			truncated[key] = fileHistories[key]
+		} else {
+			other = sumMatrices(other, fileHistories[key])
+		}
+	}
+	truncated[TopOtherName] = other
+	return truncated
+}
+
+// sumMatrices adds `src` to `dst` element-wise, growing `dst` as needed. The rows of `src`
+// are never shared with `dst`.
+func sumMatrices(dst, src [][]int64) [][]int64 {
+	for len(dst) < len(src) {
+		dst = append(dst, nil)
+	}
+	for i, row := range src {
+		for len(dst[i]) < len(row) {
+			dst[i] = append(dst[i], 0)
+		}
+		for j, val := range row {
+			dst[i][j] += val
+		}
+	}
+	return dst
+}
+
 func checkClose(c io.Closer) {
 	if err := c.Close(); err != nil {
 		panic(err)
@@ -816,13 +1595,18 @@ func (analyser *BurndownAnalysis) updateStatus(
 	status interface{}, _ int, previousValue int, delta int) {
 
 	_, previousTime := analyser.unpackPersonWithDay(previousValue)
-	status.(map[int]int64)[previousTime] += int64(delta)
+	mapStatus := status.(map[int]int64)
+	// the files which do not match FileGlobs do not have the local status
+	if previousTime == burndownExcludedDay || mapStatus == nil {
+		return
+	}
+	mapStatus[previousTime] += int64(delta)
 }
 
 func (analyser *BurndownAnalysis) updatePeople(
 	peopleUncasted interface{}, _ int, previousValue int, delta int) {
 	previousAuthor, previousTime := analyser.unpackPersonWithDay(previousValue)
-	if previousAuthor == identity.AuthorMissing {
+	if previousAuthor == identity.AuthorMissing || previousTime == burndownExcludedDay {
 		return
 	}
 	people := peopleUncasted.([]map[int]int64)
@@ -837,10 +1621,13 @@ func (analyser *BurndownAnalysis) updatePeople(
 func (analyser *BurndownAnalysis) updateMatrix(
 	matrixUncasted interface{}, currentTime int, previousTime int, delta int) {
 
+	if analyser.moving {
+		return
+	}
 	matrix := matrixUncasted.([]map[int]int64)
 	newAuthor, _ := analyser.unpackPersonWithDay(currentTime)
-	oldAuthor, _ := analyser.unpackPersonWithDay(previousTime)
-	if oldAuthor == identity.AuthorMissing {
+	oldAuthor, oldDay := analyser.unpackPersonWithDay(previousTime)
+	if oldAuthor == identity.AuthorMissing || oldDay == burndownExcludedDay {
 		return
 	}
 	if newAuthor == oldAuthor && delta > 0 {
@@ -860,19 +1647,44 @@ func (analyser *BurndownAnalysis) updateMatrix(
 }
 
 func (analyser *BurndownAnalysis) newFile(
-	author int, day int, size int, global map[int]int64, people []map[int]int64,
-	matrix []map[int]int64) *burndown.File {
+	name string, author int, day int, size int, global map[int]int64, people []map[int]int64,
+	matrix []map[int]int64, group map[int]int64) *burndown.File {
+	statuses := analyser.fileStatuses(global, analyser.localStatus(name), people, matrix, group)
+	if analyser.PeopleNumber > 0 {
+		day = analyser.packPersonWithDay(author, day)
+	}
+	return burndown.NewFile(day, size, statuses...)
+}
+
+// localStatus returns the new per-file status of the file, nil if the file does not match
+// FileGlobs, so that the untracked files do not waste memory.
+func (analyser *BurndownAnalysis) localStatus(name string) map[int]int64 {
+	if !analyser.isFileTracked(name) {
+		return nil
+	}
+	return map[int]int64{}
+}
+
+// fileStatuses returns the statuses which are bound to each file: global, local if TrackFiles
+// is set, people and matrix if PeopleNumber is positive and group if it is not nil.
+// The local status is nil if the file does not match FileGlobs; it is a placeholder which keeps
+// the positions of the rest.
+func (analyser *BurndownAnalysis) fileStatuses(
+	global map[int]int64, local map[int]int64, people []map[int]int64,
+	matrix []map[int]int64, group map[int]int64) []burndown.Status {
 	statuses := make([]burndown.Status, 1)
 	statuses[0] = burndown.NewStatus(global, analyser.updateStatus)
 	if analyser.TrackFiles {
-		statuses = append(statuses, burndown.NewStatus(map[int]int64{}, analyser.updateStatus))
+		statuses = append(statuses, burndown.NewStatus(local, analyser.updateStatus))
 	}
 	if analyser.PeopleNumber > 0 {
 		statuses = append(statuses, burndown.NewStatus(people, analyser.updatePeople))
 		statuses = append(statuses, burndown.NewStatus(matrix, analyser.updateMatrix))
-		day = analyser.packPersonWithDay(author, day)
 	}
-	return burndown.NewFile(day, size, statuses...)
+	if group != nil {
+		statuses = append(statuses, burndown.NewStatus(group, analyser.updateStatus))
+	}
+	return statuses
 }
 
 func (analyser *BurndownAnalysis) handleInsertion(
@@ -890,12 +1702,44 @@ func (analyser *BurndownAnalysis) handleInsertion(
 	if exists {
 		return fmt.Errorf("file %s already exists", name)
 	}
-	file = analyser.newFile(
-		author, analyser.day, lines, analyser.globalStatus, analyser.people, analyser.matrix)
+	var group map[int]int64
+	if groupName, exists := analyser.extensionGroups[strings.ToLower(path.Ext(name))]; exists {
+		group = analyser.groupStatuses[groupName]
+	}
+	if moved := analyser.moves.insertedLines(name); moved != nil {
+		file = analyser.newFile(
+			name, author, analyser.day, 0, analyser.globalStatus, analyser.people, analyser.matrix, group)
+		analyser.insertLines(file, 0, 0, lines, moved, analyser.packPersonWithDay(author, analyser.day))
+	} else if analyser.initial && analyser.InitialCommit != BurndownInitialCommitKeep {
+		file = analyser.newFile(
+			name, author, analyser.day, 0, analyser.globalStatus, analyser.people, analyser.matrix, group)
+		analyser.backfillLines(file, author, lines)
+	} else {
+		file = analyser.newFile(
+			name, author, analyser.day, lines, analyser.globalStatus, analyser.people, analyser.matrix, group)
+	}
 	analyser.files[name] = file
 	return nil
 }
 
+// backfillLines inserts the lines of a file in the initial commit according to InitialCommit:
+// they are either excluded or spread evenly over the days before the commit, so that
+// the beginning of the file is the oldest.
+func (analyser *BurndownAnalysis) backfillLines(file *burndown.File, author int, lines int) {
+	if analyser.InitialCommit == BurndownInitialCommitExclude {
+		file.Update(analyser.packPersonWithDay(author, burndownExcludedDay), 0, lines, 0)
+		return
+	}
+	window := analyser.BackfillDays
+	for pos := 0; pos < lines; {
+		day := pos * window / lines
+		// the first line of the next day
+		end := ((day+1)*lines + window - 1) / window
+		file.Update(analyser.packPersonWithDay(author, day), pos, end-pos, 0)
+		pos = end
+	}
+}
+
 func (analyser *BurndownAnalysis) handleDeletion(
 	change *object.Change, author int, cache map[plumbing.Hash]*object.Blob) error {
 
@@ -909,7 +1753,11 @@ func (analyser *BurndownAnalysis) handleDeletion(
 	}
 	name := change.From.Name
 	file := analyser.files[name]
-	file.Update(analyser.packPersonWithDay(author, analyser.day), 0, 0, lines)
+	if moved := analyser.moves.deletedLines(name); moved != nil {
+		analyser.deleteLines(file, 0, 0, lines, moved, analyser.packPersonWithDay(author, analyser.day))
+	} else {
+		file.Update(analyser.packPersonWithDay(author, analyser.day), 0, 0, lines)
+	}
 	delete(analyser.files, name)
 	return nil
 }
@@ -940,6 +1788,17 @@ func (analyser *BurndownAnalysis) handleModification(
 			change.From.TreeEntry.Hash.String(), change.To.TreeEntry.Hash.String())
 	}
 
+	movedFrom := analyser.moves.deletedLines(change.From.Name)
+	movedTo := analyser.moves.insertedLines(change.To.Name)
+	if movedFrom != nil || movedTo != nil {
+		analyser.applyMoves(file, thisDiffs.Diffs, movedFrom, movedTo, author)
+		if file.Len() != thisDiffs.NewLinesOfCode {
+			return fmt.Errorf("%s: internal integrity error dst %d != %d",
+				change.To.Name, thisDiffs.NewLinesOfCode, file.Len())
+		}
+		return nil
+	}
+
 	// we do not call RunesToDiffLines so the number of lines equals
 	// to the rune count
 	position := 0
@@ -1018,6 +1877,19 @@ func (analyser *BurndownAnalysis) handleModification(
 	return nil
 }
 
+// isFileTracked checks whether the per-file burndown of the file is recorded.
+func (analyser *BurndownAnalysis) isFileTracked(name string) bool {
+	if len(analyser.filePatterns) == 0 {
+		return true
+	}
+	for _, pattern := range analyser.filePatterns {
+		if pattern.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
 func (analyser *BurndownAnalysis) handleRename(from, to string) error {
 	file, exists := analyser.files[from]
 	if !exists {
@@ -1025,10 +1897,15 @@ func (analyser *BurndownAnalysis) handleRename(from, to string) error {
 	}
 	analyser.files[to] = file
 	delete(analyser.files, from)
+	if analyser.TrackFiles && analyser.isFileTracked(from) != analyser.isFileTracked(to) {
+		// the file moves into or out of FileGlobs
+		file.SetStatus(1, burndown.NewStatus(analyser.localStatus(to), analyser.updateStatus))
+	}
 	return nil
 }
 
-func (analyser *BurndownAnalysis) groupStatus() ([]int64, map[string][]int64, [][]int64) {
+func (analyser *BurndownAnalysis) groupStatus() (
+	[]int64, map[string][]int64, map[string][]int64, [][]int64) {
 	granularity := analyser.Granularity
 	if granularity == 0 {
 		granularity = 1
@@ -1054,6 +1931,9 @@ func (analyser *BurndownAnalysis) groupStatus() ([]int64, map[string][]int64, []
 	locals := make(map[string][]int64)
 	if analyser.TrackFiles {
 		for key, file := range analyser.files {
+			if !analyser.isFileTracked(key) {
+				continue
+			}
 			status := make([]int64, day/granularity+adjust)
 			var group int64
 			for i := 0; i < day; i++ {
@@ -1069,6 +1949,22 @@ func (analyser *BurndownAnalysis) groupStatus() ([]int64, map[string][]int64, []
 			locals[key] = status
 		}
 	}
+	groups := make(map[string][]int64, len(analyser.groupStatuses))
+	for key, groupStatus := range analyser.groupStatuses {
+		status := make([]int64, day/granularity+adjust)
+		var group int64
+		for i := 0; i < day; i++ {
+			group += groupStatus[i]
+			if (i % granularity) == (granularity - 1) {
+				status[i/granularity] = group
+				group = 0
+			}
+		}
+		if day%granularity != 0 {
+			status[len(status)-1] = group
+		}
+		groups[key] = status
+	}
 	peoples := make([][]int64, len(analyser.people))
 	for key, person := range analyser.people {
 		status := make([]int64, day/granularity+adjust)
@@ -1085,11 +1981,12 @@ func (analyser *BurndownAnalysis) groupStatus() ([]int64, map[string][]int64, []
 		}
 		peoples[key] = status
 	}
-	return global, locals, peoples
+	return global, locals, groups, peoples
 }
 
 func (analyser *BurndownAnalysis) updateHistories(
-	globalStatus []int64, fileStatuses map[string][]int64, peopleStatuses [][]int64, delta int) {
+	globalStatus []int64, fileStatuses map[string][]int64, groupStatuses map[string][]int64,
+	peopleStatuses [][]int64, delta int) {
 	for i := 0; i < delta; i++ {
 		analyser.globalHistory = append(analyser.globalHistory, globalStatus)
 	}
@@ -1119,6 +2016,14 @@ func (analyser *BurndownAnalysis) updateHistories(
 		analyser.fileHistories[key] = fh
 	}
 
+	for key, gs := range groupStatuses {
+		gh := analyser.groupHistories[key]
+		for i := 0; i < delta; i++ {
+			gh = append(gh, gs)
+		}
+		analyser.groupHistories[key] = gh
+	}
+
 	for key, ph := range analyser.peopleHistories {
 		ls := peopleStatuses[key]
 		for i := 0; i < delta; i++ {
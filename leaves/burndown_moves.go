@@ -0,0 +1,307 @@
+package leaves
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/burndown"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+const (
+	// burndownMinMovedLines is the minimum number of non-blank lines in a moved block.
+	// Shorter blocks are usually coincidental, e.g. closing braces.
+	burndownMinMovedLines = 3
+	// burndownMaxMoveCandidates is the number of occurrences of a deleted line after which
+	// it is not considered as the beginning of a moved block.
+	burndownMaxMoveCandidates = 64
+)
+
+// burndownMoves are the blocks of lines which were moved within the files or between the files
+// in the same commit.
+type burndownMoves struct {
+	// deleted maps the old file names to the indexes of the lines which were moved away.
+	deleted map[string]map[int]bool
+	// inserted maps the new file names to the indexes of the moved lines to their original
+	// values in burndown.File.
+	inserted map[string]map[int]int
+}
+
+// burndownChangedLines are the deleted or the inserted lines of a file.
+type burndownChangedLines struct {
+	// Name of the file.
+	Name string
+	// Lines are the contents of the file before or after the change.
+	Lines []string
+	// Changed are the indexes of the deleted or the inserted lines in increasing order.
+	Changed []int
+}
+
+// deletedLines returns the indexes of the lines which were moved away from the file.
+func (moves *burndownMoves) deletedLines(name string) map[int]bool {
+	if moves == nil {
+		return nil
+	}
+	return moves.deleted[name]
+}
+
+// insertedLines returns the indexes of the lines which were moved to the file.
+func (moves *burndownMoves) insertedLines(name string) map[int]int {
+	if moves == nil {
+		return nil
+	}
+	return moves.inserted[name]
+}
+
+// detectMoves matches the deleted and the inserted blocks of lines in the commit.
+// The leading and the trailing whitespace is ignored, so that re-indented blocks match.
+// Returns nil if nothing was moved.
+func (analyser *BurndownAnalysis) detectMoves(
+	changes object.Changes, cache map[plumbing.Hash]*object.Blob,
+	diffs map[string]items.FileDiffData) *burndownMoves {
+	var deleted, inserted []burndownChangedLines
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			continue
+		}
+		file := analyser.files[change.From.Name]
+		if action == merkletrie.Modify && file == nil {
+			// handleModification() falls back to handleInsertion()
+			action = merkletrie.Insert
+		}
+		switch action {
+		case merkletrie.Insert:
+			lines := burndownBlobLines(cache[change.To.TreeEntry.Hash])
+			indexes := make([]int, len(lines))
+			for i := range indexes {
+				indexes[i] = i
+			}
+			inserted = append(inserted, burndownChangedLines{
+				Name: change.To.Name, Lines: lines, Changed: indexes})
+		case merkletrie.Delete:
+			lines := burndownBlobLines(cache[change.From.TreeEntry.Hash])
+			if file == nil || file.Len() != len(lines) {
+				continue
+			}
+			indexes := make([]int, len(lines))
+			for i := range indexes {
+				indexes[i] = i
+			}
+			deleted = append(deleted, burndownChangedLines{
+				Name: change.From.Name, Lines: lines, Changed: indexes})
+		case merkletrie.Modify:
+			diff, exists := diffs[change.To.Name]
+			oldLines := burndownBlobLines(cache[change.From.TreeEntry.Hash])
+			newLines := burndownBlobLines(cache[change.To.TreeEntry.Hash])
+			if !exists || file.Len() != len(oldLines) || diff.NewLinesOfCode != len(newLines) {
+				continue
+			}
+			removed := burndownChangedLines{Name: change.From.Name, Lines: oldLines}
+			added := burndownChangedLines{Name: change.To.Name, Lines: newLines}
+			oldPos, newPos := 0, 0
+			for _, edit := range diff.Diffs {
+				length := utf8.RuneCountInString(edit.Text)
+				switch edit.Type {
+				case diffmatchpatch.DiffEqual:
+					oldPos += length
+					newPos += length
+				case diffmatchpatch.DiffDelete:
+					for i := 0; i < length; i++ {
+						removed.Changed = append(removed.Changed, oldPos+i)
+					}
+					oldPos += length
+				case diffmatchpatch.DiffInsert:
+					for i := 0; i < length; i++ {
+						added.Changed = append(added.Changed, newPos+i)
+					}
+					newPos += length
+				}
+			}
+			deleted = append(deleted, removed)
+			inserted = append(inserted, added)
+		}
+	}
+	return analyser.matchMoves(deleted, inserted)
+}
+
+// matchMoves greedily assigns the longest deleted blocks to the inserted lines.
+func (analyser *BurndownAnalysis) matchMoves(
+	deleted, inserted []burndownChangedLines) *burndownMoves {
+	type lineRef struct {
+		Source *burndownChangedLines
+		Index  int
+	}
+	candidates := map[string][]lineRef{}
+	removed := map[*burndownChangedLines]map[int]bool{}
+	for i := range deleted {
+		source := &deleted[i]
+		removed[source] = map[int]bool{}
+		for _, index := range source.Changed {
+			removed[source][index] = true
+			if key := strings.TrimSpace(source.Lines[index]); key != "" {
+				candidates[key] = append(candidates[key], lineRef{source, index})
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	moves := &burndownMoves{deleted: map[string]map[int]bool{}, inserted: map[string]map[int]int{}}
+	consumed := func(ref lineRef) bool {
+		return moves.deleted[ref.Source.Name][ref.Index]
+	}
+	for _, target := range inserted {
+		for pos := 0; pos < len(target.Changed); {
+			start := target.Changed[pos]
+			var best lineRef
+			bestLength, bestWeight := 0, 0
+			refs := candidates[strings.TrimSpace(target.Lines[start])]
+			if len(refs) > burndownMaxMoveCandidates {
+				refs = nil
+			}
+			for _, ref := range refs {
+				if consumed(ref) {
+					continue
+				}
+				length, weight := 0, 0
+				for pos+length < len(target.Changed) &&
+					target.Changed[pos+length] == start+length &&
+					ref.Index+length < len(ref.Source.Lines) {
+					line := ref.Source.Lines[ref.Index+length]
+					next := lineRef{ref.Source, ref.Index + length}
+					if !removed[ref.Source][next.Index] || consumed(next) ||
+						strings.TrimSpace(line) != strings.TrimSpace(target.Lines[start+length]) {
+						break
+					}
+					if strings.TrimSpace(line) != "" {
+						weight++
+					}
+					length++
+				}
+				if weight > bestWeight {
+					best, bestLength, bestWeight = ref, length, weight
+				}
+			}
+			if bestWeight < burndownMinMovedLines {
+				pos++
+				continue
+			}
+			values := analyser.files[best.Source.Name].Values(best.Index, bestLength)
+			sourceMoves := moves.deleted[best.Source.Name]
+			if sourceMoves == nil {
+				sourceMoves = map[int]bool{}
+				moves.deleted[best.Source.Name] = sourceMoves
+			}
+			targetMoves := moves.inserted[target.Name]
+			if targetMoves == nil {
+				targetMoves = map[int]int{}
+				moves.inserted[target.Name] = targetMoves
+			}
+			for i := 0; i < bestLength; i++ {
+				sourceMoves[best.Index+i] = true
+				targetMoves[start+i] = values[i]
+			}
+			pos += bestLength
+		}
+	}
+	if len(moves.inserted) == 0 {
+		return nil
+	}
+	return moves
+}
+
+// applyMoves applies the diff to the file and transfers the values of the moved lines.
+// Unlike in handleModification(), the deletions and the insertions are separate updates.
+func (analyser *BurndownAnalysis) applyMoves(
+	file *burndown.File, diffs []diffmatchpatch.Diff, movedFrom map[int]bool,
+	movedTo map[int]int, author int) {
+	time := analyser.packPersonWithDay(author, analyser.day)
+	position, oldPos, newPos := 0, 0, 0
+	for _, edit := range diffs {
+		length := utf8.RuneCountInString(edit.Text)
+		switch edit.Type {
+		case diffmatchpatch.DiffEqual:
+			position += length
+			oldPos += length
+			newPos += length
+		case diffmatchpatch.DiffDelete:
+			analyser.deleteLines(file, position, oldPos, length, movedFrom, time)
+			oldPos += length
+		case diffmatchpatch.DiffInsert:
+			analyser.insertLines(file, position, newPos, length, movedTo, time)
+			position += length
+			newPos += length
+		}
+	}
+}
+
+// deleteLines removes the old lines [oldPos, oldPos + length) which begin at position.
+// The moved lines are not counted as overwritten.
+func (analyser *BurndownAnalysis) deleteLines(
+	file *burndown.File, position, oldPos, length int, moved map[int]bool, time int) {
+	for begin := 0; begin < length; {
+		isMoved := moved[oldPos+begin]
+		end := begin + 1
+		for end < length && moved[oldPos+end] == isMoved {
+			end++
+		}
+		analyser.moving = isMoved
+		file.Update(time, position, 0, end-begin)
+		analyser.moving = false
+		if analyser.Debug {
+			file.Validate()
+		}
+		begin = end
+	}
+}
+
+// insertLines inserts the new lines [newPos, newPos + length) at position.
+// The moved lines keep their original values.
+func (analyser *BurndownAnalysis) insertLines(
+	file *burndown.File, position, newPos, length int, moved map[int]int, time int) {
+	valueAt := func(offset int) (int, bool) {
+		if value, exists := moved[newPos+offset]; exists {
+			return value, true
+		}
+		return time, false
+	}
+	for begin := 0; begin < length; {
+		value, isMoved := valueAt(begin)
+		end := begin + 1
+		for end < length {
+			if nextValue, nextMoved := valueAt(end); nextValue != value || nextMoved != isMoved {
+				break
+			}
+			end++
+		}
+		analyser.moving = isMoved
+		file.Update(value, position+begin, end-begin, 0)
+		analyser.moving = false
+		if analyser.Debug {
+			file.Validate()
+		}
+		begin = end
+	}
+}
+
+// burndownBlobLines splits the blob into lines the same way as FileDiff does.
+// Returns nil for the binary blobs which BurndownAnalysis ignores.
+func burndownBlobLines(blob *object.Blob) []string {
+	if _, err := items.CountLines(blob); err != nil {
+		return nil
+	}
+	text, err := items.BlobToString(blob)
+	if err != nil || text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,151 @@
+package leaves
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+const (
+	fixtureBurndownMovesA = "func a() {\n\tx := 1\n\ty := 2\n\treturn x + y\n}\n"
+	fixtureBurndownMovesB = "func b() {\n\tz := 3\n\treturn z\n}\n"
+)
+
+// fixtureBurndownMovesConsume runs FileDiff and BurndownAnalysis on the changes.
+func fixtureBurndownMovesConsume(t *testing.T, analyser *BurndownAnalysis, author int, day int,
+	changes object.Changes, blobs ...*object.Blob) {
+	cache := map[plumbing.Hash]*object.Blob{}
+	for _, blob := range blobs {
+		cache[blob.Hash] = blob
+	}
+	deps := map[string]interface{}{
+		identity.DependencyAuthor:   author,
+		items.DependencyDay:         day,
+		items.DependencyBlobCache:   cache,
+		items.DependencyTreeChanges: changes,
+	}
+	fd := &items.FileDiff{CleanupDisabled: true}
+	fd.Initialize(test.Repository)
+	res, err := fd.Consume(deps)
+	assert.Nil(t, err)
+	deps[items.DependencyFileDiff] = res[items.DependencyFileDiff]
+	_, err = analyser.Consume(deps)
+	assert.Nil(t, err)
+}
+
+func fixtureBurndownMoves(detect bool) *BurndownAnalysis {
+	analyser := &BurndownAnalysis{
+		Granularity:  1,
+		Sampling:     1,
+		PeopleNumber: 2,
+		TrackFiles:   true,
+		Debug:        true,
+		DetectMoves:  detect,
+	}
+	analyser.Initialize(test.Repository)
+	return analyser
+}
+
+func TestBurndownDetectMovesConfigure(t *testing.T) {
+	analyser := BurndownAnalysis{}
+	analyser.Configure(map[string]interface{}{ConfigBurndownDetectMoves: true})
+	assert.True(t, analyser.DetectMoves)
+}
+
+func TestBurndownDetectMovesWithinFile(t *testing.T) {
+	entry := func(blob *object.Blob) object.ChangeEntry {
+		return object.ChangeEntry{Name: "a.go", TreeEntry: object.TreeEntry{
+			Name: "a.go", Mode: 0100644, Hash: blob.Hash}}
+	}
+	before := fixtureChurnOriginBlob(fixtureBurndownMovesA + "\n" + fixtureBurndownMovesB)
+	after := fixtureChurnOriginBlob(fixtureBurndownMovesB + "\n" + fixtureBurndownMovesA)
+	for _, detect := range []bool{false, true} {
+		analyser := fixtureBurndownMoves(detect)
+		fixtureBurndownMovesConsume(t, analyser, 0, 0,
+			object.Changes{{To: entry(before)}}, before)
+		fixtureBurndownMovesConsume(t, analyser, 1, 10,
+			object.Changes{{From: entry(before), To: entry(after)}}, before, after)
+		file := analyser.files["a.go"]
+		assert.Equal(t, file.Len(), 10)
+		if !detect {
+			assert.NotEqual(t, analyser.globalStatus[10], int64(0))
+			continue
+		}
+		// the blank line between the functions is deleted and inserted, it is too short to move
+		assert.Equal(t, analyser.globalStatus[0], int64(9))
+		assert.Equal(t, analyser.globalStatus[10], int64(1))
+		assert.Equal(t, analyser.people[0][0], int64(9))
+		assert.Equal(t, analyser.people[1], map[int]int64{10: 1})
+		assert.Equal(t, analyser.matrix[0][1], int64(-1))
+		assert.Equal(t, analyser.matrix[0][authorSelf], int64(10))
+		for i, value := range file.Values(0, 10) {
+			author, day := analyser.unpackPersonWithDay(value)
+			if i == 4 {
+				assert.Equal(t, author, 1)
+				assert.Equal(t, day, 10)
+			} else {
+				assert.Equal(t, author, 0)
+				assert.Equal(t, day, 0)
+			}
+		}
+	}
+}
+
+func TestBurndownDetectMovesBetweenFiles(t *testing.T) {
+	entry := func(name string, blob *object.Blob) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: blob.Hash}}
+	}
+	before := fixtureChurnOriginBlob(fixtureBurndownMovesA + "\n" + fixtureBurndownMovesB)
+	after := fixtureChurnOriginBlob("// b is here\n" + fixtureBurndownMovesB)
+	extracted := fixtureChurnOriginBlob("package a\n\n" + fixtureBurndownMovesA)
+	analyser := fixtureBurndownMoves(true)
+	fixtureBurndownMovesConsume(t, analyser, 0, 0,
+		object.Changes{{To: entry("a.go", before)}}, before)
+	fixtureBurndownMovesConsume(t, analyser, 1, 10, object.Changes{
+		{From: entry("a.go", before), To: entry("a.go", after)},
+		{To: entry("c.go", extracted)},
+	}, before, after, extracted)
+	assert.Equal(t, analyser.files["a.go"].Len(), 5)
+	values := analyser.files["c.go"].Values(0, 7)
+	for i, value := range values {
+		author, day := analyser.unpackPersonWithDay(value)
+		if i < 2 {
+			assert.Equal(t, author, 1)
+			assert.Equal(t, day, 10)
+		} else {
+			assert.Equal(t, author, 0)
+			assert.Equal(t, day, 0)
+		}
+	}
+	// the blank line between the functions and the comment are new
+	assert.Equal(t, analyser.globalStatus[0], int64(9))
+	assert.Equal(t, analyser.globalStatus[10], int64(3))
+	assert.Equal(t, analyser.matrix[0][1], int64(-1))
+	// the whole file is moved and deleted
+	cleaned := fixtureChurnOriginBlob("package a\n")
+	fixtureBurndownMovesConsume(t, analyser, 1, 20, object.Changes{
+		{From: entry("a.go", after)},
+		{From: entry("c.go", extracted), To: entry("c.go", cleaned)},
+		{To: entry("b.go", fixtureChurnOriginBlob(fixtureBurndownMovesB))},
+	}, after, extracted, cleaned, fixtureChurnOriginBlob(fixtureBurndownMovesB))
+	values = analyser.files["b.go"].Values(0, 4)
+	for _, value := range values {
+		_, day := analyser.unpackPersonWithDay(value)
+		assert.Equal(t, day, 0)
+	}
+	assert.Equal(t, analyser.globalStatus[0], int64(4))
+}
+
+func TestBurndownBlobLines(t *testing.T) {
+	assert.Equal(t, burndownBlobLines(fixtureChurnOriginBlob("a\nb")), []string{"a\n", "b"})
+	assert.Equal(t, burndownBlobLines(fixtureChurnOriginBlob("a\n\n")), []string{"a\n", "\n"})
+	assert.Nil(t, burndownBlobLines(fixtureChurnOriginBlob("")))
+	assert.Nil(t, burndownBlobLines(fixtureChurnOriginBlob("\xff\xfe")))
+	assert.Nil(t, burndownBlobLines(nil))
+}
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"path"
 	"testing"
+	"time"
 
 	"gopkg.in/src-d/hercules.v4/internal/core"
 	"gopkg.in/src-d/hercules.v4/internal/test/fixtures"
@@ -14,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/src-d/go-git.v4/plumbing"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/burndown"
 	"gopkg.in/src-d/hercules.v4/internal/pb"
 	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
 	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
@@ -35,7 +37,9 @@ func TestBurndownMeta(t *testing.T) {
 	for _, opt := range opts {
 		switch opt.Name {
 		case ConfigBurndownGranularity, ConfigBurndownSampling, ConfigBurndownTrackFiles,
-			ConfigBurndownTrackPeople, ConfigBurndownDebug:
+			ConfigBurndownTrackDirectories, ConfigBurndownTrackPeople, ConfigBurndownDebug, ConfigBurndownExtensionGroups,
+			ConfigBurndownDetectMoves, ConfigBurndownTopFiles, ConfigBurndownFileGlobs,
+			ConfigBurndownInitialCommit, ConfigBurndownBackfillDays:
 			matches++
 		}
 	}
@@ -49,16 +53,27 @@ func TestBurndownConfigure(t *testing.T) {
 	facts[ConfigBurndownGranularity] = 100
 	facts[ConfigBurndownSampling] = 200
 	facts[ConfigBurndownTrackFiles] = true
+	facts[ConfigBurndownTrackDirectories] = true
+	facts[ConfigBurndownFileGlobs] = []string{"src/**"}
+	facts[ConfigBurndownInitialCommit] = BurndownInitialCommitSpread
+	facts[ConfigBurndownBackfillDays] = 100
 	facts[ConfigBurndownTrackPeople] = true
 	facts[ConfigBurndownDebug] = true
+	facts[ConfigBurndownExtensionGroups] = "frontend=.ts,.tsx;backend=.go"
 	facts[identity.FactIdentityDetectorPeopleCount] = 5
 	facts[identity.FactIdentityDetectorReversedPeopleDict] = burndown.Requires()
 	burndown.Configure(facts)
 	assert.Equal(t, burndown.Granularity, 100)
 	assert.Equal(t, burndown.Sampling, 200)
 	assert.Equal(t, burndown.TrackFiles, true)
+	assert.Equal(t, burndown.TrackDirectories, true)
+	assert.Equal(t, burndown.FileGlobs, []string{"src/**"})
+	assert.Equal(t, burndown.InitialCommit, BurndownInitialCommitSpread)
+	assert.Equal(t, burndown.BackfillDays, 100)
 	assert.Equal(t, burndown.PeopleNumber, 5)
 	assert.Equal(t, burndown.Debug, true)
+	assert.Equal(t, burndown.ExtensionGroups, map[string][]string{
+		"frontend": {".ts", ".tsx"}, "backend": {".go"}})
 	assert.Equal(t, burndown.reversedPeopleDict, burndown.Requires())
 	facts[ConfigBurndownTrackPeople] = false
 	facts[identity.FactIdentityDetectorPeopleCount] = 50
@@ -74,6 +89,258 @@ func TestBurndownConfigure(t *testing.T) {
 	assert.Equal(t, burndown.reversedPeopleDict, burndown.Requires())
 }
 
+func TestBurndownParseExtensionGroups(t *testing.T) {
+	groups, err := ParseExtensionGroups("frontend = .ts, tsx ; backend=.go;")
+	assert.Nil(t, err)
+	assert.Equal(t, groups, map[string][]string{
+		"frontend": {".ts", ".tsx"}, "backend": {".go"}})
+	groups, err = ParseExtensionGroups("")
+	assert.Nil(t, err)
+	assert.Len(t, groups, 0)
+	_, err = ParseExtensionGroups(".go")
+	assert.NotNil(t, err)
+	_, err = ParseExtensionGroups("backend=")
+	assert.NotNil(t, err)
+}
+
+func TestBurndownGroupStatus(t *testing.T) {
+	burndown := BurndownAnalysis{
+		Granularity: 2, Sampling: 2, ExtensionGroups: map[string][]string{"backend": {".go"}}}
+	burndown.Initialize(nil)
+	burndown.day = 3
+	burndown.globalStatus[0] = 10
+	burndown.globalStatus[3] = 5
+	burndown.groupStatuses["backend"][0] = 4
+	burndown.groupStatuses["backend"][2] = 1
+	global, _, groups, _ := burndown.groupStatus()
+	assert.Equal(t, global, []int64{10, 5})
+	assert.Equal(t, groups, map[string][]int64{"backend": {4, 1}})
+	burndown.updateHistories(global, nil, groups, nil, 2)
+	assert.Equal(t, burndown.groupHistories["backend"], [][]int64{{4, 1}, {4, 1}})
+}
+
+func TestBurndownFileGlobs(t *testing.T) {
+	burndown := BurndownAnalysis{
+		Granularity: 2, Sampling: 2, FileGlobs: []string{"src/**", "main.go"}}
+	burndown.Initialize(nil)
+	assert.True(t, burndown.TrackFiles)
+	assert.Len(t, burndown.filePatterns, 2)
+	for _, name := range []string{"src/a.go", "src/pkg/b.go", "lib/c.go", "main.go", "cmd/main.go"} {
+		burndown.files[name] = burndown.newFile(name, 0, 0, 10, burndown.globalStatus, nil, nil, nil)
+	}
+	assert.True(t, burndown.isFileTracked("src/a.go"))
+	assert.False(t, burndown.isFileTracked("lib/c.go"))
+	// the files which do not match carry no local status
+	assert.Equal(t, burndown.files["src/a.go"].Status(1), map[int]int64{0: 10})
+	assert.Nil(t, burndown.files["lib/c.go"].Status(1))
+	assert.Nil(t, burndown.files["cmd/main.go"].Status(1))
+	burndown.day = 3
+	global, locals, _, _ := burndown.groupStatus()
+	assert.Equal(t, global, []int64{50, 0})
+	assert.Equal(t, locals, map[string][]int64{
+		"src/a.go": {10, 0}, "src/pkg/b.go": {10, 0}, "main.go": {10, 0}})
+	// the renamed files become tracked
+	assert.Nil(t, burndown.handleRename("lib/c.go", "src/c.go"))
+	assert.Equal(t, burndown.files["src/c.go"].Status(1), map[int]int64{0: 10})
+	_, locals, _, _ = burndown.groupStatus()
+	assert.Len(t, locals, 4)
+	assert.Equal(t, locals["src/c.go"], []int64{10, 0})
+	burndown.files["src/c.go"].Update(2, 0, 5, 0)
+	assert.Equal(t, burndown.files["src/c.go"].Status(1), map[int]int64{0: 10, 2: 5})
+	// and the files which are renamed out of the globs lose it
+	assert.Nil(t, burndown.handleRename("src/a.go", "lib/a.go"))
+	assert.Nil(t, burndown.files["lib/a.go"].Status(1))
+	burndown.files["lib/a.go"].Update(2, 0, 5, 0)
+	assert.Equal(t, burndown.globalStatus, map[int]int64{0: 50, 2: 10})
+	_, locals, _, _ = burndown.groupStatus()
+	assert.Len(t, locals, 3)
+	assert.NotContains(t, locals, "lib/a.go")
+	// the state keeps the files without the local statuses
+	state := &bytes.Buffer{}
+	assert.Nil(t, burndown.DumpState(state))
+	restored := BurndownAnalysis{
+		Granularity: 2, Sampling: 2, FileGlobs: []string{"src/**", "main.go"}}
+	restored.Initialize(nil)
+	assert.Nil(t, restored.LoadState(state))
+	assert.Nil(t, restored.files["lib/a.go"].Status(1))
+	assert.Equal(t, restored.files["src/c.go"].Status(1), map[int]int64{0: 10, 2: 5})
+	burndown = BurndownAnalysis{Granularity: 2, Sampling: 2}
+	burndown.Initialize(nil)
+	assert.False(t, burndown.TrackFiles)
+	assert.True(t, burndown.isFileTracked("lib/c.go"))
+}
+
+func fixtureBurndownInitialCommitDeps(
+	author int, day int, changes object.Changes, blobs ...*object.Blob) map[string]interface{} {
+	cache := map[plumbing.Hash]*object.Blob{}
+	for _, blob := range blobs {
+		cache[blob.Hash] = blob
+	}
+	deps := fixtureChurnOriginDeps(
+		author, time.January, changes, map[string]items.FileDiffData{}, cache)
+	deps[items.DependencyDay] = day
+	return deps
+}
+
+func TestBurndownInitialCommit(t *testing.T) {
+	blobA := fixtureChurnOriginBlob("0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n")
+	blobB := fixtureChurnOriginBlob("0\n1\n2\n3\n")
+	entry := func(name string, blob *object.Blob) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: blob.Hash}}
+	}
+	run := func(burndown *BurndownAnalysis) BurndownResult {
+		burndown.Granularity = 10
+		burndown.Sampling = 10
+		burndown.PeopleNumber = 2
+		burndown.reversedPeopleDict = []string{"one", "two"}
+		burndown.Initialize(nil)
+		_, err := burndown.Consume(fixtureBurndownInitialCommitDeps(
+			0, 0, object.Changes{{To: entry("a.go", blobA)}}, blobA))
+		assert.Nil(t, err)
+		_, err = burndown.Consume(fixtureBurndownInitialCommitDeps(
+			1, 3, object.Changes{{To: entry("b.go", blobB)}}, blobB))
+		assert.Nil(t, err)
+		_, err = burndown.Consume(fixtureBurndownInitialCommitDeps(
+			1, 12, object.Changes{{From: entry("a.go", blobA)}}, blobA))
+		assert.Nil(t, err)
+		return burndown.Finalize().(BurndownResult)
+	}
+
+	burndown := &BurndownAnalysis{}
+	result := run(burndown)
+	assert.Equal(t, burndown.InitialCommit, BurndownInitialCommitKeep)
+	assert.Equal(t, result.GlobalHistory, [][]int64{{14, 0}, {4, 0}})
+	assert.Equal(t, result.PeopleMatrix[0], []int64{10, 0, 0, -10})
+	assert.Equal(t, result.backfillDays, 0)
+	buffer := &bytes.Buffer{}
+	burndown.Serialize(result, false, buffer)
+	assert.NotContains(t, buffer.String(), "initial_commit")
+
+	burndown = &BurndownAnalysis{InitialCommit: BurndownInitialCommitSpread, BackfillDays: 20}
+	burndown.Initialize(nil)
+	burndown.PeopleNumber = 0
+	file := burndown.newFile("", 0, burndown.day, 0, burndown.globalStatus, nil, nil, nil)
+	burndown.backfillLines(file, 0, 10)
+	assert.Equal(t, file.Len(), 10)
+	assert.Equal(t, burndown.globalStatus, map[int]int64{
+		0: 1, 2: 1, 4: 1, 6: 1, 8: 1, 10: 1, 12: 1, 14: 1, 16: 1, 18: 1})
+	burndown.globalStatus = map[int]int64{}
+	file = burndown.newFile("", 0, burndown.day, 0, burndown.globalStatus, nil, nil, nil)
+	burndown.backfillLines(file, 0, 3)
+	assert.Equal(t, burndown.globalStatus, map[int]int64{0: 1, 6: 1, 13: 1})
+
+	burndown = &BurndownAnalysis{InitialCommit: BurndownInitialCommitSpread, BackfillDays: 20}
+	result = run(burndown)
+	// the commits begin on day 20
+	assert.Equal(t, result.GlobalHistory, [][]int64{{0, 0, 0}, {0, 0, 0}, {5, 5, 4, 0}, {0, 0, 4, 0}})
+	assert.Equal(t, result.backfillDays, 20)
+	assert.Equal(t, result.initialCommit, BurndownInitialCommitSpread)
+	buffer.Reset()
+	burndown.Serialize(result, false, buffer)
+	assert.Contains(t, buffer.String(), "  initial_commit: spread\n  backfill_days: 20\n")
+	buffer.Reset()
+	assert.Nil(t, burndown.Serialize(result, true, buffer))
+	msg := pb.BurndownAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.InitialCommit, BurndownInitialCommitSpread)
+	assert.Equal(t, msg.BackfillDays, int32(20))
+	deserialized, err := burndown.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized.(BurndownResult).backfillDays, 20)
+
+	burndown = &BurndownAnalysis{InitialCommit: BurndownInitialCommitExclude}
+	result = run(burndown)
+	assert.Equal(t, result.GlobalHistory, [][]int64{{4, 0}, {4, 0}})
+	assert.Equal(t, result.PeopleHistories[0], [][]int64{{0, 0}, {0, 0}})
+	assert.Equal(t, result.PeopleMatrix[0], []int64{0, 0, 0, 0})
+	assert.Equal(t, result.backfillDays, 0)
+
+	burndown = &BurndownAnalysis{InitialCommit: "wat", BackfillDays: -1}
+	burndown.Initialize(nil)
+	assert.Equal(t, burndown.InitialCommit, BurndownInitialCommitKeep)
+	burndown = &BurndownAnalysis{InitialCommit: BurndownInitialCommitSpread}
+	burndown.Initialize(nil)
+	assert.Equal(t, burndown.BackfillDays, DefaultBurndownBackfillDays)
+}
+
+func TestBurndownDumpLoad(t *testing.T) {
+	blobA := fixtureChurnOriginBlob("0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n")
+	blobB := fixtureChurnOriginBlob("0\n1\n2\n3\n")
+	entry := func(name string, blob *object.Blob) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: blob.Hash}}
+	}
+	commits := []map[string]interface{}{
+		fixtureBurndownInitialCommitDeps(0, 0, object.Changes{{To: entry("a.go", blobA)}}, blobA),
+		fixtureBurndownInitialCommitDeps(1, 3, object.Changes{{To: entry("b.txt", blobB)}}, blobB),
+		fixtureBurndownInitialCommitDeps(1, 12, object.Changes{{From: entry("a.go", blobA)}}, blobA),
+	}
+	fixture := func() *BurndownAnalysis {
+		burndown := &BurndownAnalysis{
+			Granularity: 10, Sampling: 10, PeopleNumber: 2, TrackFiles: true,
+			ExtensionGroups: map[string][]string{"backend": {".go"}},
+		}
+		burndown.Initialize(nil)
+		return burndown
+	}
+	burndown := fixture()
+	for _, deps := range commits {
+		_, err := burndown.Consume(deps)
+		assert.Nil(t, err)
+	}
+	expected := burndown.Finalize().(BurndownResult)
+
+	burndown = fixture()
+	burndown.Consume(commits[0])
+	burndown.Consume(commits[1])
+	// Finalize() does not change the state
+	burndown.Finalize()
+	assert.Len(t, burndown.globalHistory, 0)
+	state := &bytes.Buffer{}
+	assert.Nil(t, burndown.DumpState(state))
+	data := state.Bytes()
+	restored := fixture()
+	assert.Nil(t, restored.LoadState(bytes.NewReader(data)))
+	assert.Len(t, restored.files, 2)
+	assert.Equal(t, restored.files["a.go"].Dump(), burndown.files["a.go"].Dump())
+	assert.Equal(t, restored.fileGroup(restored.files["a.go"]), "backend")
+	assert.Equal(t, restored.fileGroup(restored.files["b.txt"]), "")
+	_, err := restored.Consume(commits[2])
+	assert.Nil(t, err)
+	result := restored.Finalize().(BurndownResult)
+	assert.Equal(t, result.GlobalHistory, expected.GlobalHistory)
+	assert.Equal(t, result.FileHistories, expected.FileHistories)
+	assert.Equal(t, result.GroupHistories, expected.GroupHistories)
+	assert.Equal(t, result.PeopleHistories, expected.PeopleHistories)
+	assert.Equal(t, result.PeopleMatrix, expected.PeopleMatrix)
+
+	restored = fixture()
+	restored.Granularity = 20
+	assert.NotNil(t, restored.LoadState(bytes.NewReader(data)))
+	restored = fixture()
+	restored.PeopleNumber = 3
+	assert.NotNil(t, restored.LoadState(bytes.NewReader(data)))
+	restored = &BurndownAnalysis{Granularity: 10, Sampling: 10, PeopleNumber: 2, TrackFiles: true}
+	restored.Initialize(nil)
+	assert.NotNil(t, restored.LoadState(bytes.NewReader(data)))
+	assert.NotNil(t, restored.LoadState(bytes.NewBufferString("garbage")))
+}
+
+func TestBurndownMergeBackfill(t *testing.T) {
+	burndown := BurndownAnalysis{}
+	c1 := core.CommonAnalysisResult{BeginTime: 1500000000, EndTime: 1500000000 + 10*24*3600}
+	c2 := c1
+	r1 := BurndownResult{GlobalHistory: [][]int64{{5}}, granularity: 10, sampling: 10,
+		initialCommit: BurndownInitialCommitSpread, backfillDays: 30}
+	r2 := BurndownResult{GlobalHistory: [][]int64{{5}}, granularity: 10, sampling: 10}
+	merged := burndown.MergeResults(r1, r2, &c1, &c2).(BurndownResult)
+	assert.Equal(t, merged.backfillDays, 30)
+	assert.Equal(t, merged.initialCommit, BurndownInitialCommitSpread)
+	assert.Equal(t, c1.BeginTime, int64(1500000000))
+	assert.Len(t, merged.GlobalHistory, 4)
+}
+
 func TestBurndownRegistration(t *testing.T) {
 	summoned := core.Registry.Summon((&BurndownAnalysis{}).Name())
 	assert.Len(t, summoned, 1)
@@ -106,6 +373,9 @@ func TestBurndownInitialize(t *testing.T) {
 	burndown.Initialize(test.Repository)
 	assert.Equal(t, burndown.Sampling, DefaultBurndownGranularity-1)
 	assert.Equal(t, burndown.Granularity, DefaultBurndownGranularity)
+	burndown.TrackDirectories = true
+	burndown.Initialize(test.Repository)
+	assert.True(t, burndown.TrackFiles)
 }
 
 func TestBurndownConsumeFinalize(t *testing.T) {
@@ -1079,3 +1349,203 @@ func TestBurndownDeserialize(t *testing.T) {
 	assert.Equal(t, result.granularity, 30)
 	assert.Equal(t, result.sampling, 30)
 }
+
+func TestBurndownDirectoryHistories(t *testing.T) {
+	histories, names, parents := directoryHistories(map[string][][]int64{
+		"README.md":            {{1}, {1, 2}},
+		"cmd/hercules/main.go": {{2}, {2, 3}},
+		"cmd/tool.go":          {{0}, {0, 1}},
+		"core.go":              {{3}},
+	})
+	assert.Equal(t, names, []string{"/", "cmd", "cmd/hercules"})
+	assert.Equal(t, parents, []int{-1, 0, 1})
+	assert.Equal(t, histories["/"], [][]int64{{6}, {3, 6}})
+	assert.Equal(t, histories["cmd"], [][]int64{{2}, {2, 4}})
+	assert.Equal(t, histories["cmd/hercules"], [][]int64{{2}, {2, 3}})
+	_, names, parents = directoryHistories(map[string][][]int64{})
+	assert.Equal(t, names, []string{"/"})
+	assert.Equal(t, parents, []int{-1})
+}
+
+func TestBurndownTruncateFileHistories(t *testing.T) {
+	histories := map[string][][]int64{
+		"a": {{1}, {1, 2}},
+		"b": {{5}, {0, 1}},
+		"c": {{2}, {2, 3}},
+		"d": {{0, 1, 1}},
+	}
+	truncated := truncateFileHistories(histories, 2)
+	assert.Equal(t, truncated, map[string][][]int64{
+		"a":          {{1}, {1, 2}},
+		"c":          {{2}, {2, 3}},
+		TopOtherName: {{5, 1, 1}, {0, 1}},
+	})
+	assert.Len(t, histories, 4)
+	assert.Equal(t, truncateFileHistories(histories, 0), histories)
+	assert.Equal(t, truncateFileHistories(histories, 4), histories)
+	burndown := BurndownAnalysis{TopFiles: 1}
+	result := BurndownResult{
+		GlobalHistory:    [][]int64{{8, 1, 1}, {3, 6}},
+		FileHistories:    histories,
+		sampling:         30,
+		granularity:      30,
+		trackDirectories: true,
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, burndown.serializeBinary(&result, buffer))
+	msg := pb.BurndownAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Files, 2)
+	assert.Equal(t, msg.Files[0].Name, TopOtherName)
+	assert.Equal(t, msg.Files[1].Name, "c")
+	// the directories are calculated from all the files
+	assert.Equal(t, msg.Directories[0].Matrix.Rows[0].Columns, []uint32{8, 1, 1})
+}
+
+func TestBurndownSerializeDayRanges(t *testing.T) {
+	burndown := BurndownAnalysis{}
+	result := BurndownResult{
+		GlobalHistory: [][]int64{
+			{1}, {2}, {3}, {4}, {5}, {6}, {7}, {8}, {9}, {10}},
+		FileHistories: map[string][][]int64{"a": {{1}, {2}}},
+		sampling:      30,
+		granularity:   30,
+	}
+	ranges, err := burndown.SerializeDayRanges(result, 100)
+	assert.Nil(t, err)
+	assert.Len(t, ranges, 3)
+	joined := []byte{}
+	rows := []int{4, 3, 3}
+	for i, part := range ranges {
+		assert.Equal(t, part.From, i*100)
+		assert.Equal(t, part.To, (i+1)*100)
+		msg := pb.BurndownAnalysisResults{}
+		assert.Nil(t, proto.Unmarshal(part.Data, &msg))
+		assert.Len(t, msg.Project.Rows, rows[i])
+		if i > 0 {
+			assert.Len(t, msg.Files, 0)
+		}
+		joined = append(joined, part.Data...)
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, burndown.Serialize(result, true, buffer))
+	expected := pb.BurndownAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &expected))
+	actual := pb.BurndownAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(joined, &actual))
+	assert.Equal(t, actual, expected)
+	// without the project matrix, everything is in the single part
+	ranges, err = burndown.SerializeDayRanges(BurndownResult{sampling: 30, granularity: 30}, 100)
+	assert.Nil(t, err)
+	assert.Len(t, ranges, 1)
+}
+
+func TestBurndownSerializeDirectories(t *testing.T) {
+	burndown := BurndownAnalysis{}
+	result := BurndownResult{
+		GlobalHistory: [][]int64{{4, 0}, {3, 2}},
+		FileHistories: map[string][][]int64{
+			"main.go":    {{1, 0}, {1, 1}},
+			"pkg/foo.go": {{3, 0}, {2, 1}},
+		},
+		sampling:         30,
+		granularity:      30,
+		trackDirectories: true,
+	}
+	buffer := &bytes.Buffer{}
+	burndown.serializeText(&result, buffer)
+	assert.Contains(t, buffer.String(), `  directories:
+    "/": |-
+      4 0
+      3 2
+    "pkg": |-
+      3 0
+      2 1
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, burndown.serializeBinary(&result, buffer))
+	msg := pb.BurndownAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Directories, 2)
+	assert.Equal(t, msg.Directories[0].Matrix.Name, "/")
+	assert.Equal(t, msg.Directories[0].Parent, int32(-1))
+	assert.Equal(t, msg.Directories[1].Matrix.Name, "pkg")
+	assert.Equal(t, msg.Directories[1].Parent, int32(0))
+	assert.Equal(t, msg.Directories[1].Matrix.Rows[1].Columns, []uint32{2, 1})
+	iresult, err := burndown.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.True(t, iresult.(BurndownResult).trackDirectories)
+	result.trackDirectories = false
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, burndown.serializeBinary(&result, buffer))
+	msg = pb.BurndownAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Directories, 0)
+}
+
+func TestBurndownShrink(t *testing.T) {
+	analyser := BurndownAnalysis{}
+	analyser.files = map[string]*burndown.File{
+		"a.go": burndown.NewFile(0, 10), "b.go": burndown.NewFile(1, 20)}
+	analyser.Shrink()
+	assert.Equal(t, analyser.files["a.go"].Len(), 10)
+	assert.Equal(t, analyser.files["b.go"].Dump(), "0 1\n20 -1\n")
+}
+
+func TestBurndownForkMerge(t *testing.T) {
+	repository := test.NewMemoryRepository()
+	when := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	c1 := test.CommitFiles(repository, when, map[string]string{"a.txt": "1\n2\n3\n"})
+	b1 := test.CommitFiles(repository, when.AddDate(0, 0, 1),
+		map[string]string{"a.txt": "1\nx\n3\nb\n", "c.txt": "c\n"}, c1.Hash)
+	c2 := test.CommitFiles(repository, when.AddDate(0, 0, 2),
+		map[string]string{"a.txt": "1\n2\n3\n", "e.txt": "e\ne\n"}, c1.Hash)
+	m := test.CommitFiles(repository, when.AddDate(0, 0, 3), map[string]string{
+		"a.txt": "1\nx\n3\nb\n", "c.txt": "c\n", "e.txt": "e\ne\n", "d.txt": "d\n"},
+		c2.Hash, b1.Hash)
+	treediff := &items.TreeDiff{}
+	treediff.Initialize(repository)
+	cache := &items.BlobCache{}
+	cache.Initialize(repository)
+	main := &BurndownAnalysis{Granularity: 1, Sampling: 1, PeopleNumber: 2, TrackFiles: true}
+	main.Initialize(repository)
+	consume := func(treediff *items.TreeDiff, analyser *BurndownAnalysis,
+		commit *object.Commit, author, day int) {
+		deps := map[string]interface{}{
+			"commit": commit, identity.DependencyAuthor: author, items.DependencyDay: day}
+		res, err := treediff.Consume(deps)
+		assert.Nil(t, err)
+		deps[items.DependencyTreeChanges] = res[items.DependencyTreeChanges]
+		res, err = cache.Consume(deps)
+		assert.Nil(t, err)
+		deps[items.DependencyBlobCache] = res[items.DependencyBlobCache]
+		fd := &items.FileDiff{}
+		fd.Initialize(repository)
+		res, err = fd.Consume(deps)
+		assert.Nil(t, err)
+		deps[items.DependencyFileDiff] = res[items.DependencyFileDiff]
+		_, err = analyser.Consume(deps)
+		assert.Nil(t, err)
+	}
+	consume(treediff, main, c1, 0, 0)
+	branchDiff := treediff.Fork(1)[0].(*items.TreeDiff)
+	branch := main.Fork(1)[0].(*BurndownAnalysis)
+	consume(branchDiff, branch, b1, 1, 1)
+	assert.Equal(t, branch.files["a.txt"].Len(), 4)
+	// the branch must not change the original
+	assert.Equal(t, main.files["a.txt"].Len(), 3)
+	assert.Len(t, main.files, 1)
+	consume(treediff, main, c2, 0, 2)
+	treediff.Merge([]core.PipelineItem{branchDiff})
+	main.Merge([]core.PipelineItem{branch})
+	consume(treediff, main, m, 0, 3)
+	assert.Len(t, main.files, 4)
+	// the lines adopted from the branch keep their authors and days
+	author := 1 << 14
+	assert.Equal(t, main.files["a.txt"].Values(0, 4), []int{0, author | 1, 0, author | 1})
+	assert.Equal(t, main.files["c.txt"].Values(0, 1), []int{author | 1})
+	assert.Equal(t, main.globalStatus, map[int]int64{0: 2, 1: 3, 2: 2, 3: 1})
+	// the overwrite is counted once
+	assert.Equal(t, main.matrix[0], map[int]int64{authorSelf: 6, 1: -1})
+	assert.Equal(t, main.matrix[1], map[int]int64{authorSelf: 3})
+}
@@ -0,0 +1,246 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// CaseCollisionsAnalysis finds the files and the directories whose paths differ only in case.
+// They cannot coexist in a checkout on a case-insensitive filesystem, e.g. on macOS or Windows,
+// where one silently overwrites the other. The analysis reports when each collision appeared
+// in the history and when it was resolved.
+// It is a LeafPipelineItem.
+type CaseCollisionsAnalysis struct {
+	// paths maps the files and the directories, the latter with the trailing slash, to the number
+	// of the files under them. The files map to 1.
+	paths map[string]int
+	// folded maps the lower case paths without the trailing slash to the existing paths.
+	folded map[string]map[string]bool
+	// open maps the lower case paths to the indexes of the unresolved collisions.
+	open map[string]int
+	// collisions are the collisions in the order of appearance.
+	collisions []CaseCollision
+}
+
+// CaseCollision is a set of the paths which differ only in case.
+type CaseCollision struct {
+	// Paths are all the colliding paths, sorted. The directories end with a slash.
+	Paths []string
+	// Introduced is the commit which made the paths collide.
+	Introduced plumbing.Hash
+	// Resolved is the commit which left at most one of the paths, plumbing.ZeroHash if
+	// the collision still exists.
+	Resolved plumbing.Hash
+}
+
+// CaseCollisionsResult is returned by CaseCollisionsAnalysis.Finalize() and carries
+// the path collisions.
+type CaseCollisionsResult struct {
+	// Collisions are sorted by the order of appearance.
+	Collisions []CaseCollision
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (collisions *CaseCollisionsAnalysis) Name() string {
+	return "CaseCollisions"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (collisions *CaseCollisionsAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (collisions *CaseCollisionsAnalysis) Requires() []string {
+	arr := [...]string{items.DependencyTreeChanges}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (collisions *CaseCollisionsAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (collisions *CaseCollisionsAnalysis) Flag() string {
+	return "case-collisions"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (collisions *CaseCollisionsAnalysis) Configure(facts map[string]interface{}) {
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (collisions *CaseCollisionsAnalysis) Initialize(repository *git.Repository) {
+	collisions.paths = map[string]int{}
+	collisions.folded = map[string]map[string]bool{}
+	collisions.open = map[string]int{}
+	collisions.collisions = []CaseCollision{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (collisions *CaseCollisionsAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit).Hash
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	touched := map[string]bool{}
+	for _, change := range changes {
+		if change.From.Name == change.To.Name {
+			continue
+		}
+		if change.From.Name != "" {
+			collisions.update(change.From.Name, -1, touched)
+		}
+		if change.To.Name != "" {
+			collisions.update(change.To.Name, 1, touched)
+		}
+	}
+	keys := make([]string, 0, len(touched))
+	for key := range touched {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		existing := collisions.folded[key]
+		index, isOpen := collisions.open[key]
+		if len(existing) < 2 {
+			if isOpen {
+				collisions.collisions[index].Resolved = commit
+				delete(collisions.open, key)
+			}
+			continue
+		}
+		if !isOpen {
+			index = len(collisions.collisions)
+			collisions.collisions = append(collisions.collisions, CaseCollision{Introduced: commit})
+			collisions.open[key] = index
+		}
+		collision := &collisions.collisions[index]
+		for path := range existing {
+			if i := sort.SearchStrings(collision.Paths, path); i == len(collision.Paths) ||
+				collision.Paths[i] != path {
+				collision.Paths = append(collision.Paths, path)
+				sort.Strings(collision.Paths)
+			}
+		}
+	}
+	return nil, nil
+}
+
+// update adds delta to the number of the files under the file's path and under each of
+// its parent directories and records the lower case paths whose sets of the existing paths
+// changed in `touched`.
+func (collisions *CaseCollisionsAnalysis) update(name string, delta int, touched map[string]bool) {
+	for i := 0; i <= len(name); i++ {
+		if i < len(name) && name[i] != '/' {
+			continue
+		}
+		path := name
+		if i < len(name) {
+			path = name[:i+1]
+		}
+		count := collisions.paths[path] + delta
+		key := strings.ToLower(strings.TrimSuffix(path, "/"))
+		switch {
+		case count <= 0:
+			delete(collisions.paths, path)
+			delete(collisions.folded[key], path)
+			if len(collisions.folded[key]) == 0 {
+				delete(collisions.folded, key)
+			}
+			touched[key] = true
+		case count == 1 && delta > 0:
+			collisions.paths[path] = count
+			if collisions.folded[key] == nil {
+				collisions.folded[key] = map[string]bool{}
+			}
+			collisions.folded[key][path] = true
+			touched[key] = true
+		default:
+			collisions.paths[path] = count
+		}
+	}
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (collisions *CaseCollisionsAnalysis) Finalize() interface{} {
+	return CaseCollisionsResult{Collisions: collisions.collisions}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (collisions *CaseCollisionsAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	collisionsResult := result.(CaseCollisionsResult)
+	if binary {
+		return collisions.serializeBinary(&collisionsResult, writer)
+	}
+	collisions.serializeText(&collisionsResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (collisions *CaseCollisionsAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(collisions, result, &pb.CaseCollisionsResults{}, writer)
+}
+
+func (collisions *CaseCollisionsAnalysis) serializeText(result *CaseCollisionsResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  collisions:")
+	for _, collision := range result.Collisions {
+		paths := make([]string, len(collision.Paths))
+		for i, path := range collision.Paths {
+			paths[i] = yaml.SafeString(path)
+		}
+		resolved := ""
+		if collision.Resolved != plumbing.ZeroHash {
+			resolved = collision.Resolved.String()
+		}
+		fmt.Fprintf(writer, "    - paths: [%s]\n", strings.Join(paths, ", "))
+		fmt.Fprintf(writer, "      introduced: \"%s\"\n", collision.Introduced.String())
+		fmt.Fprintf(writer, "      resolved: \"%s\"\n", resolved)
+	}
+}
+
+func (collisions *CaseCollisionsAnalysis) serializeBinary(result *CaseCollisionsResult, writer io.Writer) error {
+	message := pb.CaseCollisionsResults{
+		Collisions: make([]*pb.CaseCollision, len(result.Collisions)),
+	}
+	for i, collision := range result.Collisions {
+		pbCollision := &pb.CaseCollision{
+			Paths:      collision.Paths,
+			Introduced: collision.Introduced.String(),
+		}
+		if collision.Resolved != plumbing.ZeroHash {
+			pbCollision.Resolved = collision.Resolved.String()
+		}
+		message.Collisions[i] = pbCollision
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&CaseCollisionsAnalysis{})
+}
@@ -0,0 +1,129 @@
+package leaves
+
+import (
+	"bytes"
+	"path"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureCaseCollisions() *CaseCollisionsAnalysis {
+	collisions := CaseCollisionsAnalysis{}
+	collisions.Initialize(test.Repository)
+	return &collisions
+}
+
+func TestCaseCollisionsMeta(t *testing.T) {
+	collisions := fixtureCaseCollisions()
+	assert.Equal(t, collisions.Name(), "CaseCollisions")
+	assert.Len(t, collisions.Provides(), 0)
+	assert.Equal(t, collisions.Requires(), []string{items.DependencyTreeChanges})
+	assert.Len(t, collisions.ListConfigurationOptions(), 0)
+	assert.Equal(t, collisions.Flag(), "case-collisions")
+	collisions.Configure(nil)
+}
+
+func TestCaseCollisionsRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&CaseCollisionsAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "CaseCollisions")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&CaseCollisionsAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestCaseCollisionsConsumeFinalize(t *testing.T) {
+	collisions := fixtureCaseCollisions()
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: path.Base(name), Mode: 0100644}}
+	}
+	hash := func(i int) plumbing.Hash {
+		return plumbing.Hash{byte(i)}
+	}
+	consume := func(i int, changes object.Changes) {
+		result, err := collisions.Consume(map[string]interface{}{
+			"commit":                    &object.Commit{Hash: hash(i)},
+			items.DependencyTreeChanges: changes,
+		})
+		assert.Nil(t, result)
+		assert.Nil(t, err)
+	}
+	consume(1, object.Changes{
+		{To: entry("README.md")},
+		{To: entry("Docs/a.md")},
+		{To: entry("src/main.go")},
+	})
+	consume(2, object.Changes{
+		{To: entry("readme.md")},
+		{To: entry("docs/b.md")},
+		{From: entry("src/main.go"), To: entry("src/main.go")},
+	})
+	// the third path joins the open collision
+	consume(3, object.Changes{
+		{To: entry("ReadMe.md")},
+		{From: entry("src/main.go"), To: entry("src/Main.go")},
+	})
+	consume(4, object.Changes{
+		{From: entry("README.md")},
+		{From: entry("readme.md")},
+		{From: entry("Docs/a.md"), To: entry("docs/a.md")},
+	})
+	consume(5, object.Changes{{To: entry("DOCS")}})
+	res := collisions.Finalize().(CaseCollisionsResult)
+	assert.Equal(t, res.Collisions, []CaseCollision{
+		{Paths: []string{"Docs/", "docs/"}, Introduced: hash(2), Resolved: hash(4)},
+		{Paths: []string{"README.md", "ReadMe.md", "readme.md"}, Introduced: hash(2), Resolved: hash(4)},
+		{Paths: []string{"DOCS", "docs/"}, Introduced: hash(5)},
+	})
+	assert.Equal(t, collisions.paths, map[string]int{
+		"ReadMe.md": 1, "docs/": 2, "docs/a.md": 1, "docs/b.md": 1, "src/": 1, "src/Main.go": 1,
+		"DOCS": 1})
+}
+
+func TestCaseCollisionsSerialize(t *testing.T) {
+	collisions := fixtureCaseCollisions()
+	res := CaseCollisionsResult{Collisions: []CaseCollision{{
+		Paths:      []string{"Docs/", "docs/"},
+		Introduced: plumbing.NewHash("1111111111111111111111111111111111111111"),
+		Resolved:   plumbing.NewHash("2222222222222222222222222222222222222222"),
+	}, {
+		Paths:      []string{"README", "readme"},
+		Introduced: plumbing.NewHash("3333333333333333333333333333333333333333"),
+	}}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, collisions.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  collisions:
+    - paths: ["Docs/", "docs/"]
+      introduced: "1111111111111111111111111111111111111111"
+      resolved: "2222222222222222222222222222222222222222"
+    - paths: ["README", "readme"]
+      introduced: "3333333333333333333333333333333333333333"
+      resolved: ""
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, collisions.Serialize(res, true, buffer))
+	msg := pb.CaseCollisionsResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Collisions, 2)
+	assert.Equal(t, *msg.Collisions[0], pb.CaseCollision{
+		Paths:      []string{"Docs/", "docs/"},
+		Introduced: "1111111111111111111111111111111111111111",
+		Resolved:   "2222222222222222222222222222222222222222",
+	})
+	assert.Equal(t, msg.Collisions[1].Resolved, "")
+}
@@ -0,0 +1,367 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// ChangeSetsAnalysis approximates the pull requests in the merge-heavy workflows. Each merge
+// commit brings a "change set": the commits reachable from its second (and further) parents
+// which were not seen in the analysed history before. The analysis reports the size of every
+// change set, its duration from the first to the last authored commit and the number of
+// the distinct authors, and aggregates them per calendar month.
+// It is a LeafPipelineItem.
+type ChangeSetsAnalysis struct {
+	// MaxCommits is the maximum number of commits to visit in a single second-parent range.
+	// It protects from walking the whole history if the analysis did not start at the root.
+	MaxCommits int
+
+	repository *git.Repository
+	// peopleDict references IdentityDetector.PeopleDict, it is nil if the identities are
+	// not detected.
+	peopleDict map[string]int
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+	// seen is the set of the commits which were either analysed or included in a change set.
+	seen map[plumbing.Hash]bool
+	// changeSets are the change sets of the merges processed so far, in order.
+	changeSets []ChangeSet
+}
+
+// ChangeSet is the second-parent range of a merge commit.
+type ChangeSet struct {
+	// Merge is the hash of the merge commit.
+	Merge plumbing.Hash
+	// Merged is the committer date of the merge commit.
+	Merged time.Time
+	// Commits is the number of the non-merge commits in the range.
+	Commits int
+	// Files is the number of files changed by the merge relative to its first parent.
+	Files int
+	// Lines is the number of added, removed and changed lines relative to the first parent.
+	Lines int
+	// Duration is the time between the first and the last authored commits in the range.
+	Duration time.Duration
+	// Authors is the number of the distinct authors of the commits in the range.
+	Authors int
+}
+
+// ChangeSetStats are the distributions of the change sets merged in a month.
+type ChangeSetStats struct {
+	// ChangeSets is the number of the merged change sets.
+	ChangeSets int
+	// MedianLines is the median number of the changed lines.
+	MedianLines int
+	// P90Lines is the 90th percentile of the changed lines.
+	P90Lines int
+	// MedianDuration is the median duration.
+	MedianDuration time.Duration
+	// P90Duration is the 90th percentile of the durations.
+	P90Duration time.Duration
+	// MeanAuthors is the average number of authors.
+	MeanAuthors float64
+	// MultiAuthor is the number of change sets with more than one author.
+	MultiAuthor int
+}
+
+// ChangeSetsResult is returned by ChangeSetsAnalysis.Finalize() and carries the change sets
+// of all the analysed merges and their monthly distributions.
+type ChangeSetsResult struct {
+	// ChangeSets are sorted in the chronological order of the merges.
+	ChangeSets []ChangeSet
+	// Months maps the month ("2018-03") to the stats of the change sets merged in that month.
+	Months map[string]ChangeSetStats
+}
+
+const (
+	// ConfigChangeSetsMaxCommits is the name of the option to set ChangeSetsAnalysis.MaxCommits.
+	ConfigChangeSetsMaxCommits = "ChangeSets.MaxCommits"
+	// DefaultChangeSetsMaxCommits is the default value of ChangeSetsAnalysis.MaxCommits.
+	DefaultChangeSetsMaxCommits = 1000
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (sets *ChangeSetsAnalysis) Name() string {
+	return "ChangeSets"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (sets *ChangeSetsAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (sets *ChangeSetsAnalysis) Requires() []string {
+	arr := [...]string{
+		items.DependencyTreeChanges, items.DependencyFileDiff, items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (sets *ChangeSetsAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigChangeSetsMaxCommits,
+		Description: "Maximum number of commits to visit in the second-parent range of a merge.",
+		Flag:        "change-sets-max-commits",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultChangeSetsMaxCommits},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (sets *ChangeSetsAnalysis) Flag() string {
+	return "change-sets"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (sets *ChangeSetsAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigChangeSetsMaxCommits].(int); exists {
+		sets.MaxCommits = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorPeopleDict].(map[string]int); exists {
+		sets.peopleDict = val
+		sets.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (sets *ChangeSetsAnalysis) Initialize(repository *git.Repository) {
+	if sets.MaxCommits <= 0 {
+		log.Printf("Warning: adjusted the maximum change set size to %d\n",
+			DefaultChangeSetsMaxCommits)
+		sets.MaxCommits = DefaultChangeSetsMaxCommits
+	}
+	sets.repository = repository
+	sets.seen = map[plumbing.Hash]bool{}
+	sets.changeSets = []ChangeSet{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (sets *ChangeSetsAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	sets.seen[commit.Hash] = true
+	if commit.NumParents() < 2 {
+		return nil, nil
+	}
+	changeSet := ChangeSet{Merge: commit.Hash, Merged: commit.Committer.When}
+	if err := sets.walkRange(commit.ParentHashes[1:], &changeSet); err != nil {
+		return nil, err
+	}
+	if changeSet.Commits == 0 {
+		// e.g. merging a branch which was already merged
+		return nil, nil
+	}
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	changeSet.Files = len(treeDiff)
+	for _, change := range treeDiff {
+		_, lines, err := changedLines(change, cache, fileDiffs)
+		if err != nil {
+			return nil, err
+		}
+		changeSet.Lines += lines
+	}
+	sets.changeSets = append(sets.changeSets, changeSet)
+	return nil, nil
+}
+
+// walkRange visits the commits reachable from the specified heads which were not seen yet
+// and fills the number of commits, the duration and the number of authors.
+func (sets *ChangeSetsAnalysis) walkRange(heads []plumbing.Hash, changeSet *ChangeSet) error {
+	authors := map[string]bool{}
+	var first, last time.Time
+	queue := append([]plumbing.Hash{}, heads...)
+	visited := 0
+	for len(queue) > 0 {
+		hash := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		if sets.seen[hash] {
+			continue
+		}
+		if visited >= sets.MaxCommits {
+			log.Printf("Warning: the change set of %s is larger than %d commits => truncated\n",
+				changeSet.Merge.String(), sets.MaxCommits)
+			break
+		}
+		sets.seen[hash] = true
+		visited++
+		commit, err := sets.repository.CommitObject(hash)
+		if err != nil {
+			return err
+		}
+		queue = append(queue, commit.ParentHashes...)
+		if commit.NumParents() > 1 {
+			continue
+		}
+		changeSet.Commits++
+		authors[sets.authorKey(commit.Author)] = true
+		when := commit.Author.When
+		if first.IsZero() || when.Before(first) {
+			first = when
+		}
+		if last.IsZero() || when.After(last) {
+			last = when
+		}
+	}
+	changeSet.Duration = last.Sub(first)
+	changeSet.Authors = len(authors)
+	return nil
+}
+
+// authorKey returns the identity of the commit author. The signatures which are not matched by
+// IdentityDetector are distinguished by the email.
+func (sets *ChangeSetsAnalysis) authorKey(signature object.Signature) string {
+	email := strings.ToLower(signature.Email)
+	id, exists := sets.peopleDict[email]
+	if !exists {
+		id, exists = sets.peopleDict[strings.ToLower(signature.Name)]
+	}
+	if exists && id < len(sets.reversedPeopleDict) {
+		return sets.reversedPeopleDict[id]
+	}
+	return "<" + email + ">"
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (sets *ChangeSetsAnalysis) Finalize() interface{} {
+	months := map[string][]ChangeSet{}
+	for _, changeSet := range sets.changeSets {
+		month := changeSet.Merged.UTC().Format("2006-01")
+		months[month] = append(months[month], changeSet)
+	}
+	result := ChangeSetsResult{
+		ChangeSets: sets.changeSets,
+		Months:     map[string]ChangeSetStats{},
+	}
+	for month, changeSets := range months {
+		result.Months[month] = aggregateChangeSets(changeSets)
+	}
+	return result
+}
+
+// aggregateChangeSets calculates the distributions of the change sizes, durations and authors.
+func aggregateChangeSets(changeSets []ChangeSet) ChangeSetStats {
+	lines := make([]int, len(changeSets))
+	durations := make([]time.Duration, len(changeSets))
+	stats := ChangeSetStats{ChangeSets: len(changeSets)}
+	authors := 0
+	for i, changeSet := range changeSets {
+		lines[i] = changeSet.Lines
+		durations[i] = changeSet.Duration
+		authors += changeSet.Authors
+		if changeSet.Authors > 1 {
+			stats.MultiAuthor++
+		}
+	}
+	sort.Ints(lines)
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	stats.MedianLines = lines[len(lines)/2]
+	stats.P90Lines = lines[len(lines)*9/10]
+	stats.MedianDuration = durations[len(durations)/2]
+	stats.P90Duration = durations[len(durations)*9/10]
+	stats.MeanAuthors = float64(authors) / float64(len(changeSets))
+	return stats
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (sets *ChangeSetsAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	setsResult := result.(ChangeSetsResult)
+	if binary {
+		return sets.serializeBinary(&setsResult, writer)
+	}
+	sets.serializeText(&setsResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (sets *ChangeSetsAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(sets, result, &pb.ChangeSetsResults{}, writer)
+}
+
+func (sets *ChangeSetsAnalysis) serializeText(result *ChangeSetsResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  change_sets:  # merged, commits, files, lines, duration, authors")
+	for _, changeSet := range result.ChangeSets {
+		fmt.Fprintf(writer, "    %s: [%d, %d, %d, %d, %d, %d]\n", changeSet.Merge.String(),
+			changeSet.Merged.Unix(), changeSet.Commits, changeSet.Files, changeSet.Lines,
+			int64(changeSet.Duration.Seconds()), changeSet.Authors)
+	}
+	months := make([]string, 0, len(result.Months))
+	for month := range result.Months {
+		months = append(months, month)
+	}
+	sort.Strings(months)
+	fmt.Fprintln(writer, "  months:  # change sets, median lines, 90th percentile lines, "+
+		"median duration, 90th percentile duration, mean authors, multi-author")
+	for _, month := range months {
+		stats := result.Months[month]
+		fmt.Fprintf(writer, "    %s: [%d, %d, %d, %d, %d, %.4f, %d]\n", yaml.SafeString(month),
+			stats.ChangeSets, stats.MedianLines, stats.P90Lines,
+			int64(stats.MedianDuration.Seconds()), int64(stats.P90Duration.Seconds()),
+			stats.MeanAuthors, stats.MultiAuthor)
+	}
+}
+
+func (sets *ChangeSetsAnalysis) serializeBinary(result *ChangeSetsResult, writer io.Writer) error {
+	message := pb.ChangeSetsResults{
+		ChangeSets: make([]*pb.ChangeSet, len(result.ChangeSets)),
+		Months:     map[string]*pb.ChangeSetStats{},
+	}
+	for i, changeSet := range result.ChangeSets {
+		message.ChangeSets[i] = &pb.ChangeSet{
+			Merge:    changeSet.Merge.String(),
+			Merged:   changeSet.Merged.Unix(),
+			Commits:  int32(changeSet.Commits),
+			Files:    int32(changeSet.Files),
+			Lines:    int32(changeSet.Lines),
+			Duration: int64(changeSet.Duration.Seconds()),
+			Authors:  int32(changeSet.Authors),
+		}
+	}
+	for month, stats := range result.Months {
+		message.Months[month] = &pb.ChangeSetStats{
+			ChangeSets:     int32(stats.ChangeSets),
+			MedianLines:    int32(stats.MedianLines),
+			P90Lines:       int32(stats.P90Lines),
+			MedianDuration: int64(stats.MedianDuration.Seconds()),
+			P90Duration:    int64(stats.P90Duration.Seconds()),
+			MeanAuthors:    float32(stats.MeanAuthors),
+			MultiAuthor:    int32(stats.MultiAuthor),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&ChangeSetsAnalysis{})
+}
@@ -0,0 +1,238 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-billy.v4/memfs"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureChangeSetsDate(day int) time.Time {
+	return time.Date(2018, 1, 10+day, 12, 0, 0, 0, time.UTC)
+}
+
+// fixtureChangeSetsRepository creates an in-memory repository with the following history:
+//
+//	c1 -- c2 ------- m1 ---------- m2 -- m3
+//	  \             /             /     /
+//	   b1 (bob) -- b2 (carol) -- b3 (BOB)
+//
+// The first parents of the merges are on the main line. It returns the main line commits.
+func fixtureChangeSetsRepository() (*git.Repository, []*object.Commit) {
+	repository, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		panic(err)
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		panic(err)
+	}
+	commit := func(name string, day int, parents ...plumbing.Hash) plumbing.Hash {
+		file, err := worktree.Filesystem.Create("README")
+		if err != nil {
+			panic(err)
+		}
+		file.Write([]byte(name + fixtureChangeSetsDate(day).String()))
+		file.Close()
+		worktree.Add("README")
+		hash, err := worktree.Commit("Commit", &git.CommitOptions{
+			Author: &object.Signature{
+				Name: name, Email: name + "@sourced.tech", When: fixtureChangeSetsDate(day)},
+			Parents: parents,
+		})
+		if err != nil {
+			panic(err)
+		}
+		return hash
+	}
+	c1 := commit("alice", 0)
+	b1 := commit("bob", 1, c1)
+	b2 := commit("carol", 3, b1)
+	c2 := commit("alice", 2, c1)
+	m1 := commit("alice", 4, c2, b2)
+	b3 := commit("BOB", 25, b2)
+	m2 := commit("alice", 26, m1, b3)
+	m3 := commit("alice", 27, m2, b3)
+	var mainLine []*object.Commit
+	for _, hash := range []plumbing.Hash{c1, c2, m1, m2, m3} {
+		commit, err := repository.CommitObject(hash)
+		if err != nil {
+			panic(err)
+		}
+		mainLine = append(mainLine, commit)
+	}
+	return repository, mainLine
+}
+
+func fixtureChangeSets() *ChangeSetsAnalysis {
+	sets := ChangeSetsAnalysis{MaxCommits: 100}
+	sets.Initialize(test.Repository)
+	return &sets
+}
+
+func TestChangeSetsMeta(t *testing.T) {
+	sets := fixtureChangeSets()
+	assert.Equal(t, sets.Name(), "ChangeSets")
+	assert.Len(t, sets.Provides(), 0)
+	assert.Equal(t, sets.Requires(), []string{
+		items.DependencyTreeChanges, items.DependencyFileDiff, items.DependencyBlobCache})
+	opts := sets.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigChangeSetsMaxCommits)
+	assert.Equal(t, sets.Flag(), "change-sets")
+}
+
+func TestChangeSetsConfigure(t *testing.T) {
+	sets := ChangeSetsAnalysis{}
+	facts := map[string]interface{}{}
+	facts[ConfigChangeSetsMaxCommits] = 5
+	facts[identity.FactIdentityDetectorPeopleDict] = map[string]int{"bob": 0}
+	facts[identity.FactIdentityDetectorReversedPeopleDict] = []string{"bob"}
+	sets.Configure(facts)
+	assert.Equal(t, sets.MaxCommits, 5)
+	assert.Equal(t, sets.peopleDict, map[string]int{"bob": 0})
+	assert.Equal(t, sets.reversedPeopleDict, []string{"bob"})
+	sets.Configure(map[string]interface{}{})
+	assert.Equal(t, sets.MaxCommits, 5)
+	sets.MaxCommits = 0
+	sets.Initialize(test.Repository)
+	assert.Equal(t, sets.MaxCommits, DefaultChangeSetsMaxCommits)
+}
+
+func TestChangeSetsRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&ChangeSetsAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "ChangeSets")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&ChangeSetsAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestChangeSetsConsumeFinalize(t *testing.T) {
+	repository, mainLine := fixtureChangeSetsRepository()
+	sets := fixtureChangeSets()
+	sets.peopleDict = map[string]int{"bob@sourced.tech": 0, "carol": 1}
+	sets.reversedPeopleDict = []string{"bob", "carol"}
+	sets.Initialize(repository)
+	deps := fixtureCommitEntropyDeps()
+	for _, commit := range mainLine {
+		deps["commit"] = commit
+		result, err := sets.Consume(deps)
+		assert.Nil(t, result)
+		assert.Nil(t, err)
+	}
+	res := sets.Finalize().(ChangeSetsResult)
+	assert.Equal(t, res.ChangeSets, []ChangeSet{{
+		Merge:    mainLine[2].Hash,
+		Merged:   mainLine[2].Committer.When,
+		Commits:  2,
+		Files:    4,
+		Lines:    9,
+		Duration: 2 * 24 * time.Hour,
+		Authors:  2,
+	}, {
+		Merge:   mainLine[3].Hash,
+		Merged:  mainLine[3].Committer.When,
+		Commits: 1,
+		Files:   4,
+		Lines:   9,
+		Authors: 1,
+	}})
+	assert.Equal(t, res.Months, map[string]ChangeSetStats{
+		"2018-01": {ChangeSets: 1, MedianLines: 9, P90Lines: 9,
+			MedianDuration: 2 * 24 * time.Hour, P90Duration: 2 * 24 * time.Hour,
+			MeanAuthors: 2, MultiAuthor: 1},
+		"2018-02": {ChangeSets: 1, MedianLines: 9, P90Lines: 9, MeanAuthors: 1},
+	})
+}
+
+func TestChangeSetsMaxCommits(t *testing.T) {
+	repository, mainLine := fixtureChangeSetsRepository()
+	sets := fixtureChangeSets()
+	sets.MaxCommits = 1
+	sets.Initialize(repository)
+	deps := fixtureCommitEntropyDeps()
+	for _, commit := range mainLine[:3] {
+		deps["commit"] = commit
+		sets.Consume(deps)
+	}
+	res := sets.Finalize().(ChangeSetsResult)
+	assert.Len(t, res.ChangeSets, 1)
+	assert.Equal(t, res.ChangeSets[0].Commits, 1)
+	assert.Equal(t, res.ChangeSets[0].Authors, 1)
+}
+
+func TestChangeSetsAggregate(t *testing.T) {
+	var changeSets []ChangeSet
+	for i := 1; i <= 10; i++ {
+		changeSets = append(changeSets, ChangeSet{
+			Lines: i * 10, Duration: time.Duration(i) * time.Hour, Authors: 1 + i%2})
+	}
+	assert.Equal(t, aggregateChangeSets(changeSets), ChangeSetStats{
+		ChangeSets:     10,
+		MedianLines:    60,
+		P90Lines:       100,
+		MedianDuration: 6 * time.Hour,
+		P90Duration:    10 * time.Hour,
+		MeanAuthors:    1.5,
+		MultiAuthor:    5,
+	})
+}
+
+func TestChangeSetsSerialize(t *testing.T) {
+	sets := fixtureChangeSets()
+	res := ChangeSetsResult{
+		ChangeSets: []ChangeSet{{
+			Merge:    plumbing.NewHash("2b1ed978194a94edeabbca6de7ff3b5771d4d665"),
+			Merged:   fixtureChangeSetsDate(4),
+			Commits:  2,
+			Files:    4,
+			Lines:    9,
+			Duration: 2 * 24 * time.Hour,
+			Authors:  2,
+		}},
+		Months: map[string]ChangeSetStats{
+			"2018-02": {ChangeSets: 1, MedianLines: 9, P90Lines: 9, MeanAuthors: 1},
+			"2018-01": {ChangeSets: 1, MedianLines: 9, P90Lines: 9,
+				MedianDuration: time.Hour, P90Duration: time.Hour, MeanAuthors: 2, MultiAuthor: 1},
+		},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, sets.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  change_sets:  # merged, commits, files, lines, duration, authors
+    2b1ed978194a94edeabbca6de7ff3b5771d4d665: [1515931200, 2, 4, 9, 172800, 2]
+  months:  # change sets, median lines, 90th percentile lines, median duration, 90th percentile duration, mean authors, multi-author
+    "2018-01": [1, 9, 9, 3600, 3600, 2.0000, 1]
+    "2018-02": [1, 9, 9, 0, 0, 1.0000, 0]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, sets.Serialize(res, true, buffer))
+	msg := pb.ChangeSetsResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.ChangeSets, 1)
+	assert.Equal(t, *msg.ChangeSets[0], pb.ChangeSet{
+		Merge: "2b1ed978194a94edeabbca6de7ff3b5771d4d665", Merged: 1515931200,
+		Commits: 2, Files: 4, Lines: 9, Duration: 172800, Authors: 2})
+	assert.Len(t, msg.Months, 2)
+	assert.Equal(t, *msg.Months["2018-01"], pb.ChangeSetStats{
+		ChangeSets: 1, MedianLines: 9, P90Lines: 9, MedianDuration: 3600, P90Duration: 3600,
+		MeanAuthors: 2, MultiAuthor: 1})
+}
@@ -0,0 +1,478 @@
+package leaves
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	fdiff "gopkg.in/src-d/go-git.v4/plumbing/format/diff"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// CherryPicksAnalysis detects the commits which introduce the same changes (cherry-picks,
+// backports, re-applied commits) by comparing their patch IDs. The patch ID is the hash of
+// the changed lines without the whitespace and the line numbers, similar to `git patch-id`.
+// Besides the analysed commits, it optionally scans the other branches down to the point
+// where they fork from the analysed history. The copies in the release branches are reported
+// as backports of the main branch fixes.
+// It is a LeafPipelineItem.
+type CherryPicksAnalysis struct {
+	// ScanBranches indicates whether to look for the duplicates in the other branches.
+	ScanBranches bool
+	// ReleaseBranches is the regular expression which matches the names of the release branches.
+	ReleaseBranches string
+
+	repository      *git.Repository
+	releaseBranches *regexp.Regexp
+	// patches maps patch IDs to the commits which have them.
+	patches map[plumbing.Hash][]patchOccurrence
+	// commits is the set of already seen commits.
+	commits map[plumbing.Hash]bool
+}
+
+// patchOccurrence is a commit with the specific patch ID.
+type patchOccurrence struct {
+	Hash   plumbing.Hash
+	Branch string
+	When   time.Time
+}
+
+// CherryPick is the pair of commits with the same patch ID.
+type CherryPick struct {
+	// Original is the hash of the commit which was committed first.
+	Original plumbing.Hash
+	// OriginalBranch is the name of the branch which contains Original.
+	OriginalBranch string
+	// OriginalTime is the committer date of Original.
+	OriginalTime time.Time
+	// Copy is the hash of the duplicated commit.
+	Copy plumbing.Hash
+	// CopyBranch is the name of the branch which contains Copy.
+	CopyBranch string
+	// CopyTime is the committer date of Copy.
+	CopyTime time.Time
+}
+
+// CherryPicksResult is returned by CherryPicksAnalysis.Finalize() and carries the detected
+// duplicate commits.
+type CherryPicksResult struct {
+	// Commits is the number of inspected non-merge commits.
+	Commits int
+	// Duplicated is the number of commits which are copies of some other commits.
+	Duplicated int
+	// Pairs is the list of the detected original -> copy mappings, sorted by the copy time.
+	Pairs []CherryPick
+	// Backports maps the release branch names to the main branch commits which were copied there.
+	Backports map[string]BackportStats
+}
+
+// BackportStats describes the main branch fixes which reached a release branch.
+type BackportStats struct {
+	// Fixes are the hashes of the backported main branch commits, sorted by the backport time.
+	Fixes []plumbing.Hash
+	// MedianLatency is the median time between committing a fix to the main branch and
+	// committing its copy to the release branch.
+	MedianLatency time.Duration
+}
+
+const (
+	// ConfigCherryPicksScanBranches is the name of the option to set
+	// CherryPicksAnalysis.ScanBranches.
+	ConfigCherryPicksScanBranches = "CherryPicks.ScanBranches"
+	// ConfigCherryPicksReleaseBranches is the name of the option to set
+	// CherryPicksAnalysis.ReleaseBranches.
+	ConfigCherryPicksReleaseBranches = "CherryPicks.ReleaseBranches"
+	// DefaultCherryPicksReleaseBranches is the default value of CherryPicksAnalysis.ReleaseBranches.
+	// It matches "release-1.0", "origin/stable", "v2.1", "3.x", etc.
+	DefaultCherryPicksReleaseBranches = `^(.+/)?(release|stable|v?[0-9]+\.([0-9]+|x))`
+	// CherryPicksMainBranch is the name of the branch which corresponds to the analysed commits.
+	CherryPicksMainBranch = "HEAD"
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (picks *CherryPicksAnalysis) Name() string {
+	return "CherryPicks"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (picks *CherryPicksAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (picks *CherryPicksAnalysis) Requires() []string {
+	return []string{}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (picks *CherryPicksAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigCherryPicksScanBranches,
+		Description: "Look for the cherry-picks in all the branches, not only in the analysed history.",
+		Flag:        "cherry-picks-branches",
+		Type:        core.BoolConfigurationOption,
+		Default:     true}, {
+		Name:        ConfigCherryPicksReleaseBranches,
+		Description: "Regular expression which matches the names of the release branches.",
+		Flag:        "cherry-picks-release-branches",
+		Type:        core.StringConfigurationOption,
+		Default:     DefaultCherryPicksReleaseBranches},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (picks *CherryPicksAnalysis) Flag() string {
+	return "cherry-picks"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (picks *CherryPicksAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigCherryPicksScanBranches].(bool); exists {
+		picks.ScanBranches = val
+	}
+	if val, exists := facts[ConfigCherryPicksReleaseBranches].(string); exists {
+		picks.ReleaseBranches = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (picks *CherryPicksAnalysis) Initialize(repository *git.Repository) {
+	picks.repository = repository
+	if picks.ReleaseBranches == "" {
+		picks.ReleaseBranches = DefaultCherryPicksReleaseBranches
+	}
+	var err error
+	picks.releaseBranches, err = regexp.Compile(picks.ReleaseBranches)
+	if err != nil {
+		log.Printf("Warning: invalid release branches pattern %s: %v\n",
+			picks.ReleaseBranches, err)
+		picks.releaseBranches = regexp.MustCompile(DefaultCherryPicksReleaseBranches)
+	}
+	picks.patches = map[plumbing.Hash][]patchOccurrence{}
+	picks.commits = map[plumbing.Hash]bool{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (picks *CherryPicksAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	return nil, picks.addCommit(commit, CherryPicksMainBranch)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (picks *CherryPicksAnalysis) Finalize() interface{} {
+	if picks.ScanBranches && picks.repository != nil {
+		picks.scanBranches()
+	}
+	return picks.makeResult()
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (picks *CherryPicksAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	picksResult := result.(CherryPicksResult)
+	if binary {
+		return picks.serializeBinary(&picksResult, writer)
+	}
+	picks.serializeText(&picksResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (picks *CherryPicksAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(picks, result, &pb.CherryPicksResults{}, writer)
+}
+
+func (picks *CherryPicksAnalysis) serializeText(result *CherryPicksResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  commits:", result.Commits)
+	fmt.Fprintln(writer, "  duplicated:", result.Duplicated)
+	fmt.Fprintln(writer, "  pairs:")
+	for _, pair := range result.Pairs {
+		fmt.Fprintf(writer, "    - {original: %s, original_branch: %s, original_time: %d, "+
+			"copy: %s, copy_branch: %s, copy_time: %d}\n",
+			yaml.SafeString(pair.Original.String()), yaml.SafeString(pair.OriginalBranch),
+			pair.OriginalTime.Unix(), yaml.SafeString(pair.Copy.String()),
+			yaml.SafeString(pair.CopyBranch), pair.CopyTime.Unix())
+	}
+	fmt.Fprintln(writer, "  backports:")
+	branches := make([]string, 0, len(result.Backports))
+	for branch := range result.Backports {
+		branches = append(branches, branch)
+	}
+	sort.Strings(branches)
+	for _, branch := range branches {
+		stats := result.Backports[branch]
+		fmt.Fprintf(writer, "    %s:\n", yaml.SafeString(branch))
+		// in seconds
+		fmt.Fprintln(writer, "      median_latency:", int64(stats.MedianLatency.Seconds()))
+		fixes := make([]string, len(stats.Fixes))
+		for i, fix := range stats.Fixes {
+			fixes[i] = yaml.SafeString(fix.String())
+		}
+		fmt.Fprintf(writer, "      fixes: [%s]\n", strings.Join(fixes, ", "))
+	}
+}
+
+func (picks *CherryPicksAnalysis) serializeBinary(result *CherryPicksResult, writer io.Writer) error {
+	message := pb.CherryPicksResults{
+		Commits:    int32(result.Commits),
+		Duplicated: int32(result.Duplicated),
+		Pairs:      make([]*pb.CherryPick, len(result.Pairs)),
+		Backports:  map[string]*pb.BackportStats{},
+	}
+	for i, pair := range result.Pairs {
+		message.Pairs[i] = &pb.CherryPick{
+			Original:       pair.Original.String(),
+			OriginalBranch: pair.OriginalBranch,
+			OriginalTime:   pair.OriginalTime.Unix(),
+			Copy:           pair.Copy.String(),
+			CopyBranch:     pair.CopyBranch,
+			CopyTime:       pair.CopyTime.Unix(),
+		}
+	}
+	for branch, stats := range result.Backports {
+		fixes := make([]string, len(stats.Fixes))
+		for i, fix := range stats.Fixes {
+			fixes[i] = fix.String()
+		}
+		message.Backports[branch] = &pb.BackportStats{
+			Fixes:         fixes,
+			MedianLatency: int64(stats.MedianLatency.Seconds()),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+// addCommit calculates the patch ID of the commit and records it. Merge commits are ignored.
+func (picks *CherryPicksAnalysis) addCommit(commit *object.Commit, branch string) error {
+	if picks.commits[commit.Hash] {
+		return nil
+	}
+	picks.commits[commit.Hash] = true
+	if commit.NumParents() > 1 {
+		return nil
+	}
+	patchID, err := PatchID(commit)
+	if err != nil {
+		return err
+	}
+	if patchID == plumbing.ZeroHash {
+		return nil
+	}
+	picks.patches[patchID] = append(picks.patches[patchID], patchOccurrence{
+		Hash: commit.Hash, Branch: branch, When: commit.Committer.When,
+	})
+	return nil
+}
+
+// scanBranches visits the commits in all the branches which are not reachable from
+// the analysed history.
+func (picks *CherryPicksAnalysis) scanBranches() {
+	refs, err := picks.repository.References()
+	if err != nil {
+		log.Printf("Warning: failed to list the branches: %v\n", err)
+		return
+	}
+	var branches []*plumbing.Reference
+	refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Name().IsBranch() || ref.Name().IsRemote() {
+			branches = append(branches, ref)
+		}
+		return nil
+	})
+	sort.Slice(branches, func(i, j int) bool {
+		return branches[i].Name() < branches[j].Name()
+	})
+	for _, ref := range branches {
+		name := ref.Name().Short()
+		queue := []plumbing.Hash{ref.Hash()}
+		for len(queue) > 0 {
+			hash := queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+			if picks.commits[hash] {
+				continue
+			}
+			commit, err := picks.repository.CommitObject(hash)
+			if err != nil {
+				log.Printf("Warning: failed to load commit %s in %s: %v\n", hash.String(), name, err)
+				continue
+			}
+			if err = picks.addCommit(commit, name); err != nil {
+				log.Printf("Warning: failed to diff commit %s in %s: %v\n", hash.String(), name, err)
+			}
+			queue = append(queue, commit.ParentHashes...)
+		}
+	}
+}
+
+// makeResult groups the recorded commits by patch ID.
+func (picks *CherryPicksAnalysis) makeResult() CherryPicksResult {
+	result := CherryPicksResult{Pairs: []CherryPick{}}
+	for _, occurrences := range picks.patches {
+		result.Commits += len(occurrences)
+		if len(occurrences) < 2 {
+			continue
+		}
+		sort.Slice(occurrences, func(i, j int) bool {
+			return occurrences[i].When.Before(occurrences[j].When)
+		})
+		original := occurrences[0]
+		for _, duplicate := range occurrences[1:] {
+			result.Duplicated++
+			result.Pairs = append(result.Pairs, CherryPick{
+				Original:       original.Hash,
+				OriginalBranch: original.Branch,
+				OriginalTime:   original.When,
+				Copy:           duplicate.Hash,
+				CopyBranch:     duplicate.Branch,
+				CopyTime:       duplicate.When,
+			})
+		}
+	}
+	sort.Slice(result.Pairs, func(i, j int) bool {
+		if !result.Pairs[i].CopyTime.Equal(result.Pairs[j].CopyTime) {
+			return result.Pairs[i].CopyTime.Before(result.Pairs[j].CopyTime)
+		}
+		return result.Pairs[i].Copy.String() < result.Pairs[j].Copy.String()
+	})
+	result.Backports = picks.makeBackports(result.Pairs)
+	return result
+}
+
+// makeBackports selects the pairs which copy the main branch commits to the release branches.
+func (picks *CherryPicksAnalysis) makeBackports(pairs []CherryPick) map[string]BackportStats {
+	backports := map[string]BackportStats{}
+	latencies := map[string][]time.Duration{}
+	for _, pair := range pairs {
+		if pair.OriginalBranch != CherryPicksMainBranch || pair.CopyBranch == CherryPicksMainBranch ||
+			picks.releaseBranches == nil || !picks.releaseBranches.MatchString(pair.CopyBranch) {
+			continue
+		}
+		stats := backports[pair.CopyBranch]
+		stats.Fixes = append(stats.Fixes, pair.Original)
+		backports[pair.CopyBranch] = stats
+		latencies[pair.CopyBranch] = append(latencies[pair.CopyBranch], pair.CopyTime.Sub(pair.OriginalTime))
+	}
+	for branch, values := range latencies {
+		sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+		median := values[len(values)/2]
+		if len(values)%2 == 0 {
+			median = (values[len(values)/2-1] + median) / 2
+		}
+		stats := backports[branch]
+		stats.MedianLatency = median
+		backports[branch] = stats
+	}
+	return backports
+}
+
+// PatchID calculates the hash of the changes introduced by the commit relative to its first
+// parent. The whitespace and the line numbers are ignored, so that the patch ID remains the same
+// after cherry-picking. plumbing.ZeroHash is returned if the commit does not change anything.
+func PatchID(commit *object.Commit) (plumbing.Hash, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	var parentTree *object.Tree
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		parentTree, err = parent.Tree()
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if len(changes) == 0 {
+		return plumbing.ZeroHash, nil
+	}
+	patch, err := changes.Patch()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return hashPatch(patch.FilePatches()), nil
+}
+
+// hashPatch calculates the patch ID of the file patches.
+func hashPatch(filePatches []fdiff.FilePatch) plumbing.Hash {
+	hasher := sha1.New()
+	stripSpaces := func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}
+	for _, filePatch := range filePatches {
+		from, to := filePatch.Files()
+		if from != nil {
+			fmt.Fprintf(hasher, "--- %s\n", from.Path())
+		}
+		if to != nil {
+			fmt.Fprintf(hasher, "+++ %s\n", to.Path())
+		}
+		if filePatch.IsBinary() {
+			if from != nil {
+				fmt.Fprintf(hasher, "- %s\n", from.Hash().String())
+			}
+			if to != nil {
+				fmt.Fprintf(hasher, "+ %s\n", to.Hash().String())
+			}
+			continue
+		}
+		for _, chunk := range filePatch.Chunks() {
+			var prefix string
+			switch chunk.Type() {
+			case fdiff.Add:
+				prefix = "+"
+			case fdiff.Delete:
+				prefix = "-"
+			default:
+				continue
+			}
+			for _, line := range strings.Split(chunk.Content(), "\n") {
+				if line = strings.Map(stripSpaces, line); line != "" {
+					fmt.Fprintf(hasher, "%s%s\n", prefix, line)
+				}
+			}
+		}
+	}
+	var hash plumbing.Hash
+	copy(hash[:], hasher.Sum(nil))
+	return hash
+}
+
+func init() {
+	core.Registry.Register(&CherryPicksAnalysis{})
+}
@@ -0,0 +1,237 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	fdiff "gopkg.in/src-d/go-git.v4/plumbing/format/diff"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+type fakePatchFile string
+
+func (file fakePatchFile) Hash() plumbing.Hash {
+	return plumbing.ComputeHash(plumbing.BlobObject, []byte(file))
+}
+
+func (file fakePatchFile) Mode() filemode.FileMode {
+	return filemode.Regular
+}
+
+func (file fakePatchFile) Path() string {
+	return string(file)
+}
+
+type fakePatchChunk struct {
+	content string
+	op      fdiff.Operation
+}
+
+func (chunk fakePatchChunk) Content() string {
+	return chunk.content
+}
+
+func (chunk fakePatchChunk) Type() fdiff.Operation {
+	return chunk.op
+}
+
+type fakeFilePatch struct {
+	name   string
+	chunks []fdiff.Chunk
+}
+
+func (patch fakeFilePatch) IsBinary() bool {
+	return false
+}
+
+func (patch fakeFilePatch) Files() (fdiff.File, fdiff.File) {
+	return fakePatchFile(patch.name), fakePatchFile(patch.name)
+}
+
+func (patch fakeFilePatch) Chunks() []fdiff.Chunk {
+	return patch.chunks
+}
+
+func fixtureCherryPicks() *CherryPicksAnalysis {
+	picks := CherryPicksAnalysis{}
+	picks.Initialize(test.Repository)
+	return &picks
+}
+
+func fixtureCherryPicksResult() CherryPicksResult {
+	picks := fixtureCherryPicks()
+	when := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	hash := func(s string) plumbing.Hash {
+		return plumbing.NewHash(s)
+	}
+	patch1 := hash("1111111111111111111111111111111111111111")
+	patch2 := hash("2222222222222222222222222222222222222222")
+	patch3 := hash("3333333333333333333333333333333333333333")
+	picks.patches[patch1] = []patchOccurrence{
+		{Hash: hash("cccccccccccccccccccccccccccccccccccccccc"), Branch: "v1.0", When: when.Add(48 * time.Hour)},
+		{Hash: hash("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"), Branch: "HEAD", When: when},
+		{Hash: hash("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"), Branch: "feature", When: when.Add(time.Hour)},
+	}
+	picks.patches[patch3] = []patchOccurrence{
+		{Hash: hash("eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee"), Branch: "HEAD", When: when.Add(time.Hour)},
+		{Hash: hash("ffffffffffffffffffffffffffffffffffffffff"), Branch: "v1.0", When: when.Add(5 * time.Hour)},
+	}
+	picks.patches[patch2] = []patchOccurrence{
+		{Hash: hash("dddddddddddddddddddddddddddddddddddddddd"), Branch: "HEAD", When: when},
+	}
+	return picks.makeResult()
+}
+
+func TestCherryPicksMeta(t *testing.T) {
+	picks := fixtureCherryPicks()
+	assert.Equal(t, picks.Name(), "CherryPicks")
+	assert.Len(t, picks.Provides(), 0)
+	assert.Len(t, picks.Requires(), 0)
+	opts := picks.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Name, ConfigCherryPicksScanBranches)
+	assert.Equal(t, opts[1].Name, ConfigCherryPicksReleaseBranches)
+	assert.Equal(t, picks.Flag(), "cherry-picks")
+	assert.False(t, picks.ScanBranches)
+	assert.Equal(t, picks.ReleaseBranches, DefaultCherryPicksReleaseBranches)
+	facts := map[string]interface{}{}
+	facts[ConfigCherryPicksScanBranches] = true
+	facts[ConfigCherryPicksReleaseBranches] = "^maint"
+	picks.Configure(facts)
+	assert.True(t, picks.ScanBranches)
+	assert.Equal(t, picks.ReleaseBranches, "^maint")
+}
+
+func TestCherryPicksReleaseBranches(t *testing.T) {
+	picks := fixtureCherryPicks()
+	for _, name := range []string{"release-1.0", "origin/release/2.x", "stable", "v1.2", "1.x", "origin/v4.1"} {
+		assert.True(t, picks.releaseBranches.MatchString(name), name)
+	}
+	for _, name := range []string{"master", "HEAD", "feature/v1", "origin/develop", "v1"} {
+		assert.False(t, picks.releaseBranches.MatchString(name), name)
+	}
+	picks.ReleaseBranches = "("
+	picks.Initialize(test.Repository)
+	assert.Equal(t, picks.releaseBranches.String(), DefaultCherryPicksReleaseBranches)
+}
+
+func TestCherryPicksRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&CherryPicksAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "CherryPicks")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&CherryPicksAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestCherryPicksHashPatch(t *testing.T) {
+	original := []fdiff.FilePatch{fakeFilePatch{name: "main.go", chunks: []fdiff.Chunk{
+		fakePatchChunk{"package main\n", fdiff.Equal},
+		fakePatchChunk{"func main() {\n\tprintln(1)\n}\n", fdiff.Add},
+	}}}
+	reformatted := []fdiff.FilePatch{fakeFilePatch{name: "main.go", chunks: []fdiff.Chunk{
+		fakePatchChunk{"package main\n\nimport \"os\"\n", fdiff.Equal},
+		fakePatchChunk{"func main()  {\n    println(1)\n}\n\n", fdiff.Add},
+	}}}
+	different := []fdiff.FilePatch{fakeFilePatch{name: "main.go", chunks: []fdiff.Chunk{
+		fakePatchChunk{"func main() {\n\tprintln(2)\n}\n", fdiff.Add},
+	}}}
+	renamed := []fdiff.FilePatch{fakeFilePatch{name: "cmd.go", chunks: []fdiff.Chunk{
+		fakePatchChunk{"func main() {\n\tprintln(1)\n}\n", fdiff.Add},
+	}}}
+	assert.Equal(t, hashPatch(original), hashPatch(reformatted))
+	assert.NotEqual(t, hashPatch(original), hashPatch(different))
+	assert.NotEqual(t, hashPatch(original), hashPatch(renamed))
+}
+
+func TestCherryPicksConsume(t *testing.T) {
+	picks := fixtureCherryPicks()
+	commit, _ := test.Repository.CommitObject(plumbing.NewHash(
+		"cce947b98a050c6d356bc6ba95030254914027b1"))
+	deps := map[string]interface{}{"commit": commit}
+	result, err := picks.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	// the same commit is never counted twice
+	result, err = picks.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	picksResult := picks.Finalize().(CherryPicksResult)
+	assert.Equal(t, picksResult.Commits, 1)
+	assert.Equal(t, picksResult.Duplicated, 0)
+	assert.Len(t, picksResult.Pairs, 0)
+}
+
+func TestCherryPicksFinalize(t *testing.T) {
+	result := fixtureCherryPicksResult()
+	assert.Equal(t, result.Commits, 6)
+	assert.Equal(t, result.Duplicated, 3)
+	assert.Len(t, result.Pairs, 3)
+	assert.Equal(t, result.Pairs[0].Original.String(), "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	assert.Equal(t, result.Pairs[0].OriginalBranch, "HEAD")
+	assert.Equal(t, result.Pairs[0].Copy.String(), "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	assert.Equal(t, result.Pairs[0].CopyBranch, "feature")
+	assert.Equal(t, result.Pairs[1].Original.String(), "eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee")
+	assert.Equal(t, result.Pairs[1].Copy.String(), "ffffffffffffffffffffffffffffffffffffffff")
+	assert.Equal(t, result.Pairs[2].Original.String(), "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	assert.Equal(t, result.Pairs[2].Copy.String(), "cccccccccccccccccccccccccccccccccccccccc")
+	assert.Equal(t, result.Pairs[2].CopyBranch, "v1.0")
+	assert.Len(t, result.Backports, 1)
+	backports := result.Backports["v1.0"]
+	assert.Len(t, backports.Fixes, 2)
+	assert.Equal(t, backports.Fixes[0].String(), "eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee")
+	assert.Equal(t, backports.Fixes[1].String(), "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	// (4h + 48h) / 2
+	assert.Equal(t, backports.MedianLatency, 26*time.Hour)
+}
+
+func TestCherryPicksSerializeText(t *testing.T) {
+	picks := fixtureCherryPicks()
+	result := fixtureCherryPicksResult()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, picks.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  commits: 6
+  duplicated: 3
+  pairs:
+    - {original: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", original_branch: "HEAD", original_time: 1519905600, copy: "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", copy_branch: "feature", copy_time: 1519909200}
+    - {original: "eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee", original_branch: "HEAD", original_time: 1519909200, copy: "ffffffffffffffffffffffffffffffffffffffff", copy_branch: "v1.0", copy_time: 1519923600}
+    - {original: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", original_branch: "HEAD", original_time: 1519905600, copy: "cccccccccccccccccccccccccccccccccccccccc", copy_branch: "v1.0", copy_time: 1520078400}
+  backports:
+    "v1.0":
+      median_latency: 93600
+      fixes: ["eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"]
+`)
+}
+
+func TestCherryPicksSerializeBinary(t *testing.T) {
+	picks := fixtureCherryPicks()
+	result := fixtureCherryPicksResult()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, picks.Serialize(result, true, buffer))
+	msg := pb.CherryPicksResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Commits, int32(6))
+	assert.Equal(t, msg.Duplicated, int32(3))
+	assert.Len(t, msg.Pairs, 3)
+	assert.Equal(t, msg.Pairs[2].Original, "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	assert.Equal(t, msg.Pairs[2].Copy, "cccccccccccccccccccccccccccccccccccccccc")
+	assert.Equal(t, msg.Pairs[2].CopyBranch, "v1.0")
+	assert.Equal(t, msg.Pairs[2].CopyTime, int64(1520078400))
+	assert.Len(t, msg.Backports, 1)
+	assert.Equal(t, msg.Backports["v1.0"].Fixes, []string{
+		"eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"})
+	assert.Equal(t, msg.Backports["v1.0"].MedianLatency, int64(93600))
+}
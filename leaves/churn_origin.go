@@ -0,0 +1,337 @@
+package leaves
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/burndown"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// ChurnOriginAnalysis splits the lines deleted by each developer in each month into the lines
+// which the same developer wrote (iteration on one's own code) and the lines which somebody else
+// wrote (rework of the others' code). BurndownAnalysis has the same line ownership data but
+// only reports the totals over the whole history.
+// It is a LeafPipelineItem and a LinearPipelineItem.
+type ChurnOriginAnalysis struct {
+	// PeopleNumber is the number of developers for which to collect the stats.
+	PeopleNumber int
+
+	// files is the mapping <file path> -> *burndown.File. The values of the lines
+	// are the developer indexes, PeopleNumber corresponds to the unmatched authors.
+	files map[string]*burndown.File
+	// people maps the developer index to the month to the deletion stats.
+	people []map[string]*ChurnOriginStats
+	// month is the month of the commit which is being analysed, e.g. "2018-03".
+	month string
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// ChurnOriginStats are the numbers of deleted lines split by the original author.
+type ChurnOriginStats struct {
+	// Self is the number of deleted lines which were written by the same developer.
+	Self int
+	// Foreign is the number of deleted lines which were written by other developers.
+	// The lines of the unmatched authors are always foreign.
+	Foreign int
+}
+
+// ChurnOriginResult is returned by ChurnOriginAnalysis.Finalize() and carries
+// the monthly deletion stats of each developer.
+type ChurnOriginResult struct {
+	// People maps the developer index to the month ("2018-03") to the deletion stats.
+	// The developer index len(reversedPeopleDict) corresponds to the unmatched authors.
+	People []map[string]ChurnOriginStats
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (churn *ChurnOriginAnalysis) Name() string {
+	return "ChurnOrigin"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (churn *ChurnOriginAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (churn *ChurnOriginAnalysis) Requires() []string {
+	arr := [...]string{
+		identity.DependencyAuthor, items.DependencyTreeChanges, items.DependencyFileDiff,
+		items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (churn *ChurnOriginAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (churn *ChurnOriginAnalysis) Flag() string {
+	return "churn-origin"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (churn *ChurnOriginAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		churn.PeopleNumber = val
+		churn.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (churn *ChurnOriginAnalysis) Initialize(repository *git.Repository) {
+	churn.files = map[string]*burndown.File{}
+	churn.people = make([]map[string]*ChurnOriginStats, churn.PeopleNumber+1)
+	churn.month = ""
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (churn *ChurnOriginAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	churn.month = commit.Author.When.UTC().Format("2006-01")
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing {
+		author = churn.PeopleNumber
+	}
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			err = churn.handleInsertion(change, author, cache)
+		case merkletrie.Delete:
+			err = churn.handleDeletion(change, author)
+		case merkletrie.Modify:
+			err = churn.handleModification(change, author, cache, fileDiffs)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// RequiresLinearHistory returns true because the lines of the files are followed through
+// the diffs, which do not apply to the interleaved branches. See core.LinearPipelineItem.
+func (churn *ChurnOriginAnalysis) RequiresLinearHistory() bool {
+	return true
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (churn *ChurnOriginAnalysis) Finalize() interface{} {
+	people := make([]map[string]ChurnOriginStats, len(churn.people))
+	for i, months := range churn.people {
+		people[i] = map[string]ChurnOriginStats{}
+		for month, stats := range months {
+			people[i][month] = *stats
+		}
+	}
+	return ChurnOriginResult{
+		People:             people,
+		reversedPeopleDict: churn.reversedPeopleDict,
+	}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (churn *ChurnOriginAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	churnResult := result.(ChurnOriginResult)
+	if binary {
+		return churn.serializeBinary(&churnResult, writer)
+	}
+	churn.serializeText(&churnResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (churn *ChurnOriginAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(churn, result, &pb.ChurnOriginResults{}, writer)
+}
+
+func (churn *ChurnOriginAnalysis) serializeText(result *ChurnOriginResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  # self, foreign")
+	fmt.Fprintln(writer, "  people:")
+	for i, name := range result.reversedPeopleDict {
+		if i >= len(result.People) {
+			break
+		}
+		months := make([]string, 0, len(result.People[i]))
+		for month := range result.People[i] {
+			months = append(months, month)
+		}
+		sort.Strings(months)
+		if len(months) == 0 {
+			fmt.Fprintf(writer, "    %s: {}\n", yaml.SafeString(name))
+			continue
+		}
+		fmt.Fprintf(writer, "    %s:\n", yaml.SafeString(name))
+		for _, month := range months {
+			stats := result.People[i][month]
+			fmt.Fprintf(writer, "      %s: [%d, %d]\n", yaml.SafeString(month), stats.Self, stats.Foreign)
+		}
+	}
+}
+
+func (churn *ChurnOriginAnalysis) serializeBinary(result *ChurnOriginResult, writer io.Writer) error {
+	message := pb.ChurnOriginResults{
+		People:         make([]*pb.DeveloperChurnOrigin, len(result.People)),
+		PeopleSequence: result.reversedPeopleDict,
+	}
+	for i, months := range result.People {
+		dev := &pb.DeveloperChurnOrigin{Months: map[string]*pb.ChurnOriginStats{}}
+		for month, stats := range months {
+			dev.Months[month] = &pb.ChurnOriginStats{
+				Self:    int32(stats.Self),
+				Foreign: int32(stats.Foreign),
+			}
+		}
+		message.People[i] = dev
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+// updateStats is the burndown.Status callback which records the deleted lines.
+// The line values are the developer indexes.
+func (churn *ChurnOriginAnalysis) updateStats(_ interface{}, currentAuthor int, previousAuthor int, delta int) {
+	if delta >= 0 {
+		return
+	}
+	months := churn.people[currentAuthor]
+	if months == nil {
+		months = map[string]*ChurnOriginStats{}
+		churn.people[currentAuthor] = months
+	}
+	stats := months[churn.month]
+	if stats == nil {
+		stats = &ChurnOriginStats{}
+		months[churn.month] = stats
+	}
+	if currentAuthor == previousAuthor && currentAuthor != churn.PeopleNumber {
+		stats.Self -= delta
+	} else {
+		stats.Foreign -= delta
+	}
+}
+
+func (churn *ChurnOriginAnalysis) handleInsertion(
+	change *object.Change, author int, cache map[plumbing.Hash]*object.Blob) error {
+	lines, err := items.CountLines(cache[change.To.TreeEntry.Hash])
+	if err != nil {
+		if err.Error() == "binary" {
+			return nil
+		}
+		return err
+	}
+	name := change.To.Name
+	if _, exists := churn.files[name]; exists {
+		return fmt.Errorf("file %s already exists", name)
+	}
+	churn.files[name] = burndown.NewFile(
+		author, lines, burndown.NewStatus(nil, churn.updateStats))
+	return nil
+}
+
+func (churn *ChurnOriginAnalysis) handleDeletion(change *object.Change, author int) error {
+	name := change.From.Name
+	file, exists := churn.files[name]
+	if !exists {
+		// binary files are not tracked
+		return nil
+	}
+	file.Update(author, 0, 0, file.Len())
+	delete(churn.files, name)
+	return nil
+}
+
+func (churn *ChurnOriginAnalysis) handleModification(
+	change *object.Change, author int, cache map[plumbing.Hash]*object.Blob,
+	diffs map[string]items.FileDiffData) error {
+	file, exists := churn.files[change.From.Name]
+	if !exists {
+		return churn.handleInsertion(change, author, cache)
+	}
+	if change.To.Name != change.From.Name {
+		churn.files[change.To.Name] = file
+		delete(churn.files, change.From.Name)
+	}
+	thisDiffs := diffs[change.To.Name]
+	if file.Len() != thisDiffs.OldLinesOfCode {
+		return fmt.Errorf("%s: internal integrity error src %d != %d",
+			change.To.Name, thisDiffs.OldLinesOfCode, file.Len())
+	}
+	// the diffs are line-level so the number of lines equals to the rune count
+	position := 0
+	pending := diffmatchpatch.Diff{Text: ""}
+	for _, edit := range thisDiffs.Diffs {
+		length := utf8.RuneCountInString(edit.Text)
+		switch edit.Type {
+		case diffmatchpatch.DiffEqual:
+			if pending.Text != "" {
+				file.Update(author, position, 0, utf8.RuneCountInString(pending.Text))
+				pending.Text = ""
+			}
+			position += length
+		case diffmatchpatch.DiffInsert:
+			file.Update(author, position, length, utf8.RuneCountInString(pending.Text))
+			position += length
+			pending.Text = ""
+		case diffmatchpatch.DiffDelete:
+			if pending.Text != "" {
+				return errors.New("DiffDelete may not appear after DiffDelete")
+			}
+			pending = edit
+		default:
+			return fmt.Errorf("diff operation is not supported: %d", edit.Type)
+		}
+	}
+	if pending.Text != "" {
+		file.Update(author, position, 0, utf8.RuneCountInString(pending.Text))
+	}
+	if file.Len() != thisDiffs.NewLinesOfCode {
+		return fmt.Errorf("%s: internal integrity error dst %d != %d",
+			change.To.Name, thisDiffs.NewLinesOfCode, file.Len())
+	}
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&ChurnOriginAnalysis{})
+}
@@ -0,0 +1,179 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureChurnOrigin() *ChurnOriginAnalysis {
+	churn := ChurnOriginAnalysis{}
+	churn.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorPeopleCount:        2,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"one", "two"},
+	})
+	churn.Initialize(test.Repository)
+	return &churn
+}
+
+// fixtureChurnOriginBlob creates an in-memory blob with the specified contents.
+func fixtureChurnOriginBlob(contents string) *object.Blob {
+	obj := &plumbing.MemoryObject{}
+	obj.SetType(plumbing.BlobObject)
+	obj.Write([]byte(contents))
+	blob, err := object.DecodeBlob(obj)
+	if err != nil {
+		panic(err)
+	}
+	return blob
+}
+
+func fixtureChurnOriginDeps(
+	author int, month time.Month, changes object.Changes, diffs map[string]items.FileDiffData,
+	blobs map[plumbing.Hash]*object.Blob) map[string]interface{} {
+	deps := map[string]interface{}{}
+	deps["commit"] = &object.Commit{
+		Author: object.Signature{When: time.Date(2018, month, 10, 0, 0, 0, 0, time.UTC)}}
+	deps[identity.DependencyAuthor] = author
+	deps[items.DependencyTreeChanges] = changes
+	deps[items.DependencyFileDiff] = diffs
+	if blobs == nil {
+		blobs = map[plumbing.Hash]*object.Blob{}
+	}
+	deps[items.DependencyBlobCache] = blobs
+	return deps
+}
+
+func TestChurnOriginMeta(t *testing.T) {
+	churn := fixtureChurnOrigin()
+	assert.Equal(t, churn.Name(), "ChurnOrigin")
+	assert.Len(t, churn.Provides(), 0)
+	assert.True(t, churn.RequiresLinearHistory())
+	assert.Equal(t, churn.Requires(), []string{
+		identity.DependencyAuthor, items.DependencyTreeChanges, items.DependencyFileDiff,
+		items.DependencyBlobCache})
+	assert.Len(t, churn.ListConfigurationOptions(), 0)
+	assert.Equal(t, churn.Flag(), "churn-origin")
+	assert.Equal(t, churn.PeopleNumber, 2)
+	assert.Equal(t, churn.reversedPeopleDict, []string{"one", "two"})
+	assert.Len(t, churn.people, 3)
+}
+
+func TestChurnOriginRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&ChurnOriginAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "ChurnOrigin")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&ChurnOriginAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestChurnOriginConsumeFinalize(t *testing.T) {
+	churn := fixtureChurnOrigin()
+	blobA := fixtureChurnOriginBlob("1\n2\n3\n4\n")
+	blobB := fixtureChurnOriginBlob("1\n2\n")
+	entry := func(name string, blob *object.Blob) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: blob.Hash}}
+	}
+	// "one" creates a.go
+	result, err := churn.Consume(fixtureChurnOriginDeps(0, time.January,
+		object.Changes{{To: entry("a.go", blobA)}}, nil,
+		map[plumbing.Hash]*object.Blob{blobA.Hash: blobA}))
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	// "two" replaces two lines written by "one" with a single line
+	_, err = churn.Consume(fixtureChurnOriginDeps(1, time.February,
+		object.Changes{{From: entry("a.go", blobA), To: entry("a.go", blobB)}},
+		map[string]items.FileDiffData{"a.go": {
+			OldLinesOfCode: 4, NewLinesOfCode: 3, Diffs: []diffmatchpatch.Diff{
+				{Type: diffmatchpatch.DiffEqual, Text: "a"},
+				{Type: diffmatchpatch.DiffDelete, Text: "bc"},
+				{Type: diffmatchpatch.DiffInsert, Text: "x"},
+				{Type: diffmatchpatch.DiffEqual, Text: "d"},
+			}}}, nil))
+	assert.Nil(t, err)
+	// "one" deletes everything but the last line and renames the file
+	_, err = churn.Consume(fixtureChurnOriginDeps(0, time.February,
+		object.Changes{{From: entry("a.go", blobB), To: entry("b.go", blobA)}},
+		map[string]items.FileDiffData{"b.go": {
+			OldLinesOfCode: 3, NewLinesOfCode: 1, Diffs: []diffmatchpatch.Diff{
+				{Type: diffmatchpatch.DiffDelete, Text: "ax"},
+				{Type: diffmatchpatch.DiffEqual, Text: "d"},
+			}}}, nil))
+	assert.Nil(t, err)
+	assert.Len(t, churn.files, 1)
+	// an unmatched author deletes the file
+	_, err = churn.Consume(fixtureChurnOriginDeps(identity.AuthorMissing, time.March,
+		object.Changes{{From: entry("b.go", blobA)}}, nil, nil))
+	assert.Nil(t, err)
+	assert.Len(t, churn.files, 0)
+	res := churn.Finalize().(ChurnOriginResult)
+	assert.Len(t, res.People, 3)
+	assert.Equal(t, res.People[0], map[string]ChurnOriginStats{
+		"2018-02": {Self: 1, Foreign: 1}})
+	assert.Equal(t, res.People[1], map[string]ChurnOriginStats{
+		"2018-02": {Foreign: 2}})
+	assert.Equal(t, res.People[2], map[string]ChurnOriginStats{
+		"2018-03": {Foreign: 1}})
+}
+
+func TestChurnOriginIntegrityError(t *testing.T) {
+	churn := fixtureChurnOrigin()
+	blob := fixtureChurnOriginBlob("1\n2\n")
+	entry := object.ChangeEntry{Name: "a.go", TreeEntry: object.TreeEntry{
+		Name: "a.go", Mode: 0100644, Hash: blob.Hash}}
+	churn.Consume(fixtureChurnOriginDeps(0, time.January,
+		object.Changes{{To: entry}}, nil, map[plumbing.Hash]*object.Blob{blob.Hash: blob}))
+	_, err := churn.Consume(fixtureChurnOriginDeps(0, time.January,
+		object.Changes{{From: entry, To: entry}},
+		map[string]items.FileDiffData{"a.go": {OldLinesOfCode: 3}}, nil))
+	assert.NotNil(t, err)
+}
+
+func TestChurnOriginSerialize(t *testing.T) {
+	churn := fixtureChurnOrigin()
+	res := ChurnOriginResult{
+		People: []map[string]ChurnOriginStats{
+			{"2018-02": {Self: 1, Foreign: 1}, "2018-01": {Self: 5}},
+			{},
+			{"2018-03": {Foreign: 1}},
+		},
+		reversedPeopleDict: []string{"one", "two"},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, churn.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  # self, foreign
+  people:
+    "one":
+      "2018-01": [5, 0]
+      "2018-02": [1, 1]
+    "two": {}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, churn.Serialize(res, true, buffer))
+	msg := pb.ChurnOriginResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.People, 3)
+	assert.Equal(t, *msg.People[0].Months["2018-02"], pb.ChurnOriginStats{Self: 1, Foreign: 1})
+	assert.Len(t, msg.People[1].Months, 0)
+	assert.Equal(t, msg.People[2].Months["2018-03"].Foreign, int32(1))
+	assert.Equal(t, msg.PeopleSequence, []string{"one", "two"})
+}
@@ -0,0 +1,135 @@
+// +build tensorflow
+
+package leaves
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// EmotionAnger is the emotion category of the angry comments, e.g. "this is a stupid hack".
+	EmotionAnger = "anger"
+	// EmotionJoy is the emotion category of the happy comments, e.g. "this works great".
+	EmotionJoy = "joy"
+	// EmotionFear is the emotion category of the worried comments, e.g. "careful, this may break".
+	EmotionFear = "fear"
+	// EmotionNeutral is the emotion category of the rest of the comments.
+	EmotionNeutral = "neutral"
+
+	// LexiconEmotionClassifierName is the name of the built-in EmotionClassifier which counts
+	// the emotional words.
+	LexiconEmotionClassifierName = "lexicon"
+)
+
+// EmotionCategories lists the emotions which EmotionClassifier-s assign to the comments.
+var EmotionCategories = []string{EmotionAnger, EmotionJoy, EmotionFear, EmotionNeutral}
+
+// EmotionClassifier assigns one of EmotionCategories to each comment.
+type EmotionClassifier interface {
+	// Classify returns the emotion categories in the same order as the comments.
+	Classify(comments []string) ([]string, error)
+}
+
+var (
+	emotionClassifiers     = map[string]func() EmotionClassifier{}
+	emotionClassifiersLock sync.Mutex
+)
+
+// RegisterEmotionClassifier makes the classifier available by the name in
+// CommentSentimentAnalysis.Emotions. The factory is called once per analysis.
+func RegisterEmotionClassifier(name string, factory func() EmotionClassifier) {
+	emotionClassifiersLock.Lock()
+	defer emotionClassifiersLock.Unlock()
+	emotionClassifiers[name] = factory
+}
+
+// EmotionClassifiers returns the sorted names of the registered emotion classifiers.
+func EmotionClassifiers() []string {
+	emotionClassifiersLock.Lock()
+	defer emotionClassifiersLock.Unlock()
+	names := make([]string, 0, len(emotionClassifiers))
+	for name := range emotionClassifiers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newEmotionClassifier returns nil if there is no classifier with such name.
+func newEmotionClassifier(name string) EmotionClassifier {
+	emotionClassifiersLock.Lock()
+	defer emotionClassifiersLock.Unlock()
+	factory := emotionClassifiers[name]
+	if factory == nil {
+		return nil
+	}
+	return factory()
+}
+
+// LexiconEmotionClassifier chooses the emotion with the most words from its lexicon in
+// the comment. The comments without such words or with a tie are neutral.
+type LexiconEmotionClassifier struct {
+	// Lexicon maps the lowercase words to the emotion categories.
+	Lexicon map[string]string
+}
+
+// NewLexiconEmotionClassifier returns LexiconEmotionClassifier with the built-in English lexicon.
+func NewLexiconEmotionClassifier() EmotionClassifier {
+	lexicon := map[string]string{}
+	for emotion, words := range map[string][]string{
+		EmotionAnger: {"annoying", "awful", "crap", "crappy", "damn", "dumb", "garbage", "hate",
+			"horrible", "idiot", "idiotic", "insane", "ridiculous", "stupid", "sucks", "terrible",
+			"ugly", "wtf"},
+		EmotionJoy: {"awesome", "beautiful", "cool", "elegant", "enjoy", "excellent", "fun",
+			"great", "happy", "love", "neat", "nice", "perfect", "thanks", "wonderful", "yay"},
+		EmotionFear: {"afraid", "beware", "careful", "danger", "dangerous", "fragile", "hopefully",
+			"risky", "scary", "unsafe", "unsure", "worried", "worry"},
+	} {
+		for _, word := range words {
+			lexicon[word] = emotion
+		}
+	}
+	return &LexiconEmotionClassifier{Lexicon: lexicon}
+}
+
+// Classify implements EmotionClassifier.
+func (lec *LexiconEmotionClassifier) Classify(comments []string) ([]string, error) {
+	result := make([]string, len(comments))
+	for i, comment := range comments {
+		counts := map[string]int{}
+		for _, word := range charsRE.FindAllString(comment, -1) {
+			if emotion, exists := lec.Lexicon[strings.ToLower(word)]; exists {
+				counts[emotion]++
+			}
+		}
+		result[i] = EmotionNeutral
+		best := 0
+		for _, emotion := range EmotionCategories {
+			if counts[emotion] > best {
+				best = counts[emotion]
+				result[i] = emotion
+			} else if counts[emotion] == best && best > 0 {
+				result[i] = EmotionNeutral
+			}
+		}
+	}
+	return result, nil
+}
+
+// emotionDistribution returns the shares of EmotionCategories among the classified comments.
+func emotionDistribution(categories []string) map[string]float32 {
+	distribution := map[string]float32{}
+	for _, emotion := range EmotionCategories {
+		distribution[emotion] = 0
+	}
+	for _, emotion := range categories {
+		distribution[emotion] += 1 / float32(len(categories))
+	}
+	return distribution
+}
+
+func init() {
+	RegisterEmotionClassifier(LexiconEmotionClassifierName, NewLexiconEmotionClassifier)
+}
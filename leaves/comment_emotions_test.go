@@ -0,0 +1,45 @@
+// +build tensorflow
+
+package leaves
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failingEmotionClassifier struct{}
+
+func (fec failingEmotionClassifier) Classify(comments []string) ([]string, error) {
+	return nil, errors.New("failed")
+}
+
+func TestEmotionClassifiersRegistry(t *testing.T) {
+	assert.Contains(t, EmotionClassifiers(), LexiconEmotionClassifierName)
+	assert.Nil(t, newEmotionClassifier("whatever"))
+	assert.IsType(t, &LexiconEmotionClassifier{}, newEmotionClassifier(LexiconEmotionClassifierName))
+	RegisterEmotionClassifier("failing", func() EmotionClassifier {
+		return failingEmotionClassifier{}
+	})
+	defer delete(emotionClassifiers, "failing")
+	assert.Equal(t, EmotionClassifiers(), []string{"failing", LexiconEmotionClassifierName})
+}
+
+func TestLexiconEmotionClassifier(t *testing.T) {
+	categories, err := NewLexiconEmotionClassifier().Classify([]string{
+		"This is a STUPID hack around the ugly API",
+		"The new cache works great, thanks",
+		"Careful: the order of the calls is fragile",
+		"Returns the number of the lines",
+		"Nice, but the stupid linter complains",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, categories, []string{
+		EmotionAnger, EmotionJoy, EmotionFear, EmotionNeutral, EmotionNeutral})
+}
+
+func TestEmotionDistribution(t *testing.T) {
+	assert.Equal(t, emotionDistribution([]string{EmotionJoy, EmotionJoy, EmotionFear, EmotionJoy}),
+		map[string]float32{EmotionAnger: 0, EmotionJoy: 0.75, EmotionFear: 0.25, EmotionNeutral: 0})
+}
@@ -0,0 +1,270 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/enry.v1"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// CommentRatioAnalysis tracks the number of comment lines and code lines in the repository
+// per language over time, so that the documentation culture of the different parts of
+// a polyglot repository can be compared. A line which contains both code and a comment
+// is counted in both.
+// It is a LeafPipelineItem.
+type CommentRatioAnalysis struct {
+	// files maps the file name to the line counts of its current UAST.
+	files map[string]CommentRatioStats
+	// languages maps the file name to the language of the file.
+	languages map[string]string
+	// totals are the current line counts of every language.
+	totals map[string]CommentRatioStats
+	// history maps days to the snapshots of totals.
+	history map[int]map[string]CommentRatioStats
+	// xpather extracts the comment nodes.
+	xpather *uast_items.ChangesXPather
+}
+
+// CommentRatioStats are the numbers of comment and code lines.
+type CommentRatioStats struct {
+	// Comments is the number of lines which contain comments.
+	Comments int
+	// Code is the number of lines which contain anything but comments.
+	Code int
+}
+
+// Ratio returns the number of comment lines per code line.
+func (stats CommentRatioStats) Ratio() float32 {
+	if stats.Code == 0 {
+		return 0
+	}
+	return float32(stats.Comments) / float32(stats.Code)
+}
+
+// CommentRatioResult is returned by CommentRatioAnalysis.Finalize() and carries
+// the comment and code line counts of every language for each day when there were changes.
+type CommentRatioResult struct {
+	// Days maps the day index to the language -> line counts mapping.
+	Days map[int]map[string]CommentRatioStats
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (ratio *CommentRatioAnalysis) Name() string {
+	return "CommentRatio"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (ratio *CommentRatioAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (ratio *CommentRatioAnalysis) Requires() []string {
+	arr := [...]string{uast_items.DependencyUastChanges, items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (ratio *CommentRatioAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (ratio *CommentRatioAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (ratio *CommentRatioAnalysis) Flag() string {
+	return "comment-ratio"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (ratio *CommentRatioAnalysis) Configure(facts map[string]interface{}) {
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (ratio *CommentRatioAnalysis) Initialize(repository *git.Repository) {
+	ratio.files = map[string]CommentRatioStats{}
+	ratio.languages = map[string]string{}
+	ratio.totals = map[string]CommentRatioStats{}
+	ratio.history = map[int]map[string]CommentRatioStats{}
+	ratio.xpather = &uast_items.ChangesXPather{XPath: "//*[@roleComment]"}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (ratio *CommentRatioAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	day := deps[items.DependencyDay].(int)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	for _, change := range changes {
+		if change.Change.From.Name != "" {
+			ratio.removeFile(change.Change.From.Name)
+		}
+		if change.After != nil {
+			ratio.addFile(change)
+		}
+	}
+	snapshot := map[string]CommentRatioStats{}
+	for lang, stats := range ratio.totals {
+		if stats.Comments != 0 || stats.Code != 0 {
+			snapshot[lang] = stats
+		}
+	}
+	ratio.history[day] = snapshot
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (ratio *CommentRatioAnalysis) Finalize() interface{} {
+	return CommentRatioResult{Days: ratio.history}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (ratio *CommentRatioAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	ratioResult := result.(CommentRatioResult)
+	if binary {
+		return ratio.serializeBinary(&ratioResult, writer)
+	}
+	ratio.serializeText(&ratioResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (ratio *CommentRatioAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(ratio, result, &pb.CommentRatioResults{}, writer)
+}
+
+func (ratio *CommentRatioAnalysis) serializeText(result *CommentRatioResult, writer io.Writer) {
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	fmt.Fprintln(writer, "  # comment lines, code lines, ratio")
+	for _, day := range days {
+		fmt.Fprintf(writer, "  %d:\n", day)
+		langs := result.Days[day]
+		langKeys := make([]string, 0, len(langs))
+		for lang := range langs {
+			langKeys = append(langKeys, lang)
+		}
+		sort.Strings(langKeys)
+		for _, lang := range langKeys {
+			stats := langs[lang]
+			fmt.Fprintf(writer, "    %s: [%d, %d, %.4f]\n",
+				yaml.SafeString(lang), stats.Comments, stats.Code, stats.Ratio())
+		}
+	}
+}
+
+func (ratio *CommentRatioAnalysis) serializeBinary(result *CommentRatioResult, writer io.Writer) error {
+	message := pb.CommentRatioResults{
+		Days: map[int32]*pb.LanguageCommentRatios{},
+	}
+	for day, langs := range result.Days {
+		pbLangs := &pb.LanguageCommentRatios{
+			Languages: map[string]*pb.CommentRatioStats{},
+		}
+		for lang, stats := range langs {
+			pbLangs.Languages[lang] = &pb.CommentRatioStats{
+				Comments: int32(stats.Comments),
+				Code:     int32(stats.Code),
+			}
+		}
+		message.Days[int32(day)] = pbLangs
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+// measure counts the comment and code lines in the UAST. The comments are selected
+// with the same XPath as in CommentSentimentAnalysis; the code lines are the lines of the other
+// nodes which have a token.
+func (ratio *CommentRatioAnalysis) measure(change uast_items.Change) CommentRatioStats {
+	commentLines := map[uint32]bool{}
+	for _, node := range ratio.xpather.Filter(change.After, change.Change.To.TreeEntry.Hash) {
+		if node.StartPosition == nil {
+			continue
+		}
+		end := node.StartPosition.Line
+		if node.EndPosition != nil && node.EndPosition.Line > end {
+			end = node.EndPosition.Line
+		}
+		for line := node.StartPosition.Line; line <= end; line++ {
+			commentLines[line] = true
+		}
+	}
+	codeLines := map[uint32]bool{}
+	uast_items.VisitEachNode(change.After, func(node *uast.Node) {
+		if node.Token == "" || node.StartPosition == nil {
+			return
+		}
+		for _, role := range node.Roles {
+			if role == uast.Comment {
+				return
+			}
+		}
+		codeLines[node.StartPosition.Line] = true
+	})
+	return CommentRatioStats{Comments: len(commentLines), Code: len(codeLines)}
+}
+
+func (ratio *CommentRatioAnalysis) addFile(change uast_items.Change) {
+	name := change.Change.To.Name
+	lang, _ := enry.GetLanguageByExtension(name)
+	if lang == "" {
+		lang = "Other"
+	}
+	stats := ratio.measure(change)
+	ratio.files[name] = stats
+	ratio.languages[name] = lang
+	totals := ratio.totals[lang]
+	totals.Comments += stats.Comments
+	totals.Code += stats.Code
+	ratio.totals[lang] = totals
+}
+
+func (ratio *CommentRatioAnalysis) removeFile(name string) {
+	stats, exists := ratio.files[name]
+	if !exists {
+		return
+	}
+	lang := ratio.languages[name]
+	totals := ratio.totals[lang]
+	totals.Comments -= stats.Comments
+	totals.Code -= stats.Code
+	ratio.totals[lang] = totals
+	delete(ratio.files, name)
+	delete(ratio.languages, name)
+}
+
+func init() {
+	core.Registry.Register(&CommentRatioAnalysis{})
+}
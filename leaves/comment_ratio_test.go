@@ -0,0 +1,151 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureCommentRatio() *CommentRatioAnalysis {
+	cr := CommentRatioAnalysis{}
+	cr.Initialize(test.Repository)
+	return &cr
+}
+
+// fixtureCommentRatioUAST generates a file with a two line comment on top followed by
+// `functions` one line functions with a trailing comment each.
+func fixtureCommentRatioUAST(functions int) *uast.Node {
+	position := func(line uint32) *uast.Position {
+		return &uast.Position{Line: line}
+	}
+	root := &uast.Node{Roles: []uast.Role{uast.File}, Children: []*uast.Node{{
+		Roles: []uast.Role{uast.Comment}, Token: "// first\n// second",
+		StartPosition: position(1), EndPosition: position(2),
+	}}}
+	for i := 0; i < functions; i++ {
+		line := uint32(3 + i)
+		root.Children = append(root.Children, &uast.Node{
+			Roles: []uast.Role{uast.Function, uast.Declaration},
+			Children: []*uast.Node{{
+				Roles: []uast.Role{uast.Identifier}, Token: "foo",
+				StartPosition: position(line),
+			}, {
+				Roles: []uast.Role{uast.Comment}, Token: "// bar",
+				StartPosition: position(line),
+			}},
+		})
+	}
+	return root
+}
+
+func TestCommentRatioMeta(t *testing.T) {
+	cr := fixtureCommentRatio()
+	assert.Equal(t, cr.Name(), "CommentRatio")
+	assert.Len(t, cr.Provides(), 0)
+	assert.Equal(t, cr.Requires(), []string{uast_items.DependencyUastChanges, items.DependencyDay})
+	assert.Equal(t, cr.Features(), []string{uast_items.FeatureUast})
+	assert.Len(t, cr.ListConfigurationOptions(), 0)
+	assert.Equal(t, cr.Flag(), "comment-ratio")
+	cr.Configure(nil)
+}
+
+func TestCommentRatioRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&CommentRatioAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "CommentRatio")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&CommentRatioAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestCommentRatioConsume(t *testing.T) {
+	cr := fixtureCommentRatio()
+	deps := map[string]interface{}{}
+	deps[items.DependencyDay] = 0
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: nil, After: fixtureCommentRatioUAST(2), Change: &object.Change{
+			To: object.ChangeEntry{Name: "main.go"}}},
+		{Before: nil, After: fixtureCommentRatioUAST(1), Change: &object.Change{
+			To: object.ChangeEntry{Name: "util.py"}}},
+	}
+	result, err := cr.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps[items.DependencyDay] = 3
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: fixtureCommentRatioUAST(2), After: fixtureCommentRatioUAST(5),
+			Change: &object.Change{
+				From: object.ChangeEntry{Name: "main.go"},
+				To:   object.ChangeEntry{Name: "cmd/main.go"}}},
+		{Before: fixtureCommentRatioUAST(1), After: nil, Change: &object.Change{
+			From: object.ChangeEntry{Name: "util.py"}}},
+	}
+	result, err = cr.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps[items.DependencyDay] = 4
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{}
+	cr.Consume(deps)
+	res := cr.Finalize().(CommentRatioResult)
+	assert.Len(t, res.Days, 2)
+	assert.Equal(t, res.Days[0], map[string]CommentRatioStats{
+		"Go":     {Comments: 4, Code: 2},
+		"Python": {Comments: 3, Code: 1},
+	})
+	assert.Equal(t, res.Days[3], map[string]CommentRatioStats{
+		"Go": {Comments: 7, Code: 5},
+	})
+	assert.Len(t, cr.files, 1)
+}
+
+func TestCommentRatioStatsRatio(t *testing.T) {
+	assert.Equal(t, CommentRatioStats{}.Ratio(), float32(0))
+	assert.Equal(t, CommentRatioStats{Comments: 1, Code: 4}.Ratio(), float32(0.25))
+}
+
+func TestCommentRatioSerializeText(t *testing.T) {
+	cr := fixtureCommentRatio()
+	res := CommentRatioResult{Days: map[int]map[string]CommentRatioStats{
+		5: {"Go": {Comments: 3, Code: 4}, "C": {Comments: 1, Code: 0}},
+		1: {"Python": {Comments: 2, Code: 8}},
+	}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, cr.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  # comment lines, code lines, ratio
+  1:
+    "Python": [2, 8, 0.2500]
+  5:
+    "C": [1, 0, 0.0000]
+    "Go": [3, 4, 0.7500]
+`)
+}
+
+func TestCommentRatioSerializeBinary(t *testing.T) {
+	cr := fixtureCommentRatio()
+	res := CommentRatioResult{Days: map[int]map[string]CommentRatioStats{
+		5: {"Go": {Comments: 3, Code: 4}},
+		1: {"Python": {Comments: 2, Code: 8}},
+	}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, cr.Serialize(res, true, buffer))
+	msg := pb.CommentRatioResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Days, 2)
+	assert.Equal(t, *msg.Days[5].Languages["Go"], pb.CommentRatioStats{Comments: 3, Code: 4})
+	assert.Equal(t, *msg.Days[1].Languages["Python"], pb.CommentRatioStats{Comments: 2, Code: 8})
+}
@@ -0,0 +1,313 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// CommentReadabilityAnalysis scores the new or changed comments with the readability metrics -
+// Flesch reading ease, Flesch-Kincaid grade level and the average sentence length - per day and
+// per directory, which is the documentation quality signal to complement the sentiment.
+// The comments are extracted with the same XPath as in CommentSentimentAnalysis.
+// It is a LeafPipelineItem.
+type CommentReadabilityAnalysis struct {
+	// ComponentDepth is the number of the leading directories which name the component.
+	ComponentDepth int
+
+	// days maps the day index to the component to the stats of the comments.
+	days map[int]map[string]CommentReadabilityStats
+	// xpather extracts the comment nodes.
+	xpather *uast_items.ChangesXPather
+}
+
+// CommentReadabilityStats are the text statistics of a group of comments.
+type CommentReadabilityStats struct {
+	// Comments is the number of comments which have at least one word.
+	Comments int
+	// Sentences is the number of sentences.
+	Sentences int
+	// Words is the number of words.
+	Words int
+	// Syllables is the estimated number of syllables in the words.
+	Syllables int
+}
+
+// AverageSentenceLength returns the average number of words in a sentence.
+func (stats CommentReadabilityStats) AverageSentenceLength() float32 {
+	if stats.Sentences == 0 {
+		return 0
+	}
+	return float32(stats.Words) / float32(stats.Sentences)
+}
+
+// FleschReadingEase returns the Flesch reading ease score: 100 is very easy to read,
+// 0 and below is very hard.
+func (stats CommentReadabilityStats) FleschReadingEase() float32 {
+	if stats.Words == 0 {
+		return 0
+	}
+	return 206.835 - 1.015*stats.AverageSentenceLength() -
+		84.6*float32(stats.Syllables)/float32(stats.Words)
+}
+
+// FleschKincaidGrade returns the Flesch-Kincaid grade level: the number of years of education
+// which are needed to understand the text.
+func (stats CommentReadabilityStats) FleschKincaidGrade() float32 {
+	if stats.Words == 0 {
+		return 0
+	}
+	return 0.39*stats.AverageSentenceLength() +
+		11.8*float32(stats.Syllables)/float32(stats.Words) - 15.59
+}
+
+// add merges the other stats into these.
+func (stats *CommentReadabilityStats) add(other CommentReadabilityStats) {
+	stats.Comments += other.Comments
+	stats.Sentences += other.Sentences
+	stats.Words += other.Words
+	stats.Syllables += other.Syllables
+}
+
+// CommentReadabilityResult is returned by CommentReadabilityAnalysis.Finalize() and carries
+// the readability stats of the new or changed comments.
+type CommentReadabilityResult struct {
+	// Days maps the day index to the leading directories to the stats of the comments.
+	Days map[int]map[string]CommentReadabilityStats
+}
+
+const (
+	// ConfigCommentReadabilityComponentDepth is the name of the option to set
+	// CommentReadabilityAnalysis.ComponentDepth.
+	ConfigCommentReadabilityComponentDepth = "CommentReadability.ComponentDepth"
+	// DefaultCommentReadabilityComponentDepth is the default value of
+	// CommentReadabilityAnalysis.ComponentDepth.
+	DefaultCommentReadabilityComponentDepth = 1
+)
+
+var (
+	readabilityWordRE     = regexp.MustCompile("[a-zA-Z]+(?:'[a-zA-Z]+)?")
+	readabilitySentenceRE = regexp.MustCompile("[.!?]+(?:\\s|$)|\\n\\s*\\n")
+	readabilityVowelsRE   = regexp.MustCompile("[aeiouy]+")
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (readability *CommentReadabilityAnalysis) Name() string {
+	return "CommentReadability"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (readability *CommentReadabilityAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (readability *CommentReadabilityAnalysis) Requires() []string {
+	arr := [...]string{uast_items.DependencyUastChanges, items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (readability *CommentReadabilityAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (readability *CommentReadabilityAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigCommentReadabilityComponentDepth,
+		Description: "Number of the leading directories which define a component.",
+		Flag:        "readability-depth",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultCommentReadabilityComponentDepth},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (readability *CommentReadabilityAnalysis) Flag() string {
+	return "comment-readability"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (readability *CommentReadabilityAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigCommentReadabilityComponentDepth].(int); exists {
+		readability.ComponentDepth = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (readability *CommentReadabilityAnalysis) Initialize(repository *git.Repository) {
+	if readability.ComponentDepth <= 0 {
+		log.Printf("Warning: adjusted the component depth to %d\n",
+			DefaultCommentReadabilityComponentDepth)
+		readability.ComponentDepth = DefaultCommentReadabilityComponentDepth
+	}
+	readability.days = map[int]map[string]CommentReadabilityStats{}
+	readability.xpather = &uast_items.ChangesXPather{XPath: "//*[@roleComment]"}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (readability *CommentReadabilityAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	day := deps[items.DependencyDay].(int)
+	for _, change := range changes {
+		if change.After == nil {
+			continue
+		}
+		stats := CommentReadabilityStats{}
+		for _, node := range readability.xpather.Extract([]uast_items.Change{change}) {
+			stats.add(measureReadability(node.Token))
+		}
+		if stats.Comments == 0 {
+			continue
+		}
+		components := readability.days[day]
+		if components == nil {
+			components = map[string]CommentReadabilityStats{}
+			readability.days[day] = components
+		}
+		component := fileComponent(change.Change.To.Name, readability.ComponentDepth)
+		total := components[component]
+		total.add(stats)
+		components[component] = total
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (readability *CommentReadabilityAnalysis) Finalize() interface{} {
+	return CommentReadabilityResult{Days: readability.days}
+}
+
+// measureReadability counts the sentences, the words and the syllables in the comment.
+// The sentences end with ".", "!" or "?" followed by whitespace, or with an empty line;
+// the trailing text without the terminator is a sentence too.
+func measureReadability(comment string) CommentReadabilityStats {
+	stats := CommentReadabilityStats{}
+	for _, sentence := range readabilitySentenceRE.Split(comment, -1) {
+		words := readabilityWordRE.FindAllString(sentence, -1)
+		if len(words) == 0 {
+			continue
+		}
+		stats.Sentences++
+		stats.Words += len(words)
+		for _, word := range words {
+			stats.Syllables += countSyllables(word)
+		}
+	}
+	if stats.Words > 0 {
+		stats.Comments = 1
+	}
+	return stats
+}
+
+// countSyllables estimates the number of syllables in the English word as the number of
+// vowel groups without the silent "e" at the end.
+func countSyllables(word string) int {
+	word = strings.ToLower(word)
+	count := len(readabilityVowelsRE.FindAllStringIndex(word, -1))
+	if count > 1 && strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") &&
+		!strings.HasSuffix(word, "ee") {
+		count--
+	}
+	if count == 0 {
+		count = 1
+	}
+	return count
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (readability *CommentReadabilityAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	readabilityResult := result.(CommentReadabilityResult)
+	if binary {
+		return readability.serializeBinary(&readabilityResult, writer)
+	}
+	readability.serializeText(&readabilityResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (readability *CommentReadabilityAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(readability, result, &pb.CommentReadabilityResults{}, writer)
+}
+
+func (readability *CommentReadabilityAnalysis) serializeText(
+	result *CommentReadabilityResult, writer io.Writer) {
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	fmt.Fprintln(writer, "  # comments, sentences, words, syllables, reading ease, grade level, "+
+		"average sentence length")
+	for _, day := range days {
+		fmt.Fprintf(writer, "  %d:\n", day)
+		components := result.Days[day]
+		keys := make([]string, 0, len(components))
+		for component := range components {
+			keys = append(keys, component)
+		}
+		sort.Strings(keys)
+		for _, component := range keys {
+			stats := components[component]
+			fmt.Fprintf(writer, "    %s: [%d, %d, %d, %d, %.2f, %.2f, %.2f]\n",
+				yaml.SafeString(component), stats.Comments, stats.Sentences, stats.Words,
+				stats.Syllables, stats.FleschReadingEase(), stats.FleschKincaidGrade(),
+				stats.AverageSentenceLength())
+		}
+	}
+}
+
+func (readability *CommentReadabilityAnalysis) serializeBinary(
+	result *CommentReadabilityResult, writer io.Writer) error {
+	message := pb.CommentReadabilityResults{
+		Days: map[int32]*pb.DirectoryCommentReadability{},
+	}
+	for day, components := range result.Days {
+		pbComponents := &pb.DirectoryCommentReadability{
+			Directories: map[string]*pb.CommentReadabilityStats{},
+		}
+		for component, stats := range components {
+			pbComponents.Directories[component] = &pb.CommentReadabilityStats{
+				Comments:  int32(stats.Comments),
+				Sentences: int32(stats.Sentences),
+				Words:     int32(stats.Words),
+				Syllables: int32(stats.Syllables),
+			}
+		}
+		message.Days[int32(day)] = pbComponents
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&CommentReadabilityAnalysis{})
+}
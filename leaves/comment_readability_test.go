@@ -0,0 +1,143 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureCommentReadability() *CommentReadabilityAnalysis {
+	readability := CommentReadabilityAnalysis{
+		ComponentDepth: DefaultCommentReadabilityComponentDepth}
+	readability.Initialize(test.Repository)
+	return &readability
+}
+
+func TestCommentReadabilityMeta(t *testing.T) {
+	readability := fixtureCommentReadability()
+	assert.Equal(t, readability.Name(), "CommentReadability")
+	assert.Len(t, readability.Provides(), 0)
+	assert.Equal(t, readability.Requires(),
+		[]string{uast_items.DependencyUastChanges, items.DependencyDay})
+	assert.Equal(t, readability.Features(), []string{uast_items.FeatureUast})
+	opts := readability.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigCommentReadabilityComponentDepth)
+	assert.Equal(t, readability.Flag(), "comment-readability")
+	readability.Configure(map[string]interface{}{ConfigCommentReadabilityComponentDepth: 2})
+	assert.Equal(t, readability.ComponentDepth, 2)
+	readability.ComponentDepth = 0
+	readability.Initialize(test.Repository)
+	assert.Equal(t, readability.ComponentDepth, DefaultCommentReadabilityComponentDepth)
+}
+
+func TestCommentReadabilityRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&CommentReadabilityAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "CommentReadability")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&CommentReadabilityAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestCommentReadabilityMeasure(t *testing.T) {
+	assert.Equal(t, measureReadability("Returns the value. Call it twice!\n// TODO"),
+		CommentReadabilityStats{Comments: 1, Sentences: 3, Words: 7, Syllables: 9})
+	assert.Equal(t, measureReadability("first paragraph\n\nsecond one"),
+		CommentReadabilityStats{Comments: 1, Sentences: 2, Words: 4, Syllables: 7})
+	assert.Equal(t, measureReadability("// ----- 42 -----"), CommentReadabilityStats{})
+	assert.Equal(t, countSyllables("table"), 2)
+	assert.Equal(t, countSyllables("make"), 1)
+	assert.Equal(t, countSyllables("free"), 1)
+	assert.Equal(t, countSyllables("the"), 1)
+	assert.Equal(t, countSyllables("HTTP"), 1)
+	assert.Equal(t, countSyllables("readability"), 5)
+}
+
+func TestCommentReadabilityStats(t *testing.T) {
+	stats := CommentReadabilityStats{}
+	assert.Equal(t, stats.AverageSentenceLength(), float32(0))
+	assert.Equal(t, stats.FleschReadingEase(), float32(0))
+	assert.Equal(t, stats.FleschKincaidGrade(), float32(0))
+	stats = CommentReadabilityStats{Comments: 1, Sentences: 2, Words: 20, Syllables: 30}
+	assert.Equal(t, stats.AverageSentenceLength(), float32(10))
+	assert.InDelta(t, stats.FleschReadingEase(), 69.785, 0.001)
+	assert.InDelta(t, stats.FleschKincaidGrade(), 6.01, 0.001)
+}
+
+func TestCommentReadabilityConsume(t *testing.T) {
+	readability := fixtureCommentReadability()
+	deps := map[string]interface{}{}
+	deps[items.DependencyDay] = 0
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: nil, After: fixtureToxicityUAST("Returns the value.", "// ----"),
+			Change: &object.Change{To: object.ChangeEntry{Name: "src/parser/main.go"}}},
+		{Before: nil, After: fixtureToxicityUAST("Call it twice"),
+			Change: &object.Change{To: object.ChangeEntry{Name: "src/lexer.go"}}},
+		{Before: nil, After: fixtureToxicityUAST("// ----"),
+			Change: &object.Change{To: object.ChangeEntry{Name: "setup.py"}}},
+	}
+	result, err := readability.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps[items.DependencyDay] = 2
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: fixtureToxicityUAST("Returns the value."),
+			After:  fixtureToxicityUAST("Returns the value.", "Make it free"),
+			Change: &object.Change{To: object.ChangeEntry{Name: "setup.py"}}},
+		{Before: fixtureToxicityUAST("Removed"), After: nil,
+			Change: &object.Change{From: object.ChangeEntry{Name: "old.py"}}},
+	}
+	readability.Consume(deps)
+	res := readability.Finalize().(CommentReadabilityResult)
+	assert.Equal(t, res.Days, map[int]map[string]CommentReadabilityStats{
+		0: {"src": {Comments: 2, Sentences: 2, Words: 6, Syllables: 7}},
+		2: {"/": {Comments: 1, Sentences: 1, Words: 3, Syllables: 3}},
+	})
+}
+
+func TestCommentReadabilitySerializeText(t *testing.T) {
+	readability := fixtureCommentReadability()
+	res := CommentReadabilityResult{Days: map[int]map[string]CommentReadabilityStats{
+		5: {"src": {Comments: 1, Sentences: 2, Words: 20, Syllables: 30},
+			"/": {Comments: 1, Sentences: 1, Words: 1, Syllables: 1}},
+		1: {"docs": {}},
+	}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, readability.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  # comments, sentences, words, syllables, reading ease, grade level, average sentence length
+  1:
+    "docs": [0, 0, 0, 0, 0.00, 0.00, 0.00]
+  5:
+    "/": [1, 1, 1, 1, 121.22, -3.40, 1.00]
+    "src": [1, 2, 20, 30, 69.79, 6.01, 10.00]
+`)
+}
+
+func TestCommentReadabilitySerializeBinary(t *testing.T) {
+	readability := fixtureCommentReadability()
+	res := CommentReadabilityResult{Days: map[int]map[string]CommentReadabilityStats{
+		5: {"src": {Comments: 1, Sentences: 2, Words: 20, Syllables: 30}},
+	}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, readability.Serialize(res, true, buffer))
+	msg := pb.CommentReadabilityResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Days, 1)
+	assert.Equal(t, *msg.Days[5].Directories["src"], pb.CommentReadabilityStats{
+		Comments: 1, Sentences: 2, Words: 20, Syllables: 30})
+}
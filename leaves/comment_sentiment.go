@@ -3,6 +3,7 @@
 package leaves
 
 import (
+	"crypto/sha1"
 	"fmt"
 	"io"
 	"log"
@@ -20,6 +21,7 @@ import (
 	"gopkg.in/src-d/hercules.v4/internal/pb"
 	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
 	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/yaml"
 	"gopkg.in/vmarkovtsev/BiDiSentiment.v1"
 )
 
@@ -27,7 +29,29 @@ import (
 type CommentSentimentAnalysis struct {
 	MinCommentLength int
 	Gap              float32
+	// LettersRatio is the minimum share of the letters in a comment, 0 disables the check.
+	LettersRatio float32
+	// KeepDocstrings disables discarding the comments which start with a non-alphanumeric
+	// character, e.g. Javadoc; their leading decoration is trimmed instead.
+	KeepDocstrings bool
+	// KeepFunctionNames disables removing "name()" from the comments.
+	KeepFunctionNames bool
+	// LicenseRegexp discards the license headers, empty disables the check.
+	LicenseRegexp string
+	// Filters are the extra regular expressions which discard the matching comments.
+	Filters []string
+	// Deduplication is the policy for the comments which are extracted again after unrelated
+	// edits of the same file, see CommentSentimentDeduplicationEvery and
+	// CommentSentimentDeduplicationFirst.
+	Deduplication string
+	// Emotions is the name of the EmotionClassifier which assigns the emotion categories to
+	// the comments, empty disables the classification. See RegisterEmotionClassifier().
+	Emotions string
 
+	classifier    EmotionClassifier
+	licenseRE     *regexp.Regexp
+	filters       []*regexp.Regexp
+	seenComments  map[[sha1.Size]byte]bool
 	commentsByDay map[int][]string
 	commitsByDay  map[int][]plumbing.Hash
 	xpather       *uast_items.ChangesXPather
@@ -38,18 +62,50 @@ type CommentSentimentAnalysis struct {
 type CommentSentimentResult struct {
 	EmotionsByDay map[int]float32
 	CommentsByDay map[int][]string
+	// CategoriesByDay are the shares of EmotionCategories among CommentsByDay. It is empty
+	// if CommentSentimentAnalysis.Emotions is not set.
+	CategoriesByDay map[int]map[string]float32
 	commitsByDay  map[int][]plumbing.Hash
 }
 
 const (
 	ConfigCommentSentimentMinLength = "CommentSentiment.MinLength"
 	ConfigCommentSentimentGap       = "CommentSentiment.Gap"
+	// ConfigCommentSentimentLettersRatio is the name of the option to set
+	// CommentSentimentAnalysis.LettersRatio.
+	ConfigCommentSentimentLettersRatio = "CommentSentiment.LettersRatio"
+	// ConfigCommentSentimentKeepDocstrings is the name of the option to set
+	// CommentSentimentAnalysis.KeepDocstrings.
+	ConfigCommentSentimentKeepDocstrings = "CommentSentiment.KeepDocstrings"
+	// ConfigCommentSentimentKeepFunctionNames is the name of the option to set
+	// CommentSentimentAnalysis.KeepFunctionNames.
+	ConfigCommentSentimentKeepFunctionNames = "CommentSentiment.KeepFunctionNames"
+	// ConfigCommentSentimentLicenseRegexp is the name of the option to set
+	// CommentSentimentAnalysis.LicenseRegexp.
+	ConfigCommentSentimentLicenseRegexp = "CommentSentiment.LicenseRegexp"
+	// ConfigCommentSentimentFilters is the name of the option to set
+	// CommentSentimentAnalysis.Filters.
+	ConfigCommentSentimentFilters = "CommentSentiment.Filters"
+	// ConfigCommentSentimentDeduplication is the name of the option to set
+	// CommentSentimentAnalysis.Deduplication.
+	ConfigCommentSentimentDeduplication = "CommentSentiment.Deduplication"
+	// ConfigCommentSentimentEmotions is the name of the option to set
+	// CommentSentimentAnalysis.Emotions.
+	ConfigCommentSentimentEmotions = "CommentSentiment.Emotions"
+
+	// CommentSentimentDeduplicationEvery analyzes the comment on every modification.
+	CommentSentimentDeduplicationEvery = "every"
+	// CommentSentimentDeduplicationFirst analyzes the comment only on the day when it is seen
+	// for the first time.
+	CommentSentimentDeduplicationFirst = "first"
 
 	DefaultCommentSentimentCommentMinLength = 20
 	DefaultCommentSentimentGap              = float32(0.5)
 
 	// CommentLettersRatio is the threshold to filter impure comments which contain code.
 	CommentLettersRatio = 0.6
+	// DefaultCommentSentimentLicenseRegexp matches the license headers.
+	DefaultCommentSentimentLicenseRegexp = "(?i)[li[cs]en[cs][ei]|copyright|©"
 )
 
 var (
@@ -58,7 +114,7 @@ var (
 	charsRE             = regexp.MustCompile("[a-zA-Z]+")
 	functionNameRE      = regexp.MustCompile("\\s*[a-zA-Z_][a-zA-Z_0-9]*\\(\\)")
 	whitespaceRE        = regexp.MustCompile("\\s+")
-	licenseRE           = regexp.MustCompile("(?i)[li[cs]en[cs][ei]|copyright|©")
+	docstringRE         = regexp.MustCompile("(?m)^[^a-zA-Z0-9\\n]+")
 )
 
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
@@ -100,7 +156,47 @@ func (sent *CommentSentimentAnalysis) ListConfigurationOptions() []core.Configur
 			"considered. Must be >= 0 and < 1. The purpose is to exclude neutral comments.",
 		Flag:    "sentiment-gap",
 		Type:    core.FloatConfigurationOption,
-		Default: DefaultCommentSentimentGap},
+		Default: DefaultCommentSentimentGap}, {
+		Name: ConfigCommentSentimentLettersRatio,
+		Description: "Minimum share of the letters in the comment to exclude the commented code. " +
+			"Must be >= 0 and <= 1, 0 disables the check.",
+		Flag:    "sentiment-letters-ratio",
+		Type:    core.FloatConfigurationOption,
+		Default: float32(CommentLettersRatio)}, {
+		Name: ConfigCommentSentimentKeepDocstrings,
+		Description: "Analyze the comments which start with a non-alphanumeric character, " +
+			"e.g. Javadoc, instead of discarding them as docstrings.",
+		Flag:    "sentiment-keep-docstrings",
+		Type:    core.BoolConfigurationOption,
+		Default: false}, {
+		Name:        ConfigCommentSentimentKeepFunctionNames,
+		Description: "Do not remove the function names such as \"foo()\" from the comments.",
+		Flag:        "sentiment-keep-function-names",
+		Type:        core.BoolConfigurationOption,
+		Default:     false}, {
+		Name:        ConfigCommentSentimentLicenseRegexp,
+		Description: "Regular expression to discard the license headers, empty disables it.",
+		Flag:        "sentiment-license-regexp",
+		Type:        core.StringConfigurationOption,
+		Default:     DefaultCommentSentimentLicenseRegexp}, {
+		Name:        ConfigCommentSentimentFilters,
+		Description: "Extra regular expressions to discard the matching comments.",
+		Flag:        "sentiment-filters",
+		Type:        core.StringsConfigurationOption,
+		Default:     []string{}}, {
+		Name: ConfigCommentSentimentDeduplication,
+		Description: "Which occurrences of the same comment to analyze: \"" +
+			CommentSentimentDeduplicationEvery + "\" - on every modification, \"" +
+			CommentSentimentDeduplicationFirst + "\" - only on the day when it is seen first.",
+		Flag:    "sentiment-dedup",
+		Type:    core.StringConfigurationOption,
+		Default: CommentSentimentDeduplicationEvery}, {
+		Name: ConfigCommentSentimentEmotions,
+		Description: "Classify the comments into emotions (" + strings.Join(EmotionCategories, ", ") +
+			") with the specified classifier: " + strings.Join(EmotionClassifiers(), ", ") + ".",
+		Flag:    "sentiment-emotions",
+		Type:    core.StringConfigurationOption,
+		Default: ""},
 	}
 	return options[:]
 }
@@ -118,6 +214,27 @@ func (sent *CommentSentimentAnalysis) Configure(facts map[string]interface{}) {
 	if val, exists := facts[ConfigCommentSentimentMinLength]; exists {
 		sent.MinCommentLength = val.(int)
 	}
+	if val, exists := facts[ConfigCommentSentimentLettersRatio]; exists {
+		sent.LettersRatio = val.(float32)
+	}
+	if val, exists := facts[ConfigCommentSentimentKeepDocstrings]; exists {
+		sent.KeepDocstrings = val.(bool)
+	}
+	if val, exists := facts[ConfigCommentSentimentKeepFunctionNames]; exists {
+		sent.KeepFunctionNames = val.(bool)
+	}
+	if val, exists := facts[ConfigCommentSentimentLicenseRegexp]; exists {
+		sent.LicenseRegexp = val.(string)
+	}
+	if val, exists := facts[ConfigCommentSentimentFilters]; exists {
+		sent.Filters = val.([]string)
+	}
+	if val, exists := facts[ConfigCommentSentimentDeduplication]; exists {
+		sent.Deduplication = val.(string)
+	}
+	if val, exists := facts[ConfigCommentSentimentEmotions]; exists {
+		sent.Emotions = val.(string)
+	}
 	sent.validate()
 	sent.commitsByDay = facts[items.FactCommitsByDay].(map[int][]plumbing.Hash)
 }
@@ -133,12 +250,55 @@ func (sent *CommentSentimentAnalysis) validate() {
 			sent.MinCommentLength, DefaultCommentSentimentCommentMinLength)
 		sent.MinCommentLength = DefaultCommentSentimentCommentMinLength
 	}
+	if sent.LettersRatio < 0 || sent.LettersRatio > 1 {
+		log.Printf("Comment letters ratio is out of range: %f => reset to the default %f",
+			sent.LettersRatio, CommentLettersRatio)
+		sent.LettersRatio = CommentLettersRatio
+	}
+	sent.licenseRE = nil
+	if sent.LicenseRegexp != "" {
+		re, err := regexp.Compile(sent.LicenseRegexp)
+		if err != nil {
+			log.Printf("Invalid license regexp: %v => reset to the default %s",
+				err, DefaultCommentSentimentLicenseRegexp)
+			sent.LicenseRegexp = DefaultCommentSentimentLicenseRegexp
+			re = regexp.MustCompile(sent.LicenseRegexp)
+		}
+		sent.licenseRE = re
+	}
+	sent.filters = make([]*regexp.Regexp, 0, len(sent.Filters))
+	for _, filter := range sent.Filters {
+		re, err := regexp.Compile(filter)
+		if err != nil {
+			log.Printf("Invalid comment filter: %v => ignored", err)
+			continue
+		}
+		sent.filters = append(sent.filters, re)
+	}
+	switch sent.Deduplication {
+	case CommentSentimentDeduplicationEvery, CommentSentimentDeduplicationFirst:
+	case "":
+		sent.Deduplication = CommentSentimentDeduplicationEvery
+	default:
+		log.Printf("Unknown comment deduplication policy: %s => reset to the default %s",
+			sent.Deduplication, CommentSentimentDeduplicationEvery)
+		sent.Deduplication = CommentSentimentDeduplicationEvery
+	}
+	sent.classifier = nil
+	if sent.Emotions != "" {
+		sent.classifier = newEmotionClassifier(sent.Emotions)
+		if sent.classifier == nil {
+			log.Printf("Unknown emotion classifier: %s => the emotions are disabled", sent.Emotions)
+			sent.Emotions = ""
+		}
+	}
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (sent *CommentSentimentAnalysis) Initialize(repository *git.Repository) {
 	sent.commentsByDay = map[int][]string{}
+	sent.seenComments = map[[sha1.Size]byte]bool{}
 	sent.xpather = &uast_items.ChangesXPather{XPath: "//*[@roleComment]"}
 	sent.validate()
 }
@@ -152,7 +312,7 @@ func (sent *CommentSentimentAnalysis) Consume(deps map[string]interface{}) (map[
 	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
 	day := deps[items.DependencyDay].(int)
 	commentNodes := sent.xpather.Extract(changes)
-	comments := sent.mergeComments(commentNodes)
+	comments := sent.deduplicate(sent.mergeComments(commentNodes))
 	dayComments := sent.commentsByDay[day]
 	if dayComments == nil {
 		dayComments = []string{}
@@ -165,9 +325,10 @@ func (sent *CommentSentimentAnalysis) Consume(deps map[string]interface{}) (map[
 // Finalize returns the result of the analysis. Further Consume() calls are not expected.
 func (sent *CommentSentimentAnalysis) Finalize() interface{} {
 	result := CommentSentimentResult{
-		EmotionsByDay: map[int]float32{},
-		CommentsByDay: map[int][]string{},
-		commitsByDay:  sent.commitsByDay,
+		EmotionsByDay:   map[int]float32{},
+		CommentsByDay:   map[int][]string{},
+		CategoriesByDay: map[int]map[string]float32{},
+		commitsByDay:    sent.commitsByDay,
 	}
 	days := make([]int, 0, len(sent.commentsByDay))
 	for day := range sent.commentsByDay {
@@ -222,9 +383,36 @@ func (sent *CommentSentimentAnalysis) Finalize() interface{} {
 			result.CommentsByDay[key] = comments
 		}
 	}
+	if sent.classifier != nil {
+		sent.classifyEmotions(&result)
+	}
 	return result
 }
 
+// classifyEmotions fills CommentSentimentResult.CategoriesByDay.
+func (sent *CommentSentimentAnalysis) classifyEmotions(result *CommentSentimentResult) {
+	days := make([]int, 0, len(result.CommentsByDay))
+	for day := range result.CommentsByDay {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	texts := []string{}
+	for _, day := range days {
+		texts = append(texts, result.CommentsByDay[day]...)
+	}
+	categories, err := sent.classifier.Classify(texts)
+	if err != nil {
+		log.Printf("Warning: failed to classify the emotions: %v", err)
+		return
+	}
+	pos := 0
+	for _, day := range days {
+		size := len(result.CommentsByDay[day])
+		result.CategoriesByDay[day] = emotionDistribution(categories[pos : pos+size])
+		pos += size
+	}
+}
+
 // Serialize converts the analysis result as returned by Finalize() to text or bytes.
 // The text format is YAML and the bytes format is Protocol Buffers.
 func (sent *CommentSentimentAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
@@ -236,6 +424,11 @@ func (sent *CommentSentimentAnalysis) Serialize(result interface{}, binary bool,
 	return nil
 }
 
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (sent *CommentSentimentAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(sent, result, &pb.CommentSentimentResults{}, writer)
+}
+
 func (sent *CommentSentimentAnalysis) serializeText(result *CommentSentimentResult, writer io.Writer) {
 	days := make([]int, 0, len(result.EmotionsByDay))
 	for day := range result.EmotionsByDay {
@@ -248,9 +441,17 @@ func (sent *CommentSentimentAnalysis) serializeText(result *CommentSentimentResu
 		for i, hash := range commits {
 			hashes[i] = hash.String()
 		}
-		fmt.Fprintf(writer, "  %d: [%.4f, [%s], \"%s\"]\n",
+		categories := ""
+		if distribution, exists := result.CategoriesByDay[day]; exists {
+			shares := make([]string, len(EmotionCategories))
+			for i, emotion := range EmotionCategories {
+				shares[i] = fmt.Sprintf("%s: %.4f", emotion, distribution[emotion])
+			}
+			categories = ", {" + strings.Join(shares, ", ") + "}"
+		}
+		fmt.Fprintf(writer, "  %d: [%.4f, [%s], %s%s]\n",
 			day, result.EmotionsByDay[day], strings.Join(hashes, ","),
-			strings.Join(result.CommentsByDay[day], "|"))
+			yaml.SafeString(strings.Join(result.CommentsByDay[day], "|")), categories)
 	}
 }
 
@@ -268,6 +469,7 @@ func (sent *CommentSentimentAnalysis) serializeBinary(
 			Value:    val,
 			Comments: result.CommentsByDay[key],
 			Commits:  commits,
+			Emotions: result.CategoriesByDay[key],
 		}
 	}
 	serialized, err := proto.Marshal(&message)
@@ -321,28 +523,36 @@ func (sent *CommentSentimentAnalysis) mergeComments(nodes []*uast.Node) []string
 	filteredComments := make([]string, 0, len(mergedComments))
 	for _, comment := range mergedComments {
 		comment = strings.TrimSpace(comment)
+		if comment != "" && sent.KeepDocstrings {
+			comment = strings.TrimSpace(docstringRE.ReplaceAllString(comment, ""))
+		}
 		if comment == "" || filteredFirstCharRE.MatchString(comment[:1]) {
 			// heuristic - we discard docstrings
 			continue
 		}
-		// heuristic - remove function names
-		comment = functionNameRE.ReplaceAllString(comment, "")
+		if !sent.KeepFunctionNames {
+			// heuristic - remove function names
+			comment = functionNameRE.ReplaceAllString(comment, "")
+		}
 		comment = filteredCharsRE.ReplaceAllString(comment, "")
 		if len(comment) < sent.MinCommentLength {
 			continue
 		}
 		// collapse whitespace
 		comment = whitespaceRE.ReplaceAllString(comment, " ")
-		// heuristic - number of letters must be at least 60%
+		// heuristic - number of letters must be at least LettersRatio, 60% by default
 		charsCount := 0
 		for _, match := range charsRE.FindAllStringIndex(comment, -1) {
 			charsCount += match[1] - match[0]
 		}
-		if charsCount < int(float32(len(comment))*CommentLettersRatio) {
+		if charsCount < int(float32(len(comment))*sent.LettersRatio) {
 			continue
 		}
 		// heuristic - license
-		if licenseRE.MatchString(comment) {
+		if sent.licenseRE != nil && sent.licenseRE.MatchString(comment) {
+			continue
+		}
+		if sent.isFiltered(comment) {
 			continue
 		}
 		filteredComments = append(filteredComments, comment)
@@ -350,6 +560,34 @@ func (sent *CommentSentimentAnalysis) mergeComments(nodes []*uast.Node) []string
 	return filteredComments
 }
 
+// deduplicate removes the comments which were already analyzed if the deduplication policy
+// is CommentSentimentDeduplicationFirst. The comments are compared by the hashes of the contents.
+func (sent *CommentSentimentAnalysis) deduplicate(comments []string) []string {
+	if sent.Deduplication != CommentSentimentDeduplicationFirst {
+		return comments
+	}
+	unique := comments[:0]
+	for _, comment := range comments {
+		hash := sha1.Sum([]byte(comment))
+		if sent.seenComments[hash] {
+			continue
+		}
+		sent.seenComments[hash] = true
+		unique = append(unique, comment)
+	}
+	return unique
+}
+
+// isFiltered returns true if any of CommentSentimentAnalysis.Filters matches the comment.
+func (sent *CommentSentimentAnalysis) isFiltered(comment string) bool {
+	for _, filter := range sent.filters {
+		if filter.MatchString(comment) {
+			return true
+		}
+	}
+	return false
+}
+
 func init() {
 	core.Registry.Register(&CommentSentimentAnalysis{})
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/bblfsh/client-go.v2"
 	"gopkg.in/bblfsh/client-go.v2/tools"
+	"gopkg.in/bblfsh/sdk.v1/uast"
 	"gopkg.in/src-d/go-git.v4/plumbing"
 	"gopkg.in/src-d/hercules.v4/internal/core"
 	"gopkg.in/src-d/hercules.v4/internal/pb"
@@ -25,6 +26,8 @@ func fixtureCommentSentiment() *CommentSentimentAnalysis {
 	sent := &CommentSentimentAnalysis{
 		Gap:              DefaultCommentSentimentGap,
 		MinCommentLength: DefaultCommentSentimentCommentMinLength,
+		LettersRatio:     CommentLettersRatio,
+		LicenseRegexp:    DefaultCommentSentimentLicenseRegexp,
 	}
 	facts := map[string]interface{}{
 		items.FactCommitsByDay: map[int][]plumbing.Hash{},
@@ -46,7 +49,11 @@ func TestCommentSentimentMeta(t *testing.T) {
 	matches := 0
 	for _, opt := range opts {
 		switch opt.Name {
-		case ConfigCommentSentimentMinLength, ConfigCommentSentimentGap:
+		case ConfigCommentSentimentMinLength, ConfigCommentSentimentGap,
+			ConfigCommentSentimentLettersRatio, ConfigCommentSentimentKeepDocstrings,
+			ConfigCommentSentimentKeepFunctionNames, ConfigCommentSentimentLicenseRegexp,
+			ConfigCommentSentimentFilters, ConfigCommentSentimentDeduplication,
+			ConfigCommentSentimentEmotions:
 			matches++
 		}
 	}
@@ -61,15 +68,92 @@ func TestCommentSentimentConfigure(t *testing.T) {
 	facts := map[string]interface{}{}
 	facts[ConfigCommentSentimentMinLength] = 77
 	facts[ConfigCommentSentimentGap] = float32(0.77)
+	facts[ConfigCommentSentimentLettersRatio] = float32(0.3)
+	facts[ConfigCommentSentimentKeepDocstrings] = true
+	facts[ConfigCommentSentimentKeepFunctionNames] = true
+	facts[ConfigCommentSentimentLicenseRegexp] = "SPDX"
+	facts[ConfigCommentSentimentFilters] = []string{"^TODO", "("}
+	facts[ConfigCommentSentimentDeduplication] = CommentSentimentDeduplicationFirst
+	facts[ConfigCommentSentimentEmotions] = LexiconEmotionClassifierName
 	facts[items.FactCommitsByDay] = map[int][]plumbing.Hash{}
 	sent.Configure(facts)
 	assert.Equal(t, sent.Gap, float32(0.77))
 	assert.Equal(t, sent.MinCommentLength, 77)
+	assert.Equal(t, sent.LettersRatio, float32(0.3))
+	assert.True(t, sent.KeepDocstrings)
+	assert.True(t, sent.KeepFunctionNames)
+	assert.Equal(t, sent.LicenseRegexp, "SPDX")
+	assert.Equal(t, sent.Filters, []string{"^TODO", "("})
+	assert.Len(t, sent.filters, 1)
+	assert.Equal(t, sent.Deduplication, CommentSentimentDeduplicationFirst)
+	assert.Equal(t, sent.Emotions, LexiconEmotionClassifierName)
+	assert.NotNil(t, sent.classifier)
 	facts[ConfigCommentSentimentMinLength] = -10
 	facts[ConfigCommentSentimentGap] = float32(2)
+	facts[ConfigCommentSentimentLettersRatio] = float32(-1)
+	facts[ConfigCommentSentimentLicenseRegexp] = "["
+	facts[ConfigCommentSentimentDeduplication] = "whatever"
+	facts[ConfigCommentSentimentEmotions] = "whatever"
 	sent.Configure(facts)
+	assert.Equal(t, sent.Emotions, "")
+	assert.Nil(t, sent.classifier)
+	assert.Equal(t, sent.Deduplication, CommentSentimentDeduplicationEvery)
 	assert.Equal(t, sent.Gap, DefaultCommentSentimentGap)
 	assert.Equal(t, sent.MinCommentLength, DefaultCommentSentimentCommentMinLength)
+	assert.Equal(t, sent.LettersRatio, float32(CommentLettersRatio))
+	assert.Equal(t, sent.LicenseRegexp, DefaultCommentSentimentLicenseRegexp)
+	facts[ConfigCommentSentimentLicenseRegexp] = ""
+	sent.Configure(facts)
+	assert.Nil(t, sent.licenseRE)
+}
+
+func TestCommentSentimentDeduplicate(t *testing.T) {
+	sent := fixtureCommentSentiment()
+	assert.Equal(t, sent.Deduplication, CommentSentimentDeduplicationEvery)
+	comments := []string{"this is the first comment", "this is the second comment"}
+	assert.Equal(t, sent.deduplicate(comments), comments)
+	assert.Equal(t, sent.deduplicate(comments), comments)
+	sent.Deduplication = CommentSentimentDeduplicationFirst
+	assert.Equal(t, sent.deduplicate([]string{comments[0], comments[0]}), comments[:1])
+	assert.Equal(t, sent.deduplicate([]string{comments[1], comments[0]}), comments[1:])
+	assert.Len(t, sent.deduplicate([]string{comments[1]}), 0)
+}
+
+func TestCommentSentimentMergeComments(t *testing.T) {
+	sent := CommentSentimentAnalysis{
+		Gap:              DefaultCommentSentimentGap,
+		MinCommentLength: DefaultCommentSentimentCommentMinLength,
+		LettersRatio:     CommentLettersRatio,
+		LicenseRegexp:    DefaultCommentSentimentLicenseRegexp,
+	}
+	sent.Configure(map[string]interface{}{items.FactCommitsByDay: map[int][]plumbing.Hash{}})
+	comment := func(line uint32, token string) *uast.Node {
+		return &uast.Node{Token: token, StartPosition: &uast.Position{Line: line},
+			EndPosition: &uast.Position{Line: line}}
+	}
+	nodes := []*uast.Node{
+		comment(1, "/**\n * Returns the cached value, which is never null.\n */"),
+		comment(10, "call normalize() before the cached value is read"),
+		comment(20, "Licensed under the Apache License, Version 2.0"),
+		comment(30, "TODO: this is broken and must be fixed soon"),
+		comment(40, "x = y + z * (a - b) / c; x = y + z * (a - b) / c"),
+	}
+	assert.Equal(t, sent.mergeComments(nodes), []string{
+		"call before the cached value is read",
+		"TODO: this is broken and must be fixed soon",
+	})
+	sent.KeepDocstrings = true
+	sent.KeepFunctionNames = true
+	sent.LettersRatio = 0
+	sent.LicenseRegexp = ""
+	sent.Filters = []string{"^TODO"}
+	sent.validate()
+	assert.Equal(t, sent.mergeComments(nodes), []string{
+		"Returns the cached value, which is never null.",
+		"call normalize() before the cached value is read",
+		"Licensed under the Apache License, Version 2.0",
+		"x = y + z * (a - b) / c; x = y + z * (a - b) / c",
+	})
 }
 
 func TestCommentSentimentRegistration(t *testing.T) {
@@ -100,6 +184,16 @@ func TestCommentSentimentSerializeText(t *testing.T) {
 	buffer := &bytes.Buffer{}
 	sent.Serialize(result, false, buffer)
 	assert.Equal(t, buffer.String(), "  9: [0.5000, [4f7c7a154638a0f2468276c56188d90c9cef0dfc], \"test|hello\"]\n")
+	result.CommentsByDay[9] = []string{"say \"hi\"", "C:\\"}
+	buffer = &bytes.Buffer{}
+	sent.Serialize(result, false, buffer)
+	assert.Equal(t, buffer.String(), "  9: [0.5000, [4f7c7a154638a0f2468276c56188d90c9cef0dfc], \"say \\\"hi\\\"|C:\\\\\"]\n")
+	result.CategoriesByDay = map[int]map[string]float32{9: emotionDistribution(
+		[]string{EmotionJoy, EmotionNeutral})}
+	buffer = &bytes.Buffer{}
+	sent.Serialize(result, false, buffer)
+	assert.Equal(t, buffer.String(), "  9: [0.5000, [4f7c7a154638a0f2468276c56188d90c9cef0dfc], \"say \\\"hi\\\"|C:\\\\\", "+
+		"{anger: 0.0000, joy: 0.5000, fear: 0.0000, neutral: 0.5000}]\n")
 }
 
 func TestCommentSentimentSerializeBinary(t *testing.T) {
@@ -120,6 +214,37 @@ func TestCommentSentimentSerializeBinary(t *testing.T) {
 	assert.Equal(t, msg.SentimentByDay[int32(9)].Commits, []string{"4f7c7a154638a0f2468276c56188d90c9cef0dfc"})
 	assert.Equal(t, msg.SentimentByDay[int32(9)].Comments, []string{"test", "hello"})
 	assert.Equal(t, msg.SentimentByDay[int32(9)].Value, float32(0.5))
+	assert.Len(t, msg.SentimentByDay[int32(9)].Emotions, 0)
+	result.CategoriesByDay = map[int]map[string]float32{9: emotionDistribution(
+		[]string{EmotionJoy, EmotionNeutral})}
+	buffer = &bytes.Buffer{}
+	sent.Serialize(result, true, buffer)
+	msg = pb.CommentSentimentResults{}
+	proto.Unmarshal(buffer.Bytes(), &msg)
+	assert.Equal(t, msg.SentimentByDay[int32(9)].Emotions, map[string]float32{
+		EmotionAnger: 0, EmotionJoy: 0.5, EmotionFear: 0, EmotionNeutral: 0.5})
+}
+
+func TestCommentSentimentClassifyEmotions(t *testing.T) {
+	sent := fixtureCommentSentiment()
+	sent.Emotions = LexiconEmotionClassifierName
+	sent.validate()
+	result := CommentSentimentResult{
+		CommentsByDay: map[int][]string{
+			1: {"this is a stupid hack", "the cache works great"},
+			0: {"careful, the order is fragile"},
+		},
+		CategoriesByDay: map[int]map[string]float32{},
+	}
+	sent.classifyEmotions(&result)
+	assert.Equal(t, result.CategoriesByDay, map[int]map[string]float32{
+		0: {EmotionAnger: 0, EmotionJoy: 0, EmotionFear: 1, EmotionNeutral: 0},
+		1: {EmotionAnger: 0.5, EmotionJoy: 0.5, EmotionFear: 0, EmotionNeutral: 0},
+	})
+	sent.classifier = failingEmotionClassifier{}
+	result.CategoriesByDay = map[int]map[string]float32{}
+	sent.classifyEmotions(&result)
+	assert.Len(t, result.CategoriesByDay, 0)
 }
 
 func TestCommentSentimentFinalize(t *testing.T) {
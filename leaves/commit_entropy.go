@@ -0,0 +1,296 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"path"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+// CommitEntropyAnalysis measures how scattered the edits of each commit are across
+// the directories: the Shannon entropy of the distribution of the changed lines over the parent
+// directories of the changed files. The scattered commits are known to correlate with defects.
+// It is a LeafPipelineItem.
+// Reference: Hassan, A. E. "Predicting faults using the complexity of code changes", ICSE 2009.
+type CommitEntropyAnalysis struct {
+	// ScatteredEntropy is the entropy in bits starting from which a commit is scattered.
+	ScatteredEntropy float32
+
+	// commits are the entropies of the commits processed so far, in order.
+	commits []CommitEntropy
+	// days maps the day index to the aggregated entropies of the commits on that day.
+	days map[int]CommitEntropyStats
+}
+
+// CommitEntropy is the change entropy of a single commit.
+type CommitEntropy struct {
+	// Commit is the hash of the described commit.
+	Commit plumbing.Hash
+	// Day is the number of days since the beginning of the analysed history.
+	Day int
+	// Files is the number of changed files.
+	Files int
+	// Directories is the number of the distinct parent directories of the changed files.
+	Directories int
+	// Lines is the number of added, removed and changed lines. A binary file counts as one line.
+	Lines int
+	// Entropy is the Shannon entropy in bits of the changed lines over the directories.
+	Entropy float64
+	// NormalizedEntropy is Entropy divided by the maximum possible entropy with the same number
+	// of directories, so it is between 0 and 1.
+	NormalizedEntropy float64
+}
+
+// CommitEntropyStats are the change entropies of the commits in a day.
+type CommitEntropyStats struct {
+	// Commits is the number of commits which changed any files.
+	Commits int
+	// Entropy is the sum of the entropies of the commits.
+	Entropy float64
+	// Scattered is the number of commits with the entropy not less than ScatteredEntropy.
+	Scattered int
+}
+
+// MeanEntropy returns the average entropy of the commits.
+func (stats CommitEntropyStats) MeanEntropy() float64 {
+	if stats.Commits == 0 {
+		return 0
+	}
+	return stats.Entropy / float64(stats.Commits)
+}
+
+// CommitEntropyResult is returned by CommitEntropyAnalysis.Finalize() and carries the change
+// entropies of all the analysed commits in the chronological order and the daily trend.
+type CommitEntropyResult struct {
+	Commits []CommitEntropy
+	// Days maps the day index to the aggregated entropies of the commits on that day.
+	Days map[int]CommitEntropyStats
+}
+
+const (
+	// ConfigCommitEntropyScatteredEntropy is the name of the option to set
+	// CommitEntropyAnalysis.ScatteredEntropy.
+	ConfigCommitEntropyScatteredEntropy = "CommitEntropy.ScatteredEntropy"
+	// DefaultCommitEntropyScatteredEntropy is the default value of
+	// CommitEntropyAnalysis.ScatteredEntropy: the edits spread evenly over four directories.
+	DefaultCommitEntropyScatteredEntropy = float32(2)
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (ce *CommitEntropyAnalysis) Name() string {
+	return "CommitEntropy"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (ce *CommitEntropyAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (ce *CommitEntropyAnalysis) Requires() []string {
+	arr := [...]string{
+		items.DependencyTreeChanges, items.DependencyFileDiff, items.DependencyBlobCache,
+		items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (ce *CommitEntropyAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigCommitEntropyScatteredEntropy,
+		Description: "Change entropy in bits starting from which a commit is scattered.",
+		Flag:        "entropy-scattered",
+		Type:        core.FloatConfigurationOption,
+		Default:     DefaultCommitEntropyScatteredEntropy},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (ce *CommitEntropyAnalysis) Flag() string {
+	return "commit-entropy"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (ce *CommitEntropyAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigCommitEntropyScatteredEntropy].(float32); exists {
+		ce.ScatteredEntropy = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (ce *CommitEntropyAnalysis) Initialize(repository *git.Repository) {
+	if ce.ScatteredEntropy <= 0 {
+		log.Printf("Warning: adjusted the scattered commit entropy to %v\n",
+			DefaultCommitEntropyScatteredEntropy)
+		ce.ScatteredEntropy = DefaultCommitEntropyScatteredEntropy
+	}
+	ce.commits = []CommitEntropy{}
+	ce.days = map[int]CommitEntropyStats{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (ce *CommitEntropyAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	day := deps[items.DependencyDay].(int)
+	if len(treeDiff) == 0 {
+		return nil, nil
+	}
+	dirs := map[string]int{}
+	record := CommitEntropy{Commit: commit.Hash, Day: day, Files: len(treeDiff)}
+	for _, change := range treeDiff {
+		name, lines, err := changedLines(change, cache, fileDiffs)
+		if err != nil {
+			return nil, err
+		}
+		dirs[path.Dir(name)] += lines
+		record.Lines += lines
+	}
+	record.Directories = len(dirs)
+	record.Entropy = entropy(dirs)
+	if record.Directories > 1 {
+		record.NormalizedEntropy = record.Entropy / math.Log2(float64(record.Directories))
+	}
+	ce.commits = append(ce.commits, record)
+	stats := ce.days[day]
+	stats.Commits++
+	stats.Entropy += record.Entropy
+	if record.Entropy >= float64(ce.ScatteredEntropy) {
+		stats.Scattered++
+	}
+	ce.days[day] = stats
+	return nil, nil
+}
+
+// changedLines returns the name of the changed file and the number of added, removed and changed
+// lines in it. A binary file, an empty file or a mode change count as one line.
+func changedLines(change *object.Change, cache map[plumbing.Hash]*object.Blob,
+	fileDiffs map[string]items.FileDiffData) (string, int, error) {
+	action, err := change.Action()
+	if err != nil {
+		return "", 0, err
+	}
+	var name string
+	var lines int
+	switch action {
+	case merkletrie.Insert:
+		name = change.To.Name
+		lines, err = items.CountLines(cache[change.To.TreeEntry.Hash])
+	case merkletrie.Delete:
+		name = change.From.Name
+		lines, err = items.CountLines(cache[change.From.TreeEntry.Hash])
+	case merkletrie.Modify:
+		name = change.To.Name
+		stats := diffLineStats(fileDiffs[name].Diffs)
+		lines = stats.Added + stats.Removed + stats.Changed
+	}
+	if err != nil {
+		if err.Error() != "binary" {
+			return "", 0, err
+		}
+		lines = 1
+	}
+	if lines == 0 {
+		lines = 1
+	}
+	return name, lines, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (ce *CommitEntropyAnalysis) Finalize() interface{} {
+	return CommitEntropyResult{Commits: ce.commits, Days: ce.days}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (ce *CommitEntropyAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	entropyResult := result.(CommitEntropyResult)
+	if binary {
+		return ce.serializeBinary(&entropyResult, writer)
+	}
+	ce.serializeText(&entropyResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (ce *CommitEntropyAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(ce, result, &pb.CommitEntropyResults{}, writer)
+}
+
+func (ce *CommitEntropyAnalysis) serializeText(result *CommitEntropyResult, writer io.Writer) {
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	fmt.Fprintln(writer, "  days:  # commits, mean entropy, scattered commits")
+	for _, day := range days {
+		stats := result.Days[day]
+		fmt.Fprintf(writer, "    %d: [%d, %.4f, %d]\n",
+			day, stats.Commits, stats.MeanEntropy(), stats.Scattered)
+	}
+	fmt.Fprintln(writer, "  commits:  # day, files, directories, lines, entropy, normalized entropy")
+	for _, record := range result.Commits {
+		fmt.Fprintf(writer, "    %s: [%d, %d, %d, %d, %.4f, %.4f]\n", record.Commit.String(),
+			record.Day, record.Files, record.Directories, record.Lines, record.Entropy,
+			record.NormalizedEntropy)
+	}
+}
+
+func (ce *CommitEntropyAnalysis) serializeBinary(result *CommitEntropyResult, writer io.Writer) error {
+	message := pb.CommitEntropyResults{
+		Commits: make([]*pb.CommitEntropy, len(result.Commits)),
+		Days:    map[int32]*pb.CommitEntropyStats{},
+	}
+	for i, record := range result.Commits {
+		message.Commits[i] = &pb.CommitEntropy{
+			Commit:            record.Commit.String(),
+			Day:               int32(record.Day),
+			Files:             int32(record.Files),
+			Directories:       int32(record.Directories),
+			Lines:             int32(record.Lines),
+			Entropy:           float32(record.Entropy),
+			NormalizedEntropy: float32(record.NormalizedEntropy),
+		}
+	}
+	for day, stats := range result.Days {
+		message.Days[int32(day)] = &pb.CommitEntropyStats{
+			Commits:     int32(stats.Commits),
+			MeanEntropy: float32(stats.MeanEntropy()),
+			Scattered:   int32(stats.Scattered),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&CommitEntropyAnalysis{})
+}
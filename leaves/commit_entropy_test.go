@@ -0,0 +1,171 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureCommitEntropy() *CommitEntropyAnalysis {
+	ce := CommitEntropyAnalysis{ScatteredEntropy: 1}
+	ce.Initialize(test.Repository)
+	return &ce
+}
+
+// fixtureCommitEntropyDeps modifies the files in three directories: "a" with 3+1 changed lines,
+// "b" with 1 and "c" with 4.
+func fixtureCommitEntropyDeps() map[string]interface{} {
+	deps := map[string]interface{}{}
+	deps[items.DependencyBlobCache] = map[plumbing.Hash]*object.Blob{}
+	modify := func(name string) *object.Change {
+		return &object.Change{
+			From: object.ChangeEntry{Name: name}, To: object.ChangeEntry{Name: name}}
+	}
+	deps[items.DependencyTreeChanges] = object.Changes{
+		modify("a/x.go"), modify("a/z.png"), modify("b/y.go"), modify("c/w.go")}
+	deps[items.DependencyFileDiff] = map[string]items.FileDiffData{
+		"a/x.go": {Diffs: []diffmatchpatch.Diff{
+			{Type: diffmatchpatch.DiffEqual, Text: "ab"},
+			{Type: diffmatchpatch.DiffDelete, Text: "c"},
+			{Type: diffmatchpatch.DiffInsert, Text: "def"},
+		}},
+		"b/y.go": {Diffs: []diffmatchpatch.Diff{{Type: diffmatchpatch.DiffInsert, Text: "z"}}},
+		"c/w.go": {Diffs: []diffmatchpatch.Diff{{Type: diffmatchpatch.DiffDelete, Text: "wxyz"}}},
+	}
+	deps[items.DependencyDay] = 2
+	deps["commit"] = &object.Commit{
+		Hash: plumbing.NewHash("2b1ed978194a94edeabbca6de7ff3b5771d4d665")}
+	return deps
+}
+
+func TestCommitEntropyMeta(t *testing.T) {
+	ce := fixtureCommitEntropy()
+	assert.Equal(t, ce.Name(), "CommitEntropy")
+	assert.Len(t, ce.Provides(), 0)
+	required := [...]string{
+		items.DependencyTreeChanges, items.DependencyFileDiff, items.DependencyBlobCache,
+		items.DependencyDay}
+	for _, name := range required {
+		assert.Contains(t, ce.Requires(), name)
+	}
+	opts := ce.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigCommitEntropyScatteredEntropy)
+	assert.Equal(t, ce.Flag(), "commit-entropy")
+}
+
+func TestCommitEntropyConfigure(t *testing.T) {
+	ce := CommitEntropyAnalysis{}
+	ce.Configure(map[string]interface{}{ConfigCommitEntropyScatteredEntropy: float32(1.5)})
+	assert.Equal(t, ce.ScatteredEntropy, float32(1.5))
+	ce.Configure(map[string]interface{}{})
+	assert.Equal(t, ce.ScatteredEntropy, float32(1.5))
+	ce.ScatteredEntropy = -1
+	ce.Initialize(test.Repository)
+	assert.Equal(t, ce.ScatteredEntropy, DefaultCommitEntropyScatteredEntropy)
+}
+
+func TestCommitEntropyRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&CommitEntropyAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "CommitEntropy")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&CommitEntropyAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestCommitEntropyConsumeModify(t *testing.T) {
+	ce := fixtureCommitEntropy()
+	deps := fixtureCommitEntropyDeps()
+	result, err := ce.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	// a single file in a single directory
+	deps[items.DependencyTreeChanges] = deps[items.DependencyTreeChanges].(object.Changes)[:1]
+	ce.Consume(deps)
+	// no changes are ignored
+	deps[items.DependencyTreeChanges] = object.Changes{}
+	ce.Consume(deps)
+	res := ce.Finalize().(CommitEntropyResult)
+	assert.Len(t, res.Commits, 2)
+	record := res.Commits[0]
+	assert.Equal(t, record.Commit.String(), "2b1ed978194a94edeabbca6de7ff3b5771d4d665")
+	assert.Equal(t, record.Day, 2)
+	assert.Equal(t, record.Files, 4)
+	assert.Equal(t, record.Directories, 3)
+	assert.Equal(t, record.Lines, 9)
+	assert.InDelta(t, record.Entropy, 1.3921, 0.0001)
+	assert.InDelta(t, record.NormalizedEntropy, 0.8783, 0.0001)
+	record = res.Commits[1]
+	assert.Equal(t, record.Files, 1)
+	assert.Equal(t, record.Directories, 1)
+	assert.Equal(t, record.Lines, 3)
+	assert.Equal(t, record.Entropy, float64(0))
+	assert.Equal(t, record.NormalizedEntropy, float64(0))
+	assert.Len(t, res.Days, 1)
+	assert.Equal(t, res.Days[2].Commits, 2)
+	assert.Equal(t, res.Days[2].Scattered, 1)
+	assert.InDelta(t, res.Days[2].MeanEntropy(), 0.6961, 0.0001)
+	assert.Equal(t, CommitEntropyStats{}.MeanEntropy(), float64(0))
+}
+
+func TestCommitEntropyConsumeInsert(t *testing.T) {
+	ce := fixtureCommitEntropy()
+	result, err := ce.Consume(fixtureCommitFeaturesDeps())
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	res := ce.Finalize().(CommitEntropyResult)
+	assert.Len(t, res.Commits, 1)
+	record := res.Commits[0]
+	assert.Equal(t, record.Files, 2)
+	assert.Equal(t, record.Directories, 2)
+	assert.Equal(t, record.Lines, 207+12)
+	assert.InDelta(t, record.Entropy, 0.3064, 0.0001)
+	assert.Equal(t, res.Days[7].Scattered, 0)
+}
+
+func TestCommitEntropySerializeText(t *testing.T) {
+	ce := fixtureCommitEntropy()
+	ce.Consume(fixtureCommitEntropyDeps())
+	res := ce.Finalize().(CommitEntropyResult)
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, ce.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  days:  # commits, mean entropy, scattered commits
+    2: [1, 1.3921, 1]
+  commits:  # day, files, directories, lines, entropy, normalized entropy
+    2b1ed978194a94edeabbca6de7ff3b5771d4d665: [2, 4, 3, 9, 1.3921, 0.8783]
+`)
+}
+
+func TestCommitEntropySerializeBinary(t *testing.T) {
+	ce := fixtureCommitEntropy()
+	ce.Consume(fixtureCommitEntropyDeps())
+	res := ce.Finalize().(CommitEntropyResult)
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, ce.Serialize(res, true, buffer))
+	msg := pb.CommitEntropyResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Commits, 1)
+	assert.Equal(t, msg.Commits[0].Commit, "2b1ed978194a94edeabbca6de7ff3b5771d4d665")
+	assert.Equal(t, msg.Commits[0].Lines, int32(9))
+	assert.InDelta(t, msg.Commits[0].Entropy, 1.3921, 0.0001)
+	assert.Len(t, msg.Days, 1)
+	assert.Equal(t, msg.Days[2].Commits, int32(1))
+	assert.InDelta(t, msg.Days[2].MeanEntropy, 1.3921, 0.0001)
+	assert.Equal(t, msg.Days[2].Scattered, int32(1))
+}
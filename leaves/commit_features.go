@@ -0,0 +1,379 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+)
+
+// CommitFeaturesAnalysis extracts a fixed-size numeric feature vector from every commit.
+// The vectors are suitable for training defect prediction models and the like.
+// The UAST node deltas are calculated only if the UAST feature is enabled, so that the plain
+// runs do not need Babelfish.
+// It is a LeafPipelineItem and an OptionallyFeaturedPipelineItem.
+type CommitFeaturesAnalysis struct {
+	// CSVPath is the file to write the plain CSV table to. Empty means no file is written.
+	CSVPath string
+
+	// uast is true if the UAST feature is enabled, see EnableFeatures().
+	uast bool
+	// vectors are the features of the commits processed so far, in order.
+	vectors []CommitFeatures
+	// experience is the number of commits each author has made so far.
+	experience map[int]int
+}
+
+// CommitFeatures is the feature vector of a single commit.
+type CommitFeatures struct {
+	// Commit is the hash of the described commit.
+	Commit plumbing.Hash
+	// Day is the number of days since the beginning of the analysed history.
+	Day int
+	// Hour is the hour of the day in the author's time zone.
+	Hour int
+	// Files is the number of changed files.
+	Files int
+	// Added is the number of inserted lines.
+	Added int
+	// Removed is the number of deleted lines.
+	Removed int
+	// DirEntropy is the Shannon entropy (in bits) of the distribution of the changed
+	// files over their parent directories. 0 means that all the files are in the same directory.
+	DirEntropy float64
+	// AuthorExperience is the number of commits which the author made before this one.
+	AuthorExperience int
+	// MessageLength is the number of characters in the commit message.
+	MessageLength int
+	// MessageLines is the number of lines in the commit message.
+	MessageLines int
+	// UASTNodesAdded is the number of the UAST nodes which appeared in the changed files.
+	// It is always 0 if the UAST feature is disabled.
+	UASTNodesAdded int
+	// UASTNodesRemoved is the number of the UAST nodes which disappeared from the changed files.
+	// It is always 0 if the UAST feature is disabled.
+	UASTNodesRemoved int
+}
+
+// CommitFeaturesResult is returned by CommitFeaturesAnalysis.Finalize() and carries
+// the feature vectors of all the analysed commits in the chronological order.
+type CommitFeaturesResult struct {
+	Commits []CommitFeatures
+}
+
+const (
+	// ConfigCommitFeaturesCSVPath is the name of the configuration option
+	// (CommitFeaturesAnalysis.Configure()) which sets the file to write the plain CSV table to.
+	ConfigCommitFeaturesCSVPath = "CommitFeatures.CSVPath"
+)
+
+// commitFeaturesColumns is the CSV header which corresponds to the fields of CommitFeatures.
+var commitFeaturesColumns = []string{
+	"commit", "day", "hour", "files", "added", "removed", "dir_entropy",
+	"author_experience", "message_length", "message_lines", "uast_nodes_added", "uast_nodes_removed",
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (features *CommitFeaturesAnalysis) Name() string {
+	return "CommitFeatures"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (features *CommitFeaturesAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (features *CommitFeaturesAnalysis) Requires() []string {
+	arr := []string{
+		items.DependencyFileDiff, items.DependencyTreeChanges, items.DependencyBlobCache,
+		items.DependencyDay, identity.DependencyAuthor}
+	if features.uast {
+		arr = append(arr, uast_items.DependencyUastChanges)
+	}
+	return arr
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+// It is empty unless the UAST feature was enabled with EnableFeatures().
+func (features *CommitFeaturesAnalysis) Features() []string {
+	if features.uast {
+		return []string{uast_items.FeatureUast}
+	}
+	return []string{}
+}
+
+// OptionalFeatures returns the features which add the UAST node deltas to the vectors.
+func (features *CommitFeaturesAnalysis) OptionalFeatures() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// EnableFeatures turns on the UAST node deltas if the UAST feature is among `enabled`.
+func (features *CommitFeaturesAnalysis) EnableFeatures(enabled []string) {
+	features.uast = false
+	for _, feature := range enabled {
+		if feature == uast_items.FeatureUast {
+			features.uast = true
+		}
+	}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (features *CommitFeaturesAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigCommitFeaturesCSVPath,
+		Description: "Additionally write the feature vectors to this file as a plain CSV table.",
+		Flag:        "commit-features-csv",
+		Type:        core.StringConfigurationOption,
+		Default:     ""},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (features *CommitFeaturesAnalysis) Flag() string {
+	return "commit-features"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (features *CommitFeaturesAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigCommitFeaturesCSVPath]; exists {
+		features.CSVPath = val.(string)
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (features *CommitFeaturesAnalysis) Initialize(repository *git.Repository) {
+	features.vectors = []CommitFeatures{}
+	features.experience = map[int]int{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (features *CommitFeaturesAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	author := deps[identity.DependencyAuthor].(int)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	vector := CommitFeatures{
+		Commit:           commit.Hash,
+		Day:              deps[items.DependencyDay].(int),
+		Hour:             commit.Author.When.Hour(),
+		Files:            len(treeDiff),
+		AuthorExperience: features.experience[author],
+	}
+	features.experience[author]++
+	dirs := map[string]int{}
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			dirs[path.Dir(change.To.Name)]++
+			lines, err := items.CountLines(cache[change.To.TreeEntry.Hash])
+			if err != nil {
+				if err.Error() == "binary" {
+					continue
+				}
+				return nil, err
+			}
+			vector.Added += lines
+		case merkletrie.Delete:
+			dirs[path.Dir(change.From.Name)]++
+			lines, err := items.CountLines(cache[change.From.TreeEntry.Hash])
+			if err != nil {
+				if err.Error() == "binary" {
+					continue
+				}
+				return nil, err
+			}
+			vector.Removed += lines
+		case merkletrie.Modify:
+			dirs[path.Dir(change.To.Name)]++
+			for _, edit := range fileDiffs[change.To.Name].Diffs {
+				switch edit.Type {
+				case diffmatchpatch.DiffInsert:
+					vector.Added += utf8.RuneCountInString(edit.Text)
+				case diffmatchpatch.DiffDelete:
+					vector.Removed += utf8.RuneCountInString(edit.Text)
+				}
+			}
+		}
+	}
+	vector.DirEntropy = entropy(dirs)
+	if features.uast {
+		for _, change := range deps[uast_items.DependencyUastChanges].([]uast_items.Change) {
+			added, removed := uastNodeDeltas(change.Before, change.After)
+			vector.UASTNodesAdded += added
+			vector.UASTNodesRemoved += removed
+		}
+	}
+	message := strings.TrimSpace(commit.Message)
+	vector.MessageLength = utf8.RuneCountInString(message)
+	if message != "" {
+		vector.MessageLines = strings.Count(message, "\n") + 1
+	}
+	features.vectors = append(features.vectors, vector)
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (features *CommitFeaturesAnalysis) Finalize() interface{} {
+	return CommitFeaturesResult{Commits: features.vectors}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML with the embedded CSV table and the bytes format is Protocol Buffers.
+// The plain CSV table is also written to CSVPath if it is set.
+func (features *CommitFeaturesAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	featuresResult := result.(CommitFeaturesResult)
+	if features.CSVPath != "" {
+		if err := features.dumpCSV(&featuresResult); err != nil {
+			return err
+		}
+	}
+	if binary {
+		return features.serializeBinary(&featuresResult, writer)
+	}
+	features.serializeText(&featuresResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (features *CommitFeaturesAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(features, result, &pb.CommitFeaturesResults{}, writer)
+}
+
+func (features *CommitFeaturesAnalysis) serializeText(result *CommitFeaturesResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  csv: |-")
+	writeCommitFeaturesCSV(result, "    ", writer)
+}
+
+// dumpCSV writes the plain CSV table to CSVPath.
+func (features *CommitFeaturesAnalysis) dumpCSV(result *CommitFeaturesResult) error {
+	file, err := os.Create(features.CSVPath)
+	if err != nil {
+		return err
+	}
+	writeCommitFeaturesCSV(result, "", file)
+	return file.Close()
+}
+
+// writeCommitFeaturesCSV writes the header and one row per commit, each line is prefixed
+// with `indent`.
+func writeCommitFeaturesCSV(result *CommitFeaturesResult, indent string, writer io.Writer) {
+	fmt.Fprintln(writer, indent+strings.Join(commitFeaturesColumns, ","))
+	for _, vector := range result.Commits {
+		fmt.Fprintf(writer, "%s%s,%d,%d,%d,%d,%d,%.4f,%d,%d,%d,%d,%d\n", indent,
+			vector.Commit.String(), vector.Day, vector.Hour, vector.Files, vector.Added,
+			vector.Removed, vector.DirEntropy, vector.AuthorExperience, vector.MessageLength,
+			vector.MessageLines, vector.UASTNodesAdded, vector.UASTNodesRemoved)
+	}
+}
+
+func (features *CommitFeaturesAnalysis) serializeBinary(result *CommitFeaturesResult, writer io.Writer) error {
+	message := pb.CommitFeaturesResults{
+		Commits: make([]*pb.CommitFeatures, len(result.Commits)),
+	}
+	for i, vector := range result.Commits {
+		message.Commits[i] = &pb.CommitFeatures{
+			Commit:           vector.Commit.String(),
+			Day:              int32(vector.Day),
+			Hour:             int32(vector.Hour),
+			Files:            int32(vector.Files),
+			Added:            int32(vector.Added),
+			Removed:          int32(vector.Removed),
+			DirEntropy:       float32(vector.DirEntropy),
+			AuthorExperience: int32(vector.AuthorExperience),
+			MessageLength:    int32(vector.MessageLength),
+			MessageLines:     int32(vector.MessageLines),
+			UastNodesAdded:   int32(vector.UASTNodesAdded),
+			UastNodesRemoved: int32(vector.UASTNodesRemoved),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+// uastNodeDeltas returns the numbers of the UAST nodes which were added and removed between
+// the two versions of a file. The nodes are matched by their internal types and tokens
+// regardless of the positions, so that moving the code around does not count.
+// Either of the trees may be nil.
+func uastNodeDeltas(before, after *uast.Node) (added int, removed int) {
+	counts := map[string]int{}
+	key := func(node *uast.Node) string {
+		return node.InternalType + "\x00" + node.Token
+	}
+	if before != nil {
+		uast_items.VisitEachNode(before, func(node *uast.Node) {
+			counts[key(node)]--
+		})
+	}
+	if after != nil {
+		uast_items.VisitEachNode(after, func(node *uast.Node) {
+			counts[key(node)]++
+		})
+	}
+	for _, delta := range counts {
+		if delta > 0 {
+			added += delta
+		} else {
+			removed -= delta
+		}
+	}
+	return added, removed
+}
+
+// entropy calculates the Shannon entropy in bits of the discrete distribution
+// given by the absolute frequencies.
+func entropy(counts map[string]int) float64 {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	var result float64
+	for _, count := range counts {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / float64(total)
+		result -= p * math.Log2(p)
+	}
+	return result
+}
+
+func init() {
+	core.Registry.Register(&CommitFeaturesAnalysis{})
+}
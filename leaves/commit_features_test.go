@@ -0,0 +1,253 @@
+package leaves
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureCommitFeatures() *CommitFeaturesAnalysis {
+	cf := CommitFeaturesAnalysis{}
+	cf.Initialize(test.Repository)
+	return &cf
+}
+
+func TestCommitFeaturesMeta(t *testing.T) {
+	cf := fixtureCommitFeatures()
+	assert.Equal(t, cf.Name(), "CommitFeatures")
+	assert.Equal(t, len(cf.Provides()), 0)
+	required := [...]string{
+		items.DependencyFileDiff, items.DependencyTreeChanges, items.DependencyBlobCache,
+		items.DependencyDay, identity.DependencyAuthor}
+	for _, name := range required {
+		assert.Contains(t, cf.Requires(), name)
+	}
+	assert.NotContains(t, cf.Requires(), uast_items.DependencyUastChanges)
+	assert.Len(t, cf.Features(), 0)
+	assert.Equal(t, cf.OptionalFeatures(), []string{uast_items.FeatureUast})
+	cf.EnableFeatures([]string{"other", uast_items.FeatureUast})
+	assert.Contains(t, cf.Requires(), uast_items.DependencyUastChanges)
+	assert.Equal(t, cf.Features(), []string{uast_items.FeatureUast})
+	cf.EnableFeatures(nil)
+	assert.NotContains(t, cf.Requires(), uast_items.DependencyUastChanges)
+	assert.Len(t, cf.Features(), 0)
+	opts := cf.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigCommitFeaturesCSVPath)
+	assert.Equal(t, opts[0].Flag, "commit-features-csv")
+	assert.Equal(t, cf.Flag(), "commit-features")
+	cf.Configure(nil)
+	assert.Equal(t, cf.CSVPath, "")
+	cf.Configure(map[string]interface{}{ConfigCommitFeaturesCSVPath: "features.csv"})
+	assert.Equal(t, cf.CSVPath, "features.csv")
+}
+
+func TestCommitFeaturesDeploy(t *testing.T) {
+	pipeline := core.NewPipeline(test.Repository)
+	cf := pipeline.DeployItem(&CommitFeaturesAnalysis{}).(*CommitFeaturesAnalysis)
+	assert.False(t, cf.uast)
+	pipeline = core.NewPipeline(test.Repository)
+	pipeline.SetFeature(uast_items.FeatureUast)
+	cf = pipeline.DeployItem(&CommitFeaturesAnalysis{}).(*CommitFeaturesAnalysis)
+	assert.True(t, cf.uast)
+}
+
+func TestCommitFeaturesRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&CommitFeaturesAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "CommitFeatures")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&CommitFeaturesAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func fixtureCommitFeaturesDeps() map[string]interface{} {
+	deps := map[string]interface{}{}
+	cache := map[plumbing.Hash]*object.Blob{}
+	hash := plumbing.NewHash("291286b4ac41952cbd1389fda66420ec03c1a9fe")
+	cache[hash], _ = test.Repository.BlobObject(hash)
+	hash = plumbing.NewHash("c29112dbd697ad9b401333b80c18a63951bc18d9")
+	cache[hash], _ = test.Repository.BlobObject(hash)
+	deps[items.DependencyBlobCache] = cache
+	changes := make(object.Changes, 2)
+	treeTo, _ := test.Repository.TreeObject(plumbing.NewHash(
+		"994eac1cd07235bb9815e547a75c84265dea00f5"))
+	changes[0] = &object.Change{From: object.ChangeEntry{}, To: object.ChangeEntry{
+		Name: "cmd/hercules/main.go",
+		Tree: treeTo,
+		TreeEntry: object.TreeEntry{
+			Name: "cmd/hercules/main.go",
+			Mode: 0100644,
+			Hash: plumbing.NewHash("c29112dbd697ad9b401333b80c18a63951bc18d9"),
+		},
+	},
+	}
+	changes[1] = &object.Change{From: object.ChangeEntry{}, To: object.ChangeEntry{
+		Name: ".travis.yml",
+		Tree: treeTo,
+		TreeEntry: object.TreeEntry{
+			Name: ".travis.yml",
+			Mode: 0100644,
+			Hash: plumbing.NewHash("291286b4ac41952cbd1389fda66420ec03c1a9fe"),
+		},
+	},
+	}
+	deps[items.DependencyTreeChanges] = changes
+	deps[items.DependencyFileDiff] = map[string]items.FileDiffData{}
+	deps[items.DependencyDay] = 7
+	deps[identity.DependencyAuthor] = 0
+	commit, _ := test.Repository.CommitObject(plumbing.NewHash(
+		"2b1ed978194a94edeabbca6de7ff3b5771d4d665"))
+	deps["commit"] = commit
+	return deps
+}
+
+func TestCommitFeaturesConsumeFinalize(t *testing.T) {
+	cf := fixtureCommitFeatures()
+	deps := fixtureCommitFeaturesDeps()
+	result, err := cf.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	result, err = cf.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	res := cf.Finalize().(CommitFeaturesResult)
+	assert.Len(t, res.Commits, 2)
+	commit := deps["commit"].(*object.Commit)
+	vector := res.Commits[0]
+	assert.Equal(t, vector.Commit, commit.Hash)
+	assert.Equal(t, vector.Day, 7)
+	assert.Equal(t, vector.Hour, commit.Author.When.Hour())
+	assert.Equal(t, vector.Files, 2)
+	assert.Equal(t, vector.Added, 207+12)
+	assert.Equal(t, vector.Removed, 0)
+	assert.InDelta(t, vector.DirEntropy, 1, 0.0001)
+	assert.Equal(t, vector.AuthorExperience, 0)
+	assert.True(t, vector.MessageLength > 0)
+	assert.True(t, vector.MessageLines > 0)
+	assert.Equal(t, res.Commits[1].AuthorExperience, 1)
+	assert.Equal(t, vector.UASTNodesAdded, 0)
+	assert.Equal(t, vector.UASTNodesRemoved, 0)
+}
+
+func TestCommitFeaturesConsumeUAST(t *testing.T) {
+	cf := fixtureCommitFeatures()
+	cf.EnableFeatures([]string{uast_items.FeatureUast})
+	ident := func(token string) *uast.Node {
+		return &uast.Node{InternalType: "Ident", Token: token}
+	}
+	file := func(children ...*uast.Node) *uast.Node {
+		return &uast.Node{InternalType: "File", Children: children}
+	}
+	deps := map[string]interface{}{
+		"commit":                    &object.Commit{Message: "UAST"},
+		identity.DependencyAuthor:   0,
+		items.DependencyDay:         0,
+		items.DependencyBlobCache:   map[plumbing.Hash]*object.Blob{},
+		items.DependencyTreeChanges: object.Changes{},
+		items.DependencyFileDiff:    map[string]items.FileDiffData{},
+	}
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: file(ident("a"), ident("b")), After: file(ident("c"), ident("a"), ident("d"))},
+		{After: file(ident("x"))},
+		{Before: file(ident("y"))},
+	}
+	result, err := cf.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	vector := cf.Finalize().(CommitFeaturesResult).Commits[0]
+	assert.Equal(t, vector.UASTNodesAdded, 2+2)
+	assert.Equal(t, vector.UASTNodesRemoved, 1+2)
+	assert.Equal(t, vector.Added, 0)
+}
+
+func TestCommitFeaturesSerializeText(t *testing.T) {
+	cf := fixtureCommitFeatures()
+	cf.Consume(fixtureCommitFeaturesDeps())
+	res := cf.Finalize().(CommitFeaturesResult)
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, cf.Serialize(res, false, buffer))
+	lines := strings.Split(strings.TrimRight(buffer.String(), "\n"), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, lines[0], "  csv: |-")
+	assert.Equal(t, lines[1], "    commit,day,hour,files,added,removed,dir_entropy,"+
+		"author_experience,message_length,message_lines,uast_nodes_added,uast_nodes_removed")
+	assert.True(t, strings.HasPrefix(
+		lines[2], "    2b1ed978194a94edeabbca6de7ff3b5771d4d665,7,"))
+	assert.Contains(t, lines[2], ",2,219,0,1.0000,0,")
+	assert.True(t, strings.HasSuffix(lines[2], ",0,0"))
+}
+
+func TestCommitFeaturesSerializeBinary(t *testing.T) {
+	cf := fixtureCommitFeatures()
+	cf.Consume(fixtureCommitFeaturesDeps())
+	res := cf.Finalize().(CommitFeaturesResult)
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, cf.Serialize(res, true, buffer))
+	msg := pb.CommitFeaturesResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Commits, 1)
+	assert.Equal(t, msg.Commits[0].Commit, "2b1ed978194a94edeabbca6de7ff3b5771d4d665")
+	assert.Equal(t, msg.Commits[0].Day, int32(7))
+	assert.Equal(t, msg.Commits[0].Files, int32(2))
+	assert.Equal(t, msg.Commits[0].Added, int32(219))
+	assert.Equal(t, msg.Commits[0].DirEntropy, float32(1))
+}
+
+func TestCommitFeaturesSerializeCSV(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-features-test-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	cf := fixtureCommitFeatures()
+	cf.CSVPath = path.Join(dir, "features.csv")
+	res := CommitFeaturesResult{Commits: []CommitFeatures{{
+		Commit:     plumbing.NewHash("2b1ed978194a94edeabbca6de7ff3b5771d4d665"),
+		Day:        7,
+		Files:      2,
+		Added:      219,
+		DirEntropy: 1,
+	}}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, cf.Serialize(res, true, buffer))
+	data, err := ioutil.ReadFile(cf.CSVPath)
+	assert.Nil(t, err)
+	lines := strings.Split(string(data), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, lines[0], "commit,day,hour,files,added,removed,dir_entropy,"+
+		"author_experience,message_length,message_lines,uast_nodes_added,uast_nodes_removed")
+	assert.Equal(t, lines[1], "2b1ed978194a94edeabbca6de7ff3b5771d4d665,7,0,2,219,0,1.0000,0,0,0,0,0")
+	assert.Equal(t, lines[2], "")
+	msg := pb.CommitFeaturesResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Commits, 1)
+	cf.CSVPath = path.Join(dir, "missing", "features.csv")
+	assert.NotNil(t, cf.Serialize(res, false, &bytes.Buffer{}))
+}
+
+func TestEntropy(t *testing.T) {
+	assert.Equal(t, entropy(map[string]int{}), float64(0))
+	assert.Equal(t, entropy(map[string]int{"a": 5}), float64(0))
+	assert.InDelta(t, entropy(map[string]int{"a": 1, "b": 1}), 1, 0.0001)
+	assert.InDelta(t, entropy(map[string]int{"a": 1, "b": 1, "c": 1, "d": 1}), 2, 0.0001)
+}
@@ -0,0 +1,322 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// CommitMessagesAnalysis scores the commit messages by four criteria: the subject is not too long,
+// there is a body, the subject is in the imperative mood and there is an issue reference.
+// The average quality is tracked per developer and per calendar quarter. Merge commits are ignored.
+// It is a LeafPipelineItem.
+type CommitMessagesAnalysis struct {
+	// MaxSubjectLength is the maximum number of characters in a good subject line.
+	MaxSubjectLength int
+	// PeopleNumber is the number of developers for which to collect the stats.
+	PeopleNumber int
+
+	// quarters maps the quarters ("2018Q3") to the stats of the commits in that quarter.
+	quarters map[string]CommitMessageStats
+	// people is the stats of each developer's commits.
+	// The last element corresponds to the authors which were not matched.
+	people []CommitMessageStats
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// CommitMessageStats are the commit message quality statistics of a group of commits.
+type CommitMessageStats struct {
+	// Commits is the number of commits.
+	Commits int
+	// GoodSubjects is the number of commits with a non-empty subject which is not too long.
+	GoodSubjects int
+	// Bodies is the number of commits with a body after the subject.
+	Bodies int
+	// Imperative is the number of commits with the subject in the imperative mood.
+	Imperative int
+	// IssueRefs is the number of commits which reference an issue.
+	IssueRefs int
+	// TotalScore is the sum of the commit scores.
+	TotalScore float64
+}
+
+// Score returns the average commit message quality from 0 (bad) to 1 (good).
+func (stats CommitMessageStats) Score() float64 {
+	if stats.Commits == 0 {
+		return 0
+	}
+	return stats.TotalScore / float64(stats.Commits)
+}
+
+// add updates the statistics with one more commit's score.
+func (stats *CommitMessageStats) add(score CommitMessageScore) {
+	stats.Commits++
+	criteria := [...]bool{score.GoodSubject, score.Body, score.Imperative, score.IssueRef}
+	counters := [...]*int{&stats.GoodSubjects, &stats.Bodies, &stats.Imperative, &stats.IssueRefs}
+	for i, met := range criteria {
+		if met {
+			*counters[i]++
+		}
+	}
+	stats.TotalScore += score.Value()
+}
+
+// CommitMessageScore is the assessment of a single commit message.
+type CommitMessageScore struct {
+	// GoodSubject indicates that the subject is not empty and not too long.
+	GoodSubject bool
+	// Body indicates that there is a body after the subject.
+	Body bool
+	// Imperative indicates that the subject is in the imperative mood, e.g. "Fix" instead
+	// of "Fixed" or "Fixes".
+	Imperative bool
+	// IssueRef indicates that the message references an issue.
+	IssueRef bool
+}
+
+// Value returns the fraction of the met criteria.
+func (score CommitMessageScore) Value() float64 {
+	value := 0
+	for _, met := range [...]bool{score.GoodSubject, score.Body, score.Imperative, score.IssueRef} {
+		if met {
+			value++
+		}
+	}
+	return float64(value) / 4
+}
+
+// CommitMessagesResult is returned by CommitMessagesAnalysis.Finalize() and carries the commit
+// message quality statistics per quarter and per developer.
+type CommitMessagesResult struct {
+	// Quarters maps the quarter ("2018Q3") to the stats of the commits in that quarter.
+	Quarters map[string]CommitMessageStats
+	// People is the stats of each developer's commits, indexed by the developer's identity.
+	// The last element corresponds to the unmatched authors.
+	People []CommitMessageStats
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigCommitMessagesMaxSubjectLength is the name of the option to set
+	// CommitMessagesAnalysis.MaxSubjectLength.
+	ConfigCommitMessagesMaxSubjectLength = "CommitMessages.MaxSubjectLength"
+	// DefaultCommitMessagesMaxSubjectLength is the default value of
+	// CommitMessagesAnalysis.MaxSubjectLength.
+	DefaultCommitMessagesMaxSubjectLength = 72
+)
+
+var (
+	// issueRefRE matches "#123", "gh-123", "JIRA-123" and the links to GitHub/GitLab issues and PRs.
+	issueRefRE = regexp.MustCompile(
+		`(^|[^\w&])#\d+\b|\b(?i:gh)-\d+\b|\b[A-Z][A-Z0-9]+-\d+\b|/(issues|pull|merge_requests)/\d+`)
+	// subjectPrefixRE matches the subject prefixes like "[tag] ", "component: " or "fix(scope): ".
+	subjectPrefixRE = regexp.MustCompile(`^(\[[^\]]*\]\s*|[\w./-]+(\([^)]*\))?!?:\s+)+`)
+	// nonImperativeSuffixRE matches the past tense, the gerund and the third person singular.
+	nonImperativeSuffixRE = regexp.MustCompile(`(ed|ing|[^su]s)$`)
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (messages *CommitMessagesAnalysis) Name() string {
+	return "CommitMessages"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (messages *CommitMessagesAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (messages *CommitMessagesAnalysis) Requires() []string {
+	arr := [...]string{identity.DependencyAuthor}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (messages *CommitMessagesAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigCommitMessagesMaxSubjectLength,
+		Description: "Maximum number of characters in a good commit message subject.",
+		Flag:        "commit-messages-max-subject",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultCommitMessagesMaxSubjectLength},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (messages *CommitMessagesAnalysis) Flag() string {
+	return "commit-messages"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (messages *CommitMessagesAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigCommitMessagesMaxSubjectLength].(int); exists {
+		messages.MaxSubjectLength = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		messages.PeopleNumber = val
+		messages.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (messages *CommitMessagesAnalysis) Initialize(repository *git.Repository) {
+	if messages.MaxSubjectLength <= 0 {
+		log.Printf("Warning: adjusted the maximum commit subject length to %d\n",
+			DefaultCommitMessagesMaxSubjectLength)
+		messages.MaxSubjectLength = DefaultCommitMessagesMaxSubjectLength
+	}
+	messages.quarters = map[string]CommitMessageStats{}
+	messages.people = make([]CommitMessageStats, messages.PeopleNumber+1)
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (messages *CommitMessagesAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	if commit.NumParents() > 1 {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing {
+		author = messages.PeopleNumber
+	}
+	score := messages.ScoreMessage(commit.Message)
+	quarter := formatQuarter(commit.Author.When)
+	quarterStats := messages.quarters[quarter]
+	quarterStats.add(score)
+	messages.quarters[quarter] = quarterStats
+	messages.people[author].add(score)
+	return nil, nil
+}
+
+// ScoreMessage assesses the commit message.
+func (messages *CommitMessagesAnalysis) ScoreMessage(message string) CommitMessageScore {
+	message = strings.TrimSpace(strings.Replace(message, "\r\n", "\n", -1))
+	parts := strings.SplitN(message, "\n", 2)
+	subject := strings.TrimSpace(parts[0])
+	score := CommitMessageScore{}
+	length := utf8.RuneCountInString(subject)
+	score.GoodSubject = length > 0 && length <= messages.MaxSubjectLength
+	score.Body = len(parts) > 1 && strings.TrimSpace(parts[1]) != ""
+	words := strings.Fields(subjectPrefixRE.ReplaceAllString(subject, ""))
+	if len(words) > 0 {
+		verb := strings.ToLower(strings.Trim(words[0], ".,:;!?\"'`"))
+		score.Imperative = verb != "" && !nonImperativeSuffixRE.MatchString(verb)
+	}
+	score.IssueRef = issueRefRE.MatchString(message)
+	return score
+}
+
+// formatQuarter returns the calendar quarter of the time, e.g. "2018Q3".
+func formatQuarter(when time.Time) string {
+	return fmt.Sprintf("%dQ%d", when.Year(), (int(when.Month())-1)/3+1)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (messages *CommitMessagesAnalysis) Finalize() interface{} {
+	return CommitMessagesResult{
+		Quarters:           messages.quarters,
+		People:             messages.people,
+		reversedPeopleDict: messages.reversedPeopleDict,
+	}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (messages *CommitMessagesAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	messagesResult := result.(CommitMessagesResult)
+	if binary {
+		return messages.serializeBinary(&messagesResult, writer)
+	}
+	messages.serializeText(&messagesResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (messages *CommitMessagesAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(messages, result, &pb.CommitMessagesResults{}, writer)
+}
+
+func (messages *CommitMessagesAnalysis) serializeText(result *CommitMessagesResult, writer io.Writer) {
+	formatStats := func(stats CommitMessageStats) string {
+		return fmt.Sprintf(
+			"{commits: %d, score: %.4f, subject: %d, body: %d, imperative: %d, issue: %d}",
+			stats.Commits, stats.Score(), stats.GoodSubjects, stats.Bodies, stats.Imperative,
+			stats.IssueRefs)
+	}
+	fmt.Fprintln(writer, "  quarters:")
+	quarters := make([]string, 0, len(result.Quarters))
+	for quarter := range result.Quarters {
+		quarters = append(quarters, quarter)
+	}
+	sort.Strings(quarters)
+	for _, quarter := range quarters {
+		fmt.Fprintf(writer, "    %s: %s\n", yaml.SafeString(quarter), formatStats(result.Quarters[quarter]))
+	}
+	fmt.Fprintln(writer, "  people:")
+	for i, name := range result.reversedPeopleDict {
+		if i >= len(result.People) {
+			break
+		}
+		fmt.Fprintf(writer, "    %s: %s\n", yaml.SafeString(name), formatStats(result.People[i]))
+	}
+}
+
+func (messages *CommitMessagesAnalysis) serializeBinary(result *CommitMessagesResult, writer io.Writer) error {
+	convertStats := func(stats CommitMessageStats) *pb.CommitMessageStats {
+		return &pb.CommitMessageStats{
+			Commits:      int32(stats.Commits),
+			Score:        float32(stats.Score()),
+			GoodSubjects: int32(stats.GoodSubjects),
+			Bodies:       int32(stats.Bodies),
+			Imperative:   int32(stats.Imperative),
+			IssueRefs:    int32(stats.IssueRefs),
+		}
+	}
+	message := pb.CommitMessagesResults{
+		Quarters:       map[string]*pb.CommitMessageStats{},
+		People:         make([]*pb.CommitMessageStats, len(result.People)),
+		PeopleSequence: result.reversedPeopleDict,
+	}
+	for quarter, stats := range result.Quarters {
+		message.Quarters[quarter] = convertStats(stats)
+	}
+	for i, stats := range result.People {
+		message.People[i] = convertStats(stats)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&CommitMessagesAnalysis{})
+}
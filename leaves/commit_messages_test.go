@@ -0,0 +1,165 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureCommitMessages() *CommitMessagesAnalysis {
+	messages := CommitMessagesAnalysis{PeopleNumber: 2}
+	messages.Initialize(test.Repository)
+	return &messages
+}
+
+func fixtureCommitMessagesCommit(message string, month time.Month) *object.Commit {
+	return &object.Commit{
+		Author:  object.Signature{When: time.Date(2018, month, 1, 12, 0, 0, 0, time.UTC)},
+		Message: message,
+	}
+}
+
+func TestCommitMessagesMeta(t *testing.T) {
+	messages := fixtureCommitMessages()
+	assert.Equal(t, messages.Name(), "CommitMessages")
+	assert.Len(t, messages.Provides(), 0)
+	assert.Equal(t, messages.Requires(), []string{identity.DependencyAuthor})
+	opts := messages.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigCommitMessagesMaxSubjectLength)
+	assert.Equal(t, messages.Flag(), "commit-messages")
+	assert.Equal(t, messages.MaxSubjectLength, DefaultCommitMessagesMaxSubjectLength)
+	facts := map[string]interface{}{}
+	facts[ConfigCommitMessagesMaxSubjectLength] = 50
+	facts[identity.FactIdentityDetectorPeopleCount] = 3
+	facts[identity.FactIdentityDetectorReversedPeopleDict] = []string{"one", "two", "three"}
+	messages.Configure(facts)
+	assert.Equal(t, messages.MaxSubjectLength, 50)
+	assert.Equal(t, messages.PeopleNumber, 3)
+	assert.Equal(t, messages.reversedPeopleDict, []string{"one", "two", "three"})
+}
+
+func TestCommitMessagesRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&CommitMessagesAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "CommitMessages")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&CommitMessagesAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestCommitMessagesScoreMessage(t *testing.T) {
+	messages := fixtureCommitMessages()
+	score := messages.ScoreMessage("Fix the race in the cache\n\nThe lock was not held.\nFixes #42.\n")
+	assert.Equal(t, score, CommitMessageScore{
+		GoodSubject: true, Body: true, Imperative: true, IssueRef: true})
+	assert.Equal(t, score.Value(), float64(1))
+	score = messages.ScoreMessage("fixed stuff")
+	assert.Equal(t, score, CommitMessageScore{GoodSubject: true})
+	assert.Equal(t, score.Value(), 0.25)
+	assert.False(t, messages.ScoreMessage("Adds the cache").Imperative)
+	assert.False(t, messages.ScoreMessage("Updating the docs").Imperative)
+	assert.True(t, messages.ScoreMessage("Process the queue").Imperative)
+	assert.True(t, messages.ScoreMessage("[docs] Add the FAQ").Imperative)
+	assert.True(t, messages.ScoreMessage("fix(parser): handle EOF").Imperative)
+	assert.False(t, messages.ScoreMessage("cmd/hercules: removed the flag").Imperative)
+	assert.True(t, messages.ScoreMessage("Add the flag (PROJ-17)").IssueRef)
+	assert.True(t, messages.ScoreMessage(
+		"Add the flag\n\nSee https://github.com/src-d/hercules/issues/7").IssueRef)
+	assert.False(t, messages.ScoreMessage("Add the flag &#39;").IssueRef)
+	assert.False(t, messages.ScoreMessage("Use 10-20 workers").IssueRef)
+	score = messages.ScoreMessage("")
+	assert.Equal(t, score, CommitMessageScore{})
+	long := ""
+	for i := 0; i < 73; i++ {
+		long += "a"
+	}
+	assert.False(t, messages.ScoreMessage(long).GoodSubject)
+	assert.True(t, messages.ScoreMessage(long[:72]).GoodSubject)
+	assert.False(t, messages.ScoreMessage("Add the flag\n\n  \n").Body)
+}
+
+func TestCommitMessagesConsumeFinalize(t *testing.T) {
+	messages := fixtureCommitMessages()
+	deps := map[string]interface{}{}
+	deps["commit"] = fixtureCommitMessagesCommit("Fix the bug\n\nDetails. #1", time.February)
+	deps[identity.DependencyAuthor] = 0
+	result, err := messages.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps["commit"] = fixtureCommitMessagesCommit("fixed", time.March)
+	deps[identity.DependencyAuthor] = identity.AuthorMissing
+	messages.Consume(deps)
+	deps["commit"] = fixtureCommitMessagesCommit("Add the feature", time.July)
+	deps[identity.DependencyAuthor] = 1
+	messages.Consume(deps)
+	merge := fixtureCommitMessagesCommit("Merge branch 'master'", time.July)
+	merge.ParentHashes = []plumbing.Hash{plumbing.ZeroHash, plumbing.ZeroHash}
+	deps["commit"] = merge
+	messages.Consume(deps)
+	res := messages.Finalize().(CommitMessagesResult)
+	assert.Len(t, res.Quarters, 2)
+	assert.Equal(t, res.Quarters["2018Q1"], CommitMessageStats{
+		Commits: 2, GoodSubjects: 2, Bodies: 1, Imperative: 1, IssueRefs: 1, TotalScore: 1.25})
+	assert.Equal(t, res.Quarters["2018Q1"].Score(), 0.625)
+	assert.Equal(t, res.Quarters["2018Q3"].Commits, 1)
+	assert.Len(t, res.People, 3)
+	assert.Equal(t, res.People[0].Score(), float64(1))
+	assert.Equal(t, res.People[1].Score(), 0.5)
+	assert.Equal(t, res.People[2].Score(), 0.25)
+	assert.Equal(t, CommitMessageStats{}.Score(), float64(0))
+}
+
+func TestFormatQuarter(t *testing.T) {
+	assert.Equal(t, formatQuarter(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)), "2018Q1")
+	assert.Equal(t, formatQuarter(time.Date(2018, 6, 30, 0, 0, 0, 0, time.UTC)), "2018Q2")
+	assert.Equal(t, formatQuarter(time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)), "2017Q4")
+}
+
+func TestCommitMessagesSerialize(t *testing.T) {
+	messages := fixtureCommitMessages()
+	res := CommitMessagesResult{
+		Quarters: map[string]CommitMessageStats{
+			"2018Q3": {Commits: 1, GoodSubjects: 1, TotalScore: 0.25},
+			"2018Q1": {Commits: 2, GoodSubjects: 2, Bodies: 1, Imperative: 2, IssueRefs: 1, TotalScore: 1.5},
+		},
+		People: []CommitMessageStats{
+			{Commits: 2, GoodSubjects: 2, Bodies: 1, Imperative: 2, IssueRefs: 1, TotalScore: 1.5},
+			{}, {Commits: 1, GoodSubjects: 1, TotalScore: 0.25}},
+		reversedPeopleDict: []string{"one", "two"},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, messages.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  quarters:
+    "2018Q1": {commits: 2, score: 0.7500, subject: 2, body: 1, imperative: 2, issue: 1}
+    "2018Q3": {commits: 1, score: 0.2500, subject: 1, body: 0, imperative: 0, issue: 0}
+  people:
+    "one": {commits: 2, score: 0.7500, subject: 2, body: 1, imperative: 2, issue: 1}
+    "two": {commits: 0, score: 0.0000, subject: 0, body: 0, imperative: 0, issue: 0}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, messages.Serialize(res, true, buffer))
+	msg := pb.CommitMessagesResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Quarters, 2)
+	assert.Equal(t, msg.Quarters["2018Q1"].Score, float32(0.75))
+	assert.Equal(t, msg.Quarters["2018Q1"].Imperative, int32(2))
+	assert.Len(t, msg.People, 3)
+	assert.Equal(t, msg.People[2].Commits, int32(1))
+	assert.Equal(t, msg.PeopleSequence, []string{"one", "two"})
+}
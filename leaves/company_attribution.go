@@ -0,0 +1,462 @@
+package leaves
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/burndown"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// CompanyAttributionAnalysis calculates the number of commits and the number of owned lines
+// of each company in each quarter. The company of a commit is taken from the people dict
+// (see identity.Attributes) and falls back to the domain of the author's email, optionally
+// mapped to the company name with a gitdm-style domain map.
+// It is a LeafPipelineItem and a LinearPipelineItem.
+type CompanyAttributionAnalysis struct {
+	// DomainMapPath is the path to the file which maps the email domains to the companies.
+	// Every line is a domain followed by the company name; "#" starts a comment.
+	DomainMapPath string
+
+	// peopleAttributes references IdentityDetector.PeopleAttributes
+	peopleAttributes []identity.Attributes
+	// domains maps the email domains to the company names.
+	domains map[string]string
+	// files is the mapping <file path> -> *burndown.File. The values of the lines
+	// are the company indexes.
+	files map[string]*burndown.File
+	// companies are the company names, the indexes are the line values in files.
+	companies []string
+	// companyIndex is the reverse of companies.
+	companyIndex map[string]int
+	// lines are the number of currently owned lines per company index.
+	lines []int
+	// quarter is the quarter of the commit which is being analysed, e.g. "2018Q1".
+	quarter string
+	// quarters maps the quarter to the company to the stats.
+	quarters map[string]map[string]*CompanyAttributionStats
+}
+
+// CompanyAttributionStats is the activity of a company in a quarter.
+type CompanyAttributionStats struct {
+	// Commits is the number of commits.
+	Commits int
+	// Lines is the number of lines which the company owns at the end of the quarter.
+	Lines int
+}
+
+// CompanyAttributionResult is returned by CompanyAttributionAnalysis.Finalize() and carries
+// the quarterly stats of each company.
+type CompanyAttributionResult struct {
+	// Quarters maps the quarter ("2018Q1") to the company name to the stats.
+	// Only the quarters with commits are present.
+	Quarters map[string]map[string]CompanyAttributionStats
+}
+
+const (
+	// ConfigCompanyAttributionDomainMapPath is the name of the option to set
+	// CompanyAttributionAnalysis.DomainMapPath.
+	ConfigCompanyAttributionDomainMapPath = "CompanyAttribution.DomainMapPath"
+	// CompanyAttributionUnknown is the company of the authors without the email domain
+	// and the company in the people dict.
+	CompanyAttributionUnknown = "(unknown)"
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (companies *CompanyAttributionAnalysis) Name() string {
+	return "CompanyAttribution"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (companies *CompanyAttributionAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (companies *CompanyAttributionAnalysis) Requires() []string {
+	arr := [...]string{
+		identity.DependencyAuthor, items.DependencyTreeChanges, items.DependencyFileDiff,
+		items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (companies *CompanyAttributionAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigCompanyAttributionDomainMapPath,
+		Description: "Path to the file which maps the email domains to the companies: " +
+			"\"domain company name\" per line.",
+		Flag:    "company-domains",
+		Type:    core.StringConfigurationOption,
+		Default: ""},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (companies *CompanyAttributionAnalysis) Flag() string {
+	return "company-attribution"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (companies *CompanyAttributionAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigCompanyAttributionDomainMapPath].(string); exists {
+		companies.DomainMapPath = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorPeopleAttributes].([]identity.Attributes); exists {
+		companies.peopleAttributes = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (companies *CompanyAttributionAnalysis) Initialize(repository *git.Repository) {
+	companies.domains = map[string]string{}
+	if companies.DomainMapPath != "" {
+		domains, err := loadDomainMap(companies.DomainMapPath)
+		if err != nil {
+			log.Printf("Warning: ignored the domain map: %v\n", err)
+		} else {
+			companies.domains = domains
+		}
+	}
+	companies.files = map[string]*burndown.File{}
+	companies.companies = []string{}
+	companies.companyIndex = map[string]int{}
+	companies.lines = []int{}
+	companies.quarter = ""
+	companies.quarters = map[string]map[string]*CompanyAttributionStats{}
+}
+
+// loadDomainMap reads the file in the format of gitdm's "domain-map": every line is an email
+// domain followed by the company name, the lines which start with "#" are comments.
+func loadDomainMap(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	domains := map[string]string{}
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("%s:%d: expected \"domain company\", got %q", path, line, text)
+		}
+		domains[strings.ToLower(fields[0])] = strings.Join(fields[1:], " ")
+	}
+	return domains, scanner.Err()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (companies *CompanyAttributionAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	when := commit.Author.When.UTC()
+	quarter := fmt.Sprintf("%dQ%d", when.Year(), (int(when.Month())+2)/3)
+	if quarter != companies.quarter {
+		companies.recordLines()
+		companies.quarter = quarter
+	}
+	company := companies.getCompany(deps[identity.DependencyAuthor].(int), commit.Author.Email)
+	companies.getStats(companies.companies[company]).Commits++
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			err = companies.handleInsertion(change, company, cache)
+		case merkletrie.Delete:
+			err = companies.handleDeletion(change, company)
+		case merkletrie.Modify:
+			err = companies.handleModification(change, company, cache, fileDiffs)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// RequiresLinearHistory returns true because the lines of the files are followed through
+// the diffs, which do not apply to the interleaved branches. See core.LinearPipelineItem.
+func (companies *CompanyAttributionAnalysis) RequiresLinearHistory() bool {
+	return true
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (companies *CompanyAttributionAnalysis) Finalize() interface{} {
+	companies.recordLines()
+	quarters := map[string]map[string]CompanyAttributionStats{}
+	for quarter, stats := range companies.quarters {
+		quarters[quarter] = map[string]CompanyAttributionStats{}
+		for company, companyStats := range stats {
+			quarters[quarter][company] = *companyStats
+		}
+	}
+	return CompanyAttributionResult{Quarters: quarters}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (companies *CompanyAttributionAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	companyResult := result.(CompanyAttributionResult)
+	if binary {
+		return companies.serializeBinary(&companyResult, writer)
+	}
+	companies.serializeText(&companyResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (companies *CompanyAttributionAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(companies, result, &pb.CompanyAttributionResults{}, writer)
+}
+
+func (companies *CompanyAttributionAnalysis) serializeText(
+	result *CompanyAttributionResult, writer io.Writer) {
+	quarters := make([]string, 0, len(result.Quarters))
+	for quarter := range result.Quarters {
+		quarters = append(quarters, quarter)
+	}
+	sort.Strings(quarters)
+	fmt.Fprintln(writer, "  # commits, lines, commit share, line share")
+	fmt.Fprintln(writer, "  quarters:")
+	for _, quarter := range quarters {
+		stats := result.Quarters[quarter]
+		names := make([]string, 0, len(stats))
+		totalCommits, totalLines := 0, 0
+		for name, companyStats := range stats {
+			names = append(names, name)
+			totalCommits += companyStats.Commits
+			totalLines += companyStats.Lines
+		}
+		sort.Strings(names)
+		fmt.Fprintf(writer, "    %s:\n", yaml.SafeString(quarter))
+		for _, name := range names {
+			companyStats := stats[name]
+			commitShare, lineShare := float64(0), float64(0)
+			if totalCommits > 0 {
+				commitShare = float64(companyStats.Commits) / float64(totalCommits)
+			}
+			if totalLines > 0 {
+				lineShare = float64(companyStats.Lines) / float64(totalLines)
+			}
+			fmt.Fprintf(writer, "      %s: [%d, %d, %.4f, %.4f]\n", yaml.SafeString(name),
+				companyStats.Commits, companyStats.Lines, commitShare, lineShare)
+		}
+	}
+}
+
+func (companies *CompanyAttributionAnalysis) serializeBinary(
+	result *CompanyAttributionResult, writer io.Writer) error {
+	message := pb.CompanyAttributionResults{Quarters: map[string]*pb.CompanyQuarter{}}
+	for quarter, stats := range result.Quarters {
+		pbQuarter := &pb.CompanyQuarter{Companies: map[string]*pb.CompanyAttributionStats{}}
+		for name, companyStats := range stats {
+			pbQuarter.Companies[name] = &pb.CompanyAttributionStats{
+				Commits: int32(companyStats.Commits),
+				Lines:   int32(companyStats.Lines),
+			}
+		}
+		message.Quarters[quarter] = pbQuarter
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+// getCompany returns the index of the company of the commit author.
+func (companies *CompanyAttributionAnalysis) getCompany(author int, email string) int {
+	name := ""
+	if author != identity.AuthorMissing && author < len(companies.peopleAttributes) {
+		name = companies.peopleAttributes[author].Company
+	}
+	if name == "" {
+		name = companies.mapDomain(email)
+	}
+	index, exists := companies.companyIndex[name]
+	if !exists {
+		index = len(companies.companies)
+		companies.companyIndex[name] = index
+		companies.companies = append(companies.companies, name)
+		companies.lines = append(companies.lines, 0)
+	}
+	return index
+}
+
+// mapDomain returns the company which corresponds to the email. The subdomains inherit
+// the company of the parent domain; the domains which are not mapped are the companies
+// themselves.
+func (companies *CompanyAttributionAnalysis) mapDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 || at == len(email)-1 {
+		return CompanyAttributionUnknown
+	}
+	domain := strings.ToLower(email[at+1:])
+	for suffix := domain; suffix != ""; {
+		if name, exists := companies.domains[suffix]; exists {
+			return name
+		}
+		dot := strings.Index(suffix, ".")
+		if dot < 0 {
+			break
+		}
+		suffix = suffix[dot+1:]
+	}
+	return domain
+}
+
+// getStats returns the stats of the company in the current quarter.
+func (companies *CompanyAttributionAnalysis) getStats(name string) *CompanyAttributionStats {
+	quarter := companies.quarters[companies.quarter]
+	if quarter == nil {
+		quarter = map[string]*CompanyAttributionStats{}
+		companies.quarters[companies.quarter] = quarter
+	}
+	stats := quarter[name]
+	if stats == nil {
+		stats = &CompanyAttributionStats{}
+		quarter[name] = stats
+	}
+	return stats
+}
+
+// recordLines saves the current line ownership as the state at the end of the current quarter.
+func (companies *CompanyAttributionAnalysis) recordLines() {
+	if companies.quarter == "" {
+		return
+	}
+	for index, lines := range companies.lines {
+		if lines > 0 {
+			companies.getStats(companies.companies[index]).Lines = lines
+		}
+	}
+}
+
+// updateLines is the burndown.Status callback which maintains the number of owned lines.
+// The line values are the company indexes.
+func (companies *CompanyAttributionAnalysis) updateLines(_ interface{}, _ int, previousCompany int, delta int) {
+	companies.lines[previousCompany] += delta
+}
+
+func (companies *CompanyAttributionAnalysis) handleInsertion(
+	change *object.Change, company int, cache map[plumbing.Hash]*object.Blob) error {
+	lines, err := items.CountLines(cache[change.To.TreeEntry.Hash])
+	if err != nil {
+		if err.Error() == "binary" {
+			return nil
+		}
+		return err
+	}
+	name := change.To.Name
+	if _, exists := companies.files[name]; exists {
+		return fmt.Errorf("file %s already exists", name)
+	}
+	companies.files[name] = burndown.NewFile(
+		company, lines, burndown.NewStatus(nil, companies.updateLines))
+	return nil
+}
+
+func (companies *CompanyAttributionAnalysis) handleDeletion(change *object.Change, company int) error {
+	name := change.From.Name
+	file, exists := companies.files[name]
+	if !exists {
+		// binary files are not tracked
+		return nil
+	}
+	file.Update(company, 0, 0, file.Len())
+	delete(companies.files, name)
+	return nil
+}
+
+func (companies *CompanyAttributionAnalysis) handleModification(
+	change *object.Change, company int, cache map[plumbing.Hash]*object.Blob,
+	diffs map[string]items.FileDiffData) error {
+	file, exists := companies.files[change.From.Name]
+	if !exists {
+		return companies.handleInsertion(change, company, cache)
+	}
+	if change.To.Name != change.From.Name {
+		companies.files[change.To.Name] = file
+		delete(companies.files, change.From.Name)
+	}
+	thisDiffs := diffs[change.To.Name]
+	if file.Len() != thisDiffs.OldLinesOfCode {
+		return fmt.Errorf("%s: internal integrity error src %d != %d",
+			change.To.Name, thisDiffs.OldLinesOfCode, file.Len())
+	}
+	// the diffs are line-level so the number of lines equals to the rune count
+	position := 0
+	pending := diffmatchpatch.Diff{Text: ""}
+	for _, edit := range thisDiffs.Diffs {
+		length := utf8.RuneCountInString(edit.Text)
+		switch edit.Type {
+		case diffmatchpatch.DiffEqual:
+			if pending.Text != "" {
+				file.Update(company, position, 0, utf8.RuneCountInString(pending.Text))
+				pending.Text = ""
+			}
+			position += length
+		case diffmatchpatch.DiffInsert:
+			file.Update(company, position, length, utf8.RuneCountInString(pending.Text))
+			position += length
+			pending.Text = ""
+		case diffmatchpatch.DiffDelete:
+			if pending.Text != "" {
+				return errors.New("DiffDelete may not appear after DiffDelete")
+			}
+			pending = edit
+		default:
+			return fmt.Errorf("diff operation is not supported: %d", edit.Type)
+		}
+	}
+	if pending.Text != "" {
+		file.Update(company, position, 0, utf8.RuneCountInString(pending.Text))
+	}
+	if file.Len() != thisDiffs.NewLinesOfCode {
+		return fmt.Errorf("%s: internal integrity error dst %d != %d",
+			change.To.Name, thisDiffs.NewLinesOfCode, file.Len())
+	}
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&CompanyAttributionAnalysis{})
+}
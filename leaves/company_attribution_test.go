@@ -0,0 +1,185 @@
+package leaves
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureCompanyAttribution() *CompanyAttributionAnalysis {
+	companies := CompanyAttributionAnalysis{}
+	companies.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorPeopleAttributes: []identity.Attributes{
+			{Company: "source{d}"}, {Team: "ML"}, {}},
+	})
+	companies.Initialize(test.Repository)
+	companies.domains = map[string]string{"google.com": "Google"}
+	return &companies
+}
+
+func fixtureCompanyAttributionDeps(
+	author int, email string, month time.Month, changes object.Changes,
+	diffs map[string]items.FileDiffData, blobs map[plumbing.Hash]*object.Blob) map[string]interface{} {
+	deps := fixtureChurnOriginDeps(author, month, changes, diffs, blobs)
+	deps["commit"].(*object.Commit).Author.Email = email
+	return deps
+}
+
+func TestCompanyAttributionMeta(t *testing.T) {
+	companies := fixtureCompanyAttribution()
+	assert.Equal(t, companies.Name(), "CompanyAttribution")
+	assert.Len(t, companies.Provides(), 0)
+	assert.True(t, companies.RequiresLinearHistory())
+	assert.Equal(t, companies.Requires(), []string{
+		identity.DependencyAuthor, items.DependencyTreeChanges, items.DependencyFileDiff,
+		items.DependencyBlobCache})
+	opts := companies.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigCompanyAttributionDomainMapPath)
+	assert.Equal(t, companies.Flag(), "company-attribution")
+	assert.Len(t, companies.peopleAttributes, 3)
+	companies.Configure(map[string]interface{}{
+		ConfigCompanyAttributionDomainMapPath: "/tmp/domains",
+	})
+	assert.Equal(t, companies.DomainMapPath, "/tmp/domains")
+}
+
+func TestCompanyAttributionRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&CompanyAttributionAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "CompanyAttribution")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&CompanyAttributionAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestCompanyAttributionInitialize(t *testing.T) {
+	tmpf, err := ioutil.TempFile("", "hercules-test-")
+	assert.Nil(t, err)
+	defer os.Remove(tmpf.Name())
+	tmpf.WriteString("# comment\ngoogle.com Google Inc.\n\nSOURCED.tech\tsource{d}\n")
+	tmpf.Close()
+	companies := CompanyAttributionAnalysis{DomainMapPath: tmpf.Name()}
+	companies.Initialize(test.Repository)
+	assert.Equal(t, companies.domains, map[string]string{
+		"google.com": "Google Inc.", "sourced.tech": "source{d}"})
+	companies = CompanyAttributionAnalysis{DomainMapPath: tmpf.Name() + ".404"}
+	companies.Initialize(test.Repository)
+	assert.Len(t, companies.domains, 0)
+	_, err = loadDomainMap(tmpf.Name() + ".404")
+	assert.NotNil(t, err)
+	ioutil.WriteFile(tmpf.Name(), []byte("google.com\n"), 0666)
+	_, err = loadDomainMap(tmpf.Name())
+	assert.NotNil(t, err)
+}
+
+func TestCompanyAttributionGetCompany(t *testing.T) {
+	companies := fixtureCompanyAttribution()
+	assert.Equal(t, companies.getCompany(0, "vadim@gmail.com"), 0)
+	assert.Equal(t, companies.getCompany(1, "egor@mail.google.com"), 1)
+	assert.Equal(t, companies.getCompany(identity.AuthorMissing, "x@Example.org"), 2)
+	assert.Equal(t, companies.getCompany(5, "broken"), 3)
+	assert.Equal(t, companies.getCompany(2, "y@google.com"), 1)
+	assert.Equal(t, companies.companies, []string{
+		"source{d}", "Google", "example.org", CompanyAttributionUnknown})
+	assert.Equal(t, companies.lines, []int{0, 0, 0, 0})
+}
+
+func TestCompanyAttributionConsumeFinalize(t *testing.T) {
+	companies := fixtureCompanyAttribution()
+	blobA := fixtureChurnOriginBlob("1\n2\n3\n4\n")
+	blobB := fixtureChurnOriginBlob("1\n2\n")
+	entry := func(name string, blob *object.Blob) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: blob.Hash}}
+	}
+	// source{d} creates a.go
+	result, err := companies.Consume(fixtureCompanyAttributionDeps(0, "vadim@gmail.com", time.January,
+		object.Changes{{To: entry("a.go", blobA)}}, nil,
+		map[plumbing.Hash]*object.Blob{blobA.Hash: blobA}))
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	// Google replaces two lines with a single line
+	_, err = companies.Consume(fixtureCompanyAttributionDeps(1, "egor@google.com", time.April,
+		object.Changes{{From: entry("a.go", blobA), To: entry("a.go", blobB)}},
+		map[string]items.FileDiffData{"a.go": {
+			OldLinesOfCode: 4, NewLinesOfCode: 3, Diffs: []diffmatchpatch.Diff{
+				{Type: diffmatchpatch.DiffEqual, Text: "a"},
+				{Type: diffmatchpatch.DiffDelete, Text: "bc"},
+				{Type: diffmatchpatch.DiffInsert, Text: "x"},
+				{Type: diffmatchpatch.DiffEqual, Text: "d"},
+			}}}, nil))
+	assert.Nil(t, err)
+	// an unmatched author adds b.go
+	_, err = companies.Consume(fixtureCompanyAttributionDeps(
+		identity.AuthorMissing, "x@example.org", time.May,
+		object.Changes{{To: entry("b.go", blobB)}}, nil,
+		map[plumbing.Hash]*object.Blob{blobB.Hash: blobB}))
+	assert.Nil(t, err)
+	// Google deletes a.go
+	_, err = companies.Consume(fixtureCompanyAttributionDeps(2, "y@google.com", time.July,
+		object.Changes{{From: entry("a.go", blobB)}}, nil, nil))
+	assert.Nil(t, err)
+	assert.Len(t, companies.files, 1)
+	res := companies.Finalize().(CompanyAttributionResult)
+	assert.Equal(t, res.Quarters, map[string]map[string]CompanyAttributionStats{
+		"2018Q1": {"source{d}": {Commits: 1, Lines: 4}},
+		"2018Q2": {
+			"source{d}":   {Lines: 2},
+			"Google":      {Commits: 1, Lines: 1},
+			"example.org": {Commits: 1, Lines: 2},
+		},
+		"2018Q3": {
+			"Google":      {Commits: 1},
+			"example.org": {Lines: 2},
+		},
+	})
+}
+
+func TestCompanyAttributionSerialize(t *testing.T) {
+	companies := fixtureCompanyAttribution()
+	res := CompanyAttributionResult{Quarters: map[string]map[string]CompanyAttributionStats{
+		"2018Q2": {
+			"source{d}": {Lines: 2},
+			"Google":    {Commits: 1, Lines: 6},
+		},
+		"2018Q1": {"source{d}": {Commits: 3, Lines: 4}},
+	}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, companies.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  # commits, lines, commit share, line share
+  quarters:
+    "2018Q1":
+      "source{d}": [3, 4, 1.0000, 1.0000]
+    "2018Q2":
+      "Google": [1, 6, 1.0000, 0.7500]
+      "source{d}": [0, 2, 0.0000, 0.2500]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, companies.Serialize(res, true, buffer))
+	msg := pb.CompanyAttributionResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Quarters, 2)
+	assert.Equal(t, *msg.Quarters["2018Q2"].Companies["Google"],
+		pb.CompanyAttributionStats{Commits: 1, Lines: 6})
+	assert.Equal(t, msg.Quarters["2018Q1"].Companies["source{d}"].Commits, int32(3))
+}
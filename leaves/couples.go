@@ -1,8 +1,11 @@
 package leaves
 
 import (
+	"encoding/gob"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"sort"
 
 	"github.com/gogo/protobuf/proto"
@@ -22,6 +25,20 @@ import (
 type CouplesAnalysis struct {
 	// PeopleNumber is the number of developers for which to build the matrix. 0 disables this analysis.
 	PeopleNumber int
+	// TrackPeopleFilesMatrix enables the people × files co-occurrence matrix
+	// in CouplesResult.PeopleFilesMatrix.
+	TrackPeopleFilesMatrix bool
+	// DiskDir is the directory for the temporary files with the files co-occurrence counters.
+	// If it is empty, the counters are kept in memory.
+	DiskDir string
+	// TopFiles is the number of the most coupled files to write; the rest are merged into
+	// TopOtherName. 0 writes all the files.
+	TopFiles int
+	// Clusters is the number of the groups of the coupled files to find, e.g. the architectural
+	// components. 0 disables the clustering.
+	Clusters int
+	// ClusteringMethod is either CouplesClusteringHierarchical or CouplesClusteringSpectral.
+	ClusteringMethod string
 
 	// people store how many times every developer committed to every file.
 	people []map[string]int
@@ -29,10 +46,23 @@ type CouplesAnalysis struct {
 	peopleCommits []int
 	// files store every file occurred in the same commit with every other file.
 	files map[string]map[string]int
+	// disk replaces files if DiskDir is set.
+	disk *couplesDiskStorage
+	// renames are the renames and the removals of the files in a forked branch, they are nil
+	// in the main line. See Fork().
+	renames []couplesRename
+	// merged are the branches which the next commit merges, see Merge().
+	merged []*CouplesAnalysis
 	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
 	reversedPeopleDict []string
 }
 
+// couplesRename is a renamed file or, if To is empty, a removed file.
+type couplesRename struct {
+	From string
+	To   string
+}
+
 // CouplesResult is returned by CouplesAnalysis.Finalize() and carries couples matrices from
 // authors and files.
 type CouplesResult struct {
@@ -40,11 +70,36 @@ type CouplesResult struct {
 	PeopleFiles  [][]int
 	FilesMatrix  []map[int]int64
 	Files        []string
+	// PeopleFilesMatrix is the bipartite author × file matrix: the cell at row X and column Y
+	// is the number of commits by X which changed Y. The last row is the unidentified authors.
+	// The matrix is empty unless CouplesAnalysis.TrackPeopleFilesMatrix is set.
+	PeopleFilesMatrix []map[int]int64
+	// FileClusters are the groups of the files in Files, the biggest is 0. It is empty unless
+	// CouplesAnalysis.Clusters is set.
+	FileClusters []int
 
 	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
 	reversedPeopleDict []string
 }
 
+const (
+	// ConfigCouplesPeopleFilesMatrix is the name of the configuration option
+	// (CouplesAnalysis.Configure()) which enables the people × files co-occurrence matrix.
+	ConfigCouplesPeopleFilesMatrix = "Couples.PeopleFilesMatrix"
+	// ConfigCouplesDiskDir is the name of the configuration option (CouplesAnalysis.Configure())
+	// which sets the directory for the temporary files with the files co-occurrence counters.
+	ConfigCouplesDiskDir = "Couples.DiskDir"
+	// ConfigCouplesTopFiles is the name of the configuration option (CouplesAnalysis.Configure())
+	// which sets the number of the most coupled files to write.
+	ConfigCouplesTopFiles = "Couples.TopFiles"
+	// ConfigCouplesClusters is the name of the configuration option (CouplesAnalysis.Configure())
+	// which sets the number of the clusters of the coupled files.
+	ConfigCouplesClusters = "Couples.Clusters"
+	// ConfigCouplesClusteringMethod is the name of the configuration option
+	// (CouplesAnalysis.Configure()) which chooses the clustering algorithm.
+	ConfigCouplesClusteringMethod = "Couples.ClusteringMethod"
+)
+
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
 func (couples *CouplesAnalysis) Name() string {
 	return "Couples"
@@ -67,7 +122,38 @@ func (couples *CouplesAnalysis) Requires() []string {
 
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
 func (couples *CouplesAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
-	return []core.ConfigurationOption{}
+	opts := [...]core.ConfigurationOption{{
+		Name:        ConfigCouplesPeopleFilesMatrix,
+		Description: "Record the number of commits by each author to each file.",
+		Flag:        "couples-people-files",
+		Type:        core.BoolConfigurationOption,
+		Default:     false}, {
+		Name: ConfigCouplesDiskDir,
+		Description: "Keep the files co-occurrence counters in the temporary files in this " +
+			"directory instead of memory. Slower, but the memory stays flat in huge repositories.",
+		Flag:    "couples-disk-dir",
+		Type:    core.StringConfigurationOption,
+		Default: ""}, {
+		Name: ConfigCouplesTopFiles,
+		Description: "Write only this number of the most coupled files and merge the rest " +
+			"into \"" + TopOtherName + "\". 0 writes all the files.",
+		Flag:    "couples-top-files",
+		Type:    core.IntConfigurationOption,
+		Default: 0}, {
+		Name: ConfigCouplesClusters,
+		Description: "Group the files into this number of clusters by how often they change " +
+			"together. 0 disables the clustering.",
+		Flag:    "couples-clusters",
+		Type:    core.IntConfigurationOption,
+		Default: 0}, {
+		Name: ConfigCouplesClusteringMethod,
+		Description: "The clustering algorithm: \"" + CouplesClusteringHierarchical +
+			"\" (average linkage) or \"" + CouplesClusteringSpectral + "\".",
+		Flag:    "couples-clustering",
+		Type:    core.StringConfigurationOption,
+		Default: CouplesClusteringHierarchical},
+	}
+	return opts[:]
 }
 
 // Configure sets the properties previously published by ListConfigurationOptions().
@@ -76,6 +162,29 @@ func (couples *CouplesAnalysis) Configure(facts map[string]interface{}) {
 		couples.PeopleNumber = val
 		couples.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
 	}
+	if val, exists := facts[ConfigCouplesPeopleFilesMatrix].(bool); exists {
+		couples.TrackPeopleFilesMatrix = val
+	}
+	if val, exists := facts[ConfigCouplesDiskDir].(string); exists {
+		couples.DiskDir = val
+	}
+	if val, exists := facts[ConfigCouplesTopFiles].(int); exists {
+		couples.TopFiles = val
+	}
+	if val, exists := facts[ConfigCouplesClusters].(int); exists {
+		couples.Clusters = val
+	}
+	if val, exists := facts[ConfigCouplesClusteringMethod].(string); exists {
+		couples.ClusteringMethod = val
+	}
+	if couples.ClusteringMethod != CouplesClusteringHierarchical &&
+		couples.ClusteringMethod != CouplesClusteringSpectral {
+		if couples.ClusteringMethod != "" {
+			log.Printf("Warning: unknown clustering method %s => reset to %s\n",
+				couples.ClusteringMethod, CouplesClusteringHierarchical)
+		}
+		couples.ClusteringMethod = CouplesClusteringHierarchical
+	}
 }
 
 // Flag for the command line switch which enables this analysis.
@@ -92,6 +201,20 @@ func (couples *CouplesAnalysis) Initialize(repository *git.Repository) {
 	}
 	couples.peopleCommits = make([]int, couples.PeopleNumber+1)
 	couples.files = map[string]map[string]int{}
+	couples.renames = nil
+	couples.merged = nil
+	if couples.disk != nil {
+		couples.disk.close()
+		couples.disk = nil
+	}
+	if couples.DiskDir != "" {
+		disk, err := newCouplesDiskStorage(couples.DiskDir)
+		if err != nil {
+			log.Printf("Warning: keeping the couples in memory: %v\n", err)
+		} else {
+			couples.disk = disk
+		}
+	}
 }
 
 // Consume runs this PipelineItem on the next commit data.
@@ -100,6 +223,11 @@ func (couples *CouplesAnalysis) Initialize(repository *git.Repository) {
 // This function returns the mapping with analysis results. The keys must be the same as
 // in Provides(). If there was an error, nil is returned.
 func (couples *CouplesAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if couples.merged != nil {
+		if err := couples.mergeBranches(); err != nil {
+			return nil, err
+		}
+	}
 	author := deps[identity.DependencyAuthor].(int)
 	if author == identity.AuthorMissing {
 		author = couples.PeopleNumber
@@ -107,13 +235,6 @@ func (couples *CouplesAnalysis) Consume(deps map[string]interface{}) (map[string
 	couples.peopleCommits[author]++
 	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
 	context := make([]string, 0)
-	deleteFile := func(name string) {
-		// we do not remove the file from people - the context does not expire
-		delete(couples.files, name)
-		for _, otherFiles := range couples.files {
-			delete(otherFiles, name)
-		}
-	}
 	for _, change := range treeDiff {
 		action, err := change.Action()
 		if err != nil {
@@ -126,31 +247,19 @@ func (couples *CouplesAnalysis) Consume(deps map[string]interface{}) (map[string
 			context = append(context, toName)
 			couples.people[author][toName]++
 		case merkletrie.Delete:
-			deleteFile(fromName)
+			couples.removeFile(fromName)
 			couples.people[author][fromName]++
 		case merkletrie.Modify:
 			if fromName != toName {
-				// renamed
-				couples.files[toName] = couples.files[fromName]
-				for _, otherFiles := range couples.files {
-					val, exists := otherFiles[fromName]
-					if exists {
-						otherFiles[toName] = val
-					}
-				}
-				deleteFile(fromName)
-				for _, authorFiles := range couples.people {
-					val, exists := authorFiles[fromName]
-					if exists {
-						authorFiles[toName] = val
-						delete(authorFiles, fromName)
-					}
-				}
+				couples.renameFile(fromName, toName)
 			}
 			context = append(context, toName)
 			couples.people[author][toName]++
 		}
 	}
+	if couples.disk != nil {
+		return nil, couples.disk.add(context)
+	}
 	for _, file := range context {
 		for _, otherFile := range context {
 			lane, exists := couples.files[file]
@@ -164,13 +273,131 @@ func (couples *CouplesAnalysis) Consume(deps map[string]interface{}) (map[string
 	return nil, nil
 }
 
+// removeFile forgets the co-occurrences of the deleted file.
+func (couples *CouplesAnalysis) removeFile(name string) {
+	if couples.renames != nil {
+		couples.renames = append(couples.renames, couplesRename{From: name})
+	}
+	couples.forgetFile(name)
+}
+
+// forgetFile deletes the co-occurrences of the file.
+func (couples *CouplesAnalysis) forgetFile(name string) {
+	// we do not remove the file from people - the context does not expire
+	if couples.disk != nil {
+		couples.disk.remove(name)
+		return
+	}
+	delete(couples.files, name)
+	for _, otherFiles := range couples.files {
+		delete(otherFiles, name)
+	}
+}
+
+// renameFile moves the counters of the file to the new name.
+func (couples *CouplesAnalysis) renameFile(from, to string) {
+	if couples.disk != nil {
+		couples.disk.rename(from, to)
+	} else {
+		if lane, exists := couples.files[from]; exists {
+			couples.files[to] = lane
+		}
+		for _, otherFiles := range couples.files {
+			val, exists := otherFiles[from]
+			if exists {
+				otherFiles[to] = val
+			}
+		}
+		couples.forgetFile(from)
+	}
+	for _, authorFiles := range couples.people {
+		val, exists := authorFiles[from]
+		if exists {
+			authorFiles[to] = val
+			delete(authorFiles, from)
+		}
+	}
+	if couples.renames != nil {
+		couples.renames = append(couples.renames, couplesRename{From: from, To: to})
+	}
+}
+
+// Fork creates the branches which count the commits from scratch, see
+// core.ForkablePipelineItem. They keep the counters in memory.
+func (couples *CouplesAnalysis) Fork(n int) []core.PipelineItem {
+	clones := make([]core.PipelineItem, n)
+	for i := range clones {
+		clone := *couples
+		clone.disk = nil
+		clone.people = make([]map[string]int, couples.PeopleNumber+1)
+		for j := range clone.people {
+			clone.people[j] = map[string]int{}
+		}
+		clone.peopleCommits = make([]int, couples.PeopleNumber+1)
+		clone.files = map[string]map[string]int{}
+		clone.renames = []couplesRename{}
+		clone.merged = nil
+		clones[i] = &clone
+	}
+	return clones
+}
+
+// Merge schedules adding the counters of the branches in the next Consume(),
+// see core.ForkablePipelineItem.
+func (couples *CouplesAnalysis) Merge(branches []core.PipelineItem) {
+	for _, branch := range branches {
+		couples.merged = append(couples.merged, branch.(*CouplesAnalysis))
+	}
+}
+
+// mergeBranches repeats the renames and the removals of the merged branches and adds
+// their counters.
+func (couples *CouplesAnalysis) mergeBranches() error {
+	for _, branch := range couples.merged {
+		for _, rename := range branch.renames {
+			if rename.To == "" {
+				couples.removeFile(rename.From)
+			} else {
+				couples.renameFile(rename.From, rename.To)
+			}
+		}
+		for i, files := range branch.people {
+			for file, commits := range files {
+				couples.people[i][file] += commits
+			}
+			couples.peopleCommits[i] += branch.peopleCommits[i]
+		}
+		for file, lane := range branch.files {
+			for otherFile, cooccs := range lane {
+				if couples.disk != nil {
+					if err := couples.disk.addCount(file, otherFile, int64(cooccs)); err != nil {
+						return err
+					}
+					continue
+				}
+				ownLane, exists := couples.files[file]
+				if !exists {
+					ownLane = map[string]int{}
+					couples.files[file] = ownLane
+				}
+				ownLane[otherFile] += cooccs
+			}
+		}
+	}
+	couples.merged = nil
+	return nil
+}
+
 // Finalize returns the result of the analysis. Further Consume() calls are not expected.
 func (couples *CouplesAnalysis) Finalize() interface{} {
-	filesSequence := make([]string, len(couples.files))
-	i := 0
-	for file := range couples.files {
-		filesSequence[i] = file
-		i++
+	var filesSequence []string
+	if couples.disk != nil {
+		filesSequence = couples.disk.files()
+	} else {
+		filesSequence = make([]string, 0, len(couples.files))
+		for file := range couples.files {
+			filesSequence = append(filesSequence, file)
+		}
 	}
 	sort.Strings(filesSequence)
 	filesIndex := map[string]int{}
@@ -202,25 +429,95 @@ func (couples *CouplesAnalysis) Finalize() interface{} {
 	}
 
 	filesMatrix := make([]map[int]int64, len(filesIndex))
+	if couples.disk != nil {
+		err := couples.disk.rows(filesIndex, func(row int, columns map[int]int64) {
+			filesMatrix[row] = columns
+		})
+		if err != nil {
+			panic(err)
+		}
+		couples.disk.close()
+		couples.disk = nil
+	}
 	for i := range filesMatrix {
+		if filesMatrix[i] != nil {
+			continue
+		}
 		filesMatrix[i] = map[int]int64{}
 		for otherFile, cooccs := range couples.files[filesSequence[i]] {
 			filesMatrix[i][filesIndex[otherFile]] = int64(cooccs)
 		}
 	}
+
+	var peopleFilesMatrix []map[int]int64
+	if couples.TrackPeopleFilesMatrix {
+		peopleFilesMatrix = make([]map[int]int64, couples.PeopleNumber+1)
+		for i := range peopleFilesMatrix {
+			peopleFilesMatrix[i] = map[int]int64{}
+			for file, commits := range couples.people[i] {
+				if fi, exists := filesIndex[file]; exists {
+					peopleFilesMatrix[i][fi] = int64(commits)
+				}
+			}
+		}
+	}
 	return CouplesResult{
 		PeopleMatrix:       peopleMatrix,
 		PeopleFiles:        peopleFiles,
 		Files:              filesSequence,
 		FilesMatrix:        filesMatrix,
+		PeopleFilesMatrix:  peopleFilesMatrix,
 		reversedPeopleDict: couples.reversedPeopleDict,
 	}
 }
 
+// couplesState is the format of CouplesAnalysis.DumpState().
+type couplesState struct {
+	People        []map[string]int
+	PeopleCommits []int
+	Files         map[string]map[string]int
+}
+
+// DumpState writes the co-occurrence counters, see core.PersistentPipelineItem.
+// The counters on disk are not supported.
+func (couples *CouplesAnalysis) DumpState(writer io.Writer) error {
+	if couples.DiskDir != "" {
+		return errors.New("the counters on disk cannot be saved")
+	}
+	return gob.NewEncoder(writer).Encode(&couplesState{
+		People:        couples.people,
+		PeopleCommits: couples.peopleCommits,
+		Files:         couples.files,
+	})
+}
+
+// LoadState restores the state written by DumpState().
+func (couples *CouplesAnalysis) LoadState(reader io.Reader) error {
+	if couples.DiskDir != "" {
+		return errors.New("the counters on disk cannot be loaded")
+	}
+	state := couplesState{}
+	if err := gob.NewDecoder(reader).Decode(&state); err != nil {
+		return err
+	}
+	if len(state.People) != couples.PeopleNumber+1 {
+		return fmt.Errorf("the number of developers has changed from %d to %d",
+			len(state.People)-1, couples.PeopleNumber)
+	}
+	couples.people = state.People
+	couples.peopleCommits = state.PeopleCommits
+	couples.files = state.Files
+	return nil
+}
+
 // Serialize converts the analysis result as returned by Finalize() to text or bytes.
 // The text format is YAML and the bytes format is Protocol Buffers.
 func (couples *CouplesAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
-	couplesResult := result.(CouplesResult)
+	couplesResult := truncateCouplesFiles(result.(CouplesResult), couples.TopFiles)
+	if couples.Clusters > 0 {
+		couplesResult.FileClusters = clusterCouples(
+			couplesResult.FilesMatrix, couples.Clusters, couples.ClusteringMethod)
+	}
 	if binary {
 		return couples.serializeBinary(&couplesResult, writer)
 	}
@@ -228,6 +525,11 @@ func (couples *CouplesAnalysis) Serialize(result interface{}, binary bool, write
 	return nil
 }
 
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (couples *CouplesAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(couples, result, &pb.CouplesAnalysisResults{}, writer)
+}
+
 // Deserialize converts the specified protobuf bytes to CouplesResult.
 func (couples *CouplesAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
 	message := pb.CouplesAnalysisResults{}
@@ -261,6 +563,12 @@ func (couples *CouplesAnalysis) Deserialize(pbmessage []byte) (interface{}, erro
 	}
 	convertCSR(result.FilesMatrix, message.FileCouples.Matrix)
 	convertCSR(result.PeopleMatrix, message.PeopleCouples.Matrix)
+	if message.PeopleFilesMatrix != nil {
+		result.PeopleFilesMatrix = pb.CompressedSparseRowMatrixToMap(message.PeopleFilesMatrix)
+	}
+	for _, cluster := range message.FileClusters {
+		result.FileClusters = append(result.FileClusters, int(cluster))
+	}
 	return result, nil
 }
 
@@ -338,9 +646,86 @@ func (couples *CouplesAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.Co
 	}
 	addFiles(cr1.FilesMatrix, cr1.Files)
 	addFiles(cr2.FilesMatrix, cr2.Files)
+	if len(cr1.PeopleFilesMatrix) > 0 || len(cr2.PeopleFilesMatrix) > 0 {
+		merged.PeopleFilesMatrix = make([]map[int]int64, len(merged.reversedPeopleDict)+1)
+		for i := range merged.PeopleFilesMatrix {
+			merged.PeopleFilesMatrix[i] = map[int]int64{}
+		}
+		addPeopleFilesMatrix := func(peopleFilesMatrix []map[int]int64, reversedPeopleDict []string,
+			reversedFilesDict []string) {
+			for pi, pf := range peopleFilesMatrix {
+				idx := len(merged.reversedPeopleDict)
+				if pi < len(reversedPeopleDict) {
+					idx = people[reversedPeopleDict[pi]][0]
+				}
+				m := merged.PeopleFilesMatrix[idx]
+				for file, val := range pf {
+					m[files[reversedFilesDict[file]][0]] += val
+				}
+			}
+		}
+		addPeopleFilesMatrix(cr1.PeopleFilesMatrix, cr1.reversedPeopleDict, cr1.Files)
+		addPeopleFilesMatrix(cr2.PeopleFilesMatrix, cr2.reversedPeopleDict, cr2.Files)
+	}
 	return merged
 }
 
+// truncateCouplesFiles keeps the k files with the most co-occurrences and merges the rest
+// into TopOtherName. The result is not changed if k is 0.
+func truncateCouplesFiles(result CouplesResult, k int) CouplesResult {
+	weights := make([]int64, len(result.FilesMatrix))
+	for i, row := range result.FilesMatrix {
+		for _, val := range row {
+			weights[i] += val
+		}
+	}
+	remap := topRemap(weights, k)
+	if remap == nil {
+		return result
+	}
+	remapRows := func(matrix []map[int]int64, rows int, remapRow func(int) int) []map[int]int64 {
+		truncated := make([]map[int]int64, rows)
+		for i := range truncated {
+			truncated[i] = map[int]int64{}
+		}
+		for i, row := range matrix {
+			newRow := truncated[remapRow(i)]
+			for j, val := range row {
+				newRow[remap[j]] += val
+			}
+		}
+		return truncated
+	}
+	files := make([]string, k+1)
+	for i, file := range result.Files {
+		if remap[i] < k {
+			files[remap[i]] = file
+		}
+	}
+	files[k] = TopOtherName
+	result.Files = files
+	// the clusters of the merged files are meaningless
+	result.FileClusters = nil
+	result.FilesMatrix = remapRows(result.FilesMatrix, k+1, func(i int) int { return remap[i] })
+	if len(result.PeopleFilesMatrix) > 0 {
+		result.PeopleFilesMatrix = remapRows(
+			result.PeopleFilesMatrix, len(result.PeopleFilesMatrix), func(i int) int { return i })
+	}
+	peopleFiles := make([][]int, len(result.PeopleFiles))
+	for i, personFiles := range result.PeopleFiles {
+		seen := map[int]bool{}
+		for _, file := range personFiles {
+			if !seen[remap[file]] {
+				seen[remap[file]] = true
+				peopleFiles[i] = append(peopleFiles[i], remap[file])
+			}
+		}
+		sort.Ints(peopleFiles[i])
+	}
+	result.PeopleFiles = peopleFiles
+	return result
+}
+
 func (couples *CouplesAnalysis) serializeText(result *CouplesResult, writer io.Writer) {
 	fmt.Fprintln(writer, "  files_coocc:")
 	fmt.Fprintln(writer, "    index:")
@@ -364,6 +749,17 @@ func (couples *CouplesAnalysis) serializeText(result *CouplesResult, writer io.W
 		}
 		fmt.Fprintln(writer, "}")
 	}
+	if len(result.FileClusters) > 0 {
+		// the same order as in index
+		fmt.Fprint(writer, "    clusters: [")
+		for i, cluster := range result.FileClusters {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprint(writer, cluster)
+		}
+		fmt.Fprintln(writer, "]")
+	}
 
 	fmt.Fprintln(writer, "  people_coocc:")
 	fmt.Fprintln(writer, "    index:")
@@ -397,6 +793,28 @@ func (couples *CouplesAnalysis) serializeText(result *CouplesResult, writer io.W
 			fmt.Fprintf(writer, "        - %s\n", yaml.SafeString(file)) // sorted by path
 		}
 	}
+
+	if len(result.PeopleFilesMatrix) == 0 {
+		return
+	}
+	// rows correspond to people_coocc::index, columns correspond to files_coocc::index
+	fmt.Fprintln(writer, "  people_files_coocc:")
+	fmt.Fprintln(writer, "    matrix:")
+	for _, files := range result.PeopleFilesMatrix {
+		fmt.Fprint(writer, "      - {")
+		indices := []int{}
+		for file := range files {
+			indices = append(indices, file)
+		}
+		sort.Ints(indices)
+		for i, file := range indices {
+			fmt.Fprintf(writer, "%d: %d", file, files[file])
+			if i < len(indices)-1 {
+				fmt.Fprint(writer, ", ")
+			}
+		}
+		fmt.Fprintln(writer, "}")
+	}
 }
 
 func sortByNumberOfFiles(
@@ -411,7 +829,8 @@ func sortByNumberOfFiles(
 			pfl = append(pfl, authorFiles{peopleDict[peopleIdx], fileNames})
 		}
 	}
-	sort.Sort(pfl)
+	// stable to keep the people order among the authors with the same number of files
+	sort.Stable(pfl)
 	return pfl
 }
 
@@ -454,6 +873,13 @@ func (couples *CouplesAnalysis) serializeBinary(result *CouplesResult, writer io
 			Files: int32Files,
 		}
 	}
+	if len(result.PeopleFilesMatrix) > 0 {
+		message.PeopleFilesMatrix = pb.MapToCompressedSparseRowMatrix(result.PeopleFilesMatrix)
+		message.PeopleFilesMatrix.NumberOfColumns = int32(len(result.Files))
+	}
+	for _, cluster := range result.FileClusters {
+		message.FileClusters = append(message.FileClusters, int32(cluster))
+	}
 
 	serialized, err := proto.Marshal(&message)
 	if err != nil {
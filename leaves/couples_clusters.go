@@ -0,0 +1,396 @@
+package leaves
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+const (
+	// CouplesClusteringHierarchical is the average linkage agglomerative clustering of the files.
+	CouplesClusteringHierarchical = "hierarchical"
+	// CouplesClusteringSpectral is the normalized spectral clustering of the files followed by
+	// k-means in the space of the eigenvectors.
+	CouplesClusteringSpectral = "spectral"
+
+	// couplesSpectralIterations is the maximum number of the subspace iterations which find
+	// the eigenvectors.
+	couplesSpectralIterations = 300
+	// couplesKMeansIterations is the maximum number of the k-means iterations.
+	couplesKMeansIterations = 100
+)
+
+// couplesGraph is the symmetric sparse similarity matrix of the files in CSR format with
+// the sorted columns, so that the floating point sums do not depend on the map order.
+type couplesGraph struct {
+	indptr  []int
+	indices []int
+	weights []float64
+}
+
+// newCouplesGraph converts the co-occurrence matrix to the similarities: the number of the
+// common commits divided by the geometric mean of the numbers of the commits of each file
+// (the Ochiai coefficient). The diagonal is excluded.
+func newCouplesGraph(matrix []map[int]int64) *couplesGraph {
+	graph := &couplesGraph{indptr: make([]int, len(matrix)+1)}
+	for i, row := range matrix {
+		columns := make([]int, 0, len(row))
+		for j := range row {
+			columns = append(columns, j)
+		}
+		sort.Ints(columns)
+		for _, j := range columns {
+			if j == i || j >= len(matrix) || row[j] <= 0 {
+				continue
+			}
+			norm := float64(matrix[i][i]) * float64(matrix[j][j])
+			if norm <= 0 {
+				continue
+			}
+			graph.indices = append(graph.indices, j)
+			graph.weights = append(graph.weights, float64(row[j])/math.Sqrt(norm))
+		}
+		graph.indptr[i+1] = len(graph.indices)
+	}
+	return graph
+}
+
+func (graph *couplesGraph) size() int {
+	return len(graph.indptr) - 1
+}
+
+// clusterCouples assigns each file in the co-occurrence matrix to one of k clusters with
+// the specified method. The clusters are numbered by decreasing size. The files which are
+// not coupled to any others cannot be placed reasonably, so the hierarchical method leaves them
+// alone and the result may contain more than k clusters.
+func clusterCouples(matrix []map[int]int64, k int, method string) []int {
+	graph := newCouplesGraph(matrix)
+	if k <= 0 || graph.size() == 0 {
+		return nil
+	}
+	var labels []int
+	if graph.size() <= k {
+		labels = make([]int, graph.size())
+		for i := range labels {
+			labels[i] = i
+		}
+	} else if method == CouplesClusteringSpectral {
+		labels = clusterCouplesSpectral(graph, k)
+	} else {
+		labels = clusterCouplesHierarchical(graph, k)
+	}
+	return relabelClustersBySize(labels)
+}
+
+// couplesLink is the average similarity between two clusters in the agglomerative clustering.
+// The link is stale if either cluster changed after it was pushed.
+type couplesLink struct {
+	a, b               int
+	versionA, versionB int
+	average            float64
+}
+
+type couplesLinkHeap []couplesLink
+
+func (h couplesLinkHeap) Len() int {
+	return len(h)
+}
+
+func (h couplesLinkHeap) Less(i, j int) bool {
+	if h[i].average != h[j].average {
+		return h[i].average > h[j].average
+	}
+	if h[i].a != h[j].a {
+		return h[i].a < h[j].a
+	}
+	return h[i].b < h[j].b
+}
+
+func (h couplesLinkHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *couplesLinkHeap) Push(x interface{}) {
+	*h = append(*h, x.(couplesLink))
+}
+
+func (h *couplesLinkHeap) Pop() interface{} {
+	n := len(*h)
+	x := (*h)[n-1]
+	*h = (*h)[:n-1]
+	return x
+}
+
+// clusterCouplesHierarchical merges the clusters with the highest average similarity
+// until k clusters remain or no clusters are coupled.
+func clusterCouplesHierarchical(graph *couplesGraph, k int) []int {
+	n := graph.size()
+	// sums[a][b] is the sum of the similarities between the members of the clusters a and b
+	sums := make([]map[int]float64, n)
+	sizes := make([]int, n)
+	versions := make([]int, n)
+	parents := make([]int, n)
+	for i := range sums {
+		sums[i] = map[int]float64{}
+		sizes[i] = 1
+		parents[i] = i
+	}
+	for i := 0; i < n; i++ {
+		for p := graph.indptr[i]; p < graph.indptr[i+1]; p++ {
+			j := graph.indices[p]
+			// symmetrize in case the matrix is not exactly symmetric
+			sums[i][j] += graph.weights[p] / 2
+			sums[j][i] += graph.weights[p] / 2
+		}
+	}
+	links := &couplesLinkHeap{}
+	push := func(a, b int) {
+		if a > b {
+			a, b = b, a
+		}
+		heap.Push(links, couplesLink{
+			a: a, b: b, versionA: versions[a], versionB: versions[b],
+			average: sums[a][b] / float64(sizes[a]*sizes[b])})
+	}
+	for i := range sums {
+		for j := range sums[i] {
+			if i < j {
+				push(i, j)
+			}
+		}
+	}
+	for clusters := n; clusters > k && links.Len() > 0; {
+		link := heap.Pop(links).(couplesLink)
+		a, b := link.a, link.b
+		if sums[a] == nil || sums[b] == nil ||
+			link.versionA != versions[a] || link.versionB != versions[b] {
+			continue
+		}
+		for x, sum := range sums[b] {
+			delete(sums[x], b)
+			if x != a {
+				sums[a][x] += sum
+				sums[x][a] = sums[a][x]
+			}
+		}
+		delete(sums[a], b)
+		sums[b] = nil
+		parents[b] = a
+		sizes[a] += sizes[b]
+		versions[a]++
+		clusters--
+		for x := range sums[a] {
+			push(a, x)
+		}
+	}
+	labels := make([]int, n)
+	for i := range labels {
+		root := i
+		for parents[root] != root {
+			root = parents[root]
+		}
+		labels[i] = root
+	}
+	return labels
+}
+
+// clusterCouplesSpectral embeds the files with the k leading eigenvectors of the normalized
+// similarity matrix and groups the embeddings with k-means.
+func clusterCouplesSpectral(graph *couplesGraph, k int) []int {
+	n := graph.size()
+	// every file is similar to itself, so that the isolated files do not break the normalization
+	degrees := make([]float64, n)
+	for i := 0; i < n; i++ {
+		degrees[i] = 1
+		for p := graph.indptr[i]; p < graph.indptr[i+1]; p++ {
+			degrees[i] += graph.weights[p]
+		}
+	}
+	// multiply computes (I + D^-1/2 (A + I) D^-1/2) / 2 * x; the shift makes the eigenvalues
+	// non-negative, so that the leading ones are the largest by the absolute value
+	multiply := func(x []float64, y []float64) {
+		for i := 0; i < n; i++ {
+			sum := x[i] / degrees[i]
+			for p := graph.indptr[i]; p < graph.indptr[i+1]; p++ {
+				j := graph.indices[p]
+				sum += graph.weights[p] * x[j] / math.Sqrt(degrees[i]*degrees[j])
+			}
+			y[i] = (x[i] + sum) / 2
+		}
+	}
+	random := rand.New(rand.NewSource(1))
+	vectors := make([][]float64, k)
+	for v := range vectors {
+		vectors[v] = make([]float64, n)
+		for i := range vectors[v] {
+			vectors[v][i] = random.Float64() - 0.5
+		}
+	}
+	orthonormalize(vectors)
+	next := make([][]float64, k)
+	for v := range next {
+		next[v] = make([]float64, n)
+	}
+	for iteration := 0; iteration < couplesSpectralIterations; iteration++ {
+		for v := range vectors {
+			multiply(vectors[v], next[v])
+		}
+		orthonormalize(next)
+		delta := 0.0
+		for v := range vectors {
+			dot := 0.0
+			for i := range vectors[v] {
+				dot += vectors[v][i] * next[v][i]
+			}
+			delta = math.Max(delta, 1-math.Abs(dot))
+		}
+		vectors, next = next, vectors
+		if delta < 1e-10 {
+			break
+		}
+	}
+	points := make([][]float64, n)
+	for i := range points {
+		points[i] = make([]float64, k)
+		norm := 0.0
+		for v := range vectors {
+			points[i][v] = vectors[v][i]
+			norm += vectors[v][i] * vectors[v][i]
+		}
+		if norm = math.Sqrt(norm); norm > 0 {
+			for v := range points[i] {
+				points[i][v] /= norm
+			}
+		}
+	}
+	return kMeans(points, k, random)
+}
+
+// orthonormalize applies the modified Gram-Schmidt process to the vectors in place.
+func orthonormalize(vectors [][]float64) {
+	for v := range vectors {
+		for u := 0; u < v; u++ {
+			dot := 0.0
+			for i := range vectors[v] {
+				dot += vectors[v][i] * vectors[u][i]
+			}
+			for i := range vectors[v] {
+				vectors[v][i] -= dot * vectors[u][i]
+			}
+		}
+		norm := 0.0
+		for _, x := range vectors[v] {
+			norm += x * x
+		}
+		if norm = math.Sqrt(norm); norm > 0 {
+			for i := range vectors[v] {
+				vectors[v][i] /= norm
+			}
+		}
+	}
+}
+
+// kMeans groups the points with Lloyd's algorithm starting from the k-means++ seeds.
+func kMeans(points [][]float64, k int, random *rand.Rand) []int {
+	distance := func(a, b []float64) float64 {
+		sum := 0.0
+		for i := range a {
+			sum += (a[i] - b[i]) * (a[i] - b[i])
+		}
+		return sum
+	}
+	centroids := [][]float64{append([]float64{}, points[random.Intn(len(points))]...)}
+	nearest := make([]float64, len(points))
+	for len(centroids) < k {
+		total := 0.0
+		for i, point := range points {
+			nearest[i] = math.Inf(1)
+			for _, centroid := range centroids {
+				nearest[i] = math.Min(nearest[i], distance(point, centroid))
+			}
+			total += nearest[i]
+		}
+		chosen := len(points) - 1
+		threshold := random.Float64() * total
+		for i, d := range nearest {
+			if threshold -= d; threshold < 0 {
+				chosen = i
+				break
+			}
+		}
+		centroids = append(centroids, append([]float64{}, points[chosen]...))
+	}
+	labels := make([]int, len(points))
+	for iteration := 0; iteration < couplesKMeansIterations; iteration++ {
+		changed := iteration == 0
+		for i, point := range points {
+			best := 0
+			for c := 1; c < k; c++ {
+				if distance(point, centroids[c]) < distance(point, centroids[best]) {
+					best = c
+				}
+			}
+			if labels[i] != best {
+				labels[i] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+		counts := make([]int, k)
+		for c := range centroids {
+			for d := range centroids[c] {
+				centroids[c][d] = 0
+			}
+		}
+		for i, point := range points {
+			counts[labels[i]]++
+			for d, x := range point {
+				centroids[labels[i]][d] += x
+			}
+		}
+		for c := range centroids {
+			if counts[c] == 0 {
+				continue
+			}
+			for d := range centroids[c] {
+				centroids[c][d] /= float64(counts[c])
+			}
+		}
+	}
+	return labels
+}
+
+// relabelClustersBySize numbers the clusters from the biggest to the smallest; the ties are
+// resolved by the first member.
+func relabelClustersBySize(labels []int) []int {
+	sizes := map[int]int{}
+	first := map[int]int{}
+	for i, label := range labels {
+		if _, exists := first[label]; !exists {
+			first[label] = i
+		}
+		sizes[label]++
+	}
+	order := make([]int, 0, len(sizes))
+	for label := range sizes {
+		order = append(order, label)
+	}
+	sort.Slice(order, func(i, j int) bool {
+		if sizes[order[i]] != sizes[order[j]] {
+			return sizes[order[i]] > sizes[order[j]]
+		}
+		return first[order[i]] < first[order[j]]
+	})
+	remap := map[int]int{}
+	for i, label := range order {
+		remap[label] = i
+	}
+	result := make([]int, len(labels))
+	for i, label := range labels {
+		result[i] = remap[label]
+	}
+	return result
+}
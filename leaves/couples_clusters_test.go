@@ -0,0 +1,88 @@
+package leaves
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+)
+
+// fixtureCouplesClusters are two groups of the files - {0, 1, 2} and {3, 4} - which are weakly
+// coupled through 2 and 3, plus the file 5 which is not coupled to any other.
+func fixtureCouplesClusters() []map[int]int64 {
+	return []map[int]int64{
+		{0: 10, 1: 8, 2: 7},
+		{0: 8, 1: 9, 2: 6},
+		{0: 7, 1: 6, 2: 10, 3: 1},
+		{2: 1, 3: 12, 4: 11},
+		{3: 11, 4: 11},
+		{5: 3},
+	}
+}
+
+func TestCouplesGraph(t *testing.T) {
+	graph := newCouplesGraph([]map[int]int64{{0: 4, 1: 2}, {0: 2, 1: 1}, {2: 5}})
+	assert.Equal(t, graph.indptr, []int{0, 1, 2, 2})
+	assert.Equal(t, graph.indices, []int{1, 0})
+	assert.InDelta(t, graph.weights[0], 1.0, 1e-9)
+	assert.InDelta(t, graph.weights[1], 1.0, 1e-9)
+}
+
+func TestCouplesClustersHierarchical(t *testing.T) {
+	labels := clusterCouples(fixtureCouplesClusters(), 3, CouplesClusteringHierarchical)
+	assert.Equal(t, labels, []int{0, 0, 0, 1, 1, 2})
+	labels = clusterCouples(fixtureCouplesClusters(), 2, CouplesClusteringHierarchical)
+	assert.Equal(t, labels, []int{0, 0, 0, 0, 0, 1})
+	// the isolated file stays alone
+	labels = clusterCouples(fixtureCouplesClusters(), 1, CouplesClusteringHierarchical)
+	assert.Equal(t, labels, []int{0, 0, 0, 0, 0, 1})
+	labels = clusterCouples(fixtureCouplesClusters(), 5, CouplesClusteringHierarchical)
+	assert.Equal(t, labels[3], labels[4])
+	assert.Len(t, clusterCouples(fixtureCouplesClusters(), 0, CouplesClusteringHierarchical), 0)
+}
+
+func TestCouplesClustersSpectral(t *testing.T) {
+	labels := clusterCouples(fixtureCouplesClusters(), 3, CouplesClusteringSpectral)
+	assert.Equal(t, labels, []int{0, 0, 0, 1, 1, 2})
+	assert.Equal(t, clusterCouples(fixtureCouplesClusters(), 3, CouplesClusteringSpectral), labels)
+	labels = clusterCouples(fixtureCouplesClusters(), 10, CouplesClusteringSpectral)
+	assert.Equal(t, labels, []int{0, 1, 2, 3, 4, 5})
+}
+
+func TestRelabelClustersBySize(t *testing.T) {
+	assert.Equal(t, relabelClustersBySize([]int{7, 3, 3, 7, 3, 9}), []int{1, 0, 0, 1, 0, 2})
+	assert.Equal(t, relabelClustersBySize([]int{4, 2}), []int{0, 1})
+}
+
+func TestCouplesSerializeClusters(t *testing.T) {
+	c := fixtureCouples()
+	c.Clusters = 3
+	c.ClusteringMethod = CouplesClusteringHierarchical
+	result := CouplesResult{
+		Files:        []string{"a", "b", "c", "d", "e", "f"},
+		FilesMatrix:  fixtureCouplesClusters(),
+		PeopleFiles:  [][]int{{}, {}, {}},
+		PeopleMatrix: []map[int]int64{{}, {}, {}, {}},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, c.Serialize(result, false, buffer))
+	assert.True(t, strings.Contains(buffer.String(), "    clusters: [0, 0, 0, 1, 1, 2]\n"))
+	buffer.Reset()
+	assert.Nil(t, c.Serialize(result, true, buffer))
+	msg := pb.CouplesAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.FileClusters, []int32{0, 0, 0, 1, 1, 2})
+	deserialized, err := c.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized.(CouplesResult).FileClusters, []int{0, 0, 0, 1, 1, 2})
+	c.TopFiles = 3
+	buffer.Reset()
+	assert.Nil(t, c.Serialize(result, true, buffer))
+	msg = pb.CouplesAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.FileCouples.Index, 4)
+	assert.Len(t, msg.FileClusters, 4)
+}
@@ -0,0 +1,272 @@
+package leaves
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// couplesDiskBufferSize is the number of the distinct file pairs which couplesDiskStorage
+// accumulates in memory before it writes them to the next sorted run.
+const couplesDiskBufferSize = 1 << 20
+
+// couplesDiskStorage keeps the file co-occurrence counters of CouplesAnalysis in the temporary
+// files instead of the nested maps, so that the memory does not grow with the number of
+// the file pairs. The counters are buffered, sorted and appended to the disk as the runs;
+// rows() merges the runs and streams the aggregated matrix row by row. The files are identified
+// by the integer keys which follow the renames; a deleted file's key is never reused, so its
+// counters are simply skipped while merging.
+type couplesDiskStorage struct {
+	// dir is the temporary directory with the runs.
+	dir string
+	// ids maps the existing file names to their keys.
+	ids map[string]uint32
+	// nextID is the key of the next new file.
+	nextID uint32
+	// pending are the counters which are not written yet. The key is the pair of the file keys.
+	pending map[uint64]int64
+	// runs are the paths to the sorted runs.
+	runs []string
+	// bufferSize is the length of pending which triggers spill().
+	bufferSize int
+}
+
+// newCouplesDiskStorage creates the temporary directory for the runs inside parent.
+func newCouplesDiskStorage(parent string) (*couplesDiskStorage, error) {
+	dir, err := ioutil.TempDir(parent, "hercules-couples-")
+	if err != nil {
+		return nil, err
+	}
+	return &couplesDiskStorage{
+		dir:        dir,
+		ids:        map[string]uint32{},
+		pending:    map[uint64]int64{},
+		bufferSize: couplesDiskBufferSize,
+	}, nil
+}
+
+// add increments the counters of every pair of the files which were changed together,
+// including the pairs of each file with itself.
+func (storage *couplesDiskStorage) add(files []string) error {
+	keys := make([]uint64, len(files))
+	for i, file := range files {
+		keys[i] = uint64(storage.id(file))
+	}
+	for _, key := range keys {
+		for _, otherKey := range keys {
+			storage.pending[key<<32|otherKey]++
+		}
+		if len(storage.pending) >= storage.bufferSize {
+			if err := storage.spill(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addCount increases the counter of the pair of the files by count.
+func (storage *couplesDiskStorage) addCount(file, otherFile string, count int64) error {
+	storage.pending[uint64(storage.id(file))<<32|uint64(storage.id(otherFile))] += count
+	if len(storage.pending) >= storage.bufferSize {
+		return storage.spill()
+	}
+	return nil
+}
+
+// id returns the key of the file and assigns the next key to a new file.
+func (storage *couplesDiskStorage) id(file string) uint32 {
+	id, exists := storage.ids[file]
+	if !exists {
+		id = storage.nextID
+		storage.nextID++
+		storage.ids[file] = id
+	}
+	return id
+}
+
+// rename moves the counters of the file to the new name.
+func (storage *couplesDiskStorage) rename(from, to string) {
+	if id, exists := storage.ids[from]; exists {
+		storage.ids[to] = id
+		delete(storage.ids, from)
+	}
+}
+
+// remove forgets the file. Its counters are skipped in rows().
+func (storage *couplesDiskStorage) remove(name string) {
+	delete(storage.ids, name)
+}
+
+// files returns the names of the existing files, not sorted.
+func (storage *couplesDiskStorage) files() []string {
+	names := make([]string, 0, len(storage.ids))
+	for name := range storage.ids {
+		names = append(names, name)
+	}
+	return names
+}
+
+// spill writes the pending counters to a new sorted run.
+func (storage *couplesDiskStorage) spill() error {
+	if len(storage.pending) == 0 {
+		return nil
+	}
+	keys := make([]uint64, 0, len(storage.pending))
+	for key := range storage.pending {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	path := filepath.Join(storage.dir, fmt.Sprintf("run%d", len(storage.runs)))
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	buffer := make([]byte, 2*binary.MaxVarintLen64)
+	for _, key := range keys {
+		size := binary.PutUvarint(buffer, key)
+		size += binary.PutUvarint(buffer[size:], uint64(storage.pending[key]))
+		if _, err = writer.Write(buffer[:size]); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err = writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	storage.runs = append(storage.runs, path)
+	storage.pending = map[uint64]int64{}
+	return nil
+}
+
+// rows merges the runs and calls the callback for every row of the files matrix. index maps
+// the file names to the row and column numbers; the files which are absent are skipped.
+// The rows are not visited in the index order.
+func (storage *couplesDiskStorage) rows(
+	index map[string]int, callback func(row int, columns map[int]int64)) error {
+	if err := storage.spill(); err != nil {
+		return err
+	}
+	positions := make([]int, storage.nextID)
+	for i := range positions {
+		positions[i] = -1
+	}
+	for name, id := range storage.ids {
+		if pos, exists := index[name]; exists {
+			positions[id] = pos
+		}
+	}
+	merger := &couplesRunsHeap{}
+	for _, path := range storage.runs {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		run := &couplesRun{reader: bufio.NewReader(file)}
+		if ok, err := run.next(); err != nil {
+			return err
+		} else if ok {
+			merger.runs = append(merger.runs, run)
+		}
+	}
+	heap.Init(merger)
+	row, columns := -1, map[int]int64{}
+	flush := func() {
+		if row >= 0 && len(columns) > 0 {
+			callback(row, columns)
+		}
+		columns = map[int]int64{}
+	}
+	currentID := int64(-1)
+	for len(merger.runs) > 0 {
+		run := merger.runs[0]
+		key, count := run.key, run.count
+		if ok, err := run.next(); err != nil {
+			return err
+		} else if ok {
+			heap.Fix(merger, 0)
+		} else {
+			heap.Pop(merger)
+		}
+		id, otherID := int64(key>>32), key&0xffffffff
+		if id != currentID {
+			flush()
+			currentID = id
+			row = positions[id]
+		}
+		if row >= 0 && positions[otherID] >= 0 {
+			columns[positions[otherID]] += count
+		}
+	}
+	flush()
+	return nil
+}
+
+// close deletes the runs.
+func (storage *couplesDiskStorage) close() error {
+	return os.RemoveAll(storage.dir)
+}
+
+// couplesRun reads the counters from a sorted run.
+type couplesRun struct {
+	reader *bufio.Reader
+	key    uint64
+	count  int64
+}
+
+// next reads the following counter. It returns false at the end of the run.
+func (run *couplesRun) next() (bool, error) {
+	key, err := binary.ReadUvarint(run.reader)
+	if err == io.EOF {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	count, err := binary.ReadUvarint(run.reader)
+	if err != nil {
+		return false, err
+	}
+	run.key, run.count = key, int64(count)
+	return true, nil
+}
+
+// couplesRunsHeap orders the runs by their current keys.
+type couplesRunsHeap struct {
+	runs []*couplesRun
+}
+
+func (h *couplesRunsHeap) Len() int {
+	return len(h.runs)
+}
+
+func (h *couplesRunsHeap) Less(i, j int) bool {
+	return h.runs[i].key < h.runs[j].key
+}
+
+func (h *couplesRunsHeap) Swap(i, j int) {
+	h.runs[i], h.runs[j] = h.runs[j], h.runs[i]
+}
+
+func (h *couplesRunsHeap) Push(x interface{}) {
+	h.runs = append(h.runs, x.(*couplesRun))
+}
+
+func (h *couplesRunsHeap) Pop() interface{} {
+	n := len(h.runs)
+	x := h.runs[n-1]
+	h.runs = h.runs[:n-1]
+	return x
+}
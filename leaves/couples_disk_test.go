@@ -0,0 +1,88 @@
+package leaves
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func TestCouplesDiskStorage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-couples-test-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	storage, err := newCouplesDiskStorage(dir)
+	assert.Nil(t, err)
+	storage.bufferSize = 2
+	assert.Nil(t, storage.add([]string{"a", "b"}))
+	assert.Nil(t, storage.add([]string{"b", "c"}))
+	storage.rename("b", "d")
+	storage.rename("x", "y")
+	storage.remove("c")
+	assert.Nil(t, storage.add([]string{"a", "c"}))
+	assert.True(t, len(storage.runs) > 1)
+	files := storage.files()
+	assert.Len(t, files, 3)
+	assert.Contains(t, files, "a")
+	assert.Contains(t, files, "c")
+	assert.Contains(t, files, "d")
+	matrix := map[int]map[int]int64{}
+	assert.Nil(t, storage.rows(map[string]int{"a": 0, "c": 1, "d": 2},
+		func(row int, columns map[int]int64) {
+			assert.NotContains(t, matrix, row)
+			matrix[row] = columns
+		}))
+	// the deleted "c" does not share the counters with the new one
+	assert.Equal(t, matrix, map[int]map[int]int64{
+		0: {0: 2, 1: 1, 2: 1},
+		1: {0: 1, 1: 1},
+		2: {0: 1, 2: 2},
+	})
+	assert.Nil(t, storage.close())
+	_, err = os.Stat(storage.dir)
+	assert.True(t, os.IsNotExist(err))
+	_, err = newCouplesDiskStorage(filepath.Join(dir, "missing"))
+	assert.NotNil(t, err)
+}
+
+func TestCouplesDiskConsumeFinalize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-couples-test-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	results := make([]CouplesResult, 2)
+	for i, diskDir := range []string{"", dir} {
+		c := &CouplesAnalysis{PeopleNumber: 3, DiskDir: diskDir}
+		c.Initialize(test.Repository)
+		if diskDir != "" {
+			assert.NotNil(t, c.disk)
+			c.disk.bufferSize = 3
+		}
+		deps := map[string]interface{}{}
+		deps[identity.DependencyAuthor] = 0
+		deps[plumbing.DependencyTreeChanges] = generateChanges("+two", "+four", "+six")
+		c.Consume(deps)
+		deps[plumbing.DependencyTreeChanges] = generateChanges("+one", "-two", "=three", ">four>five")
+		c.Consume(deps)
+		deps[identity.DependencyAuthor] = 1
+		deps[plumbing.DependencyTreeChanges] = generateChanges("=one", "=three", "-six")
+		c.Consume(deps)
+		deps[identity.DependencyAuthor] = 2
+		deps[plumbing.DependencyTreeChanges] = generateChanges("=five", "+two")
+		c.Consume(deps)
+		results[i] = c.Finalize().(CouplesResult)
+		assert.Nil(t, c.disk)
+	}
+	assert.Equal(t, results[1], results[0])
+	assert.Equal(t, results[1].Files, []string{"five", "one", "three", "two"})
+	infos, err := ioutil.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Len(t, infos, 0)
+	c := &CouplesAnalysis{DiskDir: filepath.Join(dir, "missing")}
+	c.Initialize(test.Repository)
+	assert.Nil(t, c.disk)
+}
@@ -3,6 +3,7 @@ package leaves
 import (
 	"bytes"
 	"io/ioutil"
+	"os"
 	"path"
 	"strings"
 	"testing"
@@ -31,7 +32,55 @@ func TestCouplesMeta(t *testing.T) {
 	assert.Equal(t, c.Requires()[0], identity.DependencyAuthor)
 	assert.Equal(t, c.Requires()[1], plumbing.DependencyTreeChanges)
 	assert.Equal(t, c.Flag(), "couples")
-	assert.Len(t, c.ListConfigurationOptions(), 0)
+	assert.Len(t, c.ListConfigurationOptions(), 5)
+	assert.Equal(t, c.ListConfigurationOptions()[0].Name, ConfigCouplesPeopleFilesMatrix)
+	assert.Equal(t, c.ListConfigurationOptions()[1].Name, ConfigCouplesDiskDir)
+	assert.Equal(t, c.ListConfigurationOptions()[2].Name, ConfigCouplesTopFiles)
+	assert.Equal(t, c.ListConfigurationOptions()[3].Name, ConfigCouplesClusters)
+	assert.Equal(t, c.ListConfigurationOptions()[4].Name, ConfigCouplesClusteringMethod)
+	c.Configure(map[string]interface{}{ConfigCouplesDiskDir: "/tmp", ConfigCouplesTopFiles: 10})
+	assert.Equal(t, c.DiskDir, "/tmp")
+	assert.Equal(t, c.TopFiles, 10)
+	assert.Equal(t, c.ClusteringMethod, CouplesClusteringHierarchical)
+	c.Configure(map[string]interface{}{
+		ConfigCouplesClusters: 4, ConfigCouplesClusteringMethod: CouplesClusteringSpectral})
+	assert.Equal(t, c.Clusters, 4)
+	assert.Equal(t, c.ClusteringMethod, CouplesClusteringSpectral)
+	c.Configure(map[string]interface{}{ConfigCouplesClusteringMethod: "kmeans"})
+	assert.Equal(t, c.ClusteringMethod, CouplesClusteringHierarchical)
+}
+
+func TestCouplesTruncateFiles(t *testing.T) {
+	result := CouplesResult{
+		Files: []string{"a", "b", "c", "d"},
+		FilesMatrix: []map[int]int64{
+			{0: 3, 1: 2, 3: 1},
+			{0: 2, 1: 2},
+			{2: 1, 3: 1},
+			{0: 1, 2: 1, 3: 4},
+		},
+		PeopleFiles:       [][]int{{0, 2, 3}, {1}},
+		PeopleFilesMatrix: []map[int]int64{{0: 3, 2: 1, 3: 2}, {1: 2}},
+	}
+	truncated := truncateCouplesFiles(result, 2)
+	assert.Equal(t, truncated.Files, []string{"a", "d", TopOtherName})
+	assert.Equal(t, truncated.FilesMatrix, []map[int]int64{
+		{0: 3, 1: 1, 2: 2},
+		{0: 1, 1: 4, 2: 1},
+		{0: 2, 1: 1, 2: 3},
+	})
+	assert.Equal(t, truncated.PeopleFiles, [][]int{{0, 1, 2}, {2}})
+	assert.Equal(t, truncated.PeopleFilesMatrix, []map[int]int64{{0: 3, 1: 2, 2: 1}, {2: 2}})
+	assert.Equal(t, result.Files, []string{"a", "b", "c", "d"})
+	assert.Equal(t, truncateCouplesFiles(result, 0).Files, result.Files)
+	assert.Equal(t, truncateCouplesFiles(result, 4).Files, result.Files)
+	c := fixtureCouples()
+	c.TopFiles = 2
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, c.Serialize(result, true, buffer))
+	msg := pb.CouplesAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.FileCouples.Index, []string{"a", "d", TopOtherName})
 }
 
 func TestCouplesRegistration(t *testing.T) {
@@ -168,6 +217,82 @@ func TestCouplesConsumeFinalize(t *testing.T) {
 	assert.Equal(t, cr.FilesMatrix[2][2], int64(2))
 }
 
+func TestCouplesDumpLoad(t *testing.T) {
+	commits := []object.Changes{
+		generateChanges("+two", "+four", "+six"),
+		generateChanges("+one", "-two", "=three", ">four>five"),
+		generateChanges("=one", "=three", "-six"),
+	}
+	consume := func(c *CouplesAnalysis, changes object.Changes) {
+		deps := map[string]interface{}{}
+		deps[identity.DependencyAuthor] = 0
+		deps[plumbing.DependencyTreeChanges] = changes
+		c.Consume(deps)
+	}
+	c := fixtureCouples()
+	for _, changes := range commits {
+		consume(c, changes)
+	}
+	expected := c.Finalize()
+	c = fixtureCouples()
+	consume(c, commits[0])
+	consume(c, commits[1])
+	state := &bytes.Buffer{}
+	assert.Nil(t, c.DumpState(state))
+	data := state.Bytes()
+	restored := fixtureCouples()
+	assert.Nil(t, restored.LoadState(bytes.NewReader(data)))
+	consume(restored, commits[2])
+	assert.Equal(t, restored.Finalize(), expected)
+	restored = &CouplesAnalysis{PeopleNumber: 1}
+	restored.Initialize(test.Repository)
+	assert.NotNil(t, restored.LoadState(bytes.NewReader(data)))
+	assert.NotNil(t, restored.LoadState(bytes.NewBufferString("garbage")))
+	restored.DiskDir = "/tmp"
+	assert.NotNil(t, restored.DumpState(state))
+	assert.NotNil(t, restored.LoadState(bytes.NewReader(data)))
+}
+
+func TestCouplesForkMerge(t *testing.T) {
+	main := []object.Changes{generateChanges("+one", "+two", "+three"), generateChanges("=one")}
+	branch := []object.Changes{generateChanges(">two>four", "=one"), generateChanges("-three", "=four")}
+	consume := func(c *CouplesAnalysis, author int, changes object.Changes) {
+		_, err := c.Consume(map[string]interface{}{
+			identity.DependencyAuthor: author, plumbing.DependencyTreeChanges: changes})
+		assert.Nil(t, err)
+	}
+	c := fixtureCouples()
+	consume(c, 0, main[0])
+	consume(c, 1, branch[0])
+	consume(c, 1, branch[1])
+	consume(c, 2, main[1])
+	consume(c, 0, object.Changes{})
+	expected := c.Finalize().(CouplesResult)
+	assert.Equal(t, expected.Files, []string{"four", "one"})
+
+	dir, err := ioutil.TempDir("", "hercules-couples-test-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	for _, diskDir := range []string{"", dir} {
+		c = &CouplesAnalysis{PeopleNumber: 3, DiskDir: diskDir}
+		c.Initialize(test.Repository)
+		consume(c, 0, main[0])
+		forks := c.Fork(1)
+		assert.Len(t, forks, 1)
+		fork := forks[0].(*CouplesAnalysis)
+		assert.Nil(t, fork.disk)
+		consume(fork, 1, branch[0])
+		consume(fork, 1, branch[1])
+		assert.Len(t, fork.renames, 2)
+		consume(c, 2, main[1])
+		c.Merge([]core.PipelineItem{fork})
+		consume(c, 0, object.Changes{})
+		assert.Nil(t, c.renames)
+		assert.Nil(t, c.merged)
+		assert.Equal(t, c.Finalize(), expected)
+	}
+}
+
 func TestCouplesSerialize(t *testing.T) {
 	c := fixtureCouples()
 	c.PeopleNumber = 1
@@ -256,6 +381,56 @@ func TestCouplesSerialize(t *testing.T) {
 	assert.Equal(t, msg.FileCouples.Matrix.Indptr, indptr2[:])
 }
 
+func TestCouplesPeopleFilesMatrix(t *testing.T) {
+	c := fixtureCouples()
+	people := [...]string{"p1", "p2", "p3"}
+	facts := map[string]interface{}{}
+	facts[identity.FactIdentityDetectorPeopleCount] = 3
+	facts[identity.FactIdentityDetectorReversedPeopleDict] = people[:]
+	facts[ConfigCouplesPeopleFilesMatrix] = true
+	c.Configure(facts)
+	assert.True(t, c.TrackPeopleFilesMatrix)
+	deps := map[string]interface{}{}
+	deps[identity.DependencyAuthor] = 0
+	deps[plumbing.DependencyTreeChanges] = generateChanges("+two", "+four", "+six")
+	c.Consume(deps)
+	deps[plumbing.DependencyTreeChanges] = generateChanges("+one", "-two", "=three", ">four>five")
+	c.Consume(deps)
+	deps[identity.DependencyAuthor] = 1
+	deps[plumbing.DependencyTreeChanges] = generateChanges("=one", "=three", "-six")
+	c.Consume(deps)
+	deps[identity.DependencyAuthor] = 2
+	deps[plumbing.DependencyTreeChanges] = generateChanges("=five")
+	c.Consume(deps)
+	result := c.Finalize().(CouplesResult)
+	assert.Len(t, result.PeopleFilesMatrix, 4)
+	assert.Equal(t, result.PeopleFilesMatrix[0], getCouplesMap(0, 2, 1, 1, 2, 1))
+	assert.Equal(t, result.PeopleFilesMatrix[1], getCouplesMap(1, 1, 2, 1))
+	assert.Equal(t, result.PeopleFilesMatrix[2], getCouplesMap(0, 1))
+	assert.Equal(t, result.PeopleFilesMatrix[3], map[int]int64{})
+	buffer := &bytes.Buffer{}
+	c.Serialize(result, false, buffer)
+	assert.True(t, strings.HasSuffix(buffer.String(), `  people_files_coocc:
+    matrix:
+      - {0: 2, 1: 1, 2: 1}
+      - {1: 1, 2: 1}
+      - {0: 1}
+      - {}
+`))
+	buffer = &bytes.Buffer{}
+	c.Serialize(result, true, buffer)
+	msg := pb.CouplesAnalysisResults{}
+	proto.Unmarshal(buffer.Bytes(), &msg)
+	assert.Equal(t, msg.PeopleFilesMatrix.NumberOfRows, int32(4))
+	assert.Equal(t, msg.PeopleFilesMatrix.NumberOfColumns, int32(3))
+	iresult, err := c.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, iresult.(CouplesResult).PeopleFilesMatrix, result.PeopleFilesMatrix)
+	c.TrackPeopleFilesMatrix = false
+	result = c.Finalize().(CouplesResult)
+	assert.Nil(t, result.PeopleFilesMatrix)
+}
+
 func TestCouplesDeserialize(t *testing.T) {
 	allBuffer, err := ioutil.ReadFile(path.Join("..", "internal", "test_data", "couples.pb"))
 	assert.Nil(t, err)
@@ -339,6 +514,29 @@ func TestCouplesMerge(t *testing.T) {
 	assert.Equal(t, merged.FilesMatrix[2], getCouplesMap(1, 200))
 }
 
+func TestCouplesMergePeopleFilesMatrix(t *testing.T) {
+	r1, r2 := CouplesResult{}, CouplesResult{}
+	people1 := [...]string{"one", "two"}
+	people2 := [...]string{"two", "three"}
+	r1.reversedPeopleDict = people1[:]
+	r2.reversedPeopleDict = people2[:]
+	r1.Files = people1[:]
+	r2.Files = people2[:]
+	r1.PeopleFilesMatrix = []map[int]int64{getCouplesMap(0, 1), getCouplesMap(1, 2), getCouplesMap(0, 3)}
+	r2.PeopleFilesMatrix = []map[int]int64{getCouplesMap(0, 10), getCouplesMap(1, 20), getCouplesMap(1, 30)}
+	couples := CouplesAnalysis{}
+	merged := couples.MergeResults(r1, r2, nil, nil).(CouplesResult)
+	assert.Len(t, merged.PeopleFilesMatrix, 4)
+	assert.Equal(t, merged.PeopleFilesMatrix[0], getCouplesMap(0, 1))
+	assert.Equal(t, merged.PeopleFilesMatrix[1], getCouplesMap(1, 12))
+	assert.Equal(t, merged.PeopleFilesMatrix[2], getCouplesMap(2, 20))
+	assert.Equal(t, merged.PeopleFilesMatrix[3], getCouplesMap(0, 3, 2, 30))
+	r1.PeopleFilesMatrix = nil
+	r2.PeopleFilesMatrix = nil
+	merged = couples.MergeResults(r1, r2, nil, nil).(CouplesResult)
+	assert.Nil(t, merged.PeopleFilesMatrix)
+}
+
 func getSlice(vals ...int) []int {
 	return vals
 }
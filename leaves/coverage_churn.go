@@ -0,0 +1,464 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/burndown"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// CoverageChurnAnalysis joins the externally supplied test coverage reports with the line churn.
+// The reports are named after the commits or the tags at which they were measured. After each
+// such commit, the coverage of every line is tracked through the following diffs, and the
+// deleted or rewritten lines are counted as covered, uncovered or unmeasured. The churn in
+// the uncovered code is a strong risk signal.
+// It is a LeafPipelineItem and a LinearPipelineItem.
+type CoverageChurnAnalysis struct {
+	// ReportsDir is the directory with the lcov or Cobertura reports. The file names without
+	// the extensions are the commit hashes (at least 7 characters) or the tag names.
+	ReportsDir string
+	// PeopleNumber is the number of developers for which to collect the stats.
+	PeopleNumber int
+
+	// reports maps the report names to the file paths.
+	reports map[string]string
+	// taggedReports maps the tagged commits to the file paths of their reports.
+	taggedReports map[plumbing.Hash]string
+	// hashPrefixes maps the first 7 characters of the commit hashes to the report names.
+	hashPrefixes map[string]string
+	// files is the mapping <file path> -> *burndown.File. The values of the lines are
+	// coverageUncovered, coverageCovered or coverageUnmeasured.
+	files map[string]*burndown.File
+	// commitStats are the stats of the commit which is being analysed.
+	commitStats CoverageChurnStats
+	// days maps days to the churn stats.
+	days map[int]*CoverageChurnStats
+	// people maps the developer index to the churn stats.
+	people []CoverageChurnStats
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// CoverageChurnStats are the numbers of the deleted or rewritten lines by their coverage.
+type CoverageChurnStats struct {
+	// Covered is the number of changed lines which the tests executed.
+	Covered int
+	// Uncovered is the number of changed lines which the tests did not execute.
+	Uncovered int
+	// Unmeasured is the number of changed lines which the report did not mention, e.g. comments,
+	// and the lines which appeared after the report.
+	Unmeasured int
+}
+
+// CoverageChurnResult is returned by CoverageChurnAnalysis.Finalize() and carries the churn
+// split by coverage for each day and each developer.
+type CoverageChurnResult struct {
+	// Days maps the day index to the churn stats.
+	Days map[int]CoverageChurnStats
+	// People maps the developer index to the churn stats.
+	// The developer index len(reversedPeopleDict) corresponds to the unmatched authors.
+	People []CoverageChurnStats
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigCoverageChurnReportsDir is the name of the option to set CoverageChurnAnalysis.ReportsDir.
+	ConfigCoverageChurnReportsDir = "CoverageChurn.ReportsDir"
+
+	coverageUncovered  = 0
+	coverageCovered    = 1
+	coverageUnmeasured = 2
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (coverage *CoverageChurnAnalysis) Name() string {
+	return "CoverageChurn"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (coverage *CoverageChurnAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (coverage *CoverageChurnAnalysis) Requires() []string {
+	arr := [...]string{
+		identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges,
+		items.DependencyFileDiff}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (coverage *CoverageChurnAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigCoverageChurnReportsDir,
+		Description: "Directory with the lcov (.info, .lcov) or Cobertura (.xml) coverage reports " +
+			"named after the commit hashes or the tags.",
+		Flag:    "coverage-reports",
+		Type:    core.StringConfigurationOption,
+		Default: ""},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (coverage *CoverageChurnAnalysis) Flag() string {
+	return "coverage-churn"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (coverage *CoverageChurnAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigCoverageChurnReportsDir].(string); exists {
+		coverage.ReportsDir = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		coverage.PeopleNumber = val
+		coverage.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (coverage *CoverageChurnAnalysis) Initialize(repository *git.Repository) {
+	coverage.reports = map[string]string{}
+	coverage.taggedReports = map[plumbing.Hash]string{}
+	coverage.hashPrefixes = map[string]string{}
+	if coverage.ReportsDir == "" {
+		log.Println("Warning: no coverage reports, set --coverage-reports")
+	} else if reports, err := listCoverageReports(coverage.ReportsDir); err != nil {
+		log.Printf("Warning: ignored the coverage reports: %v\n", err)
+	} else {
+		coverage.reports = reports
+	}
+	for name := range coverage.reports {
+		if len(name) >= 7 && len(name) <= 40 && isHexString(name) {
+			coverage.hashPrefixes[strings.ToLower(name[:7])] = name
+		}
+	}
+	if repository != nil && len(coverage.reports) > 0 {
+		err := forEachTaggedCommit(repository, func(name string, commit *object.Commit) {
+			if reportPath, exists := coverage.reports[name]; exists {
+				coverage.taggedReports[commit.Hash] = reportPath
+			}
+		})
+		if err != nil {
+			log.Printf("Warning: failed to read the tags: %v\n", err)
+		}
+	}
+	coverage.files = map[string]*burndown.File{}
+	coverage.days = map[int]*CoverageChurnStats{}
+	coverage.people = make([]CoverageChurnStats, coverage.PeopleNumber+1)
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (coverage *CoverageChurnAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing || author > coverage.PeopleNumber {
+		author = coverage.PeopleNumber
+	}
+	day := deps[items.DependencyDay].(int)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	coverage.commitStats = CoverageChurnStats{}
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Delete:
+			if file, exists := coverage.files[change.From.Name]; exists {
+				file.Update(coverageUnmeasured, 0, 0, file.Len())
+				delete(coverage.files, change.From.Name)
+			}
+		case merkletrie.Modify:
+			if err := coverage.handleModification(change, fileDiffs); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if stats := coverage.commitStats; stats != (CoverageChurnStats{}) {
+		dayStats := coverage.days[day]
+		if dayStats == nil {
+			dayStats = &CoverageChurnStats{}
+			coverage.days[day] = dayStats
+		}
+		dayStats.add(stats)
+		coverage.people[author].add(stats)
+	}
+	if reportPath := coverage.findReport(commit.Hash); reportPath != "" {
+		coverage.applyReport(commit, reportPath)
+	}
+	return nil, nil
+}
+
+// RequiresLinearHistory returns true because the lines of the files are followed through
+// the diffs, which do not apply to the interleaved branches. See core.LinearPipelineItem.
+func (coverage *CoverageChurnAnalysis) RequiresLinearHistory() bool {
+	return true
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (coverage *CoverageChurnAnalysis) Finalize() interface{} {
+	days := map[int]CoverageChurnStats{}
+	for day, stats := range coverage.days {
+		days[day] = *stats
+	}
+	people := make([]CoverageChurnStats, len(coverage.people))
+	copy(people, coverage.people)
+	return CoverageChurnResult{
+		Days:               days,
+		People:             people,
+		reversedPeopleDict: coverage.reversedPeopleDict,
+	}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (coverage *CoverageChurnAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	coverageResult := result.(CoverageChurnResult)
+	if binary {
+		return coverage.serializeBinary(&coverageResult, writer)
+	}
+	coverage.serializeText(&coverageResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (coverage *CoverageChurnAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(coverage, result, &pb.CoverageChurnResults{}, writer)
+}
+
+func (coverage *CoverageChurnAnalysis) serializeText(result *CoverageChurnResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  # covered, uncovered, unmeasured")
+	fmt.Fprintln(writer, "  days:")
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	for _, day := range days {
+		stats := result.Days[day]
+		fmt.Fprintf(writer, "    %d: [%d, %d, %d]\n",
+			day, stats.Covered, stats.Uncovered, stats.Unmeasured)
+	}
+	fmt.Fprintln(writer, "  people:")
+	for i, name := range result.reversedPeopleDict {
+		if i >= len(result.People) {
+			break
+		}
+		stats := result.People[i]
+		fmt.Fprintf(writer, "    %s: [%d, %d, %d]\n", yaml.SafeString(name),
+			stats.Covered, stats.Uncovered, stats.Unmeasured)
+	}
+}
+
+func (coverage *CoverageChurnAnalysis) serializeBinary(result *CoverageChurnResult, writer io.Writer) error {
+	message := pb.CoverageChurnResults{
+		Days:           map[int32]*pb.CoverageChurnStats{},
+		People:         make([]*pb.CoverageChurnStats, len(result.People)),
+		PeopleSequence: result.reversedPeopleDict,
+	}
+	for day, stats := range result.Days {
+		message.Days[int32(day)] = stats.toProtobuf()
+	}
+	for i, stats := range result.People {
+		message.People[i] = stats.toProtobuf()
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func (stats *CoverageChurnStats) add(other CoverageChurnStats) {
+	stats.Covered += other.Covered
+	stats.Uncovered += other.Uncovered
+	stats.Unmeasured += other.Unmeasured
+}
+
+func (stats CoverageChurnStats) toProtobuf() *pb.CoverageChurnStats {
+	return &pb.CoverageChurnStats{
+		Covered:    int32(stats.Covered),
+		Uncovered:  int32(stats.Uncovered),
+		Unmeasured: int32(stats.Unmeasured),
+	}
+}
+
+// updateStats is the burndown.Status callback which records the deleted lines.
+// The line values are the coverage states.
+func (coverage *CoverageChurnAnalysis) updateStats(_ interface{}, _ int, previousState int, delta int) {
+	if delta >= 0 {
+		return
+	}
+	switch previousState {
+	case coverageCovered:
+		coverage.commitStats.Covered -= delta
+	case coverageUncovered:
+		coverage.commitStats.Uncovered -= delta
+	default:
+		coverage.commitStats.Unmeasured -= delta
+	}
+}
+
+func (coverage *CoverageChurnAnalysis) handleModification(
+	change *object.Change, diffs map[string]items.FileDiffData) error {
+	file, exists := coverage.files[change.From.Name]
+	if !exists {
+		return nil
+	}
+	if change.To.Name != change.From.Name {
+		coverage.files[change.To.Name] = file
+		delete(coverage.files, change.From.Name)
+	}
+	thisDiffs, exists := diffs[change.To.Name]
+	if !exists {
+		// the file became binary
+		delete(coverage.files, change.To.Name)
+		return nil
+	}
+	if file.Len() != thisDiffs.OldLinesOfCode {
+		return fmt.Errorf("%s: internal integrity error src %d != %d",
+			change.To.Name, thisDiffs.OldLinesOfCode, file.Len())
+	}
+	// the diffs are line-level so the number of lines equals to the rune count
+	position := 0
+	for _, edit := range thisDiffs.Diffs {
+		length := utf8.RuneCountInString(edit.Text)
+		switch edit.Type {
+		case diffmatchpatch.DiffEqual:
+			position += length
+		case diffmatchpatch.DiffInsert:
+			file.Update(coverageUnmeasured, position, length, 0)
+			position += length
+		case diffmatchpatch.DiffDelete:
+			file.Update(coverageUnmeasured, position, 0, length)
+		default:
+			return fmt.Errorf("diff operation is not supported: %d", edit.Type)
+		}
+	}
+	if file.Len() != thisDiffs.NewLinesOfCode {
+		return fmt.Errorf("%s: internal integrity error dst %d != %d",
+			change.To.Name, thisDiffs.NewLinesOfCode, file.Len())
+	}
+	return nil
+}
+
+// findReport returns the path to the report measured at the commit or an empty string.
+func (coverage *CoverageChurnAnalysis) findReport(hash plumbing.Hash) string {
+	if reportPath, exists := coverage.taggedReports[hash]; exists {
+		return reportPath
+	}
+	hex := hash.String()
+	if name, exists := coverage.hashPrefixes[hex[:7]]; exists && strings.HasPrefix(hex, strings.ToLower(name)) {
+		return coverage.reports[name]
+	}
+	return ""
+}
+
+// applyReport replaces the tracked files with the ones mentioned in the report.
+// The report describes the tree of the commit.
+func (coverage *CoverageChurnAnalysis) applyReport(commit *object.Commit, reportPath string) {
+	report, err := loadCoverageReport(reportPath)
+	if err != nil {
+		log.Printf("Warning: ignored the coverage report: %v\n", err)
+		return
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		log.Printf("Warning: ignored the coverage report %s: %v\n", reportPath, err)
+		return
+	}
+	names := map[string][]string{}
+	err = tree.Files().ForEach(func(file *object.File) error {
+		base := path.Base(file.Name)
+		names[base] = append(names[base], file.Name)
+		return nil
+	})
+	if err != nil {
+		log.Printf("Warning: ignored the coverage report %s: %v\n", reportPath, err)
+		return
+	}
+	files := map[string]*burndown.File{}
+	for reportedName, lines := range report {
+		name := resolveCoveragePath(reportedName, names)
+		if name == "" {
+			continue
+		}
+		file, err := tree.File(name)
+		if err != nil {
+			continue
+		}
+		length, err := items.CountLines(&file.Blob)
+		if err != nil {
+			continue
+		}
+		files[name] = coverage.newFile(length, lines)
+	}
+	coverage.files = files
+}
+
+// newFile creates the burndown.File with the coverage states of the lines.
+// lines maps the line numbers starting from 1 to whether they are covered.
+func (coverage *CoverageChurnAnalysis) newFile(length int, lines map[int]bool) *burndown.File {
+	keys, vals := []int{}, []int{}
+	for i := 0; i < length; i++ {
+		state := coverageUnmeasured
+		if covered, exists := lines[i+1]; exists {
+			state = coverageUncovered
+			if covered {
+				state = coverageCovered
+			}
+		}
+		if len(vals) == 0 || vals[len(vals)-1] != state {
+			keys = append(keys, i)
+			vals = append(vals, state)
+		}
+	}
+	keys = append(keys, length)
+	vals = append(vals, burndown.TreeEnd)
+	return burndown.NewFileFromTree(keys, vals, burndown.NewStatus(nil, coverage.updateStats))
+}
+
+// isHexString checks whether the string consists of hexadecimal digits only.
+func isHexString(str string) bool {
+	for _, char := range str {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", char) {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	core.Registry.Register(&CoverageChurnAnalysis{})
+}
@@ -0,0 +1,238 @@
+package leaves
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-billy.v4/memfs"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+const (
+	fixtureCoverageChurnV1 = "l1\nl2\nl3\nl4\nl5\n"
+	fixtureCoverageChurnV2 = "l1\nl3\nx4\nl5\n"
+)
+
+// fixtureCoverageChurnRepository creates an in-memory repository with two commits which change
+// src/a.go. The first commit is tagged "v1". Returns the commits.
+func fixtureCoverageChurnRepository() (*git.Repository, []*object.Commit) {
+	repository, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		panic(err)
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		panic(err)
+	}
+	var commits []*object.Commit
+	for i, contents := range []string{fixtureCoverageChurnV1, fixtureCoverageChurnV2} {
+		worktree.Filesystem.MkdirAll("src", 0755)
+		file, err := worktree.Filesystem.Create("src/a.go")
+		if err != nil {
+			panic(err)
+		}
+		file.Write([]byte(contents))
+		file.Close()
+		worktree.Add("src/a.go")
+		hash, err := worktree.Commit("Change", &git.CommitOptions{Author: &object.Signature{
+			Name: "Vadim", Email: "vadim@sourced.tech",
+			When: time.Date(2018, 1, 1+i, 12, 0, 0, 0, time.UTC)}})
+		if err != nil {
+			panic(err)
+		}
+		commit, err := repository.CommitObject(hash)
+		if err != nil {
+			panic(err)
+		}
+		commits = append(commits, commit)
+	}
+	err = repository.Storer.SetReference(
+		plumbing.NewHashReference(plumbing.ReferenceName("refs/tags/v1"), commits[0].Hash))
+	if err != nil {
+		panic(err)
+	}
+	return repository, commits
+}
+
+// fixtureCoverageChurnConsume runs FileDiff and CoverageChurnAnalysis on the changes.
+func fixtureCoverageChurnConsume(t *testing.T, coverage *CoverageChurnAnalysis,
+	commit *object.Commit, author int, day int, changes object.Changes, blobs ...*object.Blob) {
+	cache := map[plumbing.Hash]*object.Blob{}
+	for _, blob := range blobs {
+		cache[blob.Hash] = blob
+	}
+	deps := map[string]interface{}{
+		"commit":                    commit,
+		identity.DependencyAuthor:   author,
+		items.DependencyDay:         day,
+		items.DependencyBlobCache:   cache,
+		items.DependencyTreeChanges: changes,
+	}
+	fd := &items.FileDiff{CleanupDisabled: true}
+	fd.Initialize(test.Repository)
+	res, err := fd.Consume(deps)
+	assert.Nil(t, err)
+	deps[items.DependencyFileDiff] = res[items.DependencyFileDiff]
+	result, err := coverage.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+}
+
+func TestCoverageChurnMeta(t *testing.T) {
+	coverage := &CoverageChurnAnalysis{}
+	assert.Equal(t, coverage.Name(), "CoverageChurn")
+	assert.Len(t, coverage.Provides(), 0)
+	assert.True(t, coverage.RequiresLinearHistory())
+	assert.Equal(t, coverage.Requires(), []string{
+		identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges,
+		items.DependencyFileDiff})
+	opts := coverage.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigCoverageChurnReportsDir)
+	assert.Equal(t, opts[0].Flag, "coverage-reports")
+	assert.Equal(t, coverage.Flag(), "coverage-churn")
+	coverage.Configure(map[string]interface{}{
+		ConfigCoverageChurnReportsDir:                   "/tmp/reports",
+		identity.FactIdentityDetectorPeopleCount:        2,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"one", "two"},
+	})
+	assert.Equal(t, coverage.ReportsDir, "/tmp/reports")
+	assert.Equal(t, coverage.PeopleNumber, 2)
+	assert.Equal(t, coverage.reversedPeopleDict, []string{"one", "two"})
+	coverage.Initialize(nil)
+	assert.Len(t, coverage.reports, 0)
+	assert.Len(t, coverage.people, 3)
+}
+
+func TestCoverageChurnRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&CoverageChurnAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "CoverageChurn")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&CoverageChurnAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestCoverageChurnNewFile(t *testing.T) {
+	coverage := &CoverageChurnAnalysis{}
+	file := coverage.newFile(6, map[int]bool{2: true, 3: true, 4: false, 7: true})
+	assert.Equal(t, file.Values(0, 6), []int{
+		coverageUnmeasured, coverageCovered, coverageCovered, coverageUncovered,
+		coverageUnmeasured, coverageUnmeasured})
+	assert.Equal(t, coverage.newFile(0, nil).Len(), 0)
+}
+
+func TestCoverageChurnConsume(t *testing.T) {
+	repository, commits := fixtureCoverageChurnRepository()
+	dir, err := ioutil.TempDir("", "hercules-coverage-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "v1.info"), []byte(fixtureCoverageLcov), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, commits[1].Hash.String()[:10]+".xml"),
+		[]byte(`<coverage><packages><package><classes><class filename="src/a.go"><lines>
+<line number="1" hits="1"/><line number="2" hits="0"/><line number="3" hits="2"/>
+</lines></class></classes></package></packages></coverage>`), 0644))
+	coverage := &CoverageChurnAnalysis{ReportsDir: dir, PeopleNumber: 2}
+	coverage.Initialize(repository)
+	assert.Len(t, coverage.reports, 2)
+	assert.Len(t, coverage.taggedReports, 1)
+	assert.Len(t, coverage.hashPrefixes, 1)
+
+	v1 := fixtureChurnOriginBlob(fixtureCoverageChurnV1)
+	v2 := fixtureChurnOriginBlob(fixtureCoverageChurnV2)
+	entry := func(blob *object.Blob) object.ChangeEntry {
+		return object.ChangeEntry{Name: "src/a.go", TreeEntry: object.TreeEntry{
+			Name: "a.go", Mode: 0100644, Hash: blob.Hash}}
+	}
+	fixtureCoverageChurnConsume(t, coverage, commits[0], 0, 0,
+		object.Changes{{To: entry(v1)}}, v1)
+	assert.Len(t, coverage.files, 1)
+	assert.Equal(t, coverage.files["src/a.go"].Values(0, 5), []int{
+		coverageCovered, coverageUncovered, coverageUncovered, coverageUnmeasured,
+		coverageUnmeasured})
+	// deletes the uncovered l2 and rewrites the unmeasured l4
+	fixtureCoverageChurnConsume(t, coverage, commits[1], 0, 1,
+		object.Changes{{From: entry(v1), To: entry(v2)}}, v1, v2)
+	// the coverage report of the second commit is applied after the churn is counted
+	assert.Equal(t, coverage.files["src/a.go"].Values(0, 4), []int{
+		coverageCovered, coverageUncovered, coverageCovered, coverageUnmeasured})
+	fixtureCoverageChurnConsume(t, coverage, &object.Commit{}, 1, 3,
+		object.Changes{{From: entry(v2)}}, v2)
+	assert.Len(t, coverage.files, 0)
+	fixtureCoverageChurnConsume(t, coverage, &object.Commit{}, identity.AuthorMissing, 4,
+		object.Changes{{To: entry(v1)}}, v1)
+	res := coverage.Finalize().(CoverageChurnResult)
+	assert.Equal(t, res.Days, map[int]CoverageChurnStats{
+		1: {Uncovered: 1, Unmeasured: 1},
+		3: {Covered: 2, Uncovered: 1, Unmeasured: 1},
+	})
+	assert.Equal(t, res.People, []CoverageChurnStats{
+		{Uncovered: 1, Unmeasured: 1}, {Covered: 2, Uncovered: 1, Unmeasured: 1}, {}})
+}
+
+func TestCoverageChurnIntegrityError(t *testing.T) {
+	coverage := &CoverageChurnAnalysis{}
+	coverage.Initialize(nil)
+	coverage.files["a.go"] = coverage.newFile(3, nil)
+	change := &object.Change{From: object.ChangeEntry{Name: "a.go"}, To: object.ChangeEntry{Name: "b.go"}}
+	err := coverage.handleModification(change, map[string]items.FileDiffData{
+		"b.go": {OldLinesOfCode: 4, NewLinesOfCode: 4}})
+	assert.NotNil(t, err)
+	assert.Nil(t, coverage.files["a.go"])
+	// binary files are no longer tracked
+	change = &object.Change{From: object.ChangeEntry{Name: "b.go"}, To: object.ChangeEntry{Name: "b.go"}}
+	assert.Nil(t, coverage.handleModification(change, map[string]items.FileDiffData{}))
+	assert.Len(t, coverage.files, 0)
+}
+
+func TestCoverageChurnSerialize(t *testing.T) {
+	coverage := &CoverageChurnAnalysis{}
+	res := CoverageChurnResult{
+		Days: map[int]CoverageChurnStats{
+			5: {Covered: 1, Uncovered: 2, Unmeasured: 3},
+			1: {Uncovered: 4},
+		},
+		People:             []CoverageChurnStats{{Uncovered: 4}, {}, {Covered: 1}},
+		reversedPeopleDict: []string{"one", "two"},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, coverage.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  # covered, uncovered, unmeasured
+  days:
+    1: [0, 4, 0]
+    5: [1, 2, 3]
+  people:
+    "one": [0, 4, 0]
+    "two": [0, 0, 0]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, coverage.Serialize(res, true, buffer))
+	msg := pb.CoverageChurnResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Days, 2)
+	assert.Equal(t, *msg.Days[5], pb.CoverageChurnStats{Covered: 1, Uncovered: 2, Unmeasured: 3})
+	assert.Len(t, msg.People, 3)
+	assert.Equal(t, msg.People[0].Uncovered, int32(4))
+	assert.Equal(t, msg.People[2].Covered, int32(1))
+	assert.Equal(t, msg.PeopleSequence, []string{"one", "two"})
+}
@@ -0,0 +1,147 @@
+package leaves
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// coverageReport maps the file paths as they are written in the report to the line numbers
+// (starting from 1) to whether the line was executed.
+type coverageReport map[string]map[int]bool
+
+// add records the hits of the line. The line is covered if any record hits it.
+func (report coverageReport) add(file string, line int, hits int64) {
+	lines := report[file]
+	if lines == nil {
+		lines = map[int]bool{}
+		report[file] = lines
+	}
+	lines[line] = lines[line] || hits > 0
+}
+
+// listCoverageReports returns the mapping from the report names without extensions, which are
+// the commit hashes or the tags, to the paths of the report files in the directory.
+// The files with the unknown extensions are ignored.
+func listCoverageReports(dir string) (map[string]string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	reports := map[string]string{}
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(info.Name())
+		switch ext {
+		case ".info", ".lcov", ".xml":
+			reports[strings.TrimSuffix(info.Name(), ext)] = filepath.Join(dir, info.Name())
+		}
+	}
+	return reports, nil
+}
+
+// loadCoverageReport parses the lcov (.info, .lcov) or Cobertura (.xml) report.
+func loadCoverageReport(reportPath string) (coverageReport, error) {
+	file, err := os.Open(reportPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var report coverageReport
+	if filepath.Ext(reportPath) == ".xml" {
+		report, err = parseCobertura(file)
+	} else {
+		report, err = parseLcov(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", reportPath, err)
+	}
+	return report, nil
+}
+
+// parseLcov reads the "SF:" and "DA:" records of the lcov tracefile.
+func parseLcov(reader io.Reader) (coverageReport, error) {
+	report := coverageReport{}
+	scanner := bufio.NewScanner(reader)
+	file := ""
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(text, "SF:"):
+			file = text[3:]
+		case text == "end_of_record":
+			file = ""
+		case strings.HasPrefix(text, "DA:"):
+			if file == "" {
+				return nil, fmt.Errorf("line %d: DA outside of a record", line)
+			}
+			fields := strings.Split(text[3:], ",")
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("line %d: invalid DA record %q", line, text)
+			}
+			number, err := strconv.Atoi(fields[0])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", line, err)
+			}
+			hits, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", line, err)
+			}
+			report.add(file, number, hits)
+		}
+	}
+	return report, scanner.Err()
+}
+
+// parseCobertura reads the line hits of the classes in the Cobertura XML report.
+// The relative file names are joined with the first source directory.
+func parseCobertura(reader io.Reader) (coverageReport, error) {
+	var coverage struct {
+		Sources []string `xml:"sources>source"`
+		Classes []struct {
+			Filename string `xml:"filename,attr"`
+			Lines    []struct {
+				Number int   `xml:"number,attr"`
+				Hits   int64 `xml:"hits,attr"`
+			} `xml:"lines>line"`
+		} `xml:"packages>package>classes>class"`
+	}
+	if err := xml.NewDecoder(reader).Decode(&coverage); err != nil {
+		return nil, err
+	}
+	report := coverageReport{}
+	for _, class := range coverage.Classes {
+		file := class.Filename
+		if len(coverage.Sources) > 0 && !path.IsAbs(filepath.ToSlash(file)) {
+			file = path.Join(filepath.ToSlash(strings.TrimSpace(coverage.Sources[0])), file)
+		}
+		for _, line := range class.Lines {
+			report.add(file, line.Number, line.Hits)
+		}
+	}
+	return report, nil
+}
+
+// resolveCoveragePath finds the file in the repository which the report path refers to.
+// The reports often contain the absolute paths, so the longest file name which is a suffix
+// of the report path wins. names maps the base names to the full names of the files.
+// Returns an empty string if nothing matches.
+func resolveCoveragePath(reportPath string, names map[string][]string) string {
+	reportPath = strings.TrimPrefix(filepath.ToSlash(reportPath), "./")
+	best := ""
+	for _, name := range names[path.Base(reportPath)] {
+		if (reportPath == name || strings.HasSuffix(reportPath, "/"+name)) && len(name) > len(best) {
+			best = name
+		}
+	}
+	return best
+}
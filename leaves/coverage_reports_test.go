@@ -0,0 +1,123 @@
+package leaves
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const fixtureCoverageLcov = `TN:
+SF:/ci/build/src/a.go
+FN:1,main
+DA:1,3
+DA:2,0
+DA:3,0
+end_of_record
+SF:/ci/build/src/b.go
+DA:1,0
+end_of_record
+SF:/ci/build/src/b.go
+DA:1,1
+DA:2,0,checksum
+end_of_record
+`
+
+const fixtureCoverageCobertura = `<?xml version="1.0" ?>
+<coverage line-rate="0.5" version="1.9">
+  <sources>
+    <source>/ci/build</source>
+  </sources>
+  <packages>
+    <package name="src">
+      <classes>
+        <class filename="src/a.go" name="a">
+          <lines>
+            <line hits="1" number="1"/>
+            <line hits="0" number="2"/>
+          </lines>
+        </class>
+        <class filename="/abs/c.go" name="c">
+          <lines>
+            <line hits="5" number="3"/>
+          </lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>
+`
+
+func TestParseLcov(t *testing.T) {
+	report, err := parseLcov(strings.NewReader(fixtureCoverageLcov))
+	assert.Nil(t, err)
+	assert.Equal(t, report, coverageReport{
+		"/ci/build/src/a.go": {1: true, 2: false, 3: false},
+		"/ci/build/src/b.go": {1: true, 2: false},
+	})
+	_, err = parseLcov(strings.NewReader("DA:1,1\n"))
+	assert.NotNil(t, err)
+	_, err = parseLcov(strings.NewReader("SF:a.go\nDA:1\n"))
+	assert.NotNil(t, err)
+	_, err = parseLcov(strings.NewReader("SF:a.go\nDA:x,1\n"))
+	assert.NotNil(t, err)
+	_, err = parseLcov(strings.NewReader("SF:a.go\nDA:1,x\n"))
+	assert.NotNil(t, err)
+}
+
+func TestParseCobertura(t *testing.T) {
+	report, err := parseCobertura(strings.NewReader(fixtureCoverageCobertura))
+	assert.Nil(t, err)
+	assert.Equal(t, report, coverageReport{
+		"/ci/build/src/a.go": {1: true, 2: false},
+		"/abs/c.go":          {3: true},
+	})
+	_, err = parseCobertura(strings.NewReader("<coverage>"))
+	assert.NotNil(t, err)
+}
+
+func TestListAndLoadCoverageReports(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-coverage-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "v1.0.info"), []byte(fixtureCoverageLcov), 0644))
+	assert.Nil(t, ioutil.WriteFile(
+		filepath.Join(dir, "abcdef1.xml"), []byte(fixtureCoverageCobertura), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("text"), 0644))
+	assert.Nil(t, os.Mkdir(filepath.Join(dir, "sub.info"), 0755))
+	reports, err := listCoverageReports(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, reports, map[string]string{
+		"v1.0":    filepath.Join(dir, "v1.0.info"),
+		"abcdef1": filepath.Join(dir, "abcdef1.xml"),
+	})
+	report, err := loadCoverageReport(reports["v1.0"])
+	assert.Nil(t, err)
+	assert.Len(t, report, 2)
+	report, err = loadCoverageReport(reports["abcdef1"])
+	assert.Nil(t, err)
+	assert.Len(t, report, 2)
+	_, err = loadCoverageReport(filepath.Join(dir, "missing.info"))
+	assert.NotNil(t, err)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "bad.xml"), []byte("<"), 0644))
+	_, err = loadCoverageReport(filepath.Join(dir, "bad.xml"))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "bad.xml")
+	_, err = listCoverageReports(filepath.Join(dir, "missing"))
+	assert.NotNil(t, err)
+}
+
+func TestResolveCoveragePath(t *testing.T) {
+	names := map[string][]string{
+		"a.go": {"a.go", "src/a.go", "vendor/src/a.go"},
+		"b.go": {"b.go"},
+	}
+	assert.Equal(t, resolveCoveragePath("/ci/build/src/a.go", names), "src/a.go")
+	assert.Equal(t, resolveCoveragePath("/ci/vendor/src/a.go", names), "vendor/src/a.go")
+	assert.Equal(t, resolveCoveragePath("./a.go", names), "a.go")
+	assert.Equal(t, resolveCoveragePath("/ci/xb.go", names), "")
+	assert.Equal(t, resolveCoveragePath("c.go", names), "")
+}
@@ -0,0 +1,455 @@
+package leaves
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// DefectsAnalysis joins the issue tracker export with the commits which fix the issues.
+// A commit fixes an issue if its message references the issue ID, e.g. "#123" or "PROJ-123".
+// The analysis reports the number of fixed issues, the mean time to fix and the defect density
+// (fixed issues per 1000 lines) of each component, which is a directory prefix.
+// It is a LeafPipelineItem.
+type DefectsAnalysis struct {
+	// IssuesPath is the path to the CSV file with the columns "id", "opened", "closed"
+	// and "severity".
+	IssuesPath string
+	// ComponentDepth is the number of the leading directories which name the component.
+	ComponentDepth int
+
+	// issues maps the normalized issue IDs to the issues.
+	issues map[string]*defectsIssue
+	// lines maps the file names to the numbers of lines.
+	lines map[string]int
+	// components maps the component names to the stats which are being collected.
+	components map[string]*defectsComponent
+}
+
+// DefectsStats are the defect metrics of a component.
+type DefectsStats struct {
+	// Issues is the number of fixed issues.
+	Issues int
+	// FixCommits is the number of commits which fixed the issues.
+	FixCommits int
+	// Lines is the number of lines in the component at the end of the analysed history.
+	Lines int
+	// MeanTimeToFix is the mean lifetime of the fixed issues in days. The lifetime of an issue
+	// which is not closed lasts until the last fix.
+	MeanTimeToFix float64
+	// Severities maps the severities to the numbers of fixed issues.
+	Severities map[string]int
+}
+
+// Density returns the number of fixed issues per 1000 lines.
+func (stats DefectsStats) Density() float64 {
+	if stats.Lines == 0 {
+		return 0
+	}
+	return float64(stats.Issues) * 1000 / float64(stats.Lines)
+}
+
+// DefectsResult is returned by DefectsAnalysis.Finalize() and carries the defect metrics
+// of the components.
+type DefectsResult struct {
+	// Components maps the component names to the defect metrics. The files in the root
+	// directory belong to the component "/".
+	Components map[string]DefectsStats
+	// Issues is the total number of issues in the issue tracker export.
+	Issues int
+	// LinkedIssues is the number of issues which are referenced by commits.
+	LinkedIssues int
+}
+
+// defectsIssue is a record of the issue tracker export.
+type defectsIssue struct {
+	Opened   time.Time
+	Closed   time.Time
+	Severity string
+	// LastFix is the time of the last commit which referenced the issue.
+	LastFix time.Time
+}
+
+// defectsComponent is the intermediate state of DefectsStats.
+type defectsComponent struct {
+	Issues     map[string]bool
+	FixCommits int
+}
+
+const (
+	// ConfigDefectsIssuesPath is the name of the option to set DefectsAnalysis.IssuesPath.
+	ConfigDefectsIssuesPath = "Defects.IssuesPath"
+	// ConfigDefectsComponentDepth is the name of the option to set DefectsAnalysis.ComponentDepth.
+	ConfigDefectsComponentDepth = "Defects.ComponentDepth"
+	// DefaultDefectsComponentDepth is the default value of DefectsAnalysis.ComponentDepth.
+	DefaultDefectsComponentDepth = 1
+)
+
+var (
+	// defectsIssueRefRE extracts the issue IDs from the references like in issueRefRE.
+	defectsIssueRefRE = regexp.MustCompile(
+		`(?:^|[^\w&])#(\d+)\b|\b(?i:gh)-(\d+)\b|\b([A-Z][A-Z0-9]+-\d+)\b|/issues/(\d+)`)
+	// defectsDateLayouts are the supported formats of the dates in the issue tracker export.
+	defectsDateLayouts = [...]string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (defects *DefectsAnalysis) Name() string {
+	return "Defects"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (defects *DefectsAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (defects *DefectsAnalysis) Requires() []string {
+	arr := [...]string{items.DependencyTreeChanges, items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (defects *DefectsAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigDefectsIssuesPath,
+		Description: "CSV file with the issues exported from the issue tracker. The header must " +
+			"contain \"id\" and \"opened\", \"closed\" and \"severity\" are optional.",
+		Flag:    "issues",
+		Type:    core.StringConfigurationOption,
+		Default: ""}, {
+		Name:        ConfigDefectsComponentDepth,
+		Description: "Number of the leading directories which name the component.",
+		Flag:        "defects-component-depth",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultDefectsComponentDepth},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (defects *DefectsAnalysis) Flag() string {
+	return "defects"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (defects *DefectsAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigDefectsIssuesPath].(string); exists {
+		defects.IssuesPath = val
+	}
+	if val, exists := facts[ConfigDefectsComponentDepth].(int); exists {
+		defects.ComponentDepth = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (defects *DefectsAnalysis) Initialize(repository *git.Repository) {
+	if defects.ComponentDepth <= 0 {
+		log.Printf("Warning: adjusted the component depth to %d\n", DefaultDefectsComponentDepth)
+		defects.ComponentDepth = DefaultDefectsComponentDepth
+	}
+	defects.issues = map[string]*defectsIssue{}
+	if defects.IssuesPath == "" {
+		log.Println("Warning: no issues, set --issues")
+	} else if issues, err := loadDefectsIssues(defects.IssuesPath); err != nil {
+		log.Printf("Warning: ignored the issues: %v\n", err)
+	} else {
+		defects.issues = issues
+	}
+	defects.lines = map[string]int{}
+	defects.components = map[string]*defectsComponent{}
+}
+
+// loadDefectsIssues reads the issue tracker export.
+func loadDefectsIssues(path string) (map[string]*defectsIssue, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	issues, err := readDefectsIssues(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return issues, nil
+}
+
+func readDefectsIssues(reader io.Reader) (map[string]*defectsIssue, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	issues := map[string]*defectsIssue{}
+	if len(records) == 0 {
+		return issues, nil
+	}
+	columns := map[string]int{}
+	for i, column := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	for _, column := range [...]string{"id", "opened"} {
+		if _, exists := columns[column]; !exists {
+			return nil, fmt.Errorf("the header must contain %q", column)
+		}
+	}
+	cell := func(record []string, column string) string {
+		if i, exists := columns[column]; exists && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	for line, record := range records[1:] {
+		id := normalizeDefectsIssueID(cell(record, "id"))
+		if id == "" {
+			continue
+		}
+		issue := &defectsIssue{Severity: strings.ToLower(cell(record, "severity"))}
+		if issue.Opened, err = parseDefectsDate(cell(record, "opened")); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line+2, err)
+		}
+		if closed := cell(record, "closed"); closed != "" {
+			if issue.Closed, err = parseDefectsDate(closed); err != nil {
+				return nil, fmt.Errorf("line %d: %v", line+2, err)
+			}
+		}
+		issues[id] = issue
+	}
+	return issues, nil
+}
+
+func parseDefectsDate(str string) (time.Time, error) {
+	for _, layout := range defectsDateLayouts {
+		if date, err := time.Parse(layout, str); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", str)
+}
+
+// normalizeDefectsIssueID removes the leading "#" and converts the ID to upper case, so that
+// "#123" matches "123" and "proj-1" matches "PROJ-1".
+func normalizeDefectsIssueID(id string) string {
+	return strings.ToUpper(strings.TrimPrefix(strings.TrimSpace(id), "#"))
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (defects *DefectsAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	components := map[string]bool{}
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			defects.countLines(change.To.Name, cache[change.To.TreeEntry.Hash])
+			components[fileComponent(change.To.Name, defects.ComponentDepth)] = true
+		case merkletrie.Delete:
+			delete(defects.lines, change.From.Name)
+			components[fileComponent(change.From.Name, defects.ComponentDepth)] = true
+		case merkletrie.Modify:
+			delete(defects.lines, change.From.Name)
+			defects.countLines(change.To.Name, cache[change.To.TreeEntry.Hash])
+			components[fileComponent(change.To.Name, defects.ComponentDepth)] = true
+		}
+	}
+	if commit.NumParents() > 1 || len(defects.issues) == 0 {
+		// merge commits duplicate the changes and reference the pull requests
+		return nil, nil
+	}
+	var fixed []string
+	for _, match := range defectsIssueRefRE.FindAllStringSubmatch(commit.Message, -1) {
+		for _, id := range match[1:] {
+			if id == "" {
+				continue
+			}
+			id = normalizeDefectsIssueID(id)
+			if issue, exists := defects.issues[id]; exists {
+				fixed = append(fixed, id)
+				if commit.Author.When.After(issue.LastFix) {
+					issue.LastFix = commit.Author.When
+				}
+			}
+		}
+	}
+	if len(fixed) == 0 {
+		return nil, nil
+	}
+	for name := range components {
+		component := defects.components[name]
+		if component == nil {
+			component = &defectsComponent{Issues: map[string]bool{}}
+			defects.components[name] = component
+		}
+		component.FixCommits++
+		for _, id := range fixed {
+			component.Issues[id] = true
+		}
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (defects *DefectsAnalysis) Finalize() interface{} {
+	result := DefectsResult{Components: map[string]DefectsStats{}, Issues: len(defects.issues)}
+	for _, issue := range defects.issues {
+		if !issue.LastFix.IsZero() {
+			result.LinkedIssues++
+		}
+	}
+	for name, component := range defects.components {
+		stats := DefectsStats{
+			Issues:     len(component.Issues),
+			FixCommits: component.FixCommits,
+			Severities: map[string]int{},
+		}
+		var lifetime time.Duration
+		for id := range component.Issues {
+			issue := defects.issues[id]
+			closed := issue.Closed
+			if closed.IsZero() {
+				closed = issue.LastFix
+			}
+			if closed.After(issue.Opened) {
+				lifetime += closed.Sub(issue.Opened)
+			}
+			if issue.Severity != "" {
+				stats.Severities[issue.Severity]++
+			}
+		}
+		stats.MeanTimeToFix = lifetime.Hours() / 24 / float64(stats.Issues)
+		result.Components[name] = stats
+	}
+	for file, lines := range defects.lines {
+		name := fileComponent(file, defects.ComponentDepth)
+		if stats, exists := result.Components[name]; exists {
+			stats.Lines += lines
+			result.Components[name] = stats
+		}
+	}
+	return result
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (defects *DefectsAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	defectsResult := result.(DefectsResult)
+	if binary {
+		return defects.serializeBinary(&defectsResult, writer)
+	}
+	defects.serializeText(&defectsResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (defects *DefectsAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(defects, result, &pb.DefectsResults{}, writer)
+}
+
+func (defects *DefectsAnalysis) serializeText(result *DefectsResult, writer io.Writer) {
+	fmt.Fprintf(writer, "  issues: %d\n", result.Issues)
+	fmt.Fprintf(writer, "  linked_issues: %d\n", result.LinkedIssues)
+	fmt.Fprintln(writer, "  components:")
+	names := make([]string, 0, len(result.Components))
+	for name := range result.Components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		stats := result.Components[name]
+		severities := make([]string, 0, len(stats.Severities))
+		for severity := range stats.Severities {
+			severities = append(severities, severity)
+		}
+		sort.Strings(severities)
+		for i, severity := range severities {
+			severities[i] = fmt.Sprintf("%s: %d", yaml.SafeString(severity), stats.Severities[severity])
+		}
+		fmt.Fprintf(writer, "    %s: {issues: %d, fix_commits: %d, lines: %d, density: %.4f, "+
+			"mean_time_to_fix: %.4f, severities: {%s}}\n", yaml.SafeString(name), stats.Issues,
+			stats.FixCommits, stats.Lines, stats.Density(), stats.MeanTimeToFix,
+			strings.Join(severities, ", "))
+	}
+}
+
+func (defects *DefectsAnalysis) serializeBinary(result *DefectsResult, writer io.Writer) error {
+	message := pb.DefectsResults{
+		Components:   map[string]*pb.DefectsStats{},
+		Issues:       int32(result.Issues),
+		LinkedIssues: int32(result.LinkedIssues),
+	}
+	for name, stats := range result.Components {
+		severities := map[string]int32{}
+		for severity, count := range stats.Severities {
+			severities[severity] = int32(count)
+		}
+		message.Components[name] = &pb.DefectsStats{
+			Issues:        int32(stats.Issues),
+			FixCommits:    int32(stats.FixCommits),
+			Lines:         int32(stats.Lines),
+			MeanTimeToFix: float32(stats.MeanTimeToFix),
+			Severities:    severities,
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+// countLines records the number of lines in the file. The binary files are ignored.
+func (defects *DefectsAnalysis) countLines(name string, blob *object.Blob) {
+	if lines, err := items.CountLines(blob); err == nil {
+		defects.lines[name] = lines
+	}
+}
+
+// fileComponent returns the first `depth` directories of the file path, "/" for the files
+// in the repository root. The analyses which aggregate by component share it.
+func fileComponent(name string, depth int) string {
+	parts := strings.Split(name, "/")
+	parts = parts[:len(parts)-1]
+	if len(parts) == 0 {
+		return "/"
+	}
+	if len(parts) > depth {
+		parts = parts[:depth]
+	}
+	return strings.Join(parts, "/")
+}
+
+func init() {
+	core.Registry.Register(&DefectsAnalysis{})
+}
@@ -0,0 +1,213 @@
+package leaves
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+const fixtureDefectsIssues = `ID,Opened,Closed,Severity
+#1,2018-01-01,2018-01-03,Critical
+2,2018-01-02T00:00:00Z,,minor
+PROJ-3,2018-01-01 12:00:00,2018-01-11,
+,2018-01-01,,
+`
+
+func fixtureDefectsCommit(message string, day int, parents int) *object.Commit {
+	return &object.Commit{
+		Message:      message,
+		Author:       object.Signature{When: time.Date(2018, 1, day, 0, 0, 0, 0, time.UTC)},
+		ParentHashes: make([]plumbing.Hash, parents),
+	}
+}
+
+func fixtureDefectsConsume(t *testing.T, defects *DefectsAnalysis, commit *object.Commit,
+	changes object.Changes, blobs ...*object.Blob) {
+	cache := map[plumbing.Hash]*object.Blob{}
+	for _, blob := range blobs {
+		cache[blob.Hash] = blob
+	}
+	result, err := defects.Consume(map[string]interface{}{
+		"commit":                    commit,
+		items.DependencyBlobCache:   cache,
+		items.DependencyTreeChanges: changes,
+	})
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+}
+
+func TestDefectsMeta(t *testing.T) {
+	defects := &DefectsAnalysis{}
+	assert.Equal(t, defects.Name(), "Defects")
+	assert.Len(t, defects.Provides(), 0)
+	assert.Equal(t, defects.Requires(), []string{items.DependencyTreeChanges, items.DependencyBlobCache})
+	opts := defects.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Name, ConfigDefectsIssuesPath)
+	assert.Equal(t, opts[0].Flag, "issues")
+	assert.Equal(t, opts[1].Name, ConfigDefectsComponentDepth)
+	assert.Equal(t, defects.Flag(), "defects")
+	defects.Configure(map[string]interface{}{
+		ConfigDefectsIssuesPath:     "/tmp/issues.csv",
+		ConfigDefectsComponentDepth: 2,
+	})
+	assert.Equal(t, defects.IssuesPath, "/tmp/issues.csv")
+	assert.Equal(t, defects.ComponentDepth, 2)
+	defects.IssuesPath = ""
+	defects.ComponentDepth = 0
+	defects.Initialize(nil)
+	assert.Equal(t, defects.ComponentDepth, DefaultDefectsComponentDepth)
+	assert.Len(t, defects.issues, 0)
+}
+
+func TestDefectsRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&DefectsAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Defects")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&DefectsAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestReadDefectsIssues(t *testing.T) {
+	issues, err := readDefectsIssues(strings.NewReader(fixtureDefectsIssues))
+	assert.Nil(t, err)
+	assert.Len(t, issues, 3)
+	assert.Equal(t, *issues["1"], defectsIssue{
+		Opened:   time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+		Closed:   time.Date(2018, 1, 3, 0, 0, 0, 0, time.UTC),
+		Severity: "critical"})
+	assert.True(t, issues["2"].Closed.IsZero())
+	assert.Equal(t, issues["PROJ-3"].Opened, time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC))
+	_, err = readDefectsIssues(strings.NewReader("id,closed\n1,2018-01-01\n"))
+	assert.NotNil(t, err)
+	_, err = readDefectsIssues(strings.NewReader("id,opened\n1,yesterday\n"))
+	assert.NotNil(t, err)
+	_, err = readDefectsIssues(strings.NewReader("id,opened,closed\n1,2018-01-01,never\n"))
+	assert.NotNil(t, err)
+	issues, err = readDefectsIssues(strings.NewReader(""))
+	assert.Nil(t, err)
+	assert.Len(t, issues, 0)
+}
+
+func TestLoadDefectsIssues(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-defects-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "issues.csv")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(fixtureDefectsIssues), 0644))
+	issues, err := loadDefectsIssues(path)
+	assert.Nil(t, err)
+	assert.Len(t, issues, 3)
+	_, err = loadDefectsIssues(filepath.Join(dir, "missing.csv"))
+	assert.NotNil(t, err)
+	assert.Nil(t, ioutil.WriteFile(path, []byte("name\nx\n"), 0644))
+	_, err = loadDefectsIssues(path)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "issues.csv")
+	defects := &DefectsAnalysis{IssuesPath: path}
+	defects.Initialize(nil)
+	assert.Len(t, defects.issues, 0)
+}
+
+func TestDefectsComponent(t *testing.T) {
+	defects := &DefectsAnalysis{ComponentDepth: 2}
+	assert.Equal(t, fileComponent("main.go", defects.ComponentDepth), "/")
+	assert.Equal(t, fileComponent("cmd/main.go", defects.ComponentDepth), "cmd")
+	assert.Equal(t, fileComponent("internal/core/pipeline.go", defects.ComponentDepth), "internal/core")
+	assert.Equal(t, fileComponent("internal/core/sub/pipeline.go", defects.ComponentDepth), "internal/core")
+}
+
+func TestDefectsConsumeFinalize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-defects-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "issues.csv")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(fixtureDefectsIssues), 0644))
+	defects := &DefectsAnalysis{IssuesPath: path}
+	defects.Initialize(nil)
+	assert.Len(t, defects.issues, 3)
+
+	a1 := fixtureChurnOriginBlob("1\n2\n3\n4\n")
+	a2 := fixtureChurnOriginBlob("1\n2\n")
+	b := fixtureChurnOriginBlob("1\n2\n3\n4\n5\n6\n")
+	entry := func(name string, blob *object.Blob) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: filepath.Base(name), Mode: 0100644, Hash: blob.Hash}}
+	}
+	fixtureDefectsConsume(t, defects, fixtureDefectsCommit("Initial", 1, 0), object.Changes{
+		{To: entry("core/a.go", a1)}, {To: entry("main.go", b)}}, a1, b)
+	fixtureDefectsConsume(t, defects, fixtureDefectsCommit("Fix #1 and PROJ-3", 2, 1),
+		object.Changes{{From: entry("core/a.go", a1), To: entry("core/a.go", a2)}}, a1, a2)
+	// merge commits are not linked
+	fixtureDefectsConsume(t, defects, fixtureDefectsCommit("Merge pull request #2", 4, 2),
+		object.Changes{{From: entry("main.go", b), To: entry("main.go", a1)}}, b, a1)
+	fixtureDefectsConsume(t, defects, fixtureDefectsCommit("Fix gh-2, see #99", 5, 1),
+		object.Changes{{From: entry("main.go", a1), To: entry("main.go", b)},
+			{From: entry("core/a.go", a2)}}, a1, b, a2)
+	res := defects.Finalize().(DefectsResult)
+	assert.Equal(t, res.Issues, 3)
+	assert.Equal(t, res.LinkedIssues, 3)
+	assert.Len(t, res.Components, 2)
+	coreStats := res.Components["core"]
+	assert.Equal(t, coreStats.Issues, 3)
+	assert.Equal(t, coreStats.FixCommits, 2)
+	assert.Equal(t, coreStats.Lines, 0)
+	assert.Equal(t, coreStats.Density(), 0.0)
+	// 2 days, 3 days and 9.5 days
+	assert.InDelta(t, coreStats.MeanTimeToFix, 14.5/3, 1e-6)
+	assert.Equal(t, coreStats.Severities, map[string]int{"critical": 1, "minor": 1})
+	root := res.Components["/"]
+	assert.Equal(t, root, DefectsStats{
+		Issues: 1, FixCommits: 1, Lines: 6, MeanTimeToFix: 3, Severities: map[string]int{"minor": 1}})
+	assert.InDelta(t, root.Density(), 1000.0/6, 1e-6)
+}
+
+func TestDefectsSerialize(t *testing.T) {
+	defects := &DefectsAnalysis{}
+	res := DefectsResult{
+		Components: map[string]DefectsStats{
+			"core": {Issues: 2, FixCommits: 3, Lines: 500, MeanTimeToFix: 2.5,
+				Severities: map[string]int{"minor": 1, "critical": 1}},
+			"/": {Issues: 1, FixCommits: 1, Severities: map[string]int{}},
+		},
+		Issues:       4,
+		LinkedIssues: 3,
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, defects.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  issues: 4
+  linked_issues: 3
+  components:
+    "/": {issues: 1, fix_commits: 1, lines: 0, density: 0.0000, mean_time_to_fix: 0.0000, severities: {}}
+    "core": {issues: 2, fix_commits: 3, lines: 500, density: 4.0000, mean_time_to_fix: 2.5000, severities: {"critical": 1, "minor": 1}}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, defects.Serialize(res, true, buffer))
+	msg := pb.DefectsResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Issues, int32(4))
+	assert.Equal(t, msg.LinkedIssues, int32(3))
+	assert.Len(t, msg.Components, 2)
+	assert.Equal(t, *msg.Components["core"], pb.DefectsStats{
+		Issues: 2, FixCommits: 3, Lines: 500, MeanTimeToFix: 2.5,
+		Severities: map[string]int32{"minor": 1, "critical": 1}})
+}
@@ -0,0 +1,423 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/enry.v1"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// DevsAnalysis calculates the number of commits, the line stats, the number of touched files
+// and the touched languages of each developer on each day. It is the raw material for the team
+// dashboards which Burndown's band matrices do not provide.
+// It is a LeafPipelineItem.
+type DevsAnalysis struct {
+	// PeopleNumber is the number of developers for which to collect the stats.
+	PeopleNumber int
+	// TopPeople is the number of the developers with the most commits to write; the rest are
+	// merged into TopOtherName. 0 writes all the developers.
+	TopPeople int
+
+	// days maps days to developers to the stats.
+	// The developer index PeopleNumber corresponds to the authors which were not matched.
+	days map[int]map[int]*DevDay
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigDevsTopPeople is the name of the option to set DevsAnalysis.TopPeople.
+	ConfigDevsTopPeople = "Devs.TopPeople"
+)
+
+// LineStats are the numbers of lines added, removed and changed.
+type LineStats struct {
+	// Added is the number of inserted lines.
+	Added int
+	// Removed is the number of deleted lines.
+	Removed int
+	// Changed is the number of lines which were replaced by other lines.
+	Changed int
+}
+
+// add sums the line stats.
+func (stats *LineStats) add(other LineStats) {
+	stats.Added += other.Added
+	stats.Removed += other.Removed
+	stats.Changed += other.Changed
+}
+
+// DevDay is the activity of a developer on a single day.
+type DevDay struct {
+	// Commits is the number of commits.
+	Commits int
+	// LineStats are the line stats summed over all the files.
+	LineStats
+	// Files is the number of changed files summed over the commits.
+	Files int
+	// Languages maps the language names to the line stats of the files in those languages.
+	Languages map[string]LineStats
+}
+
+// add sums the daily stats.
+func (dev *DevDay) add(other *DevDay) {
+	dev.Commits += other.Commits
+	dev.LineStats.add(other.LineStats)
+	dev.Files += other.Files
+	for lang, stats := range other.Languages {
+		langStats := dev.Languages[lang]
+		langStats.add(stats)
+		dev.Languages[lang] = langStats
+	}
+}
+
+// DevsResult is returned by DevsAnalysis.Finalize() and carries the daily stats of each developer.
+type DevsResult struct {
+	// Days maps the day index to the developer index to the stats.
+	// The developer index len(reversedPeopleDict) corresponds to the unmatched authors.
+	Days map[int]map[int]*DevDay
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (devs *DevsAnalysis) Name() string {
+	return "Devs"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (devs *DevsAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (devs *DevsAnalysis) Requires() []string {
+	arr := [...]string{
+		identity.DependencyAuthor, items.DependencyTreeChanges, items.DependencyFileDiff,
+		items.DependencyBlobCache, items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (devs *DevsAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigDevsTopPeople,
+		Description: "Write only this number of the developers with the most commits and merge " +
+			"the rest into \"" + TopOtherName + "\". 0 writes all the developers.",
+		Flag:    "devs-top-people",
+		Type:    core.IntConfigurationOption,
+		Default: 0},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (devs *DevsAnalysis) Flag() string {
+	return "devs"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (devs *DevsAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		devs.PeopleNumber = val
+		devs.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+	if val, exists := facts[ConfigDevsTopPeople].(int); exists {
+		devs.TopPeople = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (devs *DevsAnalysis) Initialize(repository *git.Repository) {
+	devs.days = map[int]map[int]*DevDay{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (devs *DevsAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	day := deps[items.DependencyDay].(int)
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing {
+		author = devs.PeopleNumber
+	}
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	dayDevs := devs.days[day]
+	if dayDevs == nil {
+		dayDevs = map[int]*DevDay{}
+		devs.days[day] = dayDevs
+	}
+	dev := dayDevs[author]
+	if dev == nil {
+		dev = &DevDay{Languages: map[string]LineStats{}}
+		dayDevs[author] = dev
+	}
+	dev.Commits++
+	dev.Files += len(treeDiff)
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		var name string
+		var stats LineStats
+		switch action {
+		case merkletrie.Insert:
+			name = change.To.Name
+			stats.Added, err = items.CountLines(cache[change.To.TreeEntry.Hash])
+		case merkletrie.Delete:
+			name = change.From.Name
+			stats.Removed, err = items.CountLines(cache[change.From.TreeEntry.Hash])
+		case merkletrie.Modify:
+			name = change.To.Name
+			stats = diffLineStats(fileDiffs[name].Diffs)
+		}
+		if err != nil {
+			if err.Error() == "binary" {
+				continue
+			}
+			return nil, err
+		}
+		dev.LineStats.add(stats)
+		lang, _ := enry.GetLanguageByExtension(name)
+		if lang == "" {
+			lang = "Other"
+		}
+		langStats := dev.Languages[lang]
+		langStats.add(stats)
+		dev.Languages[lang] = langStats
+	}
+	return nil, nil
+}
+
+// diffLineStats counts the added, removed and changed lines in the line-level diff.
+// A deletion which is immediately followed by an insertion is a change of
+// min(deleted, inserted) lines, the rest is added or removed.
+func diffLineStats(diffs []diffmatchpatch.Diff) LineStats {
+	stats := LineStats{}
+	removedPending := 0
+	for _, edit := range diffs {
+		size := utf8.RuneCountInString(edit.Text)
+		switch edit.Type {
+		case diffmatchpatch.DiffDelete:
+			removedPending += size
+		case diffmatchpatch.DiffInsert:
+			if removedPending > size {
+				stats.Changed += size
+				stats.Removed += removedPending - size
+			} else {
+				stats.Changed += removedPending
+				stats.Added += size - removedPending
+			}
+			removedPending = 0
+		default:
+			stats.Removed += removedPending
+			removedPending = 0
+		}
+	}
+	stats.Removed += removedPending
+	return stats
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (devs *DevsAnalysis) Finalize() interface{} {
+	return DevsResult{
+		Days:               devs.days,
+		reversedPeopleDict: devs.reversedPeopleDict,
+	}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (devs *DevsAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	devsResult := truncateDevs(result.(DevsResult), devs.TopPeople)
+	if binary {
+		return devs.serializeBinary(&devsResult, writer)
+	}
+	devs.serializeText(&devsResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (devs *DevsAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(devs, result, &pb.DevsAnalysisResults{}, writer)
+}
+
+// truncateDevs keeps the k developers with the most commits and merges the rest into
+// TopOtherName. The unmatched authors stay separate. The result is not changed if k is 0.
+func truncateDevs(result DevsResult, k int) DevsResult {
+	unmatched := len(result.reversedPeopleDict)
+	weights := make([]int64, unmatched)
+	for _, dayDevs := range result.Days {
+		for dev, stats := range dayDevs {
+			if dev < unmatched {
+				weights[dev] += int64(stats.Commits)
+			}
+		}
+	}
+	remap := topRemap(weights, k)
+	if remap == nil {
+		return result
+	}
+	days := map[int]map[int]*DevDay{}
+	for day, dayDevs := range result.Days {
+		newDayDevs := map[int]*DevDay{}
+		for dev, stats := range dayDevs {
+			newDev := k + 1
+			if dev < unmatched {
+				newDev = remap[dev]
+			}
+			merged := newDayDevs[newDev]
+			if merged == nil {
+				merged = &DevDay{Languages: map[string]LineStats{}}
+				newDayDevs[newDev] = merged
+			}
+			merged.add(stats)
+		}
+		days[day] = newDayDevs
+	}
+	people := make([]string, k+1)
+	for i, name := range result.reversedPeopleDict {
+		if remap[i] < k {
+			people[remap[i]] = name
+		}
+	}
+	people[k] = TopOtherName
+	return DevsResult{Days: days, reversedPeopleDict: people}
+}
+
+func (devs *DevsAnalysis) serializeText(result *DevsResult, writer io.Writer) {
+	formatStats := func(stats LineStats) string {
+		return fmt.Sprintf("[%d, %d, %d]", stats.Added, stats.Removed, stats.Changed)
+	}
+	fmt.Fprintln(writer, "  days:")
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	for _, day := range days {
+		fmt.Fprintf(writer, "    %d:\n", day)
+		dayDevs := result.Days[day]
+		people := make([]int, 0, len(dayDevs))
+		for dev := range dayDevs {
+			people = append(people, dev)
+		}
+		sort.Ints(people)
+		for _, dev := range people {
+			stats := dayDevs[dev]
+			langs := make([]string, 0, len(stats.Languages))
+			for lang := range stats.Languages {
+				langs = append(langs, lang)
+			}
+			sort.Strings(langs)
+			langItems := make([]string, len(langs))
+			for i, lang := range langs {
+				langItems[i] = yaml.SafeString(lang) + ": " + formatStats(stats.Languages[lang])
+			}
+			// the line stats are [added, removed, changed]
+			fmt.Fprintf(writer, "      %d: {commits: %d, lines: %s, files: %d, languages: {%s}}\n",
+				dev, stats.Commits, formatStats(stats.LineStats), stats.Files,
+				strings.Join(langItems, ", "))
+		}
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, name := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(name))
+	}
+}
+
+// SerializeDayRanges splits the binary result by the days: the first part carries the developer
+// names, all the parts carry the days of their ranges.
+func (devs *DevsAnalysis) SerializeDayRanges(result interface{}, days int) ([]core.DayRange, error) {
+	devsResult := truncateDevs(result.(DevsResult), devs.TopPeople)
+	message := devs.binaryMessage(&devsResult)
+	parts := []*pb.DevsAnalysisResults{{Days: map[int32]*pb.DayDevs{}, DevIndex: message.DevIndex}}
+	for day, dayDevs := range message.Days {
+		index := int(day) / days
+		for len(parts) <= index {
+			parts = append(parts, &pb.DevsAnalysisResults{Days: map[int32]*pb.DayDevs{}})
+		}
+		parts[index].Days[day] = dayDevs
+	}
+	ranges := make([]core.DayRange, 0, len(parts))
+	for i, part := range parts {
+		if i > 0 && len(part.Days) == 0 {
+			continue
+		}
+		serialized, err := proto.Marshal(part)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, core.DayRange{From: i * days, To: (i + 1) * days, Data: serialized})
+	}
+	return ranges, nil
+}
+
+func (devs *DevsAnalysis) serializeBinary(result *DevsResult, writer io.Writer) error {
+	serialized, err := proto.Marshal(devs.binaryMessage(result))
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func (devs *DevsAnalysis) binaryMessage(result *DevsResult) *pb.DevsAnalysisResults {
+	convertStats := func(stats LineStats) *pb.LineStats {
+		return &pb.LineStats{
+			Added:   int32(stats.Added),
+			Removed: int32(stats.Removed),
+			Changed: int32(stats.Changed),
+		}
+	}
+	message := pb.DevsAnalysisResults{
+		Days:     map[int32]*pb.DayDevs{},
+		DevIndex: result.reversedPeopleDict,
+	}
+	for day, dayDevs := range result.Days {
+		pbDay := &pb.DayDevs{Devs: map[int32]*pb.DevDay{}}
+		for dev, stats := range dayDevs {
+			pbDev := &pb.DevDay{
+				Commits:   int32(stats.Commits),
+				Stats:     convertStats(stats.LineStats),
+				Files:     int32(stats.Files),
+				Languages: map[string]*pb.LineStats{},
+			}
+			for lang, langStats := range stats.Languages {
+				pbDev.Languages[lang] = convertStats(langStats)
+			}
+			pbDay.Devs[int32(dev)] = pbDev
+		}
+		message.Days[int32(day)] = pbDay
+	}
+	return &message
+}
+
+func init() {
+	core.Registry.Register(&DevsAnalysis{})
+}
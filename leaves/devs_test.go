@@ -0,0 +1,235 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureDevs() *DevsAnalysis {
+	devs := DevsAnalysis{}
+	devs.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorPeopleCount:        2,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"one", "two"},
+	})
+	devs.Initialize(test.Repository)
+	return &devs
+}
+
+func TestDevsMeta(t *testing.T) {
+	devs := fixtureDevs()
+	assert.Equal(t, devs.Name(), "Devs")
+	assert.Equal(t, len(devs.Provides()), 0)
+	required := [...]string{
+		identity.DependencyAuthor, items.DependencyTreeChanges, items.DependencyFileDiff,
+		items.DependencyBlobCache, items.DependencyDay}
+	for _, name := range required {
+		assert.Contains(t, devs.Requires(), name)
+	}
+	assert.Len(t, devs.ListConfigurationOptions(), 1)
+	assert.Equal(t, devs.ListConfigurationOptions()[0].Name, ConfigDevsTopPeople)
+	assert.Equal(t, devs.Flag(), "devs")
+	assert.Equal(t, devs.PeopleNumber, 2)
+	assert.Equal(t, devs.reversedPeopleDict, []string{"one", "two"})
+	devs.Configure(map[string]interface{}{ConfigDevsTopPeople: 5})
+	assert.Equal(t, devs.TopPeople, 5)
+}
+
+func TestDevsRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&DevsAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Devs")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&DevsAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestDiffLineStats(t *testing.T) {
+	// every rune is a line
+	diffs := []diffmatchpatch.Diff{
+		{Type: diffmatchpatch.DiffEqual, Text: "ab"},
+		{Type: diffmatchpatch.DiffDelete, Text: "cde"},
+		{Type: diffmatchpatch.DiffInsert, Text: "f"},
+		{Type: diffmatchpatch.DiffEqual, Text: "g"},
+		{Type: diffmatchpatch.DiffDelete, Text: "h"},
+		{Type: diffmatchpatch.DiffInsert, Text: "ijk"},
+		{Type: diffmatchpatch.DiffInsert, Text: "l"},
+		{Type: diffmatchpatch.DiffDelete, Text: "m"},
+		{Type: diffmatchpatch.DiffEqual, Text: "n"},
+		{Type: diffmatchpatch.DiffDelete, Text: "o"},
+	}
+	assert.Equal(t, diffLineStats(diffs), LineStats{Added: 3, Removed: 4, Changed: 2})
+	assert.Equal(t, diffLineStats(nil), LineStats{})
+}
+
+func fixtureDevsDeps(author int, day int) map[string]interface{} {
+	change := func(name, from, to string) *object.Change {
+		return &object.Change{
+			From: object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+				Name: name, Mode: 0100644, Hash: plumbing.NewHash(from)}},
+			To: object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+				Name: name, Mode: 0100644, Hash: plumbing.NewHash(to)}},
+		}
+	}
+	deps := map[string]interface{}{}
+	deps[items.DependencyBlobCache] = map[plumbing.Hash]*object.Blob{}
+	deps[items.DependencyTreeChanges] = object.Changes{
+		change("main.go", "291286b4ac41952cbd1389fda66420ec03c1a9fe",
+			"c29112dbd697ad9b401333b80c18a63951bc18d9"),
+		change("README", "baa64828831d174f40140e4b3cfa77d1e917a2c1",
+			"dc248ba2b22048cc730c571a748e8ffcf7085ab9"),
+	}
+	deps[items.DependencyFileDiff] = map[string]items.FileDiffData{
+		"main.go": {Diffs: []diffmatchpatch.Diff{
+			{Type: diffmatchpatch.DiffEqual, Text: "ab"},
+			{Type: diffmatchpatch.DiffDelete, Text: "c"},
+			{Type: diffmatchpatch.DiffInsert, Text: "de"},
+		}},
+		"README": {Diffs: []diffmatchpatch.Diff{
+			{Type: diffmatchpatch.DiffDelete, Text: "a"},
+			{Type: diffmatchpatch.DiffEqual, Text: "b"},
+		}},
+	}
+	deps[items.DependencyDay] = day
+	deps[identity.DependencyAuthor] = author
+	return deps
+}
+
+func TestDevsConsumeFinalize(t *testing.T) {
+	devs := fixtureDevs()
+	result, err := devs.Consume(fixtureDevsDeps(0, 1))
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	devs.Consume(fixtureDevsDeps(0, 1))
+	devs.Consume(fixtureDevsDeps(identity.AuthorMissing, 3))
+	res := devs.Finalize().(DevsResult)
+	assert.Len(t, res.Days, 2)
+	assert.Len(t, res.Days[1], 1)
+	dev := res.Days[1][0]
+	assert.Equal(t, dev.Commits, 2)
+	assert.Equal(t, dev.Files, 4)
+	assert.Equal(t, dev.LineStats, LineStats{Added: 2, Removed: 2, Changed: 2})
+	assert.Equal(t, dev.Languages, map[string]LineStats{
+		"Go":    {Added: 2, Changed: 2},
+		"Other": {Removed: 2},
+	})
+	assert.Len(t, res.Days[3], 1)
+	assert.Equal(t, res.Days[3][2].Commits, 1)
+	assert.Equal(t, res.reversedPeopleDict, []string{"one", "two"})
+}
+
+func TestDevsSerializeText(t *testing.T) {
+	devs := fixtureDevs()
+	devs.Consume(fixtureDevsDeps(1, 1))
+	devs.Consume(fixtureDevsDeps(identity.AuthorMissing, 0))
+	res := devs.Finalize().(DevsResult)
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, devs.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  days:
+    0:
+      2: {commits: 1, lines: [1, 1, 1], files: 2, languages: {"Go": [1, 0, 1], "Other": [0, 1, 0]}}
+    1:
+      1: {commits: 1, lines: [1, 1, 1], files: 2, languages: {"Go": [1, 0, 1], "Other": [0, 1, 0]}}
+  people:
+  - "one"
+  - "two"
+`)
+}
+
+func TestDevsTruncate(t *testing.T) {
+	result := DevsResult{
+		Days: map[int]map[int]*DevDay{
+			0: {
+				0: {Commits: 1, LineStats: LineStats{Added: 1}, Files: 1,
+					Languages: map[string]LineStats{"Go": {Added: 1}}},
+				1: {Commits: 3, LineStats: LineStats{Removed: 2}, Files: 2,
+					Languages: map[string]LineStats{"Go": {Removed: 2}}},
+			},
+			1: {
+				2: {Commits: 1, LineStats: LineStats{Changed: 1}, Files: 1,
+					Languages: map[string]LineStats{"Python": {Changed: 1}}},
+				3: {Commits: 7, Languages: map[string]LineStats{}},
+			},
+		},
+		reversedPeopleDict: []string{"one", "two", "three"},
+	}
+	truncated := truncateDevs(result, 1)
+	assert.Equal(t, truncated.reversedPeopleDict, []string{"two", TopOtherName})
+	assert.Equal(t, *truncated.Days[0][0], DevDay{Commits: 3, LineStats: LineStats{Removed: 2},
+		Files: 2, Languages: map[string]LineStats{"Go": {Removed: 2}}})
+	assert.Equal(t, *truncated.Days[0][1], DevDay{Commits: 1, LineStats: LineStats{Added: 1},
+		Files: 1, Languages: map[string]LineStats{"Go": {Added: 1}}})
+	assert.Len(t, truncated.Days[1], 2)
+	assert.Equal(t, truncated.Days[1][1].Commits, 1)
+	// the unmatched authors stay separate
+	assert.Equal(t, truncated.Days[1][2].Commits, 7)
+	assert.Equal(t, truncateDevs(result, 0).reversedPeopleDict, result.reversedPeopleDict)
+	assert.Equal(t, truncateDevs(result, 3).reversedPeopleDict, result.reversedPeopleDict)
+}
+
+func TestDevsSerializeBinary(t *testing.T) {
+	devs := fixtureDevs()
+	devs.Consume(fixtureDevsDeps(1, 5))
+	res := devs.Finalize().(DevsResult)
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, devs.Serialize(res, true, buffer))
+	msg := pb.DevsAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.DevIndex, []string{"one", "two"})
+	assert.Len(t, msg.Days, 1)
+	dev := msg.Days[5].Devs[1]
+	assert.Equal(t, dev.Commits, int32(1))
+	assert.Equal(t, dev.Files, int32(2))
+	assert.Equal(t, *dev.Stats, pb.LineStats{Added: 1, Removed: 1, Changed: 1})
+	assert.Equal(t, *dev.Languages["Go"], pb.LineStats{Added: 1, Changed: 1})
+}
+
+func TestDevsSerializeDayRanges(t *testing.T) {
+	devs := fixtureDevs()
+	devs.Consume(fixtureDevsDeps(1, 5))
+	devs.Consume(fixtureDevsDeps(0, 12))
+	devs.Consume(fixtureDevsDeps(1, 25))
+	res := devs.Finalize().(DevsResult)
+	ranges, err := devs.SerializeDayRanges(res, 10)
+	assert.Nil(t, err)
+	assert.Len(t, ranges, 3)
+	joined := []byte{}
+	for i, part := range ranges {
+		assert.Equal(t, part.From, i*10)
+		assert.Equal(t, part.To, (i+1)*10)
+		msg := pb.DevsAnalysisResults{}
+		assert.Nil(t, proto.Unmarshal(part.Data, &msg))
+		assert.Len(t, msg.Days, 1)
+		joined = append(joined, part.Data...)
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, devs.Serialize(res, true, buffer))
+	expected := pb.DevsAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &expected))
+	actual := pb.DevsAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(joined, &actual))
+	assert.Equal(t, actual, expected)
+	// the empty ranges are skipped except the first which carries the names
+	ranges, err = devs.SerializeDayRanges(res, 4)
+	assert.Nil(t, err)
+	assert.Len(t, ranges, 4)
+	assert.Equal(t, ranges[0].From, 0)
+	assert.Equal(t, ranges[1].From, 4)
+	assert.Equal(t, ranges[3].From, 24)
+}
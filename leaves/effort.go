@@ -0,0 +1,537 @@
+package leaves
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/burndown"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// EffortEstimationAnalysis converts the churn and the surviving code into the rough effort
+// estimations in person-months with the basic COCOMO model: effort = Coefficient * KLOC ^ Exponent.
+// The estimations are calculated per period and per component, which is a directory prefix.
+// It is a LeafPipelineItem and a LinearPipelineItem.
+// Reference: Boehm, B. W. "Software Engineering Economics", 1981.
+type EffortEstimationAnalysis struct {
+	// Coefficient is the multiplier of the model; 2.4 corresponds to the "organic" projects,
+	// 3.0 to the "semi-detached" and 3.6 to the "embedded".
+	Coefficient float32
+	// Exponent is the power of the thousands of lines in the model; 1.05 corresponds to
+	// the "organic" projects, 1.12 to the "semi-detached" and 1.20 to the "embedded".
+	Exponent float32
+	// Period is the length of the periods: EffortPeriodMonth, EffortPeriodQuarter or
+	// EffortPeriodYear.
+	Period string
+	// ComponentDepth is the number of the leading directories which name the component.
+	ComponentDepth int
+
+	// files is the mapping <file path> -> *effortFile. The values of the lines are the indexes
+	// of the periods in which the lines were written.
+	files map[string]*effortFile
+	// periods are the names of the periods, the indexes are the line values in files.
+	periods []string
+	// stats maps the component to the period index to the line stats.
+	stats map[string]map[int]*EffortStats
+}
+
+// effortFile is the line history of a file together with its component, so that
+// the burndown.Status callback knows what to update.
+type effortFile struct {
+	*burndown.File
+	// component is the component of the current file name.
+	component string
+	// periods maps the period index to the number of the alive lines written in that period.
+	periods map[int]int
+}
+
+// EffortStats are the line stats and the effort estimations of a component in a period.
+type EffortStats struct {
+	// Added is the number of the added lines.
+	Added int
+	// Removed is the number of the removed lines.
+	Removed int
+	// Surviving is the number of the added lines which are alive at the end of the analysed history.
+	Surviving int
+	// Effort is the estimated effort to write the added lines in person-months.
+	Effort float64
+	// SurvivingEffort is the estimated effort to write the surviving lines in person-months.
+	SurvivingEffort float64
+}
+
+// EffortEstimationResult is returned by EffortEstimationAnalysis.Finalize() and carries
+// the effort estimations.
+type EffortEstimationResult struct {
+	// Periods maps the period ("2018Q1", "2018-03" or "2018") to the component to the stats.
+	// The files in the root directory belong to the component "/".
+	Periods map[string]map[string]EffortStats
+	// Components maps the component to the stats over the whole analysed history.
+	Components map[string]EffortStats
+	// Total are the stats of the whole repository over the whole analysed history.
+	Total EffortStats
+
+	// coefficient, exponent and period are copied from EffortEstimationAnalysis.
+	coefficient float32
+	exponent    float32
+	period      string
+}
+
+const (
+	// ConfigEffortCoefficient is the name of the option to set EffortEstimationAnalysis.Coefficient.
+	ConfigEffortCoefficient = "EffortEstimation.Coefficient"
+	// ConfigEffortExponent is the name of the option to set EffortEstimationAnalysis.Exponent.
+	ConfigEffortExponent = "EffortEstimation.Exponent"
+	// ConfigEffortPeriod is the name of the option to set EffortEstimationAnalysis.Period.
+	ConfigEffortPeriod = "EffortEstimation.Period"
+	// ConfigEffortComponentDepth is the name of the option to set
+	// EffortEstimationAnalysis.ComponentDepth.
+	ConfigEffortComponentDepth = "EffortEstimation.ComponentDepth"
+	// DefaultEffortCoefficient is the COCOMO multiplier of the "organic" projects.
+	DefaultEffortCoefficient = float32(2.4)
+	// DefaultEffortExponent is the COCOMO exponent of the "organic" projects.
+	DefaultEffortExponent = float32(1.05)
+	// DefaultEffortComponentDepth is the default value of EffortEstimationAnalysis.ComponentDepth.
+	DefaultEffortComponentDepth = 1
+	// EffortPeriodMonth splits the history by calendar months, e.g. "2018-03".
+	EffortPeriodMonth = "month"
+	// EffortPeriodQuarter splits the history by calendar quarters, e.g. "2018Q1".
+	EffortPeriodQuarter = "quarter"
+	// EffortPeriodYear splits the history by calendar years, e.g. "2018".
+	EffortPeriodYear = "year"
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (effort *EffortEstimationAnalysis) Name() string {
+	return "EffortEstimation"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (effort *EffortEstimationAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (effort *EffortEstimationAnalysis) Requires() []string {
+	arr := [...]string{items.DependencyTreeChanges, items.DependencyFileDiff, items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (effort *EffortEstimationAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigEffortCoefficient,
+		Description: "The multiplier of the COCOMO model: 2.4 for the \"organic\" projects, " +
+			"3.0 for the \"semi-detached\" and 3.6 for the \"embedded\".",
+		Flag:    "effort-coefficient",
+		Type:    core.FloatConfigurationOption,
+		Default: DefaultEffortCoefficient}, {
+		Name: ConfigEffortExponent,
+		Description: "The exponent of the COCOMO model: 1.05 for the \"organic\" projects, " +
+			"1.12 for the \"semi-detached\" and 1.20 for the \"embedded\".",
+		Flag:    "effort-exponent",
+		Type:    core.FloatConfigurationOption,
+		Default: DefaultEffortExponent}, {
+		Name:        ConfigEffortPeriod,
+		Description: "The length of the periods: \"month\", \"quarter\" or \"year\".",
+		Flag:        "effort-period",
+		Type:        core.StringConfigurationOption,
+		Default:     EffortPeriodQuarter}, {
+		Name:        ConfigEffortComponentDepth,
+		Description: "The number of the leading directories which name the component.",
+		Flag:        "effort-component-depth",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultEffortComponentDepth},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (effort *EffortEstimationAnalysis) Flag() string {
+	return "effort"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (effort *EffortEstimationAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigEffortCoefficient].(float32); exists {
+		effort.Coefficient = val
+	}
+	if val, exists := facts[ConfigEffortExponent].(float32); exists {
+		effort.Exponent = val
+	}
+	if val, exists := facts[ConfigEffortPeriod].(string); exists {
+		effort.Period = val
+	}
+	if val, exists := facts[ConfigEffortComponentDepth].(int); exists {
+		effort.ComponentDepth = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (effort *EffortEstimationAnalysis) Initialize(repository *git.Repository) {
+	if effort.Coefficient <= 0 {
+		log.Printf("Warning: adjusted the effort coefficient to %v\n", DefaultEffortCoefficient)
+		effort.Coefficient = DefaultEffortCoefficient
+	}
+	if effort.Exponent <= 0 {
+		log.Printf("Warning: adjusted the effort exponent to %v\n", DefaultEffortExponent)
+		effort.Exponent = DefaultEffortExponent
+	}
+	switch effort.Period {
+	case EffortPeriodMonth, EffortPeriodQuarter, EffortPeriodYear:
+	default:
+		log.Printf("Warning: unknown effort period %s => reset to %s\n",
+			effort.Period, EffortPeriodQuarter)
+		effort.Period = EffortPeriodQuarter
+	}
+	if effort.ComponentDepth <= 0 {
+		log.Printf("Warning: adjusted the component depth to %d\n", DefaultEffortComponentDepth)
+		effort.ComponentDepth = DefaultEffortComponentDepth
+	}
+	effort.files = map[string]*effortFile{}
+	effort.periods = []string{}
+	effort.stats = map[string]map[int]*EffortStats{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (effort *EffortEstimationAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	period := effort.periodIndex(commit)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			err = effort.handleInsertion(change, period, cache)
+		case merkletrie.Delete:
+			err = effort.handleDeletion(change, period)
+		case merkletrie.Modify:
+			err = effort.handleModification(change, period, cache, fileDiffs)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// RequiresLinearHistory returns true because the lines of the files are followed through
+// the diffs, which do not apply to the interleaved branches. See core.LinearPipelineItem.
+func (effort *EffortEstimationAnalysis) RequiresLinearHistory() bool {
+	return true
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (effort *EffortEstimationAnalysis) Finalize() interface{} {
+	result := EffortEstimationResult{
+		Periods:     map[string]map[string]EffortStats{},
+		Components:  map[string]EffortStats{},
+		coefficient: effort.Coefficient,
+		exponent:    effort.Exponent,
+		period:      effort.Period,
+	}
+	for component, periods := range effort.stats {
+		var componentStats EffortStats
+		for index, stats := range periods {
+			name := effort.periods[index]
+			if result.Periods[name] == nil {
+				result.Periods[name] = map[string]EffortStats{}
+			}
+			result.Periods[name][component] = effort.estimate(*stats)
+			componentStats.Added += stats.Added
+			componentStats.Removed += stats.Removed
+			componentStats.Surviving += stats.Surviving
+		}
+		result.Components[component] = effort.estimate(componentStats)
+		result.Total.Added += componentStats.Added
+		result.Total.Removed += componentStats.Removed
+		result.Total.Surviving += componentStats.Surviving
+	}
+	result.Total = effort.estimate(result.Total)
+	return result
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (effort *EffortEstimationAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	effortResult := result.(EffortEstimationResult)
+	if binary {
+		return effort.serializeBinary(&effortResult, writer)
+	}
+	effort.serializeText(&effortResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (effort *EffortEstimationAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(effort, result, &pb.EffortEstimationResults{}, writer)
+}
+
+func (effort *EffortEstimationAnalysis) serializeText(result *EffortEstimationResult, writer io.Writer) {
+	formatStats := func(stats EffortStats) string {
+		return fmt.Sprintf("[%d, %d, %d, %.2f, %.2f]", stats.Added, stats.Removed,
+			stats.Surviving, stats.Effort, stats.SurvivingEffort)
+	}
+	fmt.Fprintln(writer, "  coefficient:", result.coefficient)
+	fmt.Fprintln(writer, "  exponent:", result.exponent)
+	fmt.Fprintln(writer, "  period:", result.period)
+	fmt.Fprintln(writer, "  # added, removed, surviving, effort, surviving effort (person-months)")
+	fmt.Fprintln(writer, "  periods:")
+	periods := make([]string, 0, len(result.Periods))
+	for period := range result.Periods {
+		periods = append(periods, period)
+	}
+	sort.Strings(periods)
+	for _, period := range periods {
+		fmt.Fprintf(writer, "    %s:\n", yaml.SafeString(period))
+		components := result.Periods[period]
+		names := make([]string, 0, len(components))
+		for name := range components {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(writer, "      %s: %s\n", yaml.SafeString(name), formatStats(components[name]))
+		}
+	}
+	fmt.Fprintln(writer, "  components:")
+	names := make([]string, 0, len(result.Components))
+	for name := range result.Components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(writer, "    %s: %s\n", yaml.SafeString(name), formatStats(result.Components[name]))
+	}
+	fmt.Fprintln(writer, "  total:", formatStats(result.Total))
+}
+
+func (effort *EffortEstimationAnalysis) serializeBinary(result *EffortEstimationResult, writer io.Writer) error {
+	convertStats := func(stats EffortStats) *pb.EffortStats {
+		return &pb.EffortStats{
+			Added:           int32(stats.Added),
+			Removed:         int32(stats.Removed),
+			Surviving:       int32(stats.Surviving),
+			Effort:          float32(stats.Effort),
+			SurvivingEffort: float32(stats.SurvivingEffort),
+		}
+	}
+	message := pb.EffortEstimationResults{
+		Coefficient: result.coefficient,
+		Exponent:    result.exponent,
+		Period:      result.period,
+		Periods:     map[string]*pb.ComponentEfforts{},
+		Components:  map[string]*pb.EffortStats{},
+		Total:       convertStats(result.Total),
+	}
+	for period, components := range result.Periods {
+		pbPeriod := &pb.ComponentEfforts{Components: map[string]*pb.EffortStats{}}
+		for name, stats := range components {
+			pbPeriod.Components[name] = convertStats(stats)
+		}
+		message.Periods[period] = pbPeriod
+	}
+	for name, stats := range result.Components {
+		message.Components[name] = convertStats(stats)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+// estimate fills the effort estimations from the line stats.
+func (effort *EffortEstimationAnalysis) estimate(stats EffortStats) EffortStats {
+	model := func(lines int) float64 {
+		if lines <= 0 {
+			return 0
+		}
+		return float64(effort.Coefficient) * math.Pow(float64(lines)/1000, float64(effort.Exponent))
+	}
+	stats.Effort = model(stats.Added)
+	stats.SurvivingEffort = model(stats.Surviving)
+	return stats
+}
+
+// periodIndex returns the index of the period of the commit in effort.periods.
+// The commits are not guaranteed to be sorted by time, so the periods are searched.
+func (effort *EffortEstimationAnalysis) periodIndex(commit *object.Commit) int {
+	when := commit.Author.When.UTC()
+	var name string
+	switch effort.Period {
+	case EffortPeriodMonth:
+		name = when.Format("2006-01")
+	case EffortPeriodYear:
+		name = when.Format("2006")
+	default:
+		name = fmt.Sprintf("%dQ%d", when.Year(), (int(when.Month())+2)/3)
+	}
+	for i := len(effort.periods) - 1; i >= 0; i-- {
+		if effort.periods[i] == name {
+			return i
+		}
+	}
+	effort.periods = append(effort.periods, name)
+	return len(effort.periods) - 1
+}
+
+// getStats returns the stats of the component in the period.
+func (effort *EffortEstimationAnalysis) getStats(component string, period int) *EffortStats {
+	periods := effort.stats[component]
+	if periods == nil {
+		periods = map[int]*EffortStats{}
+		effort.stats[component] = periods
+	}
+	stats := periods[period]
+	if stats == nil {
+		stats = &EffortStats{}
+		periods[period] = stats
+	}
+	return stats
+}
+
+// updateLines is the burndown.Status callback which counts the added, removed and surviving lines.
+// The line values are the period indexes.
+func (effort *EffortEstimationAnalysis) updateLines(
+	data interface{}, currentPeriod int, previousPeriod int, delta int) {
+	file := data.(*effortFile)
+	file.periods[previousPeriod] += delta
+	if file.periods[previousPeriod] == 0 {
+		delete(file.periods, previousPeriod)
+	}
+	if delta > 0 {
+		effort.getStats(file.component, currentPeriod).Added += delta
+	} else {
+		effort.getStats(file.component, currentPeriod).Removed -= delta
+	}
+	effort.getStats(file.component, previousPeriod).Surviving += delta
+}
+
+func (effort *EffortEstimationAnalysis) handleInsertion(
+	change *object.Change, period int, cache map[plumbing.Hash]*object.Blob) error {
+	lines, err := items.CountLines(cache[change.To.TreeEntry.Hash])
+	if err != nil {
+		if err.Error() == "binary" {
+			return nil
+		}
+		return err
+	}
+	name := change.To.Name
+	if _, exists := effort.files[name]; exists {
+		return fmt.Errorf("file %s already exists", name)
+	}
+	file := &effortFile{component: fileComponent(name, effort.ComponentDepth), periods: map[int]int{}}
+	file.File = burndown.NewFile(period, lines, burndown.NewStatus(file, effort.updateLines))
+	effort.files[name] = file
+	return nil
+}
+
+func (effort *EffortEstimationAnalysis) handleDeletion(change *object.Change, period int) error {
+	name := change.From.Name
+	file, exists := effort.files[name]
+	if !exists {
+		// binary files are not tracked
+		return nil
+	}
+	file.Update(period, 0, 0, file.Len())
+	delete(effort.files, name)
+	return nil
+}
+
+// handleRename moves the surviving lines of the file to the component of the new name.
+func (effort *EffortEstimationAnalysis) handleRename(file *effortFile, from, to string) {
+	effort.files[to] = file
+	delete(effort.files, from)
+	component := fileComponent(to, effort.ComponentDepth)
+	if component == file.component {
+		return
+	}
+	for period, lines := range file.periods {
+		effort.getStats(file.component, period).Surviving -= lines
+		effort.getStats(component, period).Surviving += lines
+	}
+	file.component = component
+}
+
+func (effort *EffortEstimationAnalysis) handleModification(
+	change *object.Change, period int, cache map[plumbing.Hash]*object.Blob,
+	diffs map[string]items.FileDiffData) error {
+	file, exists := effort.files[change.From.Name]
+	if !exists {
+		return effort.handleInsertion(change, period, cache)
+	}
+	if change.To.Name != change.From.Name {
+		effort.handleRename(file, change.From.Name, change.To.Name)
+	}
+	thisDiffs := diffs[change.To.Name]
+	if file.Len() != thisDiffs.OldLinesOfCode {
+		return fmt.Errorf("%s: internal integrity error src %d != %d",
+			change.To.Name, thisDiffs.OldLinesOfCode, file.Len())
+	}
+	// the diffs are line-level so the number of lines equals to the rune count
+	position := 0
+	pending := diffmatchpatch.Diff{Text: ""}
+	for _, edit := range thisDiffs.Diffs {
+		length := utf8.RuneCountInString(edit.Text)
+		switch edit.Type {
+		case diffmatchpatch.DiffEqual:
+			if pending.Text != "" {
+				file.Update(period, position, 0, utf8.RuneCountInString(pending.Text))
+				pending.Text = ""
+			}
+			position += length
+		case diffmatchpatch.DiffInsert:
+			file.Update(period, position, length, utf8.RuneCountInString(pending.Text))
+			position += length
+			pending.Text = ""
+		case diffmatchpatch.DiffDelete:
+			if pending.Text != "" {
+				return errors.New("DiffDelete may not appear after DiffDelete")
+			}
+			pending = edit
+		default:
+			return fmt.Errorf("diff operation is not supported: %d", edit.Type)
+		}
+	}
+	if pending.Text != "" {
+		file.Update(period, position, 0, utf8.RuneCountInString(pending.Text))
+	}
+	if file.Len() != thisDiffs.NewLinesOfCode {
+		return fmt.Errorf("%s: internal integrity error dst %d != %d",
+			change.To.Name, thisDiffs.NewLinesOfCode, file.Len())
+	}
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&EffortEstimationAnalysis{})
+}
@@ -0,0 +1,228 @@
+package leaves
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureEffort() *EffortEstimationAnalysis {
+	effort := EffortEstimationAnalysis{}
+	effort.Configure(map[string]interface{}{
+		ConfigEffortCoefficient:    DefaultEffortCoefficient,
+		ConfigEffortExponent:       DefaultEffortExponent,
+		ConfigEffortPeriod:         EffortPeriodQuarter,
+		ConfigEffortComponentDepth: 1,
+	})
+	effort.Initialize(test.Repository)
+	return &effort
+}
+
+func TestEffortMeta(t *testing.T) {
+	effort := fixtureEffort()
+	assert.Equal(t, effort.Name(), "EffortEstimation")
+	assert.Len(t, effort.Provides(), 0)
+	assert.True(t, effort.RequiresLinearHistory())
+	assert.Equal(t, effort.Requires(), []string{
+		items.DependencyTreeChanges, items.DependencyFileDiff, items.DependencyBlobCache})
+	opts := effort.ListConfigurationOptions()
+	assert.Len(t, opts, 4)
+	assert.Equal(t, opts[0].Name, ConfigEffortCoefficient)
+	assert.Equal(t, opts[1].Name, ConfigEffortExponent)
+	assert.Equal(t, opts[2].Name, ConfigEffortPeriod)
+	assert.Equal(t, opts[3].Name, ConfigEffortComponentDepth)
+	assert.Equal(t, effort.Flag(), "effort")
+	effort.Configure(map[string]interface{}{
+		ConfigEffortCoefficient:    float32(3.6),
+		ConfigEffortExponent:       float32(1.2),
+		ConfigEffortPeriod:         EffortPeriodMonth,
+		ConfigEffortComponentDepth: 2,
+	})
+	assert.Equal(t, effort.Coefficient, float32(3.6))
+	assert.Equal(t, effort.Exponent, float32(1.2))
+	assert.Equal(t, effort.Period, EffortPeriodMonth)
+	assert.Equal(t, effort.ComponentDepth, 2)
+}
+
+func TestEffortRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&EffortEstimationAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "EffortEstimation")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&EffortEstimationAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestEffortInitialize(t *testing.T) {
+	effort := EffortEstimationAnalysis{Period: "week"}
+	effort.Initialize(test.Repository)
+	assert.Equal(t, effort.Coefficient, DefaultEffortCoefficient)
+	assert.Equal(t, effort.Exponent, DefaultEffortExponent)
+	assert.Equal(t, effort.Period, EffortPeriodQuarter)
+	assert.Equal(t, effort.ComponentDepth, DefaultEffortComponentDepth)
+	assert.NotNil(t, effort.files)
+	assert.NotNil(t, effort.stats)
+}
+
+func TestEffortEstimate(t *testing.T) {
+	effort := fixtureEffort()
+	stats := effort.estimate(EffortStats{Added: 1000, Surviving: 10000})
+	assert.InDelta(t, stats.Effort, 2.4, 1e-6)
+	assert.InDelta(t, stats.SurvivingEffort, 2.4*math.Pow(10, 1.05), 1e-4)
+	assert.Equal(t, effort.estimate(EffortStats{Removed: 10}), EffortStats{Removed: 10})
+}
+
+func TestEffortPeriodsAndComponents(t *testing.T) {
+	effort := fixtureEffort()
+	commit := func(month time.Month, year int) *object.Commit {
+		return &object.Commit{Author: object.Signature{
+			When: time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)}}
+	}
+	assert.Equal(t, effort.periodIndex(commit(time.February, 2018)), 0)
+	assert.Equal(t, effort.periodIndex(commit(time.May, 2018)), 1)
+	assert.Equal(t, effort.periodIndex(commit(time.March, 2018)), 0)
+	assert.Equal(t, effort.periods, []string{"2018Q1", "2018Q2"})
+	effort.Period = EffortPeriodMonth
+	assert.Equal(t, effort.periodIndex(commit(time.March, 2018)), 2)
+	effort.Period = EffortPeriodYear
+	assert.Equal(t, effort.periodIndex(commit(time.March, 2017)), 3)
+	assert.Equal(t, effort.periods, []string{"2018Q1", "2018Q2", "2018-03", "2017"})
+	assert.Equal(t, fileComponent("a.go", effort.ComponentDepth), "/")
+	assert.Equal(t, fileComponent("src/a.go", effort.ComponentDepth), "src")
+	assert.Equal(t, fileComponent("src/pkg/a.go", effort.ComponentDepth), "src")
+	effort.ComponentDepth = 2
+	assert.Equal(t, fileComponent("src/pkg/a.go", effort.ComponentDepth), "src/pkg")
+}
+
+func TestEffortConsumeFinalize(t *testing.T) {
+	effort := fixtureEffort()
+	blobA := fixtureChurnOriginBlob("1\n2\n3\n4\n")
+	blobB := fixtureChurnOriginBlob("1\n2\n")
+	blobC := fixtureChurnOriginBlob("1\n2\n3\n")
+	entry := func(name string, blob *object.Blob) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: blob.Hash}}
+	}
+	// src/a.go is created
+	result, err := effort.Consume(fixtureChurnOriginDeps(0, time.January,
+		object.Changes{{To: entry("src/a.go", blobA)}}, nil,
+		map[plumbing.Hash]*object.Blob{blobA.Hash: blobA}))
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	// two lines are replaced with a single line
+	_, err = effort.Consume(fixtureChurnOriginDeps(0, time.April,
+		object.Changes{{From: entry("src/a.go", blobA), To: entry("src/a.go", blobC)}},
+		map[string]items.FileDiffData{"src/a.go": {
+			OldLinesOfCode: 4, NewLinesOfCode: 3, Diffs: []diffmatchpatch.Diff{
+				{Type: diffmatchpatch.DiffEqual, Text: "a"},
+				{Type: diffmatchpatch.DiffDelete, Text: "bc"},
+				{Type: diffmatchpatch.DiffInsert, Text: "x"},
+				{Type: diffmatchpatch.DiffEqual, Text: "d"},
+			}}}, nil))
+	assert.Nil(t, err)
+	// src/a.go is moved to lib/a.go
+	_, err = effort.Consume(fixtureChurnOriginDeps(0, time.May,
+		object.Changes{{From: entry("src/a.go", blobC), To: entry("lib/a.go", blobC)}},
+		map[string]items.FileDiffData{"lib/a.go": {
+			OldLinesOfCode: 3, NewLinesOfCode: 3, Diffs: []diffmatchpatch.Diff{
+				{Type: diffmatchpatch.DiffEqual, Text: "abc"},
+			}}}, nil))
+	assert.Nil(t, err)
+	// b.go is created and deleted
+	_, err = effort.Consume(fixtureChurnOriginDeps(0, time.July,
+		object.Changes{{To: entry("b.go", blobB)}}, nil,
+		map[plumbing.Hash]*object.Blob{blobB.Hash: blobB}))
+	assert.Nil(t, err)
+	_, err = effort.Consume(fixtureChurnOriginDeps(0, time.August,
+		object.Changes{{From: entry("b.go", blobB)}}, nil, nil))
+	assert.Nil(t, err)
+	assert.Len(t, effort.files, 1)
+	res := effort.Finalize().(EffortEstimationResult)
+	strip := func(stats EffortStats) EffortStats {
+		stats.Effort = 0
+		stats.SurvivingEffort = 0
+		return stats
+	}
+	assert.Len(t, res.Periods, 3)
+	assert.Len(t, res.Periods["2018Q1"], 2)
+	assert.Equal(t, strip(res.Periods["2018Q1"]["src"]), EffortStats{Added: 4})
+	assert.Equal(t, strip(res.Periods["2018Q1"]["lib"]), EffortStats{Surviving: 2})
+	assert.Len(t, res.Periods["2018Q2"], 2)
+	assert.Equal(t, strip(res.Periods["2018Q2"]["src"]), EffortStats{Added: 1, Removed: 2})
+	assert.Equal(t, strip(res.Periods["2018Q2"]["lib"]), EffortStats{Surviving: 1})
+	assert.Equal(t, strip(res.Periods["2018Q3"]["/"]), EffortStats{Added: 2, Removed: 2})
+	assert.Len(t, res.Components, 3)
+	assert.Equal(t, strip(res.Components["src"]), EffortStats{Added: 5, Removed: 2})
+	assert.Equal(t, strip(res.Components["lib"]), EffortStats{Surviving: 3})
+	assert.Equal(t, strip(res.Total), EffortStats{Added: 7, Removed: 4, Surviving: 3})
+	assert.InDelta(t, res.Total.Effort, 2.4*math.Pow(0.007, 1.05), 1e-6)
+	assert.InDelta(t, res.Total.SurvivingEffort, 2.4*math.Pow(0.003, 1.05), 1e-6)
+	assert.Equal(t, res.Components["lib"].Effort, float64(0))
+	assert.Equal(t, res.period, EffortPeriodQuarter)
+}
+
+func TestEffortSerialize(t *testing.T) {
+	effort := fixtureEffort()
+	res := EffortEstimationResult{
+		Periods: map[string]map[string]EffortStats{
+			"2018Q2": {
+				"src": {Added: 1000, Removed: 10, Surviving: 500, Effort: 2.4, SurvivingEffort: 1.16},
+				"/":   {Added: 20, Surviving: 20, Effort: 0.03, SurvivingEffort: 0.03},
+			},
+			"2018Q1": {"src": {Added: 4}},
+		},
+		Components: map[string]EffortStats{
+			"src": {Added: 1004, Removed: 10, Surviving: 500, Effort: 2.41, SurvivingEffort: 1.16},
+			"/":   {Added: 20, Surviving: 20, Effort: 0.03, SurvivingEffort: 0.03},
+		},
+		Total:       EffortStats{Added: 1024, Removed: 10, Surviving: 520, Effort: 2.46, SurvivingEffort: 1.2},
+		coefficient: 2.4,
+		exponent:    1.05,
+		period:      EffortPeriodQuarter,
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, effort.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  coefficient: 2.4
+  exponent: 1.05
+  period: quarter
+  # added, removed, surviving, effort, surviving effort (person-months)
+  periods:
+    "2018Q1":
+      "src": [4, 0, 0, 0.00, 0.00]
+    "2018Q2":
+      "/": [20, 0, 20, 0.03, 0.03]
+      "src": [1000, 10, 500, 2.40, 1.16]
+  components:
+    "/": [20, 0, 20, 0.03, 0.03]
+    "src": [1004, 10, 500, 2.41, 1.16]
+  total: [1024, 10, 520, 2.46, 1.20]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, effort.Serialize(res, true, buffer))
+	msg := pb.EffortEstimationResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Coefficient, float32(2.4))
+	assert.Equal(t, msg.Period, EffortPeriodQuarter)
+	assert.Len(t, msg.Periods, 2)
+	assert.Equal(t, *msg.Periods["2018Q2"].Components["src"], pb.EffortStats{
+		Added: 1000, Removed: 10, Surviving: 500, Effort: 2.4, SurvivingEffort: 1.16})
+	assert.Len(t, msg.Components, 2)
+	assert.Equal(t, msg.Total.Added, int32(1024))
+}
@@ -0,0 +1,279 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+)
+
+// ErrorHandlingAnalysis measures the error handling hygiene of Go code over time. It counts
+// the calls to panic() and log.Fatal*(), the return statements which propagate errors and
+// the assignments which discard the results of calls with the blank identifier (`_ =`).
+// It is a LeafPipelineItem.
+type ErrorHandlingAnalysis struct {
+	// files maps the file name to the error handling statistics of its current UAST.
+	files map[string]ErrorHandlingStats
+	// history maps days to the repository-wide error handling statistics.
+	history map[int]ErrorHandlingStats
+}
+
+// ErrorHandlingStats are the counts of the error handling patterns in a file or in the whole
+// repository.
+type ErrorHandlingStats struct {
+	// Panics is the number of panic() calls.
+	Panics int
+	// Fatals is the number of log.Fatal(), log.Fatalf() and log.Fatalln() calls.
+	Fatals int
+	// Returns is the number of return statements which return errors.
+	Returns int
+	// Ignored is the number of assignments of call results to the blank identifier.
+	Ignored int
+}
+
+// ErrorHandlingResult is returned by ErrorHandlingAnalysis.Finalize() and carries
+// the error handling statistics for each day when they changed.
+type ErrorHandlingResult struct {
+	Days map[int]ErrorHandlingStats
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (eh *ErrorHandlingAnalysis) Name() string {
+	return "ErrorHandling"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (eh *ErrorHandlingAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (eh *ErrorHandlingAnalysis) Requires() []string {
+	arr := [...]string{uast_items.DependencyUastChanges, items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (eh *ErrorHandlingAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (eh *ErrorHandlingAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (eh *ErrorHandlingAnalysis) Flag() string {
+	return "error-handling"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (eh *ErrorHandlingAnalysis) Configure(facts map[string]interface{}) {
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (eh *ErrorHandlingAnalysis) Initialize(repository *git.Repository) {
+	eh.files = map[string]ErrorHandlingStats{}
+	eh.history = map[int]ErrorHandlingStats{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (eh *ErrorHandlingAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	day := deps[items.DependencyDay].(int)
+	changed := false
+	for _, change := range changes {
+		if name := change.Change.From.Name; name != "" {
+			if _, exists := eh.files[name]; exists {
+				delete(eh.files, name)
+				changed = true
+			}
+		}
+		if change.After == nil || path.Ext(change.Change.To.Name) != ".go" {
+			continue
+		}
+		eh.files[change.Change.To.Name] = ExtractErrorHandlingStats(change.After)
+		changed = true
+	}
+	if !changed {
+		return nil, nil
+	}
+	totals := ErrorHandlingStats{}
+	for _, stats := range eh.files {
+		totals.Panics += stats.Panics
+		totals.Fatals += stats.Fatals
+		totals.Returns += stats.Returns
+		totals.Ignored += stats.Ignored
+	}
+	eh.history[day] = totals
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (eh *ErrorHandlingAnalysis) Finalize() interface{} {
+	return ErrorHandlingResult{Days: eh.history}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (eh *ErrorHandlingAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	ehResult := result.(ErrorHandlingResult)
+	if binary {
+		return eh.serializeBinary(&ehResult, writer)
+	}
+	eh.serializeText(&ehResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (eh *ErrorHandlingAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(eh, result, &pb.ErrorHandlingResults{}, writer)
+}
+
+func (eh *ErrorHandlingAnalysis) serializeText(result *ErrorHandlingResult, writer io.Writer) {
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	for _, day := range days {
+		stats := result.Days[day]
+		fmt.Fprintf(writer, "  %d: {panics: %d, fatals: %d, returns: %d, ignored: %d}\n",
+			day, stats.Panics, stats.Fatals, stats.Returns, stats.Ignored)
+	}
+}
+
+func (eh *ErrorHandlingAnalysis) serializeBinary(result *ErrorHandlingResult, writer io.Writer) error {
+	message := pb.ErrorHandlingResults{
+		Days: map[int32]*pb.ErrorHandlingStats{},
+	}
+	for day, stats := range result.Days {
+		message.Days[int32(day)] = &pb.ErrorHandlingStats{
+			Panics:  int32(stats.Panics),
+			Fatals:  int32(stats.Fatals),
+			Returns: int32(stats.Returns),
+			Ignored: int32(stats.Ignored),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+// ExtractErrorHandlingStats counts the error handling patterns in the UAST of a Go file.
+func ExtractErrorHandlingStats(root *uast.Node) ErrorHandlingStats {
+	stats := ErrorHandlingStats{}
+	uast_items.VisitEachNode(root, func(node *uast.Node) {
+		switch {
+		case hasRole(node, uast.Call):
+			switch callee := calleeName(node); {
+			case callee == "panic":
+				stats.Panics++
+			case strings.HasPrefix(callee, "log.Fatal"):
+				stats.Fatals++
+			}
+		case hasRole(node, uast.Return):
+			if returnsError(node) {
+				stats.Returns++
+			}
+		case hasRole(node, uast.Assignment):
+			if ignoresResult(node) {
+				stats.Ignored++
+			}
+		}
+	})
+	return stats
+}
+
+func hasRole(node *uast.Node, role uast.Role) bool {
+	for _, r := range node.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// calleeName returns the dot-separated identifiers of the called function, e.g. "log.Fatalf".
+func calleeName(call *uast.Node) string {
+	for _, child := range call.Children {
+		if !hasRole(child, uast.Callee) {
+			continue
+		}
+		return strings.Join(collectIdentifiers(child, nil), ".")
+	}
+	return ""
+}
+
+// collectIdentifiers appends the identifier tokens in the subtree to the list in the source order.
+// uast_items.VisitEachNode() is not suitable because it traverses the children backwards.
+func collectIdentifiers(node *uast.Node, identifiers []string) []string {
+	if node.Token != "" && hasRole(node, uast.Identifier) {
+		identifiers = append(identifiers, node.Token)
+	}
+	for _, child := range node.Children {
+		identifiers = collectIdentifiers(child, identifiers)
+	}
+	return identifiers
+}
+
+// returnsError checks whether the return statement propagates "err" or creates a new error.
+func returnsError(ret *uast.Node) bool {
+	found := false
+	uast_items.VisitEachNode(ret, func(node *uast.Node) {
+		if found {
+			return
+		}
+		if node.Token == "err" && hasRole(node, uast.Identifier) {
+			found = true
+			return
+		}
+		if hasRole(node, uast.Call) {
+			switch calleeName(node) {
+			case "errors.New", "fmt.Errorf", "errors.Errorf", "errors.Wrap", "errors.Wrapf":
+				found = true
+			}
+		}
+	})
+	return found
+}
+
+// ignoresResult checks whether the assignment discards the result of a call with "_".
+func ignoresResult(assignment *uast.Node) bool {
+	blank, call := false, false
+	for _, child := range assignment.Children {
+		if hasRole(child, uast.Left) && child.Token == "_" {
+			blank = true
+		} else if hasRole(child, uast.Right) && hasRole(child, uast.Call) {
+			call = true
+		}
+	}
+	return blank && call
+}
+
+func init() {
+	core.Registry.Register(&ErrorHandlingAnalysis{})
+}
@@ -0,0 +1,151 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureErrorHandling() *ErrorHandlingAnalysis {
+	eh := ErrorHandlingAnalysis{}
+	eh.Initialize(test.Repository)
+	return &eh
+}
+
+func fixtureErrorHandlingCall(extraRoles []uast.Role, names ...string) *uast.Node {
+	callee := &uast.Node{Roles: []uast.Role{uast.Callee}}
+	for _, name := range names {
+		callee.Children = append(callee.Children, &uast.Node{
+			Token: name, Roles: []uast.Role{uast.Identifier}})
+	}
+	return &uast.Node{
+		Roles:    append([]uast.Role{uast.Expression, uast.Call}, extraRoles...),
+		Children: []*uast.Node{callee},
+	}
+}
+
+// fixtureErrorHandlingUAST builds the UAST of
+//
+//	panic(x)
+//	log.Fatalf(x)
+//	return err
+//	return fmt.Errorf(x)
+//	return nil
+//	_ = f.Close()
+//	x = f.Close()
+func fixtureErrorHandlingUAST() *uast.Node {
+	return &uast.Node{Roles: []uast.Role{uast.File}, Children: []*uast.Node{
+		fixtureErrorHandlingCall(nil, "panic"),
+		fixtureErrorHandlingCall(nil, "log", "Fatalf"),
+		{Roles: []uast.Role{uast.Statement, uast.Return}, Children: []*uast.Node{
+			{Token: "err", Roles: []uast.Role{uast.Identifier}}}},
+		{Roles: []uast.Role{uast.Statement, uast.Return}, Children: []*uast.Node{
+			fixtureErrorHandlingCall(nil, "fmt", "Errorf")}},
+		{Roles: []uast.Role{uast.Statement, uast.Return}, Children: []*uast.Node{
+			{Token: "nil", Roles: []uast.Role{uast.Identifier}}}},
+		{Roles: []uast.Role{uast.Statement, uast.Assignment}, Children: []*uast.Node{
+			{Token: "_", Roles: []uast.Role{uast.Identifier, uast.Left}},
+			fixtureErrorHandlingCall([]uast.Role{uast.Right}, "f", "Close")}},
+		{Roles: []uast.Role{uast.Statement, uast.Assignment}, Children: []*uast.Node{
+			{Token: "x", Roles: []uast.Role{uast.Identifier, uast.Left}},
+			fixtureErrorHandlingCall([]uast.Role{uast.Right}, "f", "Close")}},
+	}}
+}
+
+func TestErrorHandlingMeta(t *testing.T) {
+	eh := fixtureErrorHandling()
+	assert.Equal(t, eh.Name(), "ErrorHandling")
+	assert.Len(t, eh.Provides(), 0)
+	assert.Equal(t, eh.Requires(), []string{uast_items.DependencyUastChanges, items.DependencyDay})
+	assert.Equal(t, eh.Features(), []string{uast_items.FeatureUast})
+	assert.Len(t, eh.ListConfigurationOptions(), 0)
+	assert.Equal(t, eh.Flag(), "error-handling")
+	eh.Configure(nil)
+}
+
+func TestErrorHandlingRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&ErrorHandlingAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "ErrorHandling")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&ErrorHandlingAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestExtractErrorHandlingStats(t *testing.T) {
+	stats := ExtractErrorHandlingStats(fixtureErrorHandlingUAST())
+	assert.Equal(t, stats, ErrorHandlingStats{Panics: 1, Fatals: 1, Returns: 2, Ignored: 1})
+	assert.Equal(t, ExtractErrorHandlingStats(&uast.Node{}), ErrorHandlingStats{})
+}
+
+func TestErrorHandlingConsume(t *testing.T) {
+	eh := fixtureErrorHandling()
+	deps := map[string]interface{}{}
+	deps[items.DependencyDay] = 0
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{After: fixtureErrorHandlingUAST(), Change: &object.Change{
+			To: object.ChangeEntry{Name: "a.go"}}},
+		{After: fixtureErrorHandlingUAST(), Change: &object.Change{
+			To: object.ChangeEntry{Name: "b.go"}}},
+		{After: fixtureErrorHandlingUAST(), Change: &object.Change{
+			To: object.ChangeEntry{Name: "c.py"}}},
+	}
+	result, err := eh.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps[items.DependencyDay] = 1
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{After: fixtureErrorHandlingUAST(), Change: &object.Change{
+			To: object.ChangeEntry{Name: "d.py"}}},
+	}
+	result, err = eh.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps[items.DependencyDay] = 2
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: fixtureErrorHandlingUAST(), Change: &object.Change{
+			From: object.ChangeEntry{Name: "a.go"}}},
+	}
+	eh.Consume(deps)
+	res := eh.Finalize().(ErrorHandlingResult)
+	assert.Len(t, res.Days, 2)
+	assert.Equal(t, res.Days[0], ErrorHandlingStats{Panics: 2, Fatals: 2, Returns: 4, Ignored: 2})
+	assert.Equal(t, res.Days[2], ErrorHandlingStats{Panics: 1, Fatals: 1, Returns: 2, Ignored: 1})
+}
+
+func TestErrorHandlingSerialize(t *testing.T) {
+	eh := fixtureErrorHandling()
+	res := ErrorHandlingResult{Days: map[int]ErrorHandlingStats{
+		4: {Panics: 1, Fatals: 2, Returns: 10, Ignored: 3},
+		0: {Returns: 1},
+	}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, eh.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  0: {panics: 0, fatals: 0, returns: 1, ignored: 0}
+  4: {panics: 1, fatals: 2, returns: 10, ignored: 3}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, eh.Serialize(res, true, buffer))
+	msg := pb.ErrorHandlingResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Days, 2)
+	assert.Equal(t, msg.Days[4].Panics, int32(1))
+	assert.Equal(t, msg.Days[4].Fatals, int32(2))
+	assert.Equal(t, msg.Days[4].Returns, int32(10))
+	assert.Equal(t, msg.Days[4].Ignored, int32(3))
+}
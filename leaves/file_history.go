@@ -112,6 +112,11 @@ func (history *FileHistory) Serialize(result interface{}, binary bool, writer io
 	return nil
 }
 
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (history *FileHistory) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(history, result, &pb.FileHistoryResultMessage{}, writer)
+}
+
 func (history *FileHistory) serializeText(result *FileHistoryResult, writer io.Writer) {
 	keys := make([]string, len(result.Files))
 	i := 0
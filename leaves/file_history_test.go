@@ -113,6 +113,44 @@ func TestFileHistoryConsume(t *testing.T) {
 	assert.Equal(t, fh.files, res.Files)
 }
 
+func TestFileHistoryCaseRename(t *testing.T) {
+	fh := fixtureFileHistory()
+	ra := &items.RenameAnalysis{Fast: true}
+	ra.Initialize(test.Repository)
+	entry := func(name string, hash string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: plumbing.NewHash(hash)}}
+	}
+	consume := func(commit string, changes object.Changes) {
+		res, err := ra.Consume(map[string]interface{}{
+			items.DependencyBlobCache:   map[plumbing.Hash]*object.Blob{},
+			items.DependencyTreeChanges: changes,
+		})
+		assert.Nil(t, err)
+		_, err = fh.Consume(map[string]interface{}{
+			"commit":                    &object.Commit{Hash: plumbing.NewHash(commit)},
+			items.DependencyTreeChanges: res[items.DependencyTreeChanges],
+		})
+		assert.Nil(t, err)
+	}
+	consume("1111111111111111111111111111111111111111", object.Changes{
+		{To: entry("Makefile", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")},
+		{To: entry("LICENSE", "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")},
+	})
+	// the case-only rename with edits and the copy of the old contents
+	consume("2222222222222222222222222222222222222222", object.Changes{
+		{From: entry("Makefile", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")},
+		{To: entry("makefile", "cccccccccccccccccccccccccccccccccccccccc")},
+		{To: entry("Makefile.old", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")},
+	})
+	assert.Equal(t, fh.files, map[string][]plumbing.Hash{
+		"makefile": {plumbing.NewHash("1111111111111111111111111111111111111111"),
+			plumbing.NewHash("2222222222222222222222222222222222222222")},
+		"LICENSE":      {plumbing.NewHash("1111111111111111111111111111111111111111")},
+		"Makefile.old": {plumbing.NewHash("2222222222222222222222222222222222222222")},
+	})
+}
+
 func TestFileHistorySerializeText(t *testing.T) {
 	fh := fixtureFileHistory()
 	deps := map[string]interface{}{}
@@ -0,0 +1,431 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// FileLifecycleAnalysis classifies the files into lifecycle states based on how recently they
+// changed, whether their authors are still active and whether they are coupled to the files
+// which are still being developed. The number of files in each state is reported per directory
+// over time, and the state of each file at HEAD.
+// It is a LeafPipelineItem.
+type FileLifecycleAnalysis struct {
+	// ActiveDays is the maximum number of days since the last change of an actively developed file.
+	ActiveDays int
+	// MaintainedDays is the maximum number of days since the last change of a maintained file.
+	// It is also the period during which a developer is considered active after their last commit.
+	MaintainedDays int
+	// MinCoupling is the minimum number of active or maintained files which must have changed
+	// together with an idle file which nobody maintains any longer to consider it
+	// abandoned but depended on.
+	MinCoupling int
+	// Sampling is the interval in days between consecutive measurements.
+	Sampling int
+
+	// files maps the file names to their change history.
+	files map[string]*fileLifecycleStatus
+	// lastCommits maps the developer indexes to the day of their last commit.
+	lastCommits map[int]int
+	// samples maps the day indexes to the directory -> counts mapping.
+	samples map[int]map[string]FileLifecycleCounts
+	// day is the most recent day index processed.
+	day int
+}
+
+// fileLifecycleStatus is the information about a file which is needed to classify it.
+type fileLifecycleStatus struct {
+	// LastDay is the day of the last change.
+	LastDay int
+	// Authors is the set of the developers who changed the file.
+	Authors map[int]bool
+	// Coupled is the set of the files which changed in the same commits.
+	Coupled map[string]bool
+}
+
+// FileLifecycleState is the lifecycle state of a file.
+type FileLifecycleState int
+
+const (
+	// FileLifecycleActive means that the file is actively developed.
+	FileLifecycleActive FileLifecycleState = iota
+	// FileLifecycleMaintained means that the file changes from time to time.
+	FileLifecycleMaintained
+	// FileLifecycleFrozen means that the file has not changed for a long time.
+	FileLifecycleFrozen
+	// FileLifecycleAbandoned means that the file has not changed for a long time, none of its
+	// authors is active anymore, yet it is coupled to the files which are still developed.
+	FileLifecycleAbandoned
+)
+
+// String returns the name of the lifecycle state.
+func (state FileLifecycleState) String() string {
+	switch state {
+	case FileLifecycleActive:
+		return "active"
+	case FileLifecycleMaintained:
+		return "maintained"
+	case FileLifecycleFrozen:
+		return "frozen"
+	case FileLifecycleAbandoned:
+		return "abandoned"
+	}
+	return "unknown"
+}
+
+// FileLifecycleCounts are the numbers of files in each lifecycle state.
+type FileLifecycleCounts struct {
+	Active     int
+	Maintained int
+	Frozen     int
+	Abandoned  int
+}
+
+// add increments the number of files in the specified state.
+func (counts *FileLifecycleCounts) add(state FileLifecycleState) {
+	switch state {
+	case FileLifecycleActive:
+		counts.Active++
+	case FileLifecycleMaintained:
+		counts.Maintained++
+	case FileLifecycleFrozen:
+		counts.Frozen++
+	case FileLifecycleAbandoned:
+		counts.Abandoned++
+	}
+}
+
+// FileLifecycleResult is returned by FileLifecycleAnalysis.Finalize() and carries
+// the lifecycle states of the files.
+type FileLifecycleResult struct {
+	// Files maps the names of the files at HEAD to their lifecycle states.
+	Files map[string]FileLifecycleState
+	// Samples maps the day index to the directory -> numbers of files in each state mapping.
+	// The files in the repository root belong to the directory "/".
+	Samples map[int]map[string]FileLifecycleCounts
+}
+
+const (
+	// ConfigFileLifecycleActiveDays is the name of the option to set
+	// FileLifecycleAnalysis.ActiveDays.
+	ConfigFileLifecycleActiveDays = "FileLifecycle.ActiveDays"
+	// ConfigFileLifecycleMaintainedDays is the name of the option to set
+	// FileLifecycleAnalysis.MaintainedDays.
+	ConfigFileLifecycleMaintainedDays = "FileLifecycle.MaintainedDays"
+	// ConfigFileLifecycleMinCoupling is the name of the option to set
+	// FileLifecycleAnalysis.MinCoupling.
+	ConfigFileLifecycleMinCoupling = "FileLifecycle.MinCoupling"
+	// ConfigFileLifecycleSampling is the name of the option to set FileLifecycleAnalysis.Sampling.
+	ConfigFileLifecycleSampling = "FileLifecycle.Sampling"
+	// DefaultFileLifecycleActiveDays is the default value of FileLifecycleAnalysis.ActiveDays.
+	DefaultFileLifecycleActiveDays = 30
+	// DefaultFileLifecycleMaintainedDays is the default value of
+	// FileLifecycleAnalysis.MaintainedDays.
+	DefaultFileLifecycleMaintainedDays = 180
+	// DefaultFileLifecycleMinCoupling is the default value of FileLifecycleAnalysis.MinCoupling.
+	DefaultFileLifecycleMinCoupling = 3
+	// DefaultFileLifecycleSampling is the default value of FileLifecycleAnalysis.Sampling.
+	DefaultFileLifecycleSampling = 30
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (lifecycle *FileLifecycleAnalysis) Name() string {
+	return "FileLifecycle"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (lifecycle *FileLifecycleAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (lifecycle *FileLifecycleAnalysis) Requires() []string {
+	arr := [...]string{identity.DependencyAuthor, items.DependencyTreeChanges, items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (lifecycle *FileLifecycleAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigFileLifecycleActiveDays,
+		Description: "Maximum number of days since the last change of an actively developed file.",
+		Flag:        "lifecycle-active-days",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultFileLifecycleActiveDays}, {
+		Name: ConfigFileLifecycleMaintainedDays,
+		Description: "Maximum number of days since the last change of a maintained file " +
+			"and since the last commit of an active developer.",
+		Flag:    "lifecycle-maintained-days",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultFileLifecycleMaintainedDays}, {
+		Name: ConfigFileLifecycleMinCoupling,
+		Description: "Minimum number of co-changed active or maintained files to consider " +
+			"an unmaintained file depended on.",
+		Flag:    "lifecycle-min-coupling",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultFileLifecycleMinCoupling}, {
+		Name:        ConfigFileLifecycleSampling,
+		Description: "How frequently to record the state in days.",
+		Flag:        "lifecycle-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultFileLifecycleSampling},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (lifecycle *FileLifecycleAnalysis) Flag() string {
+	return "file-lifecycle"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (lifecycle *FileLifecycleAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigFileLifecycleActiveDays].(int); exists {
+		lifecycle.ActiveDays = val
+	}
+	if val, exists := facts[ConfigFileLifecycleMaintainedDays].(int); exists {
+		lifecycle.MaintainedDays = val
+	}
+	if val, exists := facts[ConfigFileLifecycleMinCoupling].(int); exists {
+		lifecycle.MinCoupling = val
+	}
+	if val, exists := facts[ConfigFileLifecycleSampling].(int); exists {
+		lifecycle.Sampling = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (lifecycle *FileLifecycleAnalysis) Initialize(repository *git.Repository) {
+	if lifecycle.ActiveDays <= 0 {
+		log.Printf("Warning: adjusted the active files threshold to %d days\n",
+			DefaultFileLifecycleActiveDays)
+		lifecycle.ActiveDays = DefaultFileLifecycleActiveDays
+	}
+	if lifecycle.MaintainedDays < lifecycle.ActiveDays {
+		maintained := DefaultFileLifecycleMaintainedDays
+		if maintained < lifecycle.ActiveDays {
+			maintained = lifecycle.ActiveDays
+		}
+		log.Printf("Warning: adjusted the maintained files threshold to %d days\n", maintained)
+		lifecycle.MaintainedDays = maintained
+	}
+	if lifecycle.MinCoupling <= 0 {
+		log.Printf("Warning: adjusted the minimum coupling to %d\n", DefaultFileLifecycleMinCoupling)
+		lifecycle.MinCoupling = DefaultFileLifecycleMinCoupling
+	}
+	if lifecycle.Sampling <= 0 {
+		log.Printf("Warning: adjusted the sampling to %d days\n", DefaultFileLifecycleSampling)
+		lifecycle.Sampling = DefaultFileLifecycleSampling
+	}
+	lifecycle.files = map[string]*fileLifecycleStatus{}
+	lifecycle.lastCommits = map[int]int{}
+	lifecycle.samples = map[int]map[string]FileLifecycleCounts{}
+	lifecycle.day = 0
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (lifecycle *FileLifecycleAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	author := deps[identity.DependencyAuthor].(int)
+	day := deps[items.DependencyDay].(int)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	if day/lifecycle.Sampling != lifecycle.day/lifecycle.Sampling && len(lifecycle.files) > 0 {
+		lifecycle.samples[lifecycle.day] = lifecycle.countStates(lifecycle.day)
+	}
+	lifecycle.day = day
+	lifecycle.lastCommits[author] = day
+	changed := make([]string, 0, len(treeDiff))
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			lifecycle.files[change.To.Name] = &fileLifecycleStatus{
+				Authors: map[int]bool{}, Coupled: map[string]bool{}}
+		case merkletrie.Delete:
+			delete(lifecycle.files, change.From.Name)
+			continue
+		case merkletrie.Modify:
+			if change.From.Name != change.To.Name {
+				status := lifecycle.files[change.From.Name]
+				delete(lifecycle.files, change.From.Name)
+				lifecycle.files[change.To.Name] = status
+			}
+		}
+		status := lifecycle.files[change.To.Name]
+		if status == nil {
+			status = &fileLifecycleStatus{Authors: map[int]bool{}, Coupled: map[string]bool{}}
+			lifecycle.files[change.To.Name] = status
+		}
+		status.LastDay = day
+		status.Authors[author] = true
+		changed = append(changed, change.To.Name)
+	}
+	for _, name := range changed {
+		coupled := lifecycle.files[name].Coupled
+		for _, other := range changed {
+			if other != name {
+				coupled[other] = true
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (lifecycle *FileLifecycleAnalysis) Finalize() interface{} {
+	files := map[string]FileLifecycleState{}
+	for name := range lifecycle.files {
+		files[name] = lifecycle.classify(name, lifecycle.day)
+	}
+	if len(lifecycle.files) > 0 {
+		lifecycle.samples[lifecycle.day] = lifecycle.countStates(lifecycle.day)
+	}
+	return FileLifecycleResult{Files: files, Samples: lifecycle.samples}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (lifecycle *FileLifecycleAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	lifecycleResult := result.(FileLifecycleResult)
+	if binary {
+		return lifecycle.serializeBinary(&lifecycleResult, writer)
+	}
+	lifecycle.serializeText(&lifecycleResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (lifecycle *FileLifecycleAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(lifecycle, result, &pb.FileLifecycleResults{}, writer)
+}
+
+func (lifecycle *FileLifecycleAnalysis) serializeText(result *FileLifecycleResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  files:")
+	files := make([]string, 0, len(result.Files))
+	for name := range result.Files {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+	for _, name := range files {
+		fmt.Fprintf(writer, "    %s: %s\n", yaml.SafeString(name), result.Files[name])
+	}
+	fmt.Fprintln(writer, "  # active, maintained, frozen, abandoned")
+	fmt.Fprintln(writer, "  directories:")
+	days := make([]int, 0, len(result.Samples))
+	for day := range result.Samples {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	for _, day := range days {
+		fmt.Fprintf(writer, "    %d:\n", day)
+		dirs := make([]string, 0, len(result.Samples[day]))
+		for dir := range result.Samples[day] {
+			dirs = append(dirs, dir)
+		}
+		sort.Strings(dirs)
+		for _, dir := range dirs {
+			counts := result.Samples[day][dir]
+			fmt.Fprintf(writer, "      %s: [%d, %d, %d, %d]\n", yaml.SafeString(dir),
+				counts.Active, counts.Maintained, counts.Frozen, counts.Abandoned)
+		}
+	}
+}
+
+func (lifecycle *FileLifecycleAnalysis) serializeBinary(result *FileLifecycleResult, writer io.Writer) error {
+	message := pb.FileLifecycleResults{
+		Files: map[string]string{},
+		Days:  map[int32]*pb.DirectoryLifecycles{},
+	}
+	for name, state := range result.Files {
+		message.Files[name] = state.String()
+	}
+	for day, dirs := range result.Samples {
+		pbDirs := &pb.DirectoryLifecycles{Directories: map[string]*pb.FileLifecycleCounts{}}
+		for dir, counts := range dirs {
+			pbDirs.Directories[dir] = &pb.FileLifecycleCounts{
+				Active:     int32(counts.Active),
+				Maintained: int32(counts.Maintained),
+				Frozen:     int32(counts.Frozen),
+				Abandoned:  int32(counts.Abandoned),
+			}
+		}
+		message.Days[int32(day)] = pbDirs
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+// classify determines the lifecycle state of the file on the specified day.
+func (lifecycle *FileLifecycleAnalysis) classify(name string, day int) FileLifecycleState {
+	status := lifecycle.files[name]
+	idle := day - status.LastDay
+	if idle <= lifecycle.ActiveDays {
+		return FileLifecycleActive
+	}
+	if idle <= lifecycle.MaintainedDays {
+		return FileLifecycleMaintained
+	}
+	for author := range status.Authors {
+		if day-lifecycle.lastCommits[author] <= lifecycle.MaintainedDays {
+			return FileLifecycleFrozen
+		}
+	}
+	dependents := 0
+	for other := range status.Coupled {
+		if otherStatus, exists := lifecycle.files[other]; exists &&
+			day-otherStatus.LastDay <= lifecycle.MaintainedDays {
+			dependents++
+		}
+	}
+	if dependents >= lifecycle.MinCoupling {
+		return FileLifecycleAbandoned
+	}
+	return FileLifecycleFrozen
+}
+
+// countStates classifies all the files on the specified day and sums the states by directories.
+func (lifecycle *FileLifecycleAnalysis) countStates(day int) map[string]FileLifecycleCounts {
+	result := map[string]FileLifecycleCounts{}
+	for name := range lifecycle.files {
+		dir := path.Dir(name)
+		if dir == "." {
+			dir = burndownRootDirectory
+		}
+		counts := result[dir]
+		counts.add(lifecycle.classify(name, day))
+		result[dir] = counts
+	}
+	return result
+}
+
+func init() {
+	core.Registry.Register(&FileLifecycleAnalysis{})
+}
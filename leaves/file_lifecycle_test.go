@@ -0,0 +1,178 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureFileLifecycle() *FileLifecycleAnalysis {
+	lifecycle := FileLifecycleAnalysis{
+		ActiveDays: 10, MaintainedDays: 50, MinCoupling: 2, Sampling: 100}
+	lifecycle.Initialize(test.Repository)
+	return &lifecycle
+}
+
+func fixtureFileLifecycleDeps(author int, day int, inserted []string, modified []string,
+	deleted []string) map[string]interface{} {
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: plumbing.NewHash(
+				"291286b4ac41952cbd1389fda66420ec03c1a9fe")}}
+	}
+	changes := object.Changes{}
+	for _, name := range inserted {
+		changes = append(changes, &object.Change{To: entry(name)})
+	}
+	for _, name := range modified {
+		changes = append(changes, &object.Change{From: entry(name), To: entry(name)})
+	}
+	for _, name := range deleted {
+		changes = append(changes, &object.Change{From: entry(name)})
+	}
+	deps := map[string]interface{}{}
+	deps[identity.DependencyAuthor] = author
+	deps[items.DependencyDay] = day
+	deps[items.DependencyTreeChanges] = changes
+	return deps
+}
+
+func TestFileLifecycleMeta(t *testing.T) {
+	lifecycle := fixtureFileLifecycle()
+	assert.Equal(t, lifecycle.Name(), "FileLifecycle")
+	assert.Len(t, lifecycle.Provides(), 0)
+	assert.Equal(t, lifecycle.Requires(), []string{
+		identity.DependencyAuthor, items.DependencyTreeChanges, items.DependencyDay})
+	opts := lifecycle.ListConfigurationOptions()
+	assert.Len(t, opts, 4)
+	assert.Equal(t, opts[0].Name, ConfigFileLifecycleActiveDays)
+	assert.Equal(t, opts[1].Name, ConfigFileLifecycleMaintainedDays)
+	assert.Equal(t, opts[2].Name, ConfigFileLifecycleMinCoupling)
+	assert.Equal(t, opts[3].Name, ConfigFileLifecycleSampling)
+	assert.Equal(t, lifecycle.Flag(), "file-lifecycle")
+	facts := map[string]interface{}{}
+	facts[ConfigFileLifecycleActiveDays] = 7
+	facts[ConfigFileLifecycleMaintainedDays] = 70
+	facts[ConfigFileLifecycleMinCoupling] = 5
+	facts[ConfigFileLifecycleSampling] = 14
+	lifecycle.Configure(facts)
+	assert.Equal(t, lifecycle.ActiveDays, 7)
+	assert.Equal(t, lifecycle.MaintainedDays, 70)
+	assert.Equal(t, lifecycle.MinCoupling, 5)
+	assert.Equal(t, lifecycle.Sampling, 14)
+}
+
+func TestFileLifecycleInitialize(t *testing.T) {
+	lifecycle := FileLifecycleAnalysis{MaintainedDays: 5}
+	lifecycle.Initialize(test.Repository)
+	assert.Equal(t, lifecycle.ActiveDays, DefaultFileLifecycleActiveDays)
+	assert.Equal(t, lifecycle.MaintainedDays, DefaultFileLifecycleMaintainedDays)
+	assert.Equal(t, lifecycle.MinCoupling, DefaultFileLifecycleMinCoupling)
+	assert.Equal(t, lifecycle.Sampling, DefaultFileLifecycleSampling)
+	lifecycle = FileLifecycleAnalysis{ActiveDays: 300, MinCoupling: 1, Sampling: 1}
+	lifecycle.Initialize(test.Repository)
+	assert.Equal(t, lifecycle.MaintainedDays, 300)
+	assert.Equal(t, lifecycle.MinCoupling, 1)
+	assert.Equal(t, lifecycle.Sampling, 1)
+}
+
+func TestFileLifecycleRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&FileLifecycleAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "FileLifecycle")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&FileLifecycleAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestFileLifecycleConsumeFinalize(t *testing.T) {
+	lifecycle := fixtureFileLifecycle()
+	result, err := lifecycle.Consume(fixtureFileLifecycleDeps(
+		0, 0, []string{"lib/core.go", "lib/util.go", "main.go", "tmp.go"}, nil, nil))
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	lifecycle.Consume(fixtureFileLifecycleDeps(
+		1, 20, nil, []string{"main.go", "lib/util.go"}, []string{"tmp.go"}))
+	assert.Len(t, lifecycle.samples, 0)
+	lifecycle.Consume(fixtureFileLifecycleDeps(1, 120, nil, []string{"main.go", "lib/util.go"}, nil))
+	assert.Len(t, lifecycle.samples, 1)
+	assert.Equal(t, lifecycle.samples[20], map[string]FileLifecycleCounts{
+		"lib": {Active: 1, Maintained: 1},
+		"/":   {Active: 1},
+	})
+	res := lifecycle.Finalize().(FileLifecycleResult)
+	assert.Equal(t, res.Files, map[string]FileLifecycleState{
+		"lib/core.go": FileLifecycleAbandoned,
+		"lib/util.go": FileLifecycleActive,
+		"main.go":     FileLifecycleActive,
+	})
+	assert.Len(t, res.Samples, 2)
+	assert.Equal(t, res.Samples[120], map[string]FileLifecycleCounts{
+		"lib": {Active: 1, Abandoned: 1},
+		"/":   {Active: 1},
+	})
+	// the author of lib/core.go is active again
+	lifecycle.lastCommits[0] = 100
+	assert.Equal(t, lifecycle.classify("lib/core.go", 120), FileLifecycleFrozen)
+	// not enough coupled files
+	lifecycle.lastCommits[0] = 0
+	lifecycle.MinCoupling = 3
+	assert.Equal(t, lifecycle.classify("lib/core.go", 120), FileLifecycleFrozen)
+	assert.Equal(t, lifecycle.classify("main.go", 170), FileLifecycleMaintained)
+}
+
+func TestFileLifecycleStateString(t *testing.T) {
+	assert.Equal(t, FileLifecycleActive.String(), "active")
+	assert.Equal(t, FileLifecycleMaintained.String(), "maintained")
+	assert.Equal(t, FileLifecycleFrozen.String(), "frozen")
+	assert.Equal(t, FileLifecycleAbandoned.String(), "abandoned")
+	assert.Equal(t, FileLifecycleState(10).String(), "unknown")
+}
+
+func TestFileLifecycleSerialize(t *testing.T) {
+	lifecycle := fixtureFileLifecycle()
+	res := FileLifecycleResult{
+		Files: map[string]FileLifecycleState{
+			"main.go": FileLifecycleActive, "lib/core.go": FileLifecycleAbandoned},
+		Samples: map[int]map[string]FileLifecycleCounts{
+			120: {"lib": {Active: 1, Abandoned: 1}, "/": {Active: 1}},
+			20:  {"lib": {Active: 1, Maintained: 1}},
+		},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, lifecycle.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  files:
+    "lib/core.go": abandoned
+    "main.go": active
+  # active, maintained, frozen, abandoned
+  directories:
+    20:
+      "lib": [1, 1, 0, 0]
+    120:
+      "/": [1, 0, 0, 0]
+      "lib": [1, 0, 0, 1]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, lifecycle.Serialize(res, true, buffer))
+	msg := pb.FileLifecycleResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Files, map[string]string{"main.go": "active", "lib/core.go": "abandoned"})
+	assert.Len(t, msg.Days, 2)
+	assert.Equal(t, *msg.Days[120].Directories["lib"], pb.FileLifecycleCounts{Active: 1, Abandoned: 1})
+	assert.Equal(t, msg.Days[20].Directories["lib"].Maintained, int32(1))
+}
@@ -0,0 +1,375 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/enry.v1"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// FunctionSizeAnalysis tracks the distribution of the function and method lengths in lines
+// per language over time, and reports how much the longest functions at HEAD grew since they
+// appeared. The functions are the UAST nodes with the Function and Declaration roles.
+// It is a LeafPipelineItem.
+type FunctionSizeAnalysis struct {
+	// TopFunctions is the number of the longest functions to report.
+	TopFunctions int
+
+	// files maps the file name to the functions in its current UAST.
+	files map[string][]functionSize
+	// languages maps the file name to the language of the file.
+	languages map[string]string
+	// stats is the current distribution of the function lengths in every language.
+	stats map[string]FunctionSizeStats
+	// history maps days to the snapshots of stats.
+	history map[int]map[string]FunctionSizeStats
+}
+
+// functionSize is the length of a function in the current revision of a file.
+type functionSize struct {
+	name  string
+	lines int
+	// since is the day when the function appeared.
+	since int
+	// initialLines is the length of the function when it appeared.
+	initialLines int
+}
+
+// FunctionSizeStats is the distribution of the function lengths in lines.
+type FunctionSizeStats struct {
+	// Functions is the number of functions.
+	Functions int
+	// Lines is the total number of lines in the functions.
+	Lines int
+	// Median is the median function length.
+	Median int
+	// P90 is the 90th percentile of the function lengths.
+	P90 int
+	// Max is the length of the longest function.
+	Max int
+}
+
+// LongestFunction is one of the longest functions at HEAD.
+type LongestFunction struct {
+	// File is the path to the file at HEAD.
+	File string
+	// Name is the name of the function.
+	Name string
+	// Lines is the length of the function at HEAD.
+	Lines int
+	// Since is the day when the function appeared.
+	Since int
+	// InitialLines is the length of the function when it appeared.
+	InitialLines int
+}
+
+// FunctionSizeResult is returned by FunctionSizeAnalysis.Finalize() and carries the function
+// length distributions of every language for each day when there were changes, and the longest
+// functions at HEAD sorted by their length in descending order.
+type FunctionSizeResult struct {
+	// Days maps the day index to the language -> function length distribution mapping.
+	Days map[int]map[string]FunctionSizeStats
+	// Longest are the TopFunctions longest functions at HEAD.
+	Longest []LongestFunction
+}
+
+const (
+	// ConfigFunctionSizeTopFunctions is the name of the option to set
+	// FunctionSizeAnalysis.TopFunctions.
+	ConfigFunctionSizeTopFunctions = "FunctionSize.TopFunctions"
+	// DefaultFunctionSizeTopFunctions is the default value of FunctionSizeAnalysis.TopFunctions.
+	DefaultFunctionSizeTopFunctions = 20
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (sizes *FunctionSizeAnalysis) Name() string {
+	return "FunctionSize"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (sizes *FunctionSizeAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (sizes *FunctionSizeAnalysis) Requires() []string {
+	arr := [...]string{uast_items.DependencyUastChanges, items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (sizes *FunctionSizeAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (sizes *FunctionSizeAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigFunctionSizeTopFunctions,
+		Description: "Number of the longest functions to report.",
+		Flag:        "function-size-top",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultFunctionSizeTopFunctions},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (sizes *FunctionSizeAnalysis) Flag() string {
+	return "function-size"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (sizes *FunctionSizeAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigFunctionSizeTopFunctions].(int); exists {
+		sizes.TopFunctions = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (sizes *FunctionSizeAnalysis) Initialize(repository *git.Repository) {
+	if sizes.TopFunctions <= 0 {
+		log.Printf("Warning: adjusted the number of the longest functions to %d\n",
+			DefaultFunctionSizeTopFunctions)
+		sizes.TopFunctions = DefaultFunctionSizeTopFunctions
+	}
+	sizes.files = map[string][]functionSize{}
+	sizes.languages = map[string]string{}
+	sizes.stats = map[string]FunctionSizeStats{}
+	sizes.history = map[int]map[string]FunctionSizeStats{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (sizes *FunctionSizeAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	day := deps[items.DependencyDay].(int)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	dirty := map[string]bool{}
+	for _, change := range changes {
+		var previous []functionSize
+		if change.Change.From.Name != "" {
+			previous = sizes.files[change.Change.From.Name]
+			dirty[sizes.languages[change.Change.From.Name]] = true
+			delete(sizes.files, change.Change.From.Name)
+			delete(sizes.languages, change.Change.From.Name)
+		}
+		if change.After != nil {
+			name := change.Change.To.Name
+			lang, _ := enry.GetLanguageByExtension(name)
+			if lang == "" {
+				lang = "Other"
+			}
+			sizes.files[name] = matchFunctionSizes(previous, extractFunctionSizes(change.After), day)
+			sizes.languages[name] = lang
+			dirty[lang] = true
+		}
+	}
+	for lang := range dirty {
+		sizes.updateStats(lang)
+	}
+	snapshot := map[string]FunctionSizeStats{}
+	for lang, stats := range sizes.stats {
+		snapshot[lang] = stats
+	}
+	sizes.history[day] = snapshot
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (sizes *FunctionSizeAnalysis) Finalize() interface{} {
+	longest := []LongestFunction{}
+	for file, functions := range sizes.files {
+		for _, function := range functions {
+			longest = append(longest, LongestFunction{
+				File: file, Name: function.name, Lines: function.lines, Since: function.since,
+				InitialLines: function.initialLines,
+			})
+		}
+	}
+	sort.Slice(longest, func(i, j int) bool {
+		if longest[i].Lines != longest[j].Lines {
+			return longest[i].Lines > longest[j].Lines
+		}
+		if longest[i].File != longest[j].File {
+			return longest[i].File < longest[j].File
+		}
+		return longest[i].Name < longest[j].Name
+	})
+	if len(longest) > sizes.TopFunctions {
+		longest = longest[:sizes.TopFunctions]
+	}
+	return FunctionSizeResult{Days: sizes.history, Longest: longest}
+}
+
+// updateStats calculates the function length distribution of the language from scratch.
+func (sizes *FunctionSizeAnalysis) updateStats(lang string) {
+	var lengths []int
+	for file, functions := range sizes.files {
+		if sizes.languages[file] != lang {
+			continue
+		}
+		for _, function := range functions {
+			lengths = append(lengths, function.lines)
+		}
+	}
+	if len(lengths) == 0 {
+		delete(sizes.stats, lang)
+		return
+	}
+	sort.Ints(lengths)
+	stats := FunctionSizeStats{
+		Functions: len(lengths),
+		Median:    lengths[len(lengths)/2],
+		P90:       lengths[len(lengths)*9/10],
+		Max:       lengths[len(lengths)-1],
+	}
+	for _, length := range lengths {
+		stats.Lines += length
+	}
+	sizes.stats[lang] = stats
+}
+
+// extractFunctionSizes returns the named functions in the UAST with their lengths in lines.
+// The functions without the position are ignored.
+func extractFunctionSizes(root *uast.Node) []functionSize {
+	var functions []functionSize
+	uast_items.VisitEachNode(root, func(node *uast.Node) {
+		if node.StartPosition == nil || !isFunctionDeclaration(node) {
+			return
+		}
+		startLine, endLine := nodeLines(node)
+		functions = append(functions, functionSize{
+			name: declarationName(node), lines: int(endLine-startLine) + 1,
+		})
+	})
+	return functions
+}
+
+// matchFunctionSizes carries the day when each function appeared and its initial length over
+// from the previous revision of the file. The functions with the same name are matched
+// in the order of their appearance.
+func matchFunctionSizes(previous []functionSize, current []functionSize, day int) []functionSize {
+	byName := map[string][]functionSize{}
+	for _, function := range previous {
+		byName[function.name] = append(byName[function.name], function)
+	}
+	for i, function := range current {
+		if candidates := byName[function.name]; len(candidates) > 0 {
+			current[i].since = candidates[0].since
+			current[i].initialLines = candidates[0].initialLines
+			byName[function.name] = candidates[1:]
+			continue
+		}
+		current[i].since = day
+		current[i].initialLines = function.lines
+	}
+	return current
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (sizes *FunctionSizeAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	sizesResult := result.(FunctionSizeResult)
+	if binary {
+		return sizes.serializeBinary(&sizesResult, writer)
+	}
+	sizes.serializeText(&sizesResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (sizes *FunctionSizeAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(sizes, result, &pb.FunctionSizeResults{}, writer)
+}
+
+func (sizes *FunctionSizeAnalysis) serializeText(result *FunctionSizeResult, writer io.Writer) {
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	fmt.Fprintln(writer, "  days:  # functions, lines, median, 90th percentile, max")
+	for _, day := range days {
+		fmt.Fprintf(writer, "    %d:\n", day)
+		langs := result.Days[day]
+		langKeys := make([]string, 0, len(langs))
+		for lang := range langs {
+			langKeys = append(langKeys, lang)
+		}
+		sort.Strings(langKeys)
+		for _, lang := range langKeys {
+			stats := langs[lang]
+			fmt.Fprintf(writer, "      %s: [%d, %d, %d, %d, %d]\n", yaml.SafeString(lang),
+				stats.Functions, stats.Lines, stats.Median, stats.P90, stats.Max)
+		}
+	}
+	fmt.Fprintln(writer, "  longest:")
+	for _, function := range result.Longest {
+		fmt.Fprintf(writer, "  - {file: %s, name: %s, lines: %d, since: %d, initial_lines: %d}\n",
+			yaml.SafeString(function.File), yaml.SafeString(function.Name), function.Lines,
+			function.Since, function.InitialLines)
+	}
+}
+
+func (sizes *FunctionSizeAnalysis) serializeBinary(result *FunctionSizeResult, writer io.Writer) error {
+	message := pb.FunctionSizeResults{
+		Days:    map[int32]*pb.LanguageFunctionSizes{},
+		Longest: make([]*pb.LongestFunction, len(result.Longest)),
+	}
+	for day, langs := range result.Days {
+		pbLangs := &pb.LanguageFunctionSizes{
+			Languages: map[string]*pb.FunctionSizeStats{},
+		}
+		for lang, stats := range langs {
+			pbLangs.Languages[lang] = &pb.FunctionSizeStats{
+				Functions: int32(stats.Functions),
+				Lines:     int32(stats.Lines),
+				Median:    int32(stats.Median),
+				P90:       int32(stats.P90),
+				Max:       int32(stats.Max),
+			}
+		}
+		message.Days[int32(day)] = pbLangs
+	}
+	for i, function := range result.Longest {
+		message.Longest[i] = &pb.LongestFunction{
+			File:         function.File,
+			Name:         function.Name,
+			Lines:        int32(function.Lines),
+			Since:        int32(function.Since),
+			InitialLines: int32(function.InitialLines),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&FunctionSizeAnalysis{})
+}
@@ -0,0 +1,195 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureFunctionSize() *FunctionSizeAnalysis {
+	fs := FunctionSizeAnalysis{TopFunctions: 3}
+	fs.Initialize(test.Repository)
+	return &fs
+}
+
+// fixtureFunctionSizeUAST generates the functions which follow each other.
+func fixtureFunctionSizeUAST(functions ...functionSize) *uast.Node {
+	root := &uast.Node{Roles: []uast.Role{uast.File}}
+	line := uint32(1)
+	for _, function := range functions {
+		root.Children = append(root.Children, &uast.Node{
+			Roles:         []uast.Role{uast.Function, uast.Declaration},
+			StartPosition: &uast.Position{Line: line},
+			EndPosition:   &uast.Position{Line: line + uint32(function.lines) - 1},
+			Children: []*uast.Node{{
+				Roles: []uast.Role{uast.Function, uast.Identifier, uast.Name},
+				Token: function.name,
+			}},
+		})
+		line += uint32(function.lines)
+	}
+	// lambdas are ignored
+	root.Children = append(root.Children, &uast.Node{
+		Roles:         []uast.Role{uast.Function, uast.Declaration, uast.Anonymous},
+		StartPosition: &uast.Position{Line: line},
+		EndPosition:   &uast.Position{Line: line + 100},
+	})
+	return root
+}
+
+func TestFunctionSizeMeta(t *testing.T) {
+	fs := fixtureFunctionSize()
+	assert.Equal(t, fs.Name(), "FunctionSize")
+	assert.Len(t, fs.Provides(), 0)
+	assert.Equal(t, fs.Requires(), []string{uast_items.DependencyUastChanges, items.DependencyDay})
+	assert.Equal(t, fs.Features(), []string{uast_items.FeatureUast})
+	opts := fs.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigFunctionSizeTopFunctions)
+	assert.Equal(t, fs.Flag(), "function-size")
+}
+
+func TestFunctionSizeConfigure(t *testing.T) {
+	fs := FunctionSizeAnalysis{}
+	fs.Configure(map[string]interface{}{ConfigFunctionSizeTopFunctions: 7})
+	assert.Equal(t, fs.TopFunctions, 7)
+	fs.Configure(map[string]interface{}{})
+	assert.Equal(t, fs.TopFunctions, 7)
+	fs.TopFunctions = -1
+	fs.Initialize(test.Repository)
+	assert.Equal(t, fs.TopFunctions, DefaultFunctionSizeTopFunctions)
+}
+
+func TestFunctionSizeRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&FunctionSizeAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "FunctionSize")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&FunctionSizeAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestFunctionSizeConsume(t *testing.T) {
+	fs := fixtureFunctionSize()
+	deps := map[string]interface{}{}
+	deps[items.DependencyDay] = 0
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: nil, After: fixtureFunctionSizeUAST(
+			functionSize{name: "main", lines: 10}, functionSize{name: "run", lines: 4}),
+			Change: &object.Change{To: object.ChangeEntry{Name: "main.go"}}},
+		{Before: nil, After: fixtureFunctionSizeUAST(functionSize{name: "util", lines: 6}),
+			Change: &object.Change{To: object.ChangeEntry{Name: "util.py"}}},
+	}
+	result, err := fs.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps[items.DependencyDay] = 3
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: fixtureFunctionSizeUAST(), After: fixtureFunctionSizeUAST(
+			functionSize{name: "main", lines: 25}, functionSize{name: "run", lines: 4},
+			functionSize{name: "init", lines: 2}),
+			Change: &object.Change{
+				From: object.ChangeEntry{Name: "main.go"},
+				To:   object.ChangeEntry{Name: "cmd/main.go"}}},
+		{Before: fixtureFunctionSizeUAST(), After: nil, Change: &object.Change{
+			From: object.ChangeEntry{Name: "util.py"}}},
+		{Before: nil, After: fixtureFunctionSizeUAST(functionSize{name: "lib", lines: 8}),
+			Change: &object.Change{To: object.ChangeEntry{Name: "lib.go"}}},
+	}
+	result, err = fs.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps[items.DependencyDay] = 4
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{}
+	fs.Consume(deps)
+	res := fs.Finalize().(FunctionSizeResult)
+	assert.Len(t, res.Days, 2)
+	assert.Equal(t, res.Days[0], map[string]FunctionSizeStats{
+		"Go":     {Functions: 2, Lines: 14, Median: 10, P90: 10, Max: 10},
+		"Python": {Functions: 1, Lines: 6, Median: 6, P90: 6, Max: 6},
+	})
+	assert.Equal(t, res.Days[3], map[string]FunctionSizeStats{
+		"Go": {Functions: 4, Lines: 39, Median: 8, P90: 25, Max: 25},
+	})
+	assert.Equal(t, res.Longest, []LongestFunction{
+		{File: "cmd/main.go", Name: "main", Lines: 25, Since: 0, InitialLines: 10},
+		{File: "lib.go", Name: "lib", Lines: 8, Since: 3, InitialLines: 8},
+		{File: "cmd/main.go", Name: "run", Lines: 4, Since: 0, InitialLines: 4},
+	})
+}
+
+func TestFunctionSizeMatch(t *testing.T) {
+	previous := []functionSize{
+		{name: "f", lines: 3, since: 1, initialLines: 2},
+		{name: "f", lines: 5, since: 2, initialLines: 5},
+	}
+	current := matchFunctionSizes(previous, []functionSize{
+		{name: "f", lines: 4}, {name: "g", lines: 1}, {name: "f", lines: 6}, {name: "f", lines: 7},
+	}, 10)
+	assert.Equal(t, current, []functionSize{
+		{name: "f", lines: 4, since: 1, initialLines: 2},
+		{name: "g", lines: 1, since: 10, initialLines: 1},
+		{name: "f", lines: 6, since: 2, initialLines: 5},
+		{name: "f", lines: 7, since: 10, initialLines: 7},
+	})
+}
+
+func TestFunctionSizeSerializeText(t *testing.T) {
+	fs := fixtureFunctionSize()
+	res := FunctionSizeResult{
+		Days: map[int]map[string]FunctionSizeStats{
+			5: {"Go": {Functions: 3, Lines: 30, Median: 8, P90: 20, Max: 20}},
+			1: {"Python": {Functions: 1, Lines: 2, Median: 2, P90: 2, Max: 2}},
+		},
+		Longest: []LongestFunction{
+			{File: "main.go", Name: "main", Lines: 20, Since: 1, InitialLines: 12},
+		},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, fs.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  days:  # functions, lines, median, 90th percentile, max
+    1:
+      "Python": [1, 2, 2, 2, 2]
+    5:
+      "Go": [3, 30, 8, 20, 20]
+  longest:
+  - {file: "main.go", name: "main", lines: 20, since: 1, initial_lines: 12}
+`)
+}
+
+func TestFunctionSizeSerializeBinary(t *testing.T) {
+	fs := fixtureFunctionSize()
+	res := FunctionSizeResult{
+		Days: map[int]map[string]FunctionSizeStats{
+			5: {"Go": {Functions: 3, Lines: 30, Median: 8, P90: 20, Max: 20}},
+		},
+		Longest: []LongestFunction{
+			{File: "main.go", Name: "main", Lines: 20, Since: 1, InitialLines: 12},
+		},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, fs.Serialize(res, true, buffer))
+	msg := pb.FunctionSizeResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Days, 1)
+	assert.Equal(t, *msg.Days[5].Languages["Go"], pb.FunctionSizeStats{
+		Functions: 3, Lines: 30, Median: 8, P90: 20, Max: 20})
+	assert.Len(t, msg.Longest, 1)
+	assert.Equal(t, *msg.Longest[0], pb.LongestFunction{
+		File: "main.go", Name: "main", Lines: 20, Since: 1, InitialLines: 12})
+}
@@ -0,0 +1,249 @@
+package leaves
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"path"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// GofmtComplianceAnalysis checks whether the Go files changed in each commit are formatted
+// with gofmt and reports the compliance over time and per author. Files which cannot be parsed
+// are ignored.
+// It is a LeafPipelineItem.
+type GofmtComplianceAnalysis struct {
+	// PeopleNumber is the number of developers for which to collect the compliance stats.
+	PeopleNumber int
+
+	// days maps days to the compliance of the files changed on that day.
+	days map[int]GofmtCompliance
+	// people is the compliance of the files changed by each developer.
+	// The last element is the compliance of the authors which were not matched.
+	people []GofmtCompliance
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// GofmtCompliance is the number of checked and gofmt-clean Go files.
+type GofmtCompliance struct {
+	// Checked is the number of Go files which were checked.
+	Checked int
+	// Clean is the number of Go files which did not change after gofmt.
+	Clean int
+}
+
+// Rate returns the ratio of gofmt-clean files to all the checked files.
+func (compliance GofmtCompliance) Rate() float64 {
+	if compliance.Checked == 0 {
+		return 0
+	}
+	return float64(compliance.Clean) / float64(compliance.Checked)
+}
+
+// GofmtComplianceResult is returned by GofmtComplianceAnalysis.Finalize() and carries
+// the gofmt compliance per day and per developer.
+type GofmtComplianceResult struct {
+	// Days maps the day index to the compliance of the Go files changed on that day.
+	Days map[int]GofmtCompliance
+	// People is the compliance of the Go files changed by each developer, indexed by
+	// the developer's identity. The last element corresponds to the unmatched authors.
+	People []GofmtCompliance
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (gofmt *GofmtComplianceAnalysis) Name() string {
+	return "GofmtCompliance"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (gofmt *GofmtComplianceAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (gofmt *GofmtComplianceAnalysis) Requires() []string {
+	arr := [...]string{
+		items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyDay,
+		identity.DependencyAuthor}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (gofmt *GofmtComplianceAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (gofmt *GofmtComplianceAnalysis) Flag() string {
+	return "gofmt"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (gofmt *GofmtComplianceAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		gofmt.PeopleNumber = val
+		gofmt.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (gofmt *GofmtComplianceAnalysis) Initialize(repository *git.Repository) {
+	gofmt.days = map[int]GofmtCompliance{}
+	gofmt.people = make([]GofmtCompliance, gofmt.PeopleNumber+1)
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (gofmt *GofmtComplianceAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	day := deps[items.DependencyDay].(int)
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing {
+		author = gofmt.PeopleNumber
+	}
+	compliance := GofmtCompliance{}
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		if action == merkletrie.Delete || path.Ext(change.To.Name) != ".go" {
+			continue
+		}
+		contents, err := items.BlobToString(cache[change.To.TreeEntry.Hash])
+		if err != nil {
+			return nil, err
+		}
+		clean, err := IsGofmtClean([]byte(contents))
+		if err != nil {
+			// does not parse
+			continue
+		}
+		compliance.Checked++
+		if clean {
+			compliance.Clean++
+		}
+	}
+	if compliance.Checked == 0 {
+		return nil, nil
+	}
+	dayCompliance := gofmt.days[day]
+	dayCompliance.Checked += compliance.Checked
+	dayCompliance.Clean += compliance.Clean
+	gofmt.days[day] = dayCompliance
+	gofmt.people[author].Checked += compliance.Checked
+	gofmt.people[author].Clean += compliance.Clean
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (gofmt *GofmtComplianceAnalysis) Finalize() interface{} {
+	return GofmtComplianceResult{
+		Days:               gofmt.days,
+		People:             gofmt.people,
+		reversedPeopleDict: gofmt.reversedPeopleDict,
+	}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (gofmt *GofmtComplianceAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	gofmtResult := result.(GofmtComplianceResult)
+	if binary {
+		return gofmt.serializeBinary(&gofmtResult, writer)
+	}
+	gofmt.serializeText(&gofmtResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (gofmt *GofmtComplianceAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(gofmt, result, &pb.GofmtComplianceResults{}, writer)
+}
+
+func (gofmt *GofmtComplianceAnalysis) serializeText(result *GofmtComplianceResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  days:")
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	for _, day := range days {
+		compliance := result.Days[day]
+		fmt.Fprintf(writer, "    %d: {checked: %d, clean: %d}\n", day, compliance.Checked, compliance.Clean)
+	}
+	fmt.Fprintln(writer, "  people:")
+	for i, name := range result.reversedPeopleDict {
+		if i >= len(result.People) {
+			break
+		}
+		compliance := result.People[i]
+		fmt.Fprintf(writer, "    %s: {checked: %d, clean: %d}\n",
+			yaml.SafeString(name), compliance.Checked, compliance.Clean)
+	}
+}
+
+func (gofmt *GofmtComplianceAnalysis) serializeBinary(result *GofmtComplianceResult, writer io.Writer) error {
+	message := pb.GofmtComplianceResults{
+		Days:           map[int32]*pb.GofmtCompliance{},
+		People:         make([]*pb.GofmtCompliance, len(result.People)),
+		PeopleSequence: result.reversedPeopleDict,
+	}
+	for day, compliance := range result.Days {
+		message.Days[int32(day)] = &pb.GofmtCompliance{
+			Checked: int32(compliance.Checked),
+			Clean:   int32(compliance.Clean),
+		}
+	}
+	for i, compliance := range result.People {
+		message.People[i] = &pb.GofmtCompliance{
+			Checked: int32(compliance.Checked),
+			Clean:   int32(compliance.Clean),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+// IsGofmtClean returns true if the Go source code does not change after gofmt.
+// The error is not nil if the code cannot be parsed.
+func IsGofmtClean(src []byte) (bool, error) {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(formatted, src), nil
+}
+
+func init() {
+	core.Registry.Register(&GofmtComplianceAnalysis{})
+}
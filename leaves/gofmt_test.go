@@ -0,0 +1,139 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureGofmtCompliance() *GofmtComplianceAnalysis {
+	gofmt := GofmtComplianceAnalysis{PeopleNumber: 2}
+	gofmt.Initialize(test.Repository)
+	return &gofmt
+}
+
+func TestGofmtComplianceMeta(t *testing.T) {
+	gofmt := fixtureGofmtCompliance()
+	assert.Equal(t, gofmt.Name(), "GofmtCompliance")
+	assert.Len(t, gofmt.Provides(), 0)
+	assert.Equal(t, gofmt.Requires(), []string{
+		items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyDay,
+		identity.DependencyAuthor})
+	assert.Len(t, gofmt.ListConfigurationOptions(), 0)
+	assert.Equal(t, gofmt.Flag(), "gofmt")
+	facts := map[string]interface{}{}
+	facts[identity.FactIdentityDetectorPeopleCount] = 3
+	facts[identity.FactIdentityDetectorReversedPeopleDict] = []string{"one", "two", "three"}
+	gofmt.Configure(facts)
+	assert.Equal(t, gofmt.PeopleNumber, 3)
+	assert.Equal(t, gofmt.reversedPeopleDict, []string{"one", "two", "three"})
+}
+
+func TestGofmtComplianceRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&GofmtComplianceAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "GofmtCompliance")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&GofmtComplianceAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestIsGofmtClean(t *testing.T) {
+	clean, err := IsGofmtClean([]byte("package main\n\nfunc main() {\n\tprintln(1)\n}\n"))
+	assert.Nil(t, err)
+	assert.True(t, clean)
+	clean, err = IsGofmtClean([]byte("package main\nfunc main() {\n  println( 1 )\n}\n"))
+	assert.Nil(t, err)
+	assert.False(t, clean)
+	_, err = IsGofmtClean([]byte("package main\nfunc main() {"))
+	assert.NotNil(t, err)
+}
+
+func TestGofmtComplianceRate(t *testing.T) {
+	assert.Equal(t, GofmtCompliance{}.Rate(), float64(0))
+	assert.Equal(t, GofmtCompliance{Checked: 4, Clean: 3}.Rate(), 0.75)
+}
+
+func TestGofmtComplianceConsume(t *testing.T) {
+	gofmt := fixtureGofmtCompliance()
+	deps := map[string]interface{}{}
+	cache := map[plumbing.Hash]*object.Blob{}
+	for _, hash := range []string{
+		"291286b4ac41952cbd1389fda66420ec03c1a9fe", "c29112dbd697ad9b401333b80c18a63951bc18d9",
+		"dc248ba2b22048cc730c571a748e8ffcf7085ab9"} {
+		cache[plumbing.NewHash(hash)], _ = test.Repository.BlobObject(plumbing.NewHash(hash))
+	}
+	deps[items.DependencyBlobCache] = cache
+	deps[items.DependencyDay] = 1
+	deps[identity.DependencyAuthor] = 1
+	deps[items.DependencyTreeChanges] = object.Changes{
+		&object.Change{To: object.ChangeEntry{Name: "analyser.go", TreeEntry: object.TreeEntry{
+			Name: "analyser.go", Hash: plumbing.NewHash("dc248ba2b22048cc730c571a748e8ffcf7085ab9")}}},
+		&object.Change{To: object.ChangeEntry{Name: ".travis.yml", TreeEntry: object.TreeEntry{
+			Name: ".travis.yml", Hash: plumbing.NewHash("291286b4ac41952cbd1389fda66420ec03c1a9fe")}}},
+	}
+	result, err := gofmt.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps[identity.DependencyAuthor] = identity.AuthorMissing
+	deps[items.DependencyTreeChanges] = object.Changes{
+		&object.Change{To: object.ChangeEntry{Name: "cmd/hercules/main.go", TreeEntry: object.TreeEntry{
+			Name: "cmd/hercules/main.go", Hash: plumbing.NewHash("c29112dbd697ad9b401333b80c18a63951bc18d9")}}},
+	}
+	result, err = gofmt.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	res := gofmt.Finalize().(GofmtComplianceResult)
+	assert.Len(t, res.Days, 1)
+	assert.Equal(t, res.Days[1].Checked, 2)
+	assert.Len(t, res.People, 3)
+	assert.Equal(t, res.People[0].Checked, 0)
+	assert.Equal(t, res.People[1].Checked, 1)
+	assert.Equal(t, res.People[2].Checked, 1)
+}
+
+func TestGofmtComplianceSerialize(t *testing.T) {
+	gofmt := fixtureGofmtCompliance()
+	res := GofmtComplianceResult{
+		Days: map[int]GofmtCompliance{
+			5: {Checked: 3, Clean: 1},
+			0: {Checked: 2, Clean: 2},
+		},
+		People:             []GofmtCompliance{{Checked: 4, Clean: 2}, {Checked: 1, Clean: 1}, {}},
+		reversedPeopleDict: []string{"one@srcd", "two@srcd"},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, gofmt.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  days:
+    0: {checked: 2, clean: 2}
+    5: {checked: 3, clean: 1}
+  people:
+    "one@srcd": {checked: 4, clean: 2}
+    "two@srcd": {checked: 1, clean: 1}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, gofmt.Serialize(res, true, buffer))
+	msg := pb.GofmtComplianceResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Days, 2)
+	assert.Equal(t, msg.Days[5].Checked, int32(3))
+	assert.Equal(t, msg.Days[5].Clean, int32(1))
+	assert.Len(t, msg.People, 3)
+	assert.Equal(t, msg.People[0].Clean, int32(2))
+	assert.Equal(t, msg.PeopleSequence, []string{"one@srcd", "two@srcd"})
+}
@@ -0,0 +1,265 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// HalsteadAnalysis calculates Halstead complexity measures of every changed file from the UAST
+// and aggregates them per directory per day. The operators are the nodes with the Operator role
+// and the operands are the identifiers and the literals.
+// Reference: https://en.wikipedia.org/wiki/Halstead_complexity_measures
+// It is a LeafPipelineItem.
+type HalsteadAnalysis struct {
+	// files maps the file name to its current Halstead metrics.
+	files map[string]HalsteadMetrics
+	// dirs maps the directory name to the set of files inside.
+	dirs map[string]map[string]bool
+	// history maps days to the directory metrics which changed on that day.
+	history map[int]map[string]HalsteadMetrics
+}
+
+// HalsteadMetrics are the Halstead complexity measures of a file or a group of files.
+type HalsteadMetrics struct {
+	// Volume is N * log2(n), where N is the total number of operators and operands and n is
+	// the number of distinct operators and operands. Volumes of several files are summed.
+	Volume float64
+	// Difficulty is n1 / 2 * N2 / n2, where n1 is the number of distinct operators,
+	// N2 is the total number of operands and n2 is the number of distinct operands.
+	// Difficulties of several files are averaged.
+	Difficulty float64
+	// Files is the number of aggregated files.
+	Files int
+}
+
+// HalsteadResult is returned by HalsteadAnalysis.Finalize() and carries the Halstead metrics
+// per directory on each day when the directory changed.
+type HalsteadResult struct {
+	// Days maps the day index to the directory -> metrics mapping.
+	Days map[int]map[string]HalsteadMetrics
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (halstead *HalsteadAnalysis) Name() string {
+	return "Halstead"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (halstead *HalsteadAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (halstead *HalsteadAnalysis) Requires() []string {
+	arr := [...]string{uast_items.DependencyUastChanges, items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (halstead *HalsteadAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (halstead *HalsteadAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (halstead *HalsteadAnalysis) Flag() string {
+	return "halstead"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (halstead *HalsteadAnalysis) Configure(facts map[string]interface{}) {
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (halstead *HalsteadAnalysis) Initialize(repository *git.Repository) {
+	halstead.files = map[string]HalsteadMetrics{}
+	halstead.dirs = map[string]map[string]bool{}
+	halstead.history = map[int]map[string]HalsteadMetrics{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (halstead *HalsteadAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	day := deps[items.DependencyDay].(int)
+	touched := map[string]bool{}
+	for _, change := range changes {
+		if name := change.Change.From.Name; name != "" {
+			dir := path.Dir(name)
+			delete(halstead.files, name)
+			delete(halstead.dirs[dir], name)
+			touched[dir] = true
+		}
+		if change.After != nil {
+			name := change.Change.To.Name
+			dir := path.Dir(name)
+			halstead.files[name] = CalculateHalsteadMetrics(change.After)
+			files := halstead.dirs[dir]
+			if files == nil {
+				files = map[string]bool{}
+				halstead.dirs[dir] = files
+			}
+			files[name] = true
+			touched[dir] = true
+		}
+	}
+	if len(touched) == 0 {
+		return nil, nil
+	}
+	snapshot := halstead.history[day]
+	if snapshot == nil {
+		snapshot = map[string]HalsteadMetrics{}
+		halstead.history[day] = snapshot
+	}
+	for dir := range touched {
+		metrics := HalsteadMetrics{}
+		for file := range halstead.dirs[dir] {
+			fileMetrics := halstead.files[file]
+			metrics.Volume += fileMetrics.Volume
+			metrics.Difficulty += fileMetrics.Difficulty
+			metrics.Files++
+		}
+		if metrics.Files > 0 {
+			metrics.Difficulty /= float64(metrics.Files)
+		} else {
+			delete(halstead.dirs, dir)
+		}
+		snapshot[dir] = metrics
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (halstead *HalsteadAnalysis) Finalize() interface{} {
+	return HalsteadResult{Days: halstead.history}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (halstead *HalsteadAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	halsteadResult := result.(HalsteadResult)
+	if binary {
+		return halstead.serializeBinary(&halsteadResult, writer)
+	}
+	halstead.serializeText(&halsteadResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (halstead *HalsteadAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(halstead, result, &pb.HalsteadResults{}, writer)
+}
+
+func (halstead *HalsteadAnalysis) serializeText(result *HalsteadResult, writer io.Writer) {
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	for _, day := range days {
+		fmt.Fprintf(writer, "  %d:\n", day)
+		dirs := result.Days[day]
+		keys := make([]string, 0, len(dirs))
+		for dir := range dirs {
+			keys = append(keys, dir)
+		}
+		sort.Strings(keys)
+		for _, dir := range keys {
+			metrics := dirs[dir]
+			fmt.Fprintf(writer, "    %s: {volume: %.1f, difficulty: %.2f, files: %d}\n",
+				yaml.SafeString(dir), metrics.Volume, metrics.Difficulty, metrics.Files)
+		}
+	}
+}
+
+func (halstead *HalsteadAnalysis) serializeBinary(result *HalsteadResult, writer io.Writer) error {
+	message := pb.HalsteadResults{
+		Days: map[int32]*pb.DirectoryHalstead{},
+	}
+	for day, dirs := range result.Days {
+		pbDirs := &pb.DirectoryHalstead{
+			Directories: map[string]*pb.HalsteadMetrics{},
+		}
+		for dir, metrics := range dirs {
+			pbDirs.Directories[dir] = &pb.HalsteadMetrics{
+				Volume:     float32(metrics.Volume),
+				Difficulty: float32(metrics.Difficulty),
+				Files:      int32(metrics.Files),
+			}
+		}
+		message.Days[int32(day)] = pbDirs
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+// CalculateHalsteadMetrics finds the Halstead volume and difficulty of the specified UAST.
+func CalculateHalsteadMetrics(root *uast.Node) HalsteadMetrics {
+	operators := map[string]int{}
+	operands := map[string]int{}
+	uast_items.VisitEachNode(root, func(node *uast.Node) {
+		if node.Token == "" {
+			return
+		}
+		for _, role := range node.Roles {
+			switch role {
+			case uast.Operator:
+				operators[node.Token]++
+				return
+			case uast.Identifier, uast.Literal:
+				operands[node.Token]++
+				return
+			}
+		}
+	})
+	var totalOperators, totalOperands int
+	for _, count := range operators {
+		totalOperators += count
+	}
+	for _, count := range operands {
+		totalOperands += count
+	}
+	metrics := HalsteadMetrics{Files: 1}
+	vocabulary := len(operators) + len(operands)
+	if vocabulary > 0 {
+		metrics.Volume = float64(totalOperators+totalOperands) * math.Log2(float64(vocabulary))
+	}
+	if len(operands) > 0 {
+		metrics.Difficulty = float64(len(operators)) / 2 *
+			float64(totalOperands) / float64(len(operands))
+	}
+	return metrics
+}
+
+func init() {
+	core.Registry.Register(&HalsteadAnalysis{})
+}
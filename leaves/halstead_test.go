@@ -0,0 +1,124 @@
+package leaves
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureHalstead() *HalsteadAnalysis {
+	h := HalsteadAnalysis{}
+	h.Initialize(test.Repository)
+	return &h
+}
+
+// fixtureHalsteadUAST builds the UAST of "a = b + a".
+func fixtureHalsteadUAST() *uast.Node {
+	return &uast.Node{Roles: []uast.Role{uast.File}, Children: []*uast.Node{
+		{Token: "a", Roles: []uast.Role{uast.Identifier}},
+		{Token: "=", Roles: []uast.Role{uast.Operator, uast.Assignment}},
+		{Token: "b", Roles: []uast.Role{uast.Identifier}},
+		{Token: "+", Roles: []uast.Role{uast.Operator, uast.Add}},
+		{Token: "a", Roles: []uast.Role{uast.Identifier}},
+	}}
+}
+
+func TestHalsteadMeta(t *testing.T) {
+	h := fixtureHalstead()
+	assert.Equal(t, h.Name(), "Halstead")
+	assert.Len(t, h.Provides(), 0)
+	assert.Equal(t, h.Requires(), []string{uast_items.DependencyUastChanges, items.DependencyDay})
+	assert.Equal(t, h.Features(), []string{uast_items.FeatureUast})
+	assert.Len(t, h.ListConfigurationOptions(), 0)
+	assert.Equal(t, h.Flag(), "halstead")
+	h.Configure(nil)
+}
+
+func TestHalsteadRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&HalsteadAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Halstead")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&HalsteadAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestCalculateHalsteadMetrics(t *testing.T) {
+	metrics := CalculateHalsteadMetrics(fixtureHalsteadUAST())
+	// n1 = 2, n2 = 2, N1 = 2, N2 = 3
+	assert.Equal(t, metrics.Files, 1)
+	assert.InDelta(t, metrics.Volume, 5*math.Log2(4), 1e-9)
+	assert.InDelta(t, metrics.Difficulty, 1.5, 1e-9)
+	metrics = CalculateHalsteadMetrics(&uast.Node{})
+	assert.Equal(t, metrics.Volume, float64(0))
+	assert.Equal(t, metrics.Difficulty, float64(0))
+}
+
+func TestHalsteadConsume(t *testing.T) {
+	h := fixtureHalstead()
+	deps := map[string]interface{}{}
+	deps[items.DependencyDay] = 0
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{After: fixtureHalsteadUAST(), Change: &object.Change{
+			To: object.ChangeEntry{Name: "pkg/a.go"}}},
+		{After: fixtureHalsteadUAST(), Change: &object.Change{
+			To: object.ChangeEntry{Name: "pkg/b.go"}}},
+	}
+	result, err := h.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps[items.DependencyDay] = 2
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: fixtureHalsteadUAST(), After: fixtureHalsteadUAST(), Change: &object.Change{
+			From: object.ChangeEntry{Name: "pkg/a.go"},
+			To:   object.ChangeEntry{Name: "main.go"}}},
+	}
+	result, err = h.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	res := h.Finalize().(HalsteadResult)
+	assert.Len(t, res.Days, 2)
+	assert.Len(t, res.Days[0], 1)
+	assert.Equal(t, res.Days[0]["pkg"].Files, 2)
+	assert.InDelta(t, res.Days[0]["pkg"].Volume, 10*math.Log2(4), 1e-9)
+	assert.InDelta(t, res.Days[0]["pkg"].Difficulty, 1.5, 1e-9)
+	assert.Len(t, res.Days[2], 2)
+	assert.Equal(t, res.Days[2]["pkg"].Files, 1)
+	assert.Equal(t, res.Days[2]["."].Files, 1)
+}
+
+func TestHalsteadSerialize(t *testing.T) {
+	h := fixtureHalstead()
+	res := HalsteadResult{Days: map[int]map[string]HalsteadMetrics{
+		3: {"pkg": {Volume: 10, Difficulty: 1.5, Files: 2}, ".": {Volume: 1, Difficulty: 2, Files: 1}},
+	}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, h.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  3:
+    ".": {volume: 1.0, difficulty: 2.00, files: 1}
+    "pkg": {volume: 10.0, difficulty: 1.50, files: 2}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, h.Serialize(res, true, buffer))
+	msg := pb.HalsteadResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Days, 1)
+	assert.Equal(t, msg.Days[3].Directories["pkg"].Volume, float32(10))
+	assert.Equal(t, msg.Days[3].Directories["pkg"].Files, int32(2))
+}
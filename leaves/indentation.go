@@ -0,0 +1,260 @@
+package leaves
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// IndentationComplexityAnalysis measures the indentation depth of every changed file and
+// tracks it over time. The indentation depth is a cheap, language agnostic proxy of
+// the code complexity which does not require any parsers.
+// Reference: http://www.adamtornhill.com/articles/indentation/indentation.htm
+// It is a LeafPipelineItem.
+type IndentationComplexityAnalysis struct {
+	// TabWidth is the number of spaces which make one indentation level. A tab is always
+	// one level.
+	TabWidth int
+
+	// files maps the file name to the history of its indentation statistics.
+	files map[string][]IndentationStats
+}
+
+// IndentationStats is the indentation summary of a file at some point in time.
+type IndentationStats struct {
+	// Day is the number of days since the beginning of the analysed history.
+	Day int
+	// Lines is the number of non-blank lines.
+	Lines int
+	// Total is the sum of the indentation levels of all the non-blank lines.
+	Total int
+	// Max is the largest indentation level.
+	Max int
+}
+
+// IndentationComplexityResult is returned by IndentationComplexityAnalysis.Finalize() and carries
+// the indentation statistics history of every file which exists in the last analysed commit.
+type IndentationComplexityResult struct {
+	Files map[string][]IndentationStats
+}
+
+const (
+	// ConfigIndentationTabWidth is the name of the option to set
+	// IndentationComplexityAnalysis.TabWidth.
+	ConfigIndentationTabWidth = "IndentationComplexity.TabWidth"
+	// DefaultIndentationTabWidth is the default value of IndentationComplexityAnalysis.TabWidth.
+	DefaultIndentationTabWidth = 4
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (indent *IndentationComplexityAnalysis) Name() string {
+	return "IndentationComplexity"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (indent *IndentationComplexityAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (indent *IndentationComplexityAnalysis) Requires() []string {
+	arr := [...]string{items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (indent *IndentationComplexityAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigIndentationTabWidth,
+		Description: "How many spaces make one indentation level.",
+		Flag:        "indentation-tab-width",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultIndentationTabWidth},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (indent *IndentationComplexityAnalysis) Flag() string {
+	return "indentation-complexity"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (indent *IndentationComplexityAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigIndentationTabWidth].(int); exists {
+		indent.TabWidth = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (indent *IndentationComplexityAnalysis) Initialize(repository *git.Repository) {
+	if indent.TabWidth <= 0 {
+		log.Printf("Warning: adjusted the tab width to %d\n", DefaultIndentationTabWidth)
+		indent.TabWidth = DefaultIndentationTabWidth
+	}
+	indent.files = map[string][]IndentationStats{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (indent *IndentationComplexityAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	day := deps[items.DependencyDay].(int)
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		var history []IndentationStats
+		switch action {
+		case merkletrie.Delete:
+			delete(indent.files, change.From.Name)
+			continue
+		case merkletrie.Modify:
+			history = indent.files[change.From.Name]
+			delete(indent.files, change.From.Name)
+		}
+		contents, err := items.BlobToString(cache[change.To.TreeEntry.Hash])
+		if err != nil {
+			return nil, err
+		}
+		if !utf8.ValidString(contents) {
+			// binary
+			continue
+		}
+		stats := indent.measure(contents)
+		stats.Day = day
+		if len(history) > 0 && history[len(history)-1].Day == day {
+			history[len(history)-1] = stats
+		} else {
+			history = append(history, stats)
+		}
+		indent.files[change.To.Name] = history
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (indent *IndentationComplexityAnalysis) Finalize() interface{} {
+	return IndentationComplexityResult{Files: indent.files}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (indent *IndentationComplexityAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	indentResult := result.(IndentationComplexityResult)
+	if binary {
+		return indent.serializeBinary(&indentResult, writer)
+	}
+	indent.serializeText(&indentResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (indent *IndentationComplexityAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(indent, result, &pb.IndentationComplexityResults{}, writer)
+}
+
+func (indent *IndentationComplexityAnalysis) serializeText(
+	result *IndentationComplexityResult, writer io.Writer) {
+	keys := make([]string, 0, len(result.Files))
+	for key := range result.Files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fmt.Fprintln(writer, "  # day, non-blank lines, total indentation, max indentation")
+	for _, key := range keys {
+		history := result.Files[key]
+		records := make([]string, len(history))
+		for i, stats := range history {
+			records[i] = fmt.Sprintf("[%d, %d, %d, %d]", stats.Day, stats.Lines, stats.Total, stats.Max)
+		}
+		fmt.Fprintf(writer, "  %s: [%s]\n", yaml.SafeString(key), strings.Join(records, ", "))
+	}
+}
+
+func (indent *IndentationComplexityAnalysis) serializeBinary(
+	result *IndentationComplexityResult, writer io.Writer) error {
+	message := pb.IndentationComplexityResults{
+		Files: map[string]*pb.IndentationHistory{},
+	}
+	for key, history := range result.Files {
+		pbHistory := &pb.IndentationHistory{
+			Stats: make([]*pb.IndentationStats, len(history)),
+		}
+		for i, stats := range history {
+			pbHistory.Stats[i] = &pb.IndentationStats{
+				Day:   int32(stats.Day),
+				Lines: int32(stats.Lines),
+				Total: int32(stats.Total),
+				Max:   int32(stats.Max),
+			}
+		}
+		message.Files[key] = pbHistory
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+// measure calculates the indentation statistics of the text. Blank lines are ignored.
+func (indent *IndentationComplexityAnalysis) measure(contents string) IndentationStats {
+	stats := IndentationStats{}
+	scanner := bufio.NewScanner(strings.NewReader(contents))
+	scanner.Buffer(make([]byte, bufio.MaxScanTokenSize), len(contents)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		tabs, spaces := 0, 0
+	LOOP:
+		for _, char := range line {
+			switch char {
+			case '\t':
+				tabs++
+			case ' ':
+				spaces++
+			default:
+				break LOOP
+			}
+		}
+		level := tabs + spaces/indent.TabWidth
+		stats.Lines++
+		stats.Total += level
+		if level > stats.Max {
+			stats.Max = level
+		}
+	}
+	return stats
+}
+
+func init() {
+	core.Registry.Register(&IndentationComplexityAnalysis{})
+}
@@ -0,0 +1,134 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureIndentationComplexity() *IndentationComplexityAnalysis {
+	indent := IndentationComplexityAnalysis{}
+	indent.Initialize(test.Repository)
+	return &indent
+}
+
+func TestIndentationComplexityMeta(t *testing.T) {
+	indent := fixtureIndentationComplexity()
+	assert.Equal(t, indent.Name(), "IndentationComplexity")
+	assert.Len(t, indent.Provides(), 0)
+	assert.Equal(t, indent.Requires(), []string{
+		items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyDay})
+	opts := indent.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigIndentationTabWidth)
+	assert.Equal(t, indent.Flag(), "indentation-complexity")
+	assert.Equal(t, indent.TabWidth, DefaultIndentationTabWidth)
+	indent.Configure(map[string]interface{}{ConfigIndentationTabWidth: 2})
+	assert.Equal(t, indent.TabWidth, 2)
+	indent.Configure(map[string]interface{}{ConfigIndentationTabWidth: -1})
+	indent.Initialize(test.Repository)
+	assert.Equal(t, indent.TabWidth, DefaultIndentationTabWidth)
+}
+
+func TestIndentationComplexityRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&IndentationComplexityAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "IndentationComplexity")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&IndentationComplexityAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestIndentationComplexityMeasure(t *testing.T) {
+	indent := fixtureIndentationComplexity()
+	stats := indent.measure("func main() {\n\tif true {\n\t\treturn\n\t}\n\n    }\n  \t\n")
+	assert.Equal(t, stats.Lines, 5)
+	assert.Equal(t, stats.Total, 0+1+2+1+1)
+	assert.Equal(t, stats.Max, 2)
+	indent.TabWidth = 2
+	stats = indent.measure("a\n  b\n      c\n   d")
+	assert.Equal(t, stats.Lines, 4)
+	assert.Equal(t, stats.Total, 0+1+3+1)
+	assert.Equal(t, stats.Max, 3)
+	stats = indent.measure("")
+	assert.Equal(t, stats, IndentationStats{})
+}
+
+func TestIndentationComplexityConsume(t *testing.T) {
+	indent := fixtureIndentationComplexity()
+	deps := map[string]interface{}{}
+	cache := map[plumbing.Hash]*object.Blob{}
+	for _, hash := range []string{
+		"291286b4ac41952cbd1389fda66420ec03c1a9fe", "c29112dbd697ad9b401333b80c18a63951bc18d9",
+		"baa64828831d174f40140e4b3cfa77d1e917a2c1", "dc248ba2b22048cc730c571a748e8ffcf7085ab9"} {
+		cache[plumbing.NewHash(hash)], _ = test.Repository.BlobObject(plumbing.NewHash(hash))
+	}
+	deps[items.DependencyBlobCache] = cache
+	deps[items.DependencyDay] = 0
+	deps[items.DependencyTreeChanges] = object.Changes{
+		&object.Change{To: object.ChangeEntry{Name: "analyser.go", TreeEntry: object.TreeEntry{
+			Name: "analyser.go", Hash: plumbing.NewHash("dc248ba2b22048cc730c571a748e8ffcf7085ab9")}}},
+		&object.Change{To: object.ChangeEntry{Name: ".travis.yml", TreeEntry: object.TreeEntry{
+			Name: ".travis.yml", Hash: plumbing.NewHash("291286b4ac41952cbd1389fda66420ec03c1a9fe")}}},
+	}
+	result, err := indent.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	assert.Len(t, indent.files, 2)
+	assert.Len(t, indent.files["analyser.go"], 1)
+	first := indent.files["analyser.go"][0]
+	assert.True(t, first.Lines > 0)
+	assert.True(t, first.Max > 0)
+	deps[items.DependencyDay] = 3
+	deps[items.DependencyTreeChanges] = object.Changes{
+		test.FakeChangeForName("analyser.go",
+			"dc248ba2b22048cc730c571a748e8ffcf7085ab9", "baa64828831d174f40140e4b3cfa77d1e917a2c1"),
+		&object.Change{From: object.ChangeEntry{Name: ".travis.yml", TreeEntry: object.TreeEntry{
+			Name: ".travis.yml", Hash: plumbing.NewHash("291286b4ac41952cbd1389fda66420ec03c1a9fe")}}},
+	}
+	result, err = indent.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	res := indent.Finalize().(IndentationComplexityResult)
+	assert.Len(t, res.Files, 1)
+	assert.Len(t, res.Files["analyser.go"], 2)
+	assert.Equal(t, res.Files["analyser.go"][0], first)
+	assert.Equal(t, res.Files["analyser.go"][1].Day, 3)
+}
+
+func TestIndentationComplexitySerialize(t *testing.T) {
+	indent := fixtureIndentationComplexity()
+	res := IndentationComplexityResult{Files: map[string][]IndentationStats{
+		"main.go": {{Day: 0, Lines: 10, Total: 12, Max: 3}, {Day: 5, Lines: 20, Total: 30, Max: 4}},
+		"a.py":    {{Day: 2, Lines: 1, Total: 0, Max: 0}},
+	}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, indent.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  # day, non-blank lines, total indentation, max indentation
+  "a.py": [[2, 1, 0, 0]]
+  "main.go": [[0, 10, 12, 3], [5, 20, 30, 4]]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, indent.Serialize(res, true, buffer))
+	msg := pb.IndentationComplexityResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Files, 2)
+	assert.Len(t, msg.Files["main.go"].Stats, 2)
+	assert.Equal(t, msg.Files["main.go"].Stats[1].Day, int32(5))
+	assert.Equal(t, msg.Files["main.go"].Stats[1].Total, int32(30))
+	assert.Equal(t, msg.Files["a.py"].Stats[0].Lines, int32(1))
+}
@@ -0,0 +1,286 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// NestingDepthAnalysis measures the block nesting depth of every changed file from its UAST and
+// tracks it over time. The files whose nesting keeps increasing are reported as the refactoring
+// candidates. The depth of a block is the number of the UAST nodes with the Block role on the path
+// from the root, so the function bodies are usually the first level.
+// It is a LeafPipelineItem.
+type NestingDepthAnalysis struct {
+	// MinIncreases is the minimum number of the consecutive nesting increases to report a file.
+	MinIncreases int
+
+	// files maps the file name to the history of its nesting statistics.
+	files map[string][]NestingDepthStats
+}
+
+// NestingDepthStats is the nesting summary of a file at some point in time.
+type NestingDepthStats struct {
+	// Day is the number of days since the beginning of the analysed history.
+	Day int
+	// Blocks is the number of blocks.
+	Blocks int
+	// Total is the sum of the nesting depths of all the blocks.
+	Total int
+	// Max is the largest nesting depth.
+	Max int
+}
+
+// Average returns the average nesting depth of the blocks.
+func (stats NestingDepthStats) Average() float32 {
+	if stats.Blocks == 0 {
+		return 0
+	}
+	return float32(stats.Total) / float32(stats.Blocks)
+}
+
+// NestingDepthResult is returned by NestingDepthAnalysis.Finalize() and carries the nesting
+// statistics history of every file which exists in the last analysed commit.
+type NestingDepthResult struct {
+	Files map[string][]NestingDepthStats
+	// Increasing maps the files whose nesting keeps increasing to the number of
+	// the consecutive increases at the end of their history.
+	Increasing map[string]int
+}
+
+const (
+	// ConfigNestingDepthMinIncreases is the name of the option to set
+	// NestingDepthAnalysis.MinIncreases.
+	ConfigNestingDepthMinIncreases = "NestingDepth.MinIncreases"
+	// DefaultNestingDepthMinIncreases is the default value of NestingDepthAnalysis.MinIncreases.
+	DefaultNestingDepthMinIncreases = 3
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (nesting *NestingDepthAnalysis) Name() string {
+	return "NestingDepth"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (nesting *NestingDepthAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (nesting *NestingDepthAnalysis) Requires() []string {
+	arr := [...]string{uast_items.DependencyUastChanges, items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (nesting *NestingDepthAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (nesting *NestingDepthAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigNestingDepthMinIncreases,
+		Description: "Minimum number of the consecutive changes which increased the nesting " +
+			"to report a file.",
+		Flag:    "nesting-min-increases",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultNestingDepthMinIncreases},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (nesting *NestingDepthAnalysis) Flag() string {
+	return "nesting-depth"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (nesting *NestingDepthAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigNestingDepthMinIncreases].(int); exists {
+		nesting.MinIncreases = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (nesting *NestingDepthAnalysis) Initialize(repository *git.Repository) {
+	if nesting.MinIncreases <= 0 {
+		log.Printf("Warning: adjusted the minimum number of the nesting increases to %d\n",
+			DefaultNestingDepthMinIncreases)
+		nesting.MinIncreases = DefaultNestingDepthMinIncreases
+	}
+	nesting.files = map[string][]NestingDepthStats{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (nesting *NestingDepthAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	day := deps[items.DependencyDay].(int)
+	for _, change := range changes {
+		var history []NestingDepthStats
+		if change.Change.From.Name != "" {
+			history = nesting.files[change.Change.From.Name]
+			delete(nesting.files, change.Change.From.Name)
+		}
+		if change.After == nil {
+			continue
+		}
+		stats := measureNestingDepth(change.After)
+		stats.Day = day
+		if len(history) > 0 && history[len(history)-1].Day == day {
+			history[len(history)-1] = stats
+		} else {
+			history = append(history, stats)
+		}
+		nesting.files[change.Change.To.Name] = history
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (nesting *NestingDepthAnalysis) Finalize() interface{} {
+	increasing := map[string]int{}
+	for file, history := range nesting.files {
+		if increases := countNestingIncreases(history); increases >= nesting.MinIncreases {
+			increasing[file] = increases
+		}
+	}
+	return NestingDepthResult{Files: nesting.files, Increasing: increasing}
+}
+
+// measureNestingDepth calculates the nesting statistics of the UAST.
+func measureNestingDepth(root *uast.Node) NestingDepthStats {
+	stats := NestingDepthStats{}
+	var visit func(node *uast.Node, depth int)
+	visit = func(node *uast.Node, depth int) {
+		if hasRole(node, uast.Block) {
+			depth++
+			stats.Blocks++
+			stats.Total += depth
+			if depth > stats.Max {
+				stats.Max = depth
+			}
+		}
+		for _, child := range node.Children {
+			visit(child, depth)
+		}
+	}
+	visit(root, 0)
+	return stats
+}
+
+// countNestingIncreases returns the number of the consecutive records at the end of the history
+// which increased the maximum or the average nesting depth and decreased neither of them.
+// The records which did not change the nesting are skipped.
+func countNestingIncreases(history []NestingDepthStats) int {
+	increases := 0
+	for i := len(history) - 1; i > 0; i-- {
+		prev, next := history[i-1], history[i]
+		// compare the averages without the division
+		avgCmp := next.Total*prev.Blocks - prev.Total*next.Blocks
+		if next.Max < prev.Max || avgCmp < 0 {
+			break
+		}
+		if next.Max > prev.Max || avgCmp > 0 {
+			increases++
+		}
+	}
+	return increases
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (nesting *NestingDepthAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	nestingResult := result.(NestingDepthResult)
+	if binary {
+		return nesting.serializeBinary(&nestingResult, writer)
+	}
+	nesting.serializeText(&nestingResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (nesting *NestingDepthAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(nesting, result, &pb.NestingDepthResults{}, writer)
+}
+
+func (nesting *NestingDepthAnalysis) serializeText(result *NestingDepthResult, writer io.Writer) {
+	keys := make([]string, 0, len(result.Files))
+	for key := range result.Files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fmt.Fprintln(writer, "  files:  # day, blocks, total depth, max depth")
+	for _, key := range keys {
+		history := result.Files[key]
+		records := make([]string, len(history))
+		for i, stats := range history {
+			records[i] = fmt.Sprintf("[%d, %d, %d, %d]", stats.Day, stats.Blocks, stats.Total, stats.Max)
+		}
+		fmt.Fprintf(writer, "    %s: [%s]\n", yaml.SafeString(key), strings.Join(records, ", "))
+	}
+	keys = make([]string, 0, len(result.Increasing))
+	for key := range result.Increasing {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fmt.Fprintln(writer, "  increasing:")
+	for _, key := range keys {
+		fmt.Fprintf(writer, "    %s: %d\n", yaml.SafeString(key), result.Increasing[key])
+	}
+}
+
+func (nesting *NestingDepthAnalysis) serializeBinary(result *NestingDepthResult, writer io.Writer) error {
+	message := pb.NestingDepthResults{
+		Files:      map[string]*pb.NestingDepthHistory{},
+		Increasing: map[string]int32{},
+	}
+	for key, history := range result.Files {
+		pbHistory := &pb.NestingDepthHistory{
+			Stats: make([]*pb.NestingDepthStats, len(history)),
+		}
+		for i, stats := range history {
+			pbHistory.Stats[i] = &pb.NestingDepthStats{
+				Day:    int32(stats.Day),
+				Blocks: int32(stats.Blocks),
+				Total:  int32(stats.Total),
+				Max:    int32(stats.Max),
+			}
+		}
+		message.Files[key] = pbHistory
+	}
+	for key, increases := range result.Increasing {
+		message.Increasing[key] = int32(increases)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&NestingDepthAnalysis{})
+}
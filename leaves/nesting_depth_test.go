@@ -0,0 +1,192 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureNestingDepth() *NestingDepthAnalysis {
+	nd := NestingDepthAnalysis{MinIncreases: 2}
+	nd.Initialize(test.Repository)
+	return &nd
+}
+
+// fixtureNestingDepthUAST generates a function with a chain of nested blocks for each depth.
+func fixtureNestingDepthUAST(depths ...int) *uast.Node {
+	root := &uast.Node{Roles: []uast.Role{uast.File}}
+	for _, depth := range depths {
+		parent := root
+		for i := 0; i < depth; i++ {
+			node := &uast.Node{Roles: []uast.Role{uast.Block}}
+			parent.Children = append(parent.Children, &uast.Node{
+				Roles: []uast.Role{uast.If, uast.Statement}, Children: []*uast.Node{node}})
+			parent = node
+		}
+	}
+	return root
+}
+
+func TestNestingDepthMeta(t *testing.T) {
+	nd := fixtureNestingDepth()
+	assert.Equal(t, nd.Name(), "NestingDepth")
+	assert.Len(t, nd.Provides(), 0)
+	assert.Equal(t, nd.Requires(), []string{uast_items.DependencyUastChanges, items.DependencyDay})
+	assert.Equal(t, nd.Features(), []string{uast_items.FeatureUast})
+	opts := nd.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigNestingDepthMinIncreases)
+	assert.Equal(t, nd.Flag(), "nesting-depth")
+}
+
+func TestNestingDepthConfigure(t *testing.T) {
+	nd := NestingDepthAnalysis{}
+	nd.Configure(map[string]interface{}{ConfigNestingDepthMinIncreases: 5})
+	assert.Equal(t, nd.MinIncreases, 5)
+	nd.Configure(map[string]interface{}{})
+	assert.Equal(t, nd.MinIncreases, 5)
+	nd.MinIncreases = 0
+	nd.Initialize(test.Repository)
+	assert.Equal(t, nd.MinIncreases, DefaultNestingDepthMinIncreases)
+}
+
+func TestNestingDepthRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&NestingDepthAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "NestingDepth")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&NestingDepthAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestNestingDepthMeasure(t *testing.T) {
+	assert.Equal(t, measureNestingDepth(fixtureNestingDepthUAST()), NestingDepthStats{})
+	stats := measureNestingDepth(fixtureNestingDepthUAST(1, 3))
+	assert.Equal(t, stats, NestingDepthStats{Blocks: 4, Total: 7, Max: 3})
+	assert.InDelta(t, stats.Average(), 1.75, 0.001)
+	assert.Equal(t, NestingDepthStats{}.Average(), float32(0))
+}
+
+func TestNestingDepthCountIncreases(t *testing.T) {
+	assert.Equal(t, countNestingIncreases(nil), 0)
+	assert.Equal(t, countNestingIncreases([]NestingDepthStats{
+		{Blocks: 2, Total: 2, Max: 1}}), 0)
+	assert.Equal(t, countNestingIncreases([]NestingDepthStats{
+		{Blocks: 2, Total: 2, Max: 1},
+		{Blocks: 3, Total: 5, Max: 2},
+		// the same
+		{Blocks: 3, Total: 5, Max: 2},
+		// the average grows
+		{Blocks: 2, Total: 4, Max: 2},
+	}), 2)
+	assert.Equal(t, countNestingIncreases([]NestingDepthStats{
+		{Blocks: 2, Total: 2, Max: 1},
+		{Blocks: 3, Total: 5, Max: 2},
+		// the max grows but the average drops
+		{Blocks: 10, Total: 13, Max: 3},
+		{Blocks: 10, Total: 14, Max: 3},
+	}), 1)
+}
+
+func TestNestingDepthConsume(t *testing.T) {
+	nd := fixtureNestingDepth()
+	deps := map[string]interface{}{}
+	deps[items.DependencyDay] = 0
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: nil, After: fixtureNestingDepthUAST(1), Change: &object.Change{
+			To: object.ChangeEntry{Name: "main.go"}}},
+		{Before: nil, After: fixtureNestingDepthUAST(2), Change: &object.Change{
+			To: object.ChangeEntry{Name: "util.go"}}},
+	}
+	result, err := nd.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps[items.DependencyDay] = 1
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: fixtureNestingDepthUAST(1), After: fixtureNestingDepthUAST(2),
+			Change: &object.Change{
+				From: object.ChangeEntry{Name: "main.go"},
+				To:   object.ChangeEntry{Name: "cmd/main.go"}}},
+		{Before: fixtureNestingDepthUAST(2), After: nil, Change: &object.Change{
+			From: object.ChangeEntry{Name: "util.go"}}},
+	}
+	nd.Consume(deps)
+	// the same day overwrites
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: fixtureNestingDepthUAST(2), After: fixtureNestingDepthUAST(1, 2),
+			Change: &object.Change{
+				From: object.ChangeEntry{Name: "cmd/main.go"},
+				To:   object.ChangeEntry{Name: "cmd/main.go"}}},
+	}
+	nd.Consume(deps)
+	deps[items.DependencyDay] = 5
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: fixtureNestingDepthUAST(1, 2), After: fixtureNestingDepthUAST(3),
+			Change: &object.Change{
+				From: object.ChangeEntry{Name: "cmd/main.go"},
+				To:   object.ChangeEntry{Name: "cmd/main.go"}}},
+	}
+	nd.Consume(deps)
+	res := nd.Finalize().(NestingDepthResult)
+	assert.Equal(t, res.Files, map[string][]NestingDepthStats{
+		"cmd/main.go": {
+			{Day: 0, Blocks: 1, Total: 1, Max: 1},
+			{Day: 1, Blocks: 3, Total: 4, Max: 2},
+			{Day: 5, Blocks: 3, Total: 6, Max: 3},
+		},
+	})
+	assert.Equal(t, res.Increasing, map[string]int{"cmd/main.go": 2})
+}
+
+func TestNestingDepthSerializeText(t *testing.T) {
+	nd := fixtureNestingDepth()
+	res := NestingDepthResult{
+		Files: map[string][]NestingDepthStats{
+			"b.go": {{Day: 0, Blocks: 2, Total: 3, Max: 2}, {Day: 4, Blocks: 3, Total: 6, Max: 3}},
+			"a.go": {{Day: 1, Blocks: 1, Total: 1, Max: 1}},
+		},
+		Increasing: map[string]int{"b.go": 1},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, nd.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  files:  # day, blocks, total depth, max depth
+    "a.go": [[1, 1, 1, 1]]
+    "b.go": [[0, 2, 3, 2], [4, 3, 6, 3]]
+  increasing:
+    "b.go": 1
+`)
+}
+
+func TestNestingDepthSerializeBinary(t *testing.T) {
+	nd := fixtureNestingDepth()
+	res := NestingDepthResult{
+		Files: map[string][]NestingDepthStats{
+			"b.go": {{Day: 0, Blocks: 2, Total: 3, Max: 2}, {Day: 4, Blocks: 3, Total: 6, Max: 3}},
+		},
+		Increasing: map[string]int{"b.go": 1},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, nd.Serialize(res, true, buffer))
+	msg := pb.NestingDepthResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Files, 1)
+	assert.Len(t, msg.Files["b.go"].Stats, 2)
+	assert.Equal(t, *msg.Files["b.go"].Stats[1], pb.NestingDepthStats{
+		Day: 4, Blocks: 3, Total: 6, Max: 3})
+	assert.Equal(t, msg.Increasing, map[string]int32{"b.go": 1})
+}
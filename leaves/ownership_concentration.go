@@ -0,0 +1,477 @@
+package leaves
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/burndown"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// OwnershipConcentrationAnalysis measures how concentrated the line ownership is in each
+// directory at the end of each quarter: the Gini coefficient and the entropy of the numbers
+// of the lines which each developer wrote. A single trendable number shows where the knowledge
+// is held by few people.
+// It is a LeafPipelineItem and a LinearPipelineItem.
+type OwnershipConcentrationAnalysis struct {
+	// Depth is the maximum number of the path components of the reported directories.
+	// 0 reports only the repository root, negative values report all the directories.
+	Depth int
+	// PeopleNumber is the number of the identified developers.
+	PeopleNumber int
+
+	// files is the mapping <file path> -> *ownershipFile. The values of the lines
+	// are the developer indexes, PeopleNumber corresponds to the unmatched authors.
+	files map[string]*ownershipFile
+	// directories maps the directory to the developer index to the number of owned lines.
+	directories map[string]map[int]int
+	// contributors are the developers who committed so far; the Gini coefficient
+	// is calculated among them.
+	contributors map[int]bool
+	// quarter is the quarter of the commit which is being analysed, e.g. "2018Q1".
+	quarter string
+	// quarters maps the quarter to the directory to the stats.
+	quarters map[string]map[string]OwnershipConcentrationStats
+}
+
+// ownershipFile is the line ownership of a file together with the directories which
+// the file belongs to, so that the burndown.Status callback knows what to update.
+type ownershipFile struct {
+	*burndown.File
+	// directories are the reported directories which include the file.
+	directories []string
+	// owners maps the developer index to the number of owned lines in the file.
+	owners map[int]int
+}
+
+// OwnershipConcentrationStats is the line ownership concentration in a directory.
+type OwnershipConcentrationStats struct {
+	// Lines is the number of lines in the directory.
+	Lines int
+	// Owners is the number of developers who own at least one line.
+	Owners int
+	// Gini is the Gini coefficient of the owned lines among all the developers who committed
+	// so far: 0 means that everybody owns the same number of lines, the values close to 1 mean
+	// that a single developer owns everything.
+	Gini float64
+	// Entropy is the Shannon entropy of the line ownership in bits: 0 means a single owner,
+	// log2(Owners) means that the owners have equal shares.
+	Entropy float64
+}
+
+// OwnershipConcentrationResult is returned by OwnershipConcentrationAnalysis.Finalize() and
+// carries the quarterly stats of each directory.
+type OwnershipConcentrationResult struct {
+	// Quarters maps the quarter ("2018Q1") to the directory to the stats at the end of
+	// the quarter. The root directory is "/". Only the quarters with commits are present.
+	Quarters map[string]map[string]OwnershipConcentrationStats
+	// Directories maps the reported directory to the developer index to the number of lines
+	// which the developer owns there at the end of the analysed history. The unidentified
+	// authors have the index equal to PeopleNumber. It is not serialized.
+	Directories map[string]map[int]int
+}
+
+const (
+	// ConfigOwnershipConcentrationDepth is the name of the option to set
+	// OwnershipConcentrationAnalysis.Depth.
+	ConfigOwnershipConcentrationDepth = "OwnershipConcentration.Depth"
+	// DefaultOwnershipConcentrationDepth is the default value of OwnershipConcentrationAnalysis.Depth.
+	DefaultOwnershipConcentrationDepth = 2
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (ownership *OwnershipConcentrationAnalysis) Name() string {
+	return "OwnershipConcentration"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (ownership *OwnershipConcentrationAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (ownership *OwnershipConcentrationAnalysis) Requires() []string {
+	arr := [...]string{
+		identity.DependencyAuthor, items.DependencyTreeChanges, items.DependencyFileDiff,
+		items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (ownership *OwnershipConcentrationAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigOwnershipConcentrationDepth,
+		Description: "Report the directories with at most this number of path components; " +
+			"0 reports only the root and negative values report all the directories.",
+		Flag:    "ownership-depth",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultOwnershipConcentrationDepth},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (ownership *OwnershipConcentrationAnalysis) Flag() string {
+	return "ownership-concentration"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (ownership *OwnershipConcentrationAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigOwnershipConcentrationDepth].(int); exists {
+		ownership.Depth = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		ownership.PeopleNumber = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (ownership *OwnershipConcentrationAnalysis) Initialize(repository *git.Repository) {
+	ownership.files = map[string]*ownershipFile{}
+	ownership.directories = map[string]map[int]int{}
+	ownership.contributors = map[int]bool{}
+	ownership.quarter = ""
+	ownership.quarters = map[string]map[string]OwnershipConcentrationStats{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (ownership *OwnershipConcentrationAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	when := commit.Author.When.UTC()
+	quarter := fmt.Sprintf("%dQ%d", when.Year(), (int(when.Month())+2)/3)
+	if quarter != ownership.quarter {
+		ownership.recordQuarter()
+		ownership.quarter = quarter
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing {
+		author = ownership.PeopleNumber
+	}
+	ownership.contributors[author] = true
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			err = ownership.handleInsertion(change, author, cache)
+		case merkletrie.Delete:
+			err = ownership.handleDeletion(change, author)
+		case merkletrie.Modify:
+			err = ownership.handleModification(change, author, cache, fileDiffs)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// RequiresLinearHistory returns true because the lines of the files are followed through
+// the diffs, which do not apply to the interleaved branches. See core.LinearPipelineItem.
+func (ownership *OwnershipConcentrationAnalysis) RequiresLinearHistory() bool {
+	return true
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (ownership *OwnershipConcentrationAnalysis) Finalize() interface{} {
+	ownership.recordQuarter()
+	directories := map[string]map[int]int{}
+	for dir, owners := range ownership.directories {
+		if len(owners) == 0 {
+			continue
+		}
+		directories[dir] = owners
+	}
+	return OwnershipConcentrationResult{Quarters: ownership.quarters, Directories: directories}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (ownership *OwnershipConcentrationAnalysis) Serialize(
+	result interface{}, binary bool, writer io.Writer) error {
+	ownershipResult := result.(OwnershipConcentrationResult)
+	if binary {
+		return ownership.serializeBinary(&ownershipResult, writer)
+	}
+	ownership.serializeText(&ownershipResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (ownership *OwnershipConcentrationAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(ownership, result, &pb.OwnershipConcentrationResults{}, writer)
+}
+
+func (ownership *OwnershipConcentrationAnalysis) serializeText(
+	result *OwnershipConcentrationResult, writer io.Writer) {
+	quarters := make([]string, 0, len(result.Quarters))
+	for quarter := range result.Quarters {
+		quarters = append(quarters, quarter)
+	}
+	sort.Strings(quarters)
+	fmt.Fprintln(writer, "  # lines, owners, gini, entropy")
+	fmt.Fprintln(writer, "  quarters:")
+	for _, quarter := range quarters {
+		stats := result.Quarters[quarter]
+		dirs := make([]string, 0, len(stats))
+		for dir := range stats {
+			dirs = append(dirs, dir)
+		}
+		sort.Strings(dirs)
+		fmt.Fprintf(writer, "    %s:\n", yaml.SafeString(quarter))
+		for _, dir := range dirs {
+			dirStats := stats[dir]
+			fmt.Fprintf(writer, "      %s: [%d, %d, %.4f, %.4f]\n", yaml.SafeString(dir),
+				dirStats.Lines, dirStats.Owners, dirStats.Gini, dirStats.Entropy)
+		}
+	}
+}
+
+func (ownership *OwnershipConcentrationAnalysis) serializeBinary(
+	result *OwnershipConcentrationResult, writer io.Writer) error {
+	message := pb.OwnershipConcentrationResults{
+		Quarters: map[string]*pb.DirectoryOwnershipConcentration{}}
+	for quarter, stats := range result.Quarters {
+		pbQuarter := &pb.DirectoryOwnershipConcentration{
+			Directories: map[string]*pb.OwnershipConcentrationStats{}}
+		for dir, dirStats := range stats {
+			pbQuarter.Directories[dir] = &pb.OwnershipConcentrationStats{
+				Lines:   int32(dirStats.Lines),
+				Owners:  int32(dirStats.Owners),
+				Gini:    float32(dirStats.Gini),
+				Entropy: float32(dirStats.Entropy),
+			}
+		}
+		message.Quarters[quarter] = pbQuarter
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+// recordQuarter saves the current ownership concentration as the state at the end of
+// the current quarter.
+func (ownership *OwnershipConcentrationAnalysis) recordQuarter() {
+	if ownership.quarter == "" {
+		return
+	}
+	stats := map[string]OwnershipConcentrationStats{}
+	for dir, owners := range ownership.directories {
+		if len(owners) == 0 {
+			continue
+		}
+		stats[dir] = measureOwnershipConcentration(owners, len(ownership.contributors))
+	}
+	ownership.quarters[ownership.quarter] = stats
+}
+
+// measureOwnershipConcentration calculates the stats of the line ownership. The developers
+// who own nothing are not present in owners but are counted in contributors.
+func measureOwnershipConcentration(owners map[int]int, contributors int) OwnershipConcentrationStats {
+	lines := make([]int, 0, len(owners))
+	total := 0
+	for _, count := range owners {
+		lines = append(lines, count)
+		total += count
+	}
+	stats := OwnershipConcentrationStats{Lines: total, Owners: len(lines)}
+	if total == 0 {
+		return stats
+	}
+	if contributors < len(lines) {
+		contributors = len(lines)
+	}
+	sort.Ints(lines)
+	// G = 2 * sum(i * x_i) / (n * sum(x_i)) - (n + 1) / n, where x is sorted ascending
+	// and the zeros of the developers without lines go first
+	offset := contributors - len(lines)
+	weighted := 0.0
+	for i, count := range lines {
+		weighted += float64(offset+i+1) * float64(count)
+		p := float64(count) / float64(total)
+		stats.Entropy -= p * math.Log2(p)
+	}
+	n := float64(contributors)
+	stats.Gini = 2*weighted/(n*float64(total)) - (n+1)/n
+	if stats.Gini < 0 {
+		// rounding errors
+		stats.Gini = 0
+	}
+	return stats
+}
+
+// reportedDirectories returns the directories which include the file and have at most
+// Depth path components, starting from the root.
+func (ownership *OwnershipConcentrationAnalysis) reportedDirectories(name string) []string {
+	dirs := []string{burndownRootDirectory}
+	dir := path.Dir(name)
+	if dir == "." {
+		return dirs
+	}
+	parts := strings.Split(dir, "/")
+	for i := range parts {
+		if ownership.Depth >= 0 && i >= ownership.Depth {
+			break
+		}
+		dirs = append(dirs, strings.Join(parts[:i+1], "/"))
+	}
+	return dirs
+}
+
+// updateLines is the burndown.Status callback which maintains the number of owned lines.
+// The line values are the developer indexes.
+func (ownership *OwnershipConcentrationAnalysis) updateLines(
+	data interface{}, _ int, previousAuthor int, delta int) {
+	file := data.(*ownershipFile)
+	file.owners[previousAuthor] += delta
+	if file.owners[previousAuthor] == 0 {
+		delete(file.owners, previousAuthor)
+	}
+	ownership.updateDirectories(file.directories, previousAuthor, delta)
+}
+
+// updateDirectories adds delta to the number of lines owned by the developer
+// in each of the directories.
+func (ownership *OwnershipConcentrationAnalysis) updateDirectories(dirs []string, author int, delta int) {
+	for _, dir := range dirs {
+		owners := ownership.directories[dir]
+		if owners == nil {
+			owners = map[int]int{}
+			ownership.directories[dir] = owners
+		}
+		owners[author] += delta
+		if owners[author] == 0 {
+			delete(owners, author)
+		}
+	}
+}
+
+func (ownership *OwnershipConcentrationAnalysis) handleInsertion(
+	change *object.Change, author int, cache map[plumbing.Hash]*object.Blob) error {
+	lines, err := items.CountLines(cache[change.To.TreeEntry.Hash])
+	if err != nil {
+		if err.Error() == "binary" {
+			return nil
+		}
+		return err
+	}
+	name := change.To.Name
+	if _, exists := ownership.files[name]; exists {
+		return fmt.Errorf("file %s already exists", name)
+	}
+	file := &ownershipFile{directories: ownership.reportedDirectories(name), owners: map[int]int{}}
+	file.File = burndown.NewFile(author, lines, burndown.NewStatus(file, ownership.updateLines))
+	ownership.files[name] = file
+	return nil
+}
+
+func (ownership *OwnershipConcentrationAnalysis) handleDeletion(change *object.Change, author int) error {
+	name := change.From.Name
+	file, exists := ownership.files[name]
+	if !exists {
+		// binary files are not tracked
+		return nil
+	}
+	file.Update(author, 0, 0, file.Len())
+	delete(ownership.files, name)
+	return nil
+}
+
+// handleRename moves the owned lines of the file to the directories of the new name.
+func (ownership *OwnershipConcentrationAnalysis) handleRename(file *ownershipFile, from, to string) {
+	ownership.files[to] = file
+	delete(ownership.files, from)
+	dirs := ownership.reportedDirectories(to)
+	for author, lines := range file.owners {
+		ownership.updateDirectories(file.directories, author, -lines)
+		ownership.updateDirectories(dirs, author, lines)
+	}
+	file.directories = dirs
+}
+
+func (ownership *OwnershipConcentrationAnalysis) handleModification(
+	change *object.Change, author int, cache map[plumbing.Hash]*object.Blob,
+	diffs map[string]items.FileDiffData) error {
+	file, exists := ownership.files[change.From.Name]
+	if !exists {
+		return ownership.handleInsertion(change, author, cache)
+	}
+	if change.To.Name != change.From.Name {
+		ownership.handleRename(file, change.From.Name, change.To.Name)
+	}
+	thisDiffs := diffs[change.To.Name]
+	if file.Len() != thisDiffs.OldLinesOfCode {
+		return fmt.Errorf("%s: internal integrity error src %d != %d",
+			change.To.Name, thisDiffs.OldLinesOfCode, file.Len())
+	}
+	// the diffs are line-level so the number of lines equals to the rune count
+	position := 0
+	pending := diffmatchpatch.Diff{Text: ""}
+	for _, edit := range thisDiffs.Diffs {
+		length := utf8.RuneCountInString(edit.Text)
+		switch edit.Type {
+		case diffmatchpatch.DiffEqual:
+			if pending.Text != "" {
+				file.Update(author, position, 0, utf8.RuneCountInString(pending.Text))
+				pending.Text = ""
+			}
+			position += length
+		case diffmatchpatch.DiffInsert:
+			file.Update(author, position, length, utf8.RuneCountInString(pending.Text))
+			position += length
+			pending.Text = ""
+		case diffmatchpatch.DiffDelete:
+			if pending.Text != "" {
+				return errors.New("DiffDelete may not appear after DiffDelete")
+			}
+			pending = edit
+		default:
+			return fmt.Errorf("diff operation is not supported: %d", edit.Type)
+		}
+	}
+	if pending.Text != "" {
+		file.Update(author, position, 0, utf8.RuneCountInString(pending.Text))
+	}
+	if file.Len() != thisDiffs.NewLinesOfCode {
+		return fmt.Errorf("%s: internal integrity error dst %d != %d",
+			change.To.Name, thisDiffs.NewLinesOfCode, file.Len())
+	}
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&OwnershipConcentrationAnalysis{})
+}
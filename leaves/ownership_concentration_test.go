@@ -0,0 +1,188 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureOwnershipConcentration() *OwnershipConcentrationAnalysis {
+	ownership := OwnershipConcentrationAnalysis{}
+	ownership.Configure(map[string]interface{}{
+		ConfigOwnershipConcentrationDepth:        1,
+		identity.FactIdentityDetectorPeopleCount: 2,
+	})
+	ownership.Initialize(test.Repository)
+	return &ownership
+}
+
+func TestOwnershipConcentrationMeta(t *testing.T) {
+	ownership := fixtureOwnershipConcentration()
+	assert.Equal(t, ownership.Name(), "OwnershipConcentration")
+	assert.Len(t, ownership.Provides(), 0)
+	assert.True(t, ownership.RequiresLinearHistory())
+	assert.Equal(t, ownership.Requires(), []string{
+		identity.DependencyAuthor, items.DependencyTreeChanges, items.DependencyFileDiff,
+		items.DependencyBlobCache})
+	opts := ownership.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigOwnershipConcentrationDepth)
+	assert.Equal(t, opts[0].Default, DefaultOwnershipConcentrationDepth)
+	assert.Equal(t, ownership.Flag(), "ownership-concentration")
+	assert.Equal(t, ownership.Depth, 1)
+	assert.Equal(t, ownership.PeopleNumber, 2)
+}
+
+func TestOwnershipConcentrationRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&OwnershipConcentrationAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "OwnershipConcentration")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&OwnershipConcentrationAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestOwnershipConcentrationReportedDirectories(t *testing.T) {
+	ownership := OwnershipConcentrationAnalysis{Depth: 2}
+	assert.Equal(t, ownership.reportedDirectories("a.go"), []string{"/"})
+	assert.Equal(t, ownership.reportedDirectories("a/b/c/d.go"), []string{"/", "a", "a/b"})
+	ownership.Depth = 0
+	assert.Equal(t, ownership.reportedDirectories("a/b/c/d.go"), []string{"/"})
+	ownership.Depth = -1
+	assert.Equal(t, ownership.reportedDirectories("a/b/c/d.go"),
+		[]string{"/", "a", "a/b", "a/b/c"})
+}
+
+func TestMeasureOwnershipConcentration(t *testing.T) {
+	stats := measureOwnershipConcentration(map[int]int{0: 10}, 1)
+	assert.Equal(t, stats, OwnershipConcentrationStats{Lines: 10, Owners: 1})
+	stats = measureOwnershipConcentration(map[int]int{0: 10}, 4)
+	assert.InDelta(t, stats.Gini, 0.75, 1e-9)
+	assert.Equal(t, stats.Entropy, float64(0))
+	stats = measureOwnershipConcentration(map[int]int{0: 5, 1: 5, 2: 5, 3: 5}, 4)
+	assert.InDelta(t, stats.Gini, 0, 1e-9)
+	assert.InDelta(t, stats.Entropy, 2, 1e-9)
+	stats = measureOwnershipConcentration(map[int]int{0: 2, 1: 2, 2: 1}, 3)
+	assert.Equal(t, stats.Lines, 5)
+	assert.Equal(t, stats.Owners, 3)
+	assert.InDelta(t, stats.Gini, 2.0/15, 1e-9)
+	assert.InDelta(t, stats.Entropy, 1.5219, 1e-4)
+	// the contributors may not be fewer than the owners
+	stats = measureOwnershipConcentration(map[int]int{0: 1, 1: 1}, 1)
+	assert.InDelta(t, stats.Gini, 0, 1e-9)
+	assert.Equal(t, measureOwnershipConcentration(map[int]int{}, 3), OwnershipConcentrationStats{})
+}
+
+func TestOwnershipConcentrationConsumeFinalize(t *testing.T) {
+	ownership := fixtureOwnershipConcentration()
+	blobA := fixtureChurnOriginBlob("1\n2\n3\n4\n")
+	blobB := fixtureChurnOriginBlob("1\n2\n")
+	blobC := fixtureChurnOriginBlob("1\n2\n3\n")
+	entry := func(name string, blob *object.Blob) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: blob.Hash}}
+	}
+	// the first developer creates src/a.go
+	result, err := ownership.Consume(fixtureChurnOriginDeps(0, time.January,
+		object.Changes{{To: entry("src/a.go", blobA)}}, nil,
+		map[plumbing.Hash]*object.Blob{blobA.Hash: blobA}))
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	// the second developer creates b.go
+	_, err = ownership.Consume(fixtureChurnOriginDeps(1, time.February,
+		object.Changes{{To: entry("b.go", blobB)}}, nil,
+		map[plumbing.Hash]*object.Blob{blobB.Hash: blobB}))
+	assert.Nil(t, err)
+	// an unmatched author replaces two lines in src/a.go with a single line
+	_, err = ownership.Consume(fixtureChurnOriginDeps(identity.AuthorMissing, time.April,
+		object.Changes{{From: entry("src/a.go", blobA), To: entry("src/a.go", blobC)}},
+		map[string]items.FileDiffData{"src/a.go": {
+			OldLinesOfCode: 4, NewLinesOfCode: 3, Diffs: []diffmatchpatch.Diff{
+				{Type: diffmatchpatch.DiffEqual, Text: "a"},
+				{Type: diffmatchpatch.DiffDelete, Text: "bc"},
+				{Type: diffmatchpatch.DiffInsert, Text: "x"},
+				{Type: diffmatchpatch.DiffEqual, Text: "d"},
+			}}}, nil))
+	assert.Nil(t, err)
+	// the second developer moves src/a.go to lib/a.go
+	_, err = ownership.Consume(fixtureChurnOriginDeps(1, time.May,
+		object.Changes{{From: entry("src/a.go", blobC), To: entry("lib/a.go", blobC)}},
+		map[string]items.FileDiffData{"lib/a.go": {
+			OldLinesOfCode: 3, NewLinesOfCode: 3, Diffs: []diffmatchpatch.Diff{
+				{Type: diffmatchpatch.DiffEqual, Text: "abc"},
+			}}}, nil))
+	assert.Nil(t, err)
+	// the first developer deletes b.go
+	_, err = ownership.Consume(fixtureChurnOriginDeps(0, time.July,
+		object.Changes{{From: entry("b.go", blobB)}}, nil, nil))
+	assert.Nil(t, err)
+	assert.Len(t, ownership.files, 1)
+	assert.Equal(t, ownership.files["lib/a.go"].owners, map[int]int{0: 2, 2: 1})
+	res := ownership.Finalize().(OwnershipConcentrationResult)
+	assert.Equal(t, len(res.Quarters), 3)
+	q1 := res.Quarters["2018Q1"]
+	assert.Len(t, q1, 2)
+	assert.Equal(t, q1["src"].Lines, 4)
+	assert.Equal(t, q1["src"].Owners, 1)
+	assert.InDelta(t, q1["src"].Gini, 0.5, 1e-9)
+	assert.Equal(t, q1["/"].Lines, 6)
+	assert.InDelta(t, q1["/"].Gini, 1.0/6, 1e-9)
+	assert.InDelta(t, q1["/"].Entropy, 0.9183, 1e-4)
+	q2 := res.Quarters["2018Q2"]
+	assert.Len(t, q2, 2)
+	assert.Equal(t, q2["lib"].Lines, 3)
+	assert.InDelta(t, q2["lib"].Gini, 4.0/9, 1e-9)
+	assert.Equal(t, q2["/"].Owners, 3)
+	assert.InDelta(t, q2["/"].Gini, 2.0/15, 1e-9)
+	q3 := res.Quarters["2018Q3"]
+	assert.Equal(t, q3["/"].Lines, 3)
+	assert.InDelta(t, q3["/"].Gini, 4.0/9, 1e-9)
+	assert.Equal(t, res.Directories, map[string]map[int]int{
+		"/": {0: 2, 2: 1}, "lib": {0: 2, 2: 1}})
+}
+
+func TestOwnershipConcentrationSerialize(t *testing.T) {
+	ownership := fixtureOwnershipConcentration()
+	res := OwnershipConcentrationResult{Quarters: map[string]map[string]OwnershipConcentrationStats{
+		"2018Q2": {
+			"/":   {Lines: 10, Owners: 2, Gini: 0.25, Entropy: 1},
+			"src": {Lines: 4, Owners: 1, Gini: 0.5},
+		},
+		"2018Q1": {"/": {Lines: 4, Owners: 1}},
+	}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, ownership.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  # lines, owners, gini, entropy
+  quarters:
+    "2018Q1":
+      "/": [4, 1, 0.0000, 0.0000]
+    "2018Q2":
+      "/": [10, 2, 0.2500, 1.0000]
+      "src": [4, 1, 0.5000, 0.0000]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, ownership.Serialize(res, true, buffer))
+	msg := pb.OwnershipConcentrationResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Quarters, 2)
+	assert.Equal(t, *msg.Quarters["2018Q2"].Directories["/"],
+		pb.OwnershipConcentrationStats{Lines: 10, Owners: 2, Gini: 0.25, Entropy: 1})
+	assert.Equal(t, msg.Quarters["2018Q1"].Directories["/"].Lines, int32(4))
+}
@@ -0,0 +1,475 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// RefactoringAnalysis detects the common refactorings in each commit on top of the UAST edit
+// scripts: extract method, rename method, rename class and move class. It also splits the changed
+// UAST nodes into the refactoring work and the rest (feature churn) so that the refactoring rate
+// can be tracked over time and per developer.
+// It is a LeafPipelineItem.
+type RefactoringAnalysis struct {
+	// PeopleNumber is the number of developers for which to collect the stats.
+	PeopleNumber int
+
+	// days maps days to the refactoring stats of the commits on that day.
+	days map[int]*RefactoringStats
+	// people maps the developer index to the refactoring stats of the commits.
+	people []RefactoringStats
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// RefactoringStats are the numbers of the detected refactorings and the changed UAST nodes.
+type RefactoringStats struct {
+	// ExtractedMethods is the number of new functions which took over the code of existing ones.
+	ExtractedMethods int
+	// RenamedMethods is the number of renamed functions.
+	RenamedMethods int
+	// RenamedClasses is the number of renamed classes (type declarations).
+	RenamedClasses int
+	// MovedClasses is the number of classes which moved to a different file.
+	MovedClasses int
+	// RefactoringNodes is the number of changed UAST nodes which belong to the refactorings.
+	RefactoringNodes int
+	// ChangedNodes is the total number of changed UAST nodes.
+	ChangedNodes int
+	// Commits is the number of commits which changed the UASTs.
+	Commits int
+	// RefactoringCommits is the number of commits with at least one refactoring.
+	RefactoringCommits int
+}
+
+// RefactoringResult is returned by RefactoringAnalysis.Finalize() and carries the refactoring
+// stats by day and by developer.
+type RefactoringResult struct {
+	// Days maps the day index to the refactoring stats of the commits on that day.
+	Days map[int]RefactoringStats
+	// People maps the developer index to the refactoring stats of the developer's commits.
+	// The developer index len(reversedPeopleDict) corresponds to the unmatched authors.
+	People []RefactoringStats
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// refactoringClass is a class declaration which appeared or disappeared in a file.
+type refactoringClass struct {
+	File string
+	// Nodes is the number of changed UAST nodes in the declaration.
+	Nodes int
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (ref *RefactoringAnalysis) Name() string {
+	return "Refactoring"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (ref *RefactoringAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (ref *RefactoringAnalysis) Requires() []string {
+	arr := [...]string{
+		uast_items.DependencyUastChanges, uast_items.DependencyUastEditScripts,
+		identity.DependencyAuthor, items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (ref *RefactoringAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (ref *RefactoringAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (ref *RefactoringAnalysis) Flag() string {
+	return "refactorings"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (ref *RefactoringAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		ref.PeopleNumber = val
+		ref.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (ref *RefactoringAnalysis) Initialize(repository *git.Repository) {
+	ref.days = map[int]*RefactoringStats{}
+	ref.people = make([]RefactoringStats, ref.PeopleNumber+1)
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (ref *RefactoringAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	scripts := deps[uast_items.DependencyUastEditScripts].([]uast_items.EditScript)
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing || author > ref.PeopleNumber {
+		author = ref.PeopleNumber
+	}
+	day := deps[items.DependencyDay].(int)
+	stats := DetectRefactorings(changes, scripts)
+	stats.Commits = 1
+	if stats.ExtractedMethods+stats.RenamedMethods+stats.RenamedClasses+stats.MovedClasses > 0 {
+		stats.RefactoringCommits = 1
+	}
+	dayStats := ref.days[day]
+	if dayStats == nil {
+		dayStats = &RefactoringStats{}
+		ref.days[day] = dayStats
+	}
+	dayStats.add(stats)
+	ref.people[author].add(stats)
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (ref *RefactoringAnalysis) Finalize() interface{} {
+	days := map[int]RefactoringStats{}
+	for day, stats := range ref.days {
+		days[day] = *stats
+	}
+	people := make([]RefactoringStats, len(ref.people))
+	copy(people, ref.people)
+	return RefactoringResult{
+		Days:               days,
+		People:             people,
+		reversedPeopleDict: ref.reversedPeopleDict,
+	}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (ref *RefactoringAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	refResult := result.(RefactoringResult)
+	if binary {
+		return ref.serializeBinary(&refResult, writer)
+	}
+	ref.serializeText(&refResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (ref *RefactoringAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(ref, result, &pb.RefactoringResults{}, writer)
+}
+
+func (ref *RefactoringAnalysis) serializeText(result *RefactoringResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  # extracted methods, renamed methods, renamed classes, moved classes, "+
+		"refactoring nodes, changed nodes, commits, refactoring commits")
+	fmt.Fprintln(writer, "  days:")
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	for _, day := range days {
+		fmt.Fprintf(writer, "    %d: %s\n", day, result.Days[day].format())
+	}
+	fmt.Fprintln(writer, "  people:")
+	for i, name := range result.reversedPeopleDict {
+		if i >= len(result.People) {
+			break
+		}
+		fmt.Fprintf(writer, "    %s: %s\n", yaml.SafeString(name), result.People[i].format())
+	}
+}
+
+func (ref *RefactoringAnalysis) serializeBinary(result *RefactoringResult, writer io.Writer) error {
+	message := pb.RefactoringResults{
+		Days:           map[int32]*pb.RefactoringStats{},
+		People:         make([]*pb.RefactoringStats, len(result.People)),
+		PeopleSequence: result.reversedPeopleDict,
+	}
+	for day, stats := range result.Days {
+		message.Days[int32(day)] = stats.toProtobuf()
+	}
+	for i, stats := range result.People {
+		message.People[i] = stats.toProtobuf()
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func (stats *RefactoringStats) add(other RefactoringStats) {
+	stats.ExtractedMethods += other.ExtractedMethods
+	stats.RenamedMethods += other.RenamedMethods
+	stats.RenamedClasses += other.RenamedClasses
+	stats.MovedClasses += other.MovedClasses
+	stats.RefactoringNodes += other.RefactoringNodes
+	stats.ChangedNodes += other.ChangedNodes
+	stats.Commits += other.Commits
+	stats.RefactoringCommits += other.RefactoringCommits
+}
+
+func (stats RefactoringStats) format() string {
+	return fmt.Sprintf("[%d, %d, %d, %d, %d, %d, %d, %d]",
+		stats.ExtractedMethods, stats.RenamedMethods, stats.RenamedClasses, stats.MovedClasses,
+		stats.RefactoringNodes, stats.ChangedNodes, stats.Commits, stats.RefactoringCommits)
+}
+
+func (stats RefactoringStats) toProtobuf() *pb.RefactoringStats {
+	return &pb.RefactoringStats{
+		ExtractedMethods:   int32(stats.ExtractedMethods),
+		RenamedMethods:     int32(stats.RenamedMethods),
+		RenamedClasses:     int32(stats.RenamedClasses),
+		MovedClasses:       int32(stats.MovedClasses),
+		RefactoringNodes:   int32(stats.RefactoringNodes),
+		ChangedNodes:       int32(stats.ChangedNodes),
+		Commits:            int32(stats.Commits),
+		RefactoringCommits: int32(stats.RefactoringCommits),
+	}
+}
+
+// DetectRefactorings finds the refactorings in the UAST changes of a single commit.
+// scripts are the edit scripts of the modified files as provided by uast.SemanticDiff.
+// Commits and RefactoringCommits are not set.
+func DetectRefactorings(
+	changes []uast_items.Change, scripts []uast_items.EditScript) RefactoringStats {
+	stats := RefactoringStats{}
+	removed := map[string][]refactoringClass{}
+	added := map[string][]refactoringClass{}
+	collectClasses := func(root *uast.Node, file string, changed func(*uast.Node) bool,
+		skip map[*uast.Node]bool, classes map[string][]refactoringClass) {
+		uast_items.VisitEachNode(root, func(node *uast.Node) {
+			if !isClassDeclaration(node) || skip[node] {
+				return
+			}
+			name := declarationName(node)
+			if name == "" {
+				return
+			}
+			classes[name] = append(classes[name], refactoringClass{
+				File: file, Nodes: countRefactoringNodes(node, changed)})
+		})
+	}
+	always := func(*uast.Node) bool { return true }
+	for _, change := range changes {
+		if change.Before != nil && change.After == nil {
+			stats.ChangedNodes += countRefactoringNodes(change.Before, always)
+			collectClasses(change.Before, change.Change.From.Name, always, nil, removed)
+		} else if change.Before == nil && change.After != nil {
+			stats.ChangedNodes += countRefactoringNodes(change.After, always)
+			collectClasses(change.After, change.Change.To.Name, always, nil, added)
+		}
+	}
+	roots := map[*object.Change]uast_items.Change{}
+	for _, change := range changes {
+		roots[change.Change] = change
+	}
+	for _, script := range scripts {
+		change, exists := roots[script.Change]
+		if !exists || change.Before == nil || change.After == nil {
+			continue
+		}
+		stats.ChangedNodes += len(script.Edits)
+		mappedBefore := make(map[*uast.Node]bool, len(script.Mapping))
+		mappedAfter := make(map[*uast.Node]bool, len(script.Mapping))
+		for before, after := range script.Mapping {
+			mappedBefore[before] = true
+			mappedAfter[after] = true
+		}
+		editedBefore := map[*uast.Node]bool{}
+		editedAfter := map[*uast.Node]bool{}
+		for _, edit := range script.Edits {
+			if edit.Action == uast_items.EditInsert {
+				editedAfter[edit.After] = true
+			} else if edit.Action == uast_items.EditDelete {
+				editedBefore[edit.Before] = true
+			}
+		}
+		collectClasses(change.Before, change.Change.From.Name,
+			func(node *uast.Node) bool { return editedBefore[node] }, mappedBefore, removed)
+		collectClasses(change.After, change.Change.To.Name,
+			func(node *uast.Node) bool { return editedAfter[node] }, mappedAfter, added)
+		detectRenames(change, script, mappedAfter, &stats)
+		detectExtractedMethods(change, script, mappedAfter, &stats)
+	}
+	for name, sources := range removed {
+		targets := added[name]
+		for i := 0; i < len(sources) && i < len(targets); i++ {
+			if sources[i].File == targets[i].File {
+				continue
+			}
+			stats.MovedClasses++
+			stats.RefactoringNodes += sources[i].Nodes + targets[i].Nodes
+		}
+	}
+	return stats
+}
+
+// detectRenames counts the mapped function and class declarations which changed their names.
+// The classes in the renamed files are counted as moved. If a new declaration has the old name,
+// the mapping followed the bigger part of an extracted function and the update belongs to
+// the extraction.
+func detectRenames(change uast_items.Change, script uast_items.EditScript,
+	mappedAfter map[*uast.Node]bool, stats *RefactoringStats) {
+	declared := map[string]bool{}
+	uast_items.VisitEachNode(change.After, func(node *uast.Node) {
+		if !mappedAfter[node] && (isFunctionDeclaration(node) || isClassDeclaration(node)) {
+			declared[declarationName(node)] = true
+		}
+	})
+	for before, after := range script.Mapping {
+		isClass := isClassDeclaration(before)
+		if isClass != isClassDeclaration(after) {
+			continue
+		}
+		if !isClass && (!isFunctionDeclaration(before) || !isFunctionDeclaration(after)) {
+			continue
+		}
+		oldName, newName := declarationName(before), declarationName(after)
+		if oldName == "" || newName == "" {
+			continue
+		}
+		if isClass && change.Change.From.Name != change.Change.To.Name {
+			stats.MovedClasses++
+		}
+		if oldName == newName {
+			continue
+		}
+		// the update of the name
+		stats.RefactoringNodes++
+		if declared[oldName] {
+			continue
+		}
+		if isClass {
+			stats.RenamedClasses++
+		} else {
+			stats.RenamedMethods++
+		}
+	}
+}
+
+// detectExtractedMethods counts the new function declarations which received the nodes moved
+// from the functions which still exist. Those moved nodes and the rest of the new functions
+// are the refactoring work.
+func detectExtractedMethods(change uast_items.Change, script uast_items.EditScript,
+	mappedAfter map[*uast.Node]bool, stats *RefactoringStats) {
+	var parents map[*uast.Node]*uast.Node
+	uast_items.VisitEachNode(change.After, func(function *uast.Node) {
+		if !isFunctionDeclaration(function) || mappedAfter[function] {
+			return
+		}
+		subtree := map[*uast.Node]bool{}
+		uast_items.VisitEachNode(function, func(node *uast.Node) {
+			subtree[node] = true
+		})
+		extracted, nodes := false, 0
+		for _, edit := range script.Edits {
+			if edit.After == nil || !subtree[edit.After] {
+				continue
+			}
+			nodes++
+			if edit.Action != uast_items.EditMove || extracted {
+				continue
+			}
+			if parents == nil {
+				parents = refactoringParents(change.Before)
+			}
+			source := parents[edit.Before]
+			for source != nil && !isFunctionDeclaration(source) {
+				source = parents[source]
+			}
+			if source != nil {
+				_, extracted = script.Mapping[source]
+			}
+		}
+		if extracted {
+			stats.ExtractedMethods++
+			stats.RefactoringNodes += nodes
+		}
+	})
+}
+
+// refactoringParents maps each node in the UAST to its parent.
+func refactoringParents(root *uast.Node) map[*uast.Node]*uast.Node {
+	parents := map[*uast.Node]*uast.Node{}
+	uast_items.VisitEachNode(root, func(node *uast.Node) {
+		for _, child := range node.Children {
+			parents[child] = node
+		}
+	})
+	return parents
+}
+
+// countRefactoringNodes returns the number of nodes in the subtree which satisfy the predicate.
+func countRefactoringNodes(root *uast.Node, changed func(*uast.Node) bool) int {
+	count := 0
+	uast_items.VisitEachNode(root, func(node *uast.Node) {
+		if changed(node) {
+			count++
+		}
+	})
+	return count
+}
+
+// isFunctionDeclaration checks whether the node declares a named function or method.
+// The identifiers of the declarations often carry the same roles and are excluded.
+func isFunctionDeclaration(node *uast.Node) bool {
+	return hasRole(node, uast.Function) && hasRole(node, uast.Declaration) &&
+		!hasRole(node, uast.Identifier) && !hasRole(node, uast.Anonymous)
+}
+
+// isClassDeclaration checks whether the node declares a class or another named type.
+func isClassDeclaration(node *uast.Node) bool {
+	return hasRole(node, uast.Type) && hasRole(node, uast.Declaration) &&
+		!hasRole(node, uast.Identifier)
+}
+
+// declarationName returns the name of the declared function or class, either the own token
+// or the token of the first child identifier.
+func declarationName(node *uast.Node) string {
+	if node.Token != "" {
+		return node.Token
+	}
+	for _, child := range node.Children {
+		if hasRole(child, uast.Identifier) && child.Token != "" {
+			return child.Token
+		}
+	}
+	return ""
+}
+
+func init() {
+	core.Registry.Register(&RefactoringAnalysis{})
+}
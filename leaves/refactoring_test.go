@@ -0,0 +1,248 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureRefactoring() *RefactoringAnalysis {
+	ref := RefactoringAnalysis{}
+	ref.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorPeopleCount:        2,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"one", "two"},
+	})
+	ref.Initialize(test.Repository)
+	return &ref
+}
+
+// fixtureRefactoringFunction builds the declaration of the function with the statements.
+func fixtureRefactoringFunction(name string, statements ...*uast.Node) *uast.Node {
+	return &uast.Node{InternalType: "FuncDecl",
+		Roles: []uast.Role{uast.Function, uast.Declaration}, Children: []*uast.Node{
+			{InternalType: "Ident", Token: name, Roles: []uast.Role{
+				uast.Function, uast.Declaration, uast.Name, uast.Identifier}},
+			{InternalType: "BlockStmt", Roles: []uast.Role{uast.Body}, Children: statements},
+		}}
+}
+
+// fixtureRefactoringClass builds the declaration of the class with the methods.
+func fixtureRefactoringClass(name string, methods ...*uast.Node) *uast.Node {
+	return &uast.Node{InternalType: "ClassDecl",
+		Roles: []uast.Role{uast.Type, uast.Declaration}, Children: append([]*uast.Node{
+			{InternalType: "Ident", Token: name, Roles: []uast.Role{
+				uast.Type, uast.Declaration, uast.Identifier}},
+		}, methods...)}
+}
+
+// fixtureRefactoringCall builds the statement which calls the function with the argument.
+func fixtureRefactoringCall(function string, argument string) *uast.Node {
+	return &uast.Node{InternalType: "ExprStmt", Children: []*uast.Node{
+		{InternalType: "CallExpr", Roles: []uast.Role{uast.Call}, Children: []*uast.Node{
+			{InternalType: "Ident", Token: function, Roles: []uast.Role{uast.Identifier}},
+			{InternalType: "BasicLit", Token: argument, Roles: []uast.Role{uast.Literal}},
+		}}}}
+}
+
+func fixtureRefactoringFile(declarations ...*uast.Node) *uast.Node {
+	return &uast.Node{InternalType: "File", Roles: []uast.Role{uast.File}, Children: declarations}
+}
+
+// fixtureRefactoringChanges diffs the modified files like uast.SemanticDiff does.
+func fixtureRefactoringChanges(changes ...uast_items.Change) map[string]interface{} {
+	diff := &uast_items.SemanticDiff{}
+	diff.Initialize(test.Repository)
+	result, err := diff.Consume(map[string]interface{}{uast_items.DependencyUastChanges: changes})
+	if err != nil {
+		panic(err)
+	}
+	return map[string]interface{}{
+		uast_items.DependencyUastChanges:     changes,
+		uast_items.DependencyUastEditScripts: result[uast_items.DependencyUastEditScripts],
+		identity.DependencyAuthor:            0,
+		items.DependencyDay:                  0,
+	}
+}
+
+func fixtureRefactoringChange(from, to string, before, after *uast.Node) uast_items.Change {
+	return uast_items.Change{Before: before, After: after, Change: &object.Change{
+		From: object.ChangeEntry{Name: from}, To: object.ChangeEntry{Name: to}}}
+}
+
+func TestRefactoringMeta(t *testing.T) {
+	ref := fixtureRefactoring()
+	assert.Equal(t, ref.Name(), "Refactoring")
+	assert.Len(t, ref.Provides(), 0)
+	assert.Equal(t, ref.Requires(), []string{
+		uast_items.DependencyUastChanges, uast_items.DependencyUastEditScripts,
+		identity.DependencyAuthor, items.DependencyDay})
+	assert.Equal(t, ref.Features(), []string{uast_items.FeatureUast})
+	assert.Len(t, ref.ListConfigurationOptions(), 0)
+	assert.Equal(t, ref.Flag(), "refactorings")
+	assert.Equal(t, ref.PeopleNumber, 2)
+	assert.Equal(t, ref.reversedPeopleDict, []string{"one", "two"})
+	assert.Len(t, ref.people, 3)
+}
+
+func TestRefactoringRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&RefactoringAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Refactoring")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&RefactoringAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestDetectRefactoringsRename(t *testing.T) {
+	before := fixtureRefactoringFile(fixtureRefactoringClass("Parser",
+		fixtureRefactoringFunction("parse",
+			fixtureRefactoringCall("read", "1"), fixtureRefactoringCall("check", "2"))))
+	after := fixtureRefactoringFile(fixtureRefactoringClass("Reader",
+		fixtureRefactoringFunction("read",
+			fixtureRefactoringCall("read", "1"), fixtureRefactoringCall("check", "2"))))
+	deps := fixtureRefactoringChanges(fixtureRefactoringChange("a.go", "a.go", before, after))
+	stats := DetectRefactorings(deps[uast_items.DependencyUastChanges].([]uast_items.Change),
+		deps[uast_items.DependencyUastEditScripts].([]uast_items.EditScript))
+	assert.Equal(t, stats, RefactoringStats{
+		RenamedMethods: 1, RenamedClasses: 1, RefactoringNodes: 2, ChangedNodes: 2})
+}
+
+func TestDetectRefactoringsExtractMethod(t *testing.T) {
+	before := fixtureRefactoringFile(fixtureRefactoringFunction("main",
+		fixtureRefactoringCall("open", "1"), fixtureRefactoringCall("read", "2"),
+		fixtureRefactoringCall("close", "3")))
+	after := fixtureRefactoringFile(
+		fixtureRefactoringFunction("main",
+			fixtureRefactoringCall("open", "1"), fixtureRefactoringCall("process", "0")),
+		fixtureRefactoringFunction("process",
+			fixtureRefactoringCall("read", "2"), fixtureRefactoringCall("close", "3")))
+	deps := fixtureRefactoringChanges(fixtureRefactoringChange("a.go", "a.go", before, after))
+	stats := DetectRefactorings(deps[uast_items.DependencyUastChanges].([]uast_items.Change),
+		deps[uast_items.DependencyUastEditScripts].([]uast_items.EditScript))
+	assert.Equal(t, stats.ExtractedMethods, 1)
+	assert.Equal(t, stats.RenamedMethods, 0)
+	// the call of the extracted function is a part of the refactoring
+	assert.Equal(t, stats.RefactoringNodes, stats.ChangedNodes)
+	// a brand new function is not extracted
+	after = fixtureRefactoringFile(
+		fixtureRefactoringFunction("main", fixtureRefactoringCall("open", "1"),
+			fixtureRefactoringCall("read", "2"), fixtureRefactoringCall("close", "3")),
+		fixtureRefactoringFunction("process", fixtureRefactoringCall("write", "4")))
+	deps = fixtureRefactoringChanges(fixtureRefactoringChange("a.go", "a.go", before, after))
+	stats = DetectRefactorings(deps[uast_items.DependencyUastChanges].([]uast_items.Change),
+		deps[uast_items.DependencyUastEditScripts].([]uast_items.EditScript))
+	assert.Equal(t, stats.ExtractedMethods, 0)
+	assert.Equal(t, stats.RefactoringNodes, 0)
+	assert.Equal(t, stats.ChangedNodes, 7)
+}
+
+func TestDetectRefactoringsMoveClass(t *testing.T) {
+	class := func() *uast.Node {
+		return fixtureRefactoringClass("Parser", fixtureRefactoringFunction("parse",
+			fixtureRefactoringCall("read", "1"), fixtureRefactoringCall("check", "2")))
+	}
+	other := func() *uast.Node {
+		return fixtureRefactoringFunction("main", fixtureRefactoringCall("run", "0"))
+	}
+	deps := fixtureRefactoringChanges(
+		fixtureRefactoringChange("a.go", "a.go",
+			fixtureRefactoringFile(class(), other()), fixtureRefactoringFile(other())),
+		fixtureRefactoringChange("", "b.go", nil, fixtureRefactoringFile(class())))
+	stats := DetectRefactorings(deps[uast_items.DependencyUastChanges].([]uast_items.Change),
+		deps[uast_items.DependencyUastEditScripts].([]uast_items.EditScript))
+	assert.Equal(t, stats, RefactoringStats{
+		MovedClasses: 1, RefactoringNodes: 26, ChangedNodes: 27})
+	// renamed file
+	deps = fixtureRefactoringChanges(fixtureRefactoringChange("a.go", "c.go",
+		fixtureRefactoringFile(class()), fixtureRefactoringFile(class())))
+	stats = DetectRefactorings(deps[uast_items.DependencyUastChanges].([]uast_items.Change),
+		deps[uast_items.DependencyUastEditScripts].([]uast_items.EditScript))
+	assert.Equal(t, stats, RefactoringStats{MovedClasses: 1})
+	// deleted class
+	deps = fixtureRefactoringChanges(
+		fixtureRefactoringChange("a.go", "", fixtureRefactoringFile(class()), nil))
+	stats = DetectRefactorings(deps[uast_items.DependencyUastChanges].([]uast_items.Change),
+		deps[uast_items.DependencyUastEditScripts].([]uast_items.EditScript))
+	assert.Equal(t, stats, RefactoringStats{ChangedNodes: 14})
+}
+
+func TestRefactoringConsumeFinalize(t *testing.T) {
+	ref := fixtureRefactoring()
+	before := fixtureRefactoringFile(fixtureRefactoringFunction("parse",
+		fixtureRefactoringCall("read", "1"), fixtureRefactoringCall("check", "2")))
+	after := fixtureRefactoringFile(fixtureRefactoringFunction("load",
+		fixtureRefactoringCall("read", "1"), fixtureRefactoringCall("check", "2")))
+	deps := fixtureRefactoringChanges(fixtureRefactoringChange("a.go", "a.go", before, after))
+	result, err := ref.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps = fixtureRefactoringChanges(fixtureRefactoringChange("", "b.go", nil, before))
+	deps[identity.DependencyAuthor] = identity.AuthorMissing
+	deps[items.DependencyDay] = 3
+	result, err = ref.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps = fixtureRefactoringChanges()
+	deps[items.DependencyDay] = 5
+	ref.Consume(deps)
+	res := ref.Finalize().(RefactoringResult)
+	assert.Len(t, res.Days, 2)
+	assert.Equal(t, res.Days[0], RefactoringStats{RenamedMethods: 1, RefactoringNodes: 1,
+		ChangedNodes: 1, Commits: 1, RefactoringCommits: 1})
+	assert.Equal(t, res.Days[3], RefactoringStats{ChangedNodes: 12, Commits: 1})
+	assert.Equal(t, res.People, []RefactoringStats{res.Days[0], {}, res.Days[3]})
+	assert.Equal(t, res.reversedPeopleDict, ref.reversedPeopleDict)
+}
+
+func TestRefactoringSerialize(t *testing.T) {
+	ref := fixtureRefactoring()
+	res := RefactoringResult{
+		Days: map[int]RefactoringStats{
+			7: {MovedClasses: 1, RefactoringNodes: 10, ChangedNodes: 20, Commits: 2,
+				RefactoringCommits: 1},
+			2: {ExtractedMethods: 1, RenamedMethods: 2, RenamedClasses: 3, RefactoringNodes: 8,
+				ChangedNodes: 9, Commits: 1, RefactoringCommits: 1},
+		},
+		People:             []RefactoringStats{{ChangedNodes: 20, Commits: 2}, {}, {Commits: 1}},
+		reversedPeopleDict: []string{"one", "two"},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, ref.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  # extracted methods, renamed methods, renamed classes, moved classes, refactoring nodes, changed nodes, commits, refactoring commits
+  days:
+    2: [1, 2, 3, 0, 8, 9, 1, 1]
+    7: [0, 0, 0, 1, 10, 20, 2, 1]
+  people:
+    "one": [0, 0, 0, 0, 0, 20, 2, 0]
+    "two": [0, 0, 0, 0, 0, 0, 0, 0]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, ref.Serialize(res, true, buffer))
+	msg := pb.RefactoringResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Days, 2)
+	assert.Equal(t, *msg.Days[2], pb.RefactoringStats{ExtractedMethods: 1, RenamedMethods: 2,
+		RenamedClasses: 3, RefactoringNodes: 8, ChangedNodes: 9, Commits: 1,
+		RefactoringCommits: 1})
+	assert.Equal(t, msg.Days[7].MovedClasses, int32(1))
+	assert.Len(t, msg.People, 3)
+	assert.Equal(t, msg.People[0].ChangedNodes, int32(20))
+	assert.Equal(t, msg.People[2].Commits, int32(1))
+	assert.Equal(t, msg.PeopleSequence, []string{"one", "two"})
+}
@@ -0,0 +1,340 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+// ReleasePressureAnalysis measures how the churn, the commit size and the revert rate change
+// in the days immediately before the releases, that is, the tags. The commits are bucketed
+// by the number of days left till the next release; the commits which are further than
+// Window days from the next release or after the last release form the baseline.
+// The commit date is the committer date, both for the analysed commits and for the tags.
+// It is a LeafPipelineItem.
+type ReleasePressureAnalysis struct {
+	// Window is the number of days before each release to analyse.
+	Window int
+	// TagRegexp selects the tags which are releases. Empty means all the tags.
+	TagRegexp string
+
+	// releases are the sorted dates of the releases.
+	releases []time.Time
+	// days are the stats per each number of days before the release.
+	days []ReleasePressureStats
+	// baseline are the stats of the commits outside of the windows.
+	baseline ReleasePressureStats
+}
+
+// ReleasePressureStats are the aggregated stats of the commits in a bucket.
+type ReleasePressureStats struct {
+	// Commits is the number of commits.
+	Commits int
+	// LineStats are the line stats summed over all the commits.
+	LineStats
+	// Files is the number of changed files summed over the commits.
+	Files int
+	// Reverts is the number of commits which revert other commits.
+	Reverts int
+}
+
+// Size returns the average number of changed lines per commit.
+func (stats ReleasePressureStats) Size() float64 {
+	if stats.Commits == 0 {
+		return 0
+	}
+	return float64(stats.Added+stats.Removed+stats.Changed) / float64(stats.Commits)
+}
+
+// RevertRate returns the fraction of the commits which revert other commits.
+func (stats ReleasePressureStats) RevertRate() float64 {
+	if stats.Commits == 0 {
+		return 0
+	}
+	return float64(stats.Reverts) / float64(stats.Commits)
+}
+
+// ReleasePressureResult is returned by ReleasePressureAnalysis.Finalize() and carries
+// the commit stats before the releases.
+type ReleasePressureResult struct {
+	// Releases is the number of releases.
+	Releases int
+	// Days are the stats per each number of days before the release: the first element
+	// is the release day, the second is the day before, etc.
+	Days []ReleasePressureStats
+	// Baseline are the stats of the rest of the commits.
+	Baseline ReleasePressureStats
+}
+
+const (
+	// ConfigReleasePressureWindow is the name of the option to set ReleasePressureAnalysis.Window.
+	ConfigReleasePressureWindow = "ReleasePressure.Window"
+	// ConfigReleasePressureTagRegexp is the name of the option to set
+	// ReleasePressureAnalysis.TagRegexp.
+	ConfigReleasePressureTagRegexp = "ReleasePressure.TagRegexp"
+	// DefaultReleasePressureWindow is the default value of ReleasePressureAnalysis.Window.
+	DefaultReleasePressureWindow = 14
+)
+
+var revertRE = regexp.MustCompile(`(?m)(\ARevert "|^This reverts commit [0-9a-f]{7,40})`)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (pressure *ReleasePressureAnalysis) Name() string {
+	return "ReleasePressure"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (pressure *ReleasePressureAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (pressure *ReleasePressureAnalysis) Requires() []string {
+	arr := [...]string{
+		items.DependencyTreeChanges, items.DependencyFileDiff, items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (pressure *ReleasePressureAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigReleasePressureWindow,
+		Description: "Number of days before each release to analyse.",
+		Flag:        "release-pressure-window",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultReleasePressureWindow}, {
+		Name:        ConfigReleasePressureTagRegexp,
+		Description: "Regular expression which the release tag names must match; all the tags by default.",
+		Flag:        "release-tags",
+		Type:        core.StringConfigurationOption,
+		Default:     ""},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (pressure *ReleasePressureAnalysis) Flag() string {
+	return "release-pressure"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (pressure *ReleasePressureAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigReleasePressureWindow].(int); exists {
+		pressure.Window = val
+	}
+	if val, exists := facts[ConfigReleasePressureTagRegexp].(string); exists {
+		pressure.TagRegexp = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (pressure *ReleasePressureAnalysis) Initialize(repository *git.Repository) {
+	if pressure.Window <= 0 {
+		log.Printf("Warning: adjusted the release window to %d days\n", DefaultReleasePressureWindow)
+		pressure.Window = DefaultReleasePressureWindow
+	}
+	var pattern *regexp.Regexp
+	if pressure.TagRegexp != "" {
+		var err error
+		pattern, err = regexp.Compile(pressure.TagRegexp)
+		if err != nil {
+			log.Printf("Warning: ignored the release tags filter: %v\n", err)
+			pattern = nil
+		}
+	}
+	releases, err := loadReleases(repository, pattern)
+	if err != nil {
+		log.Printf("Warning: failed to read the tags: %v\n", err)
+	}
+	pressure.releases = releases
+	pressure.days = make([]ReleasePressureStats, pressure.Window)
+	pressure.baseline = ReleasePressureStats{}
+}
+
+// loadReleases returns the sorted committer dates of the commits which are tagged.
+func loadReleases(repository *git.Repository, pattern *regexp.Regexp) ([]time.Time, error) {
+	releases := []time.Time{}
+	err := forEachTaggedCommit(repository, func(name string, commit *object.Commit) {
+		if pattern == nil || pattern.MatchString(name) {
+			releases = append(releases, commit.Committer.When)
+		}
+	})
+	sort.Slice(releases, func(i, j int) bool { return releases[i].Before(releases[j]) })
+	return releases, err
+}
+
+// forEachTaggedCommit calls `callback` with the short name and the commit of each tag,
+// both annotated and lightweight. The tags which do not point to commits are ignored.
+func forEachTaggedCommit(
+	repository *git.Repository, callback func(name string, commit *object.Commit)) error {
+	tags, err := repository.Tags()
+	if err != nil {
+		return err
+	}
+	return tags.ForEach(func(ref *plumbing.Reference) error {
+		var commit *object.Commit
+		if tag, err := repository.TagObject(ref.Hash()); err == nil {
+			// annotated tag
+			commit, err = tag.Commit()
+			if err != nil {
+				// the tag does not point to a commit
+				return nil
+			}
+		} else if commit, err = repository.CommitObject(ref.Hash()); err != nil {
+			return nil
+		}
+		callback(ref.Name().Short(), commit)
+		return nil
+	})
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (pressure *ReleasePressureAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	if commit.NumParents() > 1 {
+		// merge commits duplicate the changes
+		return nil, nil
+	}
+	stats := &pressure.baseline
+	if days := pressure.daysBeforeRelease(commit.Committer.When); days >= 0 && days < pressure.Window {
+		stats = &pressure.days[days]
+	}
+	stats.Commits++
+	if revertRE.MatchString(commit.Message) {
+		stats.Reverts++
+	}
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	stats.Files += len(treeDiff)
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		var lines LineStats
+		switch action {
+		case merkletrie.Insert:
+			lines.Added, err = items.CountLines(cache[change.To.TreeEntry.Hash])
+		case merkletrie.Delete:
+			lines.Removed, err = items.CountLines(cache[change.From.TreeEntry.Hash])
+		case merkletrie.Modify:
+			lines = diffLineStats(fileDiffs[change.To.Name].Diffs)
+		}
+		if err != nil {
+			if err.Error() == "binary" {
+				continue
+			}
+			return nil, err
+		}
+		stats.LineStats.add(lines)
+	}
+	return nil, nil
+}
+
+// daysBeforeRelease returns the number of whole days between the specified date and
+// the next release, or -1 if there are no releases after it.
+func (pressure *ReleasePressureAnalysis) daysBeforeRelease(when time.Time) int {
+	index := sort.Search(len(pressure.releases), func(i int) bool {
+		return !pressure.releases[i].Before(when)
+	})
+	if index == len(pressure.releases) {
+		return -1
+	}
+	return int(pressure.releases[index].Sub(when).Hours() / 24)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (pressure *ReleasePressureAnalysis) Finalize() interface{} {
+	return ReleasePressureResult{
+		Releases: len(pressure.releases),
+		Days:     pressure.days,
+		Baseline: pressure.baseline,
+	}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (pressure *ReleasePressureAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	pressureResult := result.(ReleasePressureResult)
+	if binary {
+		return pressure.serializeBinary(&pressureResult, writer)
+	}
+	pressure.serializeText(&pressureResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (pressure *ReleasePressureAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(pressure, result, &pb.ReleasePressureResults{}, writer)
+}
+
+func (pressure *ReleasePressureAnalysis) serializeText(result *ReleasePressureResult, writer io.Writer) {
+	formatStats := func(stats ReleasePressureStats) string {
+		return fmt.Sprintf(
+			"{commits: %d, lines: [%d, %d, %d], files: %d, reverts: %d, size: %.2f, revert_rate: %.4f}",
+			stats.Commits, stats.Added, stats.Removed, stats.Changed, stats.Files, stats.Reverts,
+			stats.Size(), stats.RevertRate())
+	}
+	fmt.Fprintln(writer, "  releases:", result.Releases)
+	fmt.Fprintln(writer, "  # days before the release; the line stats are [added, removed, changed]")
+	fmt.Fprintln(writer, "  days:")
+	for day, stats := range result.Days {
+		fmt.Fprintf(writer, "    %d: %s\n", day, formatStats(stats))
+	}
+	fmt.Fprintln(writer, "  baseline:", formatStats(result.Baseline))
+}
+
+func (pressure *ReleasePressureAnalysis) serializeBinary(result *ReleasePressureResult, writer io.Writer) error {
+	convertStats := func(stats ReleasePressureStats) *pb.ReleasePressureStats {
+		return &pb.ReleasePressureStats{
+			Commits: int32(stats.Commits),
+			Lines: &pb.LineStats{
+				Added:   int32(stats.Added),
+				Removed: int32(stats.Removed),
+				Changed: int32(stats.Changed),
+			},
+			Files:   int32(stats.Files),
+			Reverts: int32(stats.Reverts),
+		}
+	}
+	message := pb.ReleasePressureResults{
+		Releases: int32(result.Releases),
+		Days:     make([]*pb.ReleasePressureStats, len(result.Days)),
+		Baseline: convertStats(result.Baseline),
+	}
+	for i, stats := range result.Days {
+		message.Days[i] = convertStats(stats)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&ReleasePressureAnalysis{})
+}
@@ -0,0 +1,228 @@
+package leaves
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-billy.v4/memfs"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func fixtureReleasePressureDate(day int) time.Time {
+	return time.Date(2018, 1, 1+day, 12, 0, 0, 0, time.UTC)
+}
+
+// fixtureReleasePressureRepository creates an in-memory repository with a lightweight tag
+// "v1.0.0" on day 10, an annotated tag "v2.0.0" on day 40 and a lightweight tag "nightly"
+// on day 50.
+func fixtureReleasePressureRepository() *git.Repository {
+	repository, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		panic(err)
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		panic(err)
+	}
+	commit := func(day int) plumbing.Hash {
+		file, err := worktree.Filesystem.Create("README")
+		if err != nil {
+			panic(err)
+		}
+		file.Write([]byte(fixtureReleasePressureDate(day).String()))
+		file.Close()
+		worktree.Add("README")
+		hash, err := worktree.Commit("Release", &git.CommitOptions{Author: &object.Signature{
+			Name: "Vadim", Email: "vadim@sourced.tech", When: fixtureReleasePressureDate(day)}})
+		if err != nil {
+			panic(err)
+		}
+		return hash
+	}
+	tag := func(name string, hash plumbing.Hash) {
+		err := repository.Storer.SetReference(
+			plumbing.NewHashReference(plumbing.ReferenceName("refs/tags/"+name), hash))
+		if err != nil {
+			panic(err)
+		}
+	}
+	tag("v1.0.0", commit(10))
+	_, err = repository.CreateTag("v2.0.0", commit(40), &git.CreateTagOptions{
+		Tagger: &object.Signature{
+			Name: "Vadim", Email: "vadim@sourced.tech", When: fixtureReleasePressureDate(41)},
+		Message: "v2.0.0"})
+	if err != nil {
+		panic(err)
+	}
+	tag("nightly", commit(50))
+	return repository
+}
+
+func fixtureReleasePressure() *ReleasePressureAnalysis {
+	pressure := ReleasePressureAnalysis{Window: 14, TagRegexp: "^v"}
+	pressure.Initialize(fixtureReleasePressureRepository())
+	return &pressure
+}
+
+func fixtureReleasePressureDeps(day int, hours int, message string) map[string]interface{} {
+	blob := fixtureChurnOriginBlob("1\n2\n3\n")
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: blob.Hash}}
+	}
+	deps := map[string]interface{}{}
+	deps["commit"] = &object.Commit{
+		Committer: object.Signature{
+			When: fixtureReleasePressureDate(day).Add(time.Duration(hours) * time.Hour)},
+		Message: message,
+	}
+	deps[items.DependencyBlobCache] = map[plumbing.Hash]*object.Blob{blob.Hash: blob}
+	deps[items.DependencyTreeChanges] = object.Changes{
+		{To: entry("new.go")},
+		{From: entry("main.go"), To: entry("main.go")},
+	}
+	deps[items.DependencyFileDiff] = map[string]items.FileDiffData{
+		"main.go": {Diffs: []diffmatchpatch.Diff{
+			{Type: diffmatchpatch.DiffEqual, Text: "a"},
+			{Type: diffmatchpatch.DiffDelete, Text: "b"},
+			{Type: diffmatchpatch.DiffInsert, Text: "cd"},
+		}},
+	}
+	return deps
+}
+
+func TestReleasePressureMeta(t *testing.T) {
+	pressure := fixtureReleasePressure()
+	assert.Equal(t, pressure.Name(), "ReleasePressure")
+	assert.Len(t, pressure.Provides(), 0)
+	assert.Equal(t, pressure.Requires(), []string{
+		items.DependencyTreeChanges, items.DependencyFileDiff, items.DependencyBlobCache})
+	opts := pressure.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Name, ConfigReleasePressureWindow)
+	assert.Equal(t, opts[1].Name, ConfigReleasePressureTagRegexp)
+	assert.Equal(t, pressure.Flag(), "release-pressure")
+	facts := map[string]interface{}{}
+	facts[ConfigReleasePressureWindow] = 7
+	facts[ConfigReleasePressureTagRegexp] = `^release-\d+`
+	pressure.Configure(facts)
+	assert.Equal(t, pressure.Window, 7)
+	assert.Equal(t, pressure.TagRegexp, `^release-\d+`)
+}
+
+func TestReleasePressureRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&ReleasePressureAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "ReleasePressure")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&ReleasePressureAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestReleasePressureInitialize(t *testing.T) {
+	pressure := ReleasePressureAnalysis{TagRegexp: "("}
+	pressure.Initialize(fixtureReleasePressureRepository())
+	assert.Equal(t, pressure.Window, DefaultReleasePressureWindow)
+	assert.Len(t, pressure.days, DefaultReleasePressureWindow)
+	assert.Len(t, pressure.releases, 3)
+	pressure = *fixtureReleasePressure()
+	assert.Len(t, pressure.releases, 2)
+	assert.True(t, pressure.releases[0].Equal(fixtureReleasePressureDate(10)))
+	assert.True(t, pressure.releases[1].Equal(fixtureReleasePressureDate(40)))
+}
+
+func TestLoadReleases(t *testing.T) {
+	repository := fixtureReleasePressureRepository()
+	releases, err := loadReleases(repository, nil)
+	assert.Nil(t, err)
+	assert.Len(t, releases, 3)
+	for i, day := range []int{10, 40, 50} {
+		assert.True(t, releases[i].Equal(fixtureReleasePressureDate(day)))
+	}
+	releases, err = loadReleases(repository, regexp.MustCompile("^night"))
+	assert.Nil(t, err)
+	assert.Len(t, releases, 1)
+}
+
+func TestReleasePressureConsumeFinalize(t *testing.T) {
+	pressure := fixtureReleasePressure()
+	// the release day
+	result, err := pressure.Consume(fixtureReleasePressureDeps(10, -1, "Fix the release"))
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	pressure.Consume(fixtureReleasePressureDeps(10, 0, "Bump the version"))
+	// one day before the release
+	pressure.Consume(fixtureReleasePressureDeps(9, -1,
+		"Revert \"Add the feature\"\n\nThis reverts commit 0123456789abcdef.\n"))
+	// outside of the window
+	pressure.Consume(fixtureReleasePressureDeps(20, 0, "Add the feature"))
+	// after the last release
+	pressure.Consume(fixtureReleasePressureDeps(45, 0,
+		"Undo the feature\n\nThis reverts commit 0123456789abcdef.\n"))
+	// merges are ignored
+	merge := fixtureReleasePressureDeps(39, 0, "Merge branch 'master'")
+	merge["commit"].(*object.Commit).ParentHashes = []plumbing.Hash{
+		plumbing.ZeroHash, plumbing.ZeroHash}
+	pressure.Consume(merge)
+	res := pressure.Finalize().(ReleasePressureResult)
+	assert.Equal(t, res.Releases, 2)
+	assert.Len(t, res.Days, 14)
+	assert.Equal(t, res.Days[0], ReleasePressureStats{
+		Commits: 2, LineStats: LineStats{Added: 8, Removed: 0, Changed: 2}, Files: 4})
+	assert.Equal(t, res.Days[1], ReleasePressureStats{
+		Commits: 1, LineStats: LineStats{Added: 4, Removed: 0, Changed: 1}, Files: 2, Reverts: 1})
+	assert.Equal(t, res.Days[2], ReleasePressureStats{})
+	assert.Equal(t, res.Baseline, ReleasePressureStats{
+		Commits: 2, LineStats: LineStats{Added: 8, Removed: 0, Changed: 2}, Files: 4, Reverts: 1})
+	assert.Equal(t, res.Days[0].Size(), float64(5))
+	assert.Equal(t, res.Baseline.RevertRate(), 0.5)
+	assert.Equal(t, ReleasePressureStats{}.Size(), float64(0))
+	assert.Equal(t, ReleasePressureStats{}.RevertRate(), float64(0))
+}
+
+func TestReleasePressureSerialize(t *testing.T) {
+	pressure := fixtureReleasePressure()
+	res := ReleasePressureResult{
+		Releases: 2,
+		Days: []ReleasePressureStats{
+			{Commits: 2, LineStats: LineStats{Added: 8, Changed: 2}, Files: 4},
+			{Commits: 1, LineStats: LineStats{Added: 4, Removed: 3}, Files: 2, Reverts: 1},
+		},
+		Baseline: ReleasePressureStats{Commits: 4, LineStats: LineStats{Added: 1}, Files: 1},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, pressure.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  releases: 2
+  # days before the release; the line stats are [added, removed, changed]
+  days:
+    0: {commits: 2, lines: [8, 0, 2], files: 4, reverts: 0, size: 5.00, revert_rate: 0.0000}
+    1: {commits: 1, lines: [4, 3, 0], files: 2, reverts: 1, size: 7.00, revert_rate: 1.0000}
+  baseline: {commits: 4, lines: [1, 0, 0], files: 1, reverts: 0, size: 0.25, revert_rate: 0.0000}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, pressure.Serialize(res, true, buffer))
+	msg := pb.ReleasePressureResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Releases, int32(2))
+	assert.Len(t, msg.Days, 2)
+	assert.Equal(t, msg.Days[1].Reverts, int32(1))
+	assert.Equal(t, *msg.Days[1].Lines, pb.LineStats{Added: 4, Removed: 3})
+	assert.Equal(t, msg.Baseline.Commits, int32(4))
+}
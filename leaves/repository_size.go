@@ -0,0 +1,322 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// RepositorySizeAnalysis measures how the repository grows: the number of files, the bytes
+// and the lines in the tree at the end of each day and at each tag, and the total size of
+// all the unique blobs which ever appeared, which estimates the uncompressed packfile size.
+// Besides, it finds the commits which introduced the biggest blobs.
+// It is a LeafPipelineItem.
+type RepositorySizeAnalysis struct {
+	// TopCommits is the number of the commits with the biggest growth to report.
+	TopCommits int
+
+	// files maps the file paths to their sizes and line counts.
+	files map[string]repositorySizeFile
+	// blobs are the hashes of the seen blobs.
+	blobs map[plumbing.Hash]bool
+	// current is the size of the tree after the last consumed commit.
+	current RepositorySizeStats
+	// days map the day indexes to the sizes at the end of the days.
+	days map[int]RepositorySizeStats
+	// tagged maps the tagged commits to the tag names.
+	tagged map[plumbing.Hash][]string
+	// tags map the tag names to the sizes at the tagged commits.
+	tags map[string]RepositorySizeStats
+	// topCommits are sorted by the growth in descending order.
+	topCommits []RepositorySizeCommit
+}
+
+// RepositorySizeStats is the size of the repository at some moment.
+type RepositorySizeStats struct {
+	// Files is the number of files in the tree.
+	Files int
+	// Bytes is the total size of the files in the tree.
+	Bytes int64
+	// Lines is the total number of lines in the text files in the tree.
+	Lines int64
+	// History is the total size of all the unique blobs so far.
+	History int64
+}
+
+// RepositorySizeCommit is the commit which added new blobs to the repository.
+type RepositorySizeCommit struct {
+	// Hash of the commit.
+	Hash plumbing.Hash
+	// Growth is the total size of the blobs which appeared for the first time.
+	Growth int64
+}
+
+// RepositorySizeResult is returned by RepositorySizeAnalysis.Finalize() and carries
+// the repository size history.
+type RepositorySizeResult struct {
+	// Days map the day indexes to the sizes at the end of the days with commits.
+	Days map[int]RepositorySizeStats
+	// Tags map the tag names to the sizes at the tagged commits.
+	Tags map[string]RepositorySizeStats
+	// TopCommits are the commits with the biggest growth in descending order.
+	TopCommits []RepositorySizeCommit
+}
+
+type repositorySizeFile struct {
+	Bytes int64
+	Lines int64
+}
+
+const (
+	// ConfigRepositorySizeTopCommits is the name of the option to set
+	// RepositorySizeAnalysis.TopCommits.
+	ConfigRepositorySizeTopCommits = "RepositorySize.TopCommits"
+	// DefaultRepositorySizeTopCommits is the default value of RepositorySizeAnalysis.TopCommits.
+	DefaultRepositorySizeTopCommits = 10
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (size *RepositorySizeAnalysis) Name() string {
+	return "RepositorySize"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (size *RepositorySizeAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (size *RepositorySizeAnalysis) Requires() []string {
+	arr := [...]string{items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (size *RepositorySizeAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigRepositorySizeTopCommits,
+		Description: "Number of the commits which introduced the biggest blobs to report.",
+		Flag:        "repository-size-top-commits",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultRepositorySizeTopCommits},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (size *RepositorySizeAnalysis) Flag() string {
+	return "repository-size"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (size *RepositorySizeAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigRepositorySizeTopCommits].(int); exists {
+		size.TopCommits = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (size *RepositorySizeAnalysis) Initialize(repository *git.Repository) {
+	if size.TopCommits <= 0 {
+		log.Printf("Warning: adjusted the number of top commits to %d\n",
+			DefaultRepositorySizeTopCommits)
+		size.TopCommits = DefaultRepositorySizeTopCommits
+	}
+	size.files = map[string]repositorySizeFile{}
+	size.blobs = map[plumbing.Hash]bool{}
+	size.current = RepositorySizeStats{}
+	size.days = map[int]RepositorySizeStats{}
+	size.tagged = map[plumbing.Hash][]string{}
+	size.tags = map[string]RepositorySizeStats{}
+	size.topCommits = []RepositorySizeCommit{}
+	err := forEachTaggedCommit(repository, func(name string, commit *object.Commit) {
+		size.tagged[commit.Hash] = append(size.tagged[commit.Hash], name)
+	})
+	if err != nil {
+		log.Printf("Warning: failed to read the tags: %v\n", err)
+	}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (size *RepositorySizeAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	var growth int64
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		if action != merkletrie.Insert {
+			size.removeFile(change.From.Name)
+		}
+		if action == merkletrie.Delete {
+			continue
+		}
+		blob := cache[change.To.TreeEntry.Hash]
+		lines, err := items.CountLines(blob)
+		if err != nil {
+			if err.Error() != "binary" {
+				return nil, err
+			}
+			lines = 0
+		}
+		file := repositorySizeFile{Bytes: blob.Size, Lines: int64(lines)}
+		size.files[change.To.Name] = file
+		size.current.Files++
+		size.current.Bytes += file.Bytes
+		size.current.Lines += file.Lines
+		if !size.blobs[blob.Hash] {
+			size.blobs[blob.Hash] = true
+			growth += blob.Size
+		}
+	}
+	size.current.History += growth
+	size.days[deps[items.DependencyDay].(int)] = size.current
+	for _, tag := range size.tagged[commit.Hash] {
+		size.tags[tag] = size.current
+	}
+	size.recordGrowth(commit.Hash, growth)
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (size *RepositorySizeAnalysis) Finalize() interface{} {
+	return RepositorySizeResult{
+		Days:       size.days,
+		Tags:       size.tags,
+		TopCommits: size.topCommits,
+	}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (size *RepositorySizeAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	sizeResult := result.(RepositorySizeResult)
+	if binary {
+		return size.serializeBinary(&sizeResult, writer)
+	}
+	size.serializeText(&sizeResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (size *RepositorySizeAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(size, result, &pb.RepositorySizeResults{}, writer)
+}
+
+func (size *RepositorySizeAnalysis) serializeText(result *RepositorySizeResult, writer io.Writer) {
+	formatStats := func(stats RepositorySizeStats) string {
+		return fmt.Sprintf("[%d, %d, %d, %d]", stats.Files, stats.Bytes, stats.Lines, stats.History)
+	}
+	fmt.Fprintln(writer, "  # files, bytes, lines, history bytes")
+	fmt.Fprintln(writer, "  days:")
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	for _, day := range days {
+		fmt.Fprintf(writer, "    %d: %s\n", day, formatStats(result.Days[day]))
+	}
+	fmt.Fprintln(writer, "  tags:")
+	tags := make([]string, 0, len(result.Tags))
+	for tag := range result.Tags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		fmt.Fprintf(writer, "    %s: %s\n", yaml.SafeString(tag), formatStats(result.Tags[tag]))
+	}
+	fmt.Fprintln(writer, "  top_commits:")
+	for _, commit := range result.TopCommits {
+		fmt.Fprintf(writer, "    - %s: %d\n", commit.Hash.String(), commit.Growth)
+	}
+}
+
+func (size *RepositorySizeAnalysis) serializeBinary(result *RepositorySizeResult, writer io.Writer) error {
+	convert := func(stats RepositorySizeStats) *pb.RepositorySizeStats {
+		return &pb.RepositorySizeStats{
+			Files:   int32(stats.Files),
+			Bytes:   stats.Bytes,
+			Lines:   stats.Lines,
+			History: stats.History,
+		}
+	}
+	message := pb.RepositorySizeResults{
+		Days:       map[int32]*pb.RepositorySizeStats{},
+		Tags:       map[string]*pb.RepositorySizeStats{},
+		TopCommits: make([]*pb.RepositorySizeCommit, len(result.TopCommits)),
+	}
+	for day, stats := range result.Days {
+		message.Days[int32(day)] = convert(stats)
+	}
+	for tag, stats := range result.Tags {
+		message.Tags[tag] = convert(stats)
+	}
+	for i, commit := range result.TopCommits {
+		message.TopCommits[i] = &pb.RepositorySizeCommit{
+			Hash: commit.Hash.String(), Growth: commit.Growth}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func (size *RepositorySizeAnalysis) removeFile(name string) {
+	file, exists := size.files[name]
+	if !exists {
+		return
+	}
+	delete(size.files, name)
+	size.current.Files--
+	size.current.Bytes -= file.Bytes
+	size.current.Lines -= file.Lines
+}
+
+// recordGrowth inserts the commit into the sorted list of the top commits.
+func (size *RepositorySizeAnalysis) recordGrowth(hash plumbing.Hash, growth int64) {
+	if growth == 0 {
+		return
+	}
+	index := sort.Search(len(size.topCommits), func(i int) bool {
+		return size.topCommits[i].Growth < growth
+	})
+	if index >= size.TopCommits {
+		return
+	}
+	size.topCommits = append(size.topCommits, RepositorySizeCommit{})
+	copy(size.topCommits[index+1:], size.topCommits[index:])
+	size.topCommits[index] = RepositorySizeCommit{Hash: hash, Growth: growth}
+	if len(size.topCommits) > size.TopCommits {
+		size.topCommits = size.topCommits[:size.TopCommits]
+	}
+}
+
+func init() {
+	core.Registry.Register(&RepositorySizeAnalysis{})
+}
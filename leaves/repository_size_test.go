@@ -0,0 +1,176 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func fixtureRepositorySize() *RepositorySizeAnalysis {
+	size := RepositorySizeAnalysis{TopCommits: 2}
+	size.Initialize(fixtureReleasePressureRepository())
+	return &size
+}
+
+func fixtureRepositorySizeDeps(hash plumbing.Hash, day int, changes object.Changes,
+	blobs ...*object.Blob) map[string]interface{} {
+	deps := map[string]interface{}{}
+	deps["commit"] = &object.Commit{Hash: hash}
+	deps[items.DependencyDay] = day
+	deps[items.DependencyTreeChanges] = changes
+	cache := map[plumbing.Hash]*object.Blob{}
+	for _, blob := range blobs {
+		cache[blob.Hash] = blob
+	}
+	deps[items.DependencyBlobCache] = cache
+	return deps
+}
+
+func TestRepositorySizeMeta(t *testing.T) {
+	size := fixtureRepositorySize()
+	assert.Equal(t, size.Name(), "RepositorySize")
+	assert.Len(t, size.Provides(), 0)
+	assert.Equal(t, size.Requires(), []string{
+		items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyDay})
+	opts := size.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigRepositorySizeTopCommits)
+	assert.Equal(t, size.Flag(), "repository-size")
+	size.Configure(map[string]interface{}{ConfigRepositorySizeTopCommits: 5})
+	assert.Equal(t, size.TopCommits, 5)
+}
+
+func TestRepositorySizeRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&RepositorySizeAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "RepositorySize")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&RepositorySizeAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestRepositorySizeInitialize(t *testing.T) {
+	size := RepositorySizeAnalysis{TopCommits: -1}
+	size.Initialize(fixtureReleasePressureRepository())
+	assert.Equal(t, size.TopCommits, DefaultRepositorySizeTopCommits)
+	assert.Len(t, size.tagged, 3)
+	names := []string{}
+	for _, tags := range size.tagged {
+		names = append(names, tags...)
+	}
+	assert.Contains(t, names, "v1.0.0")
+	assert.Contains(t, names, "v2.0.0")
+	assert.Contains(t, names, "nightly")
+}
+
+func TestRepositorySizeConsumeFinalize(t *testing.T) {
+	size := fixtureRepositorySize()
+	var tagged plumbing.Hash
+	for hash, tags := range size.tagged {
+		if tags[0] == "v1.0.0" {
+			tagged = hash
+		}
+	}
+	text := fixtureChurnOriginBlob("1\n2\n3\n")
+	text2 := fixtureChurnOriginBlob("1\n2\n3\n4\n5\n")
+	image := fixtureChurnOriginBlob("\x89PNG\r\n\x1a\n\xff\xfe")
+	entry := func(name string, blob *object.Blob) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: blob.Hash}}
+	}
+	hash := func(b byte) plumbing.Hash {
+		return plumbing.Hash{b}
+	}
+	result, err := size.Consume(fixtureRepositorySizeDeps(hash(1), 0, object.Changes{
+		{To: entry("a.txt", text)}, {To: entry("b.txt", text)},
+	}, text))
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	_, err = size.Consume(fixtureRepositorySizeDeps(hash(2), 0, object.Changes{
+		{To: entry("logo.png", image)},
+	}, image))
+	assert.Nil(t, err)
+	_, err = size.Consume(fixtureRepositorySizeDeps(tagged, 3, object.Changes{
+		{From: entry("a.txt", text), To: entry("c.txt", text2)},
+		{From: entry("logo.png", image)},
+	}, text2))
+	assert.Nil(t, err)
+	_, err = size.Consume(fixtureRepositorySizeDeps(hash(4), 4, object.Changes{
+		{To: entry("d.txt", text)},
+	}, text))
+	assert.Nil(t, err)
+	_, err = size.Consume(fixtureRepositorySizeDeps(hash(5), 5, object.Changes{
+		{To: entry("e.txt", text)},
+	}))
+	assert.NotNil(t, err)
+	res := size.Finalize().(RepositorySizeResult)
+	assert.Equal(t, res.Days, map[int]RepositorySizeStats{
+		0: {Files: 3, Bytes: 22, Lines: 6, History: 16},
+		3: {Files: 2, Bytes: 16, Lines: 8, History: 26},
+		4: {Files: 3, Bytes: 22, Lines: 11, History: 26},
+	})
+	assert.Equal(t, res.Tags, map[string]RepositorySizeStats{
+		"v1.0.0": {Files: 2, Bytes: 16, Lines: 8, History: 26},
+	})
+	assert.Equal(t, res.TopCommits, []RepositorySizeCommit{
+		{Hash: hash(2), Growth: 10}, {Hash: tagged, Growth: 10}})
+}
+
+func TestRepositorySizeRecordGrowth(t *testing.T) {
+	size := fixtureRepositorySize()
+	size.recordGrowth(plumbing.Hash{1}, 5)
+	size.recordGrowth(plumbing.Hash{2}, 0)
+	size.recordGrowth(plumbing.Hash{3}, 7)
+	size.recordGrowth(plumbing.Hash{4}, 1)
+	size.recordGrowth(plumbing.Hash{5}, 6)
+	assert.Equal(t, size.topCommits, []RepositorySizeCommit{
+		{Hash: plumbing.Hash{3}, Growth: 7}, {Hash: plumbing.Hash{5}, Growth: 6}})
+}
+
+func TestRepositorySizeSerialize(t *testing.T) {
+	size := fixtureRepositorySize()
+	res := RepositorySizeResult{
+		Days: map[int]RepositorySizeStats{
+			3: {Files: 2, Bytes: 16, Lines: 8, History: 26},
+			0: {Files: 3, Bytes: 16, Lines: 6, History: 16},
+		},
+		Tags: map[string]RepositorySizeStats{
+			"v1.0.0": {Files: 2, Bytes: 16, Lines: 8, History: 26},
+		},
+		TopCommits: []RepositorySizeCommit{{Hash: plumbing.NewHash(
+			"af9ddc0db70f09f3f27b4b98e415592a7485171c"), Growth: 10}},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, size.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  # files, bytes, lines, history bytes
+  days:
+    0: [3, 16, 6, 16]
+    3: [2, 16, 8, 26]
+  tags:
+    "v1.0.0": [2, 16, 8, 26]
+  top_commits:
+    - af9ddc0db70f09f3f27b4b98e415592a7485171c: 10
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, size.Serialize(res, true, buffer))
+	msg := pb.RepositorySizeResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Days, 2)
+	assert.Equal(t, *msg.Days[3], pb.RepositorySizeStats{Files: 2, Bytes: 16, Lines: 8, History: 26})
+	assert.Equal(t, msg.Tags["v1.0.0"].History, int64(26))
+	assert.Equal(t, *msg.TopCommits[0], pb.RepositorySizeCommit{
+		Hash: "af9ddc0db70f09f3f27b4b98e415592a7485171c", Growth: 10})
+}
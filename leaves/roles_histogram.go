@@ -0,0 +1,242 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/enry.v1"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// RolesHistogramAnalysis tracks how many UAST nodes with each role exist in the repository
+// per language over time. Each node contributes to all of its roles, so that it is possible
+// to observe e.g. the number of loops, lambdas or classes evolve.
+// It is a LeafPipelineItem.
+type RolesHistogramAnalysis struct {
+	// files maps the file name to the roles histogram of its current UAST.
+	files map[string]map[uast.Role]int64
+	// languages maps the file name to the language of the file.
+	languages map[string]string
+	// totals is the current roles histogram of every language.
+	totals map[string]map[uast.Role]int64
+	// history maps days to the snapshots of totals.
+	history map[int]map[string]map[uast.Role]int64
+}
+
+// RolesHistogramResult is returned by RolesHistogramAnalysis.Finalize() and carries
+// the roles histograms of every language for each day when there were changes.
+type RolesHistogramResult struct {
+	// Days maps the day index to the language -> role -> number of nodes mapping.
+	Days map[int]map[string]map[uast.Role]int64
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (histogram *RolesHistogramAnalysis) Name() string {
+	return "RolesHistogram"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (histogram *RolesHistogramAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (histogram *RolesHistogramAnalysis) Requires() []string {
+	arr := [...]string{uast_items.DependencyUastChanges, items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (histogram *RolesHistogramAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (histogram *RolesHistogramAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (histogram *RolesHistogramAnalysis) Flag() string {
+	return "roles-histogram"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (histogram *RolesHistogramAnalysis) Configure(facts map[string]interface{}) {
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (histogram *RolesHistogramAnalysis) Initialize(repository *git.Repository) {
+	histogram.files = map[string]map[uast.Role]int64{}
+	histogram.languages = map[string]string{}
+	histogram.totals = map[string]map[uast.Role]int64{}
+	histogram.history = map[int]map[string]map[uast.Role]int64{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (histogram *RolesHistogramAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	day := deps[items.DependencyDay].(int)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	for _, change := range changes {
+		if change.Change.From.Name != "" {
+			histogram.removeFile(change.Change.From.Name)
+		}
+		if change.After != nil {
+			histogram.addFile(change.Change.To.Name, change.After)
+		}
+	}
+	snapshot := map[string]map[uast.Role]int64{}
+	for lang, roles := range histogram.totals {
+		langSnapshot := map[uast.Role]int64{}
+		for role, count := range roles {
+			if count != 0 {
+				langSnapshot[role] = count
+			}
+		}
+		if len(langSnapshot) > 0 {
+			snapshot[lang] = langSnapshot
+		}
+	}
+	histogram.history[day] = snapshot
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (histogram *RolesHistogramAnalysis) Finalize() interface{} {
+	return RolesHistogramResult{Days: histogram.history}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (histogram *RolesHistogramAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	histogramResult := result.(RolesHistogramResult)
+	if binary {
+		return histogram.serializeBinary(&histogramResult, writer)
+	}
+	histogram.serializeText(&histogramResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (histogram *RolesHistogramAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(histogram, result, &pb.RolesHistogramResults{}, writer)
+}
+
+func (histogram *RolesHistogramAnalysis) serializeText(result *RolesHistogramResult, writer io.Writer) {
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	for _, day := range days {
+		fmt.Fprintf(writer, "  %d:\n", day)
+		langs := result.Days[day]
+		langKeys := make([]string, 0, len(langs))
+		for lang := range langs {
+			langKeys = append(langKeys, lang)
+		}
+		sort.Strings(langKeys)
+		for _, lang := range langKeys {
+			roles := langs[lang]
+			roleKeys := make([]int, 0, len(roles))
+			for role := range roles {
+				roleKeys = append(roleKeys, int(role))
+			}
+			sort.Ints(roleKeys)
+			fmt.Fprintf(writer, "    %s: {", yaml.SafeString(lang))
+			for i, role := range roleKeys {
+				if i > 0 {
+					fmt.Fprint(writer, ", ")
+				}
+				fmt.Fprintf(writer, "%s: %d", uast.Role(role).String(), roles[uast.Role(role)])
+			}
+			fmt.Fprintln(writer, "}")
+		}
+	}
+}
+
+func (histogram *RolesHistogramAnalysis) serializeBinary(result *RolesHistogramResult, writer io.Writer) error {
+	message := pb.RolesHistogramResults{
+		Days: map[int32]*pb.LanguageRolesHistograms{},
+	}
+	for day, langs := range result.Days {
+		pbLangs := &pb.LanguageRolesHistograms{
+			Languages: map[string]*pb.RolesHistogram{},
+		}
+		for lang, roles := range langs {
+			pbRoles := &pb.RolesHistogram{Roles: map[int32]int64{}}
+			for role, count := range roles {
+				pbRoles.Roles[int32(role)] = count
+			}
+			pbLangs.Languages[lang] = pbRoles
+		}
+		message.Days[int32(day)] = pbLangs
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func (histogram *RolesHistogramAnalysis) addFile(name string, root *uast.Node) {
+	lang, _ := enry.GetLanguageByExtension(name)
+	if lang == "" {
+		lang = "Other"
+	}
+	roles := map[uast.Role]int64{}
+	uast_items.VisitEachNode(root, func(node *uast.Node) {
+		for _, role := range node.Roles {
+			roles[role]++
+		}
+	})
+	histogram.files[name] = roles
+	histogram.languages[name] = lang
+	totals := histogram.totals[lang]
+	if totals == nil {
+		totals = map[uast.Role]int64{}
+		histogram.totals[lang] = totals
+	}
+	for role, count := range roles {
+		totals[role] += count
+	}
+}
+
+func (histogram *RolesHistogramAnalysis) removeFile(name string) {
+	roles, exists := histogram.files[name]
+	if !exists {
+		return
+	}
+	totals := histogram.totals[histogram.languages[name]]
+	for role, count := range roles {
+		totals[role] -= count
+	}
+	delete(histogram.files, name)
+	delete(histogram.languages, name)
+}
+
+func init() {
+	core.Registry.Register(&RolesHistogramAnalysis{})
+}
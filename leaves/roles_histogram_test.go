@@ -0,0 +1,138 @@
+package leaves
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureRolesHistogram() *RolesHistogramAnalysis {
+	rh := RolesHistogramAnalysis{}
+	rh.Initialize(test.Repository)
+	return &rh
+}
+
+func fixtureRolesHistogramUAST(functions int) *uast.Node {
+	root := &uast.Node{Roles: []uast.Role{uast.File}}
+	for i := 0; i < functions; i++ {
+		root.Children = append(root.Children, &uast.Node{
+			Roles: []uast.Role{uast.Function, uast.Declaration},
+			Children: []*uast.Node{{
+				Roles: []uast.Role{uast.If, uast.Statement},
+			}},
+		})
+	}
+	return root
+}
+
+func TestRolesHistogramMeta(t *testing.T) {
+	rh := fixtureRolesHistogram()
+	assert.Equal(t, rh.Name(), "RolesHistogram")
+	assert.Len(t, rh.Provides(), 0)
+	assert.Equal(t, rh.Requires(), []string{uast_items.DependencyUastChanges, items.DependencyDay})
+	assert.Equal(t, rh.Features(), []string{uast_items.FeatureUast})
+	assert.Len(t, rh.ListConfigurationOptions(), 0)
+	assert.Equal(t, rh.Flag(), "roles-histogram")
+	rh.Configure(nil)
+}
+
+func TestRolesHistogramRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&RolesHistogramAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "RolesHistogram")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&RolesHistogramAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestRolesHistogramConsume(t *testing.T) {
+	rh := fixtureRolesHistogram()
+	deps := map[string]interface{}{}
+	deps[items.DependencyDay] = 0
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: nil, After: fixtureRolesHistogramUAST(2), Change: &object.Change{
+			To: object.ChangeEntry{Name: "main.go"}}},
+		{Before: nil, After: fixtureRolesHistogramUAST(1), Change: &object.Change{
+			To: object.ChangeEntry{Name: "util.py"}}},
+	}
+	result, err := rh.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps[items.DependencyDay] = 3
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: fixtureRolesHistogramUAST(2), After: fixtureRolesHistogramUAST(5),
+			Change: &object.Change{
+				From: object.ChangeEntry{Name: "main.go"},
+				To:   object.ChangeEntry{Name: "cmd/main.go"}}},
+		{Before: fixtureRolesHistogramUAST(1), After: nil, Change: &object.Change{
+			From: object.ChangeEntry{Name: "util.py"}}},
+	}
+	result, err = rh.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps[items.DependencyDay] = 4
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{}
+	rh.Consume(deps)
+	res := rh.Finalize().(RolesHistogramResult)
+	assert.Len(t, res.Days, 2)
+	assert.Len(t, res.Days[0], 2)
+	assert.Equal(t, res.Days[0]["Go"][uast.Function], int64(2))
+	assert.Equal(t, res.Days[0]["Go"][uast.If], int64(2))
+	assert.Equal(t, res.Days[0]["Go"][uast.File], int64(1))
+	assert.Equal(t, res.Days[0]["Python"][uast.Function], int64(1))
+	assert.Len(t, res.Days[3], 1)
+	assert.Equal(t, res.Days[3]["Go"][uast.Function], int64(5))
+	assert.Equal(t, res.Days[3]["Go"][uast.File], int64(1))
+	assert.Len(t, rh.files, 1)
+}
+
+func TestRolesHistogramSerializeText(t *testing.T) {
+	rh := fixtureRolesHistogram()
+	res := RolesHistogramResult{Days: map[int]map[string]map[uast.Role]int64{
+		5: {"Go": {uast.Function: 3, uast.File: 1}},
+		1: {"Python": {uast.If: 2}},
+	}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, rh.Serialize(res, false, buffer))
+	first, second := uast.Function, uast.File
+	if first > second {
+		first, second = second, first
+	}
+	assert.Equal(t, buffer.String(), fmt.Sprintf(`  1:
+    "Python": {%s: 2}
+  5:
+    "Go": {%s: %d, %s: %d}
+`, uast.If.String(), first.String(), res.Days[5]["Go"][first],
+		second.String(), res.Days[5]["Go"][second]))
+}
+
+func TestRolesHistogramSerializeBinary(t *testing.T) {
+	rh := fixtureRolesHistogram()
+	res := RolesHistogramResult{Days: map[int]map[string]map[uast.Role]int64{
+		5: {"Go": {uast.Function: 3, uast.File: 1}},
+		1: {"Python": {uast.If: 2}},
+	}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, rh.Serialize(res, true, buffer))
+	msg := pb.RolesHistogramResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Days, 2)
+	assert.Equal(t, msg.Days[5].Languages["Go"].Roles[int32(uast.Function)], int64(3))
+	assert.Equal(t, msg.Days[1].Languages["Python"].Roles[int32(uast.If)], int64(2))
+}
@@ -0,0 +1,249 @@
+package leaves
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+)
+
+// ScopedLeaf wraps a LeafPipelineItem and hides the changes of the files which do not match
+// the path globs from it. It is deployed to the pipeline instead of the wrapped item, so that
+// different analyses can be scoped differently in the same run. The wrapper has the same name
+// and produces the same results as the wrapped item. It is a core.WrappingPipelineItem and
+// forwards the optional interfaces of the wrapped item.
+type ScopedLeaf struct {
+	core.LeafPipelineItem
+	// Globs are the path patterns of the files to analyse. "*" matches any sequence of
+	// characters except "/", "**" matches any sequence including "/" and "?" matches
+	// a single character except "/".
+	Globs []string
+
+	patterns []*regexp.Regexp
+}
+
+// NewScopedLeaf creates a ScopedLeaf which limits `leaf` to the files matching any of `globs`.
+func NewScopedLeaf(leaf core.LeafPipelineItem, globs []string) (*ScopedLeaf, error) {
+	scoped := &ScopedLeaf{LeafPipelineItem: leaf, Globs: globs}
+	for _, glob := range globs {
+		pattern, err := compileGlob(glob)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid glob %q: %v", leaf.Name(), glob, err)
+		}
+		scoped.patterns = append(scoped.patterns, pattern)
+	}
+	return scoped, nil
+}
+
+// compileGlob converts the path glob to the equivalent regular expression.
+func compileGlob(glob string) (*regexp.Regexp, error) {
+	buffer := &bytes.Buffer{}
+	buffer.WriteRune('^')
+	for i := 0; i < len(glob); i++ {
+		switch char := glob[i]; char {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					// "**/" matches zero or more directories
+					i++
+					buffer.WriteString("(.*/)?")
+				} else {
+					buffer.WriteString(".*")
+				}
+			} else {
+				buffer.WriteString("[^/]*")
+			}
+		case '?':
+			buffer.WriteString("[^/]")
+		default:
+			buffer.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+	buffer.WriteRune('$')
+	return regexp.Compile(buffer.String())
+}
+
+// Features returns the features of the wrapped item, if any.
+func (scoped *ScopedLeaf) Features() []string {
+	if featured, ok := scoped.LeafPipelineItem.(core.FeaturedPipelineItem); ok {
+		return featured.Features()
+	}
+	return nil
+}
+
+// OptionalFeatures returns the optional features of the wrapped item, if any.
+func (scoped *ScopedLeaf) OptionalFeatures() []string {
+	if featured, ok := scoped.LeafPipelineItem.(core.OptionallyFeaturedPipelineItem); ok {
+		return featured.OptionalFeatures()
+	}
+	return nil
+}
+
+// EnableFeatures passes the enabled optional features to the wrapped item.
+func (scoped *ScopedLeaf) EnableFeatures(features []string) {
+	if featured, ok := scoped.LeafPipelineItem.(core.OptionallyFeaturedPipelineItem); ok {
+		featured.EnableFeatures(features)
+	}
+}
+
+// Unwrap returns the wrapped item, see core.WrappingPipelineItem.
+func (scoped *ScopedLeaf) Unwrap() core.PipelineItem {
+	return scoped.LeafPipelineItem
+}
+
+// Fork wraps the clones of the wrapped item with the same globs, see core.ForkablePipelineItem.
+// If the wrapped item is not forkable, the branches share it.
+func (scoped *ScopedLeaf) Fork(n int) []core.PipelineItem {
+	clones := make([]core.PipelineItem, n)
+	forkable, ok := scoped.LeafPipelineItem.(core.ForkablePipelineItem)
+	if !ok {
+		for i := range clones {
+			clones[i] = scoped
+		}
+		return clones
+	}
+	for i, clone := range forkable.Fork(n) {
+		scopedClone := *scoped
+		scopedClone.LeafPipelineItem = clone.(core.LeafPipelineItem)
+		clones[i] = &scopedClone
+	}
+	return clones
+}
+
+// Merge passes the wrapped items of the branches to the wrapped item,
+// see core.ForkablePipelineItem.
+func (scoped *ScopedLeaf) Merge(branches []core.PipelineItem) {
+	forkable, ok := scoped.LeafPipelineItem.(core.ForkablePipelineItem)
+	if !ok {
+		return
+	}
+	wrapped := make([]core.PipelineItem, len(branches))
+	for i, branch := range branches {
+		wrapped[i] = branch.(*ScopedLeaf).LeafPipelineItem
+	}
+	forkable.Merge(wrapped)
+}
+
+// DumpState writes the state of the wrapped item, see core.PersistentPipelineItem.
+func (scoped *ScopedLeaf) DumpState(writer io.Writer) error {
+	persistent, ok := scoped.LeafPipelineItem.(core.PersistentPipelineItem)
+	if !ok {
+		return fmt.Errorf("%s does not support saving the state", scoped.Name())
+	}
+	return persistent.DumpState(writer)
+}
+
+// LoadState restores the state of the wrapped item, see core.PersistentPipelineItem.
+func (scoped *ScopedLeaf) LoadState(reader io.Reader) error {
+	persistent, ok := scoped.LeafPipelineItem.(core.PersistentPipelineItem)
+	if !ok {
+		return fmt.Errorf("%s does not support loading the state", scoped.Name())
+	}
+	return persistent.LoadState(reader)
+}
+
+// Shrink releases the memory of the wrapped item, see core.ShrinkablePipelineItem.
+func (scoped *ScopedLeaf) Shrink() {
+	if shrinkable, ok := scoped.LeafPipelineItem.(core.ShrinkablePipelineItem); ok {
+		shrinkable.Shrink()
+	}
+}
+
+// SerializeDayRanges splits the binary result of the wrapped item,
+// see core.DayRangePipelineItem.
+func (scoped *ScopedLeaf) SerializeDayRanges(result interface{}, days int) ([]core.DayRange, error) {
+	dri, ok := scoped.LeafPipelineItem.(core.DayRangePipelineItem)
+	if !ok {
+		return nil, fmt.Errorf("%s does not support the day ranges", scoped.Name())
+	}
+	return dri.SerializeDayRanges(result, days)
+}
+
+// ExtensionName returns the extension name of the wrapped item or an empty string,
+// see core.ExtensionPipelineItem.
+func (scoped *ScopedLeaf) ExtensionName() string {
+	if epi, ok := scoped.LeafPipelineItem.(core.ExtensionPipelineItem); ok {
+		return epi.ExtensionName()
+	}
+	return ""
+}
+
+// ExtensionMessage creates the extension message of the wrapped item or returns nil,
+// see core.ExtensionPipelineItem.
+func (scoped *ScopedLeaf) ExtensionMessage() proto.Message {
+	if epi, ok := scoped.LeafPipelineItem.(core.ExtensionPipelineItem); ok {
+		return epi.ExtensionMessage()
+	}
+	return nil
+}
+
+// Matches checks whether the file path is in the scope.
+func (scoped *ScopedLeaf) Matches(path string) bool {
+	for _, pattern := range scoped.patterns {
+		if pattern.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (scoped *ScopedLeaf) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	// the upstream values are shared with the other items and must not be modified
+	filtered := map[string]interface{}{}
+	for key, val := range deps {
+		filtered[key] = val
+	}
+	if changes, exists := deps[items.DependencyTreeChanges].(object.Changes); exists {
+		filtered[items.DependencyTreeChanges] = scoped.filterChanges(changes)
+	}
+	if diffs, exists := deps[items.DependencyFileDiff].(map[string]items.FileDiffData); exists {
+		scopedDiffs := map[string]items.FileDiffData{}
+		for path, diff := range diffs {
+			if scoped.Matches(path) {
+				scopedDiffs[path] = diff
+			}
+		}
+		filtered[items.DependencyFileDiff] = scopedDiffs
+	}
+	if changes, exists := deps[uast_items.DependencyUastChanges].([]uast_items.Change); exists {
+		scopedChanges := []uast_items.Change{}
+		for _, change := range changes {
+			if scoped.Matches(change.Change.From.Name) || scoped.Matches(change.Change.To.Name) {
+				scopedChanges = append(scopedChanges, change)
+			}
+		}
+		filtered[uast_items.DependencyUastChanges] = scopedChanges
+	}
+	return scoped.LeafPipelineItem.Consume(filtered)
+}
+
+// filterChanges removes the tree changes outside of the scope. The renames across the scope
+// boundary become insertions or deletions.
+func (scoped *ScopedLeaf) filterChanges(changes object.Changes) object.Changes {
+	result := object.Changes{}
+	for _, change := range changes {
+		fromMatches := change.From.Name != "" && scoped.Matches(change.From.Name)
+		toMatches := change.To.Name != "" && scoped.Matches(change.To.Name)
+		switch {
+		case fromMatches && toMatches:
+			result = append(result, change)
+		case fromMatches:
+			result = append(result, &object.Change{From: change.From})
+		case toMatches:
+			result = append(result, &object.Change{To: change.To})
+		}
+	}
+	return result
+}
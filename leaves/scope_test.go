@@ -0,0 +1,253 @@
+package leaves
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+// recordingLeaf saves the dependencies which it consumes.
+type recordingLeaf struct {
+	deps map[string]interface{}
+}
+
+func (leaf *recordingLeaf) Name() string {
+	return "Recording"
+}
+
+func (leaf *recordingLeaf) Provides() []string {
+	return []string{}
+}
+
+func (leaf *recordingLeaf) Requires() []string {
+	arr := [...]string{items.DependencyTreeChanges, items.DependencyFileDiff}
+	return arr[:]
+}
+
+func (leaf *recordingLeaf) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+func (leaf *recordingLeaf) Configure(facts map[string]interface{}) {
+}
+
+func (leaf *recordingLeaf) Initialize(repository *git.Repository) {
+}
+
+func (leaf *recordingLeaf) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	leaf.deps = deps
+	return nil, nil
+}
+
+func (leaf *recordingLeaf) Finalize() interface{} {
+	return leaf.deps
+}
+
+func (leaf *recordingLeaf) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	return nil
+}
+
+func (leaf *recordingLeaf) SerializeJSON(result interface{}, writer io.Writer) error {
+	return nil
+}
+
+func (leaf *recordingLeaf) Flag() string {
+	return "recording"
+}
+
+func TestScopedLeafGlobs(t *testing.T) {
+	scoped, err := NewScopedLeaf(&recordingLeaf{}, []string{"src/**", "*.md", "cmd/?/main.go"})
+	assert.Nil(t, err)
+	assert.Equal(t, scoped.Name(), "Recording")
+	assert.Equal(t, scoped.Flag(), "recording")
+	assert.Nil(t, scoped.Features())
+	assert.True(t, scoped.Matches("src/a.go"))
+	assert.True(t, scoped.Matches("src/a/b/c.go"))
+	assert.False(t, scoped.Matches("lib/src/a.go"))
+	assert.True(t, scoped.Matches("README.md"))
+	assert.False(t, scoped.Matches("doc/README.md"))
+	assert.True(t, scoped.Matches("cmd/x/main.go"))
+	assert.False(t, scoped.Matches("cmd/xy/main.go"))
+	scoped, err = NewScopedLeaf(&recordingLeaf{}, []string{"**/test/*.py"})
+	assert.Nil(t, err)
+	assert.True(t, scoped.Matches("test/a.py"))
+	assert.True(t, scoped.Matches("a/b/test/a.py"))
+	assert.False(t, scoped.Matches("a/b/test/c/a.py"))
+	scoped, err = NewScopedLeaf(&recordingLeaf{}, []string{"[.go"})
+	assert.Nil(t, err)
+	assert.True(t, scoped.Matches("[.go"))
+}
+
+func TestScopedLeafConsume(t *testing.T) {
+	leaf := &recordingLeaf{}
+	scoped, err := NewScopedLeaf(leaf, []string{"src/**"})
+	assert.Nil(t, err)
+	changes := object.Changes{
+		&object.Change{To: object.ChangeEntry{Name: "src/a.go"}},
+		&object.Change{To: object.ChangeEntry{Name: "lib/b.go"}},
+		&object.Change{
+			From: object.ChangeEntry{Name: "src/c.go"}, To: object.ChangeEntry{Name: "src/c.go"}},
+		&object.Change{
+			From: object.ChangeEntry{Name: "lib/d.go"}, To: object.ChangeEntry{Name: "src/d.go"}},
+		&object.Change{
+			From: object.ChangeEntry{Name: "src/e.go"}, To: object.ChangeEntry{Name: "lib/e.go"}},
+	}
+	diffs := map[string]items.FileDiffData{"src/c.go": {}, "lib/f.go": {}}
+	deps := map[string]interface{}{
+		items.DependencyTreeChanges: changes,
+		items.DependencyFileDiff:    diffs,
+		"commit":                    &object.Commit{},
+	}
+	_, err = scoped.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, leaf.deps["commit"], deps["commit"])
+	scopedChanges := leaf.deps[items.DependencyTreeChanges].(object.Changes)
+	assert.Len(t, scopedChanges, 4)
+	assert.Equal(t, scopedChanges[0], changes[0])
+	assert.Equal(t, scopedChanges[1], changes[2])
+	// the renames across the boundary become insertions and deletions
+	assert.Equal(t, scopedChanges[2].From.Name, "")
+	assert.Equal(t, scopedChanges[2].To.Name, "src/d.go")
+	assert.Equal(t, scopedChanges[3].From.Name, "src/e.go")
+	assert.Equal(t, scopedChanges[3].To.Name, "")
+	assert.Len(t, leaf.deps[items.DependencyFileDiff], 1)
+	assert.Contains(t, leaf.deps[items.DependencyFileDiff], "src/c.go")
+	// the upstream values are intact
+	assert.Len(t, deps[items.DependencyTreeChanges], 5)
+	assert.Len(t, diffs, 2)
+}
+
+// capableLeaf implements all the optional interfaces which ScopedLeaf forwards.
+type capableLeaf struct {
+	recordingLeaf
+	merged []core.PipelineItem
+	state  string
+	shrunk bool
+}
+
+func (leaf *capableLeaf) Fork(n int) []core.PipelineItem {
+	clones := make([]core.PipelineItem, n)
+	for i := range clones {
+		clones[i] = &capableLeaf{state: leaf.state}
+	}
+	return clones
+}
+
+func (leaf *capableLeaf) Merge(branches []core.PipelineItem) {
+	leaf.merged = append(leaf.merged, branches...)
+}
+
+func (leaf *capableLeaf) DumpState(writer io.Writer) error {
+	_, err := writer.Write([]byte(leaf.state))
+	return err
+}
+
+func (leaf *capableLeaf) LoadState(reader io.Reader) error {
+	data, err := ioutil.ReadAll(reader)
+	leaf.state = string(data)
+	return err
+}
+
+func (leaf *capableLeaf) Shrink() {
+	leaf.shrunk = true
+}
+
+func (leaf *capableLeaf) SerializeDayRanges(result interface{}, days int) ([]core.DayRange, error) {
+	return []core.DayRange{{From: 0, To: days, Data: []byte(leaf.state)}}, nil
+}
+
+func (leaf *capableLeaf) ExtensionName() string {
+	return "github.com/user/capable/Capable"
+}
+
+func (leaf *capableLeaf) ExtensionMessage() proto.Message {
+	return &pb.Metadata{}
+}
+
+func TestScopedLeafUnwrap(t *testing.T) {
+	leaf := &recordingLeaf{}
+	scoped, _ := NewScopedLeaf(leaf, []string{"src/**"})
+	assert.Equal(t, scoped.Unwrap(), leaf)
+	assert.Equal(t, core.Unwrap(scoped), leaf)
+	_, ok := core.Unwrap(scoped).(core.ForkablePipelineItem)
+	assert.False(t, ok)
+	capable := &capableLeaf{}
+	scoped, _ = NewScopedLeaf(capable, []string{"src/**"})
+	_, ok = core.Unwrap(scoped).(core.ForkablePipelineItem)
+	assert.True(t, ok)
+}
+
+func TestScopedLeafFork(t *testing.T) {
+	leaf := &capableLeaf{state: "state"}
+	scoped, _ := NewScopedLeaf(leaf, []string{"src/**"})
+	clones := scoped.Fork(2)
+	assert.Len(t, clones, 2)
+	for _, clone := range clones {
+		scopedClone := clone.(*ScopedLeaf)
+		assert.True(t, scopedClone != scoped)
+		assert.Equal(t, scopedClone.Globs, scoped.Globs)
+		assert.True(t, scopedClone.Matches("src/a.go"))
+		assert.False(t, scopedClone.Matches("lib/a.go"))
+		assert.Equal(t, scopedClone.LeafPipelineItem.(*capableLeaf).state, "state")
+		assert.True(t, scopedClone.LeafPipelineItem != leaf)
+	}
+	scoped.Merge(clones)
+	assert.Equal(t, leaf.merged, []core.PipelineItem{
+		clones[0].(*ScopedLeaf).LeafPipelineItem, clones[1].(*ScopedLeaf).LeafPipelineItem})
+	// the items which are not forkable are shared
+	scoped, _ = NewScopedLeaf(&recordingLeaf{}, []string{"src/**"})
+	clones = scoped.Fork(2)
+	assert.Equal(t, clones, []core.PipelineItem{scoped, scoped})
+	scoped.Merge(clones)
+}
+
+func TestScopedLeafState(t *testing.T) {
+	leaf := &capableLeaf{state: "state"}
+	scoped, _ := NewScopedLeaf(leaf, []string{"src/**"})
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, scoped.DumpState(buffer))
+	assert.Equal(t, buffer.String(), "state")
+	assert.Nil(t, scoped.LoadState(bytes.NewBufferString("loaded")))
+	assert.Equal(t, leaf.state, "loaded")
+	scoped, _ = NewScopedLeaf(&recordingLeaf{}, []string{"src/**"})
+	assert.EqualError(t, scoped.DumpState(buffer), "Recording does not support saving the state")
+	assert.EqualError(t, scoped.LoadState(buffer), "Recording does not support loading the state")
+}
+
+func TestScopedLeafShrink(t *testing.T) {
+	leaf := &capableLeaf{}
+	scoped, _ := NewScopedLeaf(leaf, []string{"src/**"})
+	scoped.Shrink()
+	assert.True(t, leaf.shrunk)
+	scoped, _ = NewScopedLeaf(&recordingLeaf{}, []string{"src/**"})
+	scoped.Shrink()
+}
+
+func TestScopedLeafDayRanges(t *testing.T) {
+	scoped, _ := NewScopedLeaf(&capableLeaf{state: "state"}, []string{"src/**"})
+	parts, err := scoped.SerializeDayRanges(nil, 365)
+	assert.Nil(t, err)
+	assert.Equal(t, parts, []core.DayRange{{From: 0, To: 365, Data: []byte("state")}})
+	scoped, _ = NewScopedLeaf(&recordingLeaf{}, []string{"src/**"})
+	parts, err = scoped.SerializeDayRanges(nil, 365)
+	assert.Nil(t, parts)
+	assert.EqualError(t, err, "Recording does not support the day ranges")
+}
+
+func TestScopedLeafExtension(t *testing.T) {
+	scoped, _ := NewScopedLeaf(&capableLeaf{}, []string{"src/**"})
+	assert.Equal(t, scoped.ExtensionName(), "github.com/user/capable/Capable")
+	assert.IsType(t, &pb.Metadata{}, scoped.ExtensionMessage())
+	scoped, _ = NewScopedLeaf(&recordingLeaf{}, []string{"src/**"})
+	assert.Equal(t, scoped.ExtensionName(), "")
+	assert.Nil(t, scoped.ExtensionMessage())
+}
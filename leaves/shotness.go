@@ -59,6 +59,13 @@ type NodeSummary struct {
 	Roles        []uast.Role
 	Name         string
 	File         string
+	// StartLine is the 1-based first line of the node in the latest revision of File.
+	StartLine int
+	// EndLine is the 1-based last line of the node in the latest revision of File.
+	EndLine int
+	// EnclosingType is the dot-separated name of the types which contain the node,
+	// e.g. the class of a method. It is empty for the top level nodes.
+	EnclosingType string
 }
 
 // ShotnessResult is returned by ShotnessAnalysis.Finalize() and represents the analysis result.
@@ -203,6 +210,7 @@ func (shotness *ShotnessAnalysis) Consume(deps map[string]interface{}) (map[stri
 			for name, node := range nodes {
 				addNode(name, node, toName)
 			}
+			shotness.updateLocations(toName, nodes, change.After)
 			continue
 		}
 		// Before -> After
@@ -250,24 +258,7 @@ func (shotness *ShotnessAnalysis) Consume(deps map[string]interface{}) (map[stri
 				if node.StartPosition == nil {
 					continue
 				}
-				startLine := node.StartPosition.Line
-				endLine := node.StartPosition.Line
-				if node.EndPosition != nil && node.EndPosition.Line > node.StartPosition.Line {
-					endLine = node.EndPosition.Line
-				} else {
-					// we need to determine node.EndPosition.Line
-					uast_items.VisitEachNode(node, func(child *uast.Node) {
-						if child.StartPosition != nil {
-							candidate := child.StartPosition.Line
-							if child.EndPosition != nil {
-								candidate = child.EndPosition.Line
-							}
-							if candidate > endLine {
-								endLine = candidate
-							}
-						}
-					})
-				}
+				startLine, endLine := nodeLines(node)
 				for l := startLine; l <= endLine; l++ {
 					lineNodes := res[l-1]
 					if lineNodes == nil {
@@ -310,6 +301,7 @@ func (shotness *ShotnessAnalysis) Consume(deps map[string]interface{}) (map[stri
 				lineNumAfter += size
 			}
 		}
+		shotness.updateLocations(toName, nodesAfter, change.After)
 	}
 	for keyi := range allNodes {
 		for keyj := range allNodes {
@@ -363,10 +355,17 @@ func (shotness *ShotnessAnalysis) Serialize(result interface{}, binary bool, wri
 	return nil
 }
 
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (shotness *ShotnessAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(shotness, result, &pb.ShotnessAnalysisResults{}, writer)
+}
+
 func (shotness *ShotnessAnalysis) serializeText(result *ShotnessResult, writer io.Writer) {
 	for i, summary := range result.Nodes {
-		fmt.Fprintf(writer, "  - name: %s\n    file: %s\n    internal_role: %s\n    roles: [",
-			summary.Name, summary.File, summary.InternalRole)
+		fmt.Fprintf(writer, "  - name: %s\n    file: %s\n    start_line: %d\n    end_line: %d\n"+
+			"    enclosing_type: %s\n    internal_role: %s\n    roles: [",
+			summary.Name, summary.File, summary.StartLine, summary.EndLine,
+			summary.EnclosingType, summary.InternalRole)
 		for j, r := range summary.Roles {
 			if j < len(summary.Roles)-1 {
 				fmt.Fprintf(writer, "%d,", r)
@@ -400,11 +399,14 @@ func (shotness *ShotnessAnalysis) serializeBinary(result *ShotnessResult, writer
 	}
 	for i, summary := range result.Nodes {
 		record := &pb.ShotnessRecord{
-			Name:         summary.Name,
-			File:         summary.File,
-			InternalRole: summary.InternalRole,
-			Roles:        make([]int32, len(summary.Roles)),
-			Counters:     map[int32]int32{},
+			Name:          summary.Name,
+			File:          summary.File,
+			InternalRole:  summary.InternalRole,
+			Roles:         make([]int32, len(summary.Roles)),
+			Counters:      map[int32]int32{},
+			StartLine:     int32(summary.StartLine),
+			EndLine:       int32(summary.EndLine),
+			EnclosingType: summary.EnclosingType,
 		}
 		for j, r := range summary.Roles {
 			record.Roles[j] = int32(r)
@@ -461,6 +463,119 @@ func (shotness *ShotnessAnalysis) extractNodes(root *uast.Node) (map[string]*uas
 	return res, nil
 }
 
+// updateLocations sets the lines and the enclosing types of the tracked nodes in the file
+// to their positions in the new revision. The nodes which are absent there keep the previous values.
+func (shotness *ShotnessAnalysis) updateLocations(
+	fileName string, nodes map[string]*uast.Node, root *uast.Node) {
+	tracked := shotness.files[fileName]
+	if len(tracked) == 0 {
+		return
+	}
+	enclosingTypes := findEnclosingTypes(root, nodes)
+	for _, ns := range tracked {
+		node := nodes[ns.Summary.Name]
+		if node == nil || node.InternalType != ns.Summary.InternalRole {
+			continue
+		}
+		if node.StartPosition != nil {
+			startLine, endLine := nodeLines(node)
+			ns.Summary.StartLine = int(startLine)
+			ns.Summary.EndLine = int(endLine)
+		}
+		ns.Summary.EnclosingType = enclosingTypes[node]
+	}
+}
+
+// nodeLines returns the first and the last lines of the node. If the end position is missing,
+// the last line is determined from the children. The node must have the start position.
+func nodeLines(node *uast.Node) (uint32, uint32) {
+	startLine := node.StartPosition.Line
+	endLine := node.StartPosition.Line
+	if node.EndPosition != nil && node.EndPosition.Line > node.StartPosition.Line {
+		endLine = node.EndPosition.Line
+	} else {
+		// we need to determine node.EndPosition.Line
+		uast_items.VisitEachNode(node, func(child *uast.Node) {
+			if child.StartPosition != nil {
+				candidate := child.StartPosition.Line
+				if child.EndPosition != nil {
+					candidate = child.EndPosition.Line
+				}
+				if candidate > endLine {
+					endLine = candidate
+				}
+			}
+		})
+	}
+	return startLine, endLine
+}
+
+// findEnclosingTypes maps the nodes to the dot-separated names of the type declarations
+// which contain them.
+func findEnclosingTypes(root *uast.Node, nodes map[string]*uast.Node) map[*uast.Node]string {
+	targets := map[*uast.Node]bool{}
+	for _, node := range nodes {
+		targets[node] = true
+	}
+	result := map[*uast.Node]string{}
+	var visit func(node *uast.Node, enclosing string)
+	visit = func(node *uast.Node, enclosing string) {
+		if targets[node] {
+			result[node] = enclosing
+		}
+		if name := typeDeclarationName(node); name != "" {
+			if enclosing != "" {
+				enclosing += "."
+			}
+			enclosing += name
+		}
+		for _, child := range node.Children {
+			visit(child, enclosing)
+		}
+	}
+	if root != nil {
+		visit(root, "")
+	}
+	return result
+}
+
+// typeDeclarationName returns the name of the declared type, e.g. a class, or an empty string
+// if the node does not declare a type. The name is the token of the first identifier among
+// the children and the grandchildren.
+func typeDeclarationName(node *uast.Node) string {
+	isType, isDeclaration := false, false
+	for _, role := range node.Roles {
+		switch role {
+		case uast.Type:
+			isType = true
+		case uast.Declaration:
+			isDeclaration = true
+		case uast.Identifier, uast.Function:
+			return ""
+		}
+	}
+	if !isType || !isDeclaration {
+		return ""
+	}
+	grandchildren := []*uast.Node{}
+	for _, child := range node.Children {
+		grandchildren = append(grandchildren, child.Children...)
+	}
+	for _, candidates := range [...][]*uast.Node{node.Children, grandchildren} {
+		for _, candidate := range candidates {
+			if candidate.Token == "" {
+				continue
+			}
+			for _, role := range candidate.Roles {
+				if role == uast.Identifier {
+					return candidate.Token
+				}
+			}
+		}
+	}
+	return ""
+}
+
 func reverseNodeMap(nodes map[string]*uast.Node) map[*uast.Node]string {
 	res := map[*uast.Node]string{}
 	for key, node := range nodes {
@@ -212,6 +212,13 @@ func TestShotnessConsume(t *testing.T) {
 func TestShotnessConsumeNoEnd(t *testing.T) {
 	_, result1 := bakeShotness(t, false)
 	_, result2 := bakeShotness(t, true)
+	// the end lines are inferred from the children without the end positions
+	for i := range result1.Nodes {
+		assert.Equal(t, result1.Nodes[i].StartLine, result2.Nodes[i].StartLine)
+		assert.True(t, result2.Nodes[i].EndLine >= result2.Nodes[i].StartLine)
+		result1.Nodes[i].EndLine = 0
+		result2.Nodes[i].EndLine = 0
+	}
 	assert.Equal(t, result1, result2)
 }
 
@@ -221,91 +228,145 @@ func TestShotnessSerializeText(t *testing.T) {
 	sh.Serialize(result, false, buffer)
 	assert.Equal(t, buffer.String(), `  - name: testAddEntry
     file: test.java
+    start_line: 292
+    end_line: 301
+    enclosing_type: ZipUtilTest
     internal_role: MethodDeclaration
     roles: [111,59,41,45]
     counters: {"0":1,"1":1,"2":1,"3":1,"4":1,"5":1,"6":1,"7":1,"8":1,"9":1,"10":1,"11":1,"12":1,"13":1,"15":1,"16":1,"17":1}
   - name: testArchiveEquals
     file: test.java
+    start_line: 259
+    end_line: 268
+    enclosing_type: ZipUtilTest
     internal_role: MethodDeclaration
     roles: [111,59,41,45]
     counters: {"0":1,"1":1,"2":1,"3":1,"4":1,"5":1,"6":1,"7":1,"8":1,"9":1,"10":1,"11":1,"12":1,"13":1,"15":1,"16":1,"17":1}
   - name: testContainsAnyEntry
     file: test.java
+    start_line: 280
+    end_line: 290
+    enclosing_type: ZipUtilTest
     internal_role: MethodDeclaration
     roles: [111,59,41,45]
     counters: {"0":1,"1":1,"2":1,"3":1,"4":1,"5":1,"6":1,"7":1,"8":1,"9":1,"10":1,"11":1,"12":1,"13":1,"15":1,"16":1,"17":1}
   - name: testDuplicateEntryAtAddOrReplace
     file: test.java
+    start_line: 164
+    end_line: 174
+    enclosing_type: ZipUtilTest
     internal_role: MethodDeclaration
     roles: [111,59,41,45]
     counters: {"0":1,"1":1,"2":1,"3":1,"4":1,"5":1,"6":1,"7":1,"8":1,"9":1,"10":1,"11":1,"12":1,"13":1,"15":1,"16":1,"17":1}
   - name: testDuplicateEntryAtAdd
     file: test.java
+    start_line: 140
+    end_line: 150
+    enclosing_type: ZipUtilTest
     internal_role: MethodDeclaration
     roles: [111,59,41,45]
     counters: {"0":1,"1":1,"2":1,"3":1,"4":1,"5":1,"6":1,"7":1,"8":1,"9":1,"10":1,"11":1,"12":1,"13":1,"15":1,"16":1,"17":1}
   - name: testDuplicateEntryAtReplace
     file: test.java
+    start_line: 152
+    end_line: 162
+    enclosing_type: ZipUtilTest
     internal_role: MethodDeclaration
     roles: [111,59,41,45]
     counters: {"0":1,"1":1,"2":1,"3":1,"4":1,"5":1,"6":1,"7":1,"8":1,"9":1,"10":1,"11":1,"12":1,"13":1,"15":1,"16":1,"17":1}
   - name: testPackEntries
     file: test.java
+    start_line: 213
+    end_line: 227
+    enclosing_type: ZipUtilTest
     internal_role: MethodDeclaration
     roles: [111,59,41,45]
     counters: {"0":1,"1":1,"2":1,"3":1,"4":1,"5":1,"6":1,"7":1,"8":1,"9":1,"10":1,"11":1,"12":1,"13":1,"15":1,"16":1,"17":1}
   - name: testPackEntry
     file: test.java
+    start_line: 200
+    end_line: 211
+    enclosing_type: ZipUtilTest
     internal_role: MethodDeclaration
     roles: [111,59,41,45]
     counters: {"0":1,"1":1,"2":1,"3":1,"4":1,"5":1,"6":1,"7":1,"8":1,"9":1,"10":1,"11":1,"12":1,"13":1,"15":1,"16":1,"17":1}
   - name: testPreserveRoot
     file: test.java
+    start_line: 240
+    end_line: 246
+    enclosing_type: ZipUtilTest
     internal_role: MethodDeclaration
     roles: [111,59,41,45]
     counters: {"0":1,"1":1,"2":1,"3":1,"4":1,"5":1,"6":1,"7":1,"8":1,"9":1,"10":1,"11":1,"12":1,"13":1,"15":1,"16":1,"17":1}
   - name: testRemoveDirs
     file: test.java
+    start_line: 319
+    end_line: 336
+    enclosing_type: ZipUtilTest
     internal_role: MethodDeclaration
     roles: [111,59,41,45]
     counters: {"0":1,"1":1,"2":1,"3":1,"4":1,"5":1,"6":1,"7":1,"8":1,"9":1,"10":1,"11":1,"12":1,"13":1,"15":1,"16":1,"17":1}
   - name: testRemoveEntry
     file: test.java
+    start_line: 303
+    end_line: 317
+    enclosing_type: ZipUtilTest
     internal_role: MethodDeclaration
     roles: [111,59,41,45]
     counters: {"0":1,"1":1,"2":1,"3":1,"4":1,"5":1,"6":1,"7":1,"8":1,"9":1,"10":1,"11":1,"12":1,"13":1,"15":1,"16":1,"17":1}
   - name: testRepackArchive
     file: test.java
+    start_line: 270
+    end_line: 277
+    enclosing_type: ZipUtilTest
     internal_role: MethodDeclaration
     roles: [111,59,41,45]
     counters: {"0":1,"1":1,"2":1,"3":1,"4":1,"5":1,"6":1,"7":1,"8":1,"9":1,"10":1,"11":1,"12":1,"13":1,"15":1,"16":1,"17":1}
   - name: testUnexplode
     file: test.java
+    start_line: 176
+    end_line: 198
+    enclosing_type: ZipUtilTest
     internal_role: MethodDeclaration
     roles: [111,59,41,45]
     counters: {"0":1,"1":1,"2":1,"3":1,"4":1,"5":1,"6":1,"7":1,"8":1,"9":1,"10":1,"11":1,"12":1,"13":1,"15":1,"16":1,"17":1}
   - name: testUnpackEntryFromFile
     file: test.java
+    start_line: 34
+    end_line: 64
+    enclosing_type: ZipUtilTest
     internal_role: MethodDeclaration
     roles: [111,59,41,45]
     counters: {"0":1,"1":1,"2":1,"3":1,"4":1,"5":1,"6":1,"7":1,"8":1,"9":1,"10":1,"11":1,"12":1,"13":2,"14":1,"15":1,"16":1,"17":1}
   - name: testUnpackEntryFromStreamToFile
     file: test.java
+    start_line: 66
+    end_line: 105
+    enclosing_type: ZipUtilTest
     internal_role: MethodDeclaration
     roles: [111,59,41,45]
     counters: {"13":1,"14":1}
   - name: testUnpackEntryFromStream
     file: test.java
+    start_line: 107
+    end_line: 138
+    enclosing_type: ZipUtilTest
     internal_role: MethodDeclaration
     roles: [111,59,41,45]
     counters: {"0":1,"1":1,"2":1,"3":1,"4":1,"5":1,"6":1,"7":1,"8":1,"9":1,"10":1,"11":1,"12":1,"13":1,"15":1,"16":1,"17":1}
   - name: testZipException
     file: test.java
+    start_line: 229
+    end_line: 238
+    enclosing_type: ZipUtilTest
     internal_role: MethodDeclaration
     roles: [111,59,41,45]
     counters: {"0":1,"1":1,"2":1,"3":1,"4":1,"5":1,"6":1,"7":1,"8":1,"9":1,"10":1,"11":1,"12":1,"13":1,"15":1,"16":1,"17":1}
   - name: unexplodeWithException
     file: test.java
+    start_line: 248
+    end_line: 257
+    enclosing_type: ZipUtilTest
     internal_role: MethodDeclaration
     roles: [111,100,41,45]
     counters: {"0":1,"1":1,"2":1,"3":1,"4":1,"5":1,"6":1,"7":1,"8":1,"9":1,"10":1,"11":1,"12":1,"13":1,"15":1,"16":1,"17":1}
@@ -322,4 +383,7 @@ func TestShotnessSerializeBinary(t *testing.T) {
 	assert.Len(t, message.Records, 18)
 	assert.Equal(t, message.Records[14].Name, "testUnpackEntryFromStreamToFile")
 	assert.Equal(t, message.Records[14].Counters, map[int32]int32{14: 1, 13: 1})
+	assert.Equal(t, message.Records[14].StartLine, int32(66))
+	assert.Equal(t, message.Records[14].EndLine, int32(105))
+	assert.Equal(t, message.Records[14].EnclosingType, "ZipUtilTest")
 }
@@ -0,0 +1,278 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// SQLAnalysis detects the SQL statements embedded into string literals and tracks their number,
+// the referenced tables and the number of joins over time. It also records when and where each
+// table was referenced for the first time.
+// It is a LeafPipelineItem.
+type SQLAnalysis struct {
+	// files maps the file name to the SQL statistics of its current contents.
+	files map[string]SQLStats
+	// history maps days to the snapshots of the repository-wide SQL statistics.
+	history map[int]SQLStats
+	// origins maps the table names to where they were referenced for the first time.
+	origins map[string]SQLTableOrigin
+}
+
+// SQLStats are the statistics of the SQL statements in a file or in the whole repository.
+type SQLStats struct {
+	// Statements is the number of SQL statements.
+	Statements int
+	// Joins is the number of JOIN clauses in all the statements.
+	Joins int
+	// Tables maps the table names to the number of statements which reference them.
+	Tables map[string]int
+}
+
+// SQLTableOrigin is the place where a table was referenced for the first time.
+type SQLTableOrigin struct {
+	// Day is the index of the day.
+	Day int
+	// File is the name of the file which contained the first statement.
+	File string
+}
+
+// SQLResult is returned by SQLAnalysis.Finalize() and carries the SQL statistics for each day
+// when they changed together with the table origins.
+type SQLResult struct {
+	// Days maps the day index to the repository-wide SQL statistics.
+	Days map[int]SQLStats
+	// Origins maps the table names to where they were referenced for the first time.
+	Origins map[string]SQLTableOrigin
+}
+
+// sqlColumn matches a single column expression in SELECT, e.g. "u.name AS name" or "count(*)".
+const sqlColumn = `[\w.()*]+(\s+as\s+\w+)?`
+
+var (
+	sqlStatementRegexp = regexp.MustCompile(
+		`(?is)^\s*(select\s+(distinct\s+)?` + sqlColumn + `(\s*,\s*` + sqlColumn + `)*\s+from\s|` +
+			`insert\s+into\s|update\s+\S+\s+set\s|delete\s+from\s|` +
+			`(create|alter|drop)\s+(table|index|view)\s|with\s+\w+\s+as\s*\()`)
+	sqlTableRegexp = regexp.MustCompile(`(?i)\b(?:from|join|into|update|table)\s+([\w.]+)`)
+	sqlJoinRegexp  = regexp.MustCompile(`(?i)\bjoin\b`)
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (sql *SQLAnalysis) Name() string {
+	return "SQL"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (sql *SQLAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (sql *SQLAnalysis) Requires() []string {
+	arr := [...]string{uast_items.DependencyUastChanges, items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (sql *SQLAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (sql *SQLAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (sql *SQLAnalysis) Flag() string {
+	return "sql"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (sql *SQLAnalysis) Configure(facts map[string]interface{}) {
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (sql *SQLAnalysis) Initialize(repository *git.Repository) {
+	sql.files = map[string]SQLStats{}
+	sql.history = map[int]SQLStats{}
+	sql.origins = map[string]SQLTableOrigin{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (sql *SQLAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	day := deps[items.DependencyDay].(int)
+	changed := false
+	for _, change := range changes {
+		if name := change.Change.From.Name; name != "" {
+			if _, exists := sql.files[name]; exists {
+				delete(sql.files, name)
+				changed = true
+			}
+		}
+		if change.After == nil {
+			continue
+		}
+		name := change.Change.To.Name
+		stats := ExtractSQLStats(change.After)
+		if stats.Statements == 0 {
+			continue
+		}
+		sql.files[name] = stats
+		changed = true
+		for table := range stats.Tables {
+			if _, exists := sql.origins[table]; !exists {
+				sql.origins[table] = SQLTableOrigin{Day: day, File: name}
+			}
+		}
+	}
+	if !changed {
+		return nil, nil
+	}
+	totals := SQLStats{Tables: map[string]int{}}
+	for _, stats := range sql.files {
+		totals.Statements += stats.Statements
+		totals.Joins += stats.Joins
+		for table, count := range stats.Tables {
+			totals.Tables[table] += count
+		}
+	}
+	sql.history[day] = totals
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (sql *SQLAnalysis) Finalize() interface{} {
+	return SQLResult{Days: sql.history, Origins: sql.origins}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (sql *SQLAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	sqlResult := result.(SQLResult)
+	if binary {
+		return sql.serializeBinary(&sqlResult, writer)
+	}
+	sql.serializeText(&sqlResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (sql *SQLAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(sql, result, &pb.SQLResults{}, writer)
+}
+
+func (sql *SQLAnalysis) serializeText(result *SQLResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  days:")
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	for _, day := range days {
+		stats := result.Days[day]
+		tables := make([]string, 0, len(stats.Tables))
+		for table := range stats.Tables {
+			tables = append(tables, table)
+		}
+		sort.Strings(tables)
+		for i, table := range tables {
+			tables[i] = fmt.Sprintf("%s: %d", yaml.SafeString(table), stats.Tables[table])
+		}
+		fmt.Fprintf(writer, "    %d: {statements: %d, joins: %d, tables: {%s}}\n",
+			day, stats.Statements, stats.Joins, strings.Join(tables, ", "))
+	}
+	fmt.Fprintln(writer, "  origins:")
+	tables := make([]string, 0, len(result.Origins))
+	for table := range result.Origins {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	for _, table := range tables {
+		origin := result.Origins[table]
+		fmt.Fprintf(writer, "    %s: {day: %d, file: %s}\n",
+			yaml.SafeString(table), origin.Day, yaml.SafeString(origin.File))
+	}
+}
+
+func (sql *SQLAnalysis) serializeBinary(result *SQLResult, writer io.Writer) error {
+	message := pb.SQLResults{
+		Days:    map[int32]*pb.SQLStats{},
+		Origins: map[string]*pb.SQLTableOrigin{},
+	}
+	for day, stats := range result.Days {
+		pbStats := &pb.SQLStats{
+			Statements: int32(stats.Statements),
+			Joins:      int32(stats.Joins),
+			Tables:     map[string]int32{},
+		}
+		for table, count := range stats.Tables {
+			pbStats.Tables[table] = int32(count)
+		}
+		message.Days[int32(day)] = pbStats
+	}
+	for table, origin := range result.Origins {
+		message.Origins[table] = &pb.SQLTableOrigin{
+			Day:  int32(origin.Day),
+			File: origin.File,
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+// IsSQL returns true if the string looks like an SQL statement.
+func IsSQL(text string) bool {
+	return sqlStatementRegexp.MatchString(text)
+}
+
+// ExtractSQLStats finds the SQL statements among the string literals in the UAST.
+func ExtractSQLStats(root *uast.Node) SQLStats {
+	stats := SQLStats{Tables: map[string]int{}}
+	visitStringLiterals(root, func(literal string) {
+		if !IsSQL(literal) {
+			return
+		}
+		stats.Statements++
+		stats.Joins += len(sqlJoinRegexp.FindAllStringIndex(literal, -1))
+		tables := map[string]bool{}
+		for _, match := range sqlTableRegexp.FindAllStringSubmatch(literal, -1) {
+			tables[strings.ToLower(match[1])] = true
+		}
+		for table := range tables {
+			stats.Tables[table]++
+		}
+	})
+	return stats
+}
+
+func init() {
+	core.Registry.Register(&SQLAnalysis{})
+}
@@ -0,0 +1,145 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureSQL() *SQLAnalysis {
+	sql := SQLAnalysis{}
+	sql.Initialize(test.Repository)
+	return &sql
+}
+
+func TestSQLMeta(t *testing.T) {
+	sql := fixtureSQL()
+	assert.Equal(t, sql.Name(), "SQL")
+	assert.Len(t, sql.Provides(), 0)
+	assert.Equal(t, sql.Requires(), []string{uast_items.DependencyUastChanges, items.DependencyDay})
+	assert.Equal(t, sql.Features(), []string{uast_items.FeatureUast})
+	assert.Len(t, sql.ListConfigurationOptions(), 0)
+	assert.Equal(t, sql.Flag(), "sql")
+	sql.Configure(nil)
+}
+
+func TestSQLRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&SQLAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "SQL")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&SQLAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestIsSQL(t *testing.T) {
+	assert.True(t, IsSQL("SELECT id FROM users"))
+	assert.True(t, IsSQL("  select *\n from users u join orders o on u.id = o.user"))
+	assert.True(t, IsSQL("INSERT INTO users VALUES (?)"))
+	assert.True(t, IsSQL("update users set name = ?"))
+	assert.True(t, IsSQL("DELETE FROM users"))
+	assert.True(t, IsSQL("CREATE TABLE users (id int)"))
+	assert.False(t, IsSQL("select a file from the list"))
+	assert.False(t, IsSQL("Please select one"))
+	assert.False(t, IsSQL("update"))
+}
+
+func TestExtractSQLStats(t *testing.T) {
+	root := fixtureStringLiteralsUAST(
+		`"SELECT * FROM users u JOIN orders o ON u.id = o.user JOIN Users x ON 1"`,
+		`"INSERT INTO orders VALUES (?)"`,
+		`"hello"`)
+	stats := ExtractSQLStats(root)
+	assert.Equal(t, stats.Statements, 2)
+	assert.Equal(t, stats.Joins, 2)
+	assert.Equal(t, stats.Tables, map[string]int{"users": 1, "orders": 2})
+	stats = ExtractSQLStats(&uast.Node{})
+	assert.Equal(t, stats.Statements, 0)
+	assert.Len(t, stats.Tables, 0)
+}
+
+func TestSQLConsume(t *testing.T) {
+	sql := fixtureSQL()
+	deps := map[string]interface{}{}
+	deps[items.DependencyDay] = 0
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{After: fixtureStringLiteralsUAST(`"SELECT id FROM users"`), Change: &object.Change{
+			To: object.ChangeEntry{Name: "db.go"}}},
+		{After: fixtureStringLiteralsUAST(`"hello"`), Change: &object.Change{
+			To: object.ChangeEntry{Name: "main.go"}}},
+	}
+	result, err := sql.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps[items.DependencyDay] = 1
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: fixtureStringLiteralsUAST(`"hello"`), After: fixtureStringLiteralsUAST(`"world"`),
+			Change: &object.Change{
+				From: object.ChangeEntry{Name: "main.go"}, To: object.ChangeEntry{Name: "main.go"}}},
+	}
+	result, err = sql.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps[items.DependencyDay] = 4
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{After: fixtureStringLiteralsUAST(`"DELETE FROM orders"`, `"DELETE FROM users"`),
+			Change: &object.Change{To: object.ChangeEntry{Name: "cleanup.go"}}},
+	}
+	sql.Consume(deps)
+	res := sql.Finalize().(SQLResult)
+	assert.Len(t, res.Days, 2)
+	assert.Equal(t, res.Days[0], SQLStats{Statements: 1, Tables: map[string]int{"users": 1}})
+	assert.Equal(t, res.Days[4], SQLStats{Statements: 3, Tables: map[string]int{"users": 2, "orders": 1}})
+	assert.Equal(t, res.Origins, map[string]SQLTableOrigin{
+		"users":  {Day: 0, File: "db.go"},
+		"orders": {Day: 4, File: "cleanup.go"},
+	})
+}
+
+func TestSQLSerialize(t *testing.T) {
+	sql := fixtureSQL()
+	res := SQLResult{
+		Days: map[int]SQLStats{
+			3: {Statements: 4, Joins: 1, Tables: map[string]int{"users": 3, "orders": 2}},
+			0: {Statements: 1, Tables: map[string]int{"users": 1}},
+		},
+		Origins: map[string]SQLTableOrigin{
+			"users":  {Day: 0, File: "db.go"},
+			"orders": {Day: 3, File: "shop/orders.go"},
+		},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, sql.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  days:
+    0: {statements: 1, joins: 0, tables: {"users": 1}}
+    3: {statements: 4, joins: 1, tables: {"orders": 2, "users": 3}}
+  origins:
+    "orders": {day: 3, file: "shop/orders.go"}
+    "users": {day: 0, file: "db.go"}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, sql.Serialize(res, true, buffer))
+	msg := pb.SQLResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Days, 2)
+	assert.Equal(t, msg.Days[3].Statements, int32(4))
+	assert.Equal(t, msg.Days[3].Joins, int32(1))
+	assert.Equal(t, msg.Days[3].Tables["orders"], int32(2))
+	assert.Equal(t, msg.Origins["orders"].File, "shop/orders.go")
+	assert.Equal(t, msg.Origins["users"].Day, int32(0))
+}
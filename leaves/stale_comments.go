@@ -0,0 +1,388 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// StaleCommentsAnalysis detects the likely stale documentation: the comments which stayed
+// identical for a long time while the code right below them changed again and again.
+// The comments are extracted with the same XPath as in CommentSentimentAnalysis, the adjacent
+// consecutive comment lines are merged into blocks. The report lists such blocks at HEAD.
+// It is a LeafPipelineItem.
+type StaleCommentsAnalysis struct {
+	// Window is the number of lines below the comment which are considered documented by it.
+	Window int
+	// MinChanges is the minimum number of commits which changed the documented code.
+	MinChanges int
+	// MinDays is the minimum number of days since the comment was last changed.
+	MinDays int
+
+	// files maps the file names to the comment texts to the comment states.
+	files map[string]map[string]*staleCommentState
+	// lastDay is the day of the latest consumed commit.
+	lastDay int
+	// xpather extracts the comment nodes.
+	xpather *uast_items.ChangesXPather
+}
+
+// staleCommentState is the history of a comment block in the current revision of a file.
+type staleCommentState struct {
+	// line is the 1-based first line of the comment block.
+	line int
+	// end is the 1-based last line of the comment block.
+	end int
+	// since is the day when the comment appeared in its current form.
+	since int
+	// changes is the number of commits which changed the documented code.
+	changes int
+	// lastChange is the day when the documented code changed the last time.
+	lastChange int
+}
+
+// StaleComment is the comment block at HEAD which is likely outdated.
+type StaleComment struct {
+	// File is the path to the file at HEAD.
+	File string
+	// Line is the 1-based first line of the comment block at HEAD.
+	Line int
+	// Comment is the text of the comment block.
+	Comment string
+	// Since is the day when the comment appeared in its current form.
+	Since int
+	// Changes is the number of commits which changed the documented code since then.
+	Changes int
+	// LastChange is the day when the documented code changed the last time.
+	LastChange int
+}
+
+// StaleCommentsResult is returned by StaleCommentsAnalysis.Finalize() and carries the likely
+// stale comments sorted by the number of the documented code changes in descending order.
+type StaleCommentsResult struct {
+	Comments []StaleComment
+}
+
+const (
+	// ConfigStaleCommentsWindow is the name of the option to set StaleCommentsAnalysis.Window.
+	ConfigStaleCommentsWindow = "StaleComments.Window"
+	// ConfigStaleCommentsMinChanges is the name of the option to set
+	// StaleCommentsAnalysis.MinChanges.
+	ConfigStaleCommentsMinChanges = "StaleComments.MinChanges"
+	// ConfigStaleCommentsMinDays is the name of the option to set StaleCommentsAnalysis.MinDays.
+	ConfigStaleCommentsMinDays = "StaleComments.MinDays"
+	// DefaultStaleCommentsWindow is the default value of StaleCommentsAnalysis.Window.
+	DefaultStaleCommentsWindow = 10
+	// DefaultStaleCommentsMinChanges is the default value of StaleCommentsAnalysis.MinChanges.
+	DefaultStaleCommentsMinChanges = 3
+	// DefaultStaleCommentsMinDays is the default value of StaleCommentsAnalysis.MinDays.
+	DefaultStaleCommentsMinDays = 180
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (stale *StaleCommentsAnalysis) Name() string {
+	return "StaleComments"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (stale *StaleCommentsAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (stale *StaleCommentsAnalysis) Requires() []string {
+	arr := [...]string{uast_items.DependencyUastChanges, items.DependencyFileDiff,
+		items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (stale *StaleCommentsAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (stale *StaleCommentsAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigStaleCommentsWindow,
+		Description: "Number of lines below a comment which it documents.",
+		Flag:        "stale-comments-window",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultStaleCommentsWindow}, {
+		Name: ConfigStaleCommentsMinChanges,
+		Description: "Minimum number of commits which changed the documented code while " +
+			"the comment stayed the same.",
+		Flag:    "stale-comments-min-changes",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultStaleCommentsMinChanges}, {
+		Name:        ConfigStaleCommentsMinDays,
+		Description: "Minimum number of days since the comment was changed.",
+		Flag:        "stale-comments-min-days",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultStaleCommentsMinDays},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (stale *StaleCommentsAnalysis) Flag() string {
+	return "stale-comments"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (stale *StaleCommentsAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigStaleCommentsWindow].(int); exists {
+		stale.Window = val
+	}
+	if val, exists := facts[ConfigStaleCommentsMinChanges].(int); exists {
+		stale.MinChanges = val
+	}
+	if val, exists := facts[ConfigStaleCommentsMinDays].(int); exists {
+		stale.MinDays = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (stale *StaleCommentsAnalysis) Initialize(repository *git.Repository) {
+	if stale.Window <= 0 {
+		log.Printf("Warning: adjusted the stale comments window to %d\n", DefaultStaleCommentsWindow)
+		stale.Window = DefaultStaleCommentsWindow
+	}
+	if stale.MinChanges <= 0 {
+		log.Printf("Warning: adjusted the minimum number of changes to %d\n",
+			DefaultStaleCommentsMinChanges)
+		stale.MinChanges = DefaultStaleCommentsMinChanges
+	}
+	if stale.MinDays < 0 {
+		log.Printf("Warning: adjusted the minimum number of days to %d\n", DefaultStaleCommentsMinDays)
+		stale.MinDays = DefaultStaleCommentsMinDays
+	}
+	stale.files = map[string]map[string]*staleCommentState{}
+	stale.lastDay = 0
+	stale.xpather = &uast_items.ChangesXPather{XPath: "//*[@roleComment]"}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (stale *StaleCommentsAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	day := deps[items.DependencyDay].(int)
+	stale.lastDay = day
+	for _, change := range changes {
+		if change.After == nil {
+			delete(stale.files, change.Change.From.Name)
+			continue
+		}
+		name := change.Change.To.Name
+		previous := stale.files[change.Change.From.Name]
+		if change.Change.From.Name != name {
+			delete(stale.files, change.Change.From.Name)
+		}
+		var changedLines map[int]bool
+		if diff, exists := fileDiffs[name]; exists {
+			changedLines = staleChangedLines(diff.Diffs)
+		}
+		current := map[string]*staleCommentState{}
+		for _, block := range mergeCommentBlocks(
+			stale.xpather.Filter(change.After, change.Change.To.TreeEntry.Hash)) {
+			state := previous[block.text]
+			if state == nil {
+				state = &staleCommentState{since: day}
+			} else if stale.isCodeChanged(block, changedLines) {
+				state.changes++
+				state.lastChange = day
+			}
+			state.line = block.line
+			state.end = block.end
+			current[block.text] = state
+		}
+		stale.files[name] = current
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (stale *StaleCommentsAnalysis) Finalize() interface{} {
+	comments := []StaleComment{}
+	for file, states := range stale.files {
+		for text, state := range states {
+			if state.changes < stale.MinChanges || stale.lastDay-state.since < stale.MinDays {
+				continue
+			}
+			comments = append(comments, StaleComment{
+				File: file, Line: state.line, Comment: text, Since: state.since,
+				Changes: state.changes, LastChange: state.lastChange,
+			})
+		}
+	}
+	sort.Slice(comments, func(i, j int) bool {
+		if comments[i].Changes != comments[j].Changes {
+			return comments[i].Changes > comments[j].Changes
+		}
+		if comments[i].File != comments[j].File {
+			return comments[i].File < comments[j].File
+		}
+		return comments[i].Line < comments[j].Line
+	})
+	return StaleCommentsResult{Comments: comments}
+}
+
+// isCodeChanged returns true if any of the Window lines below the comment block changed
+// while the block itself did not.
+func (stale *StaleCommentsAnalysis) isCodeChanged(block commentBlock, changedLines map[int]bool) bool {
+	for line := block.line; line <= block.end; line++ {
+		if changedLines[line] {
+			return false
+		}
+	}
+	for line := block.end + 1; line <= block.end+stale.Window; line++ {
+		if changedLines[line] {
+			return true
+		}
+	}
+	return false
+}
+
+// staleChangedLines returns the 1-based lines in the new version of the file which were inserted
+// or replaced, plus the lines which follow the deleted ones.
+func staleChangedLines(diffs []diffmatchpatch.Diff) map[int]bool {
+	lines := map[int]bool{}
+	// the diffs are line-level so the number of lines equals to the rune count
+	position := 0
+	for _, edit := range diffs {
+		length := utf8.RuneCountInString(edit.Text)
+		switch edit.Type {
+		case diffmatchpatch.DiffEqual:
+			position += length
+		case diffmatchpatch.DiffInsert:
+			for i := 0; i < length; i++ {
+				lines[position+i+1] = true
+			}
+			position += length
+		case diffmatchpatch.DiffDelete:
+			lines[position+1] = true
+		}
+	}
+	return lines
+}
+
+// commentBlock is the sequence of comments on the adjacent lines.
+type commentBlock struct {
+	text string
+	line int
+	end  int
+}
+
+// mergeCommentBlocks joins the comments on the adjacent lines, e.g. the consecutive "//" lines.
+// The comments without the position are ignored.
+func mergeCommentBlocks(nodes []*uast.Node) []commentBlock {
+	sorted := make([]*uast.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if node.StartPosition != nil {
+			sorted = append(sorted, node)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartPosition.Line < sorted[j].StartPosition.Line
+	})
+	var blocks []commentBlock
+	var texts []string
+	for _, node := range sorted {
+		line := int(node.StartPosition.Line)
+		end := line
+		if node.EndPosition != nil && int(node.EndPosition.Line) > end {
+			end = int(node.EndPosition.Line)
+		}
+		if len(blocks) > 0 && line <= blocks[len(blocks)-1].end+1 {
+			last := &blocks[len(blocks)-1]
+			if end > last.end {
+				last.end = end
+			}
+			texts = append(texts, strings.TrimSpace(node.Token))
+			continue
+		}
+		if len(blocks) > 0 {
+			blocks[len(blocks)-1].text = strings.Join(texts, "\n")
+		}
+		blocks = append(blocks, commentBlock{line: line, end: end})
+		texts = []string{strings.TrimSpace(node.Token)}
+	}
+	if len(blocks) > 0 {
+		blocks[len(blocks)-1].text = strings.Join(texts, "\n")
+	}
+	return blocks
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (stale *StaleCommentsAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	staleResult := result.(StaleCommentsResult)
+	if binary {
+		return stale.serializeBinary(&staleResult, writer)
+	}
+	stale.serializeText(&staleResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (stale *StaleCommentsAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(stale, result, &pb.StaleCommentsResults{}, writer)
+}
+
+func (stale *StaleCommentsAnalysis) serializeText(result *StaleCommentsResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  comments:")
+	for _, comment := range result.Comments {
+		fmt.Fprintf(writer, "  - {file: %s, line: %d, since: %d, changes: %d, last_change: %d, "+
+			"comment: %s}\n", yaml.SafeString(comment.File), comment.Line, comment.Since,
+			comment.Changes, comment.LastChange, yaml.SafeString(comment.Comment))
+	}
+}
+
+func (stale *StaleCommentsAnalysis) serializeBinary(result *StaleCommentsResult, writer io.Writer) error {
+	message := pb.StaleCommentsResults{
+		Comments: make([]*pb.StaleComment, len(result.Comments)),
+	}
+	for i, comment := range result.Comments {
+		message.Comments[i] = &pb.StaleComment{
+			File:       comment.File,
+			Line:       int32(comment.Line),
+			Comment:    comment.Comment,
+			Since:      int32(comment.Since),
+			Changes:    int32(comment.Changes),
+			LastChange: int32(comment.LastChange),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&StaleCommentsAnalysis{})
+}
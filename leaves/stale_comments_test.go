@@ -0,0 +1,205 @@
+package leaves
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureStaleComments() *StaleCommentsAnalysis {
+	stale := StaleCommentsAnalysis{
+		Window:     DefaultStaleCommentsWindow,
+		MinChanges: DefaultStaleCommentsMinChanges,
+		MinDays:    DefaultStaleCommentsMinDays,
+	}
+	stale.Initialize(test.Repository)
+	return &stale
+}
+
+// fixtureStaleCommentsUAST generates a file with the comments on the specified lines.
+func fixtureStaleCommentsUAST(comments map[uint32]string) *uast.Node {
+	root := &uast.Node{Roles: []uast.Role{uast.File}}
+	for line, comment := range comments {
+		root.Children = append(root.Children, &uast.Node{
+			Roles: []uast.Role{uast.Comment}, Token: comment,
+			StartPosition: &uast.Position{Line: line}, EndPosition: &uast.Position{Line: line},
+		})
+	}
+	return root
+}
+
+func fixtureStaleCommentsDeps(day int, changes []uast_items.Change,
+	diffs map[string]items.FileDiffData) map[string]interface{} {
+	if diffs == nil {
+		diffs = map[string]items.FileDiffData{}
+	}
+	return map[string]interface{}{
+		items.DependencyDay:              day,
+		uast_items.DependencyUastChanges: changes,
+		items.DependencyFileDiff:         diffs,
+	}
+}
+
+func TestStaleCommentsMeta(t *testing.T) {
+	stale := fixtureStaleComments()
+	assert.Equal(t, stale.Name(), "StaleComments")
+	assert.Len(t, stale.Provides(), 0)
+	assert.Equal(t, stale.Requires(), []string{
+		uast_items.DependencyUastChanges, items.DependencyFileDiff, items.DependencyDay})
+	assert.Equal(t, stale.Features(), []string{uast_items.FeatureUast})
+	opts := stale.ListConfigurationOptions()
+	assert.Len(t, opts, 3)
+	assert.Equal(t, opts[0].Name, ConfigStaleCommentsWindow)
+	assert.Equal(t, opts[1].Name, ConfigStaleCommentsMinChanges)
+	assert.Equal(t, opts[2].Name, ConfigStaleCommentsMinDays)
+	assert.Equal(t, stale.Flag(), "stale-comments")
+	stale.Configure(map[string]interface{}{
+		ConfigStaleCommentsWindow:     5,
+		ConfigStaleCommentsMinChanges: 2,
+		ConfigStaleCommentsMinDays:    30,
+	})
+	assert.Equal(t, stale.Window, 5)
+	assert.Equal(t, stale.MinChanges, 2)
+	assert.Equal(t, stale.MinDays, 30)
+	stale.Window = 0
+	stale.MinChanges = -1
+	stale.MinDays = -1
+	stale.Initialize(test.Repository)
+	assert.Equal(t, stale.Window, DefaultStaleCommentsWindow)
+	assert.Equal(t, stale.MinChanges, DefaultStaleCommentsMinChanges)
+	assert.Equal(t, stale.MinDays, DefaultStaleCommentsMinDays)
+}
+
+func TestStaleCommentsRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&StaleCommentsAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "StaleComments")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&StaleCommentsAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestStaleCommentsMergeBlocks(t *testing.T) {
+	nodes := fixtureStaleCommentsUAST(map[uint32]string{
+		1: "// Foo does X", 2: "// and Y ", 5: "// Bar"}).Children
+	nodes = append(nodes, &uast.Node{Token: "// nowhere"})
+	assert.Equal(t, mergeCommentBlocks(nodes), []commentBlock{
+		{text: "// Foo does X\n// and Y", line: 1, end: 2},
+		{text: "// Bar", line: 5, end: 5},
+	})
+	assert.Len(t, mergeCommentBlocks(nil), 0)
+}
+
+func TestStaleCommentsChangedLines(t *testing.T) {
+	assert.Equal(t, staleChangedLines([]diffmatchpatch.Diff{
+		{Type: diffmatchpatch.DiffEqual, Text: "abcd"},
+		{Type: diffmatchpatch.DiffInsert, Text: "xy"},
+		{Type: diffmatchpatch.DiffEqual, Text: "ef"},
+		{Type: diffmatchpatch.DiffDelete, Text: "g"},
+		{Type: diffmatchpatch.DiffEqual, Text: "h"},
+	}), map[int]bool{5: true, 6: true, 9: true})
+}
+
+func TestStaleCommentsConsume(t *testing.T) {
+	stale := fixtureStaleComments()
+	comments := map[uint32]string{1: "// Foo does X", 2: "// and Y", 20: "// Bar"}
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name}
+	}
+	// the code below "Foo" changes while the comment stays
+	codeDiff := items.FileDiffData{Diffs: []diffmatchpatch.Diff{
+		{Type: diffmatchpatch.DiffEqual, Text: "abcd"},
+		{Type: diffmatchpatch.DiffInsert, Text: "x"},
+		{Type: diffmatchpatch.DiffEqual, Text: strings.Repeat("y", 20)},
+	}}
+	result, err := stale.Consume(fixtureStaleCommentsDeps(0, []uast_items.Change{
+		{After: fixtureStaleCommentsUAST(comments), Change: &object.Change{To: entry("a.go")}},
+		{After: fixtureStaleCommentsUAST(comments), Change: &object.Change{To: entry("c.go")}},
+	}, nil))
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	for _, day := range []int{10, 200} {
+		stale.Consume(fixtureStaleCommentsDeps(day, []uast_items.Change{
+			{Before: fixtureStaleCommentsUAST(comments), After: fixtureStaleCommentsUAST(comments),
+				Change: &object.Change{From: entry("a.go"), To: entry("a.go")}},
+			{Before: fixtureStaleCommentsUAST(comments), After: fixtureStaleCommentsUAST(comments),
+				Change: &object.Change{From: entry("c.go"), To: entry("c.go")}},
+		}, map[string]items.FileDiffData{"a.go": codeDiff, "c.go": codeDiff}))
+	}
+	// "c.go" gets the comment updated
+	updated := map[uint32]string{1: "// Foo does Z", 2: "// and Y", 20: "// Bar"}
+	stale.Consume(fixtureStaleCommentsDeps(250, []uast_items.Change{
+		{Before: fixtureStaleCommentsUAST(comments), After: fixtureStaleCommentsUAST(updated),
+			Change: &object.Change{From: entry("c.go"), To: entry("c.go")}},
+	}, map[string]items.FileDiffData{"c.go": {Diffs: []diffmatchpatch.Diff{
+		{Type: diffmatchpatch.DiffDelete, Text: "a"},
+		{Type: diffmatchpatch.DiffInsert, Text: "z"},
+		{Type: diffmatchpatch.DiffEqual, Text: strings.Repeat("y", 23)},
+	}}}))
+	// "a.go" is renamed with the code change
+	stale.Consume(fixtureStaleCommentsDeps(300, []uast_items.Change{
+		{Before: fixtureStaleCommentsUAST(comments), After: fixtureStaleCommentsUAST(comments),
+			Change: &object.Change{From: entry("a.go"), To: entry("b.go")}},
+		{Before: fixtureStaleCommentsUAST(updated), After: fixtureStaleCommentsUAST(updated),
+			Change: &object.Change{From: entry("c.go"), To: entry("c.go")}},
+	}, map[string]items.FileDiffData{"b.go": codeDiff, "c.go": codeDiff}))
+	assert.Len(t, stale.files, 2)
+	res := stale.Finalize().(StaleCommentsResult)
+	assert.Equal(t, res.Comments, []StaleComment{{
+		File: "b.go", Line: 1, Comment: "// Foo does X\n// and Y", Since: 0, Changes: 3,
+		LastChange: 300,
+	}})
+	stale.MinChanges = 1
+	stale.MinDays = 0
+	res = stale.Finalize().(StaleCommentsResult)
+	assert.Len(t, res.Comments, 2)
+	assert.Equal(t, res.Comments[1].File, "c.go")
+	assert.Equal(t, res.Comments[1].Since, 250)
+	// deleted files are forgotten
+	stale.Consume(fixtureStaleCommentsDeps(301, []uast_items.Change{
+		{Before: fixtureStaleCommentsUAST(updated), Change: &object.Change{From: entry("c.go")}},
+	}, nil))
+	assert.Len(t, stale.files, 1)
+}
+
+func TestStaleCommentsSerializeText(t *testing.T) {
+	stale := fixtureStaleComments()
+	res := StaleCommentsResult{Comments: []StaleComment{{
+		File: "b.go", Line: 1, Comment: "// Foo does X\n// and Y", Since: 0, Changes: 3,
+		LastChange: 300}}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, stale.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  comments:
+  - {file: "b.go", line: 1, since: 0, changes: 3, last_change: 300, comment: "// Foo does X\n// and Y"}
+`)
+}
+
+func TestStaleCommentsSerializeBinary(t *testing.T) {
+	stale := fixtureStaleComments()
+	res := StaleCommentsResult{Comments: []StaleComment{{
+		File: "b.go", Line: 1, Comment: "// Foo", Since: 2, Changes: 3, LastChange: 300}}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, stale.Serialize(res, true, buffer))
+	msg := pb.StaleCommentsResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Comments, 1)
+	assert.Equal(t, *msg.Comments[0], pb.StaleComment{
+		File: "b.go", Line: 1, Comment: "// Foo", Since: 2, Changes: 3, LastChange: 300})
+}
@@ -0,0 +1,361 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// StringLiteralsAnalysis extracts the string literals from UASTs and tracks the set of unique
+// strings which exist in the repository. It reports which strings were added and removed
+// between each pair of consecutive releases (tags). This is useful to estimate the translation
+// load if the strings are user-facing or are i18n keys.
+// It is a LeafPipelineItem.
+type StringLiteralsAnalysis struct {
+	// Pattern is the regular expression which the literals must match to be tracked.
+	// Empty pattern matches everything.
+	Pattern string
+
+	pattern *regexp.Regexp
+	// tags maps commit hashes to the names of the tags which point to them.
+	tags map[plumbing.Hash]string
+	// files maps the file name to the literals inside and their counts.
+	files map[string]map[string]int
+	// totals maps the literals to their counts in the whole repository.
+	totals map[string]int
+	// added is the set of literals which appeared since the last release.
+	added map[string]bool
+	// removed is the set of literals which disappeared since the last release.
+	removed map[string]bool
+	// releases is the list of already finished releases.
+	releases []StringLiteralsRelease
+	// day is the index of the last consumed day.
+	day int
+}
+
+// StringLiteralsRelease is the summary of the string literals changes in a release.
+type StringLiteralsRelease struct {
+	// Name is the tag name or "HEAD" for the unreleased changes.
+	Name string
+	// Day is the index of the day when the release happened.
+	Day int
+	// Total is the number of unique literals in the release.
+	Total int
+	// Added are the literals which appeared since the previous release.
+	Added []string
+	// Removed are the literals which disappeared since the previous release.
+	Removed []string
+}
+
+// StringLiteralsResult is returned by StringLiteralsAnalysis.Finalize() and carries
+// the string literals changes per release.
+type StringLiteralsResult struct {
+	Releases []StringLiteralsRelease
+}
+
+const (
+	// ConfigStringLiteralsPattern is the name of the option to set StringLiteralsAnalysis.Pattern.
+	ConfigStringLiteralsPattern = "StringLiterals.Pattern"
+	// stringLiteralsUnreleased is the name of the pseudo release which contains the changes
+	// after the last tag.
+	stringLiteralsUnreleased = "HEAD"
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (literals *StringLiteralsAnalysis) Name() string {
+	return "StringLiterals"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (literals *StringLiteralsAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (literals *StringLiteralsAnalysis) Requires() []string {
+	arr := [...]string{uast_items.DependencyUastChanges, items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (literals *StringLiteralsAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (literals *StringLiteralsAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigStringLiteralsPattern,
+		Description: "Regular expression which the string literals must match, e.g. i18n keys.",
+		Flag:        "string-literals-pattern",
+		Type:        core.StringConfigurationOption,
+		Default:     ""},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (literals *StringLiteralsAnalysis) Flag() string {
+	return "string-literals"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (literals *StringLiteralsAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigStringLiteralsPattern].(string); exists {
+		literals.Pattern = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (literals *StringLiteralsAnalysis) Initialize(repository *git.Repository) {
+	literals.pattern = nil
+	if literals.Pattern != "" {
+		var err error
+		literals.pattern, err = regexp.Compile(literals.Pattern)
+		if err != nil {
+			log.Printf("Warning: invalid string literals pattern %s: %v\n", literals.Pattern, err)
+		}
+	}
+	literals.tags = map[plumbing.Hash]string{}
+	if repository != nil {
+		literals.loadTags(repository)
+	}
+	literals.files = map[string]map[string]int{}
+	literals.totals = map[string]int{}
+	literals.added = map[string]bool{}
+	literals.removed = map[string]bool{}
+	literals.releases = []StringLiteralsRelease{}
+	literals.day = 0
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (literals *StringLiteralsAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	literals.day = deps[items.DependencyDay].(int)
+	for _, change := range changes {
+		if name := change.Change.From.Name; name != "" {
+			for literal, count := range literals.files[name] {
+				literals.update(literal, -count)
+			}
+			delete(literals.files, name)
+		}
+		if change.After != nil {
+			fileLiterals := literals.extract(change.After)
+			for literal, count := range fileLiterals {
+				literals.update(literal, count)
+			}
+			literals.files[change.Change.To.Name] = fileLiterals
+		}
+	}
+	if tag, exists := literals.tags[commit.Hash]; exists {
+		literals.release(tag)
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (literals *StringLiteralsAnalysis) Finalize() interface{} {
+	releases := literals.releases
+	if len(literals.added) > 0 || len(literals.removed) > 0 {
+		releases = append(releases, literals.makeRelease(stringLiteralsUnreleased))
+	}
+	return StringLiteralsResult{Releases: releases}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (literals *StringLiteralsAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	literalsResult := result.(StringLiteralsResult)
+	if binary {
+		return literals.serializeBinary(&literalsResult, writer)
+	}
+	literals.serializeText(&literalsResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (literals *StringLiteralsAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(literals, result, &pb.StringLiteralsResults{}, writer)
+}
+
+func (literals *StringLiteralsAnalysis) serializeText(result *StringLiteralsResult, writer io.Writer) {
+	safeStrings := func(strs []string) string {
+		quoted := make([]string, len(strs))
+		for i, str := range strs {
+			quoted[i] = yaml.SafeString(str)
+		}
+		return strings.Join(quoted, ", ")
+	}
+	for _, release := range result.Releases {
+		fmt.Fprintf(writer, "  - name: %s\n", yaml.SafeString(release.Name))
+		fmt.Fprintf(writer, "    day: %d\n", release.Day)
+		fmt.Fprintf(writer, "    total: %d\n", release.Total)
+		fmt.Fprintf(writer, "    added: [%s]\n", safeStrings(release.Added))
+		fmt.Fprintf(writer, "    removed: [%s]\n", safeStrings(release.Removed))
+	}
+}
+
+func (literals *StringLiteralsAnalysis) serializeBinary(result *StringLiteralsResult, writer io.Writer) error {
+	message := pb.StringLiteralsResults{
+		Releases: make([]*pb.StringLiteralsRelease, len(result.Releases)),
+	}
+	for i, release := range result.Releases {
+		message.Releases[i] = &pb.StringLiteralsRelease{
+			Name:    release.Name,
+			Day:     int32(release.Day),
+			Total:   int32(release.Total),
+			Added:   release.Added,
+			Removed: release.Removed,
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func (literals *StringLiteralsAnalysis) loadTags(repository *git.Repository) {
+	iter, err := repository.Tags()
+	if err != nil {
+		log.Printf("Warning: failed to list the tags: %v\n", err)
+		return
+	}
+	iter.ForEach(func(ref *plumbing.Reference) error {
+		hash := ref.Hash()
+		if tag, err := repository.TagObject(hash); err == nil {
+			// annotated tag
+			commit, err := tag.Commit()
+			if err != nil {
+				return nil
+			}
+			hash = commit.Hash
+		}
+		literals.tags[hash] = ref.Name().Short()
+		return nil
+	})
+}
+
+// extract returns the string literals in the UAST and their counts.
+func (literals *StringLiteralsAnalysis) extract(root *uast.Node) map[string]int {
+	result := map[string]int{}
+	visitStringLiterals(root, func(literal string) {
+		if literals.pattern != nil && !literals.pattern.MatchString(literal) {
+			return
+		}
+		result[literal]++
+	})
+	return result
+}
+
+// visitStringLiterals calls the callback for each non-empty string literal in the UAST.
+// The surrounding quotes are removed.
+func visitStringLiterals(root *uast.Node, callback func(literal string)) {
+	uast_items.VisitEachNode(root, func(node *uast.Node) {
+		var isLiteral, isString bool
+		for _, role := range node.Roles {
+			switch role {
+			case uast.Literal:
+				isLiteral = true
+			case uast.String:
+				isString = true
+			}
+		}
+		if !isLiteral || !isString {
+			return
+		}
+		if literal := trimStringQuotes(node.Token); literal != "" {
+			callback(literal)
+		}
+	})
+}
+
+// update changes the repository-wide count of the literal and records if it appeared
+// or disappeared.
+func (literals *StringLiteralsAnalysis) update(literal string, delta int) {
+	before := literals.totals[literal]
+	after := before + delta
+	if after == 0 {
+		delete(literals.totals, literal)
+	} else {
+		literals.totals[literal] = after
+	}
+	if before == 0 && after > 0 {
+		if literals.removed[literal] {
+			delete(literals.removed, literal)
+		} else {
+			literals.added[literal] = true
+		}
+	} else if before > 0 && after == 0 {
+		if literals.added[literal] {
+			delete(literals.added, literal)
+		} else {
+			literals.removed[literal] = true
+		}
+	}
+}
+
+func (literals *StringLiteralsAnalysis) release(name string) {
+	literals.releases = append(literals.releases, literals.makeRelease(name))
+	literals.added = map[string]bool{}
+	literals.removed = map[string]bool{}
+}
+
+func (literals *StringLiteralsAnalysis) makeRelease(name string) StringLiteralsRelease {
+	sortedKeys := func(set map[string]bool) []string {
+		keys := make([]string, 0, len(set))
+		for key := range set {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	return StringLiteralsRelease{
+		Name:    name,
+		Day:     literals.day,
+		Total:   len(literals.totals),
+		Added:   sortedKeys(literals.added),
+		Removed: sortedKeys(literals.removed),
+	}
+}
+
+// trimStringQuotes removes the quotes around the string literal token if they exist.
+func trimStringQuotes(token string) string {
+	if len(token) >= 2 {
+		first, last := token[0], token[len(token)-1]
+		if first == last && (first == '"' || first == '\'' || first == '`') {
+			return token[1 : len(token)-1]
+		}
+	}
+	return token
+}
+
+func init() {
+	core.Registry.Register(&StringLiteralsAnalysis{})
+}
@@ -0,0 +1,148 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureStringLiterals() *StringLiteralsAnalysis {
+	literals := StringLiteralsAnalysis{}
+	literals.Initialize(test.Repository)
+	return &literals
+}
+
+func fixtureStringLiteralsUAST(tokens ...string) *uast.Node {
+	root := &uast.Node{Roles: []uast.Role{uast.File}}
+	for _, token := range tokens {
+		root.Children = append(root.Children, &uast.Node{
+			Token: token, Roles: []uast.Role{uast.Expression, uast.Literal, uast.String}})
+	}
+	root.Children = append(root.Children, &uast.Node{
+		Token: "42", Roles: []uast.Role{uast.Expression, uast.Literal, uast.Number}})
+	return root
+}
+
+func TestStringLiteralsMeta(t *testing.T) {
+	literals := fixtureStringLiterals()
+	assert.Equal(t, literals.Name(), "StringLiterals")
+	assert.Len(t, literals.Provides(), 0)
+	assert.Equal(t, literals.Requires(), []string{uast_items.DependencyUastChanges, items.DependencyDay})
+	assert.Equal(t, literals.Features(), []string{uast_items.FeatureUast})
+	opts := literals.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigStringLiteralsPattern)
+	assert.Equal(t, literals.Flag(), "string-literals")
+	literals.Configure(map[string]interface{}{ConfigStringLiteralsPattern: `^msg\.`})
+	assert.Equal(t, literals.Pattern, `^msg\.`)
+	literals.Initialize(test.Repository)
+	assert.NotNil(t, literals.pattern)
+}
+
+func TestStringLiteralsRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&StringLiteralsAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "StringLiterals")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&StringLiteralsAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestStringLiteralsExtract(t *testing.T) {
+	literals := fixtureStringLiterals()
+	extracted := literals.extract(fixtureStringLiteralsUAST(`"hello"`, "'hello'", "`raw`", `""`, "msg.title"))
+	assert.Equal(t, extracted, map[string]int{"hello": 2, "raw": 1, "msg.title": 1})
+	literals.Pattern = `^msg\.`
+	literals.Initialize(test.Repository)
+	extracted = literals.extract(fixtureStringLiteralsUAST(`"hello"`, `"msg.title"`))
+	assert.Equal(t, extracted, map[string]int{"msg.title": 1})
+}
+
+func TestStringLiteralsConsume(t *testing.T) {
+	literals := fixtureStringLiterals()
+	tagged := plumbing.NewHash("cce947b98a050c6d356bc6ba95030254914027b1")
+	literals.tags = map[plumbing.Hash]string{tagged: "v1.0.0"}
+	deps := map[string]interface{}{}
+	deps["commit"] = &object.Commit{Hash: plumbing.NewHash("a3ee37f91f0d705ec9c41ae88426f0ae44b2fbc3")}
+	deps[items.DependencyDay] = 0
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{After: fixtureStringLiteralsUAST(`"one"`, `"two"`), Change: &object.Change{
+			To: object.ChangeEntry{Name: "a.go"}}},
+		{After: fixtureStringLiteralsUAST(`"two"`), Change: &object.Change{
+			To: object.ChangeEntry{Name: "b.go"}}},
+	}
+	result, err := literals.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps["commit"] = &object.Commit{Hash: tagged}
+	deps[items.DependencyDay] = 2
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: fixtureStringLiteralsUAST(`"one"`, `"two"`),
+			After: fixtureStringLiteralsUAST(`"three"`), Change: &object.Change{
+				From: object.ChangeEntry{Name: "a.go"}, To: object.ChangeEntry{Name: "a.go"}}},
+	}
+	result, err = literals.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps["commit"] = &object.Commit{Hash: plumbing.NewHash("0000000000000000000000000000000000000001")}
+	deps[items.DependencyDay] = 5
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: fixtureStringLiteralsUAST(`"two"`), Change: &object.Change{
+			From: object.ChangeEntry{Name: "b.go"}}},
+		{After: fixtureStringLiteralsUAST(`"four"`), Change: &object.Change{
+			To: object.ChangeEntry{Name: "c.go"}}},
+	}
+	literals.Consume(deps)
+	res := literals.Finalize().(StringLiteralsResult)
+	assert.Len(t, res.Releases, 2)
+	assert.Equal(t, res.Releases[0], StringLiteralsRelease{
+		Name: "v1.0.0", Day: 2, Total: 2, Added: []string{"three", "two"}, Removed: []string{}})
+	assert.Equal(t, res.Releases[1], StringLiteralsRelease{
+		Name: "HEAD", Day: 5, Total: 2, Added: []string{"four"}, Removed: []string{"two"}})
+}
+
+func TestStringLiteralsSerialize(t *testing.T) {
+	literals := fixtureStringLiterals()
+	res := StringLiteralsResult{Releases: []StringLiteralsRelease{
+		{Name: "v1", Day: 3, Total: 2, Added: []string{"a", "b"}, Removed: []string{}},
+		{Name: "HEAD", Day: 7, Total: 1, Added: []string{}, Removed: []string{"a"}},
+	}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, literals.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  - name: "v1"
+    day: 3
+    total: 2
+    added: ["a", "b"]
+    removed: []
+  - name: "HEAD"
+    day: 7
+    total: 1
+    added: []
+    removed: ["a"]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, literals.Serialize(res, true, buffer))
+	msg := pb.StringLiteralsResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Releases, 2)
+	assert.Equal(t, msg.Releases[0].Name, "v1")
+	assert.Equal(t, msg.Releases[0].Added, []string{"a", "b"})
+	assert.Equal(t, msg.Releases[1].Day, int32(7))
+	assert.Equal(t, msg.Releases[1].Removed, []string{"a"})
+}
@@ -0,0 +1,298 @@
+package leaves
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/enry.v1"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// StyleDriftAnalysis tracks the code style statistics per language over time: the distribution
+// of line lengths, the number of lines indented with tabs and with spaces and the number of lines
+// with trailing whitespace. This way it is possible to measure how a style guide is adopted.
+// It is a LeafPipelineItem.
+type StyleDriftAnalysis struct {
+	// files maps the file name to the style statistics of its current contents.
+	files map[string]StyleStats
+	// languages maps the file name to the language of the file.
+	languages map[string]string
+	// totals are the current style statistics of every language.
+	totals map[string]StyleStats
+	// history maps days to the snapshots of totals.
+	history map[int]map[string]StyleStats
+}
+
+const (
+	// StyleLineLengthBucket is the width of each line length histogram bucket in characters.
+	StyleLineLengthBucket = 10
+	// StyleLineLengthBuckets is the number of line length histogram buckets. The last bucket
+	// contains all the lines which are longer than the rest.
+	StyleLineLengthBuckets = 16
+)
+
+// StyleStats are the code style statistics of a file or a group of files.
+type StyleStats struct {
+	// Lines is the number of lines.
+	Lines int64
+	// TabIndented is the number of lines which begin with a tab.
+	TabIndented int64
+	// SpaceIndented is the number of lines which begin with a space.
+	SpaceIndented int64
+	// TrailingWhitespace is the number of lines which end with a whitespace character.
+	TrailingWhitespace int64
+	// LineLengths is the histogram of line lengths, see StyleLineLengthBucket.
+	LineLengths [StyleLineLengthBuckets]int64
+}
+
+// StyleDriftResult is returned by StyleDriftAnalysis.Finalize() and carries the style statistics
+// of every language for each day when there were changes.
+type StyleDriftResult struct {
+	// Days maps the day index to the language -> style statistics mapping.
+	Days map[int]map[string]StyleStats
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (drift *StyleDriftAnalysis) Name() string {
+	return "StyleDrift"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (drift *StyleDriftAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (drift *StyleDriftAnalysis) Requires() []string {
+	arr := [...]string{items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (drift *StyleDriftAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (drift *StyleDriftAnalysis) Flag() string {
+	return "style-drift"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (drift *StyleDriftAnalysis) Configure(facts map[string]interface{}) {
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (drift *StyleDriftAnalysis) Initialize(repository *git.Repository) {
+	drift.files = map[string]StyleStats{}
+	drift.languages = map[string]string{}
+	drift.totals = map[string]StyleStats{}
+	drift.history = map[int]map[string]StyleStats{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (drift *StyleDriftAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	day := deps[items.DependencyDay].(int)
+	if len(treeDiff) == 0 {
+		return nil, nil
+	}
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		if action != merkletrie.Insert {
+			drift.removeFile(change.From.Name)
+		}
+		if action == merkletrie.Delete {
+			continue
+		}
+		contents, err := items.BlobToString(cache[change.To.TreeEntry.Hash])
+		if err != nil {
+			return nil, err
+		}
+		if !utf8.ValidString(contents) {
+			// binary
+			continue
+		}
+		drift.addFile(change.To.Name, MeasureStyle(contents))
+	}
+	snapshot := map[string]StyleStats{}
+	for lang, stats := range drift.totals {
+		if stats.Lines > 0 {
+			snapshot[lang] = stats
+		}
+	}
+	drift.history[day] = snapshot
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (drift *StyleDriftAnalysis) Finalize() interface{} {
+	return StyleDriftResult{Days: drift.history}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (drift *StyleDriftAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	driftResult := result.(StyleDriftResult)
+	if binary {
+		return drift.serializeBinary(&driftResult, writer)
+	}
+	drift.serializeText(&driftResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (drift *StyleDriftAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(drift, result, &pb.StyleDriftResults{}, writer)
+}
+
+func (drift *StyleDriftAnalysis) serializeText(result *StyleDriftResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  line_length_bucket:", StyleLineLengthBucket)
+	fmt.Fprintln(writer, "  days:")
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	for _, day := range days {
+		fmt.Fprintf(writer, "    %d:\n", day)
+		langs := result.Days[day]
+		keys := make([]string, 0, len(langs))
+		for lang := range langs {
+			keys = append(keys, lang)
+		}
+		sort.Strings(keys)
+		for _, lang := range keys {
+			stats := langs[lang]
+			lengths := make([]string, len(stats.LineLengths))
+			for i, count := range stats.LineLengths {
+				lengths[i] = fmt.Sprint(count)
+			}
+			fmt.Fprintf(writer, "      %s: {lines: %d, tabs: %d, spaces: %d, "+
+				"trailing_whitespace: %d, line_lengths: [%s]}\n",
+				yaml.SafeString(lang), stats.Lines, stats.TabIndented, stats.SpaceIndented,
+				stats.TrailingWhitespace, strings.Join(lengths, ", "))
+		}
+	}
+}
+
+func (drift *StyleDriftAnalysis) serializeBinary(result *StyleDriftResult, writer io.Writer) error {
+	message := pb.StyleDriftResults{
+		LineLengthBucket: StyleLineLengthBucket,
+		Days:             map[int32]*pb.LanguageStyleStats{},
+	}
+	for day, langs := range result.Days {
+		pbLangs := &pb.LanguageStyleStats{
+			Languages: map[string]*pb.StyleStats{},
+		}
+		for lang, stats := range langs {
+			pbLangs.Languages[lang] = &pb.StyleStats{
+				Lines:              stats.Lines,
+				TabIndented:        stats.TabIndented,
+				SpaceIndented:      stats.SpaceIndented,
+				TrailingWhitespace: stats.TrailingWhitespace,
+				LineLengths:        append([]int64{}, stats.LineLengths[:]...),
+			}
+		}
+		message.Days[int32(day)] = pbLangs
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func (drift *StyleDriftAnalysis) addFile(name string, stats StyleStats) {
+	lang, _ := enry.GetLanguageByExtension(name)
+	if lang == "" {
+		lang = "Other"
+	}
+	drift.files[name] = stats
+	drift.languages[name] = lang
+	drift.totals[lang] = addStyleStats(drift.totals[lang], stats, 1)
+}
+
+func (drift *StyleDriftAnalysis) removeFile(name string) {
+	stats, exists := drift.files[name]
+	if !exists {
+		return
+	}
+	lang := drift.languages[name]
+	drift.totals[lang] = addStyleStats(drift.totals[lang], stats, -1)
+	delete(drift.files, name)
+	delete(drift.languages, name)
+}
+
+// addStyleStats returns a + sign * b.
+func addStyleStats(a, b StyleStats, sign int64) StyleStats {
+	a.Lines += sign * b.Lines
+	a.TabIndented += sign * b.TabIndented
+	a.SpaceIndented += sign * b.SpaceIndented
+	a.TrailingWhitespace += sign * b.TrailingWhitespace
+	for i, count := range b.LineLengths {
+		a.LineLengths[i] += sign * count
+	}
+	return a
+}
+
+// MeasureStyle calculates the code style statistics of the text.
+func MeasureStyle(contents string) StyleStats {
+	stats := StyleStats{}
+	scanner := bufio.NewScanner(strings.NewReader(contents))
+	scanner.Buffer(make([]byte, bufio.MaxScanTokenSize), len(contents)+1)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		stats.Lines++
+		if line == "" {
+			stats.LineLengths[0]++
+			continue
+		}
+		switch line[0] {
+		case '\t':
+			stats.TabIndented++
+		case ' ':
+			stats.SpaceIndented++
+		}
+		switch line[len(line)-1] {
+		case ' ', '\t':
+			stats.TrailingWhitespace++
+		}
+		bucket := utf8.RuneCountInString(line) / StyleLineLengthBucket
+		if bucket >= StyleLineLengthBuckets {
+			bucket = StyleLineLengthBuckets - 1
+		}
+		stats.LineLengths[bucket]++
+	}
+	return stats
+}
+
+func init() {
+	core.Registry.Register(&StyleDriftAnalysis{})
+}
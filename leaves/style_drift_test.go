@@ -0,0 +1,134 @@
+package leaves
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureStyleDrift() *StyleDriftAnalysis {
+	drift := StyleDriftAnalysis{}
+	drift.Initialize(test.Repository)
+	return &drift
+}
+
+func TestStyleDriftMeta(t *testing.T) {
+	drift := fixtureStyleDrift()
+	assert.Equal(t, drift.Name(), "StyleDrift")
+	assert.Len(t, drift.Provides(), 0)
+	assert.Equal(t, drift.Requires(), []string{
+		items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyDay})
+	assert.Len(t, drift.ListConfigurationOptions(), 0)
+	assert.Equal(t, drift.Flag(), "style-drift")
+	drift.Configure(nil)
+}
+
+func TestStyleDriftRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&StyleDriftAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "StyleDrift")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&StyleDriftAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestMeasureStyle(t *testing.T) {
+	stats := MeasureStyle("func main() {\n\treturn \n}\n\n  " + strings.Repeat("x", 500) + "\r\n")
+	assert.Equal(t, stats.Lines, int64(5))
+	assert.Equal(t, stats.TabIndented, int64(1))
+	assert.Equal(t, stats.SpaceIndented, int64(1))
+	assert.Equal(t, stats.TrailingWhitespace, int64(1))
+	assert.Equal(t, stats.LineLengths[0], int64(3))
+	assert.Equal(t, stats.LineLengths[1], int64(1))
+	assert.Equal(t, stats.LineLengths[StyleLineLengthBuckets-1], int64(1))
+	assert.Equal(t, MeasureStyle(""), StyleStats{})
+}
+
+func TestStyleDriftConsume(t *testing.T) {
+	drift := fixtureStyleDrift()
+	deps := map[string]interface{}{}
+	cache := map[plumbing.Hash]*object.Blob{}
+	for _, hash := range []string{
+		"291286b4ac41952cbd1389fda66420ec03c1a9fe", "baa64828831d174f40140e4b3cfa77d1e917a2c1",
+		"dc248ba2b22048cc730c571a748e8ffcf7085ab9"} {
+		cache[plumbing.NewHash(hash)], _ = test.Repository.BlobObject(plumbing.NewHash(hash))
+	}
+	deps[items.DependencyBlobCache] = cache
+	deps[items.DependencyDay] = 0
+	deps[items.DependencyTreeChanges] = object.Changes{
+		&object.Change{To: object.ChangeEntry{Name: "analyser.go", TreeEntry: object.TreeEntry{
+			Name: "analyser.go", Hash: plumbing.NewHash("dc248ba2b22048cc730c571a748e8ffcf7085ab9")}}},
+		&object.Change{To: object.ChangeEntry{Name: ".travis.yml", TreeEntry: object.TreeEntry{
+			Name: ".travis.yml", Hash: plumbing.NewHash("291286b4ac41952cbd1389fda66420ec03c1a9fe")}}},
+	}
+	result, err := drift.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	assert.Len(t, drift.files, 2)
+	goStats := drift.totals["Go"]
+	assert.True(t, goStats.Lines > 0)
+	assert.True(t, goStats.TabIndented > 0)
+	deps[items.DependencyDay] = 3
+	deps[items.DependencyTreeChanges] = object.Changes{
+		test.FakeChangeForName("analyser.go",
+			"dc248ba2b22048cc730c571a748e8ffcf7085ab9", "baa64828831d174f40140e4b3cfa77d1e917a2c1"),
+		&object.Change{From: object.ChangeEntry{Name: ".travis.yml", TreeEntry: object.TreeEntry{
+			Name: ".travis.yml", Hash: plumbing.NewHash("291286b4ac41952cbd1389fda66420ec03c1a9fe")}}},
+	}
+	result, err = drift.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	res := drift.Finalize().(StyleDriftResult)
+	assert.Len(t, res.Days, 2)
+	assert.Len(t, res.Days[0], 2)
+	assert.Len(t, res.Days[3], 1)
+	assert.Equal(t, res.Days[0]["Go"], goStats)
+	assert.Equal(t, res.Days[3]["Go"], drift.files["analyser.go"])
+}
+
+func TestStyleDriftSerialize(t *testing.T) {
+	drift := fixtureStyleDrift()
+	stats := StyleStats{Lines: 10, TabIndented: 4, SpaceIndented: 1, TrailingWhitespace: 2}
+	stats.LineLengths[0] = 6
+	stats.LineLengths[2] = 4
+	res := StyleDriftResult{Days: map[int]map[string]StyleStats{
+		7: {"Go": stats},
+		1: {"Python": {Lines: 1, LineLengths: [StyleLineLengthBuckets]int64{1}}},
+	}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, drift.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  line_length_bucket: 10
+  days:
+    1:
+      "Python": {lines: 1, tabs: 0, spaces: 0, trailing_whitespace: 0, line_lengths: [1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0]}
+    7:
+      "Go": {lines: 10, tabs: 4, spaces: 1, trailing_whitespace: 2, line_lengths: [6, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0]}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, drift.Serialize(res, true, buffer))
+	msg := pb.StyleDriftResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.LineLengthBucket, int32(StyleLineLengthBucket))
+	assert.Len(t, msg.Days, 2)
+	goMsg := msg.Days[7].Languages["Go"]
+	assert.Equal(t, goMsg.Lines, int64(10))
+	assert.Equal(t, goMsg.TabIndented, int64(4))
+	assert.Equal(t, goMsg.TrailingWhitespace, int64(2))
+	assert.Len(t, goMsg.LineLengths, StyleLineLengthBuckets)
+	assert.Equal(t, goMsg.LineLengths[2], int64(4))
+}
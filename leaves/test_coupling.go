@@ -0,0 +1,240 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// TestCouplingAnalysis measures how often the tests are updated together with the code they
+// cover. Test files are mapped to the production files by the naming conventions, e.g.
+// "foo_test.go" -> "foo.go", "test_foo.py" -> "foo.py", "FooTest.java" -> "Foo.java" or
+// "foo.spec.js" -> "foo.js". Only the production files which have tests are considered.
+// The results are reported per directory per day.
+// It is a LeafPipelineItem.
+type TestCouplingAnalysis struct {
+	// tests maps the production file keys to the number of existing test files for them.
+	tests map[string]int
+	// history maps days to the directory -> co-change statistics mapping.
+	history map[int]map[string]TestCoChange
+}
+
+// TestCoChange is the number of production file changes and how many of them were accompanied
+// by test changes.
+type TestCoChange struct {
+	// Changed is the number of changed production files which have tests.
+	Changed int
+	// WithTests is the number of those changes which updated the tests in the same commit.
+	WithTests int
+}
+
+// TestCouplingResult is returned by TestCouplingAnalysis.Finalize() and carries the test/code
+// co-change statistics per directory per day.
+type TestCouplingResult struct {
+	// Days maps the day index to the directory -> co-change statistics mapping.
+	Days map[int]map[string]TestCoChange
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (coupling *TestCouplingAnalysis) Name() string {
+	return "TestCoupling"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (coupling *TestCouplingAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (coupling *TestCouplingAnalysis) Requires() []string {
+	arr := [...]string{items.DependencyTreeChanges, items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (coupling *TestCouplingAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (coupling *TestCouplingAnalysis) Flag() string {
+	return "test-coupling"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (coupling *TestCouplingAnalysis) Configure(facts map[string]interface{}) {
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (coupling *TestCouplingAnalysis) Initialize(repository *git.Repository) {
+	coupling.tests = map[string]int{}
+	coupling.history = map[int]map[string]TestCoChange{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (coupling *TestCouplingAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	day := deps[items.DependencyDay].(int)
+	changedTests := map[string]bool{}
+	var changedCode []string
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		if action != merkletrie.Insert {
+			if key, isTest := CoveredFileKey(change.From.Name); isTest {
+				coupling.tests[key]--
+				if coupling.tests[key] <= 0 {
+					delete(coupling.tests, key)
+				}
+			}
+		}
+		if action == merkletrie.Delete {
+			continue
+		}
+		if key, isTest := CoveredFileKey(change.To.Name); isTest {
+			coupling.tests[key]++
+			changedTests[key] = true
+		} else {
+			changedCode = append(changedCode, change.To.Name)
+		}
+	}
+	for _, name := range changedCode {
+		key := productionFileKey(name)
+		if coupling.tests[key] == 0 {
+			continue
+		}
+		dirs := coupling.history[day]
+		if dirs == nil {
+			dirs = map[string]TestCoChange{}
+			coupling.history[day] = dirs
+		}
+		dir := path.Dir(name)
+		stats := dirs[dir]
+		stats.Changed++
+		if changedTests[key] {
+			stats.WithTests++
+		}
+		dirs[dir] = stats
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (coupling *TestCouplingAnalysis) Finalize() interface{} {
+	return TestCouplingResult{Days: coupling.history}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (coupling *TestCouplingAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	couplingResult := result.(TestCouplingResult)
+	if binary {
+		return coupling.serializeBinary(&couplingResult, writer)
+	}
+	coupling.serializeText(&couplingResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (coupling *TestCouplingAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(coupling, result, &pb.TestCouplingResults{}, writer)
+}
+
+func (coupling *TestCouplingAnalysis) serializeText(result *TestCouplingResult, writer io.Writer) {
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	for _, day := range days {
+		fmt.Fprintf(writer, "  %d:\n", day)
+		dirs := result.Days[day]
+		keys := make([]string, 0, len(dirs))
+		for dir := range dirs {
+			keys = append(keys, dir)
+		}
+		sort.Strings(keys)
+		for _, dir := range keys {
+			stats := dirs[dir]
+			fmt.Fprintf(writer, "    %s: {changed: %d, with_tests: %d}\n",
+				yaml.SafeString(dir), stats.Changed, stats.WithTests)
+		}
+	}
+}
+
+func (coupling *TestCouplingAnalysis) serializeBinary(result *TestCouplingResult, writer io.Writer) error {
+	message := pb.TestCouplingResults{
+		Days: map[int32]*pb.DirectoryTestCoChanges{},
+	}
+	for day, dirs := range result.Days {
+		pbDirs := &pb.DirectoryTestCoChanges{
+			Directories: map[string]*pb.TestCoChange{},
+		}
+		for dir, stats := range dirs {
+			pbDirs.Directories[dir] = &pb.TestCoChange{
+				Changed:   int32(stats.Changed),
+				WithTests: int32(stats.WithTests),
+			}
+		}
+		message.Days[int32(day)] = pbDirs
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+// CoveredFileKey determines whether the file is a test by the naming conventions. If it is,
+// the returned key is equal to productionFileKey() of the covered production file.
+func CoveredFileKey(name string) (string, bool) {
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(path.Base(name), ext)
+	for _, suffix := range [...]string{"_test", "_spec", ".test", ".spec", "Test", "Tests"} {
+		if strings.HasSuffix(stem, suffix) && len(stem) > len(suffix) {
+			return strings.ToLower(strings.TrimSuffix(stem, suffix) + ext), true
+		}
+	}
+	if strings.HasPrefix(stem, "test_") && len(stem) > len("test_") {
+		return strings.ToLower(strings.TrimPrefix(stem, "test_") + ext), true
+	}
+	// "TestFoo" but not "Testing"
+	if strings.HasPrefix(stem, "Test") && len(stem) > len("Test") &&
+		unicode.IsUpper(rune(stem[len("Test")])) {
+		return strings.ToLower(strings.TrimPrefix(stem, "Test") + ext), true
+	}
+	return "", false
+}
+
+// productionFileKey returns the key of the production file which matches the keys of its tests.
+func productionFileKey(name string) string {
+	return strings.ToLower(path.Base(name))
+}
+
+func init() {
+	core.Registry.Register(&TestCouplingAnalysis{})
+}
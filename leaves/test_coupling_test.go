@@ -0,0 +1,134 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureTestCoupling() *TestCouplingAnalysis {
+	coupling := TestCouplingAnalysis{}
+	coupling.Initialize(test.Repository)
+	return &coupling
+}
+
+func TestTestCouplingMeta(t *testing.T) {
+	coupling := fixtureTestCoupling()
+	assert.Equal(t, coupling.Name(), "TestCoupling")
+	assert.Len(t, coupling.Provides(), 0)
+	assert.Equal(t, coupling.Requires(), []string{items.DependencyTreeChanges, items.DependencyDay})
+	assert.Len(t, coupling.ListConfigurationOptions(), 0)
+	assert.Equal(t, coupling.Flag(), "test-coupling")
+	coupling.Configure(nil)
+}
+
+func TestTestCouplingRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&TestCouplingAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "TestCoupling")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&TestCouplingAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestCoveredFileKey(t *testing.T) {
+	for name, key := range map[string]string{
+		"leaves/burndown_test.go":         "burndown.go",
+		"tests/test_labours.py":           "labours.py",
+		"src/test/java/org/FooTest.java":  "foo.java",
+		"src/test/java/org/TestBar.java":  "bar.java",
+		"src/test/java/org/BazTests.java": "baz.java",
+		"web/app.spec.ts":                 "app.ts",
+		"web/app.test.js":                 "app.js",
+		"lib/parser_spec.rb":              "parser.rb",
+	} {
+		subject, isTest := CoveredFileKey(name)
+		assert.True(t, isTest, name)
+		assert.Equal(t, subject, key, name)
+	}
+	for _, name := range []string{"main.go", "test.go", "Testing.java", "latest.py", "_test.go"} {
+		_, isTest := CoveredFileKey(name)
+		assert.False(t, isTest, name)
+	}
+}
+
+func TestTestCouplingConsume(t *testing.T) {
+	coupling := fixtureTestCoupling()
+	deps := map[string]interface{}{}
+	deps[items.DependencyDay] = 0
+	deps[items.DependencyTreeChanges] = object.Changes{
+		&object.Change{To: object.ChangeEntry{Name: "pkg/a.go"}},
+		&object.Change{To: object.ChangeEntry{Name: "pkg/a_test.go"}},
+		&object.Change{To: object.ChangeEntry{Name: "pkg/b.go"}},
+	}
+	result, err := coupling.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps[items.DependencyDay] = 1
+	deps[items.DependencyTreeChanges] = object.Changes{
+		test.FakeChangeForName("pkg/a.go", "", ""),
+		test.FakeChangeForName("pkg/b.go", "", ""),
+	}
+	result, err = coupling.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps[items.DependencyDay] = 2
+	deps[items.DependencyTreeChanges] = object.Changes{
+		test.FakeChangeForName("pkg/a.go", "", ""),
+		test.FakeChangeForName("pkg/a_test.go", "", ""),
+		&object.Change{From: object.ChangeEntry{Name: "pkg/b.go"}},
+	}
+	coupling.Consume(deps)
+	deps[items.DependencyDay] = 3
+	deps[items.DependencyTreeChanges] = object.Changes{
+		&object.Change{From: object.ChangeEntry{Name: "pkg/a_test.go"}},
+	}
+	coupling.Consume(deps)
+	deps[items.DependencyDay] = 4
+	deps[items.DependencyTreeChanges] = object.Changes{
+		test.FakeChangeForName("pkg/a.go", "", ""),
+	}
+	coupling.Consume(deps)
+	res := coupling.Finalize().(TestCouplingResult)
+	assert.Len(t, res.Days, 3)
+	assert.Equal(t, res.Days[0], map[string]TestCoChange{"pkg": {Changed: 1, WithTests: 1}})
+	assert.Equal(t, res.Days[1], map[string]TestCoChange{"pkg": {Changed: 1, WithTests: 0}})
+	assert.Equal(t, res.Days[2], map[string]TestCoChange{"pkg": {Changed: 1, WithTests: 1}})
+}
+
+func TestTestCouplingSerialize(t *testing.T) {
+	coupling := fixtureTestCoupling()
+	res := TestCouplingResult{Days: map[int]map[string]TestCoChange{
+		2: {"pkg": {Changed: 3, WithTests: 1}, ".": {Changed: 1, WithTests: 1}},
+		0: {"pkg": {Changed: 1}},
+	}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, coupling.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  0:
+    "pkg": {changed: 1, with_tests: 0}
+  2:
+    ".": {changed: 1, with_tests: 1}
+    "pkg": {changed: 3, with_tests: 1}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, coupling.Serialize(res, true, buffer))
+	msg := pb.TestCouplingResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Days, 2)
+	assert.Equal(t, msg.Days[2].Directories["pkg"].Changed, int32(3))
+	assert.Equal(t, msg.Days[2].Directories["pkg"].WithTests, int32(1))
+	assert.Equal(t, msg.Days[0].Directories["pkg"].WithTests, int32(0))
+}
@@ -0,0 +1,262 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// TicketlessCommitsAnalysis finds the commits whose messages do not reference any issue and
+// reports their fraction per calendar month and per developer, which is a simple process
+// compliance metric. Merge commits are ignored.
+// It is a LeafPipelineItem.
+type TicketlessCommitsAnalysis struct {
+	// Patterns are the regular expressions of the issue references. If empty, the references
+	// are detected in the same way as in CommitMessagesAnalysis.
+	Patterns []string
+	// PeopleNumber is the number of developers for which to collect the stats.
+	PeopleNumber int
+
+	// patterns are the compiled Patterns.
+	patterns []*regexp.Regexp
+	// months maps the months ("2018-03") to the stats of the commits in that month.
+	months map[string]TicketlessStats
+	// people is the stats of each developer's commits.
+	// The last element corresponds to the authors which were not matched.
+	people []TicketlessStats
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// TicketlessStats are the numbers of commits with and without the issue references.
+type TicketlessStats struct {
+	// Commits is the number of commits.
+	Commits int
+	// Ticketless is the number of commits without any issue reference.
+	Ticketless int
+}
+
+// Ratio returns the fraction of the commits without any issue reference.
+func (stats TicketlessStats) Ratio() float64 {
+	if stats.Commits == 0 {
+		return 0
+	}
+	return float64(stats.Ticketless) / float64(stats.Commits)
+}
+
+// TicketlessCommitsResult is returned by TicketlessCommitsAnalysis.Finalize() and carries
+// the numbers of the commits without the issue references per month and per developer.
+type TicketlessCommitsResult struct {
+	// Months maps the month ("2018-03") to the stats of the commits in that month.
+	Months map[string]TicketlessStats
+	// People is the stats of each developer's commits, indexed by the developer's identity.
+	// The last element corresponds to the unmatched authors.
+	People []TicketlessStats
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigTicketlessCommitsPatterns is the name of the option to set
+	// TicketlessCommitsAnalysis.Patterns.
+	ConfigTicketlessCommitsPatterns = "TicketlessCommits.Patterns"
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (ticketless *TicketlessCommitsAnalysis) Name() string {
+	return "TicketlessCommits"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (ticketless *TicketlessCommitsAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (ticketless *TicketlessCommitsAnalysis) Requires() []string {
+	arr := [...]string{identity.DependencyAuthor}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (ticketless *TicketlessCommitsAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigTicketlessCommitsPatterns,
+		Description: "Regular expressions of the issue references. The default matches " +
+			"\"#123\", \"gh-123\", \"JIRA-123\" and the links to the issues and the pull requests.",
+		Flag:    "ticketless-patterns",
+		Type:    core.StringsConfigurationOption,
+		Default: []string{}},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (ticketless *TicketlessCommitsAnalysis) Flag() string {
+	return "ticketless-commits"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (ticketless *TicketlessCommitsAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigTicketlessCommitsPatterns].([]string); exists {
+		ticketless.Patterns = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		ticketless.PeopleNumber = val
+		ticketless.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (ticketless *TicketlessCommitsAnalysis) Initialize(repository *git.Repository) {
+	ticketless.patterns = make([]*regexp.Regexp, 0, len(ticketless.Patterns))
+	for _, pattern := range ticketless.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Printf("Invalid issue reference pattern: %v => ignored", err)
+			continue
+		}
+		ticketless.patterns = append(ticketless.patterns, re)
+	}
+	if len(ticketless.patterns) == 0 {
+		ticketless.patterns = append(ticketless.patterns, issueRefRE)
+	}
+	ticketless.months = map[string]TicketlessStats{}
+	ticketless.people = make([]TicketlessStats, ticketless.PeopleNumber+1)
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (ticketless *TicketlessCommitsAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	if commit.NumParents() > 1 {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing {
+		author = ticketless.PeopleNumber
+	}
+	referenced := ticketless.HasReference(commit.Message)
+	month := commit.Author.When.UTC().Format("2006-01")
+	monthStats := ticketless.months[month]
+	monthStats.add(referenced)
+	ticketless.months[month] = monthStats
+	ticketless.people[author].add(referenced)
+	return nil, nil
+}
+
+// HasReference checks whether the commit message references an issue.
+func (ticketless *TicketlessCommitsAnalysis) HasReference(message string) bool {
+	for _, re := range ticketless.patterns {
+		if re.MatchString(message) {
+			return true
+		}
+	}
+	return false
+}
+
+// add updates the statistics with one more commit.
+func (stats *TicketlessStats) add(referenced bool) {
+	stats.Commits++
+	if !referenced {
+		stats.Ticketless++
+	}
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (ticketless *TicketlessCommitsAnalysis) Finalize() interface{} {
+	return TicketlessCommitsResult{
+		Months:             ticketless.months,
+		People:             ticketless.people,
+		reversedPeopleDict: ticketless.reversedPeopleDict,
+	}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (ticketless *TicketlessCommitsAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	ticketlessResult := result.(TicketlessCommitsResult)
+	if binary {
+		return ticketless.serializeBinary(&ticketlessResult, writer)
+	}
+	ticketless.serializeText(&ticketlessResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (ticketless *TicketlessCommitsAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(ticketless, result, &pb.TicketlessCommitsResults{}, writer)
+}
+
+func (ticketless *TicketlessCommitsAnalysis) serializeText(result *TicketlessCommitsResult, writer io.Writer) {
+	formatStats := func(stats TicketlessStats) string {
+		return fmt.Sprintf("{commits: %d, ticketless: %d, ratio: %.4f}",
+			stats.Commits, stats.Ticketless, stats.Ratio())
+	}
+	fmt.Fprintln(writer, "  months:")
+	months := make([]string, 0, len(result.Months))
+	for month := range result.Months {
+		months = append(months, month)
+	}
+	sort.Strings(months)
+	for _, month := range months {
+		fmt.Fprintf(writer, "    %s: %s\n", yaml.SafeString(month), formatStats(result.Months[month]))
+	}
+	fmt.Fprintln(writer, "  people:")
+	for i, name := range result.reversedPeopleDict {
+		if i >= len(result.People) {
+			break
+		}
+		fmt.Fprintf(writer, "    %s: %s\n", yaml.SafeString(name), formatStats(result.People[i]))
+	}
+}
+
+func (ticketless *TicketlessCommitsAnalysis) serializeBinary(
+	result *TicketlessCommitsResult, writer io.Writer) error {
+	convertStats := func(stats TicketlessStats) *pb.TicketlessStats {
+		return &pb.TicketlessStats{
+			Commits:    int32(stats.Commits),
+			Ticketless: int32(stats.Ticketless),
+		}
+	}
+	message := pb.TicketlessCommitsResults{
+		Months:         map[string]*pb.TicketlessStats{},
+		People:         make([]*pb.TicketlessStats, len(result.People)),
+		PeopleSequence: result.reversedPeopleDict,
+	}
+	for month, stats := range result.Months {
+		message.Months[month] = convertStats(stats)
+	}
+	for i, stats := range result.People {
+		message.People[i] = convertStats(stats)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&TicketlessCommitsAnalysis{})
+}
@@ -0,0 +1,130 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureTicketlessCommits() *TicketlessCommitsAnalysis {
+	ticketless := TicketlessCommitsAnalysis{PeopleNumber: 2}
+	ticketless.Initialize(test.Repository)
+	return &ticketless
+}
+
+func TestTicketlessCommitsMeta(t *testing.T) {
+	ticketless := fixtureTicketlessCommits()
+	assert.Equal(t, ticketless.Name(), "TicketlessCommits")
+	assert.Len(t, ticketless.Provides(), 0)
+	assert.Equal(t, ticketless.Requires(), []string{identity.DependencyAuthor})
+	opts := ticketless.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigTicketlessCommitsPatterns)
+	assert.Equal(t, ticketless.Flag(), "ticketless-commits")
+	facts := map[string]interface{}{}
+	facts[ConfigTicketlessCommitsPatterns] = []string{`\bTASK\d+\b`}
+	facts[identity.FactIdentityDetectorPeopleCount] = 3
+	facts[identity.FactIdentityDetectorReversedPeopleDict] = []string{"one", "two", "three"}
+	ticketless.Configure(facts)
+	assert.Equal(t, ticketless.Patterns, []string{`\bTASK\d+\b`})
+	assert.Equal(t, ticketless.PeopleNumber, 3)
+	assert.Equal(t, ticketless.reversedPeopleDict, []string{"one", "two", "three"})
+}
+
+func TestTicketlessCommitsRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&TicketlessCommitsAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "TicketlessCommits")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&TicketlessCommitsAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestTicketlessCommitsHasReference(t *testing.T) {
+	ticketless := fixtureTicketlessCommits()
+	assert.True(t, ticketless.HasReference("Fix the bug\n\nFixes #42."))
+	assert.True(t, ticketless.HasReference("Add the flag (PROJ-17)"))
+	assert.False(t, ticketless.HasReference("Add the flag TASK17"))
+	assert.False(t, ticketless.HasReference("Use 10-20 workers"))
+	ticketless.Patterns = []string{`\bTASK\d+\b`, "("}
+	ticketless.Initialize(test.Repository)
+	assert.Len(t, ticketless.patterns, 1)
+	assert.True(t, ticketless.HasReference("Add the flag TASK17"))
+	assert.False(t, ticketless.HasReference("Fix the bug\n\nFixes #42."))
+	ticketless.Patterns = []string{"("}
+	ticketless.Initialize(test.Repository)
+	assert.True(t, ticketless.HasReference("Fix the bug\n\nFixes #42."))
+}
+
+func TestTicketlessCommitsConsumeFinalize(t *testing.T) {
+	ticketless := fixtureTicketlessCommits()
+	deps := map[string]interface{}{}
+	deps["commit"] = fixtureCommitMessagesCommit("Fix the bug\n\nDetails. #1", time.February)
+	deps[identity.DependencyAuthor] = 0
+	result, err := ticketless.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps["commit"] = fixtureCommitMessagesCommit("fixed", time.February)
+	deps[identity.DependencyAuthor] = identity.AuthorMissing
+	ticketless.Consume(deps)
+	deps["commit"] = fixtureCommitMessagesCommit("Add the feature", time.July)
+	deps[identity.DependencyAuthor] = 0
+	ticketless.Consume(deps)
+	merge := fixtureCommitMessagesCommit("Merge branch 'master'", time.July)
+	merge.ParentHashes = []plumbing.Hash{plumbing.ZeroHash, plumbing.ZeroHash}
+	deps["commit"] = merge
+	ticketless.Consume(deps)
+	res := ticketless.Finalize().(TicketlessCommitsResult)
+	assert.Equal(t, res.Months, map[string]TicketlessStats{
+		"2018-02": {Commits: 2, Ticketless: 1},
+		"2018-07": {Commits: 1, Ticketless: 1},
+	})
+	assert.Equal(t, res.Months["2018-02"].Ratio(), 0.5)
+	assert.Equal(t, res.People, []TicketlessStats{
+		{Commits: 2, Ticketless: 1}, {}, {Commits: 1, Ticketless: 1}})
+	assert.Equal(t, TicketlessStats{}.Ratio(), float64(0))
+}
+
+func TestTicketlessCommitsSerialize(t *testing.T) {
+	ticketless := fixtureTicketlessCommits()
+	res := TicketlessCommitsResult{
+		Months: map[string]TicketlessStats{
+			"2018-07": {Commits: 1, Ticketless: 1},
+			"2018-02": {Commits: 4, Ticketless: 1},
+		},
+		People:             []TicketlessStats{{Commits: 4, Ticketless: 1}, {}, {Commits: 1, Ticketless: 1}},
+		reversedPeopleDict: []string{"one", "two"},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, ticketless.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  months:
+    "2018-02": {commits: 4, ticketless: 1, ratio: 0.2500}
+    "2018-07": {commits: 1, ticketless: 1, ratio: 1.0000}
+  people:
+    "one": {commits: 4, ticketless: 1, ratio: 0.2500}
+    "two": {commits: 0, ticketless: 0, ratio: 0.0000}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, ticketless.Serialize(res, true, buffer))
+	msg := pb.TicketlessCommitsResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Months, 2)
+	assert.Equal(t, *msg.Months["2018-02"], pb.TicketlessStats{Commits: 4, Ticketless: 1})
+	assert.Len(t, msg.People, 3)
+	assert.Equal(t, msg.People[2].Ticketless, int32(1))
+	assert.Equal(t, msg.PeopleSequence, []string{"one", "two"})
+}
@@ -0,0 +1,259 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// TimeSkewAnalysis measures the gap between the author and the committer dates of each commit.
+// Large gaps are caused by rebases, cherry-picks and long review cycles; they distort the day
+// based bucketing of the other analyses. The statistics are reported per day and per developer.
+// It is a LeafPipelineItem.
+type TimeSkewAnalysis struct {
+	// Threshold is the minimum skew for the commit to be considered skewed.
+	Threshold time.Duration
+	// PeopleNumber is the number of developers for which to collect the skew stats.
+	PeopleNumber int
+
+	// days maps days to the skew statistics of the commits on that day.
+	days map[int]TimeSkewStats
+	// people is the skew statistics of each developer's commits.
+	// The last element corresponds to the authors which were not matched.
+	people []TimeSkewStats
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// TimeSkewStats are the statistics of the absolute differences between the author and
+// the committer dates of a group of commits.
+type TimeSkewStats struct {
+	// Commits is the number of commits.
+	Commits int
+	// Skewed is the number of commits with the skew greater than the threshold.
+	Skewed int
+	// Total is the sum of the skews.
+	Total time.Duration
+	// Max is the largest skew.
+	Max time.Duration
+}
+
+// add updates the statistics with one more commit's skew.
+func (stats *TimeSkewStats) add(skew, threshold time.Duration) {
+	stats.Commits++
+	if skew > threshold {
+		stats.Skewed++
+	}
+	stats.Total += skew
+	if skew > stats.Max {
+		stats.Max = skew
+	}
+}
+
+// Mean returns the average skew.
+func (stats TimeSkewStats) Mean() time.Duration {
+	if stats.Commits == 0 {
+		return 0
+	}
+	return stats.Total / time.Duration(stats.Commits)
+}
+
+// TimeSkewResult is returned by TimeSkewAnalysis.Finalize() and carries the author/committer
+// date skew statistics per day and per developer.
+type TimeSkewResult struct {
+	// Threshold is the minimum skew for the commit to be considered skewed.
+	Threshold time.Duration
+	// Days maps the day index to the skew statistics of the commits on that day.
+	Days map[int]TimeSkewStats
+	// People is the skew statistics of each developer's commits, indexed by the developer's
+	// identity. The last element corresponds to the unmatched authors.
+	People []TimeSkewStats
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigTimeSkewThreshold is the name of the option to set TimeSkewAnalysis.Threshold
+	// in hours.
+	ConfigTimeSkewThreshold = "TimeSkew.Threshold"
+	// DefaultTimeSkewThreshold is the default value of TimeSkewAnalysis.Threshold in hours.
+	DefaultTimeSkewThreshold = 24
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (skew *TimeSkewAnalysis) Name() string {
+	return "TimeSkew"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (skew *TimeSkewAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (skew *TimeSkewAnalysis) Requires() []string {
+	arr := [...]string{identity.DependencyAuthor, items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (skew *TimeSkewAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigTimeSkewThreshold,
+		Description: "Minimum difference between the author and the committer dates in hours to consider the commit skewed.",
+		Flag:        "time-skew-threshold",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultTimeSkewThreshold},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (skew *TimeSkewAnalysis) Flag() string {
+	return "time-skew"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (skew *TimeSkewAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigTimeSkewThreshold].(int); exists {
+		skew.Threshold = time.Duration(val) * time.Hour
+	}
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		skew.PeopleNumber = val
+		skew.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (skew *TimeSkewAnalysis) Initialize(repository *git.Repository) {
+	if skew.Threshold <= 0 {
+		log.Printf("Warning: adjusted the time skew threshold to %d hours\n", DefaultTimeSkewThreshold)
+		skew.Threshold = DefaultTimeSkewThreshold * time.Hour
+	}
+	skew.days = map[int]TimeSkewStats{}
+	skew.people = make([]TimeSkewStats, skew.PeopleNumber+1)
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (skew *TimeSkewAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	day := deps[items.DependencyDay].(int)
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing {
+		author = skew.PeopleNumber
+	}
+	delta := commit.Committer.When.Sub(commit.Author.When)
+	if delta < 0 {
+		delta = -delta
+	}
+	dayStats := skew.days[day]
+	dayStats.add(delta, skew.Threshold)
+	skew.days[day] = dayStats
+	skew.people[author].add(delta, skew.Threshold)
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (skew *TimeSkewAnalysis) Finalize() interface{} {
+	return TimeSkewResult{
+		Threshold:          skew.Threshold,
+		Days:               skew.days,
+		People:             skew.people,
+		reversedPeopleDict: skew.reversedPeopleDict,
+	}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (skew *TimeSkewAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	skewResult := result.(TimeSkewResult)
+	if binary {
+		return skew.serializeBinary(&skewResult, writer)
+	}
+	skew.serializeText(&skewResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (skew *TimeSkewAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(skew, result, &pb.TimeSkewResults{}, writer)
+}
+
+func (skew *TimeSkewAnalysis) serializeText(result *TimeSkewResult, writer io.Writer) {
+	// all the durations are in seconds
+	formatStats := func(stats TimeSkewStats) string {
+		return fmt.Sprintf("{commits: %d, skewed: %d, mean: %d, max: %d}",
+			stats.Commits, stats.Skewed, int64(stats.Mean().Seconds()), int64(stats.Max.Seconds()))
+	}
+	fmt.Fprintln(writer, "  threshold:", int64(result.Threshold.Seconds()))
+	fmt.Fprintln(writer, "  days:")
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	for _, day := range days {
+		fmt.Fprintf(writer, "    %d: %s\n", day, formatStats(result.Days[day]))
+	}
+	fmt.Fprintln(writer, "  people:")
+	for i, name := range result.reversedPeopleDict {
+		if i >= len(result.People) {
+			break
+		}
+		fmt.Fprintf(writer, "    %s: %s\n", yaml.SafeString(name), formatStats(result.People[i]))
+	}
+}
+
+func (skew *TimeSkewAnalysis) serializeBinary(result *TimeSkewResult, writer io.Writer) error {
+	convertStats := func(stats TimeSkewStats) *pb.TimeSkewStats {
+		return &pb.TimeSkewStats{
+			Commits: int32(stats.Commits),
+			Skewed:  int32(stats.Skewed),
+			Total:   int64(stats.Total.Seconds()),
+			Max:     int64(stats.Max.Seconds()),
+		}
+	}
+	message := pb.TimeSkewResults{
+		Threshold:      int64(result.Threshold.Seconds()),
+		Days:           map[int32]*pb.TimeSkewStats{},
+		People:         make([]*pb.TimeSkewStats, len(result.People)),
+		PeopleSequence: result.reversedPeopleDict,
+	}
+	for day, stats := range result.Days {
+		message.Days[int32(day)] = convertStats(stats)
+	}
+	for i, stats := range result.People {
+		message.People[i] = convertStats(stats)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&TimeSkewAnalysis{})
+}
@@ -0,0 +1,132 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureTimeSkew() *TimeSkewAnalysis {
+	skew := TimeSkewAnalysis{PeopleNumber: 2}
+	skew.Initialize(test.Repository)
+	return &skew
+}
+
+func fixtureTimeSkewCommit(skew time.Duration) *object.Commit {
+	when := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	return &object.Commit{
+		Author:    object.Signature{When: when},
+		Committer: object.Signature{When: when.Add(skew)},
+	}
+}
+
+func TestTimeSkewMeta(t *testing.T) {
+	skew := fixtureTimeSkew()
+	assert.Equal(t, skew.Name(), "TimeSkew")
+	assert.Len(t, skew.Provides(), 0)
+	assert.Equal(t, skew.Requires(), []string{identity.DependencyAuthor, items.DependencyDay})
+	opts := skew.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigTimeSkewThreshold)
+	assert.Equal(t, skew.Flag(), "time-skew")
+	assert.Equal(t, skew.Threshold, DefaultTimeSkewThreshold*time.Hour)
+	facts := map[string]interface{}{}
+	facts[ConfigTimeSkewThreshold] = 3
+	facts[identity.FactIdentityDetectorPeopleCount] = 3
+	facts[identity.FactIdentityDetectorReversedPeopleDict] = []string{"one", "two", "three"}
+	skew.Configure(facts)
+	assert.Equal(t, skew.Threshold, 3*time.Hour)
+	assert.Equal(t, skew.PeopleNumber, 3)
+	assert.Equal(t, skew.reversedPeopleDict, []string{"one", "two", "three"})
+}
+
+func TestTimeSkewRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&TimeSkewAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "TimeSkew")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&TimeSkewAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestTimeSkewConsume(t *testing.T) {
+	skew := fixtureTimeSkew()
+	deps := map[string]interface{}{}
+	deps[items.DependencyDay] = 0
+	deps[identity.DependencyAuthor] = 0
+	deps["commit"] = fixtureTimeSkewCommit(time.Minute)
+	result, err := skew.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps[identity.DependencyAuthor] = identity.AuthorMissing
+	deps["commit"] = fixtureTimeSkewCommit(-48 * time.Hour)
+	result, err = skew.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps[items.DependencyDay] = 3
+	deps[identity.DependencyAuthor] = 0
+	deps["commit"] = fixtureTimeSkewCommit(72 * time.Hour)
+	skew.Consume(deps)
+	res := skew.Finalize().(TimeSkewResult)
+	assert.Equal(t, res.Threshold, 24*time.Hour)
+	assert.Len(t, res.Days, 2)
+	assert.Equal(t, res.Days[0], TimeSkewStats{
+		Commits: 2, Skewed: 1, Total: 48*time.Hour + time.Minute, Max: 48 * time.Hour})
+	assert.Equal(t, res.Days[3], TimeSkewStats{
+		Commits: 1, Skewed: 1, Total: 72 * time.Hour, Max: 72 * time.Hour})
+	assert.Len(t, res.People, 3)
+	assert.Equal(t, res.People[0].Commits, 2)
+	assert.Equal(t, res.People[0].Mean(), 36*time.Hour+30*time.Second)
+	assert.Equal(t, res.People[1], TimeSkewStats{})
+	assert.Equal(t, res.People[2].Commits, 1)
+	assert.Equal(t, TimeSkewStats{}.Mean(), time.Duration(0))
+}
+
+func TestTimeSkewSerialize(t *testing.T) {
+	skew := fixtureTimeSkew()
+	res := TimeSkewResult{
+		Threshold: time.Hour,
+		Days: map[int]TimeSkewStats{
+			2: {Commits: 2, Skewed: 1, Total: 2 * time.Hour, Max: 2 * time.Hour},
+			0: {Commits: 1, Total: time.Minute, Max: time.Minute},
+		},
+		People:             []TimeSkewStats{{Commits: 3, Skewed: 1, Total: 3 * time.Hour, Max: 2 * time.Hour}, {}, {}},
+		reversedPeopleDict: []string{"one@srcd", "two@srcd"},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, skew.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  threshold: 3600
+  days:
+    0: {commits: 1, skewed: 0, mean: 60, max: 60}
+    2: {commits: 2, skewed: 1, mean: 3600, max: 7200}
+  people:
+    "one@srcd": {commits: 3, skewed: 1, mean: 3600, max: 7200}
+    "two@srcd": {commits: 0, skewed: 0, mean: 0, max: 0}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, skew.Serialize(res, true, buffer))
+	msg := pb.TimeSkewResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Threshold, int64(3600))
+	assert.Len(t, msg.Days, 2)
+	assert.Equal(t, msg.Days[2].Commits, int32(2))
+	assert.Equal(t, msg.Days[2].Total, int64(7200))
+	assert.Len(t, msg.People, 3)
+	assert.Equal(t, msg.People[0].Max, int64(7200))
+	assert.Equal(t, msg.PeopleSequence, []string{"one@srcd", "two@srcd"})
+}
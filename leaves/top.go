@@ -0,0 +1,41 @@
+package leaves
+
+import (
+	"sort"
+)
+
+// TopOtherName is the name of the bucket which sums the entries dropped by the top-K options
+// of the leaves, e.g. ConfigCouplesTopFiles.
+const TopOtherName = "<other>"
+
+// topRemap selects the k entries with the biggest weights and returns the mapping from the old
+// indices to the new ones. The selected entries keep their relative order and occupy the indices
+// from 0 to k-1, the rest map to k which is TopOtherName. The ties are resolved in favor of
+// the smaller old indices. It returns nil if k is not positive or there is nothing to drop.
+func topRemap(weights []int64, k int) []int {
+	if k <= 0 || len(weights) <= k {
+		return nil
+	}
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return weights[order[i]] > weights[order[j]]
+	})
+	kept := make([]bool, len(weights))
+	for _, i := range order[:k] {
+		kept[i] = true
+	}
+	remap := make([]int, len(weights))
+	next := 0
+	for i := range remap {
+		if kept[i] {
+			remap[i] = next
+			next++
+		} else {
+			remap[i] = k
+		}
+	}
+	return remap
+}
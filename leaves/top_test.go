@@ -0,0 +1,17 @@
+package leaves
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopRemap(t *testing.T) {
+	assert.Equal(t, topRemap([]int64{5, 1, 7, 3}, 2), []int{0, 2, 1, 2})
+	// ties go to the smaller indices
+	assert.Equal(t, topRemap([]int64{1, 2, 2, 2}, 2), []int{2, 0, 1, 2})
+	assert.Nil(t, topRemap([]int64{5, 1, 7, 3}, 0))
+	assert.Nil(t, topRemap([]int64{5, 1, 7, 3}, -1))
+	assert.Nil(t, topRemap([]int64{5, 1, 7, 3}, 4))
+	assert.Nil(t, topRemap(nil, 1))
+}
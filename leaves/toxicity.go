@@ -0,0 +1,327 @@
+package leaves
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// ToxicityAnalysis counts the profanity and the toxic phrases in the new or changed comments
+// and in the commit messages over time and per directory, for the code of conduct monitoring.
+// The comments are extracted with the same XPath as in CommentSentimentAnalysis.
+// It is a LeafPipelineItem.
+type ToxicityAnalysis struct {
+	// LexiconPath is the path to the file with the toxic phrases, one per line; "#" starts
+	// a comment. The built-in English lexicon is used if it is empty.
+	LexiconPath string
+	// ComponentDepth is the number of the leading directories which name the component.
+	ComponentDepth int
+
+	// phraseRE matches any of the phrases in the lexicon.
+	phraseRE *regexp.Regexp
+	// days maps the day index to the phrase counts on that day.
+	days map[int]ToxicityStats
+	// directories maps the components to the phrase counts in the comments.
+	directories map[string]int
+	// phrases maps the phrases to the number of times they were found.
+	phrases map[string]int
+	// xpather extracts the comment nodes.
+	xpather *uast_items.ChangesXPather
+}
+
+// ToxicityStats are the numbers of the toxic phrases found on a day.
+type ToxicityStats struct {
+	// Comments is the number of the phrases in the new or changed comments.
+	Comments int
+	// Messages is the number of the phrases in the commit messages.
+	Messages int
+}
+
+// ToxicityResult is returned by ToxicityAnalysis.Finalize() and carries the numbers of
+// the found toxic phrases.
+type ToxicityResult struct {
+	// Days maps the day index to the phrase counts on that day. Only the days with phrases
+	// are present.
+	Days map[int]ToxicityStats
+	// Directories maps the leading directories to the number of the phrases in the comments.
+	Directories map[string]int
+	// Phrases maps the found phrases in lower case to the number of times they were found.
+	Phrases map[string]int
+}
+
+const (
+	// ConfigToxicityLexiconPath is the name of the option to set ToxicityAnalysis.LexiconPath.
+	ConfigToxicityLexiconPath = "Toxicity.LexiconPath"
+	// ConfigToxicityComponentDepth is the name of the option to set ToxicityAnalysis.ComponentDepth.
+	ConfigToxicityComponentDepth = "Toxicity.ComponentDepth"
+	// DefaultToxicityComponentDepth is the default value of ToxicityAnalysis.ComponentDepth.
+	DefaultToxicityComponentDepth = 1
+)
+
+// defaultToxicityLexicon is the built-in list of the English profanity and insults.
+var defaultToxicityLexicon = []string{
+	"asshole", "bastard", "bullshit", "crap", "crappy", "damn", "dumbass", "fuck", "fucked",
+	"fucking", "idiot", "idiotic", "moron", "moronic", "piece of shit", "retarded", "screw you",
+	"shit", "shitty", "shut up", "stfu", "wtf",
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (toxicity *ToxicityAnalysis) Name() string {
+	return "Toxicity"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (toxicity *ToxicityAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (toxicity *ToxicityAnalysis) Requires() []string {
+	arr := [...]string{uast_items.DependencyUastChanges, items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (toxicity *ToxicityAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (toxicity *ToxicityAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigToxicityLexiconPath,
+		Description: "Path to the file with the toxic phrases, one per line. " +
+			"The built-in English lexicon is used by default.",
+		Flag:    "toxicity-lexicon",
+		Type:    core.StringConfigurationOption,
+		Default: ""}, {
+		Name:        ConfigToxicityComponentDepth,
+		Description: "Number of the leading directories which define a component.",
+		Flag:        "toxicity-depth",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultToxicityComponentDepth},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (toxicity *ToxicityAnalysis) Flag() string {
+	return "toxicity"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (toxicity *ToxicityAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigToxicityLexiconPath].(string); exists {
+		toxicity.LexiconPath = val
+	}
+	if val, exists := facts[ConfigToxicityComponentDepth].(int); exists {
+		toxicity.ComponentDepth = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (toxicity *ToxicityAnalysis) Initialize(repository *git.Repository) {
+	if toxicity.ComponentDepth <= 0 {
+		log.Printf("Warning: adjusted the component depth to %d\n", DefaultToxicityComponentDepth)
+		toxicity.ComponentDepth = DefaultToxicityComponentDepth
+	}
+	lexicon := defaultToxicityLexicon
+	if toxicity.LexiconPath != "" {
+		loaded, err := loadToxicityLexicon(toxicity.LexiconPath)
+		if err != nil {
+			log.Printf("Warning: using the built-in toxicity lexicon: %v\n", err)
+		} else {
+			lexicon = loaded
+		}
+	}
+	toxicity.phraseRE = compileToxicityLexicon(lexicon)
+	toxicity.days = map[int]ToxicityStats{}
+	toxicity.directories = map[string]int{}
+	toxicity.phrases = map[string]int{}
+	toxicity.xpather = &uast_items.ChangesXPather{XPath: "//*[@roleComment]"}
+}
+
+// loadToxicityLexicon reads the phrases, one per line. The empty lines and the lines which
+// start with "#" are ignored.
+func loadToxicityLexicon(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	lexicon := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		lexicon = append(lexicon, text)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lexicon) == 0 {
+		return nil, fmt.Errorf("%s: no phrases", path)
+	}
+	return lexicon, nil
+}
+
+// compileToxicityLexicon builds the case insensitive regular expression which matches any of
+// the whole phrases. The words in the phrases may be separated with any whitespace.
+func compileToxicityLexicon(lexicon []string) *regexp.Regexp {
+	phrases := make([]string, len(lexicon))
+	for i, phrase := range lexicon {
+		words := strings.Fields(phrase)
+		for j, word := range words {
+			words[j] = regexp.QuoteMeta(word)
+		}
+		phrases[i] = strings.Join(words, "\\s+")
+	}
+	// the longer phrases go first so that "piece of shit" wins over "shit"
+	sort.Slice(phrases, func(i, j int) bool {
+		return len(phrases[i]) > len(phrases[j])
+	})
+	return regexp.MustCompile("(?i)\\b(?:" + strings.Join(phrases, "|") + ")\\b")
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (toxicity *ToxicityAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	day := deps[items.DependencyDay].(int)
+	stats := toxicity.days[day]
+	stats.Messages += toxicity.count(commit.Message)
+	for _, change := range changes {
+		if change.After == nil {
+			continue
+		}
+		found := 0
+		for _, node := range toxicity.xpather.Extract([]uast_items.Change{change}) {
+			found += toxicity.count(node.Token)
+		}
+		if found > 0 {
+			stats.Comments += found
+			toxicity.directories[fileComponent(change.Change.To.Name, toxicity.ComponentDepth)] += found
+		}
+	}
+	if stats.Comments > 0 || stats.Messages > 0 {
+		toxicity.days[day] = stats
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (toxicity *ToxicityAnalysis) Finalize() interface{} {
+	return ToxicityResult{
+		Days:        toxicity.days,
+		Directories: toxicity.directories,
+		Phrases:     toxicity.phrases,
+	}
+}
+
+// count returns the number of the toxic phrases in the text and updates the phrase counters.
+func (toxicity *ToxicityAnalysis) count(text string) int {
+	matches := toxicity.phraseRE.FindAllString(text, -1)
+	for _, match := range matches {
+		toxicity.phrases[strings.ToLower(strings.Join(strings.Fields(match), " "))]++
+	}
+	return len(matches)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (toxicity *ToxicityAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	toxicityResult := result.(ToxicityResult)
+	if binary {
+		return toxicity.serializeBinary(&toxicityResult, writer)
+	}
+	toxicity.serializeText(&toxicityResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (toxicity *ToxicityAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(toxicity, result, &pb.ToxicityResults{}, writer)
+}
+
+func (toxicity *ToxicityAnalysis) serializeText(result *ToxicityResult, writer io.Writer) {
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	fmt.Fprintln(writer, "  days:  # comments, commit messages")
+	for _, day := range days {
+		stats := result.Days[day]
+		fmt.Fprintf(writer, "    %d: [%d, %d]\n", day, stats.Comments, stats.Messages)
+	}
+	for _, section := range [...]struct {
+		name   string
+		counts map[string]int
+	}{{"directories", result.Directories}, {"phrases", result.Phrases}} {
+		fmt.Fprintf(writer, "  %s:\n", section.name)
+		keys := make([]string, 0, len(section.counts))
+		for key := range section.counts {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(writer, "    %s: %d\n", yaml.SafeString(key), section.counts[key])
+		}
+	}
+}
+
+func (toxicity *ToxicityAnalysis) serializeBinary(result *ToxicityResult, writer io.Writer) error {
+	message := pb.ToxicityResults{
+		Days:        map[int32]*pb.ToxicityStats{},
+		Directories: map[string]int32{},
+		Phrases:     map[string]int32{},
+	}
+	for day, stats := range result.Days {
+		message.Days[int32(day)] = &pb.ToxicityStats{
+			Comments: int32(stats.Comments),
+			Messages: int32(stats.Messages),
+		}
+	}
+	for dir, count := range result.Directories {
+		message.Directories[dir] = int32(count)
+	}
+	for phrase, count := range result.Phrases {
+		message.Phrases[phrase] = int32(count)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&ToxicityAnalysis{})
+}
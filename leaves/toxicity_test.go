@@ -0,0 +1,164 @@
+package leaves
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureToxicity() *ToxicityAnalysis {
+	toxicity := ToxicityAnalysis{ComponentDepth: DefaultToxicityComponentDepth}
+	toxicity.Initialize(test.Repository)
+	return &toxicity
+}
+
+// fixtureToxicityUAST generates a file with one comment per line.
+func fixtureToxicityUAST(comments ...string) *uast.Node {
+	root := &uast.Node{Roles: []uast.Role{uast.File}}
+	for i, comment := range comments {
+		root.Children = append(root.Children, &uast.Node{
+			Roles: []uast.Role{uast.Comment}, Token: comment,
+			StartPosition: &uast.Position{Line: uint32(i + 1)},
+		})
+	}
+	return root
+}
+
+func TestToxicityMeta(t *testing.T) {
+	toxicity := fixtureToxicity()
+	assert.Equal(t, toxicity.Name(), "Toxicity")
+	assert.Len(t, toxicity.Provides(), 0)
+	assert.Equal(t, toxicity.Requires(), []string{uast_items.DependencyUastChanges, items.DependencyDay})
+	assert.Equal(t, toxicity.Features(), []string{uast_items.FeatureUast})
+	opts := toxicity.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Name, ConfigToxicityLexiconPath)
+	assert.Equal(t, opts[1].Name, ConfigToxicityComponentDepth)
+	assert.Equal(t, toxicity.Flag(), "toxicity")
+	toxicity.Configure(map[string]interface{}{
+		ConfigToxicityLexiconPath:    "/tmp/lexicon.txt",
+		ConfigToxicityComponentDepth: 2,
+	})
+	assert.Equal(t, toxicity.LexiconPath, "/tmp/lexicon.txt")
+	assert.Equal(t, toxicity.ComponentDepth, 2)
+}
+
+func TestToxicityRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&ToxicityAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Toxicity")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&ToxicityAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestToxicityInitializeLexicon(t *testing.T) {
+	toxicity := ToxicityAnalysis{}
+	toxicity.Initialize(test.Repository)
+	assert.Equal(t, toxicity.ComponentDepth, DefaultToxicityComponentDepth)
+	assert.True(t, toxicity.phraseRE.MatchString("WTF is this"))
+	file, err := ioutil.TempFile("", "hercules-")
+	assert.Nil(t, err)
+	defer os.Remove(file.Name())
+	file.WriteString("# the lexicon\n\nbad  apple\n")
+	file.Close()
+	toxicity.LexiconPath = file.Name()
+	toxicity.Initialize(test.Repository)
+	assert.False(t, toxicity.phraseRE.MatchString("WTF is this"))
+	assert.True(t, toxicity.phraseRE.MatchString("a Bad\n apple"))
+	assert.False(t, toxicity.phraseRE.MatchString("bad apples"))
+	toxicity.LexiconPath = file.Name() + ".missing"
+	toxicity.Initialize(test.Repository)
+	assert.True(t, toxicity.phraseRE.MatchString("WTF is this"))
+}
+
+func TestToxicityConsume(t *testing.T) {
+	toxicity := fixtureToxicity()
+	deps := map[string]interface{}{}
+	deps["commit"] = &object.Commit{Message: "Fix the crappy parser, WTF"}
+	deps[items.DependencyDay] = 0
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: nil, After: fixtureToxicityUAST("this is crap", "a piece of shit"),
+			Change: &object.Change{To: object.ChangeEntry{Name: "src/parser/main.go"}}},
+		{Before: nil, After: fixtureToxicityUAST("nothing to see here"),
+			Change: &object.Change{To: object.ChangeEntry{Name: "README.md"}}},
+	}
+	result, err := toxicity.Consume(deps)
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+	deps["commit"] = &object.Commit{Message: "Update the docs"}
+	deps[items.DependencyDay] = 2
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{
+		{Before: fixtureToxicityUAST("this is crap"),
+			After:  fixtureToxicityUAST("this is crap", "damn it"),
+			Change: &object.Change{To: object.ChangeEntry{Name: "setup.py"}}},
+		{Before: fixtureToxicityUAST("damn"), After: nil,
+			Change: &object.Change{From: object.ChangeEntry{Name: "old.py"}}},
+	}
+	toxicity.Consume(deps)
+	deps[items.DependencyDay] = 3
+	deps[uast_items.DependencyUastChanges] = []uast_items.Change{}
+	toxicity.Consume(deps)
+	res := toxicity.Finalize().(ToxicityResult)
+	assert.Equal(t, res.Days, map[int]ToxicityStats{
+		0: {Comments: 2, Messages: 2},
+		2: {Comments: 1},
+	})
+	assert.Equal(t, res.Directories, map[string]int{"src": 2, "/": 1})
+	assert.Equal(t, res.Phrases, map[string]int{
+		"crap": 1, "piece of shit": 1, "crappy": 1, "wtf": 1, "damn": 1})
+}
+
+func TestToxicitySerializeText(t *testing.T) {
+	toxicity := fixtureToxicity()
+	res := ToxicityResult{
+		Days:        map[int]ToxicityStats{5: {Comments: 1}, 1: {Comments: 2, Messages: 3}},
+		Directories: map[string]int{"src": 2, "/": 1},
+		Phrases:     map[string]int{"wtf": 3},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, toxicity.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  days:  # comments, commit messages
+    1: [2, 3]
+    5: [1, 0]
+  directories:
+    "/": 1
+    "src": 2
+  phrases:
+    "wtf": 3
+`)
+}
+
+func TestToxicitySerializeBinary(t *testing.T) {
+	toxicity := fixtureToxicity()
+	res := ToxicityResult{
+		Days:        map[int]ToxicityStats{5: {Comments: 1}, 1: {Comments: 2, Messages: 3}},
+		Directories: map[string]int{"src": 2, "/": 1},
+		Phrases:     map[string]int{"wtf": 3},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, toxicity.Serialize(res, true, buffer))
+	msg := pb.ToxicityResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Days, 2)
+	assert.Equal(t, *msg.Days[1], pb.ToxicityStats{Comments: 2, Messages: 3})
+	assert.Equal(t, msg.Directories, map[string]int32{"src": 2, "/": 1})
+	assert.Equal(t, msg.Phrases, map[string]int32{"wtf": 3})
+}
@@ -0,0 +1,346 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/yaml"
+)
+
+// TypoFixesAnalysis finds the lines which were changed in a single identifier and nothing else,
+// with the old and the new identifiers being close in the edit distance. Such changes are typo
+// fixes most of the time, so the result is a dataset of real-world identifier corrections.
+// It is a LeafPipelineItem.
+type TypoFixesAnalysis struct {
+	// MaxDistance is the maximum Levenshtein distance between the identifiers.
+	MaxDistance int
+
+	// fixes are the typo fixes found so far, in the chronological order.
+	fixes []TypoFix
+}
+
+// TypoFix is a single identifier correction.
+type TypoFix struct {
+	// Wrong is the identifier before the fix.
+	Wrong string
+	// Correct is the identifier after the fix.
+	Correct string
+	// Commit is the hash of the commit which fixed the typo.
+	Commit plumbing.Hash
+	// File is the path of the changed file.
+	File string
+	// Line is the zero-based line number in the new version of the file.
+	Line int
+	// Before is the line before the fix.
+	Before string
+	// After is the line after the fix.
+	After string
+}
+
+// TypoFixesResult is returned by TypoFixesAnalysis.Finalize() and carries the found typo fixes.
+type TypoFixesResult struct {
+	Fixes []TypoFix
+}
+
+const (
+	// ConfigTypoFixesMaxDistance is the name of the option to set TypoFixesAnalysis.MaxDistance.
+	ConfigTypoFixesMaxDistance = "TypoFixes.MaxDistance"
+	// DefaultTypoFixesMaxDistance is the default value of TypoFixesAnalysis.MaxDistance.
+	DefaultTypoFixesMaxDistance = 4
+)
+
+// typoTokenRegexp splits a line into identifiers, numbers, whitespace and single characters.
+var typoTokenRegexp = regexp.MustCompile(`[\pL_][\pL\pN_]*|\pN+|\s+|.`)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (typos *TypoFixesAnalysis) Name() string {
+	return "TypoFixes"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (typos *TypoFixesAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (typos *TypoFixesAnalysis) Requires() []string {
+	arr := [...]string{items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyFileDiff}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (typos *TypoFixesAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigTypoFixesMaxDistance,
+		Description: "Maximum Levenshtein distance between the identifiers to consider the change a typo fix.",
+		Flag:        "typos-max-distance",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultTypoFixesMaxDistance},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (typos *TypoFixesAnalysis) Flag() string {
+	return "typos"
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (typos *TypoFixesAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigTypoFixesMaxDistance].(int); exists {
+		typos.MaxDistance = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (typos *TypoFixesAnalysis) Initialize(repository *git.Repository) {
+	if typos.MaxDistance <= 0 {
+		log.Printf("Warning: adjusted the maximum typo distance to %d\n", DefaultTypoFixesMaxDistance)
+		typos.MaxDistance = DefaultTypoFixesMaxDistance
+	}
+	typos.fixes = []TypoFix{}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, "commit" is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (typos *TypoFixesAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps["commit"].(*object.Commit)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		if action != merkletrie.Modify {
+			continue
+		}
+		before, err := items.BlobToString(cache[change.From.TreeEntry.Hash])
+		if err != nil {
+			return nil, err
+		}
+		after, err := items.BlobToString(cache[change.To.TreeEntry.Hash])
+		if err != nil {
+			return nil, err
+		}
+		if !utf8.ValidString(before) || !utf8.ValidString(after) {
+			// binary
+			continue
+		}
+		for _, fix := range typos.findFixes(
+			splitTypoLines(before), splitTypoLines(after), fileDiffs[change.To.Name].Diffs) {
+			fix.Commit = commit.Hash
+			fix.File = change.To.Name
+			typos.fixes = append(typos.fixes, fix)
+		}
+	}
+	return nil, nil
+}
+
+// splitTypoLines splits the text into lines the same way as diffmatchpatch.DiffLinesToRunes().
+func splitTypoLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// findFixes walks the line-level diff and inspects the blocks of replaced lines which have
+// the same size in the old and the new versions. Each identifier pair is reported once.
+func (typos *TypoFixesAnalysis) findFixes(
+	oldLines, newLines []string, diffs []diffmatchpatch.Diff) []TypoFix {
+	var fixes []TypoFix
+	seen := map[[2]string]bool{}
+	oldPos, newPos, deleted, inserted := 0, 0, 0, 0
+	flush := func() {
+		if deleted == inserted {
+			for i := 0; i < deleted; i++ {
+				oldIndex, newIndex := oldPos-deleted+i, newPos-inserted+i
+				if oldIndex >= len(oldLines) || newIndex >= len(newLines) {
+					break
+				}
+				fix, ok := typos.compareLines(oldLines[oldIndex], newLines[newIndex])
+				if !ok || seen[[2]string{fix.Wrong, fix.Correct}] {
+					continue
+				}
+				seen[[2]string{fix.Wrong, fix.Correct}] = true
+				fix.Line = newIndex
+				fixes = append(fixes, fix)
+			}
+		}
+		deleted, inserted = 0, 0
+	}
+	for _, edit := range diffs {
+		size := utf8.RuneCountInString(edit.Text)
+		switch edit.Type {
+		case diffmatchpatch.DiffDelete:
+			oldPos += size
+			deleted += size
+		case diffmatchpatch.DiffInsert:
+			newPos += size
+			inserted += size
+		default:
+			flush()
+			oldPos += size
+			newPos += size
+		}
+	}
+	flush()
+	return fixes
+}
+
+// compareLines checks whether the lines differ in exactly one identifier and the identifiers
+// are close enough. The edit distance must be less than half of the longer identifier's length,
+// otherwise it is rather a rename than a typo fix.
+func (typos *TypoFixesAnalysis) compareLines(before, after string) (TypoFix, bool) {
+	oldTokens := typoTokenRegexp.FindAllString(before, -1)
+	newTokens := typoTokenRegexp.FindAllString(after, -1)
+	if len(oldTokens) != len(newTokens) {
+		return TypoFix{}, false
+	}
+	diff := -1
+	for i, token := range oldTokens {
+		if token != newTokens[i] {
+			if diff >= 0 {
+				return TypoFix{}, false
+			}
+			diff = i
+		}
+	}
+	if diff < 0 || !isTypoIdentifier(oldTokens[diff]) || !isTypoIdentifier(newTokens[diff]) {
+		return TypoFix{}, false
+	}
+	wrong, correct := oldTokens[diff], newTokens[diff]
+	distance := levenshtein(wrong, correct)
+	longest := utf8.RuneCountInString(wrong)
+	if length := utf8.RuneCountInString(correct); length > longest {
+		longest = length
+	}
+	if distance > typos.MaxDistance || distance*2 >= longest {
+		return TypoFix{}, false
+	}
+	return TypoFix{
+		Wrong:   wrong,
+		Correct: correct,
+		Before:  strings.TrimRight(before, "\r\n"),
+		After:   strings.TrimRight(after, "\r\n"),
+	}, true
+}
+
+// isTypoIdentifier returns true if the token produced by typoTokenRegexp is an identifier.
+func isTypoIdentifier(token string) bool {
+	char, _ := utf8.DecodeRuneInString(token)
+	return char == '_' || unicode.IsLetter(char)
+}
+
+// levenshtein calculates the edit distance between two strings in runes.
+func levenshtein(a, b string) int {
+	runesA, runesB := []rune(a), []rune(b)
+	row := make([]int, len(runesB)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(runesA); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(runesB); j++ {
+			current := row[j]
+			cost := 1
+			if runesA[i-1] == runesB[j-1] {
+				cost = 0
+			}
+			best := row[j] + 1
+			if row[j-1]+1 < best {
+				best = row[j-1] + 1
+			}
+			if prev+cost < best {
+				best = prev + cost
+			}
+			row[j] = best
+			prev = current
+		}
+	}
+	return row[len(runesB)]
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (typos *TypoFixesAnalysis) Finalize() interface{} {
+	return TypoFixesResult{Fixes: typos.fixes}
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (typos *TypoFixesAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	typosResult := result.(TypoFixesResult)
+	if binary {
+		return typos.serializeBinary(&typosResult, writer)
+	}
+	typos.serializeText(&typosResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (typos *TypoFixesAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(typos, result, &pb.TypoFixesResults{}, writer)
+}
+
+func (typos *TypoFixesAnalysis) serializeText(result *TypoFixesResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  fixes:")
+	for _, fix := range result.Fixes {
+		fmt.Fprintf(writer, "  - {wrong: %s, correct: %s, commit: %s, file: %s, line: %d, "+
+			"before: %s, after: %s}\n", yaml.SafeString(fix.Wrong), yaml.SafeString(fix.Correct),
+			fix.Commit.String(), yaml.SafeString(fix.File), fix.Line, yaml.SafeString(fix.Before),
+			yaml.SafeString(fix.After))
+	}
+}
+
+func (typos *TypoFixesAnalysis) serializeBinary(result *TypoFixesResult, writer io.Writer) error {
+	message := pb.TypoFixesResults{
+		Fixes: make([]*pb.TypoFix, len(result.Fixes)),
+	}
+	for i, fix := range result.Fixes {
+		message.Fixes[i] = &pb.TypoFix{
+			Wrong:   fix.Wrong,
+			Correct: fix.Correct,
+			Commit:  fix.Commit.String(),
+			File:    fix.File,
+			Line:    int32(fix.Line),
+			Before:  fix.Before,
+			After:   fix.After,
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&TypoFixesAnalysis{})
+}
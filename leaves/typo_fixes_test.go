@@ -0,0 +1,132 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureTypoFixes() *TypoFixesAnalysis {
+	typos := TypoFixesAnalysis{}
+	typos.Initialize(test.Repository)
+	return &typos
+}
+
+func TestTypoFixesMeta(t *testing.T) {
+	typos := fixtureTypoFixes()
+	assert.Equal(t, typos.Name(), "TypoFixes")
+	assert.Equal(t, len(typos.Provides()), 0)
+	required := [...]string{items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyFileDiff}
+	for _, name := range required {
+		assert.Contains(t, typos.Requires(), name)
+	}
+	opts := typos.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigTypoFixesMaxDistance)
+	assert.Equal(t, typos.Flag(), "typos")
+	assert.Equal(t, typos.MaxDistance, DefaultTypoFixesMaxDistance)
+	typos.Configure(map[string]interface{}{ConfigTypoFixesMaxDistance: 2})
+	assert.Equal(t, typos.MaxDistance, 2)
+}
+
+func TestTypoFixesRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&TypoFixesAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "TypoFixes")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&TypoFixesAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, levenshtein("", ""), 0)
+	assert.Equal(t, levenshtein("abc", ""), 3)
+	assert.Equal(t, levenshtein("kitten", "sitting"), 3)
+	assert.Equal(t, levenshtein("recieve", "receive"), 2)
+	assert.Equal(t, levenshtein("привет", "превед"), 2)
+}
+
+func TestTypoFixesCompareLines(t *testing.T) {
+	typos := fixtureTypoFixes()
+	fix, ok := typos.compareLines("\tx := recieve(msg)\n", "\tx := receive(msg)\n")
+	assert.True(t, ok)
+	assert.Equal(t, fix, TypoFix{
+		Wrong: "recieve", Correct: "receive",
+		Before: "\tx := recieve(msg)", After: "\tx := receive(msg)"})
+	// two identifiers changed
+	_, ok = typos.compareLines("x := recieve(mgs)", "x := receive(msg)")
+	assert.False(t, ok)
+	// not an identifier
+	_, ok = typos.compareLines("x := 100", "x := 101")
+	assert.False(t, ok)
+	// too far
+	_, ok = typos.compareLines("x := foo(msg)", "x := bar(msg)")
+	assert.False(t, ok)
+	// whitespace only
+	_, ok = typos.compareLines("x := foo(msg)", "x := foo( msg)")
+	assert.False(t, ok)
+	_, ok = typos.compareLines("x := foo(msg)", "x := foo(msg)")
+	assert.False(t, ok)
+}
+
+func TestTypoFixesFindFixes(t *testing.T) {
+	typos := fixtureTypoFixes()
+	oldLines := splitTypoLines("a\nlenght := 1\nb\nc\nuse(lenght)\nd\ne")
+	newLines := splitTypoLines("a\nlength := 1\nb\nc\nuse(length)\nd\nf\ng")
+	assert.Len(t, oldLines, 7)
+	diffs := []diffmatchpatch.Diff{
+		{Type: diffmatchpatch.DiffEqual, Text: "a"},
+		{Type: diffmatchpatch.DiffDelete, Text: "b"},
+		{Type: diffmatchpatch.DiffInsert, Text: "c"},
+		{Type: diffmatchpatch.DiffEqual, Text: "de"},
+		{Type: diffmatchpatch.DiffDelete, Text: "f"},
+		{Type: diffmatchpatch.DiffInsert, Text: "g"},
+		{Type: diffmatchpatch.DiffEqual, Text: "h"},
+		{Type: diffmatchpatch.DiffDelete, Text: "i"},
+		{Type: diffmatchpatch.DiffInsert, Text: "jk"},
+	}
+	fixes := typos.findFixes(oldLines, newLines, diffs)
+	assert.Equal(t, fixes, []TypoFix{{
+		Wrong: "lenght", Correct: "length", Line: 1,
+		Before: "lenght := 1", After: "length := 1"}})
+}
+
+func TestTypoFixesSerialize(t *testing.T) {
+	typos := fixtureTypoFixes()
+	res := TypoFixesResult{Fixes: []TypoFix{{
+		Wrong: "lenght", Correct: "length",
+		Commit: plumbing.NewHash("2b1ed978194a94edeabbca6de7ff3b5771d4d665"),
+		File:   "main.go", Line: 1, Before: "lenght := 1", After: "length := 1"}}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, typos.Serialize(res, false, buffer))
+	assert.Equal(t, buffer.String(), `  fixes:
+  - {wrong: "lenght", correct: "length", commit: 2b1ed978194a94edeabbca6de7ff3b5771d4d665, file: "main.go", line: 1, before: "lenght := 1", after: "length := 1"}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, typos.Serialize(res, true, buffer))
+	msg := pb.TypoFixesResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Fixes, 1)
+	assert.Equal(t, *msg.Fixes[0], pb.TypoFix{
+		Wrong: "lenght", Correct: "length", Commit: "2b1ed978194a94edeabbca6de7ff3b5771d4d665",
+		File: "main.go", Line: 1, Before: "lenght := 1", After: "length := 1"})
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, typos.SerializeJSON(res, buffer))
+	assert.Equal(t, buffer.String(), `{"fixes":[{"wrong":"lenght","correct":"length",`+
+		`"commit":"2b1ed978194a94edeabbca6de7ff3b5771d4d665","file":"main.go","line":1,`+
+		`"before":"lenght := 1","after":"length := 1"}]}`+"\n")
+}
@@ -0,0 +1,88 @@
+package yaml
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	goyaml "gopkg.in/yaml.v3"
+)
+
+// ChecksumsKey is the name of the optional header block which maps the analysis names
+// to the checksums of their sections.
+const ChecksumsKey = "checksums"
+
+// Checksum returns the hex SHA-256 hash of the serialized analysis section, excluding
+// the line with the analysis name.
+func Checksum(section []byte) string {
+	hash := sha256.Sum256(section)
+	return hex.EncodeToString(hash[:])
+}
+
+// SplitSections splits the text output of Hercules into the top-level blocks. The keys are
+// the block names and the values are the lines which follow the block name.
+func SplitSections(data []byte) map[string][]byte {
+	sections := map[string][]byte{}
+	var name string
+	start := -1
+	for pos := 0; pos < len(data); {
+		end := bytes.IndexByte(data[pos:], '\n')
+		if end < 0 {
+			end = len(data)
+		} else {
+			end += pos + 1
+		}
+		line := data[pos:end]
+		if len(line) > 0 && line[0] != ' ' && line[0] != '\n' && line[0] != '#' {
+			if start >= 0 {
+				sections[name] = data[start:pos]
+			}
+			name = string(line)
+			if colon := bytes.IndexByte(line, ':'); colon >= 0 {
+				name = string(line[:colon])
+			}
+			start = end
+		}
+		pos = end
+	}
+	if start >= 0 {
+		sections[name] = data[start:]
+	}
+	return sections
+}
+
+// VerifyChecksums recomputes the checksums of the analysis sections and compares them with
+// the values in the header. It returns an error if there are no checksums in the header.
+func VerifyChecksums(data []byte) []error {
+	var doc struct {
+		Header struct {
+			Checksums map[string]string `yaml:"checksums"`
+		} `yaml:"hercules"`
+	}
+	if err := goyaml.Unmarshal(data, &doc); err != nil {
+		return []error{err}
+	}
+	if len(doc.Header.Checksums) == 0 {
+		return []error{fmt.Errorf("%s.%s is missing", HeaderKey, ChecksumsKey)}
+	}
+	sections := SplitSections(data)
+	var errs []error
+	for name, section := range sections {
+		if name == HeaderKey {
+			continue
+		}
+		expected, exists := doc.Header.Checksums[name]
+		if !exists {
+			errs = append(errs, fmt.Errorf("%s: the checksum is missing", name))
+		} else if Checksum(section) != expected {
+			errs = append(errs, fmt.Errorf("%s: checksum mismatch", name))
+		}
+	}
+	for name := range doc.Header.Checksums {
+		if _, exists := sections[name]; !exists {
+			errs = append(errs, fmt.Errorf("%s: the section is missing", name))
+		}
+	}
+	return errs
+}
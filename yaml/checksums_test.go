@@ -0,0 +1,45 @@
+package yaml
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fixtureChecksummedText() string {
+	burndown := "  granularity: 30\n  sampling: 30\n"
+	couples := "  files_coocc:\n    index:\n      - \"a\"\n"
+	return "hercules:\n  version: 4\n  checksums:\n" +
+		"    Burndown: " + Checksum([]byte(burndown)) + "\n" +
+		"    Couples: " + Checksum([]byte(couples)) + "\n" +
+		"Burndown:\n" + burndown + "Couples:\n" + couples
+}
+
+func TestSplitSections(t *testing.T) {
+	sections := SplitSections([]byte("hercules:\n  version: 4\nA:\n  x: 1\n\n  y: 2\nB:\nC: {}\n"))
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, names, []string{"A", "B", "C", "hercules"})
+	assert.Equal(t, string(sections["A"]), "  x: 1\n\n  y: 2\n")
+	assert.Equal(t, string(sections["B"]), "")
+	assert.Equal(t, string(sections["hercules"]), "  version: 4\n")
+}
+
+func TestVerifyChecksums(t *testing.T) {
+	text := fixtureChecksummedText()
+	assert.Len(t, VerifyChecksums([]byte(text)), 0)
+	errs := VerifyChecksums([]byte(strings.Replace(text, "sampling: 30", "sampling: 31", 1)))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, errs[0].Error(), "Burndown: checksum mismatch")
+	errs = VerifyChecksums([]byte(text + "Extra:\n  x: 1\n"))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, errs[0].Error(), "Extra: the checksum is missing")
+	errs = VerifyChecksums([]byte("hercules:\n  version: 4\n"))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, errs[0].Error(), "hercules.checksums is missing")
+}
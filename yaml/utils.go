@@ -7,11 +7,15 @@ import (
 	"strings"
 )
 
+// SchemaVersion is the version of the YAML output layout which is written in the "hercules"
+// header. It must be incremented after every backward incompatible change of the format.
+const SchemaVersion = 4
+
 // SafeString returns a string which is sufficiently quoted and escaped for YAML.
+// The control and non-printable characters are escaped, so the result is always a valid
+// double-quoted YAML scalar.
 func SafeString(str string) string {
-	str = strings.Replace(str, "\\", "\\\\", -1)
-	str = strings.Replace(str, "\"", "\\\"", -1)
-	return "\"" + str + "\""
+	return strconv.Quote(str)
 }
 
 // PrintMatrix outputs a rectangular integer matrix in YAML text format.
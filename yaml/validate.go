@@ -0,0 +1,74 @@
+package yaml
+
+import (
+	"fmt"
+	"io"
+
+	goyaml "gopkg.in/yaml.v3"
+)
+
+// HeaderKey is the name of the mandatory first YAML block with the metadata.
+const HeaderKey = "hercules"
+
+// headerFields are the keys which must be present in the header block.
+var headerFields = [...]string{
+	"version", "hash", "repository", "begin_unix_time", "end_unix_time", "commits", "run_time"}
+
+// Validate checks whether the text output of Hercules conforms to the schema. The document must
+// be a YAML mapping which starts with the header of the current SchemaVersion and continues with
+// the analysis results. Each analysis must appear once and be accepted by `isKnown`.
+// All the found violations are returned.
+func Validate(reader io.Reader, isKnown func(name string) bool) []error {
+	var doc goyaml.Node
+	if err := goyaml.NewDecoder(reader).Decode(&doc); err != nil {
+		return []error{err}
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != goyaml.MappingNode {
+		return []error{fmt.Errorf("the document is not a mapping")}
+	}
+	root := doc.Content[0]
+	if len(root.Content) == 0 || root.Content[0].Value != HeaderKey {
+		return []error{fmt.Errorf("line %d: the document must start with %q", root.Line, HeaderKey)}
+	}
+	errs := validateHeader(root.Content[1])
+	seen := map[string]bool{HeaderKey: true}
+	for i := 2; i < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if seen[key.Value] {
+			errs = append(errs, fmt.Errorf("line %d: duplicate key %q", key.Line, key.Value))
+			continue
+		}
+		seen[key.Value] = true
+		if !isKnown(key.Value) {
+			errs = append(errs, fmt.Errorf("line %d: unknown analysis %q", key.Line, key.Value))
+		}
+		if value.Kind != goyaml.MappingNode && value.Tag != "!!null" {
+			errs = append(errs, fmt.Errorf("line %d: %q must be a mapping", value.Line, key.Value))
+		}
+	}
+	return errs
+}
+
+func validateHeader(header *goyaml.Node) []error {
+	if header.Kind != goyaml.MappingNode {
+		return []error{fmt.Errorf("line %d: %q must be a mapping", header.Line, HeaderKey)}
+	}
+	fields := map[string]*goyaml.Node{}
+	for i := 0; i < len(header.Content); i += 2 {
+		fields[header.Content[i].Value] = header.Content[i+1]
+	}
+	var errs []error
+	for _, name := range headerFields {
+		if fields[name] == nil {
+			errs = append(errs, fmt.Errorf("line %d: %s.%s is missing", header.Line, HeaderKey, name))
+		}
+	}
+	if version := fields["version"]; version != nil {
+		var value int
+		if err := version.Decode(&value); err != nil || value != SchemaVersion {
+			errs = append(errs, fmt.Errorf("line %d: unsupported schema version %q, expected %d",
+				version.Line, version.Value, SchemaVersion))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,66 @@
+package yaml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func isKnownAnalysis(name string) bool {
+	return name == "Burndown" || name == "Couples"
+}
+
+const validHeader = `hercules:
+  version: 4
+  hash: "abcdef"
+  repository: "https://github.com/src-d/hercules"
+  begin_unix_time: 1481719198
+  end_unix_time: 1524252512
+  commits: 1000
+  run_time: 1234
+`
+
+func TestValidate(t *testing.T) {
+	errs := Validate(strings.NewReader(validHeader+`Burndown:
+  granularity: 30
+Couples:
+`), isKnownAnalysis)
+	assert.Len(t, errs, 0)
+}
+
+func TestValidateSafeString(t *testing.T) {
+	comments := []string{`"quoted"`, "back\\slash", "new\nline", "tab\tbell\a", "\x00\x1b ", "\xff"}
+	text := validHeader + "Burndown:\n"
+	for i, comment := range comments {
+		text += "  " + SafeString(string(rune('a'+i))) + ": " + SafeString(comment) + "\n"
+	}
+	assert.Len(t, Validate(strings.NewReader(text), isKnownAnalysis), 0)
+}
+
+func TestValidateErrors(t *testing.T) {
+	errs := Validate(strings.NewReader("- 1\n- 2\n"), isKnownAnalysis)
+	assert.Len(t, errs, 1)
+	errs = Validate(strings.NewReader("Burndown: {}\n"), isKnownAnalysis)
+	assert.Len(t, errs, 1)
+	errs = Validate(strings.NewReader(`Burndown: "unterminated`), isKnownAnalysis)
+	assert.Len(t, errs, 1)
+	errs = Validate(strings.NewReader(`hercules:
+  version: 3
+  hash: "abcdef"
+Burndown: {}
+Burndown: {}
+Unknown: {}
+Couples: 10
+`), isKnownAnalysis)
+	assert.Len(t, errs, 9)
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	assert.Contains(t, messages, "line 2: hercules.repository is missing")
+	assert.Contains(t, messages, `line 2: unsupported schema version "3", expected 4`)
+	assert.Contains(t, messages, `line 5: duplicate key "Burndown"`)
+	assert.Contains(t, messages, `line 6: unknown analysis "Unknown"`)
+	assert.Contains(t, messages, `line 7: "Couples" must be a mapping`)
+}